@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+)
+
+// setAccountingCommand returns the command booking an asset's capitalized cost to a cost
+// center and GL account
+func (a *App) setAccountingCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "set-accounting",
+		Usage: "Set the cost center and GL account an asset's capitalized cost is booked to in the ERP export",
+		Action: func(ctx *cli.Context) error {
+			name := ctx.String("name")
+			if err := a.assetService.SetAccounting(name, ctx.String("cost-center"), ctx.String("gl-account")); err != nil {
+				return err
+			}
+			if ctx.String("cost-center") == "" && ctx.String("gl-account") == "" {
+				fmt.Printf("Removed the accounts of asset %s\n", name)
+				return nil
+			}
+			fmt.Printf("Booked asset %s to cost center %q and GL account %q\n", name, ctx.String("cost-center"), ctx.String("gl-account"))
+			return nil
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "Asset name",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "cost-center",
+				Usage: "Cost center, e.g. CC-100; empty falls back to the configured erp.costCenter",
+			},
+			&cli.StringFlag{
+				Name:  "gl-account",
+				Usage: "General ledger account, e.g. 1710; empty falls back to the configured erp.glAccount",
+			},
+		},
+	}
+}
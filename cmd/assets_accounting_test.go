@@ -0,0 +1,56 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+func TestAssetsSetAccounting(t *testing.T) {
+	assets := new(MockAssetService)
+	assets.On("SetAccounting", "booking", "CC-100", "1710").Return(nil)
+	assets.On("SetAccounting", "booking", "", "").Return(nil)
+	app := NewApp(assets, new(MockTaskService), new(MockSprintService))
+
+	output, err := captureOutput(func() error {
+		os.Args = []string{"assetcap", "assets", "set-accounting", "--name", "booking", "--cost-center", "CC-100", "--gl-account", "1710"}
+		return app.Run()
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Booked asset booking to cost center \"CC-100\" and GL account \"1710\"\n", output)
+
+	output, err = captureOutput(func() error {
+		os.Args = []string{"assetcap", "assets", "set-accounting", "--name", "booking"}
+		return app.Run()
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Removed the accounts of asset booking\n", output)
+}
+
+func TestAssetAccounts(t *testing.T) {
+	assets := []*assetsdomain.Asset{
+		{Name: "booking", CostCenter: "CC-100", GLAccount: "1710"},
+		{Name: "search", CostCenter: "CC-200"},
+		{Name: "legacy"},
+	}
+
+	app := NewApp(nil, nil, nil)
+	assert.Equal(t, sprintdomain.AssetAccounts{
+		"booking": {CostCenter: "CC-100", GLAccount: "1710"},
+		"search":  {CostCenter: "CC-200"},
+	}, app.assetAccounts(assets))
+
+	app.defaultAccount = sprintdomain.AssetAccount{CostCenter: "CC-900", GLAccount: "1700"}
+	assert.Equal(t, sprintdomain.AssetAccounts{
+		"booking": {CostCenter: "CC-100", GLAccount: "1710"},
+		"search":  {CostCenter: "CC-200", GLAccount: "1700"},
+		"legacy":  {CostCenter: "CC-900", GLAccount: "1700"},
+	}, app.assetAccounts(assets))
+
+	assert.Nil(t, NewApp(nil, nil, nil).assetAccounts([]*assetsdomain.Asset{{Name: "legacy"}}))
+}
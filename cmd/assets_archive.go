@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+)
+
+// archiveAssetCommand returns the command archiving an asset, the default way to take an
+// asset out of the catalogue's active work without losing its history
+func (a *App) archiveAssetCommand() *cli.Command {
+	return &cli.Command{
+		Name:   "archive",
+		Usage:  "Record the Archived status of an asset instead of deleting it; refuses assets with associated tasks unless --force",
+		Action: a.archiveAsset,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "Asset name",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "date",
+				Usage: "Date the asset is archived (YYYY-MM-DD); defaults to today",
+			},
+			&cli.BoolFlag{
+				Name:  "force",
+				Usage: "Archive the asset even though tasks are still associated with it",
+			},
+		},
+	}
+}
+
+// archiveAsset records the Archived status of the asset from the given date or today
+func (a *App) archiveAsset(ctx *cli.Context) error {
+	date := time.Now().UTC().Truncate(24 * time.Hour)
+	if value := ctx.String("date"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return fmt.Errorf("invalid date %q: expected YYYY-MM-DD", value)
+		}
+		date = parsed
+	}
+
+	asset, err := a.assetService.ArchiveAsset(ctx.String("name"), date, ctx.Bool("force"))
+	if err != nil {
+		return linkedTasksHint(err, "archive")
+	}
+	fmt.Printf("Archived asset %s on %s\n", asset.Name, date.Format("2006-01-02"))
+	return nil
+}
+
+// linkedTasksHint explains how to go on when an asset still has associated tasks
+func linkedTasksHint(err error, action string) error {
+	if errors.Is(err, assetsdomain.ErrAssetHasTasks) {
+		return fmt.Errorf("%w; link its tasks to another asset first, or pass --force to %s it anyway", err, action)
+	}
+	return err
+}
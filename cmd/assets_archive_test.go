@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+)
+
+func TestAssetsArchive(t *testing.T) {
+	archived := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	assets := new(MockAssetService)
+	assets.On("ArchiveAsset", "booking", archived, false).Return(nil, fmt.Errorf("%w: booking is linked to 3 tasks", assetsdomain.ErrAssetHasTasks)).Once()
+	assets.On("ArchiveAsset", "booking", archived, true).Return(&assetsdomain.Asset{Name: "booking", Status: assetsdomain.StatusArchived}, nil)
+	app := NewApp(assets, new(MockTaskService), new(MockSprintService))
+
+	_, err := captureOutput(func() error {
+		os.Args = []string{"assetcap", "assets", "archive", "--name", "booking", "--date", "2024-06-30"}
+		return app.Run()
+	})
+	assert.EqualError(t, err, "asset still has associated tasks: booking is linked to 3 tasks; link its tasks to another asset first, or pass --force to archive it anyway")
+
+	output, err := captureOutput(func() error {
+		os.Args = []string{"assetcap", "assets", "archive", "--name", "booking", "--date", "2024-06-30", "--force"}
+		return app.Run()
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Archived asset booking on 2024-06-30\n", output)
+
+	_, err = captureOutput(func() error {
+		os.Args = []string{"assetcap", "assets", "archive", "--name", "booking", "--date", "30/06/2024"}
+		return app.Run()
+	})
+	assert.EqualError(t, err, `invalid date "30/06/2024": expected YYYY-MM-DD`)
+}
+
+func TestAssetsDelete_AssociatedTasks(t *testing.T) {
+	assets := new(MockAssetService)
+	assets.On("DeleteAsset", "booking", false).Return(fmt.Errorf("%w: booking is linked to 3 tasks", assetsdomain.ErrAssetHasTasks))
+	assets.On("DeleteAsset", "booking", true).Return(nil)
+	app := NewApp(assets, new(MockTaskService), new(MockSprintService))
+
+	_, err := captureOutput(func() error {
+		os.Args = []string{"assetcap", "assets", "delete", "--name", "booking"}
+		return app.Run()
+	})
+	assert.EqualError(t, err, "asset still has associated tasks: booking is linked to 3 tasks; link its tasks to another asset first, or pass --force to delete it anyway")
+
+	_, err = captureOutput(func() error {
+		os.Args = []string{"assetcap", "assets", "delete", "--name", "booking", "--force"}
+		return app.Run()
+	})
+	require.NoError(t, err)
+	assets.AssertCalled(t, "DeleteAsset", "booking", true)
+	assets.AssertNotCalled(t, "PurgeAsset", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+)
+
+// printDuplicateAssets warns about asset identifiers claimed by several Confluence pages, which
+// sync leaves untouched until a primary page is chosen
+func printDuplicateAssets(duplicates []*assetsdomain.DuplicateAsset) {
+	if len(duplicates) == 0 {
+		return
+	}
+	fmt.Printf("\nSkipped %d asset identifier(s) claimed by more than one page:\n", len(duplicates))
+	for _, duplicate := range duplicates {
+		fmt.Printf("  %s\n", duplicate.ID)
+		for _, page := range duplicate.Pages {
+			fmt.Printf("    - %s: %s\n", page.Title, page.DocLink)
+		}
+	}
+	fmt.Println("Resolve with: assetcap assets resolve-duplicate --primary <page URL>")
+}
+
+// printDuplicateResolution prints the primary page chosen for an asset and the pages marked as
+// superseded
+func printDuplicateResolution(resolution *assetsdomain.DuplicateResolution) {
+	fmt.Printf("Primary page for %s: %s\n", resolution.ID, resolution.Primary)
+	if len(resolution.Superseded) == 0 {
+		fmt.Println("No other page carries this asset label")
+	} else {
+		fmt.Println("Marked as superseded:")
+		for _, link := range resolution.Superseded {
+			fmt.Printf("  - %s\n", link)
+		}
+	}
+	if resolution.Asset == nil {
+		fmt.Println("The asset is not in the catalog yet; the next sync will add it")
+		return
+	}
+	fmt.Printf("Linked asset %s to the primary page\n", resolution.Asset.Name)
+}
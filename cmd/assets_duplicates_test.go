@@ -0,0 +1,62 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+)
+
+func TestPrintDuplicateAssets(t *testing.T) {
+	output, err := captureOutput(func() error {
+		printDuplicateAssets([]*assetsdomain.DuplicateAsset{
+			{ID: "cap-asset-booking", Pages: []assetsdomain.DuplicatePage{
+				{Title: "Booking", DocLink: "https://example.atlassian.net/wiki/spaces/S/pages/1"},
+				{Title: "Booking (old)", DocLink: "https://example.atlassian.net/wiki/spaces/S/pages/2"},
+			}},
+		})
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Contains(t, output, "Skipped 1 asset identifier(s) claimed by more than one page:\n")
+	assert.Contains(t, output, "    - Booking: https://example.atlassian.net/wiki/spaces/S/pages/1\n")
+	assert.Contains(t, output, "    - Booking (old): https://example.atlassian.net/wiki/spaces/S/pages/2\n")
+	assert.Contains(t, output, "assets resolve-duplicate --primary")
+}
+
+func TestPrintDuplicateAssets_None(t *testing.T) {
+	output, err := captureOutput(func() error {
+		printDuplicateAssets(nil)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Empty(t, output)
+}
+
+func TestPrintDuplicateResolution(t *testing.T) {
+	output, err := captureOutput(func() error {
+		printDuplicateResolution(&assetsdomain.DuplicateResolution{
+			ID:         "cap-asset-booking",
+			Primary:    "https://example.atlassian.net/wiki/spaces/S/pages/1",
+			Superseded: []string{"https://example.atlassian.net/wiki/spaces/S/pages/2"},
+			Asset:      &assetsdomain.Asset{Name: "booking"},
+		})
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Contains(t, output, "Primary page for cap-asset-booking: https://example.atlassian.net/wiki/spaces/S/pages/1\n")
+	assert.Contains(t, output, "  - https://example.atlassian.net/wiki/spaces/S/pages/2\n")
+	assert.Contains(t, output, "Linked asset booking to the primary page\n")
+}
+
+func TestPrintDuplicateResolution_AssetNotInCatalog(t *testing.T) {
+	output, err := captureOutput(func() error {
+		printDuplicateResolution(&assetsdomain.DuplicateResolution{ID: "cap-asset-booking", Primary: "https://example.atlassian.net/wiki/spaces/S/pages/1"})
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Contains(t, output, "No other page carries this asset label\n")
+	assert.Contains(t, output, "the next sync will add it\n")
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+)
+
+// ANSI colours of the enrichment diff
+const (
+	ansiRed   = "\033[31m"
+	ansiGreen = "\033[32m"
+	ansiReset = "\033[0m"
+)
+
+// enrichAsset previews the value generated for a field as a diff against the current one and
+// saves it once approved, or straight away with --yes
+func (a *App) enrichAsset(ctx *cli.Context) error {
+	name := ctx.String("name")
+	field := ctx.String("field")
+	proposal, err := a.assetService.ProposeEnrichment(name, field, ctx.Bool("with-attachments"), ctx.Bool("force"))
+	if err != nil {
+		return err
+	}
+	if proposal.Cached {
+		fmt.Printf("Reusing the %s value generated by %s for this version of the page; pass --force to generate a new one\n", field, proposal.Model)
+	}
+	if !proposal.Changed() {
+		fmt.Printf("No changes proposed for %s field of asset: %s\n", field, name)
+		return nil
+	}
+
+	printEnrichmentDiff(os.Stdout, proposal, colorOutput(os.Stdout))
+	if !ctx.Bool("yes") {
+		approved, err := a.confirm(fmt.Sprintf("Apply this change to the %s field of %s?", field, name))
+		if err != nil {
+			return err
+		}
+		if !approved {
+			fmt.Println("Enrichment discarded")
+			return nil
+		}
+	}
+
+	if err := a.assetService.ApplyEnrichment(proposal); err != nil {
+		return err
+	}
+	fmt.Printf("Enriched %s field for asset: %s (generated by %s)\n", field, name, proposal.Model)
+	return nil
+}
+
+// confirm asks a yes/no question on the input; anything but y or yes, including no answer, is no
+func (a *App) confirm(question string) (bool, error) {
+	input := a.stdin
+	if input == nil {
+		input = os.Stdin
+	}
+	fmt.Printf("%s [y/N]: ", question)
+	answer, err := bufio.NewReader(input).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("failed to read answer: %w", err)
+	}
+	fmt.Println()
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
+
+// colorOutput reports whether f is a terminal that accepts colours, honouring NO_COLOR
+func colorOutput(f *os.File) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// printEnrichmentDiff writes the current and proposed values of the field as a line diff,
+// removed lines in red and added lines in green when color is set
+func printEnrichmentDiff(w io.Writer, proposal *assetsdomain.EnrichmentProposal, color bool) {
+	paint := func(code, line string) string {
+		if !color {
+			return line
+		}
+		return code + line + ansiReset
+	}
+	fmt.Fprintf(w, "--- %s (current)\n", proposal.Field)
+	fmt.Fprintf(w, "+++ %s (proposed by %s)\n", proposal.Field, proposal.Model)
+	for _, line := range diffLines(splitLines(proposal.Current), splitLines(proposal.Proposed)) {
+		switch line.op {
+		case '-':
+			fmt.Fprintln(w, paint(ansiRed, "- "+line.text))
+		case '+':
+			fmt.Fprintln(w, paint(ansiGreen, "+ "+line.text))
+		default:
+			fmt.Fprintln(w, "  "+line.text)
+		}
+	}
+}
+
+// diffLine is a line of a diff: kept (' '), removed ('-') or added ('+')
+type diffLine struct {
+	op   byte
+	text string
+}
+
+// diffLines computes the line diff turning before into after from their longest common subsequence
+func diffLines(before, after []string) []diffLine {
+	// common[i][j] is the length of the longest common subsequence of before[i:] and after[j:]
+	common := make([][]int, len(before)+1)
+	for i := range common {
+		common[i] = make([]int, len(after)+1)
+	}
+	for i := len(before) - 1; i >= 0; i-- {
+		for j := len(after) - 1; j >= 0; j-- {
+			if before[i] == after[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+
+	var lines []diffLine
+	i, j := 0, 0
+	for i < len(before) && j < len(after) {
+		switch {
+		case before[i] == after[j]:
+			lines = append(lines, diffLine{op: ' ', text: before[i]})
+			i++
+			j++
+		case common[i+1][j] >= common[i][j+1]:
+			lines = append(lines, diffLine{op: '-', text: before[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{op: '+', text: after[j]})
+			j++
+		}
+	}
+	for ; i < len(before); i++ {
+		lines = append(lines, diffLine{op: '-', text: before[i]})
+	}
+	for ; j < len(after); j++ {
+		lines = append(lines, diffLine{op: '+', text: after[j]})
+	}
+	return lines
+}
+
+// splitLines splits a field value into lines; an empty value has none
+func splitLines(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimRight(value, "\n"), "\n")
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+)
+
+func TestDiffLines(t *testing.T) {
+	lines := diffLines([]string{"a", "b", "c"}, []string{"a", "x", "c", "d"})
+	var rendered []string
+	for _, line := range lines {
+		rendered = append(rendered, string(line.op)+line.text)
+	}
+	assert.Equal(t, []string{" a", "-b", "+x", " c", "+d"}, rendered)
+	assert.Equal(t, []diffLine{{op: '+', text: "new"}}, diffLines(splitLines(""), splitLines("new\n")))
+}
+
+func TestPrintEnrichmentDiff(t *testing.T) {
+	proposal := &assetsdomain.EnrichmentProposal{Field: "how", Current: "Old text", Proposed: "New text", Model: "llama3"}
+
+	var plain bytes.Buffer
+	printEnrichmentDiff(&plain, proposal, false)
+	assert.Equal(t, "--- how (current)\n+++ how (proposed by llama3)\n- Old text\n+ New text\n", plain.String())
+
+	var colored bytes.Buffer
+	printEnrichmentDiff(&colored, proposal, true)
+	assert.Contains(t, colored.String(), ansiRed+"- Old text"+ansiReset)
+	assert.Contains(t, colored.String(), ansiGreen+"+ New text"+ansiReset)
+}
+
+func TestEnrichCommand(t *testing.T) {
+	proposal := &assetsdomain.EnrichmentProposal{Asset: "booking", Field: "how", Current: "Old text", Proposed: "New text", Model: "llama3"}
+	run := func(t *testing.T, assets *MockAssetService, answer string, args ...string) string {
+		app := NewApp(assets, new(MockTaskService), new(MockSprintService))
+		app.stdin = strings.NewReader(answer)
+		output, err := captureOutput(func() error {
+			os.Args = append([]string{"assetcap", "assets", "enrich", "--name", "booking", "--field", "how"}, args...)
+			return app.Run()
+		})
+		require.NoError(t, err)
+		return output
+	}
+
+	t.Run("approved", func(t *testing.T) {
+		assets := new(MockAssetService)
+		assets.On("ProposeEnrichment", "booking", "how", false, false).Return(proposal, nil)
+		assets.On("ApplyEnrichment", proposal).Return(nil)
+		output := run(t, assets, "y\n")
+		assert.Contains(t, output, "- Old text")
+		assert.Contains(t, output, "+ New text")
+		assert.Contains(t, output, "Apply this change to the how field of booking? [y/N]")
+		assert.Contains(t, output, "Enriched how field for asset: booking (generated by llama3)")
+		assets.AssertExpectations(t)
+	})
+
+	t.Run("declined", func(t *testing.T) {
+		assets := new(MockAssetService)
+		assets.On("ProposeEnrichment", "booking", "how", false, false).Return(proposal, nil)
+		output := run(t, assets, "\n")
+		assert.Contains(t, output, "Enrichment discarded")
+		assets.AssertNotCalled(t, "ApplyEnrichment", proposal)
+	})
+
+	t.Run("yes skips the prompt", func(t *testing.T) {
+		assets := new(MockAssetService)
+		assets.On("ProposeEnrichment", "booking", "how", true, false).Return(proposal, nil)
+		assets.On("ApplyEnrichment", proposal).Return(nil)
+		output := run(t, assets, "", "--with-attachments", "--yes")
+		assert.NotContains(t, output, "[y/N]")
+		assert.Contains(t, output, "Enriched how field")
+	})
+
+	t.Run("cached", func(t *testing.T) {
+		cached := *proposal
+		cached.Cached = true
+		assets := new(MockAssetService)
+		assets.On("ProposeEnrichment", "booking", "how", false, false).Return(&cached, nil)
+		output := run(t, assets, "\n")
+		assert.Contains(t, output, "Reusing the how value generated by llama3 for this version of the page; pass --force to generate a new one")
+	})
+
+	t.Run("force", func(t *testing.T) {
+		assets := new(MockAssetService)
+		assets.On("ProposeEnrichment", "booking", "how", false, true).Return(proposal, nil)
+		output := run(t, assets, "\n", "--force")
+		assert.NotContains(t, output, "Reusing")
+		assets.AssertExpectations(t)
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		unchanged := &assetsdomain.EnrichmentProposal{Asset: "booking", Field: "how", Current: "Same", Proposed: "Same", Model: "llama3"}
+		assets := new(MockAssetService)
+		assets.On("ProposeEnrichment", "booking", "how", false, false).Return(unchanged, nil)
+		output := run(t, assets, "")
+		assert.Contains(t, output, "No changes proposed for how field of asset: booking")
+	})
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+)
+
+// liveValueWidth is the number of characters of a field value shown in the live comparison
+const liveValueWidth = 40
+
+// showLive prints the comparison of an asset with the current state of its Confluence page
+func (a *App) showLive(name string) error {
+	preview, err := a.assetService.PreviewLive(name)
+	if err != nil {
+		return err
+	}
+	return printLivePreview(os.Stdout, preview, colorOutput(os.Stdout))
+}
+
+// printLivePreview writes the local and live values of each page field side by side, marking
+// the drifted fields with an asterisk, in red when color is set
+func printLivePreview(w io.Writer, preview *assetsdomain.LivePreview, color bool) error {
+	fmt.Fprintf(w, "Live Confluence page: %s", preview.DocLink)
+	if preview.PageVersion > 0 {
+		fmt.Fprintf(w, " (version %d", preview.PageVersion)
+		if !preview.PageUpdated.IsZero() {
+			fmt.Fprintf(w, ", updated %s", preview.PageUpdated.Format("2006-01-02"))
+		}
+		fmt.Fprint(w, ")")
+	}
+	fmt.Fprintln(w)
+
+	var table bytes.Buffer
+	tw := tabwriter.NewWriter(&table, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "  FIELD\tLOCAL\tLIVE")
+	for _, field := range preview.Fields {
+		marker := " "
+		if field.Drifted() {
+			marker = "*"
+		}
+		fmt.Fprintf(tw, "%s %s\t%s\t%s\n", marker, field.Field, liveValue(field.Local), liveValue(field.Live))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	// Colour whole lines after aligning them, as tabwriter would count the escape codes
+	for _, line := range strings.Split(strings.TrimRight(table.String(), "\n"), "\n") {
+		if color && strings.HasPrefix(line, "*") {
+			line = ansiRed + line + ansiReset
+		}
+		fmt.Fprintln(w, line)
+	}
+
+	if drifted := len(preview.Drifted()); drifted > 0 {
+		fmt.Fprintf(w, "%d field(s) drifted from the page; run assets sync to update the local values\n", drifted)
+	} else {
+		fmt.Fprintln(w, "Local values match the page")
+	}
+	return nil
+}
+
+// liveValue renders a field value on a single table line
+func liveValue(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return truncate(strings.Join(strings.Fields(value), " "), liveValueWidth)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+)
+
+func livePreview() *assetsdomain.LivePreview {
+	return &assetsdomain.LivePreview{
+		Asset:       "Booking Engine",
+		DocLink:     "https://example.atlassian.net/wiki/spaces/SPACE/pages/123456/Booking",
+		PageVersion: 4,
+		PageUpdated: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+		Fields: []assetsdomain.FieldDrift{
+			{Field: "description", Local: "Books rooms", Live: "Books rooms\nand flights"},
+			{Field: "status", Local: "Live", Live: "Live"},
+			{Field: "why", Local: "", Live: ""},
+		},
+	}
+}
+
+func TestPrintLivePreview(t *testing.T) {
+	var plain bytes.Buffer
+	require.NoError(t, printLivePreview(&plain, livePreview(), false))
+	assert.Equal(t, "Live Confluence page: https://example.atlassian.net/wiki/spaces/SPACE/pages/123456/Booking (version 4, updated 2024-03-01)\n"+
+		"  FIELD        LOCAL        LIVE\n"+
+		"* description  Books rooms  Books rooms and flights\n"+
+		"  status       Live         Live\n"+
+		"  why          -            -\n"+
+		"1 field(s) drifted from the page; run assets sync to update the local values\n", plain.String())
+
+	var colored bytes.Buffer
+	require.NoError(t, printLivePreview(&colored, livePreview(), true))
+	assert.Contains(t, colored.String(), ansiRed+"* description  Books rooms  Books rooms and flights"+ansiReset)
+	assert.Contains(t, colored.String(), "\n  status       Live         Live\n")
+
+	matching := &assetsdomain.LivePreview{DocLink: "https://example.atlassian.net/wiki/pages/1", Fields: []assetsdomain.FieldDrift{{Field: "status", Local: "Live", Live: "Live"}}}
+	var match bytes.Buffer
+	require.NoError(t, printLivePreview(&match, matching, false))
+	assert.Contains(t, match.String(), "Live Confluence page: https://example.atlassian.net/wiki/pages/1\n")
+	assert.Contains(t, match.String(), "Local values match the page\n")
+}
+
+func TestAssetsShowLive(t *testing.T) {
+	assets := new(MockAssetService)
+	assets.On("GetAsset", "booking").Return(&assetsdomain.Asset{Name: "Booking Engine", Description: "Books rooms"}, nil)
+	assets.On("GetTaskBreakdown", "Booking Engine").Return(assetsdomain.NewTaskBreakdown("Booking Engine", nil), nil)
+	assets.On("PreviewLive", "Booking Engine").Return(livePreview(), nil)
+	app := NewApp(assets, new(MockTaskService), new(MockSprintService))
+
+	output, err := captureOutput(func() error {
+		os.Args = []string{"assetcap", "assets", "show", "--name", "booking", "--live"}
+		return app.Run()
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, output, "Description: Books rooms\n")
+	assert.Contains(t, output, "* description  Books rooms  Books rooms and flights\n")
+	assets.AssertExpectations(t)
+}
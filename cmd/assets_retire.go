@@ -0,0 +1,191 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	tasksdomain "github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// retireAssetCommand returns the command retiring an asset and writing its final
+// capitalization summary
+func (a *App) retireAssetCommand() *cli.Command {
+	return &cli.Command{
+		Name:   "retire",
+		Usage:  "Retire an asset at a date and write its lifetime capitalization summary; work started after the date is no longer attributed to it",
+		Action: a.retireAsset,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "Asset name",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "date",
+				Usage:    "Date the asset is taken out of service (YYYY-MM-DD)",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:    "project",
+				Aliases: []string{"p"},
+				Usage:   "Project key whose stored sprints make up the summary (repeatable); defaults to every stored project",
+			},
+			&cli.Float64Flag{
+				Name:  "hourly-rate",
+				Usage: "Cost of an hour, adding the capitalized cost of each period to the summary",
+			},
+			&cli.StringFlag{
+				Name:  "out",
+				Usage: "File to write the summary CSV to instead of the standard output",
+			},
+		},
+	}
+}
+
+// retireAsset retires the asset, then allocates every stored sprint of the projects with the
+// retirement applied and writes the asset's capitalized hours per sprint
+func (a *App) retireAsset(ctx *cli.Context) error {
+	name := ctx.String("name")
+	date, err := time.Parse("2006-01-02", ctx.String("date"))
+	if err != nil {
+		return fmt.Errorf("invalid date %q: expected YYYY-MM-DD", ctx.String("date"))
+	}
+	if ctx.Float64("hourly-rate") < 0 {
+		return fmt.Errorf("hourly rate cannot be negative")
+	}
+	asset, err := a.assetService.RetireAsset(name, date)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Retired asset %s on %s\n", asset.Name, date.Format("2006-01-02"))
+
+	periods, err := a.assetLifetime(ctx, asset)
+	if err != nil {
+		return fmt.Errorf("asset retired, but its lifetime summary failed: %w", err)
+	}
+
+	out := io.Writer(os.Stdout)
+	if path := ctx.String("out"); path != "" {
+		file, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", path, err)
+		}
+		defer file.Close()
+		out = file
+	}
+	if err := writeLifetimeSummary(out, periods, ctx.Float64("hourly-rate")); err != nil {
+		return err
+	}
+	if path := ctx.String("out"); path != "" {
+		fmt.Fprintf(os.Stderr, "Wrote the lifetime summary of %s to %s\n", asset.Name, path)
+	}
+	return nil
+}
+
+// lifetimePeriod holds the hours of an asset in one allocated sprint
+type lifetimePeriod struct {
+	Period           string
+	TotalHours       float64
+	CapitalizedHours float64
+}
+
+// assetLifetime allocates every stored sprint of the requested projects, oldest first, and
+// returns the asset's hours in the sprints it had any
+func (a *App) assetLifetime(ctx *cli.Context, asset *assetsdomain.Asset) ([]lifetimePeriod, error) {
+	assets, err := a.assetService.ListAssets()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load assets: %w", err)
+	}
+	coverage, err := a.taskService.ClassificationCoverage(ctx.Context)
+	if err != nil {
+		return nil, err
+	}
+	splits, err := a.workTypeSplits(ctx.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	var sprints []string
+	projects := make(map[string][]string)
+	for _, sprint := range dashboardCoverage(coverage, ctx.StringSlice("project")) {
+		if _, ok := projects[sprint.Sprint]; !ok {
+			sprints = append(sprints, sprint.Sprint)
+		}
+		projects[sprint.Sprint] = append(projects[sprint.Sprint], sprint.Project)
+	}
+
+	var periods []lifetimePeriod
+	for _, sprint := range sprints {
+		report, err := a.sprintService.BuildCapitalizationReport(sprintdomain.CapitalizationReportInput{
+			Projects:       projects[sprint],
+			Sprint:         sprint,
+			Impairments:    assetImpairments(assets),
+			Policy:         assetPolicy(assets),
+			AssetStatuses:  assetStatuses(assets),
+			Retirements:    assetRetirements(assets),
+			WorkTypeSplits: splits,
+			Heuristics:     a.heuristics,
+			Export:         a.jiraExport,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to allocate sprint %s: %w", sprint, err)
+		}
+		if period := assetPeriod(asset.Name, sprint, report); period.TotalHours > 0 {
+			periods = append(periods, period)
+		}
+	}
+	return periods, nil
+}
+
+// assetPeriod sums the hours the report allocated to the asset
+func assetPeriod(name, period string, report *sprintdomain.CapitalizationReport) lifetimePeriod {
+	result := lifetimePeriod{Period: period}
+	for _, allocated := range report.Assets {
+		if tasksdomain.AssetLabel(strings.ToLower(allocated.AssetName)) != tasksdomain.AssetLabel(name) {
+			continue
+		}
+		result.TotalHours += allocated.Summary.TotalHours
+		result.CapitalizedHours += allocated.Summary.CapitalizedHours()
+	}
+	return result
+}
+
+// writeLifetimeSummary writes the hours of each period and their total as CSV, with the
+// capitalized cost at the hourly rate when one is given
+func writeLifetimeSummary(w io.Writer, periods []lifetimePeriod, rate float64) error {
+	header := []string{"period", "totalHours", "capitalizedHours"}
+	if rate > 0 {
+		header = append(header, "capitalizedCost")
+	}
+	record := func(period string, total, capitalized float64) []string {
+		row := []string{period, strconv.FormatFloat(total, 'f', 2, 64), strconv.FormatFloat(capitalized, 'f', 2, 64)}
+		if rate > 0 {
+			row = append(row, strconv.FormatFloat(capitalized*rate, 'f', 2, 64))
+		}
+		return row
+	}
+
+	records := [][]string{header}
+	var total lifetimePeriod
+	for _, period := range periods {
+		records = append(records, record(period.Period, period.TotalHours, period.CapitalizedHours))
+		total.TotalHours += period.TotalHours
+		total.CapitalizedHours += period.CapitalizedHours
+	}
+	records = append(records, record("total", total.TotalHours, total.CapitalizedHours))
+
+	writer := csv.NewWriter(w)
+	if err := writer.WriteAll(records); err != nil {
+		return fmt.Errorf("failed to write the lifetime summary: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+func TestAssetPeriod(t *testing.T) {
+	report := &sprintdomain.CapitalizationReport{Assets: []sprintdomain.AssetCapitalization{
+		{AssetName: "cap-asset-booking", Summary: sprintdomain.CapitalizationSummary{TotalHours: 30, DevelopmentHours: 20, MaintenanceHours: 10}},
+		{AssetName: "cap-asset-search", Summary: sprintdomain.CapitalizationSummary{TotalHours: 8, DevelopmentHours: 8}},
+		{AssetName: "", Summary: sprintdomain.CapitalizationSummary{TotalHours: 4}},
+	}}
+
+	assert.Equal(t, lifetimePeriod{Period: "Sprint 1", TotalHours: 30, CapitalizedHours: 20}, assetPeriod("Booking", "Sprint 1", report))
+	assert.Equal(t, lifetimePeriod{Period: "Sprint 1"}, assetPeriod("checkout", "Sprint 1", report))
+}
+
+func TestWriteLifetimeSummary(t *testing.T) {
+	periods := []lifetimePeriod{
+		{Period: "Sprint 1", TotalHours: 30, CapitalizedHours: 20},
+		{Period: "Sprint 2", TotalHours: 10, CapitalizedHours: 5},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, writeLifetimeSummary(&out, periods, 0))
+	assert.Equal(t, "period,totalHours,capitalizedHours\nSprint 1,30.00,20.00\nSprint 2,10.00,5.00\ntotal,40.00,25.00\n", out.String())
+
+	out.Reset()
+	require.NoError(t, writeLifetimeSummary(&out, periods, 100))
+	assert.Equal(t, "period,totalHours,capitalizedHours,capitalizedCost\nSprint 1,30.00,20.00,2000.00\nSprint 2,10.00,5.00,500.00\ntotal,40.00,25.00,2500.00\n", out.String())
+
+	out.Reset()
+	require.NoError(t, writeLifetimeSummary(&out, nil, 0))
+	assert.Equal(t, "period,totalHours,capitalizedHours\ntotal,0.00,0.00\n", out.String())
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+)
+
+// syncAssets syncs the asset pages of one or more Confluence spaces, reporting each space as
+// it is fetched and the combined result at the end
+func (a *App) syncAssets(ctx *cli.Context) error {
+	spaces := spaceKeys(ctx.StringSlice("space"))
+	if len(spaces) == 0 {
+		spaces = a.confluence.Spaces
+	}
+	if len(spaces) == 0 {
+		return fmt.Errorf("no Confluence space given; pass --space or set confluence.spaces in the configuration")
+	}
+
+	input := assetsdomain.SyncInput{
+		Spaces: spaces,
+		Label:  ctx.String("label"),
+		Debug:  ctx.Bool("debug"),
+	}
+	if len(spaces) > 1 {
+		input.Progress = func(space assetsdomain.SpaceSync) {
+			fmt.Printf("Fetched %d page(s) from space %s\n", space.Fetched, space.Space)
+		}
+	}
+	result, err := a.assetService.SyncFromConfluence(input)
+	if err != nil {
+		if strings.Contains(err.Error(), "no assets found with label") {
+			fmt.Println(err)
+			return nil
+		}
+		return err
+	}
+
+	a.publishSynced(ctx.Context, result.SyncedAssets)
+	fmt.Printf("Successfully synced %d/%d assets from Confluence\n", len(result.SyncedAssets), result.Total())
+	if len(result.Spaces) > 1 {
+		for _, space := range result.Spaces {
+			fmt.Printf("  %s: %d/%d synced", space.Space, space.Synced, space.Fetched)
+			if space.NotSynced > 0 {
+				fmt.Printf(", %d missing information", space.NotSynced)
+			}
+			if space.Duplicates > 0 {
+				fmt.Printf(", %d duplicate(s)", space.Duplicates)
+			}
+			fmt.Println()
+		}
+	}
+	printDuplicateAssets(result.Duplicates)
+
+	if len(result.NotSyncedAssets) > 0 {
+		fmt.Printf("\nWarning: %d assets could not be synced due to missing information:\n", len(result.NotSyncedAssets))
+		for _, asset := range result.NotSyncedAssets {
+			fmt.Printf("\n- %s:\n", asset.Name)
+			fmt.Printf("  Missing fields: %s\n", strings.Join(asset.MissingFields, ", "))
+			fmt.Println("  Available fields:")
+			for field, value := range asset.AvailableFields {
+				if value != "" {
+					fmt.Printf("    %s: %s\n", field, value)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// spaceKeys returns the distinct space keys given, in order, trimmed and upper-cased
+func spaceKeys(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	var keys []string
+	for _, value := range values {
+		key := strings.ToUpper(strings.TrimSpace(value))
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/config"
+)
+
+func TestSpaceKeys(t *testing.T) {
+	assert.Equal(t, []string{"MZN", "PAY", "PLAT"}, spaceKeys([]string{"mzn", " PAY", "", "PLAT", "MZN"}))
+	assert.Nil(t, spaceKeys(nil))
+}
+
+func TestSyncAssets(t *testing.T) {
+	run := func(t *testing.T, app *App, args ...string) (string, error) {
+		return captureOutput(func() error {
+			os.Args = append([]string{"assetcap", "assets", "sync", "--label", "cap-asset"}, args...)
+			return app.Run()
+		})
+	}
+
+	t.Run("several spaces", func(t *testing.T) {
+		assets := new(MockAssetService)
+		assets.On("SyncFromConfluence", assetsdomain.SyncInput{Spaces: []string{"MZN", "PAY"}, Label: "cap-asset"}).Return(&assetsdomain.SyncResult{
+			SyncedAssets:    []*assetsdomain.Asset{{Name: "Booking"}, {Name: "Checkout"}},
+			NotSyncedAssets: []*assetsdomain.NotSyncedAsset{{Name: "Payments", MissingFields: []string{"Status"}}},
+			Spaces: []*assetsdomain.SpaceSync{
+				{Space: "MZN", Fetched: 1, Synced: 1},
+				{Space: "PAY", Fetched: 2, Synced: 1, NotSynced: 1},
+			},
+		}, nil)
+
+		output, err := run(t, NewApp(assets, new(MockTaskService), new(MockSprintService)), "--space", "MZN,PAY")
+
+		require.NoError(t, err)
+		assert.Contains(t, output, "Fetched 1 page(s) from space MZN\nFetched 2 page(s) from space PAY\n")
+		assert.Contains(t, output, "Successfully synced 2/3 assets from Confluence\n  MZN: 1/1 synced\n  PAY: 1/2 synced, 1 missing information\n")
+		assert.Contains(t, output, "- Payments:\n  Missing fields: Status")
+		assets.AssertExpectations(t)
+	})
+
+	t.Run("configured spaces", func(t *testing.T) {
+		assets := new(MockAssetService)
+		assets.On("SyncFromConfluence", assetsdomain.SyncInput{Spaces: []string{"MZN"}, Label: "cap-asset"}).Return(&assetsdomain.SyncResult{
+			SyncedAssets: []*assetsdomain.Asset{{Name: "Booking"}},
+			Spaces:       []*assetsdomain.SpaceSync{{Space: "MZN", Fetched: 1, Synced: 1}},
+		}, nil)
+		app := NewApp(assets, new(MockTaskService), new(MockSprintService))
+		app.confluence = config.ConfluenceConfig{Spaces: []string{"MZN"}}
+
+		output, err := run(t, app)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Successfully synced 1/1 assets from Confluence\n", output)
+	})
+
+	t.Run("no space", func(t *testing.T) {
+		_, err := run(t, NewApp(new(MockAssetService), new(MockTaskService), new(MockSprintService)))
+		assert.EqualError(t, err, "no Confluence space given; pass --space or set confluence.spaces in the configuration")
+	})
+}
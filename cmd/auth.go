@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/config"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/infrastructure/jira"
+)
+
+// tokenRotation replaces the stored Jira API token once the new one is accepted by Jira
+type tokenRotation struct {
+	in *bufio.Reader
+	// credentialsPath is the credentials file holding the token
+	credentialsPath string
+	verify          func(ctx context.Context, answers initAnswers) error
+}
+
+// authCommand returns the commands managing the stored credentials. Like init, they do not
+// depend on the application services, so they also run when the stored token is revoked.
+// Jira is reached through the network settings of the configuration at configPath.
+func authCommand(stdin io.Reader, configPath string) *cli.Command {
+	return &cli.Command{
+		Name:  "auth",
+		Usage: "Manage the stored Jira credentials",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "rotate",
+				Usage: "Replace the stored Jira API token after checking the new one against Jira",
+				Action: func(ctx *cli.Context) error {
+					if stdin == nil {
+						stdin = os.Stdin
+					}
+					cfg, err := config.Load(configPath)
+					if err != nil {
+						return err
+					}
+					httpClient, err := newHTTPClient(cfg.Network, jira.DefaultTimeout)
+					if err != nil {
+						return err
+					}
+					rotation := &tokenRotation{
+						in:              bufio.NewReader(stdin),
+						credentialsPath: ctx.String("credentials"),
+						verify: func(ctx context.Context, answers initAnswers) error {
+							return getWithCredentials(ctx, httpClient, answers.JiraURL+"/rest/api/3/myself", answers)
+						},
+					}
+					return rotation.run(ctx.Context)
+				},
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "credentials",
+						Usage: "Credentials file holding the token",
+						Value: config.DefaultCredentialsPath,
+					},
+				},
+			},
+		},
+	}
+}
+
+// run reads the new token, checks it against the Jira site of the stored credentials and
+// writes it to the credentials file, keeping the stored token when Jira rejects the new one
+func (r *tokenRotation) run(ctx context.Context) error {
+	values, err := config.ReadCredentials(r.credentialsPath)
+	if err != nil {
+		return err
+	}
+	answers := initAnswers{JiraURL: values["JIRA_BASE_URL"], Email: values["JIRA_EMAIL"]}
+	if answers.JiraURL == "" {
+		answers.JiraURL = os.Getenv("JIRA_BASE_URL")
+	}
+	if answers.Email == "" {
+		answers.Email = os.Getenv("JIRA_EMAIL")
+	}
+	answers.JiraURL = strings.TrimSuffix(answers.JiraURL, "/")
+	if answers.JiraURL == "" || answers.Email == "" {
+		return errors.New("no Jira site or email to check the token against: run assetcap init first")
+	}
+
+	fmt.Printf("New Jira API token for %s on %s: ", answers.Email, answers.JiraURL)
+	line, err := r.in.ReadString('\n')
+	if err != nil && !(err == io.EOF && line != "") {
+		return errors.New("rotation cancelled: no token given")
+	}
+	if answers.Token = strings.TrimSpace(line); answers.Token == "" {
+		return errors.New("rotation cancelled: no token given")
+	}
+	fmt.Println()
+
+	if err := r.verify(ctx, answers); err != nil {
+		return fmt.Errorf("the new token was not stored: %w", err)
+	}
+
+	values["JIRA_BASE_URL"] = answers.JiraURL
+	values["JIRA_EMAIL"] = answers.Email
+	values["JIRA_TOKEN"] = answers.Token
+	if err := config.SaveCredentials(r.credentialsPath, values); err != nil {
+		return err
+	}
+	fmt.Printf("Jira accepted the new token; replaced the token in %s\n", filepath.Clean(r.credentialsPath))
+	return nil
+}
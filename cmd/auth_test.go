@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/config"
+)
+
+func newTestRotation(t *testing.T, input string, verify func(initAnswers) error) *tokenRotation {
+	t.Setenv("JIRA_BASE_URL", "")
+	t.Setenv("JIRA_EMAIL", "")
+	t.Setenv("JIRA_TOKEN", "")
+	path := filepath.Join(t.TempDir(), ".assetcap", "credentials.env")
+	require.NoError(t, config.SaveCredentials(path, map[string]string{
+		"JIRA_BASE_URL": "https://acme.atlassian.net",
+		"JIRA_EMAIL":    "jane@acme.com",
+		"JIRA_TOKEN":    "old-token",
+		"GITHUB_TOKEN":  "ghp-token",
+	}))
+	return &tokenRotation{
+		in:              bufio.NewReader(strings.NewReader(input)),
+		credentialsPath: path,
+		verify: func(_ context.Context, answers initAnswers) error {
+			return verify(answers)
+		},
+	}
+}
+
+func TestTokenRotation_Run(t *testing.T) {
+	var checked initAnswers
+	rotation := newTestRotation(t, "new-token\n", func(answers initAnswers) error {
+		checked = answers
+		return nil
+	})
+
+	output, err := captureOutput(func() error {
+		return rotation.run(context.Background())
+	})
+	require.NoError(t, err)
+	assert.Equal(t, initAnswers{JiraURL: "https://acme.atlassian.net", Email: "jane@acme.com", Token: "new-token"}, checked)
+	assert.Contains(t, output, "Jira accepted the new token")
+
+	values, err := config.ReadCredentials(rotation.credentialsPath)
+	require.NoError(t, err)
+	assert.Equal(t, "new-token", values["JIRA_TOKEN"])
+	assert.Equal(t, "ghp-token", values["GITHUB_TOKEN"], "the other credentials are kept")
+}
+
+func TestTokenRotation_RejectedTokenIsNotStored(t *testing.T) {
+	rotation := newTestRotation(t, "bad-token\n", func(initAnswers) error {
+		return errors.New("the email and API token were rejected (status 401)")
+	})
+
+	_, err := captureOutput(func() error {
+		return rotation.run(context.Background())
+	})
+	assert.EqualError(t, err, "the new token was not stored: the email and API token were rejected (status 401)")
+
+	values, err := config.ReadCredentials(rotation.credentialsPath)
+	require.NoError(t, err)
+	assert.Equal(t, "old-token", values["JIRA_TOKEN"])
+}
+
+func TestTokenRotation_Errors(t *testing.T) {
+	t.Run("no token given", func(t *testing.T) {
+		rotation := newTestRotation(t, "\n", func(initAnswers) error { return nil })
+		_, err := captureOutput(func() error {
+			return rotation.run(context.Background())
+		})
+		assert.EqualError(t, err, "rotation cancelled: no token given")
+	})
+
+	t.Run("no Jira site", func(t *testing.T) {
+		rotation := newTestRotation(t, "new-token\n", func(initAnswers) error { return nil })
+		rotation.credentialsPath = filepath.Join(t.TempDir(), "missing.env")
+		_, err := captureOutput(func() error {
+			return rotation.run(context.Background())
+		})
+		assert.ErrorContains(t, err, "run assetcap init first")
+	})
+}
+
+func TestAuthRotate_UsesNetworkConfiguration(t *testing.T) {
+	cfg := config.Default()
+	cfg.Network.CABundle = filepath.Join(t.TempDir(), "missing.pem")
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, config.Save(configPath, cfg))
+
+	app := &cli.App{Name: "AssetCap", Commands: []*cli.Command{authCommand(strings.NewReader("new-token\n"), configPath)}}
+	err := app.Run([]string{"assetcap", "auth", "rotate"})
+	assert.ErrorContains(t, err, "invalid network configuration: failed to read CA bundle", "Jira is reached through the configured network")
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/backstage"
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	tasksdomain "github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// backstageCommand returns the command exporting the assets as Backstage catalog entities
+func (a *App) backstageCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "backstage",
+		Usage: "Expose the assets in the Backstage developer portal",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "export",
+				Usage:  "Write a catalog-info entity per asset with its metadata, capitalization status and latest allocation",
+				Action: a.withSnapshot(a.exportBackstage),
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "out",
+						Usage: "Directory to write the entities to",
+						Value: "./backstage/",
+					},
+					&cli.StringFlag{
+						Name:  "owner",
+						Usage: "Backstage owner of assets without an owner",
+						Value: backstage.DefaultOwner,
+					},
+					&cli.StringFlag{
+						Name:  "type",
+						Usage: "Backstage component type of the assets",
+						Value: backstage.DefaultType,
+					},
+					&cli.StringSliceFlag{
+						Name:    "project",
+						Aliases: []string{"p"},
+						Usage:   "Project key to allocate (repeatable); allocation annotations are only written for given projects",
+					},
+					&cli.StringFlag{
+						Name:    "sprint",
+						Aliases: []string{"s"},
+						Usage:   "Sprint to allocate (defaults to the latest stored sprint of the projects)",
+					},
+				},
+			},
+		},
+	}
+}
+
+// exportBackstage writes the Backstage entities of the stored assets
+func (a *App) exportBackstage(ctx *cli.Context) error {
+	assets, err := a.assetService.ListAssets()
+	if err != nil {
+		return fmt.Errorf("failed to load assets: %w", err)
+	}
+	a.telemetry.Count("assets", len(assets))
+
+	var report *sprintdomain.CapitalizationReport
+	if projects := ctx.StringSlice("project"); len(projects) > 0 {
+		if report, err = a.capitalizationReport(ctx.Context, assets, projects, ctx.String("sprint")); err != nil {
+			return err
+		}
+	}
+
+	catalog := backstage.Catalog{
+		Assets:     backstageAssets(assets, report),
+		Owner:      ctx.String("owner"),
+		Type:       ctx.String("type"),
+		ExportedAt: a.dataTime(),
+	}
+	files, err := backstage.Export(ctx.String("out"), catalog)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Wrote %d Backstage entities to %s\n", len(files), ctx.String("out"))
+	fmt.Printf("Register %s as a catalog location in Backstage to import them\n", backstage.LocationFile)
+	return nil
+}
+
+// backstageAssets converts the stored assets to catalog assets, sorted by name, with their share
+// of the report's allocation; assets without allocated hours keep no allocation
+func backstageAssets(assets []*assetsdomain.Asset, report *sprintdomain.CapitalizationReport) []backstage.Asset {
+	entries := make([]backstage.Asset, 0, len(assets))
+	for _, asset := range assets {
+		entry := backstage.Asset{
+			Name:        asset.Name,
+			Description: asset.Description,
+			DocURL:      asset.DocLink,
+			Owner:       asset.Owner,
+			Status:      asset.Status,
+			Tasks:       asset.AssociatedTaskCount,
+			LaunchDate:  asset.LaunchDate,
+			Impaired:    len(asset.Impairments) > 0,
+		}
+		if report != nil {
+			for _, allocated := range report.Assets {
+				if tasksdomain.AssetLabel(strings.ToLower(allocated.AssetName)) != tasksdomain.AssetLabel(asset.Name) {
+					continue
+				}
+				if entry.Allocation == nil {
+					entry.Allocation = &backstage.Allocation{Period: report.KPIs.Period}
+				}
+				summary := allocated.Summary
+				entry.Allocation.TotalHours += summary.TotalHours
+				entry.Allocation.CapitalizedHours += summary.CapitalizedHours()
+				entry.Allocation.DevelopmentHours += summary.DevelopmentHours
+				entry.Allocation.MaintenanceHours += summary.MaintenanceHours
+				entry.Allocation.DiscoveryHours += summary.DiscoveryHours
+			}
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/backstage"
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+func TestBackstageAssets(t *testing.T) {
+	launched := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	assets := []*assetsdomain.Asset{
+		{Name: "search", Description: "Search", AssociatedTaskCount: 2},
+		{
+			Name:                "Booking Engine",
+			Description:         "Booking",
+			DocLink:             "https://wiki/booking",
+			Owner:               "team-booking",
+			Status:              "Live",
+			AssociatedTaskCount: 5,
+			LaunchDate:          launched,
+			Impairments:         []assetsdomain.Impairment{{Date: launched, Reason: "rewrite"}},
+		},
+	}
+	report := &sprintdomain.CapitalizationReport{
+		KPIs: sprintdomain.CapitalizationKPIs{Period: "Penguins"},
+		Assets: []sprintdomain.AssetCapitalization{
+			{AssetName: "", Summary: sprintdomain.CapitalizationSummary{TotalHours: 10}},
+			{AssetName: "cap-asset-booking", Summary: sprintdomain.CapitalizationSummary{TotalHours: 30, DevelopmentHours: 20, MaintenanceHours: 10, ImpairedHours: 5}},
+		},
+	}
+
+	assert.Equal(t, []backstage.Asset{
+		{
+			Name:        "Booking Engine",
+			Description: "Booking",
+			DocURL:      "https://wiki/booking",
+			Owner:       "team-booking",
+			Status:      "Live",
+			Tasks:       5,
+			LaunchDate:  launched,
+			Impaired:    true,
+			Allocation:  &backstage.Allocation{Period: "Penguins", TotalHours: 30, CapitalizedHours: 15, DevelopmentHours: 20, MaintenanceHours: 10},
+		},
+		{Name: "search", Description: "Search", Tasks: 2},
+	}, backstageAssets(assets, report))
+
+	assert.Nil(t, backstageAssets(assets, nil)[0].Allocation, "no allocation without a report")
+}
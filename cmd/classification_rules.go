@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// setClassificationRules replaces the classification rules of an asset with the given rules and prompt
+func (a *App) setClassificationRules(ctx *cli.Context) error {
+	name := ctx.String("name")
+	rules := assetsdomain.ClassificationRules{Prompt: ctx.String("prompt")}
+	for _, value := range ctx.StringSlice("rule") {
+		rule, err := assetsdomain.ParseClassificationRule(value)
+		if err != nil {
+			return err
+		}
+		rules.Keywords = append(rules.Keywords, rule)
+	}
+	if rules.Empty() {
+		return fmt.Errorf("at least one --rule or --prompt is required; use assets rules clear to remove the rules")
+	}
+
+	if err := a.assetService.SetClassificationRules(name, rules); err != nil {
+		return err
+	}
+	fmt.Printf("Set %d classification rules for asset: %s\n", len(rules.Keywords), name)
+	return nil
+}
+
+// printClassificationRules prints the keyword rules and prompt addition of an asset
+func printClassificationRules(asset *assetsdomain.Asset) {
+	if asset.ClassificationRules == nil {
+		fmt.Printf("No classification rules for asset: %s\n", asset.Name)
+		return
+	}
+	fmt.Printf("Classification rules of %s:\n", asset.Name)
+	for i, rule := range asset.ClassificationRules.Keywords {
+		fmt.Printf("  %d. %q => %s\n", i+1, rule.Keyword, rule.WorkType)
+	}
+	if asset.ClassificationRules.Prompt != "" {
+		fmt.Printf("Prompt: %s\n", asset.ClassificationRules.Prompt)
+	}
+}
+
+// classificationRules collects the classification rules of every asset, keyed by the label
+// linking tasks to the asset
+func (a *App) classificationRules() (domain.ClassificationRules, error) {
+	assets, err := a.assetService.ListAssets()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load classification rules: %w", err)
+	}
+
+	var rules domain.ClassificationRules
+	for _, asset := range assets {
+		if asset.ClassificationRules == nil {
+			continue
+		}
+		assetRules := domain.AssetRules{Asset: asset.Name, Prompt: asset.ClassificationRules.Prompt}
+		for _, rule := range asset.ClassificationRules.Keywords {
+			assetRules.Keywords = append(assetRules.Keywords, domain.KeywordRule{Keyword: rule.Keyword, WorkType: domain.WorkType(rule.WorkType)})
+		}
+		if rules == nil {
+			rules = make(domain.ClassificationRules)
+		}
+		rules[domain.AssetLabel(asset.TaskLinkKey())] = assetRules
+	}
+	return rules, nil
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/config"
+)
+
+// configCommand returns the commands editing the configuration of the current directory. Like
+// init, they do not depend on the application services, so they run before Jira is set up.
+func configCommand(configPath string) *cli.Command {
+	return &cli.Command{
+		Name:  "config",
+		Usage: "Edit the configuration of the current directory",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "set",
+				Usage:     "Set the value a flag defaults to, e.g. config set project FN; an empty value removes it",
+				ArgsUsage: "<project|platform> <value>",
+				Action: func(ctx *cli.Context) error {
+					if ctx.NArg() != 2 {
+						return fmt.Errorf("usage: assetcap config set <project|platform> <value>")
+					}
+					return setDefault(configPath, ctx.Args().Get(0), ctx.Args().Get(1))
+				},
+			},
+		},
+	}
+}
+
+// setDefault stores the default of a flag in the configuration file
+func setDefault(configPath, key, value string) error {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return err
+	}
+	if err := cfg.Defaults.Set(key, value); err != nil {
+		return err
+	}
+	if err := config.Save(configPath, cfg); err != nil {
+		return err
+	}
+	if value := cfg.Defaults.Get(key); value != "" {
+		fmt.Printf("Commands now use --%s %s when the flag is omitted\n", key, value)
+	} else {
+		fmt.Printf("Removed the default of --%s\n", key)
+	}
+	return nil
+}
+
+// statusCommand returns the command showing the configuration the current directory runs with
+func statusCommand(configPath string) *cli.Command {
+	return &cli.Command{
+		Name:  "status",
+		Usage: "Show the configuration, Jira site and flag defaults of the current directory",
+		Action: func(*cli.Context) error {
+			return printStatus(configPath)
+		},
+	}
+}
+
+// printStatus prints where the configuration is read from and the active flag defaults
+func printStatus(configPath string) error {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return err
+	}
+
+	source := filepath.Clean(configPath)
+	if _, err := os.Stat(configPath); errors.Is(err, os.ErrNotExist) {
+		source += " (not found, using defaults)"
+	}
+	jiraSite := os.Getenv("JIRA_BASE_URL")
+	if cfg.Jira.Export != "" {
+		jiraSite = "export " + cfg.Jira.Export
+	} else if jiraSite == "" {
+		jiraSite = "not configured; run assetcap init"
+	}
+
+	fmt.Printf("Config:   %s\n", source)
+	fmt.Printf("Storage:  %s\n", cfg.Storage.Directory)
+	fmt.Printf("Jira:     %s\n", jiraSite)
+	fmt.Println("Defaults:")
+	for _, key := range config.DefaultKeys {
+		value := cfg.Defaults.Get(key)
+		if value == "" {
+			value = "(none)"
+		}
+		fmt.Printf("  --%-9s %s\n", key, value)
+	}
+	return nil
+}
+
+// applyFlagDefaults makes the project and platform flags of every command default to the
+// configured values. A defaulted flag is no longer required, and a flag given explicitly wins.
+func applyFlagDefaults(commands []*cli.Command, defaults config.DefaultsConfig) {
+	for _, command := range commands {
+		for _, flag := range command.Flags {
+			stringFlag, ok := flag.(*cli.StringFlag)
+			if !ok {
+				continue
+			}
+			if value := defaults.Get(stringFlag.Name); value != "" {
+				stringFlag.Value = value
+				stringFlag.Required = false
+			}
+		}
+		applyFlagDefaults(command.Subcommands, defaults)
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/config"
+)
+
+func TestSetDefault(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".assetcap", "config.json")
+
+	output, err := captureOutput(func() error {
+		return setDefault(path, "project", "FN")
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Commands now use --project FN when the flag is omitted\n", output)
+
+	cfg, err := config.Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, config.DefaultsConfig{Project: "FN"}, cfg.Defaults)
+
+	output, err = captureOutput(func() error {
+		return setDefault(path, "project", "")
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Removed the default of --project\n", output)
+
+	assert.EqualError(t, setDefault(path, "sprint", "Sprint 1"), "unknown default sprint: must be one of project, platform")
+}
+
+func TestPrintStatus(t *testing.T) {
+	t.Setenv("JIRA_BASE_URL", "https://acme.atlassian.net")
+	path := filepath.Join(t.TempDir(), "config.json")
+
+	output, err := captureOutput(func() error { return printStatus(path) })
+	require.NoError(t, err)
+	assert.Contains(t, output, "(not found, using defaults)")
+	assert.Contains(t, output, "Jira:     https://acme.atlassian.net\n")
+	assert.Contains(t, output, "  --project   (none)\n")
+
+	require.NoError(t, setDefault(path, "project", "FN"))
+	require.NoError(t, setDefault(path, "platform", "jira"))
+	output, err = captureOutput(func() error { return printStatus(path) })
+	require.NoError(t, err)
+	assert.NotContains(t, output, "not found")
+	assert.Contains(t, output, "Defaults:\n  --project   FN\n  --platform  jira\n")
+}
+
+func TestApplyFlagDefaults(t *testing.T) {
+	run := func(defaults config.DefaultsConfig, args ...string) (string, error) {
+		var project, platform string
+		commands := []*cli.Command{{
+			Name: "tasks",
+			Subcommands: []*cli.Command{{
+				Name: "fetch",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "project", Aliases: []string{"p"}, Required: true},
+					&cli.StringFlag{Name: "platform", Required: true},
+				},
+				Action: func(ctx *cli.Context) error {
+					project, platform = ctx.String("project"), ctx.String("platform")
+					return nil
+				},
+			}},
+		}}
+		applyFlagDefaults(commands, defaults)
+		app := &cli.App{Name: "assetcap", Commands: commands}
+		err := app.Run(append([]string{"assetcap", "tasks", "fetch"}, args...))
+		return project + " " + platform, err
+	}
+
+	got, err := run(config.DefaultsConfig{Project: "FN", Platform: "jira"})
+	require.NoError(t, err)
+	assert.Equal(t, "FN jira", got, "omitted flags take the defaults")
+
+	got, err = run(config.DefaultsConfig{Project: "FN", Platform: "jira"}, "-p", "PAY")
+	require.NoError(t, err)
+	assert.Equal(t, "PAY jira", got, "explicit flags win")
+
+	_, err = run(config.DefaultsConfig{Project: "FN"})
+	assert.EqualError(t, err, `Required flag "platform" not set`, "flags without a default stay required")
+}
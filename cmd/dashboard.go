@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/dashboard"
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// dashboardAssets converts the stored assets to catalog entries, sorted by name
+func dashboardAssets(assets []*assetsdomain.Asset) []dashboard.Asset {
+	entries := make([]dashboard.Asset, 0, len(assets))
+	for _, asset := range assets {
+		entries = append(entries, dashboard.Asset{
+			Name:        asset.Name,
+			Description: asset.Description,
+			DocURL:      asset.DocLink,
+			Tasks:       asset.AssociatedTaskCount,
+			DocUpdated:  asset.LastDocUpdateAt,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries
+}
+
+// dashboardCoverage converts the classification coverage of the given projects, or of every
+// project when none is given
+func dashboardCoverage(coverage []domain.SprintCoverage, projects []string) []dashboard.SprintCoverage {
+	wanted := make(map[string]bool, len(projects))
+	for _, project := range projects {
+		wanted[project] = true
+	}
+
+	var sprints []dashboard.SprintCoverage
+	for _, sprint := range coverage {
+		if len(projects) > 0 && !wanted[sprint.Project] {
+			continue
+		}
+		workTypes := make(map[string]int, len(sprint.WorkTypes))
+		for workType, count := range sprint.WorkTypes {
+			workTypes[string(workType)] = count
+		}
+		sprints = append(sprints, dashboard.SprintCoverage{
+			Project:   sprint.Project,
+			Sprint:    sprint.Sprint,
+			Tasks:     sprint.Tasks,
+			WorkTypes: workTypes,
+		})
+	}
+	return sprints
+}
+
+// latestSprint returns the most recent stored sprint of the given projects
+func latestSprint(sprints []dashboard.SprintCoverage, projects []string) (string, error) {
+	if len(sprints) == 0 {
+		return "", fmt.Errorf("no stored tasks for %v; pass --sprint or fetch the tasks of a sprint first", projects)
+	}
+	return sprints[len(sprints)-1].Sprint, nil
+}
+
+// dashboardAllocation converts a capitalization report to the hours of the allocation page
+func dashboardAllocation(report *sprintdomain.CapitalizationReport, projects []string) *dashboard.Allocation {
+	overall := report.KPIs.Overall
+	allocation := &dashboard.Allocation{
+		Projects: projects,
+		Period:   report.KPIs.Period,
+		WorkTypes: []dashboard.Hours{
+			{Label: "Development", Hours: overall.DevelopmentHours},
+			{Label: "Maintenance", Hours: overall.MaintenanceHours},
+			{Label: "Discovery", Hours: overall.DiscoveryHours},
+		},
+	}
+	for _, asset := range report.Assets {
+		name := asset.AssetName
+		if name == "" {
+			name = "(no asset)"
+		}
+		allocation.Assets = append(allocation.Assets, dashboard.Hours{Label: name, Hours: asset.Summary.TotalHours})
+	}
+	return allocation
+}
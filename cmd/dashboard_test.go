@@ -0,0 +1,72 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/dashboard"
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+func TestDashboardAssets(t *testing.T) {
+	updated := time.Date(2024, time.May, 20, 0, 0, 0, 0, time.UTC)
+
+	entries := dashboardAssets([]*assetsdomain.Asset{
+		{Name: "search", Description: "Search", AssociatedTaskCount: 2},
+		{Name: "booking", Description: "Booking", DocLink: "https://wiki/booking", AssociatedTaskCount: 5, LastDocUpdateAt: updated},
+	})
+
+	assert.Equal(t, []dashboard.Asset{
+		{Name: "booking", Description: "Booking", DocURL: "https://wiki/booking", Tasks: 5, DocUpdated: updated},
+		{Name: "search", Description: "Search", Tasks: 2},
+	}, entries)
+}
+
+func TestDashboardCoverage(t *testing.T) {
+	coverage := []domain.SprintCoverage{
+		{Project: "FN", Sprint: "Owls", Tasks: 2, WorkTypes: map[domain.WorkType]int{domain.WorkTypeDevelopment: 1}},
+		{Project: "OTHER", Sprint: "Tigers", Tasks: 1, WorkTypes: map[domain.WorkType]int{}},
+	}
+
+	assert.Equal(t, []dashboard.SprintCoverage{
+		{Project: "FN", Sprint: "Owls", Tasks: 2, WorkTypes: map[string]int{"cap-development": 1}},
+	}, dashboardCoverage(coverage, []string{"FN"}))
+	assert.Len(t, dashboardCoverage(coverage, nil), 2)
+}
+
+func TestLatestSprint(t *testing.T) {
+	sprint, err := latestSprint([]dashboard.SprintCoverage{{Sprint: "Owls"}, {Sprint: "Penguins"}}, []string{"FN"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Penguins", sprint)
+
+	_, err = latestSprint(nil, []string{"FN"})
+	assert.EqualError(t, err, "no stored tasks for [FN]; pass --sprint or fetch the tasks of a sprint first")
+}
+
+func TestDashboardAllocation(t *testing.T) {
+	allocation := dashboardAllocation(&sprintdomain.CapitalizationReport{
+		KPIs: sprintdomain.CapitalizationKPIs{
+			Period:  "Penguins",
+			Overall: sprintdomain.CapitalizationSummary{TotalHours: 40, DevelopmentHours: 30, MaintenanceHours: 10},
+		},
+		Assets: []sprintdomain.AssetCapitalization{
+			{AssetName: "", Summary: sprintdomain.CapitalizationSummary{TotalHours: 10}},
+			{AssetName: "booking", Summary: sprintdomain.CapitalizationSummary{TotalHours: 30}},
+		},
+	}, []string{"FN"})
+
+	assert.Equal(t, &dashboard.Allocation{
+		Projects: []string{"FN"},
+		Period:   "Penguins",
+		WorkTypes: []dashboard.Hours{
+			{Label: "Development", Hours: 30},
+			{Label: "Maintenance", Hours: 10},
+			{Label: "Discovery", Hours: 0},
+		},
+		Assets: []dashboard.Hours{{Label: "(no asset)", Hours: 10}, {Label: "booking", Hours: 30}},
+	}, allocation)
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/config"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sink"
+)
+
+// docsReminder is the stale documentation of one owner in a reminder
+type docsReminder struct {
+	Owner  string                            `json:"owner"`
+	Assets []assetsdomain.StaleDocumentation `json:"assets"`
+}
+
+// docsReminderPayload is posted to the notify destination; text is shown by chat webhooks
+type docsReminderPayload struct {
+	Text      string         `json:"text"`
+	Reminders []docsReminder `json:"reminders"`
+}
+
+// freshnessPolicy converts the configured documentation ages for the asset service
+func freshnessPolicy(cfg config.DocsConfig) assetsdomain.FreshnessPolicy {
+	return assetsdomain.FreshnessPolicy{MaxAgeDays: cfg.MaxAgeDays, DefaultMaxAgeDays: cfg.DefaultMaxAgeDays}
+}
+
+// printStaleDocumentation lists the stale documentation with its age against the policy
+func printStaleDocumentation(stale []assetsdomain.StaleDocumentation) {
+	if len(stale) == 0 {
+		fmt.Println("All asset documentation is within the freshness policy")
+		return
+	}
+	fmt.Printf("%d asset(s) with stale documentation:\n", len(stale))
+	for _, doc := range stale {
+		fmt.Printf("  %s (%s): %s, max %d days, owner %s\n", doc.Asset, orNone(doc.Status), docAge(doc), doc.MaxAgeDays, orNone(doc.Owner))
+		if doc.DocLink != "" {
+			fmt.Printf("    %s\n", doc.DocLink)
+		}
+	}
+}
+
+// docAge describes when the documentation was last updated
+func docAge(doc assetsdomain.StaleDocumentation) string {
+	if doc.UpdatedAt.IsZero() {
+		return "never updated"
+	}
+	age := fmt.Sprintf("updated %s (%d days ago)", doc.UpdatedAt.Format("2006-01-02"), doc.AgeDays)
+	if doc.PageVersion > 0 {
+		age += fmt.Sprintf(", page version %d", doc.PageVersion)
+	}
+	return age
+}
+
+// orNone returns the value, or "none" when it is empty
+func orNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
+
+// docsReminderBody builds the reminder of the stale documentation, grouped by owner with the
+// assets without one last
+func docsReminderBody(stale []assetsdomain.StaleDocumentation) ([]byte, error) {
+	byOwner := assetsdomain.StaleByOwner(stale)
+	owners := make([]string, 0, len(byOwner))
+	for owner := range byOwner {
+		owners = append(owners, owner)
+	}
+	sort.Slice(owners, func(i, j int) bool {
+		if owners[i] == "" || owners[j] == "" {
+			return owners[j] == ""
+		}
+		return owners[i] < owners[j]
+	})
+
+	payload := docsReminderPayload{Reminders: make([]docsReminder, 0, len(owners))}
+	lines := []string{fmt.Sprintf("%d asset(s) need their documentation reviewed:", len(stale))}
+	for _, owner := range owners {
+		docs := byOwner[owner]
+		payload.Reminders = append(payload.Reminders, docsReminder{Owner: owner, Assets: docs})
+		names := make([]string, 0, len(docs))
+		for _, doc := range docs {
+			names = append(names, doc.Asset)
+		}
+		lines = append(lines, fmt.Sprintf("- %s: %s", orNone(owner), strings.Join(names, ", ")))
+	}
+	payload.Text = strings.Join(lines, "\n")
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal reminder: %w", err)
+	}
+	return data, nil
+}
+
+// notifyStaleDocumentation sends the reminder of the stale documentation to the --notify
+// destination or the configured one; nothing is sent without a destination or stale documentation
+func (a *App) notifyStaleDocumentation(ctx *cli.Context, stale []assetsdomain.StaleDocumentation) error {
+	destination := ctx.String("notify")
+	if destination == "" {
+		destination = a.docs.Notify
+	}
+	if destination == "" || len(stale) == 0 {
+		return nil
+	}
+
+	body, err := docsReminderBody(stale)
+	if err != nil {
+		return err
+	}
+	out, err := sink.Open(destination, sinkOptions(a.outputs))
+	if err != nil {
+		return err
+	}
+	if err := out.Write(ctx.Context, body, sink.ContentTypeJSON); err != nil {
+		return fmt.Errorf("failed to send reminder to %s: %w", out, err)
+	}
+	fmt.Fprintf(os.Stderr, "Sent the stale documentation reminder to %s\n", out)
+	return nil
+}
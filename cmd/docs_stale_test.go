@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/config"
+)
+
+func TestDocsStale_NotifiesOwners(t *testing.T) {
+	var received docsReminderPayload
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+		assert.NoError(t, json.Unmarshal(body, &received))
+	}))
+	defer server.Close()
+
+	stale := []assetsdomain.StaleDocumentation{
+		{Asset: "billing", Status: "Development", AgeDays: 100, MaxAgeDays: 90},
+		{Asset: "ledger", Status: "Live", Owner: "Jane", DocLink: "https://wiki/ledger", PageVersion: 7,
+			UpdatedAt: time.Date(2024, 4, 17, 0, 0, 0, 0, time.UTC), AgeDays: 45, MaxAgeDays: 40},
+	}
+	mas := new(MockAssetService)
+	mas.On("FindStaleDocumentation", assetsdomain.FreshnessPolicy{MaxAgeDays: map[string]int{"Retired": 0}, DefaultMaxAgeDays: 40}).Return(stale, nil)
+	app := NewApp(mas, new(MockTaskService), new(MockSprintService))
+	app.docs = config.DocsConfig{MaxAgeDays: map[string]int{"Retired": 0}, DefaultMaxAgeDays: 180, Notify: server.URL}
+
+	output, err := captureOutput(func() error {
+		os.Args = []string{"assetcap", "assets", "docs", "stale", "--max-age", "40"}
+		return app.Run()
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "2 asset(s) with stale documentation:\n"+
+		"  billing (Development): never updated, max 90 days, owner none\n"+
+		"  ledger (Live): updated 2024-04-17 (45 days ago), page version 7, max 40 days, owner Jane\n"+
+		"    https://wiki/ledger\n", output)
+
+	assert.Equal(t, "2 asset(s) need their documentation reviewed:\n- Jane: ledger\n- none: billing", received.Text)
+	require.Len(t, received.Reminders, 2)
+	assert.Equal(t, "Jane", received.Reminders[0].Owner)
+	assert.Equal(t, "", received.Reminders[1].Owner, "assets without an owner come last")
+	mas.AssertExpectations(t)
+}
+
+func TestDocsStale_NothingStale(t *testing.T) {
+	mas := new(MockAssetService)
+	mas.On("FindStaleDocumentation", assetsdomain.FreshnessPolicy{}).Return([]assetsdomain.StaleDocumentation{}, nil)
+	app := NewApp(mas, new(MockTaskService), new(MockSprintService))
+
+	output, err := captureOutput(func() error {
+		os.Args = []string{"assetcap", "assets", "docs", "stale", "--notify", "http://127.0.0.1:1/unreachable"}
+		return app.Run()
+	})
+	require.NoError(t, err, "no reminder is sent when nothing is stale")
+	assert.Equal(t, "All asset documentation is within the freshness policy\n", output)
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// defaultUnmappedDays is how far back unmapped epics are looked for
+const defaultUnmappedDays = 90
+
+// mapCommand returns the command managing the epic to asset mappings the tasks of mapped
+// epics inherit their asset from
+func (a *App) mapCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "map",
+		Usage: "Map epics to assets so the tasks of an epic inherit its asset on fetch and classify",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "epic",
+				Usage:     "Map an epic to an asset, or remove its mapping with --clear",
+				ArgsUsage: "EPIC-KEY",
+				Action: func(ctx *cli.Context) error {
+					epicKey, name, clear, err := epicArgs(ctx)
+					if err != nil {
+						return err
+					}
+					if clear {
+						if err := a.taskService.UnmapEpic(ctx.Context, epicKey); err != nil {
+							return err
+						}
+						fmt.Printf("Removed the asset mapping of %s\n", strings.ToUpper(epicKey))
+						return nil
+					}
+
+					if name == "" {
+						return fmt.Errorf("--asset is required unless --clear is given")
+					}
+					asset, err := a.assetService.GetAsset(name)
+					if err != nil {
+						return fmt.Errorf("asset not found: %s", name)
+					}
+					mapping, err := a.taskService.MapEpic(ctx.Context, epicKey, asset.Name, domain.AssetLabel(asset.TaskLinkKey()))
+					if err != nil {
+						return err
+					}
+					fmt.Printf("Mapped %s to %s (%s); fetch or classify its tasks to apply it\n", mapping.EpicKey, mapping.Asset, mapping.Label)
+					return nil
+				},
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "asset",
+						Aliases: []string{"a"},
+						Usage:   "Name of the asset the epic's tasks belong to",
+					},
+					&cli.BoolFlag{
+						Name:  "clear",
+						Usage: "Remove the epic's mapping",
+					},
+				},
+			},
+			{
+				Name:  "list",
+				Usage: "List the epic mappings",
+				Action: func(ctx *cli.Context) error {
+					mappings, err := a.taskService.ListEpicMappings(ctx.Context)
+					if err != nil {
+						return err
+					}
+					if len(mappings) == 0 {
+						fmt.Println("No epic mappings")
+						return nil
+					}
+					for _, mapping := range mappings {
+						fmt.Printf("%s: %s (%s)\n", mapping.EpicKey, mapping.Asset, mapping.Label)
+					}
+					return nil
+				},
+			},
+			{
+				Name:  "unmapped",
+				Usage: "List the unmapped epics of recently updated tasks that are linked to no asset",
+				Action: func(ctx *cli.Context) error {
+					days := ctx.Int("days")
+					if days <= 0 {
+						return fmt.Errorf("--days must be positive")
+					}
+					epics, err := a.taskService.UnmappedEpics(ctx.Context, time.Now().AddDate(0, 0, -days))
+					if err != nil {
+						return err
+					}
+					if len(epics) == 0 {
+						fmt.Printf("No unmapped epics in the tasks updated in the last %d days\n", days)
+						return nil
+					}
+					return printUnmappedEpics(os.Stdout, epics)
+				},
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:  "days",
+						Usage: "Only consider the tasks updated in this many days",
+						Value: defaultUnmappedDays,
+					},
+				},
+			},
+		},
+	}
+}
+
+// epicArgs returns the epic key of map epic with its asset and clear flags. The CLI stops
+// parsing flags at the epic key, so the flags following it are parsed here.
+func epicArgs(ctx *cli.Context) (epicKey, asset string, clear bool, err error) {
+	set := flag.NewFlagSet("epic", flag.ContinueOnError)
+	set.SetOutput(io.Discard)
+	set.StringVar(&asset, "asset", ctx.String("asset"), "")
+	set.StringVar(&asset, "a", ctx.String("asset"), "")
+	set.BoolVar(&clear, "clear", ctx.Bool("clear"), "")
+	if err := set.Parse(ctx.Args().Tail()); err != nil {
+		return "", "", false, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	epicKey = ctx.Args().First()
+	if epicKey == "" || set.NArg() > 0 {
+		return "", "", false, fmt.Errorf("expected exactly one epic key, e.g. assetcap map epic FN-100 --asset booking")
+	}
+	return epicKey, asset, clear, nil
+}
+
+// printUnmappedEpics writes one row per unmapped epic with its unlinked tasks and sprints
+func printUnmappedEpics(w io.Writer, epics []domain.UnmappedEpic) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "EPIC\tUNLINKED TASKS\tSPRINTS")
+	for _, epic := range epics {
+		sprints := strings.Join(epic.Sprints, ", ")
+		if sprints == "" {
+			sprints = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", epic.EpicKey, epic.Tasks, sprints)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+func TestPrintUnmappedEpics(t *testing.T) {
+	var buf bytes.Buffer
+	err := printUnmappedEpics(&buf, []domain.UnmappedEpic{
+		{EpicKey: "FN-200", Tasks: 12, Sprints: []string{"Sprint 1", "Sprint 2"}},
+		{EpicKey: "FN-3000", Tasks: 1},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, ""+
+		"EPIC     UNLINKED TASKS  SPRINTS\n"+
+		"FN-200   12              Sprint 1, Sprint 2\n"+
+		"FN-3000  1               -\n",
+		buf.String())
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sink"
+	sprintusecase "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/application/usecase"
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+// erpReportCommand returns the command exporting the capitalized amounts of a sprint as journal
+// lines an ERP such as SAP or NetSuite imports
+func (a *App) erpReportCommand() *cli.Command {
+	return &cli.Command{
+		Name:   "erp",
+		Usage:  "Export the capitalized amounts per cost center, GL account and asset in the configured ERP layout",
+		Action: a.withSnapshot(a.erpReport),
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:     "project",
+				Aliases:  []string{"p"},
+				Usage:    "Project key (repeatable)",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "sprint",
+				Aliases:  []string{"s"},
+				Usage:    "Sprint name or ID",
+				Required: true,
+			},
+			&cli.Float64Flag{
+				Name:     "hourly-rate",
+				Usage:    "Cost of an hour the capitalized hours are valued at",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: fmt.Sprintf("File format (%s or %s); defaults to the configured erp.format", sprintdomain.ERPFormatCSV, sprintdomain.ERPFormatFixedWidth),
+			},
+			outFlag(),
+		},
+	}
+}
+
+// erpReport allocates the sprint, sums the capitalized hours per team, cost center, GL account
+// and asset, and writes them in the ERP layout
+func (a *App) erpReport(ctx *cli.Context) error {
+	rate := ctx.Float64("hourly-rate")
+	if rate <= 0 {
+		return fmt.Errorf("hourly rate must be positive")
+	}
+	layout := a.erpLayout
+	if len(layout.Columns) == 0 {
+		layout = sprintdomain.DefaultERPLayout()
+	}
+	if format := ctx.String("format"); format != "" {
+		layout.Format = sprintdomain.ERPFormat(format)
+	}
+
+	assets, err := a.assetService.ListAssets()
+	if err != nil {
+		return fmt.Errorf("failed to load assets: %w", err)
+	}
+	splits, err := a.workTypeSplits(ctx.Context)
+	if err != nil {
+		return err
+	}
+	report, err := a.sprintService.BuildCapitalizationReport(sprintdomain.CapitalizationReportInput{
+		Projects:       ctx.StringSlice("project"),
+		Sprint:         ctx.String("sprint"),
+		Impairments:    assetImpairments(assets),
+		Policy:         assetPolicy(assets),
+		AssetAccounts:  a.assetAccounts(assets),
+		AssetStatuses:  assetStatuses(assets),
+		Retirements:    assetRetirements(assets),
+		WorkTypeSplits: splits,
+		Heuristics:     a.heuristics,
+		Export:         a.jiraExport,
+	})
+	if err != nil {
+		return err
+	}
+
+	lines := sprintdomain.ERPJournal(*report, rate)
+	if unbooked := sprintdomain.UnbookedAssets(lines); len(unbooked) > 0 {
+		for i, asset := range unbooked {
+			if asset == "" {
+				unbooked[i] = "(no asset)"
+			}
+		}
+		fmt.Fprintf(os.Stderr, "Warning: no cost center or GL account for %s; set them with 'assets set-accounting' or the erp configuration\n", strings.Join(unbooked, ", "))
+	}
+	result, err := sprintusecase.RenderERPJournal(lines, layout)
+	if err != nil {
+		return err
+	}
+	contentType := sink.ContentTypeCSV
+	if layout.Format == sprintdomain.ERPFormatFixedWidth {
+		contentType = sink.ContentTypeText
+	}
+	return a.writeOutput(ctx, outputReportERP, result, contentType)
+}
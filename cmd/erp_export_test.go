@@ -0,0 +1,75 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/config"
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	tasksdomain "github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+func TestReportERP(t *testing.T) {
+	assets := new(MockAssetService)
+	assets.On("ListAssets").Return([]*assetsdomain.Asset{{Name: "cap-asset-checkout", CostCenter: "CC-100", GLAccount: "1710"}}, nil)
+	tasks := new(MockTaskService)
+	tasks.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+	sprints := new(MockSprintService)
+	sprints.On("BuildCapitalizationReport", mock.MatchedBy(func(input sprintdomain.CapitalizationReportInput) bool {
+		return input.Sprint == "Sprint 2" && input.AssetAccounts["cap-asset-checkout"].CostCenter == "CC-100"
+	})).Return(&sprintdomain.CapitalizationReport{
+		KPIs: sprintdomain.CapitalizationKPIs{Period: "Sprint 2"},
+		Rows: []sprintdomain.ReportRow{
+			{Team: "TEAMA", IssueAllocation: sprintdomain.IssueAllocation{AssetName: "cap-asset-checkout", WorkType: sprintdomain.WorkTypeDevelopment, Hours: 30, CostCenter: "CC-100", GLAccount: "1710"}},
+			{Team: "TEAMA", IssueAllocation: sprintdomain.IssueAllocation{AssetName: "cap-asset-checkout", WorkType: sprintdomain.WorkTypeMaintenance, Hours: 10, CostCenter: "CC-100", GLAccount: "1710"}},
+		},
+	}, nil)
+	app := NewApp(assets, tasks, sprints)
+
+	output, err := captureOutput(func() error {
+		os.Args = []string{"assetcap", "report", "erp", "--project", "TEAMA", "--sprint", "Sprint 2", "--hourly-rate", "75"}
+		return app.Run()
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "period,costCenter,glAccount,assetName,hours,amount\nSprint 2,CC-100,1710,cap-asset-checkout,30.00,2250.00\n", output)
+
+	app.erpLayout, err = erpLayout(config.ERPConfig{
+		Format:  "fixed-width",
+		Columns: []config.ERPColumnConfig{{Field: "costCenter", Width: 8}, {Field: "glAccount", Width: 6}, {Field: "amount", Width: 10}},
+	})
+	require.NoError(t, err)
+	output, err = captureOutput(func() error {
+		os.Args = []string{"assetcap", "report", "erp", "--project", "TEAMA", "--sprint", "Sprint 2", "--hourly-rate", "75"}
+		return app.Run()
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "CC-100  1710     2250.00\n", output)
+
+	_, err = captureOutput(func() error {
+		os.Args = []string{"assetcap", "report", "erp", "--project", "TEAMA", "--sprint", "Sprint 2", "--hourly-rate", "0"}
+		return app.Run()
+	})
+	assert.EqualError(t, err, "hourly rate must be positive")
+}
+
+func TestERPLayout(t *testing.T) {
+	layout, err := erpLayout(config.ERPConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, sprintdomain.DefaultERPLayout(), layout)
+
+	layout, err = erpLayout(config.ERPConfig{Delimiter: ";", Columns: []config.ERPColumnConfig{{Field: "glAccount"}, {Field: "amount"}}})
+	require.NoError(t, err)
+	assert.Equal(t, ';', layout.Delimiter)
+	assert.Equal(t, []sprintdomain.ERPColumn{{Field: "glAccount"}, {Field: "amount"}}, layout.Columns)
+
+	_, err = erpLayout(config.ERPConfig{Format: "fixed-width", Columns: []config.ERPColumnConfig{{Field: "amount"}}})
+	assert.EqualError(t, err, "invalid erp layout: ERP field amount needs a width in the fixed-width format")
+
+	_, err = erpLayout(config.ERPConfig{Columns: []config.ERPColumnConfig{{Field: "vendor"}}})
+	assert.EqualError(t, err, `invalid erp layout: unknown ERP field "vendor": use one of period, team, costCenter, glAccount, assetName, hours, amount`)
+}
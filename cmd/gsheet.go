@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sink"
+)
+
+// snapshotMetadataKey is the developer metadata key of the tab holding when the snapshot its
+// rows were read from was taken
+const snapshotMetadataKey = "assetcap.dataSnapshot"
+
+// writeSheet writes the allocation CSV to the tab of the allocated period in a Google
+// spreadsheet, replacing its rows unless --gsheet-append is set. The snapshot time goes to the
+// tab's developer metadata, leaving the CSV header on the first row.
+func (a *App) writeSheet(ctx *cli.Context, spreadsheetID, tab string, delimiter rune, output string) error {
+	sheet := sink.NewGSheet(spreadsheetID, tab, delimiter, ctx.Bool("gsheet-append"))
+	if a.snapshot != nil {
+		sheet.SetMetadata(snapshotMetadataKey, snapshotStamp(a.snapshot.TakenAt))
+	}
+	if err := sheet.Write(ctx.Context, []byte(output), sink.ContentTypeCSV); err != nil {
+		return fmt.Errorf("failed to write output to %s: %w", sheet, err)
+	}
+	fmt.Fprintf(os.Stderr, "Wrote %s output to %s\n", outputSprintAllocate, sheet)
+	return nil
+}
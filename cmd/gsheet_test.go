@@ -0,0 +1,41 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	tasksdomain "github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+func TestSprintAllocate_ToGSheet(t *testing.T) {
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "")
+	assetService := new(MockAssetService)
+	assetService.On("ListAssets").Return([]*assetsdomain.Asset{}, nil)
+	taskService := new(MockTaskService)
+	taskService.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+	sprintService := new(MockSprintService)
+	sprintService.On("ProcessJiraIssues", sprintdomain.AllocationInput{Project: "FN", Sprint: "Sprint 6", Delimiter: ','}).Return("issueKey,hours\nFN-1,8\n", nil)
+	app := NewApp(assetService, taskService, sprintService)
+
+	_, err := captureOutput(func() error {
+		os.Args = []string{"assetcap", "sprint", "allocate", "--project", "FN", "--sprint", "Sprint 6", "--to-gsheet", "sheet-1"}
+		return app.Run()
+	})
+	assert.ErrorContains(t, err, `failed to write output to Google spreadsheet sheet-1, tab "Sprint 6": GOOGLE_APPLICATION_CREDENTIALS must name a service account key`)
+
+	for _, args := range [][]string{
+		{"assetcap", "--output", "json", "sprint", "allocate", "--project", "FN", "--sprint", "Sprint 6", "--to-gsheet", "sheet-1"},
+		{"assetcap", "sprint", "allocate", "--project", "FN", "--sprint", "Sprint 6", "--to-gsheet", "sheet-1", "--out", "allocation.csv"},
+	} {
+		_, err := captureOutput(func() error {
+			os.Args = args
+			return app.Run()
+		})
+		assert.ErrorContains(t, err, "cannot be combined with --out or --output json")
+	}
+}
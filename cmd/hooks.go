@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/config"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/events"
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+// newEventBus subscribes the configured hooks to the events they run on. Without hooks it
+// returns nil, which drops every event.
+func newEventBus(hooks []config.HookConfig) *events.Bus {
+	if len(hooks) == 0 {
+		return nil
+	}
+	bus := events.NewBus()
+	for _, hook := range hooks {
+		timeout := time.Duration(hook.TimeoutSeconds) * time.Second
+		if hook.Webhook != "" {
+			bus.Subscribe(events.Name(hook.Event), events.WebhookHook(hook.Webhook, hook.Headers, timeout))
+			continue
+		}
+		bus.Subscribe(events.Name(hook.Event), events.CommandHook(hook.Command, timeout, nil))
+	}
+	return bus
+}
+
+// publishSynced publishes an event for every asset synced from Confluence
+func (a *App) publishSynced(ctx context.Context, assets []*assetsdomain.Asset) {
+	for _, asset := range assets {
+		a.events.Publish(ctx, events.AssetSynced, map[string]any{
+			"id":       asset.ID,
+			"name":     asset.Name,
+			"status":   asset.Status,
+			"platform": asset.Platform,
+			"docLink":  asset.DocLink,
+		})
+	}
+}
+
+// publishClassified publishes that the tasks of a sprint were classified and, with --apply,
+// that their labels were written back to Jira
+func (a *App) publishClassified(ctx context.Context, project, sprint string, apply bool) {
+	data := map[string]any{"project": project, "sprint": sprint, "applied": apply}
+	a.events.Publish(ctx, events.TasksClassified, data)
+	if apply {
+		a.events.Publish(ctx, events.LabelsApplied, map[string]any{"project": project, "sprint": sprint})
+	}
+}
+
+// publishAllocated publishes that the allocation of a sprint or fix version was written
+func (a *App) publishAllocated(ctx context.Context, input sprintdomain.AllocationInput) {
+	a.events.Publish(ctx, events.AllocationGenerated, map[string]any{
+		"project":    input.Project,
+		"sprint":     input.Sprint,
+		"fixVersion": input.FixVersion,
+		"method":     string(input.Method),
+	})
+}
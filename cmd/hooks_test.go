@@ -0,0 +1,48 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/config"
+)
+
+func TestNewEventBus_WithoutHooks(t *testing.T) {
+	assert.Nil(t, newEventBus(nil), "without hooks events are dropped")
+}
+
+func TestTasksClassify_RunsHooks(t *testing.T) {
+	log := filepath.Join(t.TempDir(), "events.log")
+	assetService := new(MockAssetService)
+	assetService.On("ListAssets").Return([]*assetsdomain.Asset{}, nil)
+	taskService := new(MockTaskService)
+	taskService.On("ClassifyTasks", mock.Anything, mock.Anything).Return(nil)
+	app := NewApp(assetService, taskService, new(MockSprintService))
+	app.events = newEventBus([]config.HookConfig{
+		{Event: "TasksClassified", Command: `echo "$ASSETCAP_EVENT" >> ` + log},
+		{Event: "*", Command: `grep -q '"project":"FN"' && echo "any $ASSETCAP_EVENT" >> ` + log},
+	})
+
+	_, err := captureOutput(func() error {
+		os.Args = []string{"assetcap", "tasks", "classify", "--project", "FN", "--sprint", "Penguins", "--platform", "jira", "--apply"}
+		return app.Run()
+	})
+	require.NoError(t, err)
+	logged, err := os.ReadFile(log)
+	require.NoError(t, err)
+	assert.Equal(t, "TasksClassified\nany TasksClassified\nany LabelsApplied\n", string(logged))
+
+	require.NoError(t, os.Remove(log))
+	_, err = captureOutput(func() error {
+		os.Args = []string{"assetcap", "tasks", "classify", "--project", "FN", "--sprint", "Penguins", "--platform", "jira", "--dry-run"}
+		return app.Run()
+	})
+	require.NoError(t, err)
+	assert.NoFileExists(t, log, "a dry run publishes nothing")
+}
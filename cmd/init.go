@@ -0,0 +1,355 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/config"
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	sprintinfra "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/infrastructure"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// defaultAssetLabel is the Confluence label suggested for asset pages
+const defaultAssetLabel = "cap-asset"
+
+// initAnswers are the settings collected by assetcap init
+type initAnswers struct {
+	JiraURL string
+	Email   string
+	Token   string
+	// Space and Label locate the asset pages in Confluence; Space is optional
+	Space   string
+	Label   string
+	Project string
+	Members []string
+}
+
+// connectivityCheck is the outcome of reaching one service with the collected credentials
+type connectivityCheck struct {
+	Service string
+	Err     error
+}
+
+// initWizard asks for the settings of a first run and writes the files assetcap needs
+type initWizard struct {
+	in  *bufio.Reader
+	dir string
+	// teamsPath is where the teams skeleton is written
+	teamsPath string
+	force     bool
+	verify    func(ctx context.Context, answers initAnswers) []connectivityCheck
+}
+
+// initCommand returns the command running the onboarding wizard. It does not depend on the
+// application services, so it also runs before Jira is configured.
+func initCommand(stdin io.Reader) *cli.Command {
+	return &cli.Command{
+		Name:  "init",
+		Usage: "Set up .assetcap interactively: Jira and Confluence access, config, teams and first commands",
+		Action: func(ctx *cli.Context) error {
+			if stdin == nil {
+				stdin = os.Stdin
+			}
+			wizard := &initWizard{
+				in:        bufio.NewReader(stdin),
+				dir:       filepath.Dir(config.DefaultPath),
+				teamsPath: filepath.Join(filepath.Dir(config.DefaultPath), teamsFile),
+				force:     ctx.Bool("force"),
+				verify: func(ctx context.Context, answers initAnswers) []connectivityCheck {
+					return checkConnectivity(ctx, &http.Client{Timeout: 15 * time.Second}, answers)
+				},
+			}
+			if ctx.Bool("skip-verify") {
+				wizard.verify = nil
+			}
+			return wizard.run(ctx.Context, ctx.String("project"))
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "project",
+				Usage: "Jira project key to set up (asked for when omitted)",
+			},
+			&cli.BoolFlag{
+				Name:  "force",
+				Usage: "Overwrite an existing config.json and teams.json",
+			},
+			&cli.BoolFlag{
+				Name:  "skip-verify",
+				Usage: "Do not check the connection to Jira and Confluence",
+			},
+		},
+	}
+}
+
+// run collects the answers, checks them against Jira and Confluence, writes the files and
+// prints the first commands to run
+func (w *initWizard) run(ctx context.Context, project string) error {
+	fmt.Printf("This wizard sets up assetcap in %s. Press Enter to keep the value in brackets.\n\n", w.dir)
+	answers, err := w.ask(project)
+	if err != nil {
+		return err
+	}
+
+	if w.verify != nil {
+		fmt.Println("\nChecking connectivity...")
+		failed := false
+		for _, check := range w.verify(ctx, answers) {
+			if check.Err != nil {
+				failed = true
+				fmt.Printf("  %s: failed: %v\n", check.Service, check.Err)
+				continue
+			}
+			fmt.Printf("  %s: OK\n", check.Service)
+		}
+		if failed {
+			save, err := w.yesNo("Save the settings anyway?")
+			if err != nil {
+				return err
+			}
+			if !save {
+				return errors.New("setup cancelled: fix the settings above and run assetcap init again")
+			}
+		}
+	}
+
+	fmt.Println()
+	if err := w.write(answers); err != nil {
+		return err
+	}
+	printFirstCommands(answers)
+	return nil
+}
+
+// ask prompts for each setting, offering the current environment as defaults
+func (w *initWizard) ask(project string) (initAnswers, error) {
+	var answers initAnswers
+	var err error
+	if answers.JiraURL, err = w.prompt("Jira site URL (e.g. https://your-domain.atlassian.net)", os.Getenv("JIRA_BASE_URL"), true); err != nil {
+		return answers, err
+	}
+	answers.JiraURL = strings.TrimSuffix(answers.JiraURL, "/")
+	if parsed, err := url.Parse(answers.JiraURL); err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return answers, fmt.Errorf("invalid Jira site URL %q: must be an http or https URL", answers.JiraURL)
+	}
+	if answers.Email, err = w.prompt("Jira account email", os.Getenv("JIRA_EMAIL"), true); err != nil {
+		return answers, err
+	}
+	if answers.Token, err = w.secret("Jira API token (create one at https://id.atlassian.com/manage-profile/security/api-tokens)", os.Getenv("JIRA_TOKEN")); err != nil {
+		return answers, err
+	}
+	if answers.Space, err = w.prompt("Confluence space key of the asset pages (optional)", "", false); err != nil {
+		return answers, err
+	}
+	if answers.Space != "" {
+		if answers.Label, err = w.prompt("Confluence label of the asset pages", defaultAssetLabel, true); err != nil {
+			return answers, err
+		}
+	}
+	if answers.Project, err = w.prompt("Jira project key (e.g. FN)", project, true); err != nil {
+		return answers, err
+	}
+	answers.Project = strings.ToUpper(answers.Project)
+	members, err := w.prompt("Team members of "+answers.Project+", comma separated (optional)", "", false)
+	if err != nil {
+		return answers, err
+	}
+	for _, member := range strings.Split(members, ",") {
+		if member = strings.TrimSpace(member); member != "" {
+			answers.Members = append(answers.Members, member)
+		}
+	}
+	return answers, nil
+}
+
+// prompt asks for a value, returning the default on an empty answer. Required values are
+// asked for again until one is given.
+func (w *initWizard) prompt(question, fallback string, required bool) (string, error) {
+	for {
+		if fallback != "" {
+			fmt.Printf("%s [%s]: ", question, fallback)
+		} else {
+			fmt.Printf("%s: ", question)
+		}
+		answer, err := w.readLine()
+		if err != nil {
+			return "", err
+		}
+		if answer == "" {
+			answer = fallback
+		}
+		if answer != "" || !required {
+			return answer, nil
+		}
+		fmt.Println("A value is required.")
+	}
+}
+
+// secret asks for a credential without showing the current one
+func (w *initWizard) secret(question, current string) (string, error) {
+	for {
+		if current != "" {
+			fmt.Printf("%s [keep current]: ", question)
+		} else {
+			fmt.Printf("%s: ", question)
+		}
+		answer, err := w.readLine()
+		if err != nil {
+			return "", err
+		}
+		if answer == "" {
+			answer = current
+		}
+		if answer != "" {
+			return answer, nil
+		}
+		fmt.Println("A value is required.")
+	}
+}
+
+// yesNo asks a yes/no question; anything but y or yes is no
+func (w *initWizard) yesNo(question string) (bool, error) {
+	fmt.Printf("%s [y/N]: ", question)
+	answer, err := w.readLine()
+	if err != nil {
+		return false, err
+	}
+	answer = strings.ToLower(answer)
+	return answer == "y" || answer == "yes", nil
+}
+
+// readLine reads one answer, failing when the input ends before it
+func (w *initWizard) readLine() (string, error) {
+	line, err := w.in.ReadString('\n')
+	if err == io.EOF && line != "" {
+		err = nil
+	}
+	if err == io.EOF {
+		return "", errors.New("setup cancelled: no more input")
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read answer: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// write creates the storage directory with the credentials, the config with the policy
+// defaults and the teams skeleton, keeping an existing config and teams file unless forced
+func (w *initWizard) write(answers initAnswers) error {
+	credentials := filepath.Join(w.dir, filepath.Base(config.DefaultCredentialsPath))
+	if err := config.SaveCredentials(credentials, map[string]string{
+		"JIRA_BASE_URL": answers.JiraURL,
+		"JIRA_EMAIL":    answers.Email,
+		"JIRA_TOKEN":    answers.Token,
+	}); err != nil {
+		return err
+	}
+	fmt.Printf("Wrote %s (readable by you only)\n", credentials)
+
+	configPath := filepath.Join(w.dir, filepath.Base(config.DefaultPath))
+	if w.keep(configPath) {
+		fmt.Printf("Kept the existing %s (use --force to overwrite)\n", configPath)
+	} else {
+		if err := config.Save(configPath, initConfig(w.dir)); err != nil {
+			return err
+		}
+		fmt.Printf("Wrote %s\n", configPath)
+	}
+
+	if w.keep(w.teamsPath) {
+		fmt.Printf("Kept the existing %s (use --force to overwrite)\n", w.teamsPath)
+		return nil
+	}
+	teams := sprintdomain.TeamMap{}
+	if err := teams.AddTeam(answers.Project, answers.Members); err != nil {
+		return err
+	}
+	if err := sprintinfra.NewJSONTeamsRepository(w.teamsPath).Save(teams); err != nil {
+		return err
+	}
+	fmt.Printf("Wrote %s\n", w.teamsPath)
+	return nil
+}
+
+// keep reports whether an existing file is left alone
+func (w *initWizard) keep(path string) bool {
+	if w.force {
+		return false
+	}
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// initConfig returns the default configuration with the label policy spelled out: only the
+// work type labels are managed and asset labels are never removed
+func initConfig(dir string) config.Config {
+	cfg := config.Default()
+	cfg.Storage.Directory = dir
+	for _, workType := range domain.ReportWorkTypes {
+		cfg.Jira.ManagedLabels = append(cfg.Jira.ManagedLabels, string(workType))
+	}
+	cfg.Jira.ProtectedLabels = []string{"cap-asset-*"}
+	return cfg
+}
+
+// checkConnectivity checks the credentials against the Jira user API and the Confluence space API
+func checkConnectivity(ctx context.Context, client *http.Client, answers initAnswers) []connectivityCheck {
+	confluence := answers.JiraURL + "/wiki/rest/api/space?limit=1"
+	if answers.Space != "" {
+		confluence = answers.JiraURL + "/wiki/rest/api/space/" + url.PathEscape(answers.Space)
+	}
+	return []connectivityCheck{
+		{Service: "Jira", Err: getWithCredentials(ctx, client, answers.JiraURL+"/rest/api/3/myself", answers)},
+		{Service: "Confluence", Err: getWithCredentials(ctx, client, confluence, answers)},
+	}
+}
+
+// getWithCredentials requests a URL with the collected credentials, failing on any status but 200
+func getWithCredentials(ctx context.Context, client *http.Client, target string, answers initAnswers) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.SetBasicAuth(answers.Email, answers.Token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("the email and API token were rejected (status %d)", resp.StatusCode)
+	case http.StatusNotFound:
+		return fmt.Errorf("not found (status 404); check the site URL and space key")
+	default:
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+}
+
+// printFirstCommands prints the commands that take a new project from setup to its first allocation
+func printFirstCommands(answers initAnswers) {
+	fmt.Printf("\nYou're set up. Suggested first commands for %s:\n", answers.Project)
+	if answers.Space != "" {
+		fmt.Printf("  assetcap assets sync --space %s --label %s\n", answers.Space, answers.Label)
+	}
+	fmt.Printf("  assetcap tasks fetch --project %s --sprint \"<sprint>\" --platform jira\n", answers.Project)
+	fmt.Printf("  assetcap tasks classify --project %s --sprint \"<sprint>\" --platform jira --dry-run\n", answers.Project)
+	fmt.Printf("  assetcap sprint allocate --project %s --sprint \"<sprint>\"\n", answers.Project)
+	fmt.Println("  assetcap validate-config")
+}
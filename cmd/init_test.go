@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/config"
+)
+
+// newTestWizard returns a wizard answering from input and writing to a temporary directory
+func newTestWizard(t *testing.T, input string, checks ...connectivityCheck) *initWizard {
+	t.Setenv("JIRA_BASE_URL", "")
+	t.Setenv("JIRA_EMAIL", "")
+	t.Setenv("JIRA_TOKEN", "")
+	dir := t.TempDir()
+	return &initWizard{
+		in:        bufio.NewReader(strings.NewReader(input)),
+		dir:       filepath.Join(dir, ".assetcap"),
+		teamsPath: filepath.Join(dir, "teams.json"),
+		verify: func(context.Context, initAnswers) []connectivityCheck {
+			return checks
+		},
+	}
+}
+
+func TestInitWizard_Run(t *testing.T) {
+	input := strings.Join([]string{
+		"https://acme.atlassian.net/",
+		"jane@acme.com",
+		"token-123",
+		"CAP",
+		"", // default label
+		"fn",
+		"Jane Doe, John Roe",
+	}, "\n") + "\n"
+	wizard := newTestWizard(t, input, connectivityCheck{Service: "Jira"}, connectivityCheck{Service: "Confluence"})
+
+	output, err := captureOutput(func() error {
+		return wizard.run(context.Background(), "")
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, output, "  Jira: OK\n  Confluence: OK\n")
+	assert.Contains(t, output, "assetcap assets sync --space CAP --label cap-asset\n")
+	assert.Contains(t, output, `assetcap tasks fetch --project FN --sprint "<sprint>" --platform jira`)
+	assert.Contains(t, output, `assetcap sprint allocate --project FN --sprint "<sprint>"`)
+
+	credentials, err := os.ReadFile(filepath.Join(wizard.dir, "credentials.env"))
+	require.NoError(t, err)
+	assert.Contains(t, string(credentials), "export JIRA_BASE_URL='https://acme.atlassian.net'\n")
+	assert.Contains(t, string(credentials), "export JIRA_TOKEN='token-123'\n")
+
+	cfg, err := config.Load(filepath.Join(wizard.dir, "config.json"))
+	require.NoError(t, err)
+	assert.Equal(t, wizard.dir, cfg.Storage.Directory)
+	assert.Equal(t, []string{"cap-development", "cap-maintenance", "cap-discovery"}, cfg.Jira.ManagedLabels)
+	assert.Equal(t, []string{"cap-asset-*"}, cfg.Jira.ProtectedLabels)
+
+	teams, err := os.ReadFile(wizard.teamsPath)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"FN": {"team": ["Jane Doe", "John Roe"]}}`, string(teams))
+}
+
+func TestInitWizard_KeepsExistingFiles(t *testing.T) {
+	wizard := newTestWizard(t, "https://acme.atlassian.net\njane@acme.com\ntoken\n\n\n\n")
+	wizard.verify = nil
+	require.NoError(t, os.MkdirAll(wizard.dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(wizard.dir, "config.json"), []byte(`{"classifier": "random"}`), 0644))
+	require.NoError(t, os.WriteFile(wizard.teamsPath, []byte(`{"OPS": {"team": ["Carol"]}}`), 0644))
+
+	output, err := captureOutput(func() error {
+		return wizard.run(context.Background(), "FN")
+	})
+	require.NoError(t, err)
+	assert.Contains(t, output, "Kept the existing "+wizard.teamsPath)
+	assert.NotContains(t, output, "assets sync", "no Confluence space was given")
+
+	teams, err := os.ReadFile(wizard.teamsPath)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"OPS": {"team": ["Carol"]}}`, string(teams))
+
+	wizard = newTestWizard(t, "https://acme.atlassian.net\njane@acme.com\ntoken\n\n\n\n")
+	wizard.verify = nil
+	wizard.force = true
+	require.NoError(t, os.WriteFile(wizard.teamsPath, []byte(`{"OPS": {"team": ["Carol"]}}`), 0644))
+	_, err = captureOutput(func() error {
+		return wizard.run(context.Background(), "FN")
+	})
+	require.NoError(t, err)
+	teams, err = os.ReadFile(wizard.teamsPath)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"FN": {"team": []}}`, string(teams))
+}
+
+func TestInitWizard_ConnectivityFailure(t *testing.T) {
+	answers := "https://acme.atlassian.net\njane@acme.com\ntoken\n\nFN\n\n"
+	failed := connectivityCheck{Service: "Jira", Err: errors.New("the email and API token were rejected (status 401)")}
+
+	wizard := newTestWizard(t, answers+"n\n", failed)
+	output, err := captureOutput(func() error {
+		return wizard.run(context.Background(), "")
+	})
+	assert.EqualError(t, err, "setup cancelled: fix the settings above and run assetcap init again")
+	assert.Contains(t, output, "  Jira: failed: the email and API token were rejected (status 401)\n")
+	assert.NoDirExists(t, wizard.dir)
+
+	wizard = newTestWizard(t, answers+"y\n", failed)
+	_, err = captureOutput(func() error {
+		return wizard.run(context.Background(), "")
+	})
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(wizard.dir, "credentials.env"))
+}
+
+func TestInitWizard_Answers(t *testing.T) {
+	t.Run("required values are asked again and defaults come from the environment", func(t *testing.T) {
+		wizard := newTestWizard(t, "\n\njane@acme.com\n\n\n\n\n")
+		t.Setenv("JIRA_BASE_URL", "https://acme.atlassian.net")
+		t.Setenv("JIRA_TOKEN", "exported-token")
+
+		var answers initAnswers
+		output, err := captureOutput(func() (err error) {
+			answers, err = wizard.ask("FN")
+			return err
+		})
+		require.NoError(t, err)
+		assert.Equal(t, initAnswers{JiraURL: "https://acme.atlassian.net", Email: "jane@acme.com", Token: "exported-token", Project: "FN"}, answers)
+		assert.Contains(t, output, "A value is required.")
+		assert.Contains(t, output, "[keep current]", "the exported token is not shown")
+	})
+
+	t.Run("invalid site URL", func(t *testing.T) {
+		wizard := newTestWizard(t, "acme.atlassian.net\n")
+		_, err := captureOutput(func() error {
+			_, err := wizard.ask("")
+			return err
+		})
+		assert.EqualError(t, err, `invalid Jira site URL "acme.atlassian.net": must be an http or https URL`)
+	})
+
+	t.Run("input ends early", func(t *testing.T) {
+		wizard := newTestWizard(t, "https://acme.atlassian.net\n")
+		_, err := captureOutput(func() error {
+			_, err := wizard.ask("")
+			return err
+		})
+		assert.EqualError(t, err, "setup cancelled: no more input")
+	})
+}
+
+func TestCheckConnectivity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, token, _ := r.BasicAuth()
+		switch {
+		case user != "jane@acme.com" || token != "token":
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/rest/api/3/myself", r.URL.Path == "/wiki/rest/api/space/CAP":
+			w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	checks := checkConnectivity(context.Background(), server.Client(), initAnswers{JiraURL: server.URL, Email: "jane@acme.com", Token: "token", Space: "CAP"})
+	assert.Equal(t, []connectivityCheck{{Service: "Jira"}, {Service: "Confluence"}}, checks)
+
+	checks = checkConnectivity(context.Background(), server.Client(), initAnswers{JiraURL: server.URL, Email: "jane@acme.com", Token: "token", Space: "NOPE"})
+	assert.EqualError(t, checks[1].Err, "not found (status 404); check the site URL and space key")
+
+	checks = checkConnectivity(context.Background(), server.Client(), initAnswers{JiraURL: server.URL, Email: "jane@acme.com", Token: "wrong"})
+	assert.EqualError(t, checks[0].Err, "the email and API token were rejected (status 401)")
+}
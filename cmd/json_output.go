@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/schema"
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	tasksdomain "github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// jsonOutputs maps the commands printing JSON to the value they print, keyed by command path
+var jsonOutputs = map[string]interface{}{
+	"sprint explain":             sprintdomain.AllocationExplanation{},
+	"verify sprint":              sprintdomain.VerificationResult{},
+	"sprint scope":               sprintdomain.SprintScope{},
+	"tasks fetch":                taskBatch{},
+	"tasks classify":             taskBatch{},
+	"tasks coverage":             tasksdomain.LabelCoverage{},
+	"tasks label-history":        tasksdomain.LabelHistory{},
+	"assets diff":                assetsdomain.CatalogDiff{},
+	"assets documentation stale": assetsdomain.StaleDocumentationReport{},
+	"assets list":                assetList{},
+	"assets show":                assetView{},
+	"tasks show":                 taskListing{},
+	"sprint allocate":            allocationList{},
+}
+
+// outputSchema returns the JSON Schema of the JSON output of a command
+func outputSchema(command string) (*schema.Schema, bool) {
+	value, ok := jsonOutputs[command]
+	if !ok {
+		return nil, false
+	}
+	return schema.OutputSchema("assetcap "+command, value), true
+}
+
+// printJSON prints v as JSON tagged with the output schema version
+func printJSON(v interface{}) error {
+	output, err := schema.MarshalOutput(v)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(output))
+	return nil
+}
+
+// addSchemaFlags adds a --schema flag printing the output schema to every command printing JSON.
+// Required flags are checked by the command instead, so --schema works without them.
+func addSchemaFlags(commands []*cli.Command, parent string) {
+	for _, command := range commands {
+		name := strings.TrimSpace(parent + " " + command.Name)
+		if _, ok := jsonOutputs[name]; ok && command.Action != nil {
+			var required []string
+			for _, flag := range command.Flags {
+				if stringFlag, ok := flag.(*cli.StringFlag); ok && stringFlag.Required {
+					stringFlag.Required = false
+					required = append(required, stringFlag.Name)
+				}
+			}
+			command.Flags = append(command.Flags, &cli.BoolFlag{
+				Name:  "schema",
+				Usage: "Print the JSON Schema of the JSON output and exit",
+			})
+			action := command.Action
+			command.Action = func(ctx *cli.Context) error {
+				if ctx.Bool("schema") {
+					s, _ := outputSchema(name)
+					output, err := json.MarshalIndent(s, "", "  ")
+					if err != nil {
+						return fmt.Errorf("failed to encode output schema: %w", err)
+					}
+					fmt.Println(string(output))
+					return nil
+				}
+				for _, flag := range required {
+					if !ctx.IsSet(flag) {
+						return fmt.Errorf("Required flag %q not set", flag)
+					}
+				}
+				return action(ctx)
+			}
+		}
+		addSchemaFlags(command.Subcommands, name)
+	}
+}
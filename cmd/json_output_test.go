@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/schema"
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+// updateSchemasEnv rewrites the output schema snapshots when set, refusing changes the
+// output schema version does not account for
+const updateSchemasEnv = "UPDATE_OUTPUT_SCHEMAS"
+
+// TestOutputSchemas_Snapshots enforces the compatibility policy of the JSON outputs: any change
+// to an output schema needs a new snapshot, additions a minor and breaking changes a major
+// version bump of schema.OutputVersion.
+func TestOutputSchemas_Snapshots(t *testing.T) {
+	for command := range jsonOutputs {
+		t.Run(command, func(t *testing.T) {
+			current, ok := outputSchema(command)
+			require.True(t, ok)
+			data, err := json.MarshalIndent(current, "", "  ")
+			require.NoError(t, err)
+			data = append(data, '\n')
+
+			path := filepath.Join("testdata", "schemas", strings.ReplaceAll(command, " ", "-")+".schema.json")
+			snapshot, err := os.ReadFile(path)
+			if os.IsNotExist(err) && os.Getenv(updateSchemasEnv) != "" {
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+				require.NoError(t, os.WriteFile(path, data, 0o644))
+				return
+			}
+			require.NoError(t, err, "missing snapshot; run %s=1 go test ./cmd", updateSchemasEnv)
+			if string(snapshot) == string(data) {
+				return
+			}
+
+			var previous schema.Schema
+			require.NoError(t, json.Unmarshal(snapshot, &previous))
+			if problem := versionProblem(&previous, current); problem != "" {
+				t.Fatalf("output schema of %q changed: %s", command, problem)
+			}
+			if os.Getenv(updateSchemasEnv) == "" {
+				t.Fatalf("output schema of %q changed; run %s=1 go test ./cmd to update the snapshot", command, updateSchemasEnv)
+			}
+			require.NoError(t, os.WriteFile(path, data, 0o644))
+		})
+	}
+}
+
+// versionProblem explains why the output schema version does not account for the changes from
+// previous to current, if it does not
+func versionProblem(previous, current *schema.Schema) string {
+	previousMajor, previousMinor, _ := strings.Cut(previous.Version(), ".")
+	major, minor, _ := strings.Cut(current.Version(), ".")
+	if breaking := schema.Breaking(previous, current); len(breaking) > 0 {
+		if major == previousMajor {
+			return "breaking changes need a major version bump of schema.OutputVersion: " + strings.Join(breaking, "; ")
+		}
+		return ""
+	}
+	if major == previousMajor && minor == previousMinor {
+		return "compatible changes need a minor version bump of schema.OutputVersion"
+	}
+	return ""
+}
+
+func TestVersionProblem(t *testing.T) {
+	previous := schema.OutputSchema("verify", sprintdomain.VerificationResult{})
+
+	type added struct {
+		sprintdomain.VerificationResult
+		Notes string `json:"notes"`
+	}
+	assert.Contains(t, versionProblem(previous, schema.OutputSchema("verify", added{})), "minor version bump")
+
+	type removed struct {
+		Sprint string `json:"sprint"`
+	}
+	problem := versionProblem(previous, schema.OutputSchema("verify", removed{}))
+	assert.Contains(t, problem, "major version bump")
+	assert.Contains(t, problem, "passed: removed")
+
+	bumped := schema.OutputSchema("verify", removed{})
+	major := "2.0"
+	bumped.Properties[schema.VersionField].Const = &major
+	assert.Empty(t, versionProblem(previous, bumped))
+}
+
+func TestAddSchemaFlags(t *testing.T) {
+	var ran bool
+	commands := []*cli.Command{{
+		Name: "verify",
+		Subcommands: []*cli.Command{{
+			Name:   "sprint",
+			Flags:  []cli.Flag{&cli.StringFlag{Name: "project", Required: true}},
+			Action: func(*cli.Context) error { ran = true; return nil },
+		}},
+	}}
+	addSchemaFlags(commands, "")
+	app := &cli.App{Name: "assetcap", Commands: commands}
+
+	output, err := captureOutput(func() error {
+		return app.Run([]string{"assetcap", "verify", "sprint", "--schema"})
+	})
+	require.NoError(t, err)
+	assert.False(t, ran)
+	var printed schema.Schema
+	require.NoError(t, json.Unmarshal([]byte(output), &printed))
+	assert.Equal(t, "assetcap verify sprint", printed.Title)
+	assert.Equal(t, schema.OutputVersion, printed.Version())
+
+	err = app.Run([]string{"assetcap", "verify", "sprint"})
+	assert.EqualError(t, err, `Required flag "project" not set`)
+	assert.False(t, ran)
+
+	require.NoError(t, app.Run([]string{"assetcap", "verify", "sprint", "--project", "FN"}))
+	assert.True(t, ran)
+}
+
+func TestPrintJSON_Versioned(t *testing.T) {
+	output, err := captureOutput(func() error {
+		return printJSON(sprintdomain.VerificationResult{Project: "FN", Sprint: "S1", Passed: true})
+	})
+	require.NoError(t, err)
+
+	s, _ := outputSchema("verify sprint")
+	require.NoError(t, s.Validate([]byte(output)))
+	assert.True(t, strings.HasPrefix(output, "{\n  \"schemaVersion\": \""+schema.OutputVersion+"\""))
+}
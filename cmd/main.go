@@ -2,33 +2,41 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/urfave/cli/v2"
 
 	assetsapp "github.com/helmedeiros/digital-asset-capitalization/internal/assets/application"
-	assetsinfra "github.com/helmedeiros/digital-asset-capitalization/internal/assets/infrastructure"
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/config"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/dashboard"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/events"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/pipeline"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/redact"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/schema"
 	"github.com/helmedeiros/digital-asset-capitalization/internal/shell/completion"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sink"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/slack"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/snapshot"
 	sprintapp "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/application"
-	sprintinfra "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/infrastructure"
+	sprintusecase "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/application/usecase"
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
 	tasksapp "github.com/helmedeiros/digital-asset-capitalization/internal/tasks/application"
+	tasksusecase "github.com/helmedeiros/digital-asset-capitalization/internal/tasks/application/usecase"
 	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
-	taskports "github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain/ports"
-	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/infrastructure/classifier"
-	cliui "github.com/helmedeiros/digital-asset-capitalization/internal/tasks/infrastructure/cli"
 	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/infrastructure/jira"
-	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/infrastructure/storage"
-)
-
-const (
-	assetsDir  = ".assetcap"
-	assetsFile = "assets.json"
-	tasksDir   = ".assetcap"
-	tasksFile  = "tasks.json"
-	teamsFile  = "teams.json"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/telemetry"
 )
 
 // App holds all the application dependencies
@@ -36,6 +44,48 @@ type App struct {
 	assetService  assetsapp.AssetService
 	taskService   tasksapp.TaskService
 	sprintService sprintapp.SprintService
+	// export holds the configured export locale and percent format, overridden per command
+	// with --locale, --percent-format and --percent-decimals
+	export config.ExportConfig
+	// storageDir is the configured directory of the data files
+	storageDir string
+	// configPath is the configuration file the application was wired from
+	configPath string
+	// outputs routes command output to the configured sinks
+	outputs config.OutputConfig
+	// heuristics fill in the hours of sprint issues the changelog does not track
+	heuristics sprintdomain.AllocationHeuristics
+	// jiraExport is the Jira export sprints are allocated from instead of the Jira API
+	jiraExport string
+	// personWorkTypes are the configured work type overrides of team members, by sprint
+	personWorkTypes map[string]sprintdomain.PersonWorkTypes
+	// labelPolicy guards the labels classification writes back to Jira
+	labelPolicy domain.LabelPolicy
+	// docs is how long asset documentation stays fresh and where reminders go
+	docs config.DocsConfig
+	// coverage is the label coverage the done tasks of each project must reach
+	coverage config.CoverageConfig
+	// signing writes checksums and signatures next to the exported files
+	signing config.SigningConfig
+	// confluence lists the spaces assets sync searches by default
+	confluence config.ConfluenceConfig
+	// defaults are the values the project and platform flags take when omitted
+	defaults config.DefaultsConfig
+	// telemetry records opt-in usage statistics of each command run
+	telemetry *telemetry.Recorder
+	// events runs the configured hooks on what commands did; nil without hooks
+	events *events.Bus
+	// erpLayout is the file layout of report erp; the default csv layout when zero
+	erpLayout sprintdomain.ERPLayout
+	// defaultAccount books the assets without their own cost center or GL account
+	defaultAccount sprintdomain.AssetAccount
+	// stdin answers confirmation prompts; os.Stdin when nil
+	stdin io.Reader
+	// rewire builds the services over another storage directory; reports then read a snapshot
+	// of the storage. Reports read the live storage when nil.
+	rewire func(storageDir string) (*App, error)
+	// snapshot is the storage copy the running report reads, nil outside of reports
+	snapshot *snapshot.Snapshot
 }
 
 // NewApp creates a new App instance with the given dependencies
@@ -56,9 +106,28 @@ func (a *App) Run() error {
 		UsageText: `assetcap [global options] command [command options] [arguments...]
 
 COMMANDS:
+   init                Set up .assetcap interactively for a first run
+   auth                Manage the stored Jira credentials
+     rotate          Replace the stored Jira API token after checking the new one against Jira
+   config              Edit the configuration of the current directory
+     set             Set the value a flag defaults to, e.g. config set project FN
+   status              Show the configuration, Jira site and flag defaults of the current directory
+   teams               Manage the team of each project in teams.json
+     add             Add the team of a project with its members
+     remove          Remove the team of a project
+     list            List the projects and their team members
+     set-members     Replace the members of a project's team
    assets              Manage digital assets
      create           Create a new asset
      list            List all assets
+     activity        Summarize the tasks, hours and work type mix of an asset in a sprint
+     bugfix-window   Count bugs fixed within days of an asset's launch as development
+     set-status      Record a status of an asset taking effect at a date
+     retire          Retire an asset and write its lifetime capitalization summary
+     archive         Mark an asset Archived instead of deleting it
+     set-accounting  Set the cost center and GL account an asset is booked to
+     delete          Move an asset to the trash
+     resolve-duplicate  Choose the primary Confluence page for a duplicated asset label
      documentation   Manage asset documentation
        update        Mark asset documentation as updated
      tasks           Manage asset tasks
@@ -66,12 +135,65 @@ COMMANDS:
        decrement     Decrement task count for an asset
    tasks              Manage tasks from various platforms
      fetch           Fetch tasks from a platform (e.g., Jira)
+     import          Import tasks from a Jira JSON or CSV export
+     sample          Select a reproducible random sample of classified tasks for audit
+     coverage        Report the share of a quarter's done tasks with a work type label and an asset link
+     label-history   Show when the cap-* labels of an issue were added or removed, and by whom
+     note            Leave timestamped notes on tasks (add, list)
    sprint             Manage sprint-related operations
-     allocate        Calculate time allocation for JIRA issues in a sprint
+     allocate        Calculate time allocation for JIRA issues in a sprint or fix version
+     lint            Flag sprint assignees that match no team member or alias
+     scope           Summarize the issues added to and removed from a sprint after it started
+     resolve-accounts Record the Jira account ID of each team member in teams.json
+     absences        Record the days team members were absent (add, import, list)
+     push            Write the sprint allocation back to Jira issues
+     report          Render the sprint allocation with capitalization KPIs
+   report             Export allocation reports for finance processes
+     timesheet       Export per-engineer day by issue timesheets for a sprint
+     estimates       Compare the sprint allocation with the Jira original estimates
+     org             Roll the classified tasks of every team up to an organization summary
+     erp             Export the capitalized amounts per cost center and GL account for the ERP
+   verify             Check closure criteria, exiting non-zero on violations
+     sprint          Verify a sprint is classified, linked to assets and fully allocated
+   pipeline           Run multi-step workflows with checkpoints
+     quarter         Sync, fetch, classify, allocate, verify, report and push a quarter
+
+   dashboard          Publish read-only views of the local data
+     build           Render a static HTML site for stakeholders
+   backstage          Expose the assets in the Backstage developer portal
+     export          Write a catalog-info entity per asset for the Backstage catalog
+   serve              Run long-lived listeners
+     webhooks        Receive Jira issue webhooks and update the local task store
+     slack           Answer Slack slash commands with summaries from the local data
+     mcp             Serve assets, tasks and allocations as MCP tools to LLM agents
+   devtools           Tools for maintainers
+     simulate        Run the allocation engine against a synthetic scenario
+   telemetry          Opt in to or out of anonymous usage statistics
+     enable          Record command names, durations, results and dataset sizes
+     disable         Stop recording and discard unsent events
+     status          Show whether telemetry is enabled and what is buffered
+   workspace          Move the whole .assetcap workspace between machines
+     export          Bundle assets, tasks, teams, allocations and config into an archive
+     import          Restore or merge a workspace archive
+   map                Map epics to assets so their tasks inherit the asset
+     epic            Map an epic to an asset, or remove its mapping
+     list            List the epic mappings
+     unmapped        List the unmapped epics of recent tasks linked to no asset
+   verify-artifact    Check an exported file against its checksum and signature
 
 For more information about a command:
    assetcap [command] --help`,
+		Flags: []cli.Flag{globalOutputFlag()},
 		Commands: []*cli.Command{
+			initCommand(a.stdin),
+			authCommand(a.stdin, a.configPath),
+			configCommand(a.configPath),
+			statusCommand(a.configPath),
+			teamsCommand(a.configPath),
+			a.workspaceCommand(),
+			a.mapCommand(),
+			a.backstageCommand(),
+			a.verifyArtifactCommand(),
 			{
 				Name:  "completion",
 				Usage: "Generate shell completion scripts",
@@ -108,18 +230,102 @@ For more information about a command:
 				Subcommands: []*cli.Command{
 					{
 						Name:  "allocate",
-						Usage: "Calculate time allocation for JIRA issues in a sprint",
-						Action: func(ctx *cli.Context) error {
-							project := ctx.String("project")
-							sprint := ctx.String("sprint")
-							override := ctx.String("override")
-							result, err := a.sprintService.ProcessJiraIssues(project, sprint, override)
+						Usage: "Calculate time allocation for JIRA issues in a sprint or fix version",
+						Action: a.withSnapshot(func(ctx *cli.Context) error {
+							sprint, fixVersion, err := sprintOrFixVersion(ctx)
+							if err != nil {
+								return err
+							}
+							asJSON, err := jsonOutput(ctx)
+							if err != nil {
+								return err
+							}
+							if ctx.String("to-gsheet") != "" && (asJSON || ctx.String("out") != "") {
+								return fmt.Errorf("--to-gsheet writes the CSV rows to the spreadsheet; it cannot be combined with --out or --output json")
+							}
+							delimiter, err := sprintusecase.ParseCSVDelimiter(ctx.String("delimiter"))
+							if err != nil {
+								return err
+							}
+							asOf, err := sprintdomain.ParseLabelSnapshot(ctx.String("as-of"))
+							if err != nil {
+								return err
+							}
+							locale, err := a.exportLocale(ctx)
+							if err != nil {
+								return err
+							}
+							normalization, err := parseNormalization(ctx)
+							if err != nil {
+								return err
+							}
+							summary, err := sprintdomain.ParseAllocationSummary(ctx.String("summary"))
+							if err != nil {
+								return err
+							}
+							input := sprintdomain.AllocationInput{
+								Project:       ctx.String("project"),
+								Sprint:        sprint,
+								FixVersion:    fixVersion,
+								Override:      ctx.String("override"),
+								Delimiter:     delimiter,
+								Normalization: normalization,
+								Summary:       summary,
+								LabelsAsOf:    asOf,
+								Locale:        locale,
+								Heuristics:    a.heuristics,
+								Export:        a.jiraExport,
+								StrictRoster:  ctx.Bool("strict-roster"),
+							}
+							if err := applyAllocationMethod(ctx, &input); err != nil {
+								return err
+							}
+							if input.PersonWorkTypes, err = a.allocationPersonWorkTypes(ctx, input.Sprint+input.FixVersion); err != nil {
+								return err
+							}
+							assets, err := a.assetService.ListAssets()
+							if err != nil {
+								return fmt.Errorf("failed to load asset documentation links: %w", err)
+							}
+							input.AssetDocs = assetDocLinks(assets)
+							input.AssetAccounts = a.assetAccounts(assets)
+							input.Retirements = assetRetirements(assets)
+							if input.WorkTypeSplits, err = a.workTypeSplits(ctx.Context); err != nil {
+								return err
+							}
+							if ctx.Bool("progress") {
+								input.Progress = printAllocationProgress
+							}
+							output, contentType := "", sink.ContentTypeCSV
+							if asJSON {
+								allocations, err := a.sprintService.AllocateIssues(input)
+								if err != nil {
+									return err
+								}
+								data, err := schema.MarshalOutput(allocationList{
+									Project:     input.Project,
+									Sprint:      input.Sprint,
+									FixVersion:  input.FixVersion,
+									Allocations: append([]sprintdomain.IssueAllocation{}, allocations...),
+								})
+								if err != nil {
+									return err
+								}
+								output, contentType = string(data)+"\n", sink.ContentTypeJSON
+							} else if output, err = a.sprintService.ProcessJiraIssues(input); err != nil {
+								return err
+							}
+							if spreadsheet := ctx.String("to-gsheet"); spreadsheet != "" {
+								err = a.writeSheet(ctx, spreadsheet, input.Sprint+input.FixVersion, delimiter, output)
+							} else {
+								err = a.writeOutput(ctx, outputSprintAllocate, output, contentType)
+							}
 							if err != nil {
 								return err
 							}
-							fmt.Print(result)
+							a.publishAllocated(ctx.Context, input)
 							return nil
-						},
+						}),
 						Flags: []cli.Flag{
 							&cli.StringFlag{
 								Name:     "project",
@@ -128,560 +334,3375 @@ For more information about a command:
 								Required: true,
 							},
 							&cli.StringFlag{
-								Name:     "sprint",
-								Aliases:  []string{"s"},
-								Usage:    "Sprint name or ID",
-								Required: true,
+								Name:    "sprint",
+								Aliases: []string{"s"},
+								Usage:   "Sprint name or ID",
+							},
+							&cli.StringFlag{
+								Name:  "fix-version",
+								Usage: "Allocate the issues of a fix version (e.g. 2024.5) within its start and release dates instead of a sprint",
 							},
 							&cli.StringFlag{
 								Name:    "override",
 								Aliases: []string{"o"},
 								Usage:   "Manual percentage adjustments as JSON where key is IssueID and value is amount of working hours being spent (e.g. '{\"ISSUE-1\": 6, \"ISSUE-2\": 36}')",
 							},
+							&cli.StringFlag{
+								Name:  "as-of",
+								Usage: "Classify issues by their labels at a date (YYYY-MM-DD) or at their completion ('completion')",
+							},
+							&cli.StringFlag{
+								Name:  "delimiter",
+								Usage: "CSV field delimiter (e.g. ';' for European Excel locales, '\\t' for tabs)",
+								Value: ",",
+							},
+							&cli.StringFlag{
+								Name:  "locale",
+								Usage: "Format numbers and dates for a locale, e.g. de-DE (defaults to the configured export locale)",
+							},
+							percentFormatFlag(),
+							percentDecimalsFlag(),
+							&cli.StringFlag{
+								Name:  "method",
+								Usage: "Allocation strategy: time (time in progress), storypoints (share of story points), or a weighted blend such as time:0.7,storypoints:0.3",
+								Value: string(sprintdomain.AllocationMethodTime),
+							},
+							&cli.StringFlag{
+								Name:  "points-at",
+								Usage: "Story point estimate used by the storypoints method: start, end or latest",
+								Value: string(sprintdomain.PointsAtLatest),
+							},
+							&cli.BoolFlag{
+								Name:  "progress",
+								Usage: "Report the number of allocated issues on stderr while the CSV is written",
+							},
+							&cli.StringFlag{
+								Name:  "to-gsheet",
+								Usage: "Write the allocation to a tab named after the sprint in this Google spreadsheet ID, as the service account of GOOGLE_APPLICATION_CREDENTIALS",
+							},
+							&cli.BoolFlag{
+								Name:  "gsheet-append",
+								Usage: "Append the rows to the sprint's tab instead of replacing them",
+							},
+							&cli.BoolFlag{
+								Name:  "strict-roster",
+								Usage: "Fail when a team member has no attributed hours instead of warning about it",
+							},
+							&cli.StringFlag{
+								Name:  "summary",
+								Usage: "Add a summary section after the issue rows: person (one row per person with their hours and percentage on each asset)",
+							},
+							&cli.StringSliceFlag{
+								Name:  "person-worktype",
+								Usage: "Count every row of a team member as a work type, e.g. alice=maintenance for an on-call sprint (repeatable; overrides allocation.personWorkTypes)",
+							},
+							normalizeFlag(),
+							outFlag(),
 						},
 					},
-				},
-			},
-			{
-				Name:  "assets",
-				Usage: "Manage digital assets",
-				Subcommands: []*cli.Command{
 					{
-						Name:  "create",
-						Usage: "Create a new asset",
+						Name:  "explain",
+						Usage: "Show step by step how a single issue's allocation is computed",
 						Action: func(ctx *cli.Context) error {
-							name := ctx.String("name")
-							description := ctx.String("description")
-							if err := a.assetService.CreateAsset(name, description); err != nil {
+							format := ctx.String("format")
+							if format != "json" && format != "text" {
+								return fmt.Errorf("unsupported format: %s", format)
+							}
+							sprint, fixVersion, err := sprintOrFixVersion(ctx)
+							if err != nil {
 								return err
 							}
-							fmt.Printf("Created asset: %s\n", name)
+							asOf, err := sprintdomain.ParseLabelSnapshot(ctx.String("as-of"))
+							if err != nil {
+								return err
+							}
+							issueKey := ctx.String("issue")
+							project := ctx.String("project")
+							if project == "" {
+								project, _, _ = strings.Cut(issueKey, "-")
+							}
+							allocation := sprintdomain.AllocationInput{}
+							if err := applyAllocationMethod(ctx, &allocation); err != nil {
+								return err
+							}
+							explanation, err := a.sprintService.ExplainAllocation(sprintdomain.ExplanationInput{
+								Project:    strings.ToUpper(project),
+								Sprint:     sprint,
+								FixVersion: fixVersion,
+								IssueKey:   issueKey,
+								Override:   ctx.String("override"),
+								Method:     allocation.Method,
+								PointsAt:   allocation.PointsAt,
+								LabelsAsOf: asOf,
+								Heuristics: a.heuristics,
+								Export:     a.jiraExport,
+							})
+							if err != nil {
+								return err
+							}
+
+							if format == "json" {
+								if err := printJSON(explanation); err != nil {
+									return fmt.Errorf("failed to encode explanation: %w", err)
+								}
+								return nil
+							}
+							printExplanation(explanation)
 							return nil
 						},
 						Flags: []cli.Flag{
 							&cli.StringFlag{
-								Name:     "name",
-								Usage:    "Asset name",
+								Name:     "issue",
+								Aliases:  []string{"i"},
+								Usage:    "Issue key to explain (e.g. FN-123)",
 								Required: true,
 							},
 							&cli.StringFlag{
-								Name:     "description",
-								Usage:    "Asset description",
-								Required: true,
+								Name:    "project",
+								Aliases: []string{"p"},
+								Usage:   "Project key (defaults to the issue key's prefix)",
+							},
+							&cli.StringFlag{
+								Name:    "sprint",
+								Aliases: []string{"s"},
+								Usage:   "Sprint name or ID",
+							},
+							&cli.StringFlag{
+								Name:  "fix-version",
+								Usage: "Explain the allocation of a fix version instead of a sprint",
+							},
+							&cli.StringFlag{
+								Name:    "override",
+								Aliases: []string{"o"},
+								Usage:   "Manual percentage adjustments as JSON where key is IssueID and value is amount of working hours being spent (e.g. '{\"ISSUE-1\": 6, \"ISSUE-2\": 36}')",
+							},
+							&cli.StringFlag{
+								Name:  "as-of",
+								Usage: "Classify issues by their labels at a date (YYYY-MM-DD) or at their completion ('completion')",
+							},
+							&cli.StringFlag{
+								Name:  "method",
+								Usage: "Allocation strategy: time (time in progress), storypoints (share of story points), or a weighted blend such as time:0.7,storypoints:0.3",
+								Value: string(sprintdomain.AllocationMethodTime),
+							},
+							&cli.StringFlag{
+								Name:  "points-at",
+								Usage: "Story point estimate used by the storypoints method: start, end or latest",
+								Value: string(sprintdomain.PointsAtLatest),
+							},
+							&cli.StringFlag{
+								Name:  "format",
+								Usage: "Output format: text or json",
+								Value: "text",
 							},
 						},
 					},
 					{
-						Name:  "list",
-						Usage: "List all assets",
-						Action: func(_ *cli.Context) error {
-							assets, err := a.assetService.ListAssets()
+						Name:  "lint",
+						Usage: "Flag sprint assignees that match no team member or alias",
+						Action: func(ctx *cli.Context) error {
+							project := ctx.String("project")
+							sprint := ctx.String("sprint")
+							result, err := a.sprintService.LintAssignees(project, sprint, a.jiraExport)
 							if err != nil {
 								return err
 							}
-							if len(assets) == 0 {
-								fmt.Println("No assets found")
+							if !result.HasFindings() {
+								fmt.Printf("All assignees in sprint %s matched team members of project %s\n", sprint, project)
 								return nil
 							}
-							fmt.Println("Assets:")
-							for _, asset := range assets {
-								fmt.Printf("- %s:\n", asset.Name)
-								fmt.Printf("  Description: %s\n", asset.Description)
-								fmt.Printf("  Why: %s\n", asset.Why)
-								fmt.Printf("  Benefits: %s\n", asset.Benefits)
-								fmt.Printf("  How: %s\n", asset.How)
-								fmt.Printf("  Metrics: %s\n", asset.Metrics)
-								if asset.DocLink != "" {
-									fmt.Printf("  DocLink: %s\n", asset.DocLink)
-								}
-								fmt.Println()
-							}
-							return nil
-						},
-					},
-					{
-						Name:  "sync",
-						Usage: "Sync assets from Confluence",
-						Action: func(ctx *cli.Context) error {
-							space := ctx.String("space")
-							label := ctx.String("label")
-							debug := ctx.Bool("debug")
-
-							result, err := a.assetService.SyncFromConfluence(space, label, debug)
-							if err != nil {
-								if strings.Contains(err.Error(), "no assets found with label") {
-									fmt.Println(err)
-									return nil
+							if len(result.Unmatched) > 0 {
+								fmt.Printf("Assignees not matching any team member of project %s:\n", project)
+								for _, assignee := range result.Unmatched {
+									fmt.Printf("- %s (%s)\n", assignee.Name, strings.Join(assignee.Issues, ", "))
 								}
-								return err
 							}
-
-							totalAssets := len(result.SyncedAssets) + len(result.NotSyncedAssets)
-							fmt.Printf("Successfully synced %d/%d assets from Confluence\n", len(result.SyncedAssets), totalAssets)
-
-							if len(result.NotSyncedAssets) > 0 {
-								fmt.Printf("\nWarning: %d assets could not be synced due to missing information:\n", len(result.NotSyncedAssets))
-								for _, asset := range result.NotSyncedAssets {
-									fmt.Printf("\n- %s:\n", asset.Name)
-									fmt.Printf("  Missing fields: %s\n", strings.Join(asset.MissingFields, ", "))
-									fmt.Println("  Available fields:")
-									for field, value := range asset.AvailableFields {
-										if value != "" {
-											fmt.Printf("    %s: %s\n", field, value)
-										}
-									}
+							if len(result.UnknownAliases) > 0 {
+								fmt.Println("Aliases defined for names that are not team members:")
+								for _, name := range result.UnknownAliases {
+									fmt.Printf("- %s\n", name)
 								}
 							}
-
 							return nil
 						},
 						Flags: []cli.Flag{
 							&cli.StringFlag{
-								Name:     "space",
-								Usage:    "Confluence space key (e.g. MZN)",
+								Name:     "project",
+								Aliases:  []string{"p"},
+								Usage:    "Project key",
 								Required: true,
 							},
 							&cli.StringFlag{
-								Name:     "label",
-								Usage:    "Filter pages by label (e.g. cap-asset)",
+								Name:     "sprint",
+								Aliases:  []string{"s"},
+								Usage:    "Sprint name or ID",
 								Required: true,
 							},
-							&cli.BoolFlag{
-								Name:  "debug",
-								Usage: "Enable debug logging",
-								Value: false,
-							},
 						},
 					},
 					{
-						Name:  "update",
-						Usage: "Update an asset's description",
+						Name:  "scope",
+						Usage: "Summarize the issues added to and removed from a sprint after it started",
 						Action: func(ctx *cli.Context) error {
-							name := ctx.Value("name").(string)
-							description := ctx.Value("description").(string)
-							why := ctx.Value("why").(string)
-							benefits := ctx.Value("benefits").(string)
-							how := ctx.Value("how").(string)
-							metrics := ctx.Value("metrics").(string)
-							if err := a.assetService.UpdateAsset(name, description, why, benefits, how, metrics); err != nil {
+							format := ctx.String("format")
+							if format != "json" && format != "text" {
+								return fmt.Errorf("unsupported format: %s", format)
+							}
+							scope, err := a.sprintService.SprintScope(ctx.String("project"), ctx.String("sprint"), a.jiraExport)
+							if err != nil {
 								return err
 							}
-							fmt.Printf("Updated asset: %s\n", name)
+							if format == "json" {
+								if err := printJSON(scope); err != nil {
+									return fmt.Errorf("failed to encode sprint scope: %w", err)
+								}
+								return nil
+							}
+							printSprintScope(scope)
 							return nil
 						},
 						Flags: []cli.Flag{
 							&cli.StringFlag{
-								Name:     "name",
-								Usage:    "Asset name",
-								Required: true,
-							},
-							&cli.StringFlag{
-								Name:     "description",
-								Usage:    "New asset description",
-								Required: true,
-							},
-							&cli.StringFlag{
-								Name:     "why",
-								Usage:    "Why are we doing this?",
-								Required: true,
-							},
-							&cli.StringFlag{
-								Name:     "benefits",
-								Usage:    "Economic benefits",
+								Name:     "project",
+								Aliases:  []string{"p"},
+								Usage:    "Project key",
 								Required: true,
 							},
 							&cli.StringFlag{
-								Name:     "how",
-								Usage:    "How it works?",
+								Name:     "sprint",
+								Aliases:  []string{"s"},
+								Usage:    "Sprint name or ID",
 								Required: true,
 							},
 							&cli.StringFlag{
-								Name:     "metrics",
-								Usage:    "How do we judge success?",
-								Required: true,
+								Name:  "format",
+								Usage: "Output format: text or json",
+								Value: "text",
 							},
 						},
 					},
 					{
-						Name:  "show",
-						Usage: "Show detailed information about an asset",
+						Name:  "resolve-accounts",
+						Usage: "Record the Jira account ID of each team member in teams.json",
 						Action: func(ctx *cli.Context) error {
-							name := ctx.String("name")
-							asset, err := a.assetService.GetAsset(name)
+							result, err := a.sprintService.ResolveTeamAccounts(sprintdomain.TeamAccountsInput{
+								Project: ctx.String("project"),
+								DryRun:  ctx.Bool("dry-run"),
+							})
 							if err != nil {
 								return err
 							}
-							fmt.Printf("Asset: %s\n", asset.Name)
-							fmt.Printf("Description: %s\n", asset.Description)
-							fmt.Printf("Why: %s\n", asset.Why)
-							fmt.Printf("Benefits: %s\n", asset.Benefits)
-							fmt.Printf("How: %s\n", asset.How)
-							fmt.Printf("Metrics: %s\n", asset.Metrics)
-							fmt.Printf("Created: %s\n", asset.CreatedAt.Format("2006-01-02 15:04:05"))
-							fmt.Printf("Updated: %s\n", asset.UpdatedAt.Format("2006-01-02 15:04:05"))
-							fmt.Printf("Task Count: %d\n", asset.AssociatedTaskCount)
-							if len(asset.Keywords) > 0 {
-								fmt.Printf("Keywords: %s\n", strings.Join(asset.Keywords, ", "))
+							for _, resolution := range result.Resolutions {
+								switch resolution.Status {
+								case sprintdomain.AccountResolved:
+									fmt.Printf("%s %s: resolved to %s (%s)\n", resolution.Project, resolution.Member, resolution.AccountID, resolution.DisplayName)
+								case sprintdomain.AccountAlreadyResolved:
+									fmt.Printf("%s %s: already %s\n", resolution.Project, resolution.Member, resolution.AccountID)
+								case sprintdomain.AccountAmbiguous:
+									fmt.Printf("%s %s: ambiguous, add the account ID by hand: %s\n", resolution.Project, resolution.Member, strings.Join(resolution.Candidates, ", "))
+								default:
+									fmt.Printf("%s %s: no active Jira account found\n", resolution.Project, resolution.Member)
+								}
 							}
-							if asset.DocLink != "" {
-								fmt.Printf("DocLink: %s\n", asset.DocLink)
+							if result.Written {
+								fmt.Println("Account IDs written to teams.json")
 							}
 							return nil
 						},
 						Flags: []cli.Flag{
 							&cli.StringFlag{
-								Name:     "name",
-								Usage:    "Asset name",
-								Required: true,
+								Name:    "project",
+								Aliases: []string{"p"},
+								Usage:   "Project key (defaults to every team)",
+							},
+							&cli.BoolFlag{
+								Name:  "dry-run",
+								Usage: "Show the resolved accounts without writing teams.json",
 							},
 						},
 					},
 					{
-						Name:  "documentation",
-						Usage: "Manage asset documentation",
+						Name:  "absences",
+						Usage: "Record the days team members were absent, taken out of their allocated time",
 						Subcommands: []*cli.Command{
 							{
-								Name:  "update",
-								Usage: "Mark asset documentation as updated",
+								Name:  "add",
+								Usage: "Record an absence of a team member",
 								Action: func(ctx *cli.Context) error {
-									assetName := ctx.String("asset")
-									// First check if the asset exists
-									_, err := a.assetService.GetAsset(assetName)
+									from, err := time.Parse("2006-01-02", ctx.String("from"))
+									if err != nil {
+										return fmt.Errorf("invalid date %q: expected YYYY-MM-DD", ctx.String("from"))
+									}
+									to := from
+									if ctx.IsSet("to") {
+										if to, err = time.Parse("2006-01-02", ctx.String("to")); err != nil {
+											return fmt.Errorf("invalid date %q: expected YYYY-MM-DD", ctx.String("to"))
+										}
+									}
+									absence, err := sprintdomain.NewAbsence(from, to, ctx.String("reason"))
 									if err != nil {
 										return err
 									}
-									if err := a.assetService.UpdateDocumentation(assetName); err != nil {
+									member := ctx.String("member")
+									added, err := a.sprintService.AddAbsences(member, []sprintdomain.Absence{absence})
+									if err != nil {
 										return err
 									}
-									fmt.Printf("Marked documentation as updated for asset %s\n", assetName)
+									if added == 0 {
+										fmt.Printf("Absence of %s already recorded\n", member)
+										return nil
+									}
+									fmt.Printf("Recorded %d days of absence for %s\n", absence.Days(), member)
 									return nil
 								},
 								Flags: []cli.Flag{
 									&cli.StringFlag{
-										Name:     "asset",
-										Usage:    "Asset name",
+										Name:     "member",
+										Aliases:  []string{"m"},
+										Usage:    "Team member as named in teams.json",
+										Required: true,
+									},
+									&cli.StringFlag{
+										Name:     "from",
+										Usage:    "First day absent (YYYY-MM-DD)",
 										Required: true,
 									},
+									&cli.StringFlag{
+										Name:  "to",
+										Usage: "Last day absent (YYYY-MM-DD, defaults to the first day)",
+									},
+									&cli.StringFlag{
+										Name:  "reason",
+										Usage: "Reason of the absence (e.g., vacation)",
+									},
 								},
 							},
-						},
-					},
-					{
-						Name:  "tasks",
-						Usage: "Manage asset tasks",
-						Subcommands: []*cli.Command{
 							{
-								Name:  "increment",
-								Usage: "Increment task count for an asset",
+								Name:  "import",
+								Usage: "Record the events of an iCalendar (.ics) file as absences of a team member",
 								Action: func(ctx *cli.Context) error {
-									assetName := ctx.String("asset")
-									// First check if the asset exists
-									_, err := a.assetService.GetAsset(assetName)
+									file, err := os.Open(ctx.String("file"))
 									if err != nil {
-										return err
+										return fmt.Errorf("failed to open calendar: %w", err)
 									}
-									if err := a.assetService.IncrementTaskCount(assetName); err != nil {
+									defer file.Close()
+
+									member := ctx.String("member")
+									added, err := a.sprintService.ImportAbsences(member, file)
+									if err != nil {
 										return err
 									}
-									fmt.Printf("Incremented task count for asset %s\n", assetName)
+									fmt.Printf("Imported %d absences for %s\n", added, member)
 									return nil
 								},
 								Flags: []cli.Flag{
 									&cli.StringFlag{
-										Name:     "asset",
-										Usage:    "Asset name",
+										Name:     "member",
+										Aliases:  []string{"m"},
+										Usage:    "Team member as named in teams.json",
 										Required: true,
 									},
-								},
+									&cli.StringFlag{
+										Name:     "file",
+										Aliases:  []string{"f"},
+										Usage:    "Path to the iCalendar file",
+										Required: true,
+									},
+								},
 							},
 							{
-								Name:  "decrement",
-								Usage: "Decrement task count for an asset",
+								Name:  "list",
+								Usage: "List the recorded absences",
 								Action: func(ctx *cli.Context) error {
-									assetName := ctx.String("asset")
-									// First check if the asset exists
-									_, err := a.assetService.GetAsset(assetName)
+									calendar, err := a.sprintService.ListAbsences()
 									if err != nil {
 										return err
 									}
-									if err := a.assetService.DecrementTaskCount(assetName); err != nil {
-										return err
-									}
-									fmt.Printf("Decremented task count for asset %s\n", assetName)
+									printAbsences(calendar)
 									return nil
 								},
-								Flags: []cli.Flag{
-									&cli.StringFlag{
-										Name:     "asset",
-										Usage:    "Asset name",
-										Required: true,
-									},
-								},
 							},
 						},
 					},
 					{
-						Name:  "enrich",
-						Usage: "Enrich asset fields using LLaMA 3",
+						Name:  "push",
+						Usage: "Write the sprint allocation back to Jira issues",
 						Action: func(ctx *cli.Context) error {
-							name := ctx.String("name")
-							field := ctx.String("field")
-							if err := a.assetService.EnrichAsset(name, field); err != nil {
+							input := sprintdomain.PushAllocationsInput{
+								Project:    ctx.String("project"),
+								Sprint:     ctx.String("sprint"),
+								Override:   ctx.String("override"),
+								Mode:       sprintdomain.PushMode(ctx.String("mode")),
+								DryRun:     ctx.Bool("dry-run"),
+								Heuristics: a.heuristics,
+								ChunkSize:  ctx.Int("chunk-size"),
+								Restart:    ctx.Bool("restart"),
+								Export:     a.jiraExport,
+							}
+							result, err := a.sprintService.PushAllocations(input)
+							if err != nil {
 								return err
 							}
-							fmt.Printf("Enriched %s field for asset: %s\n", field, name)
+							if input.DryRun {
+								return nil
+							}
+							written := "allocation comments"
+							if input.Mode == sprintdomain.PushModeWorklog {
+								written = "allocation worklogs"
+							}
+							fmt.Printf("Created %d, updated %d, failed %d %s\n",
+								len(result.Created), len(result.Updated), len(result.Failures), written)
+							if len(result.Resumed) > 0 {
+								fmt.Printf("Left %d rows pushed by the interrupted run alone\n", len(result.Resumed))
+							}
+							for _, failure := range result.Failures {
+								fmt.Printf("- %s: %v\n", failure.IssueKey, failure.Err)
+							}
+							if len(result.Remaining) > 0 {
+								return fmt.Errorf("rate limited by Jira with %d rows left to push; run the same command again to resume", len(result.Remaining))
+							}
 							return nil
 						},
 						Flags: []cli.Flag{
 							&cli.StringFlag{
-								Name:     "name",
-								Usage:    "Asset name or ID",
+								Name:     "project",
+								Aliases:  []string{"p"},
+								Usage:    "Project key",
 								Required: true,
 							},
 							&cli.StringFlag{
-								Name:     "field",
-								Usage:    "Field to enrich (e.g., description)",
+								Name:     "sprint",
+								Aliases:  []string{"s"},
+								Usage:    "Sprint name or ID",
 								Required: true,
 							},
+							&cli.StringFlag{
+								Name:    "override",
+								Aliases: []string{"o"},
+								Usage:   "Manual working hours per issue as JSON (same format as 'sprint allocate')",
+							},
+							&cli.StringFlag{
+								Name:  "mode",
+								Usage: "How to write the allocation to Jira (worklog, comment)",
+								Value: string(sprintdomain.PushModeWorklog),
+							},
+							&cli.BoolFlag{
+								Name:  "dry-run",
+								Usage: "Print the comments or worklogs without writing to Jira",
+							},
+							&cli.IntFlag{
+								Name:  "chunk-size",
+								Usage: "Number of rows pushed between two checkpoints",
+								Value: sprintdomain.DefaultPushChunkSize,
+							},
+							&cli.BoolFlag{
+								Name:  "restart",
+								Usage: "Push every row again instead of resuming an interrupted push",
+							},
 						},
 					},
 					{
-						Name:  "keywords",
-						Usage: "Generate keywords for an asset using LLaMA 3",
-						Action: func(ctx *cli.Context) error {
-							name := ctx.String("name")
-							// Check if asset exists
-							_, err := a.assetService.GetAsset(name)
+						Name:  "report",
+						Usage: "Render the sprint allocation with capitalization KPIs",
+						Action: a.withSnapshot(func(ctx *cli.Context) error {
+							projects, sprint := ctx.StringSlice("project"), ctx.String("sprint")
+							var batch taskBatch
+							if ctx.Bool("from-stdin") {
+								var err error
+								if batch, err = a.readBatch(); err != nil {
+									return err
+								}
+								if len(projects) == 0 && batch.Project != "" {
+									projects = []string{batch.Project}
+								}
+								if sprint == "" {
+									sprint = batch.Sprint
+								}
+							}
+							if len(projects) == 0 || sprint == "" {
+								return fmt.Errorf("--project and --sprint are required unless the task batch on stdin holds them")
+							}
+							delimiter, err := sprintusecase.ParseCSVDelimiter(ctx.String("delimiter"))
 							if err != nil {
-								return fmt.Errorf("asset not found: %s", name)
+								return err
 							}
-							if err := a.assetService.GenerateKeywords(name); err != nil {
+							var template *sprintdomain.ReportTemplate
+							if ctx.String("template") != "" {
+								if template, err = loadReportTemplate(ctx.String("template")); err != nil {
+									return err
+								}
+							}
+							asOf, err := sprintdomain.ParseLabelSnapshot(ctx.String("as-of"))
+							if err != nil {
 								return err
 							}
-							fmt.Printf("Generated keywords for asset: %s\n", name)
-							return nil
-						},
+							locale, err := a.exportLocale(ctx)
+							if err != nil {
+								return err
+							}
+							assets, err := a.assetService.ListAssets()
+							if err != nil {
+								return fmt.Errorf("failed to load assets: %w", err)
+							}
+							splits, err := a.workTypeSplits(ctx.Context)
+							if err != nil {
+								return err
+							}
+							splits = batchWorkTypes(batch, splits)
+							normalization, err := parseNormalization(ctx)
+							if err != nil {
+								return err
+							}
+							result, err := a.sprintService.GenerateCapitalizationReport(sprintdomain.CapitalizationReportInput{
+								Projects:       projects,
+								Sprint:         sprint,
+								PreviousSprint: ctx.String("previous-sprint"),
+								Override:       ctx.String("override"),
+								Format:         sprintdomain.ReportFormat(ctx.String("format")),
+								Delimiter:      delimiter,
+								Impairments:    assetImpairments(assets),
+								Policy:         assetPolicy(assets),
+								AssetDocs:      assetDocLinks(assets),
+								AssetAccounts:  a.assetAccounts(assets),
+								AssetStatuses:  assetStatuses(assets),
+								Retirements:    assetRetirements(assets),
+								WorkTypeSplits: splits,
+								Template:       template,
+								LabelsAsOf:     asOf,
+								Locale:         locale,
+								Heuristics:     a.heuristics,
+								Export:         a.jiraExport,
+								Normalization:  normalization,
+							})
+							if err != nil {
+								return err
+							}
+							if err := a.writeOutput(ctx, outputSprintReport, result, reportContentType(sprintdomain.ReportFormat(ctx.String("format")), template)); err != nil {
+								return err
+							}
+							return batch.result()
+						}),
 						Flags: []cli.Flag{
+							&cli.StringSliceFlag{
+								Name:    "project",
+								Aliases: []string{"p"},
+								Usage:   "Project key (repeat for one capitalization ratio per team); required unless --from-stdin",
+							},
 							&cli.StringFlag{
-								Name:     "name",
-								Usage:    "Asset name or ID",
-								Required: true,
+								Name:    "sprint",
+								Aliases: []string{"s"},
+								Usage:   "Sprint name or ID; required unless --from-stdin",
+							},
+							&cli.StringFlag{
+								Name:  "previous-sprint",
+								Usage: "Sprint to compare the development and maintenance shares against",
+							},
+							&cli.StringFlag{
+								Name:    "override",
+								Aliases: []string{"o"},
+								Usage:   "Manual working hours per issue as JSON (same format as 'sprint allocate')",
+							},
+							&cli.StringFlag{
+								Name:  "format",
+								Usage: "Output format (csv, markdown)",
+								Value: string(sprintdomain.ReportFormatCSV),
+							},
+							&cli.StringFlag{
+								Name:  "as-of",
+								Usage: "Classify issues by their labels at a date (YYYY-MM-DD) or at their completion ('completion')",
+							},
+							&cli.StringFlag{
+								Name:  "delimiter",
+								Usage: "CSV field delimiter for the csv format (e.g. ';' for European Excel locales, '\\t' for tabs)",
+								Value: ",",
+							},
+							&cli.StringFlag{
+								Name:  "locale",
+								Usage: "Format numbers and dates for a locale, e.g. de-DE (defaults to the configured export locale)",
+							},
+							percentFormatFlag(),
+							percentDecimalsFlag(),
+							&cli.StringFlag{
+								Name:  "template",
+								Usage: fmt.Sprintf("Render the report with a Go template file (.html for HTML templates) or a built-in template (%s)", strings.Join(sprintusecase.BuiltinReportTemplateNames(), ", ")),
 							},
+							normalizeFlag(),
+							outFlag(),
+							fromStdinFlag(),
 						},
 					},
 				},
 			},
 			{
-				Name:  "tasks",
-				Usage: "Manage tasks from various platforms",
+				Name:  "report",
+				Usage: "Export allocation reports for finance processes",
 				Subcommands: []*cli.Command{
 					{
-						Name:  "fetch",
-						Usage: "Fetch tasks from a platform (e.g., Jira)",
-						Action: func(ctx *cli.Context) error {
-							project := ctx.Value("project").(string)
-							sprint := ctx.Value("sprint").(string)
-							platform := ctx.Value("platform").(string)
-							if err := a.taskService.FetchTasks(context.Background(), project, sprint, platform); err != nil {
+						Name:  "timesheet",
+						Usage: "Export per-engineer day by issue timesheets for a sprint",
+						Action: a.withSnapshot(func(ctx *cli.Context) error {
+							delimiter, err := sprintusecase.ParseCSVDelimiter(ctx.String("delimiter"))
+							if err != nil {
 								return err
 							}
-							fmt.Printf("Successfully fetched tasks for project %s, sprint %s from %s\n", project, sprint, platform)
-							return nil
-						},
+							asOf, err := sprintdomain.ParseLabelSnapshot(ctx.String("as-of"))
+							if err != nil {
+								return err
+							}
+							locale, err := a.exportLocale(ctx)
+							if err != nil {
+								return err
+							}
+							result, err := a.sprintService.GenerateTimesheet(sprintdomain.TimesheetInput{
+								Project:    ctx.String("project"),
+								Sprint:     ctx.String("sprint"),
+								Override:   ctx.String("override"),
+								People:     ctx.StringSlice("person"),
+								Delimiter:  delimiter,
+								LabelsAsOf: asOf,
+								Locale:     locale,
+								Heuristics: a.heuristics,
+								Export:     a.jiraExport,
+							})
+							if err != nil {
+								return err
+							}
+							return a.writeOutput(ctx, outputReportTimesheet, result, sink.ContentTypeCSV)
+						}),
 						Flags: []cli.Flag{
 							&cli.StringFlag{
 								Name:     "project",
-								Usage:    "Project key (e.g., FN)",
+								Aliases:  []string{"p"},
+								Usage:    "Project key",
 								Required: true,
 							},
 							&cli.StringFlag{
 								Name:     "sprint",
-								Usage:    "Sprint name (e.g., Penguins)",
+								Aliases:  []string{"s"},
+								Usage:    "Sprint name or ID",
 								Required: true,
 							},
+							&cli.StringSliceFlag{
+								Name:  "person",
+								Usage: "Team member to export (repeatable; everyone with allocated work when omitted)",
+							},
 							&cli.StringFlag{
-								Name:     "platform",
-								Usage:    "Platform to fetch tasks from (e.g., jira)",
-								Required: true,
+								Name:    "override",
+								Aliases: []string{"o"},
+								Usage:   "Manual working hours per issue as JSON (same format as 'sprint allocate')",
+							},
+							&cli.StringFlag{
+								Name:  "as-of",
+								Usage: "Classify issues by their labels at a date (YYYY-MM-DD) or at their completion ('completion')",
+							},
+							&cli.StringFlag{
+								Name:  "delimiter",
+								Usage: "CSV field delimiter (e.g. ';' for European Excel locales, '\\t' for tabs)",
+								Value: ",",
 							},
+							&cli.StringFlag{
+								Name:  "locale",
+								Usage: "Format numbers and dates for a locale, e.g. de-DE (defaults to the configured export locale)",
+							},
+							percentFormatFlag(),
+							percentDecimalsFlag(),
+							outFlag(),
 						},
 					},
 					{
-						Name:  "show",
-						Usage: "Show tasks for a project and sprint",
-						Action: func(ctx *cli.Context) error {
-							asset := ctx.String("asset")
-							if asset != "" {
-								// Check if asset exists
-								_, err := a.assetService.GetAsset(asset)
-								if err != nil {
-									return fmt.Errorf("asset not found: %s", asset)
-								}
-
-								tasks, err := a.taskService.GetTasksByAsset(ctx.Context, asset)
-								if err != nil {
-									return fmt.Errorf("failed to get tasks for asset %s: %w", asset, err)
-								}
-
-								fmt.Printf("Tasks for asset %s:\n", asset)
-								fmt.Println("----------------------------------------")
-								if len(tasks) == 0 {
-									fmt.Println("No tasks found")
-									return nil
-								}
-
-								for _, task := range tasks {
-									fmt.Printf("Key: %s\nType: %s\nSummary: %s\nStatus: %s\nEpic: %s\nWork Type: %s\nLabels: %v\n\n",
-										task.Key, task.Type, task.Summary, task.Status, task.Epic, task.WorkType, task.Labels)
-								}
-								return nil
+						Name:  "estimates",
+						Usage: "Compare the sprint allocation with the Jira original estimates, per issue and per person",
+						Action: a.withSnapshot(func(ctx *cli.Context) error {
+							tolerance := ctx.Float64("tolerance")
+							if tolerance <= 0 {
+								return fmt.Errorf("invalid tolerance %v: must be a positive percentage", tolerance)
 							}
-
-							project := ctx.String("project")
-							sprint := ctx.String("sprint")
-
-							if project == "" || sprint == "" {
-								return fmt.Errorf("both project and sprint flags are required")
+							delimiter, err := sprintusecase.ParseCSVDelimiter(ctx.String("delimiter"))
+							if err != nil {
+								return err
 							}
-
-							tasks, err := a.taskService.GetTasks(ctx.Context, project, sprint)
+							asOf, err := sprintdomain.ParseLabelSnapshot(ctx.String("as-of"))
 							if err != nil {
-								return fmt.Errorf("failed to get tasks: %w", err)
+								return err
 							}
-
-							if len(tasks) == 0 {
-								fmt.Println("No tasks found")
-								return nil
+							locale, err := a.exportLocale(ctx)
+							if err != nil {
+								return err
 							}
-
-							fmt.Printf("\nTasks for project %s and sprint %s:\n", project, sprint)
-							fmt.Println("----------------------------------------")
-							for _, task := range tasks {
-								fmt.Printf("Key: %s\nType: %s\nSummary: %s\nStatus: %s\nEpic: %s\nWork Type: %s\nLabels: %v\n\n",
-									task.Key, task.Type, task.Summary, task.Status, task.Epic, task.WorkType, task.Labels)
+							result, err := a.sprintService.CompareEstimates(sprintdomain.EstimateComparisonInput{
+								Project:    ctx.String("project"),
+								Sprint:     ctx.String("sprint"),
+								Override:   ctx.String("override"),
+								Delimiter:  delimiter,
+								LabelsAsOf: asOf,
+								Locale:     locale,
+								Heuristics: a.heuristics,
+								Tolerance:  tolerance / 100,
+								Export:     a.jiraExport,
+							})
+							if err != nil {
+								return err
 							}
-							return nil
-						},
+							return a.writeOutput(ctx, outputReportEstimates, result, sink.ContentTypeCSV)
+						}),
 						Flags: []cli.Flag{
 							&cli.StringFlag{
-								Name:  "project",
-								Usage: "Project name",
+								Name:     "project",
+								Aliases:  []string{"p"},
+								Usage:    "Project key",
+								Required: true,
 							},
 							&cli.StringFlag{
-								Name:  "sprint",
-								Usage: "Sprint name",
+								Name:     "sprint",
+								Aliases:  []string{"s"},
+								Usage:    "Sprint name or ID",
+								Required: true,
+							},
+							&cli.Float64Flag{
+								Name:  "tolerance",
+								Usage: "Percentage of the estimate the hours may differ by and still count as accurate",
+								Value: sprintdomain.DefaultEstimateTolerance * 100,
 							},
 							&cli.StringFlag{
-								Name:  "asset",
-								Usage: "Asset name or ID to filter tasks",
+								Name:    "override",
+								Aliases: []string{"o"},
+								Usage:   "Manual working hours per issue as JSON (same format as 'sprint allocate')",
+							},
+							&cli.StringFlag{
+								Name:  "as-of",
+								Usage: "Classify issues by their labels at a date (YYYY-MM-DD) or at their completion ('completion')",
 							},
+							&cli.StringFlag{
+								Name:  "delimiter",
+								Usage: "CSV field delimiter (e.g. ';' for European Excel locales, '\\t' for tabs)",
+								Value: ",",
+							},
+							&cli.StringFlag{
+								Name:  "locale",
+								Usage: "Format numbers for a locale, e.g. de-DE (defaults to the configured export locale)",
+							},
+							percentFormatFlag(),
+							percentDecimalsFlag(),
+							outFlag(),
 						},
 					},
 					{
-						Name:  "classify",
-						Usage: "Classify tasks for a specific project and sprint",
-						Action: func(ctx *cli.Context) error {
-							project := ctx.Value("project").(string)
-							sprint := ctx.Value("sprint").(string)
-							platform := ctx.Value("platform").(string)
-							dryRun := ctx.Value("dry-run").(bool)
-							apply := ctx.Value("apply").(bool)
-							input := domain.ClassifyTasksInput{
-								Project: project,
-								Sprint:  sprint,
-								DryRun:  dryRun,
-								Apply:   apply,
+						Name:  "org",
+						Usage: "Roll the classified tasks of every team up to an organization summary",
+						Action: a.withSnapshot(func(ctx *cli.Context) error {
+							locale, err := a.exportLocale(ctx)
+							if err != nil {
+								return err
 							}
-							if err := a.taskService.ClassifyTasks(context.Background(), input); err != nil {
+							projects := ctx.StringSlice("project")
+							if len(projects) == 0 {
+								if projects, err = configuredProjects(a.dataFilePath(ctx, "teams", teamsFile)); err != nil {
+									return err
+								}
+							}
+							report, err := a.taskService.OrgReport(ctx.Context, domain.OrgReportInput{
+								Projects: projects,
+								Quarter:  ctx.String("quarter"),
+							})
+							if err != nil {
 								return err
 							}
-							if dryRun {
-								fmt.Printf("Preview: Would classify tasks for project %s, sprint %s from %s\n", project, sprint, platform)
-							} else if apply {
-								fmt.Printf("Successfully classified and applied labels to tasks for project %s, sprint %s from %s\n", project, sprint, platform)
-							} else {
-								fmt.Printf("Successfully classified tasks for project %s, sprint %s from %s\n", project, sprint, platform)
+							if report.Cached > 0 {
+								fmt.Fprintf(os.Stderr, "Reused cached rollups of %d of %d teams\n", report.Cached, len(report.Teams))
 							}
-							return nil
-						},
+							format := ctx.String("format")
+							result, err := formatOrgReport(report, format, locale)
+							if err != nil {
+								return err
+							}
+							contentType := sink.ContentTypeMarkdown
+							if format == "csv" {
+								contentType = sink.ContentTypeCSV
+							}
+							return a.writeOutput(ctx, outputReportOrg, result, contentType)
+						}),
 						Flags: []cli.Flag{
 							&cli.StringFlag{
-								Name:     "project",
-								Usage:    "Project key (e.g., FN)",
+								Name:     "quarter",
+								Usage:    "Quarter the tasks were created in (e.g., 2024-Q2)",
 								Required: true,
 							},
-							&cli.StringFlag{
-								Name:     "sprint",
-								Usage:    "Sprint name (e.g., Penguins)",
-								Required: true,
+							&cli.StringSliceFlag{
+								Name:    "project",
+								Aliases: []string{"p"},
+								Usage:   "Project to include (repeatable; every team in teams.json when omitted)",
 							},
 							&cli.StringFlag{
-								Name:     "platform",
-								Usage:    "Platform to classify tasks from (e.g., jira)",
-								Required: true,
+								Name:  "teams",
+								Usage: "Path of the teams file (defaults to teams.json in the storage directory)",
 							},
-							&cli.BoolFlag{
-								Name:  "dry-run",
-								Usage: "Preview classification without making changes",
-								Value: false,
+							&cli.StringFlag{
+								Name:  "format",
+								Usage: "Output format (markdown or csv)",
+								Value: "markdown",
 							},
-							&cli.BoolFlag{
-								Name:  "apply",
-								Usage: "Write classifications back to Jira",
-								Value: false,
+							&cli.StringFlag{
+								Name:  "locale",
+								Usage: "Format numbers for a locale, e.g. de-DE (defaults to the configured export locale)",
 							},
+							percentFormatFlag(),
+							percentDecimalsFlag(),
+							outFlag(),
 						},
 					},
+					a.erpReportCommand(),
 				},
 			},
-		},
-	}
+			{
+				Name:  "verify",
+				Usage: "Check closure criteria, exiting non-zero on violations",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "sprint",
+						Usage: "Verify a sprint is classified, linked to assets and fully allocated",
+						Action: func(ctx *cli.Context) error {
+							format := ctx.String("format")
+							if format != "json" && format != "text" {
+								return fmt.Errorf("unsupported format: %s", format)
+							}
+							asOf, err := sprintdomain.ParseLabelSnapshot(ctx.String("as-of"))
+							if err != nil {
+								return err
+							}
+							splits, err := a.workTypeSplits(ctx.Context)
+							if err != nil {
+								return err
+							}
+							result, err := a.sprintService.VerifySprint(sprintdomain.VerificationInput{
+								Project:        ctx.String("project"),
+								Sprint:         ctx.String("sprint"),
+								Override:       ctx.String("override"),
+								LabelsAsOf:     asOf,
+								WorkTypeSplits: splits,
+								Heuristics:     a.heuristics,
+								Export:         a.jiraExport,
+							})
+							if err != nil {
+								return err
+							}
+
+							if format == "json" {
+								if err := printJSON(result); err != nil {
+									return fmt.Errorf("failed to encode violations: %w", err)
+								}
+							} else {
+								for _, violation := range result.Violations {
+									fmt.Printf("[%s] %s\n", violation.Rule, violation.Message)
+								}
+							}
+
+							if !result.Passed {
+								return fmt.Errorf("sprint %s failed verification with %d violation(s)", result.Sprint, len(result.Violations))
+							}
+							if format == "text" {
+								fmt.Printf("Sprint %s meets all closure criteria\n", result.Sprint)
+							}
+							return nil
+						},
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:     "project",
+								Aliases:  []string{"p"},
+								Usage:    "Project key",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:     "sprint",
+								Aliases:  []string{"s"},
+								Usage:    "Sprint name or ID",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:    "override",
+								Aliases: []string{"o"},
+								Usage:   "Manual working hours per issue as JSON (same format as 'sprint allocate')",
+							},
+							&cli.StringFlag{
+								Name:  "as-of",
+								Usage: "Classify issues by their labels at a date (YYYY-MM-DD) or at their completion ('completion')",
+							},
+							&cli.StringFlag{
+								Name:  "format",
+								Usage: "Output format: json or text",
+								Value: "json",
+							},
+						},
+					},
+				},
+			},
+			{
+				Name:  "pipeline",
+				Usage: "Run multi-step workflows with checkpoints",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "quarter",
+						Usage: "Sync, fetch, classify, allocate, verify, report and push a quarter",
+						Action: func(ctx *cli.Context) error {
+							quarter, err := domain.ParseQuarter(ctx.String("quarter"))
+							if err != nil {
+								return err
+							}
+							var from pipeline.Stage
+							if ctx.IsSet("from-stage") {
+								if from, err = pipeline.ParseStage(ctx.String("from-stage")); err != nil {
+									return err
+								}
+							}
+							project := ctx.String("project")
+							outDir := ctx.String("out-dir")
+							if outDir == "" {
+								outDir = filepath.Join(a.storageDir, pipelineDir, project+"-"+quarter.String())
+							}
+							steps := a.quarterPipeline(pipelineOptions{
+								project: project,
+								quarter: quarter,
+								spaces:  spaceKeys(ctx.StringSlice("space")),
+								label:   ctx.String("label"),
+								outDir:  outDir,
+								push:    ctx.Bool("push"),
+							})
+							runner := pipeline.NewRunner(pipeline.NewJSONCheckpointStore(filepath.Join(a.storageDir, pipelineDir)))
+							checkpoint, err := runner.Run(ctx.Context, project, quarter.String(), from, steps)
+							if checkpoint != nil {
+								fmt.Print(formatPipelineSummary(checkpoint))
+							}
+							return err
+						},
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:     "project",
+								Aliases:  []string{"p"},
+								Usage:    "Project key",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:     "quarter",
+								Usage:    "Quarter to close (e.g., 2024-Q2)",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:  "from-stage",
+								Usage: fmt.Sprintf("Resume from a stage, reusing the checkpoint of the earlier ones (%s)", strings.Join(pipelineStageNames(), ", ")),
+							},
+							&cli.StringSliceFlag{
+								Name:  "space",
+								Usage: "Confluence spaces to sync assets from, comma separated (the sync is skipped when omitted)",
+							},
+							&cli.StringFlag{
+								Name:  "label",
+								Usage: "Confluence label of the asset pages",
+								Value: "cap-asset",
+							},
+							&cli.StringFlag{
+								Name:  "out-dir",
+								Usage: "Directory of the allocations, verifications and reports (defaults to pipeline/<project>-<quarter> in the storage directory)",
+							},
+							&cli.BoolFlag{
+								Name:  "push",
+								Usage: "Write the sprint allocations back to Jira as issue comments",
+							},
+						},
+					},
+				},
+			},
+			{
+				Name:  "dashboard",
+				Usage: "Publish read-only views of the local data",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "build",
+						Usage: "Render a static HTML site with the asset catalog, latest sprint allocation and classification coverage",
+						Action: a.withSnapshot(func(ctx *cli.Context) error {
+							projects := ctx.StringSlice("project")
+							assets, err := a.assetService.ListAssets()
+							if err != nil {
+								return fmt.Errorf("failed to load assets: %w", err)
+							}
+							a.telemetry.Count("assets", len(assets))
+							coverage, err := a.taskService.ClassificationCoverage(ctx.Context)
+							if err != nil {
+								return err
+							}
+							site := dashboard.Site{
+								Title:       ctx.String("title"),
+								GeneratedAt: a.dataTime(),
+								Assets:      dashboardAssets(assets),
+								Sprints:     dashboardCoverage(coverage, projects),
+							}
+
+							if len(projects) > 0 {
+								sprint := ctx.String("sprint")
+								if sprint == "" {
+									if sprint, err = latestSprint(site.Sprints, projects); err != nil {
+										return err
+									}
+								}
+								splits, err := a.workTypeSplits(ctx.Context)
+								if err != nil {
+									return err
+								}
+								report, err := a.sprintService.BuildCapitalizationReport(sprintdomain.CapitalizationReportInput{
+									Projects:       projects,
+									Sprint:         sprint,
+									Impairments:    assetImpairments(assets),
+									Policy:         assetPolicy(assets),
+									AssetDocs:      assetDocLinks(assets),
+									AssetAccounts:  a.assetAccounts(assets),
+									AssetStatuses:  assetStatuses(assets),
+									Retirements:    assetRetirements(assets),
+									WorkTypeSplits: splits,
+									Heuristics:     a.heuristics,
+									Export:         a.jiraExport,
+								})
+								if err != nil {
+									return err
+								}
+								site.Allocation = dashboardAllocation(report, projects)
+							}
+
+							files, err := dashboard.Build(ctx.String("out"), site)
+							if err != nil {
+								return err
+							}
+							fmt.Printf("Wrote %d dashboard pages to %s\n", len(files), ctx.String("out"))
+							return nil
+						}),
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  "out",
+								Usage: "Directory to write the site to",
+								Value: "./dashboard/",
+							},
+							&cli.StringFlag{
+								Name:  "title",
+								Usage: "Title shown on every page",
+								Value: "Digital asset capitalization",
+							},
+							&cli.StringSliceFlag{
+								Name:    "project",
+								Aliases: []string{"p"},
+								Usage:   "Project key to include (repeatable); the allocation page is only built for given projects",
+							},
+							&cli.StringFlag{
+								Name:    "sprint",
+								Aliases: []string{"s"},
+								Usage:   "Sprint to allocate (defaults to the latest stored sprint of the projects)",
+							},
+						},
+					},
+				},
+			},
+			{
+				Name:  "serve",
+				Usage: "Run long-lived listeners",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "webhooks",
+						Usage: "Receive Jira issue webhooks and update the local task store",
+						Action: func(ctx *cli.Context) error {
+							handler, err := a.webhookHandler(ctx)
+							if err != nil {
+								return err
+							}
+							return serve(ctx, handler, "Jira webhooks")
+						},
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  "addr",
+								Usage: "Address to listen on",
+								Value: ":8080",
+							},
+							&cli.StringFlag{
+								Name:  "path",
+								Usage: "URL path Jira posts webhooks to",
+								Value: "/webhooks/jira",
+							},
+							&cli.StringSliceFlag{
+								Name:    "project",
+								Aliases: []string{"p"},
+								Usage:   "Project key to track (repeatable; all projects when omitted)",
+							},
+							&cli.StringFlag{
+								Name:  "secret-env",
+								Usage: "Environment variable holding the webhook secret used to verify signatures",
+								Value: "JIRA_WEBHOOK_SECRET",
+							},
+							&cli.BoolFlag{
+								Name:  "insecure-no-verify",
+								Usage: "Serve without a webhook secret, applying unsigned events",
+							},
+							&cli.BoolFlag{
+								Name:  "reclassify",
+								Usage: "Classify updated tasks that have no work type yet",
+							},
+						},
+					},
+					{
+						Name:  "slack",
+						Usage: "Answer Slack slash commands with summaries from the local data",
+						Action: func(ctx *cli.Context) error {
+							secret, err := signingSecret(ctx)
+							if err != nil {
+								return err
+							}
+							return serve(ctx, slack.NewCommandHandler(secret, a.slackQueries()), "Slack slash commands")
+						},
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  "addr",
+								Usage: "Address to listen on",
+								Value: ":8080",
+							},
+							&cli.StringFlag{
+								Name:  "path",
+								Usage: "URL path Slack posts slash commands to",
+								Value: "/slack/commands",
+							},
+							&cli.StringFlag{
+								Name:  "secret-env",
+								Usage: "Environment variable holding the Slack signing secret used to verify requests",
+								Value: "SLACK_SIGNING_SECRET",
+							},
+							&cli.BoolFlag{
+								Name:  "insecure-no-verify",
+								Usage: "Serve without a signing secret, answering unverified requests",
+							},
+						},
+					},
+					{
+						Name:   "mcp",
+						Usage:  "Serve assets, tasks and allocations as Model Context Protocol tools to LLM agents",
+						Action: a.serveMCP,
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  "socket",
+								Usage: "Unix socket path to listen on instead of stdin and stdout",
+							},
+						},
+					},
+				},
+			},
+			{
+				Name:  "devtools",
+				Usage: "Tools for maintainers",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "simulate",
+						Usage: "Run the allocation engine against a synthetic scenario",
+						Action: func(ctx *cli.Context) error {
+							result, err := a.sprintService.SimulateAllocation(ctx.String("scenario"))
+							if err != nil {
+								return err
+							}
+
+							fmt.Printf("Scenario: %s\n%s\n\n", result.Scenario, result.Description)
+							for _, issue := range result.Issues {
+								allocation := issue.Allocation
+								fmt.Printf("%s (%s) - %s\n", allocation.IssueKey, allocation.Assignee, allocation.IssueTitle)
+								for _, line := range issue.Timeline {
+									fmt.Printf("  %s\n", line)
+								}
+								fmt.Printf("  => %.2f hours, %.2f%% of %s's sprint\n\n", allocation.Hours, allocation.Percentage, allocation.Assignee)
+							}
+							return nil
+						},
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:     "scenario",
+								Usage:    "Scenario to simulate (" + strings.Join(sprintusecase.SimulationScenarios(), ", ") + ")",
+								Required: true,
+							},
+						},
+					},
+				},
+			},
+			{
+				Name:  "assets",
+				Usage: "Manage digital assets",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "create",
+						Usage: "Create a new asset",
+						Action: func(ctx *cli.Context) error {
+							name := ctx.String("name")
+							description := ctx.String("description")
+							if err := a.assetService.CreateAsset(name, description); err != nil {
+								return err
+							}
+							fmt.Printf("Created asset: %s\n", name)
+							return nil
+						},
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:     "name",
+								Usage:    "Asset name",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:     "description",
+								Usage:    "Asset description",
+								Required: true,
+							},
+						},
+					},
+					{
+						Name:  "list",
+						Usage: "List all assets",
+						Action: func(ctx *cli.Context) error {
+							assets, err := a.assetService.ListAssets()
+							if err != nil {
+								return err
+							}
+							a.telemetry.Count("assets", len(assets))
+							return render(ctx, newAssetList(assets), func() error {
+								if len(assets) == 0 {
+									fmt.Println("No assets found")
+									return nil
+								}
+								fmt.Println("Assets:")
+								for _, asset := range assets {
+									fmt.Printf("- %s:\n", asset.Name)
+									fmt.Printf("  Description: %s\n", asset.Description)
+									fmt.Printf("  Why: %s\n", asset.Why)
+									fmt.Printf("  Benefits: %s\n", asset.Benefits)
+									fmt.Printf("  How: %s\n", asset.How)
+									fmt.Printf("  Metrics: %s\n", asset.Metrics)
+									if asset.DocLink != "" {
+										fmt.Printf("  DocLink: %s\n", asset.DocLink)
+									}
+									fmt.Println()
+								}
+								return nil
+							})
+						},
+					},
+					{
+						Name:  "delete",
+						Usage: "Delete an asset, keeping it in the trash until the retention window ends; refuses assets with associated tasks unless --force",
+						Action: func(ctx *cli.Context) error {
+							name := ctx.String("name")
+							force := ctx.Bool("force")
+							if ctx.Bool("purge") {
+								if err := a.assetService.PurgeAsset(name, force); err != nil {
+									return linkedTasksHint(err, "purge")
+								}
+								fmt.Printf("Permanently deleted asset: %s\n", name)
+								return nil
+							}
+							if err := a.assetService.DeleteAsset(name, force); err != nil {
+								return linkedTasksHint(err, "delete")
+							}
+							fmt.Printf("Moved asset %s to the trash; restore it with 'assetcap trash restore --asset %q'\n", name, name)
+							return nil
+						},
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:     "name",
+								Usage:    "Asset name",
+								Required: true,
+							},
+							&cli.BoolFlag{
+								Name:  "purge",
+								Usage: "Delete permanently instead of moving to the trash",
+							},
+							&cli.BoolFlag{
+								Name:  "force",
+								Usage: "Delete the asset even though tasks are still associated with it",
+							},
+						},
+					},
+					{
+						Name:   "sync",
+						Usage:  "Sync assets from one or more Confluence spaces",
+						Action: a.syncAssets,
+						Flags: []cli.Flag{
+							&cli.StringSliceFlag{
+								Name:  "space",
+								Usage: "Confluence space keys, comma separated or repeated (e.g. MZN,PAY,PLAT); defaults to confluence.spaces of the configuration",
+							},
+							&cli.StringFlag{
+								Name:     "label",
+								Usage:    "Filter pages by label (e.g. cap-asset)",
+								Required: true,
+							},
+							&cli.BoolFlag{
+								Name:  "debug",
+								Usage: "Enable debug logging",
+								Value: false,
+							},
+						},
+					},
+					{
+						Name:  "update",
+						Usage: "Update an asset's description",
+						Action: func(ctx *cli.Context) error {
+							name := ctx.Value("name").(string)
+							description := ctx.Value("description").(string)
+							why := ctx.Value("why").(string)
+							benefits := ctx.Value("benefits").(string)
+							how := ctx.Value("how").(string)
+							metrics := ctx.Value("metrics").(string)
+							if err := a.assetService.UpdateAsset(name, description, why, benefits, how, metrics); err != nil {
+								return err
+							}
+							fmt.Printf("Updated asset: %s\n", name)
+							return nil
+						},
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:     "name",
+								Usage:    "Asset name",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:     "description",
+								Usage:    "New asset description",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:     "why",
+								Usage:    "Why are we doing this?",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:     "benefits",
+								Usage:    "Economic benefits",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:     "how",
+								Usage:    "How it works?",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:     "metrics",
+								Usage:    "How do we judge success?",
+								Required: true,
+							},
+						},
+					},
+					{
+						Name:  "show",
+						Usage: "Show detailed information about an asset",
+						Action: func(ctx *cli.Context) error {
+							name := ctx.String("name")
+							asset, err := a.assetService.GetAsset(name)
+							if err != nil {
+								return err
+							}
+							asJSON, err := jsonOutput(ctx)
+							if err != nil {
+								return err
+							}
+							if asJSON && ctx.Bool("live") {
+								return fmt.Errorf("--live has no JSON output; run it without --output json")
+							}
+							breakdown, err := a.assetService.GetTaskBreakdown(asset.Name)
+							if err != nil {
+								return err
+							}
+							var activity *sprintdomain.AssetActivity
+							if ctx.String("sprint") != "" {
+								splits, err := a.workTypeSplits(ctx.Context)
+								if err != nil {
+									return err
+								}
+								activity, err = a.sprintService.AssetActivity(sprintdomain.AssetActivityInput{
+									Project:        ctx.String("project"),
+									Sprint:         ctx.String("sprint"),
+									Asset:          asset.Name,
+									WorkTypeSplits: splits,
+									Heuristics:     a.heuristics,
+									Export:         a.jiraExport,
+								})
+								if err != nil {
+									return err
+								}
+							}
+							view := assetView{Asset: asset, Tasks: breakdown, Activity: activity}
+							return render(ctx, view, func() error {
+								fmt.Printf("Asset: %s\n", asset.Name)
+								fmt.Printf("Description: %s\n", asset.Description)
+								fmt.Printf("Why: %s\n", asset.Why)
+								fmt.Printf("Benefits: %s\n", asset.Benefits)
+								fmt.Printf("How: %s\n", asset.How)
+								fmt.Printf("Metrics: %s\n", asset.Metrics)
+								fmt.Printf("Created: %s\n", asset.CreatedAt.Format("2006-01-02 15:04:05"))
+								fmt.Printf("Updated: %s\n", asset.UpdatedAt.Format("2006-01-02 15:04:05"))
+								fmt.Printf("Task Count: %d\n", asset.AssociatedTaskCount)
+								if len(asset.Keywords) > 0 {
+									fmt.Printf("Keywords: %s\n", strings.Join(asset.Keywords, ", "))
+								}
+								if asset.DocLink != "" {
+									fmt.Printf("DocLink: %s\n", asset.DocLink)
+								}
+								if asset.BugFixWindowDays > 0 {
+									fmt.Printf("Bug-fix window: %d days after launch (%s)\n", asset.BugFixWindowDays, asset.LaunchDate.Format("2006-01-02"))
+								}
+								if len(asset.StatusHistory) > 0 {
+									fmt.Println("Status history:")
+									for _, entry := range asset.StatusHistory {
+										fmt.Printf("  %s: %s (%s)\n", statusEffectiveDate(entry), entry.Status, entry.Source)
+									}
+								}
+								if len(asset.Impairments) > 0 {
+									fmt.Printf("Impairments (total %.2f):\n", asset.TotalImpairment())
+									for _, impairment := range asset.Impairments {
+										fmt.Printf("  %s: %.2f - %s\n", impairment.Date.Format("2006-01-02"), impairment.Amount, impairment.Reason)
+									}
+								}
+								if len(asset.GeneratedFields) > 0 {
+									fmt.Println("Generated fields:")
+									for _, field := range assetsdomain.EnrichableFields {
+										if generated, ok := asset.GeneratedFields[field]; ok {
+											fmt.Printf("  %s: %s on %s\n", field, generated.Model, generated.GeneratedAt.Format("2006-01-02"))
+										}
+									}
+								}
+								printTaskBreakdown(breakdown, activity)
+								if ctx.Bool("live") {
+									return a.showLive(asset.Name)
+								}
+								return nil
+							})
+						},
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:     "name",
+								Usage:    "Asset name",
+								Required: true,
+							},
+							&cli.BoolFlag{
+								Name:  "live",
+								Usage: "Fetch the linked Confluence page and compare its metadata with the local values, without saving",
+							},
+							&cli.StringFlag{
+								Name:    "project",
+								Aliases: []string{"p"},
+								Usage:   "Project key of the sprint whose allocated hours are shown",
+							},
+							&cli.StringFlag{
+								Name:    "sprint",
+								Aliases: []string{"s"},
+								Usage:   "Show the asset's allocated hours by work type in this sprint",
+							},
+						},
+					},
+					{
+						Name:  "activity",
+						Usage: "Summarize the tasks, hours and work type mix of an asset in a sprint",
+						Action: func(ctx *cli.Context) error {
+							name := ctx.String("name")
+							if _, err := a.assetService.GetAsset(name); err != nil {
+								return err
+							}
+							asOf, err := sprintdomain.ParseLabelSnapshot(ctx.String("as-of"))
+							if err != nil {
+								return err
+							}
+							splits, err := a.workTypeSplits(ctx.Context)
+							if err != nil {
+								return err
+							}
+							activity, err := a.sprintService.AssetActivity(sprintdomain.AssetActivityInput{
+								Project:        ctx.String("project"),
+								Sprint:         ctx.String("sprint"),
+								Asset:          name,
+								Override:       ctx.String("override"),
+								LabelsAsOf:     asOf,
+								WorkTypeSplits: splits,
+								Heuristics:     a.heuristics,
+								Export:         a.jiraExport,
+							})
+							if err != nil {
+								return err
+							}
+							if ctx.Bool("summary") {
+								if activity.Summary, err = a.assetService.SummarizeActivity(name, activity.Digest()); err != nil {
+									return err
+								}
+							}
+							printAssetActivity(activity)
+							return nil
+						},
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:     "name",
+								Usage:    "Asset name",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:     "project",
+								Aliases:  []string{"p"},
+								Usage:    "Project key",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:     "sprint",
+								Aliases:  []string{"s"},
+								Usage:    "Sprint name or ID",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:    "override",
+								Aliases: []string{"o"},
+								Usage:   "Manual working hours per issue as JSON (same format as 'sprint allocate')",
+							},
+							&cli.StringFlag{
+								Name:  "as-of",
+								Usage: "Classify issues by their labels at a date (YYYY-MM-DD) or at their completion ('completion')",
+							},
+							&cli.BoolFlag{
+								Name:  "summary",
+								Usage: "Add a one-paragraph summary written by the configured LLM",
+							},
+						},
+					},
+					{
+						Name:  "link-doc",
+						Usage: "Link an asset to its Confluence documentation page",
+						Action: func(ctx *cli.Context) error {
+							asset, err := a.assetService.LinkDocumentation(ctx.String("name"), ctx.String("url"))
+							if err != nil {
+								return err
+							}
+							fmt.Printf("Linked asset %s to %s (label %s)\n", asset.Name, asset.DocLink, asset.ID)
+							return nil
+						},
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:     "name",
+								Usage:    "Asset name",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:     "url",
+								Usage:    "Confluence page URL",
+								Required: true,
+							},
+						},
+					},
+					{
+						Name:  "resolve-duplicate",
+						Usage: "Make a Confluence page the primary documentation of its asset and mark the other pages with its label as superseded",
+						Action: func(ctx *cli.Context) error {
+							resolution, err := a.assetService.ResolveDuplicate(ctx.String("primary"))
+							if err != nil {
+								return err
+							}
+							printDuplicateResolution(resolution)
+							return nil
+						},
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:     "primary",
+								Usage:    "URL of the Confluence page documenting the asset",
+								Required: true,
+							},
+						},
+					},
+					{
+						Name:  "impair",
+						Usage: "Record an impairment (write-down) of an asset",
+						Action: func(ctx *cli.Context) error {
+							name := ctx.String("name")
+							date, err := time.Parse("2006-01-02", ctx.String("date"))
+							if err != nil {
+								return fmt.Errorf("invalid date %q: expected YYYY-MM-DD", ctx.String("date"))
+							}
+							if err := a.assetService.ImpairAsset(name, date, ctx.String("reason"), ctx.Float64("amount")); err != nil {
+								return err
+							}
+							fmt.Printf("Recorded impairment of %.2f for asset %s effective %s\n", ctx.Float64("amount"), name, date.Format("2006-01-02"))
+							return nil
+						},
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:     "name",
+								Usage:    "Asset name",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:     "date",
+								Usage:    "Date the impairment takes effect (YYYY-MM-DD)",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:     "reason",
+								Usage:    "Why the asset is written down",
+								Required: true,
+							},
+							&cli.Float64Flag{
+								Name:     "amount",
+								Usage:    "Amount written down",
+								Required: true,
+							},
+						},
+					},
+					{
+						Name:  "bugfix-window",
+						Usage: "Count bugs fixed within days of an asset's launch as development in sprint reports",
+						Action: func(ctx *cli.Context) error {
+							name := ctx.String("name")
+							var launchDate time.Time
+							if ctx.IsSet("launch-date") {
+								date, err := time.Parse("2006-01-02", ctx.String("launch-date"))
+								if err != nil {
+									return fmt.Errorf("invalid date %q: expected YYYY-MM-DD", ctx.String("launch-date"))
+								}
+								launchDate = date
+							}
+							days := ctx.Int("days")
+							if err := a.assetService.SetBugFixWindow(name, days, launchDate); err != nil {
+								return err
+							}
+							if days == 0 {
+								fmt.Printf("Removed the bug-fix window of asset %s\n", name)
+								return nil
+							}
+							fmt.Printf("Bugs on asset %s completed within %d days of launch now count as development\n", name, days)
+							return nil
+						},
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:     "name",
+								Usage:    "Asset name",
+								Required: true,
+							},
+							&cli.IntFlag{
+								Name:     "days",
+								Usage:    "Days after launch during which completed bugs count as development (0 removes the window)",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:  "launch-date",
+								Usage: "Set the asset launch date (YYYY-MM-DD) when it is not synced from Confluence",
+							},
+						},
+					},
+					{
+						Name:  "set-status",
+						Usage: "Record a status of an asset taking effect at a date, so reports of earlier periods keep the status it had then",
+						Action: func(ctx *cli.Context) error {
+							name := ctx.String("name")
+							effective := time.Now()
+							if ctx.IsSet("effective") {
+								date, err := time.Parse("2006-01-02", ctx.String("effective"))
+								if err != nil {
+									return fmt.Errorf("invalid date %q: expected YYYY-MM-DD", ctx.String("effective"))
+								}
+								effective = date
+							}
+							if err := a.assetService.SetStatus(name, ctx.String("status"), effective); err != nil {
+								return err
+							}
+							fmt.Printf("Asset %s is %s from %s\n", name, ctx.String("status"), effective.Format("2006-01-02"))
+							return nil
+						},
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:     "name",
+								Usage:    "Asset name",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:     "status",
+								Usage:    "Status of the asset (e.g., live)",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:  "effective",
+								Usage: "Date the status took effect (YYYY-MM-DD); defaults to now",
+							},
+						},
+					},
+					a.retireAssetCommand(),
+					a.archiveAssetCommand(),
+					a.setAccountingCommand(),
+					{
+						Name:  "diff",
+						Usage: "Show assets added, removed and edited since a date or between two snapshot files",
+						Action: func(ctx *cli.Context) error {
+							format := ctx.String("format")
+							if format != "json" && format != "text" {
+								return fmt.Errorf("unsupported format: %s", format)
+							}
+							input := assetsdomain.CatalogDiffInput{
+								From: ctx.String("from"),
+								To:   ctx.String("to"),
+							}
+							if ctx.IsSet("since") {
+								since, err := time.Parse("2006-01-02", ctx.String("since"))
+								if err != nil {
+									return fmt.Errorf("invalid date %q: expected YYYY-MM-DD", ctx.String("since"))
+								}
+								input.Since = since
+							}
+							diff, err := a.assetService.DiffAssets(input)
+							if err != nil {
+								return err
+							}
+
+							if format == "json" {
+								if err := printJSON(diff); err != nil {
+									return fmt.Errorf("failed to encode catalogue diff: %w", err)
+								}
+								return nil
+							}
+							printCatalogDiff(diff)
+							return nil
+						},
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  "since",
+								Usage: "Compare with the catalogue at the start of this date (YYYY-MM-DD)",
+							},
+							&cli.StringFlag{
+								Name:  "from",
+								Usage: "Compare with a catalogue snapshot file",
+							},
+							&cli.StringFlag{
+								Name:  "to",
+								Usage: "Snapshot file to compare with --from instead of the current catalogue",
+							},
+							&cli.StringFlag{
+								Name:  "format",
+								Usage: "Output format: text or json",
+								Value: "text",
+							},
+						},
+					},
+					{
+						Name:    "documentation",
+						Aliases: []string{"docs"},
+						Usage:   "Manage asset documentation",
+						Subcommands: []*cli.Command{
+							{
+								Name:  "stale",
+								Usage: "List assets whose documentation is older than the freshness policy allows and remind their owners",
+								Action: func(ctx *cli.Context) error {
+									format := ctx.String("format")
+									if format != "json" && format != "text" {
+										return fmt.Errorf("unsupported format: %s", format)
+									}
+									policy := freshnessPolicy(a.docs)
+									if ctx.IsSet("max-age") {
+										policy.DefaultMaxAgeDays = ctx.Int("max-age")
+									}
+									stale, err := a.assetService.FindStaleDocumentation(policy)
+									if err != nil {
+										return err
+									}
+
+									if format == "json" {
+										if stale == nil {
+											stale = []assetsdomain.StaleDocumentation{}
+										}
+										if err := printJSON(assetsdomain.StaleDocumentationReport{Stale: stale}); err != nil {
+											return fmt.Errorf("failed to encode stale documentation: %w", err)
+										}
+									} else {
+										printStaleDocumentation(stale)
+									}
+									return a.notifyStaleDocumentation(ctx, stale)
+								},
+								Flags: []cli.Flag{
+									&cli.IntFlag{
+										Name:  "max-age",
+										Usage: "Days documentation stays fresh for the statuses without their own age (defaults to the configured age)",
+									},
+									&cli.StringFlag{
+										Name:  "notify",
+										Usage: "Send a reminder grouped by owner to a file path, s3://bucket/key, gs://bucket/object or an http(s) webhook (defaults to the configured destination)",
+									},
+									&cli.StringFlag{
+										Name:  "format",
+										Usage: "Output format: text or json",
+										Value: "text",
+									},
+								},
+							},
+							{
+								Name:  "owner",
+								Usage: "Set who is reminded of an asset's stale documentation",
+								Action: func(ctx *cli.Context) error {
+									assetName := ctx.String("asset")
+									if err := a.assetService.SetOwner(assetName, ctx.String("owner")); err != nil {
+										return err
+									}
+									if owner := strings.TrimSpace(ctx.String("owner")); owner != "" {
+										fmt.Printf("Set the documentation owner of asset %s to %s\n", assetName, owner)
+									} else {
+										fmt.Printf("Removed the documentation owner of asset %s\n", assetName)
+									}
+									return nil
+								},
+								Flags: []cli.Flag{
+									&cli.StringFlag{
+										Name:     "asset",
+										Usage:    "Asset name",
+										Required: true,
+									},
+									&cli.StringFlag{
+										Name:  "owner",
+										Usage: "Owner name or chat handle; empty removes the owner",
+									},
+								},
+							},
+							{
+								Name:  "update",
+								Usage: "Mark asset documentation as updated",
+								Action: func(ctx *cli.Context) error {
+									assetName := ctx.String("asset")
+									// First check if the asset exists
+									_, err := a.assetService.GetAsset(assetName)
+									if err != nil {
+										return err
+									}
+									if err := a.assetService.UpdateDocumentation(assetName); err != nil {
+										return err
+									}
+									fmt.Printf("Marked documentation as updated for asset %s\n", assetName)
+									return nil
+								},
+								Flags: []cli.Flag{
+									&cli.StringFlag{
+										Name:     "asset",
+										Usage:    "Asset name",
+										Required: true,
+									},
+								},
+							},
+						},
+					},
+					{
+						Name:  "tasks",
+						Usage: "Manage asset tasks",
+						Subcommands: []*cli.Command{
+							{
+								Name:  "increment",
+								Usage: "Increment the stored task count for an asset (deprecated: counts are derived from task labels)",
+								Action: func(ctx *cli.Context) error {
+									fmt.Fprintln(os.Stderr, "Warning: 'assets tasks increment' is deprecated; task counts are derived from the asset labels of fetched tasks")
+									assetName := ctx.String("asset")
+									// First check if the asset exists
+									_, err := a.assetService.GetAsset(assetName)
+									if err != nil {
+										return err
+									}
+									if err := a.assetService.IncrementTaskCount(assetName); err != nil {
+										return err
+									}
+									fmt.Printf("Incremented task count for asset %s\n", assetName)
+									return nil
+								},
+								Flags: []cli.Flag{
+									&cli.StringFlag{
+										Name:     "asset",
+										Usage:    "Asset name",
+										Required: true,
+									},
+								},
+							},
+							{
+								Name:  "decrement",
+								Usage: "Decrement the stored task count for an asset (deprecated: counts are derived from task labels)",
+								Action: func(ctx *cli.Context) error {
+									fmt.Fprintln(os.Stderr, "Warning: 'assets tasks decrement' is deprecated; task counts are derived from the asset labels of fetched tasks")
+									assetName := ctx.String("asset")
+									// First check if the asset exists
+									_, err := a.assetService.GetAsset(assetName)
+									if err != nil {
+										return err
+									}
+									if err := a.assetService.DecrementTaskCount(assetName); err != nil {
+										return err
+									}
+									fmt.Printf("Decremented task count for asset %s\n", assetName)
+									return nil
+								},
+								Flags: []cli.Flag{
+									&cli.StringFlag{
+										Name:     "asset",
+										Usage:    "Asset name",
+										Required: true,
+									},
+								},
+							},
+						},
+					},
+					{
+						Name:   "enrich",
+						Usage:  "Enrich asset fields using LLaMA 3, previewing the change before it is saved",
+						Action: a.enrichAsset,
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:     "name",
+								Usage:    "Asset name or ID",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:     "field",
+								Usage:    "Field to enrich (e.g., description)",
+								Required: true,
+							},
+							&cli.BoolFlag{
+								Name:  "with-attachments",
+								Usage: "Include text from PDFs and other files attached to the asset's Confluence page",
+							},
+							&cli.BoolFlag{
+								Name:  "force",
+								Usage: "Generate a new value even when one was cached for this version of the page",
+							},
+							&cli.BoolFlag{
+								Name:    "yes",
+								Aliases: []string{"y"},
+								Usage:   "Save the proposed value without asking for confirmation",
+							},
+						},
+					},
+					{
+						Name:  "keywords",
+						Usage: "Generate keywords for an asset using LLaMA 3",
+						Action: func(ctx *cli.Context) error {
+							name := ctx.String("name")
+							// Check if asset exists
+							_, err := a.assetService.GetAsset(name)
+							if err != nil {
+								return fmt.Errorf("asset not found: %s", name)
+							}
+							if err := a.assetService.GenerateKeywords(name); err != nil {
+								return err
+							}
+							fmt.Printf("Generated keywords for asset: %s\n", name)
+							return nil
+						},
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:     "name",
+								Usage:    "Asset name or ID",
+								Required: true,
+							},
+						},
+					},
+					{
+						Name:  "rules",
+						Usage: "Manage the rules classifying the tasks linked to an asset",
+						Subcommands: []*cli.Command{
+							{
+								Name:      "set",
+								Usage:     "Replace the classification rules of an asset",
+								UsageText: "assetcap assets rules set --name \"Data Platform\" --rule \"pipeline backfill=development\" [--rule ...] [--prompt \"...\"]",
+								Action:    a.setClassificationRules,
+								Flags: []cli.Flag{
+									&cli.StringFlag{
+										Name:     "name",
+										Usage:    "Asset name or ID",
+										Required: true,
+									},
+									&cli.StringSliceFlag{
+										Name:  "rule",
+										Usage: "keyword=worktype, e.g. \"pipeline backfill=development\"; tried in the order given (repeatable)",
+									},
+									&cli.StringFlag{
+										Name:  "prompt",
+										Usage: "Instructions added to the prompt of classifiers that accept them",
+									},
+								},
+							},
+							{
+								Name:  "show",
+								Usage: "Show the classification rules of an asset",
+								Action: func(ctx *cli.Context) error {
+									asset, err := a.assetService.GetAsset(ctx.String("name"))
+									if err != nil {
+										return err
+									}
+									printClassificationRules(asset)
+									return nil
+								},
+								Flags: []cli.Flag{
+									&cli.StringFlag{
+										Name:     "name",
+										Usage:    "Asset name or ID",
+										Required: true,
+									},
+								},
+							},
+							{
+								Name:  "clear",
+								Usage: "Remove the classification rules of an asset",
+								Action: func(ctx *cli.Context) error {
+									name := ctx.String("name")
+									if err := a.assetService.SetClassificationRules(name, assetsdomain.ClassificationRules{}); err != nil {
+										return err
+									}
+									fmt.Printf("Removed the classification rules of asset: %s\n", name)
+									return nil
+								},
+								Flags: []cli.Flag{
+									&cli.StringFlag{
+										Name:     "name",
+										Usage:    "Asset name or ID",
+										Required: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Name:  "tasks",
+				Usage: "Manage tasks from various platforms",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "fetch",
+						Usage: "Fetch tasks from a platform (e.g., Jira)",
+						Action: func(ctx *cli.Context) error {
+							project := ctx.Value("project").(string)
+							platform := ctx.Value("platform").(string)
+							sprint, fixVersion, err := sprintOrFixVersion(ctx)
+							if err != nil {
+								return err
+							}
+							asJSON, err := jsonOutput(ctx)
+							if err != nil {
+								return err
+							}
+							if asJSON {
+								if fixVersion != "" {
+									return fmt.Errorf("--output json pipes the tasks of a sprint, not of a fix version")
+								}
+								return a.fetchBatch(ctx, project, sprint, platform)
+							}
+							if fixVersion != "" {
+								if err := a.taskService.FetchTasksByFixVersion(context.Background(), project, fixVersion, platform); err != nil {
+									return err
+								}
+								fmt.Printf("Successfully fetched tasks for project %s, fix version %s from %s\n", project, fixVersion, platform)
+								return nil
+							}
+							if err := a.taskService.FetchTasks(context.Background(), project, sprint, platform); err != nil {
+								return err
+							}
+							fmt.Printf("Successfully fetched tasks for project %s, sprint %s from %s\n", project, sprint, platform)
+							return nil
+						},
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:     "project",
+								Usage:    "Project key (e.g., FN)",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:  "sprint",
+								Usage: "Sprint name (e.g., Penguins)",
+							},
+							&cli.StringFlag{
+								Name:  "fix-version",
+								Usage: "Fetch the tasks of a fix version / release (e.g., 2024.5) instead of a sprint",
+							},
+							&cli.StringFlag{
+								Name:     "platform",
+								Usage:    "Platform to fetch tasks from (e.g., jira)",
+								Required: true,
+							},
+							outputFlag(),
+						},
+					},
+					{
+						Name:  "import",
+						Usage: "Import tasks from a Jira JSON or CSV export into the local store",
+						Action: func(ctx *cli.Context) error {
+							exportRepo, err := jira.NewExportRepository(ctx.String("file"))
+							if err != nil {
+								return err
+							}
+							tasks, err := exportRepo.FindAll(context.Background())
+							if err != nil {
+								return err
+							}
+							if err := a.taskService.ImportTasks(context.Background(), tasks, ctx.String("project")); err != nil {
+								return err
+							}
+							fmt.Printf("Successfully imported tasks from %s\n", ctx.String("file"))
+							return nil
+						},
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:     "file",
+								Usage:    "Jira export to import, a .json REST API search response or a .csv issue export",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:  "project",
+								Usage: "Import only the tasks of a project (e.g., FN)",
+							},
+						},
+					},
+					{
+						Name:  "show",
+						Usage: "Show tasks for a project and sprint",
+						Action: func(ctx *cli.Context) error {
+							query, err := parseTaskQuery(ctx)
+							if err != nil {
+								return err
+							}
+
+							asset := ctx.String("asset")
+							if asset != "" {
+								// Check if asset exists
+								_, err := a.assetService.GetAsset(asset)
+								if err != nil {
+									return fmt.Errorf("asset not found: %s", asset)
+								}
+
+								tasks, err := a.taskService.GetTasksByAsset(ctx.Context, asset)
+								if err != nil {
+									return fmt.Errorf("failed to get tasks for asset %s: %w", asset, err)
+								}
+								a.telemetry.Count("tasks", len(tasks))
+								return a.showTasks(ctx, taskListing{Asset: asset}, fmt.Sprintf("Tasks for asset %s:", asset), tasks, query)
+							}
+
+							project := ctx.String("project")
+							sprint := ctx.String("sprint")
+
+							if project == "" || sprint == "" {
+								return fmt.Errorf("both project and sprint flags are required")
+							}
+
+							tasks, err := a.taskService.GetTasks(ctx.Context, project, sprint)
+							if err != nil {
+								return fmt.Errorf("failed to get tasks: %w", err)
+							}
+							a.telemetry.Count("tasks", len(tasks))
+
+							listing := taskListing{Project: project, Sprint: sprint}
+							if len(tasks) == 0 {
+								return a.showTasks(ctx, listing, "", tasks, query)
+							}
+
+							if level := ctx.String("rollup"); level != "" {
+								matched := query.filter.Apply(tasks)
+								groups := domain.RollupByLevel(matched, level)
+								keys := make([]string, 0, len(groups))
+								listing.Level = level
+								listing.Groups = make(map[string]int, len(groups))
+								for key := range groups {
+									keys = append(keys, key)
+									listing.Groups[key] = len(groups[key])
+								}
+								sort.Strings(keys)
+								listing.Tasks = append([]*domain.Task{}, matched...)
+								listing.Matched = len(matched)
+
+								return render(ctx, listing, func() error {
+									fmt.Printf("\nTasks for project %s and sprint %s by %s:\n", project, sprint, level)
+									fmt.Println("----------------------------------------")
+									for _, key := range keys {
+										name := key
+										if name == "" {
+											name = fmt.Sprintf("(no %s)", level)
+										}
+										fmt.Printf("%s: %d tasks\n", name, len(groups[key]))
+									}
+									return nil
+								})
+							}
+
+							return a.showTasks(ctx, listing, fmt.Sprintf("\nTasks for project %s and sprint %s:", project, sprint), tasks, query)
+						},
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  "project",
+								Usage: "Project name",
+							},
+							&cli.StringFlag{
+								Name:  "sprint",
+								Usage: "Sprint name",
+							},
+							&cli.StringFlag{
+								Name:  "asset",
+								Usage: "Asset name or ID to filter tasks",
+							},
+							&cli.StringFlag{
+								Name:  "rollup",
+								Usage: "Group tasks by their ancestor at this hierarchy level (e.g., epic, initiative)",
+							},
+							&cli.StringSliceFlag{
+								Name:  "status",
+								Usage: "Only show tasks with this status: todo, in-progress, blocked or done (repeatable)",
+							},
+							&cli.StringSliceFlag{
+								Name:  "type",
+								Usage: "Only show tasks of this type: story, task, bug, epic or subtask (repeatable)",
+							},
+							&cli.StringSliceFlag{
+								Name:  "worktype",
+								Usage: "Only show tasks classified with this work type: development, maintenance or discovery (repeatable)",
+							},
+							&cli.BoolFlag{
+								Name:  "unclassified",
+								Usage: "Only show tasks without a work type (combined with --worktype, show either)",
+							},
+							&cli.StringFlag{
+								Name:  "sort",
+								Usage: "Sort tasks by key, status or updated (most recent first)",
+								Value: string(domain.TaskSortKey),
+							},
+							&cli.IntFlag{
+								Name:  "limit",
+								Usage: "Show at most this many tasks (0 shows all)",
+							},
+							&cli.StringFlag{
+								Name:  "format",
+								Usage: "Output format: text or table (one compact row per task)",
+								Value: "text",
+							},
+						},
+					},
+					{
+						Name:  "classify",
+						Usage: "Classify tasks for a specific project and sprint",
+						Action: func(ctx *cli.Context) error {
+							if ctx.Bool("from-stdin") {
+								return a.classifyBatch(ctx)
+							}
+							if err := requireFlags(ctx, "project", "sprint", "platform"); err != nil {
+								return err
+							}
+							project := ctx.String("project")
+							sprint := ctx.String("sprint")
+							platform := ctx.String("platform")
+							dryRun := ctx.Bool("dry-run")
+							apply := ctx.Bool("apply")
+							input := domain.ClassifyTasksInput{
+								Project:     project,
+								Sprint:      sprint,
+								DryRun:      dryRun,
+								Apply:       apply,
+								LabelPolicy: a.labelPolicy,
+								ReplaceAll:  ctx.Bool("replace-all"),
+							}
+							rules, err := a.classificationRules()
+							if err != nil {
+								return err
+							}
+							input.Rules = rules
+							if err := a.taskService.ClassifyTasks(context.Background(), input); err != nil {
+								return err
+							}
+							if !dryRun {
+								a.publishClassified(ctx.Context, project, sprint, apply)
+							}
+							if dryRun {
+								fmt.Printf("Preview: Would classify tasks for project %s, sprint %s from %s\n", project, sprint, platform)
+							} else if apply {
+								fmt.Printf("Successfully classified and applied labels to tasks for project %s, sprint %s from %s\n", project, sprint, platform)
+							} else {
+								fmt.Printf("Successfully classified tasks for project %s, sprint %s from %s\n", project, sprint, platform)
+							}
+							return nil
+						},
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  "project",
+								Usage: "Project key (e.g., FN); required unless --from-stdin",
+							},
+							&cli.StringFlag{
+								Name:  "sprint",
+								Usage: "Sprint name (e.g., Penguins); required unless --from-stdin",
+							},
+							&cli.StringFlag{
+								Name:  "platform",
+								Usage: "Platform to classify tasks from (e.g., jira); required unless --from-stdin",
+							},
+							&cli.BoolFlag{
+								Name:  "dry-run",
+								Usage: "Preview classification without making changes",
+								Value: false,
+							},
+							&cli.BoolFlag{
+								Name:  "apply",
+								Usage: "Write classifications back to Jira",
+								Value: false,
+							},
+							&cli.BoolFlag{
+								Name:  "replace-all",
+								Usage: "With --apply, replace every unprotected label instead of merging the work type label",
+							},
+							fromStdinFlag(),
+							outputFlag(),
+						},
+					},
+					{
+						Name:  "sample",
+						Usage: "Select a reproducible random sample of classified tasks for audit",
+						Action: func(ctx *cli.Context) error {
+							input := domain.SampleTasksInput{
+								Project:  ctx.String("project"),
+								Quarter:  ctx.String("quarter"),
+								Size:     ctx.Int("size"),
+								Stratify: ctx.String("stratify"),
+								Seed:     ctx.Int64("seed"),
+							}
+							sample, err := a.taskService.SampleTasks(ctx.Context, input)
+							if err != nil {
+								return fmt.Errorf("failed to sample tasks: %w", err)
+							}
+
+							data, err := tasksusecase.FormatSampleCSV(sample, os.Getenv("JIRA_BASE_URL"))
+							if err != nil {
+								return err
+							}
+
+							output := ctx.String("output")
+							if output == "" {
+								fmt.Print(data)
+							} else if err := os.WriteFile(output, []byte(data), 0644); err != nil {
+								return fmt.Errorf("failed to write sample: %w", err)
+							} else if err := a.signArtifact(ctx.Context, output); err != nil {
+								return err
+							}
+
+							definition := sample.Definition
+							fmt.Fprintf(os.Stderr, "Sampled %d of %d classified tasks (sample %s, seed %d)\n",
+								len(definition.TaskKeys), definition.Population, definition.ID, definition.Seed)
+							return nil
+						},
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:     "project",
+								Usage:    "Project key (e.g., FN)",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:     "quarter",
+								Usage:    "Quarter the tasks were created in (e.g., 2024-Q2)",
+								Required: true,
+							},
+							&cli.IntFlag{
+								Name:  "size",
+								Usage: "Number of tasks to sample",
+								Value: 25,
+							},
+							&cli.StringFlag{
+								Name:  "stratify",
+								Usage: "Draw the sample proportionally per group (worktype)",
+							},
+							&cli.Int64Flag{
+								Name:  "seed",
+								Usage: "Random seed; reuse a recorded seed to reproduce a sample",
+							},
+							&cli.StringFlag{
+								Name:  "output",
+								Usage: "Write the sample CSV to this file instead of stdout",
+							},
+						},
+					},
+					{
+						Name:  "coverage",
+						Usage: "Report the share of a quarter's done tasks with a work type label and an asset link",
+						Action: func(ctx *cli.Context) error {
+							format := ctx.String("format")
+							if format != "json" && format != "text" {
+								return fmt.Errorf("unsupported format: %s", format)
+							}
+							var workType, asset *float64
+							if ctx.IsSet("work-type-threshold") {
+								value := ctx.Float64("work-type-threshold")
+								workType = &value
+							}
+							if ctx.IsSet("asset-threshold") {
+								value := ctx.Float64("asset-threshold")
+								asset = &value
+							}
+							input := coverageInput(a.coverage, ctx.String("project"), ctx.String("quarter"), workType, asset)
+							coverage, err := a.taskService.LabelCoverage(ctx.Context, input)
+							if err != nil {
+								return fmt.Errorf("failed to measure label coverage: %w", err)
+							}
+
+							if format == "json" {
+								if err := printJSON(coverage); err != nil {
+									return fmt.Errorf("failed to encode coverage: %w", err)
+								}
+							} else {
+								printLabelCoverage(coverage)
+							}
+							if badge := ctx.String("badge"); badge != "" {
+								if err := writeCoverageBadge(badge, coverage); err != nil {
+									return err
+								}
+							}
+
+							if !coverage.Passed {
+								return fmt.Errorf("label coverage of %s in %s is below its thresholds", coverage.Project, coverage.Quarter)
+							}
+							return nil
+						},
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:     "project",
+								Aliases:  []string{"p"},
+								Usage:    "Project key (e.g., FN)",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:     "quarter",
+								Usage:    "Quarter the tasks were created in (e.g., 2024-Q2)",
+								Required: true,
+							},
+							&cli.Float64Flag{
+								Name:  "work-type-threshold",
+								Usage: "Minimum percentage of done tasks with a work type label, overriding the configured one; 0 disables the check",
+							},
+							&cli.Float64Flag{
+								Name:  "asset-threshold",
+								Usage: "Minimum percentage of done tasks linked to an asset, overriding the configured one; 0 disables the check",
+							},
+							&cli.StringFlag{
+								Name:  "badge",
+								Usage: "Also write a badge to this file: an SVG image (.svg) or a shields.io endpoint document (.json)",
+							},
+							&cli.StringFlag{
+								Name:  "format",
+								Usage: "Output format: text or json",
+								Value: "text",
+							},
+						},
+					},
+					a.taskNoteCommand(),
+					{
+						Name:  "label-history",
+						Usage: "Show when the cap-* labels of an issue were added or removed, and by whom",
+						Action: func(ctx *cli.Context) error {
+							format := ctx.String("format")
+							if format != "json" && format != "text" {
+								return fmt.Errorf("unsupported format: %s", format)
+							}
+							history, err := a.taskService.LabelHistory(ctx.Context, ctx.String("issue"))
+							if err != nil {
+								return err
+							}
+							if format == "json" {
+								if err := printJSON(history); err != nil {
+									return fmt.Errorf("failed to encode label history: %w", err)
+								}
+								return nil
+							}
+							printLabelHistory(history)
+							return nil
+						},
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:     "issue",
+								Aliases:  []string{"i"},
+								Usage:    "Issue key (e.g., FN-123)",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:  "format",
+								Usage: "Output format: text or json",
+								Value: "text",
+							},
+						},
+					},
+					{
+						Name:  "delete",
+						Usage: "Delete the stored tasks of a project and sprint, keeping them in the trash until the retention window ends",
+						Action: func(ctx *cli.Context) error {
+							project := ctx.String("project")
+							sprint := ctx.String("sprint")
+							purge := ctx.Bool("purge")
+							if err := a.taskService.DeleteTasks(ctx.Context, project, sprint, purge); err != nil {
+								return err
+							}
+							if purge {
+								fmt.Printf("Permanently deleted tasks for project %s, sprint %s\n", project, sprint)
+							} else {
+								fmt.Printf("Moved tasks for project %s, sprint %s to the trash\n", project, sprint)
+							}
+							return nil
+						},
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:     "project",
+								Usage:    "Project key (e.g., FN)",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:     "sprint",
+								Usage:    "Sprint name (e.g., Penguins)",
+								Required: true,
+							},
+							&cli.BoolFlag{
+								Name:  "purge",
+								Usage: "Delete permanently, including tasks already in the trash",
+							},
+						},
+					},
+					{
+						Name:  "split",
+						Usage: "Divide an issue's hours across work types in allocations and reports, or list the splits",
+						Action: func(ctx *cli.Context) error {
+							issueKey := ctx.String("issue")
+							if issueKey == "" {
+								splits, err := a.taskService.ListTaskSplits(ctx.Context)
+								if err != nil {
+									return err
+								}
+								if len(splits) == 0 {
+									fmt.Println("No work type splits")
+									return nil
+								}
+								for _, split := range splits {
+									fmt.Printf("%s: %s\n", split.IssueKey, split)
+								}
+								return nil
+							}
+
+							if ctx.Bool("clear") {
+								if err := a.taskService.RemoveTaskSplit(ctx.Context, issueKey); err != nil {
+									return err
+								}
+								fmt.Printf("Removed the work type split of %s\n", strings.ToUpper(issueKey))
+								return nil
+							}
+
+							shares := make(map[domain.WorkType]float64)
+							for flag, workType := range map[string]domain.WorkType{
+								"development": domain.WorkTypeDevelopment,
+								"maintenance": domain.WorkTypeMaintenance,
+								"discovery":   domain.WorkTypeDiscovery,
+							} {
+								if ctx.IsSet(flag) {
+									shares[workType] = ctx.Float64(flag)
+								}
+							}
+							split, err := a.taskService.SplitTask(ctx.Context, issueKey, shares)
+							if err != nil {
+								return err
+							}
+							fmt.Printf("Split %s: %s\n", split.IssueKey, split)
+							return nil
+						},
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  "issue",
+								Usage: "Issue key to split, e.g. FN-123 (omit to list the splits)",
+							},
+							&cli.Float64Flag{
+								Name:  "development",
+								Usage: "Percentage of the issue's hours that is development (cap-development)",
+							},
+							&cli.Float64Flag{
+								Name:  "maintenance",
+								Usage: "Percentage of the issue's hours that is maintenance (cap-maintenance)",
+							},
+							&cli.Float64Flag{
+								Name:  "discovery",
+								Usage: "Percentage of the issue's hours that is discovery (cap-discovery)",
+							},
+							&cli.BoolFlag{
+								Name:  "clear",
+								Usage: "Remove the issue's split so its hours go to its work type label again",
+							},
+						},
+					},
+					{
+						Name:  "code",
+						Usage: "Link the commits and pull requests mentioning issue keys to the stored tasks",
+						Subcommands: []*cli.Command{
+							{
+								Name:  "link",
+								Usage: "Look up the code changes referencing the sprint's tasks on the configured code host",
+								Action: func(ctx *cli.Context) error {
+									result, err := a.taskService.LinkCodeChanges(ctx.Context, domain.CodeLinkInput{
+										Project: ctx.String("project"),
+										Sprint:  ctx.String("sprint"),
+									})
+									if err != nil {
+										return err
+									}
+									fmt.Printf("Updated the code references of %d task(s)\n", result.Updated)
+									printCodeLinks(*result)
+									return nil
+								},
+								Flags: codeLinkFlags(),
+							},
+							{
+								Name:  "show",
+								Usage: "Show the stored code references of the sprint's tasks",
+								Action: func(ctx *cli.Context) error {
+									project := ctx.String("project")
+									sprint := ctx.String("sprint")
+									tasks, err := a.taskService.GetTasks(ctx.Context, project, sprint)
+									if err != nil {
+										return err
+									}
+									printTaskCodeReferences(tasks)
+									printCodeLinks(domain.SummarizeCodeLinks(project, sprint, tasks))
+									return nil
+								},
+								Flags: codeLinkFlags(),
+							},
+						},
+					},
+				},
+			},
+			{
+				Name:  "validate-config",
+				Usage: "Check teams.json and the asset and task stores against their schemas",
+				Action: func(ctx *cli.Context) error {
+					return validateDataFiles([]dataFile{
+						{document: schema.Teams, path: a.dataFilePath(ctx, "teams", teamsFile)},
+						{document: schema.Assets, path: a.dataFilePath(ctx, "assets", assetsFile)},
+						{document: schema.Tasks, path: a.dataFilePath(ctx, "tasks", tasksFile)},
+					})
+				},
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "teams",
+						Usage: "Path of the teams file (defaults to teams.json in the storage directory)",
+					},
+					&cli.StringFlag{
+						Name:  "assets",
+						Usage: "Path of the assets file (defaults to assets.json in the storage directory)",
+					},
+					&cli.StringFlag{
+						Name:  "tasks",
+						Usage: "Path of the tasks file (defaults to tasks.json in the storage directory)",
+					},
+				},
+			},
+			{
+				Name:  "telemetry",
+				Usage: "Opt in to or out of anonymous usage statistics",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "enable",
+						Usage: "Record the name, duration, result and dataset sizes of each command run",
+						Action: func(ctx *cli.Context) error {
+							state, err := a.telemetryRecorder().Enable()
+							if err != nil {
+								return err
+							}
+							fmt.Printf("Telemetry enabled (install ID %s)\n", state.InstallID)
+							fmt.Println("Only command names, durations, results and dataset sizes are recorded, never their content.")
+							return nil
+						},
+					},
+					{
+						Name:  "disable",
+						Usage: "Stop recording and discard the events not sent yet",
+						Action: func(ctx *cli.Context) error {
+							if err := a.telemetryRecorder().Disable(); err != nil {
+								return err
+							}
+							fmt.Println("Telemetry disabled")
+							return nil
+						},
+					},
+					{
+						Name:  "status",
+						Usage: "Show whether telemetry is enabled, its endpoint and the buffered events",
+						Action: func(ctx *cli.Context) error {
+							status, err := a.telemetryRecorder().Status()
+							if err != nil {
+								return err
+							}
+							printTelemetryStatus(status)
+							return nil
+						},
+					},
+				},
+			},
+			{
+				Name:  "trash",
+				Usage: "List and restore deleted assets and tasks",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "list",
+						Usage: "List the deleted assets and tasks that can still be restored",
+						Action: func(ctx *cli.Context) error {
+							assets, err := a.assetService.ListDeletedAssets()
+							if err != nil {
+								return err
+							}
+							tasks, err := a.taskService.ListDeletedTasks(ctx.Context)
+							if err != nil {
+								return err
+							}
+							printTrash(assets, tasks)
+							return nil
+						},
+					},
+					{
+						Name:  "restore",
+						Usage: "Restore a deleted asset or task",
+						Action: func(ctx *cli.Context) error {
+							asset := ctx.String("asset")
+							task := ctx.String("task")
+							if asset == "" && task == "" {
+								return fmt.Errorf("an asset or a task to restore is required")
+							}
+							if asset != "" {
+								if _, err := a.assetService.RestoreAsset(asset); err != nil {
+									return err
+								}
+								fmt.Printf("Restored asset: %s\n", asset)
+							}
+							if task != "" {
+								if _, err := a.taskService.RestoreTask(ctx.Context, task); err != nil {
+									return err
+								}
+								fmt.Printf("Restored task: %s\n", task)
+							}
+							return nil
+						},
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  "asset",
+								Usage: "Name of the deleted asset",
+							},
+							&cli.StringFlag{
+								Name:  "task",
+								Usage: "Key of the deleted task",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
 
+	applyFlagDefaults(app.Commands, a.defaults)
+	addSchemaFlags(app.Commands, "")
+	a.instrument(app.Commands, "")
 	return app.Run(os.Args)
 }
 
-// initializeApp creates a new App instance with all dependencies
-func initializeApp() (*App, error) {
-	// Initialize repositories
-	config := assetsinfra.RepositoryConfig{
-		Directory: assetsDir,
-		Filename:  assetsFile,
-		FileMode:  0644,
-		DirMode:   0755,
+func main() {
+	if err := config.LoadCredentials(config.DefaultCredentialsPath); err != nil {
+		log.Fatal(err)
+	}
+	redact.FromEnv()
+	log.SetOutput(redact.Writer(os.Stderr))
+	// init, auth, config, status and teams run before the wiring, which needs the Jira settings
+	// they manage or report, and teams.json is managed without Jira
+	if len(os.Args) > 1 && (os.Args[1] == "init" || os.Args[1] == "auth" || os.Args[1] == "config" || os.Args[1] == "status" || os.Args[1] == "teams") {
+		app := &cli.App{Name: "AssetCap", Commands: []*cli.Command{
+			initCommand(os.Stdin), authCommand(os.Stdin, config.DefaultPath), configCommand(config.DefaultPath), statusCommand(config.DefaultPath),
+			teamsCommand(config.DefaultPath),
+		}}
+		if cfg, err := config.Load(config.DefaultPath); err == nil {
+			applyFlagDefaults(app.Commands, cfg.Defaults)
+		}
+		if err := app.Run(os.Args); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	app, err := initializeApp(config.DefaultPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := app.Run(); err != nil {
+		var partial *partialFailureError
+		if errors.As(err, &partial) {
+			log.Print(err)
+			os.Exit(exitPartialFailure)
+		}
+		log.Fatal(err)
+	}
+}
+
+// assetImpairments converts the impairments recorded on assets into report impairments
+func assetImpairments(assets []*assetsdomain.Asset) []sprintdomain.AssetImpairment {
+	var impairments []sprintdomain.AssetImpairment
+	for _, asset := range assets {
+		for _, impairment := range asset.Impairments {
+			impairments = append(impairments, sprintdomain.AssetImpairment{
+				AssetName: asset.Name,
+				Date:      impairment.Date,
+				Reason:    impairment.Reason,
+				Amount:    impairment.Amount,
+			})
+		}
+	}
+	return impairments
+}
+
+// assetPolicy converts the bug-fix windows configured on assets into report policy rules
+func assetPolicy(assets []*assetsdomain.Asset) []sprintdomain.PolicyRule {
+	var rules []sprintdomain.PolicyRule
+	for _, asset := range assets {
+		if asset.BugFixWindowDays <= 0 || asset.LaunchDate.IsZero() {
+			continue
+		}
+		rules = append(rules, sprintdomain.BugFixWindow{
+			AssetName:  asset.Name,
+			LaunchDate: asset.LaunchDate,
+			Days:       asset.BugFixWindowDays,
+		})
+	}
+	return rules
+}
+
+// assetStatuses converts the status history of assets into the statuses reports resolve by
+// period. Assets without a history keep their current status for every period.
+func assetStatuses(assets []*assetsdomain.Asset) sprintdomain.AssetStatuses {
+	var statuses sprintdomain.AssetStatuses
+	for _, asset := range assets {
+		var changes []sprintdomain.AssetStatusChange
+		for _, entry := range asset.StatusHistory {
+			changes = append(changes, sprintdomain.AssetStatusChange{Status: entry.Status, From: entry.EffectiveFrom})
+		}
+		if len(changes) == 0 && asset.Status != "" {
+			changes = []sprintdomain.AssetStatusChange{{Status: asset.Status}}
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		if statuses == nil {
+			statuses = make(sprintdomain.AssetStatuses)
+		}
+		statuses[asset.Name] = changes
+	}
+	return statuses
+}
+
+// assetRetirements collects the retirement dates of retired assets, so work started after them
+// is no longer attributed to the assets
+func assetRetirements(assets []*assetsdomain.Asset) sprintdomain.AssetRetirements {
+	var retirements sprintdomain.AssetRetirements
+	for _, asset := range assets {
+		if !asset.IsRetired() {
+			continue
+		}
+		if retirements == nil {
+			retirements = make(sprintdomain.AssetRetirements)
+		}
+		retirements[asset.Name] = asset.RetiredAt
+	}
+	return retirements
+}
+
+// statusEffectiveDate formats when a status took effect; the initial status has no date
+func statusEffectiveDate(entry assetsdomain.StatusEntry) string {
+	if entry.EffectiveFrom.IsZero() {
+		return "initially"
+	}
+	return entry.EffectiveFrom.Format("2006-01-02")
+}
+
+// dataFile is a data file checked by validate-config
+type dataFile struct {
+	document schema.Document
+	path     string
+}
+
+// dataFilePath returns the path given by a flag, or the file in the storage directory
+func (a *App) dataFilePath(ctx *cli.Context, flag, file string) string {
+	if ctx.IsSet(flag) {
+		return ctx.String(flag)
+	}
+	return filepath.Join(a.storageDir, file)
+}
+
+// validateDataFiles checks each data file against its schema, printing the problems found
+func validateDataFiles(files []dataFile) error {
+	invalid := 0
+	for _, file := range files {
+		data, err := os.ReadFile(file.path)
+		if os.IsNotExist(err) {
+			fmt.Printf("%s: not found, skipped\n", file.path)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", file.path, err)
+		}
+
+		if err := schema.Validate(file.document, data); err != nil {
+			invalid++
+			fmt.Printf("%s: %v\n", file.path, err)
+			continue
+		}
+		fmt.Printf("%s: OK\n", file.path)
+	}
+
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d data files are invalid", invalid, len(files))
+	}
+	return nil
+}
+
+// printTrash prints the deleted assets and tasks with their deletion and expiry dates
+func printTrash(assets []*assetsdomain.DeletedAsset, tasks []*domain.DeletedTask) {
+	if len(assets) == 0 && len(tasks) == 0 {
+		fmt.Println("Trash is empty")
+		return
+	}
+	if len(assets) > 0 {
+		fmt.Println("Deleted assets:")
+		for _, deleted := range assets {
+			fmt.Printf("- %s (deleted %s, expires %s)\n", deleted.Asset.Name,
+				deleted.DeletedAt.Format("2006-01-02 15:04"), deleted.ExpiresAt.Format("2006-01-02"))
+		}
+	}
+	if len(tasks) > 0 {
+		fmt.Println("Deleted tasks:")
+		for _, deleted := range tasks {
+			fmt.Printf("- %s %s [%s, %s] (deleted %s, expires %s)\n", deleted.Task.Key, deleted.Task.Summary,
+				deleted.Task.Project, deleted.Task.Sprint,
+				deleted.DeletedAt.Format("2006-01-02 15:04"), deleted.ExpiresAt.Format("2006-01-02"))
+		}
+	}
+}
+
+// printCatalogDiff prints the catalogue changes grouped by kind
+func printCatalogDiff(diff *assetsdomain.CatalogDiff) {
+	fmt.Printf("Changes since %s:\n", diff.Reference)
+	if diff.IsEmpty() {
+		fmt.Println("No changes")
+		return
+	}
+	if len(diff.Added) > 0 {
+		fmt.Println("\nAdded:")
+		for _, name := range diff.Added {
+			fmt.Printf("  + %s\n", name)
+		}
+	}
+	if len(diff.Removed) > 0 {
+		fmt.Println("\nRemoved:")
+		for _, name := range diff.Removed {
+			fmt.Printf("  - %s\n", name)
+		}
+	}
+	if len(diff.StatusChanges) > 0 {
+		fmt.Println("\nStatus changes:")
+		for _, change := range diff.StatusChanges {
+			fmt.Printf("  ~ %s: %q -> %q\n", change.Asset, change.Before, change.After)
+		}
+	}
+	if len(diff.FieldEdits) > 0 {
+		fmt.Println("\nField edits:")
+		for _, edit := range diff.FieldEdits {
+			fmt.Printf("  ~ %s.%s: %q -> %q\n", edit.Asset, edit.Field, edit.Before, edit.After)
+		}
+	}
+}
+
+// assetDocLinks maps each documented asset's name to its Confluence page
+func assetDocLinks(assets []*assetsdomain.Asset) sprintdomain.AssetDocLinks {
+	var links sprintdomain.AssetDocLinks
+	for _, asset := range assets {
+		if asset.DocLink == "" {
+			continue
+		}
+		if links == nil {
+			links = make(sprintdomain.AssetDocLinks)
+		}
+		links[asset.Name] = asset.DocLink
+	}
+	return links
+}
+
+// assetAccounts books every asset to its cost center and GL account, falling back to the
+// configured defaults; nil when no asset is booked anywhere
+func (a *App) assetAccounts(assets []*assetsdomain.Asset) sprintdomain.AssetAccounts {
+	var accounts sprintdomain.AssetAccounts
+	for _, asset := range assets {
+		account := sprintdomain.AssetAccount{CostCenter: asset.CostCenter, GLAccount: asset.GLAccount}
+		if account.CostCenter == "" {
+			account.CostCenter = a.defaultAccount.CostCenter
+		}
+		if account.GLAccount == "" {
+			account.GLAccount = a.defaultAccount.GLAccount
+		}
+		if account == (sprintdomain.AssetAccount{}) {
+			continue
+		}
+		if accounts == nil {
+			accounts = make(sprintdomain.AssetAccounts)
+		}
+		accounts[asset.Name] = account
+	}
+	return accounts
+}
+
+// printAssetActivity prints what changed on an asset during a sprint
+func printAssetActivity(activity *sprintdomain.AssetActivity) {
+	fmt.Printf("Asset %s in %s (%s)\n\n", activity.Asset, activity.Sprint, activity.Project)
+	fmt.Printf("Completed tasks (%d):\n", len(activity.Completed))
+	for _, task := range activity.Completed {
+		fmt.Printf("- %s %s [%s] %.2f h, %s\n", task.IssueKey, task.IssueTitle, strings.Join(task.WorkTypes, ", "), task.Hours, strings.Join(task.Assignees, ", "))
+	}
+	if len(activity.Completed) == 0 {
+		fmt.Println("- none")
+	}
+	fmt.Printf("\nTotal hours: %.2f h\n", activity.TotalHours)
+	fmt.Printf("Work type mix: %s\n", activity.WorkTypeMixString())
+	if activity.Summary != "" {
+		fmt.Printf("\nSummary:\n%s\n", activity.Summary)
+	}
+}
+
+// printTaskBreakdown prints the asset's linked tasks by work type and by sprint, and the
+// allocated hours by work type when a sprint activity is given
+func printTaskBreakdown(breakdown *assetsdomain.TaskBreakdown, activity *sprintdomain.AssetActivity) {
+	if breakdown.Tasks > 0 {
+		fmt.Println("Tasks by work type:")
+		for _, count := range breakdown.WorkTypes {
+			fmt.Printf("  %s: %d\n", count.WorkType, count.Tasks)
+		}
+		fmt.Println("Tasks by sprint:")
+		for _, sprint := range breakdown.Sprints {
+			name := sprint.Sprint
+			if name == "" {
+				name = "no sprint"
+			}
+			counts := make([]string, 0, len(sprint.WorkTypes))
+			for _, count := range sprint.WorkTypes {
+				counts = append(counts, fmt.Sprintf("%s %d", count.WorkType, count.Tasks))
+			}
+			fmt.Printf("  %s: %d (%s)\n", name, sprint.Tasks, strings.Join(counts, ", "))
+		}
+	}
+	if activity == nil {
+		return
+	}
+	fmt.Printf("Hours in %s (%s): %.2f h\n", activity.Sprint, activity.Project, activity.TotalHours)
+	for _, share := range activity.WorkTypeMix {
+		fmt.Printf("  %s: %.2f h (%.1f%%)\n", share.WorkType, share.Hours, share.Percentage)
+	}
+}
+
+// printAbsences prints the recorded absences of every team member, by member name
+func printAbsences(calendar sprintdomain.AbsenceCalendar) {
+	if len(calendar) == 0 {
+		fmt.Println("No absences recorded")
+		return
+	}
+	members := make([]string, 0, len(calendar))
+	for member := range calendar {
+		members = append(members, member)
 	}
-	assetRepo := assetsinfra.NewJSONRepository(config)
-	assetService := assetsapp.NewAssetService(assetRepo)
+	sort.Strings(members)
+	for _, member := range members {
+		fmt.Printf("%s:\n", member)
+		for _, absence := range calendar[member] {
+			fmt.Printf("  %s to %s (%d days)", absence.From.Format("2006-01-02"), absence.To.Format("2006-01-02"), absence.Days())
+			if absence.Reason != "" {
+				fmt.Printf(" %s", absence.Reason)
+			}
+			fmt.Println()
+		}
+	}
+}
 
-	// Initialize task repositories
-	var jiraRepo taskports.TaskRepository
-	var err error
-	jiraRepo, err = jira.NewRepository()
+// workTypeSplits returns the stored per-issue work type splits for the allocation
+func (a *App) workTypeSplits(ctx context.Context) (sprintdomain.WorkTypeSplits, error) {
+	stored, err := a.taskService.ListTaskSplits(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize Jira repository: %v", err)
+		return nil, fmt.Errorf("failed to load work type splits: %w", err)
 	}
 
-	localRepo := storage.NewJSONStorage(tasksDir, tasksFile)
-	taskClassifier := classifier.NewRandomClassifier()
-	userInput := cliui.NewUserInput()
-	taskService := tasksapp.NewTasksService(jiraRepo, localRepo, taskClassifier, userInput)
+	var splits sprintdomain.WorkTypeSplits
+	for _, split := range stored {
+		if splits == nil {
+			splits = make(sprintdomain.WorkTypeSplits)
+		}
+		shares := make(sprintdomain.WorkTypeShares, len(split.Shares))
+		for workType, share := range split.Shares {
+			shares[string(workType)] = share
+		}
+		splits[split.IssueKey] = shares
+	}
+	return splits, nil
+}
 
-	// Initialize sprint service
-	jiraAdapter, err := sprintinfra.NewJiraAdapter(teamsFile)
+// capitalizationReport builds the capitalization report of the projects' sprint, the latest
+// stored one when no sprint is given
+func (a *App) capitalizationReport(ctx context.Context, assets []*assetsdomain.Asset, projects []string, sprint string) (*sprintdomain.CapitalizationReport, error) {
+	if sprint == "" {
+		coverage, err := a.taskService.ClassificationCoverage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if sprint, err = latestSprint(dashboardCoverage(coverage, projects), projects); err != nil {
+			return nil, err
+		}
+	}
+	splits, err := a.workTypeSplits(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize Jira adapter: %v", err)
+		return nil, err
+	}
+	return a.sprintService.BuildCapitalizationReport(sprintdomain.CapitalizationReportInput{
+		Projects:       projects,
+		Sprint:         sprint,
+		Impairments:    assetImpairments(assets),
+		Policy:         assetPolicy(assets),
+		AssetDocs:      assetDocLinks(assets),
+		AssetAccounts:  a.assetAccounts(assets),
+		AssetStatuses:  assetStatuses(assets),
+		Retirements:    assetRetirements(assets),
+		WorkTypeSplits: splits,
+		Heuristics:     a.heuristics,
+		Export:         a.jiraExport,
+	})
+}
+
+// loadReportTemplate resolves a built-in report template by name or reads a template file
+func loadReportTemplate(nameOrPath string) (*sprintdomain.ReportTemplate, error) {
+	if template, ok := sprintusecase.BuiltinReportTemplate(nameOrPath); ok {
+		return template, nil
 	}
-	sprintService := sprintapp.NewSprintService(jiraAdapter)
 
-	return NewApp(assetService, taskService, sprintService), nil
+	body, err := os.ReadFile(nameOrPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report template: %w", err)
+	}
+	name := filepath.Base(nameOrPath)
+	lower := strings.ToLower(name)
+	return &sprintdomain.ReportTemplate{
+		Name: name,
+		Body: string(body),
+		HTML: strings.HasSuffix(lower, ".html") || strings.HasSuffix(lower, ".html.tmpl") || strings.HasSuffix(lower, ".gohtml"),
+	}, nil
 }
 
-func main() {
-	app, err := initializeApp()
+// exportLocale resolves the --locale, --percent-format and --percent-decimals flags, falling
+// back to the configured export settings
+func (a *App) exportLocale(ctx *cli.Context) (sprintdomain.Locale, error) {
+	export := a.export
+	if ctx.IsSet("locale") {
+		export.Locale = ctx.String("locale")
+	}
+	if ctx.IsSet("percent-format") {
+		export.PercentFormat = ctx.String("percent-format")
+	}
+	if ctx.IsSet("percent-decimals") {
+		decimals := ctx.Int("percent-decimals")
+		export.PercentDecimals = &decimals
+	}
+	return configuredLocale(export)
+}
+
+// configuredLocale returns the locale and percent format of the export settings
+func configuredLocale(export config.ExportConfig) (sprintdomain.Locale, error) {
+	locale, err := sprintdomain.ParseLocale(export.Locale)
 	if err != nil {
-		log.Fatal(err)
+		return sprintdomain.Locale{}, fmt.Errorf("invalid export locale: %w", err)
+	}
+	format, err := sprintdomain.ParsePercentFormat(export.PercentFormat)
+	if err != nil {
+		return sprintdomain.Locale{}, fmt.Errorf("invalid export percent format: %w", err)
+	}
+	locale, err = locale.WithPercentFormat(format, export.PercentDecimals)
+	if err != nil {
+		return sprintdomain.Locale{}, fmt.Errorf("invalid export percent format: %w", err)
+	}
+	return locale, nil
+}
+
+// percentFormatFlag returns the flag selecting whether exports write percentages with a
+// percent sign or as fractions of one
+func percentFormatFlag() *cli.StringFlag {
+	return &cli.StringFlag{
+		Name:  "percent-format",
+		Usage: "Write percentages with a percent sign (percent) or as fractions of one (fraction); defaults to the configured export percent format",
 	}
+}
 
-	if err := app.Run(); err != nil {
-		log.Fatal(err)
+// percentDecimalsFlag returns the flag selecting the decimals of exported percentages
+func percentDecimalsFlag() *cli.IntFlag {
+	return &cli.IntFlag{
+		Name:  "percent-decimals",
+		Usage: "Decimals of percentages (defaults to 2 with a percent sign and 4 as a fraction)",
+	}
+}
+
+// normalizeFlag returns the flag selecting what the allocation percentages are a share of
+func normalizeFlag() *cli.StringFlag {
+	return &cli.StringFlag{
+		Name:  "normalize",
+		Usage: "Percentages as shares of each person's time (person) or of the team's time weighted by the capacity in teams.json (capacity)",
+		Value: string(sprintdomain.NormalizationPerson),
+	}
+}
+
+// parseNormalization returns the normalization chosen on the command line, empty when none was
+func parseNormalization(ctx *cli.Context) (sprintdomain.Normalization, error) {
+	if !ctx.IsSet("normalize") {
+		return "", nil
+	}
+	return sprintdomain.ParseNormalization(ctx.String("normalize"))
+}
+
+// allocationPersonWorkTypes returns the work type overrides of the team members in a sprint:
+// the configured ones with those of --person-worktype taking precedence
+func (a *App) allocationPersonWorkTypes(ctx *cli.Context, sprint string) (sprintdomain.PersonWorkTypes, error) {
+	flags, err := sprintdomain.ParsePersonWorkTypes(ctx.StringSlice("person-worktype"))
+	if err != nil {
+		return nil, err
+	}
+	overrides := a.personWorkTypes[sprint].Merge(flags)
+	if len(overrides) == 0 {
+		return nil, nil
+	}
+	return overrides, nil
+}
+
+// applyAllocationMethod sets the allocation method and story point snapshot chosen on the command line
+func applyAllocationMethod(ctx *cli.Context, input *sprintdomain.AllocationInput) error {
+	if !ctx.IsSet("method") && !ctx.IsSet("points-at") {
+		return nil
+	}
+	method, err := sprintdomain.ParseAllocationMethod(ctx.String("method"))
+	if err != nil {
+		return err
+	}
+	pointsAt, err := sprintdomain.ParsePointsAt(ctx.String("points-at"))
+	if err != nil {
+		return err
+	}
+	if ctx.IsSet("points-at") && !method.Uses(sprintdomain.AllocationMethodStoryPoints) {
+		return fmt.Errorf("--points-at requires --method %s", sprintdomain.AllocationMethodStoryPoints)
+	}
+	input.Method = method
+	input.PointsAt = pointsAt
+	return nil
+}
+
+// printExplanation prints an allocation explanation: the issue's timeline, the steps applied
+// to it and the formula behind each team member's share
+func printExplanation(explanation *sprintdomain.AllocationExplanation) {
+	fmt.Printf("%s: %s\n", explanation.IssueKey, explanation.Summary)
+	fmt.Printf("Type: %s, status: %s, assignee: %s, period: %s, method: %s\n",
+		explanation.IssueType, explanation.Status, explanation.Assignee, explanation.Period, explanation.Method)
+
+	fmt.Println("\nTimeline:")
+	if len(explanation.Timeline) == 0 {
+		fmt.Println("  (no changelog)")
+	}
+	for _, event := range explanation.Timeline {
+		marker := " "
+		if event.Counted {
+			marker = "*"
+		}
+		fmt.Printf("%s %s  %s: %q -> %q  %s\n", marker, event.At.UTC().Format("2006-01-02 15:04 MST"), event.Field, event.From, event.To, event.Note)
+	}
+
+	fmt.Println("\nSteps:")
+	for i, step := range explanation.Steps {
+		fmt.Printf("  %d. %s\n", i+1, step)
+	}
+
+	if len(explanation.Shares) == 0 {
+		return
+	}
+	fmt.Println("\nAllocation:")
+	for _, share := range explanation.Shares {
+		fmt.Printf("  %s: %.2f h, %.2f%%\n", share.Assignee, share.Hours, share.Percentage)
+		for _, line := range share.Formula {
+			fmt.Printf("    %s\n", line)
+		}
+	}
+}
+
+// printAllocationProgress overwrites a single stderr line with the number of allocated issues
+func printAllocationProgress(done, total int) {
+	fmt.Fprintf(os.Stderr, "\rAllocated %d/%d issues", done, total)
+	if done == total {
+		fmt.Fprintln(os.Stderr)
+	}
+}
+
+// sprintOrFixVersion returns the command's --sprint and --fix-version flags, exactly one of which must be set
+func sprintOrFixVersion(ctx *cli.Context) (string, string, error) {
+	sprint, fixVersion := ctx.String("sprint"), ctx.String("fix-version")
+	if sprint != "" && fixVersion != "" {
+		return "", "", fmt.Errorf("--sprint and --fix-version cannot be combined")
+	}
+	if sprint == "" && fixVersion == "" {
+		return "", "", fmt.Errorf("either --sprint or --fix-version is required")
+	}
+	return sprint, fixVersion, nil
+}
+
+// webhookHandler returns the Jira webhook receiver of serve webhooks, applying the events of
+// the tracked projects to the task store once their signature is verified
+func (a *App) webhookHandler(ctx *cli.Context) (http.Handler, error) {
+	secret, err := signingSecret(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reclassify := ctx.Bool("reclassify")
+	return jira.NewWebhookHandler(ctx.StringSlice("project"), secret,
+		func(eventCtx context.Context, event domain.IssueEvent) error {
+			input := domain.IssueEventInput{Event: event, Reclassify: reclassify}
+			if reclassify {
+				rules, err := a.classificationRules()
+				if err != nil {
+					return err
+				}
+				input.Rules = rules
+			}
+			return a.taskService.ApplyIssueEvent(eventCtx, input)
+		}), nil
+}
+
+// signingSecret returns the secret a listener verifies requests with, read from the environment
+// variable --secret-env names. Without one anyone reaching the port could call the listener, so
+// serving is refused unless --insecure-no-verify is set.
+func signingSecret(ctx *cli.Context) (string, error) {
+	name := ctx.String("secret-env")
+	if secret := os.Getenv(name); secret != "" {
+		return secret, nil
+	}
+	if !ctx.Bool("insecure-no-verify") {
+		return "", fmt.Errorf("%s is not set: set it to verify request signatures, or pass --insecure-no-verify to serve unverified requests", name)
+	}
+	log.Printf("Warning: %s is not set; request signatures will not be verified", name)
+	return "", nil
+}
+
+// serve runs an HTTP listener for the handler on the command's --addr and --path until interrupted
+func serve(ctx *cli.Context, handler http.Handler, description string) error {
+	mux := http.NewServeMux()
+	mux.Handle(ctx.String("path"), handler)
+	server := &http.Server{
+		Addr:              ctx.String("addr"),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	runCtx, stop := signal.NotifyContext(ctx.Context, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+	log.Printf("Listening for %s on %s%s", description, server.Addr, ctx.String("path"))
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("%s server stopped: %w", description, err)
+	case <-runCtx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
 	}
 }
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -75,19 +76,162 @@ func (m *MockAssetService) GenerateKeywords(name string) error {
 	return args.Error(0)
 }
 
-func (m *MockAssetService) EnrichAsset(name, field string) error {
-	args := m.Called(name, field)
+func (m *MockAssetService) GetTaskBreakdown(name string) (*assetsdomain.TaskBreakdown, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*assetsdomain.TaskBreakdown), args.Error(1)
+}
+
+func (m *MockAssetService) SummarizeActivity(name, activity string) (string, error) {
+	args := m.Called(name, activity)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockAssetService) ImpairAsset(name string, date time.Time, reason string, amount float64) error {
+	args := m.Called(name, date, reason, amount)
+	return args.Error(0)
+}
+
+func (m *MockAssetService) SetBugFixWindow(name string, days int, launchDate time.Time) error {
+	args := m.Called(name, days, launchDate)
 	return args.Error(0)
 }
 
-func (m *MockAssetService) DeleteAsset(name string) error {
+func (m *MockAssetService) SetStatus(name, status string, effective time.Time) error {
+	args := m.Called(name, status, effective)
+	return args.Error(0)
+}
+
+func (m *MockAssetService) RetireAsset(name string, date time.Time) (*assetsdomain.Asset, error) {
+	args := m.Called(name, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*assetsdomain.Asset), args.Error(1)
+}
+
+func (m *MockAssetService) ResolveDuplicate(primaryURL string) (*assetsdomain.DuplicateResolution, error) {
+	args := m.Called(primaryURL)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*assetsdomain.DuplicateResolution), args.Error(1)
+}
+
+func (m *MockAssetService) LinkDocumentation(name, docURL string) (*assetsdomain.Asset, error) {
+	args := m.Called(name, docURL)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*assetsdomain.Asset), args.Error(1)
+}
+
+func (m *MockAssetService) PreviewLive(name string) (*assetsdomain.LivePreview, error) {
 	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*assetsdomain.LivePreview), args.Error(1)
+}
+
+func (m *MockAssetService) DiffAssets(input assetsdomain.CatalogDiffInput) (*assetsdomain.CatalogDiff, error) {
+	args := m.Called(input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*assetsdomain.CatalogDiff), args.Error(1)
+}
+
+func (m *MockAssetService) EnrichAsset(name, field string, withAttachments bool) error {
+	args := m.Called(name, field, withAttachments)
+	return args.Error(0)
+}
+
+func (m *MockAssetService) ProposeEnrichment(name, field string, withAttachments, force bool) (*assetsdomain.EnrichmentProposal, error) {
+	args := m.Called(name, field, withAttachments, force)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*assetsdomain.EnrichmentProposal), args.Error(1)
+}
+
+func (m *MockAssetService) ApplyEnrichment(proposal *assetsdomain.EnrichmentProposal) error {
+	args := m.Called(proposal)
+	return args.Error(0)
+}
+
+func (m *MockAssetService) SetClassificationRules(name string, rules assetsdomain.ClassificationRules) error {
+	args := m.Called(name, rules)
+	return args.Error(0)
+}
+
+func (m *MockAssetService) SetOwner(name, owner string) error {
+	args := m.Called(name, owner)
+	return args.Error(0)
+}
+
+func (m *MockAssetService) SetAccounting(name, costCenter, glAccount string) error {
+	args := m.Called(name, costCenter, glAccount)
+	return args.Error(0)
+}
+
+func (m *MockAssetService) FindStaleDocumentation(policy assetsdomain.FreshnessPolicy) ([]assetsdomain.StaleDocumentation, error) {
+	args := m.Called(policy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]assetsdomain.StaleDocumentation), args.Error(1)
+}
+
+func (m *MockAssetService) DeleteAsset(name string, force bool) error {
+	args := m.Called(name, force)
+	return args.Error(0)
+}
+
+func (m *MockAssetService) PurgeAsset(name string, force bool) error {
+	args := m.Called(name, force)
 	return args.Error(0)
 }
 
-func (m *MockAssetService) SyncFromConfluence(space, label string, debug bool) (*assetsdomain.SyncResult, error) {
-	args := m.Called(space, label, debug)
-	return args.Get(0).(*assetsdomain.SyncResult), args.Error(1)
+func (m *MockAssetService) ArchiveAsset(name string, date time.Time, force bool) (*assetsdomain.Asset, error) {
+	args := m.Called(name, date, force)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*assetsdomain.Asset), args.Error(1)
+}
+
+func (m *MockAssetService) ListDeletedAssets() ([]*assetsdomain.DeletedAsset, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*assetsdomain.DeletedAsset), args.Error(1)
+}
+
+func (m *MockAssetService) RestoreAsset(name string) (*assetsdomain.Asset, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*assetsdomain.Asset), args.Error(1)
+}
+
+// SyncFromConfluence matches the input without its progress callback, which is told about
+// the spaces of the returned result
+func (m *MockAssetService) SyncFromConfluence(input assetsdomain.SyncInput) (*assetsdomain.SyncResult, error) {
+	progress := input.Progress
+	input.Progress = nil
+	args := m.Called(input)
+	result, _ := args.Get(0).(*assetsdomain.SyncResult)
+	if progress != nil && result != nil {
+		for _, space := range result.Spaces {
+			progress(*space)
+		}
+	}
+	return result, args.Error(1)
 }
 
 // MockTaskService is a mock implementation of TaskService
@@ -100,6 +244,16 @@ func (m *MockTaskService) FetchTasks(ctx context.Context, project, sprint, platf
 	return args.Error(0)
 }
 
+func (m *MockTaskService) FetchTasksByFixVersion(ctx context.Context, project, fixVersion, platform string) error {
+	args := m.Called(ctx, project, fixVersion, platform)
+	return args.Error(0)
+}
+
+func (m *MockTaskService) ImportTasks(ctx context.Context, tasks []*tasksdomain.Task, project string) error {
+	args := m.Called(ctx, tasks, project)
+	return args.Error(0)
+}
+
 func (m *MockTaskService) GetTasks(ctx context.Context, project, sprint string) ([]*tasksdomain.Task, error) {
 	args := m.Called(ctx, project, sprint)
 	return args.Get(0).([]*tasksdomain.Task), args.Error(1)
@@ -110,135 +264,404 @@ func (m *MockTaskService) GetTasksByAsset(ctx context.Context, asset string) ([]
 	return args.Get(0).([]*tasksdomain.Task), args.Error(1)
 }
 
+func (m *MockTaskService) GetTask(ctx context.Context, issueKey string) (*tasksdomain.Task, error) {
+	args := m.Called(ctx, issueKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*tasksdomain.Task), args.Error(1)
+}
+
 func (m *MockTaskService) ClassifyTasks(ctx context.Context, input tasksdomain.ClassifyTasksInput) error {
 	args := m.Called(ctx, input)
 	return args.Error(0)
 }
 
-func (m *MockTaskService) GetLocalRepository() taskports.TaskRepository {
-	args := m.Called()
-	return args.Get(0).(taskports.TaskRepository)
+func (m *MockTaskService) SampleTasks(ctx context.Context, input tasksdomain.SampleTasksInput) (*tasksdomain.TaskSample, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*tasksdomain.TaskSample), args.Error(1)
 }
 
-// MockSprintService is a mock implementation of SprintService
-type MockSprintService struct {
-	mock.Mock
+func (m *MockTaskService) OrgReport(ctx context.Context, input tasksdomain.OrgReportInput) (*tasksdomain.OrgReport, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*tasksdomain.OrgReport), args.Error(1)
 }
 
-func (m *MockSprintService) ProcessJiraIssues(project, sprint, override string) (string, error) {
-	args := m.Called(project, sprint, override)
-	return args.String(0), args.Error(1)
+func (m *MockTaskService) ClassificationCoverage(ctx context.Context) ([]tasksdomain.SprintCoverage, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]tasksdomain.SprintCoverage), args.Error(1)
 }
 
-func (m *MockSprintService) ProcessSprint(project string, sprint *sprintdomain.Sprint) error {
-	args := m.Called(project, sprint)
-	return args.Error(0)
+func (m *MockTaskService) LabelCoverage(ctx context.Context, input tasksdomain.LabelCoverageInput) (*tasksdomain.LabelCoverage, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*tasksdomain.LabelCoverage), args.Error(1)
 }
 
-func (m *MockSprintService) ProcessTeamIssues(team *sprintdomain.Team) error {
-	args := m.Called(team)
-	return args.Error(0)
+func (m *MockTaskService) LabelHistory(ctx context.Context, issueKey string) (*tasksdomain.LabelHistory, error) {
+	args := m.Called(ctx, issueKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*tasksdomain.LabelHistory), args.Error(1)
 }
 
-// MockTaskRepository is a mock implementation of TaskRepository
-type MockTaskRepository struct {
-	mock.Mock
+func (m *MockTaskService) ApplyIssueEvent(ctx context.Context, input tasksdomain.IssueEventInput) error {
+	args := m.Called(ctx, input)
+	return args.Error(0)
 }
 
-func (m *MockTaskRepository) Save(ctx context.Context, task *tasksdomain.Task) error {
-	args := m.Called(ctx, task)
+func (m *MockTaskService) DeleteTasks(ctx context.Context, project, sprint string, purge bool) error {
+	args := m.Called(ctx, project, sprint, purge)
 	return args.Error(0)
 }
 
-func (m *MockTaskRepository) FindByKey(ctx context.Context, key string) (*tasksdomain.Task, error) {
+func (m *MockTaskService) ListDeletedTasks(ctx context.Context) ([]*tasksdomain.DeletedTask, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*tasksdomain.DeletedTask), args.Error(1)
+}
+
+func (m *MockTaskService) RestoreTask(ctx context.Context, key string) (*tasksdomain.Task, error) {
 	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
 	return args.Get(0).(*tasksdomain.Task), args.Error(1)
 }
 
-func (m *MockTaskRepository) FindByProjectAndSprint(ctx context.Context, project, sprint string) ([]*tasksdomain.Task, error) {
-	args := m.Called(ctx, project, sprint)
-	return args.Get(0).([]*tasksdomain.Task), args.Error(1)
+func (m *MockTaskService) SplitTask(ctx context.Context, issueKey string, shares map[tasksdomain.WorkType]float64) (*tasksdomain.WorkTypeSplit, error) {
+	args := m.Called(ctx, issueKey, shares)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*tasksdomain.WorkTypeSplit), args.Error(1)
 }
 
-func (m *MockTaskRepository) FindByAsset(ctx context.Context, asset string) ([]*tasksdomain.Task, error) {
-	args := m.Called(ctx, asset)
-	return args.Get(0).([]*tasksdomain.Task), args.Error(1)
+func (m *MockTaskService) RemoveTaskSplit(ctx context.Context, issueKey string) error {
+	args := m.Called(ctx, issueKey)
+	return args.Error(0)
 }
 
-func setupTestEnvironment(t *testing.T) func() {
-	t.Helper()
-
-	// Save original stdout
-	oldStdout := os.Stdout
-
-	// Create test directory
-	testDir := filepath.Join("testdata", t.Name())
-	err := os.MkdirAll(testDir, 0755)
-	require.NoError(t, err, "Failed to create test directory")
-
-	// Create .assetcap directory
-	assetcapDir := filepath.Join(testDir, ".assetcap")
-	err = os.MkdirAll(assetcapDir, 0755)
-	require.NoError(t, err, "Failed to create .assetcap directory")
-
-	// Get current working directory
-	oldWd, err := os.Getwd()
-	require.NoError(t, err, "Failed to get working directory")
+func (m *MockTaskService) AddTaskNote(ctx context.Context, issueKey, text string) (*tasksdomain.TaskNote, error) {
+	args := m.Called(ctx, issueKey, text)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*tasksdomain.TaskNote), args.Error(1)
+}
 
-	// Change working directory to test directory
-	err = os.Chdir(testDir)
-	require.NoError(t, err, "Failed to change working directory")
+func (m *MockTaskService) TaskNotes(ctx context.Context, issueKeys ...string) (map[string][]tasksdomain.TaskNote, error) {
+	args := m.Called(ctx, issueKeys)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string][]tasksdomain.TaskNote), args.Error(1)
+}
 
-	return func() {
-		// Restore original stdout
-		os.Stdout = oldStdout
+func (m *MockTaskService) MapEpic(ctx context.Context, epicKey, asset, label string) (*tasksdomain.EpicMapping, error) {
+	args := m.Called(ctx, epicKey, asset, label)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*tasksdomain.EpicMapping), args.Error(1)
+}
 
-		// Restore original working directory
-		err := os.Chdir(oldWd)
-		if err != nil {
-			t.Errorf("Failed to restore working directory: %v", err)
-		}
+func (m *MockTaskService) UnmapEpic(ctx context.Context, epicKey string) error {
+	args := m.Called(ctx, epicKey)
+	return args.Error(0)
+}
 
-		// Clean up test directory
-		err = os.RemoveAll(filepath.Join(oldWd, "testdata", t.Name()))
-		if err != nil {
-			t.Errorf("Failed to clean up test directory: %v", err)
-		}
+func (m *MockTaskService) ListEpicMappings(ctx context.Context) ([]tasksdomain.EpicMapping, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
+	return args.Get(0).([]tasksdomain.EpicMapping), args.Error(1)
 }
 
-func captureOutput(f func() error) (string, error) {
-	r, w, err := os.Pipe()
-	if err != nil {
-		return "", err
+func (m *MockTaskService) UnmappedEpics(ctx context.Context, since time.Time) ([]tasksdomain.UnmappedEpic, error) {
+	args := m.Called(ctx, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
+	return args.Get(0).([]tasksdomain.UnmappedEpic), args.Error(1)
+}
 
-	oldStdout := os.Stdout
-	os.Stdout = w
-
-	errCh := make(chan error, 1)
-	outCh := make(chan string, 1)
+func (m *MockTaskService) ListTaskSplits(ctx context.Context) ([]tasksdomain.WorkTypeSplit, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]tasksdomain.WorkTypeSplit), args.Error(1)
+}
 
-	go func() {
-		funcErr := f()
-		w.Close()
-		errCh <- funcErr
-	}()
+func (m *MockTaskService) LinkCodeChanges(ctx context.Context, input tasksdomain.CodeLinkInput) (*tasksdomain.CodeLinkResult, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*tasksdomain.CodeLinkResult), args.Error(1)
+}
 
-	go func() {
-		var buf bytes.Buffer
-		io.Copy(&buf, r)
-		outCh <- buf.String()
-	}()
+func (m *MockTaskService) GetLocalRepository() taskports.TaskRepository {
+	args := m.Called()
+	return args.Get(0).(taskports.TaskRepository)
+}
 
-	err = <-errCh
-	os.Stdout = oldStdout
-	out := <-outCh
+// MockSprintService is a mock implementation of SprintService
+type MockSprintService struct {
+	mock.Mock
+}
 
-	return out, err
+func (m *MockSprintService) ProcessJiraIssues(input sprintdomain.AllocationInput) (string, error) {
+	args := m.Called(input)
+	return args.String(0), args.Error(1)
 }
 
-func TestRun(t *testing.T) {
+func (m *MockSprintService) AllocateIssues(input sprintdomain.AllocationInput) ([]sprintdomain.IssueAllocation, error) {
+	args := m.Called(input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]sprintdomain.IssueAllocation), args.Error(1)
+}
+
+func (m *MockSprintService) ProcessSprint(project string, sprint *sprintdomain.Sprint) error {
+	args := m.Called(project, sprint)
+	return args.Error(0)
+}
+
+func (m *MockSprintService) ProcessTeamIssues(team *sprintdomain.Team) error {
+	args := m.Called(team)
+	return args.Error(0)
+}
+
+func (m *MockSprintService) LintAssignees(project, sprint, export string) (*sprintdomain.AssigneeLintResult, error) {
+	args := m.Called(project, sprint, export)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sprintdomain.AssigneeLintResult), args.Error(1)
+}
+
+func (m *MockSprintService) SprintScope(project, sprint, export string) (*sprintdomain.SprintScope, error) {
+	args := m.Called(project, sprint, export)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sprintdomain.SprintScope), args.Error(1)
+}
+
+func (m *MockSprintService) ResolveTeamAccounts(input sprintdomain.TeamAccountsInput) (*sprintdomain.TeamAccountsResult, error) {
+	args := m.Called(input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sprintdomain.TeamAccountsResult), args.Error(1)
+}
+
+func (m *MockSprintService) GenerateCapitalizationReport(input sprintdomain.CapitalizationReportInput) (string, error) {
+	args := m.Called(input)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockSprintService) BuildCapitalizationReport(input sprintdomain.CapitalizationReportInput) (*sprintdomain.CapitalizationReport, error) {
+	args := m.Called(input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sprintdomain.CapitalizationReport), args.Error(1)
+}
+
+func (m *MockSprintService) GenerateTimesheet(input sprintdomain.TimesheetInput) (string, error) {
+	args := m.Called(input)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockSprintService) CompareEstimates(input sprintdomain.EstimateComparisonInput) (string, error) {
+	args := m.Called(input)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockSprintService) VerifySprint(input sprintdomain.VerificationInput) (*sprintdomain.VerificationResult, error) {
+	args := m.Called(input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sprintdomain.VerificationResult), args.Error(1)
+}
+
+func (m *MockSprintService) ListSprints(project string, from, to time.Time) ([]sprintdomain.Sprint, error) {
+	args := m.Called(project, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]sprintdomain.Sprint), args.Error(1)
+}
+
+func (m *MockSprintService) AddAbsences(member string, absences []sprintdomain.Absence) (int, error) {
+	args := m.Called(member, absences)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockSprintService) ImportAbsences(member string, calendar io.Reader) (int, error) {
+	args := m.Called(member, calendar)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockSprintService) ListAbsences() (sprintdomain.AbsenceCalendar, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(sprintdomain.AbsenceCalendar), args.Error(1)
+}
+
+func (m *MockSprintService) AssetActivity(input sprintdomain.AssetActivityInput) (*sprintdomain.AssetActivity, error) {
+	args := m.Called(input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sprintdomain.AssetActivity), args.Error(1)
+}
+
+func (m *MockSprintService) ExplainAllocation(input sprintdomain.ExplanationInput) (*sprintdomain.AllocationExplanation, error) {
+	args := m.Called(input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sprintdomain.AllocationExplanation), args.Error(1)
+}
+
+func (m *MockSprintService) SimulateAllocation(scenario string) (*sprintdomain.SimulationResult, error) {
+	args := m.Called(scenario)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sprintdomain.SimulationResult), args.Error(1)
+}
+
+func (m *MockSprintService) PushAllocations(input sprintdomain.PushAllocationsInput) (*sprintdomain.PushResult, error) {
+	args := m.Called(input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sprintdomain.PushResult), args.Error(1)
+}
+
+// MockTaskRepository is a mock implementation of TaskRepository
+type MockTaskRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaskRepository) Save(ctx context.Context, task *tasksdomain.Task) error {
+	args := m.Called(ctx, task)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) FindByKey(ctx context.Context, key string) (*tasksdomain.Task, error) {
+	args := m.Called(ctx, key)
+	return args.Get(0).(*tasksdomain.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) FindByProjectAndSprint(ctx context.Context, project, sprint string) ([]*tasksdomain.Task, error) {
+	args := m.Called(ctx, project, sprint)
+	return args.Get(0).([]*tasksdomain.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) FindByAsset(ctx context.Context, asset string) ([]*tasksdomain.Task, error) {
+	args := m.Called(ctx, asset)
+	return args.Get(0).([]*tasksdomain.Task), args.Error(1)
+}
+
+func setupTestEnvironment(t *testing.T) func() {
+	t.Helper()
+
+	// Save original stdout
+	oldStdout := os.Stdout
+
+	// Create test directory
+	testDir := filepath.Join("testdata", t.Name())
+	err := os.MkdirAll(testDir, 0755)
+	require.NoError(t, err, "Failed to create test directory")
+
+	// Create .assetcap directory
+	assetcapDir := filepath.Join(testDir, ".assetcap")
+	err = os.MkdirAll(assetcapDir, 0755)
+	require.NoError(t, err, "Failed to create .assetcap directory")
+
+	// Get current working directory
+	oldWd, err := os.Getwd()
+	require.NoError(t, err, "Failed to get working directory")
+
+	// Change working directory to test directory
+	err = os.Chdir(testDir)
+	require.NoError(t, err, "Failed to change working directory")
+
+	return func() {
+		// Restore original stdout
+		os.Stdout = oldStdout
+
+		// Restore original working directory
+		err := os.Chdir(oldWd)
+		if err != nil {
+			t.Errorf("Failed to restore working directory: %v", err)
+		}
+
+		// Clean up test directory
+		err = os.RemoveAll(filepath.Join(oldWd, "testdata", t.Name()))
+		if err != nil {
+			t.Errorf("Failed to clean up test directory: %v", err)
+		}
+	}
+}
+
+func captureOutput(f func() error) (string, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return "", err
+	}
+
+	oldStdout := os.Stdout
+	os.Stdout = w
+
+	errCh := make(chan error, 1)
+	outCh := make(chan string, 1)
+
+	go func() {
+		funcErr := f()
+		w.Close()
+		errCh <- funcErr
+	}()
+
+	go func() {
+		var buf bytes.Buffer
+		io.Copy(&buf, r)
+		outCh <- buf.String()
+	}()
+
+	err = <-errCh
+	os.Stdout = oldStdout
+	out := <-outCh
+
+	return out, err
+}
+
+func TestRun(t *testing.T) {
 	tests := []struct {
 		name    string
 		args    []string
@@ -254,129 +677,1433 @@ func TestRun(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "list empty assets",
-			args: []string{"assets", "list"},
-			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
-				mas.On("ListAssets").Return([]*assetsdomain.Asset{}, nil)
+			name: "list empty assets",
+			args: []string{"assets", "list"},
+			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+				mas.On("ListAssets").Return([]*assetsdomain.Asset{}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "list assets after creation",
+			args: []string{"assets", "list"},
+			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+				mas.On("ListAssets").Return([]*assetsdomain.Asset{
+					{
+						ID:          "cap-asset-test",
+						Name:        "Test Asset",
+						Description: "Test Description",
+					},
+				}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "update documentation",
+			args: []string{"assets", "documentation", "update", "--asset", "test"},
+			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+				mas.On("GetAsset", "test").Return(&assetsdomain.Asset{
+					ID:          "cap-asset-test",
+					Name:        "Test Asset",
+					Description: "Test Description",
+				}, nil)
+				mas.On("UpdateDocumentation", "test").Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "list stale documentation as json",
+			args: []string{"assets", "docs", "stale", "--format", "json"},
+			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+				mas.On("FindStaleDocumentation", assetsdomain.FreshnessPolicy{}).Return([]assetsdomain.StaleDocumentation{{Asset: "test", AgeDays: 200, MaxAgeDays: 180}}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:    "list stale documentation with unsupported format",
+			args:    []string{"assets", "docs", "stale", "--format", "csv"},
+			setup:   func(*MockAssetService, *MockTaskService, *MockSprintService) {},
+			wantErr: true,
+		},
+		{
+			name: "set documentation owner",
+			args: []string{"assets", "documentation", "owner", "--asset", "test", "--owner", "Jane"},
+			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+				mas.On("SetOwner", "test", "Jane").Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "increment task count",
+			args: []string{"assets", "tasks", "increment", "--asset", "test"},
+			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+				mas.On("GetAsset", "test").Return(&assetsdomain.Asset{
+					ID:          "cap-asset-test",
+					Name:        "Test Asset",
+					Description: "Test Description",
+				}, nil)
+				mas.On("IncrementTaskCount", "test").Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "decrement task count",
+			args: []string{"assets", "tasks", "decrement", "--asset", "test"},
+			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+				mas.On("GetAsset", "test").Return(&assetsdomain.Asset{
+					ID:          "cap-asset-test",
+					Name:        "Test Asset",
+					Description: "Test Description",
+				}, nil)
+				mas.On("DecrementTaskCount", "test").Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "show help",
+			args: []string{"--help"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing required flag",
+			args: []string{"assets", "create"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "sprint allocate with required flags",
+			args: []string{"sprint", "allocate", "--project", "TEST", "--sprint", "Sprint1"},
+			setup: func(mas *MockAssetService, mts *MockTaskService, mss *MockSprintService) {
+				mts.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+				mas.On("ListAssets").Return([]*assetsdomain.Asset{}, nil)
+				mss.On("ProcessJiraIssues", sprintdomain.AllocationInput{Project: "TEST", Sprint: "Sprint1", Delimiter: ','}).Return("Allocation result", nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "sprint allocate with a strict roster",
+			args: []string{"sprint", "allocate", "--project", "TEST", "--sprint", "Sprint1", "--strict-roster"},
+			setup: func(mas *MockAssetService, mts *MockTaskService, mss *MockSprintService) {
+				mts.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+				mas.On("ListAssets").Return([]*assetsdomain.Asset{}, nil)
+				mss.On("ProcessJiraIssues", sprintdomain.AllocationInput{Project: "TEST", Sprint: "Sprint1", Delimiter: ',', StrictRoster: true}).
+					Return("", fmt.Errorf("strict roster: team member bob has no attributed hours"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "sprint allocate with progress",
+			args: []string{"sprint", "allocate", "--project", "TEST", "--sprint", "Sprint1", "--progress"},
+			setup: func(mas *MockAssetService, mts *MockTaskService, mss *MockSprintService) {
+				mts.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+				mas.On("ListAssets").Return([]*assetsdomain.Asset{}, nil)
+				mss.On("ProcessJiraIssues", mock.MatchedBy(func(input sprintdomain.AllocationInput) bool {
+					return input.Project == "TEST" && input.Sprint == "Sprint1" && input.Progress != nil
+				})).Return("Allocation result", nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "sprint allocate by fix version",
+			args: []string{"sprint", "allocate", "--project", "TEST", "--fix-version", "2024.5"},
+			setup: func(mas *MockAssetService, mts *MockTaskService, mss *MockSprintService) {
+				mts.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+				mas.On("ListAssets").Return([]*assetsdomain.Asset{}, nil)
+				mss.On("ProcessJiraIssues", sprintdomain.AllocationInput{Project: "TEST", FixVersion: "2024.5", Delimiter: ','}).Return("Allocation result", nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "sprint allocate with person work types",
+			args: []string{"sprint", "allocate", "--project", "TEST", "--sprint", "Sprint1", "--person-worktype", "alice=maintenance", "--person-worktype", "bob=discovery"},
+			setup: func(mas *MockAssetService, mts *MockTaskService, mss *MockSprintService) {
+				mts.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+				mas.On("ListAssets").Return([]*assetsdomain.Asset{}, nil)
+				mss.On("ProcessJiraIssues", sprintdomain.AllocationInput{
+					Project: "TEST", Sprint: "Sprint1", Delimiter: ',',
+					PersonWorkTypes: sprintdomain.PersonWorkTypes{"alice": sprintdomain.WorkTypeMaintenance, "bob": sprintdomain.WorkTypeDiscovery},
+				}).Return("Allocation result", nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:    "sprint allocate with invalid person work type",
+			args:    []string{"sprint", "allocate", "--project", "TEST", "--sprint", "Sprint1", "--person-worktype", "alice=on-call"},
+			setup:   func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {},
+			wantErr: true,
+		},
+		{
+			name:    "sprint allocate without sprint or fix version",
+			args:    []string{"sprint", "allocate", "--project", "TEST"},
+			setup:   func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {},
+			wantErr: true,
+		},
+		{
+			name:    "sprint allocate with both sprint and fix version",
+			args:    []string{"sprint", "allocate", "--project", "TEST", "--sprint", "Sprint1", "--fix-version", "2024.5"},
+			setup:   func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {},
+			wantErr: true,
+		},
+		{
+			name: "tasks fetch by sprint",
+			args: []string{"tasks", "fetch", "--project", "TEST", "--sprint", "Sprint1", "--platform", "jira"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mts.On("FetchTasks", mock.Anything, "TEST", "Sprint1", "jira").Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "tasks fetch by fix version",
+			args: []string{"tasks", "fetch", "--project", "TEST", "--fix-version", "2024.5", "--platform", "jira"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mts.On("FetchTasksByFixVersion", mock.Anything, "TEST", "2024.5", "jira").Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:    "tasks import of a missing export",
+			args:    []string{"tasks", "import", "--file", "missing.json"},
+			setup:   func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {},
+			wantErr: true,
+		},
+		{
+			name:    "tasks fetch without sprint or fix version",
+			args:    []string{"tasks", "fetch", "--project", "TEST", "--platform", "jira"},
+			setup:   func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {},
+			wantErr: true,
+		},
+		{
+			name: "sprint allocate with override",
+			args: []string{"sprint", "allocate", "--project", "TEST", "--sprint", "Sprint1", "--override", "{\"ISSUE-1\": 6}"},
+			setup: func(mas *MockAssetService, mts *MockTaskService, mss *MockSprintService) {
+				mts.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+				mas.On("ListAssets").Return([]*assetsdomain.Asset{}, nil)
+				mss.On("ProcessJiraIssues", sprintdomain.AllocationInput{Project: "TEST", Sprint: "Sprint1", Override: "{\"ISSUE-1\": 6}", Delimiter: ','}).Return("Allocation result", nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "sprint allocate with semicolon delimiter",
+			args: []string{"sprint", "allocate", "--project", "TEST", "--sprint", "Sprint1", "--delimiter", ";"},
+			setup: func(mas *MockAssetService, mts *MockTaskService, mss *MockSprintService) {
+				mts.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+				mas.On("ListAssets").Return([]*assetsdomain.Asset{}, nil)
+				mss.On("ProcessJiraIssues", sprintdomain.AllocationInput{Project: "TEST", Sprint: "Sprint1", Delimiter: ';'}).Return("Allocation result", nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "sprint allocate with German locale",
+			args: []string{"sprint", "allocate", "--project", "TEST", "--sprint", "Sprint1", "--delimiter", ";", "--locale", "de-DE"},
+			setup: func(mas *MockAssetService, mts *MockTaskService, mss *MockSprintService) {
+				mts.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+				mas.On("ListAssets").Return([]*assetsdomain.Asset{}, nil)
+				mss.On("ProcessJiraIssues", sprintdomain.AllocationInput{
+					Project: "TEST", Sprint: "Sprint1", Delimiter: ';',
+					Locale: sprintdomain.Locale{Name: "de-DE", DecimalSeparator: ",", DateLayout: "02.01.2006"},
+				}).Return("Allocation result", nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "sprint allocate with fractions",
+			args: []string{"sprint", "allocate", "--project", "TEST", "--sprint", "Sprint1", "--percent-format", "fraction", "--percent-decimals", "3"},
+			setup: func(mas *MockAssetService, mts *MockTaskService, mss *MockSprintService) {
+				decimals := 3
+				mts.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+				mas.On("ListAssets").Return([]*assetsdomain.Asset{}, nil)
+				mss.On("ProcessJiraIssues", sprintdomain.AllocationInput{
+					Project: "TEST", Sprint: "Sprint1", Delimiter: ',',
+					Locale: sprintdomain.Locale{PercentFormat: sprintdomain.PercentFormatFraction, PercentDecimals: &decimals},
+				}).Return("Allocation result", nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "sprint allocate by story points at sprint start",
+			args: []string{"sprint", "allocate", "--project", "TEST", "--sprint", "Sprint1", "--method", "storypoints", "--points-at", "start"},
+			setup: func(mas *MockAssetService, mts *MockTaskService, mss *MockSprintService) {
+				mts.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+				mas.On("ListAssets").Return([]*assetsdomain.Asset{}, nil)
+				mss.On("ProcessJiraIssues", sprintdomain.AllocationInput{
+					Project:   "TEST",
+					Sprint:    "Sprint1",
+					Delimiter: ',',
+					Method:    sprintdomain.AllocationMethodStoryPoints,
+					PointsAt:  sprintdomain.PointsAtStart,
+				}).Return("Allocation result", nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "sprint allocate with a blend of strategies",
+			args: []string{"sprint", "allocate", "--project", "TEST", "--sprint", "Sprint1", "--method", "time:0.7,storypoints:0.3", "--points-at", "end"},
+			setup: func(mas *MockAssetService, mts *MockTaskService, mss *MockSprintService) {
+				mts.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+				mas.On("ListAssets").Return([]*assetsdomain.Asset{}, nil)
+				mss.On("ProcessJiraIssues", sprintdomain.AllocationInput{
+					Project:   "TEST",
+					Sprint:    "Sprint1",
+					Delimiter: ',',
+					Method:    "time:0.7,storypoints:0.3",
+					PointsAt:  sprintdomain.PointsAtEnd,
+				}).Return("Allocation result", nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "assets diff since a date",
+			args: []string{"assets", "diff", "--since", "2024-01-01"},
+			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+				mas.On("DiffAssets", assetsdomain.CatalogDiffInput{Since: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}).Return(&assetsdomain.CatalogDiff{
+					Reference:     "catalogue on 2024-01-01",
+					Added:         []string{"search"},
+					StatusChanges: []assetsdomain.StatusChange{{Asset: "booking", Before: "development", After: "live"}},
+				}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "assets diff between snapshot files as json",
+			args: []string{"assets", "diff", "--from", "q1.json", "--to", "q2.json", "--format", "json"},
+			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+				mas.On("DiffAssets", assetsdomain.CatalogDiffInput{From: "q1.json", To: "q2.json"}).Return(&assetsdomain.CatalogDiff{Reference: "q1.json"}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "assets diff with invalid date",
+			args: []string{"assets", "diff", "--since", "last quarter"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "sprint allocate links documented assets",
+			args: []string{"sprint", "allocate", "--project", "TEST", "--sprint", "Sprint1"},
+			setup: func(mas *MockAssetService, mts *MockTaskService, mss *MockSprintService) {
+				mts.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+				mas.On("ListAssets").Return([]*assetsdomain.Asset{
+					{Name: "booking", DocLink: "https://example.atlassian.net/wiki/spaces/FN/pages/123"},
+					{Name: "search"},
+				}, nil)
+				mss.On("ProcessJiraIssues", sprintdomain.AllocationInput{
+					Project:   "TEST",
+					Sprint:    "Sprint1",
+					Delimiter: ',',
+					AssetDocs: sprintdomain.AssetDocLinks{"booking": "https://example.atlassian.net/wiki/spaces/FN/pages/123"},
+				}).Return("Allocation result", nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "sprint allocate with points-at but time method",
+			args: []string{"sprint", "allocate", "--project", "TEST", "--sprint", "Sprint1", "--points-at", "end"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "sprint allocate normalized by capacity",
+			args: []string{"sprint", "allocate", "--project", "TEST", "--sprint", "Sprint1", "--normalize", "capacity"},
+			setup: func(mas *MockAssetService, mts *MockTaskService, mss *MockSprintService) {
+				mts.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+				mas.On("ListAssets").Return([]*assetsdomain.Asset{}, nil)
+				mss.On("ProcessJiraIssues", sprintdomain.AllocationInput{
+					Project:       "TEST",
+					Sprint:        "Sprint1",
+					Delimiter:     ',',
+					Normalization: sprintdomain.NormalizationCapacity,
+				}).Return("Allocation result", nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "sprint allocate with invalid normalization",
+			args: []string{"sprint", "allocate", "--project", "TEST", "--sprint", "Sprint1", "--normalize", "team"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "sprint scope",
+			args: []string{"sprint", "scope", "--project", "FN", "--sprint", "Sprint1"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, mss *MockSprintService) {
+				mss.On("SprintScope", "FN", "Sprint1", "").Return(&sprintdomain.SprintScope{Sprint: "Sprint1", Committed: 4}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "sprint scope as json",
+			args: []string{"sprint", "scope", "--project", "FN", "--sprint", "Sprint1", "--format", "json"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, mss *MockSprintService) {
+				mss.On("SprintScope", "FN", "Sprint1", "").Return(&sprintdomain.SprintScope{Sprint: "Sprint1", Changes: []sprintdomain.ScopeChange{}}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "sprint scope with invalid format",
+			args: []string{"sprint", "scope", "--project", "FN", "--sprint", "Sprint1", "--format", "xml"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "sprint scope error",
+			args: []string{"sprint", "scope", "--project", "FN", "--sprint", "Sprint9"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, mss *MockSprintService) {
+				mss.On("SprintScope", "FN", "Sprint9", "").Return(nil, fmt.Errorf("no issue of project FN lists sprint Sprint9"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "sprint allocate with a person summary",
+			args: []string{"sprint", "allocate", "--project", "TEST", "--sprint", "Sprint1", "--summary", "person"},
+			setup: func(mas *MockAssetService, mts *MockTaskService, mss *MockSprintService) {
+				mts.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+				mas.On("ListAssets").Return([]*assetsdomain.Asset{}, nil)
+				mss.On("ProcessJiraIssues", sprintdomain.AllocationInput{
+					Project:   "TEST",
+					Sprint:    "Sprint1",
+					Delimiter: ',',
+					Summary:   sprintdomain.AllocationSummaryPerson,
+				}).Return("Allocation result", nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "sprint allocate with invalid summary",
+			args: []string{"sprint", "allocate", "--project", "TEST", "--sprint", "Sprint1", "--summary", "team"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "sprint allocate with invalid method",
+			args: []string{"sprint", "allocate", "--project", "TEST", "--sprint", "Sprint1", "--method", "velocity"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "report timesheet for a person",
+			args: []string{"report", "timesheet", "--project", "FN", "--sprint", "Sprint1", "--person", "alice"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, mss *MockSprintService) {
+				mss.On("GenerateTimesheet", sprintdomain.TimesheetInput{Project: "FN", Sprint: "Sprint1", People: []string{"alice"}, Delimiter: ','}).Return("timesheet", nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "report estimates",
+			args: []string{"report", "estimates", "--project", "FN", "--sprint", "Sprint1", "--tolerance", "10"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, mss *MockSprintService) {
+				mss.On("CompareEstimates", sprintdomain.EstimateComparisonInput{Project: "FN", Sprint: "Sprint1", Delimiter: ',', Tolerance: 0.1}).Return("estimates", nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "report estimates with a negative tolerance",
+			args: []string{"report", "estimates", "--project", "FN", "--sprint", "Sprint1", "--tolerance", "-5"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "report timesheet missing sprint",
+			args: []string{"report", "timesheet", "--project", "FN"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "report org for projects",
+			args: []string{"report", "org", "--quarter", "2024-Q2", "--project", "FN", "--project", "OPS", "--format", "csv"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mts.On("OrgReport", mock.Anything, tasksdomain.OrgReportInput{Projects: []string{"FN", "OPS"}, Quarter: "2024-Q2"}).
+					Return(&tasksdomain.OrgReport{Quarter: "2024-Q2", Teams: []tasksdomain.TeamRollup{{Team: "FN"}, {Team: "OPS"}}}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "report org error",
+			args: []string{"report", "org", "--quarter", "2024-Q2", "--project", "FN"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mts.On("OrgReport", mock.Anything, tasksdomain.OrgReportInput{Projects: []string{"FN"}, Quarter: "2024-Q2"}).Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "report org unsupported format",
+			args: []string{"report", "org", "--quarter", "2024-Q2", "--project", "FN", "--format", "pdf"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mts.On("OrgReport", mock.Anything, mock.Anything).Return(&tasksdomain.OrgReport{Quarter: "2024-Q2"}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "report org missing quarter",
+			args: []string{"report", "org", "--project", "FN"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "verify sprint passes",
+			args: []string{"verify", "sprint", "--project", "FN", "--sprint", "Sprint1"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, mss *MockSprintService) {
+				mts.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+				mss.On("VerifySprint", sprintdomain.VerificationInput{Project: "FN", Sprint: "Sprint1"}).
+					Return(&sprintdomain.VerificationResult{Project: "FN", Sprint: "Sprint1", Passed: true, Violations: []sprintdomain.Violation{}}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "sprint explain defaults the project to the issue key prefix",
+			args: []string{"sprint", "explain", "--issue", "fn-7", "--sprint", "Sprint1"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, mss *MockSprintService) {
+				mss.On("ExplainAllocation", sprintdomain.ExplanationInput{Project: "FN", Sprint: "Sprint1", IssueKey: "fn-7"}).
+					Return(&sprintdomain.AllocationExplanation{IssueKey: "FN-7", Steps: []string{"In Progress window"}}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "sprint explain as json for a fix version",
+			args: []string{"sprint", "explain", "--issue", "FN-7", "--fix-version", "2024.5", "--method", "storypoints", "--format", "json"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, mss *MockSprintService) {
+				mss.On("ExplainAllocation", sprintdomain.ExplanationInput{
+					Project: "FN", FixVersion: "2024.5", IssueKey: "FN-7",
+					Method: sprintdomain.AllocationMethodStoryPoints, PointsAt: sprintdomain.PointsAtLatest,
+				}).Return(&sprintdomain.AllocationExplanation{IssueKey: "FN-7"}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:    "sprint explain requires a sprint or fix version",
+			args:    []string{"sprint", "explain", "--issue", "FN-7"},
+			setup:   func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {},
+			wantErr: true,
+		},
+		{
+			name:    "sprint explain rejects unknown formats",
+			args:    []string{"sprint", "explain", "--issue", "FN-7", "--sprint", "Sprint1", "--format", "xml"},
+			setup:   func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {},
+			wantErr: true,
+		},
+		{
+			name: "sprint explain fails for issues outside the sprint",
+			args: []string{"sprint", "explain", "--issue", "FN-7", "--sprint", "Sprint1"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, mss *MockSprintService) {
+				mss.On("ExplainAllocation", sprintdomain.ExplanationInput{Project: "FN", Sprint: "Sprint1", IssueKey: "FN-7"}).
+					Return(nil, fmt.Errorf("issue FN-7 is not part of Sprint1"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "verify sprint fails on violations",
+			args: []string{"verify", "sprint", "--project", "FN", "--sprint", "Sprint1", "--format", "text"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, mss *MockSprintService) {
+				mts.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+				mss.On("VerifySprint", sprintdomain.VerificationInput{Project: "FN", Sprint: "Sprint1"}).
+					Return(&sprintdomain.VerificationResult{Project: "FN", Sprint: "Sprint1", Violations: []sprintdomain.Violation{
+						{Rule: sprintdomain.RuleUnclassified, IssueKey: "FN-1", Message: "done issue FN-1 has no work type"},
+					}}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "verify sprint with unsupported format",
+			args: []string{"verify", "sprint", "--project", "FN", "--sprint", "Sprint1", "--format", "xml"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "sprint allocate with labels as of a date",
+			args: []string{"sprint", "allocate", "--project", "TEST", "--sprint", "Sprint1", "--as-of", "2024-03-31"},
+			setup: func(mas *MockAssetService, mts *MockTaskService, mss *MockSprintService) {
+				mts.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+				mas.On("ListAssets").Return([]*assetsdomain.Asset{}, nil)
+				mss.On("ProcessJiraIssues", sprintdomain.AllocationInput{
+					Project:    "TEST",
+					Sprint:     "Sprint1",
+					Delimiter:  ',',
+					LabelsAsOf: sprintdomain.LabelSnapshot{Date: time.Date(2024, 3, 31, 23, 59, 59, 999999999, time.UTC)},
+				}).Return("Allocation result", nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "verify sprint with invalid as-of",
+			args: []string{"verify", "sprint", "--project", "FN", "--sprint", "Sprint1", "--as-of", "yesterday"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "sprint allocate with invalid delimiter",
+			args: []string{"sprint", "allocate", "--project", "TEST", "--sprint", "Sprint1", "--delimiter", ";;"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "sprint allocate with unsupported locale",
+			args: []string{"sprint", "allocate", "--project", "TEST", "--sprint", "Sprint1", "--locale", "xx-YY"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+			},
+			wantErr: true,
+		},
+		{
+			name:    "sprint allocate with unsupported percent format",
+			args:    []string{"sprint", "allocate", "--project", "TEST", "--sprint", "Sprint1", "--percent-format", "ratio"},
+			setup:   func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {},
+			wantErr: true,
+		},
+		{
+			name:    "sprint allocate with too many percent decimals",
+			args:    []string{"sprint", "allocate", "--project", "TEST", "--sprint", "Sprint1", "--percent-decimals", "9"},
+			setup:   func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {},
+			wantErr: true,
+		},
+		{
+			name: "sprint allocate missing project",
+			args: []string{"sprint", "allocate", "--sprint", "Sprint1", "--platform", "jira"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "sprint allocate missing sprint",
+			args: []string{"sprint", "allocate", "--project", "TEST", "--platform", "jira"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "sprint lint with unmatched assignees",
+			args: []string{"sprint", "lint", "--project", "TEST", "--sprint", "Sprint1"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, mss *MockSprintService) {
+				mss.On("LintAssignees", "TEST", "Sprint1", "").Return(&sprintdomain.AssigneeLintResult{
+					Unmatched: []sprintdomain.UnmatchedAssignee{
+						{Name: "Jane Doe-Smith", Issues: []string{"TEST-1"}},
+					},
+				}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "sprint lint missing sprint",
+			args: []string{"sprint", "lint", "--project", "TEST"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "sprint resolve-accounts",
+			args: []string{"sprint", "resolve-accounts", "--project", "TEST"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, mss *MockSprintService) {
+				mss.On("ResolveTeamAccounts", sprintdomain.TeamAccountsInput{Project: "TEST"}).Return(&sprintdomain.TeamAccountsResult{
+					Resolutions: []sprintdomain.AccountResolution{
+						{Project: "TEST", Member: "alice", AccountID: "acc-1", DisplayName: "Alice", Status: sprintdomain.AccountResolved},
+						{Project: "TEST", Member: "bob", Status: sprintdomain.AccountAmbiguous, Candidates: []string{"Bob A (acc-2)", "Bob B (acc-3)"}},
+					},
+					Written: true,
+				}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "sprint resolve-accounts dry run",
+			args: []string{"sprint", "resolve-accounts", "--dry-run"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, mss *MockSprintService) {
+				mss.On("ResolveTeamAccounts", sprintdomain.TeamAccountsInput{DryRun: true}).Return(&sprintdomain.TeamAccountsResult{}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "sprint absences add",
+			args: []string{"sprint", "absences", "add", "--member", "alice", "--from", "2024-05-06", "--to", "2024-05-10", "--reason", "vacation"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, mss *MockSprintService) {
+				mss.On("AddAbsences", "alice", []sprintdomain.Absence{{
+					From:   time.Date(2024, time.May, 6, 0, 0, 0, 0, time.UTC),
+					To:     time.Date(2024, time.May, 10, 0, 0, 0, 0, time.UTC),
+					Reason: "vacation",
+				}}).Return(1, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:    "sprint absences add ending before it starts",
+			args:    []string{"sprint", "absences", "add", "--member", "alice", "--from", "2024-05-10", "--to", "2024-05-06"},
+			setup:   func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {},
+			wantErr: true,
+		},
+		{
+			name:    "sprint absences add invalid date",
+			args:    []string{"sprint", "absences", "add", "--member", "alice", "--from", "06/05/2024"},
+			setup:   func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {},
+			wantErr: true,
+		},
+		{
+			name:    "sprint absences import missing calendar",
+			args:    []string{"sprint", "absences", "import", "--member", "alice", "--file", "missing.ics"},
+			setup:   func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {},
+			wantErr: true,
+		},
+		{
+			name: "sprint absences list",
+			args: []string{"sprint", "absences", "list"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, mss *MockSprintService) {
+				mss.On("ListAbsences").Return(sprintdomain.AbsenceCalendar{"alice": {{
+					From: time.Date(2024, time.May, 6, 0, 0, 0, 0, time.UTC),
+					To:   time.Date(2024, time.May, 10, 0, 0, 0, 0, time.UTC),
+				}}}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "sprint push comments",
+			args: []string{"sprint", "push", "--project", "TEST", "--sprint", "Sprint1", "--mode", "comment"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, mss *MockSprintService) {
+				mss.On("PushAllocations", sprintdomain.PushAllocationsInput{
+					Project:   "TEST",
+					Sprint:    "Sprint1",
+					Mode:      sprintdomain.PushModeComment,
+					ChunkSize: sprintdomain.DefaultPushChunkSize,
+				}).Return(&sprintdomain.PushResult{Created: []string{"TEST-1"}}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "sprint push worklogs",
+			args: []string{"sprint", "push", "--project", "TEST", "--sprint", "Sprint1", "--mode", "worklog"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, mss *MockSprintService) {
+				mss.On("PushAllocations", sprintdomain.PushAllocationsInput{
+					Project:   "TEST",
+					Sprint:    "Sprint1",
+					Mode:      sprintdomain.PushModeWorklog,
+					ChunkSize: sprintdomain.DefaultPushChunkSize,
+				}).Return(&sprintdomain.PushResult{
+					Updated:  []string{"TEST-1"},
+					Failures: []sprintdomain.PushFailure{{IssueKey: "TEST-2", Err: fmt.Errorf("forbidden")}},
+				}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "sprint push stopped by the rate limit",
+			args: []string{"sprint", "push", "--project", "TEST", "--sprint", "Sprint1", "--chunk-size", "10", "--restart"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, mss *MockSprintService) {
+				mss.On("PushAllocations", sprintdomain.PushAllocationsInput{
+					Project:   "TEST",
+					Sprint:    "Sprint1",
+					Mode:      sprintdomain.PushModeWorklog,
+					ChunkSize: 10,
+					Restart:   true,
+				}).Return(&sprintdomain.PushResult{Created: []string{"TEST-1"}, Remaining: []string{"TEST-2"}}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "sprint push service error",
+			args: []string{"sprint", "push", "--project", "TEST", "--sprint", "Sprint1", "--mode", "field"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, mss *MockSprintService) {
+				mss.On("PushAllocations", mock.Anything).Return(nil, fmt.Errorf("unsupported push mode: field"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "dashboard build without projects",
+			args: []string{"dashboard", "build", "--out", filepath.Join(t.TempDir(), "site")},
+			setup: func(mas *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mas.On("ListAssets").Return([]*assetsdomain.Asset{{Name: "booking", Description: "Booking flow"}}, nil)
+				mts.On("ClassificationCoverage", mock.Anything).Return([]tasksdomain.SprintCoverage{}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "dashboard build with the latest sprint allocation",
+			args: []string{"dashboard", "build", "-p", "FN", "--out", filepath.Join(t.TempDir(), "site")},
+			setup: func(mas *MockAssetService, mts *MockTaskService, mss *MockSprintService) {
+				mas.On("ListAssets").Return([]*assetsdomain.Asset{}, nil)
+				mts.On("ClassificationCoverage", mock.Anything).Return([]tasksdomain.SprintCoverage{
+					{Project: "FN", Sprint: "Owls", Tasks: 2},
+					{Project: "FN", Sprint: "Penguins", Tasks: 3},
+					{Project: "OTHER", Sprint: "Tigers", Tasks: 1},
+				}, nil)
+				mts.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+				mss.On("BuildCapitalizationReport", sprintdomain.CapitalizationReportInput{
+					Projects: []string{"FN"},
+					Sprint:   "Penguins",
+				}).Return(&sprintdomain.CapitalizationReport{}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "dashboard build without stored sprints",
+			args: []string{"dashboard", "build", "-p", "FN", "--out", filepath.Join(t.TempDir(), "site")},
+			setup: func(mas *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mas.On("ListAssets").Return([]*assetsdomain.Asset{}, nil)
+				mts.On("ClassificationCoverage", mock.Anything).Return([]tasksdomain.SprintCoverage{}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "assets retire with lifetime summary",
+			args: []string{"assets", "retire", "--name", "booking", "--date", "2024-06-30", "-p", "FN", "--hourly-rate", "100", "--out", filepath.Join(t.TempDir(), "booking.csv")},
+			setup: func(mas *MockAssetService, mts *MockTaskService, mss *MockSprintService) {
+				retired := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+				asset := &assetsdomain.Asset{Name: "booking", Status: assetsdomain.StatusRetired, RetiredAt: retired}
+				mas.On("RetireAsset", "booking", retired).Return(asset, nil)
+				mas.On("ListAssets").Return([]*assetsdomain.Asset{asset}, nil)
+				mts.On("ClassificationCoverage", mock.Anything).Return([]tasksdomain.SprintCoverage{
+					{Project: "FN", Sprint: "Penguins", Tasks: 3},
+					{Project: "OTHER", Sprint: "Penguins", Tasks: 2},
+				}, nil)
+				mts.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+				mss.On("BuildCapitalizationReport", sprintdomain.CapitalizationReportInput{
+					Projects:      []string{"FN"},
+					Sprint:        "Penguins",
+					AssetStatuses: sprintdomain.AssetStatuses{"booking": {{Status: assetsdomain.StatusRetired}}},
+					Retirements:   sprintdomain.AssetRetirements{"booking": retired},
+				}).Return(&sprintdomain.CapitalizationReport{}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "assets retire with invalid date",
+			args: []string{"assets", "retire", "--name", "booking", "--date", "30/06/2024"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "assets retire already retired",
+			args: []string{"assets", "retire", "--name", "booking", "--date", "2024-06-30"},
+			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+				mas.On("RetireAsset", "booking", time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)).Return(nil, assetsdomain.ErrAlreadyRetired)
+			},
+			wantErr: true,
+		},
+		{
+			name: "backstage export without projects",
+			args: []string{"backstage", "export", "--out", filepath.Join(t.TempDir(), "backstage")},
+			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+				mas.On("ListAssets").Return([]*assetsdomain.Asset{{Name: "booking", Description: "Booking flow"}}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "backstage export with the latest sprint allocation",
+			args: []string{"backstage", "export", "-p", "FN", "--out", filepath.Join(t.TempDir(), "backstage")},
+			setup: func(mas *MockAssetService, mts *MockTaskService, mss *MockSprintService) {
+				mas.On("ListAssets").Return([]*assetsdomain.Asset{{Name: "booking"}}, nil)
+				mts.On("ClassificationCoverage", mock.Anything).Return([]tasksdomain.SprintCoverage{{Project: "FN", Sprint: "Penguins", Tasks: 3}}, nil)
+				mts.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+				mss.On("BuildCapitalizationReport", sprintdomain.CapitalizationReportInput{
+					Projects: []string{"FN"},
+					Sprint:   "Penguins",
+				}).Return(&sprintdomain.CapitalizationReport{}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "backstage export with conflicting asset names",
+			args: []string{"backstage", "export", "--out", filepath.Join(t.TempDir(), "backstage")},
+			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+				mas.On("ListAssets").Return([]*assetsdomain.Asset{{Name: "Data Platform"}, {Name: "data-platform"}}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "sprint report markdown for two teams",
+			args: []string{"sprint", "report", "-p", "TEAMA", "-p", "TEAMB", "--sprint", "Sprint2", "--previous-sprint", "Sprint1", "--format", "markdown"},
+			setup: func(mas *MockAssetService, mts *MockTaskService, mss *MockSprintService) {
+				mts.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+				mas.On("ListAssets").Return([]*assetsdomain.Asset{}, nil)
+				mss.On("GenerateCapitalizationReport", sprintdomain.CapitalizationReportInput{
+					Projects:       []string{"TEAMA", "TEAMB"},
+					Sprint:         "Sprint2",
+					PreviousSprint: "Sprint1",
+					Format:         sprintdomain.ReportFormatMarkdown,
+					Delimiter:      ',',
+				}).Return("## Capitalization summary\n", nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "sprint report with impaired asset",
+			args: []string{"sprint", "report", "-p", "TEAMA", "--sprint", "Sprint2"},
+			setup: func(mas *MockAssetService, mts *MockTaskService, mss *MockSprintService) {
+				mts.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+				mas.On("ListAssets").Return([]*assetsdomain.Asset{
+					{Name: "booking", Impairments: []assetsdomain.Impairment{
+						{Date: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Reason: "Sunset", Amount: 5000},
+					}},
+				}, nil)
+				mss.On("GenerateCapitalizationReport", sprintdomain.CapitalizationReportInput{
+					Projects:  []string{"TEAMA"},
+					Sprint:    "Sprint2",
+					Format:    sprintdomain.ReportFormatCSV,
+					Delimiter: ',',
+					Impairments: []sprintdomain.AssetImpairment{
+						{AssetName: "booking", Date: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Reason: "Sunset", Amount: 5000},
+					},
+				}).Return("metric,value\n", nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "sprint report normalized by capacity",
+			args: []string{"sprint", "report", "-p", "TEAMA", "--sprint", "Sprint2", "--normalize", "capacity"},
+			setup: func(mas *MockAssetService, mts *MockTaskService, mss *MockSprintService) {
+				mts.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+				mas.On("ListAssets").Return([]*assetsdomain.Asset{}, nil)
+				mss.On("GenerateCapitalizationReport", sprintdomain.CapitalizationReportInput{
+					Projects:      []string{"TEAMA"},
+					Sprint:        "Sprint2",
+					Format:        sprintdomain.ReportFormatCSV,
+					Delimiter:     ',',
+					Normalization: sprintdomain.NormalizationCapacity,
+				}).Return("metric,value\n", nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "sprint report with built-in template",
+			args: []string{"sprint", "report", "-p", "TEAMA", "--sprint", "Sprint2", "--template", "executive"},
+			setup: func(mas *MockAssetService, mts *MockTaskService, mss *MockSprintService) {
+				mts.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+				mas.On("ListAssets").Return([]*assetsdomain.Asset{}, nil)
+				mss.On("GenerateCapitalizationReport", mock.MatchedBy(func(input sprintdomain.CapitalizationReportInput) bool {
+					return input.Template != nil && input.Template.Name == "executive" && !input.Template.HTML
+				})).Return("# Sprint2 at a glance\n", nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "sprint report with missing template file",
+			args: []string{"sprint", "report", "-p", "TEAMA", "--sprint", "Sprint2", "--template", "does-not-exist.tmpl"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "sprint report missing sprint",
+			args: []string{"sprint", "report", "--project", "TEST"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "assets impair",
+			args: []string{"assets", "impair", "--name", "booking", "--date", "2024-03-01", "--reason", "Sunset", "--amount", "5000"},
+			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+				mas.On("ImpairAsset", "booking", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), "Sunset", 5000.0).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "assets bugfix-window",
+			args: []string{"assets", "bugfix-window", "--name", "booking", "--days", "30", "--launch-date", "2024-03-01"},
+			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+				mas.On("SetBugFixWindow", "booking", 30, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:    "assets bugfix-window with invalid launch date",
+			args:    []string{"assets", "bugfix-window", "--name", "booking", "--days", "30", "--launch-date", "March"},
+			setup:   func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {},
+			wantErr: true,
+		},
+		{
+			name: "assets set-status",
+			args: []string{"assets", "set-status", "--name", "booking", "--status", "live", "--effective", "2024-04-15"},
+			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+				mas.On("SetStatus", "booking", "live", time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:    "assets set-status with invalid effective date",
+			args:    []string{"assets", "set-status", "--name", "booking", "--status", "live", "--effective", "April"},
+			setup:   func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {},
+			wantErr: true,
+		},
+		{
+			name: "assets set-status service error",
+			args: []string{"assets", "set-status", "--name", "booking", "--status", "live", "--effective", "2024-04-15"},
+			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+				mas.On("SetStatus", "booking", "live", time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)).Return(assetsdomain.ErrFutureStatusEffective)
+			},
+			wantErr: true,
+		},
+		{
+			name: "assets link-doc",
+			args: []string{"assets", "link-doc", "--name", "booking", "--url", "https://example.atlassian.net/wiki/spaces/S/pages/1"},
+			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+				mas.On("LinkDocumentation", "booking", "https://example.atlassian.net/wiki/spaces/S/pages/1").Return(&assetsdomain.Asset{
+					ID:      "cap-asset-booking",
+					Name:    "booking",
+					DocLink: "https://example.atlassian.net/wiki/spaces/S/pages/1",
+				}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "assets activity",
+			args: []string{"assets", "activity", "--name", "booking", "--project", "FN", "--sprint", "Sprint1"},
+			setup: func(mas *MockAssetService, mts *MockTaskService, mss *MockSprintService) {
+				mas.On("GetAsset", "booking").Return(&assetsdomain.Asset{Name: "booking"}, nil)
+				mts.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+				mss.On("AssetActivity", sprintdomain.AssetActivityInput{Project: "FN", Sprint: "Sprint1", Asset: "booking"}).Return(&sprintdomain.AssetActivity{
+					Asset:   "booking",
+					Project: "FN",
+					Sprint:  "Sprint1",
+					Completed: []sprintdomain.AssetActivityTask{
+						{IssueKey: "FN-1", IssueTitle: "Add payment retries", WorkTypes: []string{"cap-development"}, Assignees: []string{"alice"}, Hours: 6},
+					},
+					TotalHours:  6,
+					WorkTypeMix: []sprintdomain.WorkTypeHours{{WorkType: "cap-development", Hours: 6, Percentage: 100}},
+				}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "assets activity with summary",
+			args: []string{"assets", "activity", "--name", "booking", "--project", "FN", "--sprint", "Sprint1", "--summary"},
+			setup: func(mas *MockAssetService, mts *MockTaskService, mss *MockSprintService) {
+				activity := &sprintdomain.AssetActivity{Asset: "booking", Project: "FN", Sprint: "Sprint1"}
+				mas.On("GetAsset", "booking").Return(&assetsdomain.Asset{Name: "booking"}, nil)
+				mts.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+				mss.On("AssetActivity", mock.Anything).Return(activity, nil)
+				mas.On("SummarizeActivity", "booking", activity.Digest()).Return("", assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "assets activity unknown asset",
+			args: []string{"assets", "activity", "--name", "missing", "--project", "FN", "--sprint", "Sprint1"},
+			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+				mas.On("GetAsset", "missing").Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "assets resolve-duplicate",
+			args: []string{"assets", "resolve-duplicate", "--primary", "https://example.atlassian.net/wiki/spaces/S/pages/1"},
+			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+				mas.On("ResolveDuplicate", "https://example.atlassian.net/wiki/spaces/S/pages/1").Return(&assetsdomain.DuplicateResolution{
+					ID:         "cap-asset-booking",
+					Primary:    "https://example.atlassian.net/wiki/spaces/S/pages/1",
+					Superseded: []string{"https://example.atlassian.net/wiki/spaces/S/pages/2"},
+				}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "assets resolve-duplicate service error",
+			args: []string{"assets", "resolve-duplicate", "--primary", "https://example.atlassian.net/wiki/spaces/S/pages/1"},
+			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+				mas.On("ResolveDuplicate", "https://example.atlassian.net/wiki/spaces/S/pages/1").Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "assets resolve-duplicate missing primary",
+			args:    []string{"assets", "resolve-duplicate"},
+			setup:   func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {},
+			wantErr: true,
+		},
+		{
+			name: "assets link-doc missing url",
+			args: []string{"assets", "link-doc", "--name", "booking"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "assets impair with invalid date",
+			args: []string{"assets", "impair", "--name", "booking", "--date", "03/01/2024", "--reason", "Sunset", "--amount", "5000"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "devtools simulate scenario",
+			args: []string{"devtools", "simulate", "--scenario", "blocked"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, mss *MockSprintService) {
+				mss.On("SimulateAllocation", "blocked").Return(&sprintdomain.SimulationResult{
+					Scenario: "blocked",
+					Issues: []sprintdomain.SimulatedIssue{
+						{Timeline: []string{"Mon 2024-03-04 09:00 To Do -> In Progress"}, Allocation: sprintdomain.IssueAllocation{IssueKey: "SIM-1", Hours: 56}},
+					},
+				}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "devtools simulate unknown scenario",
+			args: []string{"devtools", "simulate", "--scenario", "unknown"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, mss *MockSprintService) {
+				mss.On("SimulateAllocation", "unknown").Return(nil, fmt.Errorf("unknown scenario"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "serve webhooks with invalid address",
+			args: []string{"serve", "webhooks", "--addr", "invalid-address"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "shell completion commands",
+			args: []string{"completion", "bash"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+			},
+			wantErr: false,
+		},
+		{
+			name: "tasks classify with required flags",
+			args: []string{"tasks", "classify", "--project", "TEST", "--sprint", "Sprint1", "--platform", "jira"},
+			setup: func(mas *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mas.On("ListAssets").Return([]*assetsdomain.Asset{}, nil)
+				mts.On("ClassifyTasks", mock.Anything, tasksdomain.ClassifyTasksInput{
+					Project: "TEST",
+					Sprint:  "Sprint1",
+					DryRun:  false,
+					Apply:   false,
+				}).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "tasks classify applies asset rules",
+			args: []string{"tasks", "classify", "--project", "TEST", "--sprint", "Sprint1", "--platform", "jira"},
+			setup: func(mas *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mas.On("ListAssets").Return([]*assetsdomain.Asset{
+					{ID: "cap-asset-data", Name: "Data Platform", ClassificationRules: &assetsdomain.ClassificationRules{
+						Keywords: []assetsdomain.ClassificationRule{{Keyword: "backfill", WorkType: "cap-development"}},
+						Prompt:   "Schema migrations are development",
+					}},
+					{Name: "Search"},
+				}, nil)
+				mts.On("ClassifyTasks", mock.Anything, tasksdomain.ClassifyTasksInput{
+					Project: "TEST",
+					Sprint:  "Sprint1",
+					Rules: tasksdomain.ClassificationRules{"cap-asset-data": {
+						Asset:    "Data Platform",
+						Keywords: []tasksdomain.KeywordRule{{Keyword: "backfill", WorkType: tasksdomain.WorkTypeDevelopment}},
+						Prompt:   "Schema migrations are development",
+					}},
+				}).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "assets rules set",
+			args: []string{"assets", "rules", "set", "--name", "Data Platform", "--rule", "pipeline backfill=development", "--rule", "incident=maintenance", "--prompt", "Schema migrations are development"},
+			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+				mas.On("SetClassificationRules", "Data Platform", assetsdomain.ClassificationRules{
+					Keywords: []assetsdomain.ClassificationRule{
+						{Keyword: "pipeline backfill", WorkType: "cap-development"},
+						{Keyword: "incident", WorkType: "cap-maintenance"},
+					},
+					Prompt: "Schema migrations are development",
+				}).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:    "assets rules set rejects an unknown work type",
+			args:    []string{"assets", "rules", "set", "--name", "Data Platform", "--rule", "backfill=research"},
+			setup:   func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {},
+			wantErr: true,
+		},
+		{
+			name:    "assets rules set requires a rule or prompt",
+			args:    []string{"assets", "rules", "set", "--name", "Data Platform"},
+			setup:   func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {},
+			wantErr: true,
+		},
+		{
+			name: "assets rules show",
+			args: []string{"assets", "rules", "show", "--name", "Data Platform"},
+			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+				mas.On("GetAsset", "Data Platform").Return(&assetsdomain.Asset{Name: "Data Platform", ClassificationRules: &assetsdomain.ClassificationRules{
+					Keywords: []assetsdomain.ClassificationRule{{Keyword: "backfill", WorkType: "cap-development"}},
+				}}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "assets rules clear",
+			args: []string{"assets", "rules", "clear", "--name", "Data Platform"},
+			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+				mas.On("SetClassificationRules", "Data Platform", assetsdomain.ClassificationRules{}).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "tasks classify apply replacing all labels",
+			args: []string{"tasks", "classify", "--project", "TEST", "--sprint", "Sprint1", "--platform", "jira", "--apply", "--replace-all"},
+			setup: func(mas *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mas.On("ListAssets").Return([]*assetsdomain.Asset{}, nil)
+				mts.On("ClassifyTasks", mock.Anything, tasksdomain.ClassifyTasksInput{
+					Project:    "TEST",
+					Sprint:     "Sprint1",
+					Apply:      true,
+					ReplaceAll: true,
+				}).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "tasks sample stratified by work type",
+			args: []string{"tasks", "sample", "--project", "FN", "--quarter", "2024-Q2", "--size", "2", "--stratify", "worktype", "--seed", "42"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mts.On("SampleTasks", mock.Anything, tasksdomain.SampleTasksInput{
+					Project:  "FN",
+					Quarter:  "2024-Q2",
+					Size:     2,
+					Stratify: "worktype",
+					Seed:     42,
+				}).Return(&tasksdomain.TaskSample{
+					Definition: tasksdomain.SampleDefinition{ID: "FN-2024-Q2-42", Seed: 42, Population: 2, TaskKeys: []string{"FN-1"}},
+					Tasks:      []*tasksdomain.Task{{Key: "FN-1", WorkType: tasksdomain.WorkTypeDevelopment}},
+				}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "assets delete moves to the trash",
+			args: []string{"assets", "delete", "--name", "booking"},
+			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+				mas.On("DeleteAsset", "booking", false).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "assets delete with purge",
+			args: []string{"assets", "delete", "--name", "booking", "--purge"},
+			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+				mas.On("PurgeAsset", "booking", false).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "tasks delete moves to the trash",
+			args: []string{"tasks", "delete", "--project", "FN", "--sprint", "Sprint1"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mts.On("DeleteTasks", mock.Anything, "FN", "Sprint1", false).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "tasks delete with purge",
+			args: []string{"tasks", "delete", "--project", "FN", "--sprint", "Sprint1", "--purge"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mts.On("DeleteTasks", mock.Anything, "FN", "Sprint1", true).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "tasks code link",
+			args: []string{"tasks", "code", "link", "--project", "FN", "--sprint", "Sprint1"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mts.On("LinkCodeChanges", mock.Anything, tasksdomain.CodeLinkInput{Project: "FN", Sprint: "Sprint1"}).
+					Return(&tasksdomain.CodeLinkResult{Project: "FN", Sprint: "Sprint1", Tasks: 2, Linked: 1, Commits: 3, WithoutCode: []string{"FN-2"}, Updated: 1}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "tasks code link without a code host",
+			args: []string{"tasks", "code", "link", "--project", "FN", "--sprint", "Sprint1"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mts.On("LinkCodeChanges", mock.Anything, tasksdomain.CodeLinkInput{Project: "FN", Sprint: "Sprint1"}).
+					Return(nil, tasksdomain.ErrNoCodeHost)
+			},
+			wantErr: true,
+		},
+		{
+			name: "tasks code show",
+			args: []string{"tasks", "code", "show", "--project", "FN", "--sprint", "Sprint1"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mts.On("GetTasks", mock.Anything, "FN", "Sprint1").Return([]*tasksdomain.Task{
+					{Key: "FN-1", Summary: "Ledger export", WorkType: tasksdomain.WorkTypeDevelopment, CodeReferences: []tasksdomain.CodeReference{
+						{Kind: tasksdomain.CodeReferenceCommit, Repository: "acme/api", ID: "a1b2c3d4e5", Title: "FN-1: add ledger export"},
+					}},
+				}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "tasks split",
+			args: []string{"tasks", "split", "--issue", "FN-123", "--development", "70", "--maintenance", "30"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				shares := map[tasksdomain.WorkType]float64{
+					tasksdomain.WorkTypeDevelopment: 70,
+					tasksdomain.WorkTypeMaintenance: 30,
+				}
+				mts.On("SplitTask", mock.Anything, "FN-123", shares).
+					Return(&tasksdomain.WorkTypeSplit{IssueKey: "FN-123", Shares: shares}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "tasks split with invalid shares",
+			args: []string{"tasks", "split", "--issue", "FN-123", "--development", "70"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mts.On("SplitTask", mock.Anything, "FN-123", map[tasksdomain.WorkType]float64{tasksdomain.WorkTypeDevelopment: 70}).
+					Return(nil, tasksdomain.ErrSplitNotHundred)
 			},
-			wantErr: false,
+			wantErr: true,
 		},
 		{
-			name: "list assets after creation",
-			args: []string{"assets", "list"},
-			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
-				mas.On("ListAssets").Return([]*assetsdomain.Asset{
-					{
-						ID:          "cap-asset-test",
-						Name:        "Test Asset",
-						Description: "Test Description",
-					},
-				}, nil)
+			name: "tasks split clear",
+			args: []string{"tasks", "split", "--issue", "FN-123", "--clear"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mts.On("RemoveTaskSplit", mock.Anything, "FN-123").Return(nil)
 			},
 			wantErr: false,
 		},
 		{
-			name: "update documentation",
-			args: []string{"assets", "documentation", "update", "--asset", "test"},
-			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
-				mas.On("GetAsset", "test").Return(&assetsdomain.Asset{
-					ID:          "cap-asset-test",
-					Name:        "Test Asset",
-					Description: "Test Description",
+			name: "tasks split lists the splits",
+			args: []string{"tasks", "split"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mts.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{
+					{IssueKey: "FN-123", Shares: map[tasksdomain.WorkType]float64{tasksdomain.WorkTypeDevelopment: 100}},
 				}, nil)
-				mas.On("UpdateDocumentation", "test").Return(nil)
 			},
 			wantErr: false,
 		},
 		{
-			name: "increment task count",
-			args: []string{"assets", "tasks", "increment", "--asset", "test"},
-			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
-				mas.On("GetAsset", "test").Return(&assetsdomain.Asset{
-					ID:          "cap-asset-test",
-					Name:        "Test Asset",
-					Description: "Test Description",
+			name: "trash list",
+			args: []string{"trash", "list"},
+			setup: func(mas *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				deletedAt := time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)
+				mas.On("ListDeletedAssets").Return([]*assetsdomain.DeletedAsset{
+					{Asset: &assetsdomain.Asset{Name: "booking"}, DeletedAt: deletedAt, ExpiresAt: deletedAt.AddDate(0, 0, 30)},
+				}, nil)
+				mts.On("ListDeletedTasks", mock.Anything).Return([]*tasksdomain.DeletedTask{
+					{Task: &tasksdomain.Task{Key: "FN-1", Project: "FN", Sprint: "Sprint1"}, DeletedAt: deletedAt, ExpiresAt: deletedAt.AddDate(0, 0, 30)},
 				}, nil)
-				mas.On("IncrementTaskCount", "test").Return(nil)
 			},
 			wantErr: false,
 		},
 		{
-			name: "decrement task count",
-			args: []string{"assets", "tasks", "decrement", "--asset", "test"},
-			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
-				mas.On("GetAsset", "test").Return(&assetsdomain.Asset{
-					ID:          "cap-asset-test",
-					Name:        "Test Asset",
-					Description: "Test Description",
-				}, nil)
-				mas.On("DecrementTaskCount", "test").Return(nil)
+			name: "trash restore an asset and a task",
+			args: []string{"trash", "restore", "--asset", "booking", "--task", "FN-1"},
+			setup: func(mas *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mas.On("RestoreAsset", "booking").Return(&assetsdomain.Asset{Name: "booking"}, nil)
+				mts.On("RestoreTask", mock.Anything, "FN-1").Return(&tasksdomain.Task{Key: "FN-1"}, nil)
 			},
 			wantErr: false,
 		},
 		{
-			name: "show help",
-			args: []string{"--help"},
+			name: "trash restore without a record",
+			args: []string{"trash", "restore"},
 			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
 			},
+			wantErr: true,
+		},
+		{
+			name: "tasks coverage meeting the thresholds",
+			args: []string{"tasks", "coverage", "--project", "FN", "--quarter", "2024-Q2", "--asset-threshold", "50"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mts.On("LabelCoverage", mock.Anything, tasksdomain.LabelCoverageInput{Project: "FN", Quarter: "2024-Q2", AssetThreshold: 50}).
+					Return(&tasksdomain.LabelCoverage{Project: "FN", Quarter: "2024-Q2", Done: 2, WithWorkType: 2, WithAsset: 1, WorkTypePercent: 100, AssetPercent: 50, AssetThreshold: 50, Passed: true}, nil)
+			},
 			wantErr: false,
 		},
 		{
-			name: "missing required flag",
-			args: []string{"assets", "create"},
+			name: "tasks coverage as json",
+			args: []string{"tasks", "coverage", "--project", "FN", "--quarter", "2024-Q2", "--format", "json"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mts.On("LabelCoverage", mock.Anything, tasksdomain.LabelCoverageInput{Project: "FN", Quarter: "2024-Q2"}).
+					Return(&tasksdomain.LabelCoverage{Project: "FN", Quarter: "2024-Q2", Passed: true, MissingWorkType: []string{}, MissingAsset: []string{}}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "tasks coverage below a threshold",
+			args: []string{"tasks", "coverage", "--project", "FN", "--quarter", "2024-Q2", "--work-type-threshold", "90"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mts.On("LabelCoverage", mock.Anything, tasksdomain.LabelCoverageInput{Project: "FN", Quarter: "2024-Q2", WorkTypeThreshold: 90}).
+					Return(&tasksdomain.LabelCoverage{Project: "FN", Quarter: "2024-Q2", Done: 2, WithWorkType: 1, WorkTypePercent: 50, WorkTypeThreshold: 90, Passed: false}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "tasks coverage with invalid format",
+			args: []string{"tasks", "coverage", "--project", "FN", "--quarter", "2024-Q2", "--format", "csv"},
 			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
 			},
 			wantErr: true,
 		},
 		{
-			name: "sprint allocate with required flags",
-			args: []string{"sprint", "allocate", "--project", "TEST", "--sprint", "Sprint1"},
-			setup: func(_ *MockAssetService, _ *MockTaskService, mss *MockSprintService) {
-				mss.On("ProcessJiraIssues", "TEST", "Sprint1", "").Return("Allocation result", nil)
+			name: "tasks coverage service error",
+			args: []string{"tasks", "coverage", "--project", "FN", "--quarter", "2024"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mts.On("LabelCoverage", mock.Anything, tasksdomain.LabelCoverageInput{Project: "FN", Quarter: "2024"}).
+					Return(nil, tasksdomain.ErrInvalidQuarter)
+			},
+			wantErr: true,
+		},
+		{
+			name: "tasks label-history",
+			args: []string{"tasks", "label-history", "--issue", "FN-123"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mts.On("LabelHistory", mock.Anything, "FN-123").
+					Return(&tasksdomain.LabelHistory{IssueKey: "FN-123", Labels: []string{"cap-maintenance"}, Changes: []tasksdomain.LabelChange{
+						{Label: "cap-maintenance", Action: tasksdomain.LabelAdded, Author: "Jane Doe", At: time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)},
+					}}, nil)
 			},
 			wantErr: false,
 		},
 		{
-			name: "sprint allocate with override",
-			args: []string{"sprint", "allocate", "--project", "TEST", "--sprint", "Sprint1", "--override", "{\"ISSUE-1\": 6}"},
-			setup: func(_ *MockAssetService, _ *MockTaskService, mss *MockSprintService) {
-				mss.On("ProcessJiraIssues", "TEST", "Sprint1", "{\"ISSUE-1\": 6}").Return("Allocation result", nil)
+			name: "tasks label-history as json",
+			args: []string{"tasks", "label-history", "--issue", "FN-123", "--format", "json"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mts.On("LabelHistory", mock.Anything, "FN-123").
+					Return(&tasksdomain.LabelHistory{IssueKey: "FN-123", Labels: []string{}, Changes: []tasksdomain.LabelChange{}}, nil)
 			},
 			wantErr: false,
 		},
 		{
-			name: "sprint allocate missing project",
-			args: []string{"sprint", "allocate", "--sprint", "Sprint1", "--platform", "jira"},
+			name: "tasks label-history missing issue",
+			args: []string{"tasks", "label-history"},
 			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
 			},
 			wantErr: true,
 		},
 		{
-			name: "sprint allocate missing sprint",
-			args: []string{"sprint", "allocate", "--project", "TEST", "--platform", "jira"},
+			name: "tasks label-history with invalid format",
+			args: []string{"tasks", "label-history", "--issue", "FN-123", "--format", "csv"},
 			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
 			},
 			wantErr: true,
 		},
 		{
-			name: "shell completion commands",
-			args: []string{"completion", "bash"},
-			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+			name: "tasks label-history service error",
+			args: []string{"tasks", "label-history", "--issue", "FN-999"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mts.On("LabelHistory", mock.Anything, "FN-999").
+					Return(nil, fmt.Errorf("failed to get task FN-999: not found; fetch its sprint first"))
 			},
-			wantErr: false,
+			wantErr: true,
 		},
 		{
-			name: "tasks classify with required flags",
-			args: []string{"tasks", "classify", "--project", "TEST", "--sprint", "Sprint1", "--platform", "jira"},
-			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
-				mts.On("ClassifyTasks", mock.Anything, tasksdomain.ClassifyTasksInput{
-					Project: "TEST",
-					Sprint:  "Sprint1",
-					DryRun:  false,
-					Apply:   false,
-				}).Return(nil)
+			name: "tasks sample missing quarter",
+			args: []string{"tasks", "sample", "--project", "FN"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
 			},
-			wantErr: false,
+			wantErr: true,
 		},
 		{
 			name: "tasks classify missing project",
@@ -412,6 +2139,188 @@ func TestRun(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "tasks show rolled up by initiative",
+			args: []string{"tasks", "show", "--project", "FN", "--sprint", "Sprint1", "--rollup", "initiative"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mts.On("GetTasks", mock.Anything, "FN", "Sprint1").Return([]*tasksdomain.Task{
+					{Key: "FN-1", Hierarchy: []tasksdomain.HierarchyLink{{Level: "epic", Key: "FN-10"}, {Level: "initiative", Key: "FN-100"}}},
+					{Key: "FN-2"},
+				}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "tasks show with notes",
+			args: []string{"tasks", "show", "--project", "FN", "--sprint", "Sprint1"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mts.On("GetTasks", mock.Anything, "FN", "Sprint1").Return([]*tasksdomain.Task{{Key: "FN-1"}, {Key: "FN-2"}}, nil)
+				mts.On("TaskNotes", mock.Anything, []string{"FN-1", "FN-2"}).Return(map[string][]tasksdomain.TaskNote{
+					"FN-1": {{IssueKey: "FN-1", Text: "confirmed with tech lead: maintenance", CreatedAt: time.Now()}},
+				}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "tasks show fails when the notes cannot be read",
+			args: []string{"tasks", "show", "--project", "FN", "--sprint", "Sprint1"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mts.On("GetTasks", mock.Anything, "FN", "Sprint1").Return([]*tasksdomain.Task{{Key: "FN-1"}}, nil)
+				mts.On("TaskNotes", mock.Anything, []string{"FN-1"}).Return(nil, fmt.Errorf("failed to get task notes"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "map epic to an asset",
+			args: []string{"map", "epic", "fn-100", "--asset", "booking"},
+			setup: func(mas *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mas.On("GetAsset", "booking").Return(&assetsdomain.Asset{Name: "Booking"}, nil)
+				mts.On("MapEpic", mock.Anything, "fn-100", "Booking", "cap-asset-booking").Return(&tasksdomain.EpicMapping{
+					EpicKey: "FN-100", Asset: "Booking", Label: "cap-asset-booking",
+				}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "map epic with the asset flag first",
+			args: []string{"map", "epic", "-a", "booking", "FN-100"},
+			setup: func(mas *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mas.On("GetAsset", "booking").Return(&assetsdomain.Asset{ID: "cap-asset-booking", Name: "Booking Engine"}, nil)
+				mts.On("MapEpic", mock.Anything, "FN-100", "Booking Engine", "cap-asset-booking").Return(&tasksdomain.EpicMapping{
+					EpicKey: "FN-100", Asset: "Booking Engine", Label: "cap-asset-booking",
+				}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "map epic with two epic keys",
+			args: []string{"map", "epic", "FN-100", "FN-200", "--asset", "booking"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "map epic to an unknown asset",
+			args: []string{"map", "epic", "FN-100", "--asset", "nope"},
+			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+				mas.On("GetAsset", "nope").Return(nil, fmt.Errorf("asset not found"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "map epic without asset",
+			args: []string{"map", "epic", "FN-100"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "map epic without epic key",
+			args: []string{"map", "epic", "--asset", "booking"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "map epic clear",
+			args: []string{"map", "epic", "FN-100", "--clear"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mts.On("UnmapEpic", mock.Anything, "FN-100").Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "map list",
+			args: []string{"map", "list"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mts.On("ListEpicMappings", mock.Anything).Return([]tasksdomain.EpicMapping{
+					{EpicKey: "FN-100", Asset: "Booking", Label: "cap-asset-booking"},
+				}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "map unmapped",
+			args: []string{"map", "unmapped", "--days", "30"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mts.On("UnmappedEpics", mock.Anything, mock.AnythingOfType("time.Time")).Return([]tasksdomain.UnmappedEpic{
+					{EpicKey: "FN-200", Tasks: 3, Sprints: []string{"Sprint 1"}},
+				}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "map unmapped with non-positive days",
+			args: []string{"map", "unmapped", "--days", "0"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "tasks note add",
+			args: []string{"tasks", "note", "add", "--task", "fn-123", "--text", "confirmed with tech lead: maintenance"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mts.On("AddTaskNote", mock.Anything, "fn-123", "confirmed with tech lead: maintenance").Return(&tasksdomain.TaskNote{IssueKey: "FN-123"}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "tasks note add without text",
+			args: []string{"tasks", "note", "add", "--task", "FN-123", "--text", " "},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mts.On("AddTaskNote", mock.Anything, "FN-123", " ").Return(nil, tasksdomain.ErrEmptyNote)
+			},
+			wantErr: true,
+		},
+		{
+			name: "tasks note list",
+			args: []string{"tasks", "note", "list", "-t", "fn-123"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mts.On("TaskNotes", mock.Anything, []string{"FN-123"}).Return(map[string][]tasksdomain.TaskNote{
+					"FN-123": {{IssueKey: "FN-123", Text: "confirmed with tech lead: maintenance", CreatedAt: time.Now()}},
+				}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "tasks note list without notes",
+			args: []string{"tasks", "note", "list", "--task", "FN-124"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mts.On("TaskNotes", mock.Anything, []string{"FN-124"}).Return(map[string][]tasksdomain.TaskNote{}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "tasks note list without task",
+			args: []string{"tasks", "note", "list"},
+			setup: func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "tasks show filtered, sorted and limited as a table",
+			args: []string{"tasks", "show", "--project", "FN", "--sprint", "Sprint1", "--status", "done", "--status", "in-progress", "--worktype", "development", "--unclassified", "--sort", "status", "--limit", "1", "--format", "table"},
+			setup: func(_ *MockAssetService, mts *MockTaskService, _ *MockSprintService) {
+				mts.On("GetTasks", mock.Anything, "FN", "Sprint1").Return([]*tasksdomain.Task{
+					{Key: "FN-1", Status: tasksdomain.TaskStatusDone, WorkType: tasksdomain.WorkTypeDevelopment},
+					{Key: "FN-2", Status: tasksdomain.TaskStatusInProgress},
+					{Key: "FN-3", Status: tasksdomain.TaskStatusTodo},
+				}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:    "tasks show with an invalid sort",
+			args:    []string{"tasks", "show", "--project", "FN", "--sprint", "Sprint1", "--sort", "priority"},
+			setup:   func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {},
+			wantErr: true,
+		},
+		{
+			name:    "tasks show with an invalid status",
+			args:    []string{"tasks", "show", "--project", "FN", "--sprint", "Sprint1", "--status", "archived"},
+			setup:   func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {},
+			wantErr: true,
+		},
 		{
 			name: "tasks show with non-existent asset",
 			args: []string{"tasks", "show", "--asset", "nonexistent"},
@@ -429,9 +2338,61 @@ func TestRun(t *testing.T) {
 					Name:        "Test Asset",
 					Description: "Test Description",
 				}, nil)
+				mas.On("GetTaskBreakdown", "Test Asset").Return(assetsdomain.NewTaskBreakdown("Test Asset", []assetsdomain.LinkedTask{
+					{Key: "FN-1", Sprint: "Sprint 1", WorkType: "cap-development"},
+					{Key: "FN-2", Sprint: "Sprint 2", WorkType: "cap-maintenance"},
+				}), nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "show asset with sprint hours",
+			args: []string{"assets", "show", "--name", "test", "--project", "FN", "--sprint", "Sprint 2"},
+			setup: func(mas *MockAssetService, mts *MockTaskService, mss *MockSprintService) {
+				mas.On("GetAsset", "test").Return(&assetsdomain.Asset{Name: "Test Asset"}, nil)
+				mas.On("GetTaskBreakdown", "Test Asset").Return(assetsdomain.NewTaskBreakdown("Test Asset", nil), nil)
+				mts.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+				mss.On("AssetActivity", sprintdomain.AssetActivityInput{Project: "FN", Sprint: "Sprint 2", Asset: "Test Asset"}).Return(&sprintdomain.AssetActivity{
+					Asset:       "Test Asset",
+					Project:     "FN",
+					Sprint:      "Sprint 2",
+					TotalHours:  10,
+					WorkTypeMix: []sprintdomain.WorkTypeHours{{WorkType: "cap-development", Hours: 10, Percentage: 100}},
+				}, nil)
 			},
 			wantErr: false,
 		},
+		{
+			name: "show asset task breakdown failure",
+			args: []string{"assets", "show", "--name", "test"},
+			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+				mas.On("GetAsset", "test").Return(&assetsdomain.Asset{Name: "Test Asset"}, nil)
+				mas.On("GetTaskBreakdown", "Test Asset").Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "pipeline quarter with invalid quarter",
+			args:    []string{"pipeline", "quarter", "--project", "FN", "--quarter", "2024-Q5"},
+			setup:   func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {},
+			wantErr: true,
+		},
+		{
+			name:    "pipeline quarter from unknown stage",
+			args:    []string{"pipeline", "quarter", "--project", "FN", "--quarter", "2024-Q2", "--from-stage", "deploy"},
+			setup:   func(_ *MockAssetService, _ *MockTaskService, _ *MockSprintService) {},
+			wantErr: true,
+		},
+		{
+			name: "show asset live without linked page",
+			args: []string{"assets", "show", "--name", "test", "--live"},
+			setup: func(mas *MockAssetService, _ *MockTaskService, _ *MockSprintService) {
+				mas.On("GetAsset", "test").Return(&assetsdomain.Asset{Name: "Test Asset"}, nil)
+				mas.On("GetTaskBreakdown", "Test Asset").Return(assetsdomain.NewTaskBreakdown("Test Asset", nil), nil)
+				mas.On("PreviewLive", "Test Asset").Return(nil, fmt.Errorf("asset Test Asset is not linked to a Confluence page; link one with assets link-doc"))
+			},
+			wantErr: true,
+		},
 		{
 			name: "show non-existent asset",
 			args: []string{"assets", "show", "--name", "nonexistent"},
@@ -507,3 +2468,110 @@ func TestRun(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateConfig(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "teams.json"), []byte(`{"FN": {"team": ["alice"]}}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tasks.json"), []byte("{\n  \"FN-1\": {\"key\": \"FN-1\", \"work_type\": \"development\"}\n}"), 0644))
+	app := NewApp(new(MockAssetService), new(MockTaskService), new(MockSprintService))
+	app.storageDir = dir
+
+	output, err := captureOutput(func() error {
+		os.Args = []string{"assetcap", "validate-config"}
+		return app.Run()
+	})
+
+	assert.EqualError(t, err, "1 of 3 data files are invalid")
+	assert.Contains(t, output, filepath.Join(dir, "teams.json")+": OK")
+	assert.Contains(t, output, filepath.Join(dir, "assets.json")+": not found, skipped")
+	assert.Contains(t, output, filepath.Join(dir, "tasks.json")+`: line 2: FN-1.work_type: unsupported value "development"`)
+
+	_, err = captureOutput(func() error {
+		os.Args = []string{"assetcap", "validate-config", "--tasks", filepath.Join(dir, "teams.json")}
+		return app.Run()
+	})
+	assert.EqualError(t, err, "1 of 3 data files are invalid")
+}
+
+func TestTasksImport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "export.csv")
+	require.NoError(t, os.WriteFile(path, []byte("Summary,Issue key,Project key,Sprint\nBuild checkout,TEST-1,TEST,Sprint1\n"), 0644))
+	taskService := new(MockTaskService)
+	taskService.On("ImportTasks", mock.Anything, mock.MatchedBy(func(tasks []*tasksdomain.Task) bool {
+		return len(tasks) == 1 && tasks[0].Key == "TEST-1" && tasks[0].Sprint == "Sprint1"
+	}), "TEST").Return(nil)
+	app := NewApp(new(MockAssetService), taskService, new(MockSprintService))
+
+	output, err := captureOutput(func() error {
+		os.Args = []string{"assetcap", "tasks", "import", "--file", path, "--project", "TEST"}
+		return app.Run()
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, output, "Successfully imported tasks from "+path)
+	taskService.AssertExpectations(t)
+}
+
+func TestLoadReportTemplate(t *testing.T) {
+	dir := t.TempDir()
+	htmlPath := filepath.Join(dir, "board.html")
+	require.NoError(t, os.WriteFile(htmlPath, []byte("<h1>{{.KPIs.Period}}</h1>"), 0644))
+	textPath := filepath.Join(dir, "board.tmpl")
+	require.NoError(t, os.WriteFile(textPath, []byte("{{.KPIs.Period}}"), 0644))
+
+	template, err := loadReportTemplate(htmlPath)
+	require.NoError(t, err)
+	assert.Equal(t, "board.html", template.Name)
+	assert.True(t, template.HTML)
+
+	template, err = loadReportTemplate(textPath)
+	require.NoError(t, err)
+	assert.Equal(t, "{{.KPIs.Period}}", template.Body)
+	assert.False(t, template.HTML)
+
+	template, err = loadReportTemplate("finance")
+	require.NoError(t, err)
+	assert.Contains(t, template.Body, "# Capitalization")
+}
+
+func TestPrintExplanation(t *testing.T) {
+	output, err := captureOutput(func() error {
+		printExplanation(&sprintdomain.AllocationExplanation{
+			IssueKey: "FN-7",
+			Summary:  "Checkout",
+			Period:   "Sprint1",
+			Method:   sprintdomain.AllocationMethodTime,
+			Timeline: []sprintdomain.TimelineEvent{
+				{At: time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC), Field: "status", From: "To Do", To: "In Progress", Counted: true, Note: "starts the In Progress window"},
+			},
+			Steps: []string{"In Progress window: 2024-05-01 09:00 UTC to 2024-05-01 17:00 UTC"},
+			Shares: []sprintdomain.ExplainedShare{
+				{Assignee: "Jane Doe", Hours: 8, Percentage: 100, Formula: []string{"hours = 8.00 h"}},
+			},
+		})
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, output, "FN-7: Checkout\n")
+	assert.Contains(t, output, `* 2024-05-01 09:00 UTC  status: "To Do" -> "In Progress"  starts the In Progress window`)
+	assert.Contains(t, output, "  1. In Progress window: 2024-05-01 09:00 UTC to 2024-05-01 17:00 UTC\n")
+	assert.Contains(t, output, "  Jane Doe: 8.00 h, 100.00%\n    hours = 8.00 h\n")
+}
+
+func TestAssetStatuses(t *testing.T) {
+	launch := time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)
+	statuses := assetStatuses([]*assetsdomain.Asset{
+		{Name: "booking", Status: "live", StatusHistory: []assetsdomain.StatusEntry{
+			{Status: "development", Source: assetsdomain.StatusSourceInitial},
+			{Status: "live", EffectiveFrom: launch, Source: assetsdomain.StatusSourceSync},
+		}},
+		{Name: "search", Status: "beta"},
+		{Name: "draft"},
+	})
+
+	assert.Equal(t, sprintdomain.AssetStatuses{
+		"booking": {{Status: "development"}, {Status: "live", From: launch}},
+		"search":  {{Status: "beta"}},
+	}, statuses)
+}
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/mcp"
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// mcpServerVersion is the version the MCP server announces to agents
+const mcpServerVersion = "1.0.0"
+
+// serveMCP answers MCP requests on stdio, or on a Unix socket when --socket is given
+func (a *App) serveMCP(ctx *cli.Context) error {
+	runCtx, stop := signal.NotifyContext(ctx.Context, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	server := mcp.NewServer("assetcap", mcpServerVersion, a.mcpTools())
+
+	if path := ctx.String("socket"); path != "" {
+		listener, err := net.Listen("unix", path)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", path, err)
+		}
+		log.Printf("Serving MCP tools on %s", path)
+		return server.Listen(runCtx, listener)
+	}
+
+	// stdout carries the protocol, so anything the services print goes to stderr instead
+	protocol := os.Stdout
+	os.Stdout = os.Stderr
+	defer func() { os.Stdout = protocol }()
+	return server.Serve(runCtx, os.Stdin, protocol)
+}
+
+// mcpTools returns the tools agents query assetcap's capitalization data with
+func (a *App) mcpTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "list_assets",
+			Description: "List the digital assets tracked for capitalization with their status, owner and task count",
+			Run:         a.mcpListAssets,
+		},
+		{
+			Name:        "get_asset",
+			Description: "Get every stored detail of a digital asset, including its documentation, impairments and status history",
+			InputSchema: json.RawMessage(`{"type":"object","properties":{"name":{"type":"string","description":"Name or ID of the asset"}},"required":["name"]}`),
+			Run:         a.mcpGetAsset,
+		},
+		{
+			Name:        "get_tasks",
+			Description: "Look up stored Jira tasks by issue key, by asset, or by project and sprint",
+			InputSchema: json.RawMessage(`{"type":"object","properties":{` +
+				`"key":{"type":"string","description":"Issue key of a single task, such as FN-42"},` +
+				`"asset":{"type":"string","description":"Asset whose tasks to list"},` +
+				`"project":{"type":"string","description":"Project key, given with sprint"},` +
+				`"sprint":{"type":"string","description":"Sprint name, given with project"}}}`),
+			Run: a.mcpGetTasks,
+		},
+		{
+			Name:        "compute_allocation",
+			Description: "Compute the capitalization allocation of a sprint: hours per work type, per asset and per issue",
+			InputSchema: json.RawMessage(`{"type":"object","properties":{` +
+				`"projects":{"type":"array","items":{"type":"string"},"description":"Project keys to allocate"},` +
+				`"sprint":{"type":"string","description":"Sprint to allocate; the latest stored sprint of the projects when omitted"}},` +
+				`"required":["projects"]}`),
+			Run: a.mcpComputeAllocation,
+		},
+	}
+}
+
+// mcpAsset is an asset as listed to agents
+type mcpAsset struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Status      string `json:"status,omitempty"`
+	Owner       string `json:"owner,omitempty"`
+	Platform    string `json:"platform,omitempty"`
+	Tasks       int    `json:"tasks"`
+	DocLink     string `json:"docLink,omitempty"`
+}
+
+// mcpListAssets lists the stored assets
+func (a *App) mcpListAssets(context.Context, json.RawMessage) (any, error) {
+	assets, err := a.assetService.ListAssets()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load assets: %w", err)
+	}
+	listed := make([]mcpAsset, 0, len(assets))
+	for _, asset := range assets {
+		listed = append(listed, mcpAsset{
+			Name:        asset.Name,
+			Description: asset.Description,
+			Status:      asset.Status,
+			Owner:       asset.Owner,
+			Platform:    asset.Platform,
+			Tasks:       asset.AssociatedTaskCount,
+			DocLink:     asset.DocLink,
+		})
+	}
+	return map[string]any{"assets": listed}, nil
+}
+
+// mcpGetAsset returns a stored asset
+func (a *App) mcpGetAsset(_ context.Context, arguments json.RawMessage) (any, error) {
+	var input struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(arguments, &input); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+	if input.Name == "" {
+		return nil, errors.New("name is required")
+	}
+	asset, err := a.assetService.GetAsset(input.Name)
+	if err != nil {
+		return nil, fmt.Errorf("asset %s not found", input.Name)
+	}
+	return asset, nil
+}
+
+// mcpGetTasks looks up stored tasks by key, by asset, or by project and sprint
+func (a *App) mcpGetTasks(ctx context.Context, arguments json.RawMessage) (any, error) {
+	var input struct {
+		Key     string `json:"key"`
+		Asset   string `json:"asset"`
+		Project string `json:"project"`
+		Sprint  string `json:"sprint"`
+	}
+	if err := json.Unmarshal(arguments, &input); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	var tasks []*domain.Task
+	switch {
+	case input.Key != "":
+		task, err := a.taskService.GetTask(ctx, input.Key)
+		if err != nil {
+			return nil, err
+		}
+		tasks = []*domain.Task{task}
+	case input.Asset != "":
+		found, err := a.taskService.GetTasksByAsset(ctx, input.Asset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tasks of asset %s: %w", input.Asset, err)
+		}
+		tasks = found
+	case input.Project != "" && input.Sprint != "":
+		found, err := a.taskService.GetTasks(ctx, input.Project, input.Sprint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tasks for %s %s: %w", input.Project, input.Sprint, err)
+		}
+		tasks = found
+	default:
+		return nil, errors.New("give a key, an asset, or a project and a sprint")
+	}
+	if tasks == nil {
+		tasks = []*domain.Task{}
+	}
+	return map[string]any{"tasks": tasks}, nil
+}
+
+// mcpAllocation is the capitalization allocation of a sprint as returned to agents
+type mcpAllocation struct {
+	Period  string               `json:"period"`
+	Overall mcpHours             `json:"overall"`
+	Assets  []mcpAssetAllocation `json:"assets"`
+	Issues  []mcpIssueAllocation `json:"issues"`
+}
+
+// mcpHours are allocated hours by work type
+type mcpHours struct {
+	TotalHours       float64 `json:"totalHours"`
+	CapitalizedHours float64 `json:"capitalizedHours"`
+	DevelopmentHours float64 `json:"developmentHours"`
+	MaintenanceHours float64 `json:"maintenanceHours"`
+	DiscoveryHours   float64 `json:"discoveryHours"`
+	ImpairedHours    float64 `json:"impairedHours"`
+}
+
+type mcpAssetAllocation struct {
+	Asset  string `json:"asset"`
+	Status string `json:"status,omitempty"`
+	mcpHours
+}
+
+type mcpIssueAllocation struct {
+	Team       string  `json:"team,omitempty"`
+	IssueKey   string  `json:"issueKey"`
+	Title      string  `json:"title"`
+	Assignee   string  `json:"assignee"`
+	WorkType   string  `json:"workType"`
+	Asset      string  `json:"asset"`
+	Status     string  `json:"status"`
+	Hours      float64 `json:"hours"`
+	Percentage float64 `json:"percentage"`
+	Impaired   bool    `json:"impaired,omitempty"`
+	Policy     string  `json:"policy,omitempty"`
+}
+
+// mcpComputeAllocation computes the capitalization allocation of a sprint
+func (a *App) mcpComputeAllocation(ctx context.Context, arguments json.RawMessage) (any, error) {
+	var input struct {
+		Projects []string `json:"projects"`
+		Sprint   string   `json:"sprint"`
+	}
+	if err := json.Unmarshal(arguments, &input); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+	if len(input.Projects) == 0 {
+		return nil, errors.New("projects are required")
+	}
+
+	assets, err := a.assetService.ListAssets()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load assets: %w", err)
+	}
+	report, err := a.capitalizationReport(ctx, assets, input.Projects, input.Sprint)
+	if err != nil {
+		return nil, err
+	}
+	return newMCPAllocation(report), nil
+}
+
+// newMCPAllocation converts a capitalization report to the allocation returned to agents
+func newMCPAllocation(report *sprintdomain.CapitalizationReport) mcpAllocation {
+	allocation := mcpAllocation{
+		Period:  report.KPIs.Period,
+		Overall: newMCPHours(report.KPIs.Overall),
+		Assets:  make([]mcpAssetAllocation, 0, len(report.Assets)),
+		Issues:  make([]mcpIssueAllocation, 0, len(report.Rows)),
+	}
+	for _, asset := range report.Assets {
+		allocation.Assets = append(allocation.Assets, mcpAssetAllocation{
+			Asset:    asset.AssetName,
+			Status:   asset.Status,
+			mcpHours: newMCPHours(asset.Summary),
+		})
+	}
+	for _, row := range report.Rows {
+		allocation.Issues = append(allocation.Issues, mcpIssueAllocation{
+			Team:       row.Team,
+			IssueKey:   row.IssueKey,
+			Title:      row.IssueTitle,
+			Assignee:   row.Assignee,
+			WorkType:   row.WorkType,
+			Asset:      row.AssetName,
+			Status:     row.Status,
+			Hours:      row.Hours,
+			Percentage: row.Percentage,
+			Impaired:   row.Impaired,
+			Policy:     row.Policy,
+		})
+	}
+	return allocation
+}
+
+func newMCPHours(summary sprintdomain.CapitalizationSummary) mcpHours {
+	return mcpHours{
+		TotalHours:       summary.TotalHours,
+		CapitalizedHours: summary.CapitalizedHours(),
+		DevelopmentHours: summary.DevelopmentHours,
+		MaintenanceHours: summary.MaintenanceHours,
+		DiscoveryHours:   summary.DiscoveryHours,
+		ImpairedHours:    summary.ImpairedHours,
+	}
+}
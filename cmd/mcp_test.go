@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/mcp"
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	tasksdomain "github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+func TestMCPTools(t *testing.T) {
+	app := NewApp(new(MockAssetService), new(MockTaskService), new(MockSprintService))
+
+	names := make([]string, 0)
+	for _, tool := range app.mcpTools() {
+		names = append(names, tool.Name)
+		if tool.InputSchema != nil {
+			assert.True(t, json.Valid(tool.InputSchema), "%s has an invalid input schema", tool.Name)
+		}
+	}
+	assert.Equal(t, []string{"list_assets", "get_asset", "get_tasks", "compute_allocation"}, names)
+}
+
+func TestMCPListAndGetAssets(t *testing.T) {
+	assets := new(MockAssetService)
+	app := NewApp(assets, new(MockTaskService), new(MockSprintService))
+	booking := &assetsdomain.Asset{Name: "booking", Description: "Handles reservations", Status: "Live", AssociatedTaskCount: 7}
+	assets.On("ListAssets").Return([]*assetsdomain.Asset{booking}, nil)
+	assets.On("GetAsset", "booking").Return(booking, nil)
+	assets.On("GetAsset", "missing").Return(nil, errors.New("not found"))
+
+	listed, err := app.mcpListAssets(context.Background(), json.RawMessage(`{}`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"assets": []mcpAsset{{Name: "booking", Description: "Handles reservations", Status: "Live", Tasks: 7}}}, listed)
+
+	asset, err := app.mcpGetAsset(context.Background(), json.RawMessage(`{"name":"booking"}`))
+	require.NoError(t, err)
+	assert.Same(t, booking, asset)
+
+	_, err = app.mcpGetAsset(context.Background(), json.RawMessage(`{"name":"missing"}`))
+	assert.EqualError(t, err, "asset missing not found")
+	_, err = app.mcpGetAsset(context.Background(), json.RawMessage(`{}`))
+	assert.EqualError(t, err, "name is required")
+}
+
+func TestMCPGetTasks(t *testing.T) {
+	tasks := new(MockTaskService)
+	app := NewApp(new(MockAssetService), tasks, new(MockSprintService))
+
+	task := &tasksdomain.Task{Key: "FN-1", Summary: "Add invoices", WorkType: tasksdomain.WorkTypeDevelopment}
+	tasks.On("GetTask", mock.Anything, "FN-1").Return(task, nil)
+	tasks.On("GetTask", mock.Anything, "FN-9").Return(nil, errors.New("failed to get task FN-9: not found; fetch its sprint first"))
+	tasks.On("GetTasksByAsset", mock.Anything, "booking").Return([]*tasksdomain.Task{task}, nil)
+	tasks.On("GetTasks", mock.Anything, "FN", "Sprint 1").Return([]*tasksdomain.Task(nil), nil)
+
+	for _, arguments := range []string{`{"key":"FN-1"}`, `{"asset":"booking"}`} {
+		found, err := app.mcpGetTasks(context.Background(), json.RawMessage(arguments))
+		require.NoError(t, err, arguments)
+		assert.Equal(t, map[string]any{"tasks": []*tasksdomain.Task{task}}, found, arguments)
+	}
+
+	found, err := app.mcpGetTasks(context.Background(), json.RawMessage(`{"project":"FN","sprint":"Sprint 1"}`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"tasks": []*tasksdomain.Task{}}, found, "no tasks are an empty list")
+
+	_, err = app.mcpGetTasks(context.Background(), json.RawMessage(`{"key":"FN-9"}`))
+	assert.EqualError(t, err, "failed to get task FN-9: not found; fetch its sprint first")
+	_, err = app.mcpGetTasks(context.Background(), json.RawMessage(`{"project":"FN"}`))
+	assert.EqualError(t, err, "give a key, an asset, or a project and a sprint")
+}
+
+func TestMCPComputeAllocation(t *testing.T) {
+	assets := new(MockAssetService)
+	tasks := new(MockTaskService)
+	sprints := new(MockSprintService)
+	app := NewApp(assets, tasks, sprints)
+
+	assets.On("ListAssets").Return([]*assetsdomain.Asset{{Name: "booking"}}, nil)
+	tasks.On("ClassificationCoverage", mock.Anything).Return([]tasksdomain.SprintCoverage{
+		{Project: "FN", Sprint: "Sprint 1"}, {Project: "FN", Sprint: "Sprint 2"},
+	}, nil)
+	tasks.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+	sprints.On("BuildCapitalizationReport", mock.MatchedBy(func(input sprintdomain.CapitalizationReportInput) bool {
+		return input.Sprint == "Sprint 2" && len(input.Projects) == 1 && input.Projects[0] == "FN"
+	})).Return(&sprintdomain.CapitalizationReport{
+		KPIs: sprintdomain.CapitalizationKPIs{
+			Period:  "Sprint 2",
+			Overall: sprintdomain.CapitalizationSummary{TotalHours: 10, DevelopmentHours: 6, MaintenanceHours: 4},
+		},
+		Assets: []sprintdomain.AssetCapitalization{
+			{AssetName: "booking", Status: "Live", Summary: sprintdomain.CapitalizationSummary{TotalHours: 6, DevelopmentHours: 6}},
+		},
+		Rows: []sprintdomain.ReportRow{{Team: "Payments", IssueAllocation: sprintdomain.IssueAllocation{
+			IssueKey: "FN-1", IssueTitle: "Add invoices", Assignee: "Jane", WorkType: "cap-development",
+			AssetName: "booking", Status: "Done", Hours: 6, Percentage: 60,
+		}}},
+	}, nil)
+
+	result, err := app.mcpComputeAllocation(context.Background(), json.RawMessage(`{"projects":["FN"]}`))
+	require.NoError(t, err)
+	allocation := result.(mcpAllocation)
+	assert.Equal(t, "Sprint 2", allocation.Period)
+	assert.Equal(t, 6.0, allocation.Overall.CapitalizedHours)
+	require.Len(t, allocation.Assets, 1)
+	assert.Equal(t, mcpAssetAllocation{Asset: "booking", Status: "Live", mcpHours: mcpHours{TotalHours: 6, CapitalizedHours: 6, DevelopmentHours: 6}}, allocation.Assets[0])
+	assert.Equal(t, []mcpIssueAllocation{{
+		Team: "Payments", IssueKey: "FN-1", Title: "Add invoices", Assignee: "Jane", WorkType: "cap-development",
+		Asset: "booking", Status: "Done", Hours: 6, Percentage: 60,
+	}}, allocation.Issues)
+
+	_, err = app.mcpComputeAllocation(context.Background(), json.RawMessage(`{}`))
+	assert.EqualError(t, err, "projects are required")
+}
+
+func TestMCPServer_CallsAppTools(t *testing.T) {
+	assets := new(MockAssetService)
+	app := NewApp(assets, new(MockTaskService), new(MockSprintService))
+	assets.On("ListAssets").Return([]*assetsdomain.Asset{{Name: "booking", AssociatedTaskCount: 2}}, nil)
+
+	var out strings.Builder
+	server := mcp.NewServer("assetcap", mcpServerVersion, app.mcpTools())
+	err := server.Serve(context.Background(), strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"list_assets"}}`+"\n"), &out)
+	require.NoError(t, err)
+
+	var response struct {
+		Result struct {
+			StructuredContent map[string]any `json:"structuredContent"`
+		} `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out.String()), &response))
+	assert.Equal(t, map[string]any{"assets": []any{map[string]any{"name": "booking", "tasks": float64(2)}}}, response.Result.StructuredContent)
+}
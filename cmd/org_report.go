@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// configuredProjects returns the projects of the teams file, sorted
+func configuredProjects(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read teams file: %w", err)
+	}
+	var teams map[string]json.RawMessage
+	if err := json.Unmarshal(data, &teams); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal teams file: %w", err)
+	}
+
+	projects := make([]string, 0, len(teams))
+	for project := range teams {
+		projects = append(projects, project)
+	}
+	sort.Strings(projects)
+	return projects, nil
+}
+
+// formatOrgReport renders the organization rollup as Markdown, with a section per team, or as CSV
+func formatOrgReport(report *domain.OrgReport, format string, locale sprintdomain.Locale) (string, error) {
+	switch format {
+	case "markdown":
+		return orgReportMarkdown(report, locale), nil
+	case "csv":
+		return orgReportCSV(report, locale)
+	}
+	return "", fmt.Errorf("unsupported format: %s", format)
+}
+
+func orgReportMarkdown(report *domain.OrgReport, locale sprintdomain.Locale) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Organization capitalization %s\n\n", report.Quarter)
+	b.WriteString("| Team | Tasks | Classified |")
+	for _, workType := range domain.ReportWorkTypes {
+		fmt.Fprintf(&b, " %s |", workType)
+	}
+	b.WriteString("\n|---|---|---|" + strings.Repeat("---|", len(domain.ReportWorkTypes)) + "\n")
+	for _, rollup := range append(append([]domain.TeamRollup{}, report.Teams...), report.Overall) {
+		fmt.Fprintf(&b, "| %s | %d | %d |", rollup.Team, rollup.Tasks, rollup.Classified())
+		for _, workType := range domain.ReportWorkTypes {
+			fmt.Fprintf(&b, " %s |", locale.Percent(rollup.Share(workType)))
+		}
+		b.WriteString("\n")
+	}
+
+	for _, rollup := range report.Teams {
+		fmt.Fprintf(&b, "\n## %s\n\n", rollup.Team)
+		if rollup.Tasks == 0 {
+			fmt.Fprintf(&b, "No stored tasks were created in %s.\n", report.Quarter)
+			continue
+		}
+		b.WriteString("| Work type | Tasks | Share |\n|---|---|---|\n")
+		for _, workType := range domain.ReportWorkTypes {
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", workType, locale.Number(rollup.WorkTypes[workType], 2), locale.Percent(rollup.Share(workType)))
+		}
+		if rollup.Unclassified > 0 {
+			fmt.Fprintf(&b, "\n%d of %d tasks are not classified and are left out of the shares.\n", rollup.Unclassified, rollup.Tasks)
+		}
+		if rollup.Commits+rollup.PullRequests > 0 {
+			fmt.Fprintf(&b, "\nCode changes: %d commits, %d pull requests; %d development tasks without code changes.\n",
+				rollup.Commits, rollup.PullRequests, rollup.DevelopmentWithoutCode)
+		}
+	}
+	return b.String()
+}
+
+func orgReportCSV(report *domain.OrgReport, locale sprintdomain.Locale) (string, error) {
+	var b strings.Builder
+	writer := csv.NewWriter(&b)
+	header := []string{"team", "quarter", "tasks", "unclassified"}
+	for _, workType := range domain.ReportWorkTypes {
+		header = append(header, string(workType), string(workType)+" share")
+	}
+	header = append(header, "commits", "pull requests", "development without code")
+	if err := writer.Write(header); err != nil {
+		return "", fmt.Errorf("failed to generate CSV: %w", err)
+	}
+	for _, rollup := range append(append([]domain.TeamRollup{}, report.Teams...), report.Overall) {
+		record := []string{rollup.Team, rollup.Quarter, strconv.Itoa(rollup.Tasks), strconv.Itoa(rollup.Unclassified)}
+		for _, workType := range domain.ReportWorkTypes {
+			record = append(record, locale.Number(rollup.WorkTypes[workType], 2), locale.Percent(rollup.Share(workType)))
+		}
+		record = append(record, strconv.Itoa(rollup.Commits), strconv.Itoa(rollup.PullRequests), strconv.Itoa(rollup.DevelopmentWithoutCode))
+		if err := writer.Write(record); err != nil {
+			return "", fmt.Errorf("failed to generate CSV: %w", err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", fmt.Errorf("failed to generate CSV: %w", err)
+	}
+	return b.String(), nil
+}
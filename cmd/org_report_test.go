@@ -0,0 +1,62 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+func TestConfiguredProjects(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "teams.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"OPS": {"team": ["carol"]}, "FN": {"team": ["alice"]}}`), 0644))
+
+	projects, err := configuredProjects(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"FN", "OPS"}, projects)
+
+	_, err = configuredProjects(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorContains(t, err, "failed to read teams file")
+}
+
+func orgReport() *domain.OrgReport {
+	teams := []domain.TeamRollup{
+		{Team: "FN", Quarter: "2024-Q2", Tasks: 5, Unclassified: 1, WorkTypes: map[domain.WorkType]float64{domain.WorkTypeDevelopment: 3, domain.WorkTypeMaintenance: 1},
+			Commits: 4, PullRequests: 2, DevelopmentWithoutCode: 1},
+		{Team: "OPS", Quarter: "2024-Q2"},
+	}
+	return &domain.OrgReport{Quarter: "2024-Q2", Teams: teams, Overall: domain.RollupTeams("2024-Q2", teams)}
+}
+
+func TestFormatOrgReport_Markdown(t *testing.T) {
+	output, err := formatOrgReport(orgReport(), "markdown", sprintdomain.Locale{})
+	require.NoError(t, err)
+
+	assert.Contains(t, output, "# Organization capitalization 2024-Q2\n")
+	assert.Contains(t, output, "| FN | 5 | 4 | 75.00% | 25.00% | 0.00% |\n")
+	assert.Contains(t, output, "| organization | 5 | 4 | 75.00% | 25.00% | 0.00% |\n")
+	assert.Contains(t, output, "## FN\n\n| Work type | Tasks | Share |\n|---|---|---|\n| cap-development | 3.00 | 75.00% |\n")
+	assert.Contains(t, output, "1 of 5 tasks are not classified and are left out of the shares.\n")
+	assert.Contains(t, output, "Code changes: 4 commits, 2 pull requests; 1 development tasks without code changes.\n")
+	assert.Equal(t, 1, strings.Count(output, "Code changes:"), "teams without linked code changes get no code line")
+	assert.Contains(t, output, "## OPS\n\nNo stored tasks were created in 2024-Q2.\n")
+}
+
+func TestFormatOrgReport_CSV(t *testing.T) {
+	output, err := formatOrgReport(orgReport(), "csv", sprintdomain.Locale{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "team,quarter,tasks,unclassified,cap-development,cap-development share,cap-maintenance,cap-maintenance share,cap-discovery,cap-discovery share,commits,pull requests,development without code\n"+
+		"FN,2024-Q2,5,1,3.00,75.00%,1.00,25.00%,0.00,0.00%,4,2,1\n"+
+		"OPS,2024-Q2,0,0,0.00,0.00%,0.00,0.00%,0.00,0.00%,0,0,0\n"+
+		"organization,2024-Q2,5,1,3.00,75.00%,1.00,25.00%,0.00,0.00%,4,2,1\n", output)
+
+	_, err = formatOrgReport(orgReport(), "pdf", sprintdomain.Locale{})
+	assert.EqualError(t, err, "unsupported format: pdf")
+}
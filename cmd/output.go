@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/config"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sink"
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+// Commands whose output can be routed to a sink with --out or the output configuration
+const (
+	outputSprintAllocate  = "sprint allocate"
+	outputSprintReport    = "sprint report"
+	outputReportTimesheet = "report timesheet"
+	outputReportEstimates = "report estimates"
+	outputReportOrg       = "report org"
+	outputReportERP       = "report erp"
+)
+
+// outputCommands lists the commands that accept an output destination
+var outputCommands = []string{outputSprintAllocate, outputSprintReport, outputReportTimesheet, outputReportEstimates, outputReportOrg, outputReportERP}
+
+// outFlag returns the flag selecting the output destination of a command
+func outFlag() *cli.StringFlag {
+	return &cli.StringFlag{
+		Name:  "out",
+		Usage: "Write the output to a file path, s3://bucket/key, gs://bucket/object or an http(s) webhook instead of stdout (defaults to the configured destination)",
+	}
+}
+
+// validateOutputs checks that the configured destinations name known commands and supported sinks
+func validateOutputs(cfg config.OutputConfig) error {
+	commands := make([]string, 0, len(cfg.Destinations))
+	for command := range cfg.Destinations {
+		commands = append(commands, command)
+	}
+	sort.Strings(commands)
+
+	for _, command := range commands {
+		if !isOutputCommand(command) {
+			return fmt.Errorf("unknown output command %q: use one of %s", command, strings.Join(outputCommands, ", "))
+		}
+		if _, err := sink.Open(cfg.Destinations[command], sinkOptions(cfg)); err != nil {
+			return fmt.Errorf("invalid output destination for %s: %w", command, err)
+		}
+	}
+	return nil
+}
+
+// isOutputCommand reports whether the command accepts an output destination
+func isOutputCommand(command string) bool {
+	for _, name := range outputCommands {
+		if name == command {
+			return true
+		}
+	}
+	return false
+}
+
+// sinkOptions returns the sink settings of the output configuration
+func sinkOptions(cfg config.OutputConfig) sink.Options {
+	return sink.Options{
+		WebhookHeaders: cfg.WebhookHeaders,
+		S3Endpoint:     cfg.S3Endpoint,
+		S3Region:       cfg.S3Region,
+	}
+}
+
+// writeOutput sends a command's output to the --out destination, the destination
+// configured for the command, or stdout. Output generated from a storage snapshot starts with
+// the time the snapshot was taken.
+func (a *App) writeOutput(ctx *cli.Context, command, output, contentType string) error {
+	destination := ctx.String("out")
+	if destination == "" {
+		destination = a.outputs.Destinations[command]
+	}
+
+	out, err := sink.Open(destination, sinkOptions(a.outputs))
+	if err != nil {
+		return err
+	}
+	if a.snapshot != nil {
+		header := snapshotHeader(contentType, a.snapshot.TakenAt)
+		if header == "" {
+			fmt.Fprintf(os.Stderr, "Data snapshot: %s\n", snapshotStamp(a.snapshot.TakenAt))
+		}
+		output = header + output
+	}
+	if err := out.Write(ctx.Context, []byte(output), contentType); err != nil {
+		return fmt.Errorf("failed to write output to %s: %w", out, err)
+	}
+	if _, ok := out.(sink.Stdout); !ok {
+		fmt.Fprintf(os.Stderr, "Wrote %s output to %s\n", command, out)
+	}
+	if file, ok := out.(*sink.File); ok {
+		return a.signArtifact(ctx.Context, file.Path())
+	}
+	return nil
+}
+
+// reportContentType returns the content type of a capitalization report
+func reportContentType(format sprintdomain.ReportFormat, template *sprintdomain.ReportTemplate) string {
+	switch {
+	case template != nil && template.HTML:
+		return sink.ContentTypeHTML
+	case template != nil:
+		return sink.ContentTypeText
+	case format == sprintdomain.ReportFormatMarkdown:
+		return sink.ContentTypeMarkdown
+	default:
+		return sink.ContentTypeCSV
+	}
+}
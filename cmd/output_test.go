@@ -0,0 +1,84 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/config"
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	tasksdomain "github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+func TestWriteOutput_File(t *testing.T) {
+	mas, mts, mss := new(MockAssetService), new(MockTaskService), new(MockSprintService)
+	mas.On("ListAssets").Return([]*assetsdomain.Asset{}, nil)
+	mts.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+	mss.On("ProcessJiraIssues", sprintdomain.AllocationInput{Project: "TEST", Sprint: "Sprint1", Delimiter: ','}).Return("a,b\n", nil)
+	app := NewApp(mas, mts, mss)
+	path := filepath.Join(t.TempDir(), "out", "allocation.csv")
+
+	output, err := captureOutput(func() error {
+		os.Args = []string{"assetcap", "sprint", "allocate", "--project", "TEST", "--sprint", "Sprint1", "--out", path}
+		return app.Run()
+	})
+	require.NoError(t, err)
+	assert.Empty(t, output)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "a,b\n", string(data))
+}
+
+func TestWriteOutput_ConfiguredWebhook(t *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "text/csv", r.Header.Get("Content-Type"))
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		body, _ := io.ReadAll(r.Body)
+		received = string(body)
+	}))
+	defer server.Close()
+
+	mss := new(MockSprintService)
+	mss.On("GenerateTimesheet", sprintdomain.TimesheetInput{Project: "TEST", Sprint: "Sprint1", Delimiter: ','}).Return("person,hours\n", nil)
+	app := NewApp(new(MockAssetService), new(MockTaskService), mss)
+	app.outputs = config.OutputConfig{
+		Destinations:   map[string]string{outputReportTimesheet: server.URL + "/timesheets"},
+		WebhookHeaders: map[string]string{"Authorization": "Bearer token"},
+	}
+
+	output, err := captureOutput(func() error {
+		os.Args = []string{"assetcap", "report", "timesheet", "--project", "TEST", "--sprint", "Sprint1"}
+		return app.Run()
+	})
+	require.NoError(t, err)
+	assert.Empty(t, output)
+	assert.Equal(t, "person,hours\n", received)
+}
+
+func TestValidateOutputs(t *testing.T) {
+	assert.NoError(t, validateOutputs(config.OutputConfig{}))
+	assert.NoError(t, validateOutputs(config.OutputConfig{Destinations: map[string]string{outputSprintReport: "gs://reports/q2.md"}}))
+
+	err := validateOutputs(config.OutputConfig{Destinations: map[string]string{"sprint push": "out.csv"}})
+	assert.EqualError(t, err, `unknown output command "sprint push": use one of sprint allocate, sprint report, report timesheet, report estimates, report org, report erp`)
+
+	err = validateOutputs(config.OutputConfig{Destinations: map[string]string{outputSprintAllocate: "ftp://example.com/a.csv"}})
+	assert.ErrorContains(t, err, "invalid output destination for sprint allocate: unsupported output destination")
+}
+
+func TestReportContentType(t *testing.T) {
+	assert.Equal(t, "text/csv", reportContentType(sprintdomain.ReportFormatCSV, nil))
+	assert.Equal(t, "text/markdown", reportContentType(sprintdomain.ReportFormatMarkdown, nil))
+	assert.Equal(t, "text/html", reportContentType(sprintdomain.ReportFormatCSV, &sprintdomain.ReportTemplate{HTML: true}))
+	assert.Equal(t, "text/plain", reportContentType(sprintdomain.ReportFormatCSV, &sprintdomain.ReportTemplate{}))
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	tasksdomain "github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// exitPartialFailure is the exit code of a piped command that wrote its output but could not
+// process every task; exit code 1 means the command failed and wrote nothing to rely on
+const exitPartialFailure = 2
+
+// taskBatch is the JSON envelope piped between commands: tasks fetch --output json prints it,
+// tasks classify --from-stdin reads and, with --output json, prints it again, and sprint
+// report --from-stdin reports the sprint it holds
+type taskBatch struct {
+	Project string              `json:"project"`
+	Sprint  string              `json:"sprint"`
+	Tasks   []*tasksdomain.Task `json:"tasks"`
+	// Failures are the tasks a command in the pipe could not process, carried to the end of it
+	Failures []batchFailure `json:"failures,omitempty"`
+}
+
+// batchFailure is a task a piped command could not process
+type batchFailure struct {
+	Key   string `json:"key"`
+	Error string `json:"error"`
+}
+
+// partialFailureError reports the failed tasks of a piped command that still wrote its output;
+// the command exits with exitPartialFailure
+type partialFailureError struct {
+	failures []batchFailure
+}
+
+func (e *partialFailureError) Error() string {
+	keys := make([]string, 0, len(e.failures))
+	for _, failure := range e.failures {
+		keys = append(keys, failure.Key)
+	}
+	return fmt.Sprintf("%d tasks failed: %s", len(e.failures), strings.Join(keys, ", "))
+}
+
+// result returns the error a command ends with after writing the batch: none when every task
+// was processed, a partial failure otherwise
+func (b taskBatch) result() error {
+	if len(b.Failures) == 0 {
+		return nil
+	}
+	return &partialFailureError{failures: b.Failures}
+}
+
+// unclassified adds a failure for every task of the batch without a work type
+func (b *taskBatch) unclassified() {
+	for _, task := range b.Tasks {
+		if task.WorkType == "" {
+			b.Failures = append(b.Failures, batchFailure{Key: task.Key, Error: "not classified"})
+		}
+	}
+}
+
+// outputFlag selects the text or JSON output of a command whose JSON output can be piped
+func outputFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:  "output",
+		Usage: "Output format (text, json); json prints a task batch to pipe into --from-stdin",
+		Value: "text",
+	}
+}
+
+// fromStdinFlag reads the task batch piped by another command
+func fromStdinFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:  "from-stdin",
+		Usage: "Read the task batch piped by a command run with --output json; its project and sprint apply unless given",
+	}
+}
+
+// readBatch reads the task batch piped on the input
+func (a *App) readBatch() (taskBatch, error) {
+	input := a.stdin
+	if input == nil {
+		input = os.Stdin
+	}
+	data, err := io.ReadAll(input)
+	if err != nil {
+		return taskBatch{}, fmt.Errorf("failed to read stdin: %w", err)
+	}
+	var batch taskBatch
+	if err := json.Unmarshal(data, &batch); err != nil {
+		return taskBatch{}, fmt.Errorf("invalid task batch on stdin: %w", err)
+	}
+	return batch, nil
+}
+
+// batchScope returns the project and sprint of a command reading a batch: the flags when
+// given, the batch's otherwise
+func batchScope(ctx *cli.Context, batch taskBatch) (string, string, error) {
+	project, sprint := ctx.String("project"), ctx.String("sprint")
+	if project == "" {
+		project = batch.Project
+	}
+	if sprint == "" {
+		sprint = batch.Sprint
+	}
+	if project == "" || sprint == "" {
+		return "", "", fmt.Errorf("the task batch on stdin has no project and sprint; pass --project and --sprint")
+	}
+	return project, sprint, nil
+}
+
+// requireFlags fails like a required flag for the flags a command needs unless it reads a batch
+func requireFlags(ctx *cli.Context, names ...string) error {
+	for _, name := range names {
+		if ctx.String(name) == "" {
+			return fmt.Errorf("Required flag %q not set", name)
+		}
+	}
+	return nil
+}
+
+// batchTasks keeps the stored tasks the batch holds, in the batch's order
+func batchTasks(batch taskBatch, stored []*tasksdomain.Task) []*tasksdomain.Task {
+	byKey := make(map[string]*tasksdomain.Task, len(stored))
+	for _, task := range stored {
+		byKey[task.Key] = task
+	}
+	tasks := make([]*tasksdomain.Task, 0, len(batch.Tasks))
+	for _, task := range batch.Tasks {
+		if storedTask, ok := byKey[task.Key]; ok {
+			tasks = append(tasks, storedTask)
+		}
+	}
+	return tasks
+}
+
+// batchWorkTypes splits the hours of each classified task of the batch to its work type,
+// leaving the issues with a recorded work type split to it
+func batchWorkTypes(batch taskBatch, splits sprintdomain.WorkTypeSplits) sprintdomain.WorkTypeSplits {
+	for _, task := range batch.Tasks {
+		if task.WorkType == "" || splits.For(task.Key) != nil {
+			continue
+		}
+		if splits == nil {
+			splits = make(sprintdomain.WorkTypeSplits)
+		}
+		splits[strings.ToUpper(task.Key)] = sprintdomain.WorkTypeShares{string(task.WorkType): 100}
+	}
+	return splits
+}
+
+// fetchBatch fetches the tasks of a sprint and prints them as a task batch
+func (a *App) fetchBatch(ctx *cli.Context, project, sprint, platform string) error {
+	var tasks []*tasksdomain.Task
+	err := progressToStderr(func() error {
+		if err := a.taskService.FetchTasks(ctx.Context, project, sprint, platform); err != nil {
+			return err
+		}
+		var err error
+		tasks, err = a.taskService.GetTasks(ctx.Context, project, sprint)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return printJSON(taskBatch{Project: project, Sprint: sprint, Tasks: tasks})
+}
+
+// classifyBatch stores and classifies the tasks of the batch piped on stdin, printing them
+// classified as a task batch with --output json. Tasks left without a work type are failures.
+func (a *App) classifyBatch(ctx *cli.Context) error {
+	asJSON, err := jsonOutput(ctx)
+	if err != nil {
+		return err
+	}
+	if asJSON && ctx.Bool("dry-run") {
+		return fmt.Errorf("--dry-run only previews classifications and cannot be piped with --output json")
+	}
+	batch, err := a.readBatch()
+	if err != nil {
+		return err
+	}
+	project, sprint, err := batchScope(ctx, batch)
+	if err != nil {
+		return err
+	}
+	rules, err := a.classificationRules()
+	if err != nil {
+		return err
+	}
+
+	classify := func() error {
+		if err := a.taskService.ImportTasks(ctx.Context, batch.Tasks, project); err != nil {
+			return err
+		}
+		if err := a.taskService.ClassifyTasks(ctx.Context, tasksdomain.ClassifyTasksInput{
+			Project:     project,
+			Sprint:      sprint,
+			DryRun:      ctx.Bool("dry-run"),
+			Apply:       ctx.Bool("apply"),
+			LabelPolicy: a.labelPolicy,
+			ReplaceAll:  ctx.Bool("replace-all"),
+			Rules:       rules,
+		}); err != nil {
+			return err
+		}
+		if !ctx.Bool("dry-run") {
+			a.publishClassified(ctx.Context, project, sprint, ctx.Bool("apply"))
+		}
+		return nil
+	}
+	if !asJSON {
+		if err := classify(); err != nil {
+			return err
+		}
+		fmt.Printf("Successfully classified %d piped tasks for project %s, sprint %s\n", len(batch.Tasks), project, sprint)
+		return nil
+	}
+
+	var stored []*tasksdomain.Task
+	err = progressToStderr(func() error {
+		if err := classify(); err != nil {
+			return err
+		}
+		var err error
+		stored, err = a.taskService.GetTasks(ctx.Context, project, sprint)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	classified := taskBatch{Project: project, Sprint: sprint, Tasks: batchTasks(batch, stored), Failures: batch.Failures}
+	classified.unclassified()
+	if err := printJSON(classified); err != nil {
+		return err
+	}
+	return classified.result()
+}
+
+// progressToStderr runs f with the progress it prints on stdout sent to stderr, keeping stdout
+// for the JSON piped to the next command
+func progressToStderr(f func() error) error {
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	defer func() { os.Stdout = stdout }()
+	return f()
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	tasksdomain "github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+func TestTasksFetch_OutputJSON(t *testing.T) {
+	tasks := []*tasksdomain.Task{{Key: "FN-1", Summary: "Build checkout", Project: "FN", Sprint: "Penguins"}}
+	taskService := new(MockTaskService)
+	taskService.On("FetchTasks", mock.Anything, "FN", "Penguins", "jira").Return(nil)
+	taskService.On("GetTasks", mock.Anything, "FN", "Penguins").Return(tasks, nil)
+	app := NewApp(new(MockAssetService), taskService, new(MockSprintService))
+
+	output, err := captureOutput(func() error {
+		os.Args = []string{"assetcap", "tasks", "fetch", "--project", "FN", "--sprint", "Penguins", "--platform", "jira", "--output", "json"}
+		return app.Run()
+	})
+	require.NoError(t, err)
+
+	var batch taskBatch
+	require.NoError(t, json.Unmarshal([]byte(output), &batch), "stdout holds only the batch")
+	assert.Equal(t, "FN", batch.Project)
+	assert.Equal(t, "Penguins", batch.Sprint)
+	require.Len(t, batch.Tasks, 1)
+	assert.Equal(t, "FN-1", batch.Tasks[0].Key)
+
+	_, err = captureOutput(func() error {
+		os.Args = []string{"assetcap", "tasks", "fetch", "--project", "FN", "--fix-version", "2024.5", "--platform", "jira", "--output", "json"}
+		return app.Run()
+	})
+	assert.ErrorContains(t, err, "--output json pipes the tasks of a sprint")
+}
+
+func TestTasksClassify_FromStdin(t *testing.T) {
+	piped := `{"schemaVersion": "1.0", "project": "FN", "sprint": "Penguins", "tasks": [{"key": "FN-1"}, {"key": "FN-2"}]}`
+	taskService := new(MockTaskService)
+	taskService.On("ImportTasks", mock.Anything, mock.MatchedBy(func(tasks []*tasksdomain.Task) bool {
+		return len(tasks) == 2
+	}), "FN").Return(nil)
+	taskService.On("ClassifyTasks", mock.Anything, tasksdomain.ClassifyTasksInput{Project: "FN", Sprint: "Penguins"}).Return(nil)
+	taskService.On("GetTasks", mock.Anything, "FN", "Penguins").Return([]*tasksdomain.Task{
+		{Key: "FN-2", Project: "FN"},
+		{Key: "FN-1", Project: "FN", WorkType: tasksdomain.WorkTypeDevelopment},
+		{Key: "FN-3", Project: "FN", WorkType: tasksdomain.WorkTypeMaintenance},
+	}, nil)
+	assetService := new(MockAssetService)
+	assetService.On("ListAssets").Return([]*assetsdomain.Asset{}, nil)
+	app := NewApp(assetService, taskService, new(MockSprintService))
+	app.stdin = strings.NewReader(piped)
+
+	output, err := captureOutput(func() error {
+		os.Args = []string{"assetcap", "tasks", "classify", "--from-stdin", "--output", "json"}
+		return app.Run()
+	})
+
+	var partial *partialFailureError
+	require.True(t, errors.As(err, &partial), "unclassified tasks are a partial failure")
+	assert.EqualError(t, err, "1 tasks failed: FN-2")
+	var batch taskBatch
+	require.NoError(t, json.Unmarshal([]byte(output), &batch))
+	require.Len(t, batch.Tasks, 2, "only the piped tasks are passed on")
+	assert.Equal(t, "FN-1", batch.Tasks[0].Key)
+	assert.Equal(t, tasksdomain.WorkTypeDevelopment, batch.Tasks[0].WorkType)
+	assert.Equal(t, []batchFailure{{Key: "FN-2", Error: "not classified"}}, batch.Failures)
+}
+
+func TestTasksClassify_RequiresScope(t *testing.T) {
+	app := NewApp(new(MockAssetService), new(MockTaskService), new(MockSprintService))
+	_, err := captureOutput(func() error {
+		os.Args = []string{"assetcap", "tasks", "classify", "--project", "FN"}
+		return app.Run()
+	})
+	assert.EqualError(t, err, `Required flag "sprint" not set`)
+
+	app.stdin = strings.NewReader(`{"tasks": []}`)
+	_, err = captureOutput(func() error {
+		os.Args = []string{"assetcap", "tasks", "classify", "--from-stdin"}
+		return app.Run()
+	})
+	assert.ErrorContains(t, err, "has no project and sprint")
+}
+
+func TestSprintReport_FromStdin(t *testing.T) {
+	piped := `{"project": "FN", "sprint": "Penguins", "tasks": [{"key": "FN-1", "work_type": "cap-maintenance"}], "failures": [{"key": "FN-2", "error": "not classified"}]}`
+	taskService := new(MockTaskService)
+	taskService.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+	assetService := new(MockAssetService)
+	assetService.On("ListAssets").Return([]*assetsdomain.Asset{}, nil)
+	sprintService := new(MockSprintService)
+	sprintService.On("GenerateCapitalizationReport", sprintdomain.CapitalizationReportInput{
+		Projects:       []string{"FN"},
+		Sprint:         "Penguins",
+		Format:         sprintdomain.ReportFormatCSV,
+		Delimiter:      ',',
+		WorkTypeSplits: sprintdomain.WorkTypeSplits{"FN-1": {"cap-maintenance": 100}},
+	}).Return("metric,value\n", nil)
+	app := NewApp(assetService, taskService, sprintService)
+	app.stdin = strings.NewReader(piped)
+
+	output, err := captureOutput(func() error {
+		os.Args = []string{"assetcap", "sprint", "report", "--from-stdin"}
+		return app.Run()
+	})
+
+	assert.Equal(t, "metric,value\n", output)
+	assert.EqualError(t, err, "1 tasks failed: FN-2", "failures piped in carry to the end of the pipe")
+	sprintService.AssertExpectations(t)
+}
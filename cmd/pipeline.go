@@ -0,0 +1,290 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/pipeline"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/schema"
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// pipelineOptions are the command line choices of a quarter pipeline run
+type pipelineOptions struct {
+	project string
+	quarter domain.Quarter
+	// spaces and label select the Confluence pages synced; no space skips the sync
+	spaces []string
+	label  string
+	outDir string
+	push   bool
+}
+
+// quarterPipeline returns the stages closing a quarter for a project, in pipeline order
+func (a *App) quarterPipeline(opts pipelineOptions) []pipeline.Step {
+	return []pipeline.Step{
+		{Stage: pipeline.StageSyncAssets, Run: func(ctx context.Context, _ *pipeline.Checkpoint) (pipeline.StageResult, error) {
+			if len(opts.spaces) == 0 {
+				return pipeline.StageResult{Skipped: true, Note: "no --space given"}, nil
+			}
+			result, err := a.assetService.SyncFromConfluence(assetsdomain.SyncInput{Spaces: opts.spaces, Label: opts.label})
+			if err != nil {
+				return pipeline.StageResult{}, err
+			}
+			a.publishSynced(ctx, result.SyncedAssets)
+			return pipeline.StageResult{Note: fmt.Sprintf("%d assets synced, %d not synced", len(result.SyncedAssets), len(result.NotSyncedAssets))}, nil
+		}},
+		{Stage: pipeline.StageFetch, Run: func(ctx context.Context, checkpoint *pipeline.Checkpoint) (pipeline.StageResult, error) {
+			sprints, err := a.sprintService.ListSprints(opts.project, opts.quarter.Start(), opts.quarter.End())
+			if err != nil {
+				return pipeline.StageResult{}, err
+			}
+			if len(sprints) == 0 {
+				return pipeline.StageResult{}, fmt.Errorf("no sprints of %s started in %s", opts.project, opts.quarter)
+			}
+			checkpoint.Sprints = nil
+			for _, sprint := range sprints {
+				if err := a.taskService.FetchTasks(ctx, opts.project, sprint.Name, "jira"); err != nil {
+					return pipeline.StageResult{}, fmt.Errorf("failed to fetch tasks of sprint %s: %w", sprint.Name, err)
+				}
+				checkpoint.Sprints = append(checkpoint.Sprints, sprint.Name)
+			}
+			return pipeline.StageResult{Note: fmt.Sprintf("%d sprints: %s", len(sprints), strings.Join(checkpoint.Sprints, ", "))}, nil
+		}},
+		{Stage: pipeline.StageClassify, Run: func(ctx context.Context, checkpoint *pipeline.Checkpoint) (pipeline.StageResult, error) {
+			for _, sprint := range checkpoint.Sprints {
+				if err := a.taskService.ClassifyTasks(ctx, domain.ClassifyTasksInput{
+					Project:     opts.project,
+					Sprint:      sprint,
+					LabelPolicy: a.labelPolicy,
+				}); err != nil {
+					return pipeline.StageResult{}, fmt.Errorf("failed to classify sprint %s: %w", sprint, err)
+				}
+				a.publishClassified(ctx, opts.project, sprint, false)
+			}
+			return pipeline.StageResult{Note: fmt.Sprintf("%d sprints classified", len(checkpoint.Sprints))}, nil
+		}},
+		{Stage: pipeline.StageAllocate, Run: func(ctx context.Context, checkpoint *pipeline.Checkpoint) (pipeline.StageResult, error) {
+			locale, err := configuredLocale(a.export)
+			if err != nil {
+				return pipeline.StageResult{}, err
+			}
+			assets, err := a.assetService.ListAssets()
+			if err != nil {
+				return pipeline.StageResult{}, fmt.Errorf("failed to load asset documentation links: %w", err)
+			}
+			splits, err := a.workTypeSplits(ctx)
+			if err != nil {
+				return pipeline.StageResult{}, err
+			}
+			var result pipeline.StageResult
+			for _, sprint := range checkpoint.Sprints {
+				input := sprintdomain.AllocationInput{
+					Project:        opts.project,
+					Sprint:         sprint,
+					Delimiter:      ',',
+					Locale:         locale,
+					Heuristics:     a.heuristics,
+					Export:         a.jiraExport,
+					AssetDocs:      assetDocLinks(assets),
+					AssetAccounts:  a.assetAccounts(assets),
+					WorkTypeSplits: splits,
+					Retirements:    assetRetirements(assets),
+				}
+				allocation, err := a.sprintService.ProcessJiraIssues(input)
+				if err != nil {
+					return pipeline.StageResult{}, fmt.Errorf("failed to allocate sprint %s: %w", sprint, err)
+				}
+				path, err := a.writeArtifact(ctx, opts.outDir, "allocation-"+artifactName(sprint)+".csv", allocation)
+				if err != nil {
+					return pipeline.StageResult{}, err
+				}
+				a.publishAllocated(ctx, input)
+				result.Artifacts = append(result.Artifacts, path)
+			}
+			return result, nil
+		}},
+		{Stage: pipeline.StagePolicy, Run: func(ctx context.Context, checkpoint *pipeline.Checkpoint) (pipeline.StageResult, error) {
+			splits, err := a.workTypeSplits(ctx)
+			if err != nil {
+				return pipeline.StageResult{}, err
+			}
+			var result pipeline.StageResult
+			var failed []string
+			for _, sprint := range checkpoint.Sprints {
+				verification, err := a.sprintService.VerifySprint(sprintdomain.VerificationInput{
+					Project:        opts.project,
+					Sprint:         sprint,
+					WorkTypeSplits: splits,
+					Heuristics:     a.heuristics,
+					Export:         a.jiraExport,
+				})
+				if err != nil {
+					return pipeline.StageResult{}, fmt.Errorf("failed to verify sprint %s: %w", sprint, err)
+				}
+				if !verification.Passed {
+					failed = append(failed, sprint)
+				}
+				data, err := schema.MarshalOutput(verification)
+				if err != nil {
+					return pipeline.StageResult{}, fmt.Errorf("failed to encode violations: %w", err)
+				}
+				path, err := a.writeArtifact(ctx, opts.outDir, "verification-"+artifactName(sprint)+".json", string(data))
+				if err != nil {
+					return pipeline.StageResult{}, err
+				}
+				result.Artifacts = append(result.Artifacts, path)
+			}
+			result.Note = "all sprints meet the closure criteria"
+			if len(failed) > 0 {
+				result.Note = fmt.Sprintf("closure criteria violated in %s", strings.Join(failed, ", "))
+			}
+			return result, nil
+		}},
+		{Stage: pipeline.StageReport, Run: func(ctx context.Context, checkpoint *pipeline.Checkpoint) (pipeline.StageResult, error) {
+			locale, err := configuredLocale(a.export)
+			if err != nil {
+				return pipeline.StageResult{}, err
+			}
+			assets, err := a.assetService.ListAssets()
+			if err != nil {
+				return pipeline.StageResult{}, fmt.Errorf("failed to load assets: %w", err)
+			}
+			splits, err := a.workTypeSplits(ctx)
+			if err != nil {
+				return pipeline.StageResult{}, err
+			}
+			var result pipeline.StageResult
+			for i, sprint := range checkpoint.Sprints {
+				input := sprintdomain.CapitalizationReportInput{
+					Projects:       []string{opts.project},
+					Sprint:         sprint,
+					Format:         sprintdomain.ReportFormatMarkdown,
+					Impairments:    assetImpairments(assets),
+					Policy:         assetPolicy(assets),
+					AssetDocs:      assetDocLinks(assets),
+					AssetAccounts:  a.assetAccounts(assets),
+					AssetStatuses:  assetStatuses(assets),
+					Retirements:    assetRetirements(assets),
+					WorkTypeSplits: splits,
+					Locale:         locale,
+					Heuristics:     a.heuristics,
+					Export:         a.jiraExport,
+				}
+				if i > 0 {
+					input.PreviousSprint = checkpoint.Sprints[i-1]
+				}
+				report, err := a.sprintService.GenerateCapitalizationReport(input)
+				if err != nil {
+					return pipeline.StageResult{}, fmt.Errorf("failed to report sprint %s: %w", sprint, err)
+				}
+				path, err := a.writeArtifact(ctx, opts.outDir, "report-"+artifactName(sprint)+".md", report)
+				if err != nil {
+					return pipeline.StageResult{}, err
+				}
+				result.Artifacts = append(result.Artifacts, path)
+			}
+			return result, nil
+		}},
+		{Stage: pipeline.StagePush, Run: func(_ context.Context, checkpoint *pipeline.Checkpoint) (pipeline.StageResult, error) {
+			if !opts.push {
+				return pipeline.StageResult{Skipped: true, Note: "no --push given"}, nil
+			}
+			var created, updated, failures int
+			for _, sprint := range checkpoint.Sprints {
+				pushed, err := a.sprintService.PushAllocations(sprintdomain.PushAllocationsInput{
+					Project:    opts.project,
+					Sprint:     sprint,
+					Mode:       sprintdomain.PushModeComment,
+					Heuristics: a.heuristics,
+					Export:     a.jiraExport,
+				})
+				if err != nil {
+					return pipeline.StageResult{}, fmt.Errorf("failed to push sprint %s: %w", sprint, err)
+				}
+				if len(pushed.Remaining) > 0 {
+					return pipeline.StageResult{}, fmt.Errorf("rate limited by Jira pushing sprint %s with %d rows left", sprint, len(pushed.Remaining))
+				}
+				created += len(pushed.Created)
+				updated += len(pushed.Updated)
+				failures += len(pushed.Failures)
+			}
+			return pipeline.StageResult{Note: fmt.Sprintf("created %d, updated %d, failed %d allocation comments", created, updated, failures)}, nil
+		}},
+	}
+}
+
+// pipelineStageNames returns the names of the pipeline stages in order
+func pipelineStageNames() []string {
+	names := make([]string, len(pipeline.Stages))
+	for i, stage := range pipeline.Stages {
+		names[i] = string(stage)
+	}
+	return names
+}
+
+// writeArtifact writes a pipeline artifact to the output directory, signing it when configured,
+// and returns its path
+func (a *App) writeArtifact(ctx context.Context, dir, name, content string) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory: %w", err)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := a.signArtifact(ctx, path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// artifactName turns a sprint name into a file name, e.g. "Sprint 12" into "sprint-12"
+func artifactName(sprint string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(sprint) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && b.Len() > 0 {
+			b.WriteRune('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// formatPipelineSummary lists the outcome and artifacts of every stage of a pipeline run
+func formatPipelineSummary(checkpoint *pipeline.Checkpoint) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Pipeline %s %s\n", checkpoint.Project, checkpoint.Quarter)
+	for _, record := range checkpoint.Stages {
+		status := "done"
+		switch {
+		case record.Resumed:
+			status = "from checkpoint"
+		case record.Skipped:
+			status = "skipped"
+		}
+		fmt.Fprintf(&b, "- %s: %s", record.Stage, status)
+		if record.Note != "" {
+			fmt.Fprintf(&b, " (%s)", record.Note)
+		}
+		b.WriteString("\n")
+	}
+	artifacts := checkpoint.Artifacts()
+	fmt.Fprintf(&b, "Artifacts (%d):\n", len(artifacts))
+	for _, artifact := range artifacts {
+		fmt.Fprintf(&b, "- %s\n", artifact)
+	}
+	return b.String()
+}
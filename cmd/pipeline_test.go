@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/pipeline"
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	tasksdomain "github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+func TestQuarterPipeline(t *testing.T) {
+	mas, mts, mss := new(MockAssetService), new(MockTaskService), new(MockSprintService)
+	quarter, err := tasksdomain.ParseQuarter("2024-Q2")
+	require.NoError(t, err)
+	sprints := []sprintdomain.Sprint{{Name: "Sprint 7"}, {Name: "Sprint 8"}}
+
+	mas.On("SyncFromConfluence", assetsdomain.SyncInput{Spaces: []string{"CAP"}, Label: "cap-asset"}).Return(&assetsdomain.SyncResult{SyncedAssets: []*assetsdomain.Asset{{Name: "Booking"}}}, nil)
+	mss.On("ListSprints", "FN", quarter.Start(), quarter.End()).Return(sprints, nil)
+	mas.On("ListAssets").Return([]*assetsdomain.Asset{}, nil)
+	mts.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+	for _, sprint := range sprints {
+		mts.On("FetchTasks", mock.Anything, "FN", sprint.Name, "jira").Return(nil)
+		mts.On("ClassifyTasks", mock.Anything, tasksdomain.ClassifyTasksInput{Project: "FN", Sprint: sprint.Name}).Return(nil)
+		mss.On("ProcessJiraIssues", mock.MatchedBy(func(input sprintdomain.AllocationInput) bool { return input.Sprint == sprint.Name })).Return("allocation of "+sprint.Name, nil)
+		mss.On("VerifySprint", mock.MatchedBy(func(input sprintdomain.VerificationInput) bool { return input.Sprint == sprint.Name })).Return(&sprintdomain.VerificationResult{Sprint: sprint.Name, Passed: sprint.Name == "Sprint 7"}, nil)
+		mss.On("PushAllocations", mock.MatchedBy(func(input sprintdomain.PushAllocationsInput) bool { return input.Sprint == sprint.Name })).Return(&sprintdomain.PushResult{Created: []string{"FN-1"}}, nil)
+	}
+	mss.On("GenerateCapitalizationReport", mock.MatchedBy(func(input sprintdomain.CapitalizationReportInput) bool {
+		return input.Sprint == "Sprint 7" && input.PreviousSprint == ""
+	})).Return("report of Sprint 7", nil)
+	mss.On("GenerateCapitalizationReport", mock.MatchedBy(func(input sprintdomain.CapitalizationReportInput) bool {
+		return input.Sprint == "Sprint 8" && input.PreviousSprint == "Sprint 7"
+	})).Return("report of Sprint 8", nil)
+
+	dir := t.TempDir()
+	app := NewApp(mas, mts, mss)
+	steps := app.quarterPipeline(pipelineOptions{project: "FN", quarter: quarter, spaces: []string{"CAP"}, label: "cap-asset", outDir: filepath.Join(dir, "out"), push: true})
+
+	checkpoint, err := pipeline.NewRunner(pipeline.NewJSONCheckpointStore(dir)).Run(context.Background(), "FN", "2024-Q2", "", steps)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Sprint 7", "Sprint 8"}, checkpoint.Sprints)
+	assert.Len(t, checkpoint.Artifacts(), 6)
+	report, err := os.ReadFile(filepath.Join(dir, "out", "report-sprint-8.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "report of Sprint 8", string(report))
+	policy, _ := checkpoint.Completed(pipeline.StagePolicy)
+	assert.Equal(t, "closure criteria violated in Sprint 8", policy.Note)
+	push, _ := checkpoint.Completed(pipeline.StagePush)
+	assert.Equal(t, "created 2, updated 0, failed 0 allocation comments", push.Note)
+	mas.AssertExpectations(t)
+	mts.AssertExpectations(t)
+	mss.AssertExpectations(t)
+}
+
+func TestQuarterPipeline_NoSprints(t *testing.T) {
+	mas, mts, mss := new(MockAssetService), new(MockTaskService), new(MockSprintService)
+	quarter, err := tasksdomain.ParseQuarter("2024-Q2")
+	require.NoError(t, err)
+	mss.On("ListSprints", "FN", quarter.Start(), quarter.End()).Return([]sprintdomain.Sprint{}, nil)
+
+	steps := NewApp(mas, mts, mss).quarterPipeline(pipelineOptions{project: "FN", quarter: quarter, outDir: t.TempDir()})
+	checkpoint, err := pipeline.NewRunner(pipeline.NewJSONCheckpointStore(t.TempDir())).Run(context.Background(), "FN", "2024-Q2", "", steps)
+
+	assert.EqualError(t, err, "stage fetch failed: no sprints of FN started in 2024-Q2; resume with --from-stage fetch")
+	sync, ok := checkpoint.Completed(pipeline.StageSyncAssets)
+	require.True(t, ok)
+	assert.True(t, sync.Skipped)
+}
+
+func TestArtifactName(t *testing.T) {
+	assert.Equal(t, "sprint-12", artifactName("Sprint 12"))
+	assert.Equal(t, "penguins-2024-05", artifactName("Penguins (2024/05)"))
+}
+
+func TestFormatPipelineSummary(t *testing.T) {
+	checkpoint := &pipeline.Checkpoint{
+		Project: "FN",
+		Quarter: "2024-Q2",
+		Stages: []pipeline.StageRecord{
+			{Stage: pipeline.StageFetch, Note: "1 sprints: Sprint 7", Resumed: true, CompletedAt: time.Now()},
+			{Stage: pipeline.StageAllocate, Artifacts: []string{"out/allocation-sprint-7.csv"}},
+			{Stage: pipeline.StagePush, Skipped: true, Note: "no --push given"},
+		},
+	}
+
+	assert.Equal(t, "Pipeline FN 2024-Q2\n"+
+		"- fetch: from checkpoint (1 sprints: Sprint 7)\n"+
+		"- allocate: done\n"+
+		"- push: skipped (no --push given)\n"+
+		"Artifacts (1):\n"+
+		"- out/allocation-sprint-7.csv\n", formatPipelineSummary(checkpoint))
+}
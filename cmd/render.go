@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/urfave/cli/v2"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	tasksdomain "github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// Output formats of the commands printing text or JSON
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+// globalOutputFlag selects the output format of every command rendering its result with
+// render; a command's own --output flag takes precedence
+func globalOutputFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:  "output",
+		Usage: "Output format (text, json) of assets list/show, tasks show, sprint allocate and the piped commands",
+		Value: outputText,
+	}
+}
+
+// outputFormat returns the output format of a command: the value of the closest --output flag
+// set on the command line, text when none is
+func outputFormat(ctx *cli.Context) string {
+	for _, c := range ctx.Lineage() {
+		if c.Command == nil {
+			continue
+		}
+		for _, flag := range c.Command.Flags {
+			if slices.Contains(flag.Names(), "output") && c.IsSet("output") {
+				return c.String("output")
+			}
+		}
+	}
+	return outputText
+}
+
+// jsonOutput reports whether a command prints JSON, rejecting unsupported output formats
+func jsonOutput(ctx *cli.Context) (bool, error) {
+	switch format := outputFormat(ctx); format {
+	case "", outputText:
+		return false, nil
+	case outputJSON:
+		return true, nil
+	default:
+		return false, fmt.Errorf("unsupported output %q: must be text or json", format)
+	}
+}
+
+// render prints v as JSON when the command runs with --output json, and its text output
+// otherwise
+func render(ctx *cli.Context, v interface{}, text func() error) error {
+	asJSON, err := jsonOutput(ctx)
+	if err != nil {
+		return err
+	}
+	if asJSON {
+		return printJSON(v)
+	}
+	return text()
+}
+
+// assetList is the JSON output of assets list
+type assetList struct {
+	Assets []*assetsdomain.Asset `json:"assets"`
+}
+
+// newAssetList lists the assets, as an empty list rather than null when there are none
+func newAssetList(assets []*assetsdomain.Asset) assetList {
+	return assetList{Assets: append([]*assetsdomain.Asset{}, assets...)}
+}
+
+// assetView is the JSON output of assets show: the asset, its linked tasks and, for a sprint,
+// its allocated hours
+type assetView struct {
+	Asset    *assetsdomain.Asset         `json:"asset"`
+	Tasks    *assetsdomain.TaskBreakdown `json:"tasks"`
+	Activity *sprintdomain.AssetActivity `json:"activity,omitempty"`
+}
+
+// taskListing is the JSON output of tasks show: the shown tasks of a sprint or an asset with
+// their notes, and the task count per group with --rollup
+type taskListing struct {
+	Project string                            `json:"project,omitempty"`
+	Sprint  string                            `json:"sprint,omitempty"`
+	Asset   string                            `json:"asset,omitempty"`
+	Tasks   []*tasksdomain.Task               `json:"tasks"`
+	Matched int                               `json:"matched"`
+	Notes   map[string][]tasksdomain.TaskNote `json:"notes,omitempty"`
+	Level   string                            `json:"level,omitempty"`
+	Groups  map[string]int                    `json:"groups,omitempty"`
+}
+
+// allocationList is the JSON output of sprint allocate
+type allocationList struct {
+	Project     string                         `json:"project"`
+	Sprint      string                         `json:"sprint,omitempty"`
+	FixVersion  string                         `json:"fixVersion,omitempty"`
+	Allocations []sprintdomain.IssueAllocation `json:"allocations"`
+}
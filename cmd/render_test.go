@@ -0,0 +1,136 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	tasksdomain "github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// runJSON runs the app with args and checks its output against the output schema of command
+func runJSON(t *testing.T, app *App, command string, args ...string) map[string]interface{} {
+	t.Helper()
+	output, err := captureOutput(func() error {
+		os.Args = append([]string{"assetcap"}, args...)
+		return app.Run()
+	})
+	require.NoError(t, err)
+
+	s, ok := outputSchema(command)
+	require.True(t, ok)
+	require.NoError(t, s.Validate([]byte(output)), output)
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(output), &decoded))
+	return decoded
+}
+
+func TestAssetsList_OutputJSON(t *testing.T) {
+	assetService := new(MockAssetService)
+	assetService.On("ListAssets").Return([]*assetsdomain.Asset{{ID: "cap-asset-booking", Name: "booking", Description: "Bookings"}}, nil)
+	app := NewApp(assetService, new(MockTaskService), new(MockSprintService))
+
+	decoded := runJSON(t, app, "assets list", "--output", "json", "assets", "list")
+
+	require.Len(t, decoded["assets"], 1)
+	assert.Equal(t, "booking", decoded["assets"].([]interface{})[0].(map[string]interface{})["name"])
+
+	empty := new(MockAssetService)
+	empty.On("ListAssets").Return([]*assetsdomain.Asset{}, nil)
+	decoded = runJSON(t, NewApp(empty, new(MockTaskService), new(MockSprintService)), "assets list", "--output", "json", "assets", "list")
+	assert.Equal(t, []interface{}{}, decoded["assets"])
+}
+
+func TestAssetsShow_OutputJSON(t *testing.T) {
+	assetService := new(MockAssetService)
+	assetService.On("GetAsset", "booking").Return(&assetsdomain.Asset{Name: "booking"}, nil)
+	assetService.On("GetTaskBreakdown", "booking").Return(assetsdomain.NewTaskBreakdown("booking", []assetsdomain.LinkedTask{
+		{Key: "FN-1", Sprint: "Sprint 1", WorkType: "cap-development"},
+	}), nil)
+	taskService := new(MockTaskService)
+	taskService.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+	sprintService := new(MockSprintService)
+	sprintService.On("AssetActivity", sprintdomain.AssetActivityInput{Project: "FN", Sprint: "Sprint 1", Asset: "booking"}).Return(&sprintdomain.AssetActivity{
+		Asset: "booking", Project: "FN", Sprint: "Sprint 1", TotalHours: 8,
+	}, nil)
+	app := NewApp(assetService, taskService, sprintService)
+
+	decoded := runJSON(t, app, "assets show", "--output", "json", "assets", "show", "--name", "booking", "--project", "FN", "--sprint", "Sprint 1")
+
+	assert.Equal(t, "booking", decoded["asset"].(map[string]interface{})["name"])
+	assert.Equal(t, float64(1), decoded["tasks"].(map[string]interface{})["tasks"])
+	assert.Equal(t, float64(8), decoded["activity"].(map[string]interface{})["totalHours"])
+
+	_, err := captureOutput(func() error {
+		os.Args = []string{"assetcap", "--output", "json", "assets", "show", "--name", "booking", "--live"}
+		return app.Run()
+	})
+	assert.ErrorContains(t, err, "--live has no JSON output")
+}
+
+func TestTasksShow_OutputJSON(t *testing.T) {
+	taskService := new(MockTaskService)
+	taskService.On("GetTasks", mock.Anything, "FN", "Sprint 1").Return([]*tasksdomain.Task{
+		{Key: "FN-1", Status: tasksdomain.TaskStatusDone, Epic: "FN-100"},
+		{Key: "FN-2", Status: tasksdomain.TaskStatusTodo},
+	}, nil)
+	taskService.On("TaskNotes", mock.Anything, []string{"FN-1"}).Return(map[string][]tasksdomain.TaskNote{
+		"FN-1": {{IssueKey: "FN-1", Text: "Split from FN-2", CreatedAt: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)}},
+	}, nil)
+	app := NewApp(new(MockAssetService), taskService, new(MockSprintService))
+
+	decoded := runJSON(t, app, "tasks show", "--output", "json", "tasks", "show", "--project", "FN", "--sprint", "Sprint 1", "--status", "done", "--format", "table")
+
+	assert.Equal(t, "FN", decoded["project"])
+	assert.Equal(t, float64(1), decoded["matched"])
+	require.Len(t, decoded["tasks"], 1)
+	assert.Contains(t, decoded["notes"], "FN-1", "JSON carries the notes whatever the text format")
+}
+
+func TestSprintAllocate_OutputJSON(t *testing.T) {
+	assetService := new(MockAssetService)
+	assetService.On("ListAssets").Return([]*assetsdomain.Asset{}, nil)
+	taskService := new(MockTaskService)
+	taskService.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+	sprintService := new(MockSprintService)
+	sprintService.On("AllocateIssues", sprintdomain.AllocationInput{Project: "FN", Sprint: "Sprint 1", Delimiter: ','}).Return([]sprintdomain.IssueAllocation{
+		{Sprint: "Sprint 1", IssueKey: "FN-1", AssetName: "booking", WorkType: "cap-development", Hours: 8, Percentage: 100},
+	}, nil)
+	app := NewApp(assetService, taskService, sprintService)
+
+	decoded := runJSON(t, app, "sprint allocate", "--output", "json", "sprint", "allocate", "--project", "FN", "--sprint", "Sprint 1")
+
+	assert.Equal(t, "Sprint 1", decoded["sprint"])
+	require.Len(t, decoded["allocations"], 1)
+	allocation := decoded["allocations"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "FN-1", allocation["issueKey"])
+	assert.Equal(t, float64(8), allocation["hours"])
+	assert.NotContains(t, allocation, "dateStarted", "zero dates are left out")
+	sprintService.AssertNotCalled(t, "ProcessJiraIssues", mock.Anything)
+}
+
+func TestOutputFormat(t *testing.T) {
+	taskService := new(MockTaskService)
+	taskService.On("GetTasks", mock.Anything, "FN", "Sprint 1").Return([]*tasksdomain.Task{}, nil)
+	app := NewApp(new(MockAssetService), taskService, new(MockSprintService))
+
+	output, err := captureOutput(func() error {
+		os.Args = []string{"assetcap", "tasks", "show", "--project", "FN", "--sprint", "Sprint 1"}
+		return app.Run()
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "No tasks found\n", output, "text by default")
+
+	_, err = captureOutput(func() error {
+		os.Args = []string{"assetcap", "--output", "yaml", "tasks", "show", "--project", "FN", "--sprint", "Sprint 1"}
+		return app.Run()
+	})
+	assert.ErrorContains(t, err, `unsupported output "yaml"`)
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/config"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/signing"
+)
+
+// verifyArtifactCommand checks an exported file against its checksum and signature
+func (a *App) verifyArtifactCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "verify-artifact",
+		Usage: "Check that an exported file matches its .sha256 checksum and its signature, if any",
+		Action: func(ctx *cli.Context) error {
+			opts := signingOptions(a.signing)
+			if publicKey := ctx.String("public-key"); publicKey != "" {
+				opts.PublicKey = publicKey
+			}
+			path := ctx.String("file")
+			result, err := signing.Verify(ctx.Context, path, opts)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s matches its SHA-256 checksum %s\n", path, result.Checksum)
+			if result.Signature != "" {
+				fmt.Printf("Signature %s is valid\n", result.Signature)
+			} else {
+				fmt.Println("The file is not signed")
+			}
+			return nil
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "file",
+				Aliases:  []string{"f"},
+				Usage:    "Exported file to verify; its checksum is read from the .sha256 file next to it",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "public-key",
+				Usage: "minisign public key file to verify a .minisig signature with (defaults to the configured one)",
+			},
+		},
+	}
+}
+
+// signArtifact writes the checksum and the signature of an exported file when signing is configured
+func (a *App) signArtifact(ctx context.Context, path string) error {
+	if !a.signing.Enabled() {
+		return nil
+	}
+	result, err := signing.Sign(ctx, path, signingOptions(a.signing))
+	if err != nil {
+		return err
+	}
+	if result.Signature != "" {
+		fmt.Fprintf(os.Stderr, "Signed %s with %s\n", path, result.Signature)
+	} else {
+		fmt.Fprintf(os.Stderr, "Wrote checksum %s%s\n", path, signing.ChecksumSuffix)
+	}
+	return nil
+}
+
+// signingOptions returns the signing settings of the configuration
+func signingOptions(cfg config.SigningConfig) signing.Options {
+	return signing.Options{
+		Method:    cfg.Method,
+		Key:       cfg.Key,
+		PublicKey: cfg.PublicKey,
+	}
+}
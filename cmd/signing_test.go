@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/config"
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	tasksdomain "github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+func TestSignedExport(t *testing.T) {
+	mas, mts, mss := new(MockAssetService), new(MockTaskService), new(MockSprintService)
+	mas.On("ListAssets").Return([]*assetsdomain.Asset{}, nil)
+	mts.On("ListTaskSplits", mock.Anything).Return([]tasksdomain.WorkTypeSplit{}, nil)
+	mss.On("ProcessJiraIssues", sprintdomain.AllocationInput{Project: "TEST", Sprint: "Sprint1", Delimiter: ','}).Return("a,b\n", nil)
+	app := NewApp(mas, mts, mss)
+	app.signing = config.SigningConfig{Checksums: true}
+	path := filepath.Join(t.TempDir(), "allocation.csv")
+
+	_, err := captureOutput(func() error {
+		os.Args = []string{"assetcap", "sprint", "allocate", "--project", "TEST", "--sprint", "Sprint1", "--out", path}
+		return app.Run()
+	})
+	require.NoError(t, err)
+	assert.FileExists(t, path+".sha256")
+
+	verify := func() (string, error) {
+		return captureOutput(func() error {
+			os.Args = []string{"assetcap", "verify-artifact", "--file", path}
+			return app.Run()
+		})
+	}
+
+	output, err := verify()
+	require.NoError(t, err)
+	assert.Contains(t, output, path+" matches its SHA-256 checksum 5be08c9684a1d25efcee09318204824278b08bbfb4aef973ffefd0b9d7478313")
+	assert.Contains(t, output, "The file is not signed")
+
+	require.NoError(t, os.WriteFile(path, []byte("a,c\n"), 0644))
+	_, err = verify()
+	assert.ErrorContains(t, err, "checksum mismatch")
+}
+
+func TestSignArtifact_Disabled(t *testing.T) {
+	app := NewApp(new(MockAssetService), new(MockTaskService), new(MockSprintService))
+	path := filepath.Join(t.TempDir(), "allocation.csv")
+	require.NoError(t, os.WriteFile(path, []byte("a,b\n"), 0644))
+
+	require.NoError(t, app.signArtifact(context.Background(), path))
+	assert.NoFileExists(t, path+".sha256")
+}
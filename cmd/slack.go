@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/slack"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// slackQueries returns the slash command queries answered from the local asset and task stores
+func (a *App) slackQueries() map[string]slack.Query {
+	return map[string]slack.Query{
+		"asset": {
+			Usage: "asset <name>",
+			Run:   a.slackAssetSummary,
+		},
+		"sprint": {
+			Usage: `sprint <project> "<sprint>"`,
+			Run:   a.slackSprintSummary,
+		},
+	}
+}
+
+// slackAssetSummary summarizes a stored asset
+func (a *App) slackAssetSummary(_ context.Context, args []string) (string, error) {
+	name := strings.Join(args, " ")
+	if name == "" {
+		return "", errors.New("usage: asset <name>")
+	}
+
+	asset, err := a.assetService.GetAsset(name)
+	if err != nil {
+		return "", fmt.Errorf("asset %s not found", name)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%s*", asset.Name)
+	if asset.Description != "" {
+		fmt.Fprintf(&b, "\n%s", asset.Description)
+	}
+	fmt.Fprintf(&b, "\n• Tasks: %d", asset.AssociatedTaskCount)
+	if !asset.LastDocUpdateAt.IsZero() {
+		fmt.Fprintf(&b, "\n• Docs updated: %s", asset.LastDocUpdateAt.Format("2006-01-02"))
+	}
+	if asset.DocLink != "" {
+		fmt.Fprintf(&b, "\n• Docs: %s", asset.DocLink)
+	}
+	if len(asset.Keywords) > 0 {
+		fmt.Fprintf(&b, "\n• Keywords: %s", strings.Join(asset.Keywords, ", "))
+	}
+	if len(asset.Impairments) > 0 {
+		fmt.Fprintf(&b, "\n• Impairments: %d (total %.2f)", len(asset.Impairments), asset.TotalImpairment())
+	}
+	return b.String(), nil
+}
+
+// slackSprintSummary summarizes the stored tasks of a sprint by work type and status
+func (a *App) slackSprintSummary(ctx context.Context, args []string) (string, error) {
+	if len(args) < 2 {
+		return "", errors.New(`usage: sprint <project> "<sprint>"`)
+	}
+	project, sprint := args[0], strings.Join(args[1:], " ")
+
+	tasks, err := a.taskService.GetTasks(ctx, project, sprint)
+	if err != nil {
+		return "", fmt.Errorf("failed to get tasks for %s %s: %w", project, sprint, err)
+	}
+	if len(tasks) == 0 {
+		return "", fmt.Errorf("no tasks stored for %s %s; run 'assetcap tasks fetch' first", project, sprint)
+	}
+
+	byWorkType := make(map[domain.WorkType]int)
+	byStatus := make(map[domain.TaskStatus]int)
+	for _, task := range tasks {
+		byWorkType[task.WorkType]++
+		byStatus[task.Status]++
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%s — %s*: %d tasks", project, sprint, len(tasks))
+	for _, workType := range []domain.WorkType{domain.WorkTypeDevelopment, domain.WorkTypeMaintenance, domain.WorkTypeDiscovery, ""} {
+		count := byWorkType[workType]
+		if count == 0 {
+			continue
+		}
+		label := string(workType)
+		if workType == "" {
+			label = "unclassified"
+		}
+		fmt.Fprintf(&b, "\n• %s: %d (%.0f%%)", label, count, float64(count)/float64(len(tasks))*100)
+	}
+
+	statuses := make([]string, 0, len(byStatus))
+	for _, status := range []domain.TaskStatus{domain.TaskStatusDone, domain.TaskStatusInProgress, domain.TaskStatusBlocked, domain.TaskStatusTodo} {
+		if count := byStatus[status]; count > 0 {
+			statuses = append(statuses, fmt.Sprintf("%s %d", status, count))
+		}
+	}
+	if len(statuses) > 0 {
+		fmt.Fprintf(&b, "\nStatus: %s", strings.Join(statuses, ", "))
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	tasksdomain "github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+func TestSlackAssetSummary(t *testing.T) {
+	assets := new(MockAssetService)
+	app := NewApp(assets, new(MockTaskService), new(MockSprintService))
+
+	asset := &assetsdomain.Asset{
+		Name:                "Booking Engine",
+		Description:         "Handles reservations",
+		AssociatedTaskCount: 7,
+		LastDocUpdateAt:     time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Keywords:            []string{"booking", "payments"},
+	}
+	assets.On("GetAsset", "Booking Engine").Return(asset, nil)
+	assets.On("GetAsset", "missing").Return(nil, errors.New("not found"))
+
+	text, err := app.slackAssetSummary(context.Background(), []string{"Booking", "Engine"})
+	require.NoError(t, err)
+	assert.Equal(t, "*Booking Engine*\nHandles reservations\n• Tasks: 7\n• Docs updated: 2024-03-01\n• Keywords: booking, payments", text)
+
+	_, err = app.slackAssetSummary(context.Background(), []string{"missing"})
+	assert.EqualError(t, err, "asset missing not found")
+
+	_, err = app.slackAssetSummary(context.Background(), nil)
+	assert.Error(t, err)
+}
+
+func TestSlackSprintSummary(t *testing.T) {
+	tasks := new(MockTaskService)
+	app := NewApp(new(MockAssetService), tasks, new(MockSprintService))
+
+	tasks.On("GetTasks", mock.Anything, "FN", "Sprint 42").Return([]*tasksdomain.Task{
+		{Key: "FN-1", WorkType: tasksdomain.WorkTypeDevelopment, Status: tasksdomain.TaskStatusDone},
+		{Key: "FN-2", WorkType: tasksdomain.WorkTypeDevelopment, Status: tasksdomain.TaskStatusInProgress},
+		{Key: "FN-3", WorkType: tasksdomain.WorkTypeMaintenance, Status: tasksdomain.TaskStatusDone},
+		{Key: "FN-4", Status: tasksdomain.TaskStatusTodo},
+	}, nil)
+	tasks.On("GetTasks", mock.Anything, "FN", "Sprint 1").Return([]*tasksdomain.Task{}, nil)
+
+	text, err := app.slackSprintSummary(context.Background(), []string{"FN", "Sprint 42"})
+	require.NoError(t, err)
+	assert.Equal(t, "*FN — Sprint 42*: 4 tasks\n• cap-development: 2 (50%)\n• cap-maintenance: 1 (25%)\n• unclassified: 1 (25%)\nStatus: DONE 2, IN_PROGRESS 1, TODO 1", text)
+
+	// Unquoted sprint names are joined back together
+	_, err = app.slackSprintSummary(context.Background(), []string{"FN", "Sprint", "1"})
+	assert.ErrorContains(t, err, "no tasks stored for FN Sprint 1")
+
+	_, err = app.slackSprintSummary(context.Background(), []string{"FN"})
+	assert.Error(t, err)
+}
+
+func TestSlackQueries(t *testing.T) {
+	app := NewApp(new(MockAssetService), new(MockTaskService), new(MockSprintService))
+
+	queries := app.slackQueries()
+	assert.Contains(t, queries, "asset")
+	assert.Contains(t, queries, "sprint")
+}
+
+func TestServeSlack_RequiresSigningSecret(t *testing.T) {
+	t.Setenv("SLACK_SIGNING_SECRET", "")
+	app := NewApp(new(MockAssetService), new(MockTaskService), new(MockSprintService))
+
+	_, err := captureOutput(func() error {
+		os.Args = []string{"assetcap", "serve", "slack", "--addr", "invalid-address"}
+		return app.Run()
+	})
+	assert.EqualError(t, err, "SLACK_SIGNING_SECRET is not set: set it to verify request signatures, or pass --insecure-no-verify to serve unverified requests")
+
+	_, err = captureOutput(func() error {
+		os.Args = []string{"assetcap", "serve", "slack", "--addr", "invalid-address", "--insecure-no-verify"}
+		return app.Run()
+	})
+	assert.ErrorContains(t, err, "Slack slash commands server stopped", "the opt-out serves without a secret")
+}
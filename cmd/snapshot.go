@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sink"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/snapshot"
+)
+
+// withSnapshot runs a report action against a snapshot of the storage directory, so a fetch or
+// classification running meanwhile cannot mix old and new data into the report
+func (a *App) withSnapshot(action cli.ActionFunc) cli.ActionFunc {
+	return func(ctx *cli.Context) error {
+		if a.rewire == nil || a.snapshot != nil {
+			return action(ctx)
+		}
+
+		snap, err := snapshot.Take(a.storageDir)
+		if err != nil {
+			return fmt.Errorf("failed to take a snapshot of the storage: %w", err)
+		}
+		defer func() {
+			if err := snap.Close(); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+		}()
+		view, err := a.rewire(snap.Dir)
+		if err != nil {
+			return err
+		}
+
+		assetService, taskService, sprintService, storageDir := a.assetService, a.taskService, a.sprintService, a.storageDir
+		a.assetService, a.taskService, a.sprintService, a.storageDir = view.assetService, view.taskService, view.sprintService, snap.Dir
+		a.snapshot = snap
+		defer func() {
+			a.assetService, a.taskService, a.sprintService, a.storageDir = assetService, taskService, sprintService, storageDir
+			a.snapshot = nil
+		}()
+		return action(ctx)
+	}
+}
+
+// dataTime returns when the data of the running report was read: the time of its snapshot, or
+// now when it reads the live storage
+func (a *App) dataTime() time.Time {
+	if a.snapshot != nil {
+		return a.snapshot.TakenAt
+	}
+	return time.Now()
+}
+
+// snapshotHeader returns the header line stating when the snapshot a report was generated from
+// was taken, written as a comment of the report's format. Formats without comments, such as
+// CSV and JSON, get no header: a line ahead of the data would break their readers.
+func snapshotHeader(contentType string, takenAt time.Time) string {
+	switch contentType {
+	case sink.ContentTypeHTML:
+		return fmt.Sprintf("<!-- Data snapshot: %s -->\n", snapshotStamp(takenAt))
+	case sink.ContentTypeMarkdown:
+		return fmt.Sprintf("_Data snapshot: %s_\n\n", snapshotStamp(takenAt))
+	default:
+		return ""
+	}
+}
+
+// snapshotStamp formats the time a snapshot was taken
+func snapshotStamp(takenAt time.Time) string {
+	return takenAt.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sink"
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+func TestWithSnapshot_ReportReadsCopy(t *testing.T) {
+	storageDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(storageDir, "tasks.json"), []byte(`{}`), 0644))
+
+	live := new(MockSprintService)
+	snapshotted := new(MockSprintService)
+	snapshotted.On("GenerateTimesheet", sprintdomain.TimesheetInput{Project: "TEST", Sprint: "Sprint1", Delimiter: ','}).Return("person,hours\n", nil)
+	app := NewApp(new(MockAssetService), new(MockTaskService), live)
+	app.storageDir = storageDir
+	var snapshotDir string
+	app.rewire = func(dir string) (*App, error) {
+		snapshotDir = dir
+		assert.FileExists(t, filepath.Join(dir, "tasks.json"))
+		return NewApp(new(MockAssetService), new(MockTaskService), snapshotted), nil
+	}
+	path := filepath.Join(t.TempDir(), "timesheet.csv")
+
+	_, err := captureOutput(func() error {
+		os.Args = []string{"assetcap", "report", "timesheet", "--project", "TEST", "--sprint", "Sprint1", "--out", path}
+		return app.Run()
+	})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "person,hours\n", string(data), "CSV output carries no snapshot line")
+
+	snapshotted.AssertExpectations(t)
+	live.AssertNotCalled(t, "GenerateTimesheet")
+	assert.Same(t, live, app.sprintService, "the live services are restored after the report")
+	assert.Equal(t, storageDir, app.storageDir)
+	assert.Nil(t, app.snapshot)
+	assert.NoDirExists(t, snapshotDir)
+}
+
+func TestSnapshotHeader(t *testing.T) {
+	at := time.Date(2024, 6, 30, 18, 0, 0, 0, time.UTC)
+	assert.Empty(t, snapshotHeader(sink.ContentTypeCSV, at))
+	assert.Empty(t, snapshotHeader(sink.ContentTypeText, at))
+	assert.Empty(t, snapshotHeader(sink.ContentTypeJSON, at))
+	assert.Equal(t, "_Data snapshot: 2024-06-30T18:00:00Z_\n\n", snapshotHeader(sink.ContentTypeMarkdown, at))
+	assert.Equal(t, "<!-- Data snapshot: 2024-06-30T18:00:00Z -->\n", snapshotHeader(sink.ContentTypeHTML, at))
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+// printSprintScope prints the scope totals of a sprint followed by each change in order
+func printSprintScope(scope *sprintdomain.SprintScope) {
+	fmt.Printf("Sprint %s", scope.Sprint)
+	if !scope.StartDate.IsZero() {
+		fmt.Printf(" (started %s)", scope.StartDate.Format("2006-01-02"))
+	}
+	fmt.Println()
+	fmt.Printf("  Committed at start: %d\n", scope.Committed)
+	fmt.Printf("  Added: %d\n", scope.Added)
+	fmt.Printf("  Removed: %d\n", scope.Removed)
+	fmt.Printf("  In the sprint now: %d\n", scope.Current)
+	fmt.Printf("  Churn: %.1f%% of the committed issues\n", scope.ChurnPercent)
+
+	if len(scope.Changes) == 0 {
+		fmt.Println("No issues were added or removed after the sprint started")
+		return
+	}
+	fmt.Println("Changes:")
+	for _, change := range scope.Changes {
+		fmt.Printf("  %s %-7s %s %s\n", change.At.Format("2006-01-02 15:04"), change.Change, change.IssueKey, change.Summary)
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+func TestPrintSprintScope(t *testing.T) {
+	output, err := captureOutput(func() error {
+		printSprintScope(&sprintdomain.SprintScope{
+			Sprint:       "Sprint 1",
+			StartDate:    time.Date(2024, 3, 18, 9, 0, 0, 0, time.UTC),
+			Committed:    8,
+			Added:        2,
+			Removed:      1,
+			Current:      9,
+			ChurnPercent: 37.5,
+			Changes: []sprintdomain.ScopeChange{
+				{IssueKey: "FN-3", Summary: "Nice to have", Change: sprintdomain.ScopeRemoved, At: time.Date(2024, 3, 19, 10, 0, 0, 0, time.UTC)},
+				{IssueKey: "FN-9", Summary: "Urgent fix", Change: sprintdomain.ScopeAdded, At: time.Date(2024, 3, 20, 14, 30, 0, 0, time.UTC)},
+			},
+		})
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Contains(t, output, "Sprint Sprint 1 (started 2024-03-18)\n")
+	assert.Contains(t, output, "  Committed at start: 8\n")
+	assert.Contains(t, output, "  Churn: 37.5% of the committed issues\n")
+	assert.Contains(t, output, "  2024-03-19 10:00 removed FN-3 Nice to have\n")
+	assert.Contains(t, output, "  2024-03-20 14:30 added   FN-9 Urgent fix\n")
+}
+
+func TestPrintSprintScope_NoChanges(t *testing.T) {
+	output, err := captureOutput(func() error {
+		printSprintScope(&sprintdomain.SprintScope{Sprint: "Sprint 1", Committed: 3, Current: 3})
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Contains(t, output, "No issues were added or removed after the sprint started")
+}
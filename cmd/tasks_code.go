@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// codeLinkFlags returns the flags selecting the sprint of tasks code link and show
+func codeLinkFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     "project",
+			Usage:    "Project key (e.g., FN)",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:     "sprint",
+			Usage:    "Sprint name (e.g., Penguins)",
+			Required: true,
+		},
+	}
+}
+
+// printCodeLinks prints the commit and pull request counts of a sprint and the development
+// tasks without any code change
+func printCodeLinks(result domain.CodeLinkResult) {
+	fmt.Printf("Project %s, sprint %s: %d of %d task(s) linked to %d commit(s) and %d pull request(s)\n",
+		result.Project, result.Sprint, result.Linked, result.Tasks, result.Commits, result.PullRequests)
+	if len(result.WithoutCode) > 0 {
+		fmt.Printf("Development tasks without code changes: %s\n", strings.Join(result.WithoutCode, ", "))
+	}
+}
+
+// printTaskCodeReferences lists the commits and pull requests referencing each task
+func printTaskCodeReferences(tasks []*domain.Task) {
+	for _, task := range tasks {
+		if len(task.CodeReferences) == 0 {
+			continue
+		}
+		fmt.Printf("%s: %s\n", task.Key, task.Summary)
+		for _, reference := range task.CodeReferences {
+			id := reference.ID
+			if reference.Kind == domain.CodeReferenceCommit && len(id) > 7 {
+				id = id[:7]
+			} else if reference.Kind == domain.CodeReferencePullRequest {
+				id = "#" + id
+			}
+			fmt.Printf("  %s %s %s %s (%s)\n", reference.Date.Format("2006-01-02"), reference.Repository, id, reference.Title, reference.Kind)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+func TestPrintCodeLinks(t *testing.T) {
+	output, err := captureOutput(func() error {
+		printCodeLinks(domain.CodeLinkResult{Project: "FN", Sprint: "Penguins", Tasks: 3, Linked: 1, Commits: 2, PullRequests: 1, WithoutCode: []string{"FN-2", "FN-3"}})
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Project FN, sprint Penguins: 1 of 3 task(s) linked to 2 commit(s) and 1 pull request(s)\n"+
+		"Development tasks without code changes: FN-2, FN-3\n", output)
+}
+
+func TestPrintTaskCodeReferences(t *testing.T) {
+	date := time.Date(2024, 5, 3, 10, 0, 0, 0, time.UTC)
+	output, err := captureOutput(func() error {
+		printTaskCodeReferences([]*domain.Task{
+			{Key: "FN-1", Summary: "Ledger export", CodeReferences: []domain.CodeReference{
+				{Kind: domain.CodeReferenceCommit, Repository: "acme/api", ID: "a1b2c3d4e5", Title: "FN-1: add ledger export", Date: date},
+				{Kind: domain.CodeReferencePullRequest, Repository: "acme/api", ID: "12", Title: "Ledger export", Date: date},
+			}},
+			{Key: "FN-2", Summary: "No code"},
+		})
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "FN-1: Ledger export\n"+
+		"  2024-05-03 acme/api a1b2c3d FN-1: add ledger export (commit)\n"+
+		"  2024-05-03 acme/api #12 Ledger export (pull_request)\n", output)
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/config"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// coverageBadgeLabel is the left-hand text of the coverage badge
+const coverageBadgeLabel = "label coverage"
+
+// Badge colors, as named by shields.io
+var badgeColors = map[string]string{
+	"brightgreen": "#4c1",
+	"red":         "#e05d44",
+	"lightgrey":   "#9f9f9f",
+}
+
+// coverageInput builds the coverage input of a project and quarter from the configured
+// thresholds, overridden by the command's threshold flags when set
+func coverageInput(cfg config.CoverageConfig, project, quarter string, workType, asset *float64) domain.LabelCoverageInput {
+	thresholds := cfg.For(project)
+	input := domain.LabelCoverageInput{
+		Project:           project,
+		Quarter:           quarter,
+		WorkTypeThreshold: thresholds.WorkTypePercent,
+		AssetThreshold:    thresholds.AssetPercent,
+	}
+	if workType != nil {
+		input.WorkTypeThreshold = *workType
+	}
+	if asset != nil {
+		input.AssetThreshold = *asset
+	}
+	return input
+}
+
+// printLabelCoverage prints the coverage of each label against its threshold, then the done
+// tasks missing a label
+func printLabelCoverage(coverage *domain.LabelCoverage) {
+	if coverage.Done == 0 {
+		fmt.Printf("No done tasks of %s were created in %s\n", coverage.Project, coverage.Quarter)
+		return
+	}
+	fmt.Printf("%s %s: %d done task(s)\n", coverage.Project, coverage.Quarter, coverage.Done)
+	fmt.Printf("  Work type label: %d of %d (%.1f%%), %s\n", coverage.WithWorkType, coverage.Done, coverage.WorkTypePercent,
+		thresholdStatus(coverage.WorkTypeThreshold, coverage.WorkTypePassed()))
+	fmt.Printf("  Asset link:      %d of %d (%.1f%%), %s\n", coverage.WithAsset, coverage.Done, coverage.AssetPercent,
+		thresholdStatus(coverage.AssetThreshold, coverage.AssetPassed()))
+	if len(coverage.MissingWorkType) > 0 {
+		fmt.Printf("Missing a work type label: %s\n", strings.Join(coverage.MissingWorkType, ", "))
+	}
+	if len(coverage.MissingAsset) > 0 {
+		fmt.Printf("Missing an asset link: %s\n", strings.Join(coverage.MissingAsset, ", "))
+	}
+}
+
+// thresholdStatus describes whether a coverage met its threshold
+func thresholdStatus(threshold float64, passed bool) string {
+	switch {
+	case threshold == 0:
+		return "no threshold"
+	case passed:
+		return fmt.Sprintf("threshold %.1f%% met", threshold)
+	default:
+		return fmt.Sprintf("threshold %.1f%% missed", threshold)
+	}
+}
+
+// coverageBadge returns the message and color of the coverage badge
+func coverageBadge(coverage *domain.LabelCoverage) (message, color string) {
+	if coverage.Done == 0 {
+		return "no done tasks", "lightgrey"
+	}
+	message = fmt.Sprintf("work type %.0f%% | asset %.0f%%", coverage.WorkTypePercent, coverage.AssetPercent)
+	if !coverage.Passed {
+		return message, "red"
+	}
+	return message, "brightgreen"
+}
+
+// writeCoverageBadge writes the coverage badge to path, as an SVG image for a .svg file or as
+// a shields.io endpoint document for a .json file
+func writeCoverageBadge(path string, coverage *domain.LabelCoverage) error {
+	message, color := coverageBadge(coverage)
+
+	var data []byte
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".svg":
+		data = []byte(badgeSVG(coverageBadgeLabel, message, badgeColors[color]))
+	case ".json":
+		encoded, err := json.MarshalIndent(struct {
+			SchemaVersion int    `json:"schemaVersion"`
+			Label         string `json:"label"`
+			Message       string `json:"message"`
+			Color         string `json:"color"`
+		}{1, coverageBadgeLabel, message, color}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode badge: %w", err)
+		}
+		data = append(encoded, '\n')
+	default:
+		return fmt.Errorf("unsupported badge file %s: use a .svg or .json file", path)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write badge: %w", err)
+	}
+	return nil
+}
+
+// badgeSVG renders a flat two-part badge. Text widths are estimated from the character count,
+// which is close enough for the short ASCII texts of a badge.
+func badgeSVG(label, message, color string) string {
+	labelWidth := textWidth(label)
+	messageWidth := textWidth(message)
+	width := labelWidth + messageWidth
+	title := html.EscapeString(label + ": " + message)
+
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s">
+  <title>%s</title>
+  <rect width="%d" height="20" fill="#555"/>
+  <rect x="%d" width="%d" height="20" fill="%s"/>
+  <g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">
+    <text x="%d" y="14">%s</text>
+    <text x="%d" y="14">%s</text>
+  </g>
+</svg>
+`, width, title, title, labelWidth, labelWidth, messageWidth, color,
+		labelWidth/2, html.EscapeString(label), labelWidth+messageWidth/2, html.EscapeString(message))
+}
+
+// textWidth estimates the width in pixels of a badge part holding text, padding included
+func textWidth(text string) int {
+	return len(text)*7 + 10
+}
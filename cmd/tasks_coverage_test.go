@@ -0,0 +1,80 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/config"
+	tasksdomain "github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+func TestCoverageInput(t *testing.T) {
+	cfg := config.CoverageConfig{
+		CoverageThresholds: config.CoverageThresholds{WorkTypePercent: 90, AssetPercent: 80},
+		Projects:           map[string]config.CoverageThresholds{"OPS": {WorkTypePercent: 95}},
+	}
+
+	assert.Equal(t, tasksdomain.LabelCoverageInput{Project: "FN", Quarter: "2024-Q2", WorkTypeThreshold: 90, AssetThreshold: 80},
+		coverageInput(cfg, "FN", "2024-Q2", nil, nil))
+	assert.Equal(t, tasksdomain.LabelCoverageInput{Project: "OPS", Quarter: "2024-Q2", WorkTypeThreshold: 95},
+		coverageInput(cfg, "OPS", "2024-Q2", nil, nil))
+
+	zero, asset := 0.0, 60.0
+	assert.Equal(t, tasksdomain.LabelCoverageInput{Project: "FN", Quarter: "2024-Q2", AssetThreshold: 60},
+		coverageInput(cfg, "FN", "2024-Q2", &zero, &asset), "flags override the configured thresholds")
+}
+
+func TestPrintLabelCoverage(t *testing.T) {
+	output, err := captureOutput(func() error {
+		printLabelCoverage(&tasksdomain.LabelCoverage{
+			Project: "FN", Quarter: "2024-Q2", Done: 4, WithWorkType: 4, WithAsset: 3,
+			WorkTypePercent: 100, AssetPercent: 75, AssetThreshold: 80,
+			MissingAsset: []string{"FN-2"},
+		})
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Contains(t, output, "FN 2024-Q2: 4 done task(s)\n")
+	assert.Contains(t, output, "  Work type label: 4 of 4 (100.0%), no threshold\n")
+	assert.Contains(t, output, "  Asset link:      3 of 4 (75.0%), threshold 80.0% missed\n")
+	assert.Contains(t, output, "Missing an asset link: FN-2\n")
+	assert.NotContains(t, output, "Missing a work type label")
+
+	output, err = captureOutput(func() error {
+		printLabelCoverage(&tasksdomain.LabelCoverage{Project: "FN", Quarter: "2024-Q2", Passed: true})
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "No done tasks of FN were created in 2024-Q2\n", output)
+}
+
+func TestWriteCoverageBadge(t *testing.T) {
+	dir := t.TempDir()
+	passing := &tasksdomain.LabelCoverage{Done: 10, WorkTypePercent: 95, AssetPercent: 82.4, Passed: true}
+
+	svgPath := filepath.Join(dir, "coverage.svg")
+	require.NoError(t, writeCoverageBadge(svgPath, passing))
+	svg, err := os.ReadFile(svgPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(svg), `<svg xmlns="http://www.w3.org/2000/svg"`)
+	assert.Contains(t, string(svg), "<title>label coverage: work type 95% | asset 82%</title>")
+	assert.Contains(t, string(svg), `fill="#4c1"`)
+
+	jsonPath := filepath.Join(dir, "coverage.json")
+	require.NoError(t, writeCoverageBadge(jsonPath, &tasksdomain.LabelCoverage{Done: 10, WorkTypePercent: 50, AssetPercent: 90}))
+	badge, err := os.ReadFile(jsonPath)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"schemaVersion": 1, "label": "label coverage", "message": "work type 50% | asset 90%", "color": "red"}`, string(badge))
+
+	require.NoError(t, writeCoverageBadge(jsonPath, &tasksdomain.LabelCoverage{Passed: true}))
+	badge, err = os.ReadFile(jsonPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(badge), `"color": "lightgrey"`)
+
+	err = writeCoverageBadge(filepath.Join(dir, "coverage.png"), passing)
+	assert.EqualError(t, err, "unsupported badge file "+filepath.Join(dir, "coverage.png")+": use a .svg or .json file")
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// printLabelHistory prints the current cap-* labels of an issue followed by each recorded
+// change, oldest first
+func printLabelHistory(history *domain.LabelHistory) {
+	fmt.Printf("%s %s\n", history.IssueKey, history.Summary)
+	labels := "none"
+	if len(history.Labels) > 0 {
+		labels = strings.Join(history.Labels, ", ")
+	}
+	fmt.Printf("Current cap-* labels: %s\n", labels)
+
+	if len(history.Changes) == 0 {
+		fmt.Println("No cap-* label changes recorded. Tasks fetched before label history was recorded gain it when their sprint is fetched again.")
+		return
+	}
+	fmt.Println("Changes:")
+	for _, change := range history.Changes {
+		author := change.Author
+		if author == "" {
+			author = "unknown"
+		}
+		fmt.Printf("  %s %-7s %s by %s\n", change.At.Format("2006-01-02 15:04"), change.Action, change.Label, author)
+	}
+}
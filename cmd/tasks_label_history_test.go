@@ -0,0 +1,41 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tasksdomain "github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+func TestPrintLabelHistory(t *testing.T) {
+	output, err := captureOutput(func() error {
+		printLabelHistory(&tasksdomain.LabelHistory{
+			IssueKey: "FN-123",
+			Summary:  "Fix login",
+			Labels:   []string{"cap-maintenance"},
+			Changes: []tasksdomain.LabelChange{
+				{Label: "cap-development", Action: tasksdomain.LabelAdded, Author: "Jane Doe", At: time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)},
+				{Label: "cap-development", Action: tasksdomain.LabelRemoved, At: time.Date(2024, 3, 8, 14, 30, 0, 0, time.UTC)},
+			},
+		})
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Contains(t, output, "FN-123 Fix login\n")
+	assert.Contains(t, output, "Current cap-* labels: cap-maintenance\n")
+	assert.Contains(t, output, "  2024-03-01 09:00 added   cap-development by Jane Doe\n")
+	assert.Contains(t, output, "  2024-03-08 14:30 removed cap-development by unknown\n")
+}
+
+func TestPrintLabelHistory_NoChanges(t *testing.T) {
+	output, err := captureOutput(func() error {
+		printLabelHistory(&tasksdomain.LabelHistory{IssueKey: "FN-123", Labels: []string{}, Changes: []tasksdomain.LabelChange{}})
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Contains(t, output, "Current cap-* labels: none\n")
+	assert.Contains(t, output, "No cap-* label changes recorded.")
+}
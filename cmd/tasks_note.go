@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// taskNoteCommand returns the command recording the notes analysts leave on tasks
+func (a *App) taskNoteCommand() *cli.Command {
+	taskFlag := &cli.StringFlag{
+		Name:     "task",
+		Aliases:  []string{"t"},
+		Usage:    "Issue key of the task, e.g. FN-123",
+		Required: true,
+	}
+	return &cli.Command{
+		Name:  "note",
+		Usage: "Leave timestamped notes on tasks, shown in tasks show and sample exports",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "add",
+				Usage: "Add a note to a task, e.g. \"confirmed with tech lead: maintenance\"",
+				Action: func(ctx *cli.Context) error {
+					note, err := a.taskService.AddTaskNote(ctx.Context, ctx.String("task"), ctx.String("text"))
+					if err != nil {
+						return err
+					}
+					fmt.Printf("Added a note to %s\n", note.IssueKey)
+					return nil
+				},
+				Flags: []cli.Flag{
+					taskFlag,
+					&cli.StringFlag{
+						Name:     "text",
+						Usage:    "Text of the note",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:  "list",
+				Usage: "List the notes of a task, oldest first",
+				Action: func(ctx *cli.Context) error {
+					issueKey := strings.ToUpper(ctx.String("task"))
+					notes, err := a.taskService.TaskNotes(ctx.Context, issueKey)
+					if err != nil {
+						return err
+					}
+					if len(notes[issueKey]) == 0 {
+						fmt.Printf("No notes on %s\n", issueKey)
+						return nil
+					}
+					fmt.Printf("Notes on %s:\n", issueKey)
+					printTaskNotes(os.Stdout, notes[issueKey])
+					return nil
+				},
+				Flags: []cli.Flag{taskFlag},
+			},
+		},
+	}
+}
+
+// printTaskNotes writes one indented line per note
+func printTaskNotes(w io.Writer, notes []domain.TaskNote) {
+	for _, note := range notes {
+		fmt.Fprintf(w, "  %s\n", note)
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// summaryWidth is the number of characters of a summary shown in the table format
+const summaryWidth = 60
+
+// taskQuery holds the filter, sort, limit and format chosen for tasks show
+type taskQuery struct {
+	filter domain.TaskFilter
+	order  domain.TaskSort
+	limit  int
+	format string
+}
+
+// parseTaskQuery validates the filter, sort, limit and format flags of tasks show
+func parseTaskQuery(ctx *cli.Context) (taskQuery, error) {
+	filter, err := domain.NewTaskFilter(ctx.StringSlice("status"), ctx.StringSlice("type"), ctx.StringSlice("worktype"), ctx.Bool("unclassified"))
+	if err != nil {
+		return taskQuery{}, err
+	}
+	order, err := domain.ParseTaskSort(ctx.String("sort"))
+	if err != nil {
+		return taskQuery{}, err
+	}
+	if ctx.Int("limit") < 0 {
+		return taskQuery{}, fmt.Errorf("--limit must not be negative")
+	}
+	format := ctx.String("format")
+	if format != "text" && format != "table" {
+		return taskQuery{}, fmt.Errorf("unsupported format %q: must be text or table", format)
+	}
+	return taskQuery{filter: filter, order: order, limit: ctx.Int("limit"), format: format}, nil
+}
+
+// apply returns the matching tasks in the requested order, up to the limit, and how many matched
+func (q taskQuery) apply(tasks []*domain.Task) ([]*domain.Task, int) {
+	matched := q.filter.Apply(tasks)
+	domain.SortTasks(matched, q.order)
+	if q.limit > 0 && len(matched) > q.limit {
+		return matched[:q.limit], len(matched)
+	}
+	return matched, len(matched)
+}
+
+// showTasks prints the tasks selected by the query with their notes under a heading, noting
+// how many were left out by the limit
+func (a *App) showTasks(ctx *cli.Context, listing taskListing, heading string, tasks []*domain.Task, query taskQuery) error {
+	asJSON, err := jsonOutput(ctx)
+	if err != nil {
+		return err
+	}
+	shown, matched := query.apply(tasks)
+	listing.Tasks = append([]*domain.Task{}, shown...)
+	listing.Matched = matched
+	if len(shown) > 0 && (asJSON || query.format != "table") {
+		keys := make([]string, 0, len(shown))
+		for _, task := range shown {
+			keys = append(keys, task.Key)
+		}
+		if listing.Notes, err = a.taskService.TaskNotes(ctx.Context, keys...); err != nil {
+			return err
+		}
+	}
+	return render(ctx, listing, func() error {
+		if heading != "" {
+			fmt.Println(heading)
+			fmt.Println("----------------------------------------")
+		}
+		if len(shown) == 0 {
+			fmt.Println("No tasks found")
+			return nil
+		}
+		if err := printTasks(os.Stdout, shown, query.format, listing.Notes); err != nil {
+			return err
+		}
+		if len(shown) < matched {
+			fmt.Printf("Showing %d of %d tasks\n", len(shown), matched)
+		}
+		return nil
+	})
+}
+
+// printTasks writes the tasks one block per task with their notes, or one row per task in
+// the table format
+func printTasks(w io.Writer, tasks []*domain.Task, format string, notes map[string][]domain.TaskNote) error {
+	if format != "table" {
+		for _, task := range tasks {
+			fmt.Fprintf(w, "Key: %s\nType: %s\nSummary: %s\nStatus: %s\nEpic: %s\nWork Type: %s\nLabels: %v\n",
+				task.Key, task.Type, task.Summary, task.Status, task.Epic, task.WorkType, task.Labels)
+			if taskNotes := notes[task.Key]; len(taskNotes) > 0 {
+				fmt.Fprintln(w, "Notes:")
+				printTaskNotes(w, taskNotes)
+			}
+			fmt.Fprintln(w)
+		}
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KEY\tTYPE\tSTATUS\tWORK TYPE\tSUMMARY")
+	for _, task := range tasks {
+		workType := string(task.WorkType)
+		if workType == "" {
+			workType = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", task.Key, task.Type, task.Status, workType, truncate(task.Summary, summaryWidth))
+	}
+	return tw.Flush()
+}
+
+// truncate shortens s to at most width characters, ending it with an ellipsis when cut
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-1]) + "…"
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+func TestTaskQuery_Apply(t *testing.T) {
+	tasks := []*domain.Task{
+		{Key: "FN-10", Status: domain.TaskStatusDone},
+		{Key: "FN-9", Status: domain.TaskStatusTodo},
+		{Key: "FN-2", Status: domain.TaskStatusDone, WorkType: domain.WorkTypeMaintenance},
+	}
+	query := taskQuery{filter: domain.TaskFilter{Statuses: []domain.TaskStatus{domain.TaskStatusDone}}, order: domain.TaskSortKey, limit: 1}
+
+	shown, matched := query.apply(tasks)
+
+	assert.Equal(t, 2, matched)
+	require.Len(t, shown, 1)
+	assert.Equal(t, "FN-2", shown[0].Key)
+}
+
+func TestPrintTasks_Table(t *testing.T) {
+	var buf bytes.Buffer
+	err := printTasks(&buf, []*domain.Task{
+		{Key: "FN-1", Type: domain.TaskTypeStory, Status: domain.TaskStatusDone, WorkType: domain.WorkTypeDevelopment, Summary: "Checkout"},
+		{Key: "FN-12", Type: domain.TaskTypeBug, Status: domain.TaskStatusTodo, Summary: "A summary well over the sixty characters the table has room for"},
+	}, "table", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, ""+
+		"KEY    TYPE   STATUS  WORK TYPE        SUMMARY\n"+
+		"FN-1   STORY  DONE    cap-development  Checkout\n"+
+		"FN-12  BUG    TODO    -                A summary well over the sixty characters the table has room…\n",
+		buf.String())
+}
+
+func TestPrintTasks_TextWithNotes(t *testing.T) {
+	var buf bytes.Buffer
+	err := printTasks(&buf, []*domain.Task{
+		{Key: "FN-1", Type: domain.TaskTypeStory, Status: domain.TaskStatusDone, Summary: "Checkout"},
+		{Key: "FN-2", Type: domain.TaskTypeBug, Status: domain.TaskStatusTodo, Summary: "Login"},
+	}, "text", map[string][]domain.TaskNote{"FN-1": {
+		{IssueKey: "FN-1", Text: "confirmed with tech lead: maintenance", CreatedAt: time.Date(2024, 5, 2, 14, 30, 0, 0, time.UTC)},
+	}})
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), "Labels: []\nNotes:\n  2024-05-02 14:30: confirmed with tech lead: maintenance\n\nKey: FN-2")
+	assert.Equal(t, 1, strings.Count(buf.String(), "Notes:"))
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/config"
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain/ports"
+	sprintinfra "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/infrastructure"
+)
+
+// teamsCommand returns the commands managing the team of each project in teams.json. Like
+// config, they do not depend on the application services, so they run before Jira is set up.
+func teamsCommand(configPath string) *cli.Command {
+	projectFlag := func() cli.Flag {
+		return &cli.StringFlag{
+			Name:     "project",
+			Aliases:  []string{"p"},
+			Usage:    "Jira project key of the team",
+			Required: true,
+		}
+	}
+	run := func(action func(ctx *cli.Context, teams ports.TeamsRepository) error) cli.ActionFunc {
+		return func(ctx *cli.Context) error {
+			teams, err := teamsRepository(configPath)
+			if err != nil {
+				return err
+			}
+			return action(ctx, teams)
+		}
+	}
+
+	return &cli.Command{
+		Name:  "teams",
+		Usage: "Manage the team of each project in teams.json",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "add",
+				Usage:     "Add the team of a project, e.g. teams add --project FN \"Jane Doe\" \"John Roe\"",
+				ArgsUsage: "[member...]",
+				Flags:     []cli.Flag{projectFlag()},
+				Action: run(func(ctx *cli.Context, teams ports.TeamsRepository) error {
+					return addTeam(teams, teamProject(ctx), ctx.Args().Slice())
+				}),
+			},
+			{
+				Name:   "remove",
+				Usage:  "Remove the team of a project",
+				Flags:  []cli.Flag{projectFlag()},
+				Action: run(func(ctx *cli.Context, teams ports.TeamsRepository) error { return removeTeam(teams, teamProject(ctx)) }),
+			},
+			{
+				Name:  "list",
+				Usage: "List the projects and their team members",
+				Action: run(func(_ *cli.Context, teams ports.TeamsRepository) error {
+					return listTeams(teams)
+				}),
+			},
+			{
+				Name:      "set-members",
+				Usage:     "Replace the members of a project's team",
+				ArgsUsage: "<member>...",
+				Flags:     []cli.Flag{projectFlag()},
+				Action: run(func(ctx *cli.Context, teams ports.TeamsRepository) error {
+					if ctx.NArg() == 0 {
+						return fmt.Errorf("usage: assetcap teams set-members --project KEY <member>...")
+					}
+					return setTeamMembers(teams, teamProject(ctx), ctx.Args().Slice())
+				}),
+			},
+		},
+	}
+}
+
+// teamsRepository returns the teams.json of the storage directory configured at configPath
+func teamsRepository(configPath string) (*sprintinfra.JSONTeamsRepository, error) {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return nil, err
+	}
+	return sprintinfra.NewJSONTeamsRepository(filepath.Join(cfg.Storage.Directory, teamsFile)), nil
+}
+
+// teamProject returns the project key given with --project, in the upper case Jira uses
+func teamProject(ctx *cli.Context) string {
+	return strings.ToUpper(strings.TrimSpace(ctx.String("project")))
+}
+
+// addTeam adds the team of a project, creating teams.json for the first team
+func addTeam(repository ports.TeamsRepository, project string, members []string) error {
+	teams, err := repository.Load()
+	if errors.Is(err, sprintdomain.ErrNoTeams) {
+		teams, err = sprintdomain.TeamMap{}, nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := teams.AddTeam(project, members); err != nil {
+		return err
+	}
+	if err := repository.Save(teams); err != nil {
+		return err
+	}
+	fmt.Printf("Added the team of %s with %s\n", project, memberCount(len(teams[project].Team)))
+	return nil
+}
+
+// removeTeam removes the team of a project
+func removeTeam(repository ports.TeamsRepository, project string) error {
+	teams, err := repository.Load()
+	if err != nil {
+		return err
+	}
+	if err := teams.RemoveTeam(project); err != nil {
+		return err
+	}
+	if err := repository.Save(teams); err != nil {
+		return err
+	}
+	fmt.Printf("Removed the team of %s\n", project)
+	return nil
+}
+
+// setTeamMembers replaces the members of a project's team
+func setTeamMembers(repository ports.TeamsRepository, project string, members []string) error {
+	teams, err := repository.Load()
+	if err != nil {
+		return err
+	}
+	if err := teams.SetMembers(project, members); err != nil {
+		return err
+	}
+	if err := repository.Save(teams); err != nil {
+		return err
+	}
+	fmt.Printf("The team of %s now has %s\n", project, memberCount(len(teams[project].Team)))
+	return nil
+}
+
+// listTeams prints each project with its team members
+func listTeams(repository ports.TeamsRepository) error {
+	teams, err := repository.Load()
+	if err != nil {
+		return err
+	}
+	if len(teams) == 0 {
+		fmt.Println("No teams; add one with assetcap teams add --project KEY")
+		return nil
+	}
+	for _, project := range teams.Projects() {
+		members := teams[project].Team
+		if len(members) == 0 {
+			fmt.Printf("%s: no members\n", project)
+			continue
+		}
+		fmt.Printf("%s: %s\n", project, strings.Join(members, ", "))
+	}
+	return nil
+}
+
+func memberCount(count int) string {
+	if count == 1 {
+		return "1 member"
+	}
+	return fmt.Sprintf("%d members", count)
+}
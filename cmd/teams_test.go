@@ -0,0 +1,60 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/config"
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	sprintinfra "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/infrastructure"
+)
+
+func TestTeamsCommands(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".assetcap", "teams.json")
+	teams := sprintinfra.NewJSONTeamsRepository(path)
+
+	err := listTeams(teams)
+	assert.ErrorIs(t, err, sprintdomain.ErrNoTeams, "teams.json is never created behind the user's back")
+	assert.NoFileExists(t, path)
+
+	output, err := captureOutput(func() error { return addTeam(teams, "FN", []string{"Jane Doe", "John Roe"}) })
+	require.NoError(t, err)
+	assert.Equal(t, "Added the team of FN with 2 members\n", output)
+	output, err = captureOutput(func() error { return addTeam(teams, "OPS", nil) })
+	require.NoError(t, err)
+	assert.Equal(t, "Added the team of OPS with 0 members\n", output)
+	assert.EqualError(t, addTeam(teams, "FN", nil), "project FN already has a team")
+
+	output, err = captureOutput(func() error { return listTeams(teams) })
+	require.NoError(t, err)
+	assert.Equal(t, "FN: Jane Doe, John Roe\nOPS: no members\n", output)
+
+	output, err = captureOutput(func() error { return setTeamMembers(teams, "OPS", []string{"Carol"}) })
+	require.NoError(t, err)
+	assert.Equal(t, "The team of OPS now has 1 member\n", output)
+	assert.EqualError(t, setTeamMembers(teams, "PAY", []string{"Carol"}), "project PAY not found in teams.json")
+
+	output, err = captureOutput(func() error { return removeTeam(teams, "FN") })
+	require.NoError(t, err)
+	assert.Equal(t, "Removed the team of FN\n", output)
+
+	stored, err := teams.Load()
+	require.NoError(t, err)
+	assert.Equal(t, sprintdomain.TeamMap{"OPS": {Team: []string{"Carol"}}}, stored)
+}
+
+func TestTeamsRepository_UsesStorageDirectory(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.json")
+	cfg := config.Default()
+	cfg.Storage.Directory = filepath.Join(dir, "data")
+	require.NoError(t, config.Save(configPath, cfg))
+
+	teams, err := teamsRepository(configPath)
+	require.NoError(t, err)
+	require.NoError(t, teams.Save(sprintdomain.TeamMap{}))
+	assert.FileExists(t, filepath.Join(dir, "data", "teams.json"))
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/telemetry"
+)
+
+// instrument wraps the action of every command so each run is recorded by the telemetry
+// recorder, named by its path, e.g. "tasks show"
+func (a *App) instrument(commands []*cli.Command, parent string) {
+	for _, command := range commands {
+		name := strings.TrimSpace(parent + " " + command.Name)
+		if action := command.Action; action != nil {
+			command.Action = func(ctx *cli.Context) error {
+				started := time.Now()
+				err := action(ctx)
+				// Telemetry is best effort: a failure to record never fails the command
+				_ = a.telemetry.Record(ctx.Context, name, started, err)
+				return err
+			}
+		}
+		a.instrument(command.Subcommands, name)
+	}
+}
+
+// telemetryRecorder returns the configured recorder, or one buffering in the storage directory
+func (a *App) telemetryRecorder() *telemetry.Recorder {
+	if a.telemetry != nil {
+		return a.telemetry
+	}
+	return telemetry.NewRecorder(a.storageDir, "")
+}
+
+// printTelemetryStatus prints whether telemetry is enabled, where it is sent and what is buffered
+func printTelemetryStatus(status telemetry.Status) {
+	state := "disabled"
+	if status.Enabled {
+		state = "enabled"
+	}
+	fmt.Printf("Telemetry: %s\n", state)
+	if status.OptedOut {
+		fmt.Println("DO_NOT_TRACK is set: nothing is recorded")
+	}
+	if status.InstallID != "" {
+		fmt.Printf("Install ID: %s\n", status.InstallID)
+	}
+	endpoint := status.Endpoint
+	if endpoint == "" {
+		endpoint = "none (events stay in the local buffer)"
+	}
+	fmt.Printf("Endpoint: %s\n", endpoint)
+	fmt.Printf("Buffered events: %d\n", status.Buffered)
+}
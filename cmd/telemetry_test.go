@@ -0,0 +1,53 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/telemetry"
+)
+
+func TestTelemetryCommands(t *testing.T) {
+	t.Setenv("DO_NOT_TRACK", "")
+	dir := t.TempDir()
+	assets := new(MockAssetService)
+	assets.On("ListAssets").Return([]*assetsdomain.Asset{{Name: "booking"}, {Name: "search"}}, nil)
+	app := NewApp(assets, new(MockTaskService), new(MockSprintService))
+	app.storageDir = dir
+	app.telemetry = telemetry.NewRecorder(dir, "")
+	run := func(args ...string) string {
+		output, err := captureOutput(func() error {
+			os.Args = append([]string{"assetcap"}, args...)
+			return app.Run()
+		})
+		require.NoError(t, err)
+		return output
+	}
+
+	run("assets", "list")
+	assert.Contains(t, run("telemetry", "status"), "Telemetry: disabled")
+	_, err := os.Stat(filepath.Join(dir, "telemetry-buffer.jsonl"))
+	assert.True(t, os.IsNotExist(err), "nothing is recorded before opting in")
+
+	assert.Contains(t, run("telemetry", "enable"), "Telemetry enabled")
+	run("assets", "list")
+	output := run("telemetry", "status")
+	assert.Contains(t, output, "Telemetry: enabled")
+	assert.Contains(t, output, "Endpoint: none (events stay in the local buffer)")
+	assert.Contains(t, output, "Buffered events: 2")
+
+	buffer, err := os.ReadFile(filepath.Join(dir, "telemetry-buffer.jsonl"))
+	require.NoError(t, err)
+	assert.Contains(t, string(buffer), `"command":"telemetry enable"`)
+	assert.Contains(t, string(buffer), `"command":"assets list"`)
+	assert.Contains(t, string(buffer), `"sizes":{"assets":2}`)
+	assert.NotContains(t, string(buffer), "booking", "no content is recorded")
+
+	assert.Contains(t, run("telemetry", "disable"), "Telemetry disabled")
+	assert.Contains(t, run("telemetry", "status"), "Buffered events: 0")
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+// webhookContext returns the context of serve webhooks run with args
+func webhookContext(t *testing.T, args ...string) *cli.Context {
+	set := flag.NewFlagSet("webhooks", flag.ContinueOnError)
+	set.String("secret-env", "JIRA_WEBHOOK_SECRET", "")
+	set.Bool("insecure-no-verify", false, "")
+	set.Bool("reclassify", false, "")
+	require.NoError(t, set.Parse(args))
+	return cli.NewContext(nil, set, nil)
+}
+
+func TestWebhookHandler_RequiresSecret(t *testing.T) {
+	t.Setenv("JIRA_WEBHOOK_SECRET", "")
+	app := NewApp(new(MockAssetService), new(MockTaskService), new(MockSprintService))
+
+	_, err := app.webhookHandler(webhookContext(t))
+	assert.EqualError(t, err, "JIRA_WEBHOOK_SECRET is not set: set it to verify request signatures, or pass --insecure-no-verify to serve unverified requests")
+
+	handler, err := app.webhookHandler(webhookContext(t, "--insecure-no-verify"))
+	require.NoError(t, err)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/webhooks/jira", strings.NewReader(`{"webhookEvent": "jira:worklog_updated"}`)))
+	assert.Equal(t, http.StatusAccepted, rec.Code, "the opt-out takes unsigned events")
+}
+
+func TestWebhookHandler_RejectsUnsignedRequests(t *testing.T) {
+	t.Setenv("JIRA_WEBHOOK_SECRET", "s3cret")
+	app := NewApp(new(MockAssetService), new(MockTaskService), new(MockSprintService))
+
+	handler, err := app.webhookHandler(webhookContext(t))
+	require.NoError(t, err)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/webhooks/jira", strings.NewReader(`{"webhookEvent": "jira:issue_deleted", "issue": {"key": "FN-7", "fields": {}}}`)))
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
@@ -0,0 +1,423 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	assetsapp "github.com/helmedeiros/digital-asset-capitalization/internal/assets/application"
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	assetports "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain/ports"
+	assetsinfra "github.com/helmedeiros/digital-asset-capitalization/internal/assets/infrastructure"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/infrastructure/confluence"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/infrastructure/llama"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/config"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/httpclient"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/jirafield"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sink"
+	sprintapp "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/application"
+	sprintusecase "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/application/usecase"
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	sprintinfra "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/infrastructure"
+	tasksapp "github.com/helmedeiros/digital-asset-capitalization/internal/tasks/application"
+	tasksdomain "github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+	taskports "github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain/ports"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/infrastructure/classifier"
+	cliui "github.com/helmedeiros/digital-asset-capitalization/internal/tasks/infrastructure/cli"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/infrastructure/codehost"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/infrastructure/jira"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/infrastructure/storage"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/telemetry"
+)
+
+const (
+	assetsFile  = "assets.json"
+	tasksFile   = "tasks.json"
+	samplesFile = "samples.json"
+	splitsFile  = "splits.json"
+	notesFile   = "notes.json"
+	epicsFile   = "epics.json"
+	teamsFile   = "teams.json"
+	rollupsFile = "rollups.json"
+	pipelineDir = "pipeline"
+)
+
+// initializeApp loads the configuration at configPath and wires the application
+func initializeApp(configPath string) (*App, error) {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return nil, err
+	}
+	cfg = cacheSprintField(cfg, configPath)
+	app, err := buildApp(cfg)
+	if err != nil {
+		return nil, err
+	}
+	app.configPath = configPath
+	return app, nil
+}
+
+// cacheSprintField detects the sprint field of the Jira instance the credentials point to and
+// saves it to the configuration, so each instance is asked once. A field set by hand is kept,
+// and a failed detection warns and falls back to the default field.
+func cacheSprintField(cfg config.Config, configPath string) config.Config {
+	baseURL, email, token := os.Getenv("JIRA_BASE_URL"), os.Getenv("JIRA_EMAIL"), os.Getenv("JIRA_TOKEN")
+	if cfg.Jira.Export != "" || baseURL == "" || email == "" || token == "" {
+		return cfg
+	}
+	if cfg.Jira.SprintField != "" && (cfg.Jira.SprintFieldInstance == "" || cfg.Jira.SprintFieldInstance == baseURL) {
+		return cfg
+	}
+
+	httpClient, err := newHTTPClient(cfg.Network, jira.DefaultTimeout)
+	if err != nil {
+		return cfg
+	}
+	auth := "Basic " + base64.StdEncoding.EncodeToString([]byte(email+":"+token))
+	field, err := jirafield.DetectSprintField(httpClient, baseURL, auth)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not detect the Jira sprint field (%v); using %s\n", err, jirafield.DefaultSprintField)
+		return cfg
+	}
+
+	cfg.Jira.SprintField, cfg.Jira.SprintFieldInstance = field, baseURL
+	if err := config.Save(configPath, cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not save the detected Jira sprint field: %v\n", err)
+	}
+	return cfg
+}
+
+// buildApp creates the application services according to the configuration
+func buildApp(cfg config.Config) (*App, error) {
+	if _, err := configuredLocale(cfg.Export); err != nil {
+		return nil, err
+	}
+	if err := validateOutputs(cfg.Output); err != nil {
+		return nil, fmt.Errorf("invalid output configuration: %w", err)
+	}
+	if cfg.Docs.Notify != "" {
+		if _, err := sink.Open(cfg.Docs.Notify, sinkOptions(cfg.Output)); err != nil {
+			return nil, fmt.Errorf("invalid docs notify destination: %w", err)
+		}
+	}
+
+	taskService, err := newTaskService(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	assetService, err := newAssetService(cfg, taskLinkCounter{tasks: taskService})
+	if err != nil {
+		return nil, err
+	}
+
+	sprintService, err := newSprintService(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	app := NewApp(assetService, taskService, sprintService)
+	app.export = cfg.Export
+	app.storageDir = cfg.Storage.Directory
+	app.outputs = cfg.Output
+	if app.heuristics, err = allocationHeuristics(cfg.Allocation); err != nil {
+		return nil, err
+	}
+	app.jiraExport = cfg.Jira.Export
+	if app.personWorkTypes, err = sprintPersonWorkTypes(cfg.Allocation); err != nil {
+		return nil, err
+	}
+	app.docs = cfg.Docs
+	app.coverage = cfg.Coverage
+	app.signing = cfg.Signing
+	app.confluence = cfg.Confluence
+	app.defaults = cfg.Defaults
+	app.labelPolicy = tasksdomain.LabelPolicy{Managed: cfg.Jira.ManagedLabels, Protected: cfg.Jira.ProtectedLabels}
+	app.telemetry = telemetry.NewRecorder(cfg.Storage.Directory, cfg.Telemetry.Endpoint)
+	app.events = newEventBus(cfg.Hooks)
+	if app.erpLayout, err = erpLayout(cfg.ERP); err != nil {
+		return nil, err
+	}
+	app.defaultAccount = sprintdomain.AssetAccount{CostCenter: cfg.ERP.CostCenter, GLAccount: cfg.ERP.GLAccount}
+	app.rewire = func(storageDir string) (*App, error) {
+		view := cfg
+		view.Storage.Directory = storageDir
+		return buildApp(view)
+	}
+	return app, nil
+}
+
+// allocationHeuristics converts the configured allocation heuristics for the sprint services
+func allocationHeuristics(cfg config.AllocationConfig) (sprintdomain.AllocationHeuristics, error) {
+	heuristics := sprintdomain.AllocationHeuristics{
+		DefaultHours:          cfg.DefaultHours,
+		DisableDefaultHours:   cfg.DisableDefaultHours,
+		SameDayMinimum:        cfg.SameDayMinimum,
+		DisableSameDayMinimum: cfg.DisableSameDayMinimum,
+		StaleAfterDays:        cfg.StaleInProgressDays,
+		StaleCapHours:         cfg.StaleCapHours,
+		LowConfidence:         cfg.LowConfidence,
+	}
+	if !cfg.Calendar.Enabled() {
+		return heuristics, nil
+	}
+	weekend := cfg.Calendar.Weekend
+	if len(weekend) == 0 {
+		weekend = []string{"saturday", "sunday"}
+	}
+	calendar, err := sprintdomain.NewBusinessCalendar(cfg.Calendar.DayStart, cfg.Calendar.DayEnd, weekend, cfg.Calendar.Holidays, cfg.Calendar.TimeZone)
+	if err != nil {
+		return sprintdomain.AllocationHeuristics{}, fmt.Errorf("invalid allocation calendar: %w", err)
+	}
+	heuristics.Calendar = calendar
+	return heuristics, nil
+}
+
+// erpLayout converts the configured ERP export layout, filling in the default csv layout
+func erpLayout(cfg config.ERPConfig) (sprintdomain.ERPLayout, error) {
+	layout := sprintdomain.DefaultERPLayout()
+	if cfg.Format != "" {
+		layout.Format = sprintdomain.ERPFormat(cfg.Format)
+	}
+	if cfg.Delimiter != "" {
+		delimiter, err := sprintusecase.ParseCSVDelimiter(cfg.Delimiter)
+		if err != nil {
+			return sprintdomain.ERPLayout{}, fmt.Errorf("invalid erp layout: %w", err)
+		}
+		layout.Delimiter = delimiter
+	}
+	if len(cfg.Columns) > 0 {
+		layout.Columns = make([]sprintdomain.ERPColumn, len(cfg.Columns))
+		for i, column := range cfg.Columns {
+			layout.Columns[i] = sprintdomain.ERPColumn{Field: column.Field, Width: column.Width}
+		}
+	}
+	if err := layout.Validate(); err != nil {
+		return sprintdomain.ERPLayout{}, fmt.Errorf("invalid erp layout: %w", err)
+	}
+	return layout, nil
+}
+
+// sprintPersonWorkTypes validates the configured work type overrides of each sprint's team members
+func sprintPersonWorkTypes(cfg config.AllocationConfig) (map[string]sprintdomain.PersonWorkTypes, error) {
+	result := make(map[string]sprintdomain.PersonWorkTypes, len(cfg.PersonWorkTypes))
+	for sprint, overrides := range cfg.PersonWorkTypes {
+		personWorkTypes, err := sprintdomain.NewPersonWorkTypes(overrides)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allocation person work types of %s: %w", sprint, err)
+		}
+		result[sprint] = personWorkTypes
+	}
+	return result, nil
+}
+
+func newAssetService(cfg config.Config, taskLinks assetports.TaskLinkPort) (assetsapp.AssetService, error) {
+	assetRepo := assetsinfra.NewJSONRepository(assetsinfra.RepositoryConfig{
+		Directory:      cfg.Storage.Directory,
+		Filename:       assetsFile,
+		FileMode:       0644,
+		DirMode:        0755,
+		TrashRetention: trashRetention(cfg),
+	})
+
+	llamaClient, err := newLLMClient(cfg.LLM, cfg.Network)
+	if err != nil {
+		return nil, err
+	}
+
+	confluenceConfig := confluence.DefaultConfig()
+	confluenceConfig.BaseURL = os.Getenv("JIRA_BASE_URL")
+	confluenceConfig.Token = os.Getenv("JIRA_TOKEN")
+	confluenceConfig.HTTPClient, err = newHTTPClient(cfg.Network, confluence.DefaultTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return assetsapp.NewAssetServiceWithDependencies(assetRepo, llamaClient, confluence.NewAdapter(confluenceConfig), taskLinks), nil
+}
+
+func newLLMClient(cfg config.LLMConfig, network config.NetworkConfig) (assetsapp.LlamaClient, error) {
+	client, err := newLlamaClient(cfg, network)
+	if client == nil || err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// newLlamaClient returns the client of the configured LLM, or nil when none is configured
+func newLlamaClient(cfg config.LLMConfig, network config.NetworkConfig) (*llama.Client, error) {
+	if cfg.Provider == config.LLMProviderNone {
+		return nil, nil
+	}
+
+	httpClient, err := newHTTPClient(network, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	llamaConfig := llama.DefaultConfig()
+	if cfg.BaseURL != "" {
+		llamaConfig.BaseURL = cfg.BaseURL
+	}
+	if cfg.Model != "" {
+		llamaConfig.Model = cfg.Model
+	}
+	llamaConfig.HTTPClient = httpClient
+	client, err := llama.NewClient(llamaConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize LLaMA client: %v", err)
+	}
+	return client, nil
+}
+
+// newTaskClassifier returns the configured classifier of the work type of tasks
+func newTaskClassifier(cfg config.Config) (taskports.TaskClassifier, error) {
+	if cfg.Classifier == config.ClassifierRandom {
+		return classifier.NewRandomClassifier(), nil
+	}
+	client, err := newLlamaClient(cfg.LLM, cfg.Network)
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		return nil, fmt.Errorf("the %s classifier needs an LLM provider", cfg.Classifier)
+	}
+	return classifier.NewLlamaClassifier(client), nil
+}
+
+func newTaskService(cfg config.Config) (tasksapp.TaskService, error) {
+	hierarchy := make([]jira.HierarchyLevel, 0, len(cfg.Jira.Hierarchy))
+	for _, level := range cfg.Jira.Hierarchy {
+		hierarchy = append(hierarchy, jira.HierarchyLevel{Level: level.Level, Field: level.Field})
+	}
+
+	var jiraRepo taskports.TaskRepository
+	if cfg.Jira.Export != "" {
+		exportRepo, err := jira.NewExportRepository(cfg.Jira.Export)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize Jira export: %v", err)
+		}
+		jiraRepo = exportRepo
+	} else {
+		httpClient, err := newHTTPClient(cfg.Network, jira.DefaultTimeout)
+		if err != nil {
+			return nil, err
+		}
+		jiraRepo, err = jira.NewRepositoryWithFields(hierarchy, httpClient, jira.FieldSelection{
+			SprintField: cfg.Jira.SprintField,
+			Extra:       cfg.Jira.Fields,
+			All:         cfg.Jira.FetchAllFields,
+		}, cfg.Jira.PageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize Jira repository: %v", err)
+		}
+	}
+
+	localRepo := storage.NewJSONStorageWithTrashRetention(cfg.Storage.Directory, tasksFile, trashRetention(cfg))
+	taskClassifier, err := newTaskClassifier(cfg)
+	if err != nil {
+		return nil, err
+	}
+	userInput := cliui.NewUserInput()
+	sampleRepo := storage.NewJSONSampleStorage(cfg.Storage.Directory, samplesFile)
+	splitRepo := storage.NewJSONSplitStorage(cfg.Storage.Directory, splitsFile)
+	noteRepo := storage.NewJSONNoteStorage(cfg.Storage.Directory, notesFile)
+	epicRepo := storage.NewJSONEpicMapStorage(cfg.Storage.Directory, epicsFile)
+	rollupCache := storage.NewJSONRollupCache(cfg.Storage.Directory, rollupsFile)
+	codeHost, err := newCodeHost(cfg.Code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize code host: %v", err)
+	}
+	return tasksapp.NewTasksService(jiraRepo, localRepo, taskClassifier, userInput, sampleRepo, splitRepo, rollupCache, codeHost, noteRepo, epicRepo), nil
+}
+
+// newCodeHost creates the configured code host client, or none when code linking is not set up
+func newCodeHost(cfg config.CodeConfig) (taskports.CodeHost, error) {
+	if cfg.Host == "" {
+		return nil, nil
+	}
+	return codehost.New(codehost.ConfigFromEnv(codehost.Config{
+		Host:         cfg.Host,
+		BaseURL:      cfg.BaseURL,
+		Repositories: cfg.Repositories,
+	}))
+}
+
+// newHTTPClient creates an HTTP client honouring the network configuration, bounding each
+// request by defaultTimeout unless a timeout is configured
+func newHTTPClient(cfg config.NetworkConfig, defaultTimeout time.Duration) (*http.Client, error) {
+	client, err := httpclient.New(httpclient.Config{
+		ProxyURL:          cfg.ProxyURL,
+		ProxyUsername:     cfg.ProxyUsername,
+		CABundle:          cfg.CABundle,
+		ClientCertificate: cfg.ClientCertificate,
+		ClientKey:         cfg.ClientKey,
+		Timeout:           time.Duration(cfg.TimeoutSeconds) * time.Second,
+	}.WithEnv(), defaultTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("invalid network configuration: %w", err)
+	}
+	return client, nil
+}
+
+// trashRetention returns how long deleted assets and tasks are kept
+func trashRetention(cfg config.Config) time.Duration {
+	return time.Duration(cfg.Storage.TrashRetentionDays) * 24 * time.Hour
+}
+
+func newSprintService(cfg config.Config) (sprintapp.SprintService, error) {
+	if cfg.Jira.Export != "" {
+		exportAdapter, err := sprintinfra.NewExportAdapter(cfg.Jira.Export)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize Jira export: %v", err)
+		}
+		return sprintapp.NewSprintService(exportAdapter), nil
+	}
+	httpClient, err := newHTTPClient(cfg.Network, sprintinfra.DefaultHTTPTimeout)
+	if err != nil {
+		return nil, err
+	}
+	jiraAdapter, err := sprintinfra.NewJiraAdapterWithClient(httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize Jira adapter: %v", err)
+	}
+	jiraAdapter.UseSprintField(cfg.Jira.SprintField)
+	jiraAdapter.UsePageSize(cfg.Jira.PageSize)
+	return sprintapp.NewSprintService(jiraAdapter), nil
+}
+
+// taskLinkCounter derives asset task counts from the asset labels of the stored tasks
+type taskLinkCounter struct {
+	tasks tasksapp.TaskService
+}
+
+// CountLinkedTasks returns the number of stored tasks labeled with the asset
+func (c taskLinkCounter) CountLinkedTasks(assetKey string) (int, error) {
+	if assetKey == "" {
+		return 0, nil
+	}
+	tasks, err := c.tasks.GetTasksByAsset(context.Background(), assetKey)
+	if err != nil {
+		return 0, err
+	}
+	return len(tasks), nil
+}
+
+// FindLinkedTasks returns the sprint and work type of the stored tasks labeled with the asset
+func (c taskLinkCounter) FindLinkedTasks(assetKey string) ([]assetsdomain.LinkedTask, error) {
+	if assetKey == "" {
+		return nil, nil
+	}
+	tasks, err := c.tasks.GetTasksByAsset(context.Background(), assetKey)
+	if err != nil {
+		return nil, err
+	}
+	linked := make([]assetsdomain.LinkedTask, 0, len(tasks))
+	for _, task := range tasks {
+		linked = append(linked, assetsdomain.LinkedTask{Key: task.Key, Sprint: task.Sprint, WorkType: string(task.WorkType)})
+	}
+	return linked, nil
+}
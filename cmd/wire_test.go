@@ -0,0 +1,268 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/config"
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	tasksdomain "github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/infrastructure/classifier"
+)
+
+func TestInitializeApp_InvalidConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"storage": {"backend": "sqlite"}}`), 0644))
+
+	app, err := initializeApp(path)
+	assert.Nil(t, app)
+	assert.ErrorContains(t, err, "unsupported storage backend: sqlite")
+}
+
+func TestInitializeApp_InvalidExportLocale(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"export": {"locale": "xx-YY"}}`), 0644))
+
+	app, err := initializeApp(path)
+	assert.Nil(t, app)
+	assert.ErrorContains(t, err, "invalid export locale: unsupported locale")
+}
+
+func TestInitializeApp_InvalidExportPercentFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"export": {"percentFormat": "ratio"}}`), 0644))
+
+	app, err := initializeApp(path)
+	assert.Nil(t, app)
+	assert.ErrorContains(t, err, `invalid export percent format: unsupported percent format "ratio"`)
+}
+
+func TestInitializeApp_JiraExport(t *testing.T) {
+	t.Setenv("JIRA_BASE_URL", "")
+	t.Setenv("JIRA_EMAIL", "")
+	t.Setenv("JIRA_TOKEN", "")
+	dir := t.TempDir()
+	export := filepath.Join(dir, "FN.csv")
+	require.NoError(t, os.WriteFile(export, []byte("Summary,Issue key,Sprint\nBuild checkout,FN-1,Penguins\n"), 0644))
+	path := filepath.Join(dir, "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"storage": {"directory": "`+filepath.ToSlash(dir)+`"}, "jira": {"export": "`+filepath.ToSlash(export)+`"}}`), 0644))
+
+	app, err := initializeApp(path)
+	require.NoError(t, err, "an export stands in for the Jira credentials")
+	assert.Equal(t, export, app.jiraExport)
+
+	require.NoError(t, os.WriteFile(export, []byte("Summary\nBuild checkout\n"), 0644))
+	_, err = initializeApp(path)
+	assert.ErrorContains(t, err, "failed to initialize Jira export")
+}
+
+func TestCacheSprintField(t *testing.T) {
+	detections := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		detections++
+		assert.Equal(t, "/rest/api/2/field", r.URL.Path)
+		w.Write([]byte(`[{"id": "customfield_10100", "name": "Sprint", "schema": {"type": "array", "custom": "com.pyxis.greenhopper.jira:gh-sprint"}}]`))
+	}))
+	defer server.Close()
+	t.Setenv("JIRA_BASE_URL", server.URL)
+	t.Setenv("JIRA_EMAIL", "jane@acme.com")
+	t.Setenv("JIRA_TOKEN", "token")
+	path := filepath.Join(t.TempDir(), "config.json")
+
+	cfg := cacheSprintField(config.Default(), path)
+	assert.Equal(t, "customfield_10100", cfg.Jira.SprintField)
+	saved, err := config.Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "customfield_10100", saved.Jira.SprintField, "the detected field is cached in the config")
+	assert.Equal(t, server.URL, saved.Jira.SprintFieldInstance)
+
+	cacheSprintField(saved, path)
+	assert.Equal(t, 1, detections, "the field is detected once per instance")
+
+	saved.Jira.SprintFieldInstance = "https://other.atlassian.net"
+	cacheSprintField(saved, path)
+	assert.Equal(t, 2, detections, "another instance is asked again")
+
+	manual := config.Default()
+	manual.Jira.SprintField = "customfield_10007"
+	assert.Equal(t, "customfield_10007", cacheSprintField(manual, path).Jira.SprintField, "a field set by hand is kept")
+	assert.Equal(t, 2, detections)
+}
+
+func TestAllocationHeuristics(t *testing.T) {
+	heuristics, err := allocationHeuristics(config.AllocationConfig{DefaultHours: 6, SameDayMinimum: 0.5, DisableSameDayMinimum: true, LowConfidence: 80})
+	require.NoError(t, err)
+	assert.Equal(t, sprintdomain.AllocationHeuristics{DefaultHours: 6, SameDayMinimum: 0.5, DisableSameDayMinimum: true, LowConfidence: 80}, heuristics)
+	assert.False(t, heuristics.Calendar.Enabled(), "without a calendar allocations count wall-clock hours")
+
+	heuristics, err = allocationHeuristics(config.AllocationConfig{Calendar: config.CalendarConfig{DayStart: "09:00", DayEnd: "17:00", Holidays: []string{"2024-12-25"}}})
+	require.NoError(t, err)
+	assert.Equal(t, 9*time.Hour, heuristics.Calendar.DayStart)
+	assert.Equal(t, 17*time.Hour, heuristics.Calendar.DayEnd)
+	assert.Equal(t, []time.Weekday{time.Saturday, time.Sunday}, heuristics.Calendar.Weekend, "the weekend defaults to saturday and sunday")
+	assert.Len(t, heuristics.Calendar.Holidays, 1)
+
+	_, err = allocationHeuristics(config.AllocationConfig{Calendar: config.CalendarConfig{DayStart: "17:00", DayEnd: "09:00"}})
+	assert.EqualError(t, err, "invalid allocation calendar: workday ends at 09:00, not after it starts at 17:00")
+}
+
+func TestSprintPersonWorkTypes(t *testing.T) {
+	overrides, err := sprintPersonWorkTypes(config.AllocationConfig{PersonWorkTypes: map[string]map[string]string{
+		"Sprint 6": {"Alice": "maintenance"},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]sprintdomain.PersonWorkTypes{"Sprint 6": {"alice": sprintdomain.WorkTypeMaintenance}}, overrides)
+
+	_, err = sprintPersonWorkTypes(config.AllocationConfig{PersonWorkTypes: map[string]map[string]string{
+		"Sprint 6": {"alice": "on-call"},
+	}})
+	assert.ErrorContains(t, err, "invalid allocation person work types of Sprint 6")
+}
+
+func TestAllocationPersonWorkTypes(t *testing.T) {
+	app := NewApp(new(MockAssetService), new(MockTaskService), new(MockSprintService))
+	app.personWorkTypes = map[string]sprintdomain.PersonWorkTypes{
+		"Sprint 6": {"alice": sprintdomain.WorkTypeMaintenance, "bob": sprintdomain.WorkTypeDiscovery},
+	}
+	set := flag.NewFlagSet("allocate", flag.ContinueOnError)
+	flags := cli.NewStringSlice()
+	set.Var(flags, "person-worktype", "")
+	require.NoError(t, set.Parse([]string{"--person-worktype", "alice=development"}))
+	ctx := cli.NewContext(nil, set, nil)
+
+	overrides, err := app.allocationPersonWorkTypes(ctx, "Sprint 6")
+	require.NoError(t, err)
+	assert.Equal(t, sprintdomain.PersonWorkTypes{"alice": sprintdomain.WorkTypeDevelopment, "bob": sprintdomain.WorkTypeDiscovery}, overrides, "--person-worktype wins over the configuration")
+
+	overrides, err = app.allocationPersonWorkTypes(cli.NewContext(nil, flag.NewFlagSet("allocate", flag.ContinueOnError), nil), "Sprint 7")
+	require.NoError(t, err)
+	assert.Nil(t, overrides)
+}
+
+func TestNewLLMClient(t *testing.T) {
+	client, err := newLLMClient(config.LLMConfig{Provider: config.LLMProviderNone}, config.NetworkConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, client)
+
+	client, err = newLLMClient(config.LLMConfig{Provider: config.LLMProviderOllama, BaseURL: "http://ollama.local:11434"}, config.NetworkConfig{})
+	require.NoError(t, err)
+	assert.NotNil(t, client)
+}
+
+func TestNewTaskClassifier(t *testing.T) {
+	cfg := config.Default()
+	taskClassifier, err := newTaskClassifier(cfg)
+	require.NoError(t, err)
+	assert.IsType(t, &classifier.LlamaClassifier{}, taskClassifier)
+
+	cfg.Classifier = config.ClassifierRandom
+	taskClassifier, err = newTaskClassifier(cfg)
+	require.NoError(t, err)
+	assert.IsType(t, &classifier.RandomClassifier{}, taskClassifier)
+
+	cfg.Classifier = config.ClassifierLlama
+	cfg.LLM.Provider = config.LLMProviderNone
+	_, err = newTaskClassifier(cfg)
+	assert.EqualError(t, err, "the llama classifier needs an LLM provider")
+}
+
+func TestNewHTTPClient(t *testing.T) {
+	client, err := newHTTPClient(config.NetworkConfig{}, 30*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, client.Timeout)
+
+	client, err = newHTTPClient(config.NetworkConfig{ProxyURL: "http://proxy.example.com:8080", TimeoutSeconds: 90}, 30*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Second, client.Timeout)
+
+	_, err = newHTTPClient(config.NetworkConfig{CABundle: filepath.Join(t.TempDir(), "missing.pem")}, 30*time.Second)
+	assert.ErrorContains(t, err, "invalid network configuration: failed to read CA bundle")
+}
+
+func TestNewAssetService_InvalidNetwork(t *testing.T) {
+	cfg := config.Default()
+	cfg.Storage.Directory = t.TempDir()
+	cfg.LLM.Provider = config.LLMProviderNone
+	cfg.Network.ProxyURL = "ftp://proxy.example.com"
+
+	_, err := newAssetService(cfg, nil)
+	assert.ErrorContains(t, err, "invalid network configuration: invalid proxy URL")
+}
+
+func TestNewCodeHost(t *testing.T) {
+	host, err := newCodeHost(config.CodeConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, host)
+
+	host, err = newCodeHost(config.CodeConfig{Host: config.CodeHostBitbucket, Repositories: []string{"acme/api"}})
+	require.NoError(t, err)
+	assert.NotNil(t, host)
+}
+
+func TestNewAssetService_UsesConfiguredDirectory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "data")
+	cfg := config.Default()
+	cfg.Storage.Directory = dir
+	cfg.LLM.Provider = config.LLMProviderNone
+
+	service, err := newAssetService(cfg, nil)
+	require.NoError(t, err)
+	require.NoError(t, service.CreateAsset("checkout", "Checkout flow"))
+
+	_, err = os.Stat(filepath.Join(dir, assetsFile))
+	assert.NoError(t, err)
+}
+
+func TestTaskLinkCounter(t *testing.T) {
+	tasks := new(MockTaskService)
+	tasks.On("GetTasksByAsset", context.Background(), "cap-asset-booking").Return([]*tasksdomain.Task{{Key: "FN-1"}, {Key: "FN-2"}}, nil)
+	counter := taskLinkCounter{tasks: tasks}
+
+	count, err := counter.CountLinkedTasks("cap-asset-booking")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	count, err = counter.CountLinkedTasks("")
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestTaskLinkCounter_FindLinkedTasks(t *testing.T) {
+	tasks := new(MockTaskService)
+	tasks.On("GetTasksByAsset", context.Background(), "cap-asset-booking").Return([]*tasksdomain.Task{
+		{Key: "FN-1", Sprint: "Sprint 1", WorkType: tasksdomain.WorkTypeDevelopment},
+		{Key: "FN-2", Sprint: "Sprint 2"},
+	}, nil)
+	counter := taskLinkCounter{tasks: tasks}
+
+	linked, err := counter.FindLinkedTasks("cap-asset-booking")
+	require.NoError(t, err)
+	assert.Equal(t, []assetsdomain.LinkedTask{
+		{Key: "FN-1", Sprint: "Sprint 1", WorkType: "cap-development"},
+		{Key: "FN-2", Sprint: "Sprint 2"},
+	}, linked)
+
+	linked, err = counter.FindLinkedTasks("")
+	require.NoError(t, err)
+	assert.Empty(t, linked)
+}
+
+func TestInitializeApp_InvalidOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"output": {"destinations": {"sprint allocate": "s3://reports"}}}`), 0644))
+
+	app, err := initializeApp(path)
+	assert.Nil(t, app)
+	assert.ErrorContains(t, err, "invalid output configuration: invalid output destination for sprint allocate")
+}
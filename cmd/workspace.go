@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/workspace"
+)
+
+// workspaceCommand returns the command moving the storage directory and the configuration
+// between machines as a single archive
+func (a *App) workspaceCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "workspace",
+		Usage: "Move the whole .assetcap workspace between machines",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "export",
+				Usage: "Bundle assets, tasks, teams, allocations and config into a .tar.gz archive; credentials and telemetry stay behind",
+				Action: func(ctx *cli.Context) error {
+					return a.exportWorkspace(ctx.Context, ctx.String("out"))
+				},
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "out",
+						Usage:    "Path of the archive to write, e.g. workspace.tar.gz",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:  "import",
+				Usage: "Restore a workspace archive, or merge it with the local workspace",
+				Action: func(ctx *cli.Context) error {
+					if ctx.Bool("merge") && ctx.Bool("force") {
+						return fmt.Errorf("--merge and --force cannot be combined")
+					}
+					return a.importWorkspace(ctx.String("in"), workspace.ImportOptions{
+						Merge: ctx.Bool("merge"),
+						Force: ctx.Bool("force"),
+						Hooks: ctx.Bool("hooks"),
+					})
+				},
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "in",
+						Usage:    "Path of the archive written by workspace export",
+						Required: true,
+					},
+					&cli.BoolFlag{
+						Name:  "merge",
+						Usage: "Combine with the local workspace: the newer of each asset and task wins, team members and absences are added, other local files are kept",
+					},
+					&cli.BoolFlag{
+						Name:  "force",
+						Usage: "Replace the local files with the archived ones",
+					},
+					&cli.BoolFlag{
+						Name:  "hooks",
+						Usage: "Install the hooks of the archived config instead of keeping the local ones; the installed commands are printed",
+					},
+				},
+			},
+		},
+	}
+}
+
+// exportWorkspace writes the workspace archive to path, removing it when the export fails
+func (a *App) exportWorkspace(ctx context.Context, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	manifest, err := workspace.New(a.storageDir, a.configPath).Export(file, time.Now())
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write %s: %w", path, closeErr)
+	}
+	if err != nil {
+		os.Remove(path)
+		return err
+	}
+	if err := a.signArtifact(ctx, path); err != nil {
+		return err
+	}
+
+	fmt.Printf("Exported %d file(s) of %s to %s\n", len(manifest.Files), a.storageDir, path)
+	fmt.Println("Credentials and telemetry are not included, and config.json is exported without webhook headers and command hooks.")
+	return nil
+}
+
+// importWorkspace reads the workspace archive at path into the storage directory
+func (a *App) importWorkspace(path string, opts workspace.ImportOptions) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	result, err := workspace.New(a.storageDir, a.configPath).Import(file, opts)
+	if errors.Is(err, workspace.ErrFilesExist) {
+		return fmt.Errorf("%w: use --merge to combine the workspaces or --force to replace the local files", err)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported %s (exported %s, format %d) into %s\n", path, result.Manifest.ExportedAt.Format(time.RFC3339), result.Manifest.FormatVersion, a.storageDir)
+	printImportedFiles("Written", result.Written)
+	printImportedFiles("Merged", result.Merged)
+	printImportedFiles("Kept local", result.Kept)
+	if len(result.SkippedHooks) > 0 {
+		fmt.Printf("Kept the local hooks; the %d hook(s) of the archived config were not installed, pass --hooks to install them\n", len(result.SkippedHooks))
+	}
+	if len(result.Hooks) > 0 {
+		fmt.Println("Installed hooks:")
+		for _, hook := range result.Hooks {
+			target := hook.Command
+			if target == "" {
+				target = hook.Webhook
+			}
+			fmt.Printf("  %s: %s\n", hook.Event, target)
+		}
+	}
+	return nil
+}
+
+// printImportedFiles lists the files an import handled the same way
+func printImportedFiles(label string, files []string) {
+	if len(files) == 0 {
+		return
+	}
+	fmt.Printf("  %s: %s\n", label, strings.Join(files, ", "))
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceCommands(t *testing.T) {
+	newApp := func(files map[string]string) *App {
+		dir := filepath.Join(t.TempDir(), ".assetcap")
+		for name, content := range files {
+			require.NoError(t, os.MkdirAll(dir, 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+		}
+		app := NewApp(new(MockAssetService), new(MockTaskService), new(MockSprintService))
+		app.storageDir = dir
+		app.configPath = filepath.Join(dir, "config.json")
+		return app
+	}
+	run := func(app *App, args ...string) (string, error) {
+		return captureOutput(func() error {
+			os.Args = append([]string{"assetcap", "workspace"}, args...)
+			return app.Run()
+		})
+	}
+
+	source := newApp(map[string]string{
+		"teams.json":      `{"PROJ": {"team": ["Ana", "Bruno"]}}`,
+		"credentials.env": "JIRA_TOKEN=secret\n",
+	})
+	archive := filepath.Join(t.TempDir(), "workspace.tar.gz")
+	output, err := run(source, "export", "--out", archive)
+	require.NoError(t, err)
+	assert.Contains(t, output, "Exported 1 file(s)")
+	assert.FileExists(t, archive)
+
+	t.Run("import into an empty workspace", func(t *testing.T) {
+		target := newApp(nil)
+		output, err := run(target, "import", "--in", archive)
+		require.NoError(t, err)
+		assert.Contains(t, output, "Written: teams.json")
+		assert.FileExists(t, filepath.Join(target.storageDir, "teams.json"))
+		assert.NoFileExists(t, filepath.Join(target.storageDir, "credentials.env"))
+	})
+
+	t.Run("existing files need merge or force", func(t *testing.T) {
+		target := newApp(map[string]string{"teams.json": `{"PROJ": {"team": ["Carla"]}}`})
+		_, err := run(target, "import", "--in", archive)
+		assert.EqualError(t, err, "workspace files already exist: teams.json: use --merge to combine the workspaces or --force to replace the local files")
+
+		_, err = run(target, "import", "--in", archive, "--merge", "--force")
+		assert.EqualError(t, err, "--merge and --force cannot be combined")
+
+		output, err := run(target, "import", "--in", archive, "--merge")
+		require.NoError(t, err)
+		assert.Contains(t, output, "Merged: teams.json")
+		teams, err := os.ReadFile(filepath.Join(target.storageDir, "teams.json"))
+		require.NoError(t, err)
+		assert.Contains(t, string(teams), "Carla")
+		assert.Contains(t, string(teams), "Bruno")
+	})
+
+	t.Run("archived hooks are printed when installed", func(t *testing.T) {
+		hooked := newApp(map[string]string{
+			"teams.json":  `{"PROJ": {"team": ["Ana"]}}`,
+			"config.json": `{"hooks": [{"event": "*", "webhook": "https://hooks.example.com/assetcap"}]}`,
+		})
+		hookedArchive := filepath.Join(t.TempDir(), "hooked.tar.gz")
+		_, err := run(hooked, "export", "--out", hookedArchive)
+		require.NoError(t, err)
+
+		output, err := run(newApp(nil), "import", "--in", hookedArchive)
+		require.NoError(t, err)
+		assert.Contains(t, output, "pass --hooks to install them")
+
+		output, err = run(newApp(nil), "import", "--in", hookedArchive, "--hooks")
+		require.NoError(t, err)
+		assert.Contains(t, output, "Installed hooks:\n  *: https://hooks.example.com/assetcap")
+	})
+
+	t.Run("missing archive", func(t *testing.T) {
+		_, err := run(newApp(nil), "import", "--in", filepath.Join(t.TempDir(), "missing.tar.gz"))
+		assert.ErrorContains(t, err, "failed to open")
+	})
+}
@@ -7,6 +7,8 @@ toolchain go1.24.1
 require (
 	github.com/stretchr/testify v1.10.0
 	github.com/urfave/cli/v2 v2.27.1
+	golang.org/x/net v0.38.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -16,6 +18,4 @@ require (
 	github.com/russross/blackfriday/v2 v2.1.0 // indirect
 	github.com/stretchr/objx v0.5.2 // indirect
 	github.com/xrash/smetrics v0.0.0-20201216005158-039620a65673 // indirect
-	golang.org/x/net v0.38.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"time"
 
 	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
 	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/infrastructure/confluence"
@@ -11,6 +12,8 @@ import (
 type LlamaClient interface {
 	// EnrichContent enriches the given content for the specified field
 	EnrichContent(content, field string, asset *domain.Asset) (string, error)
+	// Model returns the name of the model generating the content
+	Model() string
 	// Close closes the client connection
 	Close() error
 }
@@ -19,6 +22,16 @@ type LlamaClient interface {
 type ConfluenceAdapter interface {
 	// FetchPage fetches a page from Confluence
 	FetchPage(ctx context.Context, pageID string) (*confluence.Page, error)
+	// ConvertPage converts a fetched page into an asset using the page metadata
+	ConvertPage(page *confluence.Page) (*domain.Asset, error)
+	// AddLabel adds a label to a page
+	AddLabel(ctx context.Context, pageID, label string) error
+	// AttachmentText returns text extracted from a page's attachments, truncated to limit characters
+	AttachmentText(ctx context.Context, pageID string, limit int) (string, error)
+	// FindPagesByLabel returns the pages carrying a label
+	FindPagesByLabel(ctx context.Context, label string) ([]confluence.Page, error)
+	// DocLink returns the full URL of a page
+	DocLink(page *confluence.Page) string
 }
 
 // AssetService defines the interface for asset management operations
@@ -29,20 +42,73 @@ type AssetService interface {
 	ListAssets() ([]*domain.Asset, error)
 	// GetAsset returns an asset by name
 	GetAsset(identifier string) (*domain.Asset, error)
-	// DeleteAsset deletes an asset by name
-	DeleteAsset(name string) error
+	// GetTaskBreakdown counts the tasks linked to an asset by work type and by sprint
+	GetTaskBreakdown(identifier string) (*domain.TaskBreakdown, error)
+	// DeleteAsset deletes an asset by name, keeping it in the trash until it expires; an asset
+	// with associated tasks is only deleted when forced
+	DeleteAsset(name string, force bool) error
+	// PurgeAsset permanently deletes an asset, from the catalogue or the trash; an asset with
+	// associated tasks is only purged when forced
+	PurgeAsset(name string, force bool) error
+	// ArchiveAsset records the Archived status of an asset from the given date instead of
+	// deleting it; an asset with associated tasks is only archived when forced
+	ArchiveAsset(name string, date time.Time, force bool) (*domain.Asset, error)
+	// ListDeletedAssets returns the assets in the trash, most recently deleted first
+	ListDeletedAssets() ([]*domain.DeletedAsset, error)
+	// RestoreAsset moves a deleted asset back into the catalogue
+	RestoreAsset(name string) (*domain.Asset, error)
 	// UpdateAsset updates an asset's name and description
 	UpdateAsset(name, description, why, benefits, how, metrics string) error
 	// UpdateDocumentation marks the documentation for an asset as updated
 	UpdateDocumentation(assetName string) error
-	// IncrementTaskCount increments the task count for an asset
+	// IncrementTaskCount increments the stored task count for an asset.
+	// Deprecated: task counts are derived from task links.
 	IncrementTaskCount(name string) error
-	// DecrementTaskCount decrements the task count for an asset
+	// DecrementTaskCount decrements the stored task count for an asset.
+	// Deprecated: task counts are derived from task links.
 	DecrementTaskCount(name string) error
-	// SyncFromConfluence fetches assets from Confluence and updates the local repository
-	SyncFromConfluence(spaceKey, label string, debug bool) (*domain.SyncResult, error)
-	// EnrichAsset enriches a specific field of an asset using LLaMA 3
-	EnrichAsset(name, field string) error
+	// SyncFromConfluence fetches assets from one or more Confluence spaces and updates the
+	// local repository
+	SyncFromConfluence(input domain.SyncInput) (*domain.SyncResult, error)
+	// EnrichAsset enriches a specific field of an asset using LLaMA 3, optionally
+	// including text from the attachments of its Confluence page
+	EnrichAsset(name, field string, withAttachments bool) error
+	// ProposeEnrichment generates a new value for a field of an asset without saving it,
+	// reusing the value cached for the same page version and prompt unless force is set
+	ProposeEnrichment(name, field string, withAttachments, force bool) (*domain.EnrichmentProposal, error)
+	// ApplyEnrichment saves an approved proposal, recording the model that generated the field
+	ApplyEnrichment(proposal *domain.EnrichmentProposal) error
 	// GenerateKeywords generates keywords for an asset using LLaMA
 	GenerateKeywords(name string) error
+	// SummarizeActivity writes a one-paragraph summary of the work done on an asset using LLaMA
+	SummarizeActivity(name, activity string) (string, error)
+	// ImpairAsset records a write-down of an asset effective from the given date
+	ImpairAsset(name string, date time.Time, reason string, amount float64) error
+	// SetBugFixWindow counts bugs completed within days after the asset's launch as development,
+	// setting the launch date first when one is given. Zero days removes the window.
+	SetBugFixWindow(name string, days int, launchDate time.Time) error
+	// SetStatus records a status of an asset taking effect at the given date
+	SetStatus(name, status string, effective time.Time) error
+	// RetireAsset takes an asset out of service at the given date
+	RetireAsset(name string, date time.Time) (*domain.Asset, error)
+	// SetClassificationRules replaces the rules guiding the classification of the asset's
+	// linked tasks; empty rules remove them
+	SetClassificationRules(name string, rules domain.ClassificationRules) error
+	// SetOwner sets who is reminded of the asset's stale documentation; empty removes the owner
+	SetOwner(name, owner string) error
+	// SetAccounting sets the cost center and general ledger account the asset's capitalized
+	// cost is booked to; empty values remove them
+	SetAccounting(name, costCenter, glAccount string) error
+	// FindStaleDocumentation lists the assets whose documentation is older than the policy allows
+	FindStaleDocumentation(policy domain.FreshnessPolicy) ([]domain.StaleDocumentation, error)
+	// LinkDocumentation binds an asset to a Confluence page and back-fills its metadata
+	LinkDocumentation(name, docURL string) (*domain.Asset, error)
+	// PreviewLive compares an asset with the current metadata of its Confluence page,
+	// without saving anything
+	PreviewLive(name string) (*domain.LivePreview, error)
+	// ResolveDuplicate makes a Confluence page the primary documentation of its asset and marks
+	// the other pages carrying its asset label as superseded
+	ResolveDuplicate(primaryURL string) (*domain.DuplicateResolution, error)
+	// DiffAssets lists the catalogue changes since a date or between two snapshot files
+	DiffAssets(input domain.CatalogDiffInput) (*domain.CatalogDiff, error)
 }
@@ -3,6 +3,7 @@ package application
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -11,12 +12,14 @@ import (
 
 // MockAssetService is a mock implementation of AssetService for testing
 type MockAssetService struct {
-	assets map[string]*domain.Asset
+	assets  map[string]*domain.Asset
+	deleted map[string]*domain.Asset
 }
 
 func NewMockAssetService() *MockAssetService {
 	return &MockAssetService{
-		assets: make(map[string]*domain.Asset),
+		assets:  make(map[string]*domain.Asset),
+		deleted: make(map[string]*domain.Asset),
 	}
 }
 
@@ -46,14 +49,48 @@ func (m *MockAssetService) GetAsset(name string) (*domain.Asset, error) {
 	return nil, errors.New("asset not found")
 }
 
-func (m *MockAssetService) DeleteAsset(name string) error {
-	if _, exists := m.assets[name]; !exists {
+func (m *MockAssetService) DeleteAsset(name string, force bool) error {
+	asset, exists := m.assets[name]
+	if !exists {
+		return errors.New("asset not found")
+	}
+	if asset.GetTaskCount() > 0 && !force {
+		return domain.ErrAssetHasTasks
+	}
+	m.deleted[name] = m.assets[name]
+	delete(m.assets, name)
+	return nil
+}
+
+func (m *MockAssetService) PurgeAsset(name string, _ bool) error {
+	_, live := m.assets[name]
+	_, deleted := m.deleted[name]
+	if !live && !deleted {
 		return errors.New("asset not found")
 	}
 	delete(m.assets, name)
+	delete(m.deleted, name)
 	return nil
 }
 
+func (m *MockAssetService) ListDeletedAssets() ([]*domain.DeletedAsset, error) {
+	result := make([]*domain.DeletedAsset, 0, len(m.deleted))
+	for _, asset := range m.deleted {
+		result = append(result, &domain.DeletedAsset{Asset: asset})
+	}
+	return result, nil
+}
+
+func (m *MockAssetService) RestoreAsset(name string) (*domain.Asset, error) {
+	asset, exists := m.deleted[name]
+	if !exists {
+		return nil, errors.New("deleted asset not found")
+	}
+	m.assets[name] = asset
+	delete(m.deleted, name)
+	return asset, nil
+}
+
 func (m *MockAssetService) UpdateAsset(name, description, why, benefits, how, metrics string) error {
 	if _, exists := m.assets[name]; !exists {
 		return errors.New("asset not found")
@@ -92,7 +129,7 @@ func (m *MockAssetService) DecrementTaskCount(name string) error {
 	return errors.New("asset not found")
 }
 
-func (m *MockAssetService) SyncFromConfluence(_, _ string, _ bool) (*domain.SyncResult, error) {
+func (m *MockAssetService) SyncFromConfluence(_ domain.SyncInput) (*domain.SyncResult, error) {
 	// Mock implementation for testing
 	return &domain.SyncResult{
 		SyncedAssets:    []*domain.Asset{},
@@ -100,13 +137,145 @@ func (m *MockAssetService) SyncFromConfluence(_, _ string, _ bool) (*domain.Sync
 	}, nil
 }
 
-func (m *MockAssetService) EnrichAsset(name, _ string) error {
+func (m *MockAssetService) EnrichAsset(name, _ string, _ bool) error {
 	if _, exists := m.assets[name]; !exists {
 		return errors.New("asset not found")
 	}
 	return nil
 }
 
+func (m *MockAssetService) ProposeEnrichment(name, field string, _, _ bool) (*domain.EnrichmentProposal, error) {
+	asset, exists := m.assets[name]
+	if !exists {
+		return nil, errors.New("asset not found")
+	}
+	current, err := asset.FieldValue(field)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.EnrichmentProposal{Asset: name, Field: field, Current: current, Proposed: current, Model: "mock"}, nil
+}
+
+func (m *MockAssetService) ApplyEnrichment(proposal *domain.EnrichmentProposal) error {
+	asset, exists := m.assets[proposal.Asset]
+	if !exists {
+		return errors.New("asset not found")
+	}
+	return asset.ApplyGenerated(proposal.Field, proposal.Proposed, proposal.Model)
+}
+
+func (m *MockAssetService) SetClassificationRules(name string, rules domain.ClassificationRules) error {
+	asset, exists := m.assets[name]
+	if !exists {
+		return errors.New("asset not found")
+	}
+	asset.SetClassificationRules(rules)
+	return nil
+}
+
+func (m *MockAssetService) SetOwner(name, owner string) error {
+	asset, exists := m.assets[name]
+	if !exists {
+		return errors.New("asset not found")
+	}
+	asset.SetOwner(owner)
+	return nil
+}
+
+func (m *MockAssetService) SetAccounting(name, costCenter, glAccount string) error {
+	asset, exists := m.assets[name]
+	if !exists {
+		return errors.New("asset not found")
+	}
+	asset.SetAccounting(costCenter, glAccount)
+	return nil
+}
+
+func (m *MockAssetService) FindStaleDocumentation(policy domain.FreshnessPolicy) ([]domain.StaleDocumentation, error) {
+	assets := make([]*domain.Asset, 0, len(m.assets))
+	for _, asset := range m.assets {
+		assets = append(assets, asset)
+	}
+	return domain.FindStaleDocumentation(assets, policy, time.Now()), nil
+}
+
+func (m *MockAssetService) ImpairAsset(name string, date time.Time, reason string, amount float64) error {
+	asset, exists := m.assets[name]
+	if !exists {
+		return errors.New("asset not found")
+	}
+	impairment, err := domain.NewImpairment(date, reason, amount)
+	if err != nil {
+		return err
+	}
+	return asset.RecordImpairment(impairment)
+}
+
+func (m *MockAssetService) SetBugFixWindow(name string, days int, launchDate time.Time) error {
+	asset, exists := m.assets[name]
+	if !exists {
+		return errors.New("asset not found")
+	}
+	if !launchDate.IsZero() {
+		asset.LaunchDate = launchDate
+	}
+	return asset.SetBugFixWindow(days)
+}
+
+func (m *MockAssetService) RetireAsset(name string, date time.Time) (*domain.Asset, error) {
+	asset, exists := m.assets[name]
+	if !exists {
+		return nil, errors.New("asset not found")
+	}
+	return asset, asset.Retire(date)
+}
+
+func (m *MockAssetService) ArchiveAsset(name string, date time.Time, force bool) (*domain.Asset, error) {
+	asset, exists := m.assets[name]
+	if !exists {
+		return nil, errors.New("asset not found")
+	}
+	if asset.GetTaskCount() > 0 && !force {
+		return nil, domain.ErrAssetHasTasks
+	}
+	return asset, asset.Archive(date)
+}
+
+func (m *MockAssetService) SetStatus(name, status string, effective time.Time) error {
+	asset, exists := m.assets[name]
+	if !exists {
+		return errors.New("asset not found")
+	}
+	return asset.SetStatus(status, effective, domain.StatusSourceManual)
+}
+
+func (m *MockAssetService) PreviewLive(name string) (*domain.LivePreview, error) {
+	asset, exists := m.assets[name]
+	if !exists {
+		return nil, errors.New("asset not found")
+	}
+	preview := domain.CompareLive(asset, asset)
+	return &preview, nil
+}
+
+func (m *MockAssetService) LinkDocumentation(name, docURL string) (*domain.Asset, error) {
+	asset, exists := m.assets[name]
+	if !exists {
+		return nil, errors.New("asset not found")
+	}
+	asset.DocLink = docURL
+	return asset, nil
+}
+
+func (m *MockAssetService) DiffAssets(input domain.CatalogDiffInput) (*domain.CatalogDiff, error) {
+	current := make([]*domain.Asset, 0, len(m.assets))
+	for _, asset := range m.assets {
+		current = append(current, asset)
+	}
+	diff := domain.DiffCatalogs(nil, current)
+	return &diff, nil
+}
+
 func (m *MockAssetService) GenerateKeywords(name string) error {
 	if _, exists := m.assets[name]; !exists {
 		return errors.New("asset not found")
@@ -165,7 +334,7 @@ func TestAssetService(t *testing.T) {
 	})
 
 	t.Run("DeleteAsset", func(t *testing.T) {
-		err := service.DeleteAsset("test-asset")
+		err := service.DeleteAsset("test-asset", false)
 		assert.NoError(t, err)
 
 		// Verify asset is deleted
@@ -173,7 +342,7 @@ func TestAssetService(t *testing.T) {
 		assert.Error(t, err)
 
 		// Test deleting non-existent asset
-		err = service.DeleteAsset("non-existent")
+		err = service.DeleteAsset("non-existent", false)
 		assert.Error(t, err)
 		assert.Equal(t, "asset not found", err.Error())
 	})
@@ -214,7 +383,7 @@ func TestAssetService(t *testing.T) {
 	})
 
 	t.Run("SyncFromConfluence", func(t *testing.T) {
-		result, err := service.SyncFromConfluence("TEST", "test-label", false)
+		result, err := service.SyncFromConfluence(domain.SyncInput{Spaces: []string{"TEST"}, Label: "test-label"})
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		assert.Empty(t, result.SyncedAssets)
@@ -226,11 +395,11 @@ func TestAssetService(t *testing.T) {
 		service.CreateAsset("enrich-asset", "Test Description")
 
 		// Test successful enrichment
-		err := service.EnrichAsset("enrich-asset", "description")
+		err := service.EnrichAsset("enrich-asset", "description", false)
 		assert.NoError(t, err)
 
 		// Test non-existent asset
-		err = service.EnrichAsset("non-existent", "description")
+		err = service.EnrichAsset("non-existent", "description", false)
 		assert.Error(t, err)
 		assert.Equal(t, "asset not found", err.Error())
 	})
@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"os"
@@ -11,25 +12,37 @@ import (
 	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/common"
 	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
 	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain/ports"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/infrastructure"
 	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/infrastructure/confluence"
 	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/infrastructure/keywords"
 	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/infrastructure/llama"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/infrastructure/summary"
 )
 
+// ErrLLMDisabled is returned by LLM-backed operations when no LLM provider is configured
+var ErrLLMDisabled = errors.New("no LLM provider configured")
+
+// maxAttachmentContext caps the attachment text added to an enrichment prompt, in characters
+const maxAttachmentContext = 4000
+
 // AssetServiceImpl implements the AssetService interface
 type AssetServiceImpl struct {
 	repo       ports.AssetRepository
 	llama      LlamaClient
 	confluence ConfluenceAdapter
+	taskLinks  ports.TaskLinkPort
 }
 
 // NewAssetService creates a new AssetService instance
 func NewAssetService(repo ports.AssetRepository) AssetService {
 	llamaConfig := llama.DefaultConfig()
-	llamaClient, err := llama.NewClient(llamaConfig)
+	var llamaClient LlamaClient
+	client, err := llama.NewClient(llamaConfig)
 	if err != nil {
 		// Log the error but don't fail initialization
 		fmt.Printf("Warning: Failed to initialize LLaMA client: %v\n", err)
+	} else {
+		llamaClient = client
 	}
 
 	// Create Confluence adapter with default config
@@ -38,13 +51,24 @@ func NewAssetService(repo ports.AssetRepository) AssetService {
 	config.Token = os.Getenv("JIRA_TOKEN")
 	confluenceAdapter := confluence.NewAdapter(config)
 
+	return NewAssetServiceWithDependencies(repo, llamaClient, confluenceAdapter, nil)
+}
+
+// NewAssetServiceWithDependencies creates a new AssetService using the given collaborators.
+// A nil LLaMA client disables enrichment and keyword generation, and without task links
+// the stored task counters are reported as is.
+func NewAssetServiceWithDependencies(repo ports.AssetRepository, llamaClient LlamaClient, confluenceAdapter ConfluenceAdapter, taskLinks ports.TaskLinkPort) AssetService {
 	return &AssetServiceImpl{
 		repo:       repo,
 		llama:      llamaClient,
 		confluence: confluenceAdapter,
+		taskLinks:  taskLinks,
 	}
 }
 
+// Ensure AssetServiceImpl implements AssetService
+var _ AssetService = (*AssetServiceImpl)(nil)
+
 // CreateAsset creates a new asset with the given name and description
 func (s *AssetServiceImpl) CreateAsset(name, description string) error {
 	// Check if asset already exists by name
@@ -78,30 +102,149 @@ func (s *AssetServiceImpl) CreateAsset(name, description string) error {
 
 // ListAssets returns all assets in the repository
 func (s *AssetServiceImpl) ListAssets() ([]*domain.Asset, error) {
-	return s.repo.FindAll()
+	assets, err := s.repo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, asset := range assets {
+		if err := s.deriveTaskCount(asset); err != nil {
+			return nil, err
+		}
+	}
+	return assets, nil
 }
 
 // GetAsset returns an asset by name or ID
 func (s *AssetServiceImpl) GetAsset(identifier string) (*domain.Asset, error) {
 	// First try to find by name
 	asset, err := s.repo.FindByName(identifier)
-	if err == nil {
-		return asset, nil
+	if err != nil {
+		// If not found by name, try to find by ID
+		asset, err = s.repo.FindByID(identifier)
+		if err != nil {
+			return nil, fmt.Errorf("asset not found by name or ID: %s", identifier)
+		}
 	}
 
-	// If not found by name, try to find by ID
-	asset, err = s.repo.FindByID(identifier)
-	if err != nil {
-		return nil, fmt.Errorf("asset not found by name or ID: %s", identifier)
+	if err := s.deriveTaskCount(asset); err != nil {
+		return nil, err
 	}
 	return asset, nil
 }
 
-// DeleteAsset deletes an asset by name
-func (s *AssetServiceImpl) DeleteAsset(name string) error {
+// deriveTaskCount replaces the stored task counter with the number of linked tasks
+func (s *AssetServiceImpl) deriveTaskCount(asset *domain.Asset) error {
+	if s.taskLinks == nil {
+		return nil
+	}
+	count, err := s.taskLinks.CountLinkedTasks(asset.TaskLinkKey())
+	if err != nil {
+		return fmt.Errorf("failed to count tasks for asset %s: %w", asset.Name, err)
+	}
+	return asset.SetTaskCount(count)
+}
+
+// GetTaskBreakdown counts the tasks linked to an asset by work type and by sprint
+func (s *AssetServiceImpl) GetTaskBreakdown(identifier string) (*domain.TaskBreakdown, error) {
+	asset, err := s.GetAsset(identifier)
+	if err != nil {
+		return nil, err
+	}
+	if s.taskLinks == nil {
+		return domain.NewTaskBreakdown(asset.Name, nil), nil
+	}
+	tasks, err := s.taskLinks.FindLinkedTasks(asset.TaskLinkKey())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find tasks for asset %s: %w", asset.Name, err)
+	}
+	return domain.NewTaskBreakdown(asset.Name, tasks), nil
+}
+
+// DeleteAsset deletes an asset by name, keeping it in the trash until it expires. An asset
+// with associated tasks is only deleted when forced.
+func (s *AssetServiceImpl) DeleteAsset(name string, force bool) error {
+	if err := s.ensureUnlinked(name, force); err != nil {
+		return err
+	}
 	return s.repo.Delete(name)
 }
 
+// PurgeAsset permanently deletes an asset, from the catalogue or the trash. An asset in the
+// catalogue with associated tasks is only purged when forced.
+func (s *AssetServiceImpl) PurgeAsset(name string, force bool) error {
+	if err := s.ensureUnlinked(name, force); err != nil {
+		return err
+	}
+	trash, ok := s.repo.(ports.AssetTrash)
+	if !ok {
+		// Without a trash, deleting is already permanent
+		return s.repo.Delete(name)
+	}
+	return trash.Purge(name)
+}
+
+// ArchiveAsset records the Archived status of an asset from the given date, keeping it in the
+// catalogue. An asset with associated tasks is only archived when forced.
+func (s *AssetServiceImpl) ArchiveAsset(name string, date time.Time, force bool) (*domain.Asset, error) {
+	asset, err := s.GetAsset(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get asset: %w", err)
+	}
+	if count := asset.GetTaskCount(); count > 0 && !force {
+		return nil, fmt.Errorf("%w: %s is linked to %d tasks", domain.ErrAssetHasTasks, asset.Name, count)
+	}
+
+	if err := asset.Archive(date); err != nil {
+		return nil, fmt.Errorf("cannot archive asset: %w", err)
+	}
+
+	if err := s.repo.Save(asset); err != nil {
+		return nil, fmt.Errorf("failed to save asset: %w", err)
+	}
+	return asset, nil
+}
+
+// ensureUnlinked refuses to remove a catalogued asset that still has associated tasks, unless
+// forced. Assets missing from the catalogue are left to the repository to report.
+func (s *AssetServiceImpl) ensureUnlinked(name string, force bool) error {
+	if force {
+		return nil
+	}
+	asset, err := s.repo.FindByName(name)
+	if err != nil {
+		return nil
+	}
+	if err := s.deriveTaskCount(asset); err != nil {
+		return err
+	}
+	if count := asset.GetTaskCount(); count > 0 {
+		return fmt.Errorf("%w: %s is linked to %d tasks", domain.ErrAssetHasTasks, asset.Name, count)
+	}
+	return nil
+}
+
+// ListDeletedAssets returns the assets in the trash, most recently deleted first
+func (s *AssetServiceImpl) ListDeletedAssets() ([]*domain.DeletedAsset, error) {
+	trash, ok := s.repo.(ports.AssetTrash)
+	if !ok {
+		return nil, fmt.Errorf("the asset repository does not keep deleted assets")
+	}
+	return trash.FindDeleted()
+}
+
+// RestoreAsset moves a deleted asset back into the catalogue
+func (s *AssetServiceImpl) RestoreAsset(name string) (*domain.Asset, error) {
+	trash, ok := s.repo.(ports.AssetTrash)
+	if !ok {
+		return nil, fmt.Errorf("the asset repository does not keep deleted assets")
+	}
+	asset, err := trash.Restore(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore asset: %w", err)
+	}
+	return asset, nil
+}
+
 // UpdateAsset updates an asset's description
 func (s *AssetServiceImpl) UpdateAsset(name, description, why, benefits, how, metrics string) error {
 	if description == "" {
@@ -133,7 +276,9 @@ func (s *AssetServiceImpl) UpdateDocumentation(assetName string) error {
 	return s.repo.Save(asset)
 }
 
-// IncrementTaskCount increments the task count for an asset
+// IncrementTaskCount increments the task count for an asset.
+//
+// Deprecated: task counts are derived from the task links when those are configured.
 func (s *AssetServiceImpl) IncrementTaskCount(name string) error {
 	asset, err := s.repo.FindByName(name)
 	if err != nil {
@@ -145,7 +290,9 @@ func (s *AssetServiceImpl) IncrementTaskCount(name string) error {
 	return s.repo.Save(asset)
 }
 
-// DecrementTaskCount decrements the task count for an asset
+// DecrementTaskCount decrements the task count for an asset.
+//
+// Deprecated: task counts are derived from the task links when those are configured.
 func (s *AssetServiceImpl) DecrementTaskCount(name string) error {
 	asset, err := s.repo.FindByName(name)
 	if err != nil {
@@ -160,37 +307,71 @@ func (s *AssetServiceImpl) DecrementTaskCount(name string) error {
 	return fmt.Errorf("task count cannot be negative")
 }
 
-// SyncFromConfluence fetches assets from Confluence and updates the local repository
-func (s *AssetServiceImpl) SyncFromConfluence(spaceKey, label string, debug bool) (*domain.SyncResult, error) {
-	config := confluence.DefaultConfig()
-
-	// Get configuration from environment variables
-	config.BaseURL = os.Getenv("JIRA_BASE_URL")
-	config.SpaceKey = spaceKey
-	config.Label = label
-	config.Token = os.Getenv("JIRA_TOKEN")
-	config.Debug = debug
-
-	if config.BaseURL == "" {
+// SyncFromConfluence fetches assets from the Confluence spaces and updates the local
+// repository. Every space is fetched before anything is saved, so pages of different spaces
+// claiming the same asset identifier are reported as duplicates.
+func (s *AssetServiceImpl) SyncFromConfluence(input domain.SyncInput) (*domain.SyncResult, error) {
+	baseURL := os.Getenv("JIRA_BASE_URL")
+	token := os.Getenv("JIRA_TOKEN")
+	if baseURL == "" {
 		return nil, fmt.Errorf("JIRA_BASE_URL environment variable must be set")
 	}
-	if config.Token == "" {
+	if token == "" {
 		return nil, fmt.Errorf("JIRA_TOKEN environment variable must be set")
 	}
+	if len(input.Spaces) == 0 {
+		return nil, fmt.Errorf("at least one Confluence space is required")
+	}
 
-	adapter := confluence.NewAdapter(config)
-	assets, err := adapter.FetchAssets(context.Background())
-	if err != nil {
-		if strings.Contains(err.Error(), "no assets found with label") {
-			return nil, err
+	result := domain.NewSyncResult()
+	var assets []*domain.Asset
+	spaceOf := make(map[*domain.Asset]*domain.SpaceSync)
+	var empty []error
+	for _, space := range input.Spaces {
+		config := confluence.DefaultConfig()
+		config.BaseURL = baseURL
+		config.SpaceKey = space
+		config.Label = input.Label
+		config.Token = token
+		config.Debug = input.Debug
+
+		summary := &domain.SpaceSync{Space: space}
+		fetched, err := confluence.NewAdapter(config).FetchAssets(context.Background())
+		if err != nil {
+			if !strings.Contains(err.Error(), "no assets found with label") {
+				return nil, fmt.Errorf("failed to fetch assets from Confluence space %s: %v", space, err)
+			}
+			empty = append(empty, err)
+		}
+		summary.Fetched = len(fetched)
+		for _, asset := range fetched {
+			spaceOf[asset] = summary
+		}
+		assets = append(assets, fetched...)
+		result.Spaces = append(result.Spaces, summary)
+		if input.Progress != nil {
+			input.Progress(*summary)
 		}
-		return nil, fmt.Errorf("failed to fetch assets from Confluence: %v", err)
+	}
+	if len(empty) == len(input.Spaces) {
+		return nil, errors.Join(empty...)
 	}
 
-	result := domain.NewSyncResult()
+	// Pages sharing an asset identifier leave the asset untouched until one is chosen
+	var unique []*domain.Asset
+	unique, result.Duplicates = domain.SeparateDuplicates(assets)
+	isUnique := make(map[*domain.Asset]bool, len(unique))
+	for _, asset := range unique {
+		isUnique[asset] = true
+	}
+	for _, asset := range assets {
+		if !isUnique[asset] {
+			spaceOf[asset].Duplicates++
+		}
+	}
 
 	// Update local repository with fetched assets
-	for _, asset := range assets {
+	for _, asset := range unique {
 		missingFields := validateRequiredFields(asset)
 		if len(missingFields) > 0 {
 			notSynced := &domain.NotSyncedAsset{
@@ -210,70 +391,206 @@ func (s *AssetServiceImpl) SyncFromConfluence(spaceKey, label string, debug bool
 				},
 			}
 			result.NotSyncedAssets = append(result.NotSyncedAssets, notSynced)
+			spaceOf[asset].NotSynced++
 			continue
 		}
 
+		// Keep the local name, write-downs, classification rules, owner, documentation stamp,
+		// status history and unchanged generated fields of assets linked with link-doc
+		if existing, err := s.repo.FindByID(asset.ID); err == nil {
+			asset.Name = existing.Name
+			asset.Impairments = existing.Impairments
+			asset.KeepGeneratedFields(existing)
+			asset.ClassificationRules = existing.ClassificationRules
+			asset.Owner = existing.Owner
+			if existing.LastDocUpdateAt.After(asset.LastDocUpdateAt) {
+				asset.LastDocUpdateAt = existing.LastDocUpdateAt
+			}
+			if err := keepStatusHistory(asset, existing); err != nil {
+				return nil, fmt.Errorf("failed to record status of asset %s: %w", asset.Name, err)
+			}
+		}
+
 		if err := s.repo.Save(asset); err != nil {
 			return nil, fmt.Errorf("failed to save asset %s: %v", asset.Name, err)
 		}
 		result.SyncedAssets = append(result.SyncedAssets, asset)
+		spaceOf[asset].Synced++
 	}
 
 	return result, nil
 }
 
-// EnrichAsset enriches a specific field of an asset using LLaMA 3
-func (s *AssetServiceImpl) EnrichAsset(name, field string) error {
-	// Get the asset
+// EnrichAsset enriches a specific field of an asset using LLaMA 3, optionally
+// including text from the attachments of its Confluence page, and saves it without review
+func (s *AssetServiceImpl) EnrichAsset(name, field string, withAttachments bool) error {
+	proposal, err := s.ProposeEnrichment(name, field, withAttachments, false)
+	if err != nil {
+		return err
+	}
+	return s.ApplyEnrichment(proposal)
+}
+
+// ProposeEnrichment generates a new value for a field of an asset using LLaMA 3, optionally
+// including text from the attachments of its Confluence page, without saving it. Values
+// generated for the same page version and prompt are reused unless force is set.
+func (s *AssetServiceImpl) ProposeEnrichment(name, field string, withAttachments, force bool) (*domain.EnrichmentProposal, error) {
 	asset, err := s.GetAsset(name)
 	if err != nil {
-		return fmt.Errorf("failed to get asset: %w", err)
+		return nil, fmt.Errorf("failed to get asset: %w", err)
 	}
 
-	// Get the content to enrich based on the field
-	var content string
-	switch field {
-	case "description":
-		content = asset.Description
-	case "why":
-		content = asset.Why
-	case "benefits":
-		content = asset.Benefits
-	case "how":
-		content = asset.How
-	case "metrics":
-		content = asset.Metrics
-	default:
-		return fmt.Errorf("failed to enrich content: unsupported field for enrichment: %s", field)
+	current, err := asset.FieldValue(field)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enrich content: %w", err)
+	}
+
+	if s.llama == nil {
+		return nil, fmt.Errorf("failed to enrich content: %w", ErrLLMDisabled)
+	}
+
+	content := current
+	if withAttachments {
+		attachments, err := s.attachmentContext(asset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to enrich content: %w", err)
+		}
+		if attachments != "" {
+			content = fmt.Sprintf("%s\n\nContent from page attachments:\n%s", content, attachments)
+		}
+	}
+
+	proposal := &domain.EnrichmentProposal{
+		Asset:   asset.Name,
+		Field:   field,
+		Current: current,
+		Model:   s.llama.Model(),
 	}
 
-	// Enrich the content
-	enrichedContent, err := s.llama.EnrichContent(content, field, asset)
+	cache, key, cacheable := s.enrichmentCache(asset, field, content)
+	if cacheable && !force {
+		cached, err := cache.FindEnrichment(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read enrichment cache: %w", err)
+		}
+		if cached != nil {
+			proposal.Proposed = cached.Value
+			proposal.Model = cached.Model
+			proposal.Cached = true
+			return proposal, nil
+		}
+	}
+
+	proposal.Proposed, err = s.llama.EnrichContent(content, field, asset)
 	if err != nil {
-		return fmt.Errorf("failed to enrich content: %w", err)
+		return nil, fmt.Errorf("failed to enrich content: %w", err)
 	}
 
-	// Update the asset with the enriched content
-	switch field {
-	case "description":
-		asset.Description = enrichedContent
-	case "why":
-		asset.Why = enrichedContent
-	case "benefits":
-		asset.Benefits = enrichedContent
-	case "how":
-		asset.How = enrichedContent
-	case "metrics":
-		asset.Metrics = enrichedContent
+	if cacheable {
+		entry := domain.CachedEnrichment{Key: key, Value: proposal.Proposed, Model: proposal.Model, CachedAt: time.Now().UTC()}
+		if err := cache.SaveEnrichment(entry); err != nil {
+			return nil, fmt.Errorf("failed to write enrichment cache: %w", err)
+		}
 	}
+	return proposal, nil
+}
 
-	asset.UpdatedAt = time.Now()
-	asset.Version++
+// enrichmentCache returns the cache of generated values and the key of the field's prompt.
+// Only assets synced from a Confluence page are cached, since the page version tells when
+// the documentation changed.
+func (s *AssetServiceImpl) enrichmentCache(asset *domain.Asset, field, content string) (ports.EnrichmentCache, domain.EnrichmentCacheKey, bool) {
+	cache, ok := s.repo.(ports.EnrichmentCache)
+	if !ok {
+		return nil, domain.EnrichmentCacheKey{}, false
+	}
+	pageID := extractPageIDFromDocLink(asset.DocLink)
+	if pageID == "" || asset.DocPageVersion == 0 {
+		return nil, domain.EnrichmentCacheKey{}, false
+	}
+	return cache, domain.EnrichmentCacheKey{
+		PageID:      pageID,
+		PageVersion: asset.DocPageVersion,
+		Field:       field,
+		PromptHash:  asset.EnrichmentPromptHash(s.llama.Model(), field, content),
+	}, true
+}
 
-	// Save the updated asset
+// ApplyEnrichment saves an approved proposal, recording the model that generated the field.
+// It fails when the field changed since the proposal was made.
+func (s *AssetServiceImpl) ApplyEnrichment(proposal *domain.EnrichmentProposal) error {
+	asset, err := s.GetAsset(proposal.Asset)
+	if err != nil {
+		return fmt.Errorf("failed to get asset: %w", err)
+	}
+
+	current, err := asset.FieldValue(proposal.Field)
+	if err != nil {
+		return fmt.Errorf("failed to apply enrichment: %w", err)
+	}
+	if current != proposal.Current {
+		return fmt.Errorf("failed to apply enrichment to %s of %s: %w", proposal.Field, proposal.Asset, domain.ErrEnrichmentStale)
+	}
+
+	if err := asset.ApplyGenerated(proposal.Field, proposal.Proposed, proposal.Model); err != nil {
+		return fmt.Errorf("failed to apply enrichment: %w", err)
+	}
 	return s.repo.Save(asset)
 }
 
+// SetClassificationRules replaces the rules guiding the classification of the asset's linked
+// tasks; empty rules remove them
+func (s *AssetServiceImpl) SetClassificationRules(name string, rules domain.ClassificationRules) error {
+	asset, err := s.GetAsset(name)
+	if err != nil {
+		return fmt.Errorf("failed to get asset: %w", err)
+	}
+	asset.SetClassificationRules(rules)
+	if err := s.repo.Save(asset); err != nil {
+		return fmt.Errorf("failed to save asset %s: %w", asset.Name, err)
+	}
+	return nil
+}
+
+// SetOwner sets who is reminded of the asset's stale documentation; empty removes the owner
+func (s *AssetServiceImpl) SetOwner(name, owner string) error {
+	asset, err := s.GetAsset(name)
+	if err != nil {
+		return fmt.Errorf("failed to get asset: %w", err)
+	}
+	asset.SetOwner(owner)
+	if err := s.repo.Save(asset); err != nil {
+		return fmt.Errorf("failed to save asset %s: %w", asset.Name, err)
+	}
+	return nil
+}
+
+// SetAccounting sets the cost center and general ledger account the asset's capitalized cost is
+// booked to; empty values remove them
+func (s *AssetServiceImpl) SetAccounting(name, costCenter, glAccount string) error {
+	asset, err := s.GetAsset(name)
+	if err != nil {
+		return fmt.Errorf("failed to get asset: %w", err)
+	}
+	asset.SetAccounting(costCenter, glAccount)
+	if err := s.repo.Save(asset); err != nil {
+		return fmt.Errorf("failed to save asset %s: %w", asset.Name, err)
+	}
+	return nil
+}
+
+// FindStaleDocumentation lists the assets whose documentation is older than the policy allows,
+// the most overdue first
+func (s *AssetServiceImpl) FindStaleDocumentation(policy domain.FreshnessPolicy) ([]domain.StaleDocumentation, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	assets, err := s.repo.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assets: %w", err)
+	}
+	return domain.FindStaleDocumentation(assets, policy, time.Now()), nil
+}
+
 // GenerateKeywords generates keywords for an asset using LLaMA
 func (s *AssetServiceImpl) GenerateKeywords(name string) error {
 	// Get the asset
@@ -282,6 +599,10 @@ func (s *AssetServiceImpl) GenerateKeywords(name string) error {
 		return fmt.Errorf("failed to get asset: %w", err)
 	}
 
+	if s.llama == nil {
+		return fmt.Errorf("failed to generate keywords: %w", ErrLLMDisabled)
+	}
+
 	// Create keyword generator
 	generator := keywords.NewGenerator(s.llama)
 
@@ -303,6 +624,295 @@ func (s *AssetServiceImpl) GenerateKeywords(name string) error {
 	return nil
 }
 
+// SummarizeActivity writes a one-paragraph summary of the work done on an asset using LLaMA
+func (s *AssetServiceImpl) SummarizeActivity(name, activity string) (string, error) {
+	asset, err := s.GetAsset(name)
+	if err != nil {
+		return "", fmt.Errorf("failed to get asset: %w", err)
+	}
+
+	if s.llama == nil {
+		return "", fmt.Errorf("failed to summarize activity: %w", ErrLLMDisabled)
+	}
+
+	return summary.NewGenerator(s.llama).GenerateActivitySummary(asset, activity)
+}
+
+// ImpairAsset records a write-down of an asset effective from the given date
+func (s *AssetServiceImpl) ImpairAsset(name string, date time.Time, reason string, amount float64) error {
+	asset, err := s.GetAsset(name)
+	if err != nil {
+		return fmt.Errorf("failed to get asset: %w", err)
+	}
+
+	impairment, err := domain.NewImpairment(date, reason, amount)
+	if err != nil {
+		return fmt.Errorf("invalid impairment: %w", err)
+	}
+
+	if err := asset.RecordImpairment(impairment); err != nil {
+		return fmt.Errorf("failed to record impairment: %w", err)
+	}
+
+	if err := s.repo.Save(asset); err != nil {
+		return fmt.Errorf("failed to save asset: %w", err)
+	}
+	return nil
+}
+
+// SetBugFixWindow counts bugs completed within days after the asset's launch as development,
+// setting the launch date first when one is given. Zero days removes the window.
+func (s *AssetServiceImpl) SetBugFixWindow(name string, days int, launchDate time.Time) error {
+	asset, err := s.GetAsset(name)
+	if err != nil {
+		return fmt.Errorf("failed to get asset: %w", err)
+	}
+
+	if !launchDate.IsZero() {
+		asset.LaunchDate = launchDate
+	}
+	if err := asset.SetBugFixWindow(days); err != nil {
+		return fmt.Errorf("invalid bug-fix window: %w", err)
+	}
+
+	if err := s.repo.Save(asset); err != nil {
+		return fmt.Errorf("failed to save asset: %w", err)
+	}
+	return nil
+}
+
+// SetStatus records a status of an asset taking effect at the given date, so reports of
+// earlier periods keep the status the asset had then
+func (s *AssetServiceImpl) SetStatus(name, status string, effective time.Time) error {
+	asset, err := s.GetAsset(name)
+	if err != nil {
+		return fmt.Errorf("failed to get asset: %w", err)
+	}
+
+	if err := asset.SetStatus(status, effective, domain.StatusSourceManual); err != nil {
+		return fmt.Errorf("invalid status: %w", err)
+	}
+
+	if err := s.repo.Save(asset); err != nil {
+		return fmt.Errorf("failed to save asset: %w", err)
+	}
+	return nil
+}
+
+// RetireAsset takes an asset out of service at the given date, recording the Retired status
+// from that date, and returns the retired asset
+func (s *AssetServiceImpl) RetireAsset(name string, date time.Time) (*domain.Asset, error) {
+	asset, err := s.GetAsset(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get asset: %w", err)
+	}
+
+	if err := asset.Retire(date); err != nil {
+		return nil, fmt.Errorf("cannot retire asset: %w", err)
+	}
+
+	if err := s.repo.Save(asset); err != nil {
+		return nil, fmt.Errorf("failed to save asset: %w", err)
+	}
+	return asset, nil
+}
+
+// LinkDocumentation binds an asset to a Confluence page. It validates the page, makes sure
+// the page carries the asset label, sets the DocLink and back-fills empty fields from the page.
+func (s *AssetServiceImpl) LinkDocumentation(name, docURL string) (*domain.Asset, error) {
+	asset, err := s.GetAsset(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get asset: %w", err)
+	}
+
+	if s.confluence == nil {
+		return nil, fmt.Errorf("confluence integration is not configured")
+	}
+
+	pageID := extractPageIDFromDocLink(docURL)
+	if pageID == "" {
+		return nil, fmt.Errorf("invalid Confluence page URL: %s", docURL)
+	}
+
+	ctx := context.Background()
+	page, err := s.confluence.FetchPage(ctx, pageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Confluence page: %w", err)
+	}
+
+	label := page.AssetLabel()
+	if label == "" {
+		label = assetLabel(asset.Name)
+		if err := s.confluence.AddLabel(ctx, pageID, label); err != nil {
+			return nil, fmt.Errorf("failed to label Confluence page: %w", err)
+		}
+	}
+
+	linked, err := s.confluence.ConvertPage(page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Confluence page: %w", err)
+	}
+
+	backfillFromPage(asset, linked)
+	now := time.Now()
+	asset.ID = label
+	asset.DocLink = linked.DocLink
+	asset.UpdatedAt = now
+	asset.LastDocUpdateAt = now
+	asset.Version++
+
+	if err := s.repo.Save(asset); err != nil {
+		return nil, fmt.Errorf("failed to save asset: %w", err)
+	}
+	return asset, nil
+}
+
+// PreviewLive fetches the Confluence page linked to an asset and compares the metadata
+// extracted from it with the local values. The local asset is left untouched.
+func (s *AssetServiceImpl) PreviewLive(name string) (*domain.LivePreview, error) {
+	asset, err := s.GetAsset(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get asset: %w", err)
+	}
+
+	if s.confluence == nil {
+		return nil, fmt.Errorf("confluence integration is not configured")
+	}
+
+	pageID := extractPageIDFromDocLink(asset.DocLink)
+	if pageID == "" {
+		return nil, fmt.Errorf("asset %s is not linked to a Confluence page; link one with assets link-doc", asset.Name)
+	}
+
+	page, err := s.confluence.FetchPage(context.Background(), pageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Confluence page: %w", err)
+	}
+	live, err := s.confluence.ConvertPage(page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Confluence page: %w", err)
+	}
+
+	preview := domain.CompareLive(asset, live)
+	return &preview, nil
+}
+
+// ResolveDuplicate makes a page the primary documentation of its asset. Every other page
+// carrying the same asset label gets the superseded label, which sync skips, and the local
+// asset is linked to the primary page.
+func (s *AssetServiceImpl) ResolveDuplicate(primaryURL string) (*domain.DuplicateResolution, error) {
+	if s.confluence == nil {
+		return nil, fmt.Errorf("confluence integration is not configured")
+	}
+
+	pageID := extractPageIDFromDocLink(primaryURL)
+	if pageID == "" {
+		return nil, fmt.Errorf("invalid Confluence page URL: %s", primaryURL)
+	}
+
+	ctx := context.Background()
+	primary, err := s.confluence.FetchPage(ctx, pageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Confluence page: %w", err)
+	}
+	label := primary.AssetLabel()
+	if label == "" {
+		return nil, fmt.Errorf("page %s carries no asset label; link it to an asset with 'assets link-doc'", primaryURL)
+	}
+	if primary.IsSuperseded() {
+		return nil, fmt.Errorf("page %s is marked as superseded; remove its %s label in Confluence first", primaryURL, confluence.SupersededLabel)
+	}
+
+	pages, err := s.confluence.FindPagesByLabel(ctx, label)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the pages labelled %s: %w", label, err)
+	}
+
+	resolution := &domain.DuplicateResolution{ID: label, Primary: s.confluence.DocLink(primary), Superseded: []string{}}
+	for i := range pages {
+		page := &pages[i]
+		if page.ID == primary.ID || page.IsSuperseded() {
+			continue
+		}
+		if err := s.confluence.AddLabel(ctx, page.ID, confluence.SupersededLabel); err != nil {
+			return nil, fmt.Errorf("failed to mark page %s as superseded: %w", page.Title, err)
+		}
+		resolution.Superseded = append(resolution.Superseded, s.confluence.DocLink(page))
+	}
+
+	if asset, err := s.repo.FindByID(label); err == nil {
+		if asset.DocLink != resolution.Primary {
+			asset.DocLink = resolution.Primary
+			asset.UpdatedAt = time.Now()
+			asset.Version++
+			if err := s.repo.Save(asset); err != nil {
+				return nil, fmt.Errorf("failed to save asset: %w", err)
+			}
+		}
+		resolution.Asset = asset
+	}
+	return resolution, nil
+}
+
+// assetLabel builds the Confluence asset label for an asset name
+func assetLabel(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteRune('-')
+			dash = true
+		}
+	}
+	return confluence.AssetLabelPrefix + strings.TrimSuffix(b.String(), "-")
+}
+
+// keepStatusHistory carries over the status history of the stored asset, recording the
+// status of the page as a change when it differs. The change takes effect when the page was
+// last edited, or now when the page does not tell.
+func keepStatusHistory(asset, existing *domain.Asset) error {
+	status := asset.Status
+	asset.Status = existing.Status
+	asset.StatusHistory = existing.StatusHistory
+	if status == existing.Status {
+		return nil
+	}
+
+	effective := asset.DocPageUpdatedAt
+	if effective.IsZero() || effective.After(time.Now()) {
+		effective = time.Now()
+	}
+	return asset.SetStatus(status, effective, domain.StatusSourceSync)
+}
+
+// backfillFromPage copies the page metadata into the fields the asset does not define yet
+func backfillFromPage(asset, page *domain.Asset) {
+	fill := func(field *string, value string) {
+		if *field == "" {
+			*field = value
+		}
+	}
+	fill(&asset.Description, page.Description)
+	fill(&asset.Why, page.Why)
+	fill(&asset.Benefits, page.Benefits)
+	fill(&asset.How, page.How)
+	fill(&asset.Metrics, page.Metrics)
+	fill(&asset.Platform, page.Platform)
+	fill(&asset.Status, page.Status)
+	if asset.LaunchDate.IsZero() {
+		asset.LaunchDate = page.LaunchDate
+	}
+	if !asset.IsRolledOut100 {
+		asset.IsRolledOut100 = page.IsRolledOut100
+	}
+	if len(asset.Keywords) == 0 {
+		asset.Keywords = page.Keywords
+	}
+}
+
 // Helper function to validate required fields
 func validateRequiredFields(asset *domain.Asset) []string {
 	var missingFields []string
@@ -329,6 +939,24 @@ func validateRequiredFields(asset *domain.Asset) []string {
 	return missingFields
 }
 
+// attachmentContext returns the text of the attachments on the asset's Confluence page
+func (s *AssetServiceImpl) attachmentContext(asset *domain.Asset) (string, error) {
+	if s.confluence == nil {
+		return "", fmt.Errorf("confluence integration is not configured")
+	}
+
+	pageID := extractPageIDFromDocLink(asset.DocLink)
+	if pageID == "" {
+		return "", fmt.Errorf("asset %s has no Confluence page; link one with 'assets link-doc'", asset.Name)
+	}
+
+	text, err := s.confluence.AttachmentText(context.Background(), pageID, maxAttachmentContext)
+	if err != nil {
+		return "", fmt.Errorf("failed to read page attachments: %w", err)
+	}
+	return text, nil
+}
+
 // Helper function to extract page ID from Confluence doc link
 func extractPageIDFromDocLink(docLink string) string {
 	parsedURL, err := url.Parse(docLink)
@@ -346,3 +974,54 @@ func extractPageIDFromDocLink(docLink string) string {
 
 	return ""
 }
+
+// DiffAssets compares the catalogue with its state at the start of a day, or compares two
+// catalogue snapshot files
+func (s *AssetServiceImpl) DiffAssets(input domain.CatalogDiffInput) (*domain.CatalogDiff, error) {
+	if input.From != "" && !input.Since.IsZero() {
+		return nil, fmt.Errorf("compare either since a date or from a snapshot file, not both")
+	}
+	if input.To != "" && input.From == "" {
+		return nil, fmt.Errorf("a snapshot file to compare from is required when comparing to one")
+	}
+
+	var before []*domain.Asset
+	var reference string
+	var err error
+	switch {
+	case input.From != "":
+		if before, err = infrastructure.ReadCatalogFile(input.From); err != nil {
+			return nil, err
+		}
+		reference = input.From
+	case !input.Since.IsZero():
+		history, ok := s.repo.(ports.AssetHistory)
+		if !ok {
+			return nil, fmt.Errorf("the asset repository does not keep a catalogue history")
+		}
+		var snapshotDate time.Time
+		if before, snapshotDate, err = history.CatalogAt(input.Since); err != nil {
+			return nil, fmt.Errorf("failed to load catalogue history: %w", err)
+		}
+		reference = "catalogue on " + input.Since.Format("2006-01-02")
+		if !snapshotDate.IsZero() {
+			reference += " (snapshot of " + snapshotDate.Format("2006-01-02") + ")"
+		}
+	default:
+		return nil, fmt.Errorf("a date or a snapshot file to compare with is required")
+	}
+
+	var after []*domain.Asset
+	if input.To != "" {
+		after, err = infrastructure.ReadCatalogFile(input.To)
+	} else {
+		after, err = s.repo.FindAll()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load catalogue: %w", err)
+	}
+
+	diff := domain.DiffCatalogs(before, after)
+	diff.Reference = reference
+	return &diff, nil
+}
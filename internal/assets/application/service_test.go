@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -65,6 +68,10 @@ func (m *MockLlamaClient) EnrichContent(content, field string, asset *domain.Ass
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockLlamaClient) Model() string {
+	return "llama3"
+}
+
 func (m *MockLlamaClient) Close() error {
 	args := m.Called()
 	return args.Error(0)
@@ -83,8 +90,56 @@ func (m *MockConfluenceAdapter) FetchPage(ctx context.Context, pageID string) (*
 	return args.Get(0).(*confluence.Page), args.Error(1)
 }
 
+func (m *MockConfluenceAdapter) ConvertPage(page *confluence.Page) (*domain.Asset, error) {
+	args := m.Called(page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Asset), args.Error(1)
+}
+
+func (m *MockConfluenceAdapter) AddLabel(ctx context.Context, pageID, label string) error {
+	args := m.Called(ctx, pageID, label)
+	return args.Error(0)
+}
+
+func (m *MockConfluenceAdapter) AttachmentText(ctx context.Context, pageID string, limit int) (string, error) {
+	args := m.Called(ctx, pageID, limit)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockConfluenceAdapter) FindPagesByLabel(ctx context.Context, label string) ([]confluence.Page, error) {
+	args := m.Called(ctx, label)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]confluence.Page), args.Error(1)
+}
+
+func (m *MockConfluenceAdapter) DocLink(page *confluence.Page) string {
+	return "https://example.atlassian.net/wiki/spaces/SPACE/pages/" + page.ID
+}
+
 var _ ConfluenceAdapter = (*MockConfluenceAdapter)(nil)
 
+// MockTaskLinkPort is a mock implementation of TaskLinkPort
+type MockTaskLinkPort struct {
+	mock.Mock
+}
+
+func (m *MockTaskLinkPort) CountLinkedTasks(assetKey string) (int, error) {
+	args := m.Called(assetKey)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaskLinkPort) FindLinkedTasks(assetKey string) ([]domain.LinkedTask, error) {
+	args := m.Called(assetKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.LinkedTask), args.Error(1)
+}
+
 func TestCreateAsset(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -618,12 +673,43 @@ func TestEnrichAsset(t *testing.T) {
 	}()
 
 	tests := []struct {
-		name          string
-		assetName     string
-		field         string
-		mockSetup     func(*MockAssetRepository, *MockLlamaClient, *MockConfluenceAdapter)
-		expectedError string
+		name            string
+		assetName       string
+		field           string
+		withAttachments bool
+		mockSetup       func(*MockAssetRepository, *MockLlamaClient, *MockConfluenceAdapter)
+		expectedError   string
 	}{
+		{
+			name:            "enrichment with attachments",
+			assetName:       "test-asset",
+			field:           "how",
+			withAttachments: true,
+			mockSetup: func(repo *MockAssetRepository, llama *MockLlamaClient, confluenceAdapter *MockConfluenceAdapter) {
+				asset := &domain.Asset{
+					Name:    "test-asset",
+					How:     "original how",
+					DocLink: "https://confluence.example.com/wiki/spaces/SPACE/pages/123456",
+					Version: 1,
+				}
+				repo.On("FindByName", "test-asset").Return(asset, nil)
+				confluenceAdapter.On("AttachmentText", mock.Anything, "123456", maxAttachmentContext).Return("Attachment architecture.pdf:\nEvent driven", nil)
+				llama.On("EnrichContent", "original how\n\nContent from page attachments:\nAttachment architecture.pdf:\nEvent driven", "how", asset).Return("enriched how", nil)
+				repo.On("Save", mock.MatchedBy(func(a *domain.Asset) bool {
+					return a.How == "enriched how" && a.Version == 2
+				})).Return(nil)
+			},
+		},
+		{
+			name:            "attachments without a linked page",
+			assetName:       "test-asset",
+			field:           "description",
+			withAttachments: true,
+			mockSetup: func(repo *MockAssetRepository, _ *MockLlamaClient, _ *MockConfluenceAdapter) {
+				repo.On("FindByName", "test-asset").Return(&domain.Asset{Name: "test-asset"}, nil)
+			},
+			expectedError: "failed to enrich content: asset test-asset has no Confluence page; link one with 'assets link-doc'",
+		},
 		{
 			name:      "successful enrichment",
 			assetName: "test-asset",
@@ -698,11 +784,7 @@ func TestEnrichAsset(t *testing.T) {
 					}{
 						Key: "SPACE",
 					},
-					Version: struct {
-						Number int `json:"number"`
-					}{
-						Number: 1,
-					},
+					Version: confluence.PageVersion{Number: 1},
 					Body: struct {
 						Storage struct {
 							Value string `json:"value"`
@@ -762,7 +844,7 @@ func TestEnrichAsset(t *testing.T) {
 				confluence: mockConfluence,
 			}
 
-			err := service.EnrichAsset(tt.assetName, tt.field)
+			err := service.EnrichAsset(tt.assetName, tt.field, tt.withAttachments)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -778,6 +860,83 @@ func TestEnrichAsset(t *testing.T) {
 	}
 }
 
+func TestProposeAndApplyEnrichment(t *testing.T) {
+	asset := &domain.Asset{Name: "test-asset", How: "original how", Version: 1}
+	mockRepo := new(MockAssetRepository)
+	mockLlama := new(MockLlamaClient)
+	mockRepo.On("FindByName", "test-asset").Return(asset, nil)
+	mockLlama.On("EnrichContent", "original how", "how", asset).Return("enriched how", nil)
+	service := &AssetServiceImpl{repo: mockRepo, llama: mockLlama}
+
+	proposal, err := service.ProposeEnrichment("test-asset", "how", false, false)
+	require.NoError(t, err)
+	assert.Equal(t, &domain.EnrichmentProposal{
+		Asset: "test-asset", Field: "how", Current: "original how", Proposed: "enriched how", Model: "llama3",
+	}, proposal)
+	assert.Equal(t, "original how", asset.How, "a proposal is not saved")
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything)
+
+	stale := *proposal
+	stale.Current = "edited meanwhile"
+	assert.ErrorIs(t, service.ApplyEnrichment(&stale), domain.ErrEnrichmentStale)
+
+	mockRepo.On("Save", mock.MatchedBy(func(saved *domain.Asset) bool {
+		return saved.How == "enriched how" && saved.GeneratedFields["how"].Model == "llama3"
+	})).Return(nil)
+	require.NoError(t, service.ApplyEnrichment(proposal))
+	assert.Equal(t, 2, asset.Version)
+	mockRepo.AssertExpectations(t)
+}
+
+// cachingRepository is an asset repository keeping generated field values in memory
+type cachingRepository struct {
+	*MockAssetRepository
+	entries map[domain.EnrichmentCacheKey]domain.CachedEnrichment
+}
+
+func (r *cachingRepository) FindEnrichment(key domain.EnrichmentCacheKey) (*domain.CachedEnrichment, error) {
+	entry, ok := r.entries[key]
+	if !ok {
+		return nil, nil
+	}
+	return &entry, nil
+}
+
+func (r *cachingRepository) SaveEnrichment(entry domain.CachedEnrichment) error {
+	r.entries[entry.Key] = entry
+	return nil
+}
+
+func TestProposeEnrichment_Cache(t *testing.T) {
+	asset := &domain.Asset{Name: "test-asset", How: "original how", DocLink: "https://example.atlassian.net/wiki/spaces/SPACE/pages/123", DocPageVersion: 4}
+	repo := &cachingRepository{MockAssetRepository: new(MockAssetRepository), entries: map[domain.EnrichmentCacheKey]domain.CachedEnrichment{}}
+	repo.On("FindByName", "test-asset").Return(asset, nil)
+	mockLlama := new(MockLlamaClient)
+	mockLlama.On("EnrichContent", "original how", "how", asset).Return("enriched how", nil)
+	service := &AssetServiceImpl{repo: repo, llama: mockLlama}
+
+	proposal, err := service.ProposeEnrichment("test-asset", "how", false, false)
+	require.NoError(t, err)
+	assert.False(t, proposal.Cached)
+	require.Len(t, repo.entries, 1)
+
+	proposal, err = service.ProposeEnrichment("test-asset", "how", false, false)
+	require.NoError(t, err)
+	assert.True(t, proposal.Cached, "an unchanged page reuses the generated value")
+	assert.Equal(t, "enriched how", proposal.Proposed)
+	mockLlama.AssertNumberOfCalls(t, "EnrichContent", 1)
+
+	_, err = service.ProposeEnrichment("test-asset", "how", false, true)
+	require.NoError(t, err)
+	mockLlama.AssertNumberOfCalls(t, "EnrichContent", 2)
+
+	asset.DocPageVersion = 5
+	proposal, err = service.ProposeEnrichment("test-asset", "how", false, false)
+	require.NoError(t, err)
+	assert.False(t, proposal.Cached, "a new page version calls the model again")
+	mockLlama.AssertNumberOfCalls(t, "EnrichContent", 3)
+}
+
 func TestExtractPageIDFromDocLink(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -934,3 +1093,838 @@ func TestGenerateKeywords(t *testing.T) {
 		})
 	}
 }
+
+func TestSummarizeActivity(t *testing.T) {
+	asset := &domain.Asset{Name: "booking", Description: "Hotel booking flow"}
+
+	t.Run("generates the summary", func(t *testing.T) {
+		mockRepo := new(MockAssetRepository)
+		mockLlama := new(MockLlamaClient)
+		mockRepo.On("FindByName", "booking").Return(asset, nil)
+		mockLlama.On("EnrichContent", mock.Anything, "activity summary", asset).Return("The team added payment retries.", nil)
+		service := &AssetServiceImpl{repo: mockRepo, llama: mockLlama}
+
+		summary, err := service.SummarizeActivity("booking", "Completed tasks: 1")
+		require.NoError(t, err)
+		assert.Equal(t, "The team added payment retries.", summary)
+		mockLlama.AssertExpectations(t)
+	})
+
+	t.Run("without an LLM provider", func(t *testing.T) {
+		mockRepo := new(MockAssetRepository)
+		mockRepo.On("FindByName", "booking").Return(asset, nil)
+		service := &AssetServiceImpl{repo: mockRepo}
+
+		_, err := service.SummarizeActivity("booking", "Completed tasks: 1")
+		assert.ErrorIs(t, err, ErrLLMDisabled)
+	})
+}
+
+func TestImpairAsset(t *testing.T) {
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("records impairment", func(t *testing.T) {
+		asset := &domain.Asset{Name: "booking", Version: 1}
+		mockRepo := new(MockAssetRepository)
+		mockRepo.On("FindByName", "booking").Return(asset, nil)
+		mockRepo.On("Save", asset).Return(nil)
+		service := NewAssetServiceWithDependencies(mockRepo, nil, nil, nil)
+
+		err := service.ImpairAsset("booking", date, "Sunset", 5000)
+
+		require.NoError(t, err)
+		require.Len(t, asset.Impairments, 1)
+		assert.Equal(t, date, asset.Impairments[0].Date)
+		assert.Equal(t, "Sunset", asset.Impairments[0].Reason)
+		assert.Equal(t, 5000.0, asset.Impairments[0].Amount)
+		assert.Equal(t, 2, asset.Version)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects invalid impairment", func(t *testing.T) {
+		mockRepo := new(MockAssetRepository)
+		mockRepo.On("FindByName", "booking").Return(&domain.Asset{Name: "booking"}, nil)
+		service := NewAssetServiceWithDependencies(mockRepo, nil, nil, nil)
+
+		err := service.ImpairAsset("booking", date, "Sunset", 0)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidImpairmentAmount)
+		mockRepo.AssertNotCalled(t, "Save", mock.Anything)
+	})
+
+	t.Run("asset not found", func(t *testing.T) {
+		mockRepo := new(MockAssetRepository)
+		mockRepo.On("FindByName", "missing").Return(nil, errors.New("not found"))
+		mockRepo.On("FindByID", "missing").Return(nil, errors.New("not found"))
+		service := NewAssetServiceWithDependencies(mockRepo, nil, nil, nil)
+
+		err := service.ImpairAsset("missing", date, "Sunset", 100)
+
+		assert.EqualError(t, err, "failed to get asset: asset not found by name or ID: missing")
+	})
+}
+
+func TestSetBugFixWindow(t *testing.T) {
+	launch := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("sets window and launch date", func(t *testing.T) {
+		asset := &domain.Asset{Name: "booking", Version: 1}
+		mockRepo := new(MockAssetRepository)
+		mockRepo.On("FindByName", "booking").Return(asset, nil)
+		mockRepo.On("Save", asset).Return(nil)
+		service := NewAssetServiceWithDependencies(mockRepo, nil, nil, nil)
+
+		err := service.SetBugFixWindow("booking", 30, launch)
+
+		require.NoError(t, err)
+		assert.Equal(t, 30, asset.BugFixWindowDays)
+		assert.Equal(t, launch, asset.LaunchDate)
+		assert.Equal(t, 2, asset.Version)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("requires a launch date", func(t *testing.T) {
+		mockRepo := new(MockAssetRepository)
+		mockRepo.On("FindByName", "booking").Return(&domain.Asset{Name: "booking"}, nil)
+		service := NewAssetServiceWithDependencies(mockRepo, nil, nil, nil)
+
+		err := service.SetBugFixWindow("booking", 30, time.Time{})
+
+		assert.ErrorIs(t, err, domain.ErrMissingLaunchDate)
+		mockRepo.AssertNotCalled(t, "Save", mock.Anything)
+	})
+}
+
+func TestSetStatus(t *testing.T) {
+	effective := time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("records the status change", func(t *testing.T) {
+		asset := &domain.Asset{Name: "booking", Status: "development", Version: 1}
+		mockRepo := new(MockAssetRepository)
+		mockRepo.On("FindByName", "booking").Return(asset, nil)
+		mockRepo.On("Save", asset).Return(nil)
+		service := NewAssetServiceWithDependencies(mockRepo, nil, nil, nil)
+
+		require.NoError(t, service.SetStatus("booking", "live", effective))
+
+		assert.Equal(t, "live", asset.Status)
+		assert.Equal(t, "development", asset.StatusAt(effective.AddDate(0, 0, -1)))
+		assert.Equal(t, domain.StatusSourceManual, asset.StatusHistory[1].Source)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects an empty status", func(t *testing.T) {
+		mockRepo := new(MockAssetRepository)
+		mockRepo.On("FindByName", "booking").Return(&domain.Asset{Name: "booking"}, nil)
+		service := NewAssetServiceWithDependencies(mockRepo, nil, nil, nil)
+
+		assert.ErrorIs(t, service.SetStatus("booking", "", effective), domain.ErrEmptyStatus)
+		mockRepo.AssertNotCalled(t, "Save", mock.Anything)
+	})
+}
+
+func TestRetireAsset(t *testing.T) {
+	retired := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	t.Run("retires the asset", func(t *testing.T) {
+		asset := &domain.Asset{Name: "booking", Status: "Live", Version: 1}
+		mockRepo := new(MockAssetRepository)
+		mockRepo.On("FindByName", "booking").Return(asset, nil)
+		mockRepo.On("Save", asset).Return(nil)
+		service := NewAssetServiceWithDependencies(mockRepo, nil, nil, nil)
+
+		result, err := service.RetireAsset("booking", retired)
+		require.NoError(t, err)
+
+		assert.Same(t, asset, result)
+		assert.Equal(t, retired, asset.RetiredAt)
+		assert.Equal(t, domain.StatusRetired, asset.Status)
+		assert.Equal(t, "Live", asset.StatusAt(retired.AddDate(0, 0, -1)))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects retiring twice", func(t *testing.T) {
+		mockRepo := new(MockAssetRepository)
+		mockRepo.On("FindByName", "booking").Return(&domain.Asset{Name: "booking", RetiredAt: retired}, nil)
+		service := NewAssetServiceWithDependencies(mockRepo, nil, nil, nil)
+
+		_, err := service.RetireAsset("booking", retired)
+		assert.ErrorIs(t, err, domain.ErrAlreadyRetired)
+		mockRepo.AssertNotCalled(t, "Save", mock.Anything)
+	})
+}
+
+func TestKeepStatusHistory(t *testing.T) {
+	edited := time.Date(2024, 4, 15, 9, 0, 0, 0, time.UTC)
+	existing := &domain.Asset{Status: "development", StatusHistory: []domain.StatusEntry{
+		{Status: "development", EffectiveFrom: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), Source: domain.StatusSourceSync},
+	}}
+
+	unchanged := &domain.Asset{Status: "development", DocPageUpdatedAt: edited}
+	require.NoError(t, keepStatusHistory(unchanged, existing))
+	assert.Equal(t, existing.StatusHistory, unchanged.StatusHistory)
+
+	changed := &domain.Asset{Status: "live", DocPageUpdatedAt: edited}
+	require.NoError(t, keepStatusHistory(changed, existing))
+	assert.Equal(t, "live", changed.Status)
+	assert.Equal(t, []domain.StatusEntry{
+		existing.StatusHistory[0],
+		{Status: "live", EffectiveFrom: edited, Source: domain.StatusSourceSync},
+	}, changed.StatusHistory, "the change takes effect when the page was edited")
+	assert.Len(t, existing.StatusHistory, 1, "the stored asset is left untouched")
+}
+
+// confluenceSpaces serves the asset pages of each space, by page ID, as found by label search
+func confluenceSpaces(t *testing.T, spaces map[string][]string) {
+	t.Helper()
+	complete := `<table><tr><th>Why are we doing this?</th><td>Grow bookings</td></tr><tr><th>Status</th><td>Live</td></tr><tr><th>Launch date</th><td>January 15, 2024</td></tr></table>`
+	pages := map[string]string{
+		"1": `{"id": "1", "title": "Booking", "body": {"storage": {"value": %q}}, "metadata": {"labels": {"results": [{"name": "cap-asset-booking"}]}}, "_links": {"webui": "/spaces/MZN/pages/1"}}`,
+		"2": `{"id": "2", "title": "Search", "body": {"storage": {"value": %q}}, "metadata": {"labels": {"results": [{"name": "cap-asset-search"}]}}, "_links": {"webui": "/spaces/MZN/pages/2"}}`,
+		"3": `{"id": "3", "title": "Search (payments)", "body": {"storage": {"value": %q}}, "metadata": {"labels": {"results": [{"name": "cap-asset-search"}]}}, "_links": {"webui": "/spaces/PAY/pages/3"}}`,
+		"4": `{"id": "4", "title": "Payments", "body": {"storage": {"value": "<table><tr><th>Why are we doing this?</th><td>Take payments</td></tr></table>"}}, "metadata": {"labels": {"results": [{"name": "cap-asset-payments"}]}}, "_links": {"webui": "/spaces/PAY/pages/4"}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/content/search") {
+			var results []string
+			for space, ids := range spaces {
+				if strings.Contains(r.URL.Query().Get("cql"), fmt.Sprintf(`space="%s"`, space)) {
+					for _, id := range ids {
+						results = append(results, fmt.Sprintf(`{"id": %q, "title": "Page %s"}`, id, id))
+					}
+				}
+			}
+			fmt.Fprintf(w, `{"results": [%s]}`, strings.Join(results, ","))
+			return
+		}
+		page := pages[strings.TrimPrefix(r.URL.Path, "/wiki/rest/api/content/")]
+		if strings.Contains(page, "%q") {
+			page = fmt.Sprintf(page, complete)
+		}
+		fmt.Fprint(w, page)
+	}))
+	t.Cleanup(server.Close)
+	t.Setenv("JIRA_BASE_URL", server.URL)
+	t.Setenv("JIRA_TOKEN", "token")
+}
+
+func TestSyncFromConfluence_MultipleSpaces(t *testing.T) {
+	confluenceSpaces(t, map[string][]string{"MZN": {"1", "2"}, "PAY": {"3", "4"}})
+	repo := new(MockAssetRepository)
+	repo.On("FindByID", mock.Anything).Return(nil, errors.New("asset not found"))
+	repo.On("Save", mock.Anything).Return(nil)
+	service := NewAssetServiceWithDependencies(repo, nil, nil, nil)
+
+	var progress []domain.SpaceSync
+	result, err := service.SyncFromConfluence(domain.SyncInput{
+		Spaces:   []string{"MZN", "PAY", "PLAT"},
+		Label:    "cap-asset",
+		Progress: func(space domain.SpaceSync) { progress = append(progress, space) },
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result.SyncedAssets, 1)
+	assert.Equal(t, "cap-asset-booking", result.SyncedAssets[0].ID)
+	require.Len(t, result.NotSyncedAssets, 1)
+	assert.Equal(t, "Payments", result.NotSyncedAssets[0].Name)
+	require.Len(t, result.Duplicates, 1)
+	assert.Equal(t, "cap-asset-search", result.Duplicates[0].ID, "pages of different spaces claiming one asset are duplicates")
+	assert.Equal(t, 4, result.Total())
+	assert.Equal(t, []*domain.SpaceSync{
+		{Space: "MZN", Fetched: 2, Synced: 1, Duplicates: 1},
+		{Space: "PAY", Fetched: 2, NotSynced: 1, Duplicates: 1},
+		{Space: "PLAT"},
+	}, result.Spaces)
+	assert.Equal(t, []domain.SpaceSync{{Space: "MZN", Fetched: 2}, {Space: "PAY", Fetched: 2}, {Space: "PLAT"}}, progress)
+	repo.AssertNumberOfCalls(t, "Save", 1)
+}
+
+func TestSyncFromConfluence_NoAssetsInAnySpace(t *testing.T) {
+	confluenceSpaces(t, map[string][]string{})
+	service := NewAssetServiceWithDependencies(new(MockAssetRepository), nil, nil, nil)
+
+	_, err := service.SyncFromConfluence(domain.SyncInput{Spaces: []string{"MZN", "PAY"}, Label: "cap-asset"})
+
+	assert.EqualError(t, err, "no assets found with label 'cap-asset' in space 'MZN'\nno assets found with label 'cap-asset' in space 'PAY'")
+}
+
+func TestPreviewLive(t *testing.T) {
+	docURL := "https://example.atlassian.net/wiki/spaces/SPACE/pages/123456/Booking"
+
+	t.Run("compares local and live fields without saving", func(t *testing.T) {
+		asset := &domain.Asset{Name: "Booking Engine", Description: "Books rooms", Status: "Live", DocLink: docURL}
+		page := &confluence.Page{ID: "123456", Title: "Booking"}
+		repo := new(MockAssetRepository)
+		adapter := new(MockConfluenceAdapter)
+		repo.On("FindByName", "Booking Engine").Return(asset, nil)
+		adapter.On("FetchPage", mock.Anything, "123456").Return(page, nil)
+		adapter.On("ConvertPage", page).Return(&domain.Asset{Description: "Books rooms and flights", Status: "Live", DocPageVersion: 4}, nil)
+		service := NewAssetServiceWithDependencies(repo, nil, adapter, nil)
+
+		preview, err := service.PreviewLive("Booking Engine")
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, preview.PageVersion)
+		assert.Equal(t, []domain.FieldDrift{{Field: "description", Local: "Books rooms", Live: "Books rooms and flights"}}, preview.Drifted())
+		assert.Equal(t, "Books rooms", asset.Description)
+		repo.AssertNotCalled(t, "Save", mock.Anything)
+		adapter.AssertExpectations(t)
+	})
+
+	t.Run("asset without linked page", func(t *testing.T) {
+		repo := new(MockAssetRepository)
+		repo.On("FindByName", "Booking Engine").Return(&domain.Asset{Name: "Booking Engine"}, nil)
+		service := NewAssetServiceWithDependencies(repo, nil, new(MockConfluenceAdapter), nil)
+
+		_, err := service.PreviewLive("Booking Engine")
+
+		assert.EqualError(t, err, "asset Booking Engine is not linked to a Confluence page; link one with assets link-doc")
+	})
+
+	t.Run("page not found", func(t *testing.T) {
+		repo := new(MockAssetRepository)
+		adapter := new(MockConfluenceAdapter)
+		repo.On("FindByName", "Booking Engine").Return(&domain.Asset{Name: "Booking Engine", DocLink: docURL}, nil)
+		adapter.On("FetchPage", mock.Anything, "123456").Return(nil, errors.New("unexpected status code: 404"))
+		service := NewAssetServiceWithDependencies(repo, nil, adapter, nil)
+
+		_, err := service.PreviewLive("Booking Engine")
+
+		assert.EqualError(t, err, "failed to fetch Confluence page: unexpected status code: 404")
+	})
+}
+
+func TestLinkDocumentation(t *testing.T) {
+	docURL := "https://example.atlassian.net/wiki/spaces/SPACE/pages/123456/Booking"
+	launch := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	pageAsset := &domain.Asset{
+		Description: "From Confluence",
+		Why:         "Grow bookings",
+		Status:      "Live",
+		LaunchDate:  launch,
+		Keywords:    []string{"booking"},
+		DocLink:     docURL,
+	}
+
+	labeledPage := func(labels ...string) *confluence.Page {
+		page := &confluence.Page{ID: "123456", Title: "Booking"}
+		for _, label := range labels {
+			page.Metadata.Labels.Results = append(page.Metadata.Labels.Results, struct {
+				Name string `json:"name"`
+			}{Name: label})
+		}
+		return page
+	}
+
+	t.Run("labels unlabeled page and back-fills metadata", func(t *testing.T) {
+		asset := &domain.Asset{ID: "abc123", Name: "Booking Engine", Description: "Local description", Version: 1}
+		page := labeledPage("docs")
+		repo := new(MockAssetRepository)
+		adapter := new(MockConfluenceAdapter)
+		repo.On("FindByName", "Booking Engine").Return(asset, nil)
+		adapter.On("FetchPage", mock.Anything, "123456").Return(page, nil)
+		adapter.On("AddLabel", mock.Anything, "123456", "cap-asset-booking-engine").Return(nil)
+		adapter.On("ConvertPage", page).Return(pageAsset, nil)
+		repo.On("Save", asset).Return(nil)
+		service := NewAssetServiceWithDependencies(repo, nil, adapter, nil)
+
+		linked, err := service.LinkDocumentation("Booking Engine", docURL)
+
+		require.NoError(t, err)
+		assert.Equal(t, "cap-asset-booking-engine", linked.ID)
+		assert.Equal(t, "Booking Engine", linked.Name)
+		assert.Equal(t, "Local description", linked.Description)
+		assert.Equal(t, "Grow bookings", linked.Why)
+		assert.Equal(t, "Live", linked.Status)
+		assert.Equal(t, launch, linked.LaunchDate)
+		assert.Equal(t, []string{"booking"}, linked.Keywords)
+		assert.Equal(t, docURL, linked.DocLink)
+		assert.Equal(t, 2, linked.Version)
+		repo.AssertExpectations(t)
+		adapter.AssertExpectations(t)
+	})
+
+	t.Run("reuses existing page label", func(t *testing.T) {
+		asset := &domain.Asset{Name: "Booking Engine", Description: "Local description"}
+		page := labeledPage("cap-asset-booking")
+		repo := new(MockAssetRepository)
+		adapter := new(MockConfluenceAdapter)
+		repo.On("FindByName", "Booking Engine").Return(asset, nil)
+		adapter.On("FetchPage", mock.Anything, "123456").Return(page, nil)
+		adapter.On("ConvertPage", page).Return(pageAsset, nil)
+		repo.On("Save", asset).Return(nil)
+		service := NewAssetServiceWithDependencies(repo, nil, adapter, nil)
+
+		linked, err := service.LinkDocumentation("Booking Engine", docURL)
+
+		require.NoError(t, err)
+		assert.Equal(t, "cap-asset-booking", linked.ID)
+		adapter.AssertNotCalled(t, "AddLabel", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid URL", func(t *testing.T) {
+		repo := new(MockAssetRepository)
+		repo.On("FindByName", "Booking Engine").Return(&domain.Asset{Name: "Booking Engine"}, nil)
+		service := NewAssetServiceWithDependencies(repo, nil, new(MockConfluenceAdapter), nil)
+
+		_, err := service.LinkDocumentation("Booking Engine", "https://example.com/not-a-page")
+
+		assert.EqualError(t, err, "invalid Confluence page URL: https://example.com/not-a-page")
+	})
+
+	t.Run("page not found", func(t *testing.T) {
+		repo := new(MockAssetRepository)
+		adapter := new(MockConfluenceAdapter)
+		repo.On("FindByName", "Booking Engine").Return(&domain.Asset{Name: "Booking Engine"}, nil)
+		adapter.On("FetchPage", mock.Anything, "123456").Return(nil, errors.New("unexpected status code: 404"))
+		service := NewAssetServiceWithDependencies(repo, nil, adapter, nil)
+
+		_, err := service.LinkDocumentation("Booking Engine", docURL)
+
+		assert.EqualError(t, err, "failed to fetch Confluence page: unexpected status code: 404")
+		repo.AssertNotCalled(t, "Save", mock.Anything)
+	})
+}
+
+func TestResolveDuplicate(t *testing.T) {
+	primaryURL := "https://example.atlassian.net/wiki/spaces/SPACE/pages/1/Booking"
+	page := func(id string, labels ...string) confluence.Page {
+		page := confluence.Page{ID: id, Title: "Booking " + id}
+		for _, label := range labels {
+			page.Metadata.Labels.Results = append(page.Metadata.Labels.Results, struct {
+				Name string `json:"name"`
+			}{Name: label})
+		}
+		return page
+	}
+
+	t.Run("marks the other pages as superseded and relinks the asset", func(t *testing.T) {
+		primary := page("1", "cap-asset-booking")
+		asset := &domain.Asset{ID: "cap-asset-booking", Name: "Booking", DocLink: "https://example.atlassian.net/wiki/spaces/SPACE/pages/2", Version: 3}
+		repo := new(MockAssetRepository)
+		adapter := new(MockConfluenceAdapter)
+		adapter.On("FetchPage", mock.Anything, "1").Return(&primary, nil)
+		adapter.On("FindPagesByLabel", mock.Anything, "cap-asset-booking").Return([]confluence.Page{
+			primary,
+			page("2", "cap-asset-booking"),
+			page("3", "cap-asset-booking", "cap-superseded"),
+		}, nil)
+		adapter.On("AddLabel", mock.Anything, "2", "cap-superseded").Return(nil)
+		repo.On("FindByID", "cap-asset-booking").Return(asset, nil)
+		repo.On("Save", asset).Return(nil)
+		service := NewAssetServiceWithDependencies(repo, nil, adapter, nil)
+
+		resolution, err := service.ResolveDuplicate(primaryURL)
+
+		require.NoError(t, err)
+		assert.Equal(t, "cap-asset-booking", resolution.ID)
+		assert.Equal(t, "https://example.atlassian.net/wiki/spaces/SPACE/pages/1", resolution.Primary)
+		assert.Equal(t, []string{"https://example.atlassian.net/wiki/spaces/SPACE/pages/2"}, resolution.Superseded, "pages already superseded are left alone")
+		assert.Equal(t, resolution.Primary, asset.DocLink)
+		assert.Equal(t, 4, asset.Version)
+		repo.AssertExpectations(t)
+		adapter.AssertExpectations(t)
+	})
+
+	t.Run("asset not in the catalog yet", func(t *testing.T) {
+		primary := page("1", "cap-asset-booking")
+		repo := new(MockAssetRepository)
+		adapter := new(MockConfluenceAdapter)
+		adapter.On("FetchPage", mock.Anything, "1").Return(&primary, nil)
+		adapter.On("FindPagesByLabel", mock.Anything, "cap-asset-booking").Return([]confluence.Page{primary}, nil)
+		repo.On("FindByID", "cap-asset-booking").Return(nil, errors.New("not found"))
+		service := NewAssetServiceWithDependencies(repo, nil, adapter, nil)
+
+		resolution, err := service.ResolveDuplicate(primaryURL)
+
+		require.NoError(t, err)
+		assert.Empty(t, resolution.Superseded)
+		assert.Nil(t, resolution.Asset)
+	})
+
+	t.Run("page without an asset label", func(t *testing.T) {
+		primary := page("1", "docs")
+		adapter := new(MockConfluenceAdapter)
+		adapter.On("FetchPage", mock.Anything, "1").Return(&primary, nil)
+		service := NewAssetServiceWithDependencies(new(MockAssetRepository), nil, adapter, nil)
+
+		_, err := service.ResolveDuplicate(primaryURL)
+
+		assert.EqualError(t, err, "page "+primaryURL+" carries no asset label; link it to an asset with 'assets link-doc'")
+	})
+
+	t.Run("superseded primary page", func(t *testing.T) {
+		primary := page("1", "cap-asset-booking", "cap-superseded")
+		adapter := new(MockConfluenceAdapter)
+		adapter.On("FetchPage", mock.Anything, "1").Return(&primary, nil)
+		service := NewAssetServiceWithDependencies(new(MockAssetRepository), nil, adapter, nil)
+
+		_, err := service.ResolveDuplicate(primaryURL)
+
+		assert.ErrorContains(t, err, "is marked as superseded")
+	})
+
+	t.Run("invalid URL", func(t *testing.T) {
+		service := NewAssetServiceWithDependencies(new(MockAssetRepository), nil, new(MockConfluenceAdapter), nil)
+
+		_, err := service.ResolveDuplicate("https://example.atlassian.net/wiki/home")
+
+		assert.EqualError(t, err, "invalid Confluence page URL: https://example.atlassian.net/wiki/home")
+	})
+}
+
+func TestAssetLabel(t *testing.T) {
+	assert.Equal(t, "cap-asset-booking-engine", assetLabel("Booking Engine"))
+	assert.Equal(t, "cap-asset-b2b-api-v2", assetLabel("  B2B API (v2) "))
+}
+
+func TestDerivedTaskCount(t *testing.T) {
+	t.Run("get asset reports linked tasks", func(t *testing.T) {
+		repo := new(MockAssetRepository)
+		links := new(MockTaskLinkPort)
+		repo.On("FindByName", "Booking").Return(&domain.Asset{Name: "Booking", AssociatedTaskCount: 7}, nil)
+		links.On("CountLinkedTasks", "Booking").Return(3, nil)
+		service := NewAssetServiceWithDependencies(repo, nil, nil, links)
+
+		asset, err := service.GetAsset("Booking")
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, asset.GetTaskCount())
+	})
+
+	t.Run("linked asset counts by label", func(t *testing.T) {
+		repo := new(MockAssetRepository)
+		links := new(MockTaskLinkPort)
+		repo.On("FindAll").Return([]*domain.Asset{
+			{ID: "cap-asset-booking", Name: "Booking Engine"},
+			{ID: "3f2a", Name: "Payments"},
+		}, nil)
+		links.On("CountLinkedTasks", "cap-asset-booking").Return(5, nil)
+		links.On("CountLinkedTasks", "Payments").Return(0, nil)
+		service := NewAssetServiceWithDependencies(repo, nil, nil, links)
+
+		assets, err := service.ListAssets()
+
+		require.NoError(t, err)
+		assert.Equal(t, 5, assets[0].GetTaskCount())
+		assert.Equal(t, 0, assets[1].GetTaskCount())
+	})
+
+	t.Run("task lookup failure", func(t *testing.T) {
+		repo := new(MockAssetRepository)
+		links := new(MockTaskLinkPort)
+		repo.On("FindByName", "Booking").Return(&domain.Asset{Name: "Booking"}, nil)
+		links.On("CountLinkedTasks", "Booking").Return(0, errors.New("corrupt tasks file"))
+		service := NewAssetServiceWithDependencies(repo, nil, nil, links)
+
+		_, err := service.GetAsset("Booking")
+
+		assert.EqualError(t, err, "failed to count tasks for asset Booking: corrupt tasks file")
+	})
+
+	t.Run("without task links the stored counter is kept", func(t *testing.T) {
+		repo := new(MockAssetRepository)
+		repo.On("FindByName", "Booking").Return(&domain.Asset{Name: "Booking", AssociatedTaskCount: 7}, nil)
+		service := NewAssetServiceWithDependencies(repo, nil, nil, nil)
+
+		asset, err := service.GetAsset("Booking")
+
+		require.NoError(t, err)
+		assert.Equal(t, 7, asset.GetTaskCount())
+	})
+}
+
+// historyRepository is a MockAssetRepository that also keeps a catalogue history
+type historyRepository struct {
+	*MockAssetRepository
+}
+
+func (r *historyRepository) CatalogAt(day time.Time) ([]*domain.Asset, time.Time, error) {
+	args := r.Called(day)
+	if args.Get(0) == nil {
+		return nil, time.Time{}, args.Error(2)
+	}
+	return args.Get(0).([]*domain.Asset), args.Get(1).(time.Time), args.Error(2)
+}
+
+func TestDiffAssets(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	current := []*domain.Asset{
+		{ID: "1", Name: "booking", Status: "live"},
+		{ID: "2", Name: "search"},
+	}
+
+	t.Run("since a date", func(t *testing.T) {
+		repo := &historyRepository{MockAssetRepository: new(MockAssetRepository)}
+		repo.On("CatalogAt", since).Return([]*domain.Asset{{ID: "1", Name: "booking", Status: "development"}}, time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), nil)
+		repo.On("FindAll").Return(current, nil)
+		service := NewAssetServiceWithDependencies(repo, nil, nil, nil)
+
+		diff, err := service.DiffAssets(domain.CatalogDiffInput{Since: since})
+
+		require.NoError(t, err)
+		assert.Equal(t, "catalogue on 2024-01-01 (snapshot of 2024-01-03)", diff.Reference)
+		assert.Equal(t, []string{"search"}, diff.Added)
+		assert.Equal(t, []domain.StatusChange{{Asset: "booking", Before: "development", After: "live"}}, diff.StatusChanges)
+	})
+
+	t.Run("between two snapshot files", func(t *testing.T) {
+		dir := t.TempDir()
+		from := dir + "/from.json"
+		to := dir + "/to.json"
+		require.NoError(t, os.WriteFile(from, []byte(`{"booking": {"id": "1", "name": "booking"}}`), 0644))
+		require.NoError(t, os.WriteFile(to, []byte(`{}`), 0644))
+		service := NewAssetServiceWithDependencies(new(MockAssetRepository), nil, nil, nil)
+
+		diff, err := service.DiffAssets(domain.CatalogDiffInput{From: from, To: to})
+
+		require.NoError(t, err)
+		assert.Equal(t, from, diff.Reference)
+		assert.Equal(t, []string{"booking"}, diff.Removed)
+	})
+
+	t.Run("repository without history", func(t *testing.T) {
+		service := NewAssetServiceWithDependencies(new(MockAssetRepository), nil, nil, nil)
+
+		_, err := service.DiffAssets(domain.CatalogDiffInput{Since: since})
+
+		assert.ErrorContains(t, err, "does not keep a catalogue history")
+	})
+
+	t.Run("invalid references", func(t *testing.T) {
+		service := NewAssetServiceWithDependencies(new(MockAssetRepository), nil, nil, nil)
+
+		_, err := service.DiffAssets(domain.CatalogDiffInput{})
+		assert.Error(t, err)
+		_, err = service.DiffAssets(domain.CatalogDiffInput{Since: since, From: "a.json"})
+		assert.Error(t, err)
+		_, err = service.DiffAssets(domain.CatalogDiffInput{To: "b.json"})
+		assert.Error(t, err)
+	})
+}
+
+// trashRepository is a MockAssetRepository that also keeps deleted assets
+type trashRepository struct {
+	*MockAssetRepository
+}
+
+func (r *trashRepository) FindDeleted() ([]*domain.DeletedAsset, error) {
+	args := r.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.DeletedAsset), args.Error(1)
+}
+
+func (r *trashRepository) Restore(name string) (*domain.Asset, error) {
+	args := r.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Asset), args.Error(1)
+}
+
+func (r *trashRepository) Purge(name string) error {
+	return r.Called(name).Error(0)
+}
+
+func TestAssetTrash(t *testing.T) {
+	t.Run("purges through the trash", func(t *testing.T) {
+		repo := &trashRepository{MockAssetRepository: new(MockAssetRepository)}
+		repo.On("FindByName", "booking").Return(nil, errors.New("asset not found"))
+		repo.On("Purge", "booking").Return(nil)
+		service := NewAssetServiceWithDependencies(repo, nil, nil, nil)
+
+		require.NoError(t, service.PurgeAsset("booking", false))
+		repo.AssertNotCalled(t, "Delete", "booking")
+	})
+
+	t.Run("lists and restores deleted assets", func(t *testing.T) {
+		repo := &trashRepository{MockAssetRepository: new(MockAssetRepository)}
+		deleted := []*domain.DeletedAsset{{Asset: &domain.Asset{Name: "booking"}}}
+		repo.On("FindDeleted").Return(deleted, nil)
+		repo.On("Restore", "booking").Return(&domain.Asset{Name: "booking"}, nil)
+		repo.On("Restore", "search").Return(nil, errors.New("deleted asset search not found"))
+		service := NewAssetServiceWithDependencies(repo, nil, nil, nil)
+
+		result, err := service.ListDeletedAssets()
+		require.NoError(t, err)
+		assert.Equal(t, deleted, result)
+
+		asset, err := service.RestoreAsset("booking")
+		require.NoError(t, err)
+		assert.Equal(t, "booking", asset.Name)
+
+		_, err = service.RestoreAsset("search")
+		assert.ErrorContains(t, err, "failed to restore asset: deleted asset search not found")
+	})
+
+	t.Run("repositories without a trash delete permanently", func(t *testing.T) {
+		repo := new(MockAssetRepository)
+		repo.On("FindByName", "booking").Return(&domain.Asset{Name: "booking"}, nil)
+		repo.On("Delete", "booking").Return(nil)
+		service := NewAssetServiceWithDependencies(repo, nil, nil, nil)
+
+		require.NoError(t, service.PurgeAsset("booking", false))
+		repo.AssertCalled(t, "Delete", "booking")
+
+		_, err := service.ListDeletedAssets()
+		assert.ErrorContains(t, err, "the asset repository does not keep deleted assets")
+		_, err = service.RestoreAsset("booking")
+		assert.ErrorContains(t, err, "the asset repository does not keep deleted assets")
+	})
+}
+
+func TestAssetRemoval_AssociatedTasks(t *testing.T) {
+	setup := func(linked int) (*MockAssetRepository, AssetService) {
+		repo := new(MockAssetRepository)
+		links := new(MockTaskLinkPort)
+		repo.On("FindByName", "Booking").Return(&domain.Asset{ID: "cap-asset-booking", Name: "Booking", Status: "Live"}, nil)
+		links.On("CountLinkedTasks", "cap-asset-booking").Return(linked, nil)
+		return repo, NewAssetServiceWithDependencies(repo, nil, nil, links)
+	}
+	archived := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	t.Run("refuses assets with associated tasks", func(t *testing.T) {
+		repo, service := setup(3)
+
+		err := service.DeleteAsset("Booking", false)
+		assert.ErrorIs(t, err, domain.ErrAssetHasTasks)
+		assert.EqualError(t, err, "asset still has associated tasks: Booking is linked to 3 tasks")
+		assert.ErrorIs(t, service.PurgeAsset("Booking", false), domain.ErrAssetHasTasks)
+		_, err = service.ArchiveAsset("Booking", archived, false)
+		assert.ErrorIs(t, err, domain.ErrAssetHasTasks)
+		repo.AssertNotCalled(t, "Delete", mock.Anything)
+		repo.AssertNotCalled(t, "Save", mock.Anything)
+	})
+
+	t.Run("forced removal ignores associated tasks", func(t *testing.T) {
+		repo, service := setup(3)
+		repo.On("Delete", "Booking").Return(nil)
+		repo.On("Save", mock.Anything).Return(nil)
+
+		require.NoError(t, service.DeleteAsset("Booking", true))
+		asset, err := service.ArchiveAsset("Booking", archived, true)
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusArchived, asset.Status)
+	})
+
+	t.Run("archives assets without tasks", func(t *testing.T) {
+		repo, service := setup(0)
+		repo.On("Save", mock.Anything).Return(nil)
+
+		asset, err := service.ArchiveAsset("Booking", archived, false)
+		require.NoError(t, err)
+		assert.True(t, asset.IsArchived())
+		assert.Equal(t, "Live", asset.StatusAt(archived.AddDate(0, 0, -1)))
+	})
+}
+
+func TestGetTaskBreakdown(t *testing.T) {
+	t.Run("counts linked tasks", func(t *testing.T) {
+		repo := new(MockAssetRepository)
+		links := new(MockTaskLinkPort)
+		repo.On("FindByName", "Booking").Return(&domain.Asset{ID: "cap-asset-booking", Name: "Booking"}, nil)
+		linked := []domain.LinkedTask{
+			{Key: "FN-1", Sprint: "Sprint 1", WorkType: "cap-development"},
+			{Key: "FN-2", Sprint: "Sprint 1", WorkType: "cap-maintenance"},
+		}
+		links.On("CountLinkedTasks", "cap-asset-booking").Return(2, nil)
+		links.On("FindLinkedTasks", "cap-asset-booking").Return(linked, nil)
+		service := NewAssetServiceWithDependencies(repo, nil, nil, links)
+
+		breakdown, err := service.GetTaskBreakdown("Booking")
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.NewTaskBreakdown("Booking", linked), breakdown)
+	})
+
+	t.Run("task lookup failure", func(t *testing.T) {
+		repo := new(MockAssetRepository)
+		links := new(MockTaskLinkPort)
+		repo.On("FindByName", "Booking").Return(&domain.Asset{Name: "Booking"}, nil)
+		links.On("CountLinkedTasks", "Booking").Return(0, nil)
+		links.On("FindLinkedTasks", "Booking").Return(nil, errors.New("corrupt tasks file"))
+		service := NewAssetServiceWithDependencies(repo, nil, nil, links)
+
+		_, err := service.GetTaskBreakdown("Booking")
+
+		assert.EqualError(t, err, "failed to find tasks for asset Booking: corrupt tasks file")
+	})
+
+	t.Run("without task links nothing is counted", func(t *testing.T) {
+		repo := new(MockAssetRepository)
+		repo.On("FindByName", "Booking").Return(&domain.Asset{Name: "Booking"}, nil)
+		service := NewAssetServiceWithDependencies(repo, nil, nil, nil)
+
+		breakdown, err := service.GetTaskBreakdown("Booking")
+
+		require.NoError(t, err)
+		assert.Zero(t, breakdown.Tasks)
+	})
+}
+
+func TestSetClassificationRules(t *testing.T) {
+	asset := &domain.Asset{Name: "data", Version: 1}
+	mockRepo := new(MockAssetRepository)
+	mockRepo.On("FindByName", "data").Return(asset, nil)
+	mockRepo.On("Save", asset).Return(nil)
+	service := NewAssetServiceWithDependencies(mockRepo, nil, nil, nil)
+	rules := domain.ClassificationRules{
+		Keywords: []domain.ClassificationRule{{Keyword: "pipeline backfill", WorkType: "cap-development"}},
+	}
+
+	require.NoError(t, service.SetClassificationRules("data", rules))
+	assert.Equal(t, &rules, asset.ClassificationRules)
+	assert.Equal(t, 2, asset.Version)
+
+	require.NoError(t, service.SetClassificationRules("data", domain.ClassificationRules{}))
+	assert.Nil(t, asset.ClassificationRules)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSetOwner(t *testing.T) {
+	asset := &domain.Asset{Name: "data", Version: 1}
+	mockRepo := new(MockAssetRepository)
+	mockRepo.On("FindByName", "data").Return(asset, nil)
+	mockRepo.On("Save", asset).Return(nil)
+	service := NewAssetServiceWithDependencies(mockRepo, nil, nil, nil)
+
+	require.NoError(t, service.SetOwner("data", " Jane Doe "))
+	assert.Equal(t, "Jane Doe", asset.Owner)
+	assert.Equal(t, 2, asset.Version)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSetAccounting(t *testing.T) {
+	asset := &domain.Asset{Name: "data", Version: 1}
+	mockRepo := new(MockAssetRepository)
+	mockRepo.On("FindByName", "data").Return(asset, nil)
+	mockRepo.On("Save", asset).Return(nil)
+	service := NewAssetServiceWithDependencies(mockRepo, nil, nil, nil)
+
+	require.NoError(t, service.SetAccounting("data", "CC-100", "1710"))
+	assert.Equal(t, "CC-100", asset.CostCenter)
+	assert.Equal(t, "1710", asset.GLAccount)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestFindStaleDocumentation(t *testing.T) {
+	now := time.Now()
+	mockRepo := new(MockAssetRepository)
+	mockRepo.On("FindAll").Return([]*domain.Asset{
+		{Name: "fresh", Status: "Live", LastDocUpdateAt: now.AddDate(0, 0, -10)},
+		{Name: "stale", Status: "Live", Owner: "Jane", LastDocUpdateAt: now.AddDate(0, 0, -40)},
+		{Name: "retired", Status: "Retired"},
+	}, nil)
+	service := NewAssetServiceWithDependencies(mockRepo, nil, nil, nil)
+
+	stale, err := service.FindStaleDocumentation(domain.FreshnessPolicy{MaxAgeDays: map[string]int{"retired": 0}, DefaultMaxAgeDays: 30})
+	require.NoError(t, err)
+	require.Len(t, stale, 1)
+	assert.Equal(t, "stale", stale[0].Asset)
+	assert.Equal(t, "Jane", stale[0].Owner)
+
+	_, err = service.FindStaleDocumentation(domain.FreshnessPolicy{DefaultMaxAgeDays: -1})
+	assert.ErrorIs(t, err, domain.ErrNegativeMaxAge)
+}
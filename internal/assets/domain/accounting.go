@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// SetAccounting sets the cost center and general ledger account the capitalized cost of the
+// asset is booked to; empty values remove them
+func (a *Asset) SetAccounting(costCenter, glAccount string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.CostCenter = strings.TrimSpace(costCenter)
+	a.GLAccount = strings.TrimSpace(glAccount)
+	a.UpdatedAt = time.Now()
+	a.Version++
+}
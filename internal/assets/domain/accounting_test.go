@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsset_SetAccounting(t *testing.T) {
+	asset, err := NewAsset("booking", "Booking flow")
+	require.NoError(t, err)
+
+	asset.SetAccounting(" CC-100 ", " 1710 ")
+	assert.Equal(t, "CC-100", asset.CostCenter)
+	assert.Equal(t, "1710", asset.GLAccount)
+	assert.Equal(t, 2, asset.Version)
+
+	asset.SetAccounting("", "")
+	assert.Empty(t, asset.CostCenter)
+	assert.Empty(t, asset.GLAccount)
+}
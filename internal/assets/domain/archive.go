@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// StatusArchived is the status of an asset kept in the catalogue for its history but no longer
+// worked on
+const StatusArchived = "Archived"
+
+// Archive errors
+var (
+	ErrMissingArchiveDate = errors.New("archive date cannot be empty")
+	ErrAlreadyArchived    = errors.New("asset is already archived")
+	// ErrAssetHasTasks is returned when removing an asset would leave tasks linked to nothing
+	ErrAssetHasTasks = errors.New("asset still has associated tasks")
+)
+
+// Archive records the Archived status from a date, keeping the asset and its history in the
+// catalogue instead of deleting it
+func (a *Asset) Archive(date time.Time) error {
+	if date.IsZero() {
+		return ErrMissingArchiveDate
+	}
+	if a.IsArchived() {
+		return ErrAlreadyArchived
+	}
+	return a.SetStatus(StatusArchived, date, StatusSourceManual)
+}
+
+// IsArchived reports whether the asset's current status is Archived
+func (a *Asset) IsArchived() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.Status == StatusArchived
+}
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsset_Archive(t *testing.T) {
+	asset, err := NewAsset("booking", "Books trips")
+	require.NoError(t, err)
+	asset.Status = "Live"
+
+	assert.ErrorIs(t, asset.Archive(time.Time{}), ErrMissingArchiveDate)
+	assert.ErrorIs(t, asset.Archive(time.Now().AddDate(0, 1, 0)), ErrFutureStatusEffective)
+	assert.False(t, asset.IsArchived())
+
+	archived := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, asset.Archive(archived))
+	assert.True(t, asset.IsArchived())
+	assert.Equal(t, StatusArchived, asset.Status)
+	assert.Equal(t, "Live", asset.StatusAt(archived.AddDate(0, 0, -1)), "the status history is kept")
+
+	assert.ErrorIs(t, asset.Archive(archived), ErrAlreadyArchived)
+}
@@ -5,10 +5,14 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"strings"
 	"sync"
 	"time"
 )
 
+// assetLabelPrefix is the label prefix linking tasks and documentation pages to an asset
+const assetLabelPrefix = "cap-asset-"
+
 // Domain-specific errors
 var (
 	ErrEmptyName         = errors.New("asset name cannot be empty")
@@ -33,7 +37,14 @@ type Asset struct {
 	UpdatedAt time.Time `json:"updated_at"`
 	// LastDocUpdateAt is when the asset's documentation was last updated
 	LastDocUpdateAt time.Time `json:"last_doc_update_at"`
-	// AssociatedTaskCount tracks how many tasks are linked to this asset
+	// DocPageVersion and DocPageUpdatedAt are the version of the asset's Confluence page and
+	// when it was last edited, as of the last sync
+	DocPageVersion   int       `json:"doc_page_version,omitempty"`
+	DocPageUpdatedAt time.Time `json:"doc_page_updated_at"`
+	// Owner is who is reminded when the asset's documentation goes stale
+	Owner string `json:"owner,omitempty"`
+	// AssociatedTaskCount tracks how many tasks are linked to this asset.
+	// It is derived from the stored task labels when task links are available.
 	AssociatedTaskCount int `json:"associated_task_count"`
 	// Version is used for optimistic locking
 	Version int `json:"version"`
@@ -41,8 +52,12 @@ type Asset struct {
 	Platform string `json:"platform"`
 	// Status represents the current state of the asset
 	Status string `json:"status"`
+	// StatusHistory records the statuses of the asset over time, oldest first
+	StatusHistory []StatusEntry `json:"status_history,omitempty"`
 	// LaunchDate is when the asset was rolled out to production
 	LaunchDate time.Time `json:"launch_date"`
+	// RetiredAt is when the asset was taken out of service; zero while it is in service
+	RetiredAt time.Time `json:"retired_at"`
 	// IsRolledOut100 indicates if the asset is fully rolled out
 	IsRolledOut100 bool `json:"is_rolled_out_100"`
 	// Keywords are terms to match against task titles/descriptions
@@ -59,6 +74,17 @@ type Asset struct {
 	Metrics string `json:"metrics"`
 	// DateStarted is when the asset development started
 	DateStarted time.Time `json:"date_started"`
+	// Impairments are the write-downs recorded against the asset
+	Impairments []Impairment `json:"impairments,omitempty"`
+	// BugFixWindowDays counts bugs completed within this many days after launch as development
+	BugFixWindowDays int `json:"bug_fix_window_days,omitempty"`
+	// GeneratedFields records which fields were written by a language model, keyed by field
+	GeneratedFields map[string]GeneratedField `json:"generated_fields,omitempty"`
+	// ClassificationRules guide the classification of the tasks linked to the asset
+	ClassificationRules *ClassificationRules `json:"classification_rules,omitempty"`
+	// CostCenter and GLAccount are where the capitalized cost of the asset is booked
+	CostCenter string `json:"cost_center,omitempty"`
+	GLAccount  string `json:"gl_account,omitempty"`
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface
@@ -146,7 +172,9 @@ func (a *Asset) UpdateDocumentation() error {
 	return nil
 }
 
-// IncrementTaskCount increments the task count for this asset
+// IncrementTaskCount increments the task count for this asset.
+//
+// Deprecated: task counts are derived from task links, use SetTaskCount.
 func (a *Asset) IncrementTaskCount() error {
 	a.mu.Lock()
 	defer a.mu.Unlock()
@@ -155,7 +183,9 @@ func (a *Asset) IncrementTaskCount() error {
 	return nil
 }
 
-// DecrementTaskCount decrements the task count for this asset
+// DecrementTaskCount decrements the task count for this asset.
+//
+// Deprecated: task counts are derived from task links, use SetTaskCount.
 func (a *Asset) DecrementTaskCount() error {
 	a.mu.Lock()
 	defer a.mu.Unlock()
@@ -167,6 +197,28 @@ func (a *Asset) DecrementTaskCount() error {
 	return nil
 }
 
+// SetTaskCount sets the task count derived from the linked tasks
+func (a *Asset) SetTaskCount(count int) error {
+	if count < 0 {
+		return ErrNegativeTaskCount
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.AssociatedTaskCount = count
+	return nil
+}
+
+// TaskLinkKey returns the key tasks use to reference this asset: its Confluence
+// label once linked, otherwise its name
+func (a *Asset) TaskLinkKey() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if strings.HasPrefix(a.ID, assetLabelPrefix) {
+		return a.ID
+	}
+	return a.Name
+}
+
 // GetTaskCount returns the current task count
 func (a *Asset) GetTaskCount() int {
 	a.mu.RLock()
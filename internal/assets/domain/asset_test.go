@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"sync"
 	"testing"
 	"time"
 
@@ -155,6 +156,35 @@ func TestTaskCountOperations(t *testing.T) {
 	assert.Equal(t, 3, asset.Version)
 }
 
+func TestSetTaskCount(t *testing.T) {
+	asset, err := NewAsset("test-asset", "Test description")
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 10; i++ {
+		wg.Add(1)
+		go func(count int) {
+			defer wg.Done()
+			assert.NoError(t, asset.SetTaskCount(count))
+			_ = asset.GetTaskCount()
+		}(i)
+	}
+	wg.Wait()
+
+	assert.GreaterOrEqual(t, asset.GetTaskCount(), 1)
+	assert.Equal(t, 1, asset.GetVersion(), "derived counts should not bump the version")
+	assert.ErrorIs(t, asset.SetTaskCount(-1), ErrNegativeTaskCount)
+}
+
+func TestTaskLinkKey(t *testing.T) {
+	asset, err := NewAsset("Booking Engine", "Test description")
+	require.NoError(t, err)
+	assert.Equal(t, "Booking Engine", asset.TaskLinkKey())
+
+	asset.ID = "cap-asset-booking"
+	assert.Equal(t, "cap-asset-booking", asset.TaskLinkKey())
+}
+
 func TestGenerateID(t *testing.T) {
 	// Test that IDs are unique
 	id1 := generateID("test-asset")
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Bug-fix window errors
+var (
+	ErrNegativeBugFixWindow = errors.New("bug-fix window cannot be negative")
+	ErrMissingLaunchDate    = errors.New("a bug-fix window needs the asset launch date")
+)
+
+// SetBugFixWindow counts bugs completed within days after the asset's launch as development.
+// Zero days removes the window.
+func (a *Asset) SetBugFixWindow(days int) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if days < 0 {
+		return ErrNegativeBugFixWindow
+	}
+	if days > 0 && a.LaunchDate.IsZero() {
+		return ErrMissingLaunchDate
+	}
+	a.BugFixWindowDays = days
+	a.UpdatedAt = time.Now()
+	a.Version++
+	return nil
+}
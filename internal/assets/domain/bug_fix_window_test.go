@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAsset_SetBugFixWindow(t *testing.T) {
+	launched := &Asset{Name: "booking", LaunchDate: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Version: 1}
+
+	assert.NoError(t, launched.SetBugFixWindow(30))
+	assert.Equal(t, 30, launched.BugFixWindowDays)
+	assert.Equal(t, 2, launched.Version)
+
+	assert.NoError(t, launched.SetBugFixWindow(0), "zero days removes the window")
+	assert.Zero(t, launched.BugFixWindowDays)
+
+	assert.ErrorIs(t, launched.SetBugFixWindow(-1), ErrNegativeBugFixWindow)
+	assert.ErrorIs(t, (&Asset{Name: "draft"}).SetBugFixWindow(30), ErrMissingLaunchDate)
+	assert.NoError(t, (&Asset{Name: "draft"}).SetBugFixWindow(0), "removing a window needs no launch date")
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Classification rule errors
+var (
+	ErrInvalidClassificationRule = errors.New("invalid classification rule")
+	ErrInvalidRuleWorkType       = errors.New("work type must be development, maintenance or discovery")
+)
+
+// ruleWorkTypes are the work types a classification rule may assign
+var ruleWorkTypes = []string{"cap-development", "cap-maintenance", "cap-discovery"}
+
+// ClassificationRule classifies the linked tasks mentioning Keyword as WorkType
+type ClassificationRule struct {
+	Keyword  string `json:"keyword"`
+	WorkType string `json:"work_type"`
+}
+
+// ClassificationRules are the hints an asset gives the classification of its linked tasks
+type ClassificationRules struct {
+	// Keywords are tried in order; the first found in a task's summary or description wins
+	Keywords []ClassificationRule `json:"keywords,omitempty"`
+	// Prompt is added to the instructions of classifiers that accept them
+	Prompt string `json:"prompt,omitempty"`
+}
+
+// Empty reports whether there are no rules
+func (r ClassificationRules) Empty() bool {
+	return len(r.Keywords) == 0 && r.Prompt == ""
+}
+
+// ParseClassificationRule parses a rule written keyword=worktype, e.g. "pipeline backfill=development".
+// The work type may omit its cap- prefix.
+func ParseClassificationRule(value string) (ClassificationRule, error) {
+	i := strings.LastIndex(value, "=")
+	if i < 0 {
+		return ClassificationRule{}, fmt.Errorf("%w %q: must be keyword=worktype", ErrInvalidClassificationRule, value)
+	}
+	keyword := strings.TrimSpace(value[:i])
+	if keyword == "" {
+		return ClassificationRule{}, fmt.Errorf("%w %q: keyword cannot be empty", ErrInvalidClassificationRule, value)
+	}
+	workType := strings.ToLower(strings.TrimSpace(value[i+1:]))
+	if !strings.HasPrefix(workType, "cap-") {
+		workType = "cap-" + workType
+	}
+	for _, valid := range ruleWorkTypes {
+		if workType == valid {
+			return ClassificationRule{Keyword: keyword, WorkType: workType}, nil
+		}
+	}
+	return ClassificationRule{}, fmt.Errorf("%w %q: %w", ErrInvalidClassificationRule, value, ErrInvalidRuleWorkType)
+}
+
+// SetClassificationRules replaces the classification rules of the asset; empty rules remove them
+func (a *Asset) SetClassificationRules(rules ClassificationRules) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if rules.Empty() {
+		a.ClassificationRules = nil
+	} else {
+		a.ClassificationRules = &rules
+	}
+	a.UpdatedAt = time.Now()
+	a.Version++
+}
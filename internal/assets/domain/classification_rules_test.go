@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseClassificationRule(t *testing.T) {
+	rule, err := ParseClassificationRule(" pipeline backfill = Development ")
+	require.NoError(t, err)
+	assert.Equal(t, ClassificationRule{Keyword: "pipeline backfill", WorkType: "cap-development"}, rule)
+
+	rule, err = ParseClassificationRule("a=b=cap-maintenance")
+	require.NoError(t, err)
+	assert.Equal(t, ClassificationRule{Keyword: "a=b", WorkType: "cap-maintenance"}, rule)
+
+	for _, value := range []string{"backfill", "=development", "backfill=research"} {
+		_, err := ParseClassificationRule(value)
+		assert.ErrorIs(t, err, ErrInvalidClassificationRule, value)
+	}
+	_, err = ParseClassificationRule("backfill=research")
+	assert.ErrorIs(t, err, ErrInvalidRuleWorkType)
+}
+
+func TestAsset_SetClassificationRules(t *testing.T) {
+	asset, err := NewAsset("data", "Data platform")
+	require.NoError(t, err)
+
+	asset.SetClassificationRules(ClassificationRules{Prompt: "Backfills are development"})
+	require.NotNil(t, asset.ClassificationRules)
+	assert.Equal(t, "Backfills are development", asset.ClassificationRules.Prompt)
+
+	asset.SetClassificationRules(ClassificationRules{})
+	assert.Nil(t, asset.ClassificationRules)
+	assert.Equal(t, 3, asset.Version)
+}
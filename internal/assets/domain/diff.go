@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CatalogDiffInput selects the two catalogue states to compare
+type CatalogDiffInput struct {
+	// Since compares the current catalogue with its state at the start of this day
+	Since time.Time
+	// From compares with a catalogue snapshot file instead
+	From string
+	// To is the snapshot file compared with From; empty uses the current catalogue
+	To string
+}
+
+// StatusChange records an asset whose status changed between two catalogue states
+type StatusChange struct {
+	Asset  string `json:"asset"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// FieldEdit records a field of an asset edited between two catalogue states
+type FieldEdit struct {
+	Asset  string `json:"asset"`
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// CatalogDiff lists the changes between two states of the asset catalogue
+type CatalogDiff struct {
+	// Reference describes the state the catalogue is compared against
+	Reference     string         `json:"reference"`
+	Added         []string       `json:"added"`
+	Removed       []string       `json:"removed"`
+	StatusChanges []StatusChange `json:"statusChanges"`
+	FieldEdits    []FieldEdit    `json:"fieldEdits"`
+}
+
+// IsEmpty reports whether the two catalogue states are identical
+func (d CatalogDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.StatusChanges) == 0 && len(d.FieldEdits) == 0
+}
+
+// DiffCatalogs compares two states of the asset catalogue. Assets are matched by ID, or
+// by name when they have none, so a renamed asset shows up as an edit of its name.
+// Derived counters, versions and timestamps are not compared.
+func DiffCatalogs(before, after []*Asset) CatalogDiff {
+	diff := CatalogDiff{
+		Added:         []string{},
+		Removed:       []string{},
+		StatusChanges: []StatusChange{},
+		FieldEdits:    []FieldEdit{},
+	}
+
+	previous := make(map[string]*Asset, len(before))
+	for _, asset := range before {
+		previous[catalogKey(asset)] = asset
+	}
+
+	matched := make(map[string]bool, len(after))
+	for _, asset := range sortedByName(after) {
+		key := catalogKey(asset)
+		old, ok := previous[key]
+		if !ok {
+			diff.Added = append(diff.Added, asset.Name)
+			continue
+		}
+		matched[key] = true
+
+		if old.Status != asset.Status {
+			diff.StatusChanges = append(diff.StatusChanges, StatusChange{Asset: asset.Name, Before: old.Status, After: asset.Status})
+		}
+		oldFields, newFields := diffFields(old), diffFields(asset)
+		for _, field := range diffFieldNames {
+			if oldFields[field] != newFields[field] {
+				diff.FieldEdits = append(diff.FieldEdits, FieldEdit{Asset: asset.Name, Field: field, Before: oldFields[field], After: newFields[field]})
+			}
+		}
+	}
+
+	for _, asset := range sortedByName(before) {
+		if !matched[catalogKey(asset)] {
+			diff.Removed = append(diff.Removed, asset.Name)
+		}
+	}
+	return diff
+}
+
+// diffFieldNames are the compared asset fields, in report order
+var diffFieldNames = []string{
+	"name", "description", "platform", "launch_date", "is_rolled_out_100", "keywords",
+	"doc_link", "why", "benefits", "how", "metrics", "date_started", "impairments",
+	"bug_fix_window_days",
+}
+
+// diffFields renders the compared fields of an asset as text
+func diffFields(asset *Asset) map[string]string {
+	impairments := make([]string, 0, len(asset.Impairments))
+	for _, impairment := range asset.Impairments {
+		impairments = append(impairments, fmt.Sprintf("%s %.2f", diffDate(impairment.Date), impairment.Amount))
+	}
+	return map[string]string{
+		"name":                asset.Name,
+		"description":         asset.Description,
+		"platform":            asset.Platform,
+		"launch_date":         diffDate(asset.LaunchDate),
+		"is_rolled_out_100":   strconv.FormatBool(asset.IsRolledOut100),
+		"keywords":            strings.Join(asset.Keywords, ", "),
+		"doc_link":            asset.DocLink,
+		"why":                 asset.Why,
+		"benefits":            asset.Benefits,
+		"how":                 asset.How,
+		"metrics":             asset.Metrics,
+		"date_started":        diffDate(asset.DateStarted),
+		"impairments":         strings.Join(impairments, ", "),
+		"bug_fix_window_days": strconv.Itoa(asset.BugFixWindowDays),
+	}
+}
+
+// diffDate renders a date for comparison, empty when unset
+func diffDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.Format("2006-01-02")
+}
+
+// catalogKey identifies an asset across catalogue states
+func catalogKey(asset *Asset) string {
+	if asset.ID != "" {
+		return "id:" + asset.ID
+	}
+	return "name:" + asset.Name
+}
+
+// sortedByName returns a copy of the assets ordered by name
+func sortedByName(assets []*Asset) []*Asset {
+	sorted := append([]*Asset{}, assets...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffCatalogs(t *testing.T) {
+	before := []*Asset{
+		{ID: "1", Name: "booking", Description: "Booking flow", Status: "development"},
+		{ID: "2", Name: "legacy", Description: "Old search"},
+		{ID: "3", Name: "payments", Description: "Payments", Keywords: []string{"card"}},
+	}
+	after := []*Asset{
+		{ID: "1", Name: "booking", Description: "Booking flow", Status: "live", LaunchDate: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: "3", Name: "payments", Description: "Payments", Keywords: []string{"card", "wallet"}, UpdatedAt: time.Now(), Version: 7},
+		{ID: "4", Name: "search", Description: "New search"},
+	}
+
+	diff := DiffCatalogs(before, after)
+
+	assert.Equal(t, []string{"search"}, diff.Added)
+	assert.Equal(t, []string{"legacy"}, diff.Removed)
+	assert.Equal(t, []StatusChange{{Asset: "booking", Before: "development", After: "live"}}, diff.StatusChanges)
+	assert.Equal(t, []FieldEdit{
+		{Asset: "booking", Field: "launch_date", Before: "", After: "2024-02-01"},
+		{Asset: "payments", Field: "keywords", Before: "card", After: "card, wallet"},
+	}, diff.FieldEdits)
+	assert.False(t, diff.IsEmpty())
+}
+
+func TestDiffCatalogs_MatchesRenamedAssetsByID(t *testing.T) {
+	diff := DiffCatalogs(
+		[]*Asset{{ID: "1", Name: "booking"}, {Name: "draft"}},
+		[]*Asset{{ID: "1", Name: "checkout"}, {Name: "draft"}},
+	)
+
+	assert.Empty(t, diff.Added)
+	assert.Empty(t, diff.Removed)
+	assert.Equal(t, []FieldEdit{{Asset: "checkout", Field: "name", Before: "booking", After: "checkout"}}, diff.FieldEdits)
+}
+
+func TestDiffCatalogs_Unchanged(t *testing.T) {
+	assets := []*Asset{{ID: "1", Name: "booking"}}
+
+	assert.True(t, DiffCatalogs(assets, assets).IsEmpty())
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrNegativeMaxAge is returned for a freshness policy allowing a negative documentation age
+var ErrNegativeMaxAge = errors.New("documentation max age cannot be negative")
+
+// FreshnessPolicy sets how many days an asset's documentation may go without an update
+type FreshnessPolicy struct {
+	// MaxAgeDays is the allowed age by asset status, matched regardless of case; zero exempts a status
+	MaxAgeDays map[string]int
+	// DefaultMaxAgeDays applies to the statuses not listed; zero exempts them
+	DefaultMaxAgeDays int
+}
+
+// Validate checks that no age is negative
+func (p FreshnessPolicy) Validate() error {
+	if p.DefaultMaxAgeDays < 0 {
+		return ErrNegativeMaxAge
+	}
+	for _, days := range p.MaxAgeDays {
+		if days < 0 {
+			return ErrNegativeMaxAge
+		}
+	}
+	return nil
+}
+
+// MaxAge returns the days allowed for the documentation of assets with the status, zero when
+// it never goes stale
+func (p FreshnessPolicy) MaxAge(status string) int {
+	for listed, days := range p.MaxAgeDays {
+		if strings.EqualFold(strings.TrimSpace(listed), strings.TrimSpace(status)) {
+			return days
+		}
+	}
+	return p.DefaultMaxAgeDays
+}
+
+// DocumentationUpdatedAt returns when the asset's documentation was last updated: the later
+// of the recorded update and the last edit of its Confluence page
+func (a *Asset) DocumentationUpdatedAt() time.Time {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.DocPageUpdatedAt.After(a.LastDocUpdateAt) {
+		return a.DocPageUpdatedAt
+	}
+	return a.LastDocUpdateAt
+}
+
+// SetOwner sets who is reminded of the asset's stale documentation; empty removes the owner
+func (a *Asset) SetOwner(owner string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.Owner = strings.TrimSpace(owner)
+	a.UpdatedAt = time.Now()
+	a.Version++
+}
+
+// StaleDocumentation is an asset whose documentation is older than the policy allows
+type StaleDocumentation struct {
+	Asset       string    `json:"asset"`
+	Status      string    `json:"status"`
+	Owner       string    `json:"owner"`
+	DocLink     string    `json:"doc_link"`
+	PageVersion int       `json:"page_version,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	AgeDays     int       `json:"age_days"`
+	MaxAgeDays  int       `json:"max_age_days"`
+}
+
+// StaleDocumentationReport is the stale documentation printed by assets docs stale
+type StaleDocumentationReport struct {
+	Stale []StaleDocumentation `json:"stale"`
+}
+
+// FindStaleDocumentation returns the assets whose documentation is older than the policy
+// allows for the status they had at now, the most overdue first
+func FindStaleDocumentation(assets []*Asset, policy FreshnessPolicy, now time.Time) []StaleDocumentation {
+	var stale []StaleDocumentation
+	for _, asset := range assets {
+		status := asset.StatusAt(now)
+		maxAge := policy.MaxAge(status)
+		if maxAge == 0 {
+			continue
+		}
+		updated := asset.DocumentationUpdatedAt()
+		age := int(now.Sub(updated).Hours() / 24)
+		if age <= maxAge {
+			continue
+		}
+		stale = append(stale, StaleDocumentation{
+			Asset:       asset.Name,
+			Status:      status,
+			Owner:       asset.Owner,
+			DocLink:     asset.DocLink,
+			PageVersion: asset.DocPageVersion,
+			UpdatedAt:   updated,
+			AgeDays:     age,
+			MaxAgeDays:  maxAge,
+		})
+	}
+	sort.SliceStable(stale, func(i, j int) bool {
+		overdueI, overdueJ := stale[i].AgeDays-stale[i].MaxAgeDays, stale[j].AgeDays-stale[j].MaxAgeDays
+		if overdueI != overdueJ {
+			return overdueI > overdueJ
+		}
+		return stale[i].Asset < stale[j].Asset
+	})
+	return stale
+}
+
+// StaleByOwner groups stale documentation by owner, with the assets without one under ""
+func StaleByOwner(stale []StaleDocumentation) map[string][]StaleDocumentation {
+	byOwner := make(map[string][]StaleDocumentation)
+	for _, doc := range stale {
+		byOwner[doc.Owner] = append(byOwner[doc.Owner], doc)
+	}
+	return byOwner
+}
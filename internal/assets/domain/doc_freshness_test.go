@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFreshnessPolicy_MaxAge(t *testing.T) {
+	policy := FreshnessPolicy{MaxAgeDays: map[string]int{"Development": 30, "Retired": 0}, DefaultMaxAgeDays: 180}
+
+	assert.Equal(t, 30, policy.MaxAge("development"))
+	assert.Equal(t, 0, policy.MaxAge(" Retired "))
+	assert.Equal(t, 180, policy.MaxAge("Live"))
+	assert.NoError(t, policy.Validate())
+
+	policy.MaxAgeDays["Live"] = -1
+	assert.ErrorIs(t, policy.Validate(), ErrNegativeMaxAge)
+}
+
+func TestAsset_DocumentationUpdatedAt(t *testing.T) {
+	stamped := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	edited := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	asset := &Asset{LastDocUpdateAt: stamped, DocPageUpdatedAt: edited}
+	assert.Equal(t, edited, asset.DocumentationUpdatedAt())
+
+	asset.DocPageUpdatedAt = stamped.AddDate(0, 0, -1)
+	assert.Equal(t, stamped, asset.DocumentationUpdatedAt())
+}
+
+func TestFindStaleDocumentation(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	assets := []*Asset{
+		{Name: "fresh", Status: "Live", LastDocUpdateAt: now.AddDate(0, 0, -30)},
+		{Name: "ledger", Status: "Live", Owner: "Jane", DocLink: "https://wiki/ledger", DocPageVersion: 7, DocPageUpdatedAt: now.AddDate(0, 0, -45)},
+		{Name: "billing", Status: "Development", Owner: "John", LastDocUpdateAt: now.AddDate(0, 0, -100)},
+		{Name: "archive", Status: "Retired"},
+	}
+	policy := FreshnessPolicy{MaxAgeDays: map[string]int{"development": 90, "retired": 0}, DefaultMaxAgeDays: 40}
+
+	stale := FindStaleDocumentation(assets, policy, now)
+
+	require.Len(t, stale, 2)
+	assert.Equal(t, StaleDocumentation{
+		Asset:      "billing",
+		Status:     "Development",
+		Owner:      "John",
+		UpdatedAt:  now.AddDate(0, 0, -100),
+		AgeDays:    100,
+		MaxAgeDays: 90,
+	}, stale[0], "the most overdue comes first")
+	assert.Equal(t, StaleDocumentation{
+		Asset:       "ledger",
+		Status:      "Live",
+		Owner:       "Jane",
+		DocLink:     "https://wiki/ledger",
+		PageVersion: 7,
+		UpdatedAt:   now.AddDate(0, 0, -45),
+		AgeDays:     45,
+		MaxAgeDays:  40,
+	}, stale[1])
+
+	byOwner := StaleByOwner(append(stale, StaleDocumentation{Asset: "orphan"}))
+	assert.Len(t, byOwner, 3)
+	assert.Equal(t, "orphan", byOwner[""][0].Asset)
+}
+
+func TestFindStaleDocumentation_StatusAtDate(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	asset := &Asset{Name: "billing", Status: "Retired", LastDocUpdateAt: now.AddDate(0, 0, -100), StatusHistory: []StatusEntry{
+		{Status: "Development"},
+		{Status: "Retired", EffectiveFrom: now.AddDate(0, 0, 10)},
+	}}
+	policy := FreshnessPolicy{MaxAgeDays: map[string]int{"development": 90, "retired": 0}}
+
+	stale := FindStaleDocumentation([]*Asset{asset}, policy, now)
+
+	require.Len(t, stale, 1, "the policy applies to the status in effect at the date")
+	assert.Equal(t, "Development", stale[0].Status)
+}
+
+func TestAsset_SetOwner(t *testing.T) {
+	asset := &Asset{Version: 1}
+	asset.SetOwner(" Jane ")
+	assert.Equal(t, "Jane", asset.Owner)
+	assert.Equal(t, 2, asset.Version)
+}
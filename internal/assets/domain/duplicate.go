@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"sort"
+)
+
+// DuplicatePage is one of the documentation pages claiming an asset identifier
+type DuplicatePage struct {
+	Title   string
+	DocLink string
+}
+
+// DuplicateAsset is an asset identifier claimed by several documentation pages. Sync leaves
+// such assets untouched until one page is chosen as the primary.
+type DuplicateAsset struct {
+	ID    string
+	Pages []DuplicatePage
+}
+
+// DuplicateResolution records the primary page chosen for an asset identifier and the pages
+// marked as superseded
+type DuplicateResolution struct {
+	ID      string
+	Primary string
+	// Superseded are the links of the pages marked as superseded
+	Superseded []string
+	// Asset is the local asset now linked to the primary page; nil when the asset is not in
+	// the catalog yet and the next sync will add it
+	Asset *Asset
+}
+
+// SeparateDuplicates splits fetched assets into those with a unique identifier, in their
+// original order, and the identifiers claimed by several of them, by identifier
+func SeparateDuplicates(assets []*Asset) ([]*Asset, []*DuplicateAsset) {
+	count := make(map[string]int, len(assets))
+	for _, asset := range assets {
+		count[asset.ID]++
+	}
+
+	unique := make([]*Asset, 0, len(assets))
+	byID := make(map[string]*DuplicateAsset)
+	for _, asset := range assets {
+		if count[asset.ID] == 1 {
+			unique = append(unique, asset)
+			continue
+		}
+		duplicate, ok := byID[asset.ID]
+		if !ok {
+			duplicate = &DuplicateAsset{ID: asset.ID}
+			byID[asset.ID] = duplicate
+		}
+		duplicate.Pages = append(duplicate.Pages, DuplicatePage{Title: asset.Name, DocLink: asset.DocLink})
+	}
+
+	duplicates := make([]*DuplicateAsset, 0, len(byID))
+	for _, duplicate := range byID {
+		duplicates = append(duplicates, duplicate)
+	}
+	sort.Slice(duplicates, func(i, j int) bool { return duplicates[i].ID < duplicates[j].ID })
+	return unique, duplicates
+}
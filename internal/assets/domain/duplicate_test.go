@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeparateDuplicates(t *testing.T) {
+	booking := &Asset{ID: "cap-asset-booking", Name: "Booking", DocLink: "https://wiki/pages/1"}
+	search := &Asset{ID: "cap-asset-search", Name: "Search", DocLink: "https://wiki/pages/2"}
+	bookingCopy := &Asset{ID: "cap-asset-booking", Name: "Booking (old)", DocLink: "https://wiki/pages/3"}
+	payments := &Asset{ID: "cap-asset-payments", Name: "Payments", DocLink: "https://wiki/pages/4"}
+
+	unique, duplicates := SeparateDuplicates([]*Asset{booking, search, bookingCopy, payments})
+
+	assert.Equal(t, []*Asset{search, payments}, unique)
+	assert.Equal(t, []*DuplicateAsset{{
+		ID: "cap-asset-booking",
+		Pages: []DuplicatePage{
+			{Title: "Booking", DocLink: "https://wiki/pages/1"},
+			{Title: "Booking (old)", DocLink: "https://wiki/pages/3"},
+		},
+	}}, duplicates)
+}
+
+func TestSeparateDuplicates_NoDuplicates(t *testing.T) {
+	assets := []*Asset{{ID: "cap-asset-booking"}, {ID: "cap-asset-search"}}
+
+	unique, duplicates := SeparateDuplicates(assets)
+
+	assert.Equal(t, assets, unique)
+	assert.Empty(t, duplicates)
+}
@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Enrichment-specific errors
+var (
+	ErrUnsupportedEnrichField = errors.New("unsupported field for enrichment")
+	ErrEnrichmentStale        = errors.New("field changed since the enrichment was proposed")
+)
+
+// GeneratedField records that the value of a field was written by a language model
+type GeneratedField struct {
+	// Model is the name of the model that generated the value
+	Model string `json:"model"`
+	// GeneratedAt is when the generated value was accepted
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// EnrichmentProposal is a value generated for a field of an asset, awaiting approval
+type EnrichmentProposal struct {
+	Asset    string
+	Field    string
+	Current  string
+	Proposed string
+	Model    string
+	// Cached is set when the value was reused from an earlier run instead of generated
+	Cached bool
+}
+
+// Changed reports whether the proposed value differs from the current one
+func (p *EnrichmentProposal) Changed() bool {
+	return p.Current != p.Proposed
+}
+
+// EnrichmentCacheKey identifies a value generated for a field of an asset from a version of
+// its Confluence page and a prompt
+type EnrichmentCacheKey struct {
+	PageID      string `json:"page_id"`
+	PageVersion int    `json:"page_version"`
+	Field       string `json:"field"`
+	PromptHash  string `json:"prompt_hash"`
+}
+
+// String returns the key in the page/version/field/hash form
+func (k EnrichmentCacheKey) String() string {
+	return fmt.Sprintf("%s/%d/%s/%s", k.PageID, k.PageVersion, k.Field, k.PromptHash)
+}
+
+// CachedEnrichment is a value generated for a field, kept to skip the model on later runs
+type CachedEnrichment struct {
+	Key      EnrichmentCacheKey `json:"key"`
+	Value    string             `json:"value"`
+	Model    string             `json:"model"`
+	CachedAt time.Time          `json:"cached_at"`
+}
+
+// EnrichmentPromptHash hashes everything an enrichment prompt is built from: the model, the
+// field, the content sent and the asset fields giving context
+func (a *Asset) EnrichmentPromptHash(model, field, content string) string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	hash := sha256.New()
+	for _, part := range []string{model, field, content, a.Name, a.Why, a.Benefits, a.How, a.Metrics} {
+		fmt.Fprintf(hash, "%d:%s;", len(part), part)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// EnrichableFields lists the fields a language model may rewrite
+var EnrichableFields = []string{"description", "why", "benefits", "how", "metrics"}
+
+// FieldValue returns the value of an enrichable field
+func (a *Asset) FieldValue(field string) (string, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	switch field {
+	case "description":
+		return a.Description, nil
+	case "why":
+		return a.Why, nil
+	case "benefits":
+		return a.Benefits, nil
+	case "how":
+		return a.How, nil
+	case "metrics":
+		return a.Metrics, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedEnrichField, field)
+	}
+}
+
+// ApplyGenerated writes a value generated by model into an enrichable field and records its origin
+func (a *Asset) ApplyGenerated(field, value, model string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	switch field {
+	case "description":
+		a.Description = value
+	case "why":
+		a.Why = value
+	case "benefits":
+		a.Benefits = value
+	case "how":
+		a.How = value
+	case "metrics":
+		a.Metrics = value
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedEnrichField, field)
+	}
+	now := time.Now()
+	if a.GeneratedFields == nil {
+		a.GeneratedFields = make(map[string]GeneratedField)
+	}
+	a.GeneratedFields[field] = GeneratedField{Model: model, GeneratedAt: now}
+	a.UpdatedAt = now
+	a.Version++
+	return nil
+}
+
+// KeepGeneratedFields carries over the generation records of previous whose field still holds
+// the same value, so a field rewritten by hand or from Confluence loses its record
+func (a *Asset) KeepGeneratedFields(previous *Asset) {
+	for field, generated := range previous.GeneratedFields {
+		before, err := previous.FieldValue(field)
+		if err != nil {
+			continue
+		}
+		if after, err := a.FieldValue(field); err == nil && after == before {
+			if a.GeneratedFields == nil {
+				a.GeneratedFields = make(map[string]GeneratedField)
+			}
+			a.GeneratedFields[field] = generated
+		}
+	}
+}
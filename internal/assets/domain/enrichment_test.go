@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsset_ApplyGenerated(t *testing.T) {
+	asset, err := NewAsset("booking", "Books trips")
+	require.NoError(t, err)
+
+	require.NoError(t, asset.ApplyGenerated("how", "Generated how", "llama3"))
+	value, err := asset.FieldValue("how")
+	require.NoError(t, err)
+	assert.Equal(t, "Generated how", value)
+	assert.Equal(t, "llama3", asset.GeneratedFields["how"].Model)
+	assert.False(t, asset.GeneratedFields["how"].GeneratedAt.IsZero())
+	assert.Equal(t, 2, asset.Version)
+
+	assert.ErrorIs(t, asset.ApplyGenerated("name", "x", "llama3"), ErrUnsupportedEnrichField)
+	_, err = asset.FieldValue("name")
+	assert.ErrorIs(t, err, ErrUnsupportedEnrichField)
+}
+
+func TestAsset_KeepGeneratedFields(t *testing.T) {
+	previous, err := NewAsset("booking", "Books trips")
+	require.NoError(t, err)
+	require.NoError(t, previous.ApplyGenerated("how", "Generated how", "llama3"))
+	require.NoError(t, previous.ApplyGenerated("why", "Generated why", "llama3"))
+
+	synced, err := NewAsset("booking", "Books trips")
+	require.NoError(t, err)
+	synced.How = "Generated how"
+	synced.Why = "Rewritten on the page"
+
+	synced.KeepGeneratedFields(previous)
+	assert.Contains(t, synced.GeneratedFields, "how")
+	assert.NotContains(t, synced.GeneratedFields, "why")
+}
+
+func TestEnrichmentProposal_Changed(t *testing.T) {
+	assert.True(t, (&EnrichmentProposal{Current: "a", Proposed: "b"}).Changed())
+	assert.False(t, (&EnrichmentProposal{Current: "a", Proposed: "a"}).Changed())
+}
+
+func TestAsset_EnrichmentPromptHash(t *testing.T) {
+	asset, err := NewAsset("booking", "Books trips")
+	require.NoError(t, err)
+	hash := asset.EnrichmentPromptHash("llama3", "how", "content")
+
+	assert.Equal(t, hash, asset.EnrichmentPromptHash("llama3", "how", "content"))
+	assert.NotEqual(t, hash, asset.EnrichmentPromptHash("mistral", "how", "content"), "the model is part of the prompt")
+	assert.NotEqual(t, hash, asset.EnrichmentPromptHash("llama3", "why", "content"))
+	assert.NotEqual(t, hash, asset.EnrichmentPromptHash("llama3", "how", "other content"))
+
+	asset.Why = "Faster bookings"
+	assert.NotEqual(t, hash, asset.EnrichmentPromptHash("llama3", "how", "content"), "the asset context is part of the prompt")
+}
+
+func TestEnrichmentCacheKey_String(t *testing.T) {
+	key := EnrichmentCacheKey{PageID: "123", PageVersion: 4, Field: "how", PromptHash: "abc"}
+	assert.Equal(t, "123/4/how/abc", key.String())
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// Impairment-specific errors
+var (
+	ErrMissingImpairmentDate   = errors.New("impairment date cannot be empty")
+	ErrEmptyImpairmentReason   = errors.New("impairment reason cannot be empty")
+	ErrInvalidImpairmentAmount = errors.New("impairment amount must be greater than zero")
+)
+
+// Impairment records a write-down of a capitalized asset
+type Impairment struct {
+	// Date is when the impairment takes effect
+	Date time.Time `json:"date"`
+	// Reason explains why the asset was written down
+	Reason string `json:"reason"`
+	// Amount is the value written down
+	Amount float64 `json:"amount"`
+	// RecordedAt is when the impairment was recorded
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// NewImpairment creates a new Impairment instance
+func NewImpairment(date time.Time, reason string, amount float64) (Impairment, error) {
+	if date.IsZero() {
+		return Impairment{}, ErrMissingImpairmentDate
+	}
+	if reason == "" {
+		return Impairment{}, ErrEmptyImpairmentReason
+	}
+	if amount <= 0 {
+		return Impairment{}, ErrInvalidImpairmentAmount
+	}
+	return Impairment{
+		Date:       date,
+		Reason:     reason,
+		Amount:     amount,
+		RecordedAt: time.Now(),
+	}, nil
+}
+
+// RecordImpairment adds an impairment to the asset, keeping impairments ordered by date
+func (a *Asset) RecordImpairment(impairment Impairment) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.Impairments = append(a.Impairments, impairment)
+	sort.SliceStable(a.Impairments, func(i, j int) bool {
+		return a.Impairments[i].Date.Before(a.Impairments[j].Date)
+	})
+	a.UpdatedAt = time.Now()
+	a.Version++
+	return nil
+}
+
+// TotalImpairment returns the sum of all amounts written down
+func (a *Asset) TotalImpairment() float64 {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	var total float64
+	for _, impairment := range a.Impairments {
+		total += impairment.Amount
+	}
+	return total
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewImpairment(t *testing.T) {
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		date    time.Time
+		reason  string
+		amount  float64
+		wantErr error
+	}{
+		{name: "valid impairment", date: date, reason: "Sunset", amount: 1000},
+		{name: "missing date", reason: "Sunset", amount: 1000, wantErr: ErrMissingImpairmentDate},
+		{name: "empty reason", date: date, amount: 1000, wantErr: ErrEmptyImpairmentReason},
+		{name: "zero amount", date: date, reason: "Sunset", wantErr: ErrInvalidImpairmentAmount},
+		{name: "negative amount", date: date, reason: "Sunset", amount: -5, wantErr: ErrInvalidImpairmentAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			impairment, err := NewImpairment(tt.date, tt.reason, tt.amount)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.date, impairment.Date)
+			assert.Equal(t, tt.reason, impairment.Reason)
+			assert.Equal(t, tt.amount, impairment.Amount)
+			assert.False(t, impairment.RecordedAt.IsZero())
+		})
+	}
+}
+
+func TestAsset_RecordImpairment(t *testing.T) {
+	asset, err := NewAsset("booking", "Booking engine")
+	require.NoError(t, err)
+
+	later, err := NewImpairment(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), "Partial sunset", 300)
+	require.NoError(t, err)
+	earlier, err := NewImpairment(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), "Market change", 700)
+	require.NoError(t, err)
+
+	require.NoError(t, asset.RecordImpairment(later))
+	require.NoError(t, asset.RecordImpairment(earlier))
+
+	assert.Equal(t, []Impairment{earlier, later}, asset.Impairments)
+	assert.Equal(t, 1000.0, asset.TotalImpairment())
+	assert.Equal(t, 3, asset.GetVersion())
+}
@@ -0,0 +1,58 @@
+package domain
+
+import "time"
+
+// liveFieldNames are the fields read from a Confluence page, in display order
+var liveFieldNames = []string{
+	"description", "platform", "status", "launch_date", "is_rolled_out_100", "keywords",
+	"why", "benefits", "how", "metrics",
+}
+
+// FieldDrift compares the local value of an asset field with the value on its Confluence page
+type FieldDrift struct {
+	Field string `json:"field"`
+	Local string `json:"local"`
+	Live  string `json:"live"`
+}
+
+// Drifted reports whether the page no longer matches the local value
+func (d FieldDrift) Drifted() bool {
+	return d.Local != d.Live
+}
+
+// LivePreview compares an asset with the current state of its Confluence page
+type LivePreview struct {
+	Asset       string       `json:"asset"`
+	DocLink     string       `json:"docLink"`
+	PageVersion int          `json:"pageVersion"`
+	PageUpdated time.Time    `json:"pageUpdated"`
+	Fields      []FieldDrift `json:"fields"`
+}
+
+// Drifted returns the fields whose page value differs from the local one
+func (p LivePreview) Drifted() []FieldDrift {
+	var drifted []FieldDrift
+	for _, field := range p.Fields {
+		if field.Drifted() {
+			drifted = append(drifted, field)
+		}
+	}
+	return drifted
+}
+
+// CompareLive compares the page-sourced fields of a local asset with the asset read from
+// its Confluence page
+func CompareLive(local, live *Asset) LivePreview {
+	preview := LivePreview{
+		Asset:       local.Name,
+		DocLink:     local.DocLink,
+		PageVersion: live.DocPageVersion,
+		PageUpdated: live.DocPageUpdatedAt,
+	}
+	localFields, liveFields := diffFields(local), diffFields(live)
+	localFields["status"], liveFields["status"] = local.Status, live.Status
+	for _, field := range liveFieldNames {
+		preview.Fields = append(preview.Fields, FieldDrift{Field: field, Local: localFields[field], Live: liveFields[field]})
+	}
+	return preview
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareLive(t *testing.T) {
+	launch := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	local := &Asset{
+		Name:        "Booking Engine",
+		DocLink:     "https://example.atlassian.net/wiki/spaces/SPACE/pages/123456/Booking",
+		Description: "Books rooms",
+		Status:      "Live",
+		LaunchDate:  launch,
+		Keywords:    []string{"booking"},
+		Why:         "Grow bookings",
+	}
+	live := &Asset{
+		Name:           "Booking",
+		Description:    "Books rooms and flights",
+		Status:         "Live",
+		LaunchDate:     launch,
+		IsRolledOut100: true,
+		Keywords:       []string{"booking"},
+		Why:            "Grow bookings",
+		DocPageVersion: 7,
+	}
+
+	preview := CompareLive(local, live)
+
+	assert.Equal(t, "Booking Engine", preview.Asset)
+	assert.Equal(t, local.DocLink, preview.DocLink)
+	assert.Equal(t, 7, preview.PageVersion)
+	assert.Len(t, preview.Fields, len(liveFieldNames))
+	assert.Equal(t, FieldDrift{Field: "status", Local: "Live", Live: "Live"}, preview.Fields[2])
+	assert.Equal(t, []FieldDrift{
+		{Field: "description", Local: "Books rooms", Live: "Books rooms and flights"},
+		{Field: "is_rolled_out_100", Local: "false", Live: "true"},
+	}, preview.Drifted())
+}
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"time"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+)
+
+// AssetHistory defines the interface for looking up past states of the asset catalogue
+type AssetHistory interface {
+	// CatalogAt returns the catalogue as it was at the start of the given day, and the date
+	// of the snapshot it was read from. A zero date means the catalogue has not changed since.
+	CatalogAt(day time.Time) ([]*domain.Asset, time.Time, error)
+}
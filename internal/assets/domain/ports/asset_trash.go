@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+)
+
+// AssetTrash defines the interface of repositories that keep deleted assets for a while
+type AssetTrash interface {
+	// FindDeleted returns the deleted assets that have not expired yet, most recent first
+	FindDeleted() ([]*domain.DeletedAsset, error)
+	// Restore moves a deleted asset back into the catalogue
+	Restore(name string) (*domain.Asset, error)
+	// Purge permanently removes an asset, from the catalogue or the trash
+	Purge(name string) error
+}
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+)
+
+// EnrichmentCache defines the interface of repositories that keep generated field values,
+// so unchanged pages are not sent to the language model again
+type EnrichmentCache interface {
+	// FindEnrichment returns the value cached under a key, or nil when none is cached
+	FindEnrichment(key domain.EnrichmentCacheKey) (*domain.CachedEnrichment, error)
+	// SaveEnrichment caches a generated value, replacing any previous one of the same key
+	SaveEnrichment(entry domain.CachedEnrichment) error
+}
@@ -0,0 +1,11 @@
+package ports
+
+import "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+
+// TaskLinkPort defines the interface for looking up the tasks linked to an asset
+type TaskLinkPort interface {
+	// CountLinkedTasks returns the number of stored tasks linked to the asset with the given key
+	CountLinkedTasks(assetKey string) (int, error)
+	// FindLinkedTasks returns the stored tasks linked to the asset with the given key
+	FindLinkedTasks(assetKey string) ([]domain.LinkedTask, error)
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// StatusRetired is the status of an asset taken out of service
+const StatusRetired = "Retired"
+
+// Retirement errors
+var (
+	ErrMissingRetirementDate = errors.New("retirement date cannot be empty")
+	ErrAlreadyRetired        = errors.New("asset is already retired")
+	ErrRetiredBeforeLaunch   = errors.New("an asset cannot be retired before its launch")
+)
+
+// Retire takes the asset out of service at a date: its status becomes Retired from that date and
+// work started after it is no longer attributed to the asset
+func (a *Asset) Retire(date time.Time) error {
+	if date.IsZero() {
+		return ErrMissingRetirementDate
+	}
+	a.mu.RLock()
+	retired, launched := a.RetiredAt, a.LaunchDate
+	a.mu.RUnlock()
+	if !retired.IsZero() {
+		return ErrAlreadyRetired
+	}
+	if !launched.IsZero() && date.Before(launched) {
+		return ErrRetiredBeforeLaunch
+	}
+
+	if err := a.SetStatus(StatusRetired, date, StatusSourceManual); err != nil {
+		return err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.RetiredAt = date
+	return nil
+}
+
+// IsRetired reports whether the asset was retired
+func (a *Asset) IsRetired() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return !a.RetiredAt.IsZero()
+}
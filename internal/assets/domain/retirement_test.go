@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsset_Retire(t *testing.T) {
+	asset, err := NewAsset("booking", "Books trips")
+	require.NoError(t, err)
+	asset.Status = "Live"
+	asset.LaunchDate = time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.ErrorIs(t, asset.Retire(time.Time{}), ErrMissingRetirementDate)
+	assert.ErrorIs(t, asset.Retire(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)), ErrRetiredBeforeLaunch)
+	assert.ErrorIs(t, asset.Retire(time.Now().AddDate(0, 1, 0)), ErrFutureStatusEffective)
+	assert.False(t, asset.IsRetired())
+
+	retired := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, asset.Retire(retired))
+	assert.True(t, asset.IsRetired())
+	assert.Equal(t, retired, asset.RetiredAt)
+	assert.Equal(t, StatusRetired, asset.Status)
+	assert.Equal(t, "Live", asset.StatusAt(retired.AddDate(0, 0, -1)))
+
+	assert.ErrorIs(t, asset.Retire(retired), ErrAlreadyRetired)
+}
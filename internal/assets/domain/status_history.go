@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Status history errors
+var (
+	ErrEmptyStatus           = errors.New("status cannot be empty")
+	ErrFutureStatusEffective = errors.New("a status cannot take effect in the future")
+)
+
+// Sources of the recorded status changes
+const (
+	// StatusSourceInitial marks the status an asset had before its changes were recorded
+	StatusSourceInitial = "initial"
+	StatusSourceSync    = "sync"
+	StatusSourceManual  = "manual"
+)
+
+// StatusEntry is a status of an asset and the date it took effect
+type StatusEntry struct {
+	Status        string    `json:"status"`
+	EffectiveFrom time.Time `json:"effective_from"`
+	Source        string    `json:"source"`
+}
+
+// SetStatus records a status taking effect at a date, keeping the history ordered by date.
+// The status the asset had before is kept as its initial status, and a status already in
+// effect at the date is not recorded again.
+func (a *Asset) SetStatus(status string, effective time.Time, source string) error {
+	status = strings.TrimSpace(status)
+	if status == "" {
+		return ErrEmptyStatus
+	}
+	now := time.Now()
+	if effective.After(now) {
+		return ErrFutureStatusEffective
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.statusAt(effective) == status {
+		return nil
+	}
+	if len(a.StatusHistory) == 0 && a.Status != "" {
+		a.StatusHistory = []StatusEntry{{Status: a.Status, Source: StatusSourceInitial}}
+	}
+
+	history := make([]StatusEntry, 0, len(a.StatusHistory)+1)
+	for _, entry := range a.StatusHistory {
+		if !entry.EffectiveFrom.Equal(effective) {
+			history = append(history, entry)
+		}
+	}
+	history = append(history, StatusEntry{Status: status, EffectiveFrom: effective, Source: source})
+	sort.SliceStable(history, func(i, j int) bool { return history[i].EffectiveFrom.Before(history[j].EffectiveFrom) })
+
+	a.StatusHistory = history
+	a.Status = history[len(history)-1].Status
+	a.UpdatedAt = now
+	a.Version++
+	return nil
+}
+
+// StatusAt returns the status the asset had at a point in time. Without a recorded history
+// it is the current status; before the first recorded change it is empty.
+func (a *Asset) StatusAt(at time.Time) string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.statusAt(at)
+}
+
+// statusAt resolves the status at a point in time; callers hold the lock
+func (a *Asset) statusAt(at time.Time) string {
+	if len(a.StatusHistory) == 0 {
+		return a.Status
+	}
+	status := ""
+	for _, entry := range a.StatusHistory {
+		if entry.EffectiveFrom.After(at) {
+			break
+		}
+		status = entry.Status
+	}
+	return status
+}
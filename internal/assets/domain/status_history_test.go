@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsset_SetStatus(t *testing.T) {
+	asset, err := NewAsset("booking", "Books trips")
+	require.NoError(t, err)
+	asset.Status = "development"
+	launch := time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)
+
+	require.NoError(t, asset.SetStatus("live", launch, StatusSourceSync))
+	assert.Equal(t, "live", asset.Status)
+	assert.Equal(t, []StatusEntry{
+		{Status: "development", Source: StatusSourceInitial},
+		{Status: "live", EffectiveFrom: launch, Source: StatusSourceSync},
+	}, asset.StatusHistory)
+	assert.Equal(t, 2, asset.Version)
+
+	require.NoError(t, asset.SetStatus("live", launch.AddDate(0, 1, 0), StatusSourceSync))
+	assert.Len(t, asset.StatusHistory, 2, "a status already in effect is not recorded again")
+
+	beta := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, asset.SetStatus("beta", beta, StatusSourceManual))
+	assert.Equal(t, "live", asset.Status, "an earlier change keeps the latest status current")
+	assert.Equal(t, []string{"development", "beta", "live"}, statuses(asset.StatusHistory))
+
+	require.NoError(t, asset.SetStatus("pilot", beta, StatusSourceManual))
+	assert.Equal(t, []string{"development", "pilot", "live"}, statuses(asset.StatusHistory), "a change on the same date replaces it")
+
+	assert.ErrorIs(t, asset.SetStatus(" ", beta, StatusSourceManual), ErrEmptyStatus)
+	assert.ErrorIs(t, asset.SetStatus("retired", time.Now().Add(time.Hour), StatusSourceManual), ErrFutureStatusEffective)
+}
+
+func TestAsset_SetStatus_Unchanged(t *testing.T) {
+	asset, err := NewAsset("booking", "Books trips")
+	require.NoError(t, err)
+	asset.Status = "live"
+
+	require.NoError(t, asset.SetStatus("live", time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC), StatusSourceSync))
+	assert.Empty(t, asset.StatusHistory)
+	assert.Equal(t, 1, asset.Version)
+}
+
+func TestAsset_StatusAt(t *testing.T) {
+	asset := &Asset{Status: "live"}
+	assert.Equal(t, "live", asset.StatusAt(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)), "without history the current status applies")
+
+	asset.StatusHistory = []StatusEntry{
+		{Status: "development", EffectiveFrom: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)},
+		{Status: "live", EffectiveFrom: time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)},
+	}
+	assert.Empty(t, asset.StatusAt(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "development", asset.StatusAt(time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "live", asset.StatusAt(time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)))
+}
+
+func statuses(history []StatusEntry) []string {
+	result := make([]string, 0, len(history))
+	for _, entry := range history {
+		result = append(result, entry.Status)
+	}
+	return result
+}
@@ -1,9 +1,35 @@
 package domain
 
+// SyncInput selects the Confluence pages synced into the catalogue
+type SyncInput struct {
+	// Spaces are the Confluence space keys searched, e.g. one per tribe
+	Spaces []string
+	// Label filters the pages of every space
+	Label string
+	Debug bool
+	// Progress, when set, is told about each space once its pages are fetched
+	Progress func(space SpaceSync)
+}
+
 // SyncResult represents the result of a sync operation
 type SyncResult struct {
 	SyncedAssets    []*Asset
 	NotSyncedAssets []*NotSyncedAsset
+	// Duplicates are the asset identifiers claimed by several pages, none of which was synced
+	Duplicates []*DuplicateAsset
+	// Spaces summarises the sync of each space, in the order they were given
+	Spaces []*SpaceSync
+}
+
+// SpaceSync counts the pages fetched from a Confluence space and what became of them
+type SpaceSync struct {
+	Space     string
+	Fetched   int
+	Synced    int
+	NotSynced int
+	// Duplicates counts the pages whose asset identifier another page also claims,
+	// in this space or another one
+	Duplicates int
 }
 
 // NotSyncedAsset represents an asset that couldn't be synced due to missing information
@@ -18,5 +44,16 @@ func NewSyncResult() *SyncResult {
 	return &SyncResult{
 		SyncedAssets:    make([]*Asset, 0),
 		NotSyncedAssets: make([]*NotSyncedAsset, 0),
+		Duplicates:      make([]*DuplicateAsset, 0),
+		Spaces:          make([]*SpaceSync, 0),
+	}
+}
+
+// Total returns the number of pages fetched, synced or not
+func (r *SyncResult) Total() int {
+	total := len(r.SyncedAssets) + len(r.NotSyncedAssets)
+	for _, duplicate := range r.Duplicates {
+		total += len(duplicate.Pages)
 	}
+	return total
 }
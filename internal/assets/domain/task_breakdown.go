@@ -0,0 +1,87 @@
+package domain
+
+import "sort"
+
+// UnclassifiedWorkType labels the linked tasks without a work type
+const UnclassifiedWorkType = "unclassified"
+
+// LinkedTask is a stored task linked to an asset, reduced to what the breakdown counts
+type LinkedTask struct {
+	Key      string
+	Sprint   string
+	WorkType string
+}
+
+// WorkTypeCount is the number of linked tasks of one work type
+type WorkTypeCount struct {
+	WorkType string `json:"work_type"`
+	Tasks    int    `json:"tasks"`
+}
+
+// SprintTaskCount is the number of linked tasks of one sprint, split by work type
+type SprintTaskCount struct {
+	Sprint    string          `json:"sprint"`
+	Tasks     int             `json:"tasks"`
+	WorkTypes []WorkTypeCount `json:"work_types"`
+}
+
+// TaskBreakdown counts the tasks linked to an asset by work type and by sprint
+type TaskBreakdown struct {
+	Asset     string          `json:"asset"`
+	Tasks     int             `json:"tasks"`
+	WorkTypes []WorkTypeCount `json:"work_types"`
+	// Sprints are ordered by name, with tasks outside any sprint last under an empty name
+	Sprints []SprintTaskCount `json:"sprints"`
+}
+
+// NewTaskBreakdown counts the linked tasks of an asset
+func NewTaskBreakdown(asset string, tasks []LinkedTask) *TaskBreakdown {
+	breakdown := &TaskBreakdown{Asset: asset, Tasks: len(tasks)}
+	bySprint := make(map[string][]LinkedTask)
+	for _, task := range tasks {
+		bySprint[task.Sprint] = append(bySprint[task.Sprint], task)
+	}
+	breakdown.WorkTypes = countWorkTypes(tasks)
+
+	sprints := make([]string, 0, len(bySprint))
+	for sprint := range bySprint {
+		sprints = append(sprints, sprint)
+	}
+	sort.Slice(sprints, func(i, j int) bool {
+		if sprints[i] == "" || sprints[j] == "" {
+			return sprints[j] == "" && sprints[i] != ""
+		}
+		return sprints[i] < sprints[j]
+	})
+	for _, sprint := range sprints {
+		breakdown.Sprints = append(breakdown.Sprints, SprintTaskCount{
+			Sprint:    sprint,
+			Tasks:     len(bySprint[sprint]),
+			WorkTypes: countWorkTypes(bySprint[sprint]),
+		})
+	}
+	return breakdown
+}
+
+// countWorkTypes counts the tasks by work type, most frequent first
+func countWorkTypes(tasks []LinkedTask) []WorkTypeCount {
+	counts := make(map[string]int)
+	for _, task := range tasks {
+		workType := task.WorkType
+		if workType == "" {
+			workType = UnclassifiedWorkType
+		}
+		counts[workType]++
+	}
+	result := make([]WorkTypeCount, 0, len(counts))
+	for workType, count := range counts {
+		result = append(result, WorkTypeCount{WorkType: workType, Tasks: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Tasks != result[j].Tasks {
+			return result[i].Tasks > result[j].Tasks
+		}
+		return result[i].WorkType < result[j].WorkType
+	})
+	return result
+}
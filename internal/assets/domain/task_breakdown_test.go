@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTaskBreakdown(t *testing.T) {
+	breakdown := NewTaskBreakdown("Booking", []LinkedTask{
+		{Key: "FN-1", Sprint: "Sprint 2", WorkType: "cap-development"},
+		{Key: "FN-2", Sprint: "Sprint 1", WorkType: "cap-maintenance"},
+		{Key: "FN-3", Sprint: "Sprint 2", WorkType: "cap-development"},
+		{Key: "FN-4", WorkType: "cap-maintenance"},
+		{Key: "FN-5", Sprint: "Sprint 2"},
+	})
+
+	assert.Equal(t, "Booking", breakdown.Asset)
+	assert.Equal(t, 5, breakdown.Tasks)
+	assert.Equal(t, []WorkTypeCount{
+		{WorkType: "cap-development", Tasks: 2},
+		{WorkType: "cap-maintenance", Tasks: 2},
+		{WorkType: UnclassifiedWorkType, Tasks: 1},
+	}, breakdown.WorkTypes)
+	assert.Equal(t, []SprintTaskCount{
+		{Sprint: "Sprint 1", Tasks: 1, WorkTypes: []WorkTypeCount{{WorkType: "cap-maintenance", Tasks: 1}}},
+		{Sprint: "Sprint 2", Tasks: 3, WorkTypes: []WorkTypeCount{{WorkType: "cap-development", Tasks: 2}, {WorkType: UnclassifiedWorkType, Tasks: 1}}},
+		{Sprint: "", Tasks: 1, WorkTypes: []WorkTypeCount{{WorkType: "cap-maintenance", Tasks: 1}}},
+	}, breakdown.Sprints)
+}
+
+func TestNewTaskBreakdown_NoTasks(t *testing.T) {
+	breakdown := NewTaskBreakdown("Booking", nil)
+
+	assert.Zero(t, breakdown.Tasks)
+	assert.Empty(t, breakdown.WorkTypes)
+	assert.Empty(t, breakdown.Sprints)
+}
@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// DefaultTrashRetention is how long deleted records are kept before they expire
+const DefaultTrashRetention = 30 * 24 * time.Hour
+
+// DeletedAsset is an asset kept in the trash after deletion
+type DeletedAsset struct {
+	Asset     *Asset    `json:"asset"`
+	DeletedAt time.Time `json:"deletedAt"`
+	// ExpiresAt is when the record is removed from the trash for good
+	ExpiresAt time.Time `json:"-"`
+}
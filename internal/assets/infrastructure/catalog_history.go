@@ -0,0 +1,137 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain/ports"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/schema"
+)
+
+// snapshotDir is the directory, next to the catalogue file, holding its daily snapshots
+const snapshotDir = "snapshots"
+
+// snapshotDateFormat is the date suffix of snapshot file names
+const snapshotDateFormat = "2006-01-02"
+
+// Ensure JSONRepository keeps the catalogue history
+var _ ports.AssetHistory = (*JSONRepository)(nil)
+
+// ReadCatalogFile reads a catalogue snapshot, in the format of the assets file
+func ReadCatalogFile(path string) ([]*domain.Asset, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read catalogue file: %w", err)
+	}
+	if err := schema.Validate(schema.Assets, data); err != nil {
+		return nil, fmt.Errorf("invalid catalogue file %s: %w", path, err)
+	}
+
+	var assets map[string]*domain.Asset
+	if err := json.Unmarshal(data, &assets); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal catalogue file %s: %w", path, err)
+	}
+
+	result := make([]*domain.Asset, 0, len(assets))
+	for _, asset := range assets {
+		result = append(result, asset)
+	}
+	return result, nil
+}
+
+// CatalogAt returns the catalogue as it was at the start of the given day. Every snapshot
+// holds the catalogue before the first change of its day, so the earliest snapshot taken
+// on or after the day reflects it; without one, nothing changed since.
+func (r *JSONRepository) CatalogAt(day time.Time) ([]*domain.Asset, time.Time, error) {
+	dates, err := r.snapshotDates()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	for _, date := range dates {
+		if date.Before(day) {
+			continue
+		}
+		assets, err := ReadCatalogFile(r.snapshotPath(date))
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		return assets, date, nil
+	}
+
+	assets, err := r.FindAll()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return assets, time.Time{}, nil
+}
+
+// recordSnapshot keeps a copy of the catalogue before its first change of the day
+func (r *JSONRepository) recordSnapshot() error {
+	path := r.snapshotPath(r.now().UTC())
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(r.dir, r.file))
+	if os.IsNotExist(err) {
+		// The catalogue starts out empty
+		data, err = []byte("{}"), nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), DefaultConfig().DirMode); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, DefaultConfig().FileMode); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// snapshotDates returns the days with a catalogue snapshot, oldest first
+func (r *JSONRepository) snapshotDates() ([]time.Time, error) {
+	entries, err := os.ReadDir(filepath.Join(r.dir, snapshotDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read snapshot directory: %w", err)
+	}
+
+	prefix := r.snapshotPrefix()
+	var dates []time.Time
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		date, err := time.Parse(snapshotDateFormat, strings.TrimSuffix(strings.TrimPrefix(name, prefix), filepath.Ext(r.file)))
+		if err != nil {
+			continue
+		}
+		dates = append(dates, date)
+	}
+	sort.Slice(dates, func(i, j int) bool {
+		return dates[i].Before(dates[j])
+	})
+	return dates, nil
+}
+
+// snapshotPath returns the path of the snapshot taken on the given day
+func (r *JSONRepository) snapshotPath(day time.Time) string {
+	return filepath.Join(r.dir, snapshotDir, r.snapshotPrefix()+day.Format(snapshotDateFormat)+filepath.Ext(r.file))
+}
+
+// snapshotPrefix returns the file name prefix of the catalogue's snapshots
+func (r *JSONRepository) snapshotPrefix() string {
+	return strings.TrimSuffix(r.file, filepath.Ext(r.file)) + "-"
+}
@@ -0,0 +1,80 @@
+package infrastructure
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+)
+
+func newHistoryRepository(t *testing.T) (*JSONRepository, *time.Time) {
+	t.Helper()
+	repo := NewJSONRepository(RepositoryConfig{Directory: t.TempDir(), Filename: "assets.json"}).(*JSONRepository)
+	now := time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)
+	repo.now = func() time.Time { return now }
+	return repo, &now
+}
+
+func assetNames(assets []*domain.Asset) []string {
+	names := make([]string, 0, len(assets))
+	for _, asset := range assets {
+		names = append(names, asset.Name)
+	}
+	return names
+}
+
+func TestJSONRepository_CatalogAt(t *testing.T) {
+	repo, now := newHistoryRepository(t)
+
+	require.NoError(t, repo.Save(&domain.Asset{ID: "1", Name: "booking"}))
+	require.NoError(t, repo.Save(&domain.Asset{ID: "2", Name: "search"}))
+	*now = time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.Save(&domain.Asset{ID: "3", Name: "payments"}))
+
+	t.Run("keeps one snapshot per day of change", func(t *testing.T) {
+		entries, err := os.ReadDir(filepath.Join(repo.dir, snapshotDir))
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, "assets-2024-01-10.json", entries[0].Name())
+		assert.Equal(t, "assets-2024-01-15.json", entries[1].Name())
+	})
+
+	t.Run("reads the state at the start of a day", func(t *testing.T) {
+		assets, snapshotDate, err := repo.CatalogAt(time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), snapshotDate)
+		assert.ElementsMatch(t, []string{"booking", "search"}, assetNames(assets))
+	})
+
+	t.Run("starts from an empty catalogue", func(t *testing.T) {
+		assets, snapshotDate, err := repo.CatalogAt(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), snapshotDate)
+		assert.Empty(t, assets)
+	})
+
+	t.Run("uses the current catalogue without later changes", func(t *testing.T) {
+		assets, snapshotDate, err := repo.CatalogAt(time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.True(t, snapshotDate.IsZero())
+		assert.ElementsMatch(t, []string{"booking", "search", "payments"}, assetNames(assets))
+	})
+}
+
+func TestReadCatalogFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalogue.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"booking": {"id": "1", "name": "booking", "status": "live"}}`), 0644))
+
+	assets, err := ReadCatalogFile(path)
+	require.NoError(t, err)
+	require.Len(t, assets, 1)
+	assert.Equal(t, "live", assets[0].Status)
+
+	_, err = ReadCatalogFile(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/common"
 	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/redact"
 )
 
 // Page represents a page in Confluence
@@ -22,10 +23,8 @@ type Page struct {
 	Space struct {
 		Key string `json:"key"`
 	} `json:"space"`
-	Version struct {
-		Number int `json:"number"`
-	} `json:"version"`
-	Body struct {
+	Version PageVersion `json:"version"`
+	Body    struct {
 		Storage struct {
 			Value string `json:"value"`
 		} `json:"storage"`
@@ -42,6 +41,12 @@ type Page struct {
 	} `json:"metadata"`
 }
 
+// PageVersion is the version number of a page and when it was made
+type PageVersion struct {
+	Number int       `json:"number"`
+	When   time.Time `json:"when"`
+}
+
 // Response represents the response from the Confluence API
 type Response struct {
 	Results []Page `json:"results"`
@@ -68,13 +73,20 @@ type Adapter struct {
 	httpClient *http.Client
 }
 
+// DefaultTimeout bounds each Confluence request when no HTTP client is configured
+const DefaultTimeout = 30 * time.Second
+
 // NewAdapter creates a new Confluence adapter
 func NewAdapter(config *Config) *Adapter {
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: DefaultTimeout,
+		}
+	}
 	return &Adapter{
-		config: config,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		config:     config,
+		httpClient: httpClient,
 	}
 }
 
@@ -91,20 +103,19 @@ func (a *Adapter) getSpaceID(ctx context.Context) (string, error) {
 	req.SetBasicAuth(a.config.Username, a.config.Token)
 	req.Header.Set("Accept", "application/json")
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := a.httpClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to make request: %v", err)
+		return "", redact.Error(fmt.Errorf("failed to make request: %v", err))
 	}
 	defer resp.Body.Close()
 
 	body, _ := io.ReadAll(resp.Body)
 	if a.config.Debug {
-		fmt.Printf("Space response status: %d\nResponse body: %s\n", resp.StatusCode, string(body))
+		fmt.Printf("Space response status: %d\nResponse body: %s\n", resp.StatusCode, redact.String(string(body)))
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+		return "", fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, redact.String(string(body)))
 	}
 
 	var result SpaceResponse
@@ -131,93 +142,69 @@ func (a *Adapter) buildSearchURL(spaceID string) string {
 	return searchURL + "?" + query.Encode()
 }
 
-// FetchAssets retrieves assets from Confluence
+// FetchAssets retrieves assets from Confluence, fetching the page bodies concurrently.
+// The pages are searched by label, within the configured space when one is set.
 func (a *Adapter) FetchAssets(ctx context.Context) ([]*domain.Asset, error) {
 	baseURL := strings.TrimRight(a.config.BaseURL, "/")
-	url := fmt.Sprintf("%s/wiki/rest/api/content/search?cql=type=page%%20AND%%20label=%%22%s%%22&expand=version,metadata.labels&limit=%d",
-		baseURL, a.config.Label, a.config.MaxResults)
-	if a.config.Debug {
-		fmt.Printf("Fetching pages from URL: %s\n", url)
+	cql := fmt.Sprintf(`type=page AND label="%s"`, a.config.Label)
+	if a.config.SpaceKey != "" {
+		cql += fmt.Sprintf(` AND space="%s"`, a.config.SpaceKey)
 	}
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %v", err)
+	searchURL := fmt.Sprintf("%s/wiki/rest/api/content/search?cql=%s&expand=version,metadata.labels&limit=%d",
+		baseURL, url.QueryEscape(cql), a.config.MaxResults)
+	if a.config.Debug {
+		fmt.Printf("Fetching pages from URL: %s\n", searchURL)
 	}
 
-	// Set authentication header using Basic auth
-	req.SetBasicAuth(a.config.Username, a.config.Token)
-	req.Header.Set("Accept", "application/json")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	started := time.Now()
+	resp, err := a.getWithRetry(ctx, searchURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %v", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
 	if a.config.Debug {
-		fmt.Printf("Response status: %d\nResponse body: %s\n", resp.StatusCode, string(body))
+		fmt.Printf("Response status: %d\nResponse body: %s\n", resp.status, redact.String(string(resp.body)))
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	if resp.status != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.status, redact.String(string(resp.body)))
 	}
 
 	var result Response
-	if err := json.NewDecoder(bytes.NewReader(body)).Decode(&result); err != nil {
+	if err := json.NewDecoder(bytes.NewReader(resp.body)).Decode(&result); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %v", err)
 	}
+	a.debugPhase("search", started, len(result.Results))
 
 	if len(result.Results) == 0 {
 		return nil, fmt.Errorf("no assets found with label '%s' in space '%s'", a.config.Label, a.config.SpaceKey)
 	}
 
-	// Convert pages to assets
-	var assets = make([]*domain.Asset, 0, len(result.Results))
+	pages := make([]Page, 0, len(result.Results))
 	for _, page := range result.Results {
-		// Fetch page content
-		contentURL := fmt.Sprintf("%s/wiki/rest/api/content/%s?expand=body.storage,version,metadata.labels",
-			baseURL, page.ID)
-		contentReq, err := http.NewRequestWithContext(ctx, "GET", contentURL, nil)
-		if err != nil {
+		if page.IsSuperseded() {
 			if a.config.Debug {
-				fmt.Printf("Warning: failed to create request for page %s: %v\n", page.Title, err)
+				fmt.Printf("Skipping superseded page %s\n", page.Title)
 			}
 			continue
 		}
+		pages = append(pages, page)
+	}
 
-		contentReq.SetBasicAuth(a.config.Username, a.config.Token)
-		contentReq.Header.Set("Accept", "application/json")
-
-		contentResp, err := client.Do(contentReq)
-		if err != nil {
-			if a.config.Debug {
-				fmt.Printf("Warning: failed to fetch content for page %s: %v\n", page.Title, err)
-			}
-			continue
-		}
-		defer contentResp.Body.Close()
-
-		contentBody, _ := io.ReadAll(contentResp.Body)
-		if a.config.Debug {
-			fmt.Printf("Content response for page %s: %s\n", page.Title, string(contentBody))
-		}
+	started = time.Now()
+	contents, err := a.fetchPageContents(ctx, pages)
+	if err != nil {
+		return nil, err
+	}
+	a.debugPhase("content fetch", started, len(contents))
 
-		if contentResp.StatusCode != http.StatusOK {
-			if a.config.Debug {
-				fmt.Printf("Warning: failed to fetch content for page %s: status %d\n", page.Title, contentResp.StatusCode)
-			}
+	// Convert pages to assets
+	started = time.Now()
+	var assets = make([]*domain.Asset, 0, len(result.Results))
+	for i, contentPage := range contents {
+		if contentPage == nil {
 			continue
 		}
 
-		var contentPage Page
-		var decodeErr error
-		if decodeErr = json.NewDecoder(bytes.NewReader(contentBody)).Decode(&contentPage); decodeErr != nil {
-			return nil, fmt.Errorf("failed to decode content page: %w", decodeErr)
-		}
-
 		if a.config.Debug {
 			fmt.Printf("Labels for page %s:\n", contentPage.Title)
 			for _, label := range contentPage.Metadata.Labels.Results {
@@ -225,15 +212,16 @@ func (a *Adapter) FetchAssets(ctx context.Context) ([]*domain.Asset, error) {
 			}
 		}
 
-		asset, err := a.convertPageToAsset(contentPage)
+		asset, err := a.convertPageToAsset(*contentPage)
 		if err != nil {
 			if a.config.Debug {
-				fmt.Printf("Warning: failed to convert page %s to asset: %v\n", page.Title, err)
+				fmt.Printf("Warning: failed to convert page %s to asset: %v\n", pages[i].Title, err)
 			}
 			continue
 		}
 		assets = append(assets, asset)
 	}
+	a.debugPhase("conversion", started, len(assets))
 
 	return assets, nil
 }
@@ -264,35 +252,34 @@ func (a *Adapter) convertPageToAsset(page Page) (*domain.Asset, error) {
 	}
 
 	// Ensure we have the full URL for DocLink
-	docLink := page.Links.WebUI
-	if !strings.HasPrefix(docLink, "http") {
-		baseURL := strings.TrimRight(a.config.BaseURL, "/")
-		// Add /wiki if it's not already in the path
-		if !strings.Contains(docLink, "/wiki/") {
-			docLink = "/wiki" + docLink
-		}
-		docLink = baseURL + docLink
-	}
+	docLink := a.DocLink(&page)
 
 	now := time.Now()
+	// The page's last edit is when the documentation was last updated
+	docUpdated := page.Version.When
+	if docUpdated.IsZero() {
+		docUpdated = now
+	}
 	asset := &domain.Asset{
-		ID:              metadata.Identifier,
-		Name:            page.Title,
-		Description:     metadata.Description,
-		Why:             metadata.Why,
-		Benefits:        metadata.Benefits,
-		How:             metadata.How,
-		Metrics:         metadata.Metrics,
-		CreatedAt:       now,
-		UpdatedAt:       now,
-		LastDocUpdateAt: now,
-		Version:         1,
-		Platform:        metadata.Platform,
-		Status:          metadata.Status,
-		LaunchDate:      metadata.LaunchDate,
-		IsRolledOut100:  metadata.IsRolledOut100,
-		Keywords:        metadata.Keywords,
-		DocLink:         docLink,
+		ID:               metadata.Identifier,
+		Name:             page.Title,
+		Description:      metadata.Description,
+		Why:              metadata.Why,
+		Benefits:         metadata.Benefits,
+		How:              metadata.How,
+		Metrics:          metadata.Metrics,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+		LastDocUpdateAt:  docUpdated,
+		DocPageVersion:   page.Version.Number,
+		DocPageUpdatedAt: page.Version.When,
+		Version:          1,
+		Platform:         metadata.Platform,
+		Status:           metadata.Status,
+		LaunchDate:       metadata.LaunchDate,
+		IsRolledOut100:   metadata.IsRolledOut100,
+		Keywords:         metadata.Keywords,
+		DocLink:          docLink,
 	}
 
 	return asset, nil
@@ -304,28 +291,17 @@ func (a *Adapter) FetchPage(ctx context.Context, pageID string) (*Page, error) {
 	url := fmt.Sprintf("%s/wiki/rest/api/content/%s?expand=body.storage,version,metadata.labels",
 		baseURL, pageID)
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	resp, err := a.getWithRetry(ctx, url)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %v", err)
+		return nil, err
 	}
 
-	// Set authentication header using Basic auth
-	req.SetBasicAuth(a.config.Username, a.config.Token)
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := a.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	if resp.status != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.status, redact.String(string(resp.body)))
 	}
 
 	var page Page
-	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+	if err := json.NewDecoder(bytes.NewReader(resp.body)).Decode(&page); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %v", err)
 	}
 
@@ -8,6 +8,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
 )
 
@@ -34,6 +37,20 @@ func TestNewAdapter(t *testing.T) {
 	}
 }
 
+func TestNewAdapter_WithHTTPClient(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(`{"results": [{"id": "12345", "key": "TEST"}]}`))
+	}))
+	defer server.Close()
+
+	adapter := NewAdapter(&Config{BaseURL: server.URL, SpaceKey: "TEST", HTTPClient: server.Client()})
+
+	assert.Same(t, server.Client(), adapter.httpClient)
+	spaceID, err := adapter.getSpaceID(context.Background())
+	require.NoError(t, err, "the space lookup uses the configured client")
+	assert.Equal(t, "12345", spaceID)
+}
+
 func TestGetSpaceID(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -308,6 +325,49 @@ func TestFetchAssets(t *testing.T) {
 	}
 }
 
+func TestFetchAssets_SkipsSupersededPages(t *testing.T) {
+	var fetched []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/content/search") {
+			_, _ = w.Write([]byte(`{"results": [
+				{"id": "1", "title": "Booking", "metadata": {"labels": {"results": [{"name": "cap-asset-booking"}]}}},
+				{"id": "2", "title": "Booking (old)", "metadata": {"labels": {"results": [{"name": "cap-asset-booking"}, {"name": "cap-superseded"}]}}}
+			]}`))
+			return
+		}
+		fetched = append(fetched, r.URL.Path)
+		_, _ = w.Write([]byte(`{"id": "1", "title": "Booking", "body": {"storage": {"value": "<table><tr><td><strong>Why are we doing this?</strong></td><td><p>Bookings</p></td></tr></table>"}}, "metadata": {"labels": {"results": [{"name": "cap-asset-booking"}]}}, "_links": {"webui": "/spaces/S/pages/1"}}`))
+	}))
+	defer server.Close()
+
+	adapter := NewAdapter(&Config{BaseURL: server.URL, Label: "cap", MaxResults: 10})
+	assets, err := adapter.FetchAssets(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, assets, 1)
+	assert.Equal(t, "cap-asset-booking", assets[0].ID)
+	assert.Equal(t, []string{"/wiki/rest/api/content/1"}, fetched, "the superseded page is not fetched")
+}
+
+func TestFetchAssets_SearchesConfiguredSpace(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("cql"))
+		_, _ = w.Write([]byte(`{"results": []}`))
+	}))
+	defer server.Close()
+
+	_, err := NewAdapter(&Config{BaseURL: server.URL, Label: "cap-asset", SpaceKey: "PAY", MaxResults: 10}).FetchAssets(context.Background())
+	assert.EqualError(t, err, "no assets found with label 'cap-asset' in space 'PAY'")
+	_, err = NewAdapter(&Config{BaseURL: server.URL, Label: "cap-asset", MaxResults: 10}).FetchAssets(context.Background())
+	assert.Error(t, err)
+
+	assert.Equal(t, []string{
+		`type=page AND label="cap-asset" AND space="PAY"`,
+		`type=page AND label="cap-asset"`,
+	}, queries)
+}
+
 func TestConvertPageToAsset(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -324,9 +384,7 @@ func TestConvertPageToAsset(t *testing.T) {
 				Space: struct {
 					Key string `json:"key"`
 				}{Key: "TEST"},
-				Version: struct {
-					Number int `json:"number"`
-				}{Number: 1},
+				Version: PageVersion{Number: 4, When: time.Date(2024, 2, 3, 10, 0, 0, 0, time.UTC)},
 				Body: struct {
 					Storage struct {
 						Value string `json:"value"`
@@ -354,14 +412,17 @@ func TestConvertPageToAsset(t *testing.T) {
 				BaseURL: "https://test.atlassian.net",
 			},
 			expectedAsset: &domain.Asset{
-				ID:          "cap-asset-test-asset",
-				Name:        "Test Asset",
-				Description: "Test description",
-				Version:     1,
-				Platform:    "Test Platform",
-				Status:      "in development",
-				LaunchDate:  time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
-				DocLink:     "https://test.atlassian.net/wiki/spaces/TEST/pages/test-id",
+				ID:               "cap-asset-test-asset",
+				Name:             "Test Asset",
+				Description:      "Test description",
+				Version:          1,
+				Platform:         "Test Platform",
+				Status:           "in development",
+				LaunchDate:       time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+				DocLink:          "https://test.atlassian.net/wiki/spaces/TEST/pages/test-id",
+				DocPageVersion:   4,
+				DocPageUpdatedAt: time.Date(2024, 2, 3, 10, 0, 0, 0, time.UTC),
+				LastDocUpdateAt:  time.Date(2024, 2, 3, 10, 0, 0, 0, time.UTC),
 			},
 			expectError: false,
 		},
@@ -373,9 +434,7 @@ func TestConvertPageToAsset(t *testing.T) {
 				Space: struct {
 					Key string `json:"key"`
 				}{Key: "TEST"},
-				Version: struct {
-					Number int `json:"number"`
-				}{Number: 1},
+				Version: PageVersion{Number: 1},
 				Body: struct {
 					Storage struct {
 						Value string `json:"value"`
@@ -403,14 +462,15 @@ func TestConvertPageToAsset(t *testing.T) {
 				BaseURL: "https://test.atlassian.net",
 			},
 			expectedAsset: &domain.Asset{
-				ID:          "cap-asset-test-asset",
-				Name:        "Test Asset",
-				Description: "Test description",
-				Version:     1,
-				Platform:    "Test Platform",
-				Status:      "in development",
-				LaunchDate:  time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
-				DocLink:     "https://test.atlassian.net/wiki/spaces/TEST/pages/test-id",
+				ID:             "cap-asset-test-asset",
+				Name:           "Test Asset",
+				Description:    "Test description",
+				Version:        1,
+				Platform:       "Test Platform",
+				Status:         "in development",
+				LaunchDate:     time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+				DocLink:        "https://test.atlassian.net/wiki/spaces/TEST/pages/test-id",
+				DocPageVersion: 1,
 			},
 			expectError: false,
 		},
@@ -422,9 +482,7 @@ func TestConvertPageToAsset(t *testing.T) {
 				Space: struct {
 					Key string `json:"key"`
 				}{Key: "TEST"},
-				Version: struct {
-					Number int `json:"number"`
-				}{Number: 1},
+				Version: PageVersion{Number: 1},
 				Body: struct {
 					Storage struct {
 						Value string `json:"value"`
@@ -452,14 +510,15 @@ func TestConvertPageToAsset(t *testing.T) {
 				BaseURL: "https://test.atlassian.net",
 			},
 			expectedAsset: &domain.Asset{
-				ID:          "cap-asset-test-asset",
-				Name:        "Test Asset",
-				Description: "Test description",
-				Version:     1,
-				Platform:    "Test Platform",
-				Status:      "in development",
-				LaunchDate:  time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
-				DocLink:     "https://test.atlassian.net/wiki/spaces/TEST/pages/test-id",
+				ID:             "cap-asset-test-asset",
+				Name:           "Test Asset",
+				Description:    "Test description",
+				Version:        1,
+				Platform:       "Test Platform",
+				Status:         "in development",
+				LaunchDate:     time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+				DocLink:        "https://test.atlassian.net/wiki/spaces/TEST/pages/test-id",
+				DocPageVersion: 1,
 			},
 			expectError: false,
 		},
@@ -528,6 +587,15 @@ func TestConvertPageToAsset(t *testing.T) {
 			if asset.DocLink != tt.expectedAsset.DocLink {
 				t.Errorf("asset.DocLink = %v, want %v", asset.DocLink, tt.expectedAsset.DocLink)
 			}
+			if asset.DocPageVersion != tt.expectedAsset.DocPageVersion {
+				t.Errorf("asset.DocPageVersion = %v, want %v", asset.DocPageVersion, tt.expectedAsset.DocPageVersion)
+			}
+			if !asset.DocPageUpdatedAt.Equal(tt.expectedAsset.DocPageUpdatedAt) {
+				t.Errorf("asset.DocPageUpdatedAt = %v, want %v", asset.DocPageUpdatedAt, tt.expectedAsset.DocPageUpdatedAt)
+			}
+			if !tt.expectedAsset.LastDocUpdateAt.IsZero() && !asset.LastDocUpdateAt.Equal(tt.expectedAsset.LastDocUpdateAt) {
+				t.Errorf("asset.LastDocUpdateAt = %v, want %v", asset.LastDocUpdateAt, tt.expectedAsset.LastDocUpdateAt)
+			}
 		})
 	}
 }
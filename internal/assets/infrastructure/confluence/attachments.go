@@ -0,0 +1,176 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/redact"
+)
+
+// maxAttachmentSize caps the size of a downloaded attachment
+const maxAttachmentSize = 20 << 20
+
+// pdfMediaType is the media type of PDF attachments
+const pdfMediaType = "application/pdf"
+
+// Attachment represents a file attached to a Confluence page
+type Attachment struct {
+	ID        string
+	Title     string
+	MediaType string
+	FileSize  int64
+	// DownloadPath is the download link relative to the wiki base URL
+	DownloadPath string
+}
+
+// attachmentResponse represents the response of the Confluence attachments API
+type attachmentResponse struct {
+	Results []struct {
+		ID       string `json:"id"`
+		Title    string `json:"title"`
+		Metadata struct {
+			MediaType string `json:"mediaType"`
+		} `json:"metadata"`
+		Extensions struct {
+			MediaType string `json:"mediaType"`
+			FileSize  int64  `json:"fileSize"`
+		} `json:"extensions"`
+		Links struct {
+			Download string `json:"download"`
+		} `json:"_links"`
+	} `json:"results"`
+}
+
+// ListAttachments retrieves the attachments of a page
+func (a *Adapter) ListAttachments(ctx context.Context, pageID string) ([]Attachment, error) {
+	baseURL := strings.TrimRight(a.config.BaseURL, "/")
+	url := fmt.Sprintf("%s/wiki/rest/api/content/%s/child/attachment?limit=100", baseURL, pageID)
+
+	body, err := a.get(ctx, url, "application/json")
+	if err != nil {
+		return nil, err
+	}
+
+	var response attachmentResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v", err)
+	}
+
+	attachments := make([]Attachment, 0, len(response.Results))
+	for _, result := range response.Results {
+		mediaType := result.Metadata.MediaType
+		if mediaType == "" {
+			mediaType = result.Extensions.MediaType
+		}
+		attachments = append(attachments, Attachment{
+			ID:           result.ID,
+			Title:        result.Title,
+			MediaType:    mediaType,
+			FileSize:     result.Extensions.FileSize,
+			DownloadPath: result.Links.Download,
+		})
+	}
+	return attachments, nil
+}
+
+// DownloadAttachment retrieves the content of an attachment
+func (a *Adapter) DownloadAttachment(ctx context.Context, attachment Attachment) ([]byte, error) {
+	if attachment.DownloadPath == "" {
+		return nil, fmt.Errorf("attachment %s has no download link", attachment.Title)
+	}
+	if attachment.FileSize > maxAttachmentSize {
+		return nil, fmt.Errorf("attachment %s exceeds %d bytes", attachment.Title, maxAttachmentSize)
+	}
+
+	baseURL := strings.TrimRight(a.config.BaseURL, "/")
+	return a.get(ctx, baseURL+"/wiki"+attachment.DownloadPath, "*/*")
+}
+
+// AttachmentText returns text extracted from a page's attachments, truncated to limit
+// characters. PDFs and plain text files contribute their content; other attachments,
+// such as diagrams, are listed by title.
+func (a *Adapter) AttachmentText(ctx context.Context, pageID string, limit int) (string, error) {
+	attachments, err := a.ListAttachments(ctx, pageID)
+	if err != nil {
+		return "", fmt.Errorf("failed to list attachments: %w", err)
+	}
+
+	var text strings.Builder
+	var others []string
+	for _, attachment := range attachments {
+		isPDF := attachment.MediaType == pdfMediaType || strings.HasSuffix(strings.ToLower(attachment.Title), ".pdf")
+		isText := strings.HasPrefix(attachment.MediaType, "text/")
+		if !isPDF && !isText {
+			others = append(others, attachment.Title)
+			continue
+		}
+
+		data, err := a.DownloadAttachment(ctx, attachment)
+		if err != nil {
+			return "", fmt.Errorf("failed to download attachment %s: %w", attachment.Title, err)
+		}
+
+		content := string(data)
+		if isPDF {
+			if content, err = ExtractPDFText(data); err != nil {
+				// Unreadable documents are listed like any other attachment
+				others = append(others, attachment.Title)
+				continue
+			}
+		}
+		if strings.TrimSpace(content) == "" {
+			others = append(others, attachment.Title)
+			continue
+		}
+		fmt.Fprintf(&text, "Attachment %s:\n%s\n\n", attachment.Title, strings.TrimSpace(content))
+	}
+	if len(others) > 0 {
+		fmt.Fprintf(&text, "Other attachments: %s\n", strings.Join(others, ", "))
+	}
+
+	return truncateText(strings.TrimSpace(text.String()), limit), nil
+}
+
+// get performs an authenticated GET request and returns the response body
+func (a *Adapter) get(ctx context.Context, url, accept string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.SetBasicAuth(a.config.Username, a.config.Token)
+	req.Header.Set("Accept", accept)
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return nil, redact.Error(fmt.Errorf("failed to make request: %v", err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, redact.String(string(body)))
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxAttachmentSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %v", err)
+	}
+	if len(body) > maxAttachmentSize {
+		return nil, fmt.Errorf("response exceeds %d bytes", maxAttachmentSize)
+	}
+	return body, nil
+}
+
+// truncateText cuts text to at most limit characters, marking the cut. A limit of zero or less keeps everything.
+func truncateText(text string, limit int) string {
+	runes := []rune(text)
+	if limit <= 0 || len(runes) <= limit {
+		return text
+	}
+	return strings.TrimSpace(string(runes[:limit])) + " [truncated]"
+}
@@ -0,0 +1,76 @@
+package confluence
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func attachmentServer(t *testing.T, pdf []byte) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wiki/rest/api/content/123/child/attachment":
+			_, _ = w.Write([]byte(`{"results": [
+				{"id": "att1", "title": "architecture.pdf", "metadata": {"mediaType": "application/pdf"}, "extensions": {"fileSize": 100}, "_links": {"download": "/download/attachments/123/architecture.pdf"}},
+				{"id": "att2", "title": "notes.txt", "extensions": {"mediaType": "text/plain", "fileSize": 10}, "_links": {"download": "/download/attachments/123/notes.txt"}},
+				{"id": "att3", "title": "context.png", "metadata": {"mediaType": "image/png"}, "_links": {"download": "/download/attachments/123/context.png"}}
+			]}`))
+		case "/wiki/download/attachments/123/architecture.pdf":
+			_, _ = w.Write(pdf)
+		case "/wiki/download/attachments/123/notes.txt":
+			_, _ = w.Write([]byte("Runs on Kubernetes"))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestAdapter_ListAttachments(t *testing.T) {
+	server := attachmentServer(t, nil)
+	defer server.Close()
+	adapter := NewAdapter(&Config{BaseURL: server.URL})
+
+	attachments, err := adapter.ListAttachments(context.Background(), "123")
+
+	require.NoError(t, err)
+	require.Len(t, attachments, 3)
+	assert.Equal(t, Attachment{
+		ID:           "att1",
+		Title:        "architecture.pdf",
+		MediaType:    "application/pdf",
+		FileSize:     100,
+		DownloadPath: "/download/attachments/123/architecture.pdf",
+	}, attachments[0])
+	assert.Equal(t, "text/plain", attachments[1].MediaType)
+}
+
+func TestAdapter_AttachmentText(t *testing.T) {
+	server := attachmentServer(t, buildPDF(t, true, "BT (Event driven booking flow) Tj ET"))
+	defer server.Close()
+	adapter := NewAdapter(&Config{BaseURL: server.URL})
+
+	text, err := adapter.AttachmentText(context.Background(), "123", 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Attachment architecture.pdf:\nEvent driven booking flow\n\n"+
+		"Attachment notes.txt:\nRuns on Kubernetes\n\n"+
+		"Other attachments: context.png", text)
+
+	truncated, err := adapter.AttachmentText(context.Background(), "123", 20)
+	require.NoError(t, err)
+	assert.Equal(t, "Attachment architect [truncated]", truncated)
+}
+
+func TestAdapter_DownloadAttachmentTooLarge(t *testing.T) {
+	adapter := NewAdapter(&Config{BaseURL: "http://localhost"})
+
+	_, err := adapter.DownloadAttachment(context.Background(), Attachment{Title: "huge.pdf", FileSize: maxAttachmentSize + 1, DownloadPath: "/download/huge.pdf"})
+
+	assert.Error(t, err)
+}
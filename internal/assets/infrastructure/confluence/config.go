@@ -1,7 +1,9 @@
 package confluence
 
 import (
+	"net/http"
 	"os"
+	"time"
 )
 
 // Config holds the configuration for the Confluence adapter
@@ -18,17 +20,28 @@ type Config struct {
 	Username string
 	// MaxResults is the maximum number of results to fetch per page
 	MaxResults int
+	// Concurrency is the number of page bodies fetched in parallel; below one fetches them one at a time
+	Concurrency int
+	// MaxRetries is how many times a request is retried after a network error, 429 or 5xx response
+	MaxRetries int
+	// RetryDelay is the wait before the first retry, doubled for each following one
+	RetryDelay time.Duration
 	// Debug enables debug logging
 	Debug bool
+	// HTTPClient sends the requests; nil uses a client with DefaultTimeout
+	HTTPClient *http.Client
 }
 
 // DefaultConfig returns a default configuration
 func DefaultConfig() *Config {
 	return &Config{
-		MaxResults: 200,
-		Username:   os.Getenv("JIRA_EMAIL"),
-		Token:      os.Getenv("JIRA_TOKEN"),
-		BaseURL:    os.Getenv("JIRA_BASE_URL"),
-		Debug:      false,
+		MaxResults:  200,
+		Concurrency: 8,
+		MaxRetries:  3,
+		RetryDelay:  500 * time.Millisecond,
+		Username:    os.Getenv("JIRA_EMAIL"),
+		Token:       os.Getenv("JIRA_TOKEN"),
+		BaseURL:     os.Getenv("JIRA_BASE_URL"),
+		Debug:       false,
 	}
 }
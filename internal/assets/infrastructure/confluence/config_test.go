@@ -37,6 +37,12 @@ func TestDefaultConfig(t *testing.T) {
 	if config.MaxResults != 200 {
 		t.Errorf("MaxResults = %v, want %v", config.MaxResults, 200)
 	}
+	if config.Concurrency != 8 {
+		t.Errorf("Concurrency = %v, want %v", config.Concurrency, 8)
+	}
+	if config.MaxRetries != 3 {
+		t.Errorf("MaxRetries = %v, want %v", config.MaxRetries, 3)
+	}
 }
 
 func TestConfigWithEmptyEnvVars(t *testing.T) {
@@ -0,0 +1,170 @@
+package confluence
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/redact"
+)
+
+// response is the outcome of a GET request to Confluence
+type response struct {
+	status int
+	body   []byte
+	// retryAfter is the wait requested by a 429 or 503 response, zero when not given
+	retryAfter time.Duration
+}
+
+// getWithRetry performs an authenticated GET, retrying network errors, 429 and 5xx responses up to
+// MaxRetries times. Retries wait RetryDelay, doubled on every attempt, or the server's Retry-After.
+func (a *Adapter) getWithRetry(ctx context.Context, url string) (*response, error) {
+	delay := a.config.RetryDelay
+	for attempt := 0; ; attempt++ {
+		resp, err := a.getOnce(ctx, url)
+		if attempt >= a.config.MaxRetries || !retryable(resp, err) || ctx.Err() != nil {
+			return resp, err
+		}
+
+		wait := delay
+		if resp != nil && resp.retryAfter > 0 {
+			wait = resp.retryAfter
+		}
+		if a.config.Debug {
+			reason := fmt.Sprint(err)
+			if err == nil {
+				reason = fmt.Sprintf("status %d", resp.status)
+			}
+			fmt.Printf("Retrying %s in %s after %s (retry %d of %d)\n", url, wait, reason, attempt+1, a.config.MaxRetries)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+		delay *= 2
+	}
+}
+
+// getOnce performs a single authenticated GET and reads the response body
+func (a *Adapter) getOnce(ctx context.Context, url string) (*response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	// Set authentication header using Basic auth
+	req.SetBasicAuth(a.config.Username, a.config.Token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return nil, redact.Error(fmt.Errorf("failed to make request: %v", err))
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %v", err)
+	}
+	result := &response{status: resp.StatusCode, body: body}
+	if seconds, err := strconv.Atoi(strings.TrimSpace(resp.Header.Get("Retry-After"))); err == nil && seconds > 0 {
+		result.retryAfter = time.Duration(seconds) * time.Second
+	}
+	return result, nil
+}
+
+// retryable reports whether a request failed transiently: a network error, rate limiting
+// or a server error
+func retryable(resp *response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.status == http.StatusTooManyRequests || resp.status >= http.StatusInternalServerError
+}
+
+// fetchPageContents fetches the full content of the pages with a pool of Concurrency workers,
+// keeping the order of the pages. Pages that cannot be fetched are left nil.
+func (a *Adapter) fetchPageContents(ctx context.Context, pages []Page) ([]*Page, error) {
+	workers := a.config.Concurrency
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(pages) {
+		workers = len(pages)
+	}
+
+	contents := make([]*Page, len(pages))
+	errs := make([]error, len(pages))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				contents[i], errs[i] = a.fetchPageContent(ctx, pages[i])
+			}
+		}()
+	}
+	for i := range pages {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return contents, nil
+}
+
+// fetchPageContent fetches the body, version and labels of a page. A page that cannot be
+// fetched is skipped with a debug warning, and only an undecodable page is an error.
+func (a *Adapter) fetchPageContent(ctx context.Context, page Page) (*Page, error) {
+	baseURL := strings.TrimRight(a.config.BaseURL, "/")
+	contentURL := fmt.Sprintf("%s/wiki/rest/api/content/%s?expand=body.storage,version,metadata.labels",
+		baseURL, page.ID)
+
+	resp, err := a.getWithRetry(ctx, contentURL)
+	if err != nil {
+		if a.config.Debug {
+			fmt.Printf("Warning: failed to fetch content for page %s: %v\n", page.Title, err)
+		}
+		return nil, nil
+	}
+	if a.config.Debug {
+		fmt.Printf("Content response for page %s: %s\n", page.Title, redact.String(string(resp.body)))
+	}
+
+	if resp.status != http.StatusOK {
+		if a.config.Debug {
+			fmt.Printf("Warning: failed to fetch content for page %s: status %d\n", page.Title, resp.status)
+		}
+		return nil, nil
+	}
+
+	var contentPage Page
+	if err := json.NewDecoder(bytes.NewReader(resp.body)).Decode(&contentPage); err != nil {
+		return nil, fmt.Errorf("failed to decode content page: %w", err)
+	}
+	return &contentPage, nil
+}
+
+// debugPhase prints how long a phase of the sync took in debug mode
+func (a *Adapter) debugPhase(phase string, started time.Time, count int) {
+	if a.config.Debug {
+		fmt.Printf("Phase %s: %d page(s) in %s\n", phase, count, time.Since(started).Round(time.Millisecond))
+	}
+}
@@ -0,0 +1,118 @@
+package confluence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assetPageBody is the storage body of a minimal asset page
+const assetPageBody = `<table><tr><td><strong>Why are we doing this?</strong></td><td><p>Test description</p></td></tr></table>`
+
+func TestFetchAssets_ConcurrentPageFetch(t *testing.T) {
+	const pageCount = 20
+	var inFlight, maxInFlight int32
+	var mu sync.Mutex
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/content/search") {
+			results := make([]string, 0, pageCount)
+			for i := 0; i < pageCount; i++ {
+				results = append(results, fmt.Sprintf(`{"id": "%d", "title": "Asset %d"}`, i, i))
+			}
+			fmt.Fprintf(w, `{"results": [%s]}`, strings.Join(results, ","))
+			return
+		}
+
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		mu.Lock()
+		if current > maxInFlight {
+			maxInFlight = current
+		}
+		mu.Unlock()
+		time.Sleep(20 * time.Millisecond)
+
+		id := strings.TrimPrefix(r.URL.Path, "/wiki/rest/api/content/")
+		fmt.Fprintf(w, `{"id": "%s", "title": "Asset %s", "body": {"storage": {"value": %q}}}`, id, id, assetPageBody)
+	}))
+	defer server.Close()
+
+	adapter := NewAdapter(&Config{BaseURL: server.URL, Concurrency: 4})
+	assets, err := adapter.FetchAssets(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, assets, pageCount)
+	for i, asset := range assets {
+		assert.Equal(t, fmt.Sprintf("Asset %d", i), asset.Name, "assets keep the search order")
+	}
+	assert.LessOrEqual(t, maxInFlight, int32(4))
+	assert.Greater(t, maxInFlight, int32(1))
+}
+
+func TestFetchAssets_RetriesTransientFailures(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxRetries int
+		wantAssets int
+	}{
+		{name: "retried until the page is served", maxRetries: 2, wantAssets: 1},
+		{name: "skipped once retries run out", maxRetries: 1, wantAssets: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var contentCalls int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.Contains(r.URL.Path, "/content/search") {
+					fmt.Fprint(w, `{"results": [{"id": "1", "title": "Asset"}]}`)
+					return
+				}
+				if atomic.AddInt32(&contentCalls, 1) <= 2 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				fmt.Fprintf(w, `{"id": "1", "title": "Asset", "body": {"storage": {"value": %q}}}`, assetPageBody)
+			}))
+			defer server.Close()
+
+			adapter := NewAdapter(&Config{BaseURL: server.URL, MaxRetries: tt.maxRetries, RetryDelay: time.Millisecond})
+			assets, err := adapter.FetchAssets(context.Background())
+			require.NoError(t, err)
+			assert.Len(t, assets, tt.wantAssets)
+		})
+	}
+}
+
+func TestGetWithRetry_DoesNotRetryClientErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	adapter := NewAdapter(&Config{BaseURL: server.URL, MaxRetries: 3, RetryDelay: time.Millisecond})
+	resp, err := adapter.getWithRetry(context.Background(), server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.status)
+	assert.Equal(t, int32(1), calls)
+}
+
+func TestRetryable(t *testing.T) {
+	assert.True(t, retryable(nil, errors.New("connection reset")))
+	assert.True(t, retryable(&response{status: http.StatusTooManyRequests}, nil))
+	assert.True(t, retryable(&response{status: http.StatusBadGateway}, nil))
+	assert.False(t, retryable(&response{status: http.StatusOK}, nil))
+	assert.False(t, retryable(&response{status: http.StatusUnauthorized}, nil))
+}
@@ -0,0 +1,121 @@
+package confluence
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/redact"
+)
+
+// AssetLabelPrefix is the prefix of the page label identifying an asset
+const AssetLabelPrefix = "cap-asset-"
+
+// SupersededLabel marks a page replaced by another page documenting the same asset. Sync skips
+// superseded pages, so the asset label they keep no longer makes the asset ambiguous.
+const SupersededLabel = "cap-superseded"
+
+// labelRequest represents a label in the Confluence add-labels API
+type labelRequest struct {
+	Prefix string `json:"prefix"`
+	Name   string `json:"name"`
+}
+
+// AssetLabel returns the page label identifying an asset, or an empty string when there is none
+func (p *Page) AssetLabel() string {
+	for _, label := range p.Metadata.Labels.Results {
+		if strings.HasPrefix(label.Name, AssetLabelPrefix) {
+			return label.Name
+		}
+	}
+	return ""
+}
+
+// IsSuperseded reports whether the page was replaced by another page of the same asset
+func (p *Page) IsSuperseded() bool {
+	for _, label := range p.Metadata.Labels.Results {
+		if label.Name == SupersededLabel {
+			return true
+		}
+	}
+	return false
+}
+
+// DocLink returns the full URL of the page
+func (a *Adapter) DocLink(page *Page) string {
+	docLink := page.Links.WebUI
+	if !strings.HasPrefix(docLink, "http") {
+		baseURL := strings.TrimRight(a.config.BaseURL, "/")
+		// Add /wiki if it's not already in the path
+		if !strings.Contains(docLink, "/wiki/") {
+			docLink = "/wiki" + docLink
+		}
+		docLink = baseURL + docLink
+	}
+	return docLink
+}
+
+// FindPagesByLabel returns the pages carrying a label, with their labels but without their body
+func (a *Adapter) FindPagesByLabel(ctx context.Context, label string) ([]Page, error) {
+	baseURL := strings.TrimRight(a.config.BaseURL, "/")
+	cql := url.QueryEscape(fmt.Sprintf(`type=page AND label="%s"`, label))
+	searchURL := fmt.Sprintf("%s/wiki/rest/api/content/search?cql=%s&expand=metadata.labels&limit=%d", baseURL, cql, a.config.MaxResults)
+
+	resp, err := a.getWithRetry(ctx, searchURL)
+	if err != nil {
+		return nil, err
+	}
+	if resp.status != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.status, redact.String(string(resp.body)))
+	}
+
+	var result Response
+	if err := json.NewDecoder(bytes.NewReader(resp.body)).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v", err)
+	}
+	return result.Results, nil
+}
+
+// ConvertPage converts a fetched page into an asset using the page metadata
+func (a *Adapter) ConvertPage(page *Page) (*domain.Asset, error) {
+	return a.convertPageToAsset(*page)
+}
+
+// AddLabel adds a global label to a page
+func (a *Adapter) AddLabel(ctx context.Context, pageID, label string) error {
+	baseURL := strings.TrimRight(a.config.BaseURL, "/")
+	url := fmt.Sprintf("%s/wiki/rest/api/content/%s/label", baseURL, pageID)
+
+	payload, err := json.Marshal([]labelRequest{{Prefix: "global", Name: label}})
+	if err != nil {
+		return fmt.Errorf("failed to marshal label: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.SetBasicAuth(a.config.Username, a.config.Token)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return redact.Error(fmt.Errorf("failed to make request: %v", err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, redact.String(string(body)))
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPage_AssetLabel(t *testing.T) {
+	var page Page
+	require.NoError(t, json.Unmarshal([]byte(`{"metadata": {"labels": {"results": [{"name": "docs"}, {"name": "cap-asset-booking"}]}}}`), &page))
+	assert.Equal(t, "cap-asset-booking", page.AssetLabel())
+
+	assert.Empty(t, (&Page{}).AssetLabel())
+}
+
+func TestAdapter_AddLabel(t *testing.T) {
+	var received []labelRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/wiki/rest/api/content/123/label", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"results": []}`))
+	}))
+	defer server.Close()
+
+	adapter := NewAdapter(&Config{BaseURL: server.URL, Token: "token"})
+
+	err := adapter.AddLabel(context.Background(), "123", "cap-asset-booking")
+
+	require.NoError(t, err)
+	assert.Equal(t, []labelRequest{{Prefix: "global", Name: "cap-asset-booking"}}, received)
+}
+
+func TestAdapter_AddLabelError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("forbidden"))
+	}))
+	defer server.Close()
+
+	adapter := NewAdapter(&Config{BaseURL: server.URL})
+
+	err := adapter.AddLabel(context.Background(), "123", "cap-asset-booking")
+
+	assert.EqualError(t, err, "unexpected status code: 403, body: forbidden")
+}
+
+func TestPage_IsSuperseded(t *testing.T) {
+	var page Page
+	require.NoError(t, json.Unmarshal([]byte(`{"metadata": {"labels": {"results": [{"name": "cap-asset-booking"}, {"name": "cap-superseded"}]}}}`), &page))
+	assert.True(t, page.IsSuperseded())
+	assert.False(t, (&Page{}).IsSuperseded())
+}
+
+func TestAdapter_DocLink(t *testing.T) {
+	adapter := NewAdapter(&Config{BaseURL: "https://example.atlassian.net/"})
+
+	page := &Page{}
+	page.Links.WebUI = "/spaces/SPACE/pages/123/Booking"
+	assert.Equal(t, "https://example.atlassian.net/wiki/spaces/SPACE/pages/123/Booking", adapter.DocLink(page))
+
+	page.Links.WebUI = "https://other.example.com/wiki/pages/1"
+	assert.Equal(t, "https://other.example.com/wiki/pages/1", adapter.DocLink(page))
+}
+
+func TestAdapter_FindPagesByLabel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/wiki/rest/api/content/search", r.URL.Path)
+		assert.Equal(t, `type=page AND label="cap-asset-booking"`, r.URL.Query().Get("cql"))
+		assert.Equal(t, "metadata.labels", r.URL.Query().Get("expand"))
+		_, _ = w.Write([]byte(`{"results": [{"id": "1", "title": "Booking"}, {"id": "2", "title": "Booking (old)"}]}`))
+	}))
+	defer server.Close()
+
+	adapter := NewAdapter(&Config{BaseURL: server.URL, MaxResults: 50})
+
+	pages, err := adapter.FindPagesByLabel(context.Background(), "cap-asset-booking")
+
+	require.NoError(t, err)
+	require.Len(t, pages, 2)
+	assert.Equal(t, "2", pages[1].ID)
+}
+
+func TestAdapter_FindPagesByLabelError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("forbidden"))
+	}))
+	defer server.Close()
+
+	_, err := NewAdapter(&Config{BaseURL: server.URL}).FindPagesByLabel(context.Background(), "cap-asset-booking")
+
+	assert.EqualError(t, err, "unexpected status code: 403, body: forbidden")
+}
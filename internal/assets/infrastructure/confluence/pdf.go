@@ -0,0 +1,226 @@
+package confluence
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// maxPDFStreamSize caps the decompressed size of a single PDF content stream
+const maxPDFStreamSize = 8 << 20
+
+// tjSpaceThreshold is the TJ kerning offset, in thousandths of an em, treated as a word gap
+const tjSpaceThreshold = -200
+
+var (
+	pdfStreamPattern     = regexp.MustCompile(`(?s)<<(.*?)>>\s*stream\r?\n`)
+	pdfWhitespacePattern = regexp.MustCompile(`[ \t]+`)
+	pdfBlankLinesPattern = regexp.MustCompile(`\n\s*\n+`)
+)
+
+// ExtractPDFText extracts the text drawn by the content streams of a PDF. It is a
+// best-effort extractor for text-based documents: it handles uncompressed and
+// Flate-compressed streams with simple font encodings, and skips images and fonts.
+func ExtractPDFText(data []byte) (string, error) {
+	if !bytes.HasPrefix(data, []byte("%PDF")) {
+		return "", fmt.Errorf("not a PDF document")
+	}
+
+	var text strings.Builder
+	for _, match := range pdfStreamPattern.FindAllSubmatchIndex(data, -1) {
+		dictionary := string(data[match[2]:match[3]])
+		if strings.Contains(dictionary, "/Image") || strings.Contains(dictionary, "/FontFile") ||
+			strings.Contains(dictionary, "/Length1") || strings.Contains(dictionary, "/XRef") {
+			continue
+		}
+
+		start := match[1]
+		end := bytes.Index(data[start:], []byte("endstream"))
+		if end < 0 {
+			break
+		}
+		stream := data[start : start+end]
+
+		if strings.Contains(dictionary, "/FlateDecode") {
+			reader, err := zlib.NewReader(bytes.NewReader(stream))
+			if err != nil {
+				continue
+			}
+			// Truncated streams still yield their readable prefix
+			decoded, _ := io.ReadAll(io.LimitReader(reader, maxPDFStreamSize))
+			reader.Close()
+			stream = decoded
+		} else if strings.Contains(dictionary, "/Filter") {
+			continue
+		}
+
+		text.WriteString(extractContentStreamText(stream))
+	}
+
+	return normalizePDFText(text.String()), nil
+}
+
+// extractContentStreamText returns the strings shown by the text operators of a content stream
+func extractContentStreamText(stream []byte) string {
+	var out strings.Builder
+	var pending []string
+	inArray := false
+
+	for i := 0; i < len(stream); {
+		c := stream[i]
+		switch {
+		case c == '(':
+			value, next := readLiteralString(stream, i)
+			pending = append(pending, value)
+			i = next
+		case c == '<' && i+1 < len(stream) && stream[i+1] != '<':
+			value, next := readHexString(stream, i)
+			pending = append(pending, value)
+			i = next
+		case c == '[':
+			inArray = true
+			i++
+		case c == ']':
+			inArray = false
+			i++
+		case c == '%':
+			for i < len(stream) && stream[i] != '\n' && stream[i] != '\r' {
+				i++
+			}
+		case isPDFDelimiter(c) || isPDFWhitespace(c):
+			i++
+		default:
+			start := i
+			for i < len(stream) && !isPDFWhitespace(stream[i]) && !isPDFDelimiter(stream[i]) {
+				i++
+			}
+			token := string(stream[start:i])
+			if number, err := strconv.ParseFloat(token, 64); err == nil {
+				if inArray && number < tjSpaceThreshold {
+					pending = append(pending, " ")
+				}
+				continue
+			}
+
+			switch token {
+			case "Tj", "TJ":
+				out.WriteString(strings.Join(pending, ""))
+			case "'", `"`:
+				out.WriteString("\n" + strings.Join(pending, ""))
+			case "Td", "TD", "T*", "Tm":
+				out.WriteString("\n")
+			case "ET":
+				out.WriteString("\n")
+			}
+			pending = nil
+		}
+	}
+	return out.String()
+}
+
+// readLiteralString reads a parenthesized PDF string starting at i, returning its value and the next index
+func readLiteralString(data []byte, i int) (string, int) {
+	var value strings.Builder
+	depth := 0
+	for i < len(data) {
+		c := data[i]
+		switch {
+		case c == '\\' && i+1 < len(data):
+			i++
+			switch e := data[i]; e {
+			case 'n':
+				value.WriteByte('\n')
+			case 'r':
+				value.WriteByte('\r')
+			case 't':
+				value.WriteByte('\t')
+			case 'b', 'f':
+			case '\r', '\n':
+				// Escaped line breaks continue the string
+			default:
+				if e >= '0' && e <= '7' {
+					end := i
+					for end < len(data) && end < i+3 && data[end] >= '0' && data[end] <= '7' {
+						end++
+					}
+					code, _ := strconv.ParseUint(string(data[i:end]), 8, 8)
+					value.WriteRune(rune(code))
+					i = end
+					continue
+				}
+				value.WriteByte(e)
+			}
+		case c == '(':
+			if depth > 0 {
+				value.WriteByte(c)
+			}
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return value.String(), i + 1
+			}
+			value.WriteByte(c)
+		default:
+			value.WriteByte(c)
+		}
+		i++
+	}
+	return value.String(), i
+}
+
+// readHexString reads an angle-bracketed hex PDF string starting at i, returning its value and the next index
+func readHexString(data []byte, i int) (string, int) {
+	end := bytes.IndexByte(data[i:], '>')
+	if end < 0 {
+		return "", len(data)
+	}
+	digits := strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.ASCII_Hex_Digit, r) {
+			return r
+		}
+		return -1
+	}, string(data[i+1:i+end]))
+	if len(digits)%2 == 1 {
+		digits += "0"
+	}
+
+	var value strings.Builder
+	for j := 0; j+1 < len(digits); j += 2 {
+		code, _ := strconv.ParseUint(digits[j:j+2], 16, 8)
+		value.WriteRune(rune(code))
+	}
+	return value.String(), i + end + 1
+}
+
+// isPDFWhitespace reports whether c is a PDF whitespace character
+func isPDFWhitespace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == '\f' || c == 0
+}
+
+// isPDFDelimiter reports whether c is a PDF delimiter character
+func isPDFDelimiter(c byte) bool {
+	return strings.IndexByte("()<>[]{}/%", c) >= 0
+}
+
+// normalizePDFText drops unprintable characters and collapses blank space
+func normalizePDFText(text string) string {
+	text = strings.Map(func(r rune) rune {
+		if r == '\n' || unicode.IsPrint(r) {
+			return r
+		}
+		return ' '
+	}, text)
+	text = pdfWhitespacePattern.ReplaceAllString(text, " ")
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	text = strings.Join(lines, "\n")
+	return strings.TrimSpace(pdfBlankLinesPattern.ReplaceAllString(text, "\n"))
+}
@@ -0,0 +1,61 @@
+package confluence
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildPDF assembles a minimal PDF with the given content streams, compressing them when flate is set
+func buildPDF(t *testing.T, flate bool, contents ...string) []byte {
+	t.Helper()
+	var pdf bytes.Buffer
+	pdf.WriteString("%PDF-1.4\n")
+	for i, content := range contents {
+		data := []byte(content)
+		filter := ""
+		if flate {
+			var compressed bytes.Buffer
+			writer := zlib.NewWriter(&compressed)
+			_, err := writer.Write(data)
+			require.NoError(t, err)
+			require.NoError(t, writer.Close())
+			data = compressed.Bytes()
+			filter = " /Filter /FlateDecode"
+		}
+		fmt.Fprintf(&pdf, "%d 0 obj\n<< /Length %d%s >>\nstream\n", i+4, len(data), filter)
+		pdf.Write(data)
+		pdf.WriteString("\nendstream\nendobj\n")
+	}
+	pdf.WriteString("%%EOF\n")
+	return pdf.Bytes()
+}
+
+func TestExtractPDFText(t *testing.T) {
+	content := "BT /F1 12 Tf 72 720 Td (Booking Engine \\(v2\\)) Tj 0 -14 Td [(Event) -250 (dri) 20 (ven)] TJ ET\n" +
+		"BT <4172636869746563747572652E> Tj ET"
+
+	for _, flate := range []bool{false, true} {
+		text, err := ExtractPDFText(buildPDF(t, flate, content))
+		require.NoError(t, err)
+		assert.Equal(t, "Booking Engine (v2)\nEvent driven\nArchitecture.", text)
+	}
+}
+
+func TestExtractPDFText_SkipsImagesAndFonts(t *testing.T) {
+	pdf := buildPDF(t, false, "BT (Visible) Tj ET")
+	pdf = append(pdf, []byte("9 0 obj\n<< /Subtype /Image /Length 14 >>\nstream\nBT (Hidden) Tj\nendstream\nendobj\n")...)
+
+	text, err := ExtractPDFText(pdf)
+	require.NoError(t, err)
+	assert.Equal(t, "Visible", text)
+}
+
+func TestExtractPDFText_NotAPDF(t *testing.T) {
+	_, err := ExtractPDFText([]byte("plain text"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,73 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain/ports"
+)
+
+// enrichmentCacheDir is the directory, next to the catalogue file, holding its generated
+// field values
+const enrichmentCacheDir = "enrichment-cache"
+
+// Ensure JSONRepository keeps generated field values
+var _ ports.EnrichmentCache = (*JSONRepository)(nil)
+
+// FindEnrichment returns the value cached under a key, or nil when none is cached
+func (r *JSONRepository) FindEnrichment(key domain.EnrichmentCacheKey) (*domain.CachedEnrichment, error) {
+	entries, err := r.loadEnrichmentCache()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load enrichment cache: %w", err)
+	}
+	entry, exists := entries[key.String()]
+	if !exists {
+		return nil, nil
+	}
+	return &entry, nil
+}
+
+// SaveEnrichment caches a generated value, replacing any previous one of the same key
+func (r *JSONRepository) SaveEnrichment(entry domain.CachedEnrichment) error {
+	entries, err := r.loadEnrichmentCache()
+	if err != nil {
+		return fmt.Errorf("failed to load enrichment cache: %w", err)
+	}
+	entries[entry.Key.String()] = entry
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal enrichment cache: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.enrichmentCachePath()), DefaultConfig().DirMode); err != nil {
+		return fmt.Errorf("failed to create enrichment cache directory: %w", err)
+	}
+	if err := os.WriteFile(r.enrichmentCachePath(), data, DefaultConfig().FileMode); err != nil {
+		return fmt.Errorf("failed to write enrichment cache file: %w", err)
+	}
+	return nil
+}
+
+// loadEnrichmentCache loads the cached values by key
+func (r *JSONRepository) loadEnrichmentCache() (map[string]domain.CachedEnrichment, error) {
+	entries := make(map[string]domain.CachedEnrichment)
+	data, err := os.ReadFile(r.enrichmentCachePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return entries, nil
+		}
+		return nil, fmt.Errorf("failed to read enrichment cache file: %w", err)
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal enrichment cache: %w", err)
+	}
+	return entries, nil
+}
+
+// enrichmentCachePath returns the path of the file holding the generated field values
+func (r *JSONRepository) enrichmentCachePath() string {
+	return filepath.Join(r.dir, enrichmentCacheDir, r.file)
+}
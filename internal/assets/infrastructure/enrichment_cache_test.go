@@ -0,0 +1,37 @@
+package infrastructure
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+)
+
+func TestJSONRepository_EnrichmentCache(t *testing.T) {
+	repo, _ := newHistoryRepository(t)
+	key := domain.EnrichmentCacheKey{PageID: "123", PageVersion: 4, Field: "how", PromptHash: "abc"}
+
+	entry, err := repo.FindEnrichment(key)
+	require.NoError(t, err)
+	assert.Nil(t, entry, "nothing is cached yet")
+
+	cached := domain.CachedEnrichment{Key: key, Value: "Generated", Model: "llama3", CachedAt: time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)}
+	require.NoError(t, repo.SaveEnrichment(cached))
+
+	entry, err = repo.FindEnrichment(key)
+	require.NoError(t, err)
+	assert.Equal(t, &cached, entry)
+
+	newerPage := key
+	newerPage.PageVersion = 5
+	entry, err = repo.FindEnrichment(newerPage)
+	require.NoError(t, err)
+	assert.Nil(t, entry, "a new page version misses the cache")
+
+	assets, err := repo.FindAll()
+	require.NoError(t, err)
+	assert.Empty(t, assets, "the cache is kept apart from the catalogue")
+}
@@ -5,15 +5,21 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
 	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain/ports"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/schema"
 )
 
 // JSONRepository implements AssetRepository using JSON files
 type JSONRepository struct {
 	dir  string
 	file string
+	// now returns the current time, used to date catalogue snapshots and deletions
+	now func() time.Time
+	// retention is how long deleted assets are kept in the trash
+	retention time.Duration
 }
 
 // RepositoryConfig holds configuration for the JSON repository
@@ -26,6 +32,8 @@ type RepositoryConfig struct {
 	FileMode os.FileMode
 	// Directory permissions for the storage directory
 	DirMode os.FileMode
+	// TrashRetention is how long deleted assets are kept; zero uses the default
+	TrashRetention time.Duration
 }
 
 // DefaultConfig returns a default configuration for the repository
@@ -40,9 +48,15 @@ func DefaultConfig() RepositoryConfig {
 
 // NewJSONRepository creates a new JSON repository with the given configuration
 func NewJSONRepository(config RepositoryConfig) ports.AssetRepository {
+	retention := config.TrashRetention
+	if retention <= 0 {
+		retention = domain.DefaultTrashRetention
+	}
 	return &JSONRepository{
-		dir:  config.Directory,
-		file: config.Filename,
+		dir:       config.Directory,
+		file:      config.Filename,
+		now:       time.Now,
+		retention: retention,
 	}
 }
 
@@ -99,7 +113,7 @@ func (r *JSONRepository) FindAll() ([]*domain.Asset, error) {
 	return result, nil
 }
 
-// Delete deletes an asset by name
+// Delete deletes an asset by name, keeping it in the trash until it expires
 func (r *JSONRepository) Delete(name string) error {
 	if name == "" {
 		return fmt.Errorf("asset name cannot be empty")
@@ -110,10 +124,14 @@ func (r *JSONRepository) Delete(name string) error {
 		return fmt.Errorf("failed to load assets: %w", err)
 	}
 
-	if _, exists := assets[name]; !exists {
+	asset, exists := assets[name]
+	if !exists {
 		return fmt.Errorf("asset %s not found", name)
 	}
 
+	if err := r.moveToTrash(asset); err != nil {
+		return err
+	}
 	delete(assets, name)
 	return r.saveAssets(assets)
 }
@@ -155,6 +173,10 @@ func (r *JSONRepository) loadAssets() (map[string]*domain.Asset, error) {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
+	if err := schema.Validate(schema.Assets, data); err != nil {
+		return nil, fmt.Errorf("invalid assets file %s: %w", filePath, err)
+	}
+
 	var assets map[string]*domain.Asset
 	if err := json.Unmarshal(data, &assets); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal assets: %w", err)
@@ -170,6 +192,10 @@ func (r *JSONRepository) saveAssets(assets map[string]*domain.Asset) error {
 		return fmt.Errorf("failed to marshal assets: %w", err)
 	}
 
+	if err := r.recordSnapshot(); err != nil {
+		return err
+	}
+
 	filePath := filepath.Join(r.dir, r.file)
 	if err := os.WriteFile(filePath, data, DefaultConfig().FileMode); err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
@@ -11,8 +11,6 @@ import (
 	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
 )
 
-// testDir is a temporary directory for test files
-const testDir = "testdata"
 const testFile = "test_assets.json"
 
 type testHelper struct {
@@ -23,15 +21,8 @@ type testHelper struct {
 func setupTest(t *testing.T) *testHelper {
 	t.Helper()
 
-	// Create a unique test directory for each test
-	dir := filepath.Join(testDir, t.Name())
-
-	// Clean up any existing test data
-	_ = os.RemoveAll(dir)
-
-	// Create test directory
-	err := os.MkdirAll(dir, 0755)
-	require.NoError(t, err, "Failed to create test directory")
+	// Create a unique test directory for each test, outside the source tree
+	dir := t.TempDir()
 
 	config := RepositoryConfig{
 		Directory: dir,
@@ -210,6 +201,9 @@ func TestJSONRepository_ErrorHandling(t *testing.T) {
 	defer h.cleanup(t)
 
 	t.Run("should handle file permission errors", func(t *testing.T) {
+		if os.Geteuid() == 0 {
+			t.Skip("directory permissions do not apply to root")
+		}
 		// Create a directory with no write permissions
 		noWriteDir := filepath.Join(h.dir, "no_write")
 		err := os.MkdirAll(noWriteDir, 0444)
@@ -240,7 +234,7 @@ func TestJSONRepository_ErrorHandling(t *testing.T) {
 		// Try to load the assets (should fail due to invalid date format)
 		_, err = h.repo.FindAll()
 		assert.Error(t, err, "Expected error loading invalid JSON")
-		assert.Contains(t, err.Error(), "failed to unmarshal assets", "Unexpected error message")
+		assert.Contains(t, err.Error(), "invalid assets file", "Unexpected error message")
 	})
 
 	t.Run("should handle file read errors", func(t *testing.T) {
@@ -252,7 +246,7 @@ func TestJSONRepository_ErrorHandling(t *testing.T) {
 		// Try to read the file
 		_, err = h.repo.FindAll()
 		assert.Error(t, err, "Expected error reading invalid file")
-		assert.Contains(t, err.Error(), "failed to unmarshal assets", "Unexpected error message")
+		assert.Contains(t, err.Error(), "invalid assets file", "Unexpected error message")
 	})
 }
 
@@ -301,6 +295,9 @@ func TestJSONRepository_EdgeCases(t *testing.T) {
 	})
 
 	t.Run("should handle write errors", func(t *testing.T) {
+		if os.Geteuid() == 0 {
+			t.Skip("directory permissions do not apply to root")
+		}
 		// Create a read-only directory
 		readOnlyDir := filepath.Join(h.dir, "readonly")
 		err := os.MkdirAll(readOnlyDir, 0444)
@@ -358,3 +355,12 @@ func TestJSONRepository_FindByID(t *testing.T) {
 		assert.Contains(t, err.Error(), "asset ID cannot be empty", "Unexpected error message")
 	})
 }
+
+func TestJSONRepository_InvalidFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "assets.json"), []byte("{\n  \"booking\": {\"name\": \"booking\", \"keyword\": []}\n}"), 0644))
+	repo := NewJSONRepository(RepositoryConfig{Directory: dir, Filename: "assets.json"})
+
+	_, err := repo.FindAll()
+	assert.ErrorContains(t, err, "invalid assets file "+filepath.Join(dir, "assets.json")+`: line 2: booking.keyword: unknown field "keyword"; did you mean "keywords"?`)
+}
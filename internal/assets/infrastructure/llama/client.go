@@ -12,27 +12,41 @@ import (
 	"strings"
 
 	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/redact"
 )
 
 // Client represents an Ollama API client
 type Client struct {
 	baseURL    string
+	model      string
 	httpClient *http.Client
 }
 
 // Config holds the configuration for the Ollama client
 type Config struct {
 	BaseURL string
+	Model   string
+	// HTTPClient sends the requests; nil uses a client without a timeout, as generation
+	// can take minutes
+	HTTPClient *http.Client
 }
 
+// DefaultModel is the model used when none is configured
+const DefaultModel = "llama3"
+
 // DefaultConfig returns a default configuration for the Ollama client
 func DefaultConfig() Config {
 	baseURL := os.Getenv("OLLAMA_API_URL")
 	if baseURL == "" {
 		baseURL = "http://localhost:11434"
 	}
+	model := os.Getenv("OLLAMA_MODEL")
+	if model == "" {
+		model = DefaultModel
+	}
 	return Config{
 		BaseURL: baseURL,
+		Model:   model,
 	}
 }
 
@@ -42,12 +56,26 @@ func NewClient(config Config) (*Client, error) {
 		return nil, fmt.Errorf("OLLAMA_API_URL environment variable must be set")
 	}
 
+	model := config.Model
+	if model == "" {
+		model = DefaultModel
+	}
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
 	return &Client{
 		baseURL:    config.BaseURL,
-		httpClient: &http.Client{},
+		model:      model,
+		httpClient: httpClient,
 	}, nil
 }
 
+// Model returns the name of the model generating the content
+func (c *Client) Model() string {
+	return c.model
+}
+
 // cleanHTML removes HTML tags and normalizes whitespace
 func cleanHTML(content string) string {
 	// Remove HTML tags
@@ -127,8 +155,13 @@ Field content:`, asset.Name, asset.Why, asset.Benefits, asset.How, asset.Metrics
 	fmt.Printf("Content from Confluence (cleaned):\n%s\n", cleanedContent)
 	fmt.Printf("=====================================\n\n")
 
+	return c.Generate(prompt)
+}
+
+// Generate sends a prompt to the model and returns its whole response
+func (c *Client) Generate(prompt string) (string, error) {
 	requestBody := map[string]interface{}{
-		"model":  "llama3",
+		"model":  c.model,
 		"prompt": prompt,
 		"stream": false,
 	}
@@ -147,13 +180,13 @@ Field content:`, asset.Name, asset.Why, asset.Benefits, asset.How, asset.Metrics
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to send request: %w", err)
+		return "", redact.Error(fmt.Errorf("failed to send request: %w", err))
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return "", fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, redact.String(string(body)))
 	}
 
 	var result struct {
@@ -61,6 +61,17 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
+func TestNewClient_HTTPClient(t *testing.T) {
+	httpClient := &http.Client{}
+	client, err := NewClient(Config{BaseURL: "http://localhost:11434", HTTPClient: httpClient})
+	require.NoError(t, err)
+	assert.Same(t, httpClient, client.httpClient)
+
+	client, err = NewClient(Config{BaseURL: "http://localhost:11434"})
+	require.NoError(t, err)
+	assert.Zero(t, client.httpClient.Timeout, "generation is not cut short by default")
+}
+
 func TestEnrichContent(t *testing.T) {
 	tests := []struct {
 		name          string
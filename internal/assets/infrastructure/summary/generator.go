@@ -0,0 +1,54 @@
+package summary
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+)
+
+// LlamaClient defines the interface for LLaMA operations
+type LlamaClient interface {
+	EnrichContent(content, field string, asset *domain.Asset) (string, error)
+	Close() error
+}
+
+// Generator writes stakeholder summaries of the work done on an asset
+type Generator struct {
+	llamaClient LlamaClient
+}
+
+// NewGenerator creates a new summary generator
+func NewGenerator(llamaClient LlamaClient) *Generator {
+	return &Generator{
+		llamaClient: llamaClient,
+	}
+}
+
+// GenerateActivitySummary writes a one-paragraph summary of the activity digest of an asset
+func (g *Generator) GenerateActivitySummary(asset *domain.Asset, activity string) (string, error) {
+	prompt := fmt.Sprintf(`You are a professional technical writer helping an asset owner update stakeholders on a software asset.
+
+Asset Name: %s
+Description: %s
+
+Work done on the asset this sprint:
+%s
+
+Please write a one-paragraph summary of what changed on the asset this sprint. Guidelines:
+1. Write 2-4 sentences in plain, professional language
+2. Describe the outcomes of the completed tasks rather than listing issue keys
+3. Mention the total hours and the main work type
+4. Do not invent work that is not in the list above
+5. Do not include any formatting, headers, bullet points or line breaks
+6. Do not mention that you are an AI or that this is a generated response
+
+Summary:`, asset.Name, asset.Description, activity)
+
+	response, err := g.llamaClient.EnrichContent(prompt, "activity summary", asset)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate activity summary: %w", err)
+	}
+
+	return strings.Join(strings.Fields(response), " "), nil
+}
@@ -0,0 +1,51 @@
+package summary
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+)
+
+// MockLlamaClient is a mock implementation of the LLaMA client
+type MockLlamaClient struct {
+	mock.Mock
+}
+
+func (m *MockLlamaClient) EnrichContent(content, field string, asset *domain.Asset) (string, error) {
+	args := m.Called(content, field, asset)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockLlamaClient) Close() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func TestGenerateActivitySummary(t *testing.T) {
+	asset := &domain.Asset{Name: "booking", Description: "Hotel booking flow"}
+	activity := "Completed tasks: 1\n- FN-1 Add payment retries (cap-development, 6.00 h)\n"
+
+	client := new(MockLlamaClient)
+	client.On("EnrichContent", mock.MatchedBy(func(prompt string) bool {
+		return strings.Contains(prompt, "Asset Name: booking") && strings.Contains(prompt, "FN-1 Add payment retries")
+	}), "activity summary", asset).Return("  The team added payment retries.\n\nAll 6 hours were development. ", nil)
+
+	summary, err := NewGenerator(client).GenerateActivitySummary(asset, activity)
+	require.NoError(t, err)
+	assert.Equal(t, "The team added payment retries. All 6 hours were development.", summary)
+	client.AssertExpectations(t)
+}
+
+func TestGenerateActivitySummary_Error(t *testing.T) {
+	client := new(MockLlamaClient)
+	client.On("EnrichContent", mock.Anything, mock.Anything, mock.Anything).Return("", errors.New("connection refused"))
+
+	_, err := NewGenerator(client).GenerateActivitySummary(&domain.Asset{Name: "booking"}, "")
+	assert.EqualError(t, err, "failed to generate activity summary: connection refused")
+}
@@ -0,0 +1,166 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain/ports"
+)
+
+// trashDir is the directory, next to the catalogue file, holding its deleted assets
+const trashDir = "trash"
+
+// Ensure JSONRepository keeps deleted assets
+var _ ports.AssetTrash = (*JSONRepository)(nil)
+
+// FindDeleted returns the deleted assets that have not expired yet, most recent first
+func (r *JSONRepository) FindDeleted() ([]*domain.DeletedAsset, error) {
+	trash, err := r.loadTrash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load trash: %w", err)
+	}
+
+	result := make([]*domain.DeletedAsset, 0, len(trash))
+	for _, deleted := range trash {
+		result = append(result, deleted)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].DeletedAt.Equal(result[j].DeletedAt) {
+			return result[i].DeletedAt.After(result[j].DeletedAt)
+		}
+		return result[i].Asset.Name < result[j].Asset.Name
+	})
+	return result, nil
+}
+
+// Restore moves a deleted asset back into the catalogue
+func (r *JSONRepository) Restore(name string) (*domain.Asset, error) {
+	if name == "" {
+		return nil, fmt.Errorf("asset name cannot be empty")
+	}
+
+	trash, err := r.loadTrash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load trash: %w", err)
+	}
+	deleted, exists := trash[name]
+	if !exists {
+		return nil, fmt.Errorf("deleted asset %s not found", name)
+	}
+
+	assets, err := r.loadAssets()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load assets: %w", err)
+	}
+	if _, exists := assets[name]; exists {
+		return nil, fmt.Errorf("asset %s already exists", name)
+	}
+
+	assets[name] = deleted.Asset
+	if err := r.saveAssets(assets); err != nil {
+		return nil, err
+	}
+	delete(trash, name)
+	if err := r.saveTrash(trash); err != nil {
+		return nil, err
+	}
+	return deleted.Asset, nil
+}
+
+// Purge permanently removes an asset, from the catalogue or the trash
+func (r *JSONRepository) Purge(name string) error {
+	if name == "" {
+		return fmt.Errorf("asset name cannot be empty")
+	}
+
+	assets, err := r.loadAssets()
+	if err != nil {
+		return fmt.Errorf("failed to load assets: %w", err)
+	}
+	trash, err := r.loadTrash()
+	if err != nil {
+		return fmt.Errorf("failed to load trash: %w", err)
+	}
+
+	_, live := assets[name]
+	_, deleted := trash[name]
+	if !live && !deleted {
+		return fmt.Errorf("asset %s not found", name)
+	}
+
+	if live {
+		delete(assets, name)
+		if err := r.saveAssets(assets); err != nil {
+			return err
+		}
+	}
+	if deleted {
+		delete(trash, name)
+		if err := r.saveTrash(trash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveToTrash keeps a copy of a deleted asset in the trash
+func (r *JSONRepository) moveToTrash(asset *domain.Asset) error {
+	trash, err := r.loadTrash()
+	if err != nil {
+		return fmt.Errorf("failed to load trash: %w", err)
+	}
+	trash[asset.Name] = &domain.DeletedAsset{Asset: asset, DeletedAt: r.now().UTC()}
+	return r.saveTrash(trash)
+}
+
+// loadTrash loads the deleted assets, leaving out the expired ones
+func (r *JSONRepository) loadTrash() (map[string]*domain.DeletedAsset, error) {
+	trash := make(map[string]*domain.DeletedAsset)
+	data, err := os.ReadFile(r.trashPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return trash, nil
+		}
+		return nil, fmt.Errorf("failed to read trash file: %w", err)
+	}
+	if err := json.Unmarshal(data, &trash); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal trash: %w", err)
+	}
+
+	now := r.now()
+	for name, deleted := range trash {
+		if deleted.Asset == nil {
+			delete(trash, name)
+			continue
+		}
+		deleted.ExpiresAt = deleted.DeletedAt.Add(r.retention)
+		if !now.Before(deleted.ExpiresAt) {
+			delete(trash, name)
+		}
+	}
+	return trash, nil
+}
+
+// saveTrash writes the deleted assets to the trash file
+func (r *JSONRepository) saveTrash(trash map[string]*domain.DeletedAsset) error {
+	data, err := json.MarshalIndent(trash, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal trash: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.trashPath()), DefaultConfig().DirMode); err != nil {
+		return fmt.Errorf("failed to create trash directory: %w", err)
+	}
+	if err := os.WriteFile(r.trashPath(), data, DefaultConfig().FileMode); err != nil {
+		return fmt.Errorf("failed to write trash file: %w", err)
+	}
+	return nil
+}
+
+// trashPath returns the path of the file holding the deleted assets
+func (r *JSONRepository) trashPath() string {
+	return filepath.Join(r.dir, trashDir, r.file)
+}
@@ -0,0 +1,88 @@
+package infrastructure
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+)
+
+func TestJSONRepository_Trash(t *testing.T) {
+	repo, now := newHistoryRepository(t)
+	require.NoError(t, repo.Save(&domain.Asset{ID: "1", Name: "booking"}))
+	require.NoError(t, repo.Save(&domain.Asset{ID: "2", Name: "search"}))
+
+	require.NoError(t, repo.Delete("booking"))
+	*now = now.Add(time.Hour)
+	require.NoError(t, repo.Delete("search"))
+
+	t.Run("keeps deleted assets out of the catalogue", func(t *testing.T) {
+		assets, err := repo.FindAll()
+		require.NoError(t, err)
+		assert.Empty(t, assets)
+	})
+
+	t.Run("lists deleted assets most recent first", func(t *testing.T) {
+		deleted, err := repo.FindDeleted()
+		require.NoError(t, err)
+		require.Len(t, deleted, 2)
+		assert.Equal(t, "search", deleted[0].Asset.Name)
+		assert.Equal(t, "booking", deleted[1].Asset.Name)
+		assert.Equal(t, time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC), deleted[1].DeletedAt)
+		assert.Equal(t, time.Date(2024, 2, 9, 9, 0, 0, 0, time.UTC), deleted[1].ExpiresAt)
+	})
+
+	t.Run("restores a deleted asset", func(t *testing.T) {
+		asset, err := repo.Restore("booking")
+		require.NoError(t, err)
+		assert.Equal(t, "1", asset.ID)
+
+		found, err := repo.FindByName("booking")
+		require.NoError(t, err)
+		assert.Equal(t, "1", found.ID)
+
+		_, err = repo.Restore("booking")
+		assert.ErrorContains(t, err, "deleted asset booking not found")
+	})
+
+	t.Run("refuses to overwrite a live asset", func(t *testing.T) {
+		require.NoError(t, repo.Save(&domain.Asset{ID: "3", Name: "search"}))
+		_, err := repo.Restore("search")
+		assert.ErrorContains(t, err, "asset search already exists")
+	})
+
+	t.Run("purges live and deleted assets", func(t *testing.T) {
+		require.NoError(t, repo.Purge("search"))
+		deleted, err := repo.FindDeleted()
+		require.NoError(t, err)
+		assert.Empty(t, deleted)
+		_, err = repo.FindByName("search")
+		assert.Error(t, err)
+
+		assert.ErrorContains(t, repo.Purge("search"), "asset search not found")
+	})
+}
+
+func TestJSONRepository_TrashRetention(t *testing.T) {
+	repo := NewJSONRepository(RepositoryConfig{Directory: t.TempDir(), Filename: "assets.json", TrashRetention: 24 * time.Hour}).(*JSONRepository)
+	now := time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)
+	repo.now = func() time.Time { return now }
+
+	require.NoError(t, repo.Save(&domain.Asset{Name: "booking"}))
+	require.NoError(t, repo.Delete("booking"))
+
+	now = now.Add(23 * time.Hour)
+	deleted, err := repo.FindDeleted()
+	require.NoError(t, err)
+	assert.Len(t, deleted, 1)
+
+	now = now.Add(time.Hour)
+	deleted, err = repo.FindDeleted()
+	require.NoError(t, err)
+	assert.Empty(t, deleted)
+	_, err = repo.Restore("booking")
+	assert.ErrorContains(t, err, "deleted asset booking not found")
+}
@@ -0,0 +1,298 @@
+// Package backstage exports the asset catalog as Backstage software catalog entities, one
+// catalog-info file per asset and a Location entity registering them all, so assets appear in
+// the developer portal with their metadata, capitalization status and latest allocation.
+package backstage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// APIVersion is the Backstage catalog API version of the written entities
+const APIVersion = "backstage.io/v1alpha1"
+
+// LocationFile is the entity registering every exported asset; register it in Backstage
+const LocationFile = "catalog-info.yaml"
+
+// Annotations of the exported entities, readable by Backstage plugins
+const (
+	AnnotationStatus              = "assetcap.io/status"
+	AnnotationCapitalization      = "assetcap.io/capitalization-status"
+	AnnotationTasks               = "assetcap.io/tasks"
+	AnnotationLaunchDate          = "assetcap.io/launch-date"
+	AnnotationPeriod              = "assetcap.io/allocation-period"
+	AnnotationTotalHours          = "assetcap.io/allocation-total-hours"
+	AnnotationCapitalizedHours    = "assetcap.io/allocation-capitalized-hours"
+	AnnotationDevelopmentHours    = "assetcap.io/allocation-development-hours"
+	AnnotationMaintenanceHours    = "assetcap.io/allocation-maintenance-hours"
+	AnnotationDiscoveryHours      = "assetcap.io/allocation-discovery-hours"
+	AnnotationCapitalizationRatio = "assetcap.io/capitalization-ratio"
+	AnnotationExportedAt          = "assetcap.io/exported-at"
+)
+
+// Capitalization statuses of an asset, from its latest allocation and impairments
+const (
+	CapitalizationCapitalizing = "capitalizing"
+	CapitalizationExpensed     = "expensed"
+	CapitalizationImpaired     = "impaired"
+	CapitalizationUnknown      = "unknown"
+)
+
+// Backstage lifecycles of the exported components
+const (
+	LifecycleExperimental = "experimental"
+	LifecycleProduction   = "production"
+	LifecycleDeprecated   = "deprecated"
+)
+
+// DefaultOwner and DefaultType fill in the required spec fields of assets without an owner
+const (
+	DefaultOwner = "unknown"
+	DefaultType  = "service"
+)
+
+// Catalog is the data exported to Backstage
+type Catalog struct {
+	Assets []Asset
+	// Owner is the owner of assets without one; DefaultOwner when empty
+	Owner string
+	// Type is the component type of every asset; DefaultType when empty
+	Type       string
+	ExportedAt time.Time
+}
+
+// Asset is an asset of the catalog
+type Asset struct {
+	Name        string
+	Description string
+	DocURL      string
+	Owner       string
+	Status      string
+	Tasks       int
+	// LaunchDate is zero before the asset was launched
+	LaunchDate time.Time
+	// Impaired marks an asset with a recorded write-down
+	Impaired bool
+	// Allocation is the asset's share of the latest sprint allocation, nil when none was computed
+	Allocation *Allocation
+}
+
+// Allocation holds the hours of an asset in a sprint allocation by work type
+type Allocation struct {
+	Period           string
+	TotalHours       float64
+	CapitalizedHours float64
+	DevelopmentHours float64
+	MaintenanceHours float64
+	DiscoveryHours   float64
+}
+
+// CapitalizationStatus tells whether the asset's latest work is capitalized: impaired assets are
+// written down, and assets without an allocation are unknown
+func (a Asset) CapitalizationStatus() string {
+	switch {
+	case a.Impaired:
+		return CapitalizationImpaired
+	case a.Allocation == nil:
+		return CapitalizationUnknown
+	case a.Allocation.CapitalizedHours > 0:
+		return CapitalizationCapitalizing
+	default:
+		return CapitalizationExpensed
+	}
+}
+
+// Lifecycle maps the asset's status and launch to a Backstage lifecycle: retired or deprecated
+// assets are deprecated, launched ones in production and the others experimental
+func (a Asset) Lifecycle(at time.Time) string {
+	status := strings.ToLower(a.Status)
+	switch {
+	case strings.Contains(status, "retired") || strings.Contains(status, "deprecated") || strings.Contains(status, "decommissioned"):
+		return LifecycleDeprecated
+	case !a.LaunchDate.IsZero() && !a.LaunchDate.After(at):
+		return LifecycleProduction
+	default:
+		return LifecycleExperimental
+	}
+}
+
+// Entity is a Backstage catalog entity
+type Entity struct {
+	APIVersion string   `yaml:"apiVersion"`
+	Kind       string   `yaml:"kind"`
+	Metadata   Metadata `yaml:"metadata"`
+	Spec       Spec     `yaml:"spec"`
+}
+
+// Metadata is the metadata of a catalog entity
+type Metadata struct {
+	Name        string            `yaml:"name"`
+	Title       string            `yaml:"title,omitempty"`
+	Description string            `yaml:"description,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+	Tags        []string          `yaml:"tags,omitempty"`
+	Links       []Link            `yaml:"links,omitempty"`
+}
+
+// Link is a link shown on an entity's page
+type Link struct {
+	URL   string `yaml:"url"`
+	Title string `yaml:"title,omitempty"`
+}
+
+// Spec is the spec of a Component or Location entity
+type Spec struct {
+	Type      string   `yaml:"type,omitempty"`
+	Lifecycle string   `yaml:"lifecycle,omitempty"`
+	Owner     string   `yaml:"owner,omitempty"`
+	Targets   []string `yaml:"targets,omitempty"`
+}
+
+// Component returns the Component entity of an asset
+func (c Catalog) Component(asset Asset) Entity {
+	owner := asset.Owner
+	if owner == "" {
+		owner = c.Owner
+	}
+	if owner == "" {
+		owner = DefaultOwner
+	}
+	componentType := c.Type
+	if componentType == "" {
+		componentType = DefaultType
+	}
+
+	annotations := map[string]string{
+		AnnotationCapitalization: asset.CapitalizationStatus(),
+		AnnotationTasks:          strconv.Itoa(asset.Tasks),
+		AnnotationExportedAt:     c.ExportedAt.UTC().Format(time.RFC3339),
+	}
+	if asset.Status != "" {
+		annotations[AnnotationStatus] = asset.Status
+	}
+	if !asset.LaunchDate.IsZero() {
+		annotations[AnnotationLaunchDate] = asset.LaunchDate.Format("2006-01-02")
+	}
+	if allocation := asset.Allocation; allocation != nil {
+		annotations[AnnotationPeriod] = allocation.Period
+		annotations[AnnotationTotalHours] = hours(allocation.TotalHours)
+		annotations[AnnotationCapitalizedHours] = hours(allocation.CapitalizedHours)
+		annotations[AnnotationDevelopmentHours] = hours(allocation.DevelopmentHours)
+		annotations[AnnotationMaintenanceHours] = hours(allocation.MaintenanceHours)
+		annotations[AnnotationDiscoveryHours] = hours(allocation.DiscoveryHours)
+		ratio := 0.0
+		if allocation.TotalHours > 0 {
+			ratio = allocation.CapitalizedHours / allocation.TotalHours * 100
+		}
+		annotations[AnnotationCapitalizationRatio] = strconv.FormatFloat(ratio, 'f', 1, 64)
+	}
+
+	entity := Entity{
+		APIVersion: APIVersion,
+		Kind:       "Component",
+		Metadata: Metadata{
+			Name:        EntityName(asset.Name),
+			Title:       asset.Name,
+			Description: asset.Description,
+			Annotations: annotations,
+			Tags:        []string{"assetcap", asset.CapitalizationStatus()},
+		},
+		Spec: Spec{Type: componentType, Lifecycle: asset.Lifecycle(c.ExportedAt), Owner: owner},
+	}
+	if asset.DocURL != "" {
+		entity.Metadata.Links = []Link{{URL: asset.DocURL, Title: "Asset documentation"}}
+	}
+	return entity
+}
+
+// hours formats hours for an annotation
+func hours(h float64) string {
+	return strconv.FormatFloat(h, 'f', 1, 64)
+}
+
+// EntityName converts an asset name to a valid entity name: lowercase letters, digits and
+// dashes, at most 63 characters
+func EntityName(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	result := strings.TrimRight(b.String(), "-")
+	if len(result) > 63 {
+		result = strings.TrimRight(result[:63], "-")
+	}
+	return result
+}
+
+// Export writes the entity of each asset into dir, creating it if needed, with a Location
+// entity registering them, and returns the written files
+func Export(dir string, catalog Catalog) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	files := make([]string, 0, len(catalog.Assets)+1)
+	targets := make([]string, 0, len(catalog.Assets))
+	seen := make(map[string]string, len(catalog.Assets))
+	for _, asset := range catalog.Assets {
+		entity := catalog.Component(asset)
+		if entity.Metadata.Name == "" {
+			return nil, fmt.Errorf("asset %q has no characters usable in an entity name", asset.Name)
+		}
+		if other, ok := seen[entity.Metadata.Name]; ok {
+			return nil, fmt.Errorf("assets %q and %q have the same entity name %s", other, asset.Name, entity.Metadata.Name)
+		}
+		seen[entity.Metadata.Name] = asset.Name
+
+		file := entity.Metadata.Name + ".yaml"
+		path, err := writeEntity(dir, file, entity)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, path)
+		targets = append(targets, "./"+file)
+	}
+
+	location := Entity{
+		APIVersion: APIVersion,
+		Kind:       "Location",
+		Metadata: Metadata{
+			Name:        "assetcap-assets",
+			Description: "Digital assets exported by assetcap",
+		},
+		Spec: Spec{Targets: targets},
+	}
+	path, err := writeEntity(dir, LocationFile, location)
+	if err != nil {
+		return nil, err
+	}
+	return append(files, path), nil
+}
+
+// writeEntity writes an entity as a YAML document into dir
+func writeEntity(dir, file string, entity Entity) (string, error) {
+	data, err := yaml.Marshal(entity)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal %s: %w", file, err)
+	}
+	path := filepath.Join(dir, file)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return path, nil
+}
@@ -0,0 +1,112 @@
+package backstage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+var exportedAt = time.Date(2024, 6, 30, 18, 0, 0, 0, time.UTC)
+
+func TestEntityName(t *testing.T) {
+	assert.Equal(t, "booking-engine", EntityName("Booking Engine"))
+	assert.Equal(t, "data-platform-v2", EntityName("  Data Platform (v2)!"))
+	assert.Equal(t, "", EntityName("!!!"))
+	assert.Len(t, EntityName("a-very-long-asset-name-that-goes-on-and-on-well-beyond-the-sixty-three-character-limit"), 63)
+}
+
+func TestAsset_CapitalizationStatus(t *testing.T) {
+	assert.Equal(t, CapitalizationUnknown, Asset{}.CapitalizationStatus())
+	assert.Equal(t, CapitalizationCapitalizing, Asset{Allocation: &Allocation{CapitalizedHours: 10}}.CapitalizationStatus())
+	assert.Equal(t, CapitalizationExpensed, Asset{Allocation: &Allocation{TotalHours: 10}}.CapitalizationStatus())
+	assert.Equal(t, CapitalizationImpaired, Asset{Impaired: true, Allocation: &Allocation{CapitalizedHours: 10}}.CapitalizationStatus())
+}
+
+func TestAsset_Lifecycle(t *testing.T) {
+	assert.Equal(t, LifecycleExperimental, Asset{}.Lifecycle(exportedAt))
+	assert.Equal(t, LifecycleExperimental, Asset{LaunchDate: exportedAt.AddDate(0, 1, 0)}.Lifecycle(exportedAt), "launches ahead are not in production yet")
+	assert.Equal(t, LifecycleProduction, Asset{LaunchDate: exportedAt.AddDate(0, -1, 0)}.Lifecycle(exportedAt))
+	assert.Equal(t, LifecycleDeprecated, Asset{Status: "Retired", LaunchDate: exportedAt.AddDate(-1, 0, 0)}.Lifecycle(exportedAt))
+}
+
+func TestCatalog_Component(t *testing.T) {
+	catalog := Catalog{Owner: "team-platform", ExportedAt: exportedAt}
+	entity := catalog.Component(Asset{
+		Name:        "Booking Engine",
+		Description: "Handles bookings",
+		DocURL:      "https://wiki.example.com/booking",
+		Status:      "Live",
+		Tasks:       12,
+		LaunchDate:  time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Allocation:  &Allocation{Period: "Sprint 6", TotalHours: 80, CapitalizedHours: 60, DevelopmentHours: 60, MaintenanceHours: 20},
+	})
+
+	assert.Equal(t, Entity{
+		APIVersion: APIVersion,
+		Kind:       "Component",
+		Metadata: Metadata{
+			Name:        "booking-engine",
+			Title:       "Booking Engine",
+			Description: "Handles bookings",
+			Annotations: map[string]string{
+				AnnotationStatus:              "Live",
+				AnnotationCapitalization:      CapitalizationCapitalizing,
+				AnnotationTasks:               "12",
+				AnnotationLaunchDate:          "2024-03-01",
+				AnnotationPeriod:              "Sprint 6",
+				AnnotationTotalHours:          "80.0",
+				AnnotationCapitalizedHours:    "60.0",
+				AnnotationDevelopmentHours:    "60.0",
+				AnnotationMaintenanceHours:    "20.0",
+				AnnotationDiscoveryHours:      "0.0",
+				AnnotationCapitalizationRatio: "75.0",
+				AnnotationExportedAt:          "2024-06-30T18:00:00Z",
+			},
+			Tags:  []string{"assetcap", CapitalizationCapitalizing},
+			Links: []Link{{URL: "https://wiki.example.com/booking", Title: "Asset documentation"}},
+		},
+		Spec: Spec{Type: DefaultType, Lifecycle: LifecycleProduction, Owner: "team-platform"},
+	}, entity)
+
+	own := catalog.Component(Asset{Name: "Search", Owner: "team-search"})
+	assert.Equal(t, "team-search", own.Spec.Owner, "the asset's owner wins over the catalog's")
+	assert.Equal(t, DefaultOwner, Catalog{}.Component(Asset{Name: "Search"}).Spec.Owner)
+}
+
+func TestExport(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "backstage")
+	files, err := Export(dir, Catalog{ExportedAt: exportedAt, Assets: []Asset{{Name: "Booking Engine"}, {Name: "Search"}}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "booking-engine.yaml"),
+		filepath.Join(dir, "search.yaml"),
+		filepath.Join(dir, LocationFile),
+	}, files)
+
+	data, err := os.ReadFile(filepath.Join(dir, LocationFile))
+	require.NoError(t, err)
+	var location Entity
+	require.NoError(t, yaml.Unmarshal(data, &location))
+	assert.Equal(t, "Location", location.Kind)
+	assert.Equal(t, []string{"./booking-engine.yaml", "./search.yaml"}, location.Spec.Targets)
+
+	data, err = os.ReadFile(filepath.Join(dir, "search.yaml"))
+	require.NoError(t, err)
+	var component Entity
+	require.NoError(t, yaml.Unmarshal(data, &component))
+	assert.Equal(t, "search", component.Metadata.Name)
+	assert.Equal(t, CapitalizationUnknown, component.Metadata.Annotations[AnnotationCapitalization])
+}
+
+func TestExport_ConflictingNames(t *testing.T) {
+	_, err := Export(t.TempDir(), Catalog{Assets: []Asset{{Name: "Data Platform"}, {Name: "data-platform"}}})
+	assert.ErrorContains(t, err, `assets "Data Platform" and "data-platform" have the same entity name data-platform`)
+
+	_, err = Export(t.TempDir(), Catalog{Assets: []Asset{{Name: "!!!"}}})
+	assert.ErrorContains(t, err, "no characters usable")
+}
@@ -0,0 +1,607 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/events"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/jiraexport"
+)
+
+// DefaultPath is the location of the assetcap configuration file
+const DefaultPath = ".assetcap/config.json"
+
+// DefaultTrashRetentionDays is how many days deleted assets and tasks are kept
+const DefaultTrashRetentionDays = 30
+
+// DefaultDocsMaxAgeDays is how many days asset documentation may go without an update
+const DefaultDocsMaxAgeDays = 180
+
+// Default label coverage the done tasks of a quarter must reach, in percent
+const (
+	DefaultCoverageWorkTypePercent = 90
+	DefaultCoverageAssetPercent    = 80
+)
+
+// Default hours of the allocation heuristics
+const (
+	DefaultAllocationHours        = 8
+	DefaultAllocationMinimumHours = 1
+)
+
+// Supported backends and providers
+const (
+	StorageBackendJSON = "json"
+	ClassifierLlama    = "llama"
+	ClassifierRandom   = "random"
+	LLMProviderOllama  = "ollama"
+	LLMProviderNone    = "none"
+	CodeHostGitHub     = "github"
+	CodeHostBitbucket  = "bitbucket"
+)
+
+// Supported signature methods of exported files
+const (
+	SigningMethodGPG      = "gpg"
+	SigningMethodMinisign = "minisign"
+)
+
+// StorageConfig selects where assets and tasks are persisted
+type StorageConfig struct {
+	Backend   string `json:"backend"`
+	Directory string `json:"directory"`
+	// TrashRetentionDays is how many days deleted assets and tasks stay restorable
+	TrashRetentionDays int `json:"trashRetentionDays"`
+}
+
+// LLMConfig selects the language model used for enrichment and keywords
+type LLMConfig struct {
+	Provider string `json:"provider"`
+	BaseURL  string `json:"baseUrl,omitempty"`
+	Model    string `json:"model,omitempty"`
+}
+
+// HierarchyLevelConfig maps a Jira hierarchy level to the field holding the parent key
+// on the issues one level below it
+type HierarchyLevelConfig struct {
+	Level string `json:"level"`
+	Field string `json:"field"`
+}
+
+// ConfluenceConfig holds the Confluence settings of the asset pages
+type ConfluenceConfig struct {
+	// Spaces are the space keys assets sync searches when no --space is given,
+	// e.g. one per tribe
+	Spaces []string `json:"spaces,omitempty"`
+}
+
+// JiraConfig holds Jira instance specific settings
+type JiraConfig struct {
+	// Hierarchy lists the parent levels from the closest parent upwards,
+	// e.g. epic then initiative
+	Hierarchy []HierarchyLevelConfig `json:"hierarchy,omitempty"`
+	// ManagedLabels are the cap-* labels classification may add or remove, exactly or by a
+	// trailing "*" prefix; empty means the work type labels
+	ManagedLabels []string `json:"managedLabels,omitempty"`
+	// ProtectedLabels are never removed when classification applies labels
+	ProtectedLabels []string `json:"protectedLabels,omitempty"`
+	// SprintField is the custom field holding the sprints of an issue. It is detected and saved
+	// on the first run against an instance; empty uses customfield_10020.
+	SprintField string `json:"sprintField,omitempty"`
+	// SprintFieldInstance is the base URL SprintField was detected on; against another
+	// instance the field is detected again. Empty keeps a SprintField set by hand.
+	SprintFieldInstance string `json:"sprintFieldInstance,omitempty"`
+	// Fields lists issue fields requested on top of the ones tasks consume, e.g. for extensions
+	Fields []string `json:"fields,omitempty"`
+	// FetchAllFields requests every issue field instead of only the needed ones
+	FetchAllFields bool `json:"fetchAllFields,omitempty"`
+	// PageSize is the number of issues requested per page of a search; zero uses 100
+	PageSize int `json:"pageSize,omitempty"`
+	// Export is a JSON or CSV export of the Jira issues read instead of the Jira API, where
+	// the tool has no API access
+	Export string `json:"export,omitempty"`
+}
+
+// ExportConfig holds the defaults of exported reports
+type ExportConfig struct {
+	// Locale formats numbers and dates, e.g. de-DE; empty uses decimal points and ISO dates
+	Locale string `json:"locale,omitempty"`
+	// PercentFormat writes percentages with a percent sign ("percent", the default) or as
+	// fractions of one ("fraction")
+	PercentFormat string `json:"percentFormat,omitempty"`
+	// PercentDecimals is the number of decimals of percentages; unset uses two with a
+	// percent sign and four as a fraction
+	PercentDecimals *int `json:"percentDecimals,omitempty"`
+}
+
+// OutputConfig routes command output to files, object storage or webhooks
+type OutputConfig struct {
+	// Destinations maps a command, e.g. "sprint allocate", to where its output is written
+	// when --out is not given
+	Destinations map[string]string `json:"destinations,omitempty"`
+	// WebhookHeaders are sent with every webhook request, e.g. an Authorization header
+	WebhookHeaders map[string]string `json:"webhookHeaders,omitempty"`
+	// S3Endpoint and S3Region override the S3 endpoint, e.g. for MinIO, and its region
+	S3Endpoint string `json:"s3Endpoint,omitempty"`
+	S3Region   string `json:"s3Region,omitempty"`
+}
+
+// HookConfig runs a shell command or posts to a webhook whenever an event is published
+type HookConfig struct {
+	// Event is the event the hook runs on, e.g. TasksClassified, or * for every event
+	Event string `json:"event"`
+	// Command is run with sh -c and reads the event as JSON on stdin
+	Command string `json:"command,omitempty"`
+	// Webhook is an http(s) URL the event is posted to as JSON
+	Webhook string `json:"webhook,omitempty"`
+	// Headers are sent with the webhook request, e.g. an Authorization header
+	Headers map[string]string `json:"headers,omitempty"`
+	// TimeoutSeconds bounds the hook; zero uses 30 seconds
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
+}
+
+// AllocationConfig tunes the heuristics that fill in hours the Jira changelog does not track
+type AllocationConfig struct {
+	// DefaultHours is credited to issues that never went In Progress
+	DefaultHours        float64 `json:"defaultHours"`
+	DisableDefaultHours bool    `json:"disableDefaultHours,omitempty"`
+	// SameDayMinimum is the least credited to an issue completed the day it started
+	SameDayMinimum        float64 `json:"sameDayMinimum"`
+	DisableSameDayMinimum bool    `json:"disableSameDayMinimum,omitempty"`
+	// StaleInProgressDays flags issues In Progress for longer as needing review; zero disables it
+	StaleInProgressDays int `json:"staleInProgressDays,omitempty"`
+	// StaleCapHours caps the hours credited for a flagged issue pending a manual override
+	StaleCapHours float64 `json:"staleCapHours,omitempty"`
+	// LowConfidence lists the allocation rows scoring below it, out of 100, for review; zero
+	// uses the default of 70
+	LowConfidence int `json:"lowConfidence,omitempty"`
+	// PersonWorkTypes maps sprint names to the team members whose every row counts as the
+	// given work type in that sprint, e.g. {"Sprint 6": {"alice": "maintenance"}}
+	PersonWorkTypes map[string]map[string]string `json:"personWorkTypes,omitempty"`
+	// Calendar counts only working hours in the tracked time of issues; without it,
+	// allocations count wall-clock hours, nights and weekends included
+	Calendar CalendarConfig `json:"calendar,omitzero"`
+}
+
+// CalendarConfig is the business calendar of the allocations: the workday hours, weekend days
+// and holidays. Teams add their own holidays in teams.json.
+type CalendarConfig struct {
+	// DayStart and DayEnd bound each workday, e.g. 09:00 and 17:00; setting either enables the
+	// calendar
+	DayStart string `json:"dayStart,omitempty"`
+	DayEnd   string `json:"dayEnd,omitempty"`
+	// Weekend lists the days nobody works; empty means saturday and sunday
+	Weekend []string `json:"weekend,omitempty"`
+	// Holidays are the dates nobody works, e.g. 2024-12-25
+	Holidays []string `json:"holidays,omitempty"`
+	// TimeZone of the workday hours, e.g. Europe/Berlin; UTC when empty
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// Enabled reports whether allocations count working hours instead of wall-clock hours
+func (c CalendarConfig) Enabled() bool {
+	return c.DayStart != "" || c.DayEnd != ""
+}
+
+// ERPConfig sets the accounts of the assets without their own and the layout of the ERP export
+type ERPConfig struct {
+	// CostCenter and GLAccount book the capitalized cost of the assets that do not set them
+	CostCenter string `json:"costCenter,omitempty"`
+	GLAccount  string `json:"glAccount,omitempty"`
+	// Format is csv or fixed-width; csv when empty
+	Format string `json:"format,omitempty"`
+	// Delimiter separates the fields of the csv format; a comma when empty
+	Delimiter string `json:"delimiter,omitempty"`
+	// Columns are the exported fields in order, with their widths in the fixed-width format;
+	// period, cost center, GL account, asset, hours and amount when empty
+	Columns []ERPColumnConfig `json:"columns,omitempty"`
+}
+
+// ERPColumnConfig is a field of the ERP export
+type ERPColumnConfig struct {
+	Field string `json:"field"`
+	Width int    `json:"width,omitempty"`
+}
+
+// TelemetryConfig configures where opt-in usage statistics are sent
+type TelemetryConfig struct {
+	// Endpoint receives the buffered events as a JSON array; empty keeps them in the local buffer
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+// CodeConfig selects the code host whose commits and pull requests are linked to tasks
+type CodeConfig struct {
+	// Host is github or bitbucket; empty disables code linking
+	Host string `json:"host,omitempty"`
+	// BaseURL overrides the host's API location, e.g. for GitHub Enterprise
+	BaseURL string `json:"baseUrl,omitempty"`
+	// Repositories are named owner/name, or workspace/slug on Bitbucket
+	Repositories []string `json:"repositories,omitempty"`
+}
+
+// DocsConfig sets how long asset documentation stays fresh and where reminders go
+type DocsConfig struct {
+	// MaxAgeDays is the allowed age by asset status; zero exempts a status
+	MaxAgeDays map[string]int `json:"maxAgeDays,omitempty"`
+	// DefaultMaxAgeDays applies to the statuses not listed; zero exempts them
+	DefaultMaxAgeDays int `json:"defaultMaxAgeDays"`
+	// Notify is the destination receiving the stale documentation reminders, e.g. a chat webhook
+	Notify string `json:"notify,omitempty"`
+}
+
+// CoverageThresholds are the minimum percentages of done tasks with a work type label and with
+// an asset link; zero disables a check
+type CoverageThresholds struct {
+	WorkTypePercent float64 `json:"workTypePercent"`
+	AssetPercent    float64 `json:"assetPercent"`
+}
+
+// NetworkConfig configures how the Jira, Confluence and LLM clients reach their servers,
+// e.g. through a corporate proxy with a private certificate authority
+type NetworkConfig struct {
+	// ProxyURL routes requests through a proxy; empty honours HTTPS_PROXY and HTTP_PROXY
+	ProxyURL string `json:"proxyUrl,omitempty"`
+	// ProxyUsername authenticates with the proxy; the password is read from
+	// ASSETCAP_PROXY_PASSWORD
+	ProxyUsername string `json:"proxyUsername,omitempty"`
+	// CABundle is a PEM file of certificate authorities trusted besides the system ones
+	CABundle string `json:"caBundle,omitempty"`
+	// ClientCertificate and ClientKey are PEM files presented for mutual TLS
+	ClientCertificate string `json:"clientCertificate,omitempty"`
+	ClientKey         string `json:"clientKey,omitempty"`
+	// TimeoutSeconds bounds each request; zero keeps the default of each client
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
+}
+
+// SigningConfig writes a SHA-256 checksum file, and optionally a detached signature, next to
+// every file the exports write locally, so auditors can prove the files were not altered
+type SigningConfig struct {
+	// Checksums writes a .sha256 file next to each exported file
+	Checksums bool `json:"checksums,omitempty"`
+	// Method also signs each exported file with gpg or minisign
+	Method string `json:"method,omitempty"`
+	// Key is the GPG key ID to sign with, or the minisign secret key file;
+	// empty signs with the default GPG key
+	Key string `json:"key,omitempty"`
+	// PublicKey is the minisign public key file verify-artifact checks signatures with
+	PublicKey string `json:"publicKey,omitempty"`
+}
+
+// Enabled reports whether exported files are checksummed or signed
+func (s SigningConfig) Enabled() bool {
+	return s.Checksums || s.Method != ""
+}
+
+// DefaultsConfig holds the flag values commands use when the flags are omitted, so commands
+// run in a project's directory need no --project or --platform
+type DefaultsConfig struct {
+	Project  string `json:"project,omitempty"`
+	Platform string `json:"platform,omitempty"`
+}
+
+// DefaultKeys are the flags a default can be set for
+var DefaultKeys = []string{"project", "platform"}
+
+// Get returns the default of a flag, empty when none is set
+func (d DefaultsConfig) Get(key string) string {
+	switch key {
+	case "project":
+		return d.Project
+	case "platform":
+		return d.Platform
+	}
+	return ""
+}
+
+// Set sets the default of a flag; an empty value removes it
+func (d *DefaultsConfig) Set(key, value string) error {
+	value = strings.TrimSpace(value)
+	if key != "project" && key != "platform" {
+		return fmt.Errorf("unknown default %s: must be one of %s", key, strings.Join(DefaultKeys, ", "))
+	}
+	if strings.ContainsAny(value, " ,") {
+		return fmt.Errorf("default %s %q must be a single value", key, value)
+	}
+	if key == "project" {
+		d.Project = value
+	} else {
+		d.Platform = strings.ToLower(value)
+	}
+	return nil
+}
+
+// CoverageConfig sets the label coverage the done tasks of each project must reach
+type CoverageConfig struct {
+	CoverageThresholds
+	// Projects replaces both thresholds for the listed projects
+	Projects map[string]CoverageThresholds `json:"projects,omitempty"`
+}
+
+// For returns the thresholds of a project
+func (c CoverageConfig) For(project string) CoverageThresholds {
+	if thresholds, ok := c.Projects[project]; ok {
+		return thresholds
+	}
+	return c.CoverageThresholds
+}
+
+// validate checks that every threshold is a percentage
+func (t CoverageThresholds) validate(scope string) error {
+	if t.WorkTypePercent < 0 || t.WorkTypePercent > 100 || t.AssetPercent < 0 || t.AssetPercent > 100 {
+		return fmt.Errorf("coverage thresholds%s must be between 0 and 100", scope)
+	}
+	return nil
+}
+
+// Config holds the application wiring choices
+type Config struct {
+	Storage    StorageConfig    `json:"storage"`
+	Classifier string           `json:"classifier"`
+	LLM        LLMConfig        `json:"llm"`
+	Jira       JiraConfig       `json:"jira"`
+	Confluence ConfluenceConfig `json:"confluence"`
+	Export     ExportConfig     `json:"export"`
+	Output     OutputConfig     `json:"output"`
+	Allocation AllocationConfig `json:"allocation"`
+	Telemetry  TelemetryConfig  `json:"telemetry"`
+	Code       CodeConfig       `json:"code"`
+	Docs       DocsConfig       `json:"docs"`
+	Coverage   CoverageConfig   `json:"coverage"`
+	Network    NetworkConfig    `json:"network"`
+	Signing    SigningConfig    `json:"signing"`
+	Defaults   DefaultsConfig   `json:"defaults"`
+	// Hooks run shell commands or post to webhooks when events are published
+	Hooks []HookConfig `json:"hooks,omitempty"`
+	// ERP books the capitalized amounts to cost centers and GL accounts for the ERP export
+	ERP ERPConfig `json:"erp,omitzero"`
+}
+
+// Default returns the configuration used when no config file is present
+func Default() Config {
+	return Config{
+		Storage: StorageConfig{
+			Backend:            StorageBackendJSON,
+			Directory:          ".assetcap",
+			TrashRetentionDays: DefaultTrashRetentionDays,
+		},
+		Classifier: ClassifierLlama,
+		LLM: LLMConfig{
+			Provider: LLMProviderOllama,
+		},
+		Allocation: AllocationConfig{
+			DefaultHours:   DefaultAllocationHours,
+			SameDayMinimum: DefaultAllocationMinimumHours,
+		},
+		Docs: DocsConfig{
+			DefaultMaxAgeDays: DefaultDocsMaxAgeDays,
+		},
+		Coverage: CoverageConfig{
+			CoverageThresholds: CoverageThresholds{
+				WorkTypePercent: DefaultCoverageWorkTypePercent,
+				AssetPercent:    DefaultCoverageAssetPercent,
+			},
+		},
+	}
+}
+
+// Load reads the configuration file at path, falling back to defaults for
+// a missing file or omitted fields
+func Load(path string) (Config, error) {
+	cfg := Default()
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to unmarshal config file: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return cfg, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Save validates the configuration and writes it to path, creating its directory
+func Save(path string, cfg Config) error {
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// Validate checks that every configured backend is supported
+func (c Config) Validate() error {
+	if c.Storage.Backend != StorageBackendJSON {
+		return fmt.Errorf("unsupported storage backend: %s", c.Storage.Backend)
+	}
+	if c.Storage.Directory == "" {
+		return fmt.Errorf("storage directory cannot be empty")
+	}
+	if c.Storage.TrashRetentionDays <= 0 {
+		return fmt.Errorf("storage trash retention days must be positive")
+	}
+	if c.Classifier != ClassifierLlama && c.Classifier != ClassifierRandom {
+		return fmt.Errorf("unsupported classifier: %s", c.Classifier)
+	}
+	if c.LLM.Provider != LLMProviderOllama && c.LLM.Provider != LLMProviderNone {
+		return fmt.Errorf("unsupported LLM provider: %s", c.LLM.Provider)
+	}
+	if c.Classifier == ClassifierLlama && c.LLM.Provider == LLMProviderNone {
+		return fmt.Errorf("the llama classifier needs an LLM provider: set llm.provider to ollama, or classifier to random")
+	}
+	for i, level := range c.Jira.Hierarchy {
+		if level.Level == "" || level.Field == "" {
+			return fmt.Errorf("jira hierarchy level %d must define both level and field", i+1)
+		}
+	}
+	for _, label := range c.Jira.ManagedLabels {
+		if !strings.HasPrefix(label, "cap-") {
+			return fmt.Errorf("jira managed label %s must start with cap-", label)
+		}
+	}
+	for _, space := range c.Confluence.Spaces {
+		if strings.TrimSpace(space) == "" || strings.Contains(space, ",") {
+			return fmt.Errorf("confluence space %q must be a single non-empty space key", space)
+		}
+	}
+	for _, field := range c.Jira.Fields {
+		if strings.TrimSpace(field) == "" || strings.Contains(field, ",") {
+			return fmt.Errorf("jira field %q must be a single non-empty field id", field)
+		}
+	}
+	if c.Jira.PageSize < 0 {
+		return fmt.Errorf("jira page size cannot be negative")
+	}
+	if c.Jira.Export != "" {
+		if _, err := jiraexport.DetectFormat(c.Jira.Export); err != nil {
+			return fmt.Errorf("jira export: %w", err)
+		}
+	}
+	if !c.Allocation.DisableDefaultHours && c.Allocation.DefaultHours <= 0 {
+		return fmt.Errorf("allocation default hours must be positive")
+	}
+	if !c.Allocation.DisableSameDayMinimum && c.Allocation.SameDayMinimum <= 0 {
+		return fmt.Errorf("allocation same-day minimum must be positive")
+	}
+	if c.Allocation.StaleInProgressDays < 0 {
+		return fmt.Errorf("allocation stale in progress days cannot be negative")
+	}
+	if c.Allocation.StaleCapHours < 0 {
+		return fmt.Errorf("allocation stale cap hours cannot be negative")
+	}
+	if c.Allocation.StaleCapHours > 0 && c.Allocation.StaleInProgressDays == 0 {
+		return fmt.Errorf("allocation stale cap hours needs stale in progress days")
+	}
+	if c.Allocation.LowConfidence < 0 || c.Allocation.LowConfidence > 100 {
+		return fmt.Errorf("allocation low confidence must be between 0 and 100")
+	}
+	if calendar := c.Allocation.Calendar; !calendar.Enabled() && (len(calendar.Weekend) > 0 || len(calendar.Holidays) > 0 || calendar.TimeZone != "") {
+		return fmt.Errorf("allocation calendar needs the workday dayStart and dayEnd")
+	}
+	if endpoint := c.Telemetry.Endpoint; endpoint != "" && !strings.HasPrefix(endpoint, "https://") && !strings.HasPrefix(endpoint, "http://") {
+		return fmt.Errorf("telemetry endpoint %s must be an http or https URL", endpoint)
+	}
+	if c.Docs.DefaultMaxAgeDays < 0 {
+		return fmt.Errorf("docs default max age days cannot be negative")
+	}
+	for status, days := range c.Docs.MaxAgeDays {
+		if days < 0 {
+			return fmt.Errorf("docs max age days of status %s cannot be negative", status)
+		}
+	}
+	if c.Network.TimeoutSeconds < 0 {
+		return fmt.Errorf("network timeout seconds cannot be negative")
+	}
+	if (c.Network.ClientCertificate == "") != (c.Network.ClientKey == "") {
+		return fmt.Errorf("network client certificate and client key must be configured together")
+	}
+	switch c.Signing.Method {
+	case "", SigningMethodGPG:
+	case SigningMethodMinisign:
+		if c.Signing.Key == "" {
+			return fmt.Errorf("signing method minisign needs a secret key file")
+		}
+	default:
+		return fmt.Errorf("unsupported signing method: %s", c.Signing.Method)
+	}
+	for _, key := range DefaultKeys {
+		if value := c.Defaults.Get(key); strings.ContainsAny(value, " ,") {
+			return fmt.Errorf("default %s %q must be a single value", key, value)
+		}
+	}
+	for i, hook := range c.Hooks {
+		if err := hook.validate(); err != nil {
+			return fmt.Errorf("hook %d: %w", i+1, err)
+		}
+	}
+	if err := c.Coverage.validate(""); err != nil {
+		return err
+	}
+	for project, thresholds := range c.Coverage.Projects {
+		if err := thresholds.validate(" of project " + project); err != nil {
+			return err
+		}
+	}
+	return c.Code.validate()
+}
+
+// WithoutSecrets returns a copy of the configuration without the values that may hold
+// credentials, such as the webhook headers, for sharing it outside the workstation. Command
+// hooks are left out too, as they would run on the machine the configuration is shared with.
+func (c Config) WithoutSecrets() Config {
+	c.Output.WebhookHeaders = nil
+	if c.Hooks != nil {
+		hooks := make([]HookConfig, 0, len(c.Hooks))
+		for _, hook := range c.Hooks {
+			if hook.Command != "" {
+				continue
+			}
+			hook.Headers = nil
+			hooks = append(hooks, hook)
+		}
+		c.Hooks = hooks
+	}
+	return c
+}
+
+// validate checks the hook runs on a known event and does exactly one thing
+func (h HookConfig) validate() error {
+	if !events.Valid(events.Name(h.Event)) {
+		names := make([]string, len(events.Names))
+		for i, name := range events.Names {
+			names[i] = string(name)
+		}
+		return fmt.Errorf("unknown event %q: use one of %s, or * for every event", h.Event, strings.Join(names, ", "))
+	}
+	if (h.Command == "") == (h.Webhook == "") {
+		return fmt.Errorf("set either a command or a webhook")
+	}
+	if h.Webhook != "" && !strings.HasPrefix(h.Webhook, "https://") && !strings.HasPrefix(h.Webhook, "http://") {
+		return fmt.Errorf("webhook %s must be an http or https URL", h.Webhook)
+	}
+	if h.TimeoutSeconds < 0 {
+		return fmt.Errorf("timeout seconds cannot be negative")
+	}
+	return nil
+}
+
+// validate checks the code host and the names of its repositories
+func (c CodeConfig) validate() error {
+	switch c.Host {
+	case "":
+		return nil
+	case CodeHostGitHub, CodeHostBitbucket:
+	default:
+		return fmt.Errorf("unsupported code host: %s", c.Host)
+	}
+	if len(c.Repositories) == 0 {
+		return fmt.Errorf("code host %s needs at least one repository", c.Host)
+	}
+	for _, repository := range c.Repositories {
+		owner, name, ok := strings.Cut(repository, "/")
+		if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+			return fmt.Errorf("code repository %s must be named owner/name", repository)
+		}
+	}
+	return nil
+}
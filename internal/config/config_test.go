@@ -0,0 +1,362 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_MissingFileReturnsDefaults(t *testing.T) {
+	cfg, err := Load(filepath.Join(t.TempDir(), "config.json"))
+	require.NoError(t, err)
+	assert.Equal(t, Default(), cfg)
+}
+
+func TestLoad_OverridesDefaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"storage": {"directory": "data"}, "classifier": "random", "llm": {"provider": "none"}}`), 0644))
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, StorageBackendJSON, cfg.Storage.Backend)
+	assert.Equal(t, "data", cfg.Storage.Directory)
+	assert.Equal(t, DefaultTrashRetentionDays, cfg.Storage.TrashRetentionDays)
+	assert.Equal(t, ClassifierRandom, cfg.Classifier)
+	assert.Equal(t, LLMProviderNone, cfg.LLM.Provider)
+}
+
+func TestLoad_LlamaClassifierNeedsLLM(t *testing.T) {
+	assert.Equal(t, ClassifierLlama, Default().Classifier)
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"llm": {"provider": "none"}}`), 0644))
+
+	_, err := Load(path)
+	assert.ErrorContains(t, err, "the llama classifier needs an LLM provider")
+}
+
+func TestLoad_JiraHierarchy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"jira": {"hierarchy": [
+		{"level": "epic", "field": "parent"},
+		{"level": "initiative", "field": "customfield_10200"}
+	]}}`), 0644))
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, []HierarchyLevelConfig{
+		{Level: "epic", Field: "parent"},
+		{Level: "initiative", Field: "customfield_10200"},
+	}, cfg.Jira.Hierarchy)
+}
+
+func TestLoad_JiraLabels(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"jira": {"managedLabels": ["cap-*"], "protectedLabels": ["cap-asset-*", "security"]}}`), 0644))
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cap-*"}, cfg.Jira.ManagedLabels)
+	assert.Equal(t, []string{"cap-asset-*", "security"}, cfg.Jira.ProtectedLabels)
+}
+
+func TestLoad_ExportLocale(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"export": {"locale": "de-DE"}}`), 0644))
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "de-DE", cfg.Export.Locale)
+	assert.Empty(t, cfg.Export.PercentFormat)
+	assert.Nil(t, cfg.Export.PercentDecimals)
+}
+
+func TestLoad_ExportPercentFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"export": {"percentFormat": "fraction", "percentDecimals": 0}}`), 0644))
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "fraction", cfg.Export.PercentFormat)
+	require.NotNil(t, cfg.Export.PercentDecimals)
+	assert.Equal(t, 0, *cfg.Export.PercentDecimals)
+}
+
+func TestLoad_Output(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"output": {
+		"destinations": {"sprint allocate": "s3://reports/allocation.csv"},
+		"webhookHeaders": {"Authorization": "Bearer token"},
+		"s3Endpoint": "http://localhost:9000"
+	}}`), 0644))
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"sprint allocate": "s3://reports/allocation.csv"}, cfg.Output.Destinations)
+	assert.Equal(t, map[string]string{"Authorization": "Bearer token"}, cfg.Output.WebhookHeaders)
+	assert.Equal(t, "http://localhost:9000", cfg.Output.S3Endpoint)
+}
+
+func TestLoad_Confluence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"confluence": {"spaces": ["MZN", "PAY", "PLAT"]}}`), 0644))
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"MZN", "PAY", "PLAT"}, cfg.Confluence.Spaces)
+}
+
+func TestLoad_Allocation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"allocation": {"defaultHours": 4, "disableSameDayMinimum": true, "sameDayMinimum": 0, "staleInProgressDays": 10, "staleCapHours": 40}}`), 0644))
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, AllocationConfig{DefaultHours: 4, DisableSameDayMinimum: true, StaleInProgressDays: 10, StaleCapHours: 40}, cfg.Allocation)
+}
+
+func TestLoad_AllocationCalendar(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"allocation": {"calendar": {"dayStart": "09:00", "dayEnd": "17:00", "weekend": ["friday", "saturday"], "holidays": ["2024-12-25"], "timeZone": "Asia/Jerusalem"}}}`), 0644))
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, CalendarConfig{DayStart: "09:00", DayEnd: "17:00", Weekend: []string{"friday", "saturday"}, Holidays: []string{"2024-12-25"}, TimeZone: "Asia/Jerusalem"}, cfg.Allocation.Calendar)
+	assert.True(t, cfg.Allocation.Calendar.Enabled())
+	assert.False(t, Default().Allocation.Calendar.Enabled(), "allocations count wall-clock hours by default")
+}
+
+func TestLoad_Telemetry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"telemetry": {"endpoint": "https://stats.example.com/assetcap"}}`), 0644))
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "https://stats.example.com/assetcap", cfg.Telemetry.Endpoint)
+}
+
+func TestLoad_Code(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"code": {"host": "github", "repositories": ["acme/api", "acme/web"]}}`), 0644))
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, CodeConfig{Host: CodeHostGitHub, Repositories: []string{"acme/api", "acme/web"}}, cfg.Code)
+}
+
+func TestLoad_Docs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"docs": {"maxAgeDays": {"Retired": 0, "Development": 30}, "notify": "https://hooks.example.com/docs"}}`), 0644))
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, DocsConfig{
+		MaxAgeDays:        map[string]int{"Retired": 0, "Development": 30},
+		DefaultMaxAgeDays: DefaultDocsMaxAgeDays,
+		Notify:            "https://hooks.example.com/docs",
+	}, cfg.Docs)
+}
+
+func TestLoad_Coverage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"coverage": {"assetPercent": 70, "projects": {"OPS": {"workTypePercent": 95}}}}`), 0644))
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, CoverageThresholds{WorkTypePercent: DefaultCoverageWorkTypePercent, AssetPercent: 70}, cfg.Coverage.For("FN"))
+	assert.Equal(t, CoverageThresholds{WorkTypePercent: 95}, cfg.Coverage.For("OPS"), "a project entry replaces both thresholds")
+}
+
+func TestLoad_Network(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"network": {
+		"proxyUrl": "http://proxy.example.com:8080",
+		"proxyUsername": "jane",
+		"caBundle": "/etc/ssl/corp-ca.pem",
+		"clientCertificate": "client.pem",
+		"clientKey": "client-key.pem",
+		"timeoutSeconds": 60
+	}}`), 0644))
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, NetworkConfig{
+		ProxyURL:          "http://proxy.example.com:8080",
+		ProxyUsername:     "jane",
+		CABundle:          "/etc/ssl/corp-ca.pem",
+		ClientCertificate: "client.pem",
+		ClientKey:         "client-key.pem",
+		TimeoutSeconds:    60,
+	}, cfg.Network)
+}
+
+func TestLoad_Signing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"signing": {
+		"method": "minisign",
+		"key": "assetcap.key",
+		"publicKey": "assetcap.pub"
+	}}`), 0644))
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, SigningConfig{Method: "minisign", Key: "assetcap.key", PublicKey: "assetcap.pub"}, cfg.Signing)
+	assert.True(t, cfg.Signing.Enabled(), "a signature method writes the checksums too")
+	assert.False(t, Default().Signing.Enabled())
+	assert.True(t, SigningConfig{Checksums: true}.Enabled())
+}
+
+func TestLoad_Hooks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"hooks": [
+		{"event": "TasksClassified", "command": "./notify.sh"},
+		{"event": "*", "webhook": "https://hooks.example.com/assetcap", "headers": {"Authorization": "Bearer secret"}, "timeoutSeconds": 5}
+	]}`), 0644))
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, []HookConfig{
+		{Event: "TasksClassified", Command: "./notify.sh"},
+		{Event: "*", Webhook: "https://hooks.example.com/assetcap", Headers: map[string]string{"Authorization": "Bearer secret"}, TimeoutSeconds: 5},
+	}, cfg.Hooks)
+}
+
+func TestLoad_ERP(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"erp": {
+		"costCenter": "CC-900",
+		"glAccount": "1700",
+		"format": "fixed-width",
+		"columns": [{"field": "costCenter", "width": 10}, {"field": "amount", "width": 15}]
+	}}`), 0644))
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, ERPConfig{
+		CostCenter: "CC-900",
+		GLAccount:  "1700",
+		Format:     "fixed-width",
+		Columns:    []ERPColumnConfig{{Field: "costCenter", Width: 10}, {Field: "amount", Width: 15}},
+	}, cfg.ERP)
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"defaults": {"project": "FN", "platform": "jira"}}`), 0644))
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultsConfig{Project: "FN", Platform: "jira"}, cfg.Defaults)
+	assert.Equal(t, "FN", cfg.Defaults.Get("project"))
+	assert.Empty(t, cfg.Defaults.Get("sprint"))
+}
+
+func TestDefaultsConfig_Set(t *testing.T) {
+	var defaults DefaultsConfig
+	require.NoError(t, defaults.Set("project", " FN "))
+	require.NoError(t, defaults.Set("platform", "Jira"))
+	assert.Equal(t, DefaultsConfig{Project: "FN", Platform: "jira"}, defaults)
+
+	require.NoError(t, defaults.Set("project", ""))
+	assert.Empty(t, defaults.Project, "an empty value removes the default")
+
+	assert.EqualError(t, defaults.Set("sprint", "Sprint 1"), "unknown default sprint: must be one of project, platform")
+	assert.EqualError(t, defaults.Set("project", "FN,PAY"), `default project "FN,PAY" must be a single value`)
+}
+
+func TestSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".assetcap", "config.json")
+	cfg := Default()
+	cfg.Jira.ProtectedLabels = []string{"cap-asset-*"}
+	require.NoError(t, Save(path, cfg))
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, cfg, loaded)
+
+	cfg.Classifier = "magic"
+	assert.EqualError(t, Save(path, cfg), "invalid config: unsupported classifier: magic")
+}
+
+func TestWithoutSecrets(t *testing.T) {
+	cfg := Default()
+	cfg.Output.Destinations = map[string]string{"sprint allocate": "https://hooks.example.com/allocations"}
+	cfg.Output.WebhookHeaders = map[string]string{"Authorization": "Bearer secret"}
+
+	shared := cfg.WithoutSecrets()
+	assert.Nil(t, shared.Output.WebhookHeaders)
+	assert.Equal(t, cfg.Output.Destinations, shared.Output.Destinations)
+	assert.Equal(t, "Bearer secret", cfg.Output.WebhookHeaders["Authorization"])
+
+	cfg.Hooks = []HookConfig{
+		{Event: "*", Command: "./notify.sh"},
+		{Event: "*", Webhook: "https://hooks.example.com", Headers: map[string]string{"Authorization": "Bearer secret"}},
+	}
+	shared = cfg.WithoutSecrets()
+	require.Len(t, shared.Hooks, 1, "command hooks are not shared")
+	assert.Nil(t, shared.Hooks[0].Headers)
+	assert.Equal(t, "https://hooks.example.com", shared.Hooks[0].Webhook)
+	assert.Equal(t, "Bearer secret", cfg.Hooks[1].Headers["Authorization"])
+	assert.Len(t, cfg.Hooks, 2)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"invalid json", `{`, "failed to unmarshal config file"},
+		{"unknown storage backend", `{"storage": {"backend": "sqlite"}}`, "unsupported storage backend: sqlite"},
+		{"empty storage directory", `{"storage": {"directory": ""}}`, "storage directory cannot be empty"},
+		{"non-positive trash retention", `{"storage": {"trashRetentionDays": 0}}`, "storage trash retention days must be positive"},
+		{"unknown classifier", `{"classifier": "ml"}`, "unsupported classifier: ml"},
+		{"unknown LLM provider", `{"llm": {"provider": "openai"}}`, "unsupported LLM provider: openai"},
+		{"incomplete hierarchy level", `{"jira": {"hierarchy": [{"level": "epic"}]}}`, "jira hierarchy level 1 must define both level and field"},
+		{"unmanaged label taxonomy", `{"jira": {"managedLabels": ["team-a"]}}`, "jira managed label team-a must start with cap-"},
+		{"blank confluence space", `{"confluence": {"spaces": ["MZN", ""]}}`, `confluence space "" must be a single non-empty space key`},
+		{"comma-separated confluence spaces", `{"confluence": {"spaces": ["MZN,PAY"]}}`, `confluence space "MZN,PAY" must be a single non-empty space key`},
+		{"blank jira field", `{"jira": {"fields": [" "]}}`, `jira field " " must be a single non-empty field id`},
+		{"comma-separated jira fields", `{"jira": {"fields": ["a,b"]}}`, `jira field "a,b" must be a single non-empty field id`},
+		{"negative jira page size", `{"jira": {"pageSize": -1}}`, "jira page size cannot be negative"},
+		{"unsupported jira export", `{"jira": {"export": "backup/FN.xml"}}`, "jira export: unsupported export file backup/FN.xml: expected a .json or .csv file"},
+		{"non-positive default hours", `{"allocation": {"defaultHours": 0}}`, "allocation default hours must be positive"},
+		{"negative same-day minimum", `{"allocation": {"sameDayMinimum": -1}}`, "allocation same-day minimum must be positive"},
+		{"negative stale in progress days", `{"allocation": {"staleInProgressDays": -1}}`, "allocation stale in progress days cannot be negative"},
+		{"negative stale cap hours", `{"allocation": {"staleInProgressDays": 10, "staleCapHours": -1}}`, "allocation stale cap hours cannot be negative"},
+		{"stale cap without threshold", `{"allocation": {"staleCapHours": 40}}`, "allocation stale cap hours needs stale in progress days"},
+		{"calendar without workday hours", `{"allocation": {"calendar": {"holidays": ["2024-12-25"]}}}`, "allocation calendar needs the workday dayStart and dayEnd"},
+		{"low confidence above 100", `{"allocation": {"lowConfidence": 120}}`, "allocation low confidence must be between 0 and 100"},
+		{"telemetry endpoint without scheme", `{"telemetry": {"endpoint": "stats.example.com"}}`, "telemetry endpoint stats.example.com must be an http or https URL"},
+		{"unknown code host", `{"code": {"host": "gitlab", "repositories": ["acme/api"]}}`, "unsupported code host: gitlab"},
+		{"code host without repositories", `{"code": {"host": "bitbucket"}}`, "code host bitbucket needs at least one repository"},
+		{"negative docs default max age", `{"docs": {"defaultMaxAgeDays": -1}}`, "docs default max age days cannot be negative"},
+		{"negative docs max age", `{"docs": {"maxAgeDays": {"Live": -5}}}`, "docs max age days of status Live cannot be negative"},
+		{"coverage threshold above 100", `{"coverage": {"workTypePercent": 120}}`, "coverage thresholds must be between 0 and 100"},
+		{"negative project coverage threshold", `{"coverage": {"projects": {"FN": {"assetPercent": -1}}}}`, "coverage thresholds of project FN must be between 0 and 100"},
+		{"negative network timeout", `{"network": {"timeoutSeconds": -1}}`, "network timeout seconds cannot be negative"},
+		{"client certificate without key", `{"network": {"clientCertificate": "client.pem"}}`, "network client certificate and client key must be configured together"},
+		{"unknown signing method", `{"signing": {"method": "pgp"}}`, "unsupported signing method: pgp"},
+		{"minisign without key", `{"signing": {"method": "minisign"}}`, "signing method minisign needs a secret key file"},
+		{"default project with several values", `{"defaults": {"project": "FN PAY"}}`, `default project "FN PAY" must be a single value`},
+		{"repository without owner", `{"code": {"host": "github", "repositories": ["api"]}}`, "code repository api must be named owner/name"},
+		{"hook on unknown event", `{"hooks": [{"event": "AssetDeleted", "command": "true"}]}`, `hook 1: unknown event "AssetDeleted"`},
+		{"hook without action", `{"hooks": [{"event": "AssetSynced"}]}`, "hook 1: set either a command or a webhook"},
+		{"hook with command and webhook", `{"hooks": [{"event": "*", "command": "true", "webhook": "https://hooks.example.com"}]}`, "hook 1: set either a command or a webhook"},
+		{"hook webhook without scheme", `{"hooks": [{"event": "*", "webhook": "hooks.example.com"}]}`, "hook 1: webhook hooks.example.com must be an http or https URL"},
+		{"negative hook timeout", `{"hooks": [{"event": "*", "command": "true", "timeoutSeconds": -1}]}`, "hook 1: timeout seconds cannot be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.json")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0644))
+
+			_, err := Load(path)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultCredentialsPath is where assetcap init stores the credentials it collected
+const DefaultCredentialsPath = ".assetcap/credentials.env"
+
+// LoadCredentials sets the environment variables of a credentials file that are not set
+// already, so exported variables win. A missing file is not an error.
+func LoadCredentials(path string) error {
+	values, err := ReadCredentials(path)
+	if err != nil {
+		return err
+	}
+	for key, value := range values {
+		if _, set := os.LookupEnv(key); set {
+			continue
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return fmt.Errorf("failed to set %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// ReadCredentials reads the variables of a credentials file; a missing file has none.
+//
+// The file holds one KEY=value per line, optionally prefixed with export and with the value
+// in quotes, so it can be sourced by a shell as well.
+func ReadCredentials(path string) (map[string]string, error) {
+	values := make(map[string]string)
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return values, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open credentials file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(strings.TrimPrefix(text, "export "), "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid credentials file %s: line %d is not KEY=value", path, line)
+		}
+		values[key] = unquote(strings.TrimSpace(value))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read credentials file: %w", err)
+	}
+	return values, nil
+}
+
+// SaveCredentials writes the variables to a credentials file readable by its owner only
+func SaveCredentials(path string, values map[string]string) error {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString("# assetcap credentials, loaded by every assetcap command; keep this file private\n")
+	for _, key := range keys {
+		fmt.Fprintf(&b, "export %s='%s'\n", key, strings.ReplaceAll(values[key], "'", `'\''`))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create credentials directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0600); err != nil {
+		return fmt.Errorf("failed to write credentials file: %w", err)
+	}
+	// WriteFile keeps the mode of an existing file
+	if err := os.Chmod(path, 0600); err != nil {
+		return fmt.Errorf("failed to restrict credentials file: %w", err)
+	}
+	return nil
+}
+
+// unquote removes the single or double quotes around a value, undoing the escaping of
+// single quotes SaveCredentials applies
+func unquote(value string) string {
+	if len(value) >= 2 {
+		switch {
+		case value[0] == '\'' && value[len(value)-1] == '\'':
+			return strings.ReplaceAll(value[1:len(value)-1], `'\''`, "'")
+		case value[0] == '"' && value[len(value)-1] == '"':
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
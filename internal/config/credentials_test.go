@@ -0,0 +1,57 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredentials_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".assetcap", "credentials.env")
+	require.NoError(t, SaveCredentials(path, map[string]string{
+		"ASSETCAP_TEST_URL":   "https://acme.atlassian.net",
+		"ASSETCAP_TEST_TOKEN": "it's secret",
+	}))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	t.Setenv("ASSETCAP_TEST_URL", "https://exported.example.com")
+	t.Setenv("ASSETCAP_TEST_TOKEN", "")
+	os.Unsetenv("ASSETCAP_TEST_TOKEN")
+
+	require.NoError(t, LoadCredentials(path))
+	assert.Equal(t, "https://exported.example.com", os.Getenv("ASSETCAP_TEST_URL"), "exported variables win")
+	assert.Equal(t, "it's secret", os.Getenv("ASSETCAP_TEST_TOKEN"))
+}
+
+func TestLoadCredentials(t *testing.T) {
+	require.NoError(t, LoadCredentials(filepath.Join(t.TempDir(), "missing.env")))
+
+	path := filepath.Join(t.TempDir(), "credentials.env")
+	require.NoError(t, os.WriteFile(path, []byte("# comment\n\nASSETCAP_TEST_EMAIL=\"jane@example.com\"\n"), 0600))
+	t.Setenv("ASSETCAP_TEST_EMAIL", "")
+	os.Unsetenv("ASSETCAP_TEST_EMAIL")
+	require.NoError(t, LoadCredentials(path))
+	assert.Equal(t, "jane@example.com", os.Getenv("ASSETCAP_TEST_EMAIL"))
+
+	require.NoError(t, os.WriteFile(path, []byte("ASSETCAP_TEST_EMAIL\n"), 0600))
+	assert.EqualError(t, LoadCredentials(path), "invalid credentials file "+path+": line 1 is not KEY=value")
+}
+
+func TestReadCredentials(t *testing.T) {
+	values, err := ReadCredentials(filepath.Join(t.TempDir(), "missing.env"))
+	require.NoError(t, err)
+	assert.Empty(t, values)
+
+	path := filepath.Join(t.TempDir(), "credentials.env")
+	require.NoError(t, os.WriteFile(path, []byte("export JIRA_EMAIL='jane@example.com'\nJIRA_TOKEN=token\n"), 0600))
+	t.Setenv("JIRA_TOKEN", "exported")
+	values, err = ReadCredentials(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"JIRA_EMAIL": "jane@example.com", "JIRA_TOKEN": "token"}, values, "the file is read regardless of the environment")
+}
@@ -0,0 +1,138 @@
+package dashboard
+
+import (
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+)
+
+const (
+	chartWidth    = 640
+	barHeight     = 22
+	labelWidth    = 180
+	columnsHeight = 200
+	// axisHeight leaves room for the sprint labels under the columns
+	axisHeight = 60
+)
+
+// palette colours the work types of the charts, in the order of siteWorkTypes
+var palette = []string{"#2b6cb0", "#dd6b20", "#38a169", "#805ad5", "#d53f8c", "#718096"}
+
+// siteWorkTypes returns the work types the sprints were classified with, sorted
+func siteWorkTypes(sprints []SprintCoverage) []string {
+	seen := make(map[string]bool)
+	var workTypes []string
+	for _, sprint := range sprints {
+		for workType := range sprint.WorkTypes {
+			if !seen[workType] {
+				seen[workType] = true
+				workTypes = append(workTypes, workType)
+			}
+		}
+	}
+	sort.Strings(workTypes)
+	return workTypes
+}
+
+// color returns the palette colour of the i-th series
+func color(i int) string {
+	return palette[i%len(palette)]
+}
+
+// hoursChart draws labelled horizontal bars scaled to the largest number of hours
+func hoursChart(hours []Hours) template.HTML {
+	if len(hours) == 0 {
+		return ""
+	}
+	most := 0.0
+	for _, h := range hours {
+		if h.Hours > most {
+			most = h.Hours
+		}
+	}
+
+	var b strings.Builder
+	height := len(hours) * (barHeight + 6)
+	fmt.Fprintf(&b, `<svg class="chart" viewBox="0 0 %d %d" role="img">`, chartWidth, height)
+	for i, h := range hours {
+		y := i * (barHeight + 6)
+		width := 0.0
+		if most > 0 {
+			width = h.Hours / most * float64(chartWidth-labelWidth-60)
+		}
+		fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="end">%s</text>`, labelWidth-8, y+barHeight-6, template.HTMLEscapeString(h.Label))
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%.1f" height="%d" fill="%s"><title>%s: %.1f h</title></rect>`,
+			labelWidth, y, width, barHeight, color(i), template.HTMLEscapeString(h.Label), h.Hours)
+		fmt.Fprintf(&b, `<text x="%.1f" y="%d">%.1f h</text>`, float64(labelWidth)+width+6, y+barHeight-6, h.Hours)
+	}
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
+
+// coverageChart draws the classified percentage of every sprint as a column, oldest first
+func coverageChart(sprints []SprintCoverage) template.HTML {
+	if len(sprints) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	columnWidth := float64(chartWidth-40) / float64(len(sprints))
+	fmt.Fprintf(&b, `<svg class="chart" viewBox="0 0 %d %d" role="img">`, chartWidth, columnsHeight+axisHeight)
+	writeAxis(&b)
+	for i, sprint := range sprints {
+		x := 40 + float64(i)*columnWidth
+		height := sprint.Percent() / 100 * columnsHeight
+		fmt.Fprintf(&b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"><title>%s: %.1f%% of %d tasks</title></rect>`,
+			x+columnWidth*0.15, columnsHeight-height, columnWidth*0.7, height, color(0), template.HTMLEscapeString(sprint.Label()), sprint.Percent(), sprint.Tasks)
+		writeColumnLabel(&b, x+columnWidth/2, sprint.Label())
+	}
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
+
+// mixChart draws the work type mix of the classified tasks of every sprint as stacked
+// columns, oldest first
+func mixChart(sprints []SprintCoverage, workTypes []string) template.HTML {
+	if len(sprints) == 0 || len(workTypes) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	columnWidth := float64(chartWidth-40) / float64(len(sprints))
+	fmt.Fprintf(&b, `<svg class="chart" viewBox="0 0 %d %d" role="img">`, chartWidth, columnsHeight+axisHeight)
+	writeAxis(&b)
+	for i, sprint := range sprints {
+		x := 40 + float64(i)*columnWidth
+		classified := sprint.Classified()
+		top := float64(columnsHeight)
+		for j, workType := range workTypes {
+			count := sprint.WorkTypes[workType]
+			if count == 0 {
+				continue
+			}
+			height := float64(count) / float64(classified) * columnsHeight
+			top -= height
+			fmt.Fprintf(&b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"><title>%s %s: %d of %d tasks</title></rect>`,
+				x+columnWidth*0.15, top, columnWidth*0.7, height, color(j), template.HTMLEscapeString(sprint.Label()), template.HTMLEscapeString(workType), count, classified)
+		}
+		writeColumnLabel(&b, x+columnWidth/2, sprint.Label())
+	}
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
+
+// writeAxis draws the 0, 50 and 100 percent gridlines of a column chart
+func writeAxis(b *strings.Builder) {
+	for _, percent := range []int{0, 50, 100} {
+		y := columnsHeight - percent*columnsHeight/100
+		fmt.Fprintf(b, `<line class="grid" x1="40" y1="%d" x2="%d" y2="%d"/><text x="34" y="%d" text-anchor="end">%d%%</text>`,
+			y, chartWidth, y, y+4, percent)
+	}
+}
+
+// writeColumnLabel writes a slanted sprint label under a column
+func writeColumnLabel(b *strings.Builder, x float64, label string) {
+	fmt.Fprintf(b, `<text class="label" x="%.1f" y="%d" transform="rotate(-30 %.1f %d)" text-anchor="end">%s</text>`,
+		x, columnsHeight+14, x, columnsHeight+14, template.HTMLEscapeString(label))
+}
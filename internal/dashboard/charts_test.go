@@ -0,0 +1,51 @@
+package dashboard
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHoursChart(t *testing.T) {
+	chart := string(hoursChart([]Hours{{Label: "Development", Hours: 30}, {Label: "R&D", Hours: 15}}))
+
+	assert.True(t, strings.HasPrefix(chart, `<svg class="chart" viewBox="0 0 640 56"`))
+	assert.Contains(t, chart, `width="400.0"`)
+	assert.Contains(t, chart, `width="200.0"`)
+	assert.Contains(t, chart, ">R&amp;D<")
+	assert.Contains(t, chart, ">30.0 h<")
+	assert.Empty(t, hoursChart(nil))
+}
+
+func TestCoverageChart(t *testing.T) {
+	chart := string(coverageChart([]SprintCoverage{
+		{Project: "FN", Sprint: "Owls", Tasks: 4, WorkTypes: map[string]int{"cap-development": 2}},
+		{Project: "FN", Sprint: "Penguins", Tasks: 2, WorkTypes: map[string]int{"cap-development": 2}},
+	}))
+
+	assert.Contains(t, chart, `y="100.0" width="210.0" height="100.0"`)
+	assert.Contains(t, chart, `y="0.0" width="210.0" height="200.0"`)
+	assert.Contains(t, chart, "<title>FN Owls: 50.0% of 4 tasks</title>")
+	assert.Contains(t, chart, ">100%</text>")
+	assert.Empty(t, coverageChart(nil))
+}
+
+func TestMixChart(t *testing.T) {
+	sprints := []SprintCoverage{
+		{Project: "FN", Sprint: "Owls", Tasks: 5, WorkTypes: map[string]int{"cap-development": 3, "cap-maintenance": 1}},
+	}
+
+	chart := string(mixChart(sprints, siteWorkTypes(sprints)))
+
+	assert.Contains(t, chart, `y="50.0" width="420.0" height="150.0" fill="#2b6cb0"><title>FN Owls cap-development: 3 of 4 tasks</title>`)
+	assert.Contains(t, chart, `y="0.0" width="420.0" height="50.0" fill="#dd6b20"><title>FN Owls cap-maintenance: 1 of 4 tasks</title>`)
+	assert.Empty(t, mixChart(sprints, nil))
+}
+
+func TestSiteWorkTypes(t *testing.T) {
+	assert.Equal(t, []string{"cap-development", "cap-discovery"}, siteWorkTypes([]SprintCoverage{
+		{WorkTypes: map[string]int{"cap-discovery": 1}},
+		{WorkTypes: map[string]int{"cap-development": 2, "cap-discovery": 1}},
+	}))
+}
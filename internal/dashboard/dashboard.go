@@ -0,0 +1,156 @@
+// Package dashboard renders a read-only static HTML site of the asset catalog, the latest
+// sprint allocation and the classification coverage, for stakeholders who don't use the CLI.
+package dashboard
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+//go:embed templates/*.html
+var templates embed.FS
+
+// pages are the pages of the site, by file name and the template rendering them
+var pages = []struct {
+	file, template, title string
+}{
+	{"index.html", "index.html", "Overview"},
+	{"assets.html", "assets.html", "Assets"},
+	{"allocations.html", "allocations.html", "Allocations"},
+	{"coverage.html", "coverage.html", "Classification coverage"},
+}
+
+// Site is the data rendered into the dashboard
+type Site struct {
+	Title       string
+	GeneratedAt time.Time
+	Assets      []Asset
+	// Allocation is the latest sprint allocation, nil when none was computed
+	Allocation *Allocation
+	// Sprints is the classification coverage of every sprint, oldest first
+	Sprints []SprintCoverage
+}
+
+// Asset is an entry of the asset catalog
+type Asset struct {
+	Name        string
+	Description string
+	DocURL      string
+	Tasks       int
+	// DocUpdated is zero when the documentation was never updated
+	DocUpdated time.Time
+}
+
+// Allocation holds the hours of a sprint allocation by work type and by asset
+type Allocation struct {
+	Projects  []string
+	Period    string
+	WorkTypes []Hours
+	Assets    []Hours
+}
+
+// Hours is a labelled number of allocated hours
+type Hours struct {
+	Label string
+	Hours float64
+}
+
+// TotalHours returns the hours allocated across every work type
+func (a Allocation) TotalHours() float64 {
+	total := 0.0
+	for _, hours := range a.WorkTypes {
+		total += hours.Hours
+	}
+	return total
+}
+
+// SprintCoverage counts the tasks of a sprint and how many are classified, by work type
+type SprintCoverage struct {
+	Project   string
+	Sprint    string
+	Tasks     int
+	WorkTypes map[string]int
+}
+
+// Classified returns the number of tasks with a work type
+func (c SprintCoverage) Classified() int {
+	classified := 0
+	for _, count := range c.WorkTypes {
+		classified += count
+	}
+	return classified
+}
+
+// Percent returns the percentage of the tasks that are classified
+func (c SprintCoverage) Percent() float64 {
+	if c.Tasks == 0 {
+		return 0
+	}
+	return float64(c.Classified()) / float64(c.Tasks) * 100
+}
+
+// Label names the sprint in charts and tables
+func (c SprintCoverage) Label() string {
+	return c.Project + " " + c.Sprint
+}
+
+// Build renders the site into dir, creating it if needed, and returns the written files
+func Build(dir string, site Site) ([]string, error) {
+	tmpl, err := template.New("dashboard").Funcs(template.FuncMap{
+		"hours":         func(h float64) string { return fmt.Sprintf("%.1f", h) },
+		"percent":       func(p float64) string { return fmt.Sprintf("%.1f%%", p) },
+		"share":         share,
+		"date":          func(t time.Time) string { return t.Format("2006-01-02") },
+		"workTypes":     func() []string { return siteWorkTypes(site.Sprints) },
+		"lastSprint":    lastSprint,
+		"color":         color,
+		"hoursChart":    hoursChart,
+		"coverageChart": coverageChart,
+		"mixChart":      mixChart,
+	}).ParseFS(templates, "templates/*.html")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse dashboard templates: %w", err)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+	files := make([]string, 0, len(pages))
+	for _, page := range pages {
+		var buf bytes.Buffer
+		data := struct {
+			Site
+			Page string
+		}{site, page.title}
+		if err := tmpl.ExecuteTemplate(&buf, page.template, data); err != nil {
+			return nil, fmt.Errorf("failed to render %s: %w", page.file, err)
+		}
+		path := filepath.Join(dir, page.file)
+		if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		files = append(files, path)
+	}
+	return files, nil
+}
+
+// lastSprint returns the most recent sprint, or nil when there is none
+func lastSprint(sprints []SprintCoverage) *SprintCoverage {
+	if len(sprints) == 0 {
+		return nil
+	}
+	return &sprints[len(sprints)-1]
+}
+
+// share returns part as a percentage of total, or zero when total is zero
+func share(part, total float64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return part / total * 100
+}
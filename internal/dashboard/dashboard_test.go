@@ -0,0 +1,92 @@
+package dashboard
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSite() Site {
+	return Site{
+		Title:       "Capitalization",
+		GeneratedAt: time.Date(2024, time.June, 3, 9, 30, 0, 0, time.UTC),
+		Assets: []Asset{
+			{Name: "booking", Description: "Booking <flow>", DocURL: "https://wiki/booking", Tasks: 12, DocUpdated: time.Date(2024, time.May, 20, 0, 0, 0, 0, time.UTC)},
+			{Name: "search", Description: "Search", Tasks: 3},
+		},
+		Allocation: &Allocation{
+			Projects:  []string{"FN"},
+			Period:    "Penguins",
+			WorkTypes: []Hours{{Label: "Development", Hours: 30}, {Label: "Maintenance", Hours: 10}},
+			Assets:    []Hours{{Label: "booking", Hours: 40}},
+		},
+		Sprints: []SprintCoverage{
+			{Project: "FN", Sprint: "Owls", Tasks: 4, WorkTypes: map[string]int{"cap-development": 2}},
+			{Project: "FN", Sprint: "Penguins", Tasks: 5, WorkTypes: map[string]int{"cap-development": 3, "cap-maintenance": 1}},
+		},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "dashboard")
+
+	files, err := Build(dir, testSite())
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "index.html"),
+		filepath.Join(dir, "assets.html"),
+		filepath.Join(dir, "allocations.html"),
+		filepath.Join(dir, "coverage.html"),
+	}, files)
+
+	index := readPage(t, dir, "index.html")
+	assert.Contains(t, index, "<title>Overview · Capitalization</title>")
+	assert.Contains(t, index, "<strong>40.0 h</strong>allocated in Penguins")
+	assert.Contains(t, index, "<strong>80.0%</strong>of FN Penguins tasks classified")
+	assert.Contains(t, index, "<svg")
+	assert.Contains(t, index, "Generated 2024-06-03 09:30 UTC")
+
+	assets := readPage(t, dir, "assets.html")
+	assert.Contains(t, assets, `<a href="https://wiki/booking">booking</a>`)
+	assert.Contains(t, assets, "Booking &lt;flow&gt;")
+	assert.Contains(t, assets, "2024-05-20")
+	assert.Contains(t, assets, `<span class="muted">never</span>`)
+
+	allocations := readPage(t, dir, "allocations.html")
+	assert.Contains(t, allocations, `<td>Development</td><td class="number">30.0</td><td class="number">75.0%</td>`)
+	assert.Contains(t, allocations, `<th>Total</th><th class="number">40.0</th>`)
+	assert.Contains(t, allocations, "Hours by asset")
+
+	coverage := readPage(t, dir, "coverage.html")
+	assert.Contains(t, coverage, `<td>FN Owls</td><td class="number">4</td><td class="number">2</td><td class="number">50.0%</td><td class="number">2</td><td class="number">0</td>`)
+	assert.Contains(t, coverage, `<i style="background: #2b6cb0"></i>cap-development`)
+}
+
+func TestBuild_WithoutData(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := Build(dir, Site{Title: "Capitalization", GeneratedAt: time.Now()})
+
+	require.NoError(t, err)
+	assert.Contains(t, readPage(t, dir, "assets.html"), "No assets are registered.")
+	assert.Contains(t, readPage(t, dir, "allocations.html"), "No sprint allocation was included in this build.")
+	assert.Contains(t, readPage(t, dir, "coverage.html"), "No sprint tasks are stored locally.")
+	assert.NotContains(t, readPage(t, dir, "index.html"), "<svg")
+}
+
+func TestSprintCoverage_Percent(t *testing.T) {
+	assert.Equal(t, 75.0, SprintCoverage{Tasks: 4, WorkTypes: map[string]int{"a": 2, "b": 1}}.Percent())
+	assert.Equal(t, 0.0, SprintCoverage{}.Percent())
+}
+
+func readPage(t *testing.T, dir, file string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, file))
+	require.NoError(t, err)
+	return string(data)
+}
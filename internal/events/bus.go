@@ -0,0 +1,101 @@
+// Package events publishes what assetcap did, such as assets synced or tasks classified, as
+// domain events. Subscribers run in process; hooks configured by the user run a shell command
+// or post to a webhook, gluing assetcap into a team's own automation.
+package events
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Name identifies the kind of an event
+type Name string
+
+const (
+	// AssetSynced is published for every asset synced from Confluence
+	AssetSynced Name = "AssetSynced"
+	// TasksClassified is published when the tasks of a sprint were classified
+	TasksClassified Name = "TasksClassified"
+	// AllocationGenerated is published when the allocation of a sprint or fix version was written
+	AllocationGenerated Name = "AllocationGenerated"
+	// LabelsApplied is published when classification labels were written back to Jira
+	LabelsApplied Name = "LabelsApplied"
+)
+
+// All subscribes a handler to every event
+const All Name = "*"
+
+// Names lists the events published, in alphabetical order
+var Names = []Name{AllocationGenerated, AssetSynced, LabelsApplied, TasksClassified}
+
+// Valid reports whether name is a published event or All
+func Valid(name Name) bool {
+	if name == All {
+		return true
+	}
+	i := sort.Search(len(Names), func(i int) bool { return Names[i] >= name })
+	return i < len(Names) && Names[i] == name
+}
+
+// Event is something assetcap did, with the details of what it did
+type Event struct {
+	Name Name           `json:"event"`
+	Time time.Time      `json:"time"`
+	Data map[string]any `json:"data"`
+}
+
+// Handler reacts to an event
+type Handler func(ctx context.Context, event Event) error
+
+// Bus delivers published events to the handlers subscribed to them. A nil Bus drops every
+// event.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[Name][]Handler
+	// warnings receives the failures of handlers, which never fail the publisher
+	warnings io.Writer
+	now      func() time.Time
+}
+
+// NewBus creates a bus without subscribers
+func NewBus() *Bus {
+	return &Bus{
+		handlers: make(map[Name][]Handler),
+		warnings: os.Stderr,
+		now:      time.Now,
+	}
+}
+
+// Subscribe runs the handler on every event of the name, or on every event for All
+func (b *Bus) Subscribe(name Name, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[name] = append(b.handlers[name], handler)
+}
+
+// Publish delivers an event to its handlers in the order they subscribed, then to the
+// handlers of every event. A failing handler is reported as a warning and the others still
+// run.
+func (b *Bus) Publish(ctx context.Context, name Name, data map[string]any) {
+	if b == nil {
+		return
+	}
+	b.mu.RLock()
+	handlers := append(append([]Handler(nil), b.handlers[name]...), b.handlers[All]...)
+	b.mu.RUnlock()
+	if len(handlers) == 0 {
+		return
+	}
+
+	event := Event{Name: name, Time: b.now().UTC(), Data: data}
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			fmt.Fprintf(b.warnings, "Warning: hook on %s failed: %v\n", name, err)
+		}
+	}
+}
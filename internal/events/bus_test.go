@@ -0,0 +1,51 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBus_Publish(t *testing.T) {
+	var warnings bytes.Buffer
+	bus := NewBus()
+	bus.warnings = &warnings
+	bus.now = func() time.Time { return time.Date(2024, 5, 6, 9, 0, 0, 0, time.UTC) }
+
+	var received []string
+	bus.Subscribe(TasksClassified, func(_ context.Context, event Event) error {
+		received = append(received, "classified "+event.Data["sprint"].(string))
+		return errors.New("exit status 1")
+	})
+	bus.Subscribe(All, func(_ context.Context, event Event) error {
+		received = append(received, "all "+string(event.Name)+" "+event.Time.Format(time.RFC3339))
+		return nil
+	})
+
+	bus.Publish(context.Background(), TasksClassified, map[string]any{"sprint": "Sprint 1"})
+	bus.Publish(context.Background(), AssetSynced, map[string]any{"name": "booking"})
+
+	assert.Equal(t, []string{
+		"classified Sprint 1",
+		"all TasksClassified 2024-05-06T09:00:00Z",
+		"all AssetSynced 2024-05-06T09:00:00Z",
+	}, received, "a failing handler does not stop the others")
+	assert.Equal(t, "Warning: hook on TasksClassified failed: exit status 1\n", warnings.String())
+}
+
+func TestBus_NilDropsEvents(t *testing.T) {
+	var bus *Bus
+	assert.NotPanics(t, func() { bus.Publish(context.Background(), AssetSynced, nil) })
+}
+
+func TestValid(t *testing.T) {
+	for _, name := range append(Names, All) {
+		assert.True(t, Valid(name), name)
+	}
+	assert.False(t, Valid("TaskClassified"))
+	assert.False(t, Valid(""))
+}
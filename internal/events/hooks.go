@@ -0,0 +1,73 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sink"
+)
+
+// DefaultHookTimeout bounds a hook without a timeout of its own
+const DefaultHookTimeout = 30 * time.Second
+
+// CommandHook returns a handler running command with sh -c. The command reads the event as
+// JSON on stdin and its name from ASSETCAP_EVENT; its output goes to output, so it never
+// mixes with the output of the command that published the event. A command exiting non-zero
+// fails the hook.
+func CommandHook(command string, timeout time.Duration, output io.Writer) Handler {
+	if timeout <= 0 {
+		timeout = DefaultHookTimeout
+	}
+	if output == nil {
+		output = os.Stderr
+	}
+	return func(ctx context.Context, event Event) error {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal event: %w", err)
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		cmd := exec.CommandContext(ctx, "sh", "-c", command)
+		cmd.Stdin = bytes.NewReader(payload)
+		cmd.Stdout = output
+		cmd.Stderr = output
+		cmd.Env = append(os.Environ(), "ASSETCAP_EVENT="+string(event.Name))
+		// background processes the command left holding its output do not hold up assetcap
+		cmd.WaitDelay = time.Second
+		if err := cmd.Run(); err != nil {
+			if ctx.Err() == context.DeadlineExceeded {
+				return fmt.Errorf("command %q timed out after %s", command, timeout)
+			}
+			return fmt.Errorf("command %q: %w", command, err)
+		}
+		return nil
+	}
+}
+
+// WebhookHook returns a handler posting the event as JSON to url with the given headers
+func WebhookHook(url string, headers map[string]string, timeout time.Duration) Handler {
+	if timeout <= 0 {
+		timeout = DefaultHookTimeout
+	}
+	webhook := sink.NewWebhook(url, headers)
+	return func(ctx context.Context, event Event) error {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal event: %w", err)
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		if err := webhook.Write(ctx, payload, sink.ContentTypeJSON); err != nil {
+			return fmt.Errorf("webhook %s: %w", webhook, err)
+		}
+		return nil
+	}
+}
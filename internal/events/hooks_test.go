@@ -0,0 +1,64 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testEvent() Event {
+	return Event{
+		Name: LabelsApplied,
+		Time: time.Date(2024, 5, 6, 9, 0, 0, 0, time.UTC),
+		Data: map[string]any{"project": "FN", "sprint": "Sprint 1"},
+	}
+}
+
+func TestCommandHook(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "event.json")
+	var output bytes.Buffer
+	hook := CommandHook(`echo "got $ASSETCAP_EVENT"; cat > `+path, 0, &output)
+
+	require.NoError(t, hook(context.Background(), testEvent()))
+	assert.Equal(t, "got LabelsApplied\n", output.String())
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"event": "LabelsApplied", "time": "2024-05-06T09:00:00Z", "data": {"project": "FN", "sprint": "Sprint 1"}}`, string(data))
+
+	err = CommandHook("exit 3", 0, io.Discard)(context.Background(), testEvent())
+	assert.EqualError(t, err, `command "exit 3": exit status 3`)
+	err = CommandHook("exec sleep 5", 10*time.Millisecond, io.Discard)(context.Background(), testEvent())
+	assert.EqualError(t, err, `command "exec sleep 5" timed out after 10ms`)
+}
+
+func TestWebhookHook(t *testing.T) {
+	var received Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	hook := WebhookHook(server.URL+"/hooks?token=abc", map[string]string{"Authorization": "Bearer secret"}, 0)
+	require.NoError(t, hook(context.Background(), testEvent()))
+	assert.Equal(t, testEvent().Name, received.Name)
+	assert.Equal(t, "Sprint 1", received.Data["sprint"])
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusForbidden)
+	}))
+	defer failing.Close()
+	err := WebhookHook(failing.URL+"/hooks?token=abc", nil, 0)(context.Background(), testEvent())
+	assert.EqualError(t, err, "webhook "+failing.URL+"/hooks: unexpected status 403 Forbidden: nope")
+}
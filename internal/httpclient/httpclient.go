@@ -0,0 +1,121 @@
+// Package httpclient builds the HTTP clients shared by the Jira, Confluence and LLM
+// integrations, so proxies, private certificate authorities and client certificates are
+// configured in one place.
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// EnvProxyPassword holds the password of the proxy, kept out of the configuration file
+const EnvProxyPassword = "ASSETCAP_PROXY_PASSWORD"
+
+// Config configures the network access of the HTTP clients
+type Config struct {
+	// ProxyURL routes requests through a proxy, e.g. http://proxy.example.com:8080; empty
+	// honours the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables
+	ProxyURL string
+	// ProxyUsername and ProxyPassword authenticate with the proxy
+	ProxyUsername string
+	ProxyPassword string
+	// CABundle is a PEM file of certificate authorities trusted besides the system ones
+	CABundle string
+	// ClientCertificate and ClientKey are PEM files presented for mutual TLS
+	ClientCertificate string
+	ClientKey         string
+	// Timeout bounds each request, including reading the response; zero keeps the default
+	// of the client
+	Timeout time.Duration
+}
+
+// WithEnv completes the configuration with the proxy password of the environment
+func (c Config) WithEnv() Config {
+	if c.ProxyPassword == "" {
+		c.ProxyPassword = os.Getenv(EnvProxyPassword)
+	}
+	return c
+}
+
+// New returns an HTTP client honouring the configuration. defaultTimeout applies when no
+// timeout is configured; zero means no timeout.
+func New(cfg Config, defaultTimeout time.Duration) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if cfg.ProxyURL != "" {
+		proxy, err := proxyURL(cfg)
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	timeout := defaultTimeout
+	if cfg.Timeout > 0 {
+		timeout = cfg.Timeout
+	}
+	return &http.Client{Transport: transport, Timeout: timeout}, nil
+}
+
+// proxyURL parses the proxy URL, adding the configured credentials
+func proxyURL(cfg Config) (*url.URL, error) {
+	proxy, err := url.Parse(cfg.ProxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	switch proxy.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("invalid proxy URL %s: scheme must be http, https or socks5", cfg.ProxyURL)
+	}
+	if proxy.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %s: missing host", cfg.ProxyURL)
+	}
+	if cfg.ProxyUsername != "" {
+		proxy.User = url.UserPassword(cfg.ProxyUsername, cfg.ProxyPassword)
+	}
+	return proxy, nil
+}
+
+// newTLSConfig trusts the CA bundle and presents the client certificate, if configured
+func newTLSConfig(cfg Config) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if cfg.CABundle != "" {
+		pem, err := os.ReadFile(cfg.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("CA bundle %s contains no PEM certificates", cfg.CABundle)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if (cfg.ClientCertificate == "") != (cfg.ClientKey == "") {
+		return nil, fmt.Errorf("client certificate and client key must be configured together")
+	}
+	if cfg.ClientCertificate != "" {
+		certificate, err := tls.LoadX509KeyPair(cfg.ClientCertificate, cfg.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return tlsConfig, nil
+}
@@ -0,0 +1,169 @@
+package httpclient
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writePEM writes a PEM block to a file in dir and returns its path
+func writePEM(t *testing.T, dir, name, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600))
+	return path
+}
+
+// writeServerCA writes the certificate of a TLS test server as a CA bundle
+func writeServerCA(t *testing.T, server *httptest.Server) string {
+	t.Helper()
+	return writePEM(t, t.TempDir(), "ca.pem", "CERTIFICATE", server.Certificate().Raw)
+}
+
+// writeClientCertificate writes a self-signed client certificate and its key
+func writeClientCertificate(t *testing.T) (certPath, keyPath string, certificate *x509.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "assetcap"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	certificate, err = x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	return writePEM(t, dir, "client.pem", "CERTIFICATE", der), writePEM(t, dir, "client-key.pem", "EC PRIVATE KEY", keyDER), certificate
+}
+
+func TestNew_Defaults(t *testing.T) {
+	client, err := New(Config{}, 30*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, client.Timeout)
+
+	client, err = New(Config{Timeout: 5 * time.Second}, 30*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, client.Timeout)
+}
+
+func TestNew_CABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	untrusted, err := New(Config{}, time.Second)
+	require.NoError(t, err)
+	_, err = untrusted.Get(server.URL)
+	assert.Error(t, err, "the test server's CA is not trusted by default")
+
+	client, err := New(Config{CABundle: writeServerCA(t, server)}, time.Second)
+	require.NoError(t, err)
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
+
+func TestNew_ClientCertificate(t *testing.T) {
+	certPath, keyPath, certificate := writeClientCertificate(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(certificate)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Client", r.TLS.PeerCertificates[0].Subject.CommonName)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+	ca := writeServerCA(t, server)
+
+	withoutCertificate, err := New(Config{CABundle: ca}, time.Second)
+	require.NoError(t, err)
+	_, err = withoutCertificate.Get(server.URL)
+	assert.Error(t, err)
+
+	client, err := New(Config{CABundle: ca, ClientCertificate: certPath, ClientKey: keyPath}, time.Second)
+	require.NoError(t, err)
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, "assetcap", resp.Header.Get("X-Client"))
+}
+
+func TestNew_Proxy(t *testing.T) {
+	var target, authorization string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		target = r.URL.String()
+		authorization = r.Header.Get("Proxy-Authorization")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	client, err := New(Config{ProxyURL: proxy.URL, ProxyUsername: "jane", ProxyPassword: "secret"}, time.Second)
+	require.NoError(t, err)
+	resp, err := client.Get("http://jira.example.com/rest/api/2/myself")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, "http://jira.example.com/rest/api/2/myself", target)
+	assert.Equal(t, "Basic "+base64.StdEncoding.EncodeToString([]byte("jane:secret")), authorization)
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr string
+	}{
+		{name: "unsupported proxy scheme", config: Config{ProxyURL: "ftp://proxy:21"}, wantErr: "scheme must be http, https or socks5"},
+		{name: "proxy without host", config: Config{ProxyURL: "http://"}, wantErr: "missing host"},
+		{name: "missing CA bundle", config: Config{CABundle: "missing.pem"}, wantErr: "failed to read CA bundle"},
+		{name: "client certificate without key", config: Config{ClientCertificate: "client.pem"}, wantErr: "must be configured together"},
+		{name: "missing client certificate", config: Config{ClientCertificate: "client.pem", ClientKey: "client-key.pem"}, wantErr: "failed to load client certificate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.config, time.Second)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestNew_CABundleWithoutCertificates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(path, []byte("not a certificate"), 0600))
+
+	_, err := New(Config{CABundle: path}, time.Second)
+	assert.ErrorContains(t, err, "contains no PEM certificates")
+}
+
+func TestConfig_WithEnv(t *testing.T) {
+	t.Setenv(EnvProxyPassword, "from-env")
+
+	assert.Equal(t, "from-env", Config{}.WithEnv().ProxyPassword)
+	assert.Equal(t, "configured", Config{ProxyPassword: "configured"}.WithEnv().ProxyPassword)
+}
@@ -0,0 +1,316 @@
+// Package jiraexport reads the Jira issue exports used where the tool cannot reach the Jira
+// API: search responses of the REST API saved as JSON, and the CSV files of the issue
+// navigator's export. Both the task store and the allocations load issues through it.
+package jiraexport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format is the format of an export file
+type Format string
+
+const (
+	// FormatJSON is a REST API search response, an array of them, or an array of issues
+	FormatJSON Format = "json"
+	// FormatCSV is a CSV export of the issue navigator
+	FormatCSV Format = "csv"
+)
+
+// TimestampLayout is the layout of the timestamps of the Jira REST API, which CSV dates are
+// converted to
+const TimestampLayout = "2006-01-02T15:04:05.000-0700"
+
+// csvDateLayouts are the date layouts of CSV exports, the default one first; dates without a
+// zone are read as UTC
+var csvDateLayouts = []string{
+	"02/Jan/06 3:04 PM",
+	"02/Jan/2006 3:04 PM",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	TimestampLayout,
+	time.RFC3339,
+}
+
+// DetectFormat tells the format of an export file from its extension
+func DetectFormat(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON, nil
+	case ".csv":
+		return FormatCSV, nil
+	default:
+		return "", fmt.Errorf("unsupported export file %s: expected a .json or .csv file", path)
+	}
+}
+
+// ReadFile reads an export file, returning its format and content
+func ReadFile(path string) (Format, []byte, error) {
+	format, err := DetectFormat(path)
+	if err != nil {
+		return "", nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read export %s: %w", path, err)
+	}
+	return format, data, nil
+}
+
+// Issues splits a JSON export into its issues, left encoded for the caller's issue type. It
+// accepts a search response, an array of search responses such as the pages of a search
+// saved one after the other, and an array of issues.
+func Issues(data []byte) ([]json.RawMessage, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, fmt.Errorf("export is empty")
+	}
+
+	var page struct {
+		Issues []json.RawMessage `json:"issues"`
+	}
+	if data[0] == '{' {
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("invalid JSON export: %w", err)
+		}
+		return page.Issues, nil
+	}
+
+	var elements []json.RawMessage
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return nil, fmt.Errorf("invalid JSON export: %w", err)
+	}
+	var issues []json.RawMessage
+	for _, element := range elements {
+		var fields struct {
+			Key    string            `json:"key"`
+			Issues []json.RawMessage `json:"issues"`
+		}
+		if err := json.Unmarshal(element, &fields); err != nil {
+			return nil, fmt.Errorf("invalid JSON export: %w", err)
+		}
+		if fields.Key != "" {
+			issues = append(issues, element)
+			continue
+		}
+		issues = append(issues, fields.Issues...)
+	}
+	return issues, nil
+}
+
+// Sprint is a sprint of a JSON export issue
+type Sprint struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	State     string `json:"state"`
+	StartDate string `json:"startDate"`
+	EndDate   string `json:"endDate"`
+}
+
+// Sprints finds the sprints of an issue among its fields: instances name the sprint field
+// after a custom field ID, so every custom field holding sprint objects is considered
+func Sprints(fields json.RawMessage) []Sprint {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(fields, &raw); err != nil {
+		return nil
+	}
+	if value, ok := raw["sprint"]; ok {
+		if sprints := sprintList(value); len(sprints) > 0 {
+			return sprints
+		}
+	}
+	for key, value := range raw {
+		if !strings.HasPrefix(key, "customfield_") {
+			continue
+		}
+		if sprints := sprintList(value); len(sprints) > 0 {
+			return sprints
+		}
+	}
+	return nil
+}
+
+// sprintList decodes a field holding sprint objects, or returns nil for any other field
+func sprintList(value json.RawMessage) []Sprint {
+	var sprints []Sprint
+	if err := json.Unmarshal(value, &sprints); err != nil {
+		return nil
+	}
+	for _, sprint := range sprints {
+		if sprint.Name == "" || (sprint.ID == 0 && sprint.State == "") {
+			return nil
+		}
+	}
+	return sprints
+}
+
+// Record is an issue of a CSV export. CSV exports carry no changelog.
+type Record struct {
+	Key            string
+	Summary        string
+	Description    string
+	IssueType      string
+	Status         string
+	StatusCategory string
+	Project        string
+	Assignee       string
+	Parent         string
+	// Created, Updated and Resolved are in TimestampLayout, empty when the export has none
+	Created     string
+	Updated     string
+	Resolved    string
+	Sprints     []string
+	Labels      []string
+	FixVersions []string
+	// StoryPoints is nil when the export has no estimate for the issue
+	StoryPoints *float64
+	// OriginalEstimate and RemainingEstimate are the time tracking estimates in seconds, nil
+	// when the export has none for the issue
+	OriginalEstimate  *int64
+	RemainingEstimate *int64
+}
+
+// StatusCategoryKey returns the API key of the record's status category, e.g. done for a
+// Done category, or an empty key when the export has no category column
+func (r Record) StatusCategoryKey() string {
+	switch strings.ToLower(strings.TrimSpace(r.StatusCategory)) {
+	case "to do", "new":
+		return "new"
+	case "in progress", "indeterminate":
+		return "indeterminate"
+	case "done", "complete":
+		return "done"
+	default:
+		return ""
+	}
+}
+
+// ReadCSV reads the issues of a CSV export. Columns are found by their header, as exports
+// include a configurable set of columns; multi-valued fields such as Sprint and Labels are
+// repeated columns of the same header.
+func ReadCSV(r io.Reader) ([]Record, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV export: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("export is empty")
+	}
+
+	columns := make(map[string][]int)
+	for i, header := range rows[0] {
+		name := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(header, "\ufeff")))
+		columns[name] = append(columns[name], i)
+	}
+	if len(columns["issue key"]) == 0 {
+		return nil, fmt.Errorf("invalid CSV export: no Issue key column")
+	}
+
+	records := make([]Record, 0, len(rows)-1)
+	for line, row := range rows[1:] {
+		get := func(names ...string) string {
+			for _, name := range names {
+				for _, i := range columns[name] {
+					if i < len(row) && strings.TrimSpace(row[i]) != "" {
+						return strings.TrimSpace(row[i])
+					}
+				}
+			}
+			return ""
+		}
+		all := func(names ...string) []string {
+			var values []string
+			for _, name := range names {
+				for _, i := range columns[name] {
+					if i < len(row) && strings.TrimSpace(row[i]) != "" {
+						values = append(values, strings.TrimSpace(row[i]))
+					}
+				}
+			}
+			return values
+		}
+
+		record := Record{
+			Key:            get("issue key"),
+			Summary:        get("summary"),
+			Description:    get("description"),
+			IssueType:      get("issue type"),
+			Status:         get("status"),
+			StatusCategory: get("status category"),
+			Project:        get("project key"),
+			Assignee:       get("assignee"),
+			Parent:         get("parent key", "parent", "custom field (epic link)"),
+			Sprints:        all("sprint"),
+			Labels:         all("labels"),
+			FixVersions:    all("fix version/s", "fix versions"),
+		}
+		if record.Key == "" {
+			continue
+		}
+		dates := []struct {
+			field *string
+			value string
+		}{
+			{&record.Created, get("created")},
+			{&record.Updated, get("updated")},
+			{&record.Resolved, get("resolved", "resolution date")},
+		}
+		for _, date := range dates {
+			if *date.field, err = Timestamp(date.value); err != nil {
+				return nil, fmt.Errorf("invalid CSV export: line %d (%s): %w", line+2, record.Key, err)
+			}
+		}
+		if points := get("custom field (story points)", "story points"); points != "" {
+			var value float64
+			if _, err := fmt.Sscan(points, &value); err != nil {
+				return nil, fmt.Errorf("invalid CSV export: line %d (%s): invalid story points %q", line+2, record.Key, points)
+			}
+			record.StoryPoints = &value
+		}
+		estimates := []struct {
+			field *(*int64)
+			name  string
+			value string
+		}{
+			{&record.OriginalEstimate, "original estimate", get("original estimate")},
+			{&record.RemainingEstimate, "remaining estimate", get("remaining estimate")},
+		}
+		for _, estimate := range estimates {
+			if estimate.value == "" {
+				continue
+			}
+			seconds, err := strconv.ParseInt(estimate.value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CSV export: line %d (%s): invalid %s %q", line+2, record.Key, estimate.name, estimate.value)
+			}
+			*estimate.field = &seconds
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// Timestamp converts a CSV export date to TimestampLayout; an empty date stays empty
+func Timestamp(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	for _, layout := range csvDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.Format(TimestampLayout), nil
+		}
+	}
+	return "", fmt.Errorf("unrecognized date %q", value)
+}
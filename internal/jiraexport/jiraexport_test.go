@@ -0,0 +1,126 @@
+package jiraexport
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectFormat(t *testing.T) {
+	format, err := DetectFormat("backup/FN.JSON")
+	require.NoError(t, err)
+	assert.Equal(t, FormatJSON, format)
+
+	format, err = DetectFormat("FN.csv")
+	require.NoError(t, err)
+	assert.Equal(t, FormatCSV, format)
+
+	_, err = DetectFormat("FN.xml")
+	assert.ErrorContains(t, err, "expected a .json or .csv file")
+}
+
+func TestIssues(t *testing.T) {
+	keys := func(issues []json.RawMessage) []string {
+		var result []string
+		for _, issue := range issues {
+			var fields struct {
+				Key string `json:"key"`
+			}
+			require.NoError(t, json.Unmarshal(issue, &fields))
+			result = append(result, fields.Key)
+		}
+		return result
+	}
+
+	issues, err := Issues([]byte(`{"startAt":0,"total":2,"issues":[{"key":"FN-1"},{"key":"FN-2"}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"FN-1", "FN-2"}, keys(issues))
+
+	issues, err = Issues([]byte(`[{"issues":[{"key":"FN-1"}]},{"issues":[{"key":"FN-2"}]}]`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"FN-1", "FN-2"}, keys(issues), "pages of a search are read one after the other")
+
+	issues, err = Issues([]byte(` [{"key":"FN-3","fields":{}}]`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"FN-3"}, keys(issues))
+
+	_, err = Issues([]byte(" "))
+	assert.EqualError(t, err, "export is empty")
+	_, err = Issues([]byte(`{"issues":`))
+	assert.ErrorContains(t, err, "invalid JSON export")
+}
+
+func TestSprints(t *testing.T) {
+	fields := json.RawMessage(`{
+		"labels": ["cap-development"],
+		"customfield_10001": [{"name": "not a sprint"}],
+		"customfield_10020": [{"id": 7, "name": "Penguins", "state": "closed", "startDate": "2024-03-18T09:00:00.000Z"}]
+	}`)
+	assert.Equal(t, []Sprint{{ID: 7, Name: "Penguins", State: "closed", StartDate: "2024-03-18T09:00:00.000Z"}}, Sprints(fields))
+
+	assert.Equal(t, []Sprint{{ID: 1, Name: "Owls"}}, Sprints(json.RawMessage(`{"sprint":[{"id":1,"name":"Owls"}]}`)))
+	assert.Empty(t, Sprints(json.RawMessage(`{"summary":"no sprint"}`)))
+}
+
+func TestReadCSV(t *testing.T) {
+	export := "Summary,Issue key,Issue Type,Status,Status Category,Project key,Assignee,Created,Updated,Resolved,Sprint,Sprint,Labels,Labels,Custom field (Story Points),Parent,Original Estimate,Remaining Estimate\n" +
+		"Build checkout,FN-1,Story,Done,Done,FN,Alice Doe,18/Mar/24 9:00 AM,19/Mar/24 5:30 PM,19/Mar/24 5:30 PM,Owls,Penguins,cap-development,cap-asset-booking,3,FN-100,28800,0\n" +
+		"Fix login,FN-2,Bug,In Review,In Progress,FN,,2024-03-20 10:00,2024-03-20 10:00,,Penguins,,,,,,,\n" +
+		",,,,,,,,,,,,,,,,,\n"
+
+	records, err := ReadCSV(strings.NewReader(export))
+	require.NoError(t, err)
+	require.Len(t, records, 2, "rows without an issue key are skipped")
+
+	points := 3.0
+	original, remaining := int64(28800), int64(0)
+	assert.Equal(t, Record{
+		Key:               "FN-1",
+		Summary:           "Build checkout",
+		IssueType:         "Story",
+		Status:            "Done",
+		StatusCategory:    "Done",
+		Project:           "FN",
+		Assignee:          "Alice Doe",
+		Parent:            "FN-100",
+		Created:           "2024-03-18T09:00:00.000+0000",
+		Updated:           "2024-03-19T17:30:00.000+0000",
+		Resolved:          "2024-03-19T17:30:00.000+0000",
+		Sprints:           []string{"Owls", "Penguins"},
+		Labels:            []string{"cap-development", "cap-asset-booking"},
+		StoryPoints:       &points,
+		OriginalEstimate:  &original,
+		RemainingEstimate: &remaining,
+	}, records[0])
+	assert.Equal(t, "done", records[0].StatusCategoryKey())
+	assert.Equal(t, "indeterminate", records[1].StatusCategoryKey())
+	assert.Empty(t, records[1].Resolved)
+	assert.Nil(t, records[1].StoryPoints)
+	assert.Nil(t, records[1].OriginalEstimate)
+
+	_, err = ReadCSV(strings.NewReader("Issue key,Original Estimate\nFN-1,2h\n"))
+	assert.EqualError(t, err, `invalid CSV export: line 2 (FN-1): invalid original estimate "2h"`)
+
+	_, err = ReadCSV(strings.NewReader("Summary,Status\nBuild,Done\n"))
+	assert.EqualError(t, err, "invalid CSV export: no Issue key column")
+
+	_, err = ReadCSV(strings.NewReader("Issue key,Created\nFN-1,yesterday\n"))
+	assert.EqualError(t, err, `invalid CSV export: line 2 (FN-1): unrecognized date "yesterday"`)
+}
+
+func TestTimestamp(t *testing.T) {
+	for value, want := range map[string]string{
+		"":                             "",
+		"05/Apr/24 1:15 PM":            "2024-04-05T13:15:00.000+0000",
+		"05/Apr/2024 1:15 PM":          "2024-04-05T13:15:00.000+0000",
+		"2024-04-05":                   "2024-04-05T00:00:00.000+0000",
+		"2024-04-05T13:15:00.000+0200": "2024-04-05T13:15:00.000+0200",
+	} {
+		got, err := Timestamp(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, want, got, value)
+	}
+}
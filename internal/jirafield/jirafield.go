@@ -0,0 +1,71 @@
+// Package jirafield discovers the custom fields whose IDs differ between Jira instances. The
+// sprint field is detected once per instance and cached in the configuration, so the task
+// store and the allocations request and read it by its ID.
+package jirafield
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DefaultSprintField is the custom field Jira Cloud stores the sprints of an issue in, used
+// until the field of the instance is detected
+const DefaultSprintField = "customfield_10020"
+
+// SprintSchema is the custom field type of the sprint field
+const SprintSchema = "com.pyxis.greenhopper.jira:gh-sprint"
+
+// ErrSprintFieldNotFound is returned when no field of the instance holds sprints
+var ErrSprintFieldNotFound = errors.New("sprint field not found")
+
+// Field is a field of a Jira instance as listed by the fields API
+type Field struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Schema struct {
+		Type   string `json:"type"`
+		Custom string `json:"custom"`
+	} `json:"schema"`
+}
+
+// DetectSprintField asks the Jira instance at baseURL which custom field holds the sprints of
+// its issues
+func DetectSprintField(client *http.Client, baseURL, authHeader string) (string, error) {
+	req, err := http.NewRequest(http.MethodGet, strings.TrimRight(baseURL, "/")+"/rest/api/2/field", nil)
+	if err != nil {
+		return "", fmt.Errorf("error creating request: %v", err)
+	}
+	req.Header.Set("Authorization", authHeader)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("error response from Jira: %s - %s", resp.Status, string(body))
+	}
+
+	var fields []Field
+	if err := json.NewDecoder(resp.Body).Decode(&fields); err != nil {
+		return "", fmt.Errorf("error decoding response: %v", err)
+	}
+	return SprintField(fields)
+}
+
+// SprintField returns the ID of the field holding sprints among the fields of an instance
+func SprintField(fields []Field) (string, error) {
+	for _, field := range fields {
+		if field.Schema.Custom == SprintSchema {
+			return field.ID, nil
+		}
+	}
+	return "", ErrSprintFieldNotFound
+}
@@ -0,0 +1,48 @@
+package jirafield
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectSprintField(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/2/field", r.URL.Path)
+		assert.Equal(t, "Basic token", r.Header.Get("Authorization"))
+		w.Write([]byte(`[
+			{"id": "summary", "name": "Summary", "schema": {"type": "string"}},
+			{"id": "customfield_10100", "name": "Sprint", "schema": {"type": "array", "custom": "com.pyxis.greenhopper.jira:gh-sprint"}}
+		]`))
+	}))
+	defer server.Close()
+
+	field, err := DetectSprintField(server.Client(), server.URL+"/", "Basic token")
+	require.NoError(t, err)
+	assert.Equal(t, "customfield_10100", field)
+}
+
+func TestDetectSprintField_Errors(t *testing.T) {
+	t.Run("no sprint field", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Write([]byte(`[{"id": "summary", "name": "Summary", "schema": {"type": "string"}}]`))
+		}))
+		defer server.Close()
+
+		_, err := DetectSprintField(server.Client(), server.URL, "")
+		assert.ErrorIs(t, err, ErrSprintFieldNotFound)
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer server.Close()
+
+		_, err := DetectSprintField(server.Client(), server.URL, "")
+		assert.ErrorContains(t, err, "401 Unauthorized")
+	})
+}
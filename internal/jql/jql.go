@@ -0,0 +1,14 @@
+// Package jql builds the values of Jira Query Language searches shared by the task store and
+// the allocations.
+package jql
+
+import "strings"
+
+// escaper escapes the characters that end or escape a quoted JQL string
+var escaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// Quote returns a value as a double-quoted JQL string, escaping its quotes and backslashes so
+// that a name such as `2024.5 "Phoenix"` matches literally instead of breaking the query
+func Quote(value string) string {
+	return `"` + escaper.Replace(value) + `"`
+}
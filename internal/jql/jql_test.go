@@ -0,0 +1,14 @@
+package jql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuote(t *testing.T) {
+	assert.Equal(t, `"2024.5"`, Quote("2024.5"))
+	assert.Equal(t, `"2024.5 \"Phoenix\""`, Quote(`2024.5 "Phoenix"`))
+	assert.Equal(t, `"release\\candidate"`, Quote(`release\candidate`))
+	assert.Equal(t, `"Bob's release"`, Quote("Bob's release"))
+}
@@ -0,0 +1,298 @@
+// Package mcp serves tools to LLM agents over the Model Context Protocol: JSON-RPC 2.0
+// messages, one per line, read from and written to a stream such as stdio or a socket
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"sort"
+	"sync"
+)
+
+const (
+	jsonRPCVersion = "2.0"
+	// maxMessageSize bounds a single message; tool arguments are small
+	maxMessageSize = 4 << 20
+)
+
+// protocolVersions are the protocol revisions the server speaks, newest first
+var protocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// JSON-RPC error codes
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// ToolFunc runs a tool with its JSON arguments and returns a result encoded as JSON
+type ToolFunc func(ctx context.Context, arguments json.RawMessage) (any, error)
+
+// Tool is a capability offered to the agent, such as listing the assets
+type Tool struct {
+	Name        string
+	Description string
+	// InputSchema is the JSON schema of the arguments; an object without properties when nil
+	InputSchema json.RawMessage
+	Run         ToolFunc
+}
+
+// Server answers MCP requests with the registered tools
+type Server struct {
+	name    string
+	version string
+	tools   map[string]Tool
+}
+
+// NewServer creates a server announcing itself with the given name and version
+func NewServer(name, version string, tools []Tool) *Server {
+	registered := make(map[string]Tool, len(tools))
+	for _, tool := range tools {
+		registered[tool.Name] = tool
+	}
+	return &Server{name: name, version: version, tools: registered}
+}
+
+// request is a JSON-RPC request, or a notification when it has no ID
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return e.Message
+}
+
+// Serve answers the requests read from r on w until r ends or the context is cancelled
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+	encoder := json.NewEncoder(w)
+
+	lines := make(chan []byte)
+	go func() {
+		defer close(lines)
+		for scanner.Scan() {
+			line := append([]byte(nil), scanner.Bytes()...)
+			select {
+			case lines <- line:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case line, ok := <-lines:
+			if !ok {
+				return scanner.Err()
+			}
+			if len(bytes.TrimSpace(line)) == 0 {
+				continue
+			}
+			reply := s.handle(ctx, line)
+			if reply == nil {
+				continue
+			}
+			if err := encoder.Encode(reply); err != nil {
+				return fmt.Errorf("failed to write MCP response: %w", err)
+			}
+		}
+	}
+}
+
+// Listen serves every connection accepted on the listener until the context is cancelled
+func (s *Server) Listen(ctx context.Context, listener net.Listener) error {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to accept MCP connection: %w", err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer conn.Close()
+			connCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			go func() {
+				<-connCtx.Done()
+				conn.Close()
+			}()
+			if err := s.Serve(connCtx, conn, conn); err != nil && connCtx.Err() == nil {
+				log.Printf("MCP connection closed: %v", err)
+			}
+		}()
+	}
+}
+
+// handle answers one message; notifications get no response
+func (s *Server) handle(ctx context.Context, message []byte) *response {
+	var req request
+	if err := json.Unmarshal(message, &req); err != nil {
+		return &response{JSONRPC: jsonRPCVersion, ID: json.RawMessage("null"), Error: &rpcError{Code: codeParseError, Message: "parse error: " + err.Error()}}
+	}
+	if req.JSONRPC != jsonRPCVersion || req.Method == "" {
+		id := req.ID
+		if id == nil {
+			id = json.RawMessage("null")
+		}
+		return &response{JSONRPC: jsonRPCVersion, ID: id, Error: &rpcError{Code: codeInvalidRequest, Message: "invalid request"}}
+	}
+
+	result, err := s.dispatch(ctx, req)
+	if req.ID == nil {
+		return nil
+	}
+	reply := &response{JSONRPC: jsonRPCVersion, ID: req.ID, Result: result}
+	if err != nil {
+		var rpcErr *rpcError
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &rpcError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		reply.Result, reply.Error = nil, rpcErr
+	}
+	return reply
+}
+
+func (s *Server) dispatch(ctx context.Context, req request) (any, error) {
+	switch req.Method {
+	case "initialize":
+		return s.initialize(req.Params)
+	case "ping":
+		return struct{}{}, nil
+	case "tools/list":
+		return s.listTools(), nil
+	case "tools/call":
+		return s.callTool(ctx, req.Params)
+	}
+	if req.ID == nil {
+		// notifications such as notifications/initialized need no answer
+		return nil, nil
+	}
+	return nil, &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("method %s not found", req.Method)}
+}
+
+// initialize agrees on the protocol version: the client's when the server speaks it, the
+// server's newest otherwise
+func (s *Server) initialize(params json.RawMessage) (any, error) {
+	var input struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &input); err != nil {
+			return nil, fmt.Errorf("invalid initialize params: %w", err)
+		}
+	}
+	version := protocolVersions[0]
+	for _, supported := range protocolVersions {
+		if supported == input.ProtocolVersion {
+			version = supported
+		}
+	}
+	return map[string]any{
+		"protocolVersion": version,
+		"capabilities":    map[string]any{"tools": map[string]any{}},
+		"serverInfo":      map[string]string{"name": s.name, "version": s.version},
+	}, nil
+}
+
+type toolDescription struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	InputSchema json.RawMessage `json:"inputSchema"`
+}
+
+// listTools describes the tools sorted by name
+func (s *Server) listTools() any {
+	tools := make([]toolDescription, 0, len(s.tools))
+	for _, tool := range s.tools {
+		schema := tool.InputSchema
+		if schema == nil {
+			schema = json.RawMessage(`{"type":"object","properties":{}}`)
+		}
+		tools = append(tools, toolDescription{Name: tool.Name, Description: tool.Description, InputSchema: schema})
+	}
+	sort.Slice(tools, func(i, j int) bool { return tools[i].Name < tools[j].Name })
+	return map[string]any{"tools": tools}
+}
+
+type content struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type toolResult struct {
+	Content           []content `json:"content"`
+	StructuredContent any       `json:"structuredContent,omitempty"`
+	IsError           bool      `json:"isError,omitempty"`
+}
+
+// callTool runs a tool; its failures are reported in the result so the agent can read them,
+// while an unknown tool is a protocol error
+func (s *Server) callTool(ctx context.Context, params json.RawMessage) (any, error) {
+	var input struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(params, &input); err != nil {
+		return nil, fmt.Errorf("invalid tools/call params: %w", err)
+	}
+	tool, ok := s.tools[input.Name]
+	if !ok {
+		return nil, &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("unknown tool %s", input.Name)}
+	}
+	if len(input.Arguments) == 0 || string(input.Arguments) == "null" {
+		input.Arguments = json.RawMessage("{}")
+	}
+
+	value, err := tool.Run(ctx, input.Arguments)
+	if err != nil {
+		return toolResult{Content: []content{{Type: "text", Text: err.Error()}}, IsError: true}, nil
+	}
+	text, err := json.Marshal(value)
+	if err != nil {
+		return toolResult{Content: []content{{Type: "text", Text: fmt.Sprintf("failed to encode the result: %v", err)}}, IsError: true}, nil
+	}
+	result := toolResult{Content: []content{{Type: "text", Text: string(text)}}}
+	// structured content must be an object, so lists are only returned as text
+	if bytes.HasPrefix(text, []byte("{")) {
+		result.StructuredContent = json.RawMessage(text)
+	}
+	return result, nil
+}
@@ -0,0 +1,160 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testServer() *Server {
+	return NewServer("assetcap", "1.0.0", []Tool{
+		{
+			Name:        "echo",
+			Description: "Returns its arguments",
+			InputSchema: json.RawMessage(`{"type":"object","properties":{"text":{"type":"string"}}}`),
+			Run: func(_ context.Context, arguments json.RawMessage) (any, error) {
+				var input struct {
+					Text string `json:"text"`
+				}
+				if err := json.Unmarshal(arguments, &input); err != nil {
+					return nil, err
+				}
+				return map[string]string{"text": input.Text}, nil
+			},
+		},
+		{
+			Name:        "list",
+			Description: "Returns a list",
+			Run: func(context.Context, json.RawMessage) (any, error) {
+				return []string{"a", "b"}, nil
+			},
+		},
+		{
+			Name:        "fail",
+			Description: "Always fails",
+			Run: func(context.Context, json.RawMessage) (any, error) {
+				return nil, errors.New("asset booking not found")
+			},
+		},
+	})
+}
+
+// exchange sends the messages, one per line, and returns the decoded responses
+func exchange(t *testing.T, messages ...string) []map[string]any {
+	t.Helper()
+	var out strings.Builder
+	err := testServer().Serve(context.Background(), strings.NewReader(strings.Join(messages, "\n")+"\n"), &out)
+	require.NoError(t, err)
+
+	var responses []map[string]any
+	scanner := bufio.NewScanner(strings.NewReader(out.String()))
+	for scanner.Scan() {
+		var response map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &response))
+		responses = append(responses, response)
+	}
+	return responses
+}
+
+func TestServer_Initialize(t *testing.T) {
+	responses := exchange(t,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"agent","version":"1"}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"initialize","params":{"protocolVersion":"2099-01-01"}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"ping"}`,
+	)
+	require.Len(t, responses, 3, "notifications get no response")
+
+	result := responses[0]["result"].(map[string]any)
+	assert.Equal(t, float64(1), responses[0]["id"])
+	assert.Equal(t, "2025-03-26", result["protocolVersion"])
+	assert.Equal(t, map[string]any{"name": "assetcap", "version": "1.0.0"}, result["serverInfo"])
+	assert.Contains(t, result["capabilities"], "tools")
+
+	assert.Equal(t, "2025-06-18", responses[1]["result"].(map[string]any)["protocolVersion"], "unknown versions get the newest")
+	assert.Equal(t, map[string]any{}, responses[2]["result"])
+}
+
+func TestServer_ListTools(t *testing.T) {
+	responses := exchange(t, `{"jsonrpc":"2.0","id":"list","method":"tools/list"}`)
+	require.Len(t, responses, 1)
+	assert.Equal(t, "list", responses[0]["id"])
+
+	tools := responses[0]["result"].(map[string]any)["tools"].([]any)
+	require.Len(t, tools, 3)
+	names := make([]string, 0, len(tools))
+	for _, tool := range tools {
+		names = append(names, tool.(map[string]any)["name"].(string))
+	}
+	assert.Equal(t, []string{"echo", "fail", "list"}, names)
+	assert.Equal(t, map[string]any{"type": "object", "properties": map[string]any{}}, tools[2].(map[string]any)["inputSchema"])
+}
+
+func TestServer_CallTool(t *testing.T) {
+	responses := exchange(t,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"echo","arguments":{"text":"hi"}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"list"}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"fail","arguments":{}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"missing"}}`,
+	)
+	require.Len(t, responses, 4)
+
+	echo := responses[0]["result"].(map[string]any)
+	assert.Equal(t, map[string]any{"text": "hi"}, echo["structuredContent"])
+	assert.Equal(t, []any{map[string]any{"type": "text", "text": `{"text":"hi"}`}}, echo["content"])
+	assert.NotContains(t, echo, "isError")
+
+	list := responses[1]["result"].(map[string]any)
+	assert.NotContains(t, list, "structuredContent", "structured content must be an object")
+	assert.Equal(t, `["a","b"]`, list["content"].([]any)[0].(map[string]any)["text"])
+
+	failed := responses[2]["result"].(map[string]any)
+	assert.Equal(t, true, failed["isError"])
+	assert.Equal(t, "asset booking not found", failed["content"].([]any)[0].(map[string]any)["text"])
+
+	assert.Equal(t, map[string]any{"code": float64(codeInvalidParams), "message": "unknown tool missing"}, responses[3]["error"])
+}
+
+func TestServer_Errors(t *testing.T) {
+	responses := exchange(t,
+		`{not json`,
+		`{"jsonrpc":"1.0","id":1,"method":"ping"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"resources/list"}`,
+		`{"jsonrpc":"2.0","method":"notifications/unknown"}`,
+	)
+	require.Len(t, responses, 3)
+	assert.Equal(t, float64(codeParseError), responses[0]["error"].(map[string]any)["code"])
+	assert.Nil(t, responses[0]["id"])
+	assert.Equal(t, float64(codeInvalidRequest), responses[1]["error"].(map[string]any)["code"])
+	assert.Equal(t, map[string]any{"code": float64(codeMethodNotFound), "message": "method resources/list not found"}, responses[2]["error"])
+}
+
+func TestServer_Listen(t *testing.T) {
+	listener, err := net.Listen("unix", filepath.Join(t.TempDir(), "mcp.sock"))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- testServer().Listen(ctx, listener) }()
+
+	conn, err := net.Dial("unix", listener.Addr().String())
+	require.NoError(t, err)
+	_, err = conn.Write([]byte(`{"jsonrpc":"2.0","id":1,"method":"ping"}` + "\n"))
+	require.NoError(t, err)
+
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":1,"result":{}}`, line)
+
+	cancel()
+	assert.NoError(t, <-done)
+	conn.Close()
+}
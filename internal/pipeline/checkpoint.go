@@ -0,0 +1,60 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// JSONCheckpointStore implements CheckpointStore with a JSON file per project and quarter
+type JSONCheckpointStore struct {
+	dir string
+}
+
+// NewJSONCheckpointStore creates a new JSON checkpoint store writing to dir
+func NewJSONCheckpointStore(dir string) *JSONCheckpointStore {
+	return &JSONCheckpointStore{dir: dir}
+}
+
+// Load returns the checkpoint of the project and quarter, or nil when there is none
+func (s *JSONCheckpointStore) Load(project, quarter string) (*Checkpoint, error) {
+	data, err := os.ReadFile(s.path(project, quarter))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var checkpoint Checkpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal checkpoint: %w", err)
+	}
+	return &checkpoint, nil
+}
+
+// Save stores the checkpoint, replacing an earlier one of the same project and quarter
+func (s *JSONCheckpointStore) Save(checkpoint *Checkpoint) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+
+	if err := os.WriteFile(s.path(checkpoint.Project, checkpoint.Quarter), data, 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// path returns the checkpoint file of the project and quarter
+func (s *JSONCheckpointStore) path(project, quarter string) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%s-%s.json", project, quarter))
+}
+
+// Ensure JSONCheckpointStore implements CheckpointStore
+var _ CheckpointStore = (*JSONCheckpointStore)(nil)
@@ -0,0 +1,48 @@
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONCheckpointStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "pipeline")
+	store := NewJSONCheckpointStore(dir)
+
+	checkpoint, err := store.Load("FN", "2024-Q2")
+	require.NoError(t, err)
+	assert.Nil(t, checkpoint)
+
+	saved := &Checkpoint{
+		Project: "FN",
+		Quarter: "2024-Q2",
+		Sprints: []string{"Sprint 7"},
+		Stages: []StageRecord{
+			{Stage: StageFetch, CompletedAt: time.Date(2024, time.July, 1, 9, 0, 0, 0, time.UTC), Note: "1 sprint"},
+		},
+	}
+	require.NoError(t, store.Save(saved))
+
+	checkpoint, err = store.Load("FN", "2024-Q2")
+	require.NoError(t, err)
+	assert.Equal(t, saved, checkpoint)
+	assert.FileExists(t, filepath.Join(dir, "FN-2024-Q2.json"))
+
+	other, err := store.Load("FN", "2024-Q3")
+	require.NoError(t, err)
+	assert.Nil(t, other)
+}
+
+func TestJSONCheckpointStore_CorruptFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "FN-2024-Q2.json"), []byte("{"), 0644))
+
+	_, err := NewJSONCheckpointStore(dir).Load("FN", "2024-Q2")
+
+	assert.ErrorContains(t, err, "failed to unmarshal checkpoint")
+}
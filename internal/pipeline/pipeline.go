@@ -0,0 +1,186 @@
+// Package pipeline runs the quarter close as a sequence of checkpointed stages.
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Stage names a step of the quarter pipeline
+type Stage string
+
+// Stages of the quarter pipeline
+const (
+	StageSyncAssets Stage = "sync-assets"
+	StageFetch      Stage = "fetch"
+	StageClassify   Stage = "classify"
+	StageAllocate   Stage = "allocate"
+	StagePolicy     Stage = "policy"
+	StageReport     Stage = "report"
+	StagePush       Stage = "push"
+)
+
+// Stages are the pipeline stages in execution order
+var Stages = []Stage{StageSyncAssets, StageFetch, StageClassify, StageAllocate, StagePolicy, StageReport, StagePush}
+
+// ParseStage returns the stage with the given name
+func ParseStage(name string) (Stage, error) {
+	for _, stage := range Stages {
+		if string(stage) == name {
+			return stage, nil
+		}
+	}
+	names := make([]string, len(Stages))
+	for i, stage := range Stages {
+		names[i] = string(stage)
+	}
+	return "", fmt.Errorf("unknown pipeline stage %s; stages are %s", name, strings.Join(names, ", "))
+}
+
+// StageResult is what a stage produced
+type StageResult struct {
+	// Artifacts are the paths of the files the stage wrote
+	Artifacts []string
+	// Note summarizes the stage outcome, e.g. the number of sprints fetched
+	Note string
+	// Skipped marks a stage that had nothing to do, e.g. push without --push
+	Skipped bool
+}
+
+// StageRecord is the checkpoint of a completed stage
+type StageRecord struct {
+	Stage       Stage     `json:"stage"`
+	CompletedAt time.Time `json:"completed_at"`
+	Artifacts   []string  `json:"artifacts,omitempty"`
+	Note        string    `json:"note,omitempty"`
+	Skipped     bool      `json:"skipped,omitempty"`
+	// Resumed marks a stage reused from an earlier run; it is not persisted
+	Resumed bool `json:"-"`
+}
+
+// Checkpoint records the progress of a pipeline run for a project and quarter
+type Checkpoint struct {
+	Project string `json:"project"`
+	Quarter string `json:"quarter"`
+	// Sprints are the sprints of the quarter, found by the fetch stage
+	Sprints []string      `json:"sprints,omitempty"`
+	Stages  []StageRecord `json:"stages"`
+}
+
+// Completed returns the record of the stage, and false when it has not completed
+func (c *Checkpoint) Completed(stage Stage) (StageRecord, bool) {
+	for _, record := range c.Stages {
+		if record.Stage == stage {
+			return record, true
+		}
+	}
+	return StageRecord{}, false
+}
+
+// Artifacts returns the artifacts of every completed stage, in stage order
+func (c *Checkpoint) Artifacts() []string {
+	var artifacts []string
+	for _, record := range c.Stages {
+		artifacts = append(artifacts, record.Artifacts...)
+	}
+	return artifacts
+}
+
+// CheckpointStore persists the checkpoints of pipeline runs
+type CheckpointStore interface {
+	// Load returns the checkpoint of the project and quarter, or nil when there is none
+	Load(project, quarter string) (*Checkpoint, error)
+	// Save stores the checkpoint, replacing an earlier one of the same project and quarter
+	Save(checkpoint *Checkpoint) error
+}
+
+// Step binds a stage to the function running it
+type Step struct {
+	Stage Stage
+	Run   func(ctx context.Context, checkpoint *Checkpoint) (StageResult, error)
+}
+
+// Runner executes pipeline steps, saving a checkpoint after each completed stage
+type Runner struct {
+	store CheckpointStore
+	now   func() time.Time
+}
+
+// NewRunner creates a new Runner
+func NewRunner(store CheckpointStore) *Runner {
+	return &Runner{store: store, now: time.Now}
+}
+
+// Run executes the steps in order. Without a from stage it starts afresh; otherwise the
+// stages before from are taken from the saved checkpoint, where they must have completed.
+// The returned checkpoint covers the stages completed so far, also when a stage fails.
+func (r *Runner) Run(ctx context.Context, project, quarter string, from Stage, steps []Step) (*Checkpoint, error) {
+	checkpoint, start, err := r.resume(project, quarter, from, steps)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, step := range steps[start:] {
+		if err := ctx.Err(); err != nil {
+			return checkpoint, err
+		}
+		result, err := step.Run(ctx, checkpoint)
+		if err != nil {
+			return checkpoint, fmt.Errorf("stage %s failed: %w; resume with --from-stage %s", step.Stage, err, step.Stage)
+		}
+		checkpoint.Stages = append(checkpoint.Stages, StageRecord{
+			Stage:       step.Stage,
+			CompletedAt: r.now(),
+			Artifacts:   result.Artifacts,
+			Note:        result.Note,
+			Skipped:     result.Skipped,
+		})
+		if err := r.store.Save(checkpoint); err != nil {
+			return checkpoint, fmt.Errorf("failed to save checkpoint after stage %s: %w", step.Stage, err)
+		}
+	}
+	return checkpoint, nil
+}
+
+// resume returns the checkpoint to continue from and the index of the first step to run
+func (r *Runner) resume(project, quarter string, from Stage, steps []Step) (*Checkpoint, int, error) {
+	fresh := &Checkpoint{Project: project, Quarter: quarter}
+	if from == "" {
+		return fresh, 0, nil
+	}
+
+	start := -1
+	for i, step := range steps {
+		if step.Stage == from {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return nil, 0, fmt.Errorf("stage %s is not part of the pipeline", from)
+	}
+	if start == 0 {
+		return fresh, 0, nil
+	}
+
+	saved, err := r.store.Load(project, quarter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+	if saved == nil {
+		return nil, 0, fmt.Errorf("no checkpoint for %s %s; run the pipeline without --from-stage first", project, quarter)
+	}
+
+	resumed := &Checkpoint{Project: project, Quarter: quarter, Sprints: saved.Sprints}
+	for _, step := range steps[:start] {
+		record, ok := saved.Completed(step.Stage)
+		if !ok {
+			return nil, 0, fmt.Errorf("stage %s has not completed for %s %s; resume with --from-stage %s", step.Stage, project, quarter, step.Stage)
+		}
+		record.Resumed = true
+		resumed.Stages = append(resumed.Stages, record)
+	}
+	return resumed, start, nil
+}
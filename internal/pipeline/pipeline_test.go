@@ -0,0 +1,146 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryCheckpointStore keeps checkpoints in memory
+type memoryCheckpointStore struct {
+	checkpoints map[string]Checkpoint
+	saves       int
+}
+
+func newMemoryCheckpointStore() *memoryCheckpointStore {
+	return &memoryCheckpointStore{checkpoints: make(map[string]Checkpoint)}
+}
+
+func (s *memoryCheckpointStore) Load(project, quarter string) (*Checkpoint, error) {
+	checkpoint, ok := s.checkpoints[project+"/"+quarter]
+	if !ok {
+		return nil, nil
+	}
+	return &checkpoint, nil
+}
+
+func (s *memoryCheckpointStore) Save(checkpoint *Checkpoint) error {
+	s.saves++
+	saved := *checkpoint
+	saved.Stages = append([]StageRecord(nil), checkpoint.Stages...)
+	s.checkpoints[checkpoint.Project+"/"+checkpoint.Quarter] = saved
+	return nil
+}
+
+// recordingSteps returns a step per stage, recording the stages run
+func recordingSteps(ran *[]Stage, fail Stage) []Step {
+	steps := make([]Step, 0, len(Stages))
+	for _, stage := range Stages {
+		stage := stage
+		steps = append(steps, Step{Stage: stage, Run: func(_ context.Context, checkpoint *Checkpoint) (StageResult, error) {
+			*ran = append(*ran, stage)
+			if stage == fail {
+				return StageResult{}, errors.New("jira unavailable")
+			}
+			if stage == StageFetch {
+				checkpoint.Sprints = []string{"Sprint 7", "Sprint 8"}
+			}
+			if stage == StagePush {
+				return StageResult{Skipped: true, Note: "not requested"}, nil
+			}
+			return StageResult{Artifacts: []string{string(stage) + ".out"}}, nil
+		}})
+	}
+	return steps
+}
+
+func TestParseStage(t *testing.T) {
+	stage, err := ParseStage("allocate")
+	require.NoError(t, err)
+	assert.Equal(t, StageAllocate, stage)
+
+	_, err = ParseStage("deploy")
+	assert.EqualError(t, err, "unknown pipeline stage deploy; stages are sync-assets, fetch, classify, allocate, policy, report, push")
+}
+
+func TestRunner_Run(t *testing.T) {
+	store := newMemoryCheckpointStore()
+	runner := NewRunner(store)
+	runner.now = func() time.Time { return time.Date(2024, time.July, 1, 9, 0, 0, 0, time.UTC) }
+	var ran []Stage
+
+	checkpoint, err := runner.Run(context.Background(), "FN", "2024-Q2", "", recordingSteps(&ran, ""))
+
+	require.NoError(t, err)
+	assert.Equal(t, Stages, ran)
+	assert.Equal(t, []string{"Sprint 7", "Sprint 8"}, checkpoint.Sprints)
+	assert.Len(t, checkpoint.Stages, len(Stages))
+	assert.Equal(t, len(Stages), store.saves)
+	push, ok := checkpoint.Completed(StagePush)
+	require.True(t, ok)
+	assert.True(t, push.Skipped)
+	assert.Equal(t, []string{"sync-assets.out", "fetch.out", "classify.out", "allocate.out", "policy.out", "report.out"}, checkpoint.Artifacts())
+}
+
+func TestRunner_RunFailureKeepsCompletedStages(t *testing.T) {
+	store := newMemoryCheckpointStore()
+	var ran []Stage
+
+	checkpoint, err := NewRunner(store).Run(context.Background(), "FN", "2024-Q2", "", recordingSteps(&ran, StageAllocate))
+
+	assert.EqualError(t, err, "stage allocate failed: jira unavailable; resume with --from-stage allocate")
+	assert.Len(t, checkpoint.Stages, 3)
+	saved, _ := store.Load("FN", "2024-Q2")
+	assert.Len(t, saved.Stages, 3)
+}
+
+func TestRunner_RunFromStage(t *testing.T) {
+	store := newMemoryCheckpointStore()
+	var ran []Stage
+	_, err := NewRunner(store).Run(context.Background(), "FN", "2024-Q2", "", recordingSteps(&ran, StageAllocate))
+	require.Error(t, err)
+
+	ran = nil
+	checkpoint, err := NewRunner(store).Run(context.Background(), "FN", "2024-Q2", StageAllocate, recordingSteps(&ran, ""))
+
+	require.NoError(t, err)
+	assert.Equal(t, []Stage{StageAllocate, StagePolicy, StageReport, StagePush}, ran)
+	assert.Equal(t, []string{"Sprint 7", "Sprint 8"}, checkpoint.Sprints)
+	fetch, ok := checkpoint.Completed(StageFetch)
+	require.True(t, ok)
+	assert.True(t, fetch.Resumed)
+	assert.Len(t, checkpoint.Stages, len(Stages))
+}
+
+func TestRunner_RunFromStageErrors(t *testing.T) {
+	t.Run("without checkpoint", func(t *testing.T) {
+		var ran []Stage
+		_, err := NewRunner(newMemoryCheckpointStore()).Run(context.Background(), "FN", "2024-Q2", StageReport, recordingSteps(&ran, ""))
+
+		assert.EqualError(t, err, "no checkpoint for FN 2024-Q2; run the pipeline without --from-stage first")
+		assert.Empty(t, ran)
+	})
+
+	t.Run("earlier stage incomplete", func(t *testing.T) {
+		store := newMemoryCheckpointStore()
+		var ran []Stage
+		_, err := NewRunner(store).Run(context.Background(), "FN", "2024-Q2", "", recordingSteps(&ran, StageClassify))
+		require.Error(t, err)
+
+		_, err = NewRunner(store).Run(context.Background(), "FN", "2024-Q2", StageReport, recordingSteps(&ran, ""))
+
+		assert.EqualError(t, err, "stage classify has not completed for FN 2024-Q2; resume with --from-stage classify")
+	})
+
+	t.Run("first stage starts afresh", func(t *testing.T) {
+		var ran []Stage
+		_, err := NewRunner(newMemoryCheckpointStore()).Run(context.Background(), "FN", "2024-Q2", StageSyncAssets, recordingSteps(&ran, ""))
+
+		require.NoError(t, err)
+		assert.Equal(t, Stages, ran)
+	})
+}
@@ -0,0 +1,131 @@
+// Package redact masks credentials in the errors and logs of the Jira, Confluence and LLM
+// clients, which quote request URLs and response bodies that may echo them. The secrets of
+// the environment are registered once at startup; authorization headers, passwords in URLs
+// and token query parameters are masked wherever they appear.
+package redact
+
+import (
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Mask replaces every credential found
+const Mask = "[REDACTED]"
+
+// minSecretLength keeps short values, which would mask unrelated text, from being registered
+const minSecretLength = 6
+
+// SecretEnv lists the environment variables holding credentials
+var SecretEnv = []string{
+	"JIRA_TOKEN",
+	"GITHUB_TOKEN",
+	"BITBUCKET_APP_PASSWORD",
+	"AWS_SECRET_ACCESS_KEY",
+	"AWS_SESSION_TOKEN",
+	"GOOGLE_OAUTH_ACCESS_TOKEN",
+}
+
+var (
+	mu      sync.RWMutex
+	secrets []string
+
+	// authorization matches the credential of an authorization header or scheme
+	authorization = regexp.MustCompile(`(?i)\b(basic|bearer)\s+[A-Za-z0-9+/=._~-]{8,}`)
+	// userinfo matches the password of a URL
+	userinfo = regexp.MustCompile(`(://[^/\s:@]+:)[^/\s@]+@`)
+	// tokenParameter matches query parameters and JSON fields named like a credential
+	tokenParameter = regexp.MustCompile(`(?i)((?:api[_-]?key|access[_-]?token|token|password|secret|x-amz-signature|x-amz-credential|x-amz-security-token)"?\s*[=:]\s*"?)[^&\s"',}]+`)
+)
+
+// Secret registers values to mask wherever they appear, ignoring empty and short ones
+func Secret(values ...string) {
+	mu.Lock()
+	defer mu.Unlock()
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if len(value) < minSecretLength || contains(secrets, value) {
+			continue
+		}
+		secrets = append(secrets, value)
+	}
+	// Longer secrets first, so a secret containing another is masked whole
+	sort.Slice(secrets, func(i, j int) bool { return len(secrets[i]) > len(secrets[j]) })
+}
+
+// FromEnv registers the credentials of the environment variables in SecretEnv
+func FromEnv() {
+	for _, name := range SecretEnv {
+		Secret(os.Getenv(name))
+	}
+}
+
+// String masks the credentials in s
+func String(s string) string {
+	mu.RLock()
+	for _, secret := range secrets {
+		s = strings.ReplaceAll(s, secret, Mask)
+	}
+	mu.RUnlock()
+	s = authorization.ReplaceAllString(s, "$1 "+Mask)
+	s = userinfo.ReplaceAllString(s, "${1}"+Mask+"@")
+	return tokenParameter.ReplaceAllString(s, "${1}"+Mask)
+}
+
+// Error masks the credentials in the message of err, keeping it inspectable with errors.Is
+// and errors.As. Errors without credentials are returned as they are.
+func Error(err error) error {
+	if err == nil {
+		return nil
+	}
+	message := String(err.Error())
+	if message == err.Error() {
+		return err
+	}
+	return &redactedError{err: err, message: message}
+}
+
+// redactedError is an error whose message had credentials masked
+type redactedError struct {
+	err     error
+	message string
+}
+
+// Error implements error
+func (e *redactedError) Error() string {
+	return e.message
+}
+
+// Unwrap returns the original error
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// Writer returns a writer masking the credentials of each write to w, e.g. for the log
+func Writer(w io.Writer) io.Writer {
+	return writer{w: w}
+}
+
+type writer struct {
+	w io.Writer
+}
+
+// Write masks p and writes it, reporting the length of p as written
+func (w writer) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(w.w, String(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
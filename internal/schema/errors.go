@@ -0,0 +1,41 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Issue is a problem found in a data file
+type Issue struct {
+	// Line is the 1-based line the problem was found on
+	Line int
+	// Path locates the offending field, e.g. FN.team[2]; empty for syntax errors
+	Path    string
+	Message string
+}
+
+// String formats the issue as "line N: path: message"
+func (i Issue) String() string {
+	if i.Path == "" {
+		return fmt.Sprintf("line %d: %s", i.Line, i.Message)
+	}
+	return fmt.Sprintf("line %d: %s: %s", i.Line, i.Path, i.Message)
+}
+
+// ValidationError lists the problems found in a data file
+type ValidationError struct {
+	Issues []Issue
+}
+
+// Error lists the issues, one per line when there are several
+func (e *ValidationError) Error() string {
+	if len(e.Issues) == 1 {
+		return e.Issues[0].String()
+	}
+	lines := make([]string, 0, len(e.Issues)+1)
+	lines = append(lines, fmt.Sprintf("%d problems found", len(e.Issues)))
+	for _, issue := range e.Issues {
+		lines = append(lines, "  "+issue.String())
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,226 @@
+package schema
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// OutputVersion is the version of the JSON documents the commands print, as major.minor. The
+// minor version grows when fields are added; the major version when a change breaks existing
+// consumers, such as a field renamed, removed, made optional or given another type.
+const OutputVersion = "1.3"
+
+// VersionField is the field every JSON output carries OutputVersion in
+const VersionField = "schemaVersion"
+
+// draft is the JSON Schema dialect of the generated output schemas
+const draft = "https://json-schema.org/draft/2020-12/schema"
+
+// MarshalOutput encodes v, which must encode to a JSON object, as indented JSON with the output
+// schema version as its first field
+func MarshalOutput(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) < 2 || data[0] != '{' {
+		return nil, fmt.Errorf("output must be a JSON object, got %s", data)
+	}
+
+	var versioned bytes.Buffer
+	fmt.Fprintf(&versioned, "{%q:%q", VersionField, OutputVersion)
+	if rest := bytes.TrimSpace(data[1 : len(data)-1]); len(rest) > 0 {
+		versioned.WriteByte(',')
+		versioned.Write(rest)
+	}
+	versioned.WriteByte('}')
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, versioned.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	return indented.Bytes(), nil
+}
+
+// OutputSchema describes the JSON printed by MarshalOutput for values of v's type
+func OutputSchema(title string, v interface{}) *Schema {
+	s := generate(reflect.TypeOf(v), map[reflect.Type]bool{})
+	s.SchemaURI = draft
+	s.Title = title
+	s.Description = fmt.Sprintf("Output schema version %s", OutputVersion)
+	if s.Properties == nil {
+		s.Properties = map[string]*Schema{}
+	}
+	version := OutputVersion
+	s.Properties[VersionField] = &Schema{
+		Type:        Types{"string"},
+		Const:       &version,
+		Description: "Version of this schema; the major version changes with breaking changes",
+	}
+	s.Required = append([]string{VersionField}, s.Required...)
+	return s
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// generate describes how encoding/json encodes values of type t. Types with their own JSON
+// encoding, and recursive types, are described as any value.
+func generate(t reflect.Type, visiting map[reflect.Type]bool) *Schema {
+	if t.Kind() == reflect.Pointer {
+		s := generate(t.Elem(), visiting)
+		return nullable(s)
+	}
+	switch {
+	case t == timeType:
+		return &Schema{Type: Types{"string"}, Format: "date-time"}
+	case t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType):
+		return &Schema{}
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		return &Schema{Type: Types{"string"}}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: Types{"string"}}
+	case reflect.Bool:
+		return &Schema{Type: Types{"boolean"}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: Types{"integer"}}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: Types{"number"}}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: Types{"string", "null"}}
+		}
+		return &Schema{Type: Types{"array", "null"}, Items: generate(t.Elem(), visiting)}
+	case reflect.Array:
+		return &Schema{Type: Types{"array"}, Items: generate(t.Elem(), visiting)}
+	case reflect.Map:
+		return &Schema{Type: Types{"object", "null"}, AdditionalProperties: generate(t.Elem(), visiting)}
+	case reflect.Struct:
+		if visiting[t] {
+			return &Schema{}
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+		s := &Schema{Type: Types{"object"}, Properties: map[string]*Schema{}}
+		addFields(s, t, visiting)
+		sort.Strings(s.Required)
+		return s
+	default:
+		return &Schema{}
+	}
+}
+
+// addFields adds the properties encoding/json writes for the fields of struct type t,
+// promoting those of embedded structs
+func addFields(s *Schema, t reflect.Type, visiting map[reflect.Type]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				addFields(s, embedded, visiting)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		s.Properties[name] = generate(field.Type, visiting)
+		if !strings.Contains(options, "omitempty") && !strings.Contains(options, "omitzero") {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+// nullable allows null besides the types of s
+func nullable(s *Schema) *Schema {
+	if len(s.Type) > 0 && !contains(s.Type, kindNull) {
+		s.Type = append(s.Type, kindNull)
+	}
+	return s
+}
+
+// Breaking lists the changes from previous to current that break consumers written against
+// previous: fields removed or no longer always present, and values of other types or formats
+func Breaking(previous, current *Schema) []string {
+	var changes []string
+	breaking(previous, current, "", &changes)
+	sort.Strings(changes)
+	return changes
+}
+
+// breaking collects the breaking changes of a value at path
+func breaking(previous, current *Schema, path string, changes *[]string) {
+	at := displayPath(path)
+	if len(previous.Type) > 0 {
+		if len(current.Type) == 0 {
+			*changes = append(*changes, fmt.Sprintf("%s: type changed from %s to any value", at, strings.Join(previous.Type, " or ")))
+			return
+		}
+		for _, kind := range current.Type {
+			if !contains(previous.Type, kind) {
+				*changes = append(*changes, fmt.Sprintf("%s: type changed from %s to %s", at, strings.Join(previous.Type, " or "), strings.Join(current.Type, " or ")))
+				return
+			}
+		}
+	}
+	if previous.Format != current.Format {
+		*changes = append(*changes, fmt.Sprintf("%s: format changed from %q to %q", at, previous.Format, current.Format))
+	}
+
+	for name, property := range previous.Properties {
+		if name == VersionField && path == "" {
+			continue
+		}
+		next, ok := current.Properties[name]
+		if !ok {
+			*changes = append(*changes, fmt.Sprintf("%s: removed", displayPath(joinPath(path, name))))
+			continue
+		}
+		breaking(property, next, joinPath(path, name), changes)
+	}
+	for _, name := range previous.Required {
+		if _, ok := current.Properties[name]; ok && !contains(current.Required, name) {
+			*changes = append(*changes, fmt.Sprintf("%s: no longer always present", displayPath(joinPath(path, name))))
+		}
+	}
+	if previous.Items != nil && current.Items != nil {
+		breaking(previous.Items, current.Items, path+"[]", changes)
+	}
+	if previous.AdditionalProperties != nil && current.AdditionalProperties != nil {
+		breaking(previous.AdditionalProperties, current.AdditionalProperties, joinPath(path, "*"), changes)
+	}
+}
+
+// Version returns the output schema version an output schema describes, if any
+func (s *Schema) Version() string {
+	if property, ok := s.Properties[VersionField]; ok && property.Const != nil {
+		return *property.Const
+	}
+	return ""
+}
@@ -0,0 +1,101 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type outputLine struct {
+	Key   string  `json:"key"`
+	Hours float64 `json:"hours"`
+}
+
+type outputBase struct {
+	Sprint string `json:"sprint"`
+}
+
+type outputReport struct {
+	outputBase
+	Passed   bool               `json:"passed"`
+	At       time.Time          `json:"at"`
+	Lines    []outputLine       `json:"lines"`
+	Totals   map[string]float64 `json:"totals,omitempty"`
+	Previous *outputLine        `json:"previous,omitempty"`
+	Closed   time.Time          `json:"closed,omitzero"`
+	internal string
+	Skipped  string `json:"-"`
+}
+
+func TestMarshalOutput(t *testing.T) {
+	data, err := MarshalOutput(outputReport{outputBase: outputBase{Sprint: "S1"}, Lines: []outputLine{{Key: "FN-1", Hours: 2}}})
+	require.NoError(t, err)
+
+	assert.Regexp(t, `^\{\n  "schemaVersion": "`+OutputVersion+`",\n  "sprint": "S1",`, string(data))
+	require.NoError(t, OutputSchema("report", outputReport{}).Validate(data))
+
+	_, err = MarshalOutput([]string{"a"})
+	assert.ErrorContains(t, err, "output must be a JSON object")
+}
+
+func TestOutputSchema(t *testing.T) {
+	s := OutputSchema("report", outputReport{})
+
+	assert.Equal(t, "report", s.Title)
+	assert.Equal(t, OutputVersion, s.Version())
+	assert.Equal(t, []string{VersionField, "at", "lines", "passed", "sprint"}, s.Required)
+	assert.NotContains(t, s.Properties, "internal")
+	assert.NotContains(t, s.Properties, "Skipped")
+	assert.Equal(t, Types{"string"}, s.Properties["at"].Type)
+	assert.Equal(t, "date-time", s.Properties["at"].Format)
+	assert.Equal(t, "date-time", s.Properties["closed"].Format)
+	assert.Equal(t, Types{"array", "null"}, s.Properties["lines"].Type)
+	assert.Equal(t, Types{"number"}, s.Properties["lines"].Items.Properties["hours"].Type)
+	assert.Equal(t, Types{"object", "null"}, s.Properties["previous"].Type)
+	assert.Equal(t, Types{"number"}, s.Properties["totals"].AdditionalProperties.Type)
+
+	err := s.Validate([]byte(`{"schemaVersion": "0.1", "sprint": "S1", "passed": true, "at": "2025-01-01T00:00:00Z", "lines": null}`))
+	assert.ErrorContains(t, err, `schemaVersion: unsupported value "0.1"; expected "`+OutputVersion+`"`)
+
+	// the schema survives a round trip through its JSON form
+	data, err := json.Marshal(s)
+	require.NoError(t, err)
+	var decoded Schema
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Empty(t, Breaking(s, &decoded))
+	assert.Empty(t, Breaking(&decoded, s))
+}
+
+func TestBreaking(t *testing.T) {
+	previous := OutputSchema("report", outputReport{})
+
+	t.Run("added fields are compatible", func(t *testing.T) {
+		type extended struct {
+			outputReport
+			Notes string `json:"notes"`
+		}
+		assert.Empty(t, Breaking(previous, OutputSchema("report", extended{})))
+	})
+
+	t.Run("removed, optional and retyped fields break", func(t *testing.T) {
+		type changed struct {
+			Sprint int          `json:"sprint"`
+			Passed bool         `json:"passed,omitempty"`
+			At     string       `json:"at"`
+			Lines  []outputBase `json:"lines"`
+		}
+		assert.Equal(t, []string{
+			`at: format changed from "date-time" to ""`,
+			`closed: removed`,
+			`lines[].hours: removed`,
+			`lines[].key: removed`,
+			`passed: no longer always present`,
+			`previous: removed`,
+			`sprint: type changed from string to integer`,
+			`totals: removed`,
+		}, Breaking(previous, OutputSchema("report", changed{})))
+	})
+}
@@ -0,0 +1,191 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+)
+
+// JSON value kinds, named after the JSON Schema types
+const (
+	kindString  = "string"
+	kindNumber  = "number"
+	kindInteger = "integer"
+	kindBoolean = "boolean"
+	kindObject  = "object"
+	kindArray   = "array"
+	kindNull    = "null"
+)
+
+// node is a parsed JSON value that remembers the line it was written on
+type node struct {
+	kind    string
+	line    int
+	text    string
+	number  float64
+	integer bool
+	fields  []field
+	items   []*node
+}
+
+// field is an object property in the order it was written
+type field struct {
+	key   string
+	line  int
+	value *node
+}
+
+// parse reads a JSON document into a tree of nodes
+func parse(data []byte) (*node, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	p := &parser{decoder: decoder, lines: newlineOffsets(data)}
+
+	return p.value()
+}
+
+// parser walks the tokens of a JSON document
+type parser struct {
+	decoder *json.Decoder
+	lines   []int
+}
+
+// value parses the next JSON value
+func (p *parser) value() (*node, error) {
+	token, err := p.decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	n := &node{line: p.line()}
+
+	switch v := token.(type) {
+	case json.Delim:
+		if v == '{' {
+			n.kind = kindObject
+			err = p.object(n)
+		} else {
+			n.kind = kindArray
+			err = p.array(n)
+		}
+		if err != nil {
+			return nil, err
+		}
+	case string:
+		n.kind, n.text = kindString, v
+	case json.Number:
+		n.kind, n.text = kindNumber, v.String()
+		n.number, _ = v.Float64()
+		n.integer = n.number == math.Trunc(n.number)
+	case bool:
+		n.kind = kindBoolean
+		n.text = fmt.Sprint(v)
+	case nil:
+		n.kind = kindNull
+	}
+	return n, nil
+}
+
+// object parses the properties of an object up to its closing brace
+func (p *parser) object(n *node) error {
+	for p.decoder.More() {
+		token, err := p.decoder.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := token.(string)
+		line := p.line()
+		value, err := p.value()
+		if err != nil {
+			return err
+		}
+		n.fields = append(n.fields, field{key: key, line: line, value: value})
+	}
+	_, err := p.decoder.Token()
+	return err
+}
+
+// array parses the items of an array up to its closing bracket
+func (p *parser) array(n *node) error {
+	for p.decoder.More() {
+		item, err := p.value()
+		if err != nil {
+			return err
+		}
+		n.items = append(n.items, item)
+	}
+	_, err := p.decoder.Token()
+	return err
+}
+
+// line returns the line of the last token read
+func (p *parser) line() int {
+	return lineAt(p.lines, p.decoder.InputOffset()-1)
+}
+
+// newlineOffsets returns the offsets of the line breaks of a document
+func newlineOffsets(data []byte) []int {
+	var offsets []int
+	for i, b := range data {
+		if b == '\n' {
+			offsets = append(offsets, i)
+		}
+	}
+	return offsets
+}
+
+// lineAt returns the 1-based line holding the byte at offset
+func lineAt(newlines []int, offset int64) int {
+	return sort.SearchInts(newlines, int(offset)) + 1
+}
+
+// syntaxIssue describes why a document is not valid JSON
+func syntaxIssue(data []byte, err error) Issue {
+	newlines := newlineOffsets(data)
+
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.As(err, &syntaxErr) && syntaxErr.Error() != "unexpected end of JSON input":
+		offset := syntaxErr.Offset - 1
+		if offset < 0 {
+			offset = 0
+		}
+		return Issue{Line: lineAt(newlines, offset), Message: "invalid JSON: " + syntaxErr.Error() + syntaxHint(data, offset)}
+	case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, io.EOF):
+		return Issue{Line: lineAt(newlines, int64(len(bytes.TrimRight(data, " \t\r\n")))), Message: "invalid JSON: unexpected end of file; check for a missing closing } or ]"}
+	default:
+		return Issue{Line: 1, Message: "invalid JSON: " + err.Error()}
+	}
+}
+
+// syntaxHint suggests the usual fix for the syntax error found at offset
+func syntaxHint(data []byte, offset int64) string {
+	if offset >= int64(len(data)) {
+		return ""
+	}
+	switch data[offset] {
+	case '}', ']':
+		if previous := lastNonSpace(data[:offset]); previous == ',' {
+			return "; remove the trailing comma before it"
+		}
+	case '"':
+		if previous := lastNonSpace(data[:offset]); previous == '"' || previous == '}' || previous == ']' {
+			return "; add a comma before it"
+		}
+	case '\'':
+		return "; use double quotes for strings and field names"
+	}
+	return ""
+}
+
+// lastNonSpace returns the last byte that is not white space
+func lastNonSpace(data []byte) byte {
+	trimmed := bytes.TrimRight(data, " \t\r\n")
+	if len(trimmed) == 0 {
+		return 0
+	}
+	return trimmed[len(trimmed)-1]
+}
@@ -0,0 +1,311 @@
+// Package schema validates the assetcap data files against their JSON Schema definitions,
+// reporting problems with the line and field they were found at.
+package schema
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Document names a data file format with a JSON Schema definition
+type Document string
+
+// Supported documents
+const (
+	Teams  Document = "teams"
+	Assets Document = "assets"
+	Tasks  Document = "tasks"
+)
+
+//go:embed schemas/*.schema.json
+var definitions embed.FS
+
+// Schema is the subset of JSON Schema used by the assetcap definitions
+type Schema struct {
+	SchemaURI   string             `json:"$schema,omitempty"`
+	Title       string             `json:"title,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Type        Types              `json:"type,omitempty"`
+	Format      string             `json:"format,omitempty"`
+	Const       *string            `json:"const,omitempty"`
+	Enum        []string           `json:"enum,omitempty"`
+	MinLength   int                `json:"minLength,omitempty"`
+	Minimum     *float64           `json:"minimum,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	// AdditionalProperties describes the values of properties not listed in Properties;
+	// nil allows any value and Forbidden rejects them
+	AdditionalProperties *Schema `json:"additionalProperties,omitempty"`
+	Items                *Schema `json:"items,omitempty"`
+	// Forbidden is set by the boolean schema false
+	Forbidden bool `json:"-"`
+}
+
+// UnmarshalJSON accepts the boolean schemas true and false besides schema objects
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	var allowed bool
+	if err := json.Unmarshal(data, &allowed); err == nil {
+		*s = Schema{Forbidden: !allowed}
+		return nil
+	}
+	type plain Schema
+	return json.Unmarshal(data, (*plain)(s))
+}
+
+// MarshalJSON writes Forbidden as the boolean schema false
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	if s.Forbidden {
+		return []byte("false"), nil
+	}
+	type plain Schema
+	return json.Marshal((*plain)(s))
+}
+
+// Types lists the JSON types a value may have, written as a string or an array
+type Types []string
+
+// UnmarshalJSON accepts a single type name or a list of them
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = Types{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*t = list
+	return nil
+}
+
+// MarshalJSON writes a single type as a string
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// Load returns the schema definition of a document
+func Load(doc Document) (*Schema, error) {
+	data, err := definitions.ReadFile("schemas/" + string(doc) + ".schema.json")
+	if err != nil {
+		return nil, fmt.Errorf("unknown document %q", doc)
+	}
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("failed to parse %s schema: %w", doc, err)
+	}
+	return &schema, nil
+}
+
+// Definition returns the raw JSON Schema of a document, for editors and other tools
+func Definition(doc Document) ([]byte, error) {
+	data, err := definitions.ReadFile("schemas/" + string(doc) + ".schema.json")
+	if err != nil {
+		return nil, fmt.Errorf("unknown document %q", doc)
+	}
+	return data, nil
+}
+
+// Validate checks the content of a data file against its document schema. It returns a
+// *ValidationError listing every problem found.
+func Validate(doc Document, data []byte) error {
+	schema, err := Load(doc)
+	if err != nil {
+		return err
+	}
+	return schema.Validate(data)
+}
+
+// Validate checks a JSON document against the schema
+func (s *Schema) Validate(data []byte) error {
+	// The standard decoder describes syntax errors best
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return &ValidationError{Issues: []Issue{syntaxIssue(data, err)}}
+	}
+	root, err := parse(data)
+	if err != nil {
+		return &ValidationError{Issues: []Issue{syntaxIssue(data, err)}}
+	}
+
+	var issues []Issue
+	s.check(root, "", &issues)
+	if len(issues) == 0 {
+		return nil
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Line < issues[j].Line
+	})
+	return &ValidationError{Issues: issues}
+}
+
+// check validates a node against the schema, collecting the problems found
+func (s *Schema) check(n *node, path string, issues *[]Issue) {
+	report := func(format string, args ...interface{}) {
+		*issues = append(*issues, Issue{Line: n.line, Path: displayPath(path), Message: fmt.Sprintf(format, args...)})
+	}
+
+	if s.Forbidden {
+		report("unexpected field")
+		return
+	}
+	if len(s.Type) > 0 && !s.Type.allows(n) {
+		report("expected %s, got %s%s", strings.Join(s.Type, " or "), n.kind, typeHint(s.Type, n))
+		return
+	}
+
+	switch n.kind {
+	case kindString:
+		s.checkString(n, report)
+	case kindNumber:
+		if s.Minimum != nil && n.number < *s.Minimum {
+			report("must be at least %v, got %v", *s.Minimum, n.number)
+		}
+	case kindObject:
+		s.checkObject(n, path, issues, report)
+	case kindArray:
+		if s.Items != nil {
+			for i, item := range n.items {
+				s.Items.check(item, fmt.Sprintf("%s[%d]", path, i), issues)
+			}
+		}
+	}
+}
+
+// checkString validates the constant, enumeration, length and format of a string
+func (s *Schema) checkString(n *node, report func(string, ...interface{})) {
+	if s.Const != nil && n.text != *s.Const {
+		report("unsupported value %q; expected %q", n.text, *s.Const)
+		return
+	}
+	if len(s.Enum) > 0 && !contains(s.Enum, n.text) {
+		message := fmt.Sprintf("unsupported value %q; expected one of %s", n.text, quoteAll(s.Enum))
+		if suggestion := closest(n.text, s.Enum); suggestion != "" {
+			message += fmt.Sprintf("; did you mean %q?", suggestion)
+		}
+		report("%s", message)
+		return
+	}
+	if len([]rune(n.text)) < s.MinLength {
+		if s.MinLength == 1 {
+			report("must not be empty")
+		} else {
+			report("must be at least %d characters long", s.MinLength)
+		}
+		return
+	}
+	if s.Format != "" {
+		if example, ok := checkFormat(s.Format, n.text); !ok {
+			report("%q is not a valid %s; use a value such as %q", n.text, s.Format, example)
+		}
+	}
+}
+
+// checkObject validates the properties of an object
+func (s *Schema) checkObject(n *node, path string, issues *[]Issue, report func(string, ...interface{})) {
+	present := make(map[string]bool, len(n.fields))
+	for _, field := range n.fields {
+		present[field.key] = true
+		fieldPath := joinPath(path, field.key)
+		if property, ok := s.Properties[field.key]; ok {
+			property.check(field.value, fieldPath, issues)
+			continue
+		}
+		if s.AdditionalProperties == nil {
+			continue
+		}
+		if s.AdditionalProperties.Forbidden {
+			message := fmt.Sprintf("unknown field %q", field.key)
+			names := s.propertyNames()
+			if suggestion := closest(field.key, names); suggestion != "" {
+				message += fmt.Sprintf("; did you mean %q?", suggestion)
+			} else if len(names) > 0 {
+				message += fmt.Sprintf("; expected one of %s", quoteAll(names))
+			}
+			*issues = append(*issues, Issue{Line: field.line, Path: displayPath(fieldPath), Message: message})
+			continue
+		}
+		s.AdditionalProperties.check(field.value, fieldPath, issues)
+	}
+
+	for _, name := range s.Required {
+		if !present[name] {
+			report("missing required field %q", name)
+		}
+	}
+}
+
+// propertyNames returns the declared property names in alphabetical order
+func (s *Schema) propertyNames() []string {
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// allows reports whether a node has one of the types
+func (t Types) allows(n *node) bool {
+	for _, name := range t {
+		if name == n.kind || (name == kindInteger && n.kind == kindNumber && n.integer) {
+			return true
+		}
+	}
+	return false
+}
+
+// typeHint suggests how to fix a value of the wrong type
+func typeHint(types Types, n *node) string {
+	expects := func(kind string) bool { return contains(types, kind) }
+	switch {
+	case expects(kindArray) && n.kind != kindObject && n.kind != kindNull:
+		return "; wrap the value in [ ] to make it a list"
+	case (expects(kindNumber) || expects(kindInteger)) && n.kind == kindString:
+		return "; remove the quotes around the number"
+	case expects(kindBoolean) && n.kind == kindString && (n.text == "true" || n.text == "false"):
+		return "; remove the quotes around " + n.text
+	case expects(kindInteger) && n.kind == kindNumber:
+		return "; use a whole number"
+	}
+	return ""
+}
+
+// joinPath appends a property name to a field path
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// displayPath returns the field path shown to users
+func displayPath(path string) string {
+	if path == "" {
+		return "(root)"
+	}
+	return path
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+func quoteAll(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = fmt.Sprintf("%q", value)
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,114 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func issues(t *testing.T, doc Document, data string) []string {
+	t.Helper()
+	err := Validate(doc, []byte(data))
+	if err == nil {
+		return nil
+	}
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	result := make([]string, 0, len(validationErr.Issues))
+	for _, issue := range validationErr.Issues {
+		result = append(result, issue.String())
+	}
+	return result
+}
+
+func TestValidate_Teams(t *testing.T) {
+	assert.Empty(t, issues(t, Teams, `{"FN": {"team": ["alice"], "aliases": {"alice": ["Alice A."]}}}`))
+	assert.Empty(t, issues(t, Teams, `{"FN": {"team": ["alice"], "account_ids": {"alice": "5b10a2844c20165700ede21g"}}}`))
+	assert.Empty(t, issues(t, Teams, `{"FN": {"team": ["alice"], "capacity": {"alice": 0.5}}}`))
+	assert.Equal(t, []string{
+		`line 1: FN.capacity.alice: must be at least 0, got -1`,
+	}, issues(t, Teams, `{"FN": {"team": ["alice"], "capacity": {"alice": -1}}}`))
+	assert.Empty(t, issues(t, Teams, `{"FN": {"team": ["alice"], "holidays": ["2024-12-25"]}}`))
+	assert.Equal(t, []string{
+		`line 1: FN.holidays[0]: "25/12/2024" is not a valid date; use a value such as "2024-01-31"`,
+	}, issues(t, Teams, `{"FN": {"team": ["alice"], "holidays": ["25/12/2024"]}}`))
+
+	assert.Equal(t, []string{
+		`line 2: FN: missing required field "team"`,
+		`line 3: FN.Members: unknown field "Members"; expected one of "account_ids", "aliases", "capacity", "holidays", "team"`,
+	}, issues(t, Teams, "{\n  \"FN\": {\n    \"Members\": [\"alice\"]\n  }\n}"))
+
+	assert.Equal(t, []string{
+		`line 1: FN.team: expected array, got string; wrap the value in [ ] to make it a list`,
+	}, issues(t, Teams, `{"FN": {"team": "alice"}}`))
+
+	assert.Equal(t, []string{
+		`line 1: FN.teams: unknown field "teams"; did you mean "team"?`,
+		`line 1: FN: missing required field "team"`,
+	}, issues(t, Teams, `{"FN": {"teams": ["alice"]}}`))
+
+	assert.Equal(t, []string{
+		`line 4: FN.team[1]: must not be empty`,
+	}, issues(t, Teams, "{\"FN\": {\"team\": [\n  \"alice\",\n\n  \"\"\n]}}"))
+}
+
+func TestValidate_Assets(t *testing.T) {
+	assert.Empty(t, issues(t, Assets, `{"booking": {"name": "booking", "keywords": null, "created_at": "2024-01-31T10:00:00.5+01:00", "version": 1}}`))
+
+	assert.Equal(t, []string{
+		`line 1: booking.version: expected integer, got string; remove the quotes around the number`,
+		`line 1: booking.launch_date: "31.01.2024" is not a valid date-time; use a value such as "2024-01-31T00:00:00Z"`,
+		`line 1: booking.is_rolled_out_100: expected boolean, got string; remove the quotes around true`,
+		`line 1: booking.associated_task_count: must be at least 0, got -1`,
+	}, issues(t, Assets, `{"booking": {"name": "booking", "version": "1", "launch_date": "31.01.2024", "is_rolled_out_100": "true", "associated_task_count": -1}}`))
+}
+
+func TestValidate_Tasks(t *testing.T) {
+	assert.Empty(t, issues(t, Tasks, `{"FN-1": {"key": "FN-1", "work_type": "", "labels": null, "hierarchy": [{"level": "epic", "key": "FN-0"}]}}`))
+
+	assert.Equal(t, []string{
+		`line 1: FN-1.work_type: unsupported value "cap-developement"; expected one of "", "cap-development", "cap-maintenance", "cap-discovery"; did you mean "cap-development"?`,
+	}, issues(t, Tasks, `{"FN-1": {"key": "FN-1", "work_type": "cap-developement"}}`))
+
+	assert.Empty(t, issues(t, Tasks, `{"FN-1": {"key": "FN-1", "code_references": [{"kind": "commit", "repository": "acme/api", "id": "a1b2c3", "date": "2024-05-03T10:00:00Z"}]}}`))
+	assert.Len(t, issues(t, Tasks, `{"FN-1": {"key": "FN-1", "code_references": [{"kind": "merge", "repository": "acme/api", "id": "a1b2c3"}]}}`), 1)
+	assert.Empty(t, issues(t, Tasks, `{"FN-1": {"key": "FN-1", "classification": {"workType": "cap-maintenance", "source": "classifier", "rationale": "Fixes a crash", "confidence": 0.9, "classifiedAt": "2024-05-03T10:00:00Z"}}}`))
+	assert.Len(t, issues(t, Tasks, `{"FN-1": {"key": "FN-1", "classification": {"workType": "cap-maintenance", "source": "llm"}}}`), 1)
+	assert.Empty(t, issues(t, Tasks, `{"FN-1": {"key": "FN-1", "original_estimate": 8, "remaining_estimate": 1.5}}`))
+}
+
+func TestValidate_SyntaxErrors(t *testing.T) {
+	assert.Equal(t, []string{
+		`line 3: invalid JSON: invalid character '}' looking for beginning of object key string; remove the trailing comma before it`,
+	}, issues(t, Teams, "{\n  \"FN\": {\"team\": []},\n}"))
+
+	assert.Equal(t, []string{
+		`line 2: invalid JSON: unexpected end of file; check for a missing closing } or ]`,
+	}, issues(t, Teams, "{\n  \"FN\": {\"team\": []}"))
+
+	assert.Equal(t, []string{
+		`line 1: invalid JSON: invalid character '\'' looking for beginning of object key string; use double quotes for strings and field names`,
+	}, issues(t, Teams, `{'FN': {}}`))
+
+	assert.Equal(t, []string{
+		`line 1: invalid JSON: invalid character '{' after top-level value`,
+	}, issues(t, Teams, `{} {}`))
+}
+
+func TestValidationError_Error(t *testing.T) {
+	err := &ValidationError{Issues: []Issue{
+		{Line: 2, Path: "FN", Message: `missing required field "team"`},
+		{Line: 5, Message: "invalid JSON"},
+	}}
+	assert.Equal(t, "2 problems found\n  line 2: FN: missing required field \"team\"\n  line 5: invalid JSON", err.Error())
+}
+
+func TestLoad_UnknownDocument(t *testing.T) {
+	_, err := Load("samples")
+	assert.ErrorContains(t, err, `unknown document "samples"`)
+
+	definition, err := Definition(Teams)
+	require.NoError(t, err)
+	assert.Contains(t, string(definition), `"title": "assetcap teams"`)
+}
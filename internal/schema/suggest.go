@@ -0,0 +1,72 @@
+package schema
+
+import (
+	"strings"
+	"time"
+)
+
+// formatExamples are example values of the supported string formats
+var formatExamples = map[string]string{
+	"date-time": "2024-01-31T00:00:00Z",
+	"date":      "2024-01-31",
+}
+
+// checkFormat reports whether a string matches a format, returning an example value
+func checkFormat(format, value string) (string, bool) {
+	var err error
+	switch format {
+	case "date-time":
+		_, err = time.Parse(time.RFC3339Nano, value)
+	case "date":
+		_, err = time.Parse("2006-01-02", value)
+	default:
+		return "", true
+	}
+	return formatExamples[format], err == nil
+}
+
+// closest returns the candidate that looks like a misspelling of value, or an empty string
+func closest(value string, candidates []string) string {
+	for _, candidate := range candidates {
+		if strings.EqualFold(candidate, value) {
+			return candidate
+		}
+	}
+
+	limit := len(value) / 3
+	if limit < 1 {
+		limit = 1
+	}
+	if limit > 3 {
+		limit = 3
+	}
+	best, bestDistance := "", limit+1
+	for _, candidate := range candidates {
+		if distance := editDistance(strings.ToLower(value), strings.ToLower(candidate)); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
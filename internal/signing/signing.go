@@ -0,0 +1,176 @@
+// Package signing writes a SHA-256 checksum file and an optional detached GPG or minisign
+// signature next to an exported file, and verifies them, so the recipients of an export can
+// prove the file was not altered after it was generated.
+package signing
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Signature methods
+const (
+	MethodGPG      = "gpg"
+	MethodMinisign = "minisign"
+)
+
+// Suffixes of the files written next to a signed file
+const (
+	ChecksumSuffix = ".sha256"
+	GPGSuffix      = ".asc"
+	MinisignSuffix = ".minisig"
+)
+
+// ErrChecksumMismatch is returned when a file no longer matches its checksum
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// Options selects how files are signed and verified
+type Options struct {
+	// Method is the signature method, gpg or minisign; empty writes the checksum only
+	Method string
+	// Key is the GPG key ID to sign with, or the minisign secret key file;
+	// empty signs with the default GPG key
+	Key string
+	// PublicKey is the minisign public key file signatures are verified with
+	PublicKey string
+}
+
+// Result describes what was written or verified for a file
+type Result struct {
+	// Checksum is the hex SHA-256 digest of the file
+	Checksum string
+	// Signature is the path of the signature file, empty when the file is not signed
+	Signature string
+}
+
+// run executes a signing tool, returning its standard error with the failure
+var run = func(ctx context.Context, name string, args ...string) error {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return fmt.Errorf("%s failed: %w: %s", name, err, message)
+		}
+		return fmt.Errorf("%s failed: %w", name, err)
+	}
+	return nil
+}
+
+// ValidateMethod checks that the signature method is supported
+func ValidateMethod(method string) error {
+	switch method {
+	case "", MethodGPG, MethodMinisign:
+		return nil
+	default:
+		return fmt.Errorf("unsupported signing method: %s", method)
+	}
+}
+
+// Sign writes the checksum file of path and, when a method is set, its detached signature
+func Sign(ctx context.Context, path string, opts Options) (Result, error) {
+	if err := ValidateMethod(opts.Method); err != nil {
+		return Result{}, err
+	}
+	checksum, err := fileChecksum(path)
+	if err != nil {
+		return Result{}, err
+	}
+	line := fmt.Sprintf("%s  %s\n", checksum, filepath.Base(path))
+	if err := os.WriteFile(path+ChecksumSuffix, []byte(line), 0644); err != nil {
+		return Result{}, fmt.Errorf("failed to write checksum file: %w", err)
+	}
+
+	result := Result{Checksum: checksum}
+	switch opts.Method {
+	case MethodGPG:
+		result.Signature = path + GPGSuffix
+		args := []string{"--batch", "--yes", "--armor", "--detach-sign"}
+		if opts.Key != "" {
+			args = append(args, "--local-user", opts.Key)
+		}
+		args = append(args, "--output", result.Signature, path)
+		if err := run(ctx, "gpg", args...); err != nil {
+			return Result{}, fmt.Errorf("failed to sign %s: %w", path, err)
+		}
+	case MethodMinisign:
+		if opts.Key == "" {
+			return Result{}, fmt.Errorf("minisign needs a secret key file")
+		}
+		result.Signature = path + MinisignSuffix
+		if err := run(ctx, "minisign", "-S", "-s", opts.Key, "-m", path, "-x", result.Signature); err != nil {
+			return Result{}, fmt.Errorf("failed to sign %s: %w", path, err)
+		}
+	}
+	return result, nil
+}
+
+// Verify checks path against its checksum file and against the signature found next to it.
+// A GPG signature is verified with the keyring, a minisign one with opts.PublicKey.
+func Verify(ctx context.Context, path string, opts Options) (Result, error) {
+	data, err := os.ReadFile(path + ChecksumSuffix)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to read checksum file: %w", err)
+	}
+	expected, name, ok := strings.Cut(strings.TrimSpace(string(data)), "  ")
+	if !ok || name != filepath.Base(path) {
+		return Result{}, fmt.Errorf("checksum file %s%s does not describe %s", path, ChecksumSuffix, filepath.Base(path))
+	}
+	checksum, err := fileChecksum(path)
+	if err != nil {
+		return Result{}, err
+	}
+	if checksum != expected {
+		return Result{}, fmt.Errorf("%w: %s has SHA-256 %s, expected %s", ErrChecksumMismatch, path, checksum, expected)
+	}
+
+	result := Result{Checksum: checksum}
+	switch {
+	case exists(path + GPGSuffix):
+		result.Signature = path + GPGSuffix
+		if err := run(ctx, "gpg", "--batch", "--verify", result.Signature, path); err != nil {
+			return Result{}, fmt.Errorf("invalid signature of %s: %w", path, err)
+		}
+	case exists(path + MinisignSuffix):
+		if opts.PublicKey == "" {
+			return Result{}, fmt.Errorf("minisign signature of %s needs a public key file to verify", path)
+		}
+		result.Signature = path + MinisignSuffix
+		if err := run(ctx, "minisign", "-V", "-p", opts.PublicKey, "-m", path, "-x", result.Signature); err != nil {
+			return Result{}, fmt.Errorf("invalid signature of %s: %w", path, err)
+		}
+	case opts.Method != "":
+		return Result{}, fmt.Errorf("%s has no %s signature", path, opts.Method)
+	}
+	return result, nil
+}
+
+// fileChecksum returns the hex SHA-256 digest of the file at path
+func fileChecksum(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// exists reports whether a file exists at path
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,157 @@
+package signing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubRun replaces the signing tools with a recorder that writes the signature file
+// named after --output or -x, failing when fail is set
+func stubRun(t *testing.T, fail error) *[]string {
+	t.Helper()
+	var calls []string
+	original := run
+	run = func(_ context.Context, name string, args ...string) error {
+		calls = append(calls, name+" "+strings.Join(args, " "))
+		if fail != nil {
+			return fail
+		}
+		signing := args[0] == "-S" || len(args) > 3 && args[3] == "--detach-sign"
+		for i, arg := range args {
+			if signing && (arg == "--output" || arg == "-x") && i+1 < len(args) {
+				return os.WriteFile(args[i+1], []byte("signature"), 0644)
+			}
+		}
+		return nil
+	}
+	t.Cleanup(func() { run = original })
+	return &calls
+}
+
+// checksumOfAB is the SHA-256 digest of "a,b\n"
+const checksumOfAB = "5be08c9684a1d25efcee09318204824278b08bbfb4aef973ffefd0b9d7478313"
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "allocation.csv")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestSign_ChecksumOnly(t *testing.T) {
+	calls := stubRun(t, nil)
+	path := writeFile(t, "a,b\n")
+
+	result, err := Sign(context.Background(), path, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, checksumOfAB, result.Checksum)
+	assert.Empty(t, result.Signature)
+	assert.Empty(t, *calls)
+
+	data, err := os.ReadFile(path + ChecksumSuffix)
+	require.NoError(t, err)
+	assert.Equal(t, result.Checksum+"  allocation.csv\n", string(data))
+
+	verified, err := Verify(context.Background(), path, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, result.Checksum, verified.Checksum)
+}
+
+func TestSign_GPG(t *testing.T) {
+	calls := stubRun(t, nil)
+	path := writeFile(t, "a,b\n")
+
+	result, err := Sign(context.Background(), path, Options{Method: MethodGPG, Key: "finance@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, path+GPGSuffix, result.Signature)
+	assert.FileExists(t, path+GPGSuffix)
+
+	_, err = Verify(context.Background(), path, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		fmt.Sprintf("gpg --batch --yes --armor --detach-sign --local-user finance@example.com --output %s.asc %s", path, path),
+		fmt.Sprintf("gpg --batch --verify %s.asc %s", path, path),
+	}, *calls)
+}
+
+func TestSign_Minisign(t *testing.T) {
+	calls := stubRun(t, nil)
+	path := writeFile(t, "a,b\n")
+
+	_, err := Sign(context.Background(), path, Options{Method: MethodMinisign})
+	assert.EqualError(t, err, "minisign needs a secret key file")
+
+	result, err := Sign(context.Background(), path, Options{Method: MethodMinisign, Key: "assetcap.key"})
+	require.NoError(t, err)
+	assert.Equal(t, path+MinisignSuffix, result.Signature)
+
+	_, err = Verify(context.Background(), path, Options{})
+	assert.EqualError(t, err, fmt.Sprintf("minisign signature of %s needs a public key file to verify", path))
+
+	_, err = Verify(context.Background(), path, Options{PublicKey: "assetcap.pub"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		fmt.Sprintf("minisign -S -s assetcap.key -m %s -x %s.minisig", path, path),
+		fmt.Sprintf("minisign -V -p assetcap.pub -m %s -x %s.minisig", path, path),
+	}, *calls)
+}
+
+func TestSign_UnsupportedMethod(t *testing.T) {
+	_, err := Sign(context.Background(), writeFile(t, "a,b\n"), Options{Method: "pgp"})
+	assert.EqualError(t, err, "unsupported signing method: pgp")
+}
+
+func TestVerify_Failures(t *testing.T) {
+	stubRun(t, nil)
+	ctx := context.Background()
+
+	t.Run("missing checksum file", func(t *testing.T) {
+		_, err := Verify(ctx, writeFile(t, "a,b\n"), Options{})
+		assert.ErrorContains(t, err, "failed to read checksum file")
+	})
+
+	t.Run("altered file", func(t *testing.T) {
+		path := writeFile(t, "a,b\n")
+		_, err := Sign(ctx, path, Options{})
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, []byte("a,c\n"), 0644))
+
+		_, err = Verify(ctx, path, Options{})
+		assert.ErrorIs(t, err, ErrChecksumMismatch)
+	})
+
+	t.Run("checksum of another file", func(t *testing.T) {
+		path := writeFile(t, "a,b\n")
+		require.NoError(t, os.WriteFile(path+ChecksumSuffix, []byte("abc  other.csv\n"), 0644))
+
+		_, err := Verify(ctx, path, Options{})
+		assert.ErrorContains(t, err, "does not describe allocation.csv")
+	})
+
+	t.Run("expected signature is missing", func(t *testing.T) {
+		path := writeFile(t, "a,b\n")
+		_, err := Sign(ctx, path, Options{})
+		require.NoError(t, err)
+
+		_, err = Verify(ctx, path, Options{Method: MethodGPG})
+		assert.EqualError(t, err, fmt.Sprintf("%s has no gpg signature", path))
+	})
+}
+
+func TestVerify_InvalidSignature(t *testing.T) {
+	path := writeFile(t, "a,b\n")
+	stubRun(t, nil)
+	_, err := Sign(context.Background(), path, Options{Method: MethodGPG})
+	require.NoError(t, err)
+
+	stubRun(t, fmt.Errorf("gpg failed: exit status 1: BAD signature"))
+	_, err = Verify(context.Background(), path, Options{})
+	assert.EqualError(t, err, fmt.Sprintf("invalid signature of %s: gpg failed: exit status 1: BAD signature", path))
+}
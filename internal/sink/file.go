@@ -0,0 +1,41 @@
+package sink
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// File writes output to a local file, creating its directory when needed
+type File struct {
+	path string
+}
+
+// NewFile creates a sink writing to the file at path
+func NewFile(path string) *File {
+	return &File{path: path}
+}
+
+// Write replaces the file with the output
+func (f *File) Write(_ context.Context, data []byte, _ string) error {
+	if dir := filepath.Dir(f.path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(f.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	return nil
+}
+
+// Path returns the path of the file
+func (f *File) Path() string {
+	return f.path
+}
+
+// String describes the destination
+func (f *File) String() string {
+	return f.path
+}
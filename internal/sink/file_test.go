@@ -0,0 +1,23 @@
+package sink
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile_Write(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "exports", "allocation.csv")
+
+	require.NoError(t, NewFile(path).Write(context.Background(), []byte("first"), ContentTypeCSV))
+	require.NoError(t, NewFile(path).Write(context.Background(), []byte("a,b\n"), ContentTypeCSV))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "a,b\n", string(data))
+	assert.Equal(t, path, NewFile(path).Path())
+}
@@ -0,0 +1,358 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// sheetsAPIURL is the Google Sheets API endpoint of the spreadsheets
+	sheetsAPIURL = "https://sheets.googleapis.com/v4/spreadsheets"
+	// sheetsScope lets the service account read and edit the spreadsheets shared with it
+	sheetsScope = "https://www.googleapis.com/auth/spreadsheets"
+	// googleTokenURL exchanges a signed service account assertion for an access token, unless
+	// the key names another token URI
+	googleTokenURL = "https://oauth2.googleapis.com/token"
+)
+
+// serviceAccountKey holds the fields of a Google service account JSON key used to sign in
+type serviceAccountKey struct {
+	ClientEmail  string `json:"client_email"`
+	PrivateKey   string `json:"private_key"`
+	PrivateKeyID string `json:"private_key_id"`
+	TokenURI     string `json:"token_uri"`
+}
+
+// GSheet writes CSV output to a tab of a Google spreadsheet as the service account whose JSON
+// key GOOGLE_APPLICATION_CREDENTIALS names. The spreadsheet must be shared with the service
+// account's email address.
+type GSheet struct {
+	spreadsheetID string
+	tab           string
+	delimiter     rune
+	appendRows    bool
+	// metadata is attached to the tab as developer metadata, by key
+	metadata   map[string]string
+	apiURL     string
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewGSheet creates a sink writing CSV separated by delimiter to a tab of a spreadsheet,
+// replacing the tab's rows or, with appendRows, adding them after the existing ones
+func NewGSheet(spreadsheetID, tab string, delimiter rune, appendRows bool) *GSheet {
+	return &GSheet{
+		spreadsheetID: spreadsheetID,
+		tab:           tab,
+		delimiter:     delimiter,
+		appendRows:    appendRows,
+		apiURL:        sheetsAPIURL,
+		httpClient:    &http.Client{Timeout: 60 * time.Second},
+		now:           time.Now,
+	}
+}
+
+// SetMetadata attaches a value to the tab as developer metadata on the next write, replacing
+// the value the key held. The metadata is kept out of the cells, so it shifts no rows.
+func (g *GSheet) SetMetadata(key, value string) {
+	if g.metadata == nil {
+		g.metadata = make(map[string]string)
+	}
+	g.metadata[key] = value
+}
+
+// Write writes the rows of the CSV output to the tab, creating the tab when the spreadsheet
+// has none of that name, then its metadata. The blocks of the output stay apart by an empty row.
+func (g *GSheet) Write(ctx context.Context, data []byte, contentType string) error {
+	if contentType != ContentTypeCSV {
+		return fmt.Errorf("%s only takes CSV output, not %s", g, contentType)
+	}
+	rows, err := sheetRows(data, g.delimiter)
+	if err != nil {
+		return fmt.Errorf("failed to read the CSV output: %w", err)
+	}
+	token, err := g.accessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	sheetID, err := g.sheetID(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	values := map[string]interface{}{"values": rows}
+	tab := url.PathEscape(sheetRange(g.tab))
+	if g.appendRows {
+		err = g.call(ctx, token, http.MethodPost, "/values/"+tab+":append?valueInputOption=RAW&insertDataOption=INSERT_ROWS", values, nil)
+	} else if err = g.call(ctx, token, http.MethodPost, "/values/"+tab+":clear", map[string]string{}, nil); err != nil {
+		return fmt.Errorf("failed to clear tab %q: %w", g.tab, err)
+	} else {
+		err = g.call(ctx, token, http.MethodPut, "/values/"+tab+"?valueInputOption=RAW", values, nil)
+	}
+	if err != nil {
+		return err
+	}
+	return g.writeMetadata(ctx, token, sheetID)
+}
+
+// sheetID returns the ID of the tab, adding the tab when the spreadsheet has none of its name
+func (g *GSheet) sheetID(ctx context.Context, token string) (int64, error) {
+	type properties struct {
+		SheetID int64  `json:"sheetId"`
+		Title   string `json:"title"`
+	}
+	var spreadsheet struct {
+		Sheets []struct {
+			Properties properties `json:"properties"`
+		} `json:"sheets"`
+	}
+	if err := g.call(ctx, token, http.MethodGet, "?fields=sheets.properties(sheetId,title)", nil, &spreadsheet); err != nil {
+		return 0, fmt.Errorf("failed to read spreadsheet %s: %w", g.spreadsheetID, err)
+	}
+	for _, sheet := range spreadsheet.Sheets {
+		if sheet.Properties.Title == g.tab {
+			return sheet.Properties.SheetID, nil
+		}
+	}
+
+	addSheet := map[string]interface{}{"requests": []interface{}{
+		map[string]interface{}{"addSheet": map[string]interface{}{"properties": map[string]string{"title": g.tab}}},
+	}}
+	var added struct {
+		Replies []struct {
+			AddSheet struct {
+				Properties properties `json:"properties"`
+			} `json:"addSheet"`
+		} `json:"replies"`
+	}
+	if err := g.call(ctx, token, http.MethodPost, ":batchUpdate", addSheet, &added); err != nil {
+		return 0, fmt.Errorf("failed to add tab %q: %w", g.tab, err)
+	}
+	if len(added.Replies) == 0 {
+		return 0, fmt.Errorf("failed to add tab %q: no tab in the response", g.tab)
+	}
+	return added.Replies[0].AddSheet.Properties.SheetID, nil
+}
+
+// writeMetadata replaces the developer metadata of the tab with the values set on the sink
+func (g *GSheet) writeMetadata(ctx context.Context, token string, sheetID int64) error {
+	if len(g.metadata) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(g.metadata))
+	for key := range g.metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	location := map[string]interface{}{"sheetId": sheetID}
+	var requests []interface{}
+	for _, key := range keys {
+		requests = append(requests,
+			map[string]interface{}{"deleteDeveloperMetadata": map[string]interface{}{
+				"dataFilter": map[string]interface{}{"developerMetadataLookup": map[string]interface{}{
+					"metadataKey":      key,
+					"metadataLocation": location,
+				}},
+			}},
+			map[string]interface{}{"createDeveloperMetadata": map[string]interface{}{
+				"developerMetadata": map[string]interface{}{
+					"metadataKey":   key,
+					"metadataValue": g.metadata[key],
+					"location":      location,
+					"visibility":    "DOCUMENT",
+				},
+			}},
+		)
+	}
+	if err := g.call(ctx, token, http.MethodPost, ":batchUpdate", map[string]interface{}{"requests": requests}, nil); err != nil {
+		return fmt.Errorf("failed to write the metadata of tab %q: %w", g.tab, err)
+	}
+	return nil
+}
+
+// String describes the destination
+func (g *GSheet) String() string {
+	return fmt.Sprintf("Google spreadsheet %s, tab %q", g.spreadsheetID, g.tab)
+}
+
+// call sends a Sheets API request on the spreadsheet with the JSON body, if any, decoding
+// the JSON response into out, if any
+func (g *GSheet) call(ctx context.Context, token, method, path string, body, out interface{}) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, method, g.apiURL+"/"+url.PathEscape(g.spreadsheetID)+path, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if body != nil {
+		req.Header.Set("Content-Type", ContentTypeJSON)
+	}
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// accessToken signs in as the service account of GOOGLE_APPLICATION_CREDENTIALS with a signed
+// JWT assertion, returning the access token
+func (g *GSheet) accessToken(ctx context.Context) (string, error) {
+	path := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
+	if path == "" {
+		return "", fmt.Errorf("GOOGLE_APPLICATION_CREDENTIALS must name a service account key to write to %s", g)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read service account key: %w", err)
+	}
+	var key serviceAccountKey
+	if err := json.Unmarshal(data, &key); err != nil {
+		return "", fmt.Errorf("failed to parse service account key %s: %w", path, err)
+	}
+	if key.TokenURI == "" {
+		key.TokenURI = googleTokenURL
+	}
+	assertion, err := key.assertion(g.now())
+	if err != nil {
+		return "", fmt.Errorf("service account key %s: %w", path, err)
+	}
+
+	form := url.Values{"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"}, "assertion": {assertion}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, key.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign in as %s: %w", key.ClientEmail, err)
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return "", fmt.Errorf("failed to sign in as %s: %w", key.ClientEmail, err)
+	}
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil || token.AccessToken == "" {
+		return "", fmt.Errorf("failed to sign in as %s: no access token in the response", key.ClientEmail)
+	}
+	return token.AccessToken, nil
+}
+
+// assertion returns the JWT asking for access to the spreadsheets for an hour, signed with
+// the key's private key
+func (k serviceAccountKey) assertion(now time.Time) (string, error) {
+	block, _ := pem.Decode([]byte(k.PrivateKey))
+	if block == nil {
+		return "", fmt.Errorf("no PEM private key")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return "", fmt.Errorf("invalid private key: %w", err)
+	}
+	private, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return "", fmt.Errorf("private key is not an RSA key")
+	}
+
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": k.PrivateKeyID})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss":   k.ClientEmail,
+		"scope": sheetsScope,
+		"aud":   k.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, private, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign assertion: %w", err)
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// sheetRange returns the A1 notation of a whole tab, quoting its name
+func sheetRange(tab string) string {
+	return "'" + strings.ReplaceAll(tab, "'", "''") + "'"
+}
+
+// sheetRows reads the blocks of CSV output, separated by blank lines, into spreadsheet rows
+// with an empty row between blocks. Numbers become numeric cells.
+func sheetRows(data []byte, delimiter rune) ([][]interface{}, error) {
+	rows := [][]interface{}{}
+	for i, block := range strings.Split(strings.TrimRight(string(data), "\n"), "\n\n") {
+		if i > 0 {
+			rows = append(rows, []interface{}{})
+		}
+		reader := csv.NewReader(strings.NewReader(block))
+		reader.Comma = delimiter
+		reader.FieldsPerRecord = -1
+		records, err := reader.ReadAll()
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			row := make([]interface{}, len(record))
+			for j, cell := range record {
+				row[j] = sheetValue(cell)
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+// sheetValue returns a cell holding a plain decimal number as a number, and any other as is
+func sheetValue(cell string) interface{} {
+	if cell == "" || strings.Trim(cell, "0123456789.-") != "" {
+		return cell
+	}
+	if number, err := strconv.ParseFloat(cell, 64); err == nil {
+		return number
+	}
+	return cell
+}
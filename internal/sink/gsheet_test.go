@@ -0,0 +1,173 @@
+package sink
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sheetsServer fakes the token endpoint and the Sheets API of a spreadsheet with one tab,
+// recording the requests and the values written
+type sheetsServer struct {
+	*httptest.Server
+	requests []string
+	values   [][]interface{}
+	// batches are the requests of each batch update
+	batches [][]map[string]interface{}
+}
+
+func newSheetsServer(t *testing.T) *sheetsServer {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	s := &sheetsServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+		if r.URL.Path == "/token" {
+			require.NoError(t, r.ParseForm())
+			assert.Equal(t, "urn:ietf:params:oauth:grant-type:jwt-bearer", r.PostForm.Get("grant_type"))
+			parts := strings.Split(r.PostForm.Get("assertion"), ".")
+			require.Len(t, parts, 3)
+			signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+			require.NoError(t, err)
+			digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+			assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature))
+			claims, err := base64.RawURLEncoding.DecodeString(parts[1])
+			require.NoError(t, err)
+			assert.Contains(t, string(claims), `"iss":"allocations@finance.iam.gserviceaccount.com"`)
+			assert.Contains(t, string(claims), `"scope":"https://www.googleapis.com/auth/spreadsheets"`)
+			_, _ = w.Write([]byte(`{"access_token": "ya29.sheets", "expires_in": 3600}`))
+			return
+		}
+		assert.Equal(t, "Bearer ya29.sheets", r.Header.Get("Authorization"))
+		switch {
+		case r.Method == http.MethodGet:
+			assert.Equal(t, "sheets.properties(sheetId,title)", r.URL.Query().Get("fields"))
+			_, _ = w.Write([]byte(`{"sheets": [{"properties": {"sheetId": 5, "title": "Sprint 5"}}]}`))
+		case strings.HasSuffix(r.URL.Path, ":batchUpdate"):
+			var body struct {
+				Requests []map[string]interface{} `json:"requests"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			s.batches = append(s.batches, body.Requests)
+			_, _ = w.Write([]byte(`{"replies": [{"addSheet": {"properties": {"sheetId": 6, "title": "Sprint 6"}}}]}`))
+		case r.Method == http.MethodPut || strings.HasSuffix(r.URL.Path, ":append"):
+			var body struct {
+				Values [][]interface{} `json:"values"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			s.values = body.Values
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			_, _ = io.Copy(io.Discard, r.Body)
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(s.Close)
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	credentials, err := json.Marshal(serviceAccountKey{
+		ClientEmail:  "allocations@finance.iam.gserviceaccount.com",
+		PrivateKey:   string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		PrivateKeyID: "key-1",
+		TokenURI:     s.URL + "/token",
+	})
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "service-account.json")
+	require.NoError(t, os.WriteFile(path, credentials, 0o600))
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", path)
+	return s
+}
+
+func TestGSheet_Write(t *testing.T) {
+	server := newSheetsServer(t)
+	sheet := NewGSheet("sheet-1", "Sprint 6", ';', false)
+	sheet.apiURL = server.URL
+	sheet.SetMetadata("assetcap.dataSnapshot", "2024-06-30T18:00:00Z")
+
+	output := "issueKey;assignee;hours\nFN-1;alice;12.50\n\nwarning;issueKey;hours\nteam member bob has no attributed hours;;0.00\n"
+	require.NoError(t, sheet.Write(context.Background(), []byte(output), ContentTypeCSV))
+
+	assert.Equal(t, []string{
+		"POST /token",
+		"GET /sheet-1",
+		"POST /sheet-1:batchUpdate",
+		"POST /sheet-1/values/'Sprint 6':clear",
+		"PUT /sheet-1/values/'Sprint 6'",
+		"POST /sheet-1:batchUpdate",
+	}, server.requests)
+	assert.Equal(t, [][]interface{}{
+		{"issueKey", "assignee", "hours"},
+		{"FN-1", "alice", 12.5},
+		{},
+		{"warning", "issueKey", "hours"},
+		{"team member bob has no attributed hours", "", 0.0},
+	}, server.values)
+	assert.Equal(t, `Google spreadsheet sheet-1, tab "Sprint 6"`, sheet.String())
+
+	require.Len(t, server.batches, 2)
+	metadata := server.batches[1]
+	require.Len(t, metadata, 2, "the key's previous value is deleted and the new one created")
+	assert.Contains(t, metadata[0], "deleteDeveloperMetadata")
+	created := metadata[1]["createDeveloperMetadata"].(map[string]interface{})["developerMetadata"].(map[string]interface{})
+	assert.Equal(t, "assetcap.dataSnapshot", created["metadataKey"])
+	assert.Equal(t, "2024-06-30T18:00:00Z", created["metadataValue"])
+	assert.Equal(t, map[string]interface{}{"sheetId": 6.0}, created["location"], "the metadata sits on the added tab")
+}
+
+func TestGSheet_WriteAppend(t *testing.T) {
+	server := newSheetsServer(t)
+	sheet := NewGSheet("sheet-1", "Sprint 5", ',', true)
+	sheet.apiURL = server.URL
+
+	require.NoError(t, sheet.Write(context.Background(), []byte("issueKey,hours\nFN-2,3\n"), ContentTypeCSV))
+
+	assert.Equal(t, []string{
+		"POST /token",
+		"GET /sheet-1",
+		"POST /sheet-1/values/'Sprint 5':append",
+	}, server.requests, "the existing tab is appended to, with no metadata set")
+	assert.Equal(t, [][]interface{}{{"issueKey", "hours"}, {"FN-2", 3.0}}, server.values)
+}
+
+func TestGSheet_WriteErrors(t *testing.T) {
+	sheet := NewGSheet("sheet-1", "Sprint 6", ',', false)
+
+	err := sheet.Write(context.Background(), []byte(`{}`), ContentTypeJSON)
+	assert.EqualError(t, err, `Google spreadsheet sheet-1, tab "Sprint 6" only takes CSV output, not application/json`)
+
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "")
+	err = sheet.Write(context.Background(), []byte("a,b\n"), ContentTypeCSV)
+	assert.ErrorContains(t, err, "GOOGLE_APPLICATION_CREDENTIALS must name a service account key")
+
+	path := filepath.Join(t.TempDir(), "service-account.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"client_email": "allocations@finance.iam.gserviceaccount.com", "private_key": "not a key"}`), 0o600))
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", path)
+	err = sheet.Write(context.Background(), []byte("a,b\n"), ContentTypeCSV)
+	assert.ErrorContains(t, err, "no PEM private key")
+}
+
+func TestSheetValue(t *testing.T) {
+	assert.Equal(t, 12.5, sheetValue("12.50"))
+	assert.Equal(t, -3.0, sheetValue("-3"))
+	assert.Equal(t, "FN-12", sheetValue("FN-12"))
+	assert.Equal(t, "2024-03-18", sheetValue("2024-03-18"))
+	assert.Equal(t, "12,50", sheetValue("12,50"))
+	assert.Equal(t, "", sheetValue(""))
+}
@@ -0,0 +1,219 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultS3Region is used when neither the options nor the environment set a region
+	defaultS3Region = "us-east-1"
+	// gcsUploadURL is the Cloud Storage JSON API endpoint for simple uploads
+	gcsUploadURL = "https://storage.googleapis.com/upload/storage/v1/b/%s/o?uploadType=media&name=%s"
+)
+
+// S3 uploads output to an S3 or S3-compatible bucket, signing requests with the
+// credentials in AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN
+type S3 struct {
+	bucket     string
+	key        string
+	endpoint   string
+	region     string
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewS3 creates a sink uploading to key in bucket. An empty endpoint uses AWS and an
+// empty region falls back to AWS_REGION, AWS_DEFAULT_REGION and then us-east-1.
+func NewS3(bucket, key, endpoint, region string) *S3 {
+	if region == "" {
+		region = os.Getenv("AWS_REGION")
+	}
+	if region == "" {
+		region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	if region == "" {
+		region = defaultS3Region
+	}
+	return &S3{
+		bucket:     bucket,
+		key:        key,
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		region:     region,
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+		now:        time.Now,
+	}
+}
+
+// Write uploads the output as the object
+func (s *S3) Write(ctx context.Context, data []byte, contentType string) error {
+	accessKey, secretKey := os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY")
+	if accessKey == "" || secretKey == "" {
+		return fmt.Errorf("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set to write to %s", s)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(), bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create upload request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	if token := os.Getenv("AWS_SESSION_TOKEN"); token != "" {
+		req.Header.Set("X-Amz-Security-Token", token)
+	}
+	signV4(req, data, accessKey, secretKey, s.region, s.now())
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload to %s: %w", s, err)
+	}
+	defer resp.Body.Close()
+	return checkResponse(resp)
+}
+
+// String describes the destination
+func (s *S3) String() string {
+	return fmt.Sprintf("s3://%s/%s", s.bucket, s.key)
+}
+
+// objectURL addresses the object path-style on custom endpoints and virtual-hosted
+// style on AWS
+func (s *S3) objectURL() string {
+	if s.endpoint != "" {
+		return fmt.Sprintf("%s/%s/%s", s.endpoint, s.bucket, escapePath(s.key))
+	}
+	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", s.bucket, s.region, escapePath(s.key))
+}
+
+// signV4 signs an S3 request with AWS Signature Version 4, covering the host and every
+// header already set on the request
+func signV4(req *http.Request, payload []byte, accessKey, secretKey, region string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+	payloadHash := sha256Hex(payload)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := day + "/" + region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), day)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKey, scope, signedHeaders, signature))
+}
+
+// GCS uploads output to a Cloud Storage bucket with the OAuth access token in
+// GOOGLE_OAUTH_ACCESS_TOKEN, e.g. from `gcloud auth print-access-token`
+type GCS struct {
+	bucket     string
+	object     string
+	uploadURL  string
+	httpClient *http.Client
+}
+
+// NewGCS creates a sink uploading to object in bucket
+func NewGCS(bucket, object string) *GCS {
+	return &GCS{
+		bucket:     bucket,
+		object:     object,
+		uploadURL:  fmt.Sprintf(gcsUploadURL, url.PathEscape(bucket), url.QueryEscape(object)),
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// Write uploads the output as the object
+func (g *GCS) Write(ctx context.Context, data []byte, contentType string) error {
+	token := os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN")
+	if token == "" {
+		return fmt.Errorf("GOOGLE_OAUTH_ACCESS_TOKEN must be set to write to %s", g)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.uploadURL, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create upload request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload to %s: %w", g, err)
+	}
+	defer resp.Body.Close()
+	return checkResponse(resp)
+}
+
+// String describes the destination
+func (g *GCS) String() string {
+	return fmt.Sprintf("gs://%s/%s", g.bucket, g.object)
+}
+
+// escapePath URI-encodes an object key the way Signature Version 4 expects, leaving only
+// unreserved characters and slashes as they are
+func escapePath(key string) string {
+	var b strings.Builder
+	for _, c := range []byte(key) {
+		switch {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', c == '/':
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// sha256Hex returns the hex encoded SHA-256 digest of data
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of data with key
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,100 @@
+package sink
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Content types of the outputs written to sinks
+const (
+	ContentTypeCSV      = "text/csv"
+	ContentTypeMarkdown = "text/markdown"
+	ContentTypeHTML     = "text/html"
+	ContentTypeText     = "text/plain"
+	ContentTypeJSON     = "application/json"
+)
+
+// Sink is a destination for command output
+type Sink interface {
+	// Write delivers the output with its content type
+	Write(ctx context.Context, data []byte, contentType string) error
+	// String describes the destination without credentials
+	String() string
+}
+
+// Options configures the remote sinks
+type Options struct {
+	// WebhookHeaders are sent with every webhook request, e.g. an Authorization header
+	WebhookHeaders map[string]string
+	// S3Endpoint overrides the S3 endpoint, e.g. for MinIO; empty uses AWS
+	S3Endpoint string
+	// S3Region overrides the region read from AWS_REGION
+	S3Region string
+}
+
+// Open returns the sink for a destination: empty or "-" for stdout, an http(s) URL for a
+// webhook, s3://bucket/key or gs://bucket/object for object storage, and a path or
+// file:// URL for a local file
+func Open(destination string, opts Options) (Sink, error) {
+	if destination == "" || destination == "-" {
+		return Stdout{}, nil
+	}
+	if !strings.Contains(destination, "://") {
+		return NewFile(destination), nil
+	}
+
+	u, err := url.Parse(destination)
+	if err != nil {
+		return nil, fmt.Errorf("invalid output destination %q: %w", destination, err)
+	}
+
+	switch u.Scheme {
+	case "file":
+		if u.Path == "" {
+			return nil, fmt.Errorf("output destination %q has no path", destination)
+		}
+		return NewFile(u.Path), nil
+	case "http", "https":
+		return NewWebhook(destination, opts.WebhookHeaders), nil
+	case "s3":
+		bucket, key, err := bucketAndKey(u)
+		if err != nil {
+			return nil, err
+		}
+		return NewS3(bucket, key, opts.S3Endpoint, opts.S3Region), nil
+	case "gs":
+		bucket, key, err := bucketAndKey(u)
+		if err != nil {
+			return nil, err
+		}
+		return NewGCS(bucket, key), nil
+	default:
+		return nil, fmt.Errorf("unsupported output destination %q: use a path, file://, http(s)://, s3:// or gs://", destination)
+	}
+}
+
+// bucketAndKey splits an object storage URL into its bucket and object key
+func bucketAndKey(u *url.URL) (string, string, error) {
+	key := strings.TrimPrefix(u.Path, "/")
+	if u.Host == "" || key == "" {
+		return "", "", fmt.Errorf("output destination %s must name a bucket and an object, e.g. %s://bucket/report.csv", u.Redacted(), u.Scheme)
+	}
+	return u.Host, key, nil
+}
+
+// Stdout writes output to the standard output
+type Stdout struct{}
+
+// Write prints the output
+func (Stdout) Write(_ context.Context, data []byte, _ string) error {
+	_, err := os.Stdout.Write(data)
+	return err
+}
+
+// String describes the destination
+func (Stdout) String() string {
+	return "stdout"
+}
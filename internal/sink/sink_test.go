@@ -0,0 +1,44 @@
+package sink
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpen(t *testing.T) {
+	tests := []struct {
+		destination string
+		want        string
+		wantType    Sink
+	}{
+		{destination: "", want: "stdout", wantType: Stdout{}},
+		{destination: "-", want: "stdout", wantType: Stdout{}},
+		{destination: "out/allocation.csv", want: "out/allocation.csv", wantType: &File{}},
+		{destination: "file:///tmp/allocation.csv", want: "/tmp/allocation.csv", wantType: &File{}},
+		{destination: "https://hooks.example.com/assetcap?token=secret", want: "https://hooks.example.com/assetcap", wantType: &Webhook{}},
+		{destination: "s3://reports/q2/allocation.csv", want: "s3://reports/q2/allocation.csv", wantType: &S3{}},
+		{destination: "gs://reports/q2/allocation.csv", want: "gs://reports/q2/allocation.csv", wantType: &GCS{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.destination, func(t *testing.T) {
+			s, err := Open(tt.destination, Options{})
+			require.NoError(t, err)
+			assert.IsType(t, tt.wantType, s)
+			assert.Equal(t, tt.want, s.String())
+		})
+	}
+}
+
+func TestOpen_Invalid(t *testing.T) {
+	_, err := Open("ftp://example.com/report.csv", Options{})
+	assert.EqualError(t, err, `unsupported output destination "ftp://example.com/report.csv": use a path, file://, http(s)://, s3:// or gs://`)
+
+	_, err = Open("s3://reports", Options{})
+	assert.EqualError(t, err, "output destination s3://reports must name a bucket and an object, e.g. s3://bucket/report.csv")
+
+	_, err = Open("file://", Options{})
+	assert.EqualError(t, err, `output destination "file://" has no path`)
+}
@@ -0,0 +1,68 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// maxErrorBody limits how much of an error response is included in errors
+const maxErrorBody = 1 << 10
+
+// Webhook posts output to an HTTP endpoint
+type Webhook struct {
+	url        string
+	headers    map[string]string
+	httpClient *http.Client
+}
+
+// NewWebhook creates a sink posting to url with the given extra headers
+func NewWebhook(url string, headers map[string]string) *Webhook {
+	return &Webhook{
+		url:        url,
+		headers:    headers,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Write posts the output as the request body
+func (w *Webhook) Write(ctx context.Context, data []byte, contentType string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	for name, value := range w.headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	return checkResponse(resp)
+}
+
+// String describes the destination without its query, which may hold a token
+func (w *Webhook) String() string {
+	u, err := url.Parse(w.url)
+	if err != nil {
+		return "webhook"
+	}
+	u.RawQuery = ""
+	return u.Redacted()
+}
+
+// checkResponse returns an error for a response outside the 2xx range
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	return fmt.Errorf("unexpected status %s: %s", resp.Status, bytes.TrimSpace(body))
+}
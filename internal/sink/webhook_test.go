@@ -0,0 +1,37 @@
+package sink
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook_Write(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "text/csv", r.Header.Get("Content-Type"))
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "a,b\n", string(body))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	webhook := NewWebhook(server.URL+"/hook", map[string]string{"Authorization": "Bearer secret"})
+	require.NoError(t, webhook.Write(context.Background(), []byte("a,b\n"), ContentTypeCSV))
+}
+
+func TestWebhook_WriteError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "pipeline paused", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	err := NewWebhook(server.URL, nil).Write(context.Background(), []byte("a,b\n"), ContentTypeCSV)
+	assert.EqualError(t, err, "unexpected status 503 Service Unavailable: pipeline paused")
+}
@@ -0,0 +1,187 @@
+package slack
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// signatureHeader carries the HMAC signature Slack computes with the app's signing secret
+	signatureHeader = "X-Slack-Signature"
+	// timestampHeader carries the request timestamp included in the signature
+	timestampHeader    = "X-Slack-Request-Timestamp"
+	signatureVersion   = "v0"
+	maxRequestAge      = 5 * time.Minute
+	maxCommandBodySize = 1 << 20
+	// responseEphemeral shows the answer only to the user who ran the command
+	responseEphemeral = "ephemeral"
+)
+
+// QueryFunc answers a slash command query with text formatted for Slack
+type QueryFunc func(ctx context.Context, args []string) (string, error)
+
+// Query is a slash command subcommand, such as "asset" in "/assetcap asset booking"
+type Query struct {
+	// Usage describes the query arguments in the help text
+	Usage string
+	Run   QueryFunc
+}
+
+// Response is the message returned to Slack for a slash command
+type Response struct {
+	ResponseType string `json:"response_type"`
+	Text         string `json:"text"`
+}
+
+// CommandHandler answers Slack slash commands from the registered queries
+type CommandHandler struct {
+	secret  string
+	queries map[string]Query
+	now     func() time.Time
+}
+
+// NewCommandHandler creates a new slash command handler. An empty secret disables
+// request signature verification.
+func NewCommandHandler(secret string, queries map[string]Query) *CommandHandler {
+	return &CommandHandler{
+		secret:  secret,
+		queries: queries,
+		now:     time.Now,
+	}
+}
+
+// ServeHTTP implements http.Handler
+func (h *CommandHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxCommandBodySize))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	if h.secret != "" && !h.validSignature(body, r.Header.Get(timestampHeader), r.Header.Get(signatureHeader)) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	text := h.answer(r.Context(), form.Get("command"), SplitArgs(form.Get("text")))
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(Response{ResponseType: responseEphemeral, Text: text}); err != nil {
+		log.Printf("failed to write slash command response: %v", err)
+	}
+}
+
+// answer runs the query named by the first argument, or returns the help text
+func (h *CommandHandler) answer(ctx context.Context, command string, args []string) string {
+	if len(args) == 0 || strings.EqualFold(args[0], "help") {
+		return h.help(command)
+	}
+
+	query, ok := h.queries[strings.ToLower(args[0])]
+	if !ok {
+		return fmt.Sprintf("Unknown query %q.\n%s", args[0], h.help(command))
+	}
+
+	text, err := query.Run(ctx, args[1:])
+	if err != nil {
+		// Slack only displays successful responses, so errors are reported as text
+		return fmt.Sprintf(":warning: %v", err)
+	}
+	return text
+}
+
+// help lists the available queries
+func (h *CommandHandler) help(command string) string {
+	if command == "" {
+		command = "/assetcap"
+	}
+
+	names := make([]string, 0, len(h.queries))
+	for name := range h.queries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString("Available queries:")
+	for _, name := range names {
+		fmt.Fprintf(&b, "\n• `%s %s`", command, h.queries[name].Usage)
+	}
+	return b.String()
+}
+
+// validSignature checks the request signature and rejects requests older than maxRequestAge
+func (h *CommandHandler) validSignature(body []byte, timestamp, signature string) bool {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	age := h.now().Sub(time.Unix(seconds, 0))
+	if age > maxRequestAge || age < -maxRequestAge {
+		return false
+	}
+
+	expected := Sign(h.secret, timestamp, body)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// Sign computes the Slack request signature for a body sent at the given timestamp
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signatureVersion + ":" + timestamp + ":"))
+	mac.Write(body)
+	return signatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// SplitArgs splits slash command text into arguments, keeping quoted phrases such as
+// "Sprint 42" together. Slack's typographic quotes are treated as plain quotes.
+func SplitArgs(text string) []string {
+	text = strings.NewReplacer("“", `"`, "”", `"`).Replace(text)
+
+	var args []string
+	var current strings.Builder
+	quoted, pending := false, false
+	for _, r := range text {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			pending = true
+		case !quoted && (r == ' ' || r == '\t' || r == '\n'):
+			if pending {
+				args = append(args, current.String())
+				current.Reset()
+				pending = false
+			}
+		default:
+			current.WriteRune(r)
+			pending = true
+		}
+	}
+	if pending {
+		args = append(args, current.String())
+	}
+	return args
+}
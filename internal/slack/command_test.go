@@ -0,0 +1,155 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testQueries(received *[]string) map[string]Query {
+	return map[string]Query{
+		"asset": {
+			Usage: "asset <name>",
+			Run: func(_ context.Context, args []string) (string, error) {
+				*received = args
+				return "*Booking*", nil
+			},
+		},
+		"sprint": {
+			Usage: `sprint <project> "<sprint>"`,
+			Run: func(_ context.Context, _ []string) (string, error) {
+				return "", errors.New("no tasks stored for FN Sprint 42")
+			},
+		},
+	}
+}
+
+func commandRequest(t *testing.T, text string) (*http.Request, []byte) {
+	t.Helper()
+	body := []byte(url.Values{"command": {"/assetcap"}, "text": {text}}.Encode())
+	req := httptest.NewRequest(http.MethodPost, "/slack/commands", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req, body
+}
+
+func decodeResponse(t *testing.T, rec *httptest.ResponseRecorder) Response {
+	t.Helper()
+	require.Equal(t, http.StatusOK, rec.Code)
+	var response Response
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "ephemeral", response.ResponseType)
+	return response
+}
+
+func TestCommandHandler_RunsQuery(t *testing.T) {
+	var received []string
+	handler := NewCommandHandler("", testQueries(&received))
+
+	req, _ := commandRequest(t, `asset booking engine`)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	response := decodeResponse(t, rec)
+	assert.Equal(t, "*Booking*", response.Text)
+	assert.Equal(t, []string{"booking", "engine"}, received)
+}
+
+func TestCommandHandler_ReportsQueryErrors(t *testing.T) {
+	var received []string
+	handler := NewCommandHandler("", testQueries(&received))
+
+	req, _ := commandRequest(t, `sprint FN "Sprint 42"`)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	response := decodeResponse(t, rec)
+	assert.Equal(t, ":warning: no tasks stored for FN Sprint 42", response.Text)
+}
+
+func TestCommandHandler_Help(t *testing.T) {
+	var received []string
+	handler := NewCommandHandler("", testQueries(&received))
+
+	for _, text := range []string{"", "help", "velocity"} {
+		req, _ := commandRequest(t, text)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		response := decodeResponse(t, rec)
+		assert.Contains(t, response.Text, "Available queries:\n• `/assetcap asset <name>`\n• `/assetcap sprint <project> \"<sprint>\"`", text)
+	}
+}
+
+func TestCommandHandler_Signature(t *testing.T) {
+	var received []string
+	now := time.Date(2024, 3, 20, 10, 0, 0, 0, time.UTC)
+	handler := NewCommandHandler("secret", testQueries(&received))
+	handler.now = func() time.Time { return now }
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	t.Run("accepts a valid signature", func(t *testing.T) {
+		req, body := commandRequest(t, "asset booking")
+		req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+		req.Header.Set("X-Slack-Signature", Sign("secret", timestamp, body))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, "*Booking*", decodeResponse(t, rec).Text)
+	})
+
+	t.Run("rejects an invalid signature", func(t *testing.T) {
+		req, body := commandRequest(t, "asset booking")
+		req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+		req.Header.Set("X-Slack-Signature", Sign("other", timestamp, body))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("rejects a replayed request", func(t *testing.T) {
+		stale := strconv.FormatInt(now.Add(-10*time.Minute).Unix(), 10)
+		req, body := commandRequest(t, "asset booking")
+		req.Header.Set("X-Slack-Request-Timestamp", stale)
+		req.Header.Set("X-Slack-Signature", Sign("secret", stale, body))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+func TestCommandHandler_RejectsGet(t *testing.T) {
+	handler := NewCommandHandler("", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/slack/commands", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"", nil},
+		{"asset booking", []string{"asset", "booking"}},
+		{`sprint FN "Sprint 42"`, []string{"sprint", "FN", "Sprint 42"}},
+		{"sprint FN “Sprint 42”", []string{"sprint", "FN", "Sprint 42"}},
+		{`  asset   "" `, []string{"asset", ""}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, SplitArgs(tt.text), tt.text)
+	}
+}
@@ -0,0 +1,203 @@
+// Package snapshot copies the storage directory into a private directory before a report is
+// generated, so a fetch or classification writing the data files meanwhile cannot mix old and
+// new data into the report. The copy is only accepted once every file reads the same before and
+// after copying and each JSON file is complete, which makes all the files of a snapshot agree
+// with each other as of its timestamp.
+package snapshot
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/config"
+)
+
+// DefaultAttempts is how many times a snapshot is tried while the files keep changing
+const DefaultAttempts = 5
+
+// DefaultRetryDelay is how long to wait for a write to finish before copying again
+const DefaultRetryDelay = 200 * time.Millisecond
+
+// ErrUnstable is returned when the files kept changing during every attempt
+var ErrUnstable = errors.New("storage kept changing while taking a snapshot")
+
+// excluded are the files never copied: the credentials and the telemetry of the installation
+var excluded = map[string]bool{
+	filepath.Base(config.DefaultCredentialsPath): true,
+	"telemetry.json":         true,
+	"telemetry-buffer.jsonl": true,
+}
+
+// Snapshot is a read-only copy of the storage directory
+type Snapshot struct {
+	// Dir is the directory holding the copy
+	Dir string
+	// TakenAt is when the copied files were last seen unchanged
+	TakenAt time.Time
+}
+
+// Options tune how a snapshot waits for concurrent writes
+type Options struct {
+	// Attempts is how many times to copy while the files change; DefaultAttempts when zero
+	Attempts int
+	// RetryDelay is the pause between attempts; DefaultRetryDelay when zero
+	RetryDelay time.Duration
+	// Now returns the current time; time.Now when nil
+	Now func() time.Time
+}
+
+// Take copies the files of dir into a temporary directory with the default options
+func Take(dir string) (*Snapshot, error) {
+	return TakeWithOptions(dir, Options{})
+}
+
+// TakeWithOptions copies the files of dir into a temporary directory. A missing directory
+// gives an empty snapshot.
+func TakeWithOptions(dir string, opts Options) (*Snapshot, error) {
+	if opts.Attempts <= 0 {
+		opts.Attempts = DefaultAttempts
+	}
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = DefaultRetryDelay
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+
+	for attempt := 1; attempt <= opts.Attempts; attempt++ {
+		files, stable, err := read(dir)
+		if err != nil {
+			return nil, err
+		}
+		if stable {
+			takenAt := opts.Now()
+			target, err := write(files)
+			if err != nil {
+				return nil, err
+			}
+			return &Snapshot{Dir: target, TakenAt: takenAt}, nil
+		}
+		if attempt < opts.Attempts {
+			time.Sleep(opts.RetryDelay)
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnstable, dir)
+}
+
+// Close removes the copy
+func (s *Snapshot) Close() error {
+	if err := os.RemoveAll(s.Dir); err != nil {
+		return fmt.Errorf("failed to remove snapshot %s: %w", s.Dir, err)
+	}
+	return nil
+}
+
+// fileState identifies a version of a file
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+// read reads the files of dir, keyed by their path relative to it. The files are stable when
+// none was written while they were read and every JSON file holds a complete document.
+func read(dir string) (map[string][]byte, bool, error) {
+	before, err := states(dir)
+	if err != nil {
+		return nil, false, err
+	}
+
+	files := make(map[string][]byte, len(before))
+	for name := range before {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, false, nil
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		if strings.HasSuffix(name, ".json") && len(bytes.TrimSpace(data)) > 0 && !json.Valid(data) {
+			return nil, false, nil
+		}
+		files[name] = data
+	}
+
+	after, err := states(dir)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(after) != len(before) {
+		return nil, false, nil
+	}
+	for name, state := range before {
+		if after[name] != state {
+			return nil, false, nil
+		}
+	}
+	return files, true, nil
+}
+
+// states returns the size and modification time of the files of dir
+func states(dir string) (map[string]fileState, error) {
+	result := make(map[string]fileState)
+	err := filepath.WalkDir(dir, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				if p == dir {
+					return fs.SkipDir
+				}
+				return nil
+			}
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		if excluded[filepath.ToSlash(rel)] {
+			return nil
+		}
+		info, err := entry.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		result[rel] = fileState{size: info.Size(), modTime: info.ModTime()}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read storage %s: %w", dir, err)
+	}
+	return result, nil
+}
+
+// write copies the files into a new temporary directory
+func write(files map[string][]byte) (string, error) {
+	target, err := os.MkdirTemp("", "assetcap-snapshot-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	for name, data := range files {
+		p := filepath.Join(target, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			os.RemoveAll(target)
+			return "", fmt.Errorf("failed to create snapshot directory: %w", err)
+		}
+		if err := os.WriteFile(p, data, 0644); err != nil {
+			os.RemoveAll(target)
+			return "", fmt.Errorf("failed to copy %s to the snapshot: %w", name, err)
+		}
+	}
+	return target, nil
+}
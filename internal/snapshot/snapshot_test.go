@@ -0,0 +1,90 @@
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestTake_CopiesStorage(t *testing.T) {
+	at := time.Date(2024, 6, 30, 18, 0, 0, 0, time.UTC)
+	dir := writeFiles(t, map[string]string{
+		"assets.json":          `{"a": {}}`,
+		"tasks.json":           `{}`,
+		"pipeline/state.json":  `{"stage": "fetch"}`,
+		"credentials.env":      "JIRA_TOKEN=secret",
+		"telemetry.json":       `{}`,
+		"absences/FN.json":     `[]`,
+		"exports/allocate.csv": "a,b\n",
+	})
+
+	snap, err := TakeWithOptions(dir, Options{Now: func() time.Time { return at }})
+	require.NoError(t, err)
+	defer snap.Close()
+
+	assert.Equal(t, at, snap.TakenAt)
+	assert.NotEqual(t, dir, snap.Dir)
+	for name, content := range map[string]string{
+		"assets.json":          `{"a": {}}`,
+		"pipeline/state.json":  `{"stage": "fetch"}`,
+		"exports/allocate.csv": "a,b\n",
+	} {
+		data, err := os.ReadFile(filepath.Join(snap.Dir, name))
+		require.NoError(t, err)
+		assert.Equal(t, content, string(data))
+	}
+	assert.NoFileExists(t, filepath.Join(snap.Dir, "credentials.env"))
+	assert.NoFileExists(t, filepath.Join(snap.Dir, "telemetry.json"))
+
+	// Writes after the snapshot do not reach it
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "assets.json"), []byte(`{}`), 0644))
+	data, err := os.ReadFile(filepath.Join(snap.Dir, "assets.json"))
+	require.NoError(t, err)
+	assert.Equal(t, `{"a": {}}`, string(data))
+
+	require.NoError(t, snap.Close())
+	assert.NoDirExists(t, snap.Dir)
+}
+
+func TestTake_MissingDirectory(t *testing.T) {
+	snap, err := Take(filepath.Join(t.TempDir(), "missing"))
+	require.NoError(t, err)
+	defer snap.Close()
+
+	entries, err := os.ReadDir(snap.Dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestTake_WaitsForPartialWrites(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"tasks.json": `{"FN-1": {"key": "FN-1"`})
+
+	_, err := TakeWithOptions(dir, Options{Attempts: 2, RetryDelay: time.Millisecond})
+	assert.ErrorIs(t, err, ErrUnstable)
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		_ = os.WriteFile(filepath.Join(dir, "tasks.json"), []byte(`{"FN-1": {"key": "FN-1"}}`), 0644)
+	}()
+	snap, err := TakeWithOptions(dir, Options{Attempts: 50, RetryDelay: 5 * time.Millisecond})
+	require.NoError(t, err)
+	defer snap.Close()
+	data, err := os.ReadFile(filepath.Join(snap.Dir, "tasks.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"FN-1": {"key": "FN-1"}}`, string(data))
+}
@@ -2,12 +2,18 @@ package application
 
 import (
 	"fmt"
+	"io"
+	"time"
 
 	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/application/usecase"
 	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
 	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain/ports"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/infrastructure"
 )
 
+// absencesPath is the file holding the absences of the team members
+const absencesPath = ".assetcap/absences.json"
+
 // SprintServiceImpl handles sprint-related operations
 type SprintServiceImpl struct {
 	jiraPort ports.JiraPort
@@ -60,11 +66,238 @@ func (s *SprintServiceImpl) ProcessTeamIssues(team *domain.Team) error {
 }
 
 // ProcessJiraIssues processes Jira issues and returns CSV data
-func (s *SprintServiceImpl) ProcessJiraIssues(project, sprint, override string) (string, error) {
-	processor, err := usecase.NewSprintTimeAllocationUseCase(project, sprint, override)
+func (s *SprintServiceImpl) ProcessJiraIssues(input domain.AllocationInput) (string, error) {
+	formatter, err := usecase.NewCSVFormatter(input.Delimiter)
+	if err != nil {
+		return "", err
+	}
+	processor, err := s.allocationProcessor(input)
+	if err != nil {
+		return "", err
+	}
+	return processor.Process(formatter)
+}
+
+// AllocateIssues computes the allocation rows of a sprint or fix version, for callers
+// rendering them other than as CSV
+func (s *SprintServiceImpl) AllocateIssues(input domain.AllocationInput) ([]domain.IssueAllocation, error) {
+	processor, err := s.allocationProcessor(input)
+	if err != nil {
+		return nil, err
+	}
+	return processor.Allocate()
+}
+
+// allocationProcessor creates the allocation of a sprint or fix version configured by the input
+func (s *SprintServiceImpl) allocationProcessor(input domain.AllocationInput) (*usecase.SprintTimeAllocationUseCase, error) {
+	processor, err := s.newAllocationUseCase(input.Project, input.Sprint, input.Override, input.Export)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Jira processor: %w", err)
+	}
+	if err := processor.UseStrategy(input.Method, input.PointsAt); err != nil {
+		return nil, err
+	}
+	if input.FixVersion != "" {
+		processor.UseFixVersion(input.FixVersion)
+	}
+	processor.UseLabelsAsOf(input.LabelsAsOf)
+	processor.UseNormalization(input.Normalization)
+	processor.UseSummary(input.Summary)
+	processor.UseHeuristics(input.Heuristics)
+	processor.UseAssetDocs(input.AssetDocs)
+	processor.UseAssetAccounts(input.AssetAccounts)
+	processor.UseWorkTypeSplits(input.WorkTypeSplits)
+	processor.UsePersonWorkTypes(input.PersonWorkTypes)
+	processor.UseRetirements(input.Retirements)
+	processor.UseStrictRoster(input.StrictRoster)
+	processor.UseLocale(input.Locale)
+	processor.UseProgress(input.Progress)
+	return processor, nil
+}
+
+// LintAssignees checks the sprint assignees against the project team and its aliases
+func (s *SprintServiceImpl) LintAssignees(project, sprint, export string) (*domain.AssigneeLintResult, error) {
+	processor, err := s.newAllocationUseCase(project, sprint, "", export)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Jira processor: %w", err)
+	}
+
+	return processor.LintAssignees()
+}
+
+// PushAllocations computes the sprint allocation and writes it back to Jira
+func (s *SprintServiceImpl) PushAllocations(input domain.PushAllocationsInput) (*domain.PushResult, error) {
+	comments, ok := s.jiraPort.(ports.JiraCommentPort)
+	if !ok && input.Mode != domain.PushModeWorklog {
+		return nil, fmt.Errorf("jira integration does not support issue comments")
+	}
+
+	processor, err := s.newAllocationUseCase(input.Project, input.Sprint, input.Override, input.Export)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Jira processor: %w", err)
+	}
+	processor.UseHeuristics(input.Heuristics)
+
+	push := usecase.NewPushAllocationsUseCase(processor, comments)
+	if worklogs, ok := s.jiraPort.(ports.JiraWorklogPort); ok {
+		push.UseWorklogs(worklogs)
+	}
+	push.UseCheckpoints(infrastructure.NewJSONPushCheckpointStore(infrastructure.DefaultPushCheckpointDir))
+	return push.Execute(input)
+}
+
+// GenerateCapitalizationReport renders sprint allocations with headline capitalization KPIs
+func (s *SprintServiceImpl) GenerateCapitalizationReport(input domain.CapitalizationReportInput) (string, error) {
+	return usecase.NewCapitalizationReportUseCase(s.reportCalculators(input)).Execute(input)
+}
+
+// BuildCapitalizationReport computes the sprint allocations and KPIs as a report model
+func (s *SprintServiceImpl) BuildCapitalizationReport(input domain.CapitalizationReportInput) (*domain.CapitalizationReport, error) {
+	return usecase.NewCapitalizationReportUseCase(s.reportCalculators(input)).Build(input)
+}
+
+// reportCalculators creates the allocation calculators of a capitalization report
+func (s *SprintServiceImpl) reportCalculators(input domain.CapitalizationReportInput) usecase.AllocationCalculatorFactory {
+	return func(project, sprint, override string) (usecase.AllocationCalculator, error) {
+		processor, err := s.newAllocationUseCase(project, sprint, override, input.Export)
+		if err != nil {
+			return nil, err
+		}
+		processor.UseLabelsAsOf(input.LabelsAsOf)
+		processor.UseNormalization(input.Normalization)
+		processor.UseHeuristics(input.Heuristics)
+		processor.UseAssetDocs(input.AssetDocs)
+		processor.UseAssetAccounts(input.AssetAccounts)
+		processor.UseWorkTypeSplits(input.WorkTypeSplits)
+		processor.UseRetirements(input.Retirements)
+		return processor, nil
+	}
+}
+
+// newAllocationUseCase creates the allocation of a project's sprint, reading the issues from
+// the Jira export when one is given and through the service's Jira integration otherwise
+func (s *SprintServiceImpl) newAllocationUseCase(project, sprint, override, export string) (*usecase.SprintTimeAllocationUseCase, error) {
+	if export != "" {
+		return usecase.NewSprintTimeAllocationUseCaseFromExport(project, sprint, override, export)
+	}
+	return usecase.NewSprintTimeAllocationUseCase(s.jiraPort, project, sprint, override)
+}
+
+// GenerateTimesheet exports the sprint allocation as per-engineer day by issue timesheets
+func (s *SprintServiceImpl) GenerateTimesheet(input domain.TimesheetInput) (string, error) {
+	processor, err := s.newAllocationUseCase(input.Project, input.Sprint, input.Override, input.Export)
+	if err != nil {
+		return "", fmt.Errorf("failed to create Jira processor: %w", err)
+	}
+	processor.UseLabelsAsOf(input.LabelsAsOf)
+	processor.UseHeuristics(input.Heuristics)
+
+	return usecase.NewTimesheetUseCase(processor).Execute(input)
+}
+
+// CompareEstimates exports the sprint allocation next to the Jira original estimates, per
+// issue and per person
+func (s *SprintServiceImpl) CompareEstimates(input domain.EstimateComparisonInput) (string, error) {
+	processor, err := s.newAllocationUseCase(input.Project, input.Sprint, input.Override, input.Export)
 	if err != nil {
 		return "", fmt.Errorf("failed to create Jira processor: %w", err)
 	}
+	processor.UseLabelsAsOf(input.LabelsAsOf)
+	processor.UseHeuristics(input.Heuristics)
+
+	return usecase.NewEstimateReportUseCase(processor).Execute(input)
+}
+
+// VerifySprint checks the sprint against the closure criteria
+func (s *SprintServiceImpl) VerifySprint(input domain.VerificationInput) (*domain.VerificationResult, error) {
+	processor, err := s.newAllocationUseCase(input.Project, input.Sprint, input.Override, input.Export)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Jira processor: %w", err)
+	}
+	processor.UseLabelsAsOf(input.LabelsAsOf)
+	processor.UseHeuristics(input.Heuristics)
+	processor.UseWorkTypeSplits(input.WorkTypeSplits)
+
+	return usecase.NewVerifySprintUseCase(processor).Execute(input)
+}
+
+// AssetActivity summarizes the work done on an asset during a sprint
+func (s *SprintServiceImpl) AssetActivity(input domain.AssetActivityInput) (*domain.AssetActivity, error) {
+	processor, err := s.newAllocationUseCase(input.Project, input.Sprint, input.Override, input.Export)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Jira processor: %w", err)
+	}
+	processor.UseLabelsAsOf(input.LabelsAsOf)
+	processor.UseHeuristics(input.Heuristics)
+	processor.UseWorkTypeSplits(input.WorkTypeSplits)
+
+	return usecase.NewAssetActivityUseCase(processor).Execute(input)
+}
+
+// ExplainAllocation traces how the allocation of a single issue is computed
+func (s *SprintServiceImpl) ExplainAllocation(input domain.ExplanationInput) (*domain.AllocationExplanation, error) {
+	processor, err := s.newAllocationUseCase(input.Project, input.Sprint, input.Override, input.Export)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Jira processor: %w", err)
+	}
+	if err := processor.UseStrategy(input.Method, input.PointsAt); err != nil {
+		return nil, err
+	}
+	if input.FixVersion != "" {
+		processor.UseFixVersion(input.FixVersion)
+	}
+	processor.UseLabelsAsOf(input.LabelsAsOf)
+	processor.UseHeuristics(input.Heuristics)
+
+	return processor.Explain(input.IssueKey)
+}
+
+// SprintScope summarizes the issues added to and removed from a sprint while it ran
+func (s *SprintServiceImpl) SprintScope(project, sprint, export string) (*domain.SprintScope, error) {
+	processor, err := s.newAllocationUseCase(project, sprint, "", export)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Jira processor: %w", err)
+	}
+
+	return processor.Scope()
+}
+
+// ResolveTeamAccounts records the Jira account ID of each team member found by the user search
+func (s *SprintServiceImpl) ResolveTeamAccounts(input domain.TeamAccountsInput) (*domain.TeamAccountsResult, error) {
+	users, ok := s.jiraPort.(ports.JiraUserPort)
+	if !ok {
+		return nil, fmt.Errorf("jira integration does not support user search")
+	}
+
+	return usecase.NewResolveTeamAccountsUseCase(users, infrastructure.NewJSONTeamsRepository(infrastructure.DefaultTeamsPath)).Execute(input)
+}
+
+// ListSprints returns the sprints of a project that started within [from, to)
+func (s *SprintServiceImpl) ListSprints(project string, from, to time.Time) ([]domain.Sprint, error) {
+	sprints, ok := s.jiraPort.(ports.JiraSprintListPort)
+	if !ok {
+		return nil, fmt.Errorf("jira integration does not support listing sprints")
+	}
+
+	return sprints.GetSprintsBetween(project, from, to)
+}
+
+// AddAbsences records absences of a team member, returning how many were new
+func (s *SprintServiceImpl) AddAbsences(member string, absences []domain.Absence) (int, error) {
+	return usecase.NewAbsencesUseCase(absencesPath).Add(member, absences)
+}
+
+// ImportAbsences records the events of an iCalendar file as absences of a team member
+func (s *SprintServiceImpl) ImportAbsences(member string, calendar io.Reader) (int, error) {
+	return usecase.NewAbsencesUseCase(absencesPath).Import(member, calendar)
+}
+
+// ListAbsences returns the recorded absences of every team member
+func (s *SprintServiceImpl) ListAbsences() (domain.AbsenceCalendar, error) {
+	return usecase.NewAbsencesUseCase(absencesPath).List()
+}
 
-	return processor.Process()
+// SimulateAllocation runs the allocation engine against a synthetic scenario
+func (s *SprintServiceImpl) SimulateAllocation(scenario string) (*domain.SimulationResult, error) {
+	return usecase.NewSimulateAllocationUseCase().Execute(scenario)
 }
@@ -15,6 +15,7 @@ import (
 
 	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
 	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain/ports"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/infrastructure"
 )
 
 func setupTestEnv(t *testing.T) func() {
@@ -157,18 +158,126 @@ func TestSprintService_ProcessJiraIssues(t *testing.T) {
 
 	// Test successful processing
 	t.Run("successful processing", func(t *testing.T) {
-		result, err := service.ProcessJiraIssues("TEST", "Sprint 1", "")
+		result, err := service.ProcessJiraIssues(domain.AllocationInput{Project: "TEST", Sprint: "Sprint 1"})
 		require.NoError(t, err, "ProcessJiraIssues should not return error")
 		assert.NotEmpty(t, result, "Result should not be empty")
 	})
 
 	// Test invalid project
 	t.Run("invalid project", func(t *testing.T) {
-		_, err := service.ProcessJiraIssues("INVALID", "Sprint 1", "")
+		_, err := service.ProcessJiraIssues(domain.AllocationInput{Project: "INVALID", Sprint: "Sprint 1"})
 		assert.Error(t, err, "ProcessJiraIssues should return error for invalid project")
 	})
 }
 
+func TestSprintService_AllocatesThroughItsJiraPort(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+	// No Jira answers here: the issues must come from the service's port
+	os.Setenv("JIRA_BASE_URL", "http://127.0.0.1:1")
+
+	service := NewSprintService(&mockJiraPort{issues: []ports.JiraIssue{
+		{Key: "TEST-7", Summary: "Wired issue", Assignee: "Test User 1", Status: "Done"},
+	}})
+
+	allocations, err := service.AllocateIssues(domain.AllocationInput{Project: "TEST", Sprint: "Sprint 1"})
+	require.NoError(t, err)
+	require.Len(t, allocations, 1)
+	assert.Equal(t, "TEST-7", allocations[0].IssueKey)
+
+	lint, err := service.LintAssignees("TEST", "Sprint 1", "")
+	require.NoError(t, err)
+	assert.Empty(t, lint.Unmatched)
+}
+
+func TestSprintService_AllocatesWithConfiguredSprintField(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	var searched []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/search" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		searched = append(searched, r.URL.Query().Get("fields"))
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{
+			"issues": [
+				{"key": "TEST-9", "fields": {
+					"summary": "Custom sprint field",
+					"assignee": {"displayName": "Test User 1"},
+					"status": {"name": "Done"},
+					"customfield_12345": [{"id": 1, "name": "Sprint 1", "state": "closed"}]
+				}}
+			]
+		}`))
+	}))
+	defer server.Close()
+	os.Setenv("JIRA_BASE_URL", server.URL)
+
+	adapter, err := infrastructure.NewJiraAdapterWithClient(nil)
+	require.NoError(t, err)
+	adapter.UseSprintField("customfield_12345")
+
+	allocations, err := NewSprintService(adapter).AllocateIssues(domain.AllocationInput{Project: "TEST", Sprint: "Sprint 1"})
+	require.NoError(t, err)
+	require.Len(t, allocations, 1)
+	assert.Equal(t, "TEST-9", allocations[0].IssueKey)
+
+	require.NotEmpty(t, searched)
+	for _, fields := range searched {
+		assert.Contains(t, fields, "customfield_12345", "the configured sprint field is requested")
+		assert.NotContains(t, fields, "customfield_10020", "the default sprint field is not requested")
+	}
+}
+
+func TestSprintService_ProcessJiraIssuesFromExport(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+	os.Unsetenv("JIRA_BASE_URL")
+	os.Unsetenv("JIRA_TOKEN")
+
+	export := "Summary,Issue key,Issue Type,Status,Assignee,Created,Resolved,Sprint,Labels\n" +
+		"Build checkout,TEST-1,Story,Done,Test User 1,18/Mar/24 9:00 AM,19/Mar/24 9:00 AM,Sprint 1,cap-development\n" +
+		"Other sprint,TEST-2,Story,Done,Test User 1,18/Mar/24 9:00 AM,19/Mar/24 9:00 AM,Sprint 2,cap-development\n"
+	require.NoError(t, os.WriteFile("export.csv", []byte(export), 0644))
+
+	service := NewSprintService(&mockJiraPort{err: fmt.Errorf("jira is not reachable")})
+	result, err := service.ProcessJiraIssues(domain.AllocationInput{Project: "TEST", Sprint: "Sprint 1", Export: "export.csv"})
+	require.NoError(t, err, "allocating an export needs no Jira configuration")
+	assert.Contains(t, result, "TEST-1")
+	assert.NotContains(t, result, "TEST-2")
+
+	_, err = service.ProcessJiraIssues(domain.AllocationInput{Project: "TEST", Sprint: "Sprint 1", Export: "missing.csv"})
+	assert.ErrorContains(t, err, "failed to read export")
+
+	allocations, err := service.AllocateIssues(domain.AllocationInput{Project: "TEST", Sprint: "Sprint 1", Export: "export.csv"})
+	require.NoError(t, err)
+	require.Len(t, allocations, 1)
+	assert.Equal(t, "TEST-1", allocations[0].IssueKey)
+	assert.Equal(t, "cap-development", allocations[0].WorkType)
+
+	// Every use case reads the export instead of the unreachable Jira
+	timesheet, err := service.GenerateTimesheet(domain.TimesheetInput{Project: "TEST", Sprint: "Sprint 1", Export: "export.csv"})
+	require.NoError(t, err)
+	assert.Contains(t, timesheet, "TEST-1")
+	_, err = service.CompareEstimates(domain.EstimateComparisonInput{Project: "TEST", Sprint: "Sprint 1", Export: "export.csv"})
+	require.NoError(t, err)
+	_, err = service.VerifySprint(domain.VerificationInput{Project: "TEST", Sprint: "Sprint 1", Export: "export.csv"})
+	require.NoError(t, err)
+	_, err = service.AssetActivity(domain.AssetActivityInput{Project: "TEST", Sprint: "Sprint 1", Asset: "checkout", Export: "export.csv"})
+	require.NoError(t, err)
+	explanation, err := service.ExplainAllocation(domain.ExplanationInput{Project: "TEST", Sprint: "Sprint 1", IssueKey: "TEST-1", Export: "export.csv"})
+	require.NoError(t, err)
+	assert.Equal(t, "TEST-1", explanation.IssueKey)
+	_, err = service.SprintScope("TEST", "Sprint 1", "export.csv")
+	require.NoError(t, err)
+	lint, err := service.LintAssignees("TEST", "Sprint 1", "export.csv")
+	require.NoError(t, err)
+	assert.Empty(t, lint.Unmatched)
+}
+
 func TestSprintService_ProcessSprint(t *testing.T) {
 	cleanup := setupTestEnv(t)
 	defer cleanup()
@@ -350,3 +459,34 @@ func TestSprintService_ProcessTeamIssues(t *testing.T) {
 func float64Ptr(v float64) *float64 {
 	return &v
 }
+
+type mockSprintListPort struct {
+	mockJiraPort
+	sprints []domain.Sprint
+}
+
+func (m *mockSprintListPort) GetSprintsBetween(_ string, _, _ time.Time) ([]domain.Sprint, error) {
+	return m.sprints, m.err
+}
+
+func TestSprintService_ListSprints(t *testing.T) {
+	from := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 3, 0)
+
+	t.Run("lists the sprints of the Jira integration", func(t *testing.T) {
+		service := NewSprintService(&mockSprintListPort{sprints: []domain.Sprint{{Name: "Sprint 7"}}})
+
+		sprints, err := service.ListSprints("TEST", from, to)
+
+		require.NoError(t, err)
+		assert.Equal(t, []domain.Sprint{{Name: "Sprint 7"}}, sprints)
+	})
+
+	t.Run("unsupported Jira integration", func(t *testing.T) {
+		service := NewSprintService(&mockJiraPort{})
+
+		_, err := service.ListSprints("TEST", from, to)
+
+		assert.EqualError(t, err, "jira integration does not support listing sprints")
+	})
+}
@@ -1,6 +1,9 @@
 package application
 
 import (
+	"io"
+	"time"
+
 	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
 )
 
@@ -13,5 +16,59 @@ type SprintService interface {
 	ProcessTeamIssues(team *domain.Team) error
 
 	// ProcessJiraIssues processes Jira issues and returns CSV data
-	ProcessJiraIssues(project, sprint, override string) (string, error)
+	ProcessJiraIssues(input domain.AllocationInput) (string, error)
+
+	// AllocateIssues computes the allocation rows of a sprint or fix version
+	AllocateIssues(input domain.AllocationInput) ([]domain.IssueAllocation, error)
+
+	// PushAllocations computes the sprint allocation and writes it back to Jira
+	PushAllocations(input domain.PushAllocationsInput) (*domain.PushResult, error)
+
+	// GenerateCapitalizationReport renders sprint allocations with headline capitalization KPIs
+	GenerateCapitalizationReport(input domain.CapitalizationReportInput) (string, error)
+
+	// BuildCapitalizationReport computes the sprint allocations and KPIs as a report model
+	BuildCapitalizationReport(input domain.CapitalizationReportInput) (*domain.CapitalizationReport, error)
+
+	// GenerateTimesheet exports the sprint allocation as per-engineer day by issue timesheets
+	GenerateTimesheet(input domain.TimesheetInput) (string, error)
+
+	// CompareEstimates exports the sprint allocation next to the Jira original estimates, per
+	// issue and per person
+	CompareEstimates(input domain.EstimateComparisonInput) (string, error)
+
+	// VerifySprint checks the sprint against the closure criteria
+	VerifySprint(input domain.VerificationInput) (*domain.VerificationResult, error)
+
+	// AssetActivity summarizes the work done on an asset during a sprint
+	AssetActivity(input domain.AssetActivityInput) (*domain.AssetActivity, error)
+
+	// ExplainAllocation traces how the allocation of a single issue is computed
+	ExplainAllocation(input domain.ExplanationInput) (*domain.AllocationExplanation, error)
+
+	// SimulateAllocation runs the allocation engine against a synthetic scenario
+	SimulateAllocation(scenario string) (*domain.SimulationResult, error)
+
+	// ResolveTeamAccounts records the Jira account ID of each team member found by the user search
+	ResolveTeamAccounts(input domain.TeamAccountsInput) (*domain.TeamAccountsResult, error)
+
+	// ListSprints returns the sprints of a project that started within [from, to)
+	ListSprints(project string, from, to time.Time) ([]domain.Sprint, error)
+
+	// AddAbsences records absences of a team member, returning how many were new
+	AddAbsences(member string, absences []domain.Absence) (int, error)
+
+	// ImportAbsences records the events of an iCalendar file as absences of a team member
+	ImportAbsences(member string, calendar io.Reader) (int, error)
+
+	// ListAbsences returns the recorded absences of every team member
+	ListAbsences() (domain.AbsenceCalendar, error)
+
+	// LintAssignees checks the sprint assignees against the project team and its aliases,
+	// reading the issues from the Jira export when one is given
+	LintAssignees(project, sprint, export string) (*domain.AssigneeLintResult, error)
+
+	// SprintScope summarizes the issues added to and removed from a sprint while it ran,
+	// reading the issues from the Jira export when one is given
+	SprintScope(project, sprint, export string) (*domain.SprintScope, error)
 }
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/infrastructure"
+)
+
+// AbsencesUseCase records the absences of team members in absences.json
+type AbsencesUseCase struct {
+	path string
+}
+
+// NewAbsencesUseCase creates a new AbsencesUseCase instance
+func NewAbsencesUseCase(path string) *AbsencesUseCase {
+	return &AbsencesUseCase{path: path}
+}
+
+// List returns the recorded absences of every team member
+func (uc *AbsencesUseCase) List() (domain.AbsenceCalendar, error) {
+	return loadAbsenceCalendar(uc.path)
+}
+
+// Add records absences of a team member, skipping the ones already recorded, and returns
+// how many were new
+func (uc *AbsencesUseCase) Add(member string, absences []domain.Absence) (int, error) {
+	if member == "" {
+		return 0, fmt.Errorf("absences need the team member they belong to")
+	}
+	calendar, err := loadAbsenceCalendar(uc.path)
+	if err != nil {
+		return 0, err
+	}
+	added := calendar.Add(member, absences...)
+	if added == 0 {
+		return 0, nil
+	}
+
+	data, err := json.MarshalIndent(calendar, "", "  ")
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal absences: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(uc.path), 0755); err != nil {
+		return 0, fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(uc.path, append(data, '\n'), 0644); err != nil {
+		return 0, fmt.Errorf("failed to write absences file: %w", err)
+	}
+	return added, nil
+}
+
+// Import records the events of an iCalendar file as absences of a team member, returning
+// how many were new
+func (uc *AbsencesUseCase) Import(member string, calendar io.Reader) (int, error) {
+	absences, err := infrastructure.ParseICalAbsences(calendar)
+	if err != nil {
+		return 0, err
+	}
+	return uc.Add(member, absences)
+}
+
+// loadAbsenceCalendar reads the absences file, returning an empty calendar when there is none
+func loadAbsenceCalendar(path string) (domain.AbsenceCalendar, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return domain.AbsenceCalendar{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read absences file: %w", err)
+	}
+
+	calendar := domain.AbsenceCalendar{}
+	if err := json.Unmarshal(data, &calendar); err != nil {
+		return nil, fmt.Errorf("invalid absences file %s: %w", path, err)
+	}
+	return calendar, nil
+}
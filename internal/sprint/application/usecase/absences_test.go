@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+func TestAbsencesUseCase_Add(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".assetcap", "absences.json")
+	uc := NewAbsencesUseCase(path)
+	vacation := domain.Absence{From: time.Date(2024, time.May, 6, 0, 0, 0, 0, time.UTC), To: time.Date(2024, time.May, 10, 0, 0, 0, 0, time.UTC), Reason: "vacation"}
+
+	added, err := uc.Add("alice", []domain.Absence{vacation})
+	require.NoError(t, err)
+	assert.Equal(t, 1, added)
+	added, err = uc.Add("alice", []domain.Absence{vacation})
+	require.NoError(t, err)
+	assert.Equal(t, 0, added)
+
+	calendar, err := uc.List()
+	require.NoError(t, err)
+	assert.Equal(t, domain.AbsenceCalendar{"alice": {vacation}}, calendar)
+
+	_, err = uc.Add("", []domain.Absence{vacation})
+	assert.EqualError(t, err, "absences need the team member they belong to")
+}
+
+func TestAbsencesUseCase_Import(t *testing.T) {
+	uc := NewAbsencesUseCase(filepath.Join(t.TempDir(), "absences.json"))
+
+	added, err := uc.Import("alice", strings.NewReader("BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;VALUE=DATE:20240506\nDTEND;VALUE=DATE:20240511\nSUMMARY:Vacation\nEND:VEVENT\nEND:VCALENDAR\n"))
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, added)
+	calendar, err := uc.List()
+	require.NoError(t, err)
+	require.Len(t, calendar["alice"], 1)
+	assert.Equal(t, 5, calendar["alice"][0].Days())
+	assert.Equal(t, "Vacation", calendar["alice"][0].Reason)
+}
+
+func TestAbsencesUseCase_List(t *testing.T) {
+	t.Run("without absences file", func(t *testing.T) {
+		calendar, err := NewAbsencesUseCase(filepath.Join(t.TempDir(), "absences.json")).List()
+
+		require.NoError(t, err)
+		assert.Empty(t, calendar)
+	})
+
+	t.Run("invalid absences file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "absences.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"alice": [{"from": "2024-05-10", "to": "2024-05-06"}]}`), 0644))
+
+		_, err := NewAbsencesUseCase(path).List()
+
+		assert.EqualError(t, err, "invalid absences file "+path+": absence ends on 2024-05-06, before it starts on 2024-05-10")
+	})
+}
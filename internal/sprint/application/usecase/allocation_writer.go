@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+// allocationColumns are the leading columns of the allocation CSV, followed by one column per team member
+var allocationColumns = []string{"sprint", "issueKey", "issueType", "issueTitle", "workType", "assetName", "status", "dateStarted", "dateCompleted", "evidenceUrl", "assetUrl", "scopeChange", "confidence"}
+
+// accountColumns follow the asset URL when the allocation books assets to their accounts
+var accountColumns = []string{"costCenter", "glAccount"}
+
+// AllocationWriter streams allocation rows as CSV, writing the header before the first row.
+// It reuses a single record so memory stays flat however many rows are written.
+type AllocationWriter struct {
+	writer  *csv.Writer
+	members []string
+	locale  domain.Locale
+	record  []string
+	rows    int
+	// accounts adds the cost center and GL account columns
+	accounts bool
+}
+
+// NewAllocationWriter creates a writer of allocation rows with one column per team member
+func (f *CSVFormatter) NewAllocationWriter(w io.Writer, members []string, locale domain.Locale) *AllocationWriter {
+	writer := csv.NewWriter(w)
+	writer.Comma = f.delimiter
+	return &AllocationWriter{
+		writer:  writer,
+		members: members,
+		locale:  locale,
+		record:  make([]string, len(allocationColumns)+len(members)),
+	}
+}
+
+// IncludeAccounts adds the cost center and GL account columns after the asset URL. It must be
+// called before the first row is written.
+func (w *AllocationWriter) IncludeAccounts() {
+	w.accounts = true
+	w.record = make([]string, len(allocationColumns)+len(accountColumns)+len(w.members))
+}
+
+// Write writes a single allocation row, preceded by the header on the first call
+func (w *AllocationWriter) Write(allocation domain.IssueAllocation) error {
+	if w.rows == 0 {
+		record := append(w.record[:0], allocationColumns[:11]...)
+		if w.accounts {
+			record = append(record, accountColumns...)
+		}
+		record = append(record, allocationColumns[11:]...)
+		w.record = append(record, w.members...)
+		if err := w.write(); err != nil {
+			return err
+		}
+	}
+
+	record := append(w.record[:0],
+		allocation.Sprint,
+		allocation.IssueKey,
+		allocation.IssueType,
+		allocation.IssueTitle,
+		allocation.WorkType,
+		allocation.AssetName,
+		allocation.Status,
+		w.locale.Date(allocation.DateStarted),
+		w.locale.Date(allocation.DateCompleted),
+		allocation.EvidenceURL,
+		allocation.AssetURL,
+	)
+	if w.accounts {
+		record = append(record, allocation.CostCenter, allocation.GLAccount)
+	}
+	record = append(record,
+		domain.DescribeScopeChanges(allocation.ScopeChanges, w.locale),
+		strconv.Itoa(allocation.Confidence()),
+	)
+	for _, member := range w.members {
+		share := ""
+		if member == allocation.Assignee {
+			share = w.locale.Percent(allocation.Percentage)
+		}
+		record = append(record, share)
+	}
+	w.record = record
+
+	if err := w.write(); err != nil {
+		return err
+	}
+	w.rows++
+	return nil
+}
+
+// Rows returns the number of allocation rows written, excluding the header
+func (w *AllocationWriter) Rows() int {
+	return w.rows
+}
+
+// Flush writes any buffered rows to the underlying writer
+func (w *AllocationWriter) Flush() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// write sanitizes the current record in place and hands it to the CSV writer
+func (w *AllocationWriter) write() error {
+	for i, field := range w.record {
+		w.record[i] = sanitizeField(field)
+	}
+	return w.writer.Write(w.record)
+}
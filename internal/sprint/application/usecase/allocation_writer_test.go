@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+func TestAllocationWriter(t *testing.T) {
+	formatter, err := NewCSVFormatter(0)
+	require.NoError(t, err)
+
+	var buffer strings.Builder
+	writer := formatter.NewAllocationWriter(&buffer, []string{"engineer1", "engineer2"}, domain.Locale{})
+	require.NoError(t, writer.Write(domain.IssueAllocation{
+		Sprint:        "Sprint 1",
+		IssueKey:      "TEST-1",
+		IssueType:     "Task",
+		IssueTitle:    `Checkout, "v2"`,
+		Assignee:      "engineer2",
+		WorkType:      "cap-development",
+		AssetName:     "cap-asset-booking",
+		Status:        "Done",
+		Percentage:    62.5,
+		DateStarted:   time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+		DateCompleted: time.Date(2024, 3, 21, 0, 0, 0, 0, time.UTC),
+		EvidenceURL:   "https://example.atlassian.net/browse/TEST-1",
+		ScopeChanges: []domain.ScopeChange{
+			{IssueKey: "TEST-1", Change: domain.ScopeAdded, At: time.Date(2024, 3, 19, 10, 0, 0, 0, time.UTC)},
+			{IssueKey: "TEST-1", Change: domain.ScopeRemoved, At: time.Date(2024, 3, 20, 9, 0, 0, 0, time.UTC)},
+		},
+	}))
+	require.NoError(t, writer.Write(domain.IssueAllocation{
+		Sprint:            "Sprint 1",
+		IssueKey:          "TEST-2",
+		IssueTitle:        "Multi\nline",
+		Assignee:          "outsider",
+		Status:            "In Progress",
+		Percentage:        100,
+		DateStarted:       time.Date(2024, 3, 22, 0, 0, 0, 0, time.UTC),
+		ConfidenceFactors: []domain.ConfidenceFactor{domain.ConfidenceDefaultHours},
+	}))
+	require.NoError(t, writer.Flush())
+
+	assert.Equal(t, 2, writer.Rows())
+	assert.Equal(t, "sprint,issueKey,issueType,issueTitle,workType,assetName,status,dateStarted,dateCompleted,evidenceUrl,assetUrl,scopeChange,confidence,engineer1,engineer2\n"+
+		"Sprint 1,TEST-1,Task,\"Checkout, \"\"v2\"\"\",cap-development,cap-asset-booking,Done,2024-03-20,2024-03-21,https://example.atlassian.net/browse/TEST-1,,added 2024-03-19; removed 2024-03-20,100,,62.50%\n"+
+		"Sprint 1,TEST-2,,\"Multi\nline\",,,In Progress,2024-03-22,,,,,50,,\n", buffer.String())
+}
+
+func TestAllocationWriter_Accounts(t *testing.T) {
+	var buffer strings.Builder
+	writer := (&CSVFormatter{delimiter: ','}).NewAllocationWriter(&buffer, []string{"engineer1"}, domain.Locale{})
+	writer.IncludeAccounts()
+	require.NoError(t, writer.Write(domain.IssueAllocation{
+		Sprint:     "Sprint 1",
+		IssueKey:   "TEST-1",
+		Assignee:   "engineer1",
+		AssetName:  "cap-asset-booking",
+		Status:     "Done",
+		Percentage: 100,
+		CostCenter: "CC-100",
+		GLAccount:  "1710",
+	}))
+	require.NoError(t, writer.Flush())
+
+	assert.Equal(t, "sprint,issueKey,issueType,issueTitle,workType,assetName,status,dateStarted,dateCompleted,evidenceUrl,assetUrl,costCenter,glAccount,scopeChange,confidence,engineer1\n"+
+		"Sprint 1,TEST-1,,,,cap-asset-booking,Done,,,,,CC-100,1710,,100,100.00%\n", buffer.String())
+}
+
+func TestAllocationWriter_Empty(t *testing.T) {
+	formatter, err := NewCSVFormatter(',')
+	require.NoError(t, err)
+
+	var buffer strings.Builder
+	writer := formatter.NewAllocationWriter(&buffer, []string{"engineer1"}, domain.Locale{})
+	require.NoError(t, writer.Flush())
+
+	assert.Zero(t, writer.Rows())
+	assert.Empty(t, buffer.String())
+}
+
+func TestAllocationWriter_Locale(t *testing.T) {
+	locale, err := domain.ParseLocale("de-DE")
+	require.NoError(t, err)
+
+	var buffer strings.Builder
+	writer := (&CSVFormatter{delimiter: ';'}).NewAllocationWriter(&buffer, []string{"engineer1"}, locale)
+	require.NoError(t, writer.Write(domain.IssueAllocation{
+		Sprint:        "Sprint 1",
+		IssueKey:      "TEST-1",
+		Assignee:      "engineer1",
+		Status:        "Done",
+		Percentage:    62.5,
+		DateStarted:   time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+		DateCompleted: time.Date(2024, 3, 21, 0, 0, 0, 0, time.UTC),
+	}))
+	require.NoError(t, writer.Flush())
+
+	assert.Contains(t, buffer.String(), "Sprint 1;TEST-1;;;;;Done;20.03.2024;21.03.2024;;;;100;62,50%\n")
+}
+
+func TestAllocationWriter_SanitizesFormulas(t *testing.T) {
+	var buffer strings.Builder
+	writer := (&CSVFormatter{delimiter: ','}).NewAllocationWriter(&buffer, []string{"=engineer"}, domain.Locale{})
+	require.NoError(t, writer.Write(domain.IssueAllocation{IssueKey: "TEST-1", IssueTitle: "=HYPERLINK(\"x\")", Assignee: "=engineer", Percentage: 50}))
+	require.NoError(t, writer.Flush())
+
+	lines := strings.Split(buffer.String(), "\n")
+	assert.True(t, strings.HasSuffix(lines[0], ",'=engineer"))
+	assert.Contains(t, lines[1], `"'=HYPERLINK(""x"")"`)
+	assert.True(t, strings.HasSuffix(lines[1], ",50.00%"))
+}
+
+func TestAllocationWriter_AllocationsPerRowStayFlat(t *testing.T) {
+	members := benchmarkMembers(50)
+	allocation := domain.IssueAllocation{
+		Sprint:      "Sprint 1",
+		IssueKey:    "TEST-1",
+		Assignee:    members[25],
+		Status:      "Done",
+		Percentage:  12.5,
+		DateStarted: time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+	}
+
+	perRow := func(rows int) float64 {
+		writer := (&CSVFormatter{delimiter: ','}).NewAllocationWriter(io.Discard, members, domain.Locale{})
+		return testing.AllocsPerRun(5, func() {
+			for i := 0; i < rows; i++ {
+				_ = writer.Write(allocation)
+			}
+		}) / float64(rows)
+	}
+
+	small, large := perRow(100), perRow(10000)
+	assert.LessOrEqual(t, large, small+0.5, "allocations per row must not grow with the number of rows")
+}
+
+func BenchmarkAllocationWriter(b *testing.B) {
+	members := benchmarkMembers(50)
+	writer := (&CSVFormatter{delimiter: ','}).NewAllocationWriter(io.Discard, members, domain.Locale{})
+	allocation := domain.IssueAllocation{
+		Sprint:      "Sprint 1",
+		IssueKey:    "TEST-1",
+		IssueTitle:  "Benchmark issue",
+		Assignee:    members[25],
+		Status:      "Done",
+		Percentage:  12.5,
+		DateStarted: time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC),
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := writer.Write(allocation); err != nil {
+			b.Fatal(err)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		b.Fatal(err)
+	}
+}
+
+// benchmarkMembers returns a team of n members
+func benchmarkMembers(n int) []string {
+	members := make([]string, n)
+	for i := range members {
+		members[i] = fmt.Sprintf("engineer%d", i+1)
+	}
+	return members
+}
@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+// AssetActivityUseCase summarizes the work done on an asset during a sprint
+type AssetActivityUseCase struct {
+	calculator AllocationCalculator
+}
+
+// NewAssetActivityUseCase creates a new AssetActivityUseCase instance
+func NewAssetActivityUseCase(calculator AllocationCalculator) *AssetActivityUseCase {
+	return &AssetActivityUseCase{
+		calculator: calculator,
+	}
+}
+
+// Execute computes the sprint allocation and collects the asset's completed issues, hours
+// and work type mix
+func (uc *AssetActivityUseCase) Execute(input domain.AssetActivityInput) (*domain.AssetActivity, error) {
+	if input.Asset == "" {
+		return nil, fmt.Errorf("asset name is required")
+	}
+
+	allocations, err := uc.calculator.Allocate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate allocations: %w", err)
+	}
+
+	return domain.NewAssetActivity(input.Project, input.Sprint, input.Asset, allocations), nil
+}
@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+func TestAssetActivityUseCase_Execute(t *testing.T) {
+	completed := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)
+	calculator := &stubAllocationCalculator{allocations: []domain.IssueAllocation{
+		{IssueKey: "FN-1", Assignee: "alice", WorkType: "cap-development", AssetName: "cap-asset-booking", Hours: 6, DateCompleted: completed},
+		{IssueKey: "FN-2", Assignee: "bob", WorkType: "cap-development", AssetName: "cap-asset-search", Hours: 8, DateCompleted: completed},
+	}}
+
+	activity, err := NewAssetActivityUseCase(calculator).Execute(domain.AssetActivityInput{Project: "FN", Sprint: "Sprint 1", Asset: "booking"})
+	require.NoError(t, err)
+	assert.Equal(t, "booking", activity.Asset)
+	assert.Equal(t, "Sprint 1", activity.Sprint)
+	require.Len(t, activity.Completed, 1)
+	assert.Equal(t, "FN-1", activity.Completed[0].IssueKey)
+	assert.Equal(t, 6.0, activity.TotalHours)
+}
+
+func TestAssetActivityUseCase_Errors(t *testing.T) {
+	_, err := NewAssetActivityUseCase(&stubAllocationCalculator{}).Execute(domain.AssetActivityInput{})
+	assert.EqualError(t, err, "asset name is required")
+
+	_, err = NewAssetActivityUseCase(&stubAllocationCalculator{err: errors.New("jira down")}).Execute(domain.AssetActivityInput{Asset: "booking"})
+	assert.ErrorContains(t, err, "failed to calculate allocations: jira down")
+}
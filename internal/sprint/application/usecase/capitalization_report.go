@@ -0,0 +1,435 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+// AllocationCalculatorFactory creates an AllocationCalculator for a project and sprint
+type AllocationCalculatorFactory func(project, sprint, override string) (AllocationCalculator, error)
+
+// CapitalizationReportUseCase renders sprint allocations with headline capitalization KPIs
+type CapitalizationReportUseCase struct {
+	newCalculator AllocationCalculatorFactory
+}
+
+// NewCapitalizationReportUseCase creates a new CapitalizationReportUseCase instance
+func NewCapitalizationReportUseCase(newCalculator AllocationCalculatorFactory) *CapitalizationReportUseCase {
+	return &CapitalizationReportUseCase{
+		newCalculator: newCalculator,
+	}
+}
+
+// Execute computes the allocations of every project and renders the report
+func (uc *CapitalizationReportUseCase) Execute(input domain.CapitalizationReportInput) (string, error) {
+	if len(input.Projects) == 0 {
+		return "", fmt.Errorf("at least one project is required")
+	}
+	if input.Template == nil && input.Format != domain.ReportFormatCSV && input.Format != domain.ReportFormatMarkdown {
+		return "", fmt.Errorf("unsupported report format: %s", input.Format)
+	}
+	formatter, err := NewCSVFormatter(input.Delimiter)
+	if err != nil {
+		return "", err
+	}
+
+	kpis, rows, err := uc.collect(input)
+	if err != nil {
+		return "", err
+	}
+
+	if input.Template != nil {
+		return renderTemplateReport(input.Template, buildReport(kpis, rows, input.Locale, input.AssetStatuses), input.Locale)
+	}
+	if input.Format == domain.ReportFormatMarkdown {
+		return renderMarkdownReport(kpis, rows, input.Locale), nil
+	}
+	return renderCSVReport(formatter, kpis, rows, input.Locale)
+}
+
+// Build computes the allocations of every project and returns the report model, for callers
+// rendering the report themselves
+func (uc *CapitalizationReportUseCase) Build(input domain.CapitalizationReportInput) (*domain.CapitalizationReport, error) {
+	if len(input.Projects) == 0 {
+		return nil, fmt.Errorf("at least one project is required")
+	}
+	kpis, rows, err := uc.collect(input)
+	if err != nil {
+		return nil, err
+	}
+	report := buildReport(kpis, rows, input.Locale, input.AssetStatuses)
+	return &report, nil
+}
+
+// collect gathers the allocations of the current period and, when requested, the previous one
+func (uc *CapitalizationReportUseCase) collect(input domain.CapitalizationReportInput) (domain.CapitalizationKPIs, []domain.ReportRow, error) {
+	kpis := domain.CapitalizationKPIs{Period: input.Sprint}
+	var rows []domain.ReportRow
+	var all []domain.IssueAllocation
+
+	for _, project := range input.Projects {
+		allocations, warnings, err := uc.allocate(project, input.Sprint, input.Override)
+		if err != nil {
+			return kpis, nil, err
+		}
+		kpis.Unattributed = append(kpis.Unattributed, warnings.unattributed...)
+		kpis.Heuristics = append(kpis.Heuristics, warnings.heuristics...)
+		kpis.Absences = append(kpis.Absences, warnings.absences...)
+		kpis.SprintOverlaps = append(kpis.SprintOverlaps, warnings.overlaps...)
+		kpis.StaleIssues = append(kpis.StaleIssues, warnings.stale...)
+		kpis.RetiredAssetWork = append(kpis.RetiredAssetWork, warnings.retired...)
+		kpis.Policies = domain.ApplyPolicy(allocations, input.Policy, kpis.Policies)
+		kpis.Impairments = markImpaired(allocations, input.Impairments, kpis.Impairments)
+		kpis.Teams = append(kpis.Teams, domain.TeamCapitalization{
+			Team:    project,
+			Summary: domain.SummarizeAllocations(allocations),
+		})
+		for _, allocation := range allocations {
+			rows = append(rows, domain.ReportRow{Team: project, IssueAllocation: allocation})
+		}
+		all = append(all, allocations...)
+	}
+	kpis.Overall = domain.SummarizeAllocations(all)
+
+	if input.PreviousSprint != "" {
+		var previous []domain.IssueAllocation
+		for _, project := range input.Projects {
+			allocations, _, err := uc.allocate(project, input.PreviousSprint, "")
+			if err != nil {
+				return kpis, nil, err
+			}
+			domain.ApplyPolicy(allocations, input.Policy, nil)
+			markImpaired(allocations, input.Impairments, nil)
+			previous = append(previous, allocations...)
+		}
+		summary := domain.SummarizeAllocations(previous)
+		kpis.PreviousPeriod = input.PreviousSprint
+		kpis.Previous = &summary
+	}
+
+	return kpis, rows, nil
+}
+
+// allocationWarnings are the in-progress time no team member held, the heuristics applied, the
+// absences taken out, the overlapping sprints split, the stale issues flagged and the work
+// taken off retired assets while allocating a sprint
+type allocationWarnings struct {
+	unattributed []domain.UnattributedTime
+	heuristics   []domain.AppliedHeuristic
+	absences     []domain.AppliedAbsence
+	overlaps     []domain.SprintOverlap
+	stale        []domain.StaleIssue
+	retired      []domain.RetiredAssetWork
+}
+
+// allocate computes a sprint's allocations and the warnings raised while computing them
+func (uc *CapitalizationReportUseCase) allocate(project, sprint, override string) ([]domain.IssueAllocation, allocationWarnings, error) {
+	var warnings allocationWarnings
+	calculator, err := uc.newCalculator(project, sprint, override)
+	if err != nil {
+		return nil, warnings, fmt.Errorf("failed to create Jira processor: %w", err)
+	}
+	allocations, err := calculator.Allocate()
+	if err != nil {
+		return nil, warnings, fmt.Errorf("failed to calculate allocations for %s in %s: %w", project, sprint, err)
+	}
+	if reporter, ok := calculator.(UnattributedReporter); ok {
+		warnings.unattributed = reporter.Unattributed()
+	}
+	if reporter, ok := calculator.(HeuristicReporter); ok {
+		warnings.heuristics = reporter.AppliedHeuristics()
+	}
+	if reporter, ok := calculator.(AbsenceReporter); ok {
+		warnings.absences = reporter.AppliedAbsences()
+	}
+	if reporter, ok := calculator.(SprintOverlapReporter); ok {
+		warnings.overlaps = reporter.SprintOverlaps()
+	}
+	if reporter, ok := calculator.(StaleIssueReporter); ok {
+		warnings.stale = reporter.StaleIssues()
+	}
+	if reporter, ok := calculator.(RetirementReporter); ok {
+		warnings.retired = reporter.RetiredAssetWork()
+	}
+	return allocations, warnings, nil
+}
+
+// markImpaired flags the allocations affected by an impairment and appends every
+// impairment that affected at least one of them to applied
+func markImpaired(allocations []domain.IssueAllocation, impairments, applied []domain.AssetImpairment) []domain.AssetImpairment {
+	for _, impairment := range impairments {
+		affected := false
+		for i := range allocations {
+			if impairment.Affects(allocations[i]) {
+				allocations[i].Impaired = true
+				affected = true
+			}
+		}
+		if affected && !containsImpairment(applied, impairment) {
+			applied = append(applied, impairment)
+		}
+	}
+	return applied
+}
+
+func containsImpairment(impairments []domain.AssetImpairment, impairment domain.AssetImpairment) bool {
+	for _, existing := range impairments {
+		if existing == impairment {
+			return true
+		}
+	}
+	return false
+}
+
+// buildReport assembles the report model handed to templates
+func buildReport(kpis domain.CapitalizationKPIs, rows []domain.ReportRow, locale domain.Locale, statuses domain.AssetStatuses) domain.CapitalizationReport {
+	report := domain.CapitalizationReport{KPIs: kpis, Rows: rows}
+	for _, line := range summaryLines(kpis, locale) {
+		report.Metrics = append(report.Metrics, domain.ReportMetric{Label: line[0], Value: line[1]})
+	}
+	if trend, ok := kpis.DevelopmentTrend(); ok {
+		report.DevelopmentTrend = &trend
+	}
+	if trend, ok := kpis.MaintenanceTrend(); ok {
+		report.MaintenanceTrend = &trend
+	}
+
+	byAsset := make(map[string][]domain.IssueAllocation)
+	for _, row := range rows {
+		byAsset[row.AssetName] = append(byAsset[row.AssetName], row.IssueAllocation)
+	}
+	names := make([]string, 0, len(byAsset))
+	for name := range byAsset {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		report.Assets = append(report.Assets, domain.AssetCapitalization{
+			AssetName: name,
+			Status:    statuses.At(name, lastCompleted(byAsset[name])),
+			Summary:   domain.SummarizeAllocations(byAsset[name]),
+		})
+	}
+	return report
+}
+
+// lastCompleted returns when the last of the allocations was completed, or zero when none was
+func lastCompleted(allocations []domain.IssueAllocation) time.Time {
+	var last time.Time
+	for _, allocation := range allocations {
+		if allocation.DateCompleted.After(last) {
+			last = allocation.DateCompleted
+		}
+	}
+	return last
+}
+
+// summaryLines returns the KPI block as label/value pairs
+func summaryLines(kpis domain.CapitalizationKPIs, locale domain.Locale) [][2]string {
+	lines := [][2]string{
+		{"Period", kpis.Period},
+		{"Total hours", locale.Hours(kpis.Overall.TotalHours)},
+		{"Capitalized hours", locale.Hours(kpis.Overall.CapitalizedHours())},
+		{"Capitalized", locale.Percent(kpis.Overall.CapitalizationRatio())},
+	}
+	for _, team := range kpis.Teams {
+		lines = append(lines, [2]string{
+			fmt.Sprintf("Capitalized (%s)", team.Team),
+			locale.Percent(team.Summary.CapitalizationRatio()),
+		})
+	}
+	if len(kpis.Impairments) > 0 {
+		lines = append(lines, [2]string{"Impaired hours", locale.Hours(kpis.Overall.ImpairedHours)})
+		for _, impairment := range kpis.Impairments {
+			lines = append(lines, [2]string{
+				fmt.Sprintf("Impairment (%s, %s)", impairment.AssetName, locale.Date(impairment.Date)),
+				fmt.Sprintf("%s - %s", locale.Number(impairment.Amount, 2), impairment.Reason),
+			})
+		}
+	}
+	if len(kpis.Policies) > 0 {
+		lines = append(lines, [2]string{"Reclassified hours", locale.Hours(kpis.Overall.ReclassifiedHours)})
+		for _, rule := range kpis.Policies {
+			lines = append(lines, [2]string{fmt.Sprintf("Policy (%s)", rule.Name()), rule.String()})
+		}
+	}
+	lines = append(lines,
+		[2]string{"Development", locale.Percent(kpis.Overall.DevelopmentShare())},
+		[2]string{"Maintenance", locale.Percent(kpis.Overall.MaintenanceShare())},
+	)
+	if trend, ok := kpis.DevelopmentTrend(); ok {
+		lines = append(lines, [2]string{fmt.Sprintf("Development vs %s", kpis.PreviousPeriod), locale.PercentagePoints(trend)})
+	}
+	if trend, ok := kpis.MaintenanceTrend(); ok {
+		lines = append(lines, [2]string{fmt.Sprintf("Maintenance vs %s", kpis.PreviousPeriod), locale.PercentagePoints(trend)})
+	}
+	return lines
+}
+
+var reportHeaders = []string{"team", "sprint", "issueKey", "issueType", "issueTitle", "assignee", "workType", "assetName", "status", "hours", "percentage", "evidenceUrl", "assetUrl"}
+
+// reportColumns returns the allocation headers, adding the cost center and GL account when any
+// allocation is booked to them, the impaired flag when impairments apply and the policy column
+// when a policy rule reclassified any allocation
+func reportColumns(kpis domain.CapitalizationKPIs, accounts bool) []string {
+	columns := reportHeaders
+	if accounts {
+		columns = append(append([]string{}, columns...), "costCenter", "glAccount")
+	}
+	if len(kpis.Impairments) > 0 {
+		columns = append(append([]string{}, columns...), "impaired")
+	}
+	if len(kpis.Policies) > 0 {
+		columns = append(append([]string{}, columns...), "policy")
+	}
+	return columns
+}
+
+func reportRecord(row domain.ReportRow, kpis domain.CapitalizationKPIs, accounts bool, locale domain.Locale) []string {
+	record := []string{
+		row.Team,
+		row.Sprint,
+		row.IssueKey,
+		row.IssueType,
+		row.IssueTitle,
+		row.Assignee,
+		row.WorkType,
+		row.AssetName,
+		row.Status,
+		locale.Hours(row.Hours),
+		locale.Percent(row.Percentage),
+		row.EvidenceURL,
+		row.AssetURL,
+	}
+	if accounts {
+		record = append(record, row.CostCenter, row.GLAccount)
+	}
+	if len(kpis.Impairments) > 0 {
+		impaired := ""
+		if row.Impaired {
+			impaired = "yes"
+		}
+		record = append(record, impaired)
+	}
+	if len(kpis.Policies) > 0 {
+		record = append(record, row.Policy)
+	}
+	return record
+}
+
+// renderCSVReport writes the KPI block, a blank line and the allocation rows as CSV
+func renderCSVReport(formatter *CSVFormatter, kpis domain.CapitalizationKPIs, rows []domain.ReportRow, locale domain.Locale) (string, error) {
+	summary := [][]string{{"metric", "value"}}
+	for _, line := range summaryLines(kpis, locale) {
+		summary = append(summary, []string{line[0], line[1]})
+	}
+
+	accounts := hasAccounts(rows)
+	allocations := [][]string{reportColumns(kpis, accounts)}
+	for _, row := range rows {
+		allocations = append(allocations, reportRecord(row, kpis, accounts, locale))
+	}
+
+	blocks := [][][]string{summary, allocations}
+	if len(kpis.Unattributed) > 0 || len(kpis.Heuristics) > 0 || len(kpis.Absences) > 0 || len(kpis.SprintOverlaps) > 0 {
+		blocks = append(blocks, warningRecords(kpis.Unattributed, kpis.Heuristics, kpis.Absences, kpis.SprintOverlaps, nil, locale))
+	}
+	if len(kpis.StaleIssues) > 0 {
+		blocks = append(blocks, staleRecords(kpis.StaleIssues, locale))
+	}
+	if len(kpis.RetiredAssetWork) > 0 {
+		blocks = append(blocks, retiredRecords(kpis.RetiredAssetWork, locale))
+	}
+	csvData, err := formatter.Format(blocks...)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate CSV: %w", err)
+	}
+	return csvData, nil
+}
+
+// renderMarkdownReport renders the KPI block and the allocation rows as Markdown tables
+func renderMarkdownReport(kpis domain.CapitalizationKPIs, rows []domain.ReportRow, locale domain.Locale) string {
+	var b strings.Builder
+
+	b.WriteString("## Capitalization summary\n\n")
+	b.WriteString("| Metric | Value |\n|---|---|\n")
+	for _, line := range summaryLines(kpis, locale) {
+		fmt.Fprintf(&b, "| %s | %s |\n", markdownCell(line[0]), markdownCell(line[1]))
+	}
+
+	accounts := hasAccounts(rows)
+	headers := reportColumns(kpis, accounts)
+	b.WriteString("\n## Allocations\n\n")
+	b.WriteString("| " + strings.Join(markdownRecord(headers), " | ") + " |\n")
+	b.WriteString("|" + strings.Repeat("---|", len(headers)-2) + "\n")
+	for _, row := range rows {
+		record := reportRecord(row, kpis, accounts, locale)
+		for i := range record {
+			record[i] = markdownCell(record[i])
+		}
+		record[2] = markdownLink(record[2], row.EvidenceURL)
+		record[7] = markdownLink(record[7], row.AssetURL)
+		b.WriteString("| " + strings.Join(markdownRecord(record), " | ") + " |\n")
+	}
+
+	if len(kpis.Unattributed) > 0 || len(kpis.Heuristics) > 0 || len(kpis.Absences) > 0 || len(kpis.SprintOverlaps) > 0 {
+		b.WriteString("\n## Warnings\n\n")
+		for _, entry := range kpis.Unattributed {
+			fmt.Fprintf(&b, "- %s: %s hours %s\n", entry.IssueKey, locale.Hours(entry.Hours), markdownCell(entry.Reason()))
+		}
+		for _, heuristic := range kpis.Heuristics {
+			fmt.Fprintf(&b, "- %s: %s hours, %s\n", heuristic.IssueKey, locale.Hours(heuristic.Hours), markdownCell(heuristic.Reason()))
+		}
+		for _, absence := range kpis.Absences {
+			fmt.Fprintf(&b, "- %s: %s hours, %s\n", absence.IssueKey, locale.Hours(absence.Hours), markdownCell(absence.Reason()))
+		}
+		for _, overlap := range kpis.SprintOverlaps {
+			fmt.Fprintf(&b, "- %s: %s hours, %s\n", overlap.IssueKey, locale.Hours(overlap.Hours), markdownCell(overlap.Reason()))
+		}
+	}
+	if len(kpis.StaleIssues) > 0 {
+		b.WriteString("\n## Needs review\n\n")
+		for _, issue := range kpis.StaleIssues {
+			fmt.Fprintf(&b, "- %s: %s hours, %s\n", issue.IssueKey, locale.Hours(issue.Hours), markdownCell(issue.Reason()))
+		}
+	}
+	if len(kpis.RetiredAssetWork) > 0 {
+		b.WriteString("\n## Retired assets\n\n")
+		for _, work := range kpis.RetiredAssetWork {
+			fmt.Fprintf(&b, "- %s: %s hours, %s\n", work.IssueKey, locale.Hours(work.Hours), markdownCell(work.Reason()))
+		}
+	}
+
+	return b.String()
+}
+
+// markdownRecord drops the link columns, which Markdown renders on the issue and asset cells instead
+// hasAccounts reports whether any row is booked to a cost center or GL account
+func hasAccounts(rows []domain.ReportRow) bool {
+	for _, row := range rows {
+		if row.CostCenter != "" || row.GLAccount != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func markdownRecord(record []string) []string {
+	return append(append([]string{}, record[:11]...), record[13:]...)
+}
+
+func markdownCell(value string) string {
+	return strings.ReplaceAll(value, "|", "\\|")
+}
+
+// markdownLink renders text as a link to url, or as plain text without one
+func markdownLink(text, url string) string {
+	if text == "" || url == "" {
+		return text
+	}
+	return fmt.Sprintf("[%s](%s)", text, url)
+}
@@ -0,0 +1,368 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+func reportFactory(data map[string][]domain.IssueAllocation) AllocationCalculatorFactory {
+	return func(project, sprint, _ string) (AllocationCalculator, error) {
+		allocations, ok := data[project+"/"+sprint]
+		if !ok {
+			return &stubAllocationCalculator{err: errors.New("sprint not found")}, nil
+		}
+		return &stubAllocationCalculator{allocations: allocations}, nil
+	}
+}
+
+func reportData() map[string][]domain.IssueAllocation {
+	return map[string][]domain.IssueAllocation{
+		"TEAMA/Sprint 2": {
+			{Sprint: "Sprint 2", IssueKey: "A-1", IssueTitle: "Build checkout", Assignee: "John Doe", WorkType: domain.WorkTypeDevelopment, Hours: 30, Percentage: 75},
+			{Sprint: "Sprint 2", IssueKey: "A-2", IssueTitle: "Fix login", Assignee: "John Doe", WorkType: domain.WorkTypeMaintenance, Hours: 10, Percentage: 25},
+		},
+		"TEAMB/Sprint 2": {
+			{Sprint: "Sprint 2", IssueKey: "B-1", IssueTitle: "Patch | upgrade", Assignee: "Jane Smith", WorkType: domain.WorkTypeMaintenance, Hours: 10, Percentage: 100},
+		},
+		"TEAMA/Sprint 1": {
+			{WorkType: domain.WorkTypeDevelopment, Hours: 10},
+			{WorkType: domain.WorkTypeMaintenance, Hours: 10},
+		},
+		"TEAMB/Sprint 1": {},
+	}
+}
+
+func TestCapitalizationReport_CSV(t *testing.T) {
+	uc := NewCapitalizationReportUseCase(reportFactory(reportData()))
+
+	report, err := uc.Execute(domain.CapitalizationReportInput{
+		Projects: []string{"TEAMA", "TEAMB"},
+		Sprint:   "Sprint 2",
+		Format:   domain.ReportFormatCSV,
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, report, "metric,value\nPeriod,Sprint 2\nTotal hours,50.00\nCapitalized hours,30.00\nCapitalized,60.00%\n")
+	assert.Contains(t, report, "Capitalized (TEAMA),75.00%\n")
+	assert.Contains(t, report, "Capitalized (TEAMB),0.00%\n")
+	assert.NotContains(t, report, "Development vs")
+	assert.Contains(t, report, "\n\nteam,sprint,issueKey,")
+	assert.Contains(t, report, "TEAMB,Sprint 2,B-1,,Patch | upgrade,Jane Smith,cap-maintenance,,,10.00,100.00%,,\n")
+}
+
+func TestCapitalizationReport_Locale(t *testing.T) {
+	uc := NewCapitalizationReportUseCase(reportFactory(reportData()))
+	locale, err := domain.ParseLocale("de-DE")
+	require.NoError(t, err)
+
+	report, err := uc.Execute(domain.CapitalizationReportInput{
+		Projects:       []string{"TEAMA", "TEAMB"},
+		Sprint:         "Sprint 2",
+		PreviousSprint: "Sprint 1",
+		Format:         domain.ReportFormatCSV,
+		Delimiter:      ';',
+		Locale:         locale,
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, report, "Total hours;50,00\n")
+	assert.Contains(t, report, "Capitalized;60,00%\n")
+	assert.Contains(t, report, "Development vs Sprint 1;+10,00 pp\n")
+	assert.Contains(t, report, "TEAMB;Sprint 2;B-1;;Patch | upgrade;Jane Smith;cap-maintenance;;;10,00;100,00%;;\n")
+}
+
+func TestCapitalizationReport_MarkdownWithTrend(t *testing.T) {
+	uc := NewCapitalizationReportUseCase(reportFactory(reportData()))
+
+	report, err := uc.Execute(domain.CapitalizationReportInput{
+		Projects:       []string{"TEAMA", "TEAMB"},
+		Sprint:         "Sprint 2",
+		PreviousSprint: "Sprint 1",
+		Format:         domain.ReportFormatMarkdown,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(report, "## Capitalization summary"))
+	assert.Contains(t, report, "| Development vs Sprint 1 | +10.00 pp |")
+	assert.Contains(t, report, "| Maintenance vs Sprint 1 | -10.00 pp |")
+	assert.Contains(t, report, "Patch \\| upgrade")
+}
+
+func TestCapitalizationReport_Impairments(t *testing.T) {
+	data := reportData()
+	data["TEAMA/Sprint 2"][0].AssetName = "cap-asset-checkout"
+	uc := NewCapitalizationReportUseCase(reportFactory(data))
+
+	report, err := uc.Execute(domain.CapitalizationReportInput{
+		Projects: []string{"TEAMA"},
+		Sprint:   "Sprint 2",
+		Format:   domain.ReportFormatCSV,
+		Impairments: []domain.AssetImpairment{
+			{AssetName: "checkout", Date: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Reason: "Sunset", Amount: 5000},
+			{AssetName: "payments", Date: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Reason: "Unused", Amount: 100},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, report, "Total hours,40.00\nCapitalized hours,0.00\nCapitalized,0.00%\n")
+	assert.Contains(t, report, "Impaired hours,30.00\n\"Impairment (checkout, 2024-03-01)\",5000.00 - Sunset\n")
+	assert.NotContains(t, report, "payments")
+	assert.Contains(t, report, ",percentage,evidenceUrl,assetUrl,impaired\n")
+	assert.Contains(t, report, "TEAMA,Sprint 2,A-1,,Build checkout,John Doe,cap-development,cap-asset-checkout,,30.00,75.00%,,,yes\n")
+	assert.Contains(t, report, "TEAMA,Sprint 2,A-2,,Fix login,John Doe,cap-maintenance,,,10.00,25.00%,,,\n")
+}
+
+func TestCapitalizationReport_BugFixWindow(t *testing.T) {
+	data := reportData()
+	data["TEAMA/Sprint 2"][1].IssueType = "Bug"
+	data["TEAMA/Sprint 2"][1].AssetName = "cap-asset-checkout"
+	data["TEAMA/Sprint 2"][1].DateCompleted = time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC)
+	uc := NewCapitalizationReportUseCase(reportFactory(data))
+
+	report, err := uc.Execute(domain.CapitalizationReportInput{
+		Projects: []string{"TEAMA"},
+		Sprint:   "Sprint 2",
+		Format:   domain.ReportFormatCSV,
+		Policy: []domain.PolicyRule{
+			domain.BugFixWindow{AssetName: "checkout", LaunchDate: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Days: 14},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, report, "Total hours,40.00\nCapitalized hours,40.00\nCapitalized,100.00%\n")
+	assert.Contains(t, report, "Reclassified hours,10.00\nPolicy (bug-fix-window),bugs on checkout within 14 days of launch (2024-03-01) count as development\n")
+	assert.Contains(t, report, ",percentage,evidenceUrl,assetUrl,policy\n")
+	assert.Contains(t, report, "TEAMA,Sprint 2,A-2,Bug,Fix login,John Doe,cap-development,cap-asset-checkout,,10.00,25.00%,,,bug-fix-window\n")
+	assert.Contains(t, report, "TEAMA,Sprint 2,A-1,,Build checkout,John Doe,cap-development,,,30.00,75.00%,,,\n")
+}
+
+func TestCapitalizationReport_Build(t *testing.T) {
+	uc := NewCapitalizationReportUseCase(reportFactory(reportData()))
+
+	report, err := uc.Build(domain.CapitalizationReportInput{Projects: []string{"TEAMA", "TEAMB"}, Sprint: "Sprint 2"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Sprint 2", report.KPIs.Period)
+	assert.Equal(t, 50.0, report.KPIs.Overall.TotalHours)
+	assert.Equal(t, 30.0, report.KPIs.Overall.DevelopmentHours)
+	assert.Len(t, report.Rows, 3)
+
+	_, err = uc.Build(domain.CapitalizationReportInput{Sprint: "Sprint 2"})
+	assert.EqualError(t, err, "at least one project is required")
+}
+
+func TestCapitalizationReport_BuildAssetStatus(t *testing.T) {
+	data := reportData()
+	data["TEAMA/Sprint 2"][0].AssetName = "cap-asset-checkout"
+	data["TEAMA/Sprint 2"][0].DateCompleted = time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC)
+	data["TEAMB/Sprint 2"][0].AssetName = "cap-asset-search"
+	uc := NewCapitalizationReportUseCase(reportFactory(data))
+
+	report, err := uc.Build(domain.CapitalizationReportInput{
+		Projects: []string{"TEAMA", "TEAMB"},
+		Sprint:   "Sprint 2",
+		AssetStatuses: domain.AssetStatuses{
+			"checkout": {{Status: "development"}, {Status: "live", From: time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)}},
+			"search":   {{Status: "beta"}, {Status: "live", From: time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)}},
+		},
+	})
+
+	require.NoError(t, err)
+	statuses := map[string]string{}
+	for _, asset := range report.Assets {
+		statuses[asset.AssetName] = asset.Status
+	}
+	assert.Equal(t, "development", statuses["cap-asset-checkout"], "the status during the period, not today's")
+	assert.Equal(t, "live", statuses["cap-asset-search"], "without completed work the latest status applies")
+	assert.Empty(t, statuses[""])
+}
+
+func TestCapitalizationReport_Errors(t *testing.T) {
+	uc := NewCapitalizationReportUseCase(reportFactory(reportData()))
+
+	_, err := uc.Execute(domain.CapitalizationReportInput{Sprint: "Sprint 2", Format: domain.ReportFormatCSV})
+	assert.EqualError(t, err, "at least one project is required")
+
+	_, err = uc.Execute(domain.CapitalizationReportInput{Projects: []string{"TEAMA"}, Sprint: "Sprint 2", Format: "pdf"})
+	assert.EqualError(t, err, "unsupported report format: pdf")
+
+	_, err = uc.Execute(domain.CapitalizationReportInput{Projects: []string{"TEAMC"}, Sprint: "Sprint 2", Format: domain.ReportFormatCSV})
+	assert.ErrorContains(t, err, "failed to calculate allocations for TEAMC in Sprint 2")
+}
+
+func TestCapitalizationReport_EvidenceLinks(t *testing.T) {
+	data := reportData()
+	data["TEAMA/Sprint 2"][0].AssetName = "cap-asset-checkout"
+	data["TEAMA/Sprint 2"][0].EvidenceURL = "https://jira/browse/A-1"
+	data["TEAMA/Sprint 2"][0].AssetURL = "https://wiki/checkout"
+	uc := NewCapitalizationReportUseCase(reportFactory(data))
+	input := domain.CapitalizationReportInput{Projects: []string{"TEAMA"}, Sprint: "Sprint 2"}
+
+	input.Format = domain.ReportFormatCSV
+	csvReport, err := uc.Execute(input)
+	require.NoError(t, err)
+	assert.Contains(t, csvReport, "75.00%,https://jira/browse/A-1,https://wiki/checkout\n")
+
+	input.Format = domain.ReportFormatMarkdown
+	markdownReport, err := uc.Execute(input)
+	require.NoError(t, err)
+	assert.Contains(t, markdownReport, "| TEAMA | Sprint 2 | [A-1](https://jira/browse/A-1) |")
+	assert.Contains(t, markdownReport, "| [cap-asset-checkout](https://wiki/checkout) |")
+	assert.Contains(t, markdownReport, "| TEAMA | Sprint 2 | A-2 |")
+	assert.NotContains(t, markdownReport, "evidenceUrl")
+}
+
+func TestCapitalizationReport_Accounts(t *testing.T) {
+	data := reportData()
+	data["TEAMA/Sprint 2"][0].AssetName = "cap-asset-checkout"
+	data["TEAMA/Sprint 2"][0].CostCenter = "CC-100"
+	data["TEAMA/Sprint 2"][0].GLAccount = "1710"
+	uc := NewCapitalizationReportUseCase(reportFactory(data))
+	input := domain.CapitalizationReportInput{Projects: []string{"TEAMA"}, Sprint: "Sprint 2"}
+
+	input.Format = domain.ReportFormatCSV
+	csvReport, err := uc.Execute(input)
+	require.NoError(t, err)
+	assert.Contains(t, csvReport, ",evidenceUrl,assetUrl,costCenter,glAccount\n")
+	assert.Contains(t, csvReport, "75.00%,,,CC-100,1710\n")
+	assert.Contains(t, csvReport, "25.00%,,,,\n")
+
+	input.Format = domain.ReportFormatMarkdown
+	markdownReport, err := uc.Execute(input)
+	require.NoError(t, err)
+	assert.Contains(t, markdownReport, "| percentage | costCenter | glAccount |")
+	assert.Contains(t, markdownReport, "| 75.00% | CC-100 | 1710 |")
+}
+
+type unattributedCalculator struct {
+	stubAllocationCalculator
+	unattributed []domain.UnattributedTime
+}
+
+func (c *unattributedCalculator) Unattributed() []domain.UnattributedTime {
+	return c.unattributed
+}
+
+type heuristicCalculator struct {
+	stubAllocationCalculator
+	applied []domain.AppliedHeuristic
+}
+
+func (c *heuristicCalculator) AppliedHeuristics() []domain.AppliedHeuristic {
+	return c.applied
+}
+
+func TestCapitalizationReport_HeuristicWarnings(t *testing.T) {
+	allocations := reportData()["TEAMA/Sprint 2"]
+	uc := NewCapitalizationReportUseCase(func(_, _, _ string) (AllocationCalculator, error) {
+		return &heuristicCalculator{
+			stubAllocationCalculator: stubAllocationCalculator{allocations: allocations},
+			applied:                  []domain.AppliedHeuristic{{IssueKey: "A-1", Heuristic: domain.HeuristicDefaultHours, Hours: 8}},
+		}, nil
+	})
+	input := domain.CapitalizationReportInput{Projects: []string{"TEAMA"}, Sprint: "Sprint 2"}
+
+	input.Format = domain.ReportFormatCSV
+	csvReport, err := uc.Execute(input)
+	require.NoError(t, err)
+	assert.Contains(t, csvReport, "\n\nwarning,issueKey,hours\nno In Progress transition: default 8 h window assumed,A-1,8.00\n")
+
+	input.Format = domain.ReportFormatMarkdown
+	markdownReport, err := uc.Execute(input)
+	require.NoError(t, err)
+	assert.Contains(t, markdownReport, "## Warnings\n\n- A-1: 8.00 hours, no In Progress transition: default 8 h window assumed\n")
+}
+
+type absenceCalculator struct {
+	stubAllocationCalculator
+	absent []domain.AppliedAbsence
+}
+
+func (c *absenceCalculator) AppliedAbsences() []domain.AppliedAbsence {
+	return c.absent
+}
+
+func TestCapitalizationReport_AbsenceWarnings(t *testing.T) {
+	allocations := reportData()["TEAMA/Sprint 2"]
+	uc := NewCapitalizationReportUseCase(func(_, _, _ string) (AllocationCalculator, error) {
+		return &absenceCalculator{
+			stubAllocationCalculator: stubAllocationCalculator{allocations: allocations},
+			absent:                   []domain.AppliedAbsence{{IssueKey: "A-1", Assignee: "alice", Hours: 24}},
+		}, nil
+	})
+	input := domain.CapitalizationReportInput{Projects: []string{"TEAMA"}, Sprint: "Sprint 2"}
+
+	input.Format = domain.ReportFormatCSV
+	csvReport, err := uc.Execute(input)
+	require.NoError(t, err)
+	assert.Contains(t, csvReport, "\n\nwarning,issueKey,hours\nalice was absent: 24 h of In Progress time removed,A-1,24.00\n")
+
+	input.Format = domain.ReportFormatMarkdown
+	markdownReport, err := uc.Execute(input)
+	require.NoError(t, err)
+	assert.Contains(t, markdownReport, "## Warnings\n\n- A-1: 24.00 hours, alice was absent: 24 h of In Progress time removed\n")
+}
+
+func TestCapitalizationReport_UnattributedWarnings(t *testing.T) {
+	allocations := reportData()["TEAMA/Sprint 2"]
+	uc := NewCapitalizationReportUseCase(func(_, _, _ string) (AllocationCalculator, error) {
+		return &unattributedCalculator{
+			stubAllocationCalculator: stubAllocationCalculator{allocations: allocations},
+			unattributed:             []domain.UnattributedTime{{IssueKey: "A-3", Hours: 2.5}},
+		}, nil
+	})
+	input := domain.CapitalizationReportInput{Projects: []string{"TEAMA"}, Sprint: "Sprint 2"}
+
+	input.Format = domain.ReportFormatCSV
+	csvReport, err := uc.Execute(input)
+	require.NoError(t, err)
+	assert.Contains(t, csvReport, "\n\nwarning,issueKey,hours\nin progress while unassigned,A-3,2.50\n")
+
+	input.Format = domain.ReportFormatMarkdown
+	markdownReport, err := uc.Execute(input)
+	require.NoError(t, err)
+	assert.Contains(t, markdownReport, "## Warnings\n\n- A-3: 2.50 hours in progress while unassigned\n")
+}
+
+type retirementCalculator struct {
+	stubAllocationCalculator
+	detached []domain.RetiredAssetWork
+}
+
+func (c *retirementCalculator) RetiredAssetWork() []domain.RetiredAssetWork {
+	return c.detached
+}
+
+func TestCapitalizationReport_RetiredAssets(t *testing.T) {
+	allocations := reportData()["TEAMA/Sprint 2"]
+	uc := NewCapitalizationReportUseCase(func(_, _, _ string) (AllocationCalculator, error) {
+		return &retirementCalculator{
+			stubAllocationCalculator: stubAllocationCalculator{allocations: allocations},
+			detached: []domain.RetiredAssetWork{{
+				IssueKey:  "A-1",
+				AssetName: "cap-asset-checkout",
+				RetiredOn: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+				Started:   time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC),
+				Hours:     30,
+			}},
+		}, nil
+	})
+	input := domain.CapitalizationReportInput{Projects: []string{"TEAMA"}, Sprint: "Sprint 2"}
+
+	input.Format = domain.ReportFormatCSV
+	csvReport, err := uc.Execute(input)
+	require.NoError(t, err)
+	assert.Contains(t, csvReport, "\n\nretired asset,issueKey,hours\ncap-asset-checkout retired on 2024-03-01: work started 2024-03-04 not attributed,A-1,30.00\n")
+
+	input.Format = domain.ReportFormatMarkdown
+	markdownReport, err := uc.Execute(input)
+	require.NoError(t, err)
+	assert.Contains(t, markdownReport, "## Retired assets\n\n- A-1: 30.00 hours, cap-asset-checkout retired on 2024-03-01: work started 2024-03-04 not attributed\n")
+}
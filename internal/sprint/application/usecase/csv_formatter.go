@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"encoding/csv"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultCSVDelimiter is the field delimiter used when none is configured
+const DefaultCSVDelimiter = ','
+
+// formulaPrefixes are the leading characters spreadsheets evaluate as formulas
+const formulaPrefixes = "=+-@"
+
+// signedNumberPattern matches signed numbers, percentages and percentage point deltas, with
+// the decimal point or comma of any export locale
+var signedNumberPattern = regexp.MustCompile(`^[+-][0-9]+([.,][0-9]+)?(%| pp)?$`)
+
+// CSVFormatter renders tabular data as CSV, guarding against spreadsheet formula injection
+type CSVFormatter struct {
+	delimiter rune
+}
+
+// NewCSVFormatter creates a new CSVFormatter, using the default delimiter when none is given
+func NewCSVFormatter(delimiter rune) (*CSVFormatter, error) {
+	if delimiter == 0 {
+		delimiter = DefaultCSVDelimiter
+	}
+	if !validDelimiter(delimiter) {
+		return nil, fmt.Errorf("invalid CSV delimiter: %q", delimiter)
+	}
+	return &CSVFormatter{delimiter: delimiter}, nil
+}
+
+// ParseCSVDelimiter parses a delimiter flag value, accepting a single character or "\t" for tabs
+func ParseCSVDelimiter(value string) (rune, error) {
+	if value == "" {
+		return DefaultCSVDelimiter, nil
+	}
+	if value == `\t` || value == "tab" {
+		return '\t', nil
+	}
+	if utf8.RuneCountInString(value) != 1 {
+		return 0, fmt.Errorf("invalid CSV delimiter %q: must be a single character", value)
+	}
+	delimiter, _ := utf8.DecodeRuneInString(value)
+	if !validDelimiter(delimiter) {
+		return 0, fmt.Errorf("invalid CSV delimiter: %q", value)
+	}
+	return delimiter, nil
+}
+
+// Format renders one or more blocks of records, separated by a blank line
+func (f *CSVFormatter) Format(blocks ...[][]string) (string, error) {
+	buffer := &strings.Builder{}
+	writer := csv.NewWriter(buffer)
+	writer.Comma = f.delimiter
+
+	for i, block := range blocks {
+		if i > 0 {
+			writer.Flush()
+			buffer.WriteString("\n")
+		}
+		for _, record := range block {
+			if err := writer.Write(sanitizeRecord(record)); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+// sanitizeRecord escapes every field of a record that a spreadsheet would evaluate as a formula
+func sanitizeRecord(record []string) []string {
+	sanitized := make([]string, len(record))
+	for i, field := range record {
+		sanitized[i] = sanitizeField(field)
+	}
+	return sanitized
+}
+
+// sanitizeField prefixes a field starting with a formula character with a single quote.
+// Signed numbers such as negative hours or trend deltas are left untouched.
+func sanitizeField(field string) string {
+	if field == "" || !strings.ContainsRune(formulaPrefixes, rune(field[0])) {
+		return field
+	}
+	if signedNumberPattern.MatchString(field) {
+		return field
+	}
+	return "'" + field
+}
+
+// validDelimiter reports whether r can separate CSV fields
+func validDelimiter(r rune) bool {
+	return r != 0 && r != '"' && r != '\r' && r != '\n' && utf8.ValidRune(r) && r != utf8.RuneError
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+func TestCSVFormatter_Delimiter(t *testing.T) {
+	formatter, err := NewCSVFormatter(';')
+	require.NoError(t, err)
+
+	csvData, err := formatter.Format([][]string{{"metric", "value"}, {"Capitalized", "62,50%"}})
+
+	require.NoError(t, err)
+	assert.Equal(t, "metric;value\nCapitalized;62,50%\n", csvData)
+}
+
+func TestCSVFormatter_Blocks(t *testing.T) {
+	formatter, err := NewCSVFormatter(',')
+	require.NoError(t, err)
+
+	csvData, err := formatter.Format([][]string{{"a", "b"}}, [][]string{{"c"}})
+
+	require.NoError(t, err)
+	assert.Equal(t, "a,b\n\nc\n", csvData)
+}
+
+func TestCSVFormatter_FormulaInjection(t *testing.T) {
+	formatter, err := NewCSVFormatter(',')
+	require.NoError(t, err)
+
+	csvData, err := formatter.Format([][]string{
+		{"=HYPERLINK(\"http://evil\")", "+1+1", "-2+3", "@SUM(A1)"},
+		{"-8.50", "+5.00 pp", "-12.50%", "a=b"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "\"'=HYPERLINK(\"\"http://evil\"\")\",'+1+1,'-2+3,'@SUM(A1)\n-8.50,+5.00 pp,-12.50%,a=b\n", csvData)
+}
+
+func TestCSVFormatter_FormulaInjectionDecimalComma(t *testing.T) {
+	locale, err := domain.ParseLocale("de-DE")
+	require.NoError(t, err)
+	formatter, err := NewCSVFormatter(';')
+	require.NoError(t, err)
+
+	csvData, err := formatter.Format([][]string{
+		{locale.PercentagePoints(-1.5), locale.Hours(-2), locale.PercentagePoints(2), locale.Percent(-12.5)},
+		{"-1,5+A1", "=1,5"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "-1,50 pp;-2,00;+2,00 pp;-12,50%\n'-1,5+A1;'=1,5\n", csvData)
+}
+
+func TestNewCSVFormatter_InvalidDelimiter(t *testing.T) {
+	for _, delimiter := range []rune{'"', '\n', '\r'} {
+		_, err := NewCSVFormatter(delimiter)
+		assert.Error(t, err)
+	}
+}
+
+func TestParseCSVDelimiter(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    rune
+		wantErr bool
+	}{
+		{value: "", want: ','},
+		{value: ",", want: ','},
+		{value: ";", want: ';'},
+		{value: `\t`, want: '\t'},
+		{value: "tab", want: '\t'},
+		{value: "|", want: '|'},
+		{value: ";;", wantErr: true},
+		{value: `"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseCSVDelimiter(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+// RenderERPJournal writes the journal lines in the layout's format: delimited lines after a
+// header, or fixed-width lines with text left-aligned and numbers right-aligned. Numbers use a
+// decimal point and two decimals whatever the locale, as ERP imports expect.
+func RenderERPJournal(lines []domain.ERPJournalLine, layout domain.ERPLayout) (string, error) {
+	if err := layout.Validate(); err != nil {
+		return "", err
+	}
+	if layout.Format == domain.ERPFormatFixedWidth {
+		return renderFixedWidth(lines, layout.Columns)
+	}
+
+	formatter, err := NewCSVFormatter(layout.Delimiter)
+	if err != nil {
+		return "", err
+	}
+	records := make([][]string, 0, len(lines)+1)
+	header := make([]string, len(layout.Columns))
+	for i, column := range layout.Columns {
+		header[i] = column.Field
+	}
+	records = append(records, header)
+	for _, line := range lines {
+		record := make([]string, len(layout.Columns))
+		for i, column := range layout.Columns {
+			record[i], _ = erpValue(line, column.Field)
+		}
+		records = append(records, record)
+	}
+	return formatter.Format(records)
+}
+
+// renderFixedWidth pads every field to its column width, failing on values that do not fit
+// rather than truncating an account or amount
+func renderFixedWidth(lines []domain.ERPJournalLine, columns []domain.ERPColumn) (string, error) {
+	var b strings.Builder
+	for _, line := range lines {
+		for _, column := range columns {
+			value, numeric := erpValue(line, column.Field)
+			padding := column.Width - utf8.RuneCountInString(value)
+			if padding < 0 {
+				return "", fmt.Errorf("%s %q of %s is wider than its %d characters", column.Field, value, line.AssetName, column.Width)
+			}
+			if numeric {
+				b.WriteString(strings.Repeat(" ", padding) + value)
+			} else {
+				b.WriteString(value + strings.Repeat(" ", padding))
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String(), nil
+}
+
+// erpValue returns the value of a field of the journal line and whether it is a number
+func erpValue(line domain.ERPJournalLine, field string) (string, bool) {
+	switch field {
+	case domain.ERPFieldPeriod:
+		return line.Period, false
+	case domain.ERPFieldTeam:
+		return line.Team, false
+	case domain.ERPFieldCostCenter:
+		return line.CostCenter, false
+	case domain.ERPFieldGLAccount:
+		return line.GLAccount, false
+	case domain.ERPFieldAsset:
+		return line.AssetName, false
+	case domain.ERPFieldHours:
+		return strconv.FormatFloat(line.Hours, 'f', 2, 64), true
+	case domain.ERPFieldAmount:
+		return strconv.FormatFloat(line.Amount, 'f', 2, 64), true
+	}
+	return "", false
+}
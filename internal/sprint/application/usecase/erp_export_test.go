@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+func erpLines() []domain.ERPJournalLine {
+	return []domain.ERPJournalLine{
+		{Period: "Sprint 2", Team: "TEAMA", CostCenter: "CC-100", GLAccount: "1710", AssetName: "cap-asset-checkout", Hours: 30, Amount: 2250},
+		{Period: "Sprint 2", Team: "TEAMB", CostCenter: "CC-200", GLAccount: "1710", AssetName: "cap-asset-search", Hours: 12.5, Amount: 937.5},
+	}
+}
+
+func TestRenderERPJournal_CSV(t *testing.T) {
+	layout := domain.DefaultERPLayout()
+	layout.Delimiter = ';'
+
+	output, err := RenderERPJournal(erpLines(), layout)
+	require.NoError(t, err)
+	assert.Equal(t, "period;costCenter;glAccount;assetName;hours;amount\n"+
+		"Sprint 2;CC-100;1710;cap-asset-checkout;30.00;2250.00\n"+
+		"Sprint 2;CC-200;1710;cap-asset-search;12.50;937.50\n", output)
+}
+
+func TestRenderERPJournal_FixedWidth(t *testing.T) {
+	layout := domain.ERPLayout{
+		Format: domain.ERPFormatFixedWidth,
+		Columns: []domain.ERPColumn{
+			{Field: domain.ERPFieldCostCenter, Width: 8},
+			{Field: domain.ERPFieldGLAccount, Width: 6},
+			{Field: domain.ERPFieldAmount, Width: 10},
+		},
+	}
+
+	output, err := RenderERPJournal(erpLines(), layout)
+	require.NoError(t, err)
+	assert.Equal(t, "CC-100  1710     2250.00\n"+
+		"CC-200  1710      937.50\n", output)
+
+	layout.Columns[0].Width = 4
+	_, err = RenderERPJournal(erpLines(), layout)
+	assert.EqualError(t, err, `costCenter "CC-100" of cap-asset-checkout is wider than its 4 characters`)
+}
+
+func TestRenderERPJournal_InvalidLayout(t *testing.T) {
+	_, err := RenderERPJournal(erpLines(), domain.ERPLayout{Format: "xml", Columns: domain.DefaultERPLayout().Columns})
+	assert.EqualError(t, err, `unsupported ERP format "xml": use csv or fixed-width`)
+}
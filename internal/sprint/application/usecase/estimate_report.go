@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+// EstimateReportUseCase compares the sprint allocation with the Jira original estimates
+type EstimateReportUseCase struct {
+	calculator AllocationCalculator
+}
+
+// NewEstimateReportUseCase creates a new EstimateReportUseCase instance
+func NewEstimateReportUseCase(calculator AllocationCalculator) *EstimateReportUseCase {
+	return &EstimateReportUseCase{
+		calculator: calculator,
+	}
+}
+
+// Execute computes the allocations and renders a CSV block comparing each issue's hours with
+// its estimate, followed by a block per person
+func (uc *EstimateReportUseCase) Execute(input domain.EstimateComparisonInput) (string, error) {
+	formatter, err := NewCSVFormatter(input.Delimiter)
+	if err != nil {
+		return "", err
+	}
+
+	allocations, err := uc.calculator.Allocate()
+	if err != nil {
+		return "", fmt.Errorf("failed to calculate allocations: %w", err)
+	}
+	if len(allocations) == 0 {
+		return "", fmt.Errorf("no allocated work found in sprint %s", input.Sprint)
+	}
+
+	comparison := domain.CompareEstimates(allocations, input.Tolerance)
+	return formatter.Format(issueEstimateRecords(comparison.Issues, input.Locale), personEstimateRecords(comparison.People, input.Locale))
+}
+
+// issueEstimateRecords lays out the comparison of each issue as a header and one row per issue
+func issueEstimateRecords(issues []domain.IssueEstimate, locale domain.Locale) [][]string {
+	records := [][]string{{"issueKey", "issueTitle", "assignees", "status", "hours", "remainingEstimate", "projectedHours", "originalEstimate", "variance", "variancePercent", "verdict"}}
+	for _, issue := range issues {
+		record := []string{
+			issue.IssueKey,
+			issue.IssueTitle,
+			strings.Join(issue.Assignees, "; "),
+			issue.Status,
+			locale.Hours(issue.Hours),
+			formatEstimate(issue.RemainingEstimate, locale),
+			locale.Hours(issue.ProjectedHours()),
+			formatEstimate(issue.OriginalEstimate, locale),
+			"",
+			"",
+			string(issue.Verdict),
+		}
+		if issue.Estimated() {
+			record[8] = locale.Hours(issue.Variance())
+			record[9] = locale.Percent(issue.VariancePercentage())
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// personEstimateRecords lays out the comparison of each person as a header and one row per person
+func personEstimateRecords(people []domain.PersonEstimate, locale domain.Locale) [][]string {
+	records := [][]string{{"person", "estimatedIssues", "estimatedHours", "projectedHours", "variance", "variancePercent", "overestimated", "underestimated", "unestimatedIssues", "unestimatedHours", "verdict", "systematic"}}
+	for _, person := range people {
+		record := []string{
+			person.Person,
+			fmt.Sprint(person.EstimatedIssues),
+			locale.Hours(person.EstimatedHours),
+			locale.Hours(person.ProjectedHours),
+			"",
+			"",
+			fmt.Sprint(person.Overestimated),
+			fmt.Sprint(person.Underestimated),
+			fmt.Sprint(person.UnestimatedIssues),
+			locale.Hours(person.UnestimatedHours),
+			string(person.Verdict),
+			fmt.Sprint(person.Systematic),
+		}
+		if person.EstimatedHours > 0 {
+			record[4] = locale.Hours(person.Variance())
+			record[5] = locale.Percent(person.VariancePercentage())
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// formatEstimate formats an estimate in hours, leaving missing estimates empty
+func formatEstimate(estimate *float64, locale domain.Locale) string {
+	if estimate == nil {
+		return ""
+	}
+	return locale.Hours(*estimate)
+}
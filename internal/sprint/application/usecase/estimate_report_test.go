@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+func estimate(hours float64) *float64 {
+	return &hours
+}
+
+func TestEstimateReportUseCase_Execute(t *testing.T) {
+	calculator := &stubAllocationCalculator{allocations: []domain.IssueAllocation{
+		{IssueKey: "FN-1", IssueTitle: "Checkout", Assignee: "alice", Status: "Done", StatusCategory: domain.StatusCategoryDone, Hours: 12, OriginalEstimate: estimate(8)},
+		{IssueKey: "FN-2", IssueTitle: "Search", Assignee: "alice", Status: "In Progress", Hours: 2, OriginalEstimate: estimate(4), RemainingEstimate: estimate(2)},
+		{IssueKey: "FN-3", IssueTitle: "Spike", Assignee: "bob", Status: "Done", StatusCategory: domain.StatusCategoryDone, Hours: 3},
+	}}
+
+	output, err := NewEstimateReportUseCase(calculator).Execute(domain.EstimateComparisonInput{Sprint: "Sprint 1"})
+	require.NoError(t, err)
+	assert.Equal(t, "issueKey,issueTitle,assignees,status,hours,remainingEstimate,projectedHours,originalEstimate,variance,variancePercent,verdict\n"+
+		"FN-1,Checkout,alice,Done,12.00,,12.00,8.00,4.00,50.00%,underestimated\n"+
+		"FN-2,Search,alice,In Progress,2.00,2.00,4.00,4.00,0.00,0.00%,accurate\n"+
+		"FN-3,Spike,bob,Done,3.00,,3.00,,,,unestimated\n"+
+		"\n"+
+		"person,estimatedIssues,estimatedHours,projectedHours,variance,variancePercent,overestimated,underestimated,unestimatedIssues,unestimatedHours,verdict,systematic\n"+
+		"alice,2,12.00,16.00,4.00,33.33%,0,1,0,0.00,underestimated,false\n"+
+		"bob,0,0.00,0.00,,,0,0,1,3.00,unestimated,false\n", output)
+}
+
+func TestEstimateReportUseCase_Errors(t *testing.T) {
+	_, err := NewEstimateReportUseCase(&stubAllocationCalculator{err: errors.New("jira down")}).Execute(domain.EstimateComparisonInput{Sprint: "Sprint 1"})
+	assert.ErrorContains(t, err, "failed to calculate allocations: jira down")
+
+	_, err = NewEstimateReportUseCase(&stubAllocationCalculator{}).Execute(domain.EstimateComparisonInput{Sprint: "Sprint 1"})
+	assert.EqualError(t, err, "no allocated work found in sprint Sprint 1")
+
+	_, err = NewEstimateReportUseCase(&stubAllocationCalculator{}).Execute(domain.EstimateComparisonInput{Sprint: "Sprint 1", Delimiter: '"'})
+	assert.Error(t, err)
+}
@@ -0,0 +1,315 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+// explainTimeLayout formats the instants shown in allocation explanations
+const explainTimeLayout = "2006-01-02 15:04 MST"
+
+// Explain traces how the allocation of one issue of the sprint or fix version is computed,
+// replaying the window, overrides, minimums and splits the allocation applies
+func (p *SprintTimeAllocationUseCase) Explain(issueKey string) (*domain.AllocationExplanation, error) {
+	team, issues, manualAdjustments, err := p.prepare()
+	if err != nil {
+		return nil, err
+	}
+
+	var issue *domain.JiraIssue
+	for i := range issues {
+		if strings.EqualFold(issues[i].Key, issueKey) {
+			issue = &issues[i]
+			break
+		}
+	}
+	if issue == nil {
+		return nil, fmt.Errorf("issue %s is not part of %s", issueKey, p.period())
+	}
+
+	method := p.method
+	if method == "" {
+		method = domain.AllocationMethodTime
+	}
+	explanation := &domain.AllocationExplanation{
+		IssueKey:  issue.Key,
+		Summary:   issue.Fields.Summary,
+		IssueType: issue.Fields.IssueType.Name,
+		Status:    issue.Fields.Status.Name,
+		Assignee:  issue.Fields.Assignee.DisplayName,
+		Period:    p.period(),
+		Method:    method,
+	}
+	if issue.Fields.IssueType.IsSubtask() {
+		explanation.Timeline = p.explainTimeline(*issue, time.Time{}, time.Time{}, false)
+		explanation.Steps = []string{"Sub-tasks are skipped: no hours are allocated"}
+		return explanation, nil
+	}
+
+	startTime, endTime, tracked, _, allocated := p.allocationWindow(*issue)
+	_, overridden := manualAdjustments[issue.Key]
+	split := allocated && !overridden && tracked && method != domain.AllocationMethodStoryPoints && endTime.After(startTime)
+	explanation.Timeline = p.explainTimeline(*issue, startTime, endTime, split)
+
+	explanation.Steps = p.explainWindow(*issue)
+	if !allocated {
+		return explanation, nil
+	}
+	explanation.Steps = append(explanation.Steps, p.explainHours(*team, *issue, manualAdjustments, startTime, endTime, split)...)
+
+	works, personHours, _ := p.issueWorks(*team, issues, manualAdjustments)
+	for _, work := range works {
+		if work.issue.Key == issue.Key && work.overlap != nil {
+			explanation.Steps = append(explanation.Steps, fmt.Sprintf("The issue is also in %s, running at the same time: the overlapping time is split evenly, crediting %s%% of the window",
+				strings.Join(work.overlap.Sprints, ", "), formatExplainNumber(math.Round(work.overlap.Share*1000)/10)))
+		}
+		if work.issue.Key == issue.Key && work.raised {
+			hours := formatExplainNumber(work.shares[0].hours)
+			explanation.Steps = append(explanation.Steps, fmt.Sprintf("Completed the same day in under %s h: raised to the %s h minimum", hours, hours))
+		}
+	}
+
+	totalHoursByPerson := p.calculateTotalHours(*team, issues, manualAdjustments)
+	personPoints := p.storyPointsByPerson(works)
+	err = p.calculatePercentageLoad(*team, issues, manualAdjustments, totalHoursByPerson, func(allocation domain.IssueAllocation) error {
+		if allocation.IssueKey != issue.Key {
+			return nil
+		}
+		explanation.Shares = append(explanation.Shares, domain.ExplainedShare{
+			Assignee:   allocation.Assignee,
+			Hours:      allocation.Hours,
+			Percentage: allocation.Percentage,
+			Formula:    p.explainFormula(*issue, allocation, personHours, personPoints, totalHoursByPerson),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(explanation.Shares) == 0 {
+		explanation.Steps = append(explanation.Steps, "No team member is credited: the issue is not allocated")
+	}
+	return explanation, nil
+}
+
+// explainWindow describes the In Progress window of the issue and the fallbacks and release
+// dates applied to it
+func (p *SprintTimeAllocationUseCase) explainWindow(issue domain.JiraIssue) []string {
+	var steps []string
+	startTime, endTime := p.getIssueTimeRange(issue)
+	start, end, resolved := p.resolutionWindow(issue)
+	switch {
+	case issue.ChangelogUnavailable && resolved:
+		startTime, endTime = start, end
+		steps = append(steps, fmt.Sprintf("Changelog unavailable: the window runs from creation or the sprint start, whichever is later, %s, to the resolution date, %s", formatExplainTime(startTime), formatExplainTime(endTime)))
+	case issue.ChangelogUnavailable:
+		steps = append(steps, "Changelog unavailable and the issue is unresolved: the resolution date heuristic does not apply")
+	case !startTime.IsZero() && !endTime.IsZero():
+		steps = append(steps, fmt.Sprintf("In Progress window: %s to %s", formatExplainTime(startTime), formatExplainTime(endTime)))
+	case !startTime.IsZero():
+		steps = append(steps, fmt.Sprintf("In Progress since %s and not completed: the window has no end", formatExplainTime(startTime)))
+	}
+
+	clippedStart, clippedEnd, inRelease := p.releaseWindow(startTime, endTime)
+	if !inRelease {
+		return append(steps, fmt.Sprintf("The window falls outside the dates of fix version %s: no hours are allocated", p.fixVersion))
+	}
+	if !clippedStart.Equal(startTime) || !clippedEnd.Equal(endTime) {
+		steps = append(steps, fmt.Sprintf("Clipped to the dates of fix version %s: %s to %s", p.fixVersion, formatExplainTime(clippedStart), formatExplainTime(clippedEnd)))
+	}
+
+	if startTime.IsZero() {
+		var first time.Time
+		if len(issue.Changelog.Histories) > 0 {
+			first, _ = time.Parse(time.RFC3339, issue.Changelog.Histories[0].Created)
+		}
+		hours, enabled := p.heuristics.UntrackedHours()
+		switch {
+		case !first.IsZero():
+			steps = append(steps, fmt.Sprintf("No In Progress or completion transition: the window starts at the first changelog entry, %s, and has no end", formatExplainTime(first)))
+		case enabled:
+			steps = append(steps, fmt.Sprintf("No In Progress or completion transition: a default %s h window ending now is used", formatExplainNumber(hours)))
+		default:
+			steps = append(steps, "No In Progress or completion transition and the default hours heuristic is disabled: no hours are allocated")
+		}
+	}
+	return steps
+}
+
+// explainHours describes how the window's hours are credited to team members
+func (p *SprintTimeAllocationUseCase) explainHours(team domain.Team, issue domain.JiraIssue, manualAdjustments map[string]float64, startTime, endTime time.Time, split bool) []string {
+	if hours, ok := manualAdjustments[issue.Key]; ok {
+		return append([]string{fmt.Sprintf("Manual override: %s h replace the computed duration", formatExplainNumber(hours))},
+			p.explainAssignee(team, issue)...)
+	}
+
+	if !split {
+		hours := p.calculateWorkingHours(issue.Key, nil, startTime, endTime)
+		step := fmt.Sprintf("Duration: %s to %s = %.2f h", formatExplainTime(startTime), formatExplainTime(endTime), hours)
+		if endTime.IsZero() {
+			step = "The window has no end, so no duration is counted yet: 0.00 h"
+		}
+		return append([]string{step}, p.explainAssignee(team, issue)...)
+	}
+
+	steps := []string{"The window is split between the assignees who held the issue:"}
+	for _, period := range issue.AssignmentPeriods() {
+		from, to, ok := period.Overlap(startTime, endTime)
+		if !ok {
+			continue
+		}
+		hours := p.calculateWorkingHours(issue.Key, nil, from, to)
+		step := fmt.Sprintf("  %s held it from %s to %s = %.2f h", displayAssignee(period.Assignee), formatExplainTime(from), formatExplainTime(to), hours)
+		if assignee, isMember := team.ResolveAssignee(period.AccountID, period.Assignee); !isMember {
+			step += ", unattributed: " + domain.UnattributedTime{Assignee: period.Assignee}.Reason()
+		} else if assignee != period.Assignee {
+			step += fmt.Sprintf(", credited to %s", assignee)
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+// explainAssignee describes whether the current assignee is credited with the issue's hours
+func (p *SprintTimeAllocationUseCase) explainAssignee(team domain.Team, issue domain.JiraIssue) []string {
+	assignee, isMember := team.ResolveAssignee(issue.Fields.Assignee.AccountID, issue.Fields.Assignee.DisplayName)
+	name := issue.Fields.Assignee.DisplayName
+	if name == "" {
+		name = issue.Fields.Assignee.AccountID
+	}
+	switch {
+	case name == "":
+		return []string{"The issue is unassigned: no team member is credited"}
+	case !isMember:
+		return []string{fmt.Sprintf("The current assignee %s is not a team member: no hours are credited", name)}
+	case p.method == domain.AllocationMethodStoryPoints:
+		return []string{fmt.Sprintf("Story points method: the hours go to the current assignee %s", assignee)}
+	default:
+		return []string{fmt.Sprintf("The hours go to the current assignee %s", assignee)}
+	}
+}
+
+// explainFormula spells out how a member's hours and percentage of the issue are calculated
+func (p *SprintTimeAllocationUseCase) explainFormula(issue domain.JiraIssue, allocation domain.IssueAllocation, personHours, personPoints, totalHoursByPerson map[string]float64) []string {
+	assignee := allocation.Assignee
+	if p.method == domain.AllocationMethodStoryPoints {
+		points := p.storyPoints(issue)
+		percentage := fmt.Sprintf("percentage = %s points / %s points across %s's issues × 100 = %.2f%%",
+			formatExplainNumber(points), formatExplainNumber(personPoints[assignee]), assignee, allocation.Percentage)
+		if personPoints[assignee] <= 0 {
+			percentage = fmt.Sprintf("percentage = 0.00%%, as %s has no story points in %s", assignee, p.period())
+		}
+		return []string{
+			percentage,
+			fmt.Sprintf("hours = %.2f h across %s's issues × %.2f%% = %.2f h", personHours[assignee], assignee, allocation.Percentage, allocation.Hours),
+		}
+	}
+
+	if p.method != "" && p.method != domain.AllocationMethodTime {
+		return []string{fmt.Sprintf("hours = %.2f h and percentage = %.2f%%, as allocated by the %s method from the %.2f h tracked across %s's issues",
+			allocation.Hours, allocation.Percentage, p.method, personHours[assignee], assignee)}
+	}
+
+	percentage := fmt.Sprintf("percentage = %.2f h / %.2f h across %s's issues × 100 = %.2f%%",
+		allocation.Hours, personHours[assignee], assignee, allocation.Percentage)
+	if totalHoursByPerson[assignee] == 0 {
+		percentage = fmt.Sprintf("percentage = 0.00%%, as %s has no tracked hours in %s", assignee, p.period())
+	}
+	return []string{fmt.Sprintf("hours = %.2f h", allocation.Hours), percentage}
+}
+
+// explainTimeline annotates the issue's changelog with how each change shaped the allocation,
+// following the same rules as getIssueTimeRange and attributeHours
+func (p *SprintTimeAllocationUseCase) explainTimeline(issue domain.JiraIssue, startTime, endTime time.Time, split bool) []domain.TimelineEvent {
+	events := issue.Timeline()
+	lastCompletion := -1
+	for i, event := range events {
+		if event.Field == "status" && event.ToCategory == domain.StatusCategoryDone {
+			lastCompletion = i
+		}
+	}
+
+	started, inProgress := false, false
+	for i := range events {
+		event := &events[i]
+		item := domain.JiraChangeItem{Field: event.Field}
+		switch {
+		case item.IsStatusChange():
+			switch {
+			case event.ToCategory == domain.StatusCategoryInProgress && !started:
+				event.Counted, event.Note = true, "starts the In Progress window"
+				started, inProgress = true, true
+			case event.ToCategory == domain.StatusCategoryInProgress:
+				event.Note = "back in progress: the window still starts at the first In Progress"
+				inProgress = true
+			case event.ToCategory == domain.StatusCategoryDone:
+				var notes []string
+				if !started {
+					notes = append(notes, "starts the window, as the issue never went In Progress")
+					started = true
+				}
+				if i == lastCompletion {
+					notes = append(notes, "ends the window")
+				}
+				event.Counted = len(notes) > 0
+				if !event.Counted {
+					notes = append(notes, "superseded by a later completion")
+				}
+				event.Note = strings.Join(notes, "; ")
+			case inProgress && event.FromCategory == domain.StatusCategoryInProgress:
+				event.Note = "pause: leaves In Progress, but paused time is not deducted"
+				inProgress = false
+			default:
+				event.Note = "does not change the window"
+			}
+		case item.IsAssigneeChange():
+			switch {
+			case !split:
+				event.Note = "hand-off not split: the hours go to the current assignee"
+			case event.At.After(startTime) && event.At.Before(endTime):
+				event.Counted = true
+				event.Note = fmt.Sprintf("splits the window: later hours go to %s", displayAssignee(event.To))
+			default:
+				event.Note = "outside the In Progress window"
+			}
+		case item.IsStoryPointsChange():
+			if p.method.Uses(domain.AllocationMethodStoryPoints) {
+				event.Counted = true
+				event.Note = fmt.Sprintf("estimate change: the %s estimate is used", p.pointsAt)
+			} else {
+				event.Note = "estimate change: not used by the time method"
+			}
+		case strings.EqualFold(event.Field, "labels"):
+			event.Note = "changes the classification, not the hours"
+		default:
+			event.Note = "does not affect the allocation"
+		}
+	}
+	return events
+}
+
+// displayAssignee names an assignee, or "nobody" for an unassigned period
+func displayAssignee(assignee string) string {
+	if assignee == "" {
+		return "nobody"
+	}
+	return assignee
+}
+
+// formatExplainTime formats an instant of an explanation in UTC, or "now" for an open end
+func formatExplainTime(t time.Time) string {
+	if t.IsZero() {
+		return "now"
+	}
+	return t.UTC().Format(explainTimeLayout)
+}
+
+// formatExplainNumber formats hours or points without trailing zeros
+func formatExplainNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
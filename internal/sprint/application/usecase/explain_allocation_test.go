@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain/ports"
+)
+
+func explainProcessor(t *testing.T, override string) *SprintTimeAllocationUseCase {
+	t.Helper()
+	mockJira := new(MockJiraAdapter)
+	mockJira.On("GetIssuesForSprint", "TEST", "S1").Return([]ports.JiraIssue{
+		{
+			Key:       "TEST-1",
+			Summary:   "Handed over",
+			Assignee:  "John Roe",
+			Status:    "Done",
+			IssueType: "Story",
+			Changelog: ports.JiraChangelog{Histories: []ports.JiraChangeHistory{
+				{Created: "2024-05-01T09:00:00.000+0000", Items: []ports.JiraChangeItem{{Field: "status", FromString: "To Do", ToString: "In Progress"}}},
+				{Created: "2024-05-01T13:00:00.000+0000", Items: []ports.JiraChangeItem{{Field: "assignee", FromString: "Jane Doe", ToString: "John Roe"}}},
+				{Created: "2024-05-01T17:00:00.000+0000", Items: []ports.JiraChangeItem{{Field: "status", FromString: "In Progress", ToString: "Done"}}},
+			}},
+		},
+		{
+			Key:       "TEST-2",
+			Summary:   "Solo",
+			Assignee:  "Jane Doe",
+			Status:    "Done",
+			IssueType: "Story",
+			Changelog: ports.JiraChangelog{Histories: []ports.JiraChangeHistory{
+				{Created: "2024-05-02T09:00:00.000+0000", Items: []ports.JiraChangeItem{{Field: "status", FromString: "To Do", ToString: "In Progress"}}},
+				{Created: "2024-05-02T17:00:00.000+0000", Items: []ports.JiraChangeItem{{Field: "status", FromString: "In Progress", ToString: "Done"}}},
+			}},
+		},
+		{Key: "TEST-3", Summary: "Sub", Assignee: "Jane Doe", Status: "Done", IssueType: "Sub-task"},
+	}, nil)
+
+	return &SprintTimeAllocationUseCase{
+		project:  "TEST",
+		sprint:   "S1",
+		override: override,
+		teams:    domain.TeamMap{"TEST": domain.Team{Team: []string{"Jane Doe", "John Roe"}}},
+		jiraPort: mockJira,
+	}
+}
+
+func TestExplain_SplitBetweenAssignees(t *testing.T) {
+	explanation, err := explainProcessor(t, "").Explain("test-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, "TEST-1", explanation.IssueKey)
+	assert.Equal(t, "S1", explanation.Period)
+	assert.Equal(t, domain.AllocationMethodTime, explanation.Method)
+
+	require.Len(t, explanation.Timeline, 3)
+	assert.True(t, explanation.Timeline[0].Counted)
+	assert.Equal(t, "starts the In Progress window", explanation.Timeline[0].Note)
+	assert.True(t, explanation.Timeline[1].Counted)
+	assert.Equal(t, "splits the window: later hours go to John Roe", explanation.Timeline[1].Note)
+	assert.True(t, explanation.Timeline[2].Counted)
+	assert.Equal(t, "ends the window", explanation.Timeline[2].Note)
+
+	assert.Equal(t, []string{
+		"In Progress window: 2024-05-01 09:00 UTC to 2024-05-01 17:00 UTC",
+		"The window is split between the assignees who held the issue:",
+		"  Jane Doe held it from 2024-05-01 09:00 UTC to 2024-05-01 13:00 UTC = 4.00 h",
+		"  John Roe held it from 2024-05-01 13:00 UTC to 2024-05-01 17:00 UTC = 4.00 h",
+	}, explanation.Steps)
+
+	require.Len(t, explanation.Shares, 2)
+	jane := explanation.Shares[0]
+	assert.Equal(t, "Jane Doe", jane.Assignee)
+	assert.Equal(t, 4.0, jane.Hours)
+	assert.InDelta(t, 33.33, jane.Percentage, 0.01)
+	assert.Equal(t, []string{
+		"hours = 4.00 h",
+		"percentage = 4.00 h / 12.00 h across Jane Doe's issues × 100 = 33.33%",
+	}, jane.Formula)
+	assert.Equal(t, "John Roe", explanation.Shares[1].Assignee)
+	assert.Equal(t, 100.0, explanation.Shares[1].Percentage)
+}
+
+func TestExplain_Blend(t *testing.T) {
+	processor := explainProcessor(t, "")
+	require.NoError(t, processor.UseStrategy("time:1,storypoints:1", domain.PointsAtLatest))
+
+	explanation, err := processor.Explain("TEST-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.AllocationMethod("time:1,storypoints:1"), explanation.Method)
+	assert.Equal(t, "The window is split between the assignees who held the issue:", explanation.Steps[1])
+	require.Len(t, explanation.Shares, 2)
+	// Nothing is estimated, so the story point half credits no hours
+	assert.Equal(t, []string{
+		"hours = 2.00 h and percentage = 16.67%, as allocated by the time:1,storypoints:1 method from the 12.00 h tracked across Jane Doe's issues",
+	}, explanation.Shares[0].Formula)
+}
+
+func TestExplain_Override(t *testing.T) {
+	explanation, err := explainProcessor(t, `{"TEST-2": 6}`).Explain("TEST-2")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"In Progress window: 2024-05-02 09:00 UTC to 2024-05-02 17:00 UTC",
+		"Manual override: 6 h replace the computed duration",
+		"The hours go to the current assignee Jane Doe",
+	}, explanation.Steps)
+	require.Len(t, explanation.Shares, 1)
+	assert.Equal(t, 6.0, explanation.Shares[0].Hours)
+	assert.Equal(t, "percentage = 6.00 h / 10.00 h across Jane Doe's issues × 100 = 60.00%", explanation.Shares[0].Formula[1])
+}
+
+func TestExplain_SubTask(t *testing.T) {
+	explanation, err := explainProcessor(t, "").Explain("TEST-3")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Sub-tasks are skipped: no hours are allocated"}, explanation.Steps)
+	assert.Empty(t, explanation.Shares)
+}
+
+func TestExplain_IssueNotInSprint(t *testing.T) {
+	_, err := explainProcessor(t, "").Explain("TEST-99")
+	require.Error(t, err)
+	assert.Equal(t, "issue TEST-99 is not part of S1", err.Error())
+}
+
+func TestExplain_ChangelogUnavailable(t *testing.T) {
+	mockJira := new(MockJiraAdapter)
+	mockJira.On("GetIssuesForSprint", "TEST", "S1").Return([]ports.JiraIssue{
+		{
+			Key:            "TEST-1",
+			Assignee:       "Jane Doe",
+			Status:         "Done",
+			IssueType:      "Story",
+			Sprints:        []ports.JiraSprint{{Name: "S1", StartDate: "2024-05-02T09:00:00.000+0000"}},
+			Created:        "2024-04-29T10:00:00.000+0000",
+			ResolutionDate: "2024-05-02T17:00:00.000+0000",
+
+			ChangelogUnavailable: true,
+		},
+	}, nil)
+	processor := &SprintTimeAllocationUseCase{
+		project:  "TEST",
+		sprint:   "S1",
+		teams:    domain.TeamMap{"TEST": domain.Team{Team: []string{"Jane Doe"}}},
+		jiraPort: mockJira,
+	}
+
+	explanation, err := processor.Explain("TEST-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Changelog unavailable: the window runs from creation or the sprint start, whichever is later, 2024-05-02 09:00 UTC, to the resolution date, 2024-05-02 17:00 UTC", explanation.Steps[0])
+	require.Len(t, explanation.Shares, 1)
+	assert.Equal(t, "Jane Doe", explanation.Shares[0].Assignee)
+}
+
+func TestExplain_OverlappingSprints(t *testing.T) {
+	mockJira := new(MockJiraAdapter)
+	mockJira.On("GetIssuesForSprint", "TEST", "S1").Return([]ports.JiraIssue{{
+		Key:       "TEST-1",
+		Summary:   "Shared",
+		Assignee:  "Jane Doe",
+		Status:    "Done",
+		IssueType: "Story",
+		Sprints: []ports.JiraSprint{
+			{ID: 1, Name: "S1", State: "active", StartDate: "2024-05-01T00:00:00.000Z", EndDate: "2024-05-14T00:00:00.000Z"},
+			{ID: 2, Name: "Platform 7", State: "active", StartDate: "2024-05-02T13:00:00.000Z", EndDate: "2024-05-16T00:00:00.000Z"},
+		},
+		Changelog: ports.JiraChangelog{Histories: []ports.JiraChangeHistory{
+			{Created: "2024-05-02T09:00:00.000+0000", Items: []ports.JiraChangeItem{{Field: "status", FromString: "To Do", ToString: "In Progress"}}},
+			{Created: "2024-05-02T17:00:00.000+0000", Items: []ports.JiraChangeItem{{Field: "status", FromString: "In Progress", ToString: "Done"}}},
+		}},
+	}}, nil)
+	processor := &SprintTimeAllocationUseCase{
+		project:  "TEST",
+		sprint:   "S1",
+		teams:    domain.TeamMap{"TEST": domain.Team{Team: []string{"Jane Doe"}}},
+		jiraPort: mockJira,
+	}
+
+	explanation, err := processor.Explain("TEST-1")
+	require.NoError(t, err)
+	assert.Contains(t, explanation.Steps, "The issue is also in Platform 7, running at the same time: the overlapping time is split evenly, crediting 75% of the window")
+	require.Len(t, explanation.Shares, 1)
+	assert.Equal(t, 6.0, explanation.Shares[0].Hours)
+}
@@ -0,0 +1,427 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain/ports"
+)
+
+// AllocationCalculator computes the per-issue time allocation of a sprint
+type AllocationCalculator interface {
+	Allocate() ([]domain.IssueAllocation, error)
+}
+
+// UnattributedReporter is implemented by calculators that report the in-progress time
+// of their last allocation that no team member held
+type UnattributedReporter interface {
+	Unattributed() []domain.UnattributedTime
+}
+
+// HeuristicReporter is implemented by calculators that report the issues of their last
+// allocation whose hours were set by a heuristic
+type HeuristicReporter interface {
+	AppliedHeuristics() []domain.AppliedHeuristic
+}
+
+// AbsenceReporter is implemented by calculators that report the in-progress time of their
+// last allocation taken out while the assignees were absent
+type AbsenceReporter interface {
+	AppliedAbsences() []domain.AppliedAbsence
+}
+
+// SprintOverlapReporter is implemented by calculators that report the issues of their last
+// allocation whose time was split with sprints overlapping the allocated one
+type SprintOverlapReporter interface {
+	SprintOverlaps() []domain.SprintOverlap
+}
+
+// StaleIssueReporter is implemented by calculators that report the issues of their last
+// allocation In Progress for longer than the stale threshold
+type StaleIssueReporter interface {
+	StaleIssues() []domain.StaleIssue
+}
+
+// RetirementReporter is implemented by calculators that report the rows of their last
+// allocation taken off assets retired before the work started
+type RetirementReporter interface {
+	RetiredAssetWork() []domain.RetiredAssetWork
+}
+
+// PushAllocationsUseCase writes computed sprint allocations back to Jira
+type PushAllocationsUseCase struct {
+	calculator  AllocationCalculator
+	comments    ports.JiraCommentPort
+	worklogs    ports.JiraWorklogPort
+	checkpoints ports.PushCheckpointStore
+}
+
+// NewPushAllocationsUseCase creates a new PushAllocationsUseCase instance
+func NewPushAllocationsUseCase(calculator AllocationCalculator, comments ports.JiraCommentPort) *PushAllocationsUseCase {
+	return &PushAllocationsUseCase{
+		calculator: calculator,
+		comments:   comments,
+	}
+}
+
+// UseWorklogs enables the worklog mode, logging the allocated hours on the issues
+func (uc *PushAllocationsUseCase) UseWorklogs(worklogs ports.JiraWorklogPort) {
+	uc.worklogs = worklogs
+}
+
+// UseCheckpoints persists the progress of pushes in the store, so a push stopped midway
+// resumes with the rows it did not write
+func (uc *PushAllocationsUseCase) UseCheckpoints(checkpoints ports.PushCheckpointStore) {
+	uc.checkpoints = checkpoints
+}
+
+// pushItem is the comment or worklog a push writes for an allocation row
+type pushItem struct {
+	issueKey string
+	// key identifies the comment or worklog of the row across pushes: the issue for
+	// comments, and the domain.WorklogKey of the row for worklogs
+	key string
+	// digest fingerprints the content written
+	digest string
+	// preview is printed instead of writing on a dry run
+	preview string
+	// write upserts the comment or worklog, reporting whether it was created
+	write func() (bool, error)
+}
+
+// Execute computes the allocations and pushes them to Jira using the requested mode, in
+// chunks of input.ChunkSize rows with a checkpoint saved after each chunk. Rows an earlier
+// push that stopped midway already wrote are left alone. Failures on individual issues are
+// recorded in the result instead of aborting the run, except rate limiting, which stops the
+// push until it is run again.
+func (uc *PushAllocationsUseCase) Execute(input domain.PushAllocationsInput) (*domain.PushResult, error) {
+	switch input.Mode {
+	case domain.PushModeComment:
+	case domain.PushModeWorklog:
+		if uc.worklogs == nil {
+			return nil, fmt.Errorf("jira integration does not support worklogs")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported push mode: %s", input.Mode)
+	}
+
+	allocations, err := uc.calculator.Allocate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate allocations: %w", err)
+	}
+
+	items := uc.pushItems(allocations, input.Mode)
+	if input.DryRun {
+		for _, item := range items {
+			fmt.Print(item.preview)
+		}
+		return &domain.PushResult{}, nil
+	}
+
+	checkpoint, err := uc.loadCheckpoint(input)
+	if err != nil {
+		return nil, err
+	}
+	chunkSize := input.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = domain.DefaultPushChunkSize
+	}
+
+	result := &domain.PushResult{}
+	for start := 0; start < len(items); start += chunkSize {
+		end := min(start+chunkSize, len(items))
+		for i, item := range items[start:end] {
+			if checkpoint.Written(item.key, item.digest) {
+				result.Resumed = append(result.Resumed, item.issueKey)
+				continue
+			}
+
+			created, err := item.write()
+			if errors.Is(err, domain.ErrRateLimited) {
+				for _, remaining := range items[start+i:] {
+					result.Remaining = append(result.Remaining, remaining.issueKey)
+				}
+				return result, uc.saveCheckpoint(checkpoint)
+			}
+			if err != nil {
+				fmt.Printf("Warning: skipping %s: %v\n", item.issueKey, err)
+				result.Failures = append(result.Failures, domain.PushFailure{IssueKey: item.issueKey, Err: err})
+				continue
+			}
+			checkpoint.Record(item.key, item.digest)
+			if created {
+				result.Created = append(result.Created, item.issueKey)
+			} else {
+				result.Updated = append(result.Updated, item.issueKey)
+			}
+		}
+
+		if end < len(items) {
+			if err := uc.saveCheckpoint(checkpoint); err != nil {
+				return result, err
+			}
+			fmt.Printf("Pushed %d of %d rows\n", end, len(items))
+		}
+	}
+
+	// A push with failures keeps its checkpoint, so running it again retries only them
+	if len(result.Failures) > 0 {
+		return result, uc.saveCheckpoint(checkpoint)
+	}
+	if uc.checkpoints != nil {
+		if err := uc.checkpoints.Delete(input.Project, input.Sprint, input.Mode); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// loadCheckpoint returns the checkpoint of an earlier push of the same sprint and mode that
+// stopped midway, or an empty one when there is none or the push restarts
+func (uc *PushAllocationsUseCase) loadCheckpoint(input domain.PushAllocationsInput) (*domain.PushCheckpoint, error) {
+	fresh := domain.NewPushCheckpoint(input.Project, input.Sprint, input.Mode)
+	if uc.checkpoints == nil {
+		return fresh, nil
+	}
+	if input.Restart {
+		return fresh, uc.checkpoints.Delete(input.Project, input.Sprint, input.Mode)
+	}
+
+	checkpoint, err := uc.checkpoints.Load(input.Project, input.Sprint, input.Mode)
+	if err != nil {
+		return nil, err
+	}
+	if checkpoint == nil {
+		return fresh, nil
+	}
+	fmt.Printf("Resuming the push of %s %s: %d rows were pushed on %s\n",
+		input.Project, input.Sprint, len(checkpoint.Pushed), checkpoint.UpdatedAt.Format("2006-01-02 15:04"))
+	return checkpoint, nil
+}
+
+// saveCheckpoint persists the progress of the push
+func (uc *PushAllocationsUseCase) saveCheckpoint(checkpoint *domain.PushCheckpoint) error {
+	if uc.checkpoints == nil {
+		return nil
+	}
+	checkpoint.UpdatedAt = time.Now()
+	return uc.checkpoints.Save(checkpoint)
+}
+
+// pushItems returns the comments or worklogs to write for the allocation rows: one comment
+// per issue covering all its rows, or one worklog per row. Rows without hours get no worklog,
+// as Jira rejects empty worklogs.
+func (uc *PushAllocationsUseCase) pushItems(allocations []domain.IssueAllocation, mode domain.PushMode) []pushItem {
+	if mode == domain.PushModeComment {
+		return uc.commentItems(allocations)
+	}
+
+	var logged []domain.IssueAllocation
+	issueRows := make(map[string][]worklogRow)
+	for _, allocation := range allocations {
+		row := newWorklogRow(allocation)
+		if row.worklog.TimeSpentSeconds == 0 {
+			continue
+		}
+		logged = append(logged, allocation)
+		issueRows[allocation.IssueKey] = append(issueRows[allocation.IssueKey], row)
+	}
+
+	items := make([]pushItem, 0, len(logged))
+	for _, allocation := range logged {
+		row := newWorklogRow(allocation)
+		issueKey, worklog := allocation.IssueKey, row.worklog
+		items = append(items, pushItem{
+			issueKey: issueKey,
+			key:      row.key.String(),
+			digest:   domain.PushDigest(fmt.Sprintf("%s\n%s\n%d", worklog.Comment, worklog.Started.Format("2006-01-02"), worklog.TimeSpentSeconds)),
+			preview:  fmt.Sprintf("Would log %sh on %s from %s:\n%s\n\n", domain.Locale{}.Hours(allocation.Hours), issueKey, worklog.Started.Format("2006-01-02"), worklog.Comment),
+			write:    func() (bool, error) { return uc.upsertWorklog(row, issueRows[issueKey]) },
+		})
+	}
+	return items
+}
+
+// commentItems returns the comment to write on each issue, in the order the issues first
+// appear, covering every assignee and work type the issue was allocated to
+func (uc *PushAllocationsUseCase) commentItems(allocations []domain.IssueAllocation) []pushItem {
+	var issueKeys []string
+	rows := make(map[string][]domain.IssueAllocation)
+	for _, allocation := range allocations {
+		if _, seen := rows[allocation.IssueKey]; !seen {
+			issueKeys = append(issueKeys, allocation.IssueKey)
+		}
+		rows[allocation.IssueKey] = append(rows[allocation.IssueKey], allocation)
+	}
+
+	items := make([]pushItem, 0, len(issueKeys))
+	for _, issueKey := range issueKeys {
+		issueKey, body := issueKey, FormatAllocationComment(rows[issueKey]...)
+		items = append(items, pushItem{
+			issueKey: issueKey,
+			key:      issueKey,
+			digest:   domain.PushDigest(body),
+			preview:  fmt.Sprintf("Would comment on %s:\n%s\n\n", issueKey, body),
+			write:    func() (bool, error) { return uc.upsertComment(issueKey, body) },
+		})
+	}
+	return items
+}
+
+// upsertComment updates the existing assetcap comment of an issue or adds a new one.
+// It reports whether a new comment was created.
+func (uc *PushAllocationsUseCase) upsertComment(issueKey, body string) (bool, error) {
+	comments, err := uc.comments.GetComments(issueKey)
+	if err != nil {
+		return false, err
+	}
+
+	for _, comment := range comments {
+		if strings.HasPrefix(strings.TrimSpace(comment.Body), domain.AllocationCommentMarker) {
+			return false, uc.comments.UpdateComment(issueKey, comment.ID, body)
+		}
+	}
+
+	return true, uc.comments.AddComment(issueKey, body)
+}
+
+// worklogRow is the worklog of an allocation row with the key finding it on later pushes
+type worklogRow struct {
+	key     domain.WorklogKey
+	worklog ports.JiraWorklog
+	// sprintLine starts the first line of the worklogs earlier pushes logged for the sprint
+	// before worklogs carried their key
+	sprintLine string
+}
+
+func newWorklogRow(allocation domain.IssueAllocation) worklogRow {
+	return worklogRow{
+		key:        domain.AllocationWorklogKey(allocation),
+		worklog:    allocationWorklog(allocation),
+		sprintLine: fmt.Sprintf("%s %s | ", domain.AllocationWorklogMarker, allocation.Sprint),
+	}
+}
+
+// logs reports whether an existing worklog logs the row: it carries the key of the row, or it
+// carries no key and opens with the same line
+func (r worklogRow) logs(worklog ports.JiraWorklog) bool {
+	if key, ok := domain.ParseWorklogKey(worklog.Comment); ok {
+		return key == r.key
+	}
+	return firstLine(worklog.Comment) == firstLine(r.worklog.Comment)
+}
+
+// sameSprint reports whether an existing worklog was logged by assetcap for the issue and
+// sprint of the row
+func (r worklogRow) sameSprint(worklog ports.JiraWorklog) bool {
+	if key, ok := domain.ParseWorklogKey(worklog.Comment); ok {
+		return key.IssueKey == r.key.IssueKey && key.Sprint == r.key.Sprint
+	}
+	return strings.HasPrefix(firstLine(worklog.Comment), r.sprintLine)
+}
+
+// upsertWorklog updates the worklog an earlier push logged for the allocation row or adds a
+// new one. The other assetcap worklogs of the issue and sprint that log none of the issue's
+// rows are deleted, such as those of an assignee taken off the issue, along with duplicates
+// of the row's worklog. It reports whether a new worklog was created.
+func (uc *PushAllocationsUseCase) upsertWorklog(row worklogRow, issueRows []worklogRow) (bool, error) {
+	issueKey := row.key.IssueKey
+	worklogs, err := uc.worklogs.GetWorklogs(issueKey)
+	if err != nil {
+		return false, err
+	}
+
+	var logged []ports.JiraWorklog
+	for _, existing := range worklogs {
+		if row.logs(existing) {
+			logged = append(logged, existing)
+			continue
+		}
+		if !row.sameSprint(existing) || slices.ContainsFunc(issueRows, func(other worklogRow) bool { return other.logs(existing) }) {
+			continue
+		}
+		if err := uc.worklogs.DeleteWorklog(issueKey, existing.ID); err != nil {
+			return false, err
+		}
+	}
+
+	if len(logged) == 0 {
+		return true, uc.worklogs.AddWorklog(issueKey, row.worklog)
+	}
+	for _, duplicate := range logged[1:] {
+		if err := uc.worklogs.DeleteWorklog(issueKey, duplicate.ID); err != nil {
+			return false, err
+		}
+	}
+	worklog := row.worklog
+	worklog.ID = logged[0].ID
+	return false, uc.worklogs.UpdateWorklog(issueKey, worklog)
+}
+
+// allocationWorklog builds the worklog of an allocation row. It starts when the work started,
+// or else when it was completed. Its comment opens with the row it logs, e.g.
+// "[assetcap:worklog] Sprint 1 | Jane Doe | cap-development", and ends with the key of the row.
+func allocationWorklog(allocation domain.IssueAllocation) ports.JiraWorklog {
+	started := allocation.DateStarted
+	if started.IsZero() {
+		started = allocation.DateCompleted
+	}
+	if started.IsZero() {
+		started = time.Now()
+	}
+
+	lines := []string{
+		fmt.Sprintf("%s %s | %s | %s", domain.AllocationWorklogMarker, allocation.Sprint, valueOrNone(allocation.Assignee), valueOrNone(allocation.WorkType)),
+		fmt.Sprintf("Allocation: %s", domain.Locale{}.Percent(allocation.Percentage)),
+		fmt.Sprintf("Asset: %s", valueOrNone(allocation.AssetName)),
+		domain.AllocationWorklogKey(allocation).String(),
+	}
+	return ports.JiraWorklog{
+		Comment:          strings.Join(lines, "\n"),
+		Started:          started,
+		TimeSpentSeconds: int(math.Round(allocation.Hours * 3600)),
+	}
+}
+
+// firstLine returns the first line of a text, trimmed
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	return strings.TrimSpace(line)
+}
+
+// FormatAllocationComment renders the structured comment posted for the allocation rows of an
+// issue, with one block per assignee and work type
+func FormatAllocationComment(allocations ...domain.IssueAllocation) string {
+	if len(allocations) == 0 {
+		return domain.AllocationCommentMarker
+	}
+	lines := []string{
+		domain.AllocationCommentMarker,
+		fmt.Sprintf("Sprint: %s", allocations[0].Sprint),
+	}
+	for i, allocation := range allocations {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines,
+			fmt.Sprintf("Assignee: %s", allocation.Assignee),
+			fmt.Sprintf("Hours: %s", domain.Locale{}.Hours(allocation.Hours)),
+			fmt.Sprintf("Allocation: %s", domain.Locale{}.Percent(allocation.Percentage)),
+			fmt.Sprintf("Work type: %s", valueOrNone(allocation.WorkType)),
+			fmt.Sprintf("Asset: %s", valueOrNone(allocation.AssetName)),
+		)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
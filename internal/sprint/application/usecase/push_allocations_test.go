@@ -0,0 +1,401 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain/ports"
+)
+
+type stubAllocationCalculator struct {
+	allocations []domain.IssueAllocation
+	err         error
+}
+
+func (s *stubAllocationCalculator) Allocate() ([]domain.IssueAllocation, error) {
+	return s.allocations, s.err
+}
+
+type MockCommentPort struct {
+	mock.Mock
+}
+
+func (m *MockCommentPort) GetComments(issueKey string) ([]ports.JiraComment, error) {
+	args := m.Called(issueKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]ports.JiraComment), args.Error(1)
+}
+
+func (m *MockCommentPort) AddComment(issueKey, body string) error {
+	return m.Called(issueKey, body).Error(0)
+}
+
+func (m *MockCommentPort) UpdateComment(issueKey, commentID, body string) error {
+	return m.Called(issueKey, commentID, body).Error(0)
+}
+
+func testAllocations() []domain.IssueAllocation {
+	return []domain.IssueAllocation{
+		{Sprint: "Sprint 1", IssueKey: "TEST-1", Assignee: "John Doe", WorkType: "Development", AssetName: "Booking", Hours: 40, Percentage: 50},
+		{Sprint: "Sprint 1", IssueKey: "TEST-2", Assignee: "Jane Smith", Hours: 20, Percentage: 25},
+	}
+}
+
+func TestPushAllocations_CreatesAndUpdatesComments(t *testing.T) {
+	allocations := testAllocations()
+	comments := new(MockCommentPort)
+	comments.On("GetComments", "TEST-1").Return([]ports.JiraComment{
+		{ID: "1", Body: "unrelated"},
+		{ID: "2", Body: domain.AllocationCommentMarker + "\nHours: 10.00"},
+	}, nil)
+	comments.On("UpdateComment", "TEST-1", "2", FormatAllocationComment(allocations[0])).Return(nil)
+	comments.On("GetComments", "TEST-2").Return([]ports.JiraComment{}, nil)
+	comments.On("AddComment", "TEST-2", FormatAllocationComment(allocations[1])).Return(nil)
+
+	uc := NewPushAllocationsUseCase(&stubAllocationCalculator{allocations: allocations}, comments)
+	result, err := uc.Execute(domain.PushAllocationsInput{Mode: domain.PushModeComment})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"TEST-2"}, result.Created)
+	assert.Equal(t, []string{"TEST-1"}, result.Updated)
+	assert.Empty(t, result.Failures)
+	comments.AssertExpectations(t)
+}
+
+func TestPushAllocations_OneCommentPerIssue(t *testing.T) {
+	allocations := []domain.IssueAllocation{
+		{Sprint: "Sprint 1", IssueKey: "TEST-1", Assignee: "John Doe", WorkType: "cap-development", AssetName: "Booking", Hours: 6, Percentage: 30},
+		{Sprint: "Sprint 1", IssueKey: "TEST-2", Assignee: "John Doe", Hours: 4, Percentage: 20},
+		{Sprint: "Sprint 1", IssueKey: "TEST-1", Assignee: "Jane Smith", WorkType: "cap-maintenance", AssetName: "Booking", Hours: 2, Percentage: 10},
+	}
+	body := "[assetcap:allocation]\nSprint: Sprint 1\n" +
+		"Assignee: John Doe\nHours: 6.00\nAllocation: 30.00%\nWork type: cap-development\nAsset: Booking\n\n" +
+		"Assignee: Jane Smith\nHours: 2.00\nAllocation: 10.00%\nWork type: cap-maintenance\nAsset: Booking"
+	comments := new(MockCommentPort)
+	comments.On("GetComments", "TEST-1").Return([]ports.JiraComment{}, nil).Once()
+	comments.On("AddComment", "TEST-1", body).Return(nil).Once()
+	comments.On("GetComments", "TEST-2").Return([]ports.JiraComment{}, nil)
+	comments.On("AddComment", "TEST-2", FormatAllocationComment(allocations[1])).Return(nil)
+	checkpoints := newMemoryPushCheckpoints()
+
+	uc := NewPushAllocationsUseCase(&stubAllocationCalculator{allocations: allocations}, comments)
+	uc.UseCheckpoints(checkpoints)
+	result, err := uc.Execute(domain.PushAllocationsInput{Project: "TEST", Sprint: "Sprint 1", Mode: domain.PushModeComment, ChunkSize: 1})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"TEST-1", "TEST-2"}, result.Created, "both assignees of TEST-1 share its comment")
+	assert.Equal(t, 1, checkpoints.saves, "one checkpoint between the two issues")
+	comments.AssertExpectations(t)
+}
+
+func TestPushAllocations_DryRunDoesNotWrite(t *testing.T) {
+	comments := new(MockCommentPort)
+
+	uc := NewPushAllocationsUseCase(&stubAllocationCalculator{allocations: testAllocations()}, comments)
+	result, err := uc.Execute(domain.PushAllocationsInput{Mode: domain.PushModeComment, DryRun: true})
+
+	require.NoError(t, err)
+	assert.Empty(t, result.Created)
+	assert.Empty(t, result.Updated)
+	comments.AssertNotCalled(t, "GetComments", mock.Anything)
+	comments.AssertNotCalled(t, "AddComment", mock.Anything, mock.Anything)
+}
+
+func TestPushAllocations_RecordsFailuresAndContinues(t *testing.T) {
+	comments := new(MockCommentPort)
+	comments.On("GetComments", "TEST-1").Return(nil, errors.New("forbidden"))
+	comments.On("GetComments", "TEST-2").Return([]ports.JiraComment{}, nil)
+	comments.On("AddComment", "TEST-2", mock.Anything).Return(nil)
+
+	uc := NewPushAllocationsUseCase(&stubAllocationCalculator{allocations: testAllocations()}, comments)
+	result, err := uc.Execute(domain.PushAllocationsInput{Mode: domain.PushModeComment})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"TEST-2"}, result.Created)
+	require.Len(t, result.Failures, 1)
+	assert.Equal(t, "TEST-1", result.Failures[0].IssueKey)
+}
+
+func TestPushAllocations_Errors(t *testing.T) {
+	t.Run("unsupported mode", func(t *testing.T) {
+		uc := NewPushAllocationsUseCase(&stubAllocationCalculator{}, new(MockCommentPort))
+		_, err := uc.Execute(domain.PushAllocationsInput{Mode: "field"})
+		assert.EqualError(t, err, "unsupported push mode: field")
+	})
+
+	t.Run("worklogs not supported", func(t *testing.T) {
+		uc := NewPushAllocationsUseCase(&stubAllocationCalculator{}, new(MockCommentPort))
+		_, err := uc.Execute(domain.PushAllocationsInput{Mode: domain.PushModeWorklog})
+		assert.EqualError(t, err, "jira integration does not support worklogs")
+	})
+
+	t.Run("calculation failure", func(t *testing.T) {
+		uc := NewPushAllocationsUseCase(&stubAllocationCalculator{err: errors.New("boom")}, new(MockCommentPort))
+		_, err := uc.Execute(domain.PushAllocationsInput{Mode: domain.PushModeComment})
+		assert.ErrorContains(t, err, "failed to calculate allocations")
+	})
+}
+
+type MockWorklogPort struct {
+	mock.Mock
+}
+
+func (m *MockWorklogPort) GetWorklogs(issueKey string) ([]ports.JiraWorklog, error) {
+	args := m.Called(issueKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]ports.JiraWorklog), args.Error(1)
+}
+
+func (m *MockWorklogPort) AddWorklog(issueKey string, worklog ports.JiraWorklog) error {
+	return m.Called(issueKey, worklog).Error(0)
+}
+
+func (m *MockWorklogPort) UpdateWorklog(issueKey string, worklog ports.JiraWorklog) error {
+	return m.Called(issueKey, worklog).Error(0)
+}
+
+func (m *MockWorklogPort) DeleteWorklog(issueKey, worklogID string) error {
+	return m.Called(issueKey, worklogID).Error(0)
+}
+
+func TestPushAllocations_Worklogs(t *testing.T) {
+	started := time.Date(2024, 3, 18, 9, 0, 0, 0, time.UTC)
+	allocations := []domain.IssueAllocation{
+		{Sprint: "Sprint 1", IssueKey: "TEST-1", Assignee: "John Doe", WorkType: "cap-development", AssetName: "Booking", Hours: 7.5, Percentage: 50, DateStarted: started},
+		{Sprint: "Sprint 1", IssueKey: "TEST-1", Assignee: "Jane Smith", Hours: 2, Percentage: 10, DateCompleted: started.AddDate(0, 0, 2)},
+		{Sprint: "Sprint 1", IssueKey: "TEST-2", Assignee: "Jane Smith", Hours: 4, Percentage: 20, DateStarted: started},
+		{Sprint: "Sprint 1", IssueKey: "TEST-3", Assignee: "Jane Smith", Hours: 0, DateStarted: started},
+	}
+	worklogs := new(MockWorklogPort)
+	worklogs.On("GetWorklogs", "TEST-1").Return([]ports.JiraWorklog{
+		{ID: "9", Comment: "worked on it"},
+		{ID: "10", Comment: "[assetcap:worklog] Sprint 1 | John Doe | cap-development\nAllocation: 40.00%"},
+	}, nil)
+	worklogs.On("UpdateWorklog", "TEST-1", ports.JiraWorklog{
+		ID:               "10",
+		Comment:          "[assetcap:worklog] Sprint 1 | John Doe | cap-development\nAllocation: 50.00%\nAsset: Booking\n[assetcap:key] TEST-1 | Sprint 1 | John Doe",
+		Started:          started,
+		TimeSpentSeconds: 27000,
+	}).Return(nil)
+	worklogs.On("AddWorklog", "TEST-1", ports.JiraWorklog{
+		Comment:          "[assetcap:worklog] Sprint 1 | Jane Smith | none\nAllocation: 10.00%\nAsset: none\n[assetcap:key] TEST-1 | Sprint 1 | Jane Smith",
+		Started:          started.AddDate(0, 0, 2),
+		TimeSpentSeconds: 7200,
+	}).Return(nil)
+	worklogs.On("GetWorklogs", "TEST-2").Return(nil, errors.New("forbidden"))
+
+	uc := NewPushAllocationsUseCase(&stubAllocationCalculator{allocations: allocations}, nil)
+	uc.UseWorklogs(worklogs)
+	result, err := uc.Execute(domain.PushAllocationsInput{Mode: domain.PushModeWorklog})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"TEST-1"}, result.Created)
+	assert.Equal(t, []string{"TEST-1"}, result.Updated)
+	require.Len(t, result.Failures, 1, "a failing issue is skipped")
+	assert.Equal(t, "TEST-2", result.Failures[0].IssueKey)
+	worklogs.AssertExpectations(t)
+	worklogs.AssertNotCalled(t, "GetWorklogs", "TEST-3")
+}
+
+func TestPushAllocations_WorklogsKeyedByStableIDs(t *testing.T) {
+	started := time.Date(2024, 3, 18, 9, 0, 0, 0, time.UTC)
+	allocations := []domain.IssueAllocation{
+		{Sprint: "Sprint 1 (renamed)", SprintID: 42, IssueKey: "TEST-1", Assignee: "Jane Smith-Doe", AssigneeAccountID: "acc-jane", Hours: 3, Percentage: 15, DateStarted: started},
+	}
+	worklogs := new(MockWorklogPort)
+	worklogs.On("GetWorklogs", "TEST-1").Return([]ports.JiraWorklog{
+		{ID: "1", Comment: "[assetcap:worklog] Sprint 1 | Jane Smith | none\nAllocation: 10.00%\nAsset: none\n[assetcap:key] TEST-1 | 42 | acc-jane"},
+		{ID: "2", Comment: "[assetcap:worklog] Sprint 1 | John Doe | none\nAllocation: 5.00%\nAsset: none\n[assetcap:key] TEST-1 | 42 | acc-john"},
+		{ID: "3", Comment: "[assetcap:worklog] Sprint 1 | Jane Smith | none\nAllocation: 10.00%\nAsset: none\n[assetcap:key] TEST-1 | 42 | acc-jane"},
+		{ID: "4", Comment: "[assetcap:worklog] Sprint 0 | John Doe | none\nAllocation: 5.00%\nAsset: none\n[assetcap:key] TEST-1 | 41 | acc-john"},
+		{ID: "5", Comment: "worked on it"},
+	}, nil)
+	worklogs.On("UpdateWorklog", "TEST-1", ports.JiraWorklog{
+		ID:               "1",
+		Comment:          "[assetcap:worklog] Sprint 1 (renamed) | Jane Smith-Doe | none\nAllocation: 15.00%\nAsset: none\n[assetcap:key] TEST-1 | 42 | acc-jane",
+		Started:          started,
+		TimeSpentSeconds: 10800,
+	}).Return(nil)
+	worklogs.On("DeleteWorklog", "TEST-1", "2").Return(nil)
+	worklogs.On("DeleteWorklog", "TEST-1", "3").Return(nil)
+
+	uc := NewPushAllocationsUseCase(&stubAllocationCalculator{allocations: allocations}, nil)
+	uc.UseWorklogs(worklogs)
+	result, err := uc.Execute(domain.PushAllocationsInput{Mode: domain.PushModeWorklog})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"TEST-1"}, result.Updated, "renaming the sprint and the assignee keeps the worklog")
+	assert.Empty(t, result.Created)
+	worklogs.AssertExpectations(t)
+	worklogs.AssertNotCalled(t, "DeleteWorklog", "TEST-1", "4")
+	worklogs.AssertNotCalled(t, "DeleteWorklog", "TEST-1", "5")
+}
+
+func TestPushAllocations_WorklogDryRunDoesNotWrite(t *testing.T) {
+	worklogs := new(MockWorklogPort)
+	uc := NewPushAllocationsUseCase(&stubAllocationCalculator{allocations: testAllocations()}, nil)
+	uc.UseWorklogs(worklogs)
+
+	result, err := uc.Execute(domain.PushAllocationsInput{Mode: domain.PushModeWorklog, DryRun: true})
+
+	require.NoError(t, err)
+	assert.Empty(t, result.Created)
+	worklogs.AssertNotCalled(t, "GetWorklogs", mock.Anything)
+}
+
+func TestFormatAllocationComment(t *testing.T) {
+	body := FormatAllocationComment(testAllocations()[1])
+	assert.Equal(t, "[assetcap:allocation]\nSprint: Sprint 1\nAssignee: Jane Smith\nHours: 20.00\nAllocation: 25.00%\nWork type: none\nAsset: none", body)
+}
+
+// memoryPushCheckpoints keeps push checkpoints in memory
+type memoryPushCheckpoints struct {
+	checkpoints map[string]domain.PushCheckpoint
+	saves       int
+}
+
+func newMemoryPushCheckpoints() *memoryPushCheckpoints {
+	return &memoryPushCheckpoints{checkpoints: make(map[string]domain.PushCheckpoint)}
+}
+
+func (s *memoryPushCheckpoints) Load(project, sprint string, mode domain.PushMode) (*domain.PushCheckpoint, error) {
+	checkpoint, ok := s.checkpoints[project+"/"+sprint+"/"+string(mode)]
+	if !ok {
+		return nil, nil
+	}
+	checkpoint.Pushed = maps.Clone(checkpoint.Pushed)
+	return &checkpoint, nil
+}
+
+func (s *memoryPushCheckpoints) Save(checkpoint *domain.PushCheckpoint) error {
+	s.saves++
+	saved := *checkpoint
+	saved.Pushed = maps.Clone(checkpoint.Pushed)
+	s.checkpoints[checkpoint.Project+"/"+checkpoint.Sprint+"/"+string(checkpoint.Mode)] = saved
+	return nil
+}
+
+func (s *memoryPushCheckpoints) Delete(project, sprint string, mode domain.PushMode) error {
+	delete(s.checkpoints, project+"/"+sprint+"/"+string(mode))
+	return nil
+}
+
+func threeAllocations() []domain.IssueAllocation {
+	return []domain.IssueAllocation{
+		{Sprint: "Sprint 1", IssueKey: "TEST-1", Assignee: "John Doe", Hours: 10, Percentage: 50},
+		{Sprint: "Sprint 1", IssueKey: "TEST-2", Assignee: "John Doe", Hours: 6, Percentage: 30},
+		{Sprint: "Sprint 1", IssueKey: "TEST-3", Assignee: "John Doe", Hours: 4, Percentage: 20},
+	}
+}
+
+func TestPushAllocations_ResumesAfterRateLimit(t *testing.T) {
+	allocations := threeAllocations()
+	store := newMemoryPushCheckpoints()
+	input := domain.PushAllocationsInput{Project: "TEST", Sprint: "Sprint 1", Mode: domain.PushModeComment, ChunkSize: 1}
+
+	comments := new(MockCommentPort)
+	comments.On("GetComments", mock.Anything).Return([]ports.JiraComment{}, nil)
+	comments.On("AddComment", "TEST-1", mock.Anything).Return(nil)
+	comments.On("AddComment", "TEST-2", mock.Anything).Return(fmt.Errorf("failed to add comment to TEST-2: %w", domain.ErrRateLimited))
+	uc := NewPushAllocationsUseCase(&stubAllocationCalculator{allocations: allocations}, comments)
+	uc.UseCheckpoints(store)
+
+	result, err := uc.Execute(input)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"TEST-1"}, result.Created)
+	assert.Equal(t, []string{"TEST-2", "TEST-3"}, result.Remaining, "the push stops at the rate limit")
+	assert.Empty(t, result.Failures)
+	comments.AssertNotCalled(t, "GetComments", "TEST-3")
+
+	checkpoint, err := store.Load("TEST", "Sprint 1", domain.PushModeComment)
+	require.NoError(t, err)
+	require.NotNil(t, checkpoint)
+	assert.Equal(t, map[string]string{"TEST-1": domain.PushDigest(FormatAllocationComment(allocations[0]))}, checkpoint.Pushed)
+
+	comments = new(MockCommentPort)
+	comments.On("GetComments", mock.Anything).Return([]ports.JiraComment{}, nil)
+	comments.On("AddComment", mock.Anything, mock.Anything).Return(nil)
+	uc = NewPushAllocationsUseCase(&stubAllocationCalculator{allocations: allocations}, comments)
+	uc.UseCheckpoints(store)
+
+	result, err = uc.Execute(input)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"TEST-1"}, result.Resumed)
+	assert.Equal(t, []string{"TEST-2", "TEST-3"}, result.Created)
+	assert.Empty(t, result.Remaining)
+	comments.AssertNotCalled(t, "GetComments", "TEST-1")
+	assert.Empty(t, store.checkpoints, "a completed push removes its checkpoint")
+}
+
+func TestPushAllocations_SavesCheckpointPerChunk(t *testing.T) {
+	store := newMemoryPushCheckpoints()
+	comments := new(MockCommentPort)
+	comments.On("GetComments", mock.Anything).Return([]ports.JiraComment{}, nil)
+	comments.On("AddComment", mock.Anything, mock.Anything).Return(nil)
+	uc := NewPushAllocationsUseCase(&stubAllocationCalculator{allocations: threeAllocations()}, comments)
+	uc.UseCheckpoints(store)
+
+	result, err := uc.Execute(domain.PushAllocationsInput{Project: "TEST", Sprint: "Sprint 1", Mode: domain.PushModeComment, ChunkSize: 2})
+	require.NoError(t, err)
+	assert.Len(t, result.Created, 3)
+	assert.Equal(t, 1, store.saves, "the checkpoint is saved after every chunk but the last")
+	assert.Empty(t, store.checkpoints)
+}
+
+func TestPushAllocations_RepushesChangedRowsAndRestarts(t *testing.T) {
+	allocations := threeAllocations()
+	store := newMemoryPushCheckpoints()
+	checkpoint := domain.NewPushCheckpoint("TEST", "Sprint 1", domain.PushModeComment)
+	checkpoint.Record("TEST-1", domain.PushDigest(FormatAllocationComment(allocations[0])))
+	checkpoint.Record("TEST-2", domain.PushDigest("an allocation pushed before the hours changed"))
+	require.NoError(t, store.Save(checkpoint))
+
+	comments := new(MockCommentPort)
+	comments.On("GetComments", mock.Anything).Return([]ports.JiraComment{}, nil)
+	comments.On("AddComment", mock.Anything, mock.Anything).Return(nil)
+	uc := NewPushAllocationsUseCase(&stubAllocationCalculator{allocations: allocations}, comments)
+	uc.UseCheckpoints(store)
+
+	result, err := uc.Execute(domain.PushAllocationsInput{Project: "TEST", Sprint: "Sprint 1", Mode: domain.PushModeComment})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"TEST-1"}, result.Resumed)
+	assert.Equal(t, []string{"TEST-2", "TEST-3"}, result.Created, "a row whose content changed is pushed again")
+
+	require.NoError(t, store.Save(checkpoint))
+	result, err = uc.Execute(domain.PushAllocationsInput{Project: "TEST", Sprint: "Sprint 1", Mode: domain.PushModeComment, Restart: true})
+	require.NoError(t, err)
+	assert.Empty(t, result.Resumed)
+	assert.Len(t, result.Created, 3, "a restart pushes every row")
+}
+
+func TestPushAllocations_FailuresKeepCheckpoint(t *testing.T) {
+	store := newMemoryPushCheckpoints()
+	comments := new(MockCommentPort)
+	comments.On("GetComments", "TEST-2").Return(nil, errors.New("forbidden"))
+	comments.On("GetComments", mock.Anything).Return([]ports.JiraComment{}, nil)
+	comments.On("AddComment", mock.Anything, mock.Anything).Return(nil)
+	uc := NewPushAllocationsUseCase(&stubAllocationCalculator{allocations: threeAllocations()}, comments)
+	uc.UseCheckpoints(store)
+
+	result, err := uc.Execute(domain.PushAllocationsInput{Project: "TEST", Sprint: "Sprint 1", Mode: domain.PushModeComment})
+	require.NoError(t, err)
+	require.Len(t, result.Failures, 1)
+
+	checkpoint, err := store.Load("TEST", "Sprint 1", domain.PushModeComment)
+	require.NoError(t, err)
+	require.NotNil(t, checkpoint, "a re-run retries only the failed rows")
+	assert.Len(t, checkpoint.Pushed, 2)
+	assert.NotContains(t, checkpoint.Pushed, "TEST-2")
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"path"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+//go:embed templates/*.tmpl
+var builtinTemplates embed.FS
+
+// reportTemplateFuncs returns the helpers available to report templates, formatting for the locale
+func reportTemplateFuncs(locale domain.Locale) map[string]interface{} {
+	return map[string]interface{}{
+		"hours":   locale.Hours,
+		"percent": locale.Percent,
+		"pp":      locale.PercentagePoints,
+		"date":    func(value time.Time) string { return locale.Date(value) },
+		"cell":    markdownCell,
+		"link":    markdownLink,
+	}
+}
+
+// BuiltinReportTemplateNames returns the names of the report templates shipped in the binary
+func BuiltinReportTemplateNames() []string {
+	entries, err := builtinTemplates.ReadDir("templates")
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".tmpl"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// BuiltinReportTemplate returns the built-in report template with the given name
+func BuiltinReportTemplate(name string) (*domain.ReportTemplate, bool) {
+	body, err := builtinTemplates.ReadFile(path.Join("templates", name+".tmpl"))
+	if err != nil {
+		return nil, false
+	}
+	return &domain.ReportTemplate{Name: name, Body: string(body)}, true
+}
+
+// renderTemplateReport executes the template against the report model
+func renderTemplateReport(tmpl *domain.ReportTemplate, report domain.CapitalizationReport, locale domain.Locale) (string, error) {
+	funcs := reportTemplateFuncs(locale)
+	var b strings.Builder
+	if tmpl.HTML {
+		parsed, err := htmltemplate.New(tmpl.Name).Funcs(funcs).Parse(tmpl.Body)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse report template %s: %w", tmpl.Name, err)
+		}
+		if err := parsed.Execute(&b, report); err != nil {
+			return "", fmt.Errorf("failed to render report template %s: %w", tmpl.Name, err)
+		}
+		return b.String(), nil
+	}
+
+	parsed, err := texttemplate.New(tmpl.Name).Funcs(funcs).Parse(tmpl.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse report template %s: %w", tmpl.Name, err)
+	}
+	if err := parsed.Execute(&b, report); err != nil {
+		return "", fmt.Errorf("failed to render report template %s: %w", tmpl.Name, err)
+	}
+	return b.String(), nil
+}
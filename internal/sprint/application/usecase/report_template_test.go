@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+func TestBuiltinReportTemplateNames(t *testing.T) {
+	assert.Equal(t, []string{"engineering", "executive", "finance"}, BuiltinReportTemplateNames())
+
+	_, ok := BuiltinReportTemplate("unknown")
+	assert.False(t, ok)
+}
+
+func TestCapitalizationReport_BuiltinTemplates(t *testing.T) {
+	data := reportData()
+	data["TEAMA/Sprint 2"][0].AssetName = "cap-asset-checkout"
+	uc := NewCapitalizationReportUseCase(reportFactory(data))
+
+	render := func(name string) string {
+		tmpl, ok := BuiltinReportTemplate(name)
+		require.True(t, ok)
+		report, err := uc.Execute(domain.CapitalizationReportInput{
+			Projects:       []string{"TEAMA", "TEAMB"},
+			Sprint:         "Sprint 2",
+			PreviousSprint: "Sprint 1",
+			Template:       tmpl,
+		})
+		require.NoError(t, err)
+		return report
+	}
+
+	finance := render("finance")
+	assert.Contains(t, finance, "# Capitalization - Sprint 2")
+	assert.Contains(t, finance, "| Capitalized | 30.00 |\n| Expensed | 20.00 |")
+	assert.Contains(t, finance, "| cap-asset-checkout | 30.00 | 0.00 | 100.00% |")
+	assert.Contains(t, finance, "| unassigned | 0.00 | 20.00 | 0.00% |")
+	assert.NotContains(t, finance, "## Impairments")
+
+	engineering := render("engineering")
+	assert.Contains(t, engineering, "| TEAMA | 75.00% | 25.00% | 75.00% |")
+	assert.Contains(t, engineering, "| TEAMB | B-1 | Patch \\| upgrade | Jane Smith | cap-maintenance | - |")
+
+	executive := render("executive")
+	assert.Contains(t, executive, "- 60.00% of 50.00 hours capitalized")
+	assert.Contains(t, executive, "- Development: 60.00% (+10.00 pp vs Sprint 1)")
+	assert.Contains(t, executive, "- TEAMB: 0.00% capitalized")
+}
+
+func TestCapitalizationReport_CustomTemplates(t *testing.T) {
+	uc := NewCapitalizationReportUseCase(reportFactory(reportData()))
+	input := domain.CapitalizationReportInput{Projects: []string{"TEAMB"}, Sprint: "Sprint 2"}
+
+	input.Template = &domain.ReportTemplate{
+		Name: "rows.tmpl",
+		Body: `{{range .Rows}}{{.IssueKey}}={{hours .Hours}} {{end}}{{range .Metrics}}{{if eq .Label "Total hours"}}total={{.Value}}{{end}}{{end}}`,
+	}
+	report, err := uc.Execute(input)
+	require.NoError(t, err)
+	assert.Equal(t, "B-1=10.00 total=10.00", report)
+
+	input.Template = &domain.ReportTemplate{Name: "rows.html", Body: `<td>{{range .Rows}}{{.IssueTitle}}{{end}}</td>`, HTML: true}
+	data := reportData()
+	data["TEAMB/Sprint 2"][0].IssueTitle = "<script>x</script>"
+	report, err = NewCapitalizationReportUseCase(reportFactory(data)).Execute(input)
+	require.NoError(t, err)
+	assert.Equal(t, "<td>&lt;script&gt;x&lt;/script&gt;</td>", report)
+
+	input.Template = &domain.ReportTemplate{Name: "broken.tmpl", Body: "{{.Missing"}
+	_, err = uc.Execute(input)
+	assert.ErrorContains(t, err, "failed to parse report template broken.tmpl")
+
+	input.Template = &domain.ReportTemplate{Name: "unknown-field.tmpl", Body: "{{.Missing}}"}
+	_, err = uc.Execute(input)
+	assert.ErrorContains(t, err, "failed to render report template unknown-field.tmpl")
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain/ports"
+)
+
+// ResolveTeamAccountsUseCase migrates the members of teams.json from display names to
+// Jira account IDs
+type ResolveTeamAccountsUseCase struct {
+	users ports.JiraUserPort
+	teams ports.TeamsRepository
+}
+
+// NewResolveTeamAccountsUseCase creates a new ResolveTeamAccountsUseCase instance
+func NewResolveTeamAccountsUseCase(users ports.JiraUserPort, teams ports.TeamsRepository) *ResolveTeamAccountsUseCase {
+	return &ResolveTeamAccountsUseCase{
+		users: users,
+		teams: teams,
+	}
+}
+
+// Execute searches Jira for every member without an account ID and records the accounts
+// that resolve unambiguously. Ambiguous and unknown members are reported and left as they are.
+func (uc *ResolveTeamAccountsUseCase) Execute(input domain.TeamAccountsInput) (*domain.TeamAccountsResult, error) {
+	teams, err := uc.teams.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	projects := make([]string, 0, len(teams))
+	for project := range teams {
+		if input.Project == "" || project == input.Project {
+			projects = append(projects, project)
+		}
+	}
+	if len(projects) == 0 {
+		return nil, fmt.Errorf("project %s not found in teams.json", input.Project)
+	}
+	sort.Strings(projects)
+
+	result := &domain.TeamAccountsResult{}
+	changed := false
+	for _, project := range projects {
+		team := teams[project]
+		for _, member := range team.Team {
+			if accountID := team.AccountIDs[member]; accountID != "" {
+				result.Resolutions = append(result.Resolutions, domain.AccountResolution{
+					Project: project, Member: member, AccountID: accountID, Status: domain.AccountAlreadyResolved,
+				})
+				continue
+			}
+
+			users, err := uc.users.SearchUsers(member)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve %s: %w", member, err)
+			}
+			resolution := domain.ResolveAccount(project, member, users)
+			result.Resolutions = append(result.Resolutions, resolution)
+			if resolution.Status != domain.AccountResolved {
+				continue
+			}
+			if team.AccountIDs == nil {
+				team.AccountIDs = make(map[string]string)
+			}
+			team.AccountIDs[member] = resolution.AccountID
+			changed = true
+		}
+		teams[project] = team
+	}
+
+	if !changed || input.DryRun {
+		return result, nil
+	}
+	if err := uc.teams.Save(teams); err != nil {
+		return nil, err
+	}
+	result.Written = true
+	return result, nil
+}
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/infrastructure"
+)
+
+// fakeUserPort returns canned user search results keyed by query
+type fakeUserPort struct {
+	users   map[string][]domain.JiraUser
+	queries []string
+	err     error
+}
+
+func (f *fakeUserPort) SearchUsers(query string) ([]domain.JiraUser, error) {
+	f.queries = append(f.queries, query)
+	return f.users[query], f.err
+}
+
+func writeTeams(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "teams.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func readTeams(t *testing.T, path string) domain.TeamMap {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var teams domain.TeamMap
+	require.NoError(t, json.Unmarshal(data, &teams))
+	return teams
+}
+
+func TestResolveTeamAccounts_Execute(t *testing.T) {
+	path := writeTeams(t, `{
+		"FN": {"team": ["alice", "bob", "carol"], "account_ids": {"carol": "acc-carol"}},
+		"OPS": {"team": ["dave"]}
+	}`)
+	users := &fakeUserPort{users: map[string][]domain.JiraUser{
+		"alice": {{AccountID: "acc-alice", AccountType: "atlassian", DisplayName: "Alice", Active: true}},
+		"bob": {
+			{AccountID: "acc-bob-1", AccountType: "atlassian", DisplayName: "Bob One", Active: true},
+			{AccountID: "acc-bob-2", AccountType: "atlassian", DisplayName: "Bob Two", Active: true},
+		},
+	}}
+
+	result, err := NewResolveTeamAccountsUseCase(users, infrastructure.NewJSONTeamsRepository(path)).Execute(domain.TeamAccountsInput{Project: "FN"})
+	require.NoError(t, err)
+	assert.True(t, result.Written)
+	assert.Equal(t, []string{"alice", "bob"}, users.queries)
+	require.Len(t, result.Resolutions, 3)
+	assert.Equal(t, domain.AccountResolved, result.Resolutions[0].Status)
+	assert.Equal(t, domain.AccountAmbiguous, result.Resolutions[1].Status)
+	assert.Equal(t, domain.AccountAlreadyResolved, result.Resolutions[2].Status)
+
+	teams := readTeams(t, path)
+	assert.Equal(t, map[string]string{"alice": "acc-alice", "carol": "acc-carol"}, teams["FN"].AccountIDs)
+	assert.Equal(t, []string{"alice", "bob", "carol"}, teams["FN"].Team)
+	assert.Empty(t, teams["OPS"].AccountIDs)
+}
+
+func TestResolveTeamAccounts_DryRun(t *testing.T) {
+	content := `{"FN": {"team": ["alice"]}}`
+	path := writeTeams(t, content)
+	users := &fakeUserPort{users: map[string][]domain.JiraUser{
+		"alice": {{AccountID: "acc-alice", AccountType: "atlassian", DisplayName: "Alice", Active: true}},
+	}}
+
+	result, err := NewResolveTeamAccountsUseCase(users, infrastructure.NewJSONTeamsRepository(path)).Execute(domain.TeamAccountsInput{DryRun: true})
+	require.NoError(t, err)
+	assert.False(t, result.Written)
+	assert.Equal(t, "acc-alice", result.Resolutions[0].AccountID)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(data))
+}
+
+func TestResolveTeamAccounts_Errors(t *testing.T) {
+	path := writeTeams(t, `{"FN": {"team": ["alice"]}}`)
+
+	_, err := NewResolveTeamAccountsUseCase(&fakeUserPort{}, infrastructure.NewJSONTeamsRepository(path)).Execute(domain.TeamAccountsInput{Project: "OPS"})
+	assert.EqualError(t, err, "project OPS not found in teams.json")
+
+	_, err = NewResolveTeamAccountsUseCase(&fakeUserPort{err: errors.New("forbidden")}, infrastructure.NewJSONTeamsRepository(path)).Execute(domain.TeamAccountsInput{})
+	assert.EqualError(t, err, "failed to resolve alice: forbidden")
+
+	_, err = NewResolveTeamAccountsUseCase(&fakeUserPort{}, infrastructure.NewJSONTeamsRepository(filepath.Join(t.TempDir(), "missing.json"))).Execute(domain.TeamAccountsInput{})
+	assert.ErrorIs(t, err, domain.ErrNoTeams)
+}
@@ -0,0 +1,216 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain/ports"
+)
+
+const (
+	simulationProject = "SIM"
+	simulationSprint  = "Simulation Sprint"
+	statusInProgress  = "In Progress"
+	statusToDo        = "To Do"
+	statusDone        = "Done"
+	statusBlocked     = "Blocked"
+)
+
+// simulationStart is a Monday so weekday and weekend spans are predictable
+var simulationStart = time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC)
+
+// transition is a status change at an offset from the simulation start
+type transition struct {
+	offset time.Duration
+	from   string
+	to     string
+}
+
+// scenarioIssue describes a synthetic issue of a scenario
+type scenarioIssue struct {
+	key         string
+	summary     string
+	assignee    string
+	workType    string
+	transitions []transition
+}
+
+// scenario is a named set of synthetic issues exercising an allocation edge case
+type scenario struct {
+	description string
+	members     []string
+	issues      []scenarioIssue
+}
+
+var simulationScenarios = map[string]scenario{
+	"rollover": {
+		description: "Work started late in the week and finished after the weekend, plus work still open at sprint end",
+		members:     []string{"Alice"},
+		issues: []scenarioIssue{
+			{
+				key: "SIM-1", summary: "Started Friday afternoon, done Monday morning", assignee: "Alice", workType: domain.WorkTypeDevelopment,
+				transitions: []transition{
+					{offset: 4*24*time.Hour + 7*time.Hour, from: statusToDo, to: statusInProgress},
+					{offset: 7*24*time.Hour + 1*time.Hour, from: statusInProgress, to: statusDone},
+				},
+			},
+			{
+				key: "SIM-2", summary: "Still in progress when the sprint ends", assignee: "Alice", workType: domain.WorkTypeDevelopment,
+				transitions: []transition{
+					{offset: 8 * 24 * time.Hour, from: statusToDo, to: statusInProgress},
+				},
+			},
+		},
+	},
+	"pairing": {
+		description: "Two members pairing on the same story, which is assigned to only one of them",
+		members:     []string{"Alice", "Bob"},
+		issues: []scenarioIssue{
+			{
+				key: "SIM-1", summary: "Paired story assigned to Alice", assignee: "Alice", workType: domain.WorkTypeDevelopment,
+				transitions: []transition{
+					{offset: 0, from: statusToDo, to: statusInProgress},
+					{offset: 8 * time.Hour, from: statusInProgress, to: statusDone},
+				},
+			},
+			{
+				key: "SIM-2", summary: "Bob's own maintenance task", assignee: "Bob", workType: domain.WorkTypeMaintenance,
+				transitions: []transition{
+					{offset: 24 * time.Hour, from: statusToDo, to: statusInProgress},
+					{offset: 28 * time.Hour, from: statusInProgress, to: statusDone},
+				},
+			},
+		},
+	},
+	"blocked": {
+		description: "An issue with several In Progress periods separated by a blocked period",
+		members:     []string{"Alice"},
+		issues: []scenarioIssue{
+			{
+				key: "SIM-1", summary: "Blocked for two days in the middle", assignee: "Alice", workType: domain.WorkTypeDevelopment,
+				transitions: []transition{
+					{offset: 0, from: statusToDo, to: statusInProgress},
+					{offset: 4 * time.Hour, from: statusInProgress, to: statusBlocked},
+					{offset: 52 * time.Hour, from: statusBlocked, to: statusInProgress},
+					{offset: 56 * time.Hour, from: statusInProgress, to: statusDone},
+				},
+			},
+			{
+				key: "SIM-2", summary: "Uninterrupted task", assignee: "Alice", workType: domain.WorkTypeMaintenance,
+				transitions: []transition{
+					{offset: 4 * time.Hour, from: statusToDo, to: statusInProgress},
+					{offset: 8 * time.Hour, from: statusInProgress, to: statusDone},
+				},
+			},
+		},
+	},
+}
+
+// SimulationScenarios returns the names of the available simulation scenarios
+func SimulationScenarios() []string {
+	names := make([]string, 0, len(simulationScenarios))
+	for name := range simulationScenarios {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// scenarioPort serves the synthetic issues of a scenario as if they came from Jira
+type scenarioPort struct {
+	issues []ports.JiraIssue
+}
+
+func (p *scenarioPort) GetIssuesForSprint(_, _ string) ([]ports.JiraIssue, error) {
+	return p.issues, nil
+}
+
+func (p *scenarioPort) GetIssuesForTeamMember(_ string) ([]ports.JiraIssue, error) {
+	return nil, nil
+}
+
+func (p *scenarioPort) GetSprintIssues(_ *domain.Sprint) ([]ports.JiraIssue, error) {
+	return p.issues, nil
+}
+
+func (p *scenarioPort) GetTeamIssues(_ *domain.Team) ([]ports.JiraIssue, error) {
+	return p.issues, nil
+}
+
+// SimulateAllocationUseCase runs the allocation engine against synthetic scenarios
+type SimulateAllocationUseCase struct{}
+
+// NewSimulateAllocationUseCase creates a new SimulateAllocationUseCase instance
+func NewSimulateAllocationUseCase() *SimulateAllocationUseCase {
+	return &SimulateAllocationUseCase{}
+}
+
+// Execute builds the named scenario and returns the allocation computed for each issue
+func (uc *SimulateAllocationUseCase) Execute(name string) (*domain.SimulationResult, error) {
+	scenario, ok := simulationScenarios[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown scenario %q (available: %s)", name, strings.Join(SimulationScenarios(), ", "))
+	}
+
+	port := &scenarioPort{}
+	timelines := make(map[string][]string, len(scenario.issues))
+	for _, issue := range scenario.issues {
+		port.issues = append(port.issues, issue.toJiraIssue())
+		timelines[issue.key] = issue.timeline()
+	}
+
+	engine := &SprintTimeAllocationUseCase{
+		teams:    domain.TeamMap{simulationProject: domain.Team{Team: scenario.members}},
+		project:  simulationProject,
+		sprint:   simulationSprint,
+		jiraPort: port,
+	}
+	allocations, err := engine.Allocate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run allocation engine: %w", err)
+	}
+
+	result := &domain.SimulationResult{Scenario: name, Description: scenario.description}
+	for _, allocation := range allocations {
+		result.Issues = append(result.Issues, domain.SimulatedIssue{
+			Timeline:   timelines[allocation.IssueKey],
+			Allocation: allocation,
+		})
+	}
+	return result, nil
+}
+
+// toJiraIssue converts the synthetic issue to the shape returned by the Jira adapter
+func (i scenarioIssue) toJiraIssue() ports.JiraIssue {
+	status := statusToDo
+	histories := make([]ports.JiraChangeHistory, 0, len(i.transitions))
+	for _, t := range i.transitions {
+		histories = append(histories, ports.JiraChangeHistory{
+			Created: simulationStart.Add(t.offset).Format(time.RFC3339),
+			Items:   []ports.JiraChangeItem{{Field: "status", FromString: t.from, ToString: t.to}},
+		})
+		status = t.to
+	}
+
+	return ports.JiraIssue{
+		Key:       i.key,
+		Summary:   i.summary,
+		Assignee:  i.assignee,
+		Status:    status,
+		IssueType: "Story",
+		Labels:    []string{i.workType},
+		Changelog: ports.JiraChangelog{Histories: histories},
+	}
+}
+
+// timeline renders the status transitions of the synthetic issue
+func (i scenarioIssue) timeline() []string {
+	lines := make([]string, 0, len(i.transitions))
+	for _, t := range i.transitions {
+		lines = append(lines, fmt.Sprintf("%s %s -> %s", simulationStart.Add(t.offset).Format("Mon 2006-01-02 15:04"), t.from, t.to))
+	}
+	return lines
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulationScenarios(t *testing.T) {
+	assert.Equal(t, []string{"blocked", "pairing", "rollover"}, SimulationScenarios())
+}
+
+func TestSimulateAllocation(t *testing.T) {
+	tests := []struct {
+		scenario    string
+		hours       map[string]float64
+		percentages map[string]float64
+	}{
+		{
+			// The weekend is counted as wall-clock time and open work gets no hours
+			scenario:    "rollover",
+			hours:       map[string]float64{"SIM-1": 66, "SIM-2": 0},
+			percentages: map[string]float64{"SIM-1": 100, "SIM-2": 0},
+		},
+		{
+			// Only the assignee receives hours for paired work
+			scenario:    "pairing",
+			hours:       map[string]float64{"SIM-1": 8, "SIM-2": 4},
+			percentages: map[string]float64{"SIM-1": 100, "SIM-2": 100},
+		},
+		{
+			// The range spans from the first In Progress to Done, including the blocked period
+			scenario:    "blocked",
+			hours:       map[string]float64{"SIM-1": 56, "SIM-2": 4},
+			percentages: map[string]float64{"SIM-1": 93.33, "SIM-2": 6.67},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scenario, func(t *testing.T) {
+			result, err := NewSimulateAllocationUseCase().Execute(tt.scenario)
+			require.NoError(t, err)
+			assert.Equal(t, tt.scenario, result.Scenario)
+			assert.NotEmpty(t, result.Description)
+			require.Len(t, result.Issues, len(tt.hours))
+
+			for _, issue := range result.Issues {
+				key := issue.Allocation.IssueKey
+				assert.NotEmpty(t, issue.Timeline, key)
+				assert.Equal(t, tt.hours[key], issue.Allocation.Hours, key)
+				assert.InDelta(t, tt.percentages[key], issue.Allocation.Percentage, 0.01, key)
+			}
+		})
+	}
+}
+
+func TestSimulateAllocation_UnknownScenario(t *testing.T) {
+	_, err := NewSimulateAllocationUseCase().Execute("weekend")
+	assert.EqualError(t, err, `unknown scenario "weekend" (available: blocked, pairing, rollover)`)
+}
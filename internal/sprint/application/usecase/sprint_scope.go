@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain/ports"
+)
+
+// Scope summarizes the issues added to and pulled from the sprint while it ran. The sprint's
+// issue search only returns the issues still in it, so the pulled ones are looked up among the
+// issues updated since the sprint started, when the Jira integration supports it.
+func (p *SprintTimeAllocationUseCase) Scope() (*domain.SprintScope, error) {
+	issues, err := p.fetchIssues()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch issues: %w", err)
+	}
+
+	var sprint domain.JiraSprint
+	found := false
+	for i := range issues {
+		if sprint, found = issues[i].AllocatedSprint(p.sprint); found {
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("no issue of project %s lists sprint %s", p.project, p.sprint)
+	}
+
+	if search, ok := p.jiraPort.(ports.JiraScopePort); ok {
+		if start, err := domain.ParseJiraTime(sprint.StartDate); err == nil {
+			updated, err := search.GetIssuesUpdatedSince(p.project, start)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch the issues pulled from the sprint: %w", err)
+			}
+			known := make(map[string]bool, len(issues))
+			for _, issue := range issues {
+				known[issue.Key] = true
+			}
+			for _, issue := range toDomainIssues(updated) {
+				if known[issue.Key] || len(issue.ScopeChanges(sprint)) == 0 {
+					continue
+				}
+				issues = append(issues, issue)
+			}
+		}
+	}
+
+	scope := domain.SummarizeScope(sprint, issues)
+	return &scope, nil
+}
+
+// scopeChanges returns when the issue entered or left the allocated sprint while it ran; the
+// issues of a fix version have none
+func (p *SprintTimeAllocationUseCase) scopeChanges(issue domain.JiraIssue) []domain.ScopeChange {
+	if p.fixVersion != "" {
+		return nil
+	}
+	sprint, ok := issue.AllocatedSprint(p.sprint)
+	if !ok {
+		return nil
+	}
+	return issue.ScopeChanges(sprint)
+}
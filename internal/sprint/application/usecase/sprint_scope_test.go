@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain/ports"
+)
+
+// scopePort serves the sprint's issues and the issues updated since a time
+type scopePort struct {
+	scenarioPort
+	updated []ports.JiraIssue
+	since   time.Time
+}
+
+func (p *scopePort) GetIssuesUpdatedSince(_ string, since time.Time) ([]ports.JiraIssue, error) {
+	p.since = since
+	return p.updated, nil
+}
+
+// scopeTestSprint runs from March 18 to March 29, 2024
+var scopeTestSprint = ports.JiraSprint{ID: 42, Name: "Sprint 1", StartDate: "2024-03-18T09:00:00.000Z", EndDate: "2024-03-29T17:00:00.000Z"}
+
+func TestSprintScope(t *testing.T) {
+	added := ports.JiraIssue{
+		Key:     "TEST-2",
+		Summary: "Urgent fix",
+		Status:  "Done",
+		Sprints: []ports.JiraSprint{scopeTestSprint},
+		Changelog: ports.JiraChangelog{Histories: []ports.JiraChangeHistory{
+			{Created: "2024-03-20T10:00:00.000+0000", Items: []ports.JiraChangeItem{{Field: "Sprint", To: "42", ToString: "Sprint 1"}}},
+		}},
+	}
+	pulled := ports.JiraIssue{
+		Key:     "TEST-3",
+		Summary: "Nice to have",
+		Changelog: ports.JiraChangelog{Histories: []ports.JiraChangeHistory{
+			{Created: "2024-03-19T10:00:00.000+0000", Items: []ports.JiraChangeItem{{Field: "Sprint", From: "42", FromString: "Sprint 1"}}},
+		}},
+	}
+	untouched := ports.JiraIssue{Key: "TEST-4", Summary: "Other work"}
+	port := &scopePort{
+		scenarioPort: scenarioPort{issues: []ports.JiraIssue{
+			{Key: "TEST-1", Summary: "Checkout", Status: "Done", Sprints: []ports.JiraSprint{scopeTestSprint}},
+			added,
+		}},
+		updated: []ports.JiraIssue{added, pulled, untouched},
+	}
+	processor := &SprintTimeAllocationUseCase{project: "TEST", sprint: "Sprint 1", jiraPort: port}
+
+	scope, err := processor.Scope()
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 18, 9, 0, 0, 0, time.UTC), port.since)
+	assert.Equal(t, 2, scope.Committed)
+	assert.Equal(t, 1, scope.Added)
+	assert.Equal(t, 1, scope.Removed)
+	assert.Equal(t, 2, scope.Current)
+	require.Len(t, scope.Changes, 2)
+	assert.Equal(t, domain.ScopeChange{IssueKey: "TEST-3", Summary: "Nice to have", Change: domain.ScopeRemoved, At: time.Date(2024, 3, 19, 10, 0, 0, 0, time.UTC)}, scope.Changes[0])
+	assert.Equal(t, "TEST-2", scope.Changes[1].IssueKey)
+}
+
+func TestSprintScope_SprintNotFound(t *testing.T) {
+	processor := &SprintTimeAllocationUseCase{project: "TEST", sprint: "Sprint 9", jiraPort: &scenarioPort{issues: []ports.JiraIssue{{Key: "TEST-1"}}}}
+
+	_, err := processor.Scope()
+	assert.EqualError(t, err, "no issue of project TEST lists sprint Sprint 9")
+}
+
+func TestCalculatePercentageLoad_ScopeChanges(t *testing.T) {
+	processor := &SprintTimeAllocationUseCase{project: "TEST", sprint: "42"}
+	team := domain.Team{Team: []string{"alice"}}
+	issues := toDomainIssues([]ports.JiraIssue{{
+		Key:      "TEST-2",
+		Assignee: "alice",
+		Status:   "Done",
+		Sprints:  []ports.JiraSprint{scopeTestSprint},
+		Changelog: ports.JiraChangelog{Histories: []ports.JiraChangeHistory{
+			{Created: "2024-03-20T10:00:00.000+0000", Items: []ports.JiraChangeItem{{Field: "Sprint", To: "42", ToString: "Sprint 1"}}},
+			{Created: "2024-03-20T11:00:00.000+0000", Items: []ports.JiraChangeItem{{Field: "status", FromString: "To Do", ToString: "In Progress"}}},
+			{Created: "2024-03-21T11:00:00.000+0000", Items: []ports.JiraChangeItem{{Field: "status", FromString: "In Progress", ToString: "Done"}}},
+		}},
+	}})
+
+	totalHours := processor.calculateTotalHours(team, issues, nil)
+	results := percentageLoad(t, processor, team, issues, totalHours)
+	require.Len(t, results, 1)
+	assert.Equal(t, []domain.ScopeChange{{IssueKey: "TEST-2", Change: domain.ScopeAdded, At: time.Date(2024, 3, 20, 10, 0, 0, 0, time.UTC)}}, results[0].ScopeChanges)
+}
@@ -1,10 +1,11 @@
 package usecase
 
 import (
-	"encoding/csv"
 	"encoding/json"
 	"fmt"
-	"os"
+	"io"
+	"math"
+	"sort"
 	"strings"
 	"time"
 
@@ -14,12 +15,6 @@ import (
 	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/infrastructure"
 )
 
-const (
-	issueTypeSubTask = "Sub-task"
-	statusDone       = "Done"
-	statusWontDo     = "Won't Do"
-)
-
 // SprintTimeAllocationUseCase handles the processing of Jira issues and time calculations
 type SprintTimeAllocationUseCase struct {
 	config   *config.JiraConfig
@@ -28,149 +23,701 @@ type SprintTimeAllocationUseCase struct {
 	sprint   string
 	override string
 	jiraPort ports.JiraPort
+	// method names the strategy splitting each person's hours across their issues
+	method   domain.AllocationMethod
+	strategy domain.AllocationStrategy
+	pointsAt domain.PointsAt
+	// normalization selects whether percentages are shares of each person's or the team's time
+	normalization domain.Normalization
+	// summary selects the summary section written after the allocation rows
+	summary   domain.AllocationSummary
+	labelsAt  domain.LabelSnapshot
+	assetDocs domain.AssetDocLinks
+	locale    domain.Locale
+	// assetAccounts books the allocated assets to their cost centers and ledger accounts
+	assetAccounts domain.AssetAccounts
+	// workTypeSplits divides the rows of annotated issues across work types
+	workTypeSplits domain.WorkTypeSplits
+	// fixVersion allocates a release instead of the sprint; release holds its dates once fetched
+	fixVersion string
+	release    *domain.Release
+	// unattributed collects the in-progress time of the last calculation that no team member held
+	unattributed []domain.UnattributedTime
+	// progress is told how many issues have been allocated as rows are computed
+	progress func(done, total int)
+	// heuristics fill in hours the changelog does not track; applied lists the issues of the
+	// last calculation whose hours they set
+	heuristics domain.AllocationHeuristics
+	applied    []domain.AppliedHeuristic
+	// absences are taken out of the tracked time of their member; absent lists the time
+	// removed in the last calculation
+	absences domain.AbsenceCalendar
+	absent   []domain.AppliedAbsence
+	// overlaps lists the issues of the last calculation whose time was split with overlapping sprints
+	overlaps []domain.SprintOverlap
+	// stale lists the issues of the last calculation In Progress for longer than the threshold
+	stale []domain.StaleIssue
+	// personWorkTypes override the work type of every row of their team members; adjusted
+	// lists the rows of the last calculation they changed
+	personWorkTypes domain.PersonWorkTypes
+	adjusted        []domain.WorkTypeAdjustment
+	// lowConfidence lists the rows of the last calculation scoring below the low confidence
+	// threshold of the heuristics
+	lowConfidence []domain.LowConfidenceRow
+	// retirements detach rows from assets retired before the work started; detached lists the
+	// rows of the last calculation they took off an asset
+	retirements domain.AssetRetirements
+	detached    []domain.RetiredAssetWork
+	// idle lists the team members the last calculation credited no hours; strictRoster fails
+	// the calculation when there are any
+	idle         []domain.IdleMember
+	strictRoster bool
+}
+
+// attribution is the share of an issue's working hours credited to one team member, and
+// the hours removed from it while they were absent
+type attribution struct {
+	assignee string
+	hours    float64
+	absent   float64
 }
 
-// NewSprintTimeAllocationUseCase creates a new JiraProcessor instance
-func NewSprintTimeAllocationUseCase(project, sprint, override string) (*SprintTimeAllocationUseCase, error) {
+// NewSprintTimeAllocationUseCase creates a new JiraProcessor instance fetching the issues
+// through jiraPort, so they are requested with the client and fields it was configured with
+func NewSprintTimeAllocationUseCase(jiraPort ports.JiraPort, project, sprint, override string) (*SprintTimeAllocationUseCase, error) {
+	if jiraPort == nil {
+		return nil, fmt.Errorf("no Jira integration to fetch the issues from")
+	}
+
 	// Load Jira configuration
 	jiraConfig, err := config.NewJiraConfig()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load Jira configuration: %w", err)
 	}
 
-	// Load teams data
-	var teamsData []byte
-	var teamsErr error
-
-	// Try different paths for teams.json
-	paths := []string{
-		".assetcap/teams.json", // .assetcap directory
-	}
-
-	for _, path := range paths {
-		teamsData, teamsErr = os.ReadFile(path)
-		if teamsErr == nil {
-			break
-		}
+	teams, absences, err := loadTeamsAndAbsences()
+	if err != nil {
+		return nil, err
 	}
 
-	if teamsErr != nil {
-		// If no file is found, create a default teams.json in the .assetcap directory
-		if mkdirErr := os.MkdirAll(".assetcap", 0755); mkdirErr != nil {
-			return nil, fmt.Errorf("failed to create .assetcap directory: %w", mkdirErr)
-		}
-		teamsData = []byte(`{
-			"FN": {
-				"team": ["helio.medeiros", "julio.medeiros"]
-			}
-		}`)
-		if writeErr := os.WriteFile(".assetcap/teams.json", teamsData, 0644); writeErr != nil {
-			return nil, fmt.Errorf("failed to write teams file: %w", writeErr)
-		}
-	}
+	return &SprintTimeAllocationUseCase{
+		config:   jiraConfig,
+		teams:    teams,
+		project:  project,
+		sprint:   sprint,
+		override: override,
+		jiraPort: jiraPort,
+		absences: absences,
+	}, nil
+}
 
-	var teams domain.TeamMap
-	if unmarshalErr := json.Unmarshal(teamsData, &teams); unmarshalErr != nil {
-		return nil, fmt.Errorf("failed to unmarshal teams data: %w", unmarshalErr)
+// NewSprintTimeAllocationUseCaseFromExport creates a JiraProcessor allocating the issues of
+// a Jira JSON or CSV export instead of querying Jira, so no Jira configuration is needed
+func NewSprintTimeAllocationUseCaseFromExport(project, sprint, override, exportPath string) (*SprintTimeAllocationUseCase, error) {
+	teams, absences, err := loadTeamsAndAbsences()
+	if err != nil {
+		return nil, err
 	}
 
-	// Create Jira adapter
-	jiraAdapter, err := infrastructure.NewJiraAdapter(".assetcap/teams.json")
+	exportAdapter, err := infrastructure.NewExportAdapter(exportPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create Jira adapter: %w", err)
+		return nil, err
 	}
 
 	return &SprintTimeAllocationUseCase{
-		config:   jiraConfig,
 		teams:    teams,
 		project:  project,
 		sprint:   sprint,
 		override: override,
-		jiraPort: jiraAdapter,
+		jiraPort: exportAdapter,
+		absences: absences,
 	}, nil
 }
 
-// Process calculates time allocation and returns CSV data
-func (p *SprintTimeAllocationUseCase) Process() (string, error) {
+// loadTeamsAndAbsences loads the project teams and the absence calendar
+func loadTeamsAndAbsences() (domain.TeamMap, domain.AbsenceCalendar, error) {
+	teams, err := infrastructure.NewJSONTeamsRepository(infrastructure.DefaultTeamsPath).Load()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	absences, err := loadAbsenceCalendar(".assetcap/absences.json")
+	if err != nil {
+		return nil, nil, err
+	}
+	return teams, absences, nil
+}
+
+// UseStrategy splits each person's hours with the strategy, or blend of strategies, of the
+// method instead of by time in progress. Story point strategies use the estimate selected by
+// pointsAt for issues re-estimated during the sprint.
+func (p *SprintTimeAllocationUseCase) UseStrategy(method domain.AllocationMethod, pointsAt domain.PointsAt) error {
+	strategy, err := domain.NewAllocationStrategy(method, domain.StrategyOptions{PointsAt: pointsAt})
+	if err != nil {
+		return err
+	}
+	p.method = method
+	p.strategy = strategy
+	p.pointsAt = pointsAt
+	return nil
+}
+
+// UseNormalization selects what the allocation percentages are a share of
+func (p *SprintTimeAllocationUseCase) UseNormalization(normalization domain.Normalization) {
+	p.normalization = normalization
+}
+
+// UseSummary adds a summary section, derived from the written rows, after the allocation rows
+func (p *SprintTimeAllocationUseCase) UseSummary(summary domain.AllocationSummary) {
+	p.summary = summary
+}
+
+// UseLabelsAsOf classifies issues by the labels they had at the snapshot instead of their current labels
+func (p *SprintTimeAllocationUseCase) UseLabelsAsOf(snapshot domain.LabelSnapshot) {
+	p.labelsAt = snapshot
+}
+
+// UseAssetDocs links allocated assets to their documentation pages
+func (p *SprintTimeAllocationUseCase) UseAssetDocs(links domain.AssetDocLinks) {
+	p.assetDocs = links
+}
+
+// UseAssetAccounts books allocated assets to their cost centers and general ledger accounts
+func (p *SprintTimeAllocationUseCase) UseAssetAccounts(accounts domain.AssetAccounts) {
+	p.assetAccounts = accounts
+}
+
+// UseWorkTypeSplits divides the hours of the issues with a split across their work types
+func (p *SprintTimeAllocationUseCase) UseWorkTypeSplits(splits domain.WorkTypeSplits) {
+	p.workTypeSplits = splits
+}
+
+// UseFixVersion allocates the issues of a fix version instead of the sprint, crediting only
+// the work done between the release's start and release dates
+func (p *SprintTimeAllocationUseCase) UseFixVersion(fixVersion string) {
+	p.fixVersion = fixVersion
+}
+
+// Process calculates time allocation and returns it as CSV rendered by the formatter
+func (p *SprintTimeAllocationUseCase) Process(formatter *CSVFormatter) (string, error) {
+	var buffer strings.Builder
+	if err := p.ProcessTo(&buffer, formatter); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+// ProcessTo calculates time allocation and streams it to w as CSV rendered by the formatter,
+// writing each row as soon as it is computed instead of building the whole export in memory
+func (p *SprintTimeAllocationUseCase) ProcessTo(w io.Writer, formatter *CSVFormatter) error {
+	team, issues, manualAdjustments, err := p.prepare()
+	if err != nil {
+		return err
+	}
+
+	writer := formatter.NewAllocationWriter(w, team.Team, p.locale)
+	if len(p.assetAccounts) > 0 {
+		writer.IncludeAccounts()
+	}
+	summary := domain.NewPersonSummary()
+	err = p.allocate(*team, issues, manualAdjustments, func(allocation domain.IssueAllocation) error {
+		summary.Add(allocation)
+		return writer.Write(allocation)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to generate CSV: %w", err)
+	}
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("failed to generate CSV: %w", err)
+	}
+	if p.summary == domain.AllocationSummaryPerson {
+		block, err := formatter.Format(personSummaryRecords(summary, p.locale))
+		if err != nil {
+			return fmt.Errorf("failed to generate CSV: %w", err)
+		}
+		if _, err := io.WriteString(w, "\n"+block); err != nil {
+			return fmt.Errorf("failed to generate CSV: %w", err)
+		}
+	}
+	if len(p.unattributed) > 0 || len(p.applied) > 0 || len(p.absent) > 0 || len(p.overlaps) > 0 || len(p.idle) > 0 {
+		warnings, err := formatter.Format(warningRecords(p.unattributed, p.applied, p.absent, p.overlaps, p.idle, p.locale))
+		if err != nil {
+			return fmt.Errorf("failed to generate CSV: %w", err)
+		}
+		if _, err := io.WriteString(w, "\n"+warnings); err != nil {
+			return fmt.Errorf("failed to generate CSV: %w", err)
+		}
+	}
+	if len(p.stale) > 0 {
+		review, err := formatter.Format(staleRecords(p.stale, p.locale))
+		if err != nil {
+			return fmt.Errorf("failed to generate CSV: %w", err)
+		}
+		if _, err := io.WriteString(w, "\n"+review); err != nil {
+			return fmt.Errorf("failed to generate CSV: %w", err)
+		}
+	}
+	if len(p.adjusted) > 0 {
+		adjustments, err := formatter.Format(adjustmentRecords(p.adjusted, p.locale))
+		if err != nil {
+			return fmt.Errorf("failed to generate CSV: %w", err)
+		}
+		if _, err := io.WriteString(w, "\n"+adjustments); err != nil {
+			return fmt.Errorf("failed to generate CSV: %w", err)
+		}
+	}
+	if len(p.lowConfidence) > 0 {
+		review, err := formatter.Format(lowConfidenceRecords(p.lowConfidence, p.locale))
+		if err != nil {
+			return fmt.Errorf("failed to generate CSV: %w", err)
+		}
+		if _, err := io.WriteString(w, "\n"+review); err != nil {
+			return fmt.Errorf("failed to generate CSV: %w", err)
+		}
+	}
+	if len(p.detached) > 0 {
+		retired, err := formatter.Format(retiredRecords(p.detached, p.locale))
+		if err != nil {
+			return fmt.Errorf("failed to generate CSV: %w", err)
+		}
+		if _, err := io.WriteString(w, "\n"+retired); err != nil {
+			return fmt.Errorf("failed to generate CSV: %w", err)
+		}
+	}
+	return nil
+}
+
+// UseProgress reports the number of allocated issues out of the total as rows are computed
+func (p *SprintTimeAllocationUseCase) UseProgress(progress func(done, total int)) {
+	p.progress = progress
+}
+
+// UseLocale formats the numbers and dates of the CSV output for the locale
+func (p *SprintTimeAllocationUseCase) UseLocale(locale domain.Locale) {
+	p.locale = locale
+}
+
+// UseHeuristics configures the hours credited to untracked issues and same-day completions
+func (p *SprintTimeAllocationUseCase) UseHeuristics(heuristics domain.AllocationHeuristics) {
+	p.heuristics = heuristics
+}
+
+// AppliedHeuristics returns the issues of the last calculation whose hours were set by a
+// heuristic instead of their changelog
+func (p *SprintTimeAllocationUseCase) AppliedHeuristics() []domain.AppliedHeuristic {
+	return p.applied
+}
+
+// UseAbsences takes the days team members were absent out of the tracked time of the
+// issues they held
+func (p *SprintTimeAllocationUseCase) UseAbsences(absences domain.AbsenceCalendar) {
+	p.absences = absences
+}
+
+// AppliedAbsences returns the in-progress time of the last calculation not credited to the
+// assignees because they were absent
+func (p *SprintTimeAllocationUseCase) AppliedAbsences() []domain.AppliedAbsence {
+	return p.absent
+}
+
+// SprintOverlaps returns the issues of the last calculation that also belong to sprints
+// running at the same time as the allocated one, with the hours credited after the split
+func (p *SprintTimeAllocationUseCase) SprintOverlaps() []domain.SprintOverlap {
+	return p.overlaps
+}
+
+// StaleIssues returns the issues of the last calculation In Progress for longer than the
+// stale threshold, which need review
+func (p *SprintTimeAllocationUseCase) StaleIssues() []domain.StaleIssue {
+	return p.stale
+}
+
+// UsePersonWorkTypes overrides the work type of every row of the given team members
+func (p *SprintTimeAllocationUseCase) UsePersonWorkTypes(overrides domain.PersonWorkTypes) {
+	p.personWorkTypes = overrides
+}
+
+// WorkTypeAdjustments returns the rows of the last calculation whose work type was
+// overridden for their assignee
+func (p *SprintTimeAllocationUseCase) WorkTypeAdjustments() []domain.WorkTypeAdjustment {
+	return p.adjusted
+}
+
+// LowConfidenceRows returns the rows of the last calculation whose confidence fell below the
+// low confidence threshold, which reviewers should check first
+func (p *SprintTimeAllocationUseCase) LowConfidenceRows() []domain.LowConfidenceRow {
+	return p.lowConfidence
+}
+
+// UseRetirements stops attributing work started after their retirement date to retired assets
+func (p *SprintTimeAllocationUseCase) UseRetirements(retirements domain.AssetRetirements) {
+	p.retirements = retirements
+}
+
+// RetiredAssetWork returns the rows of the last calculation taken off an asset retired
+// before the work started
+func (p *SprintTimeAllocationUseCase) RetiredAssetWork() []domain.RetiredAssetWork {
+	return p.detached
+}
+
+// UseStrictRoster fails the calculation when a team member is credited no hours, instead of
+// only warning about it
+func (p *SprintTimeAllocationUseCase) UseStrictRoster(strict bool) {
+	p.strictRoster = strict
+}
+
+// IdleMembers returns the team members the last calculation credited no hours, with the
+// unmatched assignees that may be them
+func (p *SprintTimeAllocationUseCase) IdleMembers() []domain.IdleMember {
+	return p.idle
+}
+
+// Unattributed returns the in-progress time of the last calculation that was not credited
+// to anyone because no team member was assigned to the issue
+func (p *SprintTimeAllocationUseCase) Unattributed() []domain.UnattributedTime {
+	return p.unattributed
+}
+
+// Allocate calculates time allocation and returns it as one entry per issue and team member
+func (p *SprintTimeAllocationUseCase) Allocate() ([]domain.IssueAllocation, error) {
+	team, issues, manualAdjustments, err := p.prepare()
+	if err != nil {
+		return nil, err
+	}
+
+	var allocations []domain.IssueAllocation
+	err = p.allocate(*team, issues, manualAdjustments, func(allocation domain.IssueAllocation) error {
+		allocations = append(allocations, allocation)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return allocations, nil
+}
+
+// allocate computes the allocation of the issues and passes each row to emit as soon as it is
+// computed, dividing the rows of split issues across their work types, detaching the rows of
+// retired assets and then applying the work type overrides of their assignees. Rows below the
+// low confidence threshold are collected for review, and the team members credited no hours
+// are checked against the roster.
+func (p *SprintTimeAllocationUseCase) allocate(team domain.Team, issues []domain.JiraIssue, manualAdjustments map[string]float64, emit func(domain.IssueAllocation) error) error {
+	p.adjusted = nil
+	p.lowConfidence = nil
+	p.detached = nil
+	threshold := p.heuristics.LowConfidenceThreshold()
+	totalHoursByPerson := p.calculateTotalHours(team, issues, manualAdjustments)
+	credited := make(map[string]float64)
+	err := p.calculatePercentageLoad(team, issues, manualAdjustments, totalHoursByPerson, func(allocation domain.IssueAllocation) error {
+		return p.emitWorkTypeSplits(allocation, func(row domain.IssueAllocation) error {
+			if work, ok := p.retirements.Apply(&row); ok {
+				p.detached = append(p.detached, work)
+			}
+			if adjustment, ok := p.personWorkTypes.Apply(&row); ok {
+				p.adjusted = append(p.adjusted, adjustment)
+			}
+			if row.Confidence() < threshold {
+				p.lowConfidence = append(p.lowConfidence, domain.NewLowConfidenceRow(row))
+			}
+			credited[row.Assignee] += row.Hours
+			return emit(row)
+		})
+	})
+	if err != nil {
+		return err
+	}
+	return p.checkRoster(team, issues, credited)
+}
+
+// checkRoster records the team members credited no hours, failing with the strict roster
+func (p *SprintTimeAllocationUseCase) checkRoster(team domain.Team, issues []domain.JiraIssue, credited map[string]float64) error {
+	p.idle = team.IdleMembers(credited, team.UnmatchedAssignees(issues))
+	if !p.strictRoster || len(p.idle) == 0 {
+		return nil
+	}
+	reasons := make([]string, len(p.idle))
+	for i, member := range p.idle {
+		reasons[i] = member.Reason()
+	}
+	return fmt.Errorf("strict roster: %s", strings.Join(reasons, "; "))
+}
+
+// prepare loads the project team, the period's issues with their labels as of the snapshot,
+// and the manual hour overrides
+func (p *SprintTimeAllocationUseCase) prepare() (*domain.Team, []domain.JiraIssue, map[string]float64, error) {
 	team, exists := p.teams.GetTeam(p.project)
 	if !exists {
-		return "", fmt.Errorf("project %s not found in teams.json", p.project)
+		return nil, nil, nil, fmt.Errorf("project %s not found in teams.json", p.project)
+	}
+	if _, err := domain.ParseHolidays(team.Holidays); err != nil {
+		return nil, nil, nil, fmt.Errorf("team of project %s: %w", p.project, err)
 	}
 
 	issues, err := p.fetchIssues()
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch issues: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to fetch issues: %w", err)
 	}
+	p.applyLabelSnapshot(issues)
 
 	manualAdjustments, err := p.parseManualAdjustments()
 	if err != nil {
-		return "", err
+		return nil, nil, nil, err
+	}
+	return team, issues, manualAdjustments, nil
+}
+
+// jiraBaseURL returns the configured Jira base URL, or an empty string without configuration
+func (p *SprintTimeAllocationUseCase) jiraBaseURL() string {
+	if p.config == nil {
+		return ""
+	}
+	return p.config.GetBaseURL()
+}
+
+// applyLabelSnapshot replaces the issues' labels with the ones they had at the configured snapshot
+func (p *SprintTimeAllocationUseCase) applyLabelSnapshot(issues []domain.JiraIssue) {
+	if p.labelsAt.IsCurrent() {
+		return
+	}
+
+	for i := range issues {
+		at := p.labelsAt.Date
+		if p.labelsAt.AtCompletion {
+			if !issues[i].Fields.Status.IsDone() {
+				continue
+			}
+			_, at = p.getIssueTimeRange(issues[i])
+			if at.IsZero() {
+				continue
+			}
+		}
+		issues[i].Fields.Labels = issues[i].LabelsAt(at)
+	}
+}
+
+// emitWorkTypeSplits emits the allocation of a split issue as one row per work type, each
+// holding the work type's share of the hours and percentage, and any other allocation as is
+func (p *SprintTimeAllocationUseCase) emitWorkTypeSplits(allocation domain.IssueAllocation, emit func(domain.IssueAllocation) error) error {
+	shares := p.workTypeSplits.For(allocation.IssueKey)
+	if len(shares) == 0 {
+		return emit(allocation)
+	}
+
+	for _, workType := range shares.WorkTypes() {
+		row := allocation
+		row.WorkType = workType
+		row.Hours = allocation.Hours * shares[workType] / 100
+		row.Percentage = allocation.Percentage * shares[workType] / 100
+		if err := emit(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// calendarDay returns the date of t at midnight UTC, or the zero time for the zero time
+func calendarDay(t time.Time) time.Time {
+	if t.IsZero() {
+		return time.Time{}
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// period returns the name of the allocated sprint or fix version
+func (p *SprintTimeAllocationUseCase) period() string {
+	if p.fixVersion != "" {
+		return p.fixVersion
+	}
+	return p.sprint
+}
+
+// sprintID returns the Jira ID of the allocated sprint from the sprints of an issue, zero when
+// allocating a fix version or when the issue does not list it
+func (p *SprintTimeAllocationUseCase) sprintID(issue domain.JiraIssue) int {
+	if p.fixVersion != "" {
+		return 0
 	}
+	sprint, _ := issue.AllocatedSprint(p.sprint)
+	return sprint.ID
+}
 
-	totalHoursByPerson := p.calculateTotalHours(*team, issues, manualAdjustments)
+// memberAccountID returns the Jira account ID of a team member: the one the team records, or
+// else the one the member held the issue with
+func memberAccountID(team domain.Team, issue domain.JiraIssue, member string) string {
+	if accountID := team.AccountIDs[member]; accountID != "" {
+		return accountID
+	}
+	for _, assignment := range issue.AssignmentPeriods() {
+		if assignment.AccountID == "" {
+			continue
+		}
+		if resolved, ok := team.ResolveAssignee(assignment.AccountID, assignment.Assignee); ok && resolved == member {
+			return assignment.AccountID
+		}
+	}
+	return ""
+}
 
-	results := p.calculatePercentageLoad(*team, issues, manualAdjustments, totalHoursByPerson)
+// periodIssues fetches the issues of the sprint, or of the fix version and its release dates
+func (p *SprintTimeAllocationUseCase) periodIssues() ([]ports.JiraIssue, error) {
+	if p.fixVersion == "" {
+		issues, err := p.jiraPort.GetIssuesForSprint(p.project, p.sprint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch sprint issues: %w", err)
+		}
+		return issues, nil
+	}
 
-	csvData, err := p.generateCSV(*team, results)
+	releases, ok := p.jiraPort.(ports.JiraReleasePort)
+	if !ok {
+		return nil, fmt.Errorf("the Jira integration does not support fix versions")
+	}
+	release, err := releases.GetRelease(p.project, p.fixVersion)
 	if err != nil {
-		return "", fmt.Errorf("failed to generate CSV: %w", err)
+		return nil, fmt.Errorf("failed to fetch fix version: %w", err)
 	}
+	p.release = release
 
-	return csvData, nil
+	issues, err := releases.GetIssuesForFixVersion(p.project, p.fixVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch fix version issues: %w", err)
+	}
+	return issues, nil
+}
+
+// releaseWindow clips a tracked time range to the release dates when allocating a fix
+// version, reporting false when the work falls outside the release
+func (p *SprintTimeAllocationUseCase) releaseWindow(startTime, endTime time.Time) (time.Time, time.Time, bool) {
+	if p.release == nil || startTime.IsZero() || endTime.IsZero() {
+		return startTime, endTime, true
+	}
+	return p.release.Window(startTime, endTime)
 }
 
 func (p *SprintTimeAllocationUseCase) fetchIssues() ([]domain.JiraIssue, error) {
-	issues, err := p.jiraPort.GetIssuesForSprint(p.project, p.sprint)
+	issues, err := p.periodIssues()
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch sprint issues: %w", err)
+		return nil, err
 	}
+	return toDomainIssues(issues), nil
+}
 
-	var domainIssues = make([]domain.JiraIssue, 0, len(issues))
+// toDomainIssues converts the issues read through the Jira port to domain issues
+func toDomainIssues(issues []ports.JiraIssue) []domain.JiraIssue {
+	domainIssues := make([]domain.JiraIssue, 0, len(issues))
 	for _, issue := range issues {
-		domainIssue := domain.JiraIssue{
-			Key: issue.Key,
-			Fields: domain.JiraFields{
-				Summary: issue.Summary,
-				Assignee: domain.JiraAssignee{
-					DisplayName: issue.Assignee,
-				},
-				Status: domain.JiraStatus{
-					Name: issue.Status,
-				},
-				StoryPoints: issue.StoryPoints,
-				IssueType: domain.IssueType{
-					Name: issue.IssueType,
-				},
-				Labels: issue.Labels,
+		domainIssues = append(domainIssues, toDomainIssue(issue))
+	}
+	return domainIssues
+}
+
+// toDomainIssue converts an issue read through the Jira port, with its sprints and changelog
+func toDomainIssue(issue ports.JiraIssue) domain.JiraIssue {
+	domainIssue := domain.JiraIssue{
+		Key: issue.Key,
+		Fields: domain.JiraFields{
+			Summary: issue.Summary,
+			Assignee: domain.JiraAssignee{
+				AccountID:   issue.AssigneeAccountID,
+				DisplayName: issue.Assignee,
+			},
+			Status: domain.JiraStatus{
+				Name:     issue.Status,
+				Category: domain.JiraStatusCategory{Key: issue.StatusCategory},
 			},
-			Changelog: domain.JiraChangelog{
-				Histories: make([]domain.JiraChangeHistory, len(issue.Changelog.Histories)),
+			StoryPoints: issue.StoryPoints,
+			IssueType: domain.IssueType{
+				Name:    issue.IssueType,
+				Subtask: issue.IssueTypeSubtask,
 			},
+			Labels:            issue.Labels,
+			Sprints:           make([]domain.JiraSprint, len(issue.Sprints)),
+			Created:           issue.Created,
+			ResolutionDate:    issue.ResolutionDate,
+			OriginalEstimate:  issue.OriginalEstimate,
+			RemainingEstimate: issue.RemainingEstimate,
+		},
+		Changelog: domain.JiraChangelog{
+			Histories: make([]domain.JiraChangeHistory, len(issue.Changelog.Histories)),
+		},
+		ChangelogUnavailable: issue.ChangelogUnavailable,
+	}
+
+	for i, sprint := range issue.Sprints {
+		domainIssue.Fields.Sprints[i] = domain.JiraSprint{
+			ID:        sprint.ID,
+			Name:      sprint.Name,
+			State:     sprint.State,
+			StartDate: sprint.StartDate,
+			EndDate:   sprint.EndDate,
+		}
+	}
+
+	// Convert changelog histories
+	for i, history := range issue.Changelog.Histories {
+		domainHistory := domain.JiraChangeHistory{
+			Created: history.Created,
+			Items:   make([]domain.JiraChangeItem, len(history.Items)),
 		}
 
-		// Convert changelog histories
-		for i, history := range issue.Changelog.Histories {
-			domainHistory := domain.JiraChangeHistory{
-				Created: history.Created,
-				Items:   make([]domain.JiraChangeItem, len(history.Items)),
+		// Convert changelog items
+		for j, item := range history.Items {
+			domainHistory.Items[j] = domain.JiraChangeItem{
+				Field:        item.Field,
+				From:         item.From,
+				FromString:   item.FromString,
+				To:           item.To,
+				ToString:     item.ToString,
+				FromCategory: item.FromCategory,
+				ToCategory:   item.ToCategory,
 			}
+		}
 
-			// Convert changelog items
-			for j, item := range history.Items {
-				domainHistory.Items[j] = domain.JiraChangeItem{
-					Field:      item.Field,
-					FromString: item.FromString,
-					ToString:   item.ToString,
-				}
-			}
+		domainIssue.Changelog.Histories[i] = domainHistory
+	}
+	return domainIssue
+}
+
+// LintAssignees returns the sprint assignees that could not be resolved to a
+// member of the project's team, either directly or through an alias
+func (p *SprintTimeAllocationUseCase) LintAssignees() (*domain.AssigneeLintResult, error) {
+	team, exists := p.teams.GetTeam(p.project)
+	if !exists {
+		return nil, fmt.Errorf("project %s not found in teams.json", p.project)
+	}
 
-			domainIssue.Changelog.Histories[i] = domainHistory
+	issues, err := p.fetchIssues()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch issues: %w", err)
+	}
+
+	issuesByAssignee := make(map[string][]string)
+	for _, issue := range issues {
+		assignee := issue.Fields.Assignee.DisplayName
+		if assignee == "" && issue.Fields.Assignee.AccountID == "" {
+			continue
 		}
+		if _, isMember := team.ResolveAssignee(issue.Fields.Assignee.AccountID, assignee); isMember {
+			continue
+		}
+		if assignee == "" {
+			assignee = issue.Fields.Assignee.AccountID
+		}
+		issuesByAssignee[assignee] = append(issuesByAssignee[assignee], issue.Key)
+	}
 
-		domainIssues = append(domainIssues, domainIssue)
+	unmatched := make([]domain.UnmatchedAssignee, 0, len(issuesByAssignee))
+	for name, keys := range issuesByAssignee {
+		unmatched = append(unmatched, domain.UnmatchedAssignee{Name: name, Issues: keys})
 	}
+	sort.Slice(unmatched, func(i, j int) bool {
+		return unmatched[i].Name < unmatched[j].Name
+	})
 
-	return domainIssues, nil
+	return &domain.AssigneeLintResult{
+		Unmatched:      unmatched,
+		UnknownAliases: team.UnknownAliasTargets(),
+	}, nil
 }
 
 func (p *SprintTimeAllocationUseCase) parseManualAdjustments() (map[string]float64, error) {
@@ -192,14 +739,8 @@ func (p *SprintTimeAllocationUseCase) calculateTotalHours(team domain.Team, issu
 	}
 
 	for _, issue := range issues {
-		assignee := issue.Fields.Assignee.DisplayName
-
-		if !team.IsTeamMember(assignee) {
-			continue
-		}
-
 		// Skip Sub-tasks
-		if issue.Fields.IssueType.Name == issueTypeSubTask {
+		if issue.Fields.IssueType.IsSubtask() {
 			continue
 		}
 
@@ -207,10 +748,15 @@ func (p *SprintTimeAllocationUseCase) calculateTotalHours(team domain.Team, issu
 		if startTime.IsZero() {
 			continue
 		}
+		startTime, endTime, inRelease := p.releaseWindow(startTime, endTime)
+		if !inRelease {
+			continue
+		}
 
-		workingHours := p.calculateWorkingHours(issue.Key, manualAdjustments, startTime, endTime)
-
-		totalHoursByPerson[assignee] += workingHours
+		shares, _ := p.attributeHours(team, issue, manualAdjustments, startTime, endTime, !endTime.IsZero())
+		for _, share := range shares {
+			totalHoursByPerson[share.assignee] += share.hours
+		}
 	}
 
 	return totalHoursByPerson
@@ -241,8 +787,10 @@ func (p *SprintTimeAllocationUseCase) getIssueTimeRange(issue domain.JiraIssue)
 			}
 			historyTime = historyTime.UTC()
 
-			// Look for transition into "In Progress" state
-			if item.ToString == "In Progress" {
+			from, to := item.FromStatusCategory(), item.ToStatusCategory()
+
+			// Look for transition into an in progress status
+			if to == domain.StatusCategoryInProgress {
 				if firstInProgressTime.IsZero() {
 					firstInProgressTime = historyTime
 				}
@@ -250,8 +798,8 @@ func (p *SprintTimeAllocationUseCase) getIssueTimeRange(issue domain.JiraIssue)
 				inProgress = true
 			}
 
-			// Look for transition to "Done" or "Won't Do" state
-			if item.ToString == statusDone || item.ToString == statusWontDo {
+			// Look for transition to a done status, e.g. Done or Won't Do
+			if to == domain.StatusCategoryDone {
 				endTime = historyTime
 				// If we weren't in progress, use the completion time as start time
 				if !inProgress && startTime.IsZero() {
@@ -259,9 +807,9 @@ func (p *SprintTimeAllocationUseCase) getIssueTimeRange(issue domain.JiraIssue)
 				}
 			}
 
-			// If moving out of "In Progress" to a non-Done state, consider this a pause
-			if inProgress && item.FromString == "In Progress" &&
-				item.ToString != statusDone && item.ToString != statusWontDo {
+			// If moving out of progress to a status that is not done, consider this a pause
+			if inProgress && from == domain.StatusCategoryInProgress &&
+				to != domain.StatusCategoryInProgress && to != domain.StatusCategoryDone {
 				// Calculate working hours up to this point and add to total
 				p.calculateWorkingHours(issue.Key, nil, startTime, historyTime)
 				inProgress = false
@@ -278,205 +826,585 @@ func (p *SprintTimeAllocationUseCase) getIssueTimeRange(issue domain.JiraIssue)
 	return startTime, endTime
 }
 
-func (p *SprintTimeAllocationUseCase) calculatePercentageLoad(team domain.Team, issues []domain.JiraIssue, manualAdjustments map[string]float64, totalHoursByPerson map[string]float64) []map[string]interface{} {
-	var results = make([]map[string]interface{}, 0, len(issues))
-	personHours := make(map[string]float64) // Track total hours per person
+// calculatePercentageLoad computes the share of each team member's time spent on every issue,
+// passing one allocation per issue and team member to emit and reporting progress per issue
+func (p *SprintTimeAllocationUseCase) calculatePercentageLoad(team domain.Team, issues []domain.JiraIssue, manualAdjustments map[string]float64, totalHoursByPerson map[string]float64, emit func(domain.IssueAllocation) error) error {
+	// First pass: calculate raw hours per team member
+	works, personHours, unattributed := p.issueWorks(team, issues, manualAdjustments)
+	p.unattributed = unattributed
+	p.applied = appliedHeuristics(works)
+	p.absent = appliedAbsences(works)
+	p.overlaps = sprintOverlaps(works)
+	p.stale = staleIssues(works)
+
+	// Second pass: let the strategy split each person's hours across their issues
+	allocated := p.allocationStrategy().Allocate(p.window(), team, trackedHours(works, personHours, totalHoursByPerson))
+	if p.normalization == domain.NormalizationCapacity {
+		allocated = domain.NormalizeByCapacity(team, allocated)
+	}
+	period := p.period()
+	baseURL := p.jiraBaseURL()
+
+	for i, work := range works {
+		issue := work.issue
+		scopeChanges := p.scopeChanges(issue)
+		confidenceFactors := work.confidenceFactors()
+		account := p.assetAccounts.For(issue.GetAssetName())
+		sprintID := p.sprintID(issue)
+		for _, share := range allocated[i].Members {
+			allocation := domain.IssueAllocation{
+				Sprint:            period,
+				SprintID:          sprintID,
+				IssueKey:          issue.Key,
+				IssueType:         issue.Fields.IssueType.Name,
+				IssueTitle:        issue.Fields.Summary,
+				Assignee:          share.Assignee,
+				AssigneeAccountID: memberAccountID(team, issue, share.Assignee),
+				WorkType:          issue.GetWorkType(),
+				AssetName:         issue.GetAssetName(),
+				Status:            issue.Fields.Status.Name,
+				StatusCategory:    issue.Fields.Status.StatusCategory(),
+				Hours:             share.Hours,
+				Percentage:        share.Percentage,
+				DateStarted:       calendarDay(work.startTime),
+				EvidenceURL:       domain.IssueURL(baseURL, issue.Key),
+				AssetURL:          p.assetDocs.For(issue.GetAssetName()),
+				CostCenter:        account.CostCenter,
+				GLAccount:         account.GLAccount,
+				ScopeChanges:      scopeChanges,
+				ConfidenceFactors: confidenceFactors,
+				OriginalEstimate:  domain.EstimateHours(issue.Fields.OriginalEstimate),
+				RemainingEstimate: domain.EstimateHours(issue.Fields.RemainingEstimate),
+			}
 
-	// First pass: calculate raw hours and percentages
-	for _, issue := range issues {
-		assignee := issue.Fields.Assignee.DisplayName
+			// Only set completion date if the issue is actually completed
+			if issue.Fields.Status.IsDone() {
+				allocation.DateCompleted = calendarDay(work.endTime)
+			}
 
-		if !team.IsTeamMember(assignee) {
-			continue
+			if err := emit(allocation); err != nil {
+				return err
+			}
 		}
-
-		// Skip Sub-tasks
-		if issue.Fields.IssueType.Name == issueTypeSubTask {
-			continue
+		if p.progress != nil {
+			p.progress(i+1, len(works))
 		}
+	}
 
-		startTime, endTime := p.getIssueTimeRange(issue)
-		if startTime.IsZero() && len(issue.Changelog.Histories) > 0 {
-			// If there's no start time but we have changelog entries,
-			// use the first changelog entry as the start time
-			startTime, _ = time.Parse(time.RFC3339, issue.Changelog.Histories[0].Created)
-		}
-		if startTime.IsZero() {
-			// If we still don't have a start time, use a default duration of 8 hours
-			endTime = time.Now()
-			startTime = endTime.Add(-8 * time.Hour)
-		}
+	return nil
+}
+
+// allocationStrategy returns the configured strategy, splitting hours by time in progress by default
+func (p *SprintTimeAllocationUseCase) allocationStrategy() domain.AllocationStrategy {
+	if p.strategy == nil {
+		return domain.TimeStrategy{}
+	}
+	return p.strategy
+}
 
-		workingHours := p.calculateWorkingHours(issue.Key, manualAdjustments, startTime, endTime)
+// window names the sprint or fix version being allocated
+func (p *SprintTimeAllocationUseCase) window() domain.AllocationWindow {
+	return domain.AllocationWindow{Sprint: p.sprint, FixVersion: p.fixVersion}
+}
 
-		// For percentage calculations, ensure a minimum of 1 hour for completed issues in the same day
-		if workingHours < 1 && startTime.Year() == endTime.Year() && startTime.Month() == endTime.Month() && startTime.Day() == endTime.Day() &&
-			(issue.Fields.Status.Name == statusDone || issue.Fields.Status.Name == statusWontDo) {
-			workingHours = 1
+// trackedHours returns the hours credited for each issue's window with each person's
+// percentage of their tracked hours, or zero for people without tracked hours
+func trackedHours(works []issueWork, personHours, totalHoursByPerson map[string]float64) []domain.IssueHours {
+	tracked := make([]domain.IssueHours, 0, len(works))
+	for _, work := range works {
+		members := make([]domain.MemberHours, 0, len(work.shares))
+		for _, share := range work.shares {
+			percentage := 0.0
+			if totalHoursByPerson[share.assignee] != 0 {
+				// The proportion of hours this issue represents of the person's total hours across all issues
+				percentage = (share.hours / personHours[share.assignee]) * 100
+			}
+			members = append(members, domain.MemberHours{Assignee: share.assignee, Hours: share.hours, Percentage: percentage})
 		}
+		tracked = append(tracked, domain.IssueHours{Issue: work.issue, Members: members})
+	}
+	return tracked
+}
 
-		personHours[assignee] += workingHours
+// issueWork is the in-progress window of an allocated issue and the hours credited for it
+type issueWork struct {
+	issue              domain.JiraIssue
+	startTime, endTime time.Time
+	shares             []attribution
+	// heuristic names the heuristic that set the window of an untracked issue, if any
+	heuristic string
+	// raised marks a same-day completion raised to the minimum
+	raised bool
+	// overlap records the split of time with the sprints overlapping the allocated one, if any
+	overlap *domain.SprintOverlap
+	// stale records a window longer than the stale threshold, if any
+	stale *domain.StaleIssue
+	// changelogStart marks a window started at the first changelog entry of an issue that never
+	// went In Progress
+	changelogStart bool
+	// unattributed marks an issue part of whose time no team member held
+	unattributed bool
+}
+
+// confidenceFactors lists the fallbacks, caps and heuristics that shaped the issue's hours
+func (w issueWork) confidenceFactors() []domain.ConfidenceFactor {
+	var factors []domain.ConfidenceFactor
+	switch w.heuristic {
+	case domain.HeuristicDefaultHours:
+		factors = append(factors, domain.ConfidenceDefaultHours)
+	case domain.HeuristicResolutionDate:
+		factors = append(factors, domain.ConfidenceResolutionDate)
+	}
+	if w.changelogStart {
+		factors = append(factors, domain.ConfidenceChangelogStart)
+	}
+	if w.raised {
+		factors = append(factors, domain.ConfidenceSameDayMinimum)
+	}
+	if w.stale != nil && w.stale.Capped {
+		factors = append(factors, domain.ConfidenceStaleCapped)
+	} else if w.stale != nil {
+		factors = append(factors, domain.ConfidenceStale)
+	}
+	if w.overlap != nil {
+		factors = append(factors, domain.ConfidenceSprintOverlap)
+	}
+	if w.unattributed {
+		factors = append(factors, domain.ConfidenceUnattributed)
 	}
+	return factors
+}
 
-	// Second pass: calculate normalized percentages
-	for _, issue := range issues {
-		assignee := issue.Fields.Assignee.DisplayName
+// issueWorks calculates the raw hours each team member spent on the allocatable issues,
+// returning them per issue and per person with the time no team member held
+func (p *SprintTimeAllocationUseCase) issueWorks(team domain.Team, issues []domain.JiraIssue, manualAdjustments map[string]float64) ([]issueWork, map[string]float64, []domain.UnattributedTime) {
+	personHours := make(map[string]float64) // Track total hours per person
+	var unattributedTime []domain.UnattributedTime
+	works := make([]issueWork, 0, len(issues))
 
-		if !team.IsTeamMember(assignee) {
+	for _, issue := range issues {
+		// Skip Sub-tasks
+		if issue.Fields.IssueType.IsSubtask() {
 			continue
 		}
 
-		// Skip Sub-tasks
-		if issue.Fields.IssueType.Name == issueTypeSubTask {
+		startTime, endTime, tracked, heuristic, ok := p.allocationWindow(issue)
+		if !ok {
 			continue
 		}
 
-		startTime, endTime := p.getIssueTimeRange(issue)
-		if startTime.IsZero() && len(issue.Changelog.Histories) > 0 {
-			startTime, _ = time.Parse(time.RFC3339, issue.Changelog.Histories[0].Created)
+		shares, unattributed := p.attributeHours(team, issue, manualAdjustments, startTime, endTime, tracked)
+		unattributedTime = append(unattributedTime, unattributed...)
+		if len(shares) == 0 {
+			continue
 		}
-		if startTime.IsZero() {
-			endTime = time.Now()
-			startTime = endTime.Add(-8 * time.Hour)
+		_, overridden := manualAdjustments[issue.Key]
+		if overridden {
+			// Manual hours replace the estimated window
+			heuristic = ""
+		}
+		changelogStart := !overridden && heuristic == "" && p.startsAtChangelog(issue)
+
+		// Split the time spent while overlapping sprints ran with them; manual hours and
+		// assumed windows are kept whole
+		var overlap *domain.SprintOverlap
+		if !overridden && p.fixVersion == "" && heuristic != domain.HeuristicDefaultHours {
+			if share, sprints := issue.SprintOverlapShare(p.sprint, startTime, endTime); len(sprints) > 0 {
+				for i := range shares {
+					shares[i].hours *= share
+				}
+				overlap = &domain.SprintOverlap{IssueKey: issue.Key, Sprints: sprints, Share: share}
+			}
 		}
 
-		workingHours := p.calculateWorkingHours(issue.Key, manualAdjustments, startTime, endTime)
+		// For percentage calculations, ensure a minimum for completed issues in the same day
+		raised := false
+		minimum, enabled := p.heuristics.MinimumSameDayHours()
+		if enabled && len(shares) == 1 && shares[0].hours < minimum && startTime.Year() == endTime.Year() && startTime.Month() == endTime.Month() && startTime.Day() == endTime.Day() &&
+			issue.Fields.Status.IsDone() {
+			shares[0].hours = minimum
+			raised = true
+		}
 
-		// For percentage calculations, ensure a minimum of 1 hour for completed issues in the same day
-		if workingHours < 1 && startTime.Year() == endTime.Year() && startTime.Month() == endTime.Month() && startTime.Day() == endTime.Day() &&
-			(issue.Fields.Status.Name == statusDone || issue.Fields.Status.Name == statusWontDo) {
-			workingHours = 1
+		// Flag tracked windows longer than the stale threshold, capping their hours when configured
+		var stale *domain.StaleIssue
+		if tracked && !overridden {
+			if flagged, ok := p.heuristics.Stale(issue.Key, startTime, endTime, totalShareHours(shares)); ok {
+				if flagged.Capped {
+					scale := flagged.Hours / totalShareHours(shares)
+					for i := range shares {
+						shares[i].hours *= scale
+					}
+				}
+				stale = &flagged
+			}
 		}
 
-		totalHours := totalHoursByPerson[assignee]
-		percentageLoad := 0.0
-		if totalHours != 0 {
-			// Calculate percentage based on the proportion of hours this issue represents
-			// of the person's total hours across all issues
-			percentageLoad = (workingHours / personHours[assignee]) * 100
+		for _, share := range shares {
+			personHours[share.assignee] += share.hours
+			if overlap != nil {
+				overlap.Hours += share.hours
+			}
 		}
+		works = append(works, issueWork{issue: issue, startTime: startTime, endTime: endTime, shares: shares, heuristic: heuristic, raised: raised, overlap: overlap, stale: stale,
+			changelogStart: changelogStart, unattributed: len(unattributed) > 0})
+	}
+	return works, personHours, unattributedTime
+}
+
+// allocationWindow returns the window an issue's hours are taken from: its In Progress time
+// clipped to the release, or a fallback when it never went In Progress or its changelog is
+// unavailable. tracked reports a window with both ends from the changelog, heuristic the
+// heuristic that set an untracked window, and ok false skips the issue.
+func (p *SprintTimeAllocationUseCase) allocationWindow(issue domain.JiraIssue) (startTime, endTime time.Time, tracked bool, heuristic string, ok bool) {
+	startTime, endTime = p.getIssueTimeRange(issue)
+	if issue.ChangelogUnavailable {
+		if start, end, resolved := p.resolutionWindow(issue); resolved {
+			startTime, endTime, heuristic = start, end, domain.HeuristicResolutionDate
+		}
+	}
+	startTime, endTime, inRelease := p.releaseWindow(startTime, endTime)
+	if !inRelease {
+		return startTime, endTime, false, "", false
+	}
+	if heuristic != "" {
+		return startTime, endTime, false, heuristic, true
+	}
+	tracked = !startTime.IsZero() && !endTime.IsZero()
+	if startTime.IsZero() && len(issue.Changelog.Histories) > 0 {
+		// If there's no start time but we have changelog entries,
+		// use the first changelog entry as the start time
+		startTime, _ = time.Parse(time.RFC3339, issue.Changelog.Histories[0].Created)
+	}
+	if startTime.IsZero() {
+		// If we still don't have a start time, use the default duration, or skip the issue
+		hours, enabled := p.heuristics.UntrackedHours()
+		if !enabled {
+			return startTime, endTime, false, "", false
+		}
+		endTime = time.Now()
+		startTime = endTime.Add(-time.Duration(hours * float64(time.Hour)))
+		heuristic = domain.HeuristicDefaultHours
+	}
+	return startTime, endTime, tracked, heuristic, true
+}
 
-		result := make(map[string]interface{})
-		result["sprint"] = p.sprint
-		result["issueKey"] = issue.Key
-		result["issueType"] = issue.Fields.IssueType.Name
-		result["issueTitle"] = issue.Fields.Summary
-		result["workType"] = issue.GetWorkType()
-		result["assetName"] = issue.GetAssetName()
-		result["status"] = issue.Fields.Status.Name
-		result["dateStarted"] = startTime.Format("2006-01-02")
-		result["workingHours"] = workingHours
+// startsAtChangelog reports whether the window of an issue that never went In Progress nor
+// completed is started at its first changelog entry
+func (p *SprintTimeAllocationUseCase) startsAtChangelog(issue domain.JiraIssue) bool {
+	startTime, _ := p.getIssueTimeRange(issue)
+	return startTime.IsZero() && len(issue.Changelog.Histories) > 0
+}
+
+// resolutionWindow estimates the In Progress window of an issue without a changelog as running
+// from its creation, or the start of the allocated sprint when later, to its resolution. It
+// returns false for unresolved issues.
+func (p *SprintTimeAllocationUseCase) resolutionWindow(issue domain.JiraIssue) (time.Time, time.Time, bool) {
+	end, err := domain.ParseJiraTime(issue.Fields.ResolutionDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	start, err := domain.ParseJiraTime(issue.Fields.Created)
+	if err != nil {
+		start = end
+	}
+	if sprintStart, _ := issue.SprintWindow(p.sprint); sprintStart.After(start) {
+		start = sprintStart
+	}
+	if start.After(end) {
+		start = end
+	}
+	return start, end, true
+}
 
-		// Only set completion date if the issue is actually completed
-		if issue.Fields.Status.Name == statusDone || issue.Fields.Status.Name == statusWontDo {
-			result["dateCompleted"] = endTime.Format("2006-01-02")
-		} else {
-			result["dateCompleted"] = ""
+// attributeHours credits the working hours of an issue's tracked in-progress window to the
+// team members it was assigned to at the time, and reports the time no team member held.
+// Manual overrides, story point allocation and untracked windows credit the current assignee.
+// The days a member was absent are taken out of the tracked time credited to them.
+func (p *SprintTimeAllocationUseCase) attributeHours(team domain.Team, issue domain.JiraIssue, manualAdjustments map[string]float64, startTime, endTime time.Time, tracked bool) ([]attribution, []domain.UnattributedTime) {
+	_, overridden := manualAdjustments[issue.Key]
+	if overridden || !tracked || p.method == domain.AllocationMethodStoryPoints || !endTime.After(startTime) {
+		assignee, isMember := team.ResolveAssignee(issue.Fields.Assignee.AccountID, issue.Fields.Assignee.DisplayName)
+		if !isMember {
+			return nil, nil
 		}
+		if overridden || !tracked {
+			return []attribution{{assignee: assignee, hours: p.calculateWorkingHours(issue.Key, manualAdjustments, startTime, endTime)}}, nil
+		}
+		return []attribution{p.presentHours(issue.Key, assignee, startTime, endTime)}, nil
+	}
 
-		for _, person := range team.Team {
-			result[person] = ""
+	var shares []attribution
+	var unattributed []domain.UnattributedTime
+	for _, period := range issue.AssignmentPeriods() {
+		from, to, ok := period.Overlap(startTime, endTime)
+		if !ok {
+			continue
 		}
+		assignee, isMember := team.ResolveAssignee(period.AccountID, period.Assignee)
+		if !isMember {
+			hours := p.calculateWorkingHours(issue.Key, nil, from, to)
+			unattributed = addUnattributed(unattributed, domain.UnattributedTime{IssueKey: issue.Key, Assignee: period.Assignee, Hours: hours})
+			continue
+		}
+		shares = addAttribution(shares, p.presentHours(issue.Key, assignee, from, to))
+	}
+	return shares, unattributed
+}
 
-		result[assignee] = fmt.Sprintf("%.2f%%", percentageLoad)
-		results = append(results, result)
+// presentHours credits a member the working hours from start to end they were not absent
+func (p *SprintTimeAllocationUseCase) presentHours(issueKey, assignee string, start, end time.Time) attribution {
+	calendar := p.calendar()
+	working := calendar.WorkingTime(start, end)
+	hours := truncateHours(working)
+	away := p.absences.AwayWorking(assignee, start, end, calendar)
+	if away <= 0 {
+		return attribution{assignee: assignee, hours: hours}
 	}
+	present := truncateHours(working - away)
+	return attribution{assignee: assignee, hours: present, absent: math.Round((hours-present)*100) / 100}
+}
 
-	return results
+// addAttribution adds the share to the member's existing share of the issue, if any
+func addAttribution(shares []attribution, share attribution) []attribution {
+	for i := range shares {
+		if shares[i].assignee == share.assignee {
+			shares[i].hours += share.hours
+			shares[i].absent += share.absent
+			return shares
+		}
+	}
+	return append(shares, share)
 }
 
-func (p *SprintTimeAllocationUseCase) generateCSV(team domain.Team, results []map[string]interface{}) (string, error) {
-	headers := []string{"sprint", "issueKey", "issueType", "issueTitle", "workType", "assetName", "status", "dateStarted", "dateCompleted"}
-	headers = append(headers, team.Team...)
+// addUnattributed adds the time to the existing entry for the same issue and assignee, if any
+func addUnattributed(entries []domain.UnattributedTime, entry domain.UnattributedTime) []domain.UnattributedTime {
+	if entry.Hours <= 0 {
+		return entries
+	}
+	for i := range entries {
+		if entries[i].IssueKey == entry.IssueKey && entries[i].Assignee == entry.Assignee {
+			entries[i].Hours += entry.Hours
+			return entries
+		}
+	}
+	return append(entries, entry)
+}
 
-	csvData, err := p.structArrayToCSVOrdered(results, headers)
-	if err != nil {
-		return "", fmt.Errorf("failed to generate CSV: %w", err)
+// appliedHeuristics lists the heuristics that set the hours of the allocated issues
+func appliedHeuristics(works []issueWork) []domain.AppliedHeuristic {
+	var applied []domain.AppliedHeuristic
+	for _, work := range works {
+		if work.heuristic != "" {
+			applied = append(applied, domain.AppliedHeuristic{IssueKey: work.issue.Key, Heuristic: work.heuristic, Hours: work.shares[0].hours})
+		}
+		if work.raised {
+			applied = append(applied, domain.AppliedHeuristic{IssueKey: work.issue.Key, Heuristic: domain.HeuristicSameDayMinimum, Hours: work.shares[0].hours})
+		}
 	}
+	return applied
+}
 
-	return csvData, nil
+// appliedAbsences lists the time taken out of the allocated issues while their assignees were absent
+func appliedAbsences(works []issueWork) []domain.AppliedAbsence {
+	var absent []domain.AppliedAbsence
+	for _, work := range works {
+		for _, share := range work.shares {
+			if share.absent > 0 {
+				absent = append(absent, domain.AppliedAbsence{IssueKey: work.issue.Key, Assignee: share.assignee, Hours: share.absent})
+			}
+		}
+	}
+	return absent
 }
 
-// calculateWorkingHours calculates the working hours for an issue
-func (p *SprintTimeAllocationUseCase) calculateWorkingHours(issueKey string, manualAdjustments map[string]float64, startTime, endTime time.Time) float64 {
-	// Check for manual adjustments first
-	if manualAdjustments != nil {
-		if hours, ok := manualAdjustments[issueKey]; ok {
-			return hours
+// sprintOverlaps lists the allocated issues whose time was split with overlapping sprints
+func sprintOverlaps(works []issueWork) []domain.SprintOverlap {
+	var overlaps []domain.SprintOverlap
+	for _, work := range works {
+		if work.overlap != nil {
+			overlaps = append(overlaps, *work.overlap)
 		}
 	}
+	return overlaps
+}
 
-	// Calculate hours between start and end time
-	duration := endTime.Sub(startTime)
-	hours := duration.Hours()
+// staleIssues lists the allocated issues In Progress for longer than the stale threshold
+func staleIssues(works []issueWork) []domain.StaleIssue {
+	var stale []domain.StaleIssue
+	for _, work := range works {
+		if work.stale != nil {
+			stale = append(stale, *work.stale)
+		}
+	}
+	return stale
+}
 
-	// Ensure hours is not negative
-	if hours < 0 {
-		hours = 0
+// totalShareHours sums the hours credited to the members sharing an issue
+func totalShareHours(shares []attribution) float64 {
+	total := 0.0
+	for _, share := range shares {
+		total += share.hours
 	}
+	return total
+}
 
-	// Round to 2 decimal places
-	roundedHours := float64(int(hours*100)) / 100
+// staleRecords renders the issues In Progress for longer than the stale threshold as the
+// needs review block of the CSV output
+func staleRecords(stale []domain.StaleIssue, locale domain.Locale) [][]string {
+	records := [][]string{{"needs review", "issueKey", "hours"}}
+	for _, issue := range stale {
+		records = append(records, []string{issue.Reason(), issue.IssueKey, locale.Hours(issue.Hours)})
+	}
+	return records
+}
 
-	return roundedHours
+// lowConfidenceRecords renders the rows below the low confidence threshold as the low
+// confidence block of the CSV output, with what lowered each row's confidence
+func lowConfidenceRecords(rows []domain.LowConfidenceRow, locale domain.Locale) [][]string {
+	records := [][]string{{"low confidence", "issueKey", "hours"}}
+	for _, row := range rows {
+		records = append(records, []string{row.Reason(), row.IssueKey, locale.Hours(row.Hours)})
+	}
+	return records
 }
 
-// structArrayToCSVOrdered converts a slice of maps to CSV format
-func (p *SprintTimeAllocationUseCase) structArrayToCSVOrdered(data []map[string]interface{}, headers []string) (string, error) {
-	if len(data) == 0 {
-		return "", nil
+// retiredRecords renders the rows taken off retired assets as the retired asset block of the
+// CSV output
+func retiredRecords(detached []domain.RetiredAssetWork, locale domain.Locale) [][]string {
+	records := [][]string{{"retired asset", "issueKey", "hours"}}
+	for _, work := range detached {
+		records = append(records, []string{work.Reason(), work.IssueKey, locale.Hours(work.Hours)})
 	}
+	return records
+}
 
-	buffer := &strings.Builder{}
-	writer := csv.NewWriter(buffer)
+// adjustmentRecords renders the rows whose work type was overridden for their assignee as the
+// adjustments block of the CSV output
+func adjustmentRecords(adjusted []domain.WorkTypeAdjustment, locale domain.Locale) [][]string {
+	records := [][]string{{"adjustment", "issueKey", "hours"}}
+	for _, adjustment := range adjusted {
+		records = append(records, []string{adjustment.Reason(), adjustment.IssueKey, locale.Hours(adjustment.Hours)})
+	}
+	return records
+}
 
-	// Configure writer
-	writer.UseCRLF = false
-	writer.Comma = ','
+// warningRecords renders the unattributed time, the applied heuristics, the absences taken out,
+// the overlapping sprints and the team members without hours as a CSV warnings block
+func warningRecords(entries []domain.UnattributedTime, applied []domain.AppliedHeuristic, absent []domain.AppliedAbsence, overlaps []domain.SprintOverlap, idle []domain.IdleMember, locale domain.Locale) [][]string {
+	records := [][]string{{"warning", "issueKey", "hours"}}
+	for _, entry := range entries {
+		records = append(records, []string{entry.Reason(), entry.IssueKey, locale.Hours(entry.Hours)})
+	}
+	for _, heuristic := range applied {
+		records = append(records, []string{heuristic.Reason(), heuristic.IssueKey, locale.Hours(heuristic.Hours)})
+	}
+	for _, absence := range absent {
+		records = append(records, []string{absence.Reason(), absence.IssueKey, locale.Hours(absence.Hours)})
+	}
+	for _, overlap := range overlaps {
+		records = append(records, []string{overlap.Reason(), overlap.IssueKey, locale.Hours(overlap.Hours)})
+	}
+	for _, member := range idle {
+		records = append(records, []string{member.Reason(), "", locale.Hours(0)})
+	}
+	return records
+}
 
-	// Write headers
-	if err := writer.Write(headers); err != nil {
-		return "", err
+// personSummaryRecords renders the per-person summary as a CSV block: the hours and summed
+// percentage of each person, then their percentage on each asset
+func personSummaryRecords(summary *domain.PersonSummary, locale domain.Locale) [][]string {
+	assets := summary.Assets()
+	header := []string{"person", "hours", "percentage"}
+	for _, asset := range assets {
+		if asset == "" {
+			asset = "no asset"
+		}
+		header = append(header, asset)
 	}
 
-	// Write data
-	for _, row := range data {
-		record := make([]string, len(headers))
-		for i, header := range headers {
-			if val, ok := row[header]; ok {
-				record[i] = fmt.Sprintf("%v", val)
+	records := [][]string{header}
+	for _, person := range summary.People() {
+		record := []string{person.Person, locale.Hours(person.Hours), locale.Percent(person.Percentage)}
+		for _, asset := range assets {
+			share, ok := person.Assets[asset]
+			if !ok {
+				record = append(record, "")
+				continue
 			}
+			record = append(record, locale.Percent(share.Percentage))
 		}
-		if err := writer.Write(record); err != nil {
-			return "", err
-		}
+		records = append(records, record)
 	}
+	return records
+}
 
-	writer.Flush()
-	if err := writer.Error(); err != nil {
-		return "", err
+// storyPointsByPerson sums the selected story points of the issues each member is credited for
+func (p *SprintTimeAllocationUseCase) storyPointsByPerson(works []issueWork) map[string]float64 {
+	pointsByPerson := make(map[string]float64)
+	for _, work := range works {
+		for _, share := range work.shares {
+			pointsByPerson[share.assignee] += p.storyPoints(work.issue)
+		}
 	}
+	return pointsByPerson
+}
 
-	// Add quotes to all fields
-	lines := strings.Split(buffer.String(), "\n")
-	for i, line := range lines {
-		if line == "" {
-			continue
-		}
-		fields := strings.Split(line, ",")
-		for j, field := range fields {
-			fields[j] = fmt.Sprintf("%q", field)
+// storyPoints returns the issue's story points at the configured point in the sprint, or zero when unestimated
+func (p *SprintTimeAllocationUseCase) storyPoints(issue domain.JiraIssue) float64 {
+	return domain.StoryPointsStrategy{PointsAt: p.pointsAt}.Points(issue, p.sprint)
+}
+
+// calculateWorkingHours calculates the working hours for an issue
+func (p *SprintTimeAllocationUseCase) calculateWorkingHours(issueKey string, manualAdjustments map[string]float64, startTime, endTime time.Time) float64 {
+	// Check for manual adjustments first
+	if manualAdjustments != nil {
+		if hours, ok := manualAdjustments[issueKey]; ok {
+			return hours
 		}
-		lines[i] = strings.Join(fields, ",")
 	}
-	return strings.Join(lines, "\n"), nil
+
+	return truncateHours(p.calendar().WorkingTime(startTime, endTime))
+}
+
+// calendar returns the business calendar counting working hours, with the holidays of the
+// project's team
+func (p *SprintTimeAllocationUseCase) calendar() domain.BusinessCalendar {
+	calendar := p.heuristics.Calendar
+	if !calendar.Enabled() {
+		return calendar
+	}
+	team, exists := p.teams.GetTeam(p.project)
+	if !exists {
+		return calendar
+	}
+	// prepare has reported invalid holidays already
+	holidays, _ := domain.ParseHolidays(team.Holidays)
+	return calendar.WithHolidays(holidays...)
+}
+
+// truncateHours converts a duration to hours, truncated to 2 decimal places
+func truncateHours(duration time.Duration) float64 {
+	hours := duration.Hours()
+	if hours < 0 {
+		hours = 0
+	}
+	return float64(int(hours*100)) / 100
 }
 
 // JiraDoer is the main entry point for processing Jira issues
 func JiraDoer(project string, sprint string, override string) (string, error) {
-	processor, err := NewSprintTimeAllocationUseCase(project, sprint, override)
+	jiraAdapter, err := infrastructure.NewJiraAdapter()
+	if err != nil {
+		return "", fmt.Errorf("failed to create Jira adapter: %w", err)
+	}
+	processor, err := NewSprintTimeAllocationUseCase(jiraAdapter, project, sprint, override)
+	if err != nil {
+		return "", err
+	}
+	formatter, err := NewCSVFormatter(DefaultCSVDelimiter)
 	if err != nil {
 		return "", err
 	}
-	return processor.Process()
+	return processor.Process(formatter)
 }
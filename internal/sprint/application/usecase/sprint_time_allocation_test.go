@@ -1,14 +1,16 @@
 package usecase
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"math"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
-	"strconv"
+	"runtime"
 	"strings"
 	"testing"
 	"time"
@@ -20,6 +22,7 @@ import (
 	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/config"
 	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
 	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain/ports"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/infrastructure"
 )
 
 // setupTestEnv sets up the test environment and returns a cleanup function
@@ -328,7 +331,9 @@ func TestJiraProcessor_GetIssueTimeRange(t *testing.T) {
 	// Set the base URL to our test server
 	os.Setenv("JIRA_BASE_URL", server.URL)
 
-	processor, err := NewSprintTimeAllocationUseCase("TEST", "Sprint 1", "")
+	jiraAdapter, err := infrastructure.NewJiraAdapter()
+	require.NoError(t, err)
+	processor, err := NewSprintTimeAllocationUseCase(jiraAdapter, "TEST", "Sprint 1", "")
 	require.NoError(t, err, "NewJiraProcessor should not return error")
 
 	issues, err := processor.fetchIssues()
@@ -418,7 +423,7 @@ func TestJiraProcessor_Process(t *testing.T) {
 	}, nil)
 
 	// Process the issues
-	csvData, err := processor.Process()
+	csvData, err := processor.Process(&CSVFormatter{delimiter: ','})
 
 	// Assert no error occurred
 	assert.NoError(t, err)
@@ -809,167 +814,550 @@ func TestCalculatePercentageLoad(t *testing.T) {
 		"test.user": 8.0,
 	}
 
-	results := processor.calculatePercentageLoad(team, issues, nil, totalHoursByPerson)
+	results := percentageLoad(t, processor, team, issues, totalHoursByPerson)
 	require.Len(t, results, 1)
 
 	result := results[0]
-	assert.Equal(t, "2024-03-20", result["dateCompleted"])
+	assert.Equal(t, time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC), result.DateCompleted)
 }
 
-func TestGenerateCSV(t *testing.T) {
-	tests := []struct {
-		name           string
-		team           domain.Team
-		results        []map[string]interface{}
-		expectedHeader string
-		wantErr        bool
-	}{
+func TestCalculatePercentageLoad_EvidenceLinks(t *testing.T) {
+	t.Setenv("JIRA_BASE_URL", "https://example.atlassian.net")
+	t.Setenv("JIRA_EMAIL", "test@example.com")
+	t.Setenv("JIRA_TOKEN", "token")
+	jiraConfig, err := config.NewJiraConfig()
+	require.NoError(t, err)
+
+	processor := &SprintTimeAllocationUseCase{sprint: "Test Sprint", config: jiraConfig}
+	processor.UseAssetDocs(domain.AssetDocLinks{"booking": "https://example.atlassian.net/wiki/pages/123"})
+	processor.UseAssetAccounts(domain.AssetAccounts{"booking": {CostCenter: "CC-100", GLAccount: "1710"}})
+
+	team := domain.Team{Team: []string{"test.user"}}
+	issues := []domain.JiraIssue{
 		{
-			name: "Single engineer team with dateStarted",
-			team: domain.Team{
-				Team: []string{"engineer1"},
+			Key: "TEST-1",
+			Fields: domain.JiraFields{
+				Assignee: domain.JiraAssignee{DisplayName: "test.user"},
+				Labels:   []string{"cap-development", "cap-asset-booking"},
+				Status:   domain.JiraStatus{Name: "Done"},
 			},
-			results: []map[string]interface{}{
-				{
-					"sprint":        "Sprint 1",
-					"issueKey":      "TEST-1",
-					"issueType":     "Task",
-					"issueTitle":    "Test Task",
-					"workType":      "Development",
-					"assetName":     "cap-asset-booking",
-					"status":        "Done",
-					"dateStarted":   "2024-03-20",
-					"dateCompleted": "2024-03-21",
-					"engineer1":     "50.00%",
-				},
-			},
-			expectedHeader: `"sprint","issueKey","issueType","issueTitle","workType","assetName","status","dateStarted","dateCompleted","engineer1"`,
-			wantErr:        false,
 		},
 		{
-			name: "Single engineer team",
-			team: domain.Team{
-				Team: []string{"engineer1"},
+			Key: "TEST-2",
+			Fields: domain.JiraFields{
+				Assignee: domain.JiraAssignee{DisplayName: "test.user"},
+				Status:   domain.JiraStatus{Name: "Done"},
 			},
-			results: []map[string]interface{}{
-				{
-					"sprint":        "Sprint 1",
-					"issueKey":      "TEST-1",
-					"issueType":     "Task",
-					"issueTitle":    "Test Task",
-					"workType":      "Development",
-					"assetName":     "cap-asset-booking",
-					"status":        "Done",
-					"dateStarted":   "2024-03-20",
-					"dateCompleted": "2024-03-21",
-					"engineer1":     "50.00%",
-				},
-			},
-			expectedHeader: `"sprint","issueKey","issueType","issueTitle","workType","assetName","status","dateStarted","dateCompleted","engineer1"`,
-			wantErr:        false,
 		},
+	}
+
+	results := percentageLoad(t, processor, team, issues, map[string]float64{"test.user": 8.0})
+	require.Len(t, results, 2)
+
+	allocations := results
+	assert.Equal(t, "https://example.atlassian.net/browse/TEST-1", allocations[0].EvidenceURL)
+	assert.Equal(t, "https://example.atlassian.net/wiki/pages/123", allocations[0].AssetURL)
+	assert.Equal(t, "https://example.atlassian.net/browse/TEST-2", allocations[1].EvidenceURL)
+	assert.Empty(t, allocations[1].AssetURL)
+	assert.Equal(t, "CC-100", allocations[0].CostCenter)
+	assert.Equal(t, "1710", allocations[0].GLAccount)
+	assert.Empty(t, allocations[1].CostCenter)
+}
+
+func TestCalculatePercentageLoad_Estimates(t *testing.T) {
+	processor := &SprintTimeAllocationUseCase{sprint: "Test Sprint"}
+	original, remaining := int64(14400), int64(0)
+	team := domain.Team{Team: []string{"test.user"}}
+	issues := []domain.JiraIssue{
 		{
-			name: "Multiple engineers team",
-			team: domain.Team{
-				Team: []string{"engineer1", "engineer2", "engineer3"},
+			Key: "TEST-1",
+			Fields: domain.JiraFields{
+				Assignee:          domain.JiraAssignee{DisplayName: "test.user"},
+				Status:            domain.JiraStatus{Name: "Done"},
+				OriginalEstimate:  &original,
+				RemainingEstimate: &remaining,
 			},
-			results: []map[string]interface{}{
-				{
-					"sprint":        "Sprint 1",
-					"issueKey":      "TEST-1",
-					"issueType":     "Task",
-					"issueTitle":    "Test Task",
-					"workType":      "Development",
-					"assetName":     "cap-asset-booking",
-					"status":        "Done",
-					"dateStarted":   "2024-03-20",
-					"dateCompleted": "2024-03-21",
-					"engineer1":     "30.00%",
-					"engineer2":     "70.00%",
-					"engineer3":     "",
-				},
-			},
-			expectedHeader: `"sprint","issueKey","issueType","issueTitle","workType","assetName","status","dateStarted","dateCompleted","engineer1","engineer2","engineer3"`,
-			wantErr:        false,
 		},
 		{
-			name: "Empty team",
-			team: domain.Team{
-				Team: []string{},
+			Key: "TEST-2",
+			Fields: domain.JiraFields{
+				Assignee: domain.JiraAssignee{DisplayName: "test.user"},
+				Status:   domain.JiraStatus{Name: "Done"},
 			},
-			results: []map[string]interface{}{
-				{
-					"sprint":        "Sprint 1",
-					"issueKey":      "TEST-1",
-					"issueType":     "Task",
-					"issueTitle":    "Test Task",
-					"workType":      "Development",
-					"assetName":     "cap-asset-booking",
-					"status":        "Done",
-					"dateStarted":   "2024-03-20",
-					"dateCompleted": "2024-03-21",
-				},
-			},
-			expectedHeader: `"sprint","issueKey","issueType","issueTitle","workType","assetName","status","dateStarted","dateCompleted"`,
-			wantErr:        false,
+		},
+	}
+
+	results := percentageLoad(t, processor, team, issues, map[string]float64{"test.user": 8.0})
+	require.Len(t, results, 2)
+	require.NotNil(t, results[0].OriginalEstimate)
+	assert.Equal(t, 4.0, *results[0].OriginalEstimate)
+	require.NotNil(t, results[0].RemainingEstimate)
+	assert.Zero(t, *results[0].RemainingEstimate)
+	assert.Nil(t, results[1].OriginalEstimate)
+}
+
+func TestCalculatePercentageLoad_AssignmentPeriods(t *testing.T) {
+	processor := &SprintTimeAllocationUseCase{sprint: "Test Sprint"}
+	team := domain.Team{Team: []string{"alice", "bob"}}
+	history := func(created string, items ...domain.JiraChangeItem) domain.JiraChangeHistory {
+		return domain.JiraChangeHistory{Created: created, Items: items}
+	}
+	issues := []domain.JiraIssue{
+		{
+			Key: "TEST-1",
+			Fields: domain.JiraFields{
+				Assignee: domain.JiraAssignee{DisplayName: "bob"},
+				Status:   domain.JiraStatus{Name: "Done"},
+			},
+			Changelog: domain.JiraChangelog{Histories: []domain.JiraChangeHistory{
+				history("2024-03-18T09:00:00.000+0000", domain.JiraChangeItem{Field: "status", FromString: "To Do", ToString: "In Progress"}),
+				history("2024-03-18T11:00:00.000+0000", domain.JiraChangeItem{Field: "assignee", ToString: "alice"}),
+				history("2024-03-18T15:00:00.000+0000", domain.JiraChangeItem{Field: "assignee", FromString: "alice", ToString: "bob"}),
+				history("2024-03-18T17:00:00.000+0000", domain.JiraChangeItem{Field: "status", FromString: "In Progress", ToString: "Done"}),
+			}},
+		},
+		{
+			Key: "TEST-2",
+			Fields: domain.JiraFields{
+				Assignee: domain.JiraAssignee{DisplayName: "carol"},
+				Status:   domain.JiraStatus{Name: "Done"},
+			},
+			Changelog: domain.JiraChangelog{Histories: []domain.JiraChangeHistory{
+				history("2024-03-19T09:00:00.000+0000", domain.JiraChangeItem{Field: "status", FromString: "To Do", ToString: "In Progress"}),
+				history("2024-03-19T12:00:00.000+0000", domain.JiraChangeItem{Field: "status", FromString: "In Progress", ToString: "Done"}),
+			}},
+		},
+	}
+
+	totalHours := processor.calculateTotalHours(team, issues, nil)
+	assert.Equal(t, 4.0, totalHours["alice"])
+	assert.Equal(t, 2.0, totalHours["bob"])
+
+	results := percentageLoad(t, processor, team, issues, totalHours)
+	require.Len(t, results, 2)
+
+	alice := results[0]
+	assert.Equal(t, "TEST-1", alice.IssueKey)
+	assert.Equal(t, "alice", alice.Assignee)
+	assert.Equal(t, 4.0, alice.Hours)
+	bob := results[1]
+	assert.Equal(t, "TEST-1", bob.IssueKey)
+	assert.Equal(t, "bob", bob.Assignee)
+	assert.Equal(t, 2.0, bob.Hours)
+
+	assert.Equal(t, []domain.UnattributedTime{
+		{IssueKey: "TEST-1", Hours: 2},
+		{IssueKey: "TEST-2", Assignee: "carol", Hours: 3},
+	}, processor.Unattributed())
+}
+
+func TestAllocate_IdleMembers(t *testing.T) {
+	team := domain.Team{Team: []string{"alice", "Carol Jones"}}
+	issue := func(key, assignee string) domain.JiraIssue {
+		return domain.JiraIssue{
+			Key: key,
+			Fields: domain.JiraFields{
+				Assignee: domain.JiraAssignee{DisplayName: assignee},
+				Status:   domain.JiraStatus{Name: "Done"},
+			},
+			Changelog: domain.JiraChangelog{Histories: []domain.JiraChangeHistory{
+				{Created: "2024-03-18T09:00:00.000+0000", Items: []domain.JiraChangeItem{{Field: "status", FromString: "To Do", ToString: "In Progress"}}},
+				{Created: "2024-03-18T13:00:00.000+0000", Items: []domain.JiraChangeItem{{Field: "status", FromString: "In Progress", ToString: "Done"}}},
+			}},
+		}
+	}
+	issues := []domain.JiraIssue{issue("TEST-1", "alice"), issue("TEST-2", "carol.jones@example.com")}
+	emit := func(domain.IssueAllocation) error { return nil }
+
+	processor := &SprintTimeAllocationUseCase{sprint: "Test Sprint"}
+	require.NoError(t, processor.allocate(team, issues, nil, emit))
+	assert.Equal(t, []domain.IdleMember{
+		{Member: "Carol Jones", Suggestions: []string{"carol.jones@example.com"}},
+	}, processor.IdleMembers())
+
+	processor.UseStrictRoster(true)
+	err := processor.allocate(team, issues, nil, emit)
+	assert.EqualError(t, err, "strict roster: team member Carol Jones has no attributed hours; add carol.jones@example.com to their aliases if it is them")
+
+	team.Aliases = map[string][]string{"Carol Jones": {"carol.jones@example.com"}}
+	require.NoError(t, processor.allocate(team, issues, nil, emit))
+	assert.Empty(t, processor.IdleMembers())
+}
+
+func TestWarningRecords(t *testing.T) {
+	records := warningRecords(
+		[]domain.UnattributedTime{{IssueKey: "TEST-1", Hours: 2}},
+		[]domain.AppliedHeuristic{{IssueKey: "TEST-2", Heuristic: domain.HeuristicSameDayMinimum, Hours: 0.5}},
+		[]domain.AppliedAbsence{{IssueKey: "TEST-3", Assignee: "alice", Hours: 24}},
+		[]domain.SprintOverlap{{IssueKey: "TEST-4", Sprints: []string{"Platform 7"}, Share: 0.75, Hours: 36}},
+		[]domain.IdleMember{{Member: "bob", Suggestions: []string{"Bobby"}}},
+		domain.Locale{},
+	)
+
+	assert.Equal(t, [][]string{
+		{"warning", "issueKey", "hours"},
+		{"in progress while unassigned", "TEST-1", "2.00"},
+		{"completed the same day: raised to the 0.5 h minimum", "TEST-2", "0.50"},
+		{"alice was absent: 24 h of In Progress time removed", "TEST-3", "24.00"},
+		{"also in overlapping sprints Platform 7: 75% of the In Progress time credited", "TEST-4", "36.00"},
+		{"team member bob has no attributed hours; add Bobby to their aliases if it is them", "", "0.00"},
+	}, records)
+}
+
+func TestCalculatePercentageLoad_SprintOverlap(t *testing.T) {
+	team := domain.Team{Team: []string{"alice", "bob"}}
+	history := func(created string, items ...domain.JiraChangeItem) domain.JiraChangeHistory {
+		return domain.JiraChangeHistory{Created: created, Items: items}
+	}
+	allocated := domain.JiraSprint{ID: 10, Name: "Sprint 12", State: "active", StartDate: "2024-03-18T00:00:00.000Z", EndDate: "2024-03-29T00:00:00.000Z"}
+	issue := func(key, assignee string, sprints ...domain.JiraSprint) domain.JiraIssue {
+		return domain.JiraIssue{
+			Key: key,
+			Fields: domain.JiraFields{
+				Assignee: domain.JiraAssignee{DisplayName: assignee},
+				Status:   domain.JiraStatus{Name: "Done"},
+				Sprints:  sprints,
+			},
+			Changelog: domain.JiraChangelog{Histories: []domain.JiraChangeHistory{
+				history("2024-03-18T09:00:00.000+0000", domain.JiraChangeItem{Field: "status", FromString: "To Do", ToString: "In Progress"}),
+				history("2024-03-20T09:00:00.000+0000", domain.JiraChangeItem{Field: "status", FromString: "In Progress", ToString: "Done"}),
+			}},
+		}
+	}
+	issues := []domain.JiraIssue{
+		// a parallel sprint of another board starts halfway through the work
+		issue("TEST-1", "alice", allocated, domain.JiraSprint{ID: 11, Name: "Platform 7", State: "active", StartDate: "2024-03-19T09:00:00.000Z", EndDate: "2024-04-01T00:00:00.000Z"}),
+		// a sprint that closed before the allocated one started does not overlap
+		issue("TEST-2", "bob", domain.JiraSprint{ID: 9, Name: "Sprint 11", State: "closed", StartDate: "2024-03-04T00:00:00.000Z", EndDate: "2024-03-18T00:00:00.000Z"}, allocated),
+	}
+
+	for _, sprint := range []string{"Sprint 12", "10"} {
+		processor := &SprintTimeAllocationUseCase{sprint: sprint}
+		totalHours := processor.calculateTotalHours(team, issues, nil)
+		results := percentageLoad(t, processor, team, issues, totalHours)
+		require.Len(t, results, 2)
+		// 24 h before the parallel sprint started, then 24 h shared by both sprints
+		assert.Equal(t, 36.0, results[0].Hours, sprint)
+		assert.Equal(t, 48.0, results[1].Hours, sprint)
+		assert.Equal(t, []domain.SprintOverlap{{IssueKey: "TEST-1", Sprints: []string{"Platform 7"}, Share: 0.75, Hours: 36}}, processor.SprintOverlaps(), sprint)
+	}
+}
+
+func TestCalculatePercentageLoad_Absences(t *testing.T) {
+	team := domain.Team{Team: []string{"alice", "bob"}}
+	history := func(created string, items ...domain.JiraChangeItem) domain.JiraChangeHistory {
+		return domain.JiraChangeHistory{Created: created, Items: items}
+	}
+	issues := []domain.JiraIssue{
+		{
+			Key: "TEST-1",
+			Fields: domain.JiraFields{
+				Assignee: domain.JiraAssignee{DisplayName: "alice"},
+				Status:   domain.JiraStatus{Name: "Done"},
+			},
+			Changelog: domain.JiraChangelog{Histories: []domain.JiraChangeHistory{
+				history("2024-03-18T09:00:00.000+0000", domain.JiraChangeItem{Field: "status", FromString: "To Do", ToString: "In Progress"}),
+				history("2024-03-21T09:00:00.000+0000", domain.JiraChangeItem{Field: "status", FromString: "In Progress", ToString: "Done"}),
+			}},
 		},
 		{
-			name: "Empty results",
-			team: domain.Team{
-				Team: []string{"engineer1", "engineer2"},
+			Key: "TEST-2",
+			Fields: domain.JiraFields{
+				Assignee: domain.JiraAssignee{DisplayName: "bob"},
+				Status:   domain.JiraStatus{Name: "Done"},
 			},
-			results:        []map[string]interface{}{},
-			expectedHeader: "",
-			wantErr:        false,
+			Changelog: domain.JiraChangelog{Histories: []domain.JiraChangeHistory{
+				history("2024-03-18T09:00:00.000+0000", domain.JiraChangeItem{Field: "status", FromString: "To Do", ToString: "In Progress"}),
+				history("2024-03-19T09:00:00.000+0000", domain.JiraChangeItem{Field: "status", FromString: "In Progress", ToString: "Done"}),
+			}},
+		},
+	}
+	processor := &SprintTimeAllocationUseCase{sprint: "Test Sprint"}
+	processor.UseAbsences(domain.AbsenceCalendar{"alice": {{
+		From: time.Date(2024, time.March, 19, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, time.March, 19, 0, 0, 0, 0, time.UTC),
+	}}})
+
+	totalHours := processor.calculateTotalHours(team, issues, nil)
+	assert.Equal(t, 48.0, totalHours["alice"])
+	assert.Equal(t, 24.0, totalHours["bob"])
+
+	results := percentageLoad(t, processor, team, issues, totalHours)
+	require.Len(t, results, 2)
+	assert.Equal(t, 48.0, results[0].Hours)
+	assert.Equal(t, 24.0, results[1].Hours)
+	assert.Equal(t, []domain.AppliedAbsence{{IssueKey: "TEST-1", Assignee: "alice", Hours: 24}}, processor.AppliedAbsences())
+}
+
+func TestCalculatePercentageLoad_BusinessCalendar(t *testing.T) {
+	team := domain.Team{Team: []string{"alice", "bob"}, Holidays: []string{"2024-03-22"}}
+	history := func(created string, items ...domain.JiraChangeItem) domain.JiraChangeHistory {
+		return domain.JiraChangeHistory{Created: created, Items: items}
+	}
+	issue := func(key, assignee, done string) domain.JiraIssue {
+		return domain.JiraIssue{
+			Key: key,
+			Fields: domain.JiraFields{
+				Assignee: domain.JiraAssignee{DisplayName: assignee},
+				Status:   domain.JiraStatus{Name: "Done"},
+			},
+			Changelog: domain.JiraChangelog{Histories: []domain.JiraChangeHistory{
+				history("2024-03-18T09:00:00.000+0000", domain.JiraChangeItem{Field: "status", FromString: "To Do", ToString: "In Progress"}),
+				history(done, domain.JiraChangeItem{Field: "status", FromString: "In Progress", ToString: "Done"}),
+			}},
+		}
+	}
+	issues := []domain.JiraIssue{
+		// Monday to the next Monday morning, over the team's Friday holiday and the weekend
+		issue("TEST-1", "alice", "2024-03-25T09:00:00.000+0000"),
+		// a workday and three hours of the next, the night in between not counted
+		issue("TEST-2", "bob", "2024-03-19T12:00:00.000+0000"),
+	}
+	calendar, err := domain.NewBusinessCalendar("09:00", "17:00", []string{"saturday", "sunday"}, nil, "")
+	require.NoError(t, err)
+	processor := &SprintTimeAllocationUseCase{sprint: "Test Sprint", project: "TEST", teams: domain.TeamMap{"TEST": team}}
+	processor.UseHeuristics(domain.AllocationHeuristics{Calendar: calendar})
+	processor.UseAbsences(domain.AbsenceCalendar{"alice": {{
+		From: time.Date(2024, time.March, 19, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, time.March, 19, 0, 0, 0, 0, time.UTC),
+	}}})
+
+	totalHours := processor.calculateTotalHours(team, issues, nil)
+	assert.Equal(t, 24.0, totalHours["alice"])
+	assert.Equal(t, 11.0, totalHours["bob"])
+
+	results := percentageLoad(t, processor, team, issues, totalHours)
+	require.Len(t, results, 2)
+	assert.Equal(t, 24.0, results[0].Hours)
+	assert.Equal(t, 11.0, results[1].Hours)
+	assert.Equal(t, []domain.AppliedAbsence{{IssueKey: "TEST-1", Assignee: "alice", Hours: 8}}, processor.AppliedAbsences(), "only the working hours of the absence are removed")
+}
+
+func TestCalculatePercentageLoad_CapacityNormalization(t *testing.T) {
+	team := domain.Team{Team: []string{"alice", "bob"}, Capacity: map[string]float64{"bob": 0.5}}
+	history := func(created string, items ...domain.JiraChangeItem) domain.JiraChangeHistory {
+		return domain.JiraChangeHistory{Created: created, Items: items}
+	}
+	issue := func(key, assignee, done string) domain.JiraIssue {
+		return domain.JiraIssue{
+			Key: key,
+			Fields: domain.JiraFields{
+				Assignee: domain.JiraAssignee{DisplayName: assignee},
+				Status:   domain.JiraStatus{Name: "Done"},
+			},
+			Changelog: domain.JiraChangelog{Histories: []domain.JiraChangeHistory{
+				history("2024-03-18T09:00:00.000+0000", domain.JiraChangeItem{Field: "status", FromString: "To Do", ToString: "In Progress"}),
+				history(done, domain.JiraChangeItem{Field: "status", FromString: "In Progress", ToString: "Done"}),
+			}},
+		}
+	}
+	issues := []domain.JiraIssue{
+		issue("TEST-1", "alice", "2024-03-19T09:00:00.000+0000"),
+		issue("TEST-2", "bob", "2024-03-19T09:00:00.000+0000"),
+	}
+	processor := &SprintTimeAllocationUseCase{sprint: "Test Sprint"}
+	processor.UseNormalization(domain.NormalizationCapacity)
+
+	totalHours := processor.calculateTotalHours(team, issues, nil)
+	results := percentageLoad(t, processor, team, issues, totalHours)
+	require.Len(t, results, 2)
+	// each person spent all their time on one issue; bob works half time
+	assert.InDelta(t, 100.0*2/3, results[0].Percentage, 0.001)
+	assert.InDelta(t, 100.0/3, results[1].Percentage, 0.001)
+	assert.Equal(t, results[0].Hours, results[1].Hours, "hours are not weighted")
+}
+
+func TestCalculatePercentageLoad_Heuristics(t *testing.T) {
+	team := domain.Team{Team: []string{"alice"}}
+	history := func(created string, items ...domain.JiraChangeItem) domain.JiraChangeHistory {
+		return domain.JiraChangeHistory{Created: created, Items: items}
+	}
+	issues := []domain.JiraIssue{
+		{
+			Key: "TEST-1",
+			Fields: domain.JiraFields{
+				Assignee: domain.JiraAssignee{DisplayName: "alice"},
+				Status:   domain.JiraStatus{Name: "To Do"},
+			},
+		},
+		{
+			Key: "TEST-2",
+			Fields: domain.JiraFields{
+				Assignee: domain.JiraAssignee{DisplayName: "alice"},
+				Status:   domain.JiraStatus{Name: "Done"},
+			},
+			Changelog: domain.JiraChangelog{Histories: []domain.JiraChangeHistory{
+				history("2024-03-19T09:00:00.000+0000", domain.JiraChangeItem{Field: "status", FromString: "To Do", ToString: "In Progress"}),
+				history("2024-03-19T09:15:00.000+0000", domain.JiraChangeItem{Field: "status", FromString: "In Progress", ToString: "Done"}),
+			}},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		heuristics domain.AllocationHeuristics
+		wantHours  map[string]float64
+		wantApply  []domain.AppliedHeuristic
+	}{
+		{
+			name:      "defaults",
+			wantHours: map[string]float64{"TEST-1": 8, "TEST-2": 1},
+			wantApply: []domain.AppliedHeuristic{
+				{IssueKey: "TEST-1", Heuristic: domain.HeuristicDefaultHours, Hours: 8},
+				{IssueKey: "TEST-2", Heuristic: domain.HeuristicSameDayMinimum, Hours: 1},
+			},
+		},
+		{
+			name:       "configured hours",
+			heuristics: domain.AllocationHeuristics{DefaultHours: 4, SameDayMinimum: 0.5},
+			wantHours:  map[string]float64{"TEST-1": 4, "TEST-2": 0.5},
+			wantApply: []domain.AppliedHeuristic{
+				{IssueKey: "TEST-1", Heuristic: domain.HeuristicDefaultHours, Hours: 4},
+				{IssueKey: "TEST-2", Heuristic: domain.HeuristicSameDayMinimum, Hours: 0.5},
+			},
+		},
+		{
+			name:       "disabled",
+			heuristics: domain.AllocationHeuristics{DisableDefaultHours: true, DisableSameDayMinimum: true},
+			wantHours:  map[string]float64{"TEST-2": 0.25},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			processor := &SprintTimeAllocationUseCase{
-				sprint: "Sprint 1",
-			}
+			processor := &SprintTimeAllocationUseCase{sprint: "Sprint 1"}
+			processor.UseHeuristics(tt.heuristics)
 
-			csvData, err := processor.generateCSV(tt.team, tt.results)
-			if tt.wantErr {
-				assert.Error(t, err)
-				return
-			}
+			results := percentageLoad(t, processor, team, issues, processor.calculateTotalHours(team, issues, nil))
 
-			assert.NoError(t, err)
-			if tt.expectedHeader == "" {
-				assert.Empty(t, csvData)
-				return
+			hours := make(map[string]float64)
+			for _, result := range results {
+				hours[result.IssueKey] = result.Hours
 			}
+			assert.Equal(t, tt.wantHours, hours)
+			assert.Equal(t, tt.wantApply, processor.AppliedHeuristics())
+		})
+	}
+}
 
-			// Split CSV into lines and check header
-			lines := strings.Split(csvData, "\n")
-			assert.NotEmpty(t, lines)
-			assert.Equal(t, tt.expectedHeader, strings.TrimSpace(lines[0]), "CSV header should match expected header with all team members")
-
-			// For each result row, verify all team members have a column
-			if len(tt.results) > 0 {
-				for i, result := range tt.results {
-					row := strings.TrimSpace(lines[i+1])
-					fields := strings.Split(row, ",")
-
-					// Count the number of columns
-					assert.Equal(t, len(strings.Split(tt.expectedHeader, ",")), len(fields),
-						"Each row should have the same number of columns as the header")
-
-					// Verify each engineer has a value (empty or percentage)
-					for _, engineer := range tt.team.Team {
-						value, exists := result[engineer]
-						assert.True(t, exists, "Each engineer should have a column in the result")
-						if value != "" {
-							assert.Contains(t, row, fmt.Sprintf("%q", value),
-								"Engineer's percentage should be in the CSV row")
-						}
-					}
-				}
-			}
+func TestCalculatePercentageLoad_StoryPoints(t *testing.T) {
+	team := domain.Team{Team: []string{"test.user"}}
+	points := func(v float64) *float64 { return &v }
+	issue := func(key string, current *float64, histories ...domain.JiraChangeHistory) domain.JiraIssue {
+		return domain.JiraIssue{
+			Key: key,
+			Fields: domain.JiraFields{
+				Assignee:    domain.JiraAssignee{DisplayName: "test.user"},
+				IssueType:   domain.IssueType{Name: "Task"},
+				Status:      domain.JiraStatus{Name: "Done"},
+				StoryPoints: current,
+				Sprints: []domain.JiraSprint{
+					{Name: "Sprint 1", StartDate: "2024-03-18T00:00:00.000Z", EndDate: "2024-03-29T00:00:00.000Z"},
+				},
+			},
+			Changelog: domain.JiraChangelog{Histories: histories},
+		}
+	}
+	status := func(created, from, to string) domain.JiraChangeHistory {
+		return domain.JiraChangeHistory{Created: created, Items: []domain.JiraChangeItem{{Field: "status", FromString: from, ToString: to}}}
+	}
+
+	// TEST-1 spends 10 hours in progress and was re-estimated from 1 to 3 points mid-sprint,
+	// TEST-2 spends 30 hours in progress and stays at 1 point
+	issues := []domain.JiraIssue{
+		issue("TEST-1", points(3),
+			status("2024-03-20T00:00:00.000Z", "To Do", "In Progress"),
+			domain.JiraChangeHistory{Created: "2024-03-20T05:00:00.000Z", Items: []domain.JiraChangeItem{{Field: "Story Points", FromString: "1", ToString: "3"}}},
+			status("2024-03-20T10:00:00.000Z", "In Progress", "Done"),
+		),
+		issue("TEST-2", points(1),
+			status("2024-03-21T00:00:00.000Z", "To Do", "In Progress"),
+			status("2024-03-22T06:00:00.000Z", "In Progress", "Done"),
+		),
+	}
+	totalHoursByPerson := map[string]float64{"test.user": 40}
+
+	tests := []struct {
+		name      string
+		pointsAt  domain.PointsAt
+		wantShare float64
+	}{
+		{"estimate at sprint start", domain.PointsAtStart, 50},
+		{"estimate at sprint end", domain.PointsAtEnd, 75},
+		{"latest estimate", domain.PointsAtLatest, 75},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := &SprintTimeAllocationUseCase{sprint: "Sprint 1"}
+			require.NoError(t, processor.UseStrategy(domain.AllocationMethodStoryPoints, tt.pointsAt))
+
+			results := percentageLoad(t, processor, team, issues, totalHoursByPerson)
+			require.Len(t, results, 2)
+			assert.InDelta(t, tt.wantShare, results[0].Percentage, 0.001)
+			assert.InDelta(t, 40*tt.wantShare/100, results[0].Hours, 0.001)
+			assert.InDelta(t, 100-tt.wantShare, results[1].Percentage, 0.001)
 		})
 	}
+
+	t.Run("time method ignores story points", func(t *testing.T) {
+		processor := &SprintTimeAllocationUseCase{sprint: "Sprint 1"}
+
+		results := percentageLoad(t, processor, team, issues, totalHoursByPerson)
+		require.Len(t, results, 2)
+		assert.InDelta(t, 25, results[0].Percentage, 0.001)
+		assert.InDelta(t, 10, results[0].Hours, 0.001)
+	})
+}
+
+func TestApplyLabelSnapshot(t *testing.T) {
+	newIssues := func() []domain.JiraIssue {
+		relabel := domain.JiraChangeHistory{
+			Created: "2024-04-02T10:00:00.000Z",
+			Items:   []domain.JiraChangeItem{{Field: "labels", FromString: "cap-development cap-asset-booking", ToString: "cap-maintenance"}},
+		}
+		return []domain.JiraIssue{
+			{
+				Key:    "TEST-1",
+				Fields: domain.JiraFields{Status: domain.JiraStatus{Name: "Done"}, Labels: []string{"cap-maintenance"}},
+				Changelog: domain.JiraChangelog{Histories: []domain.JiraChangeHistory{
+					{Created: "2024-03-20T10:00:00.000Z", Items: []domain.JiraChangeItem{{Field: "status", FromString: "In Progress", ToString: "Done"}}},
+					relabel,
+				}},
+			},
+			{
+				Key:       "TEST-2",
+				Fields:    domain.JiraFields{Status: domain.JiraStatus{Name: "In Progress"}, Labels: []string{"cap-maintenance"}},
+				Changelog: domain.JiraChangelog{Histories: []domain.JiraChangeHistory{relabel}},
+			},
+		}
+	}
+
+	t.Run("current labels", func(t *testing.T) {
+		issues := newIssues()
+		(&SprintTimeAllocationUseCase{}).applyLabelSnapshot(issues)
+		assert.Equal(t, "cap-maintenance", issues[0].GetWorkType())
+	})
+
+	t.Run("labels at completion", func(t *testing.T) {
+		issues := newIssues()
+		processor := &SprintTimeAllocationUseCase{}
+		processor.UseLabelsAsOf(domain.LabelSnapshot{AtCompletion: true})
+		processor.applyLabelSnapshot(issues)
+		assert.Equal(t, "cap-development", issues[0].GetWorkType())
+		assert.Equal(t, "cap-asset-booking", issues[0].GetAssetName())
+		// Unfinished issues keep their current labels
+		assert.Equal(t, "cap-maintenance", issues[1].GetWorkType())
+	})
+
+	t.Run("labels at a date", func(t *testing.T) {
+		issues := newIssues()
+		processor := &SprintTimeAllocationUseCase{}
+		processor.UseLabelsAsOf(domain.LabelSnapshot{Date: time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)})
+		processor.applyLabelSnapshot(issues)
+		assert.Equal(t, "cap-development", issues[0].GetWorkType())
+		assert.Equal(t, "cap-development", issues[1].GetWorkType())
+	})
 }
 
 func TestTimeCalculations(t *testing.T) {
@@ -1245,12 +1633,12 @@ func TestFilterSubtasks(t *testing.T) {
 		"test.user": 8.0,
 	}
 
-	results := processor.calculatePercentageLoad(team, issues, nil, totalHoursByPerson)
+	results := percentageLoad(t, processor, team, issues, totalHoursByPerson)
 	require.Len(t, results, 1, "Should only include non-subtask issues")
 
 	result := results[0]
-	assert.Equal(t, "Task", result["issueType"], "Should only include non-subtask issues")
-	assert.Equal(t, "TEST-1", result["issueKey"], "Should only include non-subtask issues")
+	assert.Equal(t, "Task", result.IssueType, "Should only include non-subtask issues")
+	assert.Equal(t, "TEST-1", result.IssueKey, "Should only include non-subtask issues")
 }
 
 func TestUncompletedIssues(t *testing.T) {
@@ -1299,15 +1687,15 @@ func TestUncompletedIssues(t *testing.T) {
 	}
 
 	// Calculate results
-	results := processor.calculatePercentageLoad(team, issues, nil, map[string]float64{"Test User 1": 8.0})
+	results := percentageLoad(t, processor, team, issues, map[string]float64{"Test User 1": 8.0})
 
 	// Verify results
 	assert.Equal(t, 1, len(results))
 	result := results[0]
-	assert.Equal(t, "TEST-123", result["issueKey"])
-	assert.Equal(t, "In Progress", result["status"])
-	assert.Equal(t, "2024-03-20", result["dateStarted"])
-	assert.Equal(t, "", result["dateCompleted"]) // Should be empty for uncompleted issues
+	assert.Equal(t, "TEST-123", result.IssueKey)
+	assert.Equal(t, "In Progress", result.Status)
+	assert.Equal(t, time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC), result.DateStarted)
+	assert.True(t, result.DateCompleted.IsZero()) // Should be empty for uncompleted issues
 }
 
 func TestMinimumHoursForDirectDone(t *testing.T) {
@@ -1404,18 +1792,18 @@ func TestMinimumHoursForDirectDone(t *testing.T) {
 		"test.user": 8.0,
 	}
 
-	results := processor.calculatePercentageLoad(team, issues, nil, totalHoursByPerson)
+	results := percentageLoad(t, processor, team, issues, totalHoursByPerson)
 	require.Len(t, results, 2)
 
 	// Test first issue (direct to Done)
 	result1 := results[0]
-	assert.Equal(t, "TEST-1", result1["issueKey"])
-	assert.Equal(t, "50.00%", result1["test.user"])
+	assert.Equal(t, "TEST-1", result1.IssueKey)
+	assert.InDelta(t, 50.0, result1.Percentage, 0.005)
 
 	// Test second issue (multiple transitions in same day)
 	result2 := results[1]
-	assert.Equal(t, "TEST-2", result2["issueKey"])
-	assert.Equal(t, "50.00%", result2["test.user"])
+	assert.Equal(t, "TEST-2", result2.IssueKey)
+	assert.InDelta(t, 50.0, result2.Percentage, 0.005)
 }
 
 func TestPercentageLoadWithMinimumHours(t *testing.T) {
@@ -1533,21 +1921,18 @@ func TestPercentageLoadWithMinimumHours(t *testing.T) {
 		"test.user": 8.0,
 	}
 
-	results := processor.calculatePercentageLoad(team, issues, nil, totalHoursByPerson)
+	results := percentageLoad(t, processor, team, issues, totalHoursByPerson)
 	require.Len(t, results, 3)
 
 	// Calculate total percentage and verify individual percentages
 	totalPercentage := 0.0
 	for _, result := range results {
-		percentage := result["test.user"].(string)
-		// Remove the % sign and convert to float
-		value, err := strconv.ParseFloat(strings.TrimSuffix(percentage, "%"), 64)
-		require.NoError(t, err)
+		value := result.Percentage
 		totalPercentage += value
 
 		// Each issue should have a reasonable percentage
-		assert.LessOrEqual(t, value, 100.0, "Individual percentage should not exceed 100%% for issue %s", result["issueKey"])
-		t.Logf("Issue %s: %.2f%%", result["issueKey"], value)
+		assert.LessOrEqual(t, value, 100.0, "Individual percentage should not exceed 100%% for issue %s", result.IssueKey)
+		t.Logf("Issue %s: %.2f%%", result.IssueKey, value)
 	}
 
 	// Total percentage should not exceed 100%
@@ -1557,12 +1942,749 @@ func TestPercentageLoadWithMinimumHours(t *testing.T) {
 	// The second issue (TEST-2) should have more hours than the others since it was in progress for 5 hours
 	test2Percentage := func() float64 {
 		for _, result := range results {
-			if result["issueKey"] == "TEST-2" {
-				value, _ := strconv.ParseFloat(strings.TrimSuffix(result["test.user"].(string), "%"), 64)
-				return value
+			if result.IssueKey == "TEST-2" {
+				return result.Percentage
 			}
 		}
 		return 0
 	}()
 	assert.Greater(t, test2Percentage, 50.0, "Issue TEST-2 should have more than 50%% since it was in progress for 5 hours")
 }
+
+func TestCalculateTotalHours_MergesAliases(t *testing.T) {
+	processor := &SprintTimeAllocationUseCase{}
+
+	team := domain.Team{
+		Team: []string{"Jane Doe"},
+		Aliases: map[string][]string{
+			"Jane Doe": {"Jane Doe-Smith"},
+		},
+	}
+
+	newIssue := func(key, assignee string) domain.JiraIssue {
+		return domain.JiraIssue{
+			Key: key,
+			Fields: domain.JiraFields{
+				Assignee:  domain.JiraAssignee{DisplayName: assignee},
+				IssueType: domain.IssueType{Name: "Task"},
+				Status:    domain.JiraStatus{Name: "Done"},
+			},
+			Changelog: domain.JiraChangelog{
+				Histories: []domain.JiraChangeHistory{
+					{
+						Created: "2024-03-20T10:00:00.000+0000",
+						Items:   []domain.JiraChangeItem{{Field: "status", FromString: "To Do", ToString: "In Progress"}},
+					},
+					{
+						Created: "2024-03-20T14:00:00.000+0000",
+						Items:   []domain.JiraChangeItem{{Field: "status", FromString: "In Progress", ToString: "Done"}},
+					},
+				},
+			},
+		}
+	}
+
+	issues := []domain.JiraIssue{
+		newIssue("TEST-1", "Jane Doe"),
+		newIssue("TEST-2", "Jane Doe-Smith"),
+	}
+
+	totals := processor.calculateTotalHours(team, issues, nil)
+	assert.Equal(t, map[string]float64{"Jane Doe": 8}, totals)
+
+	results := percentageLoad(t, processor, team, issues, totals)
+	require.Len(t, results, 2)
+	for _, result := range results {
+		assert.InDelta(t, 50.0, result.Percentage, 0.005)
+	}
+}
+
+func TestLintAssignees(t *testing.T) {
+	mockJira := new(MockJiraAdapter)
+	processor := &SprintTimeAllocationUseCase{
+		project: "TEST",
+		sprint:  "Sprint 1",
+		teams: domain.TeamMap{
+			"TEST": domain.Team{
+				Team: []string{"Jane Doe"},
+				Aliases: map[string][]string{
+					"Jane Doe":   {"Jane Doe-Smith"},
+					"Old Member": {"old.member"},
+				},
+			},
+		},
+		jiraPort: mockJira,
+	}
+
+	mockJira.On("GetIssuesForSprint", "TEST", "Sprint 1").Return([]ports.JiraIssue{
+		{Key: "TEST-1", Assignee: "Jane Doe-Smith"},
+		{Key: "TEST-2", Assignee: "Contractor"},
+		{Key: "TEST-3", Assignee: "Contractor"},
+		{Key: "TEST-4", Assignee: ""},
+	}, nil)
+
+	result, err := processor.LintAssignees()
+	require.NoError(t, err)
+	assert.True(t, result.HasFindings())
+	assert.Equal(t, []domain.UnmatchedAssignee{
+		{Name: "Contractor", Issues: []string{"TEST-2", "TEST-3"}},
+	}, result.Unmatched)
+	assert.Equal(t, []string{"Old Member"}, result.UnknownAliases)
+	mockJira.AssertExpectations(t)
+}
+
+// MockReleaseJiraAdapter is a mock Jira adapter that also allocates by fix version
+type MockReleaseJiraAdapter struct {
+	MockJiraAdapter
+}
+
+func (m *MockReleaseJiraAdapter) GetIssuesForFixVersion(project, fixVersion string) ([]ports.JiraIssue, error) {
+	args := m.Called(project, fixVersion)
+	return args.Get(0).([]ports.JiraIssue), args.Error(1)
+}
+
+func (m *MockReleaseJiraAdapter) GetRelease(project, fixVersion string) (*domain.Release, error) {
+	args := m.Called(project, fixVersion)
+	return args.Get(0).(*domain.Release), args.Error(1)
+}
+
+func TestAllocate_FixVersion(t *testing.T) {
+	worked := func(key, start, end string) ports.JiraIssue {
+		return ports.JiraIssue{
+			Key:       key,
+			Summary:   "Issue " + key,
+			Assignee:  "Jane Doe",
+			Status:    "Done",
+			IssueType: "Story",
+			Labels:    []string{"cap-development"},
+			Changelog: ports.JiraChangelog{Histories: []ports.JiraChangeHistory{
+				{Created: start, Items: []ports.JiraChangeItem{{Field: "status", FromString: "To Do", ToString: "In Progress"}}},
+				{Created: end, Items: []ports.JiraChangeItem{{Field: "status", FromString: "In Progress", ToString: "Done"}}},
+			}},
+		}
+	}
+
+	mockJira := new(MockReleaseJiraAdapter)
+	mockJira.On("GetRelease", "TEST", "2024.5").Return(&domain.Release{
+		Name:        "2024.5",
+		StartDate:   time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		ReleaseDate: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+	}, nil)
+	mockJira.On("GetIssuesForFixVersion", "TEST", "2024.5").Return([]ports.JiraIssue{
+		worked("TEST-1", "2024-04-30T00:00:00.000+0000", "2024-05-02T00:00:00.000+0000"),
+		worked("TEST-2", "2024-05-10T00:00:00.000+0000", "2024-05-12T00:00:00.000+0000"),
+		worked("TEST-3", "2024-04-01T00:00:00.000+0000", "2024-04-03T00:00:00.000+0000"),
+	}, nil)
+
+	processor := &SprintTimeAllocationUseCase{
+		project:  "TEST",
+		teams:    domain.TeamMap{"TEST": domain.Team{Team: []string{"Jane Doe"}}},
+		jiraPort: mockJira,
+	}
+	processor.UseFixVersion("2024.5")
+
+	allocations, err := processor.Allocate()
+	require.NoError(t, err)
+	require.Len(t, allocations, 2, "work outside the release window is not allocated")
+
+	assert.Equal(t, "TEST-1", allocations[0].IssueKey)
+	assert.Equal(t, "2024.5", allocations[0].Sprint)
+	assert.Equal(t, 24.0, allocations[0].Hours, "only the time after the release start is credited")
+	assert.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), allocations[0].DateStarted)
+	assert.Equal(t, "TEST-2", allocations[1].IssueKey)
+	assert.Equal(t, 48.0, allocations[1].Hours)
+	mockJira.AssertExpectations(t)
+}
+
+func TestAllocate_FixVersionUnsupported(t *testing.T) {
+	processor := &SprintTimeAllocationUseCase{
+		project:  "TEST",
+		teams:    domain.TeamMap{"TEST": domain.Team{Team: []string{"Jane Doe"}}},
+		jiraPort: new(MockJiraAdapter),
+	}
+	processor.UseFixVersion("2024.5")
+
+	_, err := processor.Allocate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the Jira integration does not support fix versions")
+}
+
+func TestEmitWorkTypeSplits(t *testing.T) {
+	processor := &SprintTimeAllocationUseCase{sprint: "Test Sprint"}
+	processor.UseWorkTypeSplits(domain.WorkTypeSplits{
+		"TEST-1": {"cap-development": 70, "cap-maintenance": 30},
+	})
+
+	var allocations []domain.IssueAllocation
+	collect := func(allocation domain.IssueAllocation) error {
+		allocations = append(allocations, allocation)
+		return nil
+	}
+	require.NoError(t, processor.emitWorkTypeSplits(domain.IssueAllocation{IssueKey: "TEST-1", Assignee: "alice", WorkType: "cap-development", Hours: 10, Percentage: 50}, collect))
+	require.NoError(t, processor.emitWorkTypeSplits(domain.IssueAllocation{IssueKey: "TEST-2", Assignee: "alice", WorkType: "cap-discovery", Hours: 10, Percentage: 50}, collect))
+	require.Len(t, allocations, 3)
+
+	assert.Equal(t, "cap-development", allocations[0].WorkType)
+	assert.InDelta(t, 7.0, allocations[0].Hours, 0.001)
+	assert.InDelta(t, 35.0, allocations[0].Percentage, 0.001)
+	assert.Equal(t, "cap-maintenance", allocations[1].WorkType)
+	assert.InDelta(t, 3.0, allocations[1].Hours, 0.001)
+	assert.InDelta(t, 15.0, allocations[1].Percentage, 0.001)
+	assert.Equal(t, "TEST-2", allocations[2].IssueKey)
+	assert.Equal(t, "cap-discovery", allocations[2].WorkType)
+	assert.Equal(t, 10.0, allocations[2].Hours)
+
+	t.Run("stops at the first emit error", func(t *testing.T) {
+		err := processor.emitWorkTypeSplits(domain.IssueAllocation{IssueKey: "TEST-1"}, func(domain.IssueAllocation) error {
+			return assert.AnError
+		})
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestCalculateTotalHours_AccountIDs(t *testing.T) {
+	processor := &SprintTimeAllocationUseCase{sprint: "Test Sprint"}
+	team := domain.Team{
+		Team:       []string{"alice", "bob"},
+		AccountIDs: map[string]string{"alice": "acc-alice", "bob": "acc-bob"},
+	}
+	history := func(created string, items ...domain.JiraChangeItem) domain.JiraChangeHistory {
+		return domain.JiraChangeHistory{Created: created, Items: items}
+	}
+	issues := []domain.JiraIssue{
+		{
+			Key: "TEST-1",
+			Fields: domain.JiraFields{
+				Assignee: domain.JiraAssignee{AccountID: "acc-bob", DisplayName: "Robert"},
+				Status:   domain.JiraStatus{Name: "Done"},
+				Sprints:  []domain.JiraSprint{{ID: 42, Name: "Test Sprint"}},
+			},
+			Changelog: domain.JiraChangelog{Histories: []domain.JiraChangeHistory{
+				history("2024-03-18T09:00:00.000+0000", domain.JiraChangeItem{Field: "status", FromString: "To Do", ToString: "In Progress"}),
+				history("2024-03-18T11:00:00.000+0000", domain.JiraChangeItem{Field: "assignee", From: "acc-alice", FromString: "Alice Renamed", To: "acc-bob", ToString: "Robert"}),
+				history("2024-03-18T13:00:00.000+0000", domain.JiraChangeItem{Field: "status", FromString: "In Progress", ToString: "Done"}),
+			}},
+		},
+		{
+			Key: "TEST-2",
+			Fields: domain.JiraFields{
+				Assignee: domain.JiraAssignee{AccountID: "acc-other", DisplayName: "alice"},
+				Status:   domain.JiraStatus{Name: "Done"},
+			},
+			Changelog: domain.JiraChangelog{Histories: []domain.JiraChangeHistory{
+				history("2024-03-19T09:00:00.000+0000", domain.JiraChangeItem{Field: "status", FromString: "To Do", ToString: "In Progress"}),
+				history("2024-03-19T12:00:00.000+0000", domain.JiraChangeItem{Field: "status", FromString: "In Progress", ToString: "Done"}),
+			}},
+		},
+	}
+
+	totalHours := processor.calculateTotalHours(team, issues, nil)
+	assert.Equal(t, 2.0, totalHours["alice"])
+	assert.Equal(t, 2.0, totalHours["bob"])
+
+	results := percentageLoad(t, processor, team, issues, totalHours)
+	require.Len(t, results, 2)
+	assert.Equal(t, "alice", results[0].Assignee)
+	assert.Equal(t, "bob", results[1].Assignee)
+	assert.Equal(t, "acc-alice", results[0].AssigneeAccountID)
+	assert.Equal(t, "acc-bob", results[1].AssigneeAccountID)
+	assert.Equal(t, 42, results[0].SprintID, "the sprint ID keys the worklogs of the row")
+	assert.Equal(t, []domain.UnattributedTime{{IssueKey: "TEST-2", Assignee: "alice", Hours: 3}}, processor.Unattributed())
+}
+
+// percentageLoad collects the allocations calculatePercentageLoad emits
+func percentageLoad(t *testing.T, processor *SprintTimeAllocationUseCase, team domain.Team, issues []domain.JiraIssue, totalHoursByPerson map[string]float64) []domain.IssueAllocation {
+	t.Helper()
+	var allocations []domain.IssueAllocation
+	err := processor.calculatePercentageLoad(team, issues, nil, totalHoursByPerson, func(allocation domain.IssueAllocation) error {
+		allocations = append(allocations, allocation)
+		return nil
+	})
+	require.NoError(t, err)
+	return allocations
+}
+
+func TestJiraProcessor_ProcessTo(t *testing.T) {
+	members := benchmarkMembers(3)
+	processor := &SprintTimeAllocationUseCase{
+		project:  "TEST",
+		sprint:   "Sprint 1",
+		teams:    domain.TeamMap{"TEST": domain.Team{Team: members}},
+		jiraPort: &scenarioPort{issues: syntheticIssues(5, members)},
+	}
+	var progress [][2]int
+	processor.UseProgress(func(done, total int) {
+		progress = append(progress, [2]int{done, total})
+	})
+
+	var buffer bytes.Buffer
+	require.NoError(t, processor.ProcessTo(&buffer, &CSVFormatter{delimiter: ','}))
+
+	lines := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+	require.Len(t, lines, 6)
+	assert.Equal(t, "sprint,issueKey,issueType,issueTitle,workType,assetName,status,dateStarted,dateCompleted,evidenceUrl,assetUrl,scopeChange,confidence,engineer1,engineer2,engineer3", lines[0])
+	assert.Equal(t, "Sprint 1,TEST-1,Task,Synthetic issue 1,,,Done,2024-03-18,2024-03-18,,,,100,50.00%,,", lines[1])
+	assert.Equal(t, [][2]int{{1, 5}, {2, 5}, {3, 5}, {4, 5}, {5, 5}}, progress)
+
+	csvData, err := processor.Process(&CSVFormatter{delimiter: ','})
+	require.NoError(t, err)
+	assert.Equal(t, buffer.String(), csvData, "Process should render the same CSV as ProcessTo")
+}
+
+func TestJiraProcessor_ProcessTo_PersonSummary(t *testing.T) {
+	members := benchmarkMembers(2)
+	processor := &SprintTimeAllocationUseCase{
+		project:  "TEST",
+		sprint:   "Sprint 1",
+		teams:    domain.TeamMap{"TEST": domain.Team{Team: members}},
+		jiraPort: &scenarioPort{issues: syntheticIssues(4, members)},
+	}
+	processor.UseSummary(domain.AllocationSummaryPerson)
+
+	var buffer bytes.Buffer
+	require.NoError(t, processor.ProcessTo(&buffer, &CSVFormatter{delimiter: ','}))
+	blocks := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n\n")
+	require.Len(t, blocks, 2)
+
+	allocations, err := processor.Allocate()
+	require.NoError(t, err)
+	expected := domain.NewPersonSummary()
+	for _, allocation := range allocations {
+		expected.Add(allocation)
+	}
+	rendered, err := (&CSVFormatter{delimiter: ','}).Format(personSummaryRecords(expected, domain.Locale{}))
+	require.NoError(t, err)
+	assert.Equal(t, rendered, blocks[1]+"\n", "the summary totals the rows written above it")
+	assert.True(t, strings.HasPrefix(blocks[1], "person,hours,percentage,no asset\n"))
+}
+
+func TestPersonSummaryRecords(t *testing.T) {
+	summary := domain.NewPersonSummary()
+	summary.Add(domain.IssueAllocation{Assignee: "bob", AssetName: "search", Hours: 6, Percentage: 37.5})
+	summary.Add(domain.IssueAllocation{Assignee: "alice", AssetName: "booking", Hours: 12, Percentage: 75})
+	summary.Add(domain.IssueAllocation{Assignee: "alice", Hours: 4, Percentage: 25})
+	summary.Add(domain.IssueAllocation{Assignee: "bob", AssetName: "booking", Hours: 10, Percentage: 62.5})
+
+	assert.Equal(t, [][]string{
+		{"person", "hours", "percentage", "booking", "search", "no asset"},
+		{"alice", "16.00", "100.00%", "75.00%", "", "25.00%"},
+		{"bob", "16.00", "100.00%", "62.50%", "37.50%", ""},
+	}, personSummaryRecords(summary, domain.Locale{}))
+}
+
+func TestJiraProcessor_ProcessToWriteError(t *testing.T) {
+	members := benchmarkMembers(1)
+	processor := &SprintTimeAllocationUseCase{
+		project:  "TEST",
+		sprint:   "Sprint 1",
+		teams:    domain.TeamMap{"TEST": domain.Team{Team: members}},
+		jiraPort: &scenarioPort{issues: syntheticIssues(2, members)},
+	}
+
+	err := processor.ProcessTo(failingWriter{}, &CSVFormatter{delimiter: ','})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to generate CSV")
+}
+
+// failingWriter rejects every write
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, assert.AnError
+}
+
+// BenchmarkProcessTo streams the allocation of growing sprints, reporting the bytes allocated
+// per row to show memory stays flat as the issue count grows
+func BenchmarkProcessTo(b *testing.B) {
+	members := benchmarkMembers(50)
+	for _, issueCount := range []int{100, 1000, 10000} {
+		b.Run(fmt.Sprintf("issues=%d", issueCount), func(b *testing.B) {
+			processor := &SprintTimeAllocationUseCase{
+				project:  "TEST",
+				sprint:   "Sprint 1",
+				teams:    domain.TeamMap{"TEST": domain.Team{Team: members}},
+				jiraPort: &scenarioPort{issues: syntheticIssues(issueCount, members)},
+			}
+			formatter := &CSVFormatter{delimiter: ','}
+
+			var before, after runtime.MemStats
+			runtime.ReadMemStats(&before)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := processor.ProcessTo(io.Discard, formatter); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.StopTimer()
+			runtime.ReadMemStats(&after)
+			b.ReportMetric(float64(after.TotalAlloc-before.TotalAlloc)/float64(b.N*issueCount), "B/row")
+		})
+	}
+}
+
+// syntheticIssues returns n completed issues spread round-robin across the members, each
+// in progress for two hours
+func syntheticIssues(n int, members []string) []ports.JiraIssue {
+	start := time.Date(2024, 3, 18, 9, 0, 0, 0, time.UTC)
+	issues := make([]ports.JiraIssue, n)
+	for i := range issues {
+		started := start.Add(time.Duration(i) * time.Minute)
+		issues[i] = ports.JiraIssue{
+			Key:       fmt.Sprintf("TEST-%d", i+1),
+			Summary:   fmt.Sprintf("Synthetic issue %d", i+1),
+			IssueType: "Task",
+			Assignee:  members[i%len(members)],
+			Status:    statusDone,
+			Changelog: ports.JiraChangelog{Histories: []ports.JiraChangeHistory{
+				{
+					Created: started.Format("2006-01-02T15:04:05.000-0700"),
+					Items:   []ports.JiraChangeItem{{Field: "status", FromString: "To Do", ToString: "In Progress"}},
+				},
+				{
+					Created: started.Add(2 * time.Hour).Format("2006-01-02T15:04:05.000-0700"),
+					Items:   []ports.JiraChangeItem{{Field: "status", FromString: "In Progress", ToString: "Done"}},
+				},
+			}},
+		}
+	}
+	return issues
+}
+
+func TestCalculatePercentageLoad_ChangelogUnavailable(t *testing.T) {
+	team := domain.Team{Team: []string{"alice"}}
+	issues := []domain.JiraIssue{
+		{
+			Key: "TEST-1",
+			Fields: domain.JiraFields{
+				Assignee:       domain.JiraAssignee{DisplayName: "alice"},
+				Status:         domain.JiraStatus{Name: "Done"},
+				Sprints:        []domain.JiraSprint{{Name: "Sprint 1", StartDate: "2024-05-06T09:00:00.000+0000", EndDate: "2024-05-17T17:00:00.000+0000"}},
+				Created:        "2024-04-22T10:00:00.000+0000",
+				ResolutionDate: "2024-05-07T17:00:00.000+0000",
+			},
+			ChangelogUnavailable: true,
+		},
+		{
+			Key: "TEST-2",
+			Fields: domain.JiraFields{
+				Assignee: domain.JiraAssignee{DisplayName: "alice"},
+				Status:   domain.JiraStatus{Name: "In Progress"},
+				Created:  "2024-05-08T09:00:00.000+0000",
+			},
+			ChangelogUnavailable: true,
+		},
+	}
+	processor := &SprintTimeAllocationUseCase{sprint: "Sprint 1"}
+
+	results := percentageLoad(t, processor, team, issues, processor.calculateTotalHours(team, issues, nil))
+
+	require.Len(t, results, 2)
+	assert.Equal(t, time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC), results[0].DateStarted, "the window starts with the sprint, not the issue creation")
+	assert.Positive(t, results[0].Hours)
+	assert.Equal(t, []domain.AppliedHeuristic{
+		{IssueKey: "TEST-1", Heuristic: domain.HeuristicResolutionDate, Hours: results[0].Hours},
+		{IssueKey: "TEST-2", Heuristic: domain.HeuristicDefaultHours, Hours: 8},
+	}, processor.AppliedHeuristics())
+	assert.Equal(t, "changelog unavailable: 16 h from start to resolution date assumed", domain.AppliedHeuristic{Heuristic: domain.HeuristicResolutionDate, Hours: 16}.Reason())
+}
+
+func TestCalculatePercentageLoad_LocalizedStatuses(t *testing.T) {
+	team := domain.Team{Team: []string{"anna"}}
+	done := domain.JiraStatus{Name: "Fertig", Category: domain.JiraStatusCategory{Key: domain.StatusCategoryDone}}
+	changelog := domain.JiraChangelog{Histories: []domain.JiraChangeHistory{
+		{Created: "2024-03-18T09:00:00.000+0000", Items: []domain.JiraChangeItem{
+			{Field: "status", FromString: "Zu erledigen", ToString: "In Arbeit", FromCategory: domain.StatusCategoryToDo, ToCategory: domain.StatusCategoryInProgress},
+		}},
+		{Created: "2024-03-19T09:00:00.000+0000", Items: []domain.JiraChangeItem{
+			{Field: "status", FromString: "In Arbeit", ToString: "In Prüfung", FromCategory: domain.StatusCategoryInProgress, ToCategory: domain.StatusCategoryInProgress},
+		}},
+		{Created: "2024-03-20T11:00:00.000+0000", Items: []domain.JiraChangeItem{
+			{Field: "status", FromString: "In Prüfung", ToString: "Fertig", FromCategory: domain.StatusCategoryInProgress, ToCategory: domain.StatusCategoryDone},
+		}},
+	}}
+	issues := []domain.JiraIssue{
+		{
+			Key: "TEST-1",
+			Fields: domain.JiraFields{
+				Assignee:  domain.JiraAssignee{DisplayName: "anna"},
+				Status:    done,
+				IssueType: domain.IssueType{Name: "Aufgabe", HierarchyLevel: 0},
+			},
+			Changelog: changelog,
+		},
+		{
+			Key: "TEST-2",
+			Fields: domain.JiraFields{
+				Assignee:  domain.JiraAssignee{DisplayName: "anna"},
+				Status:    done,
+				IssueType: domain.IssueType{Name: "Unteraufgabe", Subtask: true, HierarchyLevel: -1},
+			},
+			Changelog: changelog,
+		},
+	}
+	processor := &SprintTimeAllocationUseCase{sprint: "Sprint 1"}
+
+	start, end := processor.getIssueTimeRange(issues[0])
+	assert.Equal(t, time.Date(2024, 3, 18, 9, 0, 0, 0, time.UTC), start, "the window starts when the issue first enters an in progress status")
+	assert.Equal(t, time.Date(2024, 3, 20, 11, 0, 0, 0, time.UTC), end, "the window ends when the issue enters a done status")
+
+	results := percentageLoad(t, processor, team, issues, processor.calculateTotalHours(team, issues, nil))
+	require.Len(t, results, 1, "sub-tasks are skipped by their place in the hierarchy")
+	assert.Equal(t, "TEST-1", results[0].IssueKey)
+	assert.Equal(t, domain.StatusCategoryDone, results[0].StatusCategory)
+	assert.Equal(t, time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC), results[0].DateCompleted)
+}
+
+func TestCalculatePercentageLoad_StaleIssues(t *testing.T) {
+	team := domain.Team{Team: []string{"alice"}}
+	issue := func(key, started, completed string) domain.JiraIssue {
+		return domain.JiraIssue{
+			Key: key,
+			Fields: domain.JiraFields{
+				Assignee: domain.JiraAssignee{DisplayName: "alice"},
+				Status:   domain.JiraStatus{Name: "Done"},
+			},
+			Changelog: domain.JiraChangelog{Histories: []domain.JiraChangeHistory{
+				{Created: started, Items: []domain.JiraChangeItem{{Field: "status", FromString: "To Do", ToString: "In Progress"}}},
+				{Created: completed, Items: []domain.JiraChangeItem{{Field: "status", FromString: "In Progress", ToString: "Done"}}},
+			}},
+		}
+	}
+	issues := []domain.JiraIssue{
+		issue("TEST-1", "2024-03-18T09:00:00.000+0000", "2024-03-19T09:00:00.000+0000"),
+		issue("TEST-2", "2024-02-19T09:00:00.000+0000", "2024-03-19T09:00:00.000+0000"),
+	}
+
+	tests := []struct {
+		name       string
+		heuristics domain.AllocationHeuristics
+		override   map[string]float64
+		wantHours  map[string]float64
+		wantStale  []domain.StaleIssue
+	}{
+		{
+			name:      "watchdog off",
+			wantHours: map[string]float64{"TEST-1": 24, "TEST-2": 696},
+		},
+		{
+			name:       "flagged",
+			heuristics: domain.AllocationHeuristics{StaleAfterDays: 14},
+			wantHours:  map[string]float64{"TEST-1": 24, "TEST-2": 696},
+			wantStale:  []domain.StaleIssue{{IssueKey: "TEST-2", Days: 29, ThresholdDays: 14, Hours: 696}},
+		},
+		{
+			name:       "capped",
+			heuristics: domain.AllocationHeuristics{StaleAfterDays: 14, StaleCapHours: 40},
+			wantHours:  map[string]float64{"TEST-1": 24, "TEST-2": 40},
+			wantStale:  []domain.StaleIssue{{IssueKey: "TEST-2", Days: 29, ThresholdDays: 14, Hours: 40, Capped: true}},
+		},
+		{
+			name:       "manual override wins",
+			heuristics: domain.AllocationHeuristics{StaleAfterDays: 14, StaleCapHours: 40},
+			override:   map[string]float64{"TEST-2": 60},
+			wantHours:  map[string]float64{"TEST-1": 24, "TEST-2": 60},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := &SprintTimeAllocationUseCase{sprint: "Sprint 1"}
+			processor.UseHeuristics(tt.heuristics)
+
+			var results []domain.IssueAllocation
+			require.NoError(t, processor.calculatePercentageLoad(team, issues, tt.override, processor.calculateTotalHours(team, issues, tt.override), func(allocation domain.IssueAllocation) error {
+				results = append(results, allocation)
+				return nil
+			}))
+
+			hours := make(map[string]float64)
+			for _, result := range results {
+				hours[result.IssueKey] = result.Hours
+			}
+			assert.Equal(t, tt.wantHours, hours)
+			assert.Equal(t, tt.wantStale, processor.StaleIssues())
+		})
+	}
+}
+
+func TestStaleRecords(t *testing.T) {
+	records := staleRecords([]domain.StaleIssue{
+		{IssueKey: "TEST-2", Days: 29, ThresholdDays: 14, Hours: 40, Capped: true},
+	}, domain.Locale{})
+
+	assert.Equal(t, [][]string{
+		{"needs review", "issueKey", "hours"},
+		{"In Progress for 29 days, over the 14-day threshold: capped at 40 h pending a manual override", "TEST-2", "40.00"},
+	}, records)
+}
+
+func TestAllocate_PersonWorkTypes(t *testing.T) {
+	team := domain.Team{Team: []string{"alice", "bob"}}
+	issue := func(key, assignee string, labels ...string) domain.JiraIssue {
+		return domain.JiraIssue{
+			Key: key,
+			Fields: domain.JiraFields{
+				Assignee: domain.JiraAssignee{DisplayName: assignee},
+				Status:   domain.JiraStatus{Name: "Done"},
+				Labels:   labels,
+			},
+			Changelog: domain.JiraChangelog{Histories: []domain.JiraChangeHistory{
+				{Created: "2024-03-18T09:00:00.000+0000", Items: []domain.JiraChangeItem{{Field: "status", FromString: "To Do", ToString: "In Progress"}}},
+				{Created: "2024-03-19T09:00:00.000+0000", Items: []domain.JiraChangeItem{{Field: "status", FromString: "In Progress", ToString: "Done"}}},
+			}},
+		}
+	}
+	issues := []domain.JiraIssue{
+		issue("TEST-1", "alice", "cap-development"),
+		issue("TEST-2", "alice", "cap-maintenance"),
+		issue("TEST-3", "alice"),
+		issue("TEST-4", "bob", "cap-development"),
+	}
+	processor := &SprintTimeAllocationUseCase{sprint: "Sprint 1"}
+	processor.UsePersonWorkTypes(domain.PersonWorkTypes{"alice": domain.WorkTypeMaintenance})
+
+	workTypes := make(map[string]string)
+	require.NoError(t, processor.allocate(team, issues, nil, func(allocation domain.IssueAllocation) error {
+		workTypes[allocation.IssueKey] = allocation.WorkType
+		return nil
+	}))
+
+	assert.Equal(t, map[string]string{
+		"TEST-1": domain.WorkTypeMaintenance,
+		"TEST-2": domain.WorkTypeMaintenance,
+		"TEST-3": domain.WorkTypeMaintenance,
+		"TEST-4": domain.WorkTypeDevelopment,
+	}, workTypes)
+	assert.Equal(t, []domain.WorkTypeAdjustment{
+		{IssueKey: "TEST-1", Assignee: "alice", From: domain.WorkTypeDevelopment, To: domain.WorkTypeMaintenance, Hours: 24},
+		{IssueKey: "TEST-3", Assignee: "alice", To: domain.WorkTypeMaintenance, Hours: 24},
+	}, processor.WorkTypeAdjustments(), "rows already of the work type are not adjusted")
+}
+
+func TestAdjustmentRecords(t *testing.T) {
+	records := adjustmentRecords([]domain.WorkTypeAdjustment{
+		{IssueKey: "TEST-1", Assignee: "alice", From: domain.WorkTypeDevelopment, To: domain.WorkTypeMaintenance, Hours: 24},
+	}, domain.Locale{})
+
+	assert.Equal(t, [][]string{
+		{"adjustment", "issueKey", "hours"},
+		{"alice's work type set to cap-maintenance (was cap-development)", "TEST-1", "24.00"},
+	}, records)
+}
+
+func TestAllocate_Confidence(t *testing.T) {
+	team := domain.Team{Team: []string{"alice"}}
+	issue := func(key string, histories ...domain.JiraChangeHistory) domain.JiraIssue {
+		return domain.JiraIssue{
+			Key: key,
+			Fields: domain.JiraFields{
+				Assignee: domain.JiraAssignee{DisplayName: "alice"},
+				Status:   domain.JiraStatus{Name: "Done"},
+				Labels:   []string{"cap-development"},
+			},
+			Changelog: domain.JiraChangelog{Histories: histories},
+		}
+	}
+	status := func(created, from, to string) domain.JiraChangeHistory {
+		return domain.JiraChangeHistory{Created: created, Items: []domain.JiraChangeItem{{Field: "status", FromString: from, ToString: to}}}
+	}
+	issues := []domain.JiraIssue{
+		issue("TEST-1", status("2024-03-18T09:00:00.000+0000", "To Do", "In Progress"), status("2024-03-19T09:00:00.000+0000", "In Progress", "Done")),
+		issue("TEST-2", status("2024-02-19T09:00:00.000+0000", "To Do", "In Progress"), status("2024-03-19T09:00:00.000+0000", "In Progress", "Done")),
+		issue("TEST-3", domain.JiraChangeHistory{Created: "2024-03-18T09:00:00Z", Items: []domain.JiraChangeItem{{Field: "labels", ToString: "cap-development"}}}),
+		issue("TEST-4"),
+	}
+	processor := &SprintTimeAllocationUseCase{sprint: "Sprint 1"}
+	processor.UseHeuristics(domain.AllocationHeuristics{StaleAfterDays: 14, StaleCapHours: 40, LowConfidence: 80})
+
+	confidence := make(map[string]int)
+	require.NoError(t, processor.allocate(team, issues, nil, func(allocation domain.IssueAllocation) error {
+		confidence[allocation.IssueKey] = allocation.Confidence()
+		return nil
+	}))
+
+	assert.Equal(t, map[string]int{"TEST-1": 100, "TEST-2": 70, "TEST-3": 75, "TEST-4": 50}, confidence)
+	low := processor.LowConfidenceRows()
+	require.Len(t, low, 3)
+	assert.Equal(t, domain.LowConfidenceRow{
+		IssueKey:   "TEST-2",
+		Assignee:   "alice",
+		Confidence: 70,
+		Factors:    []domain.ConfidenceFactor{domain.ConfidenceStaleCapped},
+		Hours:      40,
+	}, low[0])
+	assert.Equal(t, []domain.ConfidenceFactor{domain.ConfidenceChangelogStart}, low[1].Factors)
+	assert.Equal(t, []domain.ConfidenceFactor{domain.ConfidenceDefaultHours}, low[2].Factors)
+
+	require.NoError(t, processor.allocate(team, issues[:1], nil, func(domain.IssueAllocation) error { return nil }))
+	assert.Empty(t, processor.LowConfidenceRows(), "each calculation starts a new list")
+}
+
+func TestLowConfidenceRecords(t *testing.T) {
+	records := lowConfidenceRecords([]domain.LowConfidenceRow{
+		{IssueKey: "TEST-4", Assignee: "alice", Confidence: 40, Factors: []domain.ConfidenceFactor{domain.ConfidenceDefaultHours, domain.ConfidenceSprintOverlap}, Hours: 8},
+	}, domain.Locale{})
+
+	assert.Equal(t, [][]string{
+		{"low confidence", "issueKey", "hours"},
+		{"alice: confidence 40 (default hours assumed; split with overlapping sprints)", "TEST-4", "8.00"},
+	}, records)
+}
+
+func TestAllocate_Retirements(t *testing.T) {
+	team := domain.Team{Team: []string{"alice"}}
+	issue := func(key, start, end string) domain.JiraIssue {
+		return domain.JiraIssue{
+			Key: key,
+			Fields: domain.JiraFields{
+				Assignee: domain.JiraAssignee{DisplayName: "alice"},
+				Status:   domain.JiraStatus{Name: "Done"},
+				Labels:   []string{"cap-development", "cap-asset-booking"},
+			},
+			Changelog: domain.JiraChangelog{Histories: []domain.JiraChangeHistory{
+				{Created: start, Items: []domain.JiraChangeItem{{Field: "status", FromString: "To Do", ToString: "In Progress"}}},
+				{Created: end, Items: []domain.JiraChangeItem{{Field: "status", FromString: "In Progress", ToString: "Done"}}},
+			}},
+		}
+	}
+	issues := []domain.JiraIssue{
+		issue("TEST-1", "2024-03-18T09:00:00.000+0000", "2024-03-19T09:00:00.000+0000"),
+		issue("TEST-2", "2024-03-20T09:00:00.000+0000", "2024-03-21T09:00:00.000+0000"),
+	}
+	processor := &SprintTimeAllocationUseCase{sprint: "Sprint 1"}
+	processor.UseRetirements(domain.AssetRetirements{"booking": time.Date(2024, 3, 19, 0, 0, 0, 0, time.UTC)})
+
+	assets := make(map[string]string)
+	require.NoError(t, processor.allocate(team, issues, nil, func(allocation domain.IssueAllocation) error {
+		assets[allocation.IssueKey] = allocation.AssetName
+		return nil
+	}))
+
+	assert.Equal(t, map[string]string{"TEST-1": "cap-asset-booking", "TEST-2": ""}, assets)
+	detached := processor.RetiredAssetWork()
+	require.Len(t, detached, 1)
+	assert.Equal(t, "TEST-2", detached[0].IssueKey)
+	assert.Equal(t, "cap-asset-booking", detached[0].AssetName)
+
+	require.NoError(t, processor.allocate(team, issues[:1], nil, func(domain.IssueAllocation) error { return nil }))
+	assert.Empty(t, processor.RetiredAssetWork(), "each calculation starts a new list")
+}
+
+func TestRetiredRecords(t *testing.T) {
+	records := retiredRecords([]domain.RetiredAssetWork{
+		{
+			IssueKey:  "TEST-2",
+			Assignee:  "alice",
+			AssetName: "cap-asset-booking",
+			RetiredOn: time.Date(2024, 3, 19, 0, 0, 0, 0, time.UTC),
+			Started:   time.Date(2024, 3, 20, 9, 0, 0, 0, time.UTC),
+			Hours:     8,
+		},
+	}, domain.Locale{})
+
+	assert.Equal(t, [][]string{
+		{"retired asset", "issueKey", "hours"},
+		{"cap-asset-booking retired on 2024-03-19: work started 2024-03-20 not attributed", "TEST-2", "8.00"},
+	}, records)
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+// TimesheetUseCase exports the sprint allocation as per-engineer day by issue timesheets
+type TimesheetUseCase struct {
+	calculator AllocationCalculator
+}
+
+// NewTimesheetUseCase creates a new TimesheetUseCase instance
+func NewTimesheetUseCase(calculator AllocationCalculator) *TimesheetUseCase {
+	return &TimesheetUseCase{
+		calculator: calculator,
+	}
+}
+
+// Execute computes the allocations and renders one CSV timesheet block per person
+func (uc *TimesheetUseCase) Execute(input domain.TimesheetInput) (string, error) {
+	formatter, err := NewCSVFormatter(input.Delimiter)
+	if err != nil {
+		return "", err
+	}
+
+	allocations, err := uc.calculator.Allocate()
+	if err != nil {
+		return "", fmt.Errorf("failed to calculate allocations: %w", err)
+	}
+
+	timesheets := domain.BuildTimesheets(allocations, input.People)
+	if len(timesheets) == 0 {
+		return "", fmt.Errorf("no allocated work found in sprint %s", input.Sprint)
+	}
+
+	blocks := make([][][]string, 0, len(timesheets))
+	for _, timesheet := range timesheets {
+		blocks = append(blocks, timesheetRecords(timesheet, input.Locale))
+	}
+	return formatter.Format(blocks...)
+}
+
+// timesheetRecords lays out a timesheet as a header, one row per issue and a daily total row
+func timesheetRecords(timesheet domain.Timesheet, locale domain.Locale) [][]string {
+	header := []string{"person", "issueKey", "issueTitle", "workType"}
+	for _, day := range timesheet.Days {
+		header = append(header, locale.Date(day))
+	}
+	header = append(header, "total")
+
+	records := [][]string{header}
+	for _, entry := range timesheet.Entries {
+		record := []string{timesheet.Person, entry.IssueKey, entry.IssueTitle, entry.WorkType}
+		record = append(record, formatTimesheetHours(entry.Hours, locale)...)
+		record = append(record, locale.Hours(entry.Total()))
+		records = append(records, record)
+	}
+
+	totals := []string{timesheet.Person, "total", "", ""}
+	totals = append(totals, formatTimesheetHours(timesheet.DayTotals(), locale)...)
+	totals = append(totals, locale.Hours(timesheet.Total()))
+	return append(records, totals)
+}
+
+// formatTimesheetHours formats daily hours, leaving days without hours empty
+func formatTimesheetHours(hours []float64, locale domain.Locale) []string {
+	cells := make([]string, len(hours))
+	for i, h := range hours {
+		if h != 0 {
+			cells[i] = locale.Hours(h)
+		}
+	}
+	return cells
+}
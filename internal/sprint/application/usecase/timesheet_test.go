@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+func TestTimesheetUseCase_Execute(t *testing.T) {
+	calculator := &stubAllocationCalculator{allocations: []domain.IssueAllocation{
+		{IssueKey: "FN-1", IssueTitle: "Checkout", Assignee: "alice", WorkType: "cap-development", Hours: 10,
+			DateStarted: time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), DateCompleted: time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{IssueKey: "FN-2", IssueTitle: "Bugfix", Assignee: "alice", WorkType: "cap-maintenance", Hours: 1.5,
+			DateStarted: time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), DateCompleted: time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{IssueKey: "FN-3", IssueTitle: "Search", Assignee: "bob", Hours: 4,
+			DateStarted: time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC), DateCompleted: time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC)},
+	}}
+
+	output, err := NewTimesheetUseCase(calculator).Execute(domain.TimesheetInput{Sprint: "Sprint 1", People: []string{"alice"}})
+	require.NoError(t, err)
+	assert.Equal(t, "person,issueKey,issueTitle,workType,2024-03-04,2024-03-05,total\n"+
+		"alice,FN-1,Checkout,cap-development,5.00,5.00,10.00\n"+
+		"alice,FN-2,Bugfix,cap-maintenance,,1.50,1.50\n"+
+		"alice,total,,,5.00,6.50,11.50\n", output)
+
+	output, err = NewTimesheetUseCase(calculator).Execute(domain.TimesheetInput{Sprint: "Sprint 1", Delimiter: ';'})
+	require.NoError(t, err)
+	assert.Contains(t, output, "alice;total;;;5.00;6.50;11.50\n\nperson;issueKey;issueTitle;workType;2024-03-06;total\nbob;FN-3;Search;;4.00;4.00\n")
+}
+
+func TestTimesheetUseCase_Locale(t *testing.T) {
+	calculator := &stubAllocationCalculator{allocations: []domain.IssueAllocation{
+		{IssueKey: "FN-2", IssueTitle: "Bugfix", Assignee: "alice", WorkType: "cap-maintenance", Hours: 1.5,
+			DateStarted: time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), DateCompleted: time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)},
+	}}
+	locale, err := domain.ParseLocale("de-DE")
+	require.NoError(t, err)
+
+	output, err := NewTimesheetUseCase(calculator).Execute(domain.TimesheetInput{Sprint: "Sprint 1", Delimiter: ';', Locale: locale})
+	require.NoError(t, err)
+	assert.Equal(t, "person;issueKey;issueTitle;workType;05.03.2024;total\n"+
+		"alice;FN-2;Bugfix;cap-maintenance;1,50;1,50\n"+
+		"alice;total;;;1,50;1,50\n", output)
+}
+
+func TestTimesheetUseCase_Errors(t *testing.T) {
+	_, err := NewTimesheetUseCase(&stubAllocationCalculator{err: errors.New("jira down")}).Execute(domain.TimesheetInput{Sprint: "Sprint 1"})
+	assert.ErrorContains(t, err, "failed to calculate allocations: jira down")
+
+	_, err = NewTimesheetUseCase(&stubAllocationCalculator{}).Execute(domain.TimesheetInput{Sprint: "Sprint 1"})
+	assert.EqualError(t, err, "no allocated work found in sprint Sprint 1")
+}
@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+// VerifySprintUseCase checks a sprint against the closure criteria
+type VerifySprintUseCase struct {
+	calculator AllocationCalculator
+}
+
+// NewVerifySprintUseCase creates a new VerifySprintUseCase instance
+func NewVerifySprintUseCase(calculator AllocationCalculator) *VerifySprintUseCase {
+	return &VerifySprintUseCase{
+		calculator: calculator,
+	}
+}
+
+// Execute computes the sprint allocation and lists the closure violations
+func (uc *VerifySprintUseCase) Execute(input domain.VerificationInput) (*domain.VerificationResult, error) {
+	allocations, err := uc.calculator.Allocate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate allocations: %w", err)
+	}
+
+	violations := domain.VerifySprintClosure(allocations)
+	return &domain.VerificationResult{
+		Project:    input.Project,
+		Sprint:     input.Sprint,
+		Passed:     len(violations) == 0,
+		Violations: violations,
+	}, nil
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+func TestVerifySprintUseCase_Execute(t *testing.T) {
+	input := domain.VerificationInput{Project: "FN", Sprint: "Sprint 1"}
+
+	passing := &stubAllocationCalculator{allocations: []domain.IssueAllocation{
+		{IssueKey: "FN-1", Assignee: "alice", Status: "Done", WorkType: "cap-development", AssetName: "cap-asset-booking", Percentage: 100},
+	}}
+	result, err := NewVerifySprintUseCase(passing).Execute(input)
+	require.NoError(t, err)
+	assert.True(t, result.Passed)
+	assert.Equal(t, "FN", result.Project)
+	assert.Equal(t, "Sprint 1", result.Sprint)
+	assert.Empty(t, result.Violations)
+
+	failing := &stubAllocationCalculator{allocations: []domain.IssueAllocation{
+		{IssueKey: "FN-1", Assignee: "alice", Status: "Done", Percentage: 100},
+	}}
+	result, err = NewVerifySprintUseCase(failing).Execute(input)
+	require.NoError(t, err)
+	assert.False(t, result.Passed)
+	require.Len(t, result.Violations, 1)
+	assert.Equal(t, domain.RuleUnclassified, result.Violations[0].Rule)
+}
+
+func TestVerifySprintUseCase_AllocationError(t *testing.T) {
+	_, err := NewVerifySprintUseCase(&stubAllocationCalculator{err: errors.New("jira down")}).Execute(domain.VerificationInput{})
+	assert.ErrorContains(t, err, "failed to calculate allocations: jira down")
+}
@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// absenceDateLayout is the layout of the days of an absence in absences.json
+const absenceDateLayout = "2006-01-02"
+
+// Absence is a run of whole days a team member was away, e.g. on vacation
+type Absence struct {
+	// From and To are the first and last day away, at midnight UTC
+	From   time.Time
+	To     time.Time
+	Reason string
+}
+
+// NewAbsence creates an absence from the first to the last day away, both included
+func NewAbsence(from, to time.Time, reason string) (Absence, error) {
+	from, to = calendarDate(from), calendarDate(to)
+	if from.IsZero() || to.IsZero() {
+		return Absence{}, fmt.Errorf("absence needs a first and a last day")
+	}
+	if to.Before(from) {
+		return Absence{}, fmt.Errorf("absence ends on %s, before it starts on %s", to.Format(absenceDateLayout), from.Format(absenceDateLayout))
+	}
+	return Absence{From: from, To: to, Reason: reason}, nil
+}
+
+// Days returns the number of days of the absence
+func (a Absence) Days() int {
+	return int(a.To.Sub(a.From).Hours()/24) + 1
+}
+
+// end returns the moment the absence is over: midnight after its last day
+func (a Absence) end() time.Time {
+	return a.To.AddDate(0, 0, 1)
+}
+
+// MarshalJSON writes the days of the absence as dates
+func (a Absence) MarshalJSON() ([]byte, error) {
+	return json.Marshal(absenceJSON{From: a.From.Format(absenceDateLayout), To: a.To.Format(absenceDateLayout), Reason: a.Reason})
+}
+
+// UnmarshalJSON reads an absence whose days are dates such as 2024-05-06
+func (a *Absence) UnmarshalJSON(data []byte) error {
+	var raw absenceJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	from, err := time.Parse(absenceDateLayout, raw.From)
+	if err != nil {
+		return fmt.Errorf("invalid absence start %q: use a date such as 2024-05-06", raw.From)
+	}
+	to, err := time.Parse(absenceDateLayout, raw.To)
+	if err != nil {
+		return fmt.Errorf("invalid absence end %q: use a date such as 2024-05-10", raw.To)
+	}
+	absence, err := NewAbsence(from, to, raw.Reason)
+	if err != nil {
+		return err
+	}
+	*a = absence
+	return nil
+}
+
+// absenceJSON is the absences.json form of an absence
+type absenceJSON struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// AbsenceCalendar holds the absences of the team members, keyed by their canonical name
+type AbsenceCalendar map[string][]Absence
+
+// Add records absences of a member, skipping the ones already recorded, and returns how
+// many were new
+func (c AbsenceCalendar) Add(member string, absences ...Absence) int {
+	added := 0
+	for _, absence := range absences {
+		known := false
+		for _, existing := range c[member] {
+			if existing.From.Equal(absence.From) && existing.To.Equal(absence.To) {
+				known = true
+				break
+			}
+		}
+		if known {
+			continue
+		}
+		c[member] = append(c[member], absence)
+		added++
+	}
+	sort.SliceStable(c[member], func(i, j int) bool {
+		return c[member][i].From.Before(c[member][j].From)
+	})
+	return added
+}
+
+// Away returns how much of the time from start to end the member was absent, counting
+// overlapping absences once
+func (c AbsenceCalendar) Away(member string, start, end time.Time) time.Duration {
+	return c.AwayWorking(member, start, end, BusinessCalendar{})
+}
+
+// AwayWorking returns how much of the working time from start to end the member was absent,
+// as counted by the business calendar
+func (c AbsenceCalendar) AwayWorking(member string, start, end time.Time, calendar BusinessCalendar) time.Duration {
+	if !end.After(start) {
+		return 0
+	}
+
+	absences := append([]Absence(nil), c[member]...)
+	sort.Slice(absences, func(i, j int) bool {
+		return absences[i].From.Before(absences[j].From)
+	})
+
+	var away time.Duration
+	covered := start
+	for _, absence := range absences {
+		from, to := absence.From, absence.end()
+		if from.Before(covered) {
+			from = covered
+		}
+		if to.After(end) {
+			to = end
+		}
+		if !to.After(from) {
+			continue
+		}
+		away += calendar.WorkingTime(from, to)
+		covered = to
+	}
+	return away
+}
+
+// AppliedAbsence records in-progress time of an issue not credited to its assignee because
+// they were absent
+type AppliedAbsence struct {
+	IssueKey string
+	Assignee string
+	Hours    float64
+}
+
+// Reason describes the absence and its effect on the issue
+func (a AppliedAbsence) Reason() string {
+	return fmt.Sprintf("%s was absent: %s h of In Progress time removed", a.Assignee, strconv.FormatFloat(a.Hours, 'f', -1, 64))
+}
+
+// calendarDate returns the day of t at midnight UTC, or the zero time for the zero time
+func calendarDate(t time.Time) time.Time {
+	if t.IsZero() {
+		return time.Time{}
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
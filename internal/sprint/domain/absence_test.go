@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func absenceDay(month time.Month, d int) time.Time {
+	return time.Date(2024, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestNewAbsence(t *testing.T) {
+	absence, err := NewAbsence(time.Date(2024, time.May, 6, 15, 30, 0, 0, time.UTC), absenceDay(time.May, 10), "vacation")
+	require.NoError(t, err)
+	assert.Equal(t, absenceDay(time.May, 6), absence.From)
+	assert.Equal(t, 5, absence.Days())
+
+	_, err = NewAbsence(absenceDay(time.May, 10), absenceDay(time.May, 6), "")
+	assert.EqualError(t, err, "absence ends on 2024-05-06, before it starts on 2024-05-10")
+}
+
+func TestAbsence_JSON(t *testing.T) {
+	var calendar AbsenceCalendar
+	require.NoError(t, json.Unmarshal([]byte(`{"alice": [{"from": "2024-05-06", "to": "2024-05-10", "reason": "vacation"}]}`), &calendar))
+
+	assert.Equal(t, AbsenceCalendar{"alice": {{From: absenceDay(time.May, 6), To: absenceDay(time.May, 10), Reason: "vacation"}}}, calendar)
+	data, err := json.Marshal(calendar)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"alice": [{"from": "2024-05-06", "to": "2024-05-10", "reason": "vacation"}]}`, string(data))
+
+	err = json.Unmarshal([]byte(`{"alice": [{"from": "06/05/2024", "to": "2024-05-10"}]}`), &calendar)
+	assert.EqualError(t, err, `invalid absence start "06/05/2024": use a date such as 2024-05-06`)
+}
+
+func TestAbsenceCalendar_Add(t *testing.T) {
+	calendar := AbsenceCalendar{}
+	later := Absence{From: absenceDay(time.June, 3), To: absenceDay(time.June, 4)}
+	earlier := Absence{From: absenceDay(time.May, 6), To: absenceDay(time.May, 10)}
+
+	assert.Equal(t, 2, calendar.Add("alice", later, earlier))
+	assert.Equal(t, 0, calendar.Add("alice", earlier))
+	assert.Equal(t, []Absence{earlier, later}, calendar["alice"])
+}
+
+func TestAbsenceCalendar_Away(t *testing.T) {
+	calendar := AbsenceCalendar{"alice": {
+		{From: absenceDay(time.May, 8), To: absenceDay(time.May, 9)},
+		{From: absenceDay(time.May, 9), To: absenceDay(time.May, 9)},
+	}}
+	start := time.Date(2024, time.May, 6, 12, 0, 0, 0, time.UTC)
+	end := time.Date(2024, time.May, 13, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		member     string
+		start, end time.Time
+		want       time.Duration
+	}{
+		{name: "overlapping absences count once", member: "alice", start: start, end: end, want: 48 * time.Hour},
+		{name: "window ending mid absence", member: "alice", start: start, end: time.Date(2024, time.May, 8, 18, 0, 0, 0, time.UTC), want: 18 * time.Hour},
+		{name: "window within absence", member: "alice", start: time.Date(2024, time.May, 9, 9, 0, 0, 0, time.UTC), end: time.Date(2024, time.May, 9, 17, 0, 0, 0, time.UTC), want: 8 * time.Hour},
+		{name: "window outside absences", member: "alice", start: absenceDay(time.May, 1), end: absenceDay(time.May, 8), want: 0},
+		{name: "member without absences", member: "bob", start: start, end: end, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, calendar.Away(tt.member, tt.start, tt.end))
+		})
+	}
+}
+
+func TestAppliedAbsence_Reason(t *testing.T) {
+	assert.Equal(t, "alice was absent: 48 h of In Progress time removed", AppliedAbsence{IssueKey: "FN-1", Assignee: "alice", Hours: 48}.Reason())
+}
@@ -0,0 +1,30 @@
+package domain
+
+// AssetAccount is the cost center and general ledger account the capitalized cost of an asset
+// is booked to
+type AssetAccount struct {
+	CostCenter string
+	GLAccount  string
+}
+
+// Complete reports whether both the cost center and the general ledger account are known
+func (a AssetAccount) Complete() bool {
+	return a.CostCenter != "" && a.GLAccount != ""
+}
+
+// AssetAccounts maps asset names or labels to the accounts their capitalized cost is booked to
+type AssetAccounts map[string]AssetAccount
+
+// For returns the accounts of the asset an allocation label refers to, or the zero account when
+// the asset has none
+func (a AssetAccounts) For(assetName string) AssetAccount {
+	if account, ok := a[assetName]; ok {
+		return account
+	}
+	for name, account := range a {
+		if matchesAsset(assetName, name) {
+			return account
+		}
+	}
+	return AssetAccount{}
+}
@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssetAccounts_For(t *testing.T) {
+	accounts := AssetAccounts{"Booking": {CostCenter: "CC-100", GLAccount: "1710"}}
+
+	assert.Equal(t, AssetAccount{CostCenter: "CC-100", GLAccount: "1710"}, accounts.For("Booking"))
+	assert.Equal(t, AssetAccount{CostCenter: "CC-100", GLAccount: "1710"}, accounts.For("cap-asset-booking"))
+	assert.Equal(t, AssetAccount{}, accounts.For("cap-asset-search"))
+	assert.Equal(t, AssetAccount{}, accounts.For(""))
+	assert.Equal(t, AssetAccount{}, AssetAccounts(nil).For("booking"))
+}
+
+func TestAssetAccount_Complete(t *testing.T) {
+	assert.True(t, AssetAccount{CostCenter: "CC-100", GLAccount: "1710"}.Complete())
+	assert.False(t, AssetAccount{CostCenter: "CC-100"}.Complete())
+	assert.False(t, AssetAccount{}.Complete())
+}
@@ -0,0 +1,91 @@
+package domain
+
+import "time"
+
+// AllocationInput represents the input parameters for a sprint allocation export
+type AllocationInput struct {
+	Project string
+	Sprint  string
+	// FixVersion allocates the issues of a release instead of a sprint, bounded by its dates
+	FixVersion string
+	Override   string
+	Delimiter  rune
+	// Method selects how hours are split across issues; empty means time-based
+	Method AllocationMethod
+	// PointsAt selects the story point estimate used by the story point method
+	PointsAt PointsAt
+	// Normalization selects what the percentages are a share of; empty means each person's time
+	Normalization Normalization
+	// Summary adds a summary section after the allocation rows, such as one row per person
+	Summary AllocationSummary
+	// LabelsAsOf classifies issues by their labels at a past point in time
+	LabelsAsOf LabelSnapshot
+	// AssetDocs links allocated assets to their documentation pages
+	AssetDocs AssetDocLinks
+	// AssetAccounts books allocated assets to their cost centers and general ledger accounts
+	AssetAccounts AssetAccounts
+	// WorkTypeSplits divides the hours of annotated issues across work types
+	WorkTypeSplits WorkTypeSplits
+	// Locale formats the numbers and dates of the export
+	Locale Locale
+	// Progress, when set, is told how many issues have been allocated as rows are written
+	Progress func(done, total int)
+	// Heuristics configures the hours credited to untracked issues and same-day completions
+	Heuristics AllocationHeuristics
+	// PersonWorkTypes overrides the work type of every row of the given team members
+	PersonWorkTypes PersonWorkTypes
+	// Retirements stop attributing work started after their retirement date to retired assets
+	Retirements AssetRetirements
+	// StrictRoster fails the allocation when a team member is credited no hours, which is
+	// otherwise only a warning
+	StrictRoster bool
+	// Export, when set, is a Jira JSON or CSV export allocated instead of querying Jira
+	Export string
+}
+
+// IssueAllocation represents the time attributed to a single issue in a sprint
+type IssueAllocation struct {
+	Sprint string `json:"sprint"`
+	// SprintID is the Jira ID of the allocated sprint, zero when it is not known
+	SprintID   int    `json:"sprintId,omitempty"`
+	IssueKey   string `json:"issueKey"`
+	IssueType  string `json:"issueType"`
+	IssueTitle string `json:"issueTitle"`
+	Assignee   string `json:"assignee"`
+	// AssigneeAccountID is the Jira account ID of the assignee, empty when it is not known
+	AssigneeAccountID string `json:"assigneeAccountId,omitempty"`
+	WorkType          string `json:"workType"`
+	AssetName         string `json:"assetName"`
+	Status            string `json:"status"`
+	// StatusCategory is the category of the status, whatever the language of its name
+	StatusCategory StatusCategory `json:"statusCategory,omitempty"`
+	Hours          float64        `json:"hours"`
+	Percentage     float64        `json:"percentage"`
+	// DateStarted and DateCompleted bound the work; DateCompleted is zero while in progress
+	DateStarted   time.Time `json:"dateStarted,omitzero"`
+	DateCompleted time.Time `json:"dateCompleted,omitzero"`
+	// Impaired marks work on an asset written down during the allocated period
+	Impaired bool `json:"impaired,omitempty"`
+	// Policy names the policy rule that reclassified the work type, empty when none did
+	Policy string `json:"policy,omitempty"`
+	// EvidenceURL links to the Jira issue and AssetURL to the asset's Confluence page
+	EvidenceURL string `json:"evidenceUrl,omitempty"`
+	AssetURL    string `json:"assetUrl,omitempty"`
+	// CostCenter and GLAccount are where the asset's capitalized cost is booked
+	CostCenter string `json:"costCenter,omitempty"`
+	GLAccount  string `json:"glAccount,omitempty"`
+	// ScopeChanges are the times the issue entered or left the sprint while it ran
+	ScopeChanges []ScopeChange `json:"scopeChanges,omitempty"`
+	// ConfidenceFactors are the fallbacks, caps and heuristics that shaped the row; none for a
+	// row taken from a clean changelog
+	ConfidenceFactors []ConfidenceFactor `json:"confidenceFactors,omitempty"`
+	// OriginalEstimate and RemainingEstimate are the issue's time tracking estimates in hours,
+	// nil when it is not estimated
+	OriginalEstimate  *float64 `json:"originalEstimate,omitempty"`
+	RemainingEstimate *float64 `json:"remainingEstimate,omitempty"`
+}
+
+// IsDone reports whether the allocated issue is completed
+func (a IssueAllocation) IsDone() bool {
+	return CategoryOf(a.StatusCategory, a.Status) == StatusCategoryDone
+}
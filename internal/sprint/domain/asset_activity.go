@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// unclassifiedWorkType labels hours of issues without a work type in the work type mix
+const unclassifiedWorkType = "unclassified"
+
+// AssetActivityInput represents the input for summarizing an asset's activity in a sprint
+type AssetActivityInput struct {
+	Project  string
+	Sprint   string
+	Asset    string
+	Override string
+	// LabelsAsOf classifies issues by their labels at a past point in time
+	LabelsAsOf LabelSnapshot
+	// WorkTypeSplits divides the hours of annotated issues across work types
+	WorkTypeSplits WorkTypeSplits
+	// Heuristics fills in untracked hours, as for AllocationInput
+	Heuristics AllocationHeuristics
+	// Export, when set, is a Jira JSON or CSV export allocated instead of querying Jira
+	Export string
+}
+
+// AssetActivityTask is an issue of the asset completed during the sprint
+type AssetActivityTask struct {
+	IssueKey      string    `json:"issueKey"`
+	IssueTitle    string    `json:"issueTitle"`
+	IssueType     string    `json:"issueType"`
+	WorkTypes     []string  `json:"workTypes"`
+	Assignees     []string  `json:"assignees"`
+	Hours         float64   `json:"hours"`
+	DateCompleted time.Time `json:"dateCompleted"`
+}
+
+// WorkTypeHours is the share of an asset's hours spent on one work type
+type WorkTypeHours struct {
+	WorkType   string  `json:"workType"`
+	Hours      float64 `json:"hours"`
+	Percentage float64 `json:"percentage"`
+}
+
+// AssetActivity summarizes what changed on an asset during a sprint
+type AssetActivity struct {
+	Asset   string `json:"asset"`
+	Project string `json:"project"`
+	Sprint  string `json:"sprint"`
+	// Completed lists the asset's issues completed in the sprint, in completion order
+	Completed []AssetActivityTask `json:"completed"`
+	// TotalHours sums the allocated hours of every issue of the asset, completed or not
+	TotalHours  float64         `json:"totalHours"`
+	WorkTypeMix []WorkTypeHours `json:"workTypeMix"`
+	// Summary is the generated one-paragraph summary, empty unless requested
+	Summary string `json:"summary,omitempty"`
+}
+
+// NewAssetActivity builds the activity of an asset from the sprint allocations
+func NewAssetActivity(project, sprint, asset string, allocations []IssueAllocation) *AssetActivity {
+	activity := &AssetActivity{Asset: asset, Project: project, Sprint: sprint}
+
+	hoursByWorkType := make(map[string]float64)
+	completed := make(map[string]int)
+	for _, allocation := range allocations {
+		if !matchesAsset(allocation.AssetName, asset) {
+			continue
+		}
+		workType := allocation.WorkType
+		if workType == "" {
+			workType = unclassifiedWorkType
+		}
+		activity.TotalHours += allocation.Hours
+		hoursByWorkType[workType] += allocation.Hours
+
+		if allocation.DateCompleted.IsZero() {
+			continue
+		}
+		index, seen := completed[allocation.IssueKey]
+		if !seen {
+			index = len(activity.Completed)
+			completed[allocation.IssueKey] = index
+			activity.Completed = append(activity.Completed, AssetActivityTask{
+				IssueKey:      allocation.IssueKey,
+				IssueTitle:    allocation.IssueTitle,
+				IssueType:     allocation.IssueType,
+				DateCompleted: allocation.DateCompleted,
+			})
+		}
+		task := &activity.Completed[index]
+		task.Hours += allocation.Hours
+		task.WorkTypes = appendUnique(task.WorkTypes, workType)
+		task.Assignees = appendUnique(task.Assignees, allocation.Assignee)
+	}
+
+	sort.SliceStable(activity.Completed, func(i, j int) bool {
+		return activity.Completed[i].DateCompleted.Before(activity.Completed[j].DateCompleted)
+	})
+	for workType, hours := range hoursByWorkType {
+		share := WorkTypeHours{WorkType: workType, Hours: hours}
+		if activity.TotalHours > 0 {
+			share.Percentage = hours / activity.TotalHours * 100
+		}
+		activity.WorkTypeMix = append(activity.WorkTypeMix, share)
+	}
+	sort.Slice(activity.WorkTypeMix, func(i, j int) bool {
+		if activity.WorkTypeMix[i].Hours != activity.WorkTypeMix[j].Hours {
+			return activity.WorkTypeMix[i].Hours > activity.WorkTypeMix[j].Hours
+		}
+		return activity.WorkTypeMix[i].WorkType < activity.WorkTypeMix[j].WorkType
+	})
+	return activity
+}
+
+// Digest renders the activity as plain text, the input of the generated summary
+func (a *AssetActivity) Digest() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Asset: %s\nSprint: %s (%s)\n", a.Asset, a.Sprint, a.Project)
+	fmt.Fprintf(&b, "Completed tasks: %d\n", len(a.Completed))
+	for _, task := range a.Completed {
+		fmt.Fprintf(&b, "- %s %s (%s, %.2f h)\n", task.IssueKey, task.IssueTitle, strings.Join(task.WorkTypes, ", "), task.Hours)
+	}
+	fmt.Fprintf(&b, "Total hours: %.2f\n", a.TotalHours)
+	fmt.Fprintf(&b, "Work type mix: %s\n", a.WorkTypeMixString())
+	return b.String()
+}
+
+// WorkTypeMixString describes the work type mix, e.g. "cap-development 80.0%, cap-maintenance 20.0%"
+func (a *AssetActivity) WorkTypeMixString() string {
+	if len(a.WorkTypeMix) == 0 {
+		return "none"
+	}
+	mix := make([]string, 0, len(a.WorkTypeMix))
+	for _, share := range a.WorkTypeMix {
+		mix = append(mix, fmt.Sprintf("%s %.1f%%", share.WorkType, share.Percentage))
+	}
+	return strings.Join(mix, ", ")
+}
+
+// appendUnique appends the value unless it is empty or already present
+func appendUnique(values []string, value string) []string {
+	if value == "" {
+		return values
+	}
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
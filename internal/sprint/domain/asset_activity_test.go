@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAssetActivity(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC) }
+	allocations := []IssueAllocation{
+		{IssueKey: "FN-2", IssueTitle: "Fix refund rounding", Assignee: "bob", WorkType: "cap-maintenance", AssetName: "cap-asset-booking", Hours: 2, DateCompleted: day(21)},
+		{IssueKey: "FN-1", IssueTitle: "Add payment retries", Assignee: "alice", WorkType: "cap-development", AssetName: "cap-asset-booking", Hours: 6, DateCompleted: day(20)},
+		{IssueKey: "FN-1", IssueTitle: "Add payment retries", Assignee: "bob", WorkType: "cap-development", AssetName: "cap-asset-booking", Hours: 2, DateCompleted: day(20)},
+		{IssueKey: "FN-3", IssueTitle: "Redesign checkout", Assignee: "alice", WorkType: "cap-development", AssetName: "cap-asset-booking", Hours: 8},
+		{IssueKey: "FN-4", IssueTitle: "Spike", Assignee: "carol", AssetName: "cap-asset-booking", Hours: 2, DateCompleted: day(19)},
+		{IssueKey: "FN-5", IssueTitle: "Search ranking", Assignee: "carol", WorkType: "cap-development", AssetName: "cap-asset-search", Hours: 5, DateCompleted: day(19)},
+	}
+
+	activity := NewAssetActivity("FN", "Sprint 1", "Booking", allocations)
+
+	require.Len(t, activity.Completed, 3)
+	assert.Equal(t, "FN-4", activity.Completed[0].IssueKey)
+	assert.Equal(t, []string{"unclassified"}, activity.Completed[0].WorkTypes)
+	assert.Equal(t, "FN-1", activity.Completed[1].IssueKey)
+	assert.Equal(t, []string{"alice", "bob"}, activity.Completed[1].Assignees)
+	assert.Equal(t, 8.0, activity.Completed[1].Hours)
+	assert.Equal(t, "FN-2", activity.Completed[2].IssueKey)
+
+	assert.Equal(t, 20.0, activity.TotalHours)
+	assert.Equal(t, []WorkTypeHours{
+		{WorkType: "cap-development", Hours: 16, Percentage: 80},
+		{WorkType: "cap-maintenance", Hours: 2, Percentage: 10},
+		{WorkType: "unclassified", Hours: 2, Percentage: 10},
+	}, activity.WorkTypeMix)
+	assert.Equal(t, "cap-development 80.0%, cap-maintenance 10.0%, unclassified 10.0%", activity.WorkTypeMixString())
+}
+
+func TestAssetActivity_Digest(t *testing.T) {
+	activity := NewAssetActivity("FN", "Sprint 1", "booking", []IssueAllocation{
+		{IssueKey: "FN-1", IssueTitle: "Add payment retries", Assignee: "alice", WorkType: "cap-development", AssetName: "cap-asset-booking", Hours: 6, DateCompleted: time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)},
+	})
+
+	assert.Equal(t, `Asset: booking
+Sprint: Sprint 1 (FN)
+Completed tasks: 1
+- FN-1 Add payment retries (cap-development, 6.00 h)
+Total hours: 6.00
+Work type mix: cap-development 100.0%
+`, activity.Digest())
+
+	empty := NewAssetActivity("FN", "Sprint 1", "booking", nil)
+	assert.Empty(t, empty.Completed)
+	assert.Equal(t, "none", empty.WorkTypeMixString())
+}
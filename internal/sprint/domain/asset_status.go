@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// AssetStatusChange is a status an asset took on at a date
+type AssetStatusChange struct {
+	Status string
+	From   time.Time
+}
+
+// AssetStatuses holds the status changes of assets by name, oldest first, so reports resolve
+// the status an asset had during their period rather than today's
+type AssetStatuses map[string][]AssetStatusChange
+
+// At returns the status of an asset, named with or without the label prefix, at a point in
+// time. A zero time gives the latest status, and a time before the first change none.
+func (s AssetStatuses) At(assetName string, at time.Time) string {
+	for name, changes := range s {
+		if !matchesAsset(assetName, name) {
+			continue
+		}
+		status := ""
+		for _, change := range changes {
+			if !at.IsZero() && change.From.After(at) {
+				break
+			}
+			status = change.Status
+		}
+		return status
+	}
+	return ""
+}
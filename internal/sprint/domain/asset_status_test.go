@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssetStatuses_At(t *testing.T) {
+	launch := time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)
+	statuses := AssetStatuses{"booking": {
+		{Status: "development", From: time.Time{}},
+		{Status: "live", From: launch},
+	}}
+
+	assert.Equal(t, "development", statuses.At("booking", time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "live", statuses.At("cap-asset-booking", launch), "labels match their asset")
+	assert.Equal(t, "live", statuses.At("booking", time.Time{}), "a zero time gives the latest status")
+	assert.Empty(t, statuses.At("search", launch))
+
+	dated := AssetStatuses{"search": {{Status: "beta", From: launch}}}
+	assert.Empty(t, dated.At("search", launch.AddDate(0, 0, -1)), "no status before the first change")
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AssignmentPeriod is a span of time during which an issue kept the same assignee. A zero
+// Start means since the issue was created, and a zero End means the assignee is current.
+type AssignmentPeriod struct {
+	Assignee  string
+	AccountID string
+	Start     time.Time
+	End       time.Time
+}
+
+// Overlap returns the part of the period within from and to, and false when they do not overlap
+func (p AssignmentPeriod) Overlap(from, to time.Time) (time.Time, time.Time, bool) {
+	if !p.Start.IsZero() && p.Start.After(from) {
+		from = p.Start
+	}
+	if !p.End.IsZero() && p.End.Before(to) {
+		to = p.End
+	}
+	return from, to, to.After(from)
+}
+
+// UnattributedTime is in-progress time of an issue that no team member was assigned to
+type UnattributedTime struct {
+	IssueKey string
+	// Assignee is who held the issue at the time, empty when it was unassigned
+	Assignee string
+	Hours    float64
+}
+
+// Reason describes why the time could not be attributed
+func (u UnattributedTime) Reason() string {
+	if u.Assignee == "" {
+		return "in progress while unassigned"
+	}
+	return fmt.Sprintf("in progress while assigned to %s, who is not a team member", u.Assignee)
+}
+
+// IsAssigneeChange checks if this change item represents a change of the issue assignee
+func (i *JiraChangeItem) IsAssigneeChange() bool {
+	return strings.EqualFold(i.Field, "assignee")
+}
+
+// AssignmentPeriods replays the changelog into the periods the issue spent with each
+// assignee, in chronological order. Without assignee changes, the current assignee holds
+// the issue for its whole life.
+func (i *JiraIssue) AssignmentPeriods() []AssignmentPeriod {
+	var periods []AssignmentPeriod
+	for _, history := range i.Changelog.Histories {
+		for _, item := range history.Items {
+			if !item.IsAssigneeChange() {
+				continue
+			}
+			changed, err := ParseJiraTime(history.Created)
+			if err != nil {
+				continue
+			}
+			if len(periods) == 0 {
+				periods = append(periods, AssignmentPeriod{Assignee: item.FromString, AccountID: item.From})
+			}
+			periods[len(periods)-1].End = changed
+			periods = append(periods, AssignmentPeriod{Assignee: item.ToString, AccountID: item.To, Start: changed})
+		}
+	}
+	if len(periods) == 0 {
+		return []AssignmentPeriod{{Assignee: i.Fields.Assignee.DisplayName, AccountID: i.Fields.Assignee.AccountID}}
+	}
+	return periods
+}
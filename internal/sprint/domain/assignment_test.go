@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJiraIssue_AssignmentPeriods(t *testing.T) {
+	t.Run("current assignee without changes", func(t *testing.T) {
+		issue := JiraIssue{Fields: JiraFields{Assignee: JiraAssignee{DisplayName: "alice"}}}
+
+		assert.Equal(t, []AssignmentPeriod{{Assignee: "alice"}}, issue.AssignmentPeriods())
+	})
+
+	t.Run("replays assignee changes", func(t *testing.T) {
+		issue := JiraIssue{
+			Fields: JiraFields{Assignee: JiraAssignee{DisplayName: "bob"}},
+			Changelog: JiraChangelog{Histories: []JiraChangeHistory{
+				{Created: "2024-03-18T09:00:00.000+0000", Items: []JiraChangeItem{{Field: "status", ToString: "In Progress"}}},
+				{Created: "2024-03-18T11:00:00.000+0000", Items: []JiraChangeItem{{Field: "assignee", ToString: "alice"}}},
+				{Created: "2024-03-19T11:00:00.000+0000", Items: []JiraChangeItem{{Field: "assignee", FromString: "alice", ToString: "bob"}}},
+			}},
+		}
+
+		assert.Equal(t, []AssignmentPeriod{
+			{Assignee: "", End: time.Date(2024, 3, 18, 11, 0, 0, 0, time.UTC)},
+			{Assignee: "alice", Start: time.Date(2024, 3, 18, 11, 0, 0, 0, time.UTC), End: time.Date(2024, 3, 19, 11, 0, 0, 0, time.UTC)},
+			{Assignee: "bob", Start: time.Date(2024, 3, 19, 11, 0, 0, 0, time.UTC)},
+		}, issue.AssignmentPeriods())
+	})
+
+	t.Run("keeps account IDs", func(t *testing.T) {
+		issue := JiraIssue{
+			Fields: JiraFields{Assignee: JiraAssignee{AccountID: "acc-bob", DisplayName: "bob"}},
+			Changelog: JiraChangelog{Histories: []JiraChangeHistory{
+				{Created: "2024-03-19T11:00:00.000+0000", Items: []JiraChangeItem{{Field: "assignee", From: "acc-alice", FromString: "alice", To: "acc-bob", ToString: "bob"}}},
+			}},
+		}
+
+		assert.Equal(t, []AssignmentPeriod{
+			{Assignee: "alice", AccountID: "acc-alice", End: time.Date(2024, 3, 19, 11, 0, 0, 0, time.UTC)},
+			{Assignee: "bob", AccountID: "acc-bob", Start: time.Date(2024, 3, 19, 11, 0, 0, 0, time.UTC)},
+		}, issue.AssignmentPeriods())
+
+		issue.Changelog = JiraChangelog{}
+		assert.Equal(t, []AssignmentPeriod{{Assignee: "bob", AccountID: "acc-bob"}}, issue.AssignmentPeriods())
+	})
+}
+
+func TestAssignmentPeriod_Overlap(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC) }
+	period := AssignmentPeriod{Assignee: "alice", Start: day(18), End: day(20)}
+
+	from, to, ok := period.Overlap(day(17), day(19))
+	assert.True(t, ok)
+	assert.Equal(t, day(18), from)
+	assert.Equal(t, day(19), to)
+
+	_, _, ok = period.Overlap(day(20), day(21))
+	assert.False(t, ok)
+
+	from, to, ok = AssignmentPeriod{Assignee: "alice"}.Overlap(day(17), day(19))
+	assert.True(t, ok)
+	assert.Equal(t, day(17), from)
+	assert.Equal(t, day(19), to)
+}
+
+func TestUnattributedTime_Reason(t *testing.T) {
+	assert.Equal(t, "in progress while unassigned", UnattributedTime{IssueKey: "FN-1"}.Reason())
+	assert.Equal(t, "in progress while assigned to carol, who is not a team member", UnattributedTime{IssueKey: "FN-1", Assignee: "carol"}.Reason())
+}
@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// holidayDateLayout is the layout of the holidays in teams.json and the configuration
+const holidayDateLayout = "2006-01-02"
+
+// BusinessCalendar counts the working time between two moments: the workday hours of the days
+// that are neither weekend days nor holidays. The zero value has no workday hours and counts
+// wall-clock time, nights and weekends included.
+type BusinessCalendar struct {
+	// DayStart and DayEnd bound the working hours of each workday, as time since midnight
+	DayStart time.Duration
+	DayEnd   time.Duration
+	// Weekend lists the days of the week nobody works
+	Weekend []time.Weekday
+	// Holidays are the days nobody works, at midnight UTC
+	Holidays []time.Time
+	// Location is the time zone of the workday hours and holidays; UTC when nil
+	Location *time.Location
+}
+
+// NewBusinessCalendar creates a calendar working from dayStart to dayEnd, e.g. 09:00 and 17:00,
+// on the days of the week not in weekend, e.g. saturday, excluding the holidays, e.g.
+// 2024-12-25. The hours and days are those of the time zone, UTC when empty.
+func NewBusinessCalendar(dayStart, dayEnd string, weekend, holidays []string, timeZone string) (BusinessCalendar, error) {
+	start, err := parseTimeOfDay(dayStart)
+	if err != nil {
+		return BusinessCalendar{}, fmt.Errorf("invalid workday start: %w", err)
+	}
+	end, err := parseTimeOfDay(dayEnd)
+	if err != nil {
+		return BusinessCalendar{}, fmt.Errorf("invalid workday end: %w", err)
+	}
+	if end <= start {
+		return BusinessCalendar{}, fmt.Errorf("workday ends at %s, not after it starts at %s", dayEnd, dayStart)
+	}
+
+	calendar := BusinessCalendar{DayStart: start, DayEnd: end}
+	for _, day := range weekend {
+		weekday, err := ParseWeekday(day)
+		if err != nil {
+			return BusinessCalendar{}, err
+		}
+		calendar.Weekend = append(calendar.Weekend, weekday)
+	}
+	if calendar.Holidays, err = ParseHolidays(holidays); err != nil {
+		return BusinessCalendar{}, err
+	}
+	if timeZone != "" {
+		if calendar.Location, err = time.LoadLocation(timeZone); err != nil {
+			return BusinessCalendar{}, fmt.Errorf("unknown time zone %q: use a name such as Europe/Berlin", timeZone)
+		}
+	}
+	return calendar, nil
+}
+
+// ParseWeekday parses the English name of a day of the week, e.g. saturday
+func ParseWeekday(name string) (time.Weekday, error) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(strings.TrimSpace(name), day.String()) {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown weekday %q: use a day such as saturday", name)
+}
+
+// ParseHolidays parses dates such as 2024-12-25
+func ParseHolidays(dates []string) ([]time.Time, error) {
+	holidays := make([]time.Time, 0, len(dates))
+	for _, date := range dates {
+		day, err := time.Parse(holidayDateLayout, strings.TrimSpace(date))
+		if err != nil {
+			return nil, fmt.Errorf("invalid holiday %q: use a date such as 2024-12-25", date)
+		}
+		holidays = append(holidays, day)
+	}
+	return holidays, nil
+}
+
+// Enabled reports whether the calendar counts working hours instead of wall-clock time
+func (c BusinessCalendar) Enabled() bool {
+	return c.DayEnd > c.DayStart
+}
+
+// WithHolidays returns a copy of the calendar that also rests on the given days, such as the
+// holidays of a team
+func (c BusinessCalendar) WithHolidays(holidays ...time.Time) BusinessCalendar {
+	if len(holidays) == 0 {
+		return c
+	}
+	c.Holidays = append(append([]time.Time(nil), c.Holidays...), holidays...)
+	return c
+}
+
+// WorkingTime returns the working time from start to end. A calendar without workday hours
+// returns the wall-clock time between them.
+func (c BusinessCalendar) WorkingTime(start, end time.Time) time.Duration {
+	if !end.After(start) {
+		return 0
+	}
+	if !c.Enabled() {
+		return end.Sub(start)
+	}
+
+	location := c.Location
+	if location == nil {
+		location = time.UTC
+	}
+	start, end = start.In(location), end.In(location)
+
+	var working time.Duration
+	for day := midnight(start, location); day.Before(end); day = day.AddDate(0, 0, 1) {
+		if !c.Workday(day) {
+			continue
+		}
+		from := atTimeOfDay(day, c.DayStart)
+		to := atTimeOfDay(day, c.DayEnd)
+		if from.Before(start) {
+			from = start
+		}
+		if to.After(end) {
+			to = end
+		}
+		if to.After(from) {
+			working += to.Sub(from)
+		}
+	}
+	return working
+}
+
+// WorkingHours returns the working time from start to end in hours
+func (c BusinessCalendar) WorkingHours(start, end time.Time) float64 {
+	return c.WorkingTime(start, end).Hours()
+}
+
+// Workday reports whether the day is neither a weekend day nor a holiday
+func (c BusinessCalendar) Workday(day time.Time) bool {
+	for _, weekend := range c.Weekend {
+		if day.Weekday() == weekend {
+			return false
+		}
+	}
+	year, month, date := day.Date()
+	for _, holiday := range c.Holidays {
+		if holiday.Year() == year && holiday.Month() == month && holiday.Day() == date {
+			return false
+		}
+	}
+	return true
+}
+
+// parseTimeOfDay parses a time of day such as 09:00 into the time since midnight
+func parseTimeOfDay(value string) (time.Duration, error) {
+	parsed, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a time of day such as 09:00", value)
+	}
+	return time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute, nil
+}
+
+// midnight returns the start of the day of t in the location
+func midnight(t time.Time, location *time.Location) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, location)
+}
+
+// atTimeOfDay returns the wall-clock time of day on the day, which stays right on the days
+// daylight saving time starts or ends
+func atTimeOfDay(day time.Time, offset time.Duration) time.Time {
+	year, month, date := day.Date()
+	return time.Date(year, month, date, 0, int(offset/time.Minute), 0, 0, day.Location())
+}
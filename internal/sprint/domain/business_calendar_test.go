@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBusinessCalendar(t *testing.T) {
+	calendar, err := NewBusinessCalendar("09:00", "17:30", []string{"Saturday", "sunday"}, []string{"2024-12-25"}, "Europe/Berlin")
+	require.NoError(t, err)
+	assert.Equal(t, 9*time.Hour, calendar.DayStart)
+	assert.Equal(t, 17*time.Hour+30*time.Minute, calendar.DayEnd)
+	assert.Equal(t, []time.Weekday{time.Saturday, time.Sunday}, calendar.Weekend)
+	assert.Equal(t, []time.Time{time.Date(2024, time.December, 25, 0, 0, 0, 0, time.UTC)}, calendar.Holidays)
+	assert.Equal(t, "Europe/Berlin", calendar.Location.String())
+	assert.True(t, calendar.Enabled())
+
+	tests := []struct {
+		name                   string
+		dayStart, dayEnd, zone string
+		weekend, holidays      []string
+		wantErr                string
+	}{
+		{name: "invalid start", dayStart: "9am", dayEnd: "17:00", wantErr: `invalid workday start: "9am" is not a time of day such as 09:00`},
+		{name: "end before start", dayStart: "17:00", dayEnd: "09:00", wantErr: "workday ends at 09:00, not after it starts at 17:00"},
+		{name: "unknown weekday", dayStart: "09:00", dayEnd: "17:00", weekend: []string{"sat"}, wantErr: `unknown weekday "sat": use a day such as saturday`},
+		{name: "invalid holiday", dayStart: "09:00", dayEnd: "17:00", holidays: []string{"25/12/2024"}, wantErr: `invalid holiday "25/12/2024": use a date such as 2024-12-25`},
+		{name: "unknown time zone", dayStart: "09:00", dayEnd: "17:00", zone: "Mars/Olympus", wantErr: `unknown time zone "Mars/Olympus": use a name such as Europe/Berlin`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewBusinessCalendar(tt.dayStart, tt.dayEnd, tt.weekend, tt.holidays, tt.zone)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestBusinessCalendar_WorkingTime(t *testing.T) {
+	calendar, err := NewBusinessCalendar("09:00", "17:00", []string{"saturday", "sunday"}, []string{"2024-05-09"}, "")
+	require.NoError(t, err)
+	// May 6, 2024 is a Monday
+	at := func(day, hour int) time.Time { return time.Date(2024, time.May, day, hour, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name       string
+		start, end time.Time
+		want       time.Duration
+	}{
+		{name: "within a workday", start: at(6, 10), end: at(6, 15), want: 5 * time.Hour},
+		{name: "overnight", start: at(6, 15), end: at(7, 11), want: 4 * time.Hour},
+		{name: "outside the workday hours", start: at(6, 18), end: at(7, 8), want: 0},
+		{name: "over a holiday", start: at(8, 9), end: at(10, 17), want: 16 * time.Hour},
+		{name: "over a weekend", start: at(10, 16), end: at(13, 10), want: 2 * time.Hour},
+		{name: "a whole week", start: at(6, 0), end: at(13, 0), want: 32 * time.Hour},
+		{name: "end before start", start: at(7, 10), end: at(6, 10), want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, calendar.WorkingTime(tt.start, tt.end))
+		})
+	}
+
+	assert.Equal(t, 26*time.Hour, BusinessCalendar{}.WorkingTime(at(6, 10), at(7, 12)), "the zero calendar counts wall-clock time")
+	assert.Equal(t, 8*time.Hour, calendar.WorkingTime(at(10, 0), at(11, 0)))
+	assert.Zero(t, calendar.WithHolidays(at(10, 0)).WorkingTime(at(10, 0), at(11, 0)), "a team holiday rests like the others")
+	assert.Len(t, calendar.Holidays, 1, "adding holidays leaves the calendar as it is")
+}
+
+func TestBusinessCalendar_WorkingTimeInTimeZone(t *testing.T) {
+	calendar, err := NewBusinessCalendar("09:00", "17:00", nil, nil, "Europe/Berlin")
+	require.NoError(t, err)
+
+	// 07:00 to 15:00 UTC is a whole workday in Berlin during summer time
+	start := time.Date(2024, time.July, 1, 7, 0, 0, 0, time.UTC)
+	assert.Equal(t, 8*time.Hour, calendar.WorkingTime(start, start.Add(8*time.Hour)))
+	// summer time ends on October 27, 2024; the workday still runs from 09:00 to 17:00
+	sunday := time.Date(2024, time.October, 27, 0, 0, 0, 0, calendar.Location)
+	assert.Equal(t, 8*time.Hour, calendar.WorkingTime(sunday, sunday.Add(24*time.Hour)))
+}
+
+func TestAbsenceCalendar_AwayWorking(t *testing.T) {
+	calendar, err := NewBusinessCalendar("09:00", "17:00", []string{"saturday", "sunday"}, nil, "")
+	require.NoError(t, err)
+	absences := AbsenceCalendar{"alice": {{From: absenceDay(time.May, 10), To: absenceDay(time.May, 13)}}}
+
+	// Friday to Monday away over a weekend is two workdays
+	away := absences.AwayWorking("alice", absenceDay(time.May, 6), absenceDay(time.May, 20), calendar)
+	assert.Equal(t, 16*time.Hour, away)
+	assert.Equal(t, 96*time.Hour, absences.Away("alice", absenceDay(time.May, 6), absenceDay(time.May, 20)))
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultLowConfidence is the confidence below which allocation rows are listed for review
+const DefaultLowConfidence = 70
+
+// ConfidenceFactor is a fallback, cap or heuristic that lowered the confidence of an allocation
+// row, named as shown to reviewers
+type ConfidenceFactor string
+
+// Factors lowering the confidence of allocation rows
+const (
+	// ConfidenceDefaultHours marks hours assumed for an issue without any tracked window
+	ConfidenceDefaultHours ConfidenceFactor = "default hours assumed"
+	// ConfidenceResolutionDate marks a window estimated from the resolution date without a changelog
+	ConfidenceResolutionDate ConfidenceFactor = "window from resolution date"
+	// ConfidenceChangelogStart marks a window started at the first changelog entry of an issue
+	// that never went In Progress
+	ConfidenceChangelogStart ConfidenceFactor = "start from first changelog entry"
+	// ConfidenceSameDayMinimum marks a same-day completion raised to the minimum hours
+	ConfidenceSameDayMinimum ConfidenceFactor = "raised to same-day minimum"
+	// ConfidenceStaleCapped marks a stale issue whose hours were capped
+	ConfidenceStaleCapped ConfidenceFactor = "stale hours capped"
+	// ConfidenceStale marks an issue In Progress for longer than the stale threshold
+	ConfidenceStale ConfidenceFactor = "stale window"
+	// ConfidenceSprintOverlap marks hours split with overlapping sprints
+	ConfidenceSprintOverlap ConfidenceFactor = "split with overlapping sprints"
+	// ConfidenceUnattributed marks an issue part of whose time no team member held
+	ConfidenceUnattributed ConfidenceFactor = "time held outside the team"
+)
+
+// confidencePenalties are the points each factor takes off a row's confidence
+var confidencePenalties = map[ConfidenceFactor]int{
+	ConfidenceDefaultHours:   50,
+	ConfidenceResolutionDate: 30,
+	ConfidenceChangelogStart: 25,
+	ConfidenceSameDayMinimum: 20,
+	ConfidenceStaleCapped:    30,
+	ConfidenceStale:          15,
+	ConfidenceSprintOverlap:  10,
+	ConfidenceUnattributed:   10,
+}
+
+// Penalty returns the points the factor takes off a row's confidence
+func (f ConfidenceFactor) Penalty() int {
+	return confidencePenalties[f]
+}
+
+// Confidence returns the confidence in the row's hours, from 100 for a row taken
+// from a clean changelog down to 0, lowered by each factor recorded on the row
+func (a IssueAllocation) Confidence() int {
+	score := 100
+	for _, factor := range a.ConfidenceFactors {
+		score -= factor.Penalty()
+	}
+	if score < 0 {
+		return 0
+	}
+	return score
+}
+
+// LowConfidenceThreshold returns the confidence below which rows are listed for review
+func (h AllocationHeuristics) LowConfidenceThreshold() int {
+	if h.LowConfidence > 0 {
+		return h.LowConfidence
+	}
+	return DefaultLowConfidence
+}
+
+// LowConfidenceRow records an allocation row whose confidence fell below the threshold
+type LowConfidenceRow struct {
+	IssueKey   string
+	Assignee   string
+	Confidence int
+	Factors    []ConfidenceFactor
+	Hours      float64
+}
+
+// NewLowConfidenceRow records the confidence of an allocation row
+func NewLowConfidenceRow(allocation IssueAllocation) LowConfidenceRow {
+	return LowConfidenceRow{
+		IssueKey:   allocation.IssueKey,
+		Assignee:   allocation.Assignee,
+		Confidence: allocation.Confidence(),
+		Factors:    allocation.ConfidenceFactors,
+		Hours:      allocation.Hours,
+	}
+}
+
+// Reason describes the row's confidence and what lowered it, e.g.
+// "alice: confidence 50 (default hours assumed)"
+func (r LowConfidenceRow) Reason() string {
+	factors := make([]string, len(r.Factors))
+	for i, factor := range r.Factors {
+		factors[i] = string(factor)
+	}
+	return fmt.Sprintf("%s: confidence %d (%s)", r.Assignee, r.Confidence, strings.Join(factors, "; "))
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssueAllocation_Confidence(t *testing.T) {
+	assert.Equal(t, 100, IssueAllocation{}.Confidence(), "a row from a clean changelog")
+	assert.Equal(t, 40, IssueAllocation{ConfidenceFactors: []ConfidenceFactor{ConfidenceDefaultHours, ConfidenceSprintOverlap}}.Confidence())
+	assert.Equal(t, 0, IssueAllocation{ConfidenceFactors: []ConfidenceFactor{ConfidenceDefaultHours, ConfidenceResolutionDate, ConfidenceStaleCapped}}.Confidence(), "never below zero")
+}
+
+func TestAllocationHeuristics_LowConfidenceThreshold(t *testing.T) {
+	assert.Equal(t, DefaultLowConfidence, AllocationHeuristics{}.LowConfidenceThreshold())
+	assert.Equal(t, 90, AllocationHeuristics{LowConfidence: 90}.LowConfidenceThreshold())
+}
+
+func TestLowConfidenceRow_Reason(t *testing.T) {
+	row := NewLowConfidenceRow(IssueAllocation{
+		IssueKey:          "TEST-1",
+		Assignee:          "alice",
+		Hours:             1,
+		ConfidenceFactors: []ConfidenceFactor{ConfidenceSameDayMinimum, ConfidenceUnattributed},
+	})
+
+	assert.Equal(t, 70, row.Confidence)
+	assert.Equal(t, "alice: confidence 70 (raised to same-day minimum; time held outside the team)", row.Reason())
+}
@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ERPFormat is the file format of an ERP journal export
+type ERPFormat string
+
+const (
+	// ERPFormatCSV writes one delimited line per journal line, after a header
+	ERPFormatCSV ERPFormat = "csv"
+	// ERPFormatFixedWidth writes each field padded to its column width, without a header, as
+	// flat-file imports such as SAP's expect
+	ERPFormatFixedWidth ERPFormat = "fixed-width"
+)
+
+// Fields of an ERP journal line
+const (
+	ERPFieldPeriod     = "period"
+	ERPFieldTeam       = "team"
+	ERPFieldCostCenter = "costCenter"
+	ERPFieldGLAccount  = "glAccount"
+	ERPFieldAsset      = "assetName"
+	ERPFieldHours      = "hours"
+	ERPFieldAmount     = "amount"
+)
+
+// ERPFields lists the fields an ERP layout can export
+var ERPFields = []string{ERPFieldPeriod, ERPFieldTeam, ERPFieldCostCenter, ERPFieldGLAccount, ERPFieldAsset, ERPFieldHours, ERPFieldAmount}
+
+// ERPColumn is a field of the ERP export and, for fixed-width files, its width in characters
+type ERPColumn struct {
+	Field string
+	Width int
+}
+
+// ERPLayout describes the file an ERP imports the capitalized amounts from
+type ERPLayout struct {
+	Format ERPFormat
+	// Delimiter separates the fields of the csv format
+	Delimiter rune
+	Columns   []ERPColumn
+}
+
+// DefaultERPLayout returns the csv layout used when none is configured
+func DefaultERPLayout() ERPLayout {
+	return ERPLayout{
+		Format:    ERPFormatCSV,
+		Delimiter: ',',
+		Columns: []ERPColumn{
+			{Field: ERPFieldPeriod, Width: 12},
+			{Field: ERPFieldCostCenter, Width: 10},
+			{Field: ERPFieldGLAccount, Width: 10},
+			{Field: ERPFieldAsset, Width: 30},
+			{Field: ERPFieldHours, Width: 10},
+			{Field: ERPFieldAmount, Width: 15},
+		},
+	}
+}
+
+// Validate checks that the layout has a known format and known fields, each with a width in
+// the fixed-width format
+func (l ERPLayout) Validate() error {
+	if l.Format != ERPFormatCSV && l.Format != ERPFormatFixedWidth {
+		return fmt.Errorf("unsupported ERP format %q: use %s or %s", l.Format, ERPFormatCSV, ERPFormatFixedWidth)
+	}
+	if len(l.Columns) == 0 {
+		return fmt.Errorf("ERP layout needs at least one column")
+	}
+	for _, column := range l.Columns {
+		if !isERPField(column.Field) {
+			return fmt.Errorf("unknown ERP field %q: use one of %s", column.Field, strings.Join(ERPFields, ", "))
+		}
+		if column.Width < 0 {
+			return fmt.Errorf("ERP field %s has a negative width", column.Field)
+		}
+		if l.Format == ERPFormatFixedWidth && column.Width == 0 {
+			return fmt.Errorf("ERP field %s needs a width in the fixed-width format", column.Field)
+		}
+	}
+	return nil
+}
+
+func isERPField(field string) bool {
+	for _, known := range ERPFields {
+		if known == field {
+			return true
+		}
+	}
+	return false
+}
+
+// ERPJournalLine is the capitalized work of a team on an asset in a period, booked to the
+// asset's cost center and general ledger account
+type ERPJournalLine struct {
+	Period     string
+	Team       string
+	CostCenter string
+	GLAccount  string
+	AssetName  string
+	Hours      float64
+	Amount     float64
+}
+
+// ERPJournal sums the capitalized hours of the report per team, cost center, GL account and
+// asset, valuing them at the hourly rate. Lines without capitalized hours are left out.
+func ERPJournal(report CapitalizationReport, rate float64) []ERPJournalLine {
+	type key struct{ team, costCenter, glAccount, asset string }
+	groups := make(map[key][]IssueAllocation)
+	var keys []key
+	for _, row := range report.Rows {
+		k := key{row.Team, row.CostCenter, row.GLAccount, row.AssetName}
+		if _, ok := groups[k]; !ok {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], row.IssueAllocation)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.team != b.team {
+			return a.team < b.team
+		}
+		if a.costCenter != b.costCenter {
+			return a.costCenter < b.costCenter
+		}
+		if a.glAccount != b.glAccount {
+			return a.glAccount < b.glAccount
+		}
+		return a.asset < b.asset
+	})
+
+	var lines []ERPJournalLine
+	for _, k := range keys {
+		hours := SummarizeAllocations(groups[k]).CapitalizedHours()
+		if hours <= 0 {
+			continue
+		}
+		lines = append(lines, ERPJournalLine{
+			Period:     report.KPIs.Period,
+			Team:       k.team,
+			CostCenter: k.costCenter,
+			GLAccount:  k.glAccount,
+			AssetName:  k.asset,
+			Hours:      hours,
+			Amount:     hours * rate,
+		})
+	}
+	return lines
+}
+
+// UnbookedAssets returns the assets of journal lines missing a cost center or GL account, sorted
+func UnbookedAssets(lines []ERPJournalLine) []string {
+	seen := make(map[string]bool)
+	var assets []string
+	for _, line := range lines {
+		if (AssetAccount{CostCenter: line.CostCenter, GLAccount: line.GLAccount}).Complete() || seen[line.AssetName] {
+			continue
+		}
+		seen[line.AssetName] = true
+		assets = append(assets, line.AssetName)
+	}
+	sort.Strings(assets)
+	return assets
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestERPLayout_Validate(t *testing.T) {
+	assert.NoError(t, DefaultERPLayout().Validate())
+
+	tests := []struct {
+		name   string
+		layout ERPLayout
+		want   string
+	}{
+		{"unknown format", ERPLayout{Format: "xml", Columns: []ERPColumn{{Field: ERPFieldAmount}}}, `unsupported ERP format "xml": use csv or fixed-width`},
+		{"no columns", ERPLayout{Format: ERPFormatCSV}, "ERP layout needs at least one column"},
+		{"unknown field", ERPLayout{Format: ERPFormatCSV, Columns: []ERPColumn{{Field: "vendor"}}}, `unknown ERP field "vendor": use one of period, team, costCenter, glAccount, assetName, hours, amount`},
+		{"negative width", ERPLayout{Format: ERPFormatCSV, Columns: []ERPColumn{{Field: ERPFieldAmount, Width: -1}}}, "ERP field amount has a negative width"},
+		{"fixed width without width", ERPLayout{Format: ERPFormatFixedWidth, Columns: []ERPColumn{{Field: ERPFieldAmount}}}, "ERP field amount needs a width in the fixed-width format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.layout.Validate(), tt.want)
+		})
+	}
+}
+
+func TestERPJournal(t *testing.T) {
+	report := CapitalizationReport{
+		KPIs: CapitalizationKPIs{Period: "Sprint 2"},
+		Rows: []ReportRow{
+			{Team: "TEAMA", IssueAllocation: IssueAllocation{AssetName: "cap-asset-checkout", WorkType: WorkTypeDevelopment, Hours: 20, CostCenter: "CC-100", GLAccount: "1710"}},
+			{Team: "TEAMA", IssueAllocation: IssueAllocation{AssetName: "cap-asset-checkout", WorkType: WorkTypeDevelopment, Hours: 10, CostCenter: "CC-100", GLAccount: "1710"}},
+			{Team: "TEAMA", IssueAllocation: IssueAllocation{AssetName: "cap-asset-checkout", WorkType: WorkTypeMaintenance, Hours: 5, CostCenter: "CC-100", GLAccount: "1710"}},
+			{Team: "TEAMA", IssueAllocation: IssueAllocation{AssetName: "cap-asset-legacy", WorkType: WorkTypeMaintenance, Hours: 8}},
+			{Team: "TEAMB", IssueAllocation: IssueAllocation{AssetName: "cap-asset-search", WorkType: WorkTypeDevelopment, Hours: 4, CostCenter: "CC-200"}},
+		},
+	}
+
+	lines := ERPJournal(report, 75)
+	assert.Equal(t, []ERPJournalLine{
+		{Period: "Sprint 2", Team: "TEAMA", CostCenter: "CC-100", GLAccount: "1710", AssetName: "cap-asset-checkout", Hours: 30, Amount: 2250},
+		{Period: "Sprint 2", Team: "TEAMB", CostCenter: "CC-200", AssetName: "cap-asset-search", Hours: 4, Amount: 300},
+	}, lines)
+	assert.Equal(t, []string{"cap-asset-search"}, UnbookedAssets(lines))
+}
@@ -0,0 +1,264 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// DefaultEstimateTolerance is the share of its estimate an issue's hours may differ by and
+// still count as accurately estimated
+const DefaultEstimateTolerance = 0.2
+
+// MinSystematicIssues is the number of estimated issues a person needs before a bias in
+// their estimates counts as systematic
+const MinSystematicIssues = 3
+
+// systematicShare is the share of a person's estimated issues that must miss their estimate in
+// the same direction for the bias to count as systematic
+const systematicShare = 2.0 / 3
+
+// EstimateVerdict tells how the hours worked compare with the estimate
+type EstimateVerdict string
+
+const (
+	// EstimateAccurate is work within the tolerance of its estimate
+	EstimateAccurate EstimateVerdict = "accurate"
+	// EstimateOver is work that took less than estimated
+	EstimateOver EstimateVerdict = "overestimated"
+	// EstimateUnder is work that took more than estimated
+	EstimateUnder EstimateVerdict = "underestimated"
+	// EstimateMissing is work without an original estimate
+	EstimateMissing EstimateVerdict = "unestimated"
+)
+
+// EstimateComparisonInput represents the input parameters for comparing allocated hours with
+// the Jira original estimates
+type EstimateComparisonInput struct {
+	Project   string
+	Sprint    string
+	Override  string
+	Delimiter rune
+	// LabelsAsOf classifies issues by their labels at a past point in time
+	LabelsAsOf LabelSnapshot
+	// Locale formats the numbers of the export
+	Locale Locale
+	// Heuristics fills in untracked hours, as for AllocationInput
+	Heuristics AllocationHeuristics
+	// Tolerance is the share of the estimate hours may differ by and still be accurate; zero
+	// uses DefaultEstimateTolerance
+	Tolerance float64
+	// Export, when set, is a Jira JSON or CSV export allocated instead of querying Jira
+	Export string
+}
+
+// EstimateHours converts a Jira time tracking estimate in seconds to hours
+func EstimateHours(seconds *int64) *float64 {
+	if seconds == nil {
+		return nil
+	}
+	hours := float64(*seconds) / 3600
+	return &hours
+}
+
+// IssueEstimate compares the hours allocated to an issue with its estimates
+type IssueEstimate struct {
+	IssueKey   string
+	IssueTitle string
+	Status     string
+	// Assignees are the people the issue's hours are allocated to
+	Assignees []string
+	Done      bool
+	// Hours are the hours allocated to the issue across its assignees
+	Hours             float64
+	OriginalEstimate  *float64
+	RemainingEstimate *float64
+	Verdict           EstimateVerdict
+}
+
+// ProjectedHours returns the hours the issue is expected to take: the allocated hours, plus
+// the remaining estimate while the issue is not done
+func (e IssueEstimate) ProjectedHours() float64 {
+	if e.Done || e.RemainingEstimate == nil {
+		return roundHours(e.Hours)
+	}
+	return roundHours(e.Hours + *e.RemainingEstimate)
+}
+
+// Variance returns the projected hours minus the original estimate; positive when the work
+// took more than estimated and zero without an estimate
+func (e IssueEstimate) Variance() float64 {
+	if !e.Estimated() {
+		return 0
+	}
+	return roundHours(e.ProjectedHours() - *e.OriginalEstimate)
+}
+
+// VariancePercentage returns the variance as a percentage of the original estimate
+func (e IssueEstimate) VariancePercentage() float64 {
+	if !e.Estimated() {
+		return 0
+	}
+	return e.Variance() / *e.OriginalEstimate * 100
+}
+
+// Estimated reports whether the issue has an original estimate to compare with
+func (e IssueEstimate) Estimated() bool {
+	return e.OriginalEstimate != nil && *e.OriginalEstimate > 0
+}
+
+// PersonEstimate compares a person's hours on estimated issues with their share of the
+// estimates. Each issue's estimate is shared among its assignees by their allocated hours.
+type PersonEstimate struct {
+	Person string
+	// EstimatedHours is the person's share of the original estimates of their issues
+	EstimatedHours float64
+	// ProjectedHours is the person's share of the projected hours of their estimated issues
+	ProjectedHours float64
+	// UnestimatedHours are the hours allocated to the person on issues without an estimate
+	UnestimatedHours  float64
+	EstimatedIssues   int
+	UnestimatedIssues int
+	// Overestimated and Underestimated count the person's issues missing their estimate
+	Overestimated  int
+	Underestimated int
+	Verdict        EstimateVerdict
+	// Systematic marks a bias shared by most of the person's estimated issues rather than
+	// caused by a few outliers
+	Systematic bool
+}
+
+// Variance returns the person's projected hours minus their estimated hours
+func (p PersonEstimate) Variance() float64 {
+	return roundHours(p.ProjectedHours - p.EstimatedHours)
+}
+
+// VariancePercentage returns the variance as a percentage of the estimated hours
+func (p PersonEstimate) VariancePercentage() float64 {
+	if p.EstimatedHours == 0 {
+		return 0
+	}
+	return p.Variance() / p.EstimatedHours * 100
+}
+
+// EstimateComparison compares the allocated hours with the estimates per issue and per person
+type EstimateComparison struct {
+	Issues []IssueEstimate
+	People []PersonEstimate
+}
+
+// CompareEstimates compares the allocation rows with the original estimates of their issues.
+// Hours within tolerance of the estimate are accurate; a zero tolerance uses
+// DefaultEstimateTolerance.
+func CompareEstimates(allocations []IssueAllocation, tolerance float64) EstimateComparison {
+	if tolerance <= 0 {
+		tolerance = DefaultEstimateTolerance
+	}
+
+	var comparison EstimateComparison
+	byKey := make(map[string]int)
+	for _, allocation := range allocations {
+		i, ok := byKey[allocation.IssueKey]
+		if !ok {
+			i = len(comparison.Issues)
+			byKey[allocation.IssueKey] = i
+			comparison.Issues = append(comparison.Issues, IssueEstimate{
+				IssueKey:          allocation.IssueKey,
+				IssueTitle:        allocation.IssueTitle,
+				Status:            allocation.Status,
+				Done:              allocation.IsDone(),
+				OriginalEstimate:  allocation.OriginalEstimate,
+				RemainingEstimate: allocation.RemainingEstimate,
+			})
+		}
+		issue := &comparison.Issues[i]
+		issue.Hours += allocation.Hours
+		if !containsFold(issue.Assignees, allocation.Assignee) {
+			issue.Assignees = append(issue.Assignees, allocation.Assignee)
+		}
+	}
+	for i := range comparison.Issues {
+		issue := &comparison.Issues[i]
+		issue.Hours = roundHours(issue.Hours)
+		issue.Verdict = EstimateMissing
+		if issue.Estimated() {
+			issue.Verdict = estimateVerdict(issue.ProjectedHours(), *issue.OriginalEstimate, tolerance)
+		}
+	}
+
+	people := make(map[string]*PersonEstimate)
+	counted := make(map[string]bool)
+	for _, allocation := range allocations {
+		key := strings.ToLower(allocation.Assignee)
+		person, ok := people[key]
+		if !ok {
+			person = &PersonEstimate{Person: allocation.Assignee}
+			people[key] = person
+		}
+
+		issue := comparison.Issues[byKey[allocation.IssueKey]]
+		first := !counted[key+"\x00"+issue.IssueKey]
+		counted[key+"\x00"+issue.IssueKey] = true
+		if !issue.Estimated() {
+			person.UnestimatedHours += allocation.Hours
+			if first {
+				person.UnestimatedIssues++
+			}
+			continue
+		}
+
+		share := 1 / float64(len(issue.Assignees))
+		if issue.Hours > 0 {
+			share = allocation.Hours / issue.Hours
+		}
+		person.EstimatedHours += *issue.OriginalEstimate * share
+		person.ProjectedHours += issue.ProjectedHours() * share
+		if !first {
+			continue
+		}
+		person.EstimatedIssues++
+		switch issue.Verdict {
+		case EstimateOver:
+			person.Overestimated++
+		case EstimateUnder:
+			person.Underestimated++
+		}
+	}
+
+	for _, person := range people {
+		person.EstimatedHours = roundHours(person.EstimatedHours)
+		person.ProjectedHours = roundHours(person.ProjectedHours)
+		person.UnestimatedHours = roundHours(person.UnestimatedHours)
+		person.Verdict = EstimateMissing
+		if person.EstimatedHours > 0 {
+			person.Verdict = estimateVerdict(person.ProjectedHours, person.EstimatedHours, tolerance)
+		}
+		missed := 0
+		switch person.Verdict {
+		case EstimateOver:
+			missed = person.Overestimated
+		case EstimateUnder:
+			missed = person.Underestimated
+		}
+		person.Systematic = person.EstimatedIssues >= MinSystematicIssues &&
+			float64(missed) >= systematicShare*float64(person.EstimatedIssues)
+		comparison.People = append(comparison.People, *person)
+	}
+	sort.Slice(comparison.People, func(i, j int) bool {
+		return comparison.People[i].Person < comparison.People[j].Person
+	})
+	return comparison
+}
+
+// estimateVerdict compares hours with an estimate, allowing for the tolerance
+func estimateVerdict(hours, estimate, tolerance float64) EstimateVerdict {
+	difference := hours - estimate
+	switch {
+	case math.Abs(difference) <= estimate*tolerance:
+		return EstimateAccurate
+	case difference > 0:
+		return EstimateUnder
+	default:
+		return EstimateOver
+	}
+}
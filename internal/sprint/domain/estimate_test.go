@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func hours(h float64) *float64 {
+	return &h
+}
+
+func TestEstimateHours(t *testing.T) {
+	seconds := int64(5400)
+	assert.Equal(t, 1.5, *EstimateHours(&seconds))
+	assert.Nil(t, EstimateHours(nil))
+}
+
+func TestCompareEstimates(t *testing.T) {
+	done := StatusCategoryDone
+	allocations := []IssueAllocation{
+		// Shared between alice and bob by their hours: 9 of 8 estimated hours is accurate
+		{IssueKey: "FN-1", IssueTitle: "Checkout", Assignee: "alice", Status: "Done", StatusCategory: done, Hours: 6, OriginalEstimate: hours(8)},
+		{IssueKey: "FN-1", IssueTitle: "Checkout", Assignee: "bob", Status: "Done", StatusCategory: done, Hours: 3, OriginalEstimate: hours(8)},
+		// In progress: 4 hours worked and 6 remaining against 5 estimated
+		{IssueKey: "FN-2", IssueTitle: "Search", Assignee: "alice", Status: "In Progress", Hours: 4, OriginalEstimate: hours(5), RemainingEstimate: hours(6)},
+		{IssueKey: "FN-3", IssueTitle: "Bugfix", Assignee: "bob", Status: "Done", StatusCategory: done, Hours: 2, OriginalEstimate: hours(8), RemainingEstimate: hours(1)},
+		{IssueKey: "FN-4", IssueTitle: "Spike", Assignee: "bob", Status: "Done", StatusCategory: done, Hours: 5},
+	}
+
+	comparison := CompareEstimates(allocations, 0)
+
+	require.Len(t, comparison.Issues, 4)
+	checkout := comparison.Issues[0]
+	assert.Equal(t, []string{"alice", "bob"}, checkout.Assignees)
+	assert.Equal(t, 9.0, checkout.Hours)
+	assert.Equal(t, 1.0, checkout.Variance())
+	assert.Equal(t, 12.5, checkout.VariancePercentage())
+	assert.Equal(t, EstimateAccurate, checkout.Verdict)
+
+	search := comparison.Issues[1]
+	assert.Equal(t, 10.0, search.ProjectedHours(), "the remaining estimate counts while the issue is open")
+	assert.Equal(t, EstimateUnder, search.Verdict)
+
+	bugfix := comparison.Issues[2]
+	assert.Equal(t, 2.0, bugfix.ProjectedHours(), "the remaining estimate of a done issue is ignored")
+	assert.Equal(t, -6.0, bugfix.Variance())
+	assert.Equal(t, EstimateOver, bugfix.Verdict)
+
+	assert.Equal(t, EstimateMissing, comparison.Issues[3].Verdict)
+	assert.Zero(t, comparison.Issues[3].Variance())
+
+	require.Len(t, comparison.People, 2)
+	alice := comparison.People[0]
+	assert.Equal(t, "alice", alice.Person)
+	assert.InDelta(t, 10.33, alice.EstimatedHours, 0.01)
+	assert.Equal(t, 16.0, alice.ProjectedHours)
+	assert.Equal(t, 2, alice.EstimatedIssues)
+	assert.Equal(t, 1, alice.Underestimated)
+	assert.Equal(t, EstimateUnder, alice.Verdict)
+	assert.False(t, alice.Systematic, "two issues are too few for a systematic bias")
+
+	bob := comparison.People[1]
+	assert.InDelta(t, 10.67, bob.EstimatedHours, 0.01)
+	assert.Equal(t, 5.0, bob.ProjectedHours)
+	assert.Equal(t, 5.0, bob.UnestimatedHours)
+	assert.Equal(t, 1, bob.UnestimatedIssues)
+	assert.Equal(t, EstimateOver, bob.Verdict)
+}
+
+func TestCompareEstimates_SystematicBias(t *testing.T) {
+	var allocations []IssueAllocation
+	for _, key := range []string{"FN-1", "FN-2", "FN-3"} {
+		allocations = append(allocations, IssueAllocation{IssueKey: key, Assignee: "alice", StatusCategory: StatusCategoryDone, Hours: 12, OriginalEstimate: hours(8)})
+	}
+	allocations = append(allocations, IssueAllocation{IssueKey: "FN-4", Assignee: "alice", StatusCategory: StatusCategoryDone, Hours: 8, OriginalEstimate: hours(8)})
+
+	comparison := CompareEstimates(allocations, 0.1)
+
+	require.Len(t, comparison.People, 1)
+	alice := comparison.People[0]
+	assert.Equal(t, 4, alice.EstimatedIssues)
+	assert.Equal(t, 3, alice.Underestimated)
+	assert.Equal(t, EstimateUnder, alice.Verdict)
+	assert.True(t, alice.Systematic)
+	assert.Equal(t, 12.0, alice.Variance())
+	assert.Equal(t, 37.5, alice.VariancePercentage())
+}
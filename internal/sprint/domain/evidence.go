@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"net/url"
+	"strings"
+)
+
+// AssetDocLinks maps asset names or labels to the Confluence pages documenting them
+type AssetDocLinks map[string]string
+
+// For returns the documentation page of the asset an allocation label refers to, or an
+// empty string when the asset has none
+func (l AssetDocLinks) For(assetName string) string {
+	if link, ok := l[assetName]; ok {
+		return link
+	}
+	for name, link := range l {
+		if matchesAsset(assetName, name) {
+			return link
+		}
+	}
+	return ""
+}
+
+// IssueURL returns the browse link of a Jira issue, or an empty string without a base URL
+func IssueURL(baseURL, issueKey string) string {
+	if baseURL == "" || issueKey == "" {
+		return ""
+	}
+	return strings.TrimRight(baseURL, "/") + "/browse/" + url.PathEscape(issueKey)
+}
@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssetDocLinks_For(t *testing.T) {
+	links := AssetDocLinks{"Booking": "https://wiki/booking"}
+
+	assert.Equal(t, "https://wiki/booking", links.For("Booking"))
+	assert.Equal(t, "https://wiki/booking", links.For("cap-asset-booking"))
+	assert.Empty(t, links.For("cap-asset-search"))
+	assert.Empty(t, links.For(""))
+	assert.Empty(t, AssetDocLinks(nil).For("booking"))
+}
+
+func TestIssueURL(t *testing.T) {
+	assert.Equal(t, "https://example.atlassian.net/browse/FN-1", IssueURL("https://example.atlassian.net/", "FN-1"))
+	assert.Empty(t, IssueURL("", "FN-1"))
+	assert.Empty(t, IssueURL("https://example.atlassian.net", ""))
+}
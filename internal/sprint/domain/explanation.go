@@ -0,0 +1,80 @@
+package domain
+
+import "time"
+
+// ExplanationInput represents the input parameters for explaining one issue's allocation
+type ExplanationInput struct {
+	Project string
+	Sprint  string
+	// FixVersion explains the allocation of a release instead of a sprint
+	FixVersion string
+	IssueKey   string
+	Override   string
+	// Method and PointsAt select the allocation method, as for AllocationInput
+	Method   AllocationMethod
+	PointsAt PointsAt
+	// LabelsAsOf classifies issues by their labels at a past point in time
+	LabelsAsOf LabelSnapshot
+	// Heuristics fills in untracked hours, as for AllocationInput
+	Heuristics AllocationHeuristics
+	// Export, when set, is a Jira JSON or CSV export allocated instead of querying Jira
+	Export string
+}
+
+// TimelineEvent is a change from an issue's changelog and how the allocation used it
+type TimelineEvent struct {
+	At    time.Time `json:"at"`
+	Field string    `json:"field"`
+	From  string    `json:"from"`
+	To    string    `json:"to"`
+	// FromCategory and ToCategory are the status categories of a status change
+	FromCategory StatusCategory `json:"-"`
+	ToCategory   StatusCategory `json:"-"`
+	// Counted marks the changes that shaped the allocated hours; Note explains the effect
+	Counted bool   `json:"counted"`
+	Note    string `json:"note,omitempty"`
+}
+
+// ExplainedShare is the part of an issue's allocation credited to one team member
+type ExplainedShare struct {
+	Assignee   string  `json:"assignee"`
+	Hours      float64 `json:"hours"`
+	Percentage float64 `json:"percentage"`
+	// Formula lists the calculation of the hours and percentage step by step
+	Formula []string `json:"formula"`
+}
+
+// AllocationExplanation traces how the allocation of a single issue was computed
+type AllocationExplanation struct {
+	IssueKey  string           `json:"issueKey"`
+	Summary   string           `json:"summary"`
+	IssueType string           `json:"issueType"`
+	Status    string           `json:"status"`
+	Assignee  string           `json:"assignee"`
+	Period    string           `json:"period"`
+	Method    AllocationMethod `json:"method"`
+	Timeline  []TimelineEvent  `json:"timeline"`
+	// Steps describe the window, caps, overrides and splits applied, in order
+	Steps  []string         `json:"steps"`
+	Shares []ExplainedShare `json:"shares"`
+}
+
+// Timeline returns the issue's changelog as one event per changed field in chronological
+// order, skipping entries with an unreadable timestamp
+func (i *JiraIssue) Timeline() []TimelineEvent {
+	var events []TimelineEvent
+	for _, history := range i.Changelog.Histories {
+		at, err := ParseJiraTime(history.Created)
+		if err != nil {
+			continue
+		}
+		for _, item := range history.Items {
+			event := TimelineEvent{At: at, Field: item.Field, From: item.FromString, To: item.ToString}
+			if item.IsStatusChange() {
+				event.FromCategory, event.ToCategory = item.FromStatusCategory(), item.ToStatusCategory()
+			}
+			events = append(events, event)
+		}
+	}
+	return events
+}
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJiraIssue_Timeline(t *testing.T) {
+	issue := JiraIssue{Changelog: JiraChangelog{Histories: []JiraChangeHistory{
+		{Created: "2024-05-01T09:00:00.000+0200", Items: []JiraChangeItem{
+			{Field: "status", FromString: "To Do", ToString: "In Progress"},
+			{Field: "assignee", FromString: "", ToString: "Jane Doe"},
+		}},
+		{Created: "not a date", Items: []JiraChangeItem{{Field: "labels", ToString: "cap-development"}}},
+		{Created: "2024-05-02T10:00:00Z", Items: []JiraChangeItem{{Field: "status", FromString: "In Progress", ToString: "Done"}}},
+		{Created: "2024-05-03T10:00:00Z", Items: []JiraChangeItem{{Field: "status", FromString: "Fertig", ToString: "In Arbeit", FromCategory: StatusCategoryDone, ToCategory: StatusCategoryInProgress}}},
+	}}}
+
+	assert.Equal(t, []TimelineEvent{
+		{At: time.Date(2024, 5, 1, 7, 0, 0, 0, time.UTC), Field: "status", From: "To Do", To: "In Progress", FromCategory: StatusCategoryToDo, ToCategory: StatusCategoryInProgress},
+		{At: time.Date(2024, 5, 1, 7, 0, 0, 0, time.UTC), Field: "assignee", From: "", To: "Jane Doe"},
+		{At: time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC), Field: "status", From: "In Progress", To: "Done", FromCategory: StatusCategoryInProgress, ToCategory: StatusCategoryDone},
+		{At: time.Date(2024, 5, 3, 10, 0, 0, 0, time.UTC), Field: "status", From: "Fertig", To: "In Arbeit", FromCategory: StatusCategoryDone, ToCategory: StatusCategoryInProgress},
+	}, issue.Timeline())
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Defaults of the allocation heuristics
+const (
+	DefaultUntrackedHours      = 8.0
+	DefaultSameDayMinimumHours = 1.0
+)
+
+// Names of the allocation heuristics, as reported in warnings
+const (
+	HeuristicDefaultHours   = "default-hours"
+	HeuristicSameDayMinimum = "same-day-minimum"
+	// HeuristicResolutionDate estimates the In Progress window of an issue without a changelog
+	// from its creation, or the sprint start when later, to its resolution
+	HeuristicResolutionDate = "resolution-date"
+)
+
+// AllocationHeuristics configures the rules that fill in hours the Jira changelog does not
+// track. The zero value applies both heuristics with their default hours.
+type AllocationHeuristics struct {
+	// DefaultHours is credited to issues without an In Progress transition or changelog;
+	// zero means DefaultUntrackedHours
+	DefaultHours float64
+	// DisableDefaultHours leaves such issues unallocated instead
+	DisableDefaultHours bool
+	// SameDayMinimum is the least credited to an issue completed the day it started;
+	// zero means DefaultSameDayMinimumHours
+	SameDayMinimum float64
+	// DisableSameDayMinimum credits same-day completions their tracked hours only
+	DisableSameDayMinimum bool
+	// StaleAfterDays flags the issues In Progress for longer as needing review; zero disables
+	// the watchdog
+	StaleAfterDays int
+	// StaleCapHours caps the hours credited for a flagged issue until it is overridden
+	// manually; zero keeps its tracked hours
+	StaleCapHours float64
+	// LowConfidence lists the allocation rows with a lower confidence for review; zero means
+	// DefaultLowConfidence
+	LowConfidence int
+	// Calendar counts the working hours of tracked windows; the zero calendar counts
+	// wall-clock hours
+	Calendar BusinessCalendar
+}
+
+// UntrackedHours returns the hours credited to an issue without a tracked window, and false
+// when the heuristic is disabled
+func (h AllocationHeuristics) UntrackedHours() (float64, bool) {
+	if h.DisableDefaultHours {
+		return 0, false
+	}
+	if h.DefaultHours > 0 {
+		return h.DefaultHours, true
+	}
+	return DefaultUntrackedHours, true
+}
+
+// MinimumSameDayHours returns the least credited to a same-day completion, and false when
+// the heuristic is disabled
+func (h AllocationHeuristics) MinimumSameDayHours() (float64, bool) {
+	if h.DisableSameDayMinimum {
+		return 0, false
+	}
+	if h.SameDayMinimum > 0 {
+		return h.SameDayMinimum, true
+	}
+	return DefaultSameDayMinimumHours, true
+}
+
+// AppliedHeuristic records a heuristic that set the hours of an issue instead of its changelog
+type AppliedHeuristic struct {
+	IssueKey  string
+	Heuristic string
+	Hours     float64
+}
+
+// Reason describes the heuristic and its effect on the issue
+func (a AppliedHeuristic) Reason() string {
+	hours := strconv.FormatFloat(a.Hours, 'f', -1, 64)
+	switch a.Heuristic {
+	case HeuristicDefaultHours:
+		return fmt.Sprintf("no In Progress transition: default %s h window assumed", hours)
+	case HeuristicSameDayMinimum:
+		return fmt.Sprintf("completed the same day: raised to the %s h minimum", hours)
+	case HeuristicResolutionDate:
+		return fmt.Sprintf("changelog unavailable: %s h from start to resolution date assumed", hours)
+	}
+	return a.Heuristic
+}
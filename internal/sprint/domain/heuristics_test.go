@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllocationHeuristics(t *testing.T) {
+	tests := []struct {
+		name        string
+		heuristics  AllocationHeuristics
+		wantDefault float64
+		wantMinimum float64
+		wantEnabled bool
+	}{
+		{"zero value uses defaults", AllocationHeuristics{}, DefaultUntrackedHours, DefaultSameDayMinimumHours, true},
+		{"configured hours", AllocationHeuristics{DefaultHours: 4, SameDayMinimum: 0.5}, 4, 0.5, true},
+		{"disabled", AllocationHeuristics{DefaultHours: 4, DisableDefaultHours: true, DisableSameDayMinimum: true}, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hours, enabled := tt.heuristics.UntrackedHours()
+			assert.Equal(t, tt.wantDefault, hours)
+			assert.Equal(t, tt.wantEnabled, enabled)
+
+			minimum, enabled := tt.heuristics.MinimumSameDayHours()
+			assert.Equal(t, tt.wantMinimum, minimum)
+			assert.Equal(t, tt.wantEnabled, enabled)
+		})
+	}
+}
+
+func TestAppliedHeuristic_Reason(t *testing.T) {
+	assert.Equal(t, "no In Progress transition: default 8 h window assumed", AppliedHeuristic{Heuristic: HeuristicDefaultHours, Hours: 8}.Reason())
+	assert.Equal(t, "completed the same day: raised to the 0.5 h minimum", AppliedHeuristic{Heuristic: HeuristicSameDayMinimum, Hours: 0.5}.Reason())
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// assetLabelPrefix is the Jira label prefix linking an issue to an asset
+const assetLabelPrefix = "cap-asset-"
+
+// AssetImpairment is a write-down of a capitalized asset effective from a date
+type AssetImpairment struct {
+	AssetName string
+	Date      time.Time
+	Reason    string
+	Amount    float64
+}
+
+// Affects reports whether the impairment applies to the allocation. Work on the
+// impaired asset is affected when it was still in progress on or after the impairment date.
+func (i AssetImpairment) Affects(allocation IssueAllocation) bool {
+	if !matchesAsset(allocation.AssetName, i.AssetName) {
+		return false
+	}
+	return allocation.DateCompleted.IsZero() || !allocation.DateCompleted.Before(i.Date)
+}
+
+// matchesAsset compares an allocation asset label with an asset name, with or without the label prefix
+func matchesAsset(label, name string) bool {
+	if label == "" || name == "" {
+		return false
+	}
+	label = strings.TrimPrefix(strings.ToLower(label), assetLabelPrefix)
+	name = strings.TrimPrefix(strings.ToLower(name), assetLabelPrefix)
+	return label == name
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssetImpairment_Affects(t *testing.T) {
+	impairment := AssetImpairment{AssetName: "booking", Date: time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name       string
+		allocation IssueAllocation
+		want       bool
+	}{
+		{
+			name:       "completed after impairment",
+			allocation: IssueAllocation{AssetName: "cap-asset-booking", DateCompleted: time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC)},
+			want:       true,
+		},
+		{
+			name:       "completed on impairment date",
+			allocation: IssueAllocation{AssetName: "cap-asset-booking", DateCompleted: time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)},
+			want:       true,
+		},
+		{
+			name:       "still in progress",
+			allocation: IssueAllocation{AssetName: "cap-asset-Booking"},
+			want:       true,
+		},
+		{
+			name:       "completed before impairment",
+			allocation: IssueAllocation{AssetName: "cap-asset-booking", DateCompleted: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+			want:       false,
+		},
+		{
+			name:       "other asset",
+			allocation: IssueAllocation{AssetName: "cap-asset-payments"},
+			want:       false,
+		},
+		{
+			name:       "no asset",
+			allocation: IssueAllocation{},
+			want:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, impairment.Affects(tt.allocation))
+		})
+	}
+}
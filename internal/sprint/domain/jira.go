@@ -6,14 +6,22 @@ import (
 
 // JiraAssignee represents a Jira issue assignee
 type JiraAssignee struct {
+	AccountID   string `json:"accountId"`
 	DisplayName string `json:"displayName"`
 }
 
 // JiraChangeItem represents a single change in a Jira issue's history
 type JiraChangeItem struct {
-	Field      string `json:"field"`
+	Field string `json:"field"`
+	// From and To hold the raw values, e.g. the account IDs of an assignee change
+	From       string `json:"from"`
 	FromString string `json:"fromString"`
+	To         string `json:"to"`
 	ToString   string `json:"toString"`
+	// FromCategory and ToCategory are the categories of the statuses of a status change,
+	// looked up by status ID in the Jira status metadata
+	FromCategory StatusCategory `json:"-"`
+	ToCategory   StatusCategory `json:"-"`
 }
 
 // IsStatusChange checks if this change item represents a status change
@@ -21,6 +29,16 @@ func (i *JiraChangeItem) IsStatusChange() bool {
 	return i.Field == "status"
 }
 
+// FromStatusCategory returns the category of the status a status change leaves
+func (i *JiraChangeItem) FromStatusCategory() StatusCategory {
+	return CategoryOf(i.FromCategory, i.FromString)
+}
+
+// ToStatusCategory returns the category of the status a status change enters
+func (i *JiraChangeItem) ToStatusCategory() StatusCategory {
+	return CategoryOf(i.ToCategory, i.ToString)
+}
+
 // JiraChangeHistory represents a historical change in a Jira issue
 type JiraChangeHistory struct {
 	Created string           `json:"created"`
@@ -37,11 +55,46 @@ type JiraFields struct {
 	WorkType    string       `json:"customfield_10014"`
 	AssetName   string       `json:"customfield_10015"`
 	Labels      []string     `json:"labels"`
+	Sprints     []JiraSprint `json:"sprint"`
+	// Created and ResolutionDate estimate the In Progress window when the changelog is unavailable
+	Created        string `json:"created"`
+	ResolutionDate string `json:"resolutiondate"`
+	// OriginalEstimate and RemainingEstimate are the time tracking estimates in seconds, nil
+	// when the issue is not estimated
+	OriginalEstimate  *int64 `json:"timeoriginalestimate"`
+	RemainingEstimate *int64 `json:"timeestimate"`
+}
+
+// JiraSprint represents a sprint an issue belongs to
+type JiraSprint struct {
+	ID   int    `json:"id,omitempty"`
+	Name string `json:"name"`
+	// State is active, closed or future
+	State     string `json:"state,omitempty"`
+	StartDate string `json:"startDate,omitempty"`
+	EndDate   string `json:"endDate,omitempty"`
 }
 
 // JiraStatus represents the status of a Jira issue
 type JiraStatus struct {
-	Name string `json:"name"`
+	ID       string             `json:"id,omitempty"`
+	Name     string             `json:"name"`
+	Category JiraStatusCategory `json:"statusCategory"`
+}
+
+// JiraStatusCategory represents the category of a Jira status
+type JiraStatusCategory struct {
+	Key StatusCategory `json:"key,omitempty"`
+}
+
+// StatusCategory returns the category of the status
+func (s JiraStatus) StatusCategory() StatusCategory {
+	return CategoryOf(s.Category.Key, s.Name)
+}
+
+// IsDone reports whether the status completes an issue, e.g. Done or Won't Do
+func (s JiraStatus) IsDone() bool {
+	return s.StatusCategory() == StatusCategoryDone
 }
 
 // JiraChangelog represents the changelog of a Jira issue
@@ -54,6 +107,8 @@ type JiraIssue struct {
 	Key       string        `json:"key"`
 	Fields    JiraFields    `json:"fields"`
 	Changelog JiraChangelog `json:"changelog"`
+	// ChangelogUnavailable marks an issue whose changelog Jira withheld for lack of permission
+	ChangelogUnavailable bool `json:"-"`
 }
 
 // GetStatusChanges returns all status changes in chronological order
@@ -78,7 +133,7 @@ func (i *JiraIssue) IsInProgress() bool {
 	}
 	lastChange := changes[len(changes)-1]
 	for _, item := range lastChange.Items {
-		if item.IsStatusChange() && item.ToString == "In Progress" {
+		if item.IsStatusChange() && item.ToStatusCategory() == StatusCategoryInProgress {
 			return true
 		}
 	}
@@ -93,7 +148,7 @@ func (i *JiraIssue) IsDone() bool {
 	}
 	lastChange := changes[len(changes)-1]
 	for _, item := range lastChange.Items {
-		if item.IsStatusChange() && (item.ToString == "Done" || item.ToString == "Won't Do") {
+		if item.IsStatusChange() && item.ToStatusCategory() == StatusCategoryDone {
 			return true
 		}
 	}
@@ -102,7 +157,20 @@ func (i *JiraIssue) IsDone() bool {
 
 // IssueType represents the type of a Jira issue
 type IssueType struct {
+	ID   string `json:"id,omitempty"`
 	Name string `json:"name"`
+	// Subtask and HierarchyLevel place the type in the issue type hierarchy: sub-tasks are at
+	// level -1, standard issues at 0 and epics at 1
+	Subtask        bool `json:"subtask,omitempty"`
+	HierarchyLevel int  `json:"hierarchyLevel,omitempty"`
+}
+
+// issueTypeSubTask is the name of the default sub-task type, for types read without hierarchy
+const issueTypeSubTask = "Sub-task"
+
+// IsSubtask reports whether issues of the type are sub-tasks
+func (t IssueType) IsSubtask() bool {
+	return t.Subtask || t.HierarchyLevel < 0 || t.Name == issueTypeSubTask
 }
 
 // GetWorkType returns the work type based on the issue's labels
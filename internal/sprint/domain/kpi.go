@@ -0,0 +1,169 @@
+package domain
+
+const (
+	// WorkTypeDevelopment is the work type whose hours are capitalized
+	WorkTypeDevelopment = "cap-development"
+	// WorkTypeMaintenance is the work type for maintenance hours, which are expensed
+	WorkTypeMaintenance = "cap-maintenance"
+	// WorkTypeDiscovery is the work type for discovery hours, which are expensed
+	WorkTypeDiscovery = "cap-discovery"
+)
+
+// ReportFormat represents the output format of a capitalization report
+type ReportFormat string
+
+const (
+	// ReportFormatCSV renders the report as CSV
+	ReportFormatCSV ReportFormat = "csv"
+	// ReportFormatMarkdown renders the report as Markdown
+	ReportFormatMarkdown ReportFormat = "markdown"
+)
+
+// CapitalizationReportInput represents the input parameters for a capitalization report
+type CapitalizationReportInput struct {
+	Projects       []string
+	Sprint         string
+	PreviousSprint string
+	Override       string
+	Format         ReportFormat
+	Delimiter      rune
+	Impairments    []AssetImpairment
+	// Policy reclassifies allocations, e.g. post-launch bug fixes counted as development
+	Policy []PolicyRule
+	// Template, when set, renders the report instead of Format
+	Template *ReportTemplate
+	// LabelsAsOf classifies issues by their labels at a past point in time
+	LabelsAsOf LabelSnapshot
+	// AssetDocs links allocated assets to their documentation pages
+	AssetDocs AssetDocLinks
+	// AssetAccounts books allocated assets to their cost centers and general ledger accounts
+	AssetAccounts AssetAccounts
+	// AssetStatuses resolves the status each asset had during the period
+	AssetStatuses AssetStatuses
+	// Retirements stop attributing work started after their retirement date to retired assets
+	Retirements AssetRetirements
+	// WorkTypeSplits divides the hours of annotated issues across work types
+	WorkTypeSplits WorkTypeSplits
+	// Locale formats the numbers and dates of the export
+	Locale Locale
+	// Heuristics configures the hours credited to untracked issues and same-day completions
+	Heuristics AllocationHeuristics
+	// Normalization selects what the percentages are a share of; empty means each person's time
+	Normalization Normalization
+	// Export, when set, is a Jira JSON or CSV export allocated instead of querying Jira
+	Export string
+}
+
+// CapitalizationSummary aggregates the hours of a set of allocations by work type
+type CapitalizationSummary struct {
+	TotalHours       float64
+	DevelopmentHours float64
+	MaintenanceHours float64
+	DiscoveryHours   float64
+	ImpairedHours    float64
+	// ReclassifiedHours are the hours whose work type a policy rule changed
+	ReclassifiedHours float64
+}
+
+// SummarizeAllocations aggregates allocations into a CapitalizationSummary
+func SummarizeAllocations(allocations []IssueAllocation) CapitalizationSummary {
+	var summary CapitalizationSummary
+	for _, allocation := range allocations {
+		summary.TotalHours += allocation.Hours
+		if allocation.Policy != "" {
+			summary.ReclassifiedHours += allocation.Hours
+		}
+		switch allocation.WorkType {
+		case WorkTypeDevelopment:
+			summary.DevelopmentHours += allocation.Hours
+			if allocation.Impaired {
+				summary.ImpairedHours += allocation.Hours
+			}
+		case WorkTypeMaintenance:
+			summary.MaintenanceHours += allocation.Hours
+		case WorkTypeDiscovery:
+			summary.DiscoveryHours += allocation.Hours
+		}
+	}
+	return summary
+}
+
+// CapitalizedHours returns the hours eligible for capitalization, net of impaired development work
+func (s CapitalizationSummary) CapitalizedHours() float64 {
+	return s.DevelopmentHours - s.ImpairedHours
+}
+
+// ExpensedHours returns the hours that are not capitalized
+func (s CapitalizationSummary) ExpensedHours() float64 {
+	return s.TotalHours - s.CapitalizedHours()
+}
+
+// CapitalizationRatio returns the percentage of hours that are capitalized
+func (s CapitalizationSummary) CapitalizationRatio() float64 {
+	return share(s.CapitalizedHours(), s.TotalHours)
+}
+
+// DevelopmentShare returns the percentage of hours spent on development
+func (s CapitalizationSummary) DevelopmentShare() float64 {
+	return share(s.DevelopmentHours, s.TotalHours)
+}
+
+// MaintenanceShare returns the percentage of hours spent on maintenance
+func (s CapitalizationSummary) MaintenanceShare() float64 {
+	return share(s.MaintenanceHours, s.TotalHours)
+}
+
+func share(part, total float64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return part / total * 100
+}
+
+// TeamCapitalization holds the capitalization summary of a single team
+type TeamCapitalization struct {
+	Team    string
+	Summary CapitalizationSummary
+}
+
+// CapitalizationKPIs holds the headline numbers of a capitalization report
+type CapitalizationKPIs struct {
+	Period         string
+	Overall        CapitalizationSummary
+	Teams          []TeamCapitalization
+	PreviousPeriod string
+	Previous       *CapitalizationSummary
+	Impairments    []AssetImpairment
+	// Policies are the policy rules that reclassified at least one allocation of the period
+	Policies []PolicyRule
+	// Unattributed is the in-progress time of the period that no team member held
+	Unattributed []UnattributedTime
+	// Heuristics are the issues of the period whose hours a heuristic set
+	Heuristics []AppliedHeuristic
+	// Absences are the in-progress time of the period not credited to absent assignees
+	Absences []AppliedAbsence
+	// SprintOverlaps are the issues of the period whose time was split with overlapping sprints
+	SprintOverlaps []SprintOverlap
+	// StaleIssues are the issues of the period In Progress for longer than the stale threshold
+	StaleIssues []StaleIssue
+	// RetiredAssetWork is the work of the period taken off assets retired before it started
+	RetiredAssetWork []RetiredAssetWork
+}
+
+// DevelopmentTrend returns the change in development share, in percentage points,
+// versus the previous period. It returns false when there is no previous period.
+func (k CapitalizationKPIs) DevelopmentTrend() (float64, bool) {
+	if k.Previous == nil {
+		return 0, false
+	}
+	return k.Overall.DevelopmentShare() - k.Previous.DevelopmentShare(), true
+}
+
+// MaintenanceTrend returns the change in maintenance share, in percentage points,
+// versus the previous period. It returns false when there is no previous period.
+func (k CapitalizationKPIs) MaintenanceTrend() (float64, bool) {
+	if k.Previous == nil {
+		return 0, false
+	}
+	return k.Overall.MaintenanceShare() - k.Previous.MaintenanceShare(), true
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeAllocations(t *testing.T) {
+	summary := SummarizeAllocations([]IssueAllocation{
+		{WorkType: WorkTypeDevelopment, Hours: 30},
+		{WorkType: WorkTypeMaintenance, Hours: 10},
+		{WorkType: WorkTypeDiscovery, Hours: 5},
+		{WorkType: "", Hours: 5},
+	})
+
+	assert.Equal(t, 50.0, summary.TotalHours)
+	assert.Equal(t, 30.0, summary.CapitalizedHours())
+	assert.InDelta(t, 60.0, summary.CapitalizationRatio(), 0.001)
+	assert.InDelta(t, 60.0, summary.DevelopmentShare(), 0.001)
+	assert.InDelta(t, 20.0, summary.MaintenanceShare(), 0.001)
+}
+
+func TestCapitalizationSummary_NoHours(t *testing.T) {
+	summary := SummarizeAllocations(nil)
+	assert.Equal(t, 0.0, summary.CapitalizationRatio())
+	assert.Equal(t, 0.0, summary.DevelopmentShare())
+}
+
+func TestCapitalizationKPIs_Trends(t *testing.T) {
+	kpis := CapitalizationKPIs{
+		Overall: CapitalizationSummary{TotalHours: 100, DevelopmentHours: 70, MaintenanceHours: 30},
+	}
+	_, ok := kpis.DevelopmentTrend()
+	assert.False(t, ok)
+
+	kpis.Previous = &CapitalizationSummary{TotalHours: 50, DevelopmentHours: 25, MaintenanceHours: 25}
+	trend, ok := kpis.DevelopmentTrend()
+	assert.True(t, ok)
+	assert.InDelta(t, 20.0, trend, 0.001)
+
+	trend, ok = kpis.MaintenanceTrend()
+	assert.True(t, ok)
+	assert.InDelta(t, -20.0, trend, 0.001)
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AsOfCompletion selects the labels each issue had when it was completed
+const AsOfCompletion = "completion"
+
+// LabelSnapshot selects the point in time whose labels drive classification. The zero
+// value uses the current labels.
+type LabelSnapshot struct {
+	// Date reconstructs every issue's labels as of this time
+	Date time.Time
+	// AtCompletion reconstructs each completed issue's labels as of its completion
+	AtCompletion bool
+}
+
+// IsCurrent reports whether the snapshot uses the current labels
+func (s LabelSnapshot) IsCurrent() bool {
+	return s.Date.IsZero() && !s.AtCompletion
+}
+
+// ParseLabelSnapshot parses an --as-of value: empty for the current labels, "completion",
+// or a date in YYYY-MM-DD format covering the whole day.
+func ParseLabelSnapshot(value string) (LabelSnapshot, error) {
+	value = strings.TrimSpace(value)
+	switch {
+	case value == "":
+		return LabelSnapshot{}, nil
+	case strings.EqualFold(value, AsOfCompletion):
+		return LabelSnapshot{AtCompletion: true}, nil
+	}
+
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return LabelSnapshot{}, fmt.Errorf("invalid as-of %q: use YYYY-MM-DD or %s", value, AsOfCompletion)
+	}
+	return LabelSnapshot{Date: date.AddDate(0, 0, 1).Add(-time.Nanosecond)}, nil
+}
+
+// IsLabelsChange checks if this change item represents a change of the issue labels
+func (i *JiraChangeItem) IsLabelsChange() bool {
+	return strings.EqualFold(i.Field, "labels")
+}
+
+// LabelsAt returns the labels the issue had at the given time, replaying the changelog.
+// The labels before the first change after that time are taken from the change itself.
+func (i *JiraIssue) LabelsAt(at time.Time) []string {
+	for _, history := range i.Changelog.Histories {
+		for _, item := range history.Items {
+			if !item.IsLabelsChange() {
+				continue
+			}
+			created, err := ParseJiraTime(history.Created)
+			if err != nil || !created.After(at) {
+				continue
+			}
+			return strings.Fields(item.FromString)
+		}
+	}
+	return i.Fields.Labels
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func relabeledIssue() JiraIssue {
+	return JiraIssue{
+		Key:    "FN-1",
+		Fields: JiraFields{Labels: []string{"cap-maintenance", "cap-asset-search"}},
+		Changelog: JiraChangelog{
+			Histories: []JiraChangeHistory{
+				{
+					Created: "2024-03-05T10:00:00.000+0000",
+					Items:   []JiraChangeItem{{Field: "labels", FromString: "", ToString: "cap-development cap-asset-booking"}},
+				},
+				{
+					Created: "2024-04-02T10:00:00.000+0000",
+					Items:   []JiraChangeItem{{Field: "labels", FromString: "cap-development cap-asset-booking", ToString: "cap-maintenance cap-asset-search"}},
+				},
+			},
+		},
+	}
+}
+
+func TestJiraIssue_LabelsAt(t *testing.T) {
+	issue := relabeledIssue()
+
+	assert.Empty(t, issue.LabelsAt(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, []string{"cap-development", "cap-asset-booking"}, issue.LabelsAt(time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, []string{"cap-maintenance", "cap-asset-search"}, issue.LabelsAt(time.Date(2024, 4, 2, 10, 0, 0, 0, time.UTC)))
+
+	unchanged := JiraIssue{Fields: JiraFields{Labels: []string{"cap-discovery"}}}
+	assert.Equal(t, []string{"cap-discovery"}, unchanged.LabelsAt(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestParseLabelSnapshot(t *testing.T) {
+	snapshot, err := ParseLabelSnapshot("")
+	require.NoError(t, err)
+	assert.True(t, snapshot.IsCurrent())
+
+	snapshot, err = ParseLabelSnapshot("Completion")
+	require.NoError(t, err)
+	assert.Equal(t, LabelSnapshot{AtCompletion: true}, snapshot)
+	assert.False(t, snapshot.IsCurrent())
+
+	// A date covers the whole day
+	snapshot, err = ParseLabelSnapshot("2024-03-31")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 31, 23, 59, 59, 999999999, time.UTC), snapshot.Date)
+
+	_, err = ParseLabelSnapshot("Q1")
+	assert.Error(t, err)
+}
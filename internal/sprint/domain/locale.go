@@ -0,0 +1,172 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Locale selects how numbers and dates are written in exports. The zero value writes
+// decimal points, ISO 8601 dates and percentages with two decimals and a percent sign.
+type Locale struct {
+	Name string
+	// DecimalSeparator separates the integer and fractional digits of numbers
+	DecimalSeparator string
+	// DateLayout is the Go time layout of dates
+	DateLayout string
+	// PercentFormat writes percentages with a percent sign or as fractions of one
+	PercentFormat PercentFormat
+	// PercentDecimals overrides the default decimals of the percent format when set
+	PercentDecimals *int
+}
+
+// PercentFormat selects how percentages are written in exports
+type PercentFormat string
+
+const (
+	// PercentFormatPercent writes percentages with a percent sign, e.g. 25.00%
+	PercentFormatPercent PercentFormat = "percent"
+	// PercentFormatFraction writes percentages as fractions of one, e.g. 0.2500
+	PercentFormatFraction PercentFormat = "fraction"
+)
+
+// MaxPercentDecimals is the largest number of decimals percentages can be written with
+const MaxPercentDecimals = 6
+
+// ParsePercentFormat parses a percent format. An empty value selects the default, which
+// writes percent signs.
+func ParsePercentFormat(value string) (PercentFormat, error) {
+	switch format := PercentFormat(strings.ToLower(strings.TrimSpace(value))); format {
+	case "", PercentFormatPercent, PercentFormatFraction:
+		return format, nil
+	}
+	return "", fmt.Errorf("unsupported percent format %q: use %s or %s", value, PercentFormatPercent, PercentFormatFraction)
+}
+
+// WithPercentFormat returns a copy of the locale writing percentages in the given format,
+// with the given decimals or the default decimals of the format when decimals is nil
+func (l Locale) WithPercentFormat(format PercentFormat, decimals *int) (Locale, error) {
+	if decimals != nil && (*decimals < 0 || *decimals > MaxPercentDecimals) {
+		return Locale{}, fmt.Errorf("percent decimals must be between 0 and %d, got %d", MaxPercentDecimals, *decimals)
+	}
+	l.PercentFormat = format
+	l.PercentDecimals = decimals
+	return l, nil
+}
+
+// isoDateLayout is the date layout of the default locale
+const isoDateLayout = "2006-01-02"
+
+// locales are the supported export locales by lower-case name
+var locales = map[string]Locale{
+	"iso":   {Name: "iso", DecimalSeparator: ".", DateLayout: isoDateLayout},
+	"en-us": {Name: "en-US", DecimalSeparator: ".", DateLayout: "01/02/2006"},
+	"en-gb": {Name: "en-GB", DecimalSeparator: ".", DateLayout: "02/01/2006"},
+	"de-de": {Name: "de-DE", DecimalSeparator: ",", DateLayout: "02.01.2006"},
+	"de-at": {Name: "de-AT", DecimalSeparator: ",", DateLayout: "02.01.2006"},
+	"de-ch": {Name: "de-CH", DecimalSeparator: ".", DateLayout: "02.01.2006"},
+	"fr-fr": {Name: "fr-FR", DecimalSeparator: ",", DateLayout: "02/01/2006"},
+	"es-es": {Name: "es-ES", DecimalSeparator: ",", DateLayout: "02/01/2006"},
+	"it-it": {Name: "it-IT", DecimalSeparator: ",", DateLayout: "02/01/2006"},
+	"nl-nl": {Name: "nl-NL", DecimalSeparator: ",", DateLayout: "02-01-2006"},
+	"pt-pt": {Name: "pt-PT", DecimalSeparator: ",", DateLayout: "02/01/2006"},
+	"pt-br": {Name: "pt-BR", DecimalSeparator: ",", DateLayout: "02/01/2006"},
+}
+
+// localeLanguages map a bare language to its default region
+var localeLanguages = map[string]string{
+	"en": "en-gb",
+	"de": "de-de",
+	"fr": "fr-fr",
+	"es": "es-es",
+	"it": "it-it",
+	"nl": "nl-nl",
+	"pt": "pt-pt",
+}
+
+// ParseLocale returns the export locale with the given name, such as de-DE or de. An
+// empty name selects the default ISO formatting.
+func ParseLocale(name string) (Locale, error) {
+	key := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(name), "_", "-"))
+	if key == "" {
+		return Locale{}, nil
+	}
+	if region, ok := localeLanguages[key]; ok {
+		key = region
+	}
+	locale, ok := locales[key]
+	if !ok {
+		return Locale{}, fmt.Errorf("unsupported locale %q: use one of %s", name, strings.Join(LocaleNames(), ", "))
+	}
+	return locale, nil
+}
+
+// LocaleNames returns the names of the supported export locales
+func LocaleNames() []string {
+	names := make([]string, 0, len(locales))
+	for _, locale := range locales {
+		names = append(names, locale.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Number formats a value with the given number of decimals
+func (l Locale) Number(value float64, decimals int) string {
+	formatted := strconv.FormatFloat(value, 'f', decimals, 64)
+	if l.DecimalSeparator == "" || l.DecimalSeparator == "." {
+		return formatted
+	}
+	return strings.Replace(formatted, ".", l.DecimalSeparator, 1)
+}
+
+// Hours formats hours with two decimals
+func (l Locale) Hours(hours float64) string {
+	return l.Number(hours, 2)
+}
+
+// Percent formats a percentage given out of 100 in the percent format of the locale, by
+// default with two decimals and a percent sign
+func (l Locale) Percent(percentage float64) string {
+	if l.PercentFormat == PercentFormatFraction {
+		return l.Number(percentage/100, l.percentDecimals(4))
+	}
+	return l.Number(percentage, l.percentDecimals(2)) + "%"
+}
+
+// PercentagePoints formats a signed change in percentage points. The decimals of the
+// locale apply only when percentages are written with a percent sign.
+func (l Locale) PercentagePoints(change float64) string {
+	sign := "+"
+	if change < 0 {
+		sign = "-"
+		change = -change
+	}
+	decimals := 2
+	if l.PercentFormat != PercentFormatFraction {
+		decimals = l.percentDecimals(2)
+	}
+	return sign + l.Number(change, decimals) + " pp"
+}
+
+// percentDecimals returns the configured decimals of percentages, or fallback when unset
+func (l Locale) percentDecimals(fallback int) int {
+	if l.PercentDecimals == nil {
+		return fallback
+	}
+	return *l.PercentDecimals
+}
+
+// Date formats a date, or returns an empty string for the zero time
+func (l Locale) Date(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+	layout := l.DateLayout
+	if layout == "" {
+		layout = isoDateLayout
+	}
+	return date.Format(layout)
+}
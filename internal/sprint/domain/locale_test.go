@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLocale(t *testing.T) {
+	locale, err := ParseLocale("")
+	require.NoError(t, err)
+	assert.Equal(t, Locale{}, locale)
+
+	locale, err = ParseLocale("de_de")
+	require.NoError(t, err)
+	assert.Equal(t, "de-DE", locale.Name)
+
+	locale, err = ParseLocale("fr")
+	require.NoError(t, err)
+	assert.Equal(t, "fr-FR", locale.Name)
+
+	_, err = ParseLocale("xx-YY")
+	assert.ErrorContains(t, err, `unsupported locale "xx-YY": use one of de-AT, de-CH, de-DE`)
+}
+
+func TestLocale_Format(t *testing.T) {
+	german, err := ParseLocale("de-DE")
+	require.NoError(t, err)
+	date := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "1234,50", german.Hours(1234.5))
+	assert.Equal(t, "62,50%", german.Percent(62.5))
+	assert.Equal(t, "-1,25 pp", german.PercentagePoints(-1.25))
+	assert.Equal(t, "05.03.2024", german.Date(date))
+	assert.Empty(t, german.Date(time.Time{}))
+
+	var iso Locale
+	assert.Equal(t, "1234.50", iso.Hours(1234.5))
+	assert.Equal(t, "+1.25 pp", iso.PercentagePoints(1.25))
+	assert.Equal(t, "2024-03-05", iso.Date(date))
+}
+
+func TestParsePercentFormat(t *testing.T) {
+	format, err := ParsePercentFormat("")
+	require.NoError(t, err)
+	assert.Empty(t, format)
+
+	format, err = ParsePercentFormat("Fraction")
+	require.NoError(t, err)
+	assert.Equal(t, PercentFormatFraction, format)
+
+	_, err = ParsePercentFormat("ratio")
+	assert.ErrorContains(t, err, `unsupported percent format "ratio"`)
+}
+
+func TestLocale_WithPercentFormat(t *testing.T) {
+	german, err := ParseLocale("de-DE")
+	require.NoError(t, err)
+
+	fraction, err := german.WithPercentFormat(PercentFormatFraction, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "0,6250", fraction.Percent(62.5))
+	assert.Equal(t, "-1,25 pp", fraction.PercentagePoints(-1.25))
+	assert.Equal(t, "62,50%", german.Percent(62.5), "the original locale is unchanged")
+
+	zero := 0
+	rounded, err := german.WithPercentFormat(PercentFormatPercent, &zero)
+	require.NoError(t, err)
+	assert.Equal(t, "63%", rounded.Percent(62.6))
+	assert.Equal(t, "-1 pp", rounded.PercentagePoints(-1.25))
+	assert.Equal(t, "1234,50", rounded.Hours(1234.5), "hours keep two decimals")
+
+	tooMany := MaxPercentDecimals + 1
+	_, err = german.WithPercentFormat(PercentFormatPercent, &tooMany)
+	assert.ErrorContains(t, err, "percent decimals must be between 0 and 6")
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Normalization selects what the allocation percentages are a share of
+type Normalization string
+
+const (
+	// NormalizationPerson makes each person's percentages sum to 100%, whatever their hours
+	NormalizationPerson Normalization = "person"
+	// NormalizationCapacity makes the team's percentages sum to 100%, weighting each person's
+	// share by their capacity so aggregates reflect the share of the team's effort
+	NormalizationCapacity Normalization = "capacity"
+)
+
+// ParseNormalization parses a normalization mode; an empty mode normalizes per person
+func ParseNormalization(value string) (Normalization, error) {
+	switch Normalization(strings.ToLower(strings.TrimSpace(value))) {
+	case "", NormalizationPerson:
+		return NormalizationPerson, nil
+	case NormalizationCapacity:
+		return NormalizationCapacity, nil
+	default:
+		return "", fmt.Errorf("invalid normalization %q: must be person or capacity", value)
+	}
+}
+
+// CapacityOf returns a member's capacity as a fraction of full time; members without a
+// configured capacity work full time
+func (t *Team) CapacityOf(member string) float64 {
+	if capacity, ok := t.Capacity[member]; ok {
+		return capacity
+	}
+	return 1
+}
+
+// NormalizeByCapacity turns each person's percentages into shares of the team's allocation,
+// weighted by their capacity. Only people with allocated work count towards the team, so the
+// percentages of the team still sum to 100%.
+func NormalizeByCapacity(team Team, allocated []IssueHours) []IssueHours {
+	working := make(map[string]bool)
+	for _, issue := range allocated {
+		for _, member := range issue.Members {
+			if member.Percentage > 0 {
+				working[member.Assignee] = true
+			}
+		}
+	}
+	total := 0.0
+	for member := range working {
+		total += team.CapacityOf(member)
+	}
+
+	normalized := make([]IssueHours, len(allocated))
+	for i, issue := range allocated {
+		normalized[i] = IssueHours{Issue: issue.Issue, Members: make([]MemberHours, len(issue.Members))}
+		for j, member := range issue.Members {
+			if total > 0 {
+				member.Percentage = member.Percentage * team.CapacityOf(member.Assignee) / total
+			} else {
+				member.Percentage = 0
+			}
+			normalized[i].Members[j] = member
+		}
+	}
+	return normalized
+}
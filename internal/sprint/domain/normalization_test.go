@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNormalization(t *testing.T) {
+	for value, want := range map[string]Normalization{"": NormalizationPerson, "person": NormalizationPerson, " Capacity ": NormalizationCapacity} {
+		got, err := ParseNormalization(value)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	_, err := ParseNormalization("team")
+	assert.EqualError(t, err, `invalid normalization "team": must be person or capacity`)
+}
+
+func TestTeam_CapacityOf(t *testing.T) {
+	team := Team{Team: []string{"alice", "bob"}, Capacity: map[string]float64{"bob": 0.25}}
+
+	assert.Equal(t, 1.0, team.CapacityOf("alice"))
+	assert.Equal(t, 0.25, team.CapacityOf("bob"))
+}
+
+func TestNormalizeByCapacity(t *testing.T) {
+	team := Team{Team: []string{"alice", "bob", "carol"}, Capacity: map[string]float64{"bob": 0.25}}
+	// alice works full time on two issues; bob, a part-timer, spends their 2 hours on one
+	allocated := []IssueHours{
+		{Issue: JiraIssue{Key: "FN-1"}, Members: []MemberHours{{Assignee: "alice", Hours: 45, Percentage: 75}}},
+		{Issue: JiraIssue{Key: "FN-2"}, Members: []MemberHours{{Assignee: "alice", Hours: 15, Percentage: 25}, {Assignee: "carol", Hours: 0, Percentage: 0}}},
+		{Issue: JiraIssue{Key: "FN-3"}, Members: []MemberHours{{Assignee: "bob", Hours: 2, Percentage: 100}}},
+	}
+
+	normalized := NormalizeByCapacity(team, allocated)
+
+	assert.InDelta(t, 60, normalized[0].Members[0].Percentage, 0.001)
+	assert.InDelta(t, 20, normalized[1].Members[0].Percentage, 0.001)
+	assert.Zero(t, normalized[1].Members[1].Percentage, "carol has no allocated work")
+	assert.InDelta(t, 20, normalized[2].Members[0].Percentage, 0.001)
+	assert.Equal(t, 2.0, normalized[2].Members[0].Hours, "hours are unchanged")
+	assert.Equal(t, 100.0, allocated[2].Members[0].Percentage, "the input is not modified")
+
+	total := 0.0
+	for _, issue := range normalized {
+		for _, member := range issue.Members {
+			total += member.Percentage
+		}
+	}
+	assert.InDelta(t, 100, total, 0.001)
+}
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// AllocationSummary selects the summary section added after the allocation rows
+type AllocationSummary string
+
+const (
+	// AllocationSummaryNone adds no summary
+	AllocationSummaryNone AllocationSummary = ""
+	// AllocationSummaryPerson adds one row per person with their hours and split per asset
+	AllocationSummaryPerson AllocationSummary = "person"
+)
+
+// ParseAllocationSummary parses a summary mode; an empty mode adds no summary
+func ParseAllocationSummary(value string) (AllocationSummary, error) {
+	switch AllocationSummary(strings.ToLower(strings.TrimSpace(value))) {
+	case AllocationSummaryNone:
+		return AllocationSummaryNone, nil
+	case AllocationSummaryPerson:
+		return AllocationSummaryPerson, nil
+	default:
+		return "", fmt.Errorf("invalid summary %q: must be person", value)
+	}
+}
+
+// AssetShare is the part of a person's allocation credited to one asset
+type AssetShare struct {
+	Hours      float64
+	Percentage float64
+}
+
+// PersonAllocation totals the allocation rows of one person
+type PersonAllocation struct {
+	Person     string
+	Hours      float64
+	Percentage float64
+	// Assets holds the share of each asset, keyed by asset name; work linked to no asset is
+	// keyed by the empty name
+	Assets map[string]AssetShare
+}
+
+// PersonSummary totals allocation rows per person as they are written, so the summary adds
+// up to exactly the rows it was built from
+type PersonSummary struct {
+	people map[string]*PersonAllocation
+	assets map[string]bool
+}
+
+// NewPersonSummary creates an empty summary
+func NewPersonSummary() *PersonSummary {
+	return &PersonSummary{people: make(map[string]*PersonAllocation), assets: make(map[string]bool)}
+}
+
+// Add counts an allocation row towards its assignee
+func (s *PersonSummary) Add(allocation IssueAllocation) {
+	person, ok := s.people[allocation.Assignee]
+	if !ok {
+		person = &PersonAllocation{Person: allocation.Assignee, Assets: make(map[string]AssetShare)}
+		s.people[allocation.Assignee] = person
+	}
+	person.Hours += allocation.Hours
+	person.Percentage += allocation.Percentage
+
+	share := person.Assets[allocation.AssetName]
+	share.Hours += allocation.Hours
+	share.Percentage += allocation.Percentage
+	person.Assets[allocation.AssetName] = share
+	s.assets[allocation.AssetName] = true
+}
+
+// People returns the totals of each person, by name
+func (s *PersonSummary) People() []PersonAllocation {
+	people := make([]PersonAllocation, 0, len(s.people))
+	for _, person := range s.people {
+		people = append(people, *person)
+	}
+	sort.Slice(people, func(i, j int) bool { return people[i].Person < people[j].Person })
+	return people
+}
+
+// Assets returns the names of the assets any person worked on, by name with the work linked
+// to no asset last
+func (s *PersonSummary) Assets() []string {
+	assets := make([]string, 0, len(s.assets))
+	for asset := range s.assets {
+		assets = append(assets, asset)
+	}
+	sort.Slice(assets, func(i, j int) bool {
+		if assets[i] == "" || assets[j] == "" {
+			return assets[j] == ""
+		}
+		return assets[i] < assets[j]
+	})
+	return assets
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAllocationSummary(t *testing.T) {
+	summary, err := ParseAllocationSummary("")
+	require.NoError(t, err)
+	assert.Equal(t, AllocationSummaryNone, summary)
+
+	summary, err = ParseAllocationSummary(" Person ")
+	require.NoError(t, err)
+	assert.Equal(t, AllocationSummaryPerson, summary)
+
+	_, err = ParseAllocationSummary("team")
+	assert.EqualError(t, err, `invalid summary "team": must be person`)
+}
+
+func TestPersonSummary(t *testing.T) {
+	summary := NewPersonSummary()
+	summary.Add(IssueAllocation{IssueKey: "PROJ-1", Assignee: "Bruno", AssetName: "search", Hours: 12, Percentage: 60})
+	summary.Add(IssueAllocation{IssueKey: "PROJ-2", Assignee: "Ana", AssetName: "booking", Hours: 10, Percentage: 50})
+	summary.Add(IssueAllocation{IssueKey: "PROJ-3", Assignee: "Ana", AssetName: "", Hours: 4, Percentage: 20})
+	summary.Add(IssueAllocation{IssueKey: "PROJ-4", Assignee: "Ana", AssetName: "booking", Hours: 6, Percentage: 30})
+	summary.Add(IssueAllocation{IssueKey: "PROJ-1", Assignee: "Bruno", AssetName: "booking", Hours: 8, Percentage: 40})
+
+	assert.Equal(t, []string{"booking", "search", ""}, summary.Assets())
+	assert.Equal(t, []PersonAllocation{
+		{
+			Person:     "Ana",
+			Hours:      20,
+			Percentage: 100,
+			Assets:     map[string]AssetShare{"booking": {Hours: 16, Percentage: 80}, "": {Hours: 4, Percentage: 20}},
+		},
+		{
+			Person:     "Bruno",
+			Hours:      20,
+			Percentage: 100,
+			Assets:     map[string]AssetShare{"search": {Hours: 12, Percentage: 60}, "booking": {Hours: 8, Percentage: 40}},
+		},
+	}, summary.People())
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidPersonWorkType is returned for person work type overrides that cannot be parsed
+var ErrInvalidPersonWorkType = errors.New("invalid person work type")
+
+// personWorkTypes are the work types a person's rows can be overridden to
+var personWorkTypes = []string{WorkTypeDevelopment, WorkTypeMaintenance, WorkTypeDiscovery}
+
+// PersonWorkTypes overrides the work type of every allocation row of a team member, e.g. for an
+// engineer whose whole sprint was agreed to be maintenance during an on-call rotation. It maps
+// lowercased team member names to work types.
+type PersonWorkTypes map[string]string
+
+// NewPersonWorkTypes validates the work type of each person. Work types may omit their cap- prefix.
+func NewPersonWorkTypes(overrides map[string]string) (PersonWorkTypes, error) {
+	result := make(PersonWorkTypes, len(overrides))
+	for person, workType := range overrides {
+		if err := result.set(person, workType); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// ParsePersonWorkTypes parses overrides written person=worktype, e.g. "alice=maintenance". A
+// later override of the same person wins.
+func ParsePersonWorkTypes(values []string) (PersonWorkTypes, error) {
+	result := make(PersonWorkTypes, len(values))
+	for _, value := range values {
+		i := strings.LastIndex(value, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("%w %q: must be person=worktype", ErrInvalidPersonWorkType, value)
+		}
+		if err := result.set(value[:i], value[i+1:]); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// set records the work type of a person after validating both
+func (o PersonWorkTypes) set(person, workType string) error {
+	person = strings.ToLower(strings.TrimSpace(person))
+	if person == "" {
+		return fmt.Errorf("%w: person cannot be empty", ErrInvalidPersonWorkType)
+	}
+	workType = strings.ToLower(strings.TrimSpace(workType))
+	if !strings.HasPrefix(workType, "cap-") {
+		workType = "cap-" + workType
+	}
+	for _, valid := range personWorkTypes {
+		if workType == valid {
+			o[person] = workType
+			return nil
+		}
+	}
+	return fmt.Errorf("%w %q for %s: use development, maintenance or discovery", ErrInvalidPersonWorkType, workType, person)
+}
+
+// Merge returns the overrides with those of other added, other winning for the same person
+func (o PersonWorkTypes) Merge(other PersonWorkTypes) PersonWorkTypes {
+	result := make(PersonWorkTypes, len(o)+len(other))
+	for person, workType := range o {
+		result[person] = workType
+	}
+	for person, workType := range other {
+		result[person] = workType
+	}
+	return result
+}
+
+// Apply sets the work type of an allocation row of an overridden person, returning the
+// adjustment made and false when the row keeps its work type
+func (o PersonWorkTypes) Apply(allocation *IssueAllocation) (WorkTypeAdjustment, bool) {
+	workType, ok := o[strings.ToLower(allocation.Assignee)]
+	if !ok || allocation.WorkType == workType {
+		return WorkTypeAdjustment{}, false
+	}
+	adjustment := WorkTypeAdjustment{
+		IssueKey: allocation.IssueKey,
+		Assignee: allocation.Assignee,
+		From:     allocation.WorkType,
+		To:       workType,
+		Hours:    allocation.Hours,
+	}
+	allocation.WorkType = workType
+	return adjustment, true
+}
+
+// WorkTypeAdjustment records an allocation row whose work type was overridden for its assignee
+type WorkTypeAdjustment struct {
+	IssueKey string
+	Assignee string
+	// From is the work type of the issue, empty when it was unclassified
+	From string
+	To   string
+	// Hours are the hours of the row, now counted as To
+	Hours float64
+}
+
+// Reason describes the override, e.g. "alice's work type set to cap-maintenance (was cap-development)"
+func (a WorkTypeAdjustment) Reason() string {
+	from := a.From
+	if from == "" {
+		from = "unclassified"
+	}
+	return fmt.Sprintf("%s's work type set to %s (was %s)", a.Assignee, a.To, from)
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePersonWorkTypes(t *testing.T) {
+	overrides, err := ParsePersonWorkTypes([]string{"Alice=maintenance", " bob = cap-Discovery ", "alice=development"})
+	require.NoError(t, err)
+	assert.Equal(t, PersonWorkTypes{"alice": WorkTypeDevelopment, "bob": WorkTypeDiscovery}, overrides, "a later override of the same person wins")
+
+	for _, value := range []string{"alice", "=maintenance", "alice=on-call"} {
+		_, err := ParsePersonWorkTypes([]string{value})
+		assert.ErrorIs(t, err, ErrInvalidPersonWorkType, value)
+	}
+}
+
+func TestNewPersonWorkTypes(t *testing.T) {
+	overrides, err := NewPersonWorkTypes(map[string]string{"Alice": "maintenance"})
+	require.NoError(t, err)
+	assert.Equal(t, PersonWorkTypes{"alice": WorkTypeMaintenance}, overrides)
+
+	_, err = NewPersonWorkTypes(map[string]string{"alice": "support"})
+	assert.ErrorIs(t, err, ErrInvalidPersonWorkType)
+}
+
+func TestPersonWorkTypes_Merge(t *testing.T) {
+	configured := PersonWorkTypes{"alice": WorkTypeMaintenance, "bob": WorkTypeDiscovery}
+	merged := configured.Merge(PersonWorkTypes{"alice": WorkTypeDevelopment})
+
+	assert.Equal(t, PersonWorkTypes{"alice": WorkTypeDevelopment, "bob": WorkTypeDiscovery}, merged)
+	assert.Equal(t, WorkTypeMaintenance, configured["alice"], "merging leaves the overrides unchanged")
+	assert.Empty(t, PersonWorkTypes(nil).Merge(nil))
+}
+
+func TestPersonWorkTypes_Apply(t *testing.T) {
+	overrides := PersonWorkTypes{"alice": WorkTypeMaintenance}
+
+	row := IssueAllocation{IssueKey: "TEST-1", Assignee: "Alice", WorkType: WorkTypeDevelopment, Hours: 6}
+	adjustment, ok := overrides.Apply(&row)
+	require.True(t, ok)
+	assert.Equal(t, WorkTypeMaintenance, row.WorkType)
+	assert.Equal(t, WorkTypeAdjustment{IssueKey: "TEST-1", Assignee: "Alice", From: WorkTypeDevelopment, To: WorkTypeMaintenance, Hours: 6}, adjustment)
+	assert.Equal(t, "Alice's work type set to cap-maintenance (was cap-development)", adjustment.Reason())
+
+	row = IssueAllocation{IssueKey: "TEST-2", Assignee: "bob", WorkType: WorkTypeDevelopment}
+	_, ok = overrides.Apply(&row)
+	assert.False(t, ok)
+	assert.Equal(t, WorkTypeDevelopment, row.WorkType)
+
+	unclassified := WorkTypeAdjustment{Assignee: "alice", To: WorkTypeMaintenance}
+	assert.Equal(t, "alice's work type set to cap-maintenance (was unclassified)", unclassified.Reason())
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// issueTypeBug is the Jira issue type of bug fixes
+const issueTypeBug = "Bug"
+
+// PolicyRule reclassifies allocations according to a company capitalization policy
+type PolicyRule interface {
+	// Name is the short identifier recorded on the allocations the rule reclassifies
+	Name() string
+	// Reclassify returns the work type the allocation counts as, and false when the rule does not apply
+	Reclassify(allocation IssueAllocation) (string, bool)
+	// String describes the rule
+	String() string
+}
+
+// BugFixWindow counts bugs on an asset completed within Days days after its launch as
+// development, for policies that capitalize post-launch stabilization work
+type BugFixWindow struct {
+	AssetName  string
+	LaunchDate time.Time
+	Days       int
+}
+
+// Name returns the identifier of the bug-fix window rule
+func (w BugFixWindow) Name() string {
+	return "bug-fix-window"
+}
+
+// Reclassify counts a bug on the asset as development when it was completed on the launch
+// date or within the following Days days
+func (w BugFixWindow) Reclassify(allocation IssueAllocation) (string, bool) {
+	if w.Days <= 0 || w.LaunchDate.IsZero() || allocation.DateCompleted.IsZero() {
+		return "", false
+	}
+	if !strings.EqualFold(allocation.IssueType, issueTypeBug) || !matchesAsset(allocation.AssetName, w.AssetName) {
+		return "", false
+	}
+	launch := time.Date(w.LaunchDate.Year(), w.LaunchDate.Month(), w.LaunchDate.Day(), 0, 0, 0, 0, time.UTC)
+	completed := time.Date(allocation.DateCompleted.Year(), allocation.DateCompleted.Month(), allocation.DateCompleted.Day(), 0, 0, 0, 0, time.UTC)
+	if completed.Before(launch) || completed.After(launch.AddDate(0, 0, w.Days)) {
+		return "", false
+	}
+	return WorkTypeDevelopment, true
+}
+
+// String describes the window, e.g. "bugs on booking within 30 days of launch (2024-03-01) count as development"
+func (w BugFixWindow) String() string {
+	return fmt.Sprintf("bugs on %s within %d days of launch (%s) count as development", w.AssetName, w.Days, w.LaunchDate.Format("2006-01-02"))
+}
+
+// ApplyPolicy reclassifies the allocations matched by the first applicable rule, recording the
+// rule on them, and appends every rule that matched at least one allocation to applied
+func ApplyPolicy(allocations []IssueAllocation, rules, applied []PolicyRule) []PolicyRule {
+	for _, rule := range rules {
+		matched := false
+		for i := range allocations {
+			if allocations[i].Policy != "" {
+				continue
+			}
+			workType, ok := rule.Reclassify(allocations[i])
+			if !ok {
+				continue
+			}
+			allocations[i].WorkType = workType
+			allocations[i].Policy = rule.Name()
+			matched = true
+		}
+		if matched && !containsRule(applied, rule) {
+			applied = append(applied, rule)
+		}
+	}
+	return applied
+}
+
+func containsRule(rules []PolicyRule, rule PolicyRule) bool {
+	for _, existing := range rules {
+		if existing.String() == rule.String() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBugFixWindow_Reclassify(t *testing.T) {
+	window := BugFixWindow{AssetName: "booking", LaunchDate: time.Date(2024, 3, 1, 15, 0, 0, 0, time.UTC), Days: 30}
+	completed := func(month time.Month, day int) time.Time {
+		return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name       string
+		allocation IssueAllocation
+		want       bool
+	}{
+		{
+			name:       "bug completed on launch day",
+			allocation: IssueAllocation{IssueType: "Bug", AssetName: "cap-asset-booking", WorkType: WorkTypeMaintenance, DateCompleted: completed(3, 1)},
+			want:       true,
+		},
+		{
+			name:       "bug completed on the last day of the window",
+			allocation: IssueAllocation{IssueType: "bug", AssetName: "cap-asset-Booking", DateCompleted: completed(3, 31)},
+			want:       true,
+		},
+		{
+			name:       "bug completed after the window",
+			allocation: IssueAllocation{IssueType: "Bug", AssetName: "cap-asset-booking", DateCompleted: completed(4, 1)},
+		},
+		{
+			name:       "bug completed before launch",
+			allocation: IssueAllocation{IssueType: "Bug", AssetName: "cap-asset-booking", DateCompleted: completed(2, 28)},
+		},
+		{
+			name:       "bug still in progress",
+			allocation: IssueAllocation{IssueType: "Bug", AssetName: "cap-asset-booking"},
+		},
+		{
+			name:       "story in the window",
+			allocation: IssueAllocation{IssueType: "Story", AssetName: "cap-asset-booking", DateCompleted: completed(3, 5)},
+		},
+		{
+			name:       "bug on another asset",
+			allocation: IssueAllocation{IssueType: "Bug", AssetName: "cap-asset-payments", DateCompleted: completed(3, 5)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workType, ok := window.Reclassify(tt.allocation)
+			assert.Equal(t, tt.want, ok)
+			if tt.want {
+				assert.Equal(t, WorkTypeDevelopment, workType)
+			}
+		})
+	}
+
+	t.Run("window without days or launch date never applies", func(t *testing.T) {
+		allocation := IssueAllocation{IssueType: "Bug", AssetName: "booking", DateCompleted: completed(3, 5)}
+		_, ok := BugFixWindow{AssetName: "booking", LaunchDate: window.LaunchDate}.Reclassify(allocation)
+		assert.False(t, ok)
+		_, ok = BugFixWindow{AssetName: "booking", Days: 30}.Reclassify(allocation)
+		assert.False(t, ok)
+	})
+}
+
+func TestBugFixWindow_String(t *testing.T) {
+	window := BugFixWindow{AssetName: "booking", LaunchDate: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Days: 30}
+	assert.Equal(t, "bug-fix-window", window.Name())
+	assert.Equal(t, "bugs on booking within 30 days of launch (2024-03-01) count as development", window.String())
+}
+
+func TestApplyPolicy(t *testing.T) {
+	window := BugFixWindow{AssetName: "booking", LaunchDate: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Days: 30}
+	unused := BugFixWindow{AssetName: "payments", LaunchDate: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Days: 30}
+	allocations := []IssueAllocation{
+		{IssueKey: "FN-1", IssueType: "Bug", AssetName: "cap-asset-booking", WorkType: WorkTypeMaintenance, Hours: 6, DateCompleted: time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)},
+		{IssueKey: "FN-2", IssueType: "Story", AssetName: "cap-asset-booking", WorkType: WorkTypeMaintenance, Hours: 4, DateCompleted: time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)},
+	}
+
+	applied := ApplyPolicy(allocations, []PolicyRule{window, unused}, nil)
+
+	require.Equal(t, []PolicyRule{window}, applied)
+	assert.Equal(t, WorkTypeDevelopment, allocations[0].WorkType)
+	assert.Equal(t, "bug-fix-window", allocations[0].Policy)
+	assert.Equal(t, WorkTypeMaintenance, allocations[1].WorkType)
+	assert.Empty(t, allocations[1].Policy)
+
+	summary := SummarizeAllocations(allocations)
+	assert.Equal(t, 6.0, summary.DevelopmentHours)
+	assert.Equal(t, 6.0, summary.ReclassifiedHours)
+
+	assert.Equal(t, []PolicyRule{window}, ApplyPolicy(allocations, []PolicyRule{window}, applied), "reclassified allocations are not matched again")
+}
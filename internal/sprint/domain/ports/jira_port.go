@@ -1,19 +1,45 @@
 package ports
 
 import (
+	"time"
+
 	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
 )
 
 // JiraIssue represents a Jira issue in the ports layer
 type JiraIssue struct {
-	Key         string
-	Summary     string
-	Assignee    string
-	Status      string
-	StoryPoints *float64
-	IssueType   string
-	Labels      []string
-	Changelog   JiraChangelog
+	Key      string
+	Summary  string
+	Assignee string
+	// AssigneeAccountID is the Jira account ID of the assignee; Assignee is for display
+	AssigneeAccountID string
+	Status            string
+	// StatusCategory is the category of the status, whatever the language of its name
+	StatusCategory domain.StatusCategory
+	StoryPoints    *float64
+	IssueType      string
+	// IssueTypeSubtask marks an issue whose type is at the sub-task level of the hierarchy
+	IssueTypeSubtask bool
+	Labels           []string
+	Sprints          []JiraSprint
+	Changelog        JiraChangelog
+	// Created and ResolutionDate are the issue's timestamps as returned by Jira
+	Created        string
+	ResolutionDate string
+	// OriginalEstimate and RemainingEstimate are the time tracking estimates in seconds
+	OriginalEstimate  *int64
+	RemainingEstimate *int64
+	// ChangelogUnavailable marks an issue whose changelog Jira withheld for lack of permission
+	ChangelogUnavailable bool
+}
+
+// JiraSprint represents a sprint an issue belongs to, with its dates as returned by Jira
+type JiraSprint struct {
+	ID        int
+	Name      string
+	State     string
+	StartDate string
+	EndDate   string
 }
 
 // JiraChangelog represents the changelog of a Jira issue
@@ -30,8 +56,13 @@ type JiraChangeHistory struct {
 // JiraChangeItem represents a single change in a Jira issue's history
 type JiraChangeItem struct {
 	Field      string
+	From       string
 	FromString string
+	To         string
 	ToString   string
+	// FromCategory and ToCategory are the categories of the statuses of a status change
+	FromCategory domain.StatusCategory
+	ToCategory   domain.StatusCategory
 }
 
 // JiraPort defines the interface for Jira integration
@@ -45,3 +76,66 @@ type JiraPort interface {
 	// GetTeamIssues retrieves all issues for a team
 	GetTeamIssues(team *domain.Team) ([]JiraIssue, error)
 }
+
+// JiraComment represents a comment on a Jira issue with its body as plain text
+type JiraComment struct {
+	ID   string
+	Body string
+}
+
+// JiraCommentPort defines the interface for managing Jira issue comments
+type JiraCommentPort interface {
+	// GetComments retrieves the comments of an issue
+	GetComments(issueKey string) ([]JiraComment, error)
+	// AddComment adds a plain text comment to an issue
+	AddComment(issueKey, body string) error
+	// UpdateComment replaces the body of an existing comment
+	UpdateComment(issueKey, commentID, body string) error
+}
+
+// JiraWorklog represents a worklog entry of a Jira issue with its comment as plain text
+type JiraWorklog struct {
+	ID               string
+	Comment          string
+	Started          time.Time
+	TimeSpentSeconds int
+}
+
+// JiraWorklogPort defines the interface for managing Jira issue worklogs
+type JiraWorklogPort interface {
+	// GetWorklogs retrieves the worklogs of an issue
+	GetWorklogs(issueKey string) ([]JiraWorklog, error)
+	// AddWorklog logs time on an issue
+	AddWorklog(issueKey string, worklog JiraWorklog) error
+	// UpdateWorklog replaces the time, start and comment of an existing worklog
+	UpdateWorklog(issueKey string, worklog JiraWorklog) error
+	// DeleteWorklog removes a worklog from an issue
+	DeleteWorklog(issueKey, worklogID string) error
+}
+
+// JiraReleasePort defines the interface for allocating work by Jira fix version
+type JiraReleasePort interface {
+	// GetIssuesForFixVersion retrieves all issues of a project released in a fix version
+	GetIssuesForFixVersion(project, fixVersion string) ([]JiraIssue, error)
+	// GetRelease retrieves a fix version of a project with its start and release dates
+	GetRelease(project, fixVersion string) (*domain.Release, error)
+}
+
+// JiraSprintListPort defines the interface for discovering the sprints of a project
+type JiraSprintListPort interface {
+	// GetSprintsBetween returns the sprints of the project that started within [from, to)
+	GetSprintsBetween(project string, from, to time.Time) ([]domain.Sprint, error)
+}
+
+// JiraScopePort defines the interface for finding the issues pulled from a sprint, which the
+// sprint's own issue search no longer returns
+type JiraScopePort interface {
+	// GetIssuesUpdatedSince retrieves the issues of the project updated since the given time
+	GetIssuesUpdatedSince(project string, since time.Time) ([]JiraIssue, error)
+}
+
+// JiraUserPort defines the interface for looking up Jira users
+type JiraUserPort interface {
+	// SearchUsers finds the users whose display name or email match the query
+	SearchUsers(query string) ([]domain.JiraUser, error)
+}
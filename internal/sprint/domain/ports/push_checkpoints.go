@@ -0,0 +1,13 @@
+package ports
+
+import "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+
+// PushCheckpointStore persists the checkpoints of pushes of allocations to Jira
+type PushCheckpointStore interface {
+	// Load returns the checkpoint of the push, or nil when there is none
+	Load(project, sprint string, mode domain.PushMode) (*domain.PushCheckpoint, error)
+	// Save stores the checkpoint, replacing an earlier one of the same push
+	Save(checkpoint *domain.PushCheckpoint) error
+	// Delete removes the checkpoint of the push, if any
+	Delete(project, sprint string, mode domain.PushMode) error
+}
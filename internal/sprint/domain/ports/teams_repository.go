@@ -0,0 +1,12 @@
+package ports
+
+import "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+
+// TeamsRepository stores the team of each project
+type TeamsRepository interface {
+	// Load returns the teams of every project, or an error wrapping domain.ErrNoTeams when
+	// none were set up
+	Load() (domain.TeamMap, error)
+	// Save replaces the stored teams
+	Save(teams domain.TeamMap) error
+}
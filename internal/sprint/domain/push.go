@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PushMode represents how allocations are written back to Jira
+type PushMode string
+
+const (
+	// PushModeComment posts the allocation as a structured issue comment
+	PushModeComment PushMode = "comment"
+	// PushModeWorklog logs the allocated hours as a worklog entry per allocation row
+	PushModeWorklog PushMode = "worklog"
+)
+
+// AllocationCommentMarker identifies comments created by assetcap so that
+// re-pushes update them instead of stacking duplicates
+const AllocationCommentMarker = "[assetcap:allocation]"
+
+// AllocationWorklogMarker starts the comment of worklogs created by assetcap, followed by the
+// sprint, assignee and work type of the row
+const AllocationWorklogMarker = "[assetcap:worklog]"
+
+// AllocationWorklogKeyMarker starts the line of a worklog comment holding the WorklogKey of
+// the row it logs, so that re-pushes update the row's worklog
+const AllocationWorklogKeyMarker = "[assetcap:key]"
+
+// WorklogKey identifies the worklog of an allocation row across pushes by stable IDs, so a
+// renamed sprint or assignee updates the worklog instead of logging the hours twice
+type WorklogKey struct {
+	IssueKey string
+	// Sprint is the sprint ID, or the name of the allocated period when it has no ID
+	Sprint string
+	// Assignee is the Jira account ID of the assignee, or the name when it is not known
+	Assignee string
+}
+
+// AllocationWorklogKey returns the key of the worklog of an allocation row
+func AllocationWorklogKey(allocation IssueAllocation) WorklogKey {
+	key := WorklogKey{IssueKey: allocation.IssueKey, Sprint: allocation.Sprint, Assignee: allocation.Assignee}
+	if allocation.SprintID != 0 {
+		key.Sprint = strconv.Itoa(allocation.SprintID)
+	}
+	if allocation.AssigneeAccountID != "" {
+		key.Assignee = allocation.AssigneeAccountID
+	}
+	return key
+}
+
+// String renders the key as the line of the worklog comment holding it, e.g.
+// "[assetcap:key] TEST-1 | 42 | 5b10ac8d82e05b22cc7d4ef5"
+func (k WorklogKey) String() string {
+	return fmt.Sprintf("%s %s | %s | %s", AllocationWorklogKeyMarker, k.IssueKey, k.Sprint, k.Assignee)
+}
+
+// ParseWorklogKey finds the key in the comment of a worklog, reporting false for worklogs
+// without one
+func ParseWorklogKey(comment string) (WorklogKey, bool) {
+	for _, line := range strings.Split(comment, "\n") {
+		rest, ok := strings.CutPrefix(strings.TrimSpace(line), AllocationWorklogKeyMarker)
+		if !ok {
+			continue
+		}
+		parts := strings.Split(rest, "|")
+		if len(parts) != 3 {
+			return WorklogKey{}, false
+		}
+		return WorklogKey{
+			IssueKey: strings.TrimSpace(parts[0]),
+			Sprint:   strings.TrimSpace(parts[1]),
+			Assignee: strings.TrimSpace(parts[2]),
+		}, true
+	}
+	return WorklogKey{}, false
+}
+
+// PushAllocationsInput represents the input parameters for pushing allocations to Jira
+type PushAllocationsInput struct {
+	Project  string
+	Sprint   string
+	Override string
+	Mode     PushMode
+	DryRun   bool
+	// Heuristics fills in untracked hours, as for AllocationInput
+	Heuristics AllocationHeuristics
+	// ChunkSize is the number of rows pushed between two checkpoints; zero uses
+	// DefaultPushChunkSize
+	ChunkSize int
+	// Restart discards the checkpoint of an earlier push that stopped midway and pushes
+	// every row again
+	Restart bool
+	// Export, when set, is a Jira JSON or CSV export allocated instead of querying Jira; the
+	// allocation is still written to Jira
+	Export string
+}
+
+// PushFailure describes an issue that could not be pushed
+type PushFailure struct {
+	IssueKey string
+	Err      error
+}
+
+// PushResult summarizes the outcome of pushing allocations to Jira
+type PushResult struct {
+	Created  []string
+	Updated  []string
+	Failures []PushFailure
+	// Resumed lists the issues left alone because an earlier push that stopped midway
+	// already wrote them
+	Resumed []string
+	// Remaining lists the issues not pushed because Jira rate limited the push; running
+	// the push again resumes with them
+	Remaining []string
+}
+
+// DefaultPushChunkSize is the number of allocation rows pushed between two checkpoints
+const DefaultPushChunkSize = 50
+
+// ErrRateLimited is matched by errors of Jira requests rejected for exceeding the rate limit
+var ErrRateLimited = errors.New("rate limited by Jira")
+
+// PushCheckpoint records the allocation rows a push wrote to Jira, so that a push stopped
+// midway resumes with the rows it did not write
+type PushCheckpoint struct {
+	Project string   `json:"project"`
+	Sprint  string   `json:"sprint"`
+	Mode    PushMode `json:"mode"`
+	// Pushed maps the idempotency key of every row written to the digest of what was written
+	Pushed    map[string]string `json:"pushed"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// NewPushCheckpoint creates an empty checkpoint of a push
+func NewPushCheckpoint(project, sprint string, mode PushMode) *PushCheckpoint {
+	return &PushCheckpoint{Project: project, Sprint: sprint, Mode: mode, Pushed: make(map[string]string)}
+}
+
+// Written reports whether the row of the key was pushed with the same content
+func (c *PushCheckpoint) Written(key, digest string) bool {
+	return c.Pushed[key] == digest
+}
+
+// Record marks the row of the key as pushed with the content of the digest
+func (c *PushCheckpoint) Record(key, digest string) {
+	if c.Pushed == nil {
+		c.Pushed = make(map[string]string)
+	}
+	c.Pushed[key] = digest
+}
+
+// PushDigest fingerprints the content pushed for a row, so a row whose allocation changed
+// since it was pushed is pushed again
+func PushDigest(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:8])
+}
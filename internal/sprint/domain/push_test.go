@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorklogKey(t *testing.T) {
+	key := AllocationWorklogKey(IssueAllocation{Sprint: "Sprint 1", SprintID: 42, IssueKey: "TEST-1", Assignee: "Jane", AssigneeAccountID: "acc-jane"})
+	assert.Equal(t, WorklogKey{IssueKey: "TEST-1", Sprint: "42", Assignee: "acc-jane"}, key)
+	assert.Equal(t, "[assetcap:key] TEST-1 | 42 | acc-jane", key.String())
+
+	parsed, ok := ParseWorklogKey("[assetcap:worklog] Sprint 1 | Jane | none\nAllocation: 10.00%\n" + key.String())
+	assert.True(t, ok)
+	assert.Equal(t, key, parsed)
+
+	assert.Equal(t, WorklogKey{IssueKey: "TEST-1", Sprint: "Sprint 1", Assignee: "Jane"},
+		AllocationWorklogKey(IssueAllocation{Sprint: "Sprint 1", IssueKey: "TEST-1", Assignee: "Jane"}), "names stand in for unknown IDs")
+
+	_, ok = ParseWorklogKey("[assetcap:worklog] Sprint 1 | Jane | none")
+	assert.False(t, ok)
+}
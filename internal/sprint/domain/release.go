@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// Release represents a Jira fix version whose start and release dates bound the work
+// attributed to it. A zero date leaves that side of the window open.
+type Release struct {
+	Name        string
+	StartDate   time.Time
+	ReleaseDate time.Time
+	Released    bool
+}
+
+// Window clips a time range to the release dates. It reports false when the range lies
+// entirely outside the release window.
+func (r Release) Window(start, end time.Time) (time.Time, time.Time, bool) {
+	if !r.StartDate.IsZero() && start.Before(r.StartDate) {
+		start = r.StartDate
+	}
+	if !r.ReleaseDate.IsZero() && end.After(r.ReleaseDate) {
+		end = r.ReleaseDate
+	}
+	return start, end, end.After(start)
+}
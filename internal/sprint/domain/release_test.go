@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelease_Window(t *testing.T) {
+	release := Release{
+		Name:        "2024.5",
+		StartDate:   time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		ReleaseDate: time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC),
+	}
+
+	start, end, ok := release.Window(time.Date(2024, 4, 28, 0, 0, 0, 0, time.UTC), time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, release.StartDate, start)
+	assert.Equal(t, time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC), end)
+
+	start, end, ok = release.Window(time.Date(2024, 5, 30, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2024, 5, 30, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, release.ReleaseDate, end)
+
+	_, _, ok = release.Window(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC))
+	assert.False(t, ok)
+
+	open := Release{Name: "next"}
+	start, end, ok = open.Window(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC), end)
+}
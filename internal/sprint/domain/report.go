@@ -0,0 +1,41 @@
+package domain
+
+// ReportTemplate is a Go template rendering a capitalization report
+type ReportTemplate struct {
+	Name string
+	Body string
+	// HTML renders the template with contextual HTML escaping
+	HTML bool
+}
+
+// ReportRow is an allocation in a capitalization report, tagged with the team it was computed for
+type ReportRow struct {
+	Team string
+	IssueAllocation
+}
+
+// ReportMetric is a labelled headline value of a capitalization report
+type ReportMetric struct {
+	Label string
+	Value string
+}
+
+// AssetCapitalization holds the capitalization summary of a single asset
+type AssetCapitalization struct {
+	AssetName string
+	// Status is the status the asset had at the end of the period, as far as its completed
+	// work tells, or its latest status when none was completed
+	Status  string
+	Summary CapitalizationSummary
+}
+
+// CapitalizationReport is the computed report model handed to report templates
+type CapitalizationReport struct {
+	KPIs    CapitalizationKPIs
+	Metrics []ReportMetric
+	Assets  []AssetCapitalization
+	Rows    []ReportRow
+	// DevelopmentTrend and MaintenanceTrend are nil when there is no previous period
+	DevelopmentTrend *float64
+	MaintenanceTrend *float64
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// AssetRetirements maps the names of retired assets to the date they were taken out of service.
+// Work started after that date is no longer attributed to the asset.
+type AssetRetirements map[string]time.Time
+
+// Apply detaches an allocation row from its asset when the asset was retired before the work
+// started, returning the detached work and false when the row keeps its asset
+func (r AssetRetirements) Apply(allocation *IssueAllocation) (RetiredAssetWork, bool) {
+	if allocation.AssetName == "" || allocation.DateStarted.IsZero() {
+		return RetiredAssetWork{}, false
+	}
+	for name, retired := range r {
+		if !matchesAsset(allocation.AssetName, name) || !allocation.DateStarted.After(retired) {
+			continue
+		}
+		work := RetiredAssetWork{
+			IssueKey:  allocation.IssueKey,
+			Assignee:  allocation.Assignee,
+			AssetName: allocation.AssetName,
+			RetiredOn: retired,
+			Started:   allocation.DateStarted,
+			Hours:     allocation.Hours,
+		}
+		allocation.AssetName = ""
+		allocation.AssetURL = ""
+		allocation.CostCenter = ""
+		allocation.GLAccount = ""
+		return work, true
+	}
+	return RetiredAssetWork{}, false
+}
+
+// RetiredAssetWork records an allocation row detached from an asset retired before the work started
+type RetiredAssetWork struct {
+	IssueKey  string
+	Assignee  string
+	AssetName string
+	RetiredOn time.Time
+	Started   time.Time
+	Hours     float64
+}
+
+// Reason describes why the work is not attributed to the asset, e.g.
+// "cap-asset-booking retired on 2024-06-30: work started 2024-07-02 not attributed"
+func (w RetiredAssetWork) Reason() string {
+	return fmt.Sprintf("%s retired on %s: work started %s not attributed", w.AssetName, w.RetiredOn.Format("2006-01-02"), w.Started.Format("2006-01-02"))
+}
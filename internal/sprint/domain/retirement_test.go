@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssetRetirements_Apply(t *testing.T) {
+	retired := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	retirements := AssetRetirements{"booking": retired}
+
+	before := IssueAllocation{IssueKey: "FN-1", AssetName: "cap-asset-booking", DateStarted: retired}
+	_, detached := retirements.Apply(&before)
+	assert.False(t, detached, "work started on the retirement day stays attributed")
+	assert.Equal(t, "cap-asset-booking", before.AssetName)
+
+	other := IssueAllocation{IssueKey: "FN-2", AssetName: "cap-asset-search", DateStarted: retired.AddDate(0, 0, 2)}
+	_, detached = retirements.Apply(&other)
+	assert.False(t, detached)
+
+	after := IssueAllocation{
+		IssueKey:    "FN-3",
+		Assignee:    "alice",
+		AssetName:   "cap-asset-booking",
+		AssetURL:    "https://wiki/booking",
+		DateStarted: retired.AddDate(0, 0, 2),
+		Hours:       16,
+	}
+	work, detached := retirements.Apply(&after)
+	assert.True(t, detached)
+	assert.Empty(t, after.AssetName)
+	assert.Empty(t, after.AssetURL)
+	assert.Equal(t, RetiredAssetWork{
+		IssueKey:  "FN-3",
+		Assignee:  "alice",
+		AssetName: "cap-asset-booking",
+		RetiredOn: retired,
+		Started:   retired.AddDate(0, 0, 2),
+		Hours:     16,
+	}, work)
+	assert.Equal(t, "cap-asset-booking retired on 2024-06-30: work started 2024-07-02 not attributed", work.Reason())
+}
@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// IdleMember is a team member credited no hours in the allocated period. It more often means
+// the member's Jira name or account matched no assignee than that they did no work.
+type IdleMember struct {
+	Member string
+	// Suggestions are the period's assignees matching no member who look like this one,
+	// candidates to add to the member's aliases
+	Suggestions []string
+}
+
+// Reason describes the idle member, suggesting the aliases that would match them
+func (m IdleMember) Reason() string {
+	if len(m.Suggestions) == 0 {
+		return fmt.Sprintf("team member %s has no attributed hours", m.Member)
+	}
+	return fmt.Sprintf("team member %s has no attributed hours; add %s to their aliases if it is them", m.Member, strings.Join(m.Suggestions, " or "))
+}
+
+// IdleMembers returns the team members without hours in hoursByMember, in team order, with
+// the unmatched assignees resembling them or one of their aliases
+func (t *Team) IdleMembers(hoursByMember map[string]float64, unmatched []string) []IdleMember {
+	var idle []IdleMember
+	for _, member := range t.Team {
+		if hoursByMember[member] > 0 {
+			continue
+		}
+		names := append([]string{member}, t.Aliases[member]...)
+		var suggestions []string
+		for _, assignee := range unmatched {
+			for _, name := range names {
+				if resemblesName(name, assignee) {
+					suggestions = append(suggestions, assignee)
+					break
+				}
+			}
+		}
+		idle = append(idle, IdleMember{Member: member, Suggestions: suggestions})
+	}
+	return idle
+}
+
+// UnmatchedAssignees returns the names the issues were ever assigned to that resolve to no
+// team member, sorted
+func (t *Team) UnmatchedAssignees(issues []JiraIssue) []string {
+	seen := make(map[string]bool)
+	for i := range issues {
+		for _, period := range issues[i].AssignmentPeriods() {
+			if period.Assignee == "" {
+				continue
+			}
+			if _, isMember := t.ResolveAssignee(period.AccountID, period.Assignee); !isMember {
+				seen[period.Assignee] = true
+			}
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// resemblesName reports whether an assignee looks like the same person as a member name: the
+// names of one are all part of the other, as in "Alice" and "Alice Smith", one is a login of
+// the other's initial and last name, as in "asmith", or they differ by a typo. Email addresses
+// and logins such as alice.smith@example.com compare by their names.
+func resemblesName(name, assignee string) bool {
+	a, b := personNames(name), personNames(assignee)
+	if len(a) == 0 || len(b) == 0 {
+		return false
+	}
+	if containsNames(a, b) || containsNames(b, a) || isLogin(a, b) || isLogin(b, a) {
+		return true
+	}
+	joinedA, joinedB := strings.Join(a, " "), strings.Join(b, " ")
+	limit := min(max(len(joinedA)/4, 1), 3)
+	return editDistance(joinedA, joinedB) <= limit
+}
+
+// personNames splits a name, email address or login into its lower-cased names
+func personNames(name string) []string {
+	name, _, _ = strings.Cut(strings.ToLower(name), "@")
+	return strings.FieldsFunc(name, func(r rune) bool {
+		return r == ' ' || r == '.' || r == '_' || r == '-'
+	})
+}
+
+// isLogin reports whether login is a single name made of the first initial and the last name
+// of names, as in "asmith" for Alice Smith
+func isLogin(names, login []string) bool {
+	if len(names) < 2 || len(login) != 1 {
+		return false
+	}
+	first, last := []rune(names[0]), names[len(names)-1]
+	return login[0] == string(first[0])+last
+}
+
+// containsNames reports whether every name of part is among names
+func containsNames(names, part []string) bool {
+	for _, name := range part {
+		if !slices.Contains(names, name) {
+			return false
+		}
+	}
+	return true
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeam_IdleMembers(t *testing.T) {
+	team := Team{
+		Team:    []string{"Alice Smith", "Bob", "Carol Jones", "Dave"},
+		Aliases: map[string][]string{"Carol Jones": {"cjones"}},
+	}
+	unmatched := []string{"asmith", "Bobb", "c.jones", "Zoe"}
+
+	idle := team.IdleMembers(map[string]float64{"Dave": 12, "Bob": 0}, unmatched)
+
+	assert.Equal(t, []IdleMember{
+		{Member: "Alice Smith", Suggestions: []string{"asmith"}},
+		{Member: "Bob", Suggestions: []string{"Bobb"}},
+		{Member: "Carol Jones", Suggestions: []string{"c.jones"}},
+	}, idle)
+}
+
+func TestIdleMember_Reason(t *testing.T) {
+	assert.Equal(t, "team member Bob has no attributed hours", IdleMember{Member: "Bob"}.Reason())
+	assert.Equal(t, "team member Bob has no attributed hours; add Bobby or bob@example.com to their aliases if it is them",
+		IdleMember{Member: "Bob", Suggestions: []string{"Bobby", "bob@example.com"}}.Reason())
+}
+
+func TestTeam_UnmatchedAssignees(t *testing.T) {
+	team := Team{Team: []string{"alice"}, AccountIDs: map[string]string{"alice": "acc-1"}}
+	issues := []JiraIssue{
+		{Key: "FN-1", Fields: JiraFields{Assignee: JiraAssignee{DisplayName: "Alice", AccountID: "acc-1"}}},
+		{Key: "FN-2", Fields: JiraFields{Assignee: JiraAssignee{DisplayName: "zoe"}}},
+		{Key: "FN-3", Changelog: JiraChangelog{Histories: []JiraChangeHistory{
+			{Created: "2024-03-18T09:00:00.000+0000", Items: []JiraChangeItem{{Field: "assignee", FromString: "bob", ToString: "zoe"}}},
+		}}},
+		{Key: "FN-4"},
+	}
+
+	assert.Equal(t, []string{"bob", "zoe"}, team.UnmatchedAssignees(issues))
+}
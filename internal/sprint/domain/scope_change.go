@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ScopeChangeKind tells whether an issue entered or left a sprint
+type ScopeChangeKind string
+
+const (
+	// ScopeAdded marks an issue added to a sprint after it started
+	ScopeAdded ScopeChangeKind = "added"
+	// ScopeRemoved marks an issue pulled from a sprint after it started
+	ScopeRemoved ScopeChangeKind = "removed"
+)
+
+// ScopeChange is an issue added to or removed from a sprint while it ran
+type ScopeChange struct {
+	IssueKey string          `json:"issueKey"`
+	Summary  string          `json:"summary"`
+	Change   ScopeChangeKind `json:"change"`
+	At       time.Time       `json:"at"`
+}
+
+// IsSprintChange checks if this change item represents a change of the issue's sprints
+func (i *JiraChangeItem) IsSprintChange() bool {
+	return strings.EqualFold(i.Field, "sprint")
+}
+
+// sprintMembership reports whether the sprint is among the sprints before and after the change.
+// The raw values list sprint IDs, matched when the sprint's ID is known; otherwise the names are.
+func (i *JiraChangeItem) sprintMembership(sprint JiraSprint) (before, after bool) {
+	if sprint.ID != 0 && (i.From != "" || i.To != "") {
+		id := strconv.Itoa(sprint.ID)
+		return listContains(i.From, id), listContains(i.To, id)
+	}
+	return listContains(i.FromString, sprint.Name), listContains(i.ToString, sprint.Name)
+}
+
+// listContains reports whether a comma separated Jira list holds value
+func listContains(list, value string) bool {
+	for _, entry := range strings.Split(list, ",") {
+		if strings.TrimSpace(entry) == value && value != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// ScopeChanges returns the times the issue was added to or removed from the sprint while it ran,
+// in order. Sprint changes before the sprint started are planning, and those after it ended are
+// the carry-over of unfinished work, so neither counts as a change of scope.
+func (i *JiraIssue) ScopeChanges(sprint JiraSprint) []ScopeChange {
+	period, ok := sprint.period()
+	if !ok {
+		return nil
+	}
+
+	var changes []ScopeChange
+	for _, history := range i.Changelog.Histories {
+		created, err := ParseJiraTime(history.Created)
+		if err != nil || !created.After(period.start) || (!period.end.IsZero() && created.After(period.end)) {
+			continue
+		}
+		for _, item := range history.Items {
+			if !item.IsSprintChange() {
+				continue
+			}
+			before, after := item.sprintMembership(sprint)
+			switch {
+			case after && !before:
+				changes = append(changes, ScopeChange{IssueKey: i.Key, Summary: i.Fields.Summary, Change: ScopeAdded, At: created})
+			case before && !after:
+				changes = append(changes, ScopeChange{IssueKey: i.Key, Summary: i.Fields.Summary, Change: ScopeRemoved, At: created})
+			}
+		}
+	}
+	sort.SliceStable(changes, func(a, b int) bool { return changes[a].At.Before(changes[b].At) })
+	return changes
+}
+
+// inSprintAt reports whether the issue belonged to the sprint at t, replaying the sprint
+// changes made after t back from its current sprints
+func (i *JiraIssue) inSprintAt(sprint JiraSprint, t time.Time) bool {
+	for _, history := range i.Changelog.Histories {
+		created, err := ParseJiraTime(history.Created)
+		if err != nil || !created.After(t) {
+			continue
+		}
+		for _, item := range history.Items {
+			if item.IsSprintChange() {
+				before, _ := item.sprintMembership(sprint)
+				return before
+			}
+		}
+	}
+	return i.inSprint(sprint)
+}
+
+// inSprint reports whether the sprint is among the issue's current sprints, by ID when both
+// are known and by name otherwise
+func (i *JiraIssue) inSprint(sprint JiraSprint) bool {
+	for _, current := range i.Fields.Sprints {
+		if current.ID != 0 && sprint.ID != 0 {
+			if current.ID == sprint.ID {
+				return true
+			}
+			continue
+		}
+		if current.Name == sprint.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// SprintScope summarizes how the issues of a sprint changed while it ran
+type SprintScope struct {
+	Sprint    string    `json:"sprint"`
+	StartDate time.Time `json:"startDate"`
+	// EndDate is zero for a sprint without an end date
+	EndDate time.Time `json:"endDate"`
+	// Committed counts the issues in the sprint when it started, Current those in it now
+	Committed int `json:"committed"`
+	Added     int `json:"added"`
+	Removed   int `json:"removed"`
+	Current   int `json:"current"`
+	// ChurnPercent is the issues added and removed as a percentage of the committed ones
+	ChurnPercent float64       `json:"churnPercent"`
+	Changes      []ScopeChange `json:"changes"`
+}
+
+// SummarizeScope replays the sprint changes of the issues that were ever in the sprint. Issues
+// added and removed again count in both totals.
+func SummarizeScope(sprint JiraSprint, issues []JiraIssue) SprintScope {
+	scope := SprintScope{Sprint: sprint.Name, Changes: []ScopeChange{}}
+	period, ok := sprint.period()
+	if ok {
+		scope.StartDate, scope.EndDate = period.start, period.end
+	}
+
+	for i := range issues {
+		issue := &issues[i]
+		changes := issue.ScopeChanges(sprint)
+		if ok && issue.inSprintAt(sprint, period.start) {
+			scope.Committed++
+		}
+		if issue.inSprint(sprint) {
+			scope.Current++
+		}
+		for _, change := range changes {
+			if change.Change == ScopeAdded {
+				scope.Added++
+			} else {
+				scope.Removed++
+			}
+		}
+		scope.Changes = append(scope.Changes, changes...)
+	}
+
+	sort.SliceStable(scope.Changes, func(a, b int) bool { return scope.Changes[a].At.Before(scope.Changes[b].At) })
+	if scope.Committed > 0 {
+		scope.ChurnPercent = float64(scope.Added+scope.Removed) * 100 / float64(scope.Committed)
+	}
+	return scope
+}
+
+// DescribeScopeChanges renders the scope changes of an issue for the allocation CSV, such as
+// "added 2024-03-20; removed 2024-03-22"
+func DescribeScopeChanges(changes []ScopeChange, locale Locale) string {
+	parts := make([]string, 0, len(changes))
+	for _, change := range changes {
+		parts = append(parts, string(change.Change)+" "+locale.Date(change.At))
+	}
+	return strings.Join(parts, "; ")
+}
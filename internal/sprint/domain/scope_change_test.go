@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scopeSprint runs from March 18 to March 29, 2024
+var scopeSprint = JiraSprint{ID: 42, Name: "Sprint 1", StartDate: "2024-03-18T09:00:00.000Z", EndDate: "2024-03-29T17:00:00.000Z"}
+
+// sprintChange is a sprint field change of the changelog at created
+func sprintChange(created, from, fromString, to, toString string) JiraChangeHistory {
+	return JiraChangeHistory{Created: created, Items: []JiraChangeItem{{Field: "Sprint", From: from, FromString: fromString, To: to, ToString: toString}}}
+}
+
+func TestJiraIssue_ScopeChanges(t *testing.T) {
+	issue := JiraIssue{
+		Key:    "FN-1",
+		Fields: JiraFields{Summary: "Checkout", Sprints: []JiraSprint{scopeSprint}},
+		Changelog: JiraChangelog{Histories: []JiraChangeHistory{
+			sprintChange("2024-03-15T10:00:00.000+0000", "", "", "42", "Sprint 1"),
+			sprintChange("2024-03-19T10:00:00.000+0000", "42", "Sprint 1", "", ""),
+			{Created: "2024-03-20T10:00:00.000+0000", Items: []JiraChangeItem{{Field: "status", FromString: "To Do", ToString: "In Progress"}}},
+			sprintChange("2024-03-21T10:00:00.000+0000", "41", "Sprint 0", "41, 42", "Sprint 0, Sprint 1"),
+			sprintChange("2024-03-29T18:00:00.000+0000", "42", "Sprint 1", "42, 43", "Sprint 1, Sprint 2"),
+		}},
+	}
+
+	assert.Equal(t, []ScopeChange{
+		{IssueKey: "FN-1", Summary: "Checkout", Change: ScopeRemoved, At: time.Date(2024, 3, 19, 10, 0, 0, 0, time.UTC)},
+		{IssueKey: "FN-1", Summary: "Checkout", Change: ScopeAdded, At: time.Date(2024, 3, 21, 10, 0, 0, 0, time.UTC)},
+	}, issue.ScopeChanges(scopeSprint), "planning before the start and carry-over after the end are no scope change")
+}
+
+func TestJiraIssue_ScopeChangesByName(t *testing.T) {
+	sprint := JiraSprint{Name: "Sprint 1", StartDate: "2024-03-18T09:00:00.000Z"}
+	issue := JiraIssue{
+		Key: "FN-2",
+		Changelog: JiraChangelog{Histories: []JiraChangeHistory{
+			sprintChange("2024-03-20T10:00:00.000+0000", "", "", "", "Sprint 1"),
+		}},
+	}
+
+	changes := issue.ScopeChanges(sprint)
+	require.Len(t, changes, 1)
+	assert.Equal(t, ScopeAdded, changes[0].Change)
+
+	assert.Empty(t, issue.ScopeChanges(JiraSprint{Name: "Sprint 1"}), "a sprint without a start date has no scope changes")
+}
+
+func TestSummarizeScope(t *testing.T) {
+	committed := JiraIssue{Key: "FN-1", Fields: JiraFields{Sprints: []JiraSprint{scopeSprint}}}
+	added := JiraIssue{
+		Key:    "FN-2",
+		Fields: JiraFields{Summary: "Urgent fix", Sprints: []JiraSprint{scopeSprint}},
+		Changelog: JiraChangelog{Histories: []JiraChangeHistory{
+			sprintChange("2024-03-20T10:00:00.000+0000", "", "", "42", "Sprint 1"),
+		}},
+	}
+	removed := JiraIssue{
+		Key:    "FN-3",
+		Fields: JiraFields{Summary: "Nice to have"},
+		Changelog: JiraChangelog{Histories: []JiraChangeHistory{
+			sprintChange("2024-03-14T10:00:00.000+0000", "", "", "42", "Sprint 1"),
+			sprintChange("2024-03-19T10:00:00.000+0000", "42", "Sprint 1", "", ""),
+		}},
+	}
+	bounced := JiraIssue{
+		Key:    "FN-4",
+		Fields: JiraFields{Summary: "Spike"},
+		Changelog: JiraChangelog{Histories: []JiraChangeHistory{
+			sprintChange("2024-03-21T10:00:00.000+0000", "", "", "42", "Sprint 1"),
+			sprintChange("2024-03-22T10:00:00.000+0000", "42", "Sprint 1", "", ""),
+		}},
+	}
+
+	scope := SummarizeScope(scopeSprint, []JiraIssue{committed, added, removed, bounced})
+	assert.Equal(t, "Sprint 1", scope.Sprint)
+	assert.Equal(t, time.Date(2024, 3, 18, 9, 0, 0, 0, time.UTC), scope.StartDate)
+	assert.Equal(t, 2, scope.Committed)
+	assert.Equal(t, 2, scope.Added)
+	assert.Equal(t, 2, scope.Removed)
+	assert.Equal(t, 2, scope.Current)
+	assert.Equal(t, 200.0, scope.ChurnPercent)
+	require.Len(t, scope.Changes, 4)
+	assert.Equal(t, []string{"FN-3", "FN-2", "FN-4", "FN-4"}, []string{scope.Changes[0].IssueKey, scope.Changes[1].IssueKey, scope.Changes[2].IssueKey, scope.Changes[3].IssueKey})
+}
+
+func TestSummarizeScope_NoCommittedIssues(t *testing.T) {
+	scope := SummarizeScope(JiraSprint{Name: "Sprint 1"}, nil)
+	assert.Zero(t, scope.Committed)
+	assert.Zero(t, scope.ChurnPercent)
+	assert.NotNil(t, scope.Changes)
+}
+
+func TestDescribeScopeChanges(t *testing.T) {
+	changes := []ScopeChange{
+		{Change: ScopeAdded, At: time.Date(2024, 3, 20, 10, 0, 0, 0, time.UTC)},
+		{Change: ScopeRemoved, At: time.Date(2024, 3, 22, 10, 0, 0, 0, time.UTC)},
+	}
+	assert.Equal(t, "added 2024-03-20; removed 2024-03-22", DescribeScopeChanges(changes, Locale{}))
+	assert.Empty(t, DescribeScopeChanges(nil, Locale{}))
+}
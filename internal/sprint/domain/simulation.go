@@ -0,0 +1,14 @@
+package domain
+
+// SimulatedIssue pairs the synthetic status timeline of an issue with the allocation computed for it
+type SimulatedIssue struct {
+	Timeline   []string
+	Allocation IssueAllocation
+}
+
+// SimulationResult holds the outcome of running the allocation engine against a synthetic scenario
+type SimulationResult struct {
+	Scenario    string
+	Description string
+	Issues      []SimulatedIssue
+}
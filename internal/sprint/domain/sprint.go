@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -57,16 +59,116 @@ func (s *Sprint) GetRemainingTime() time.Duration {
 // Team represents a group of team members
 type Team struct {
 	Team []string `json:"team"`
+	// Aliases maps a canonical member name to the other names or account IDs
+	// the same person may appear under in Jira
+	Aliases map[string][]string `json:"aliases,omitempty"`
+	// AccountIDs maps a canonical member name to the member's Jira account ID. Display
+	// names are neither unique nor stable, so a known account ID takes precedence.
+	AccountIDs map[string]string `json:"account_ids,omitempty"`
+	// Capacity maps a canonical member name to the fraction of full time they work, e.g. 0.5;
+	// members without one work full time
+	Capacity map[string]float64 `json:"capacity,omitempty"`
+	// Holidays are the dates, e.g. 2024-12-25, the team does not work besides the holidays of
+	// the configured business calendar
+	Holidays []string `json:"holidays,omitempty"`
 }
 
 // IsTeamMember checks if a person is a member of the team
 func (t *Team) IsTeamMember(person string) bool {
+	_, ok := t.ResolveMember(person)
+	return ok
+}
+
+// ResolveMember returns the canonical member name for a person, matching
+// either the member name itself or one of its configured aliases
+func (t *Team) ResolveMember(person string) (string, bool) {
 	for _, member := range t.Team {
 		if member == person {
-			return true
+			return member, true
+		}
+	}
+
+	normalized := normalizeName(person)
+	if normalized == "" {
+		return "", false
+	}
+	for _, member := range t.Team {
+		if normalizeName(member) == normalized {
+			return member, true
+		}
+		for _, alias := range t.Aliases[member] {
+			if normalizeName(alias) == normalized {
+				return member, true
+			}
+		}
+	}
+	return "", false
+}
+
+// ResolveAssignee returns the canonical member name for a Jira assignee, matching the
+// account ID first. Names only match members without a known account ID, so a namesake
+// with a different account is not mistaken for a member.
+func (t *Team) ResolveAssignee(accountID, name string) (string, bool) {
+	if accountID != "" {
+		for _, member := range t.Team {
+			if t.AccountIDs[member] == accountID {
+				return member, true
+			}
+		}
+		if member, ok := t.ResolveMember(accountID); ok {
+			return member, true
+		}
+	}
+
+	member, ok := t.ResolveMember(name)
+	if !ok || (accountID != "" && t.AccountIDs[member] != "") {
+		return "", false
+	}
+	return member, true
+}
+
+// UnknownAliasTargets returns the alias keys that do not refer to a team member
+func (t *Team) UnknownAliasTargets() []string {
+	var unknown []string
+	for canonical := range t.Aliases {
+		found := false
+		for _, member := range t.Team {
+			if member == canonical {
+				found = true
+				break
+			}
+		}
+		if !found {
+			unknown = append(unknown, canonical)
 		}
 	}
-	return false
+	sort.Strings(unknown)
+	return unknown
+}
+
+// normalizeName lowercases a name and collapses whitespace so that formatting
+// differences do not prevent a match
+func normalizeName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// UnmatchedAssignee describes an issue assignee that could not be resolved to a team member
+type UnmatchedAssignee struct {
+	Name   string
+	Issues []string
+}
+
+// AssigneeLintResult holds the findings of checking sprint assignees against a team
+type AssigneeLintResult struct {
+	// Unmatched lists assignees that resolved to no team member
+	Unmatched []UnmatchedAssignee
+	// UnknownAliases lists alias keys that are not team members themselves
+	UnknownAliases []string
+}
+
+// HasFindings reports whether the lint found anything to fix
+func (r *AssigneeLintResult) HasFindings() bool {
+	return len(r.Unmatched) > 0 || len(r.UnknownAliases) > 0
 }
 
 // TeamMap is a mapping of project keys to their respective teams
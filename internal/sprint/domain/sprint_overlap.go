@@ -0,0 +1,184 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sprintStateActive is the state of a sprint in progress
+const sprintStateActive = "active"
+
+// AllocatedSprint returns the issue's entry of the allocated sprint. A numeric reference
+// matching a sprint ID wins over names, as parallel boards can reuse sprint names. Among
+// entries sharing the name, the active one is preferred, then the latest to start.
+func (i *JiraIssue) AllocatedSprint(ref string) (JiraSprint, bool) {
+	ref = strings.TrimSpace(ref)
+	if id, err := strconv.Atoi(ref); err == nil {
+		for _, sprint := range i.Fields.Sprints {
+			if sprint.ID != 0 && sprint.ID == id {
+				return sprint, true
+			}
+		}
+	}
+
+	var match JiraSprint
+	found := false
+	for _, sprint := range i.Fields.Sprints {
+		if sprint.Name != ref {
+			continue
+		}
+		if !found || preferSprint(sprint, match) {
+			match, found = sprint, true
+		}
+	}
+	return match, found
+}
+
+// preferSprint reports whether candidate is a better match than current for a sprint name
+func preferSprint(candidate, current JiraSprint) bool {
+	candidateActive, currentActive := strings.EqualFold(candidate.State, sprintStateActive), strings.EqualFold(current.State, sprintStateActive)
+	if candidateActive != currentActive {
+		return candidateActive
+	}
+	candidateStart, _ := ParseJiraTime(candidate.StartDate)
+	currentStart, _ := ParseJiraTime(current.StartDate)
+	return candidateStart.After(currentStart)
+}
+
+// sameSprint reports whether two entries are the same sprint, listed twice
+func sameSprint(a, b JiraSprint) bool {
+	if a.ID != 0 || b.ID != 0 {
+		return a.ID == b.ID
+	}
+	return a.Name == b.Name && a.StartDate == b.StartDate
+}
+
+// sprintPeriod is the time a sprint runs; a zero end is a sprint still open
+type sprintPeriod struct {
+	start, end time.Time
+}
+
+// period returns when the sprint runs, and false when its start is unknown
+func (s JiraSprint) period() (sprintPeriod, bool) {
+	start, err := ParseJiraTime(s.StartDate)
+	if err != nil {
+		return sprintPeriod{}, false
+	}
+	end, err := ParseJiraTime(s.EndDate)
+	if err != nil || !end.After(start) {
+		end = time.Time{}
+	}
+	return sprintPeriod{start: start, end: end}, true
+}
+
+// contains reports whether the sprint runs at t
+func (p sprintPeriod) contains(t time.Time) bool {
+	return !t.Before(p.start) && (p.end.IsZero() || t.Before(p.end))
+}
+
+// overlaps reports whether the two sprints run at the same time
+func (p sprintPeriod) overlaps(other sprintPeriod) bool {
+	return (other.end.IsZero() || p.start.Before(other.end)) && (p.end.IsZero() || other.start.Before(p.end))
+}
+
+// SprintOverlapShare returns the share of the in-progress window [from, to] credited to the
+// allocated sprint when the issue also belongs to sprints running at the same time, as on
+// boards with parallel sprints or after a sprint is reopened. Time within k overlapping
+// sprints is split evenly, crediting 1/k of it; the rest of the window is credited in full.
+// It returns 1 and no sprints when nothing overlaps the allocated sprint.
+func (i *JiraIssue) SprintOverlapShare(ref string, from, to time.Time) (float64, []string) {
+	allocated, ok := i.AllocatedSprint(ref)
+	if !ok {
+		return 1, nil
+	}
+	period, ok := allocated.period()
+	if !ok {
+		return 1, nil
+	}
+
+	var others []sprintPeriod
+	var names []string
+	for _, sprint := range i.Fields.Sprints {
+		if sameSprint(sprint, allocated) {
+			continue
+		}
+		other, ok := sprint.period()
+		if !ok || !period.overlaps(other) {
+			continue
+		}
+		others = append(others, other)
+		if !containsString(names, sprint.Name) {
+			names = append(names, sprint.Name)
+		}
+	}
+	if len(others) == 0 {
+		return 1, nil
+	}
+
+	weight := func(t time.Time) float64 {
+		if !period.contains(t) {
+			return 1
+		}
+		k := 1
+		for _, other := range others {
+			if other.contains(t) {
+				k++
+			}
+		}
+		return 1 / float64(k)
+	}
+	if !to.After(from) {
+		return weight(from), names
+	}
+
+	points := []time.Time{from, to}
+	for _, p := range append(others, period) {
+		for _, t := range []time.Time{p.start, p.end} {
+			if t.After(from) && t.Before(to) {
+				points = append(points, t)
+			}
+		}
+	}
+	sort.Slice(points, func(a, b int) bool { return points[a].Before(points[b]) })
+
+	credited := 0.0
+	for n := 1; n < len(points); n++ {
+		span := points[n].Sub(points[n-1])
+		if span <= 0 {
+			continue
+		}
+		credited += weight(points[n-1].Add(span/2)) * span.Hours()
+	}
+	return credited / to.Sub(from).Hours(), names
+}
+
+// containsString reports whether values holds value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// SprintOverlap records an issue of the allocated sprint that also belongs to sprints running
+// at the same time, and the hours credited once the overlapping time was split
+type SprintOverlap struct {
+	IssueKey string
+	// Sprints are the other sprints overlapping the allocated one
+	Sprints []string
+	// Share is the part of the issue's In Progress time credited to the allocated sprint
+	Share float64
+	Hours float64
+}
+
+// Reason describes the overlapping sprints and the share of the time credited
+func (o SprintOverlap) Reason() string {
+	share := strconv.FormatFloat(math.Round(o.Share*1000)/10, 'f', -1, 64)
+	return fmt.Sprintf("also in overlapping sprints %s: %s%% of the In Progress time credited", strings.Join(o.Sprints, ", "), share)
+}
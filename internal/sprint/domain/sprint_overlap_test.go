@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJiraIssue_AllocatedSprint(t *testing.T) {
+	issue := JiraIssue{Fields: JiraFields{Sprints: []JiraSprint{
+		{ID: 7, Name: "Sprint 12", State: "closed", StartDate: "2024-03-04T00:00:00.000Z"},
+		{ID: 12, Name: "Sprint 12", State: "active", StartDate: "2024-02-19T00:00:00.000Z"},
+		{ID: 15, Name: "Sprint 13", State: "closed", StartDate: "2024-03-18T00:00:00.000Z"},
+		{ID: 16, Name: "Sprint 13", State: "closed", StartDate: "2024-04-01T00:00:00.000Z"},
+	}}}
+
+	sprint, ok := issue.AllocatedSprint("12")
+	assert.True(t, ok)
+	assert.Equal(t, "Sprint 12", sprint.Name, "an ID wins over the sprint named 12")
+	assert.Equal(t, 12, sprint.ID)
+
+	sprint, _ = issue.AllocatedSprint("Sprint 12")
+	assert.Equal(t, 12, sprint.ID, "the active sprint wins among those sharing the name")
+
+	sprint, _ = issue.AllocatedSprint("Sprint 13")
+	assert.Equal(t, 16, sprint.ID, "then the latest to start")
+
+	_, ok = issue.AllocatedSprint("Sprint 14")
+	assert.False(t, ok)
+}
+
+func TestJiraIssue_SprintOverlapShare(t *testing.T) {
+	day := func(d, h int) time.Time { return time.Date(2024, 3, d, h, 0, 0, 0, time.UTC) }
+	allocated := JiraSprint{ID: 1, Name: "Sprint 12", StartDate: "2024-03-18T00:00:00.000Z", EndDate: "2024-03-29T00:00:00.000Z"}
+
+	t.Run("no overlap", func(t *testing.T) {
+		issue := JiraIssue{Fields: JiraFields{Sprints: []JiraSprint{
+			{ID: 0, Name: "Sprint 11", StartDate: "2024-03-04T00:00:00.000Z", EndDate: "2024-03-18T00:00:00.000Z"},
+			allocated,
+		}}}
+		share, sprints := issue.SprintOverlapShare("Sprint 12", day(18, 0), day(20, 0))
+		assert.Equal(t, 1.0, share)
+		assert.Nil(t, sprints)
+	})
+
+	t.Run("parallel sprints split the overlapping time", func(t *testing.T) {
+		issue := JiraIssue{Fields: JiraFields{Sprints: []JiraSprint{
+			allocated,
+			{ID: 2, Name: "Platform 7", StartDate: "2024-03-19T00:00:00.000Z", EndDate: "2024-04-01T00:00:00.000Z"},
+			{ID: 3, Name: "Design 3", StartDate: "2024-03-20T00:00:00.000Z"},
+			allocated,
+		}}}
+		// a day alone, a day with Platform 7 and a day with both
+		share, sprints := issue.SprintOverlapShare("Sprint 12", day(18, 0), day(21, 0))
+		assert.InDelta(t, (1+0.5+1.0/3)/3, share, 1e-9)
+		assert.Equal(t, []string{"Platform 7", "Design 3"}, sprints)
+
+		share, _ = issue.SprintOverlapShare("1", day(20, 12), day(20, 12))
+		assert.InDelta(t, 1.0/3, share, 1e-9, "an instant within three sprints")
+
+		share, _ = issue.SprintOverlapShare("Sprint 12", day(15, 0), day(18, 0))
+		assert.Equal(t, 1.0, share, "time before the allocated sprint is credited in full")
+	})
+
+	t.Run("unknown sprint", func(t *testing.T) {
+		issue := JiraIssue{}
+		share, sprints := issue.SprintOverlapShare("Sprint 12", day(18, 0), day(20, 0))
+		assert.Equal(t, 1.0, share)
+		assert.Nil(t, sprints)
+	})
+}
+
+func TestSprintOverlap_Reason(t *testing.T) {
+	overlap := SprintOverlap{IssueKey: "FN-1", Sprints: []string{"Platform 7", "Design 3"}, Share: 2.0 / 3}
+	assert.Equal(t, "also in overlapping sprints Platform 7, Design 3: 66.7% of the In Progress time credited", overlap.Reason())
+}
@@ -204,3 +204,85 @@ func TestSprint_GetRemainingTime(t *testing.T) {
 		})
 	}
 }
+
+func TestTeam_ResolveMember(t *testing.T) {
+	team := Team{
+		Team: []string{"Jane Doe", "John Smith"},
+		Aliases: map[string][]string{
+			"Jane Doe": {"Jane Doe-Smith", "5b10a2844c20165700ede21g"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		person    string
+		canonical string
+		found     bool
+	}{
+		{name: "exact member name", person: "John Smith", canonical: "John Smith", found: true},
+		{name: "alias", person: "Jane Doe-Smith", canonical: "Jane Doe", found: true},
+		{name: "account ID alias", person: "5b10a2844c20165700ede21g", canonical: "Jane Doe", found: true},
+		{name: "formatting drift", person: "  john   SMITH ", canonical: "John Smith", found: true},
+		{name: "unknown person", person: "Someone Else", canonical: "", found: false},
+		{name: "empty name", person: "", canonical: "", found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			canonical, found := team.ResolveMember(tt.person)
+			if canonical != tt.canonical || found != tt.found {
+				t.Errorf("ResolveMember(%q) = (%q, %v), want (%q, %v)", tt.person, canonical, found, tt.canonical, tt.found)
+			}
+			if team.IsTeamMember(tt.person) != tt.found {
+				t.Errorf("IsTeamMember(%q) = %v, want %v", tt.person, !tt.found, tt.found)
+			}
+		})
+	}
+}
+
+func TestTeam_ResolveAssignee(t *testing.T) {
+	team := Team{
+		Team:       []string{"Jane Doe", "John Smith"},
+		Aliases:    map[string][]string{"John Smith": {"acc-old-john"}},
+		AccountIDs: map[string]string{"Jane Doe": "acc-jane"},
+	}
+
+	tests := []struct {
+		name      string
+		accountID string
+		person    string
+		canonical string
+		found     bool
+	}{
+		{name: "account ID after a rename", accountID: "acc-jane", person: "Jane Smith", canonical: "Jane Doe", found: true},
+		{name: "namesake with another account", accountID: "acc-other", person: "Jane Doe", canonical: "", found: false},
+		{name: "account ID alias", accountID: "acc-old-john", person: "", canonical: "John Smith", found: true},
+		{name: "name of a member without account ID", accountID: "acc-john", person: "John Smith", canonical: "John Smith", found: true},
+		{name: "name without account ID", accountID: "", person: "Jane Doe", canonical: "Jane Doe", found: true},
+		{name: "unknown", accountID: "acc-other", person: "Someone Else", canonical: "", found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			canonical, found := team.ResolveAssignee(tt.accountID, tt.person)
+			if canonical != tt.canonical || found != tt.found {
+				t.Errorf("ResolveAssignee(%q, %q) = (%q, %v), want (%q, %v)", tt.accountID, tt.person, canonical, found, tt.canonical, tt.found)
+			}
+		})
+	}
+}
+
+func TestTeam_UnknownAliasTargets(t *testing.T) {
+	team := Team{
+		Team: []string{"Jane Doe"},
+		Aliases: map[string][]string{
+			"Jane Doe":   {"Jane Doe-Smith"},
+			"Old Member": {"old.member"},
+		},
+	}
+
+	unknown := team.UnknownAliasTargets()
+	if len(unknown) != 1 || unknown[0] != "Old Member" {
+		t.Errorf("UnknownAliasTargets() = %v, want [Old Member]", unknown)
+	}
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// SprintsStartedBetween returns the distinct sprints that started within [from, to), ordered
+// by start date. Sprints without a parsable start date are left out.
+func SprintsStartedBetween(sprints []JiraSprint, from, to time.Time) []Sprint {
+	starts := make(map[string]time.Time)
+	var result []Sprint
+	for _, sprint := range sprints {
+		if _, seen := starts[sprint.Name]; seen {
+			continue
+		}
+		start, err := ParseJiraTime(sprint.StartDate)
+		if err != nil || start.Before(from) || !start.Before(to) {
+			continue
+		}
+		starts[sprint.Name] = start
+		result = append(result, Sprint{Name: sprint.Name, StartDate: sprint.StartDate, EndDate: sprint.EndDate})
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return starts[result[i].Name].Before(starts[result[j].Name])
+	})
+	return result
+}
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSprintsStartedBetween(t *testing.T) {
+	from := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC)
+
+	sprints := SprintsStartedBetween([]JiraSprint{
+		{Name: "Sprint 8", StartDate: "2024-04-15T09:00:00.000Z", EndDate: "2024-04-29T09:00:00.000Z"},
+		{Name: "Sprint 7", StartDate: "2024-04-01T09:00:00.000Z", EndDate: "2024-04-15T09:00:00.000Z"},
+		{Name: "Sprint 8", StartDate: "2024-04-15T09:00:00.000Z", EndDate: "2024-04-29T09:00:00.000Z"},
+		{Name: "Sprint 6", StartDate: "2024-03-18T09:00:00.000Z", EndDate: "2024-04-01T09:00:00.000Z"},
+		{Name: "Sprint 13", StartDate: "2024-07-01T09:00:00.000Z"},
+		{Name: "Backlog sprint"},
+	}, from, to)
+
+	assert.Equal(t, []Sprint{
+		{Name: "Sprint 7", StartDate: "2024-04-01T09:00:00.000Z", EndDate: "2024-04-15T09:00:00.000Z"},
+		{Name: "Sprint 8", StartDate: "2024-04-15T09:00:00.000Z", EndDate: "2024-04-29T09:00:00.000Z"},
+	}, sprints)
+}
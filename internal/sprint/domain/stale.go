@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// StaleIssue records an issue whose In Progress window exceeded the stale threshold. Such
+// windows inflate the hours credited or point at issues nobody moved on, so they need review.
+type StaleIssue struct {
+	IssueKey string
+	// Days is how long the issue was In Progress, in calendar days
+	Days float64
+	// ThresholdDays is the stale threshold the window exceeded
+	ThresholdDays int
+	// Hours is the time credited for the issue, the capped time when Capped is set
+	Hours float64
+	// Capped marks hours reduced to the configured cap pending a manual override
+	Capped bool
+}
+
+// Reason describes how long the issue was In Progress and whether its hours were capped
+func (s StaleIssue) Reason() string {
+	days := strconv.FormatFloat(float64(int(s.Days*10))/10, 'f', -1, 64)
+	reason := fmt.Sprintf("In Progress for %s days, over the %d-day threshold", days, s.ThresholdDays)
+	if s.Capped {
+		hours := strconv.FormatFloat(s.Hours, 'f', -1, 64)
+		reason += fmt.Sprintf(": capped at %s h pending a manual override", hours)
+	}
+	return reason
+}
+
+// Stale returns the stale issue record of an issue In Progress from start to end credited
+// hours in total, and false when the window is within the threshold or the watchdog is off.
+// With a cap configured, the hours recorded are the capped ones.
+func (h AllocationHeuristics) Stale(issueKey string, start, end time.Time, hours float64) (StaleIssue, bool) {
+	if h.StaleAfterDays <= 0 || start.IsZero() || end.IsZero() {
+		return StaleIssue{}, false
+	}
+	days := end.Sub(start).Hours() / 24
+	if days <= float64(h.StaleAfterDays) {
+		return StaleIssue{}, false
+	}
+	issue := StaleIssue{IssueKey: issueKey, Days: days, ThresholdDays: h.StaleAfterDays, Hours: hours}
+	if h.StaleCapHours > 0 && hours > h.StaleCapHours {
+		issue.Hours, issue.Capped = h.StaleCapHours, true
+	}
+	return issue, true
+}
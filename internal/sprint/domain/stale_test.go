@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllocationHeuristics_Stale(t *testing.T) {
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	_, stale := AllocationHeuristics{}.Stale("TEST-1", start, start.AddDate(0, 2, 0), 500)
+	assert.False(t, stale, "the watchdog is off by default")
+
+	heuristics := AllocationHeuristics{StaleAfterDays: 10}
+	_, stale = heuristics.Stale("TEST-1", start, start.AddDate(0, 0, 10), 240)
+	assert.False(t, stale, "a window of exactly the threshold is not stale")
+	_, stale = heuristics.Stale("TEST-1", start, time.Time{}, 0)
+	assert.False(t, stale, "open windows are not flagged")
+
+	issue, stale := heuristics.Stale("TEST-1", start, start.AddDate(0, 0, 12).Add(12*time.Hour), 300)
+	assert.True(t, stale)
+	assert.Equal(t, StaleIssue{IssueKey: "TEST-1", Days: 12.5, ThresholdDays: 10, Hours: 300}, issue)
+	assert.Equal(t, "In Progress for 12.5 days, over the 10-day threshold", issue.Reason())
+
+	heuristics.StaleCapHours = 40
+	issue, _ = heuristics.Stale("TEST-1", start, start.AddDate(0, 0, 12), 300)
+	assert.Equal(t, StaleIssue{IssueKey: "TEST-1", Days: 12, ThresholdDays: 10, Hours: 40, Capped: true}, issue)
+	assert.Equal(t, "In Progress for 12 days, over the 10-day threshold: capped at 40 h pending a manual override", issue.Reason())
+
+	issue, _ = heuristics.Stale("TEST-1", start, start.AddDate(0, 0, 12), 30)
+	assert.False(t, issue.Capped, "hours under the cap are kept")
+}
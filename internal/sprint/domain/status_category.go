@@ -0,0 +1,83 @@
+package domain
+
+// StatusCategory is the category Jira files a status under. Unlike status names, which each
+// instance may rename or translate, e.g. "Fertig" or "Concluído", the categories are the same
+// on every instance.
+type StatusCategory string
+
+// Status categories, as keyed by the Jira REST API
+const (
+	StatusCategoryToDo       StatusCategory = "new"
+	StatusCategoryInProgress StatusCategory = "indeterminate"
+	StatusCategoryDone       StatusCategory = "done"
+)
+
+// defaultStatusCategories are the categories of the English default workflow statuses, used
+// for statuses Jira reported no category for
+var defaultStatusCategories = map[string]StatusCategory{
+	"To Do":       StatusCategoryToDo,
+	"In Progress": StatusCategoryInProgress,
+	"Done":        StatusCategoryDone,
+	"Won't Do":    StatusCategoryDone,
+}
+
+// CategoryOf returns the category of a status, falling back to the English default status
+// names when the category is unknown, e.g. for issues read without status metadata
+func CategoryOf(category StatusCategory, name string) StatusCategory {
+	if category != "" {
+		return category
+	}
+	return defaultStatusCategories[name]
+}
+
+// StatusCategories maps status IDs to their categories
+type StatusCategories map[string]StatusCategory
+
+// Learn records the categories of the issues' current statuses
+func (c StatusCategories) Learn(issues []JiraIssue) {
+	for _, issue := range issues {
+		status := issue.Fields.Status
+		if status.ID != "" && status.Category.Key != "" {
+			c[status.ID] = status.Category.Key
+		}
+	}
+}
+
+// Missing returns the IDs of the statuses the changelogs of the issues move between
+// whose category is unknown
+func (c StatusCategories) Missing(issues []JiraIssue) []string {
+	seen := make(map[string]bool)
+	var missing []string
+	for _, issue := range issues {
+		for _, history := range issue.Changelog.Histories {
+			for _, item := range history.Items {
+				if !item.IsStatusChange() {
+					continue
+				}
+				for _, id := range []string{item.From, item.To} {
+					if _, known := c[id]; id == "" || known || seen[id] {
+						continue
+					}
+					seen[id] = true
+					missing = append(missing, id)
+				}
+			}
+		}
+	}
+	return missing
+}
+
+// Annotate sets the categories of the statuses the changelogs of the issues move between
+func (c StatusCategories) Annotate(issues []JiraIssue) {
+	for i := range issues {
+		for _, history := range issues[i].Changelog.Histories {
+			for j := range history.Items {
+				item := &history.Items[j]
+				if item.IsStatusChange() {
+					item.FromCategory = c[item.From]
+					item.ToCategory = c[item.To]
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCategoryOf(t *testing.T) {
+	assert.Equal(t, StatusCategoryDone, CategoryOf(StatusCategoryDone, "Fertig"))
+	assert.Equal(t, StatusCategoryInProgress, CategoryOf(StatusCategoryInProgress, "Done"), "the reported category wins over the name")
+	assert.Equal(t, StatusCategoryDone, CategoryOf("", "Won't Do"))
+	assert.Equal(t, StatusCategoryInProgress, CategoryOf("", "In Progress"))
+	assert.Empty(t, CategoryOf("", "Concluído"))
+}
+
+func TestStatusCategories(t *testing.T) {
+	issues := []JiraIssue{{
+		Fields: JiraFields{Status: JiraStatus{ID: "3", Name: "Concluído", Category: JiraStatusCategory{Key: StatusCategoryDone}}},
+		Changelog: JiraChangelog{Histories: []JiraChangeHistory{
+			{Items: []JiraChangeItem{{Field: "status", From: "1", To: "2"}, {Field: "assignee", From: "acc-1", To: "acc-2"}}},
+			{Items: []JiraChangeItem{{Field: "status", From: "2", To: "3"}}},
+		}},
+	}}
+
+	categories := StatusCategories{}
+	categories.Learn(issues)
+	assert.Equal(t, StatusCategories{"3": StatusCategoryDone}, categories)
+	assert.Equal(t, []string{"1", "2"}, categories.Missing(issues))
+
+	categories["1"], categories["2"] = StatusCategoryToDo, StatusCategoryInProgress
+	assert.Empty(t, categories.Missing(issues))
+	categories.Annotate(issues)
+
+	histories := issues[0].Changelog.Histories
+	assert.Equal(t, StatusCategoryToDo, histories[0].Items[0].FromStatusCategory())
+	assert.Equal(t, StatusCategoryInProgress, histories[0].Items[0].ToStatusCategory())
+	assert.Empty(t, histories[0].Items[1].ToCategory, "only status changes are annotated")
+	assert.Equal(t, StatusCategoryDone, histories[1].Items[0].ToCategory)
+	assert.True(t, issues[0].IsDone())
+}
+
+func TestIssueType_IsSubtask(t *testing.T) {
+	assert.True(t, IssueType{Name: "Unteraufgabe", Subtask: true}.IsSubtask())
+	assert.True(t, IssueType{Name: "Subtarefa", HierarchyLevel: -1}.IsSubtask())
+	assert.True(t, IssueType{Name: "Sub-task"}.IsSubtask())
+	assert.False(t, IssueType{Name: "Epic", HierarchyLevel: 1}.IsSubtask())
+	assert.False(t, IssueType{Name: "Aufgabe"}.IsSubtask())
+}
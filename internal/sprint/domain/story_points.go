@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AllocationMethod selects how a person's sprint hours are split across their issues
+type AllocationMethod string
+
+const (
+	// AllocationMethodTime splits hours by the time each issue spent in progress
+	AllocationMethodTime AllocationMethod = "time"
+	// AllocationMethodStoryPoints splits hours by each issue's share of the person's story points
+	AllocationMethodStoryPoints AllocationMethod = "storypoints"
+)
+
+// ParseAllocationMethod parses the name of a registered allocation strategy, or a blend of
+// them such as time:0.7,storypoints:0.3; an empty name selects time-based allocation
+func ParseAllocationMethod(value string) (AllocationMethod, error) {
+	method := AllocationMethod(strings.ToLower(strings.TrimSpace(value)))
+	if method == "" {
+		return AllocationMethodTime, nil
+	}
+	if _, ok := strategies[method]; ok {
+		return method, nil
+	}
+	if _, err := parseBlend(string(method)); err != nil {
+		return "", err
+	}
+	return method, nil
+}
+
+// PointsAt selects which story point estimate is used when points changed during the sprint
+type PointsAt string
+
+const (
+	// PointsAtStart uses the estimate in effect when the sprint started
+	PointsAtStart PointsAt = "start"
+	// PointsAtEnd uses the estimate in effect when the sprint ended
+	PointsAtEnd PointsAt = "end"
+	// PointsAtLatest uses the issue's current estimate
+	PointsAtLatest PointsAt = "latest"
+)
+
+// ParsePointsAt parses a story point snapshot name; an empty name selects the latest estimate
+func ParsePointsAt(value string) (PointsAt, error) {
+	switch PointsAt(strings.ToLower(strings.TrimSpace(value))) {
+	case "", PointsAtLatest:
+		return PointsAtLatest, nil
+	case PointsAtStart:
+		return PointsAtStart, nil
+	case PointsAtEnd:
+		return PointsAtEnd, nil
+	default:
+		return "", fmt.Errorf("invalid points-at %q: must be start, end or latest", value)
+	}
+}
+
+// IsStoryPointsChange checks if this change item represents a story point estimate change
+func (i *JiraChangeItem) IsStoryPointsChange() bool {
+	switch strings.ToLower(i.Field) {
+	case "story points", "story point estimate":
+		return true
+	}
+	return false
+}
+
+// SprintWindow returns the start and end dates of the sprint, named or given by ID, or zero
+// times when unknown
+func (i *JiraIssue) SprintWindow(name string) (time.Time, time.Time) {
+	sprint, ok := i.AllocatedSprint(name)
+	if !ok {
+		return time.Time{}, time.Time{}
+	}
+	start, _ := ParseJiraTime(sprint.StartDate)
+	end, _ := ParseJiraTime(sprint.EndDate)
+	return start, end
+}
+
+// StoryPointsAt returns the story points in effect at the given time, replaying the
+// changelog. Issues whose estimate never changed report their current story points.
+func (i *JiraIssue) StoryPointsAt(at time.Time) *float64 {
+	var points *float64
+	changed := false
+	for _, history := range i.Changelog.Histories {
+		for _, item := range history.Items {
+			if !item.IsStoryPointsChange() {
+				continue
+			}
+			created, err := ParseJiraTime(history.Created)
+			if err != nil {
+				continue
+			}
+			if created.After(at) {
+				if !changed {
+					return parseStoryPoints(item.FromString)
+				}
+				return points
+			}
+			points = parseStoryPoints(item.ToString)
+			changed = true
+		}
+	}
+	if changed {
+		return points
+	}
+	return i.Fields.StoryPoints
+}
+
+// StoryPointsFor returns the story points selected by pointsAt for the named sprint.
+// It falls back to the current estimate when the sprint dates are unknown.
+func (i *JiraIssue) StoryPointsFor(pointsAt PointsAt, sprint string) *float64 {
+	start, end := i.SprintWindow(sprint)
+	switch {
+	case pointsAt == PointsAtStart && !start.IsZero():
+		return i.StoryPointsAt(start)
+	case pointsAt == PointsAtEnd && !end.IsZero():
+		return i.StoryPointsAt(end)
+	}
+	return i.Fields.StoryPoints
+}
+
+// parseStoryPoints parses a changelog story point value; empty values mean no estimate
+func parseStoryPoints(value string) *float64 {
+	points, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return nil
+	}
+	return &points
+}
+
+// ParseJiraTime parses the timestamp formats used by Jira issues, changelogs and sprints, in UTC
+func ParseJiraTime(value string) (time.Time, error) {
+	parsed, err := time.Parse("2006-01-02T15:04:05.000-0700", value)
+	if err != nil {
+		parsed, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+	return parsed.UTC(), nil
+}
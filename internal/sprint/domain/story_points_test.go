@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func reestimatedIssue() JiraIssue {
+	current := 8.0
+	return JiraIssue{
+		Key: "TEST-1",
+		Fields: JiraFields{
+			StoryPoints: &current,
+			Sprints: []JiraSprint{
+				{Name: "Sprint 1", StartDate: "2024-03-04T09:00:00.000Z", EndDate: "2024-03-15T17:00:00.000Z"},
+			},
+		},
+		Changelog: JiraChangelog{
+			Histories: []JiraChangeHistory{
+				{
+					Created: "2024-03-06T10:00:00.000+0000",
+					Items:   []JiraChangeItem{{Field: "Story Points", FromString: "3", ToString: "5"}},
+				},
+				{
+					Created: "2024-03-18T10:00:00.000+0000",
+					Items:   []JiraChangeItem{{Field: "Story Points", FromString: "5", ToString: "8"}},
+				},
+			},
+		},
+	}
+}
+
+func TestParseAllocationMethod(t *testing.T) {
+	method, err := ParseAllocationMethod("")
+	require.NoError(t, err)
+	assert.Equal(t, AllocationMethodTime, method)
+
+	method, err = ParseAllocationMethod("StoryPoints")
+	require.NoError(t, err)
+	assert.Equal(t, AllocationMethodStoryPoints, method)
+
+	_, err = ParseAllocationMethod("velocity")
+	assert.Error(t, err)
+}
+
+func TestParsePointsAt(t *testing.T) {
+	for input, want := range map[string]PointsAt{"": PointsAtLatest, "start": PointsAtStart, "END": PointsAtEnd, "latest": PointsAtLatest} {
+		got, err := ParsePointsAt(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	_, err := ParsePointsAt("middle")
+	assert.Error(t, err)
+}
+
+func TestJiraChangeItem_IsStoryPointsChange(t *testing.T) {
+	assert.True(t, (&JiraChangeItem{Field: "Story Points"}).IsStoryPointsChange())
+	assert.True(t, (&JiraChangeItem{Field: "Story point estimate"}).IsStoryPointsChange())
+	assert.False(t, (&JiraChangeItem{Field: "status"}).IsStoryPointsChange())
+}
+
+func TestJiraIssue_StoryPointsAt(t *testing.T) {
+	issue := reestimatedIssue()
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want float64
+	}{
+		{"before any change uses the original estimate", time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC), 3},
+		{"after the first change", time.Date(2024, 3, 15, 17, 0, 0, 0, time.UTC), 5},
+		{"after the last change", time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC), 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			points := issue.StoryPointsAt(tt.at)
+			require.NotNil(t, points)
+			assert.Equal(t, tt.want, *points)
+		})
+	}
+}
+
+func TestJiraIssue_StoryPointsAt_Unestimated(t *testing.T) {
+	issue := JiraIssue{
+		Changelog: JiraChangelog{
+			Histories: []JiraChangeHistory{
+				{
+					Created: "2024-03-06T10:00:00.000+0000",
+					Items:   []JiraChangeItem{{Field: "Story Points", FromString: "", ToString: "5"}},
+				},
+			},
+		},
+	}
+
+	assert.Nil(t, issue.StoryPointsAt(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Nil(t, (&JiraIssue{}).StoryPointsAt(time.Now()))
+}
+
+func TestJiraIssue_StoryPointsFor(t *testing.T) {
+	issue := reestimatedIssue()
+
+	assert.Equal(t, 3.0, *issue.StoryPointsFor(PointsAtStart, "Sprint 1"))
+	assert.Equal(t, 5.0, *issue.StoryPointsFor(PointsAtEnd, "Sprint 1"))
+	assert.Equal(t, 8.0, *issue.StoryPointsFor(PointsAtLatest, "Sprint 1"))
+	// Without sprint dates the current estimate is used
+	assert.Equal(t, 8.0, *issue.StoryPointsFor(PointsAtStart, "Sprint 2"))
+}
+
+func TestJiraIssue_SprintWindow(t *testing.T) {
+	issue := reestimatedIssue()
+
+	start, end := issue.SprintWindow("Sprint 1")
+	assert.Equal(t, time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2024, 3, 15, 17, 0, 0, 0, time.UTC), end)
+
+	start, end = issue.SprintWindow("Sprint 2")
+	assert.True(t, start.IsZero())
+	assert.True(t, end.IsZero())
+}
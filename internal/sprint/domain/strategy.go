@@ -0,0 +1,255 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AllocationStrategy splits each team member's hours across the issues of an allocated window.
+// It is given the hours tracked on every issue and returns the hours to credit instead, for
+// the same issues and members in the same order.
+type AllocationStrategy interface {
+	Allocate(window AllocationWindow, team Team, tracked []IssueHours) []IssueHours
+}
+
+// AllocationWindow names the sprint or fix version being allocated
+type AllocationWindow struct {
+	Sprint     string
+	FixVersion string
+}
+
+// IssueHours are the hours each team member is credited for an issue
+type IssueHours struct {
+	Issue   JiraIssue
+	Members []MemberHours
+}
+
+// MemberHours are the hours credited to a team member for an issue, and their percentage of
+// the member's hours across the window
+type MemberHours struct {
+	Assignee   string
+	Hours      float64
+	Percentage float64
+}
+
+// StrategyOptions configures the strategies created for an allocation
+type StrategyOptions struct {
+	// PointsAt selects the estimate used by the story point strategy
+	PointsAt PointsAt
+}
+
+// StrategyFactory creates an allocation strategy with the given options
+type StrategyFactory func(options StrategyOptions) AllocationStrategy
+
+// strategies are the registered allocation strategies by name
+var strategies = map[AllocationMethod]StrategyFactory{
+	AllocationMethodTime: func(StrategyOptions) AllocationStrategy { return TimeStrategy{} },
+	AllocationMethodStoryPoints: func(options StrategyOptions) AllocationStrategy {
+		return StoryPointsStrategy{PointsAt: options.PointsAt}
+	},
+}
+
+// RegisterStrategy makes an allocation strategy selectable by name. It panics when the name is
+// taken or could not be parsed as a method.
+func RegisterStrategy(name AllocationMethod, factory StrategyFactory) {
+	if name == "" || strings.ContainsAny(string(name), ",: ") || name != AllocationMethod(strings.ToLower(string(name))) {
+		panic(fmt.Sprintf("invalid allocation strategy name %q", name))
+	}
+	if _, exists := strategies[name]; exists {
+		panic(fmt.Sprintf("allocation strategy %q is already registered", name))
+	}
+	strategies[name] = factory
+}
+
+// StrategyNames returns the names of the registered allocation strategies, sorted
+func StrategyNames() []AllocationMethod {
+	names := make([]AllocationMethod, 0, len(strategies))
+	for name := range strategies {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
+
+// NewAllocationStrategy creates the strategy of a method: a registered strategy, or a blend of
+// several weighted ones such as time:0.7,storypoints:0.3
+func NewAllocationStrategy(method AllocationMethod, options StrategyOptions) (AllocationStrategy, error) {
+	if method == "" {
+		method = AllocationMethodTime
+	}
+	if factory, ok := strategies[method]; ok {
+		return factory(options), nil
+	}
+
+	parts, err := parseBlend(string(method))
+	if err != nil {
+		return nil, err
+	}
+	blend := BlendStrategy{}
+	for _, part := range parts {
+		blend.Parts = append(blend.Parts, WeightedStrategy{Strategy: strategies[part.name](options), Weight: part.weight})
+	}
+	return blend, nil
+}
+
+// Uses reports whether the method is, or blends, the named strategy
+func (m AllocationMethod) Uses(name AllocationMethod) bool {
+	if m == name {
+		return true
+	}
+	parts, err := parseBlend(string(m))
+	if err != nil {
+		return false
+	}
+	for _, part := range parts {
+		if part.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// blendPart is a strategy of a blend and its weight
+type blendPart struct {
+	name   AllocationMethod
+	weight float64
+}
+
+// parseBlend parses comma-separated strategy names, each optionally weighted as name:weight.
+// Unweighted strategies weigh 1.
+func parseBlend(value string) ([]blendPart, error) {
+	var parts []blendPart
+	seen := make(map[AllocationMethod]bool)
+	for _, field := range strings.Split(value, ",") {
+		name, weight, weighted := strings.Cut(strings.TrimSpace(field), ":")
+		part := blendPart{name: AllocationMethod(strings.TrimSpace(name)), weight: 1}
+		if _, ok := strategies[part.name]; !ok {
+			return nil, fmt.Errorf("invalid allocation method %q: must be %s, or a blend such as time:0.7,storypoints:0.3", value, strategyList())
+		}
+		if seen[part.name] {
+			return nil, fmt.Errorf("invalid allocation method %q: %s is blended twice", value, part.name)
+		}
+		seen[part.name] = true
+		if weighted {
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(weight), 64)
+			if err != nil || parsed <= 0 {
+				return nil, fmt.Errorf("invalid allocation method %q: the weight of %s must be a positive number", value, part.name)
+			}
+			part.weight = parsed
+		}
+		parts = append(parts, part)
+	}
+	return parts, nil
+}
+
+// strategyList names the registered strategies for error messages, e.g. "storypoints or time"
+func strategyList() string {
+	names := StrategyNames()
+	list := make([]string, len(names))
+	for i, name := range names {
+		list[i] = string(name)
+	}
+	if len(list) == 1 {
+		return list[0]
+	}
+	return strings.Join(list[:len(list)-1], ", ") + " or " + list[len(list)-1]
+}
+
+// TimeStrategy credits the hours tracked in progress, as they are
+type TimeStrategy struct{}
+
+// Allocate returns the tracked hours unchanged
+func (TimeStrategy) Allocate(_ AllocationWindow, _ Team, tracked []IssueHours) []IssueHours {
+	return tracked
+}
+
+// StoryPointsStrategy splits each member's tracked hours by their issues' share of the member's
+// story points, using the estimate selected by PointsAt
+type StoryPointsStrategy struct {
+	PointsAt PointsAt
+}
+
+// Allocate splits each member's hours by story points
+func (s StoryPointsStrategy) Allocate(window AllocationWindow, _ Team, tracked []IssueHours) []IssueHours {
+	memberHours := make(map[string]float64)
+	memberPoints := make(map[string]float64)
+	for _, issue := range tracked {
+		points := s.Points(issue.Issue, window.Sprint)
+		for _, member := range issue.Members {
+			memberHours[member.Assignee] += member.Hours
+			memberPoints[member.Assignee] += points
+		}
+	}
+
+	allocated := make([]IssueHours, 0, len(tracked))
+	for _, issue := range tracked {
+		points := s.Points(issue.Issue, window.Sprint)
+		members := make([]MemberHours, 0, len(issue.Members))
+		for _, member := range issue.Members {
+			percentage := 0.0
+			if memberPoints[member.Assignee] > 0 {
+				percentage = points / memberPoints[member.Assignee] * 100
+			}
+			members = append(members, MemberHours{
+				Assignee:   member.Assignee,
+				Hours:      memberHours[member.Assignee] * percentage / 100,
+				Percentage: percentage,
+			})
+		}
+		allocated = append(allocated, IssueHours{Issue: issue.Issue, Members: members})
+	}
+	return allocated
+}
+
+// Points returns the issue's story points at the selected point of the sprint, or zero when
+// unestimated
+func (s StoryPointsStrategy) Points(issue JiraIssue, sprint string) float64 {
+	points := issue.StoryPointsFor(s.PointsAt, sprint)
+	if points == nil || *points < 0 {
+		return 0
+	}
+	return *points
+}
+
+// BlendStrategy credits the weighted average of the hours and percentages of several strategies
+type BlendStrategy struct {
+	Parts []WeightedStrategy
+}
+
+// WeightedStrategy is a strategy of a blend and its relative weight
+type WeightedStrategy struct {
+	Strategy AllocationStrategy
+	Weight   float64
+}
+
+// Allocate averages the allocations of the blended strategies by weight
+func (b BlendStrategy) Allocate(window AllocationWindow, team Team, tracked []IssueHours) []IssueHours {
+	total := 0.0
+	for _, part := range b.Parts {
+		total += part.Weight
+	}
+
+	allocated := make([]IssueHours, len(tracked))
+	for i, issue := range tracked {
+		allocated[i] = IssueHours{Issue: issue.Issue, Members: make([]MemberHours, len(issue.Members))}
+		for j, member := range issue.Members {
+			allocated[i].Members[j].Assignee = member.Assignee
+		}
+	}
+	if total <= 0 {
+		return allocated
+	}
+
+	for _, part := range b.Parts {
+		share := part.Weight / total
+		for i, issue := range part.Strategy.Allocate(window, team, tracked) {
+			for j, member := range issue.Members {
+				allocated[i].Members[j].Hours += member.Hours * share
+				allocated[i].Members[j].Percentage += member.Percentage * share
+			}
+		}
+	}
+	return allocated
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func strategyIssues() []IssueHours {
+	points := func(v float64) *float64 { return &v }
+	return []IssueHours{
+		{
+			Issue:   JiraIssue{Key: "TEST-1", Fields: JiraFields{StoryPoints: points(3)}},
+			Members: []MemberHours{{Assignee: "jane", Hours: 10, Percentage: 25}},
+		},
+		{
+			Issue:   JiraIssue{Key: "TEST-2", Fields: JiraFields{StoryPoints: points(1)}},
+			Members: []MemberHours{{Assignee: "jane", Hours: 30, Percentage: 75}},
+		},
+	}
+}
+
+func TestParseAllocationMethod_Blend(t *testing.T) {
+	method, err := ParseAllocationMethod(" Time:0.7,StoryPoints:0.3 ")
+	require.NoError(t, err)
+	assert.Equal(t, AllocationMethod("time:0.7,storypoints:0.3"), method)
+	assert.True(t, method.Uses(AllocationMethodStoryPoints))
+	assert.False(t, AllocationMethodTime.Uses(AllocationMethodStoryPoints))
+
+	for _, invalid := range []string{"time:0.7,velocity", "time:0,storypoints", "time:x", "time,time"} {
+		_, err := ParseAllocationMethod(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestStoryPointsStrategy_Allocate(t *testing.T) {
+	allocated := StoryPointsStrategy{PointsAt: PointsAtLatest}.Allocate(AllocationWindow{Sprint: "Sprint 1"}, Team{}, strategyIssues())
+
+	require.Len(t, allocated, 2)
+	assert.Equal(t, []MemberHours{{Assignee: "jane", Hours: 30, Percentage: 75}}, allocated[0].Members)
+	assert.Equal(t, []MemberHours{{Assignee: "jane", Hours: 10, Percentage: 25}}, allocated[1].Members)
+}
+
+func TestNewAllocationStrategy_Blend(t *testing.T) {
+	strategy, err := NewAllocationStrategy("time:3,storypoints:1", StrategyOptions{PointsAt: PointsAtLatest})
+	require.NoError(t, err)
+
+	allocated := strategy.Allocate(AllocationWindow{Sprint: "Sprint 1"}, Team{}, strategyIssues())
+
+	require.Len(t, allocated, 2)
+	// TEST-1 is 25% of the time and 75% of the points
+	assert.InDelta(t, 37.5, allocated[0].Members[0].Percentage, 0.001)
+	assert.InDelta(t, 15, allocated[0].Members[0].Hours, 0.001)
+	assert.InDelta(t, 62.5, allocated[1].Members[0].Percentage, 0.001)
+	assert.InDelta(t, 25, allocated[1].Members[0].Hours, 0.001)
+}
+
+type fixedStrategy struct{}
+
+func (fixedStrategy) Allocate(_ AllocationWindow, _ Team, tracked []IssueHours) []IssueHours {
+	allocated := make([]IssueHours, len(tracked))
+	for i, issue := range tracked {
+		allocated[i] = IssueHours{Issue: issue.Issue, Members: []MemberHours{{Assignee: issue.Members[0].Assignee, Hours: 8, Percentage: 50}}}
+	}
+	return allocated
+}
+
+func TestRegisterStrategy(t *testing.T) {
+	RegisterStrategy("fixed", func(StrategyOptions) AllocationStrategy { return fixedStrategy{} })
+	t.Cleanup(func() { delete(strategies, "fixed") })
+
+	assert.Equal(t, []AllocationMethod{"fixed", AllocationMethodStoryPoints, AllocationMethodTime}, StrategyNames())
+	method, err := ParseAllocationMethod("fixed,time")
+	require.NoError(t, err)
+	strategy, err := NewAllocationStrategy(method, StrategyOptions{})
+	require.NoError(t, err)
+	allocated := strategy.Allocate(AllocationWindow{}, Team{}, strategyIssues())
+	assert.InDelta(t, 9, allocated[0].Members[0].Hours, 0.001)
+
+	assert.Panics(t, func() { RegisterStrategy("fixed", nil) })
+	assert.Panics(t, func() { RegisterStrategy("a:b", nil) })
+}
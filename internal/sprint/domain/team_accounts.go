@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// JiraUser represents a user returned by the Jira user search API
+type JiraUser struct {
+	AccountID    string
+	AccountType  string
+	DisplayName  string
+	EmailAddress string
+	Active       bool
+}
+
+// AccountResolutionStatus describes the outcome of resolving a member to a Jira account
+type AccountResolutionStatus string
+
+const (
+	AccountResolved        AccountResolutionStatus = "resolved"
+	AccountAlreadyResolved AccountResolutionStatus = "already resolved"
+	AccountAmbiguous       AccountResolutionStatus = "ambiguous"
+	AccountNotFound        AccountResolutionStatus = "not found"
+)
+
+// AccountResolution is the Jira account found for a team member
+type AccountResolution struct {
+	Project   string
+	Member    string
+	AccountID string
+	// DisplayName is the Jira display name of the resolved account
+	DisplayName string
+	Status      AccountResolutionStatus
+	// Candidates lists the accounts that matched an ambiguous member
+	Candidates []string
+}
+
+// TeamAccountsInput represents the input for resolving team members to Jira account IDs
+type TeamAccountsInput struct {
+	// Project limits the resolution to one team; empty resolves every team
+	Project string
+	// DryRun reports the resolutions without writing teams.json
+	DryRun bool
+}
+
+// TeamAccountsResult holds the resolution of each team member
+type TeamAccountsResult struct {
+	Resolutions []AccountResolution
+	// Written reports whether teams.json was updated
+	Written bool
+}
+
+// ResolveAccount picks the Jira account of a member among the users a search returned.
+// Only active human accounts count. An account whose display name, email or email
+// local part matches the member wins; otherwise a single remaining account does.
+func ResolveAccount(project, member string, users []JiraUser) AccountResolution {
+	resolution := AccountResolution{Project: project, Member: member, Status: AccountNotFound}
+
+	var candidates, exact []JiraUser
+	for _, user := range users {
+		if !user.Active || (user.AccountType != "" && user.AccountType != "atlassian") {
+			continue
+		}
+		candidates = append(candidates, user)
+		if user.matches(member) {
+			exact = append(exact, user)
+		}
+	}
+
+	matches := exact
+	if len(exact) == 0 {
+		matches = candidates
+	}
+	switch len(matches) {
+	case 0:
+		return resolution
+	case 1:
+		resolution.AccountID = matches[0].AccountID
+		resolution.DisplayName = matches[0].DisplayName
+		resolution.Status = AccountResolved
+		return resolution
+	default:
+		resolution.Status = AccountAmbiguous
+		for _, user := range matches {
+			resolution.Candidates = append(resolution.Candidates, fmt.Sprintf("%s (%s)", user.DisplayName, user.AccountID))
+		}
+		return resolution
+	}
+}
+
+// matches reports whether the user's display name or email identifies the member
+func (u JiraUser) matches(member string) bool {
+	name := normalizeName(member)
+	if normalizeName(u.DisplayName) == name {
+		return true
+	}
+	email := strings.ToLower(u.EmailAddress)
+	localPart, _, _ := strings.Cut(email, "@")
+	return email != "" && (email == name || localPart == name)
+}
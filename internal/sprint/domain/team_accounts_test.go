@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveAccount(t *testing.T) {
+	jane := JiraUser{AccountID: "acc-jane", AccountType: "atlassian", DisplayName: "Jane Doe", EmailAddress: "jane.doe@example.com", Active: true}
+	janet := JiraUser{AccountID: "acc-janet", AccountType: "atlassian", DisplayName: "Janet Doe", Active: true}
+	bot := JiraUser{AccountID: "acc-bot", AccountType: "app", DisplayName: "Jane Doe Bot", Active: true}
+	former := JiraUser{AccountID: "acc-former", AccountType: "atlassian", DisplayName: "Jane Doe", Active: false}
+
+	tests := []struct {
+		name      string
+		member    string
+		users     []JiraUser
+		status    AccountResolutionStatus
+		accountID string
+	}{
+		{name: "display name", member: "jane  doe", users: []JiraUser{jane, janet}, status: AccountResolved, accountID: "acc-jane"},
+		{name: "email local part", member: "jane.doe", users: []JiraUser{jane, janet}, status: AccountResolved, accountID: "acc-jane"},
+		{name: "single active human", member: "jdoe", users: []JiraUser{janet, bot, former}, status: AccountResolved, accountID: "acc-janet"},
+		{name: "ambiguous", member: "doe", users: []JiraUser{jane, janet}, status: AccountAmbiguous},
+		{name: "not found", member: "jane", users: []JiraUser{bot, former}, status: AccountNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolution := ResolveAccount("FN", tt.member, tt.users)
+			assert.Equal(t, tt.status, resolution.Status)
+			assert.Equal(t, tt.accountID, resolution.AccountID)
+			assert.Equal(t, tt.member, resolution.Member)
+		})
+	}
+
+	assert.Equal(t, []string{"Jane Doe (acc-jane)", "Janet Doe (acc-janet)"}, ResolveAccount("FN", "doe", []JiraUser{jane, janet}).Candidates)
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrNoTeams is returned when no teams were set up
+var ErrNoTeams = errors.New("no teams are set up")
+
+// Projects returns the keys of the projects with a team, sorted
+func (tm TeamMap) Projects() []string {
+	projects := make([]string, 0, len(tm))
+	for project := range tm {
+		projects = append(projects, project)
+	}
+	sort.Strings(projects)
+	return projects
+}
+
+// AddTeam adds the team of a project that has none yet
+func (tm TeamMap) AddTeam(project string, members []string) error {
+	if project == "" {
+		return errors.New("project is required")
+	}
+	if _, exists := tm[project]; exists {
+		return fmt.Errorf("project %s already has a team", project)
+	}
+	tm[project] = Team{Team: teamMembers(members)}
+	return nil
+}
+
+// RemoveTeam removes the team of a project
+func (tm TeamMap) RemoveTeam(project string) error {
+	if _, exists := tm[project]; !exists {
+		return fmt.Errorf("project %s not found in teams.json", project)
+	}
+	delete(tm, project)
+	return nil
+}
+
+// SetMembers replaces the members of a project's team. The aliases, account IDs and capacity
+// of the members that stay are kept; those of the removed members are dropped.
+func (tm TeamMap) SetMembers(project string, members []string) error {
+	team, exists := tm[project]
+	if !exists {
+		return fmt.Errorf("project %s not found in teams.json", project)
+	}
+	team.Team = teamMembers(members)
+	kept := make(map[string]bool, len(team.Team))
+	for _, member := range team.Team {
+		kept[member] = true
+	}
+	for member := range team.Aliases {
+		if !kept[member] {
+			delete(team.Aliases, member)
+		}
+	}
+	for member := range team.AccountIDs {
+		if !kept[member] {
+			delete(team.AccountIDs, member)
+		}
+	}
+	for member := range team.Capacity {
+		if !kept[member] {
+			delete(team.Capacity, member)
+		}
+	}
+	tm[project] = team
+	return nil
+}
+
+// teamMembers trims the member names, dropping blank and repeated ones
+func teamMembers(members []string) []string {
+	result := make([]string, 0, len(members))
+	seen := make(map[string]bool, len(members))
+	for _, member := range members {
+		member = strings.TrimSpace(member)
+		if member == "" || seen[member] {
+			continue
+		}
+		seen[member] = true
+		result = append(result, member)
+	}
+	return result
+}
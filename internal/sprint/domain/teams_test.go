@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamMap_AddAndRemoveTeam(t *testing.T) {
+	teams := TeamMap{"OPS": {Team: []string{"dave"}}}
+
+	require.NoError(t, teams.AddTeam("FN", []string{" alice ", "bob", "", "alice"}))
+	assert.Equal(t, Team{Team: []string{"alice", "bob"}}, teams["FN"])
+	assert.Equal(t, []string{"FN", "OPS"}, teams.Projects())
+
+	assert.EqualError(t, teams.AddTeam("FN", nil), "project FN already has a team")
+	assert.EqualError(t, teams.AddTeam("", nil), "project is required")
+
+	require.NoError(t, teams.RemoveTeam("OPS"))
+	assert.Equal(t, []string{"FN"}, teams.Projects())
+	assert.EqualError(t, teams.RemoveTeam("OPS"), "project OPS not found in teams.json")
+}
+
+func TestTeamMap_SetMembers(t *testing.T) {
+	teams := TeamMap{"FN": {
+		Team:       []string{"alice", "bob"},
+		Aliases:    map[string][]string{"alice": {"Alice A."}, "bob": {"Bobby"}},
+		AccountIDs: map[string]string{"bob": "acc-bob"},
+		Capacity:   map[string]float64{"alice": 0.5},
+	}}
+
+	require.NoError(t, teams.SetMembers("FN", []string{"alice", "carol"}))
+	assert.Equal(t, Team{
+		Team:       []string{"alice", "carol"},
+		Aliases:    map[string][]string{"alice": {"Alice A."}},
+		AccountIDs: map[string]string{},
+		Capacity:   map[string]float64{"alice": 0.5},
+	}, teams["FN"], "the settings of removed members are dropped")
+
+	assert.EqualError(t, teams.SetMembers("OPS", []string{"dave"}), "project OPS not found in teams.json")
+}
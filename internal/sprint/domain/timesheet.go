@@ -0,0 +1,205 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TimesheetInput represents the input parameters for a per-engineer timesheet export
+type TimesheetInput struct {
+	Project  string
+	Sprint   string
+	Override string
+	// People limits the export to these team members; empty exports everyone with allocated work
+	People    []string
+	Delimiter rune
+	// LabelsAsOf classifies issues by their labels at a past point in time
+	LabelsAsOf LabelSnapshot
+	// Locale formats the numbers and dates of the export
+	Locale Locale
+	// Heuristics fills in untracked hours, as for AllocationInput
+	Heuristics AllocationHeuristics
+	// Export, when set, is a Jira JSON or CSV export allocated instead of querying Jira
+	Export string
+}
+
+// TimesheetEntry is one issue's hours on each day of a timesheet
+type TimesheetEntry struct {
+	IssueKey   string
+	IssueTitle string
+	WorkType   string
+	// Hours holds the hours per day, aligned with the timesheet's Days
+	Hours []float64
+}
+
+// Total returns the hours booked on the entry across all days
+func (e TimesheetEntry) Total() float64 {
+	return sumHours(e.Hours)
+}
+
+// Timesheet is a day by issue grid of the hours a person worked in a sprint
+type Timesheet struct {
+	Person  string
+	Days    []time.Time
+	Entries []TimesheetEntry
+}
+
+// DayTotals returns the hours booked on each day across all issues
+func (t Timesheet) DayTotals() []float64 {
+	totals := make([]float64, len(t.Days))
+	for _, entry := range t.Entries {
+		for i, hours := range entry.Hours {
+			totals[i] += hours
+		}
+	}
+	for i := range totals {
+		totals[i] = roundHours(totals[i])
+	}
+	return totals
+}
+
+// Total returns the hours booked on the timesheet
+func (t Timesheet) Total() float64 {
+	return sumHours(t.DayTotals())
+}
+
+// BuildTimesheets spreads each issue's hours evenly across the working days between its
+// start and completion dates, and groups them into one timesheet per person. Issues worked
+// only over a weekend are booked on those days. When people is empty, every assignee
+// with allocated work gets a timesheet.
+func BuildTimesheets(allocations []IssueAllocation, people []string) []Timesheet {
+	byPerson := make(map[string][]IssueAllocation)
+	for _, allocation := range allocations {
+		key := strings.ToLower(allocation.Assignee)
+		byPerson[key] = append(byPerson[key], allocation)
+	}
+
+	if len(people) == 0 {
+		for _, allocation := range allocations {
+			if !containsFold(people, allocation.Assignee) {
+				people = append(people, allocation.Assignee)
+			}
+		}
+		sort.Strings(people)
+	}
+
+	timesheets := make([]Timesheet, 0, len(people))
+	for _, person := range people {
+		timesheets = append(timesheets, buildTimesheet(person, byPerson[strings.ToLower(person)]))
+	}
+	return timesheets
+}
+
+// buildTimesheet lays out the person's allocations on a grid spanning all days they booked hours
+func buildTimesheet(person string, allocations []IssueAllocation) Timesheet {
+	hoursByIssue := make([]map[time.Time]float64, len(allocations))
+	daySet := make(map[time.Time]bool)
+	for i, allocation := range allocations {
+		hoursByIssue[i] = distributeHours(allocation)
+		for day := range hoursByIssue[i] {
+			daySet[day] = true
+		}
+	}
+
+	timesheet := Timesheet{Person: person}
+	for day := range daySet {
+		timesheet.Days = append(timesheet.Days, day)
+	}
+	sort.Slice(timesheet.Days, func(i, j int) bool {
+		return timesheet.Days[i].Before(timesheet.Days[j])
+	})
+
+	for i, allocation := range allocations {
+		entry := TimesheetEntry{
+			IssueKey:   allocation.IssueKey,
+			IssueTitle: allocation.IssueTitle,
+			WorkType:   allocation.WorkType,
+			Hours:      make([]float64, len(timesheet.Days)),
+		}
+		for j, day := range timesheet.Days {
+			entry.Hours[j] = hoursByIssue[i][day]
+		}
+		timesheet.Entries = append(timesheet.Entries, entry)
+	}
+	return timesheet
+}
+
+// distributeHours splits an allocation's hours across the days it was in progress. The
+// hours are rounded to two decimals, with the rounding remainder booked on the last day.
+func distributeHours(allocation IssueAllocation) map[time.Time]float64 {
+	if allocation.Hours <= 0 || allocation.DateStarted.IsZero() {
+		return nil
+	}
+
+	days := workingDays(allocation.DateStarted, allocation.DateCompleted)
+	perDay := math.Floor(allocation.Hours/float64(len(days))*100) / 100
+
+	distribution := make(map[time.Time]float64, len(days))
+	booked := 0.0
+	for i, day := range days {
+		hours := perDay
+		if i == len(days)-1 {
+			hours = roundHours(allocation.Hours - booked)
+		}
+		distribution[day] = hours
+		booked += hours
+	}
+	return distribution
+}
+
+// workingDays returns the weekdays from start to end inclusive, or every calendar day when
+// the range has no weekdays. A zero end means the work happened on the start day.
+func workingDays(start, end time.Time) []time.Time {
+	start = truncateDay(start)
+	end = truncateDay(end)
+	if end.IsZero() || end.Before(start) {
+		end = start
+	}
+
+	var weekdays, calendar []time.Time
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		calendar = append(calendar, day)
+		if day.Weekday() != time.Saturday && day.Weekday() != time.Sunday {
+			weekdays = append(weekdays, day)
+		}
+	}
+	if len(weekdays) == 0 {
+		return calendar
+	}
+	return weekdays
+}
+
+// truncateDay returns midnight UTC of the time's calendar date
+func truncateDay(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// roundHours rounds hours to two decimals
+func roundHours(hours float64) float64 {
+	return math.Round(hours*100) / 100
+}
+
+// sumHours adds up hours, rounded to two decimals
+func sumHours(hours []float64) float64 {
+	total := 0.0
+	for _, h := range hours {
+		total += h
+	}
+	return roundHours(total)
+}
+
+// containsFold reports whether values contains value, ignoring case
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
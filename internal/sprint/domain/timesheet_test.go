@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func day(d int) time.Time {
+	return time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestBuildTimesheets(t *testing.T) {
+	allocations := []IssueAllocation{
+		// Friday to Monday: the weekend is skipped
+		{IssueKey: "FN-1", IssueTitle: "Checkout", Assignee: "alice", WorkType: "cap-development", Hours: 10, DateStarted: day(1), DateCompleted: day(4)},
+		{IssueKey: "FN-2", IssueTitle: "Bugfix", Assignee: "alice", WorkType: "cap-maintenance", Hours: 2, DateStarted: day(4), DateCompleted: day(4)},
+		{IssueKey: "FN-3", IssueTitle: "Search", Assignee: "bob", Hours: 4, DateStarted: day(5), DateCompleted: day(5)},
+	}
+
+	timesheets := BuildTimesheets(allocations, nil)
+	require.Len(t, timesheets, 2)
+
+	alice := timesheets[0]
+	assert.Equal(t, "alice", alice.Person)
+	assert.Equal(t, []time.Time{day(1), day(4)}, alice.Days)
+	require.Len(t, alice.Entries, 2)
+	assert.Equal(t, []float64{5, 5}, alice.Entries[0].Hours)
+	assert.Equal(t, []float64{0, 2}, alice.Entries[1].Hours)
+	assert.Equal(t, []float64{5, 7}, alice.DayTotals())
+	assert.Equal(t, 12.0, alice.Total())
+
+	assert.Equal(t, "bob", timesheets[1].Person)
+	assert.Equal(t, 4.0, timesheets[1].Total())
+}
+
+func TestBuildTimesheets_SelectedPeople(t *testing.T) {
+	allocations := []IssueAllocation{
+		{IssueKey: "FN-1", Assignee: "Alice", Hours: 3, DateStarted: day(4)},
+		{IssueKey: "FN-2", Assignee: "bob", Hours: 3, DateStarted: day(4)},
+	}
+
+	timesheets := BuildTimesheets(allocations, []string{"alice", "carol"})
+	require.Len(t, timesheets, 2)
+	assert.Equal(t, "alice", timesheets[0].Person)
+	require.Len(t, timesheets[0].Entries, 1)
+	assert.Equal(t, "FN-1", timesheets[0].Entries[0].IssueKey)
+	assert.Equal(t, "carol", timesheets[1].Person)
+	assert.Empty(t, timesheets[1].Entries)
+	assert.Zero(t, timesheets[1].Total())
+}
+
+func TestDistributeHours(t *testing.T) {
+	t.Run("rounding remainder goes to the last day", func(t *testing.T) {
+		hours := distributeHours(IssueAllocation{Hours: 10, DateStarted: day(4), DateCompleted: day(6)})
+		assert.Equal(t, map[time.Time]float64{day(4): 3.33, day(5): 3.33, day(6): 3.34}, hours)
+	})
+
+	t.Run("weekend-only work stays on the weekend", func(t *testing.T) {
+		hours := distributeHours(IssueAllocation{Hours: 4, DateStarted: day(2), DateCompleted: day(3)})
+		assert.Equal(t, map[time.Time]float64{day(2): 2, day(3): 2}, hours)
+	})
+
+	t.Run("unfinished work is booked on the start day", func(t *testing.T) {
+		hours := distributeHours(IssueAllocation{Hours: 6, DateStarted: day(4)})
+		assert.Equal(t, map[time.Time]float64{day(4): 6}, hours)
+	})
+
+	t.Run("no hours or start date books nothing", func(t *testing.T) {
+		assert.Empty(t, distributeHours(IssueAllocation{Hours: 0, DateStarted: day(4)}))
+		assert.Empty(t, distributeHours(IssueAllocation{Hours: 5}))
+	})
+}
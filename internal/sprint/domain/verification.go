@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Sprint closure rules checked by VerifySprintClosure
+const (
+	// RuleUnclassified flags done issues without a work type
+	RuleUnclassified = "unclassified"
+	// RuleMissingAsset flags classified issues without an asset label
+	RuleMissingAsset = "missing-asset"
+	// RuleNoAllocation flags sprints without any allocated issue
+	RuleNoAllocation = "no-allocation"
+	// RuleAllocationTotal flags people whose allocation does not sum to 100%
+	RuleAllocationTotal = "allocation-total"
+)
+
+// allocationTotalTolerance absorbs rounding when checking that percentages sum to 100%
+const allocationTotalTolerance = 0.01
+
+// VerificationInput represents the input parameters for a sprint closure check
+type VerificationInput struct {
+	Project  string
+	Sprint   string
+	Override string
+	// LabelsAsOf classifies issues by their labels at a past point in time
+	LabelsAsOf LabelSnapshot
+	// WorkTypeSplits divides the hours of annotated issues across work types
+	WorkTypeSplits WorkTypeSplits
+	// Heuristics fills in untracked hours, as for AllocationInput
+	Heuristics AllocationHeuristics
+	// Export, when set, is a Jira JSON or CSV export allocated instead of querying Jira
+	Export string
+}
+
+// Violation is a sprint closure criterion that is not met
+type Violation struct {
+	Rule     string `json:"rule"`
+	IssueKey string `json:"issueKey,omitempty"`
+	Person   string `json:"person,omitempty"`
+	Message  string `json:"message"`
+}
+
+// VerificationResult lists the closure violations found in a sprint
+type VerificationResult struct {
+	Project    string      `json:"project"`
+	Sprint     string      `json:"sprint"`
+	Passed     bool        `json:"passed"`
+	Violations []Violation `json:"violations"`
+}
+
+// VerifySprintClosure checks the sprint allocation against the closure criteria: every done
+// issue is classified, every classified issue is linked to an asset, and each person's
+// allocation sums to 100%.
+func VerifySprintClosure(allocations []IssueAllocation) []Violation {
+	violations := []Violation{}
+	if len(allocations) == 0 {
+		return append(violations, Violation{
+			Rule:    RuleNoAllocation,
+			Message: "no issues were allocated in the sprint",
+		})
+	}
+
+	totals := make(map[string]float64)
+	for _, allocation := range allocations {
+		totals[allocation.Assignee] += allocation.Percentage
+
+		switch {
+		case allocation.WorkType == "" && allocation.IsDone():
+			violations = append(violations, Violation{
+				Rule:     RuleUnclassified,
+				IssueKey: allocation.IssueKey,
+				Person:   allocation.Assignee,
+				Message:  fmt.Sprintf("done issue %s has no work type", allocation.IssueKey),
+			})
+		case allocation.WorkType != "" && allocation.AssetName == "":
+			violations = append(violations, Violation{
+				Rule:     RuleMissingAsset,
+				IssueKey: allocation.IssueKey,
+				Person:   allocation.Assignee,
+				Message:  fmt.Sprintf("classified issue %s is not linked to an asset", allocation.IssueKey),
+			})
+		}
+	}
+
+	people := make([]string, 0, len(totals))
+	for person := range totals {
+		people = append(people, person)
+	}
+	sort.Strings(people)
+	for _, person := range people {
+		if math.Abs(totals[person]-100) > allocationTotalTolerance {
+			violations = append(violations, Violation{
+				Rule:    RuleAllocationTotal,
+				Person:  person,
+				Message: fmt.Sprintf("allocation of %s sums to %.2f%% instead of 100%%", person, totals[person]),
+			})
+		}
+	}
+	return violations
+}
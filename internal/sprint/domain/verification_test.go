@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifySprintClosure(t *testing.T) {
+	allocations := []IssueAllocation{
+		{IssueKey: "FN-1", Assignee: "alice", Status: "Done", WorkType: "cap-development", AssetName: "cap-asset-booking", Percentage: 60},
+		{IssueKey: "FN-2", Assignee: "alice", Status: "Done", Percentage: 40},
+		{IssueKey: "FN-3", Assignee: "bob", Status: "Done", WorkType: "cap-maintenance", Percentage: 50},
+		// Unfinished issues may stay unclassified
+		{IssueKey: "FN-4", Assignee: "bob", Status: "In Progress", Percentage: 30},
+	}
+
+	assert.Equal(t, []Violation{
+		{Rule: RuleUnclassified, IssueKey: "FN-2", Person: "alice", Message: "done issue FN-2 has no work type"},
+		{Rule: RuleMissingAsset, IssueKey: "FN-3", Person: "bob", Message: "classified issue FN-3 is not linked to an asset"},
+		{Rule: RuleAllocationTotal, Person: "bob", Message: "allocation of bob sums to 80.00% instead of 100%"},
+	}, VerifySprintClosure(allocations))
+}
+
+func TestVerifySprintClosure_Passes(t *testing.T) {
+	allocations := []IssueAllocation{
+		{IssueKey: "FN-1", Assignee: "alice", Status: "Done", WorkType: "cap-development", AssetName: "cap-asset-booking", Percentage: 33.333},
+		{IssueKey: "FN-2", Assignee: "alice", Status: "Done", WorkType: "cap-development", AssetName: "cap-asset-booking", Percentage: 66.667},
+	}
+
+	assert.Empty(t, VerifySprintClosure(allocations))
+}
+
+func TestVerifySprintClosure_NoAllocation(t *testing.T) {
+	violations := VerifySprintClosure(nil)
+
+	assert.Len(t, violations, 1)
+	assert.Equal(t, RuleNoAllocation, violations[0].Rule)
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// WorkTypeShares is the percentage of an issue's hours spent on each work type
+type WorkTypeShares map[string]float64
+
+// WorkTypes returns the work types of the shares in a stable order
+func (s WorkTypeShares) WorkTypes() []string {
+	workTypes := make([]string, 0, len(s))
+	for workType := range s {
+		workTypes = append(workTypes, workType)
+	}
+	sort.Strings(workTypes)
+	return workTypes
+}
+
+// String describes the shares, e.g. "cap-development 70%, cap-maintenance 30%"
+func (s WorkTypeShares) String() string {
+	parts := make([]string, 0, len(s))
+	for _, workType := range s.WorkTypes() {
+		parts = append(parts, fmt.Sprintf("%s %g%%", workType, s[workType]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// WorkTypeSplits maps issue keys to the work type shares their hours are divided by
+type WorkTypeSplits map[string]WorkTypeShares
+
+// For returns the shares of an issue, or nil when its hours go to a single work type
+func (s WorkTypeSplits) For(issueKey string) WorkTypeShares {
+	return s[strings.ToUpper(issueKey)]
+}
@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkTypeSplits_For(t *testing.T) {
+	splits := WorkTypeSplits{"FN-1": {"cap-maintenance": 30, "cap-development": 70}}
+
+	shares := splits.For("fn-1")
+	assert.Equal(t, []string{"cap-development", "cap-maintenance"}, shares.WorkTypes())
+	assert.Equal(t, "cap-development 70%, cap-maintenance 30%", shares.String())
+	assert.Nil(t, splits.For("FN-2"))
+	assert.Nil(t, WorkTypeSplits(nil).For("FN-1"))
+}
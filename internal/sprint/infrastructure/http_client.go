@@ -1,12 +1,18 @@
 package infrastructure
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/helmedeiros/digital-asset-capitalization/internal/redact"
 	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
 )
 
@@ -15,56 +21,208 @@ type HTTPClient struct {
 	client  *http.Client
 	baseURL string
 	auth    string
+	// sprintField is the custom field the sprints of issues are read from; empty finds them
+	// in whichever custom field holds them
+	sprintField string
+	// pageSize is the number of issues requested per page of a search; zero uses
+	// searchPageSize
+	pageSize int
+	// warnings receives warnings about incomplete responses
+	warnings io.Writer
+	// rateLimitDelay is the wait before retrying a rate limited request when Jira does not
+	// say how long to wait; it doubles on every retry
+	rateLimitDelay time.Duration
 }
 
+// JiraError is an error response from the Jira API
+type JiraError struct {
+	StatusCode int
+	Status     string
+	Body       string
+}
+
+// Error implements error
+func (e *JiraError) Error() string {
+	return fmt.Sprintf("error response from Jira: %s - %s", e.Status, redact.String(e.Body))
+}
+
+// Is makes rate limited responses match domain.ErrRateLimited
+func (e *JiraError) Is(target error) bool {
+	return target == domain.ErrRateLimited && e.StatusCode == http.StatusTooManyRequests
+}
+
+// MaxRateLimitRetries is how many times a request rate limited by Jira is retried
+const MaxRateLimitRetries = 3
+
+// defaultRateLimitDelay is the first wait before retrying a rate limited request that came
+// without a Retry-After header
+const defaultRateLimitDelay = 2 * time.Second
+
+// DefaultHTTPTimeout bounds each Jira request when no HTTP client is configured
+const DefaultHTTPTimeout = 10 * time.Second
+
 // NewHTTPClient creates a new HTTP client for Jira API
 func NewHTTPClient(baseURL, auth string) *HTTPClient {
+	return NewHTTPClientWith(&http.Client{Timeout: DefaultHTTPTimeout}, baseURL, auth)
+}
+
+// NewHTTPClientWith creates a new HTTP client for Jira API sending its requests with client
+func NewHTTPClientWith(client *http.Client, baseURL, auth string) *HTTPClient {
 	return &HTTPClient{
-		client: &http.Client{
-			Timeout: time.Second * 10,
-		},
-		baseURL: baseURL,
-		auth:    auth,
+		client:         client,
+		baseURL:        baseURL,
+		auth:           auth,
+		warnings:       os.Stderr,
+		rateLimitDelay: defaultRateLimitDelay,
 	}
 }
 
 // Get performs a GET request to the Jira API
 func (c *HTTPClient) Get(url string) ([]byte, error) {
-	req, err := http.NewRequest("GET", url, nil)
+	return c.do(http.MethodGet, url, nil)
+}
+
+// Post performs a POST request with a JSON body to the Jira API
+func (c *HTTPClient) Post(url string, payload []byte) ([]byte, error) {
+	return c.do(http.MethodPost, url, payload)
+}
+
+// Put performs a PUT request with a JSON body to the Jira API
+func (c *HTTPClient) Put(url string, payload []byte) ([]byte, error) {
+	return c.do(http.MethodPut, url, payload)
+}
+
+// Delete performs a DELETE request to the Jira API
+func (c *HTTPClient) Delete(url string) ([]byte, error) {
+	return c.do(http.MethodDelete, url, nil)
+}
+
+// do sends a request to the Jira API and returns the response body. Requests rate limited
+// by Jira are retried up to MaxRateLimitRetries times, after the wait Jira asks for in
+// Retry-After or else after rateLimitDelay, doubled on every retry.
+func (c *HTTPClient) do(method, url string, payload []byte) ([]byte, error) {
+	delay := c.rateLimitDelay
+	for attempt := 0; ; attempt++ {
+		body, retryAfter, err := c.send(method, url, payload)
+		if attempt >= MaxRateLimitRetries || !errors.Is(err, domain.ErrRateLimited) {
+			return body, err
+		}
+
+		wait := delay
+		if retryAfter > 0 {
+			wait = retryAfter
+		}
+		fmt.Fprintf(c.warnings, "Warning: rate limited by Jira, retrying in %s (retry %d of %d)\n", wait, attempt+1, MaxRateLimitRetries)
+		time.Sleep(wait)
+		delay *= 2
+	}
+}
+
+// send sends a single request to the Jira API and returns the response body, along with the
+// wait a rate limited response asked for in Retry-After
+func (c *HTTPClient) send(method, url string, payload []byte) ([]byte, time.Duration, error) {
+	var reqBody io.Reader
+	if payload != nil {
+		reqBody = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequest(method, url, reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Authorization", c.auth)
 	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return nil, 0, redact.Error(fmt.Errorf("failed to send request: %w", err))
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("error response from Jira: %s - %s", resp.Status, string(body))
+		var retryAfter time.Duration
+		if seconds, err := strconv.Atoi(strings.TrimSpace(resp.Header.Get("Retry-After"))); err == nil && seconds > 0 {
+			retryAfter = time.Duration(seconds) * time.Second
+		}
+		return nil, retryAfter, &JiraError{StatusCode: resp.StatusCode, Status: resp.Status, Body: string(body)}
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("error reading response body: %w", err)
+		return nil, 0, fmt.Errorf("error reading response body: %w", err)
 	}
 
-	return body, nil
+	return body, 0, nil
 }
 
-// JiraResponse represents the response from a Jira API search query
+// searchPageSize is the number of issues requested per page of a Jira search
+const searchPageSize = 100
+
+// JiraResponse represents a page of a Jira API search query
 type JiraResponse struct {
+	StartAt    int `json:"startAt"`
+	MaxResults int `json:"maxResults"`
+	// Total is the number of issues matching the query, nil when Jira does not report it
+	Total  *int               `json:"total"`
 	Issues []domain.JiraIssue `json:"issues"`
+	// WarningMessages report parts of the query Jira ignored, e.g. restricted fields
+	WarningMessages []string `json:"warningMessages"`
 }
 
-// GetJiraIssues retrieves issues from the Jira API
+// changelogs tells which issues of a search page came with a changelog
+type changelogs struct {
+	Issues []struct {
+		Changelog *json.RawMessage `json:"changelog"`
+	} `json:"issues"`
+}
+
+// GetJiraIssues retrieves every page of a Jira search, warning when the number of issues
+// received differs from the total Jira reported and passing on Jira's own warnings
 func (c *HTTPClient) GetJiraIssues(jiraURL string) ([]domain.JiraIssue, error) {
-	body, err := c.Get(jiraURL)
+	var issues []domain.JiraIssue
+	warned := make(map[string]bool)
+	for {
+		response, err := c.getJiraPage(jiraURL, len(issues))
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, response.Issues...)
+		for _, message := range response.WarningMessages {
+			if !warned[message] {
+				warned[message] = true
+				fmt.Fprintf(c.warnings, "Warning: Jira: %s\n", message)
+			}
+		}
+
+		if response.Total == nil {
+			return issues, nil
+		}
+		if len(response.Issues) == 0 || len(issues) >= *response.Total {
+			if len(issues) != *response.Total {
+				fmt.Fprintf(c.warnings, "Warning: Jira reported %d issues but %d were received\n", *response.Total, len(issues))
+			}
+			return issues, nil
+		}
+	}
+}
+
+// getJiraPage retrieves the page of a Jira search starting at the given issue. When the
+// search expands changelogs, issues returned without one are marked ChangelogUnavailable.
+func (c *HTTPClient) getJiraPage(jiraURL string, startAt int) (*JiraResponse, error) {
+	separator := "?"
+	if strings.Contains(jiraURL, "?") {
+		separator = "&"
+	}
+	pageSize := c.pageSize
+	if pageSize <= 0 {
+		pageSize = searchPageSize
+	}
+	body, err := c.Get(fmt.Sprintf("%s%sstartAt=%d&maxResults=%d", jiraURL, separator, startAt, pageSize))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get Jira issues: %w", err)
 	}
@@ -73,6 +231,25 @@ func (c *HTTPClient) GetJiraIssues(jiraURL string) ([]domain.JiraIssue, error) {
 	if err := json.Unmarshal(body, &response); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal Jira response: %w", err)
 	}
-
-	return response.Issues, nil
+	var present struct {
+		Issues []struct {
+			Fields json.RawMessage `json:"fields"`
+		} `json:"issues"`
+	}
+	if err := json.Unmarshal(body, &present); err == nil && len(present.Issues) == len(response.Issues) {
+		for i, issue := range present.Issues {
+			if c.sprintField != "" || len(response.Issues[i].Fields.Sprints) == 0 {
+				response.Issues[i].Fields.Sprints = issueSprints(issue.Fields, c.sprintField)
+			}
+		}
+	}
+	if strings.Contains(jiraURL, "expand=changelog") {
+		var returned changelogs
+		if err := json.Unmarshal(body, &returned); err == nil && len(returned.Issues) == len(response.Issues) {
+			for i, issue := range returned.Issues {
+				response.Issues[i].ChangelogUnavailable = issue.Changelog == nil
+			}
+		}
+	}
+	return &response, nil
 }
@@ -1,9 +1,17 @@
 package infrastructure
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
 )
 
 func TestHTTPClient_Get(t *testing.T) {
@@ -128,3 +136,190 @@ func TestHTTPClient_GetJiraIssues(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPClient_GetJiraIssuesPaginates(t *testing.T) {
+	const total = 250
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RawQuery)
+		startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		maxResults, _ := strconv.Atoi(r.URL.Query().Get("maxResults"))
+		var issues []string
+		for i := startAt; i < total && i < startAt+maxResults; i++ {
+			issues = append(issues, fmt.Sprintf(`{"key": "TEST-%d"}`, i+1))
+		}
+		fmt.Fprintf(w, `{"startAt": %d, "maxResults": %d, "total": %d, "issues": [%s]}`, startAt, maxResults, total, strings.Join(issues, ","))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(server.URL, "Bearer test-token")
+	var warnings bytes.Buffer
+	client.warnings = &warnings
+
+	issues, err := client.GetJiraIssues(server.URL + "/rest/api/3/search?jql=project+%3D+TEST")
+	if err != nil {
+		t.Fatalf("HTTPClient.GetJiraIssues() error = %v", err)
+	}
+	if len(issues) != total {
+		t.Fatalf("HTTPClient.GetJiraIssues() returned %d issues, want %d", len(issues), total)
+	}
+	if issues[0].Key != "TEST-1" || issues[total-1].Key != "TEST-250" {
+		t.Errorf("HTTPClient.GetJiraIssues() returned issues out of order: %s..%s", issues[0].Key, issues[total-1].Key)
+	}
+	wantRequests := []string{
+		"jql=project+%3D+TEST&startAt=0&maxResults=100",
+		"jql=project+%3D+TEST&startAt=100&maxResults=100",
+		"jql=project+%3D+TEST&startAt=200&maxResults=100",
+	}
+	if strings.Join(requests, "\n") != strings.Join(wantRequests, "\n") {
+		t.Errorf("HTTPClient.GetJiraIssues() requested %v, want %v", requests, wantRequests)
+	}
+	if warnings.Len() != 0 {
+		t.Errorf("HTTPClient.GetJiraIssues() warned for a complete result: %s", warnings.String())
+	}
+}
+
+func TestHTTPClient_GetJiraIssuesUsesPageSize(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Query().Get("startAt")+"/"+r.URL.Query().Get("maxResults"))
+		if r.URL.Query().Get("startAt") == "0" {
+			fmt.Fprint(w, `{"startAt": 0, "total": 3, "issues": [{"key": "TEST-1"}, {"key": "TEST-2"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"startAt": 2, "total": 3, "issues": [{"key": "TEST-3"}]}`)
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(server.URL, "Bearer test-token")
+	client.pageSize = 2
+
+	issues, err := client.GetJiraIssues(server.URL + "/rest/api/3/search?jql=project+%3D+TEST")
+	if err != nil {
+		t.Fatalf("HTTPClient.GetJiraIssues() error = %v", err)
+	}
+	if len(issues) != 3 {
+		t.Fatalf("HTTPClient.GetJiraIssues() returned %d issues, want 3", len(issues))
+	}
+	if want := "0/2,2/2"; strings.Join(requests, ",") != want {
+		t.Errorf("HTTPClient.GetJiraIssues() requested pages %v, want %s", requests, want)
+	}
+}
+
+func TestHTTPClient_GetJiraIssuesWarnsOnTotalMismatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("startAt") != "0" {
+			fmt.Fprint(w, `{"startAt": 2, "maxResults": 100, "total": 3, "issues": []}`)
+			return
+		}
+		fmt.Fprint(w, `{"startAt": 0, "maxResults": 100, "total": 3, "issues": [{"key": "TEST-1"}, {"key": "TEST-2"}]}`)
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(server.URL, "Bearer test-token")
+	var warnings bytes.Buffer
+	client.warnings = &warnings
+
+	issues, err := client.GetJiraIssues(server.URL)
+	if err != nil {
+		t.Fatalf("HTTPClient.GetJiraIssues() error = %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("HTTPClient.GetJiraIssues() returned %d issues, want 2", len(issues))
+	}
+	if got, want := warnings.String(), "Warning: Jira reported 3 issues but 2 were received\n"; got != want {
+		t.Errorf("HTTPClient.GetJiraIssues() warned %q, want %q", got, want)
+	}
+}
+
+func TestHTTPClient_GetJiraIssuesForwardsJiraWarnings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("startAt") != "0" {
+			fmt.Fprint(w, `{"startAt": 1, "maxResults": 1, "total": 2, "issues": [{"key": "TEST-2"}], "warningMessages": ["The value 'secret' does not exist for the field 'labels'."]}`)
+			return
+		}
+		fmt.Fprint(w, `{"startAt": 0, "maxResults": 1, "total": 2, "issues": [{"key": "TEST-1"}], "warningMessages": ["The value 'secret' does not exist for the field 'labels'."]}`)
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(server.URL, "Bearer test-token")
+	var warnings bytes.Buffer
+	client.warnings = &warnings
+
+	if _, err := client.GetJiraIssues(server.URL); err != nil {
+		t.Fatalf("HTTPClient.GetJiraIssues() error = %v", err)
+	}
+	if got, want := warnings.String(), "Warning: Jira: The value 'secret' does not exist for the field 'labels'.\n"; got != want {
+		t.Errorf("HTTPClient.GetJiraIssues() warned %q, want %q", got, want)
+	}
+}
+
+func TestHTTPClient_ErrorsRedactCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprintf(w, `{"errorMessages": ["Invalid header %s"]}`, r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(server.URL, "Basic amFuZUBhY21lLmNvbTpzZWNyZXQ=")
+	_, err := client.Get(server.URL + "/rest/api/3/myself")
+	if err == nil {
+		t.Fatal("Get() expected an error")
+	}
+	if strings.Contains(err.Error(), "amFuZUBhY21lLmNvbTpzZWNyZXQ=") {
+		t.Errorf("Get() error = %q, leaks the authorization header", err)
+	}
+	if !strings.Contains(err.Error(), "Basic [REDACTED]") {
+		t.Errorf("Get() error = %q, want the credential masked", err)
+	}
+}
+
+func TestHTTPClient_RetriesRateLimitedRequests(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"id": "1"}`))
+	}))
+	defer server.Close()
+
+	var warnings bytes.Buffer
+	client := NewHTTPClient(server.URL, "Bearer test-token")
+	client.warnings = &warnings
+	client.rateLimitDelay = time.Millisecond
+
+	body, err := client.Post(server.URL, []byte(`{}`))
+	if err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	if string(body) != `{"id": "1"}` || requests != 3 {
+		t.Errorf("Post() = %s after %d requests, want the response of the third", body, requests)
+	}
+	if !strings.Contains(warnings.String(), "rate limited by Jira, retrying in 2ms (retry 2 of 3)") {
+		t.Errorf("warnings = %q, want the retries reported with a doubling wait", warnings.String())
+	}
+}
+
+func TestHTTPClient_GivesUpOnRateLimit(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(server.URL, "Bearer test-token")
+	client.warnings = &bytes.Buffer{}
+	client.rateLimitDelay = time.Millisecond
+
+	_, err := client.Get(server.URL)
+	if !errors.Is(err, domain.ErrRateLimited) {
+		t.Fatalf("Get() error = %v, want it to match domain.ErrRateLimited", err)
+	}
+	if requests != MaxRateLimitRetries+1 {
+		t.Errorf("requests = %d, want %d", requests, MaxRateLimitRetries+1)
+	}
+}
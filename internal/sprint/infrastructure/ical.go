@@ -0,0 +1,166 @@
+package infrastructure
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+// icalEvent holds the properties of a VEVENT relevant to absences
+type icalEvent struct {
+	start, end, duration, summary string
+	endIsDate                     bool
+}
+
+// ParseICalAbsences reads the events of an iCalendar (.ics) file, such as a vacation
+// calendar export, as absences covering the days each event spans
+func ParseICalAbsences(r io.Reader) ([]domain.Absence, error) {
+	lines, err := unfoldICalLines(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read calendar: %w", err)
+	}
+
+	var absences []domain.Absence
+	var event *icalEvent
+	for _, line := range lines {
+		name, value, ok := splitICalProperty(line)
+		if !ok {
+			continue
+		}
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			event = &icalEvent{}
+		case name == "END" && strings.EqualFold(value, "VEVENT") && event != nil:
+			absence, err := event.absence()
+			if err != nil {
+				return nil, err
+			}
+			absences = append(absences, absence)
+			event = nil
+		case event == nil:
+		case name == "DTSTART":
+			event.start = value
+		case name == "DTEND":
+			event.end = value
+			event.endIsDate = !strings.Contains(value, "T")
+		case name == "DURATION":
+			event.duration = value
+		case name == "SUMMARY":
+			event.summary = unescapeICalText(value)
+		}
+	}
+	return absences, nil
+}
+
+// absence returns the days the event spans. An all-day event ends the day before its DTEND,
+// and an event ending at midnight the day before that midnight.
+func (e *icalEvent) absence() (domain.Absence, error) {
+	if e.start == "" {
+		return domain.Absence{}, fmt.Errorf("calendar event %q has no DTSTART", e.summary)
+	}
+	from, _, err := parseICalTime(e.start)
+	if err != nil {
+		return domain.Absence{}, err
+	}
+
+	to := from
+	switch {
+	case e.end != "":
+		end, midnight, err := parseICalTime(e.end)
+		if err != nil {
+			return domain.Absence{}, err
+		}
+		to = end
+		if (e.endIsDate || midnight) && end.After(from) {
+			to = end.AddDate(0, 0, -1)
+		}
+	case e.duration != "":
+		days, err := parseICalDays(e.duration)
+		if err != nil {
+			return domain.Absence{}, err
+		}
+		if days > 1 {
+			to = from.AddDate(0, 0, days-1)
+		}
+	}
+
+	absence, err := domain.NewAbsence(from, to, e.summary)
+	if err != nil {
+		return domain.Absence{}, fmt.Errorf("invalid calendar event %q: %w", e.summary, err)
+	}
+	return absence, nil
+}
+
+// unfoldICalLines reads the content lines of a calendar, joining folded continuation lines
+func unfoldICalLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// splitICalProperty splits a content line such as DTSTART;VALUE=DATE:20240506 into its
+// upper-case name and its value, dropping the parameters
+func splitICalProperty(line string) (name, value string, ok bool) {
+	key, value, ok := strings.Cut(line, ":")
+	if !ok {
+		return "", "", false
+	}
+	name, _, _ = strings.Cut(key, ";")
+	return strings.ToUpper(name), value, true
+}
+
+// parseICalTime parses a DATE or DATE-TIME value, returning the day it falls on and whether
+// it is a date-time at midnight. Time zones are ignored: absences are whole days.
+func parseICalTime(value string) (time.Time, bool, error) {
+	date, clock, hasTime := strings.Cut(strings.TrimSuffix(value, "Z"), "T")
+	day, err := time.Parse("20060102", date)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid calendar date %q", value)
+	}
+	return day, hasTime && strings.TrimLeft(clock, "0") == "", nil
+}
+
+// parseICalDays returns the whole days of a DURATION such as P5D or P2W, rounding partial
+// days up
+func parseICalDays(value string) (int, error) {
+	duration := strings.TrimPrefix(strings.ToUpper(value), "P")
+	weeks, rest, isWeeks := strings.Cut(duration, "W")
+	if isWeeks && rest == "" {
+		n, err := strconv.Atoi(weeks)
+		if err != nil {
+			return 0, fmt.Errorf("invalid calendar duration %q", value)
+		}
+		return n * 7, nil
+	}
+
+	days := 0
+	if d, rest, ok := strings.Cut(duration, "D"); ok {
+		n, err := strconv.Atoi(d)
+		if err != nil {
+			return 0, fmt.Errorf("invalid calendar duration %q", value)
+		}
+		days, duration = n, rest
+	}
+	if strings.TrimPrefix(duration, "T") != "" {
+		days++
+	}
+	return days, nil
+}
+
+// unescapeICalText undoes the escaping of commas, semicolons, backslashes and newlines in text
+func unescapeICalText(value string) string {
+	return strings.NewReplacer(`\,`, ",", `\;`, ";", `\\`, `\`, `\n`, " ", `\N`, " ").Replace(value)
+}
@@ -0,0 +1,69 @@
+package infrastructure
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+func TestParseICalAbsences(t *testing.T) {
+	calendar := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20240506",
+		"DTEND;VALUE=DATE:20240511",
+		"SUMMARY:Summer vacation\\, Lisbon",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART:20240603T000000Z",
+		"DTEND:20240605T000000Z",
+		"SUMMARY:Conference",
+		"  talk",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART;TZID=Europe/Berlin:20240610T090000",
+		"DTEND;TZID=Europe/Berlin:20240610T130000",
+		"SUMMARY:Doctor",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20240701",
+		"DURATION:P2W",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	absences, err := ParseICalAbsences(strings.NewReader(calendar))
+
+	require.NoError(t, err)
+	date := func(month time.Month, d int) time.Time { return time.Date(2024, month, d, 0, 0, 0, 0, time.UTC) }
+	assert.Equal(t, []domain.Absence{
+		{From: date(time.May, 6), To: date(time.May, 10), Reason: "Summer vacation, Lisbon"},
+		{From: date(time.June, 3), To: date(time.June, 4), Reason: "Conference talk"},
+		{From: date(time.June, 10), To: date(time.June, 10), Reason: "Doctor"},
+		{From: date(time.July, 1), To: date(time.July, 14)},
+	}, absences)
+}
+
+func TestParseICalAbsences_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		calendar string
+		wantErr  string
+	}{
+		{name: "missing start", calendar: "BEGIN:VEVENT\nSUMMARY:Off\nEND:VEVENT", wantErr: `calendar event "Off" has no DTSTART`},
+		{name: "invalid date", calendar: "BEGIN:VEVENT\nDTSTART:2024-05-06\nEND:VEVENT", wantErr: `invalid calendar date "2024-05-06"`},
+		{name: "end before start", calendar: "BEGIN:VEVENT\nDTSTART:20240510\nDTEND:20240501\nSUMMARY:Off\nEND:VEVENT", wantErr: `invalid calendar event "Off": absence ends on 2024-05-01, before it starts on 2024-05-10`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseICalAbsences(strings.NewReader(tt.calendar))
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
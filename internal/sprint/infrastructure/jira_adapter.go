@@ -1,10 +1,10 @@
 package infrastructure
 
 import (
-	"encoding/json"
 	"fmt"
-	"net/url"
-	"os"
+	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/config"
 	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
@@ -14,85 +14,65 @@ import (
 // JiraAdapter implements the JiraPort interface
 type JiraAdapter struct {
 	config     *config.JiraConfig
-	teams      domain.TeamMap
 	httpClient *HTTPClient
+	// sprintField is the custom field holding the sprints of issues on the instance
+	sprintField string
+	// statuses caches the categories of the statuses by ID; statusesFetched is set once the
+	// status metadata was requested
+	statuses        domain.StatusCategories
+	statusesFetched bool
 }
 
 // NewJiraAdapter creates a new Jira adapter
-func NewJiraAdapter(teamsFilePath string) (*JiraAdapter, error) {
+func NewJiraAdapter() (*JiraAdapter, error) {
+	return NewJiraAdapterWithClient(nil)
+}
+
+// NewJiraAdapterWithClient creates a new Jira adapter sending its requests with client, or
+// a client with DefaultHTTPTimeout when nil
+func NewJiraAdapterWithClient(client *http.Client) (*JiraAdapter, error) {
 	// Load Jira configuration
 	jiraConfig, err := config.NewJiraConfig()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load Jira configuration: %w", err)
 	}
 
-	// Load teams data
-	var teams domain.TeamMap
-	var teamsData []byte
-
-	// Try to read teams.json from the specified path
-	teamsData, err = os.ReadFile(teamsFilePath)
-	if err != nil {
-		// Try to use teams.json.template as fallback
-		teamsData, err = os.ReadFile(teamsFilePath + ".template")
-		if err != nil {
-			// Create a default teams.json file
-			teamsData = []byte(`{
-				"FN": {
-					"team": ["helio.medeiros", "julio.medeiros"]
-				}
-			}`)
-			err = os.WriteFile(teamsFilePath, teamsData, 0644)
-			if err != nil {
-				return nil, fmt.Errorf("failed to create default teams.json: %w", err)
-			}
-		}
-	}
-
-	if err := json.Unmarshal(teamsData, &teams); err != nil {
-		return nil, fmt.Errorf("error unmarshaling teams data: %w", err)
-	}
-
 	// Create HTTP client
-	httpClient := NewHTTPClient(jiraConfig.GetBaseURL(), jiraConfig.GetAuthHeader())
+	if client == nil {
+		client = &http.Client{Timeout: DefaultHTTPTimeout}
+	}
+	httpClient := NewHTTPClientWith(client, jiraConfig.GetBaseURL(), jiraConfig.GetAuthHeader())
 
 	return &JiraAdapter{
 		config:     jiraConfig,
-		teams:      teams,
 		httpClient: httpClient,
 	}, nil
 }
 
-// GetIssuesForSprint retrieves all issues for a given sprint
+// GetIssuesForSprint retrieves all issues for a given sprint, named or given by its numeric ID.
+// An ID selects the exact sprint where parallel boards reuse a sprint name.
 func (a *JiraAdapter) GetIssuesForSprint(project, sprintID string) ([]ports.JiraIssue, error) {
 	query := fmt.Sprintf("project = %s AND sprint = '%s'", project, sprintID)
-	encodedQuery := url.QueryEscape(query)
-	fields := "summary,assignee,status,changelog,issuetype,customfield_10014,customfield_10015,labels"
-	jiraURL := fmt.Sprintf("%s/rest/api/3/search?jql=%s&expand=changelog&fields=%s",
-		a.config.GetBaseURL(), encodedQuery, fields)
-
-	issues, err := a.httpClient.GetJiraIssues(jiraURL)
+	if id, err := strconv.Atoi(strings.TrimSpace(sprintID)); err == nil && id > 0 {
+		query = fmt.Sprintf("project = %s AND sprint = %d", project, id)
+	}
+	issues, err := a.searchIssues(query, allocationFields)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch sprint issues: %w", err)
 	}
 
-	return a.convertToPortIssues(issues), nil
+	return convertToPortIssues(issues), nil
 }
 
 // GetIssuesForTeamMember retrieves all issues assigned to a team member
 func (a *JiraAdapter) GetIssuesForTeamMember(member string) ([]ports.JiraIssue, error) {
 	query := fmt.Sprintf("assignee = '%s'", member)
-	encodedQuery := url.QueryEscape(query)
-	fields := "summary,assignee,status,changelog,issuetype,customfield_10014,customfield_10015,labels"
-	jiraURL := fmt.Sprintf("%s/rest/api/3/search?jql=%s&expand=changelog&fields=%s",
-		a.config.GetBaseURL(), encodedQuery, fields)
-
-	issues, err := a.httpClient.GetJiraIssues(jiraURL)
+	issues, err := a.searchIssues(query, []string{"summary", "assignee", "status", "changelog", "issuetype", "customfield_10014", "customfield_10015", "labels", "created", "resolutiondate"})
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch team member issues: %w", err)
 	}
 
-	return a.convertToPortIssues(issues), nil
+	return convertToPortIssues(issues), nil
 }
 
 // GetSprintIssues retrieves all issues in a sprint
@@ -139,9 +119,13 @@ func convertChangelog(changelog domain.JiraChangelog) ports.JiraChangelog {
 		// Convert changelog items
 		for j, item := range history.Items {
 			portHistory.Items[j] = ports.JiraChangeItem{
-				Field:      item.Field,
-				FromString: item.FromString,
-				ToString:   item.ToString,
+				Field:        item.Field,
+				From:         item.From,
+				FromString:   item.FromString,
+				To:           item.To,
+				ToString:     item.ToString,
+				FromCategory: item.FromCategory,
+				ToCategory:   item.ToCategory,
 			}
 		}
 
@@ -151,20 +135,44 @@ func convertChangelog(changelog domain.JiraChangelog) ports.JiraChangelog {
 	return portChangelog
 }
 
+// convertSprints converts domain sprints to ports sprints
+func convertSprints(sprints []domain.JiraSprint) []ports.JiraSprint {
+	portSprints := make([]ports.JiraSprint, len(sprints))
+	for i, sprint := range sprints {
+		portSprints[i] = ports.JiraSprint{
+			ID:        sprint.ID,
+			Name:      sprint.Name,
+			State:     sprint.State,
+			StartDate: sprint.StartDate,
+			EndDate:   sprint.EndDate,
+		}
+	}
+	return portSprints
+}
+
 // convertToPortIssues converts domain JiraIssue to port JiraIssue
-func (a *JiraAdapter) convertToPortIssues(issues []domain.JiraIssue) []ports.JiraIssue {
+func convertToPortIssues(issues []domain.JiraIssue) []ports.JiraIssue {
 	var portIssues = make([]ports.JiraIssue, 0, len(issues))
 
 	for _, issue := range issues {
 		portIssue := ports.JiraIssue{
-			Key:         issue.Key,
-			Summary:     issue.Fields.Summary,
-			Assignee:    issue.Fields.Assignee.DisplayName,
-			Status:      issue.Fields.Status.Name,
-			StoryPoints: issue.Fields.StoryPoints,
-			IssueType:   issue.Fields.IssueType.Name,
-			Labels:      issue.Fields.Labels,
-			Changelog:   convertChangelog(issue.Changelog),
+			Key:                  issue.Key,
+			Summary:              issue.Fields.Summary,
+			Assignee:             issue.Fields.Assignee.DisplayName,
+			AssigneeAccountID:    issue.Fields.Assignee.AccountID,
+			Status:               issue.Fields.Status.Name,
+			StatusCategory:       issue.Fields.Status.Category.Key,
+			StoryPoints:          issue.Fields.StoryPoints,
+			IssueType:            issue.Fields.IssueType.Name,
+			IssueTypeSubtask:     issue.Fields.IssueType.Subtask || issue.Fields.IssueType.HierarchyLevel < 0,
+			Labels:               issue.Fields.Labels,
+			Sprints:              convertSprints(issue.Fields.Sprints),
+			Changelog:            convertChangelog(issue.Changelog),
+			Created:              issue.Fields.Created,
+			ResolutionDate:       issue.Fields.ResolutionDate,
+			OriginalEstimate:     issue.Fields.OriginalEstimate,
+			RemainingEstimate:    issue.Fields.RemainingEstimate,
+			ChangelogUnavailable: issue.ChangelogUnavailable,
 		}
 
 		portIssues = append(portIssues, portIssue)
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain/ports"
 )
 
 func setupTestEnv(t *testing.T) func() {
@@ -80,7 +81,7 @@ func TestJiraAdapter_GetIssues(t *testing.T) {
 	// Create a test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "/rest/api/3/search", r.URL.Path)
-		assert.Equal(t, "jql=project+%3D+TEST+AND+sprint+%3D+%27Test+Sprint%27&expand=changelog&fields=summary,assignee,status,changelog,issuetype,customfield_10014,customfield_10015,labels", r.URL.RawQuery)
+		assert.Equal(t, "jql=project+%3D+TEST+AND+sprint+%3D+%27Test+Sprint%27&expand=changelog&fields=summary,assignee,status,changelog,issuetype,customfield_10014,customfield_10015,customfield_13192,customfield_10020,labels,created,resolutiondate,timeoriginalestimate,timeestimate&startAt=0&maxResults=100", r.URL.RawQuery)
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte(`{
 			"issues": [
@@ -93,6 +94,8 @@ func TestJiraAdapter_GetIssues(t *testing.T) {
 						"issuetype": {"name": "Task"},
 						"customfield_10014": "Development",
 						"customfield_10015": "Test Asset",
+						"customfield_13192": 5,
+						"sprint": [{"id": 42, "name": "Test Sprint", "state": "active", "startDate": "2024-03-18T09:00:00.000Z", "endDate": "2024-03-29T17:00:00.000Z"}],
 						"labels": ["cap-development", "cap-asset-booking"]
 					}
 				}
@@ -103,7 +106,7 @@ func TestJiraAdapter_GetIssues(t *testing.T) {
 
 	// Create adapter with test server URL
 	os.Setenv("JIRA_BASE_URL", server.URL)
-	adapter, err := NewJiraAdapter(t.TempDir() + "/teams.json")
+	adapter, err := NewJiraAdapter()
 	require.NoError(t, err)
 	require.NotNil(t, adapter)
 
@@ -116,6 +119,48 @@ func TestJiraAdapter_GetIssues(t *testing.T) {
 	assert.Equal(t, "Test User 1", issues[0].Assignee)
 	assert.Equal(t, "In Progress", issues[0].Status)
 	assert.Equal(t, []string{"cap-development", "cap-asset-booking"}, issues[0].Labels)
+	require.NotNil(t, issues[0].StoryPoints)
+	assert.Equal(t, 5.0, *issues[0].StoryPoints)
+	assert.Equal(t, []ports.JiraSprint{
+		{ID: 42, Name: "Test Sprint", State: "active", StartDate: "2024-03-18T09:00:00.000Z", EndDate: "2024-03-29T17:00:00.000Z"},
+	}, issues[0].Sprints)
+}
+
+func TestNewJiraAdapterWithClient(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(`{"issues": []}`))
+	}))
+	defer server.Close()
+	os.Setenv("JIRA_BASE_URL", server.URL)
+
+	adapter, err := NewJiraAdapterWithClient(server.Client())
+	require.NoError(t, err)
+
+	issues, err := adapter.GetIssuesForSprint("TEST", "Test Sprint")
+	require.NoError(t, err, "the given client trusts the test server's certificate")
+	assert.Empty(t, issues)
+}
+
+func TestJiraAdapter_GetIssuesForSprintID(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "project = TEST AND sprint = 42", r.URL.Query().Get("jql"))
+		w.Write([]byte(`{"issues": []}`))
+	}))
+	defer server.Close()
+
+	os.Setenv("JIRA_BASE_URL", server.URL)
+	adapter, err := NewJiraAdapter()
+	require.NoError(t, err)
+
+	issues, err := adapter.GetIssuesForSprint("TEST", "42")
+	require.NoError(t, err)
+	assert.Empty(t, issues)
 }
 
 func TestJiraAdapter_GetTeamIssues(t *testing.T) {
@@ -125,7 +170,7 @@ func TestJiraAdapter_GetTeamIssues(t *testing.T) {
 	// Create a test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "/rest/api/3/search", r.URL.Path)
-		assert.Equal(t, "jql=assignee+%3D+%27Test+User+1%27&expand=changelog&fields=summary,assignee,status,changelog,issuetype,customfield_10014,customfield_10015,labels", r.URL.RawQuery)
+		assert.Equal(t, "jql=assignee+%3D+%27Test+User+1%27&expand=changelog&fields=summary,assignee,status,changelog,issuetype,customfield_10014,customfield_10015,labels,created,resolutiondate&startAt=0&maxResults=100", r.URL.RawQuery)
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte(`{
 			"issues": [
@@ -148,7 +193,7 @@ func TestJiraAdapter_GetTeamIssues(t *testing.T) {
 
 	// Create adapter with test server URL
 	os.Setenv("JIRA_BASE_URL", server.URL)
-	adapter, err := NewJiraAdapter(t.TempDir() + "/teams.json")
+	adapter, err := NewJiraAdapter()
 	require.NoError(t, err)
 	require.NotNil(t, adapter)
 
@@ -176,7 +221,7 @@ func TestJiraAdapter_ServerError(t *testing.T) {
 
 	// Create adapter with test server URL
 	os.Setenv("JIRA_BASE_URL", server.URL)
-	adapter, err := NewJiraAdapter(t.TempDir() + "/teams.json")
+	adapter, err := NewJiraAdapter()
 	require.NoError(t, err)
 	require.NotNil(t, adapter)
 
@@ -200,7 +245,7 @@ func TestJiraAdapter_InvalidJSON(t *testing.T) {
 
 	// Create adapter with test server URL
 	os.Setenv("JIRA_BASE_URL", server.URL)
-	adapter, err := NewJiraAdapter(t.TempDir() + "/teams.json")
+	adapter, err := NewJiraAdapter()
 	require.NoError(t, err)
 	require.NotNil(t, adapter)
 
@@ -218,7 +263,7 @@ func TestJiraAdapter_GetSprintIssues(t *testing.T) {
 	// Create a test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "/rest/api/3/search", r.URL.Path)
-		assert.Equal(t, "jql=project+%3D+TEST+AND+sprint+%3D+%27Test+Sprint%27&expand=changelog&fields=summary,assignee,status,changelog,issuetype,customfield_10014,customfield_10015,labels", r.URL.RawQuery)
+		assert.Equal(t, "jql=project+%3D+TEST+AND+sprint+%3D+%27Test+Sprint%27&expand=changelog&fields=summary,assignee,status,changelog,issuetype,customfield_10014,customfield_10015,customfield_13192,customfield_10020,labels,created,resolutiondate,timeoriginalestimate,timeestimate&startAt=0&maxResults=100", r.URL.RawQuery)
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte(`{
 			"issues": [
@@ -241,7 +286,7 @@ func TestJiraAdapter_GetSprintIssues(t *testing.T) {
 
 	// Create adapter with test server URL
 	os.Setenv("JIRA_BASE_URL", server.URL)
-	adapter, err := NewJiraAdapter(t.TempDir() + "/teams.json")
+	adapter, err := NewJiraAdapter()
 	require.NoError(t, err)
 	require.NotNil(t, adapter)
 
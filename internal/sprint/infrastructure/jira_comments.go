@@ -0,0 +1,119 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain/ports"
+)
+
+// adfNode represents a node of an Atlassian Document Format document
+type adfNode struct {
+	Type    string    `json:"type"`
+	Version int       `json:"version,omitempty"`
+	Text    string    `json:"text,omitempty"`
+	Content []adfNode `json:"content,omitempty"`
+}
+
+// commentsPageSize is the number of comments requested per page
+const commentsPageSize = 100
+
+// commentResponse represents a page of the response from the Jira issue comments API
+type commentResponse struct {
+	// Total is the number of comments of the issue, nil when Jira does not report it
+	Total    *int `json:"total"`
+	Comments []struct {
+		ID   string  `json:"id"`
+		Body adfNode `json:"body"`
+	} `json:"comments"`
+}
+
+// GetComments retrieves every page of the comments of an issue
+func (a *JiraAdapter) GetComments(issueKey string) ([]ports.JiraComment, error) {
+	comments := []ports.JiraComment{}
+	for {
+		body, err := a.httpClient.Get(fmt.Sprintf("%s?startAt=%d&maxResults=%d", a.commentsURL(issueKey), len(comments), commentsPageSize))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch comments for %s: %w", issueKey, err)
+		}
+
+		var response commentResponse
+		if err := json.Unmarshal(body, &response); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal comments response: %w", err)
+		}
+
+		for _, comment := range response.Comments {
+			comments = append(comments, ports.JiraComment{
+				ID:   comment.ID,
+				Body: adfToText(comment.Body),
+			})
+		}
+		if response.Total == nil || len(response.Comments) == 0 || len(comments) >= *response.Total {
+			return comments, nil
+		}
+	}
+}
+
+// AddComment adds a plain text comment to an issue
+func (a *JiraAdapter) AddComment(issueKey, body string) error {
+	payload, err := json.Marshal(map[string]interface{}{"body": textToADF(body)})
+	if err != nil {
+		return fmt.Errorf("failed to marshal comment: %w", err)
+	}
+
+	if _, err := a.httpClient.Post(a.commentsURL(issueKey), payload); err != nil {
+		return fmt.Errorf("failed to add comment to %s: %w", issueKey, err)
+	}
+	return nil
+}
+
+// UpdateComment replaces the body of an existing comment
+func (a *JiraAdapter) UpdateComment(issueKey, commentID, body string) error {
+	payload, err := json.Marshal(map[string]interface{}{"body": textToADF(body)})
+	if err != nil {
+		return fmt.Errorf("failed to marshal comment: %w", err)
+	}
+
+	if _, err := a.httpClient.Put(a.commentsURL(issueKey)+"/"+commentID, payload); err != nil {
+		return fmt.Errorf("failed to update comment %s on %s: %w", commentID, issueKey, err)
+	}
+	return nil
+}
+
+func (a *JiraAdapter) commentsURL(issueKey string) string {
+	return fmt.Sprintf("%s/rest/api/3/issue/%s/comment", a.config.GetBaseURL(), issueKey)
+}
+
+// textToADF converts plain text into an ADF document with one paragraph per line
+func textToADF(text string) adfNode {
+	doc := adfNode{Type: "doc", Version: 1}
+	for _, line := range strings.Split(text, "\n") {
+		paragraph := adfNode{Type: "paragraph"}
+		if line != "" {
+			paragraph.Content = []adfNode{{Type: "text", Text: line}}
+		}
+		doc.Content = append(doc.Content, paragraph)
+	}
+	return doc
+}
+
+// adfToText flattens an ADF document into plain text, one line per paragraph
+func adfToText(node adfNode) string {
+	if node.Type == "text" {
+		return node.Text
+	}
+
+	parts := make([]string, 0, len(node.Content))
+	for _, child := range node.Content {
+		parts = append(parts, adfToText(child))
+	}
+
+	if node.Type == "doc" {
+		return strings.Join(parts, "\n")
+	}
+	return strings.Join(parts, "")
+}
+
+// Ensure JiraAdapter implements JiraCommentPort
+var _ ports.JiraCommentPort = (*JiraAdapter)(nil)
@@ -0,0 +1,149 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJiraAdapter_GetComments(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/rest/api/3/issue/TEST-1/comment", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{
+			"comments": [
+				{
+					"id": "10001",
+					"body": {
+						"type": "doc",
+						"version": 1,
+						"content": [
+							{"type": "paragraph", "content": [{"type": "text", "text": "[assetcap:allocation]"}]},
+							{"type": "paragraph", "content": [{"type": "text", "text": "Hours: 8.00"}]}
+						]
+					}
+				}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	os.Setenv("JIRA_BASE_URL", server.URL)
+	adapter, err := NewJiraAdapter()
+	require.NoError(t, err)
+
+	comments, err := adapter.GetComments("TEST-1")
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, "10001", comments[0].ID)
+	assert.Equal(t, "[assetcap:allocation]\nHours: 8.00", comments[0].Body)
+}
+
+func TestJiraAdapter_GetCommentsPages(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	comment := func(id, text string) string {
+		return `{"id": "` + id + `", "body": {"type": "doc", "version": 1, "content": [{"type": "paragraph", "content": [{"type": "text", "text": "` + text + `"}]}]}}`
+	}
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pages = append(pages, r.URL.Query().Get("startAt"))
+		assert.Equal(t, "100", r.URL.Query().Get("maxResults"))
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Query().Get("startAt") == "0" {
+			w.Write([]byte(`{"startAt": 0, "maxResults": 2, "total": 3, "comments": [` + comment("10001", "LGTM") + `, ` + comment("10002", "Merged") + `]}`))
+			return
+		}
+		w.Write([]byte(`{"startAt": 2, "maxResults": 2, "total": 3, "comments": [` + comment("10003", "[assetcap:allocation]") + `]}`))
+	}))
+	defer server.Close()
+
+	os.Setenv("JIRA_BASE_URL", server.URL)
+	adapter, err := NewJiraAdapter()
+	require.NoError(t, err)
+
+	comments, err := adapter.GetComments("TEST-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0", "2"}, pages, "pages are requested from where the last one ended")
+	require.Len(t, comments, 3)
+	assert.Equal(t, "10003", comments[2].ID)
+	assert.Equal(t, "[assetcap:allocation]", comments[2].Body, "the marker comment on the second page is found")
+}
+
+func TestJiraAdapter_AddComment(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/rest/api/3/issue/TEST-1/comment", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		data, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var payload struct {
+			Body adfNode `json:"body"`
+		}
+		require.NoError(t, json.Unmarshal(data, &payload))
+		assert.Equal(t, "doc", payload.Body.Type)
+		assert.Equal(t, "line one\nline two", adfToText(payload.Body))
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id": "10002"}`))
+	}))
+	defer server.Close()
+
+	os.Setenv("JIRA_BASE_URL", server.URL)
+	adapter, err := NewJiraAdapter()
+	require.NoError(t, err)
+
+	require.NoError(t, adapter.AddComment("TEST-1", "line one\nline two"))
+}
+
+func TestJiraAdapter_UpdateComment(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/rest/api/3/issue/TEST-1/comment/10001", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": "10001"}`))
+	}))
+	defer server.Close()
+
+	os.Setenv("JIRA_BASE_URL", server.URL)
+	adapter, err := NewJiraAdapter()
+	require.NoError(t, err)
+
+	require.NoError(t, adapter.UpdateComment("TEST-1", "10001", "updated"))
+}
+
+func TestJiraAdapter_AddCommentServerError(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	os.Setenv("JIRA_BASE_URL", server.URL)
+	adapter, err := NewJiraAdapter()
+	require.NoError(t, err)
+
+	err = adapter.AddComment("TEST-1", "body")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "TEST-1")
+}
@@ -0,0 +1,153 @@
+package infrastructure
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/jiraexport"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain/ports"
+)
+
+// ExportAdapter implements the JiraPort interface over a Jira issue export, for allocating
+// sprints where the Jira API cannot be reached. Issues exported without their changelog, as
+// every CSV export is, are marked ChangelogUnavailable and allocated from their dates.
+type ExportAdapter struct {
+	issues []domain.JiraIssue
+}
+
+// NewExportAdapter creates a Jira adapter reading the issues of a JSON or CSV export
+func NewExportAdapter(path string) (*ExportAdapter, error) {
+	format, data, err := jiraexport.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var issues []domain.JiraIssue
+	if format == jiraexport.FormatCSV {
+		issues, err = csvExportIssues(data)
+	} else {
+		issues, err = jsonExportIssues(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read export %s: %w", path, err)
+	}
+	return &ExportAdapter{issues: issues}, nil
+}
+
+// jsonExportIssues decodes the issues of a JSON export, finding their sprints in whichever
+// custom field holds them
+func jsonExportIssues(data []byte) ([]domain.JiraIssue, error) {
+	raw, err := jiraexport.Issues(data)
+	if err != nil {
+		return nil, err
+	}
+	issues := make([]domain.JiraIssue, 0, len(raw))
+	for _, element := range raw {
+		var issue domain.JiraIssue
+		if err := json.Unmarshal(element, &issue); err != nil {
+			return nil, fmt.Errorf("invalid issue in export: %w", err)
+		}
+		var present struct {
+			Fields    json.RawMessage  `json:"fields"`
+			Changelog *json.RawMessage `json:"changelog"`
+		}
+		if err := json.Unmarshal(element, &present); err != nil {
+			return nil, fmt.Errorf("invalid issue in export: %w", err)
+		}
+		issue.ChangelogUnavailable = present.Changelog == nil
+		if len(issue.Fields.Sprints) == 0 {
+			issue.Fields.Sprints = issueSprints(present.Fields, "")
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// csvExportIssues converts the records of a CSV export to issues without a changelog
+func csvExportIssues(data []byte) ([]domain.JiraIssue, error) {
+	records, err := jiraexport.ReadCSV(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	issues := make([]domain.JiraIssue, 0, len(records))
+	for _, record := range records {
+		issue := domain.JiraIssue{
+			Key: record.Key,
+			Fields: domain.JiraFields{
+				Summary:     record.Summary,
+				Assignee:    domain.JiraAssignee{DisplayName: record.Assignee},
+				StoryPoints: record.StoryPoints,
+				Status: domain.JiraStatus{
+					Name:     record.Status,
+					Category: domain.JiraStatusCategory{Key: domain.StatusCategory(record.StatusCategoryKey())},
+				},
+				IssueType:         domain.IssueType{Name: record.IssueType},
+				Labels:            record.Labels,
+				Created:           record.Created,
+				ResolutionDate:    record.Resolved,
+				OriginalEstimate:  record.OriginalEstimate,
+				RemainingEstimate: record.RemainingEstimate,
+			},
+			ChangelogUnavailable: true,
+		}
+		for _, sprint := range record.Sprints {
+			issue.Fields.Sprints = append(issue.Fields.Sprints, domain.JiraSprint{Name: sprint})
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// GetIssuesForSprint returns the exported issues of the project in a sprint, named or given
+// by its numeric ID
+func (a *ExportAdapter) GetIssuesForSprint(project, sprintID string) ([]ports.JiraIssue, error) {
+	id, _ := strconv.Atoi(strings.TrimSpace(sprintID))
+	var issues []domain.JiraIssue
+	for _, issue := range a.issues {
+		if !strings.HasPrefix(issue.Key, project+"-") {
+			continue
+		}
+		for _, sprint := range issue.Fields.Sprints {
+			if sprint.Name == sprintID || (id > 0 && sprint.ID == id) {
+				issues = append(issues, issue)
+				break
+			}
+		}
+	}
+	return convertToPortIssues(issues), nil
+}
+
+// GetIssuesForTeamMember returns the exported issues assigned to a team member
+func (a *ExportAdapter) GetIssuesForTeamMember(member string) ([]ports.JiraIssue, error) {
+	var issues []domain.JiraIssue
+	for _, issue := range a.issues {
+		if issue.Fields.Assignee.DisplayName == member {
+			issues = append(issues, issue)
+		}
+	}
+	return convertToPortIssues(issues), nil
+}
+
+// GetSprintIssues returns the exported issues of a sprint
+func (a *ExportAdapter) GetSprintIssues(sprint *domain.Sprint) ([]ports.JiraIssue, error) {
+	return a.GetIssuesForSprint(sprint.Project, sprint.ID)
+}
+
+// GetTeamIssues returns the exported issues assigned to the members of a team
+func (a *ExportAdapter) GetTeamIssues(team *domain.Team) ([]ports.JiraIssue, error) {
+	var issues []ports.JiraIssue
+	for _, member := range team.Team {
+		memberIssues, err := a.GetIssuesForTeamMember(member)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, memberIssues...)
+	}
+	return issues, nil
+}
+
+// Ensure ExportAdapter implements JiraPort
+var _ ports.JiraPort = (*ExportAdapter)(nil)
@@ -0,0 +1,96 @@
+package infrastructure
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+func writeExport(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestExportAdapter_JSON(t *testing.T) {
+	path := writeExport(t, "FN.json", `{
+		"issues": [
+			{
+				"key": "FN-1",
+				"fields": {
+					"summary": "Build checkout",
+					"assignee": {"displayName": "alice"},
+					"status": {"name": "Done", "statusCategory": {"key": "done"}},
+					"labels": ["cap-development"],
+					"customfield_10020": [{"id": 7, "name": "Penguins", "state": "closed"}]
+				},
+				"changelog": {"histories": [{"created": "2024-03-18T09:00:00.000+0000", "items": [{"field": "status", "fromString": "To Do", "toString": "In Progress"}]}]}
+			},
+			{
+				"key": "FN-2",
+				"fields": {"summary": "Fix login", "assignee": {"displayName": "bob"}, "sprint": [{"id": 8, "name": "Owls"}]}
+			},
+			{
+				"key": "OPS-1",
+				"fields": {"summary": "Other project", "customfield_10020": [{"id": 7, "name": "Penguins", "state": "closed"}]}
+			}
+		]
+	}`)
+	adapter, err := NewExportAdapter(path)
+	require.NoError(t, err)
+
+	issues, err := adapter.GetIssuesForSprint("FN", "Penguins")
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, "FN-1", issues[0].Key)
+	assert.Equal(t, domain.StatusCategoryDone, issues[0].StatusCategory)
+	assert.Len(t, issues[0].Changelog.Histories, 1)
+	assert.False(t, issues[0].ChangelogUnavailable)
+
+	issues, err = adapter.GetIssuesForSprint("FN", "8")
+	require.NoError(t, err)
+	require.Len(t, issues, 1, "sprints are also found by their ID")
+	assert.Equal(t, "FN-2", issues[0].Key)
+	assert.True(t, issues[0].ChangelogUnavailable, "issues exported without a changelog fall back to their dates")
+
+	issues, err = adapter.GetTeamIssues(&domain.Team{Team: []string{"alice", "bob"}})
+	require.NoError(t, err)
+	assert.Len(t, issues, 2)
+}
+
+func TestExportAdapter_CSV(t *testing.T) {
+	path := writeExport(t, "FN.csv", "Summary,Issue key,Issue Type,Status,Status Category,Assignee,Created,Resolved,Sprint,Labels,Labels\n"+
+		"Build checkout,FN-1,Story,Done,Done,alice,18/Mar/24 9:00 AM,19/Mar/24 5:30 PM,Penguins,cap-development,cap-asset-booking\n"+
+		"Fix login,FN-2,Bug,To Do,To Do,bob,20/Mar/24 9:00 AM,,Owls,,\n")
+	adapter, err := NewExportAdapter(path)
+	require.NoError(t, err)
+
+	issues, err := adapter.GetIssuesForSprint("FN", "Penguins")
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, "FN-1", issues[0].Key)
+	assert.Equal(t, "alice", issues[0].Assignee)
+	assert.Equal(t, "Story", issues[0].IssueType)
+	assert.Equal(t, []string{"cap-development", "cap-asset-booking"}, issues[0].Labels)
+	assert.Equal(t, "2024-03-18T09:00:00.000+0000", issues[0].Created)
+	assert.Equal(t, "2024-03-19T17:30:00.000+0000", issues[0].ResolutionDate)
+	assert.Equal(t, domain.StatusCategoryDone, issues[0].StatusCategory)
+	assert.True(t, issues[0].ChangelogUnavailable)
+}
+
+func TestNewExportAdapter_Errors(t *testing.T) {
+	_, err := NewExportAdapter(writeExport(t, "FN.xml", "<issues/>"))
+	assert.ErrorContains(t, err, "expected a .json or .csv file")
+
+	_, err = NewExportAdapter(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorContains(t, err, "failed to read export")
+
+	_, err = NewExportAdapter(writeExport(t, "FN.json", `{"issues": [{"key": 1}]}`))
+	assert.ErrorContains(t, err, "invalid issue in export")
+}
@@ -0,0 +1,77 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/jql"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain/ports"
+)
+
+// versionResponse represents a version returned by the Jira project versions API
+type versionResponse struct {
+	Name        string `json:"name"`
+	StartDate   string `json:"startDate"`
+	ReleaseDate string `json:"releaseDate"`
+	Released    bool   `json:"released"`
+}
+
+// GetIssuesForFixVersion retrieves all issues of a project released in a fix version
+func (a *JiraAdapter) GetIssuesForFixVersion(project, fixVersion string) ([]ports.JiraIssue, error) {
+	query := fmt.Sprintf("project = %s AND fixVersion = %s", project, jql.Quote(fixVersion))
+	issues, err := a.searchIssues(query, allocationFields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch fix version issues: %w", err)
+	}
+
+	return convertToPortIssues(issues), nil
+}
+
+// GetRelease retrieves a fix version of a project with its start and release dates. The
+// release date counts as a full day, so work completed on it falls inside the window.
+func (a *JiraAdapter) GetRelease(project, fixVersion string) (*domain.Release, error) {
+	body, err := a.httpClient.Get(fmt.Sprintf("%s/rest/api/3/project/%s/versions", a.config.GetBaseURL(), url.PathEscape(project)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch versions of project %s: %w", project, err)
+	}
+
+	var versions []versionResponse
+	if err := json.Unmarshal(body, &versions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal versions response: %w", err)
+	}
+
+	names := make([]string, 0, len(versions))
+	for _, version := range versions {
+		if version.Name != fixVersion {
+			names = append(names, version.Name)
+			continue
+		}
+
+		release := &domain.Release{Name: version.Name, Released: version.Released}
+		if version.StartDate != "" {
+			if release.StartDate, err = time.Parse("2006-01-02", version.StartDate); err != nil {
+				return nil, fmt.Errorf("failed to parse start date of fix version %s: %w", fixVersion, err)
+			}
+		}
+		if version.ReleaseDate != "" {
+			releaseDay, err := time.Parse("2006-01-02", version.ReleaseDate)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse release date of fix version %s: %w", fixVersion, err)
+			}
+			release.ReleaseDate = releaseDay.AddDate(0, 0, 1)
+		}
+		return release, nil
+	}
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("fix version %s not found in project %s", fixVersion, project)
+	}
+	return nil, fmt.Errorf("fix version %s not found in project %s; available versions: %s", fixVersion, project, strings.Join(names, ", "))
+}
+
+// Ensure JiraAdapter implements JiraReleasePort
+var _ ports.JiraReleasePort = (*JiraAdapter)(nil)
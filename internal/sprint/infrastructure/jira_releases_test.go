@@ -0,0 +1,104 @@
+package infrastructure
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJiraAdapter_GetIssuesForFixVersion(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/3/search", r.URL.Path)
+		assert.Equal(t, `project = TEST AND fixVersion = "2024.5"`, r.URL.Query().Get("jql"))
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{
+			"issues": [
+				{
+					"key": "TEST-1",
+					"fields": {
+						"summary": "Test Issue 1",
+						"assignee": {"accountId": "acc-1", "displayName": "Test User 1"},
+						"status": {"name": "Done"},
+						"issuetype": {"name": "Story"},
+						"labels": ["cap-development"]
+					}
+				}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	os.Setenv("JIRA_BASE_URL", server.URL)
+	adapter, err := NewJiraAdapter()
+	require.NoError(t, err)
+
+	issues, err := adapter.GetIssuesForFixVersion("TEST", "2024.5")
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, "TEST-1", issues[0].Key)
+	assert.Equal(t, "Test User 1", issues[0].Assignee)
+	assert.Equal(t, "acc-1", issues[0].AssigneeAccountID)
+}
+
+func TestJiraAdapter_GetIssuesForFixVersionQuoted(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, `project = TEST AND fixVersion = "2024.5 \"Phoenix\" \\ hotfix"`, r.URL.Query().Get("jql"))
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"issues": []}`))
+	}))
+	defer server.Close()
+
+	os.Setenv("JIRA_BASE_URL", server.URL)
+	adapter, err := NewJiraAdapter()
+	require.NoError(t, err)
+
+	_, err = adapter.GetIssuesForFixVersion("TEST", `2024.5 "Phoenix" \ hotfix`)
+	require.NoError(t, err)
+}
+
+func TestJiraAdapter_GetRelease(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/3/project/TEST/versions", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[
+			{"id": "10000", "name": "2024.4", "startDate": "2024-04-01", "releaseDate": "2024-04-30", "released": true},
+			{"id": "10001", "name": "2024.5", "startDate": "2024-05-01", "releaseDate": "2024-05-31", "released": true},
+			{"id": "10002", "name": "2024.6", "released": false}
+		]`))
+	}))
+	defer server.Close()
+
+	os.Setenv("JIRA_BASE_URL", server.URL)
+	adapter, err := NewJiraAdapter()
+	require.NoError(t, err)
+
+	release, err := adapter.GetRelease("TEST", "2024.5")
+	require.NoError(t, err)
+	assert.Equal(t, "2024.5", release.Name)
+	assert.True(t, release.Released)
+	assert.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), release.StartDate)
+	assert.Equal(t, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), release.ReleaseDate, "the release day is included in the window")
+
+	release, err = adapter.GetRelease("TEST", "2024.6")
+	require.NoError(t, err)
+	assert.True(t, release.StartDate.IsZero())
+	assert.True(t, release.ReleaseDate.IsZero())
+
+	_, err = adapter.GetRelease("TEST", "2025.1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "fix version 2025.1 not found in project TEST; available versions: 2024.4, 2024.5, 2024.6")
+}
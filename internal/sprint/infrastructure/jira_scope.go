@@ -0,0 +1,24 @@
+package infrastructure
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain/ports"
+)
+
+// GetIssuesUpdatedSince retrieves the issues of the project updated since the given time with
+// their changelogs. Moving an issue out of a sprint updates it, so the issues pulled from a
+// sprint are among those updated since it started.
+func (a *JiraAdapter) GetIssuesUpdatedSince(project string, since time.Time) ([]ports.JiraIssue, error) {
+	query := fmt.Sprintf("project = %s AND updated >= '%s'", project, since.Format("2006-01-02"))
+	issues, err := a.searchIssues(query, allocationFields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch issues updated since %s: %w", since.Format("2006-01-02"), err)
+	}
+
+	return convertToPortIssues(issues), nil
+}
+
+// Ensure JiraAdapter implements JiraScopePort
+var _ ports.JiraScopePort = (*JiraAdapter)(nil)
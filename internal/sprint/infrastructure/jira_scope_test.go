@@ -0,0 +1,52 @@
+package infrastructure
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJiraAdapter_GetIssuesUpdatedSince(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/3/search", r.URL.Path)
+		assert.Equal(t, "project = TEST AND updated >= '2024-03-18'", r.URL.Query().Get("jql"))
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{
+			"issues": [
+				{
+					"key": "TEST-9",
+					"fields": {"summary": "Pulled from the sprint", "status": {"name": "To Do"}},
+					"changelog": {
+						"histories": [
+							{
+								"created": "2024-03-20T10:00:00.000+0000",
+								"items": [{"field": "Sprint", "from": "42", "fromString": "Sprint 1", "to": "", "toString": ""}]
+							}
+						]
+					}
+				}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	os.Setenv("JIRA_BASE_URL", server.URL)
+	adapter, err := NewJiraAdapter()
+	require.NoError(t, err)
+
+	issues, err := adapter.GetIssuesUpdatedSince("TEST", time.Date(2024, 3, 18, 9, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, "TEST-9", issues[0].Key)
+	require.Len(t, issues[0].Changelog.Histories, 1)
+	assert.Equal(t, "Sprint", issues[0].Changelog.Histories[0].Items[0].Field)
+	assert.Equal(t, "42", issues[0].Changelog.Histories[0].Items[0].From)
+}
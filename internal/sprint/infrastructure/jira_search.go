@@ -0,0 +1,155 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+// changelogFallback is how allocations are estimated when Jira withholds the changelog
+const changelogFallback = "falling back to resolution date heuristic"
+
+// allocationFields are the issue fields allocations are computed from
+var allocationFields = []string{
+	"summary", "assignee", "status", "changelog", "issuetype", "customfield_10014", "customfield_10015",
+	"customfield_13192", "sprint", "labels", "created", "resolutiondate", "timeoriginalestimate", "timeestimate",
+}
+
+// deniedFieldPattern matches the Jira error naming a field the user may not view
+var deniedFieldPattern = regexp.MustCompile(`(?i)field '([^']+)' does not exist or you do not have permission to view it`)
+
+// jiraErrorBody is the error payload of the Jira REST API
+type jiraErrorBody struct {
+	ErrorMessages []string          `json:"errorMessages"`
+	Errors        map[string]string `json:"errors"`
+}
+
+// searchIssues runs a JQL search for the given fields with the issue changelogs. Fields and
+// the changelog Jira denies permission to are dropped and the search retried, warning about
+// each; issues then lack their changelog and are marked ChangelogUnavailable.
+func (a *JiraAdapter) searchIssues(jql string, fields []string) ([]domain.JiraIssue, error) {
+	fields = a.withSprintField(fields)
+	changelog := true
+	for {
+		issues, err := a.httpClient.GetJiraIssues(searchURL(a.config.GetBaseURL(), jql, fields, changelog))
+		if err == nil {
+			if !changelog {
+				for i := range issues {
+					issues[i].ChangelogUnavailable = true
+				}
+			}
+			a.warnMissingChangelogs(issues, changelog)
+			a.annotateStatusCategories(issues)
+			return issues, nil
+		}
+
+		var jiraErr *JiraError
+		if !errors.As(err, &jiraErr) {
+			return nil, err
+		}
+		if denied := jiraErr.deniedFields(fields); len(denied) > 0 {
+			for _, field := range denied {
+				fmt.Fprintf(a.httpClient.warnings, "Warning: field %s unavailable (no permission to view it); continuing without it\n", field)
+			}
+			fields = withoutFields(fields, denied)
+			continue
+		}
+		if changelog && jiraErr.permissionDenied() {
+			fmt.Fprintf(a.httpClient.warnings, "Warning: changelog unavailable (%s); %s\n", jiraErr.Status, changelogFallback)
+			changelog = false
+			continue
+		}
+		return nil, err
+	}
+}
+
+// warnMissingChangelogs warns about the issues Jira returned without the requested changelog
+func (a *JiraAdapter) warnMissingChangelogs(issues []domain.JiraIssue, requested bool) {
+	if !requested {
+		return
+	}
+	var keys []string
+	for _, issue := range issues {
+		if issue.ChangelogUnavailable {
+			keys = append(keys, issue.Key)
+		}
+	}
+	if len(keys) > 0 {
+		fmt.Fprintf(a.httpClient.warnings, "Warning: changelog unavailable for %s; %s\n", strings.Join(keys, ", "), changelogFallback)
+	}
+}
+
+// searchURL builds the URL of a Jira search, expanding changelogs when asked to
+func searchURL(baseURL, jql string, fields []string, changelog bool) string {
+	jiraURL := fmt.Sprintf("%s/rest/api/3/search?jql=%s", baseURL, url.QueryEscape(jql))
+	if changelog {
+		jiraURL += "&expand=changelog"
+	}
+	return jiraURL + "&fields=" + strings.Join(fields, ",")
+}
+
+// permissionDenied reports whether Jira refused the request for lack of permission
+func (e *JiraError) permissionDenied() bool {
+	if e.StatusCode == http.StatusForbidden {
+		return true
+	}
+	return e.StatusCode == http.StatusBadRequest && strings.Contains(strings.ToLower(e.Body), "permission")
+}
+
+// deniedFields returns the requested fields Jira reported the user may not view, sorted
+func (e *JiraError) deniedFields(requested []string) []string {
+	if e.StatusCode != http.StatusBadRequest {
+		return nil
+	}
+	var body jiraErrorBody
+	if err := json.Unmarshal([]byte(e.Body), &body); err != nil {
+		return nil
+	}
+
+	isRequested := make(map[string]bool, len(requested))
+	for _, field := range requested {
+		isRequested[field] = true
+	}
+	denied := make(map[string]bool)
+	for _, message := range body.ErrorMessages {
+		for _, match := range deniedFieldPattern.FindAllStringSubmatch(message, -1) {
+			if isRequested[match[1]] {
+				denied[match[1]] = true
+			}
+		}
+	}
+	for field, message := range body.Errors {
+		if isRequested[field] && strings.Contains(strings.ToLower(message), "permission") {
+			denied[field] = true
+		}
+	}
+
+	fields := make([]string, 0, len(denied))
+	for field := range denied {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// withoutFields returns the fields not listed in drop
+func withoutFields(fields, drop []string) []string {
+	dropped := make(map[string]bool, len(drop))
+	for _, field := range drop {
+		dropped[field] = true
+	}
+	kept := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if !dropped[field] {
+			kept = append(kept, field)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,112 @@
+package infrastructure
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// searchAdapter creates an adapter against the handler, collecting its warnings
+func searchAdapter(t *testing.T, handler http.HandlerFunc) (*JiraAdapter, *bytes.Buffer) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	os.Setenv("JIRA_BASE_URL", server.URL)
+	adapter, err := NewJiraAdapter()
+	require.NoError(t, err)
+	var warnings bytes.Buffer
+	adapter.httpClient.warnings = &warnings
+	return adapter, &warnings
+}
+
+func TestJiraAdapter_SearchWithoutChangelogPermission(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	var requests []string
+	adapter, warnings := searchAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Query().Get("expand"))
+		if r.URL.Query().Get("expand") == "changelog" {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"errorMessages": ["You do not have the permission to see the specified issue history."]}`)
+			return
+		}
+		fmt.Fprint(w, `{"issues": [{"key": "TEST-1", "fields": {"created": "2024-05-06T09:00:00.000+0000", "resolutiondate": "2024-05-08T17:00:00.000+0000"}}]}`)
+	})
+
+	issues, err := adapter.GetIssuesForSprint("TEST", "Test Sprint")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"changelog", ""}, requests)
+	require.Len(t, issues, 1)
+	assert.True(t, issues[0].ChangelogUnavailable)
+	assert.Equal(t, "2024-05-06T09:00:00.000+0000", issues[0].Created)
+	assert.Equal(t, "2024-05-08T17:00:00.000+0000", issues[0].ResolutionDate)
+	assert.Equal(t, "Warning: changelog unavailable (403 Forbidden); falling back to resolution date heuristic\n", warnings.String())
+}
+
+func TestJiraAdapter_SearchWithoutFieldPermission(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	var fields []string
+	adapter, warnings := searchAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		fields = append(fields, r.URL.Query().Get("fields"))
+		if len(fields) == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"errorMessages": ["Field 'customfield_13192' does not exist or you do not have permission to view it."], "errors": {"customfield_10015": "You do not have permission to view this field."}}`)
+			return
+		}
+		fmt.Fprint(w, `{"issues": [{"key": "TEST-1", "fields": {}, "changelog": {"histories": []}}]}`)
+	})
+
+	issues, err := adapter.GetIssuesForSprint("TEST", "Test Sprint")
+
+	require.NoError(t, err)
+	require.Len(t, fields, 2)
+	assert.Equal(t, "summary,assignee,status,changelog,issuetype,customfield_10014,customfield_10020,labels,created,resolutiondate,timeoriginalestimate,timeestimate", fields[1])
+	require.Len(t, issues, 1)
+	assert.False(t, issues[0].ChangelogUnavailable)
+	assert.Equal(t, "Warning: field customfield_10015 unavailable (no permission to view it); continuing without it\n"+
+		"Warning: field customfield_13192 unavailable (no permission to view it); continuing without it\n", warnings.String())
+}
+
+func TestJiraAdapter_SearchWithRestrictedIssueChangelogs(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	adapter, warnings := searchAdapter(t, func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"issues": [{"key": "TEST-1", "fields": {}, "changelog": {"histories": []}}, {"key": "TEST-2", "fields": {}}]}`)
+	})
+
+	issues, err := adapter.GetIssuesForSprint("TEST", "Test Sprint")
+
+	require.NoError(t, err)
+	require.Len(t, issues, 2)
+	assert.False(t, issues[0].ChangelogUnavailable)
+	assert.True(t, issues[1].ChangelogUnavailable)
+	assert.Equal(t, "Warning: changelog unavailable for TEST-2; falling back to resolution date heuristic\n", warnings.String())
+}
+
+func TestJiraAdapter_SearchOtherErrors(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	requests := 0
+	adapter, _ := searchAdapter(t, func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"errorMessages": ["Error in the JQL Query"]}`)
+	})
+
+	_, err := adapter.GetIssuesForSprint("TEST", "Test Sprint")
+
+	assert.ErrorContains(t, err, "400 Bad Request")
+	assert.Equal(t, 1, requests)
+}
@@ -0,0 +1,76 @@
+package infrastructure
+
+import (
+	"encoding/json"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/jiraexport"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/jirafield"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+// sprintFieldName stands for the sprint field in the field lists of searches, replaced by
+// the custom field of the instance when the search is sent
+const sprintFieldName = "sprint"
+
+// UseSprintField requests and reads the sprints of issues from the sprint field detected for
+// the instance. Until it is set, searches request jirafield.DefaultSprintField and find the
+// sprints in whichever custom field holds them.
+func (a *JiraAdapter) UseSprintField(field string) {
+	a.sprintField = field
+	a.httpClient.sprintField = field
+}
+
+// UsePageSize sets the number of issues requested per page of a search; zero keeps the
+// default of 100
+func (a *JiraAdapter) UsePageSize(size int) {
+	a.httpClient.pageSize = size
+}
+
+// sprintFieldID returns the custom field searches request the sprints from
+func (a *JiraAdapter) sprintFieldID() string {
+	if a.sprintField == "" {
+		return jirafield.DefaultSprintField
+	}
+	return a.sprintField
+}
+
+// withSprintField replaces the sprint field of a field list with the field of the instance
+func (a *JiraAdapter) withSprintField(fields []string) []string {
+	replaced := make([]string, len(fields))
+	for i, field := range fields {
+		if field == sprintFieldName {
+			field = a.sprintFieldID()
+		}
+		replaced[i] = field
+	}
+	return replaced
+}
+
+// issueSprints reads the sprints of an issue from its fields: from the given sprint field, or
+// from whichever custom field holds sprints when the field is not known
+func issueSprints(fields json.RawMessage, field string) []domain.JiraSprint {
+	var found []jiraexport.Sprint
+	if field == "" {
+		found = jiraexport.Sprints(fields)
+	} else {
+		var raw map[string]json.RawMessage
+		if err := json.Unmarshal(fields, &raw); err != nil {
+			return nil
+		}
+		if value, ok := raw[field]; ok {
+			_ = json.Unmarshal(value, &found)
+		}
+	}
+
+	sprints := make([]domain.JiraSprint, 0, len(found))
+	for _, sprint := range found {
+		sprints = append(sprints, domain.JiraSprint{
+			ID:        sprint.ID,
+			Name:      sprint.Name,
+			State:     sprint.State,
+			StartDate: sprint.StartDate,
+			EndDate:   sprint.EndDate,
+		})
+	}
+	return sprints
+}
@@ -0,0 +1,52 @@
+package infrastructure
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+func TestJiraAdapter_UseSprintField(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "customfield_10100", r.URL.Query().Get("fields"), "the detected field is requested")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{
+			"issues": [
+				{"key": "TEST-1", "fields": {
+					"customfield_10007": [{"name": "Board column", "state": "active"}],
+					"customfield_10100": [{"id": 7, "name": "Sprint 7", "state": "closed", "startDate": "2024-04-01T09:00:00.000Z", "endDate": "2024-04-15T09:00:00.000Z"}]
+				}}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	os.Setenv("JIRA_BASE_URL", server.URL)
+	adapter, err := NewJiraAdapter()
+	require.NoError(t, err)
+	adapter.UseSprintField("customfield_10100")
+
+	sprints, err := adapter.GetSprintsBetween("TEST", time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Sprint{
+		{Name: "Sprint 7", StartDate: "2024-04-01T09:00:00.000Z", EndDate: "2024-04-15T09:00:00.000Z"},
+	}, sprints)
+}
+
+func TestIssueSprints(t *testing.T) {
+	fields := []byte(`{"customfield_10020": [{"id": 3, "name": "Sprint 3", "state": "closed"}]}`)
+
+	assert.Equal(t, []domain.JiraSprint{{ID: 3, Name: "Sprint 3", State: "closed"}}, issueSprints(fields, ""), "an unknown field is searched for")
+	assert.Equal(t, []domain.JiraSprint{{ID: 3, Name: "Sprint 3", State: "closed"}}, issueSprints(fields, "customfield_10020"))
+	assert.Empty(t, issueSprints(fields, "customfield_10100"))
+}
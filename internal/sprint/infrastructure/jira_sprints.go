@@ -0,0 +1,31 @@
+package infrastructure
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain/ports"
+)
+
+// GetSprintsBetween returns the sprints of the project that started within [from, to). The
+// sprints are read from the issues updated since from, as every issue of such a sprint is.
+func (a *JiraAdapter) GetSprintsBetween(project string, from, to time.Time) ([]domain.Sprint, error) {
+	query := fmt.Sprintf("project = %s AND sprint is not EMPTY AND updated >= '%s'", project, from.Format("2006-01-02"))
+	jiraURL := fmt.Sprintf("%s/rest/api/3/search?jql=%s&fields=%s", a.config.GetBaseURL(), url.QueryEscape(query), a.sprintFieldID())
+
+	issues, err := a.httpClient.GetJiraIssues(jiraURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sprints of project %s: %w", project, err)
+	}
+
+	var sprints []domain.JiraSprint
+	for _, issue := range issues {
+		sprints = append(sprints, issue.Fields.Sprints...)
+	}
+	return domain.SprintsStartedBetween(sprints, from, to), nil
+}
+
+// Ensure JiraAdapter implements JiraSprintListPort
+var _ ports.JiraSprintListPort = (*JiraAdapter)(nil)
@@ -0,0 +1,47 @@
+package infrastructure
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+func TestJiraAdapter_GetSprintsBetween(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/3/search", r.URL.Path)
+		assert.Equal(t, "project = TEST AND sprint is not EMPTY AND updated >= '2024-04-01'", r.URL.Query().Get("jql"))
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{
+			"issues": [
+				{"key": "TEST-1", "fields": {"sprint": [
+					{"name": "Sprint 6", "startDate": "2024-03-18T09:00:00.000Z", "endDate": "2024-04-01T09:00:00.000Z"},
+					{"name": "Sprint 7", "startDate": "2024-04-01T09:00:00.000Z", "endDate": "2024-04-15T09:00:00.000Z"}
+				]}},
+				{"key": "TEST-2", "fields": {"sprint": [
+					{"name": "Sprint 7", "startDate": "2024-04-01T09:00:00.000Z", "endDate": "2024-04-15T09:00:00.000Z"}
+				]}}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	os.Setenv("JIRA_BASE_URL", server.URL)
+	adapter, err := NewJiraAdapter()
+	require.NoError(t, err)
+
+	sprints, err := adapter.GetSprintsBetween("TEST", time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Sprint{
+		{Name: "Sprint 7", StartDate: "2024-04-01T09:00:00.000Z", EndDate: "2024-04-15T09:00:00.000Z"},
+	}, sprints)
+}
@@ -0,0 +1,53 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+// statusResponse represents a status returned by the Jira status metadata API
+type statusResponse struct {
+	ID             string `json:"id"`
+	StatusCategory struct {
+		Key domain.StatusCategory `json:"key"`
+	} `json:"statusCategory"`
+}
+
+// annotateStatusCategories sets the categories of the statuses the changelogs of the issues
+// move between. Categories are learned from the issues' current statuses, and the status
+// metadata is fetched once for the others; without it, the English default status names
+// are relied on.
+func (a *JiraAdapter) annotateStatusCategories(issues []domain.JiraIssue) {
+	if a.statuses == nil {
+		a.statuses = make(domain.StatusCategories)
+	}
+	a.statuses.Learn(issues)
+	if missing := a.statuses.Missing(issues); len(missing) > 0 && !a.statusesFetched {
+		a.statusesFetched = true
+		if err := a.fetchStatusCategories(); err != nil {
+			fmt.Fprintf(a.httpClient.warnings, "Warning: status categories unavailable (%v); falling back to English status names\n", err)
+		}
+	}
+	a.statuses.Annotate(issues)
+}
+
+// fetchStatusCategories reads the category of every status from the Jira status metadata
+func (a *JiraAdapter) fetchStatusCategories() error {
+	body, err := a.httpClient.Get(fmt.Sprintf("%s/rest/api/3/status", a.config.GetBaseURL()))
+	if err != nil {
+		return fmt.Errorf("failed to fetch statuses: %w", err)
+	}
+
+	var statuses []statusResponse
+	if err := json.Unmarshal(body, &statuses); err != nil {
+		return fmt.Errorf("failed to unmarshal statuses: %w", err)
+	}
+	for _, status := range statuses {
+		if status.ID != "" && status.StatusCategory.Key != "" {
+			a.statuses[status.ID] = status.StatusCategory.Key
+		}
+	}
+	return nil
+}
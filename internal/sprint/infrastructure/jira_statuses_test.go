@@ -0,0 +1,94 @@
+package infrastructure
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain/ports"
+)
+
+// localizedSearch is a search result of a German Jira instance whose changelog moves through
+// a status no issue is currently in
+const localizedSearch = `{"issues": [{
+	"key": "TEST-1",
+	"fields": {
+		"status": {"id": "10002", "name": "Fertig", "statusCategory": {"key": "done"}},
+		"issuetype": {"id": "10005", "name": "Unteraufgabe", "subtask": true, "hierarchyLevel": -1}
+	},
+	"changelog": {"histories": [
+		{"created": "2024-03-18T09:00:00.000+0000", "items": [{"field": "status", "from": "10000", "fromString": "Zu erledigen", "to": "10001", "toString": "In Arbeit"}]},
+		{"created": "2024-03-19T09:00:00.000+0000", "items": [{"field": "status", "from": "10001", "fromString": "In Arbeit", "to": "10002", "toString": "Fertig"}]}
+	]}
+}]}`
+
+func TestJiraAdapter_StatusCategories(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	statusRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/search":
+			w.Write([]byte(localizedSearch))
+		case "/rest/api/3/status":
+			statusRequests++
+			w.Write([]byte(`[
+				{"id": "10000", "name": "Zu erledigen", "statusCategory": {"key": "new"}},
+				{"id": "10001", "name": "In Arbeit", "statusCategory": {"key": "indeterminate"}}
+			]`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	os.Setenv("JIRA_BASE_URL", server.URL)
+	adapter, err := NewJiraAdapter()
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		issues, err := adapter.GetIssuesForSprint("TEST", "Sprint 1")
+		require.NoError(t, err)
+		require.Len(t, issues, 1)
+		assert.Equal(t, domain.StatusCategoryDone, issues[0].StatusCategory)
+		assert.True(t, issues[0].IssueTypeSubtask)
+		assert.Equal(t, []ports.JiraChangeItem{
+			{Field: "status", From: "10000", FromString: "Zu erledigen", To: "10001", ToString: "In Arbeit", FromCategory: domain.StatusCategoryToDo, ToCategory: domain.StatusCategoryInProgress},
+		}, issues[0].Changelog.Histories[0].Items)
+		assert.Equal(t, domain.StatusCategoryDone, issues[0].Changelog.Histories[1].Items[0].ToCategory, "learned from the current status of the issue")
+	}
+	assert.Equal(t, 1, statusRequests, "the status metadata is fetched once")
+}
+
+func TestJiraAdapter_StatusCategoriesUnavailable(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rest/api/3/status" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(localizedSearch))
+	}))
+	defer server.Close()
+
+	os.Setenv("JIRA_BASE_URL", server.URL)
+	adapter, err := NewJiraAdapter()
+	require.NoError(t, err)
+	var warnings bytes.Buffer
+	adapter.httpClient.warnings = &warnings
+
+	issues, err := adapter.GetIssuesForSprint("TEST", "Sprint 1")
+	require.NoError(t, err)
+	assert.Empty(t, issues[0].Changelog.Histories[0].Items[0].ToCategory)
+	assert.Contains(t, warnings.String(), "Warning: status categories unavailable")
+	assert.Contains(t, warnings.String(), "falling back to English status names")
+}
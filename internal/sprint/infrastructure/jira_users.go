@@ -0,0 +1,47 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain/ports"
+)
+
+// userResponse represents a user returned by the Jira user search API
+type userResponse struct {
+	AccountID    string `json:"accountId"`
+	AccountType  string `json:"accountType"`
+	DisplayName  string `json:"displayName"`
+	EmailAddress string `json:"emailAddress"`
+	Active       bool   `json:"active"`
+}
+
+// SearchUsers finds the users whose display name or email match the query
+func (a *JiraAdapter) SearchUsers(query string) ([]domain.JiraUser, error) {
+	body, err := a.httpClient.Get(fmt.Sprintf("%s/rest/api/3/user/search?query=%s", a.config.GetBaseURL(), url.QueryEscape(query)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search users matching %s: %w", query, err)
+	}
+
+	var response []userResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal user search response: %w", err)
+	}
+
+	users := make([]domain.JiraUser, 0, len(response))
+	for _, user := range response {
+		users = append(users, domain.JiraUser{
+			AccountID:    user.AccountID,
+			AccountType:  user.AccountType,
+			DisplayName:  user.DisplayName,
+			EmailAddress: user.EmailAddress,
+			Active:       user.Active,
+		})
+	}
+	return users, nil
+}
+
+// Ensure JiraAdapter implements JiraUserPort
+var _ ports.JiraUserPort = (*JiraAdapter)(nil)
@@ -0,0 +1,38 @@
+package infrastructure
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+func TestJiraAdapter_SearchUsers(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/3/user/search", r.URL.Path)
+		assert.Equal(t, "jane doe", r.URL.Query().Get("query"))
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[
+			{"accountId": "acc-jane", "accountType": "atlassian", "displayName": "Jane Doe", "emailAddress": "jane@example.com", "active": true}
+		]`))
+	}))
+	defer server.Close()
+
+	os.Setenv("JIRA_BASE_URL", server.URL)
+	adapter, err := NewJiraAdapter()
+	require.NoError(t, err)
+
+	users, err := adapter.SearchUsers("jane doe")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.JiraUser{
+		{AccountID: "acc-jane", AccountType: "atlassian", DisplayName: "Jane Doe", EmailAddress: "jane@example.com", Active: true},
+	}, users)
+}
@@ -0,0 +1,103 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain/ports"
+)
+
+// worklogTimeLayout is the layout of the started time of Jira worklogs
+const worklogTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// worklogResponse represents the response from the Jira issue worklogs API
+type worklogResponse struct {
+	Worklogs []struct {
+		ID               string  `json:"id"`
+		Comment          adfNode `json:"comment"`
+		Started          string  `json:"started"`
+		TimeSpentSeconds int     `json:"timeSpentSeconds"`
+	} `json:"worklogs"`
+}
+
+// GetWorklogs retrieves the worklogs of an issue
+func (a *JiraAdapter) GetWorklogs(issueKey string) ([]ports.JiraWorklog, error) {
+	body, err := a.httpClient.Get(a.worklogsURL(issueKey) + "?maxResults=5000")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch worklogs for %s: %w", issueKey, err)
+	}
+
+	var response worklogResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal worklogs response: %w", err)
+	}
+
+	worklogs := make([]ports.JiraWorklog, 0, len(response.Worklogs))
+	for _, worklog := range response.Worklogs {
+		started, _ := time.Parse(worklogTimeLayout, worklog.Started)
+		worklogs = append(worklogs, ports.JiraWorklog{
+			ID:               worklog.ID,
+			Comment:          adfToText(worklog.Comment),
+			Started:          started,
+			TimeSpentSeconds: worklog.TimeSpentSeconds,
+		})
+	}
+	return worklogs, nil
+}
+
+// AddWorklog logs time on an issue, leaving its remaining estimate as it is
+func (a *JiraAdapter) AddWorklog(issueKey string, worklog ports.JiraWorklog) error {
+	payload, err := worklogPayload(worklog)
+	if err != nil {
+		return err
+	}
+
+	if _, err := a.httpClient.Post(a.worklogsURL(issueKey)+"?adjustEstimate=leave", payload); err != nil {
+		return fmt.Errorf("failed to add worklog to %s: %w", issueKey, err)
+	}
+	return nil
+}
+
+// UpdateWorklog replaces the time, start and comment of an existing worklog, leaving the
+// remaining estimate of the issue as it is
+func (a *JiraAdapter) UpdateWorklog(issueKey string, worklog ports.JiraWorklog) error {
+	payload, err := worklogPayload(worklog)
+	if err != nil {
+		return err
+	}
+
+	if _, err := a.httpClient.Put(a.worklogsURL(issueKey)+"/"+worklog.ID+"?adjustEstimate=leave", payload); err != nil {
+		return fmt.Errorf("failed to update worklog %s on %s: %w", worklog.ID, issueKey, err)
+	}
+	return nil
+}
+
+// DeleteWorklog removes a worklog from an issue, leaving the remaining estimate of the issue
+// as it is
+func (a *JiraAdapter) DeleteWorklog(issueKey, worklogID string) error {
+	if _, err := a.httpClient.Delete(a.worklogsURL(issueKey) + "/" + worklogID + "?adjustEstimate=leave"); err != nil {
+		return fmt.Errorf("failed to delete worklog %s on %s: %w", worklogID, issueKey, err)
+	}
+	return nil
+}
+
+func (a *JiraAdapter) worklogsURL(issueKey string) string {
+	return fmt.Sprintf("%s/rest/api/3/issue/%s/worklog", a.config.GetBaseURL(), issueKey)
+}
+
+// worklogPayload encodes a worklog for the Jira worklogs API
+func worklogPayload(worklog ports.JiraWorklog) ([]byte, error) {
+	payload, err := json.Marshal(map[string]interface{}{
+		"comment":          textToADF(worklog.Comment),
+		"started":          worklog.Started.Format(worklogTimeLayout),
+		"timeSpentSeconds": worklog.TimeSpentSeconds,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal worklog: %w", err)
+	}
+	return payload, nil
+}
+
+// Ensure JiraAdapter implements JiraWorklogPort
+var _ ports.JiraWorklogPort = (*JiraAdapter)(nil)
@@ -0,0 +1,137 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain/ports"
+)
+
+func TestJiraAdapter_GetWorklogs(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/rest/api/3/issue/TEST-1/worklog", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{
+			"worklogs": [
+				{
+					"id": "20001",
+					"started": "2024-03-18T09:00:00.000+0000",
+					"timeSpentSeconds": 28800,
+					"comment": {"type": "doc", "version": 1, "content": [{"type": "paragraph", "content": [{"type": "text", "text": "[assetcap:worklog] Sprint 1"}]}]}
+				}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	os.Setenv("JIRA_BASE_URL", server.URL)
+	adapter, err := NewJiraAdapter()
+	require.NoError(t, err)
+
+	worklogs, err := adapter.GetWorklogs("TEST-1")
+	require.NoError(t, err)
+	require.Len(t, worklogs, 1)
+	assert.Equal(t, "20001", worklogs[0].ID)
+	assert.Equal(t, "[assetcap:worklog] Sprint 1", worklogs[0].Comment)
+	assert.True(t, worklogs[0].Started.Equal(time.Date(2024, 3, 18, 9, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 28800, worklogs[0].TimeSpentSeconds)
+}
+
+func TestJiraAdapter_AddWorklog(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/rest/api/3/issue/TEST-1/worklog", r.URL.Path)
+		assert.Equal(t, "leave", r.URL.Query().Get("adjustEstimate"), "pushes leave the remaining estimate alone")
+
+		data, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var payload struct {
+			Comment          adfNode `json:"comment"`
+			Started          string  `json:"started"`
+			TimeSpentSeconds int     `json:"timeSpentSeconds"`
+		}
+		require.NoError(t, json.Unmarshal(data, &payload))
+		assert.Equal(t, "[assetcap:worklog] Sprint 1", adfToText(payload.Comment))
+		assert.Equal(t, "2024-03-18T09:00:00.000+0000", payload.Started)
+		assert.Equal(t, 5400, payload.TimeSpentSeconds)
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id": "20002"}`))
+	}))
+	defer server.Close()
+
+	os.Setenv("JIRA_BASE_URL", server.URL)
+	adapter, err := NewJiraAdapter()
+	require.NoError(t, err)
+
+	require.NoError(t, adapter.AddWorklog("TEST-1", ports.JiraWorklog{
+		Comment:          "[assetcap:worklog] Sprint 1",
+		Started:          time.Date(2024, 3, 18, 9, 0, 0, 0, time.UTC),
+		TimeSpentSeconds: 5400,
+	}))
+}
+
+func TestJiraAdapter_UpdateWorklog(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/rest/api/3/issue/TEST-1/worklog/20001", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": "20001"}`))
+	}))
+	defer server.Close()
+
+	os.Setenv("JIRA_BASE_URL", server.URL)
+	adapter, err := NewJiraAdapter()
+	require.NoError(t, err)
+
+	require.NoError(t, adapter.UpdateWorklog("TEST-1", ports.JiraWorklog{ID: "20001", TimeSpentSeconds: 3600}))
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	err = adapter.UpdateWorklog("TEST-1", ports.JiraWorklog{ID: "20001", TimeSpentSeconds: 3600})
+	assert.ErrorContains(t, err, "failed to update worklog 20001 on TEST-1")
+}
+
+func TestJiraAdapter_DeleteWorklog(t *testing.T) {
+	cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/rest/api/3/issue/TEST-1/worklog/20001", r.URL.Path)
+		assert.Equal(t, "leave", r.URL.Query().Get("adjustEstimate"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	os.Setenv("JIRA_BASE_URL", server.URL)
+	adapter, err := NewJiraAdapter()
+	require.NoError(t, err)
+
+	require.NoError(t, adapter.DeleteWorklog("TEST-1", "20001"))
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	err = adapter.DeleteWorklog("TEST-1", "20001")
+	assert.ErrorContains(t, err, "failed to delete worklog 20001 on TEST-1")
+}
@@ -0,0 +1,82 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain/ports"
+)
+
+// DefaultPushCheckpointDir is the directory holding the checkpoints of pushes stopped midway
+const DefaultPushCheckpointDir = ".assetcap/push"
+
+// JSONPushCheckpointStore implements PushCheckpointStore with a JSON file per project, sprint
+// and mode
+type JSONPushCheckpointStore struct {
+	dir string
+}
+
+// NewJSONPushCheckpointStore creates a new JSON push checkpoint store writing to dir
+func NewJSONPushCheckpointStore(dir string) *JSONPushCheckpointStore {
+	return &JSONPushCheckpointStore{dir: dir}
+}
+
+// Load returns the checkpoint of the push, or nil when there is none
+func (s *JSONPushCheckpointStore) Load(project, sprint string, mode domain.PushMode) (*domain.PushCheckpoint, error) {
+	data, err := os.ReadFile(s.path(project, sprint, mode))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read push checkpoint: %w", err)
+	}
+
+	var checkpoint domain.PushCheckpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal push checkpoint: %w", err)
+	}
+	return &checkpoint, nil
+}
+
+// Save stores the checkpoint, replacing an earlier one of the same push
+func (s *JSONPushCheckpointStore) Save(checkpoint *domain.PushCheckpoint) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create push checkpoint directory: %w", err)
+	}
+	data, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal push checkpoint: %w", err)
+	}
+	if err := os.WriteFile(s.path(checkpoint.Project, checkpoint.Sprint, checkpoint.Mode), data, 0644); err != nil {
+		return fmt.Errorf("failed to write push checkpoint: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the checkpoint of the push, if any
+func (s *JSONPushCheckpointStore) Delete(project, sprint string, mode domain.PushMode) error {
+	if err := os.Remove(s.path(project, sprint, mode)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove push checkpoint: %w", err)
+	}
+	return nil
+}
+
+// path returns the checkpoint file of the push, with the characters of the sprint name that
+// are unsafe in file names replaced
+func (s *JSONPushCheckpointStore) path(project, sprint string, mode domain.PushMode) string {
+	safe := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '.' {
+			return r
+		}
+		return '_'
+	}, sprint)
+	return filepath.Join(s.dir, fmt.Sprintf("%s-%s-%s.json", project, safe, mode))
+}
+
+// Ensure JSONPushCheckpointStore implements PushCheckpointStore
+var _ ports.PushCheckpointStore = (*JSONPushCheckpointStore)(nil)
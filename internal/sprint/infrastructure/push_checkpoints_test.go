@@ -0,0 +1,40 @@
+package infrastructure
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+func TestJSONPushCheckpointStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "push")
+	store := NewJSONPushCheckpointStore(dir)
+
+	checkpoint, err := store.Load("FN", "Sprint 1", domain.PushModeWorklog)
+	require.NoError(t, err)
+	assert.Nil(t, checkpoint, "there is no checkpoint before the first push")
+
+	saved := domain.NewPushCheckpoint("FN", "Sprint 1/2", domain.PushModeWorklog)
+	saved.Record("FN-1 [assetcap:worklog] Sprint 1/2 | Jane | cap-development", "0123456789abcdef")
+	require.NoError(t, store.Save(saved))
+	assert.FileExists(t, filepath.Join(dir, "FN-Sprint_1_2-worklog.json"))
+
+	loaded, err := store.Load("FN", "Sprint 1/2", domain.PushModeWorklog)
+	require.NoError(t, err)
+	assert.Equal(t, saved.Pushed, loaded.Pushed)
+
+	other, err := store.Load("FN", "Sprint 1/2", domain.PushModeComment)
+	require.NoError(t, err)
+	assert.Nil(t, other, "each mode has its own checkpoint")
+
+	require.NoError(t, store.Delete("FN", "Sprint 1/2", domain.PushModeWorklog))
+	require.NoError(t, store.Delete("FN", "Sprint 1/2", domain.PushModeWorklog), "deleting a missing checkpoint is fine")
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -0,0 +1,61 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/schema"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+// DefaultTeamsPath is the file holding the team of each project
+const DefaultTeamsPath = ".assetcap/teams.json"
+
+// JSONTeamsRepository implements the TeamsRepository port with a teams.json file
+type JSONTeamsRepository struct {
+	path string
+}
+
+// NewJSONTeamsRepository creates a repository keeping the teams in the file at path
+func NewJSONTeamsRepository(path string) *JSONTeamsRepository {
+	return &JSONTeamsRepository{path: path}
+}
+
+// Load reads and validates the teams file. A missing file is not replaced by any default:
+// teams are set up with assetcap teams add.
+func (r *JSONTeamsRepository) Load() (domain.TeamMap, error) {
+	data, err := os.ReadFile(r.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s not found; add a team with assetcap teams add --project KEY", domain.ErrNoTeams, r.path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read teams file: %w", err)
+	}
+	if err := schema.Validate(schema.Teams, data); err != nil {
+		return nil, fmt.Errorf("invalid teams file %s: %w", r.path, err)
+	}
+
+	teams := domain.TeamMap{}
+	if err := json.Unmarshal(data, &teams); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal teams data: %w", err)
+	}
+	return teams, nil
+}
+
+// Save writes the teams file, creating its directory when needed
+func (r *JSONTeamsRepository) Save(teams domain.TeamMap) error {
+	data, err := json.MarshalIndent(teams, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal teams data: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return fmt.Errorf("failed to create teams directory: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write teams file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package infrastructure
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+)
+
+func TestJSONTeamsRepository_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".assetcap", "teams.json")
+	repository := NewJSONTeamsRepository(path)
+
+	_, err := repository.Load()
+	require.ErrorIs(t, err, domain.ErrNoTeams)
+	assert.EqualError(t, err, "no teams are set up: "+path+" not found; add a team with assetcap teams add --project KEY")
+	assert.NoFileExists(t, path, "a missing teams file is not created")
+
+	teams := domain.TeamMap{"FN": {Team: []string{"alice"}, Capacity: map[string]float64{"alice": 0.5}}}
+	require.NoError(t, repository.Save(teams))
+
+	loaded, err := repository.Load()
+	require.NoError(t, err)
+	assert.Equal(t, teams, loaded)
+}
+
+func TestJSONTeamsRepository_LoadInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "teams.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"FN": {"teams": ["alice"]}}`), 0644))
+
+	_, err := NewJSONTeamsRepository(path).Load()
+	assert.ErrorContains(t, err, "invalid teams file "+path)
+}
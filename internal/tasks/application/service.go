@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/application/usecase"
 	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
@@ -14,13 +15,27 @@ import (
 type TaskServiceImpl struct {
 	fetchTasksUseCase    *usecase.FetchTasksUseCase
 	classifyTasksUseCase *usecase.ClassifyTasksUseCase
+	sampleTasksUseCase   *usecase.SampleTasksUseCase
+	applyEventUseCase    *usecase.ApplyIssueEventUseCase
+	orgReportUseCase     *usecase.OrgReportUseCase
+	linkCodeUseCase      *usecase.LinkCodeChangesUseCase
+	splitRepo            ports.WorkTypeSplitRepository
+	noteRepo             ports.TaskNoteRepository
+	epicRepo             ports.EpicMapRepository
 }
 
-// NewTasksService creates a new TasksService
-func NewTasksService(remoteRepo, localRepo ports.TaskRepository, classifier ports.TaskClassifier, userInput ports.UserInput) TaskService {
+// NewTasksService creates a new TasksService. The code host is optional.
+func NewTasksService(remoteRepo, localRepo ports.TaskRepository, classifier ports.TaskClassifier, userInput ports.UserInput, sampleRepo ports.SampleRepository, splitRepo ports.WorkTypeSplitRepository, rollupCache ports.RollupCache, codeHost ports.CodeHost, noteRepo ports.TaskNoteRepository, epicRepo ports.EpicMapRepository) TaskService {
 	return &TaskServiceImpl{
-		fetchTasksUseCase:    usecase.NewFetchTasksUseCase(remoteRepo, localRepo),
-		classifyTasksUseCase: usecase.NewClassifyTasksUseCase(localRepo, remoteRepo, classifier, userInput),
+		fetchTasksUseCase:    usecase.NewFetchTasksUseCase(remoteRepo, localRepo, epicRepo),
+		classifyTasksUseCase: usecase.NewClassifyTasksUseCase(localRepo, remoteRepo, classifier, userInput, epicRepo),
+		sampleTasksUseCase:   usecase.NewSampleTasksUseCase(localRepo, sampleRepo),
+		applyEventUseCase:    usecase.NewApplyIssueEventUseCase(localRepo, classifier),
+		orgReportUseCase:     usecase.NewOrgReportUseCase(localRepo, splitRepo, rollupCache),
+		linkCodeUseCase:      usecase.NewLinkCodeChangesUseCase(localRepo, codeHost),
+		splitRepo:            splitRepo,
+		noteRepo:             noteRepo,
+		epicRepo:             epicRepo,
 	}
 }
 
@@ -29,6 +44,17 @@ func (s *TaskServiceImpl) FetchTasks(ctx context.Context, project, sprint, platf
 	return s.fetchTasksUseCase.Execute(ctx, project, sprint, platform)
 }
 
+// FetchTasksByFixVersion fetches the tasks of a release from a platform
+func (s *TaskServiceImpl) FetchTasksByFixVersion(ctx context.Context, project, fixVersion, platform string) error {
+	return s.fetchTasksUseCase.ExecuteByFixVersion(ctx, project, fixVersion, platform)
+}
+
+// ImportTasks stores tasks read from an export of the platform, keeping those of the project
+// when one is given
+func (s *TaskServiceImpl) ImportTasks(ctx context.Context, tasks []*domain.Task, project string) error {
+	return s.fetchTasksUseCase.ExecuteImport(ctx, tasks, project)
+}
+
 // ClassifyTasks classifies tasks for a project and sprint
 func (s *TaskServiceImpl) ClassifyTasks(ctx context.Context, input domain.ClassifyTasksInput) error {
 	return s.classifyTasksUseCase.Execute(ctx, input)
@@ -41,22 +67,25 @@ func (s *TaskServiceImpl) GetTasks(ctx context.Context, project, sprint string)
 
 // GetTasksByAsset retrieves tasks associated with a specific asset
 func (s *TaskServiceImpl) GetTasksByAsset(ctx context.Context, assetName string) ([]*domain.Task, error) {
+	if finder, ok := s.GetLocalRepository().(ports.TaskAssetFinder); ok {
+		tasks, err := finder.FindByAsset(ctx, assetName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tasks: %w", err)
+		}
+		return tasks, nil
+	}
+
 	tasks, err := s.classifyTasksUseCase.GetAllTasks(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get tasks: %w", err)
 	}
 
 	// Handle both asset names and full asset IDs
-	assetID := assetName
-	if !strings.HasPrefix(assetName, "cap-asset-") {
-		// For multi-word asset names, use just the first word
-		words := strings.Fields(assetName)
-		assetID = "cap-asset-" + strings.ToLower(words[0])
-	}
+	assetID := domain.AssetLabel(assetName)
 
 	var assetTasks []*domain.Task
 	for _, task := range tasks {
-		for _, label := range task.Labels {
+		for _, label := range task.AssetLabels() {
 			if label == assetID {
 				assetTasks = append(assetTasks, task)
 				break
@@ -67,6 +96,251 @@ func (s *TaskServiceImpl) GetTasksByAsset(ctx context.Context, assetName string)
 	return assetTasks, nil
 }
 
+// LinkCodeChanges stores the commits and pull requests mentioning the keys of a sprint's
+// tasks on the tasks
+func (s *TaskServiceImpl) LinkCodeChanges(ctx context.Context, input domain.CodeLinkInput) (*domain.CodeLinkResult, error) {
+	return s.linkCodeUseCase.Execute(ctx, input)
+}
+
+// SampleTasks draws a reproducible random sample of classified tasks for audits
+func (s *TaskServiceImpl) SampleTasks(ctx context.Context, input domain.SampleTasksInput) (*domain.TaskSample, error) {
+	sample, err := s.sampleTasksUseCase.Execute(ctx, input)
+	if err != nil || s.noteRepo == nil {
+		return sample, err
+	}
+	keys := make([]string, 0, len(sample.Tasks))
+	for _, task := range sample.Tasks {
+		keys = append(keys, task.Key)
+	}
+	if len(keys) == 0 {
+		return sample, nil
+	}
+	sample.Notes, err = s.noteRepo.FindNotes(ctx, keys...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task notes: %w", err)
+	}
+	return sample, nil
+}
+
+// OrgReport rolls the classified tasks of the projects' teams up to an organization summary
+func (s *TaskServiceImpl) OrgReport(ctx context.Context, input domain.OrgReportInput) (*domain.OrgReport, error) {
+	return s.orgReportUseCase.Execute(ctx, input)
+}
+
+// ClassificationCoverage counts the classified tasks of every stored sprint, oldest first
+func (s *TaskServiceImpl) ClassificationCoverage(ctx context.Context) ([]domain.SprintCoverage, error) {
+	tasks, err := s.classifyTasksUseCase.GetAllTasks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+	return domain.CoverageBySprint(tasks), nil
+}
+
+// LabelCoverage measures how many done tasks of a project's quarter carry a work type label
+// and an asset link, against the input's thresholds
+func (s *TaskServiceImpl) LabelCoverage(ctx context.Context, input domain.LabelCoverageInput) (*domain.LabelCoverage, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+	tasks, err := s.GetLocalRepository().FindByProject(ctx, input.Project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+	coverage, err := domain.CoverLabels(input, tasks)
+	if err != nil {
+		return nil, err
+	}
+	return &coverage, nil
+}
+
+// GetTask retrieves a stored task by its issue key
+func (s *TaskServiceImpl) GetTask(ctx context.Context, issueKey string) (*domain.Task, error) {
+	issueKey = strings.ToUpper(strings.TrimSpace(issueKey))
+	if issueKey == "" {
+		return nil, fmt.Errorf("issue key is required")
+	}
+	task, err := s.GetLocalRepository().FindByKey(ctx, issueKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task %s: %w; fetch its sprint first", issueKey, err)
+	}
+	return task, nil
+}
+
+// LabelHistory returns the cap-* label changes recorded for a stored issue
+func (s *TaskServiceImpl) LabelHistory(ctx context.Context, issueKey string) (*domain.LabelHistory, error) {
+	task, err := s.GetTask(ctx, issueKey)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewLabelHistory(task), nil
+}
+
+// ApplyIssueEvent updates the local task store from an issue event pushed by a platform
+func (s *TaskServiceImpl) ApplyIssueEvent(ctx context.Context, input domain.IssueEventInput) error {
+	return s.applyEventUseCase.Execute(ctx, input)
+}
+
+// DeleteTasks deletes the stored tasks of a project and sprint, keeping them in the trash
+// until they expire unless purge is set
+func (s *TaskServiceImpl) DeleteTasks(ctx context.Context, project, sprint string, purge bool) error {
+	if project == "" || sprint == "" {
+		return fmt.Errorf("project and sprint are required")
+	}
+
+	repo := s.GetLocalRepository()
+	if trash, ok := repo.(ports.TaskTrash); ok && purge {
+		if err := trash.PurgeByProjectAndSprint(ctx, project, sprint); err != nil {
+			return fmt.Errorf("failed to purge tasks: %w", err)
+		}
+		return nil
+	}
+	if err := repo.DeleteByProjectAndSprint(ctx, project, sprint); err != nil {
+		return fmt.Errorf("failed to delete tasks: %w", err)
+	}
+	return nil
+}
+
+// ListDeletedTasks returns the tasks in the trash, most recently deleted first
+func (s *TaskServiceImpl) ListDeletedTasks(ctx context.Context) ([]*domain.DeletedTask, error) {
+	trash, ok := s.GetLocalRepository().(ports.TaskTrash)
+	if !ok {
+		return nil, fmt.Errorf("the task repository does not keep deleted tasks")
+	}
+	return trash.FindDeleted(ctx)
+}
+
+// RestoreTask moves a deleted task back into the local task store
+func (s *TaskServiceImpl) RestoreTask(ctx context.Context, key string) (*domain.Task, error) {
+	trash, ok := s.GetLocalRepository().(ports.TaskTrash)
+	if !ok {
+		return nil, fmt.Errorf("the task repository does not keep deleted tasks")
+	}
+	task, err := trash.Restore(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore task: %w", err)
+	}
+	return task, nil
+}
+
+// SplitTask divides an issue's hours across work types, replacing any previous split
+func (s *TaskServiceImpl) SplitTask(ctx context.Context, issueKey string, shares map[domain.WorkType]float64) (*domain.WorkTypeSplit, error) {
+	if s.splitRepo == nil {
+		return nil, fmt.Errorf("work type split storage is not configured")
+	}
+	split, err := domain.NewWorkTypeSplit(issueKey, shares)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.splitRepo.SaveSplit(ctx, *split); err != nil {
+		return nil, fmt.Errorf("failed to save work type split: %w", err)
+	}
+	return split, nil
+}
+
+// RemoveTaskSplit removes the work type split of an issue
+func (s *TaskServiceImpl) RemoveTaskSplit(ctx context.Context, issueKey string) error {
+	if s.splitRepo == nil {
+		return fmt.Errorf("work type split storage is not configured")
+	}
+	if err := s.splitRepo.DeleteSplit(ctx, issueKey); err != nil {
+		return fmt.Errorf("failed to remove work type split: %w", err)
+	}
+	return nil
+}
+
+// AddTaskNote records a timestamped note on an issue
+func (s *TaskServiceImpl) AddTaskNote(ctx context.Context, issueKey, text string) (*domain.TaskNote, error) {
+	if s.noteRepo == nil {
+		return nil, fmt.Errorf("task note storage is not configured")
+	}
+	note, err := domain.NewTaskNote(issueKey, text, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.noteRepo.SaveNote(ctx, *note); err != nil {
+		return nil, fmt.Errorf("failed to save task note: %w", err)
+	}
+	return note, nil
+}
+
+// TaskNotes returns the notes of the given issues keyed by issue, oldest first
+func (s *TaskServiceImpl) TaskNotes(ctx context.Context, issueKeys ...string) (map[string][]domain.TaskNote, error) {
+	if s.noteRepo == nil {
+		return nil, fmt.Errorf("task note storage is not configured")
+	}
+	notes, err := s.noteRepo.FindNotes(ctx, issueKeys...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task notes: %w", err)
+	}
+	return notes, nil
+}
+
+// MapEpic links an epic to an asset, so the tasks of the epic inherit the asset's label
+// when they are fetched or classified
+func (s *TaskServiceImpl) MapEpic(ctx context.Context, epicKey, asset, label string) (*domain.EpicMapping, error) {
+	if s.epicRepo == nil {
+		return nil, fmt.Errorf("epic map storage is not configured")
+	}
+	mapping, err := domain.NewEpicMapping(epicKey, asset, label)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.epicRepo.SaveEpicMapping(ctx, *mapping); err != nil {
+		return nil, fmt.Errorf("failed to save epic mapping: %w", err)
+	}
+	return mapping, nil
+}
+
+// UnmapEpic removes the asset mapping of an epic
+func (s *TaskServiceImpl) UnmapEpic(ctx context.Context, epicKey string) error {
+	if s.epicRepo == nil {
+		return fmt.Errorf("epic map storage is not configured")
+	}
+	if err := s.epicRepo.DeleteEpicMapping(ctx, epicKey); err != nil {
+		return fmt.Errorf("failed to remove epic mapping: %w", err)
+	}
+	return nil
+}
+
+// ListEpicMappings returns the stored epic mappings, ordered by epic key
+func (s *TaskServiceImpl) ListEpicMappings(ctx context.Context) ([]domain.EpicMapping, error) {
+	if s.epicRepo == nil {
+		return nil, fmt.Errorf("epic map storage is not configured")
+	}
+	mappings, err := s.epicRepo.FindEpicMappings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list epic mappings: %w", err)
+	}
+	return mappings, nil
+}
+
+// UnmappedEpics returns the unmapped epics of the stored tasks updated since the given time
+// whose tasks are linked to no asset, the epics with the most such tasks first
+func (s *TaskServiceImpl) UnmappedEpics(ctx context.Context, since time.Time) ([]domain.UnmappedEpic, error) {
+	mappings, err := s.ListEpicMappings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := s.classifyTasksUseCase.GetAllTasks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+	return domain.UnmappedEpics(tasks, domain.NewEpicMap(mappings), since), nil
+}
+
+// ListTaskSplits returns the stored work type splits, ordered by issue key
+func (s *TaskServiceImpl) ListTaskSplits(ctx context.Context) ([]domain.WorkTypeSplit, error) {
+	if s.splitRepo == nil {
+		return nil, fmt.Errorf("work type split storage is not configured")
+	}
+	splits, err := s.splitRepo.FindSplits(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list work type splits: %w", err)
+	}
+	return splits, nil
+}
+
+// GetLocalRepository returns the local task repository
 func (s *TaskServiceImpl) GetLocalRepository() ports.TaskRepository {
 	return s.classifyTasksUseCase.GetLocalRepository()
 }
@@ -4,18 +4,20 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/application/usecase/testutil"
 	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/infrastructure/storage"
 )
 
 func TestTasksService_FetchTasks(t *testing.T) {
 	remoteRepo := testutil.NewMockTaskRepository()
 	localRepo := testutil.NewMockTaskRepository()
-	service := NewTasksService(remoteRepo, localRepo, nil, nil)
+	service := NewTasksService(remoteRepo, localRepo, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	tests := []struct {
 		name     string
@@ -125,7 +127,7 @@ func TestTasksService_ClassifyTasks(t *testing.T) {
 	localRepo := testutil.NewMockTaskRepository()
 	classifier := testutil.NewMockTaskClassifier()
 	userInput := testutil.NewMockUserInput()
-	service := NewTasksService(remoteRepo, localRepo, classifier, userInput)
+	service := NewTasksService(remoteRepo, localRepo, classifier, userInput, nil, nil, nil, nil, nil, nil)
 
 	tests := []struct {
 		name    string
@@ -357,7 +359,7 @@ func TestTaskService_GetTasksByAsset(t *testing.T) {
 	})
 
 	// Create service
-	service := NewTasksService(jiraRepo, localRepo, classifier, userInput)
+	service := NewTasksService(jiraRepo, localRepo, classifier, userInput, nil, nil, nil, nil, nil, nil)
 
 	tests := []struct {
 		name      string
@@ -410,3 +412,267 @@ func TestTaskService_GetTasksByAsset(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskService_LabelHistory(t *testing.T) {
+	ctx := context.Background()
+	change := domain.LabelChange{Label: "cap-maintenance", Action: domain.LabelAdded, Author: "Jane Doe", At: time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)}
+	localRepo := storage.NewJSONStorage(t.TempDir(), "tasks.json")
+	require.NoError(t, localRepo.Save(ctx, &domain.Task{Key: "FN-123", Summary: "Fix login", Project: "FN", Sprint: "Sprint 1", Labels: []string{"cap-maintenance"}, LabelHistory: []domain.LabelChange{change}}))
+	service := NewTasksService(testutil.NewMockTaskRepository(), localRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	history, err := service.LabelHistory(ctx, " fn-123 ")
+	require.NoError(t, err)
+	assert.Equal(t, "FN-123", history.IssueKey)
+	assert.Equal(t, []string{"cap-maintenance"}, history.Labels)
+	require.Len(t, history.Changes, 1)
+	assert.True(t, change.At.Equal(history.Changes[0].At))
+
+	_, err = service.LabelHistory(ctx, "FN-999")
+	assert.ErrorContains(t, err, "failed to get task FN-999")
+
+	_, err = service.LabelHistory(ctx, "")
+	assert.EqualError(t, err, "issue key is required")
+}
+
+func TestTaskService_GetTask(t *testing.T) {
+	ctx := context.Background()
+	localRepo := storage.NewJSONStorage(t.TempDir(), "tasks.json")
+	require.NoError(t, localRepo.Save(ctx, &domain.Task{Key: "FN-7", Summary: "Add invoices", Project: "FN", Sprint: "Sprint 1"}))
+	service := NewTasksService(testutil.NewMockTaskRepository(), localRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	task, err := service.GetTask(ctx, " fn-7 ")
+	require.NoError(t, err)
+	assert.Equal(t, "Add invoices", task.Summary)
+
+	_, err = service.GetTask(ctx, "FN-999")
+	assert.ErrorContains(t, err, "failed to get task FN-999")
+
+	_, err = service.GetTask(ctx, "")
+	assert.EqualError(t, err, "issue key is required")
+}
+
+func TestTaskService_GetTasksByAssetIndexed(t *testing.T) {
+	ctx := context.Background()
+	localRepo := storage.NewJSONStorage(t.TempDir(), "tasks.json")
+	require.NoError(t, localRepo.Save(ctx, &domain.Task{Key: "FN-1", Project: "FN", Sprint: "Sprint 1", Labels: []string{"cap-asset-insurance"}}))
+	require.NoError(t, localRepo.Save(ctx, &domain.Task{Key: "FN-2", Project: "FN", Sprint: "Sprint 1", Labels: []string{"cap-asset-booking"}}))
+	service := NewTasksService(testutil.NewMockTaskRepository(), localRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	got, err := service.GetTasksByAsset(ctx, "Insurance")
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "FN-1", got[0].Key)
+}
+
+func TestTasksService_Trash(t *testing.T) {
+	ctx := context.Background()
+	localRepo := storage.NewJSONStorage(t.TempDir(), "tasks.json")
+	require.NoError(t, localRepo.Save(ctx, &domain.Task{Key: "FN-1", Project: "FN", Sprint: "Sprint 1"}))
+	require.NoError(t, localRepo.Save(ctx, &domain.Task{Key: "FN-2", Project: "FN", Sprint: "Sprint 2"}))
+	service := NewTasksService(testutil.NewMockTaskRepository(), localRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	assert.ErrorContains(t, service.DeleteTasks(ctx, "FN", "", false), "project and sprint are required")
+
+	require.NoError(t, service.DeleteTasks(ctx, "FN", "Sprint 1", false))
+	deleted, err := service.ListDeletedTasks(ctx)
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	assert.Equal(t, "FN-1", deleted[0].Task.Key)
+
+	task, err := service.RestoreTask(ctx, "FN-1")
+	require.NoError(t, err)
+	assert.Equal(t, "FN-1", task.Key)
+	_, err = service.RestoreTask(ctx, "FN-1")
+	assert.ErrorContains(t, err, "failed to restore task: deleted task FN-1 not found")
+
+	require.NoError(t, service.DeleteTasks(ctx, "FN", "Sprint 2", true))
+	deleted, err = service.ListDeletedTasks(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, deleted)
+	tasks, err := localRepo.FindAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "FN-1", tasks[0].Key)
+}
+
+func TestTasksService_TrashUnsupported(t *testing.T) {
+	ctx := context.Background()
+	localRepo := testutil.NewMockTaskRepository()
+	service := NewTasksService(testutil.NewMockTaskRepository(), localRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	require.NoError(t, service.DeleteTasks(ctx, "FN", "Sprint 1", true))
+	_, err := service.ListDeletedTasks(ctx)
+	assert.ErrorContains(t, err, "the task repository does not keep deleted tasks")
+	_, err = service.RestoreTask(ctx, "FN-1")
+	assert.ErrorContains(t, err, "the task repository does not keep deleted tasks")
+}
+
+func TestTasksService_Splits(t *testing.T) {
+	ctx := context.Background()
+	splitRepo := storage.NewJSONSplitStorage(t.TempDir(), "splits.json")
+	service := NewTasksService(testutil.NewMockTaskRepository(), testutil.NewMockTaskRepository(), nil, nil, nil, splitRepo, nil, nil, nil, nil)
+
+	split, err := service.SplitTask(ctx, "fn-123", map[domain.WorkType]float64{
+		domain.WorkTypeDevelopment: 70,
+		domain.WorkTypeMaintenance: 30,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "FN-123", split.IssueKey)
+
+	_, err = service.SplitTask(ctx, "FN-124", map[domain.WorkType]float64{domain.WorkTypeDevelopment: 70})
+	assert.ErrorIs(t, err, domain.ErrSplitNotHundred)
+
+	splits, err := service.ListTaskSplits(ctx)
+	require.NoError(t, err)
+	require.Len(t, splits, 1)
+	assert.Equal(t, 30.0, splits[0].Shares[domain.WorkTypeMaintenance])
+
+	require.NoError(t, service.RemoveTaskSplit(ctx, "FN-123"))
+	assert.ErrorContains(t, service.RemoveTaskSplit(ctx, "FN-123"), "failed to remove work type split")
+	splits, err = service.ListTaskSplits(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, splits)
+}
+
+func TestTasksService_SplitsNotConfigured(t *testing.T) {
+	ctx := context.Background()
+	service := NewTasksService(testutil.NewMockTaskRepository(), testutil.NewMockTaskRepository(), nil, nil, nil, nil, nil, nil, nil, nil)
+
+	_, err := service.SplitTask(ctx, "FN-1", map[domain.WorkType]float64{domain.WorkTypeDevelopment: 100})
+	assert.ErrorContains(t, err, "work type split storage is not configured")
+	_, err = service.ListTaskSplits(ctx)
+	assert.ErrorContains(t, err, "work type split storage is not configured")
+}
+
+func TestTasksService_Notes(t *testing.T) {
+	ctx := context.Background()
+	noteRepo := storage.NewJSONNoteStorage(t.TempDir(), "notes.json")
+	service := NewTasksService(testutil.NewMockTaskRepository(), testutil.NewMockTaskRepository(), nil, nil, nil, nil, nil, nil, noteRepo, nil)
+
+	note, err := service.AddTaskNote(ctx, "fn-123", "confirmed with tech lead: maintenance")
+	require.NoError(t, err)
+	assert.Equal(t, "FN-123", note.IssueKey)
+	assert.False(t, note.CreatedAt.IsZero())
+
+	_, err = service.AddTaskNote(ctx, "FN-123", "")
+	assert.ErrorIs(t, err, domain.ErrEmptyNote)
+
+	notes, err := service.TaskNotes(ctx, "FN-123")
+	require.NoError(t, err)
+	require.Len(t, notes["FN-123"], 1)
+	assert.Equal(t, "confirmed with tech lead: maintenance", notes["FN-123"][0].Text)
+}
+
+func TestTasksService_NotesNotConfigured(t *testing.T) {
+	ctx := context.Background()
+	service := NewTasksService(testutil.NewMockTaskRepository(), testutil.NewMockTaskRepository(), nil, nil, nil, nil, nil, nil, nil, nil)
+
+	_, err := service.AddTaskNote(ctx, "FN-1", "text")
+	assert.ErrorContains(t, err, "task note storage is not configured")
+	_, err = service.TaskNotes(ctx, "FN-1")
+	assert.ErrorContains(t, err, "task note storage is not configured")
+}
+
+func TestTasksService_SampleTasksIncludesNotes(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	localRepo := testutil.NewMockTaskRepository()
+	localRepo.SetFindByProjectFunc(func(_ context.Context, _ string) ([]*domain.Task, error) {
+		return []*domain.Task{
+			{Key: "FN-1", Project: "FN", WorkType: domain.WorkTypeDevelopment, CreatedAt: time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)},
+		}, nil
+	})
+	noteRepo := storage.NewJSONNoteStorage(dir, "notes.json")
+	require.NoError(t, noteRepo.SaveNote(ctx, domain.TaskNote{IssueKey: "FN-1", Text: "checked", CreatedAt: time.Now()}))
+	require.NoError(t, noteRepo.SaveNote(ctx, domain.TaskNote{IssueKey: "FN-9", Text: "unrelated", CreatedAt: time.Now()}))
+	service := NewTasksService(testutil.NewMockTaskRepository(), localRepo, nil, nil, storage.NewJSONSampleStorage(dir, "samples.json"), nil, nil, nil, noteRepo, nil)
+
+	sample, err := service.SampleTasks(ctx, domain.SampleTasksInput{Project: "FN", Quarter: "2024-Q2", Size: 1, Seed: 1})
+
+	require.NoError(t, err)
+	require.Len(t, sample.Notes, 1)
+	assert.Equal(t, "checked", sample.Notes["FN-1"][0].Text)
+}
+
+func TestTasksService_EpicMap(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	localRepo := testutil.NewMockTaskRepository()
+	localRepo.SetFindAllFunc(func(_ context.Context) ([]*domain.Task, error) {
+		return []*domain.Task{
+			{Key: "FN-1", Epic: "FN-100", UpdatedAt: now},
+			{Key: "FN-2", Epic: "FN-200", Sprint: "Sprint 1", UpdatedAt: now},
+		}, nil
+	})
+	epicRepo := storage.NewJSONEpicMapStorage(t.TempDir(), "epics.json")
+	service := NewTasksService(testutil.NewMockTaskRepository(), localRepo, nil, nil, nil, nil, nil, nil, nil, epicRepo)
+
+	mapping, err := service.MapEpic(ctx, "fn-100", "Booking", "cap-asset-booking")
+	require.NoError(t, err)
+	assert.Equal(t, "FN-100", mapping.EpicKey)
+	_, err = service.MapEpic(ctx, "FN-100", "Booking", "booking")
+	assert.ErrorIs(t, err, domain.ErrInvalidEpicLink)
+
+	mappings, err := service.ListEpicMappings(ctx)
+	require.NoError(t, err)
+	require.Len(t, mappings, 1)
+
+	epics, err := service.UnmappedEpics(ctx, now.AddDate(0, 0, -90))
+	require.NoError(t, err)
+	assert.Equal(t, []domain.UnmappedEpic{{EpicKey: "FN-200", Tasks: 1, Sprints: []string{"Sprint 1"}}}, epics)
+
+	require.NoError(t, service.UnmapEpic(ctx, "FN-100"))
+	assert.ErrorContains(t, service.UnmapEpic(ctx, "FN-100"), "failed to remove epic mapping")
+}
+
+func TestTasksService_EpicMapNotConfigured(t *testing.T) {
+	ctx := context.Background()
+	service := NewTasksService(testutil.NewMockTaskRepository(), testutil.NewMockTaskRepository(), nil, nil, nil, nil, nil, nil, nil, nil)
+
+	_, err := service.MapEpic(ctx, "FN-100", "Booking", "cap-asset-booking")
+	assert.ErrorContains(t, err, "epic map storage is not configured")
+	_, err = service.UnmappedEpics(ctx, time.Now())
+	assert.ErrorContains(t, err, "epic map storage is not configured")
+}
+
+func TestTasksService_ClassificationCoverage(t *testing.T) {
+	localRepo := testutil.NewMockTaskRepository()
+	localRepo.SetFindAllFunc(func(_ context.Context) ([]*domain.Task, error) {
+		return []*domain.Task{
+			{Key: "FN-1", Project: "FN", Sprint: "Penguins", WorkType: domain.WorkTypeDevelopment},
+			{Key: "FN-2", Project: "FN", Sprint: "Penguins"},
+			{Key: "FN-3", Project: "FN"},
+		}, nil
+	})
+	service := NewTasksService(testutil.NewMockTaskRepository(), localRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	coverage, err := service.ClassificationCoverage(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, coverage, 1)
+	assert.Equal(t, "Penguins", coverage[0].Sprint)
+	assert.Equal(t, 2, coverage[0].Tasks)
+	assert.Equal(t, 50.0, coverage[0].Percent())
+}
+
+func TestTasksService_LabelCoverage(t *testing.T) {
+	localRepo := testutil.NewMockTaskRepository()
+	localRepo.SetFindByProjectFunc(func(_ context.Context, project string) ([]*domain.Task, error) {
+		assert.Equal(t, "FN", project)
+		created := time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC)
+		return []*domain.Task{
+			{Key: "FN-1", Project: "FN", Status: domain.TaskStatusDone, CreatedAt: created, Labels: []string{"cap-development", "cap-asset-booking"}},
+			{Key: "FN-2", Project: "FN", Status: domain.TaskStatusDone, CreatedAt: created, Labels: []string{"cap-development"}},
+		}, nil
+	})
+	service := NewTasksService(testutil.NewMockTaskRepository(), localRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	coverage, err := service.LabelCoverage(context.Background(), domain.LabelCoverageInput{Project: "FN", Quarter: "2024-Q2", AssetThreshold: 80})
+	require.NoError(t, err)
+	assert.Equal(t, 100.0, coverage.WorkTypePercent)
+	assert.Equal(t, 50.0, coverage.AssetPercent)
+	assert.False(t, coverage.Passed)
+
+	_, err = service.LabelCoverage(context.Background(), domain.LabelCoverageInput{Project: "FN", Quarter: "2024"})
+	assert.ErrorIs(t, err, domain.ErrInvalidQuarter)
+}
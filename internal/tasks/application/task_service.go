@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"time"
 
 	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
 	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain/ports"
@@ -12,6 +13,12 @@ type TaskService interface {
 	// FetchTasks fetches tasks from a platform
 	FetchTasks(ctx context.Context, project, sprint, platform string) error
 
+	// FetchTasksByFixVersion fetches the tasks of a release from a platform
+	FetchTasksByFixVersion(ctx context.Context, project, fixVersion, platform string) error
+
+	// ImportTasks stores tasks read from an export of the platform, such as a Jira backup
+	ImportTasks(ctx context.Context, tasks []*domain.Task, project string) error
+
 	// ClassifyTasks classifies tasks for a project and sprint
 	ClassifyTasks(ctx context.Context, input domain.ClassifyTasksInput) error
 
@@ -21,6 +28,70 @@ type TaskService interface {
 	// GetTasksByAsset retrieves tasks associated with a specific asset
 	GetTasksByAsset(ctx context.Context, assetName string) ([]*domain.Task, error)
 
+	// GetTask retrieves a stored task by its issue key
+	GetTask(ctx context.Context, issueKey string) (*domain.Task, error)
+
+	// LinkCodeChanges stores the commits and pull requests mentioning the keys of a sprint's
+	// tasks on the tasks
+	LinkCodeChanges(ctx context.Context, input domain.CodeLinkInput) (*domain.CodeLinkResult, error)
+
+	// SampleTasks draws a reproducible random sample of classified tasks for audits
+	SampleTasks(ctx context.Context, input domain.SampleTasksInput) (*domain.TaskSample, error)
+
+	// OrgReport rolls the classified tasks of the projects' teams up to an organization summary
+	OrgReport(ctx context.Context, input domain.OrgReportInput) (*domain.OrgReport, error)
+
+	// ClassificationCoverage counts the classified tasks of every stored sprint, oldest first
+	ClassificationCoverage(ctx context.Context) ([]domain.SprintCoverage, error)
+
+	// LabelCoverage measures how many done tasks of a project's quarter carry a work type label
+	// and an asset link, against the input's thresholds
+	LabelCoverage(ctx context.Context, input domain.LabelCoverageInput) (*domain.LabelCoverage, error)
+
+	// LabelHistory returns the cap-* label changes recorded for a stored issue
+	LabelHistory(ctx context.Context, issueKey string) (*domain.LabelHistory, error)
+
+	// ApplyIssueEvent updates the local task store from an issue event pushed by a platform
+	ApplyIssueEvent(ctx context.Context, input domain.IssueEventInput) error
+
+	// DeleteTasks deletes the stored tasks of a project and sprint, keeping them in the trash
+	// until they expire unless purge is set
+	DeleteTasks(ctx context.Context, project, sprint string, purge bool) error
+
+	// ListDeletedTasks returns the tasks in the trash, most recently deleted first
+	ListDeletedTasks(ctx context.Context) ([]*domain.DeletedTask, error)
+
+	// RestoreTask moves a deleted task back into the local task store
+	RestoreTask(ctx context.Context, key string) (*domain.Task, error)
+
+	// SplitTask divides an issue's hours across work types, replacing any previous split
+	SplitTask(ctx context.Context, issueKey string, shares map[domain.WorkType]float64) (*domain.WorkTypeSplit, error)
+
+	// RemoveTaskSplit removes the work type split of an issue
+	RemoveTaskSplit(ctx context.Context, issueKey string) error
+
+	// AddTaskNote records a timestamped note on an issue
+	AddTaskNote(ctx context.Context, issueKey, text string) (*domain.TaskNote, error)
+
+	// TaskNotes returns the notes of the given issues keyed by issue, oldest first
+	TaskNotes(ctx context.Context, issueKeys ...string) (map[string][]domain.TaskNote, error)
+
+	// MapEpic links an epic to an asset, so the tasks of the epic inherit the asset's label
+	MapEpic(ctx context.Context, epicKey, asset, label string) (*domain.EpicMapping, error)
+
+	// UnmapEpic removes the asset mapping of an epic
+	UnmapEpic(ctx context.Context, epicKey string) error
+
+	// ListEpicMappings returns the stored epic mappings, ordered by epic key
+	ListEpicMappings(ctx context.Context) ([]domain.EpicMapping, error)
+
+	// UnmappedEpics returns the unmapped epics of the stored tasks updated since the given
+	// time whose tasks are linked to no asset
+	UnmappedEpics(ctx context.Context, since time.Time) ([]domain.UnmappedEpic, error)
+
+	// ListTaskSplits returns the stored work type splits, ordered by issue key
+	ListTaskSplits(ctx context.Context) ([]domain.WorkTypeSplit, error)
+
 	// GetLocalRepository returns the local task repository
 	GetLocalRepository() ports.TaskRepository
 }
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain/ports"
+)
+
+// ApplyIssueEventUseCase keeps the local task store in sync with issue events pushed by a platform
+type ApplyIssueEventUseCase struct {
+	localRepo  ports.TaskRepository
+	classifier ports.TaskClassifier
+}
+
+// NewApplyIssueEventUseCase creates a new instance of ApplyIssueEventUseCase
+func NewApplyIssueEventUseCase(localRepo ports.TaskRepository, classifier ports.TaskClassifier) *ApplyIssueEventUseCase {
+	return &ApplyIssueEventUseCase{
+		localRepo:  localRepo,
+		classifier: classifier,
+	}
+}
+
+// Execute applies a single issue event to the local task store.
+// A work type already recorded locally is kept when the platform carries none,
+// and unclassified tasks are classified, following the rules of their asset, when
+// reclassification is requested.
+func (uc *ApplyIssueEventUseCase) Execute(ctx context.Context, input domain.IssueEventInput) error {
+	task := input.Event.Task
+	if task == nil || task.Key == "" {
+		return fmt.Errorf("issue event has no task")
+	}
+
+	if input.Event.Type == domain.IssueEventDeleted {
+		if err := uc.localRepo.Delete(ctx, task.Key); err != nil {
+			return fmt.Errorf("failed to delete task %s: %w", task.Key, err)
+		}
+		return nil
+	}
+
+	if existing, err := uc.localRepo.FindByKey(ctx, task.Key); err == nil && existing != nil {
+		if task.WorkType == "" {
+			task.WorkType = existing.WorkType
+		}
+		task.CodeReferences = existing.CodeReferences
+		task.LabelHistory = domain.MergeLabelHistory(existing.LabelHistory, task.LabelHistory)
+		task.Version = existing.Version + 1
+	}
+
+	if input.Reclassify && task.WorkType == "" && uc.classifier != nil {
+		workType, err := classifyTask(uc.classifier, task, input.Rules)
+		if err != nil {
+			return fmt.Errorf("failed to classify task %s: %w", task.Key, err)
+		}
+		if err := task.UpdateWorkType(workType); err != nil {
+			return fmt.Errorf("failed to classify task %s: %w", task.Key, err)
+		}
+	}
+
+	if err := uc.localRepo.Save(ctx, task); err != nil {
+		return fmt.Errorf("failed to save task %s: %w", task.Key, err)
+	}
+	return nil
+}
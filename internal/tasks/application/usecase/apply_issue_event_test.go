@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// memoryTaskRepository is a TaskRepository keeping tasks in memory
+type memoryTaskRepository struct {
+	tasks map[string]*domain.Task
+}
+
+func newMemoryTaskRepository(tasks ...*domain.Task) *memoryTaskRepository {
+	repo := &memoryTaskRepository{tasks: make(map[string]*domain.Task)}
+	for _, task := range tasks {
+		repo.tasks[task.Key] = task
+	}
+	return repo
+}
+
+func (r *memoryTaskRepository) Save(_ context.Context, task *domain.Task) error {
+	r.tasks[task.Key] = task
+	return nil
+}
+
+func (r *memoryTaskRepository) FindByKey(_ context.Context, key string) (*domain.Task, error) {
+	task, ok := r.tasks[key]
+	if !ok {
+		return nil, assert.AnError
+	}
+	return task, nil
+}
+
+func (r *memoryTaskRepository) FindByProjectAndSprint(_ context.Context, _, _ string) ([]*domain.Task, error) {
+	return nil, nil
+}
+
+func (r *memoryTaskRepository) FindByProject(_ context.Context, _ string) ([]*domain.Task, error) {
+	return nil, nil
+}
+
+func (r *memoryTaskRepository) FindBySprint(_ context.Context, _ string) ([]*domain.Task, error) {
+	return nil, nil
+}
+
+func (r *memoryTaskRepository) FindByPlatform(_ context.Context, _ string) ([]*domain.Task, error) {
+	return nil, nil
+}
+
+func (r *memoryTaskRepository) FindAll(_ context.Context) ([]*domain.Task, error) {
+	return nil, nil
+}
+
+func (r *memoryTaskRepository) Delete(_ context.Context, key string) error {
+	delete(r.tasks, key)
+	return nil
+}
+
+func (r *memoryTaskRepository) DeleteByProjectAndSprint(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func (r *memoryTaskRepository) UpdateLabels(_ context.Context, _ string, _ []string) error {
+	return nil
+}
+
+type fixedClassifier struct {
+	workType domain.WorkType
+	calls    int
+}
+
+func (c *fixedClassifier) ClassifyTask(_ *domain.Task) (domain.WorkType, error) {
+	c.calls++
+	return c.workType, nil
+}
+
+func (c *fixedClassifier) ClassifyTasks(_ []*domain.Task) (map[string]domain.WorkType, error) {
+	return nil, nil
+}
+
+func TestApplyIssueEvent_CreatesTask(t *testing.T) {
+	repo := newMemoryTaskRepository()
+	uc := NewApplyIssueEventUseCase(repo, nil)
+
+	err := uc.Execute(context.Background(), domain.IssueEventInput{
+		Event: domain.IssueEvent{Type: domain.IssueEventCreated, Task: &domain.Task{Key: "FN-1", Summary: "New"}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "New", repo.tasks["FN-1"].Summary)
+}
+
+func TestApplyIssueEvent_KeepsLocalWorkType(t *testing.T) {
+	repo := newMemoryTaskRepository(&domain.Task{Key: "FN-1", WorkType: domain.WorkTypeMaintenance, Version: 3})
+	classifier := &fixedClassifier{workType: domain.WorkTypeDevelopment}
+	uc := NewApplyIssueEventUseCase(repo, classifier)
+
+	err := uc.Execute(context.Background(), domain.IssueEventInput{
+		Event:      domain.IssueEvent{Type: domain.IssueEventUpdated, Task: &domain.Task{Key: "FN-1", Summary: "Renamed"}},
+		Reclassify: true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", repo.tasks["FN-1"].Summary)
+	assert.Equal(t, domain.WorkTypeMaintenance, repo.tasks["FN-1"].WorkType)
+	assert.Equal(t, 4, repo.tasks["FN-1"].Version)
+	assert.Equal(t, 0, classifier.calls)
+}
+
+func TestApplyIssueEvent_ReclassifiesUnclassifiedTask(t *testing.T) {
+	repo := newMemoryTaskRepository()
+	classifier := &fixedClassifier{workType: domain.WorkTypeDiscovery}
+	uc := NewApplyIssueEventUseCase(repo, classifier)
+
+	err := uc.Execute(context.Background(), domain.IssueEventInput{
+		Event:      domain.IssueEvent{Type: domain.IssueEventUpdated, Task: &domain.Task{Key: "FN-2"}},
+		Reclassify: true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.WorkTypeDiscovery, repo.tasks["FN-2"].WorkType)
+}
+
+func TestApplyIssueEvent_DeletesTask(t *testing.T) {
+	repo := newMemoryTaskRepository(&domain.Task{Key: "FN-1"})
+	uc := NewApplyIssueEventUseCase(repo, nil)
+
+	err := uc.Execute(context.Background(), domain.IssueEventInput{
+		Event: domain.IssueEvent{Type: domain.IssueEventDeleted, Task: &domain.Task{Key: "FN-1"}},
+	})
+
+	require.NoError(t, err)
+	assert.NotContains(t, repo.tasks, "FN-1")
+}
+
+func TestApplyIssueEvent_MissingTask(t *testing.T) {
+	uc := NewApplyIssueEventUseCase(newMemoryTaskRepository(), nil)
+	err := uc.Execute(context.Background(), domain.IssueEventInput{Event: domain.IssueEvent{Type: domain.IssueEventUpdated}})
+	assert.EqualError(t, err, "issue event has no task")
+}
+
+func TestApplyIssueEvent_ReclassifiesByAssetRule(t *testing.T) {
+	repo := newMemoryTaskRepository()
+	classifier := &fixedClassifier{workType: domain.WorkTypeMaintenance}
+	uc := NewApplyIssueEventUseCase(repo, classifier)
+
+	err := uc.Execute(context.Background(), domain.IssueEventInput{
+		Event: domain.IssueEvent{Type: domain.IssueEventCreated, Task: &domain.Task{
+			Key: "FN-3", Summary: "Pipeline backfill", Labels: []string{"cap-asset-data"},
+		}},
+		Reclassify: true,
+		Rules: domain.ClassificationRules{"cap-asset-data": {
+			Keywords: []domain.KeywordRule{{Keyword: "backfill", WorkType: domain.WorkTypeDevelopment}},
+		}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.WorkTypeDevelopment, repo.tasks["FN-3"].WorkType)
+	assert.Equal(t, 0, classifier.calls)
+}
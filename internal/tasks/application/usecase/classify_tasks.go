@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
 	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain/ports"
@@ -14,20 +15,23 @@ type ClassifyTasksUseCase struct {
 	remoteRepo ports.TaskRepository
 	classifier ports.TaskClassifier
 	userInput  ports.UserInput
+	epicRepo   ports.EpicMapRepository
 }
 
-// NewClassifyTasksUseCase creates a new instance of ClassifyTasksUseCase
+// NewClassifyTasksUseCase creates a new instance of ClassifyTasksUseCase. The epic map is optional.
 func NewClassifyTasksUseCase(
 	localRepo ports.TaskRepository,
 	remoteRepo ports.TaskRepository,
 	classifier ports.TaskClassifier,
 	userInput ports.UserInput,
+	epicRepo ports.EpicMapRepository,
 ) *ClassifyTasksUseCase {
 	return &ClassifyTasksUseCase{
 		localRepo:  localRepo,
 		remoteRepo: remoteRepo,
 		classifier: classifier,
 		userInput:  userInput,
+		epicRepo:   epicRepo,
 	}
 }
 
@@ -67,8 +71,17 @@ func (uc *ClassifyTasksUseCase) Execute(ctx context.Context, input domain.Classi
 		}
 	}
 
-	// Classify all tasks
-	workTypes, err := uc.classifier.ClassifyTasks(tasks)
+	// Children of mapped epics inherit their asset, so its rules apply to them
+	epicMap, err := loadEpicMap(ctx, uc.epicRepo)
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		epicMap.Apply(task)
+	}
+
+	// Classify all tasks, following the rules of their assets
+	workTypes, matched, err := classifyTasks(uc.classifier, tasks, input.Rules)
 	if err != nil {
 		return fmt.Errorf("failed to classify tasks: %w", err)
 	}
@@ -78,32 +91,141 @@ func (uc *ClassifyTasksUseCase) Execute(ctx context.Context, input domain.Classi
 		fmt.Println("\nPreview of task classifications:")
 		for _, task := range tasks {
 			workType := workTypes[task.Key]
+			if rule, ok := matched[task.Key]; ok {
+				fmt.Printf("- %s: %s (%s) [asset rule %q]\n", task.Key, workType, task.Summary, rule.Keyword)
+				continue
+			}
 			fmt.Printf("- %s: %s (%s)\n", task.Key, workType, task.Summary)
 		}
 		return nil
 	}
 
+	// Store why each task got its work type on the issue when the repository takes properties
+	properties, _ := uc.remoteRepo.(ports.IssuePropertyWriter)
+	classifiedAt := time.Now()
+
 	// Update tasks with their classifications
 	for _, task := range tasks {
 		workType := workTypes[task.Key]
 		if err := task.UpdateWorkType(workType); err != nil {
 			return fmt.Errorf("failed to update work type for task %s: %w", task.Key, err)
 		}
+		classification := explainClassification(uc.classifier, task, workType, matched, classifiedAt)
+		task.Classification = &classification
+
+		// Apply labels to Jira if requested, writing the asset label inherited from the epic too
+		if input.Apply {
+			current := task.Labels
+			if task.EpicAsset != "" {
+				current = append(append([]string{}, task.Labels...), task.EpicAsset)
+			}
+			labels, err := applyLabels(input, current, []string{string(workType)})
+			if err != nil {
+				return fmt.Errorf("failed to apply labels to task %s: %w", task.Key, err)
+			}
+			if err := uc.remoteRepo.UpdateLabels(ctx, task.Key, labels); err != nil {
+				return fmt.Errorf("failed to apply labels to task %s: %w", task.Key, err)
+			}
+			task.Labels = labels
+			epicMap.Apply(task)
+
+			if properties != nil {
+				if err := properties.SetIssueProperty(ctx, task.Key, domain.ClassificationPropertyKey, classification); err != nil {
+					return fmt.Errorf("failed to store classification of task %s: %w", task.Key, err)
+				}
+			}
+		}
 
 		// Save updated task locally
 		if err := uc.localRepo.Save(ctx, task); err != nil {
 			return fmt.Errorf("failed to save classified task %s: %w", task.Key, err)
 		}
+	}
 
-		// Apply labels to Jira if requested
-		if input.Apply {
-			if err := uc.remoteRepo.UpdateLabels(ctx, task.Key, []string{string(workType)}); err != nil {
-				return fmt.Errorf("failed to apply labels to task %s: %w", task.Key, err)
-			}
+	return nil
+}
+
+// classifyTasks classifies the tasks matching a keyword rule of their asset by that rule and
+// the others with the classifier, which is given the prompt additions of their assets when it
+// takes them. It returns the work type of each task and the rule deciding it, if any.
+func classifyTasks(classifier ports.TaskClassifier, tasks []*domain.Task, rules domain.ClassificationRules) (map[string]domain.WorkType, map[string]domain.KeywordRule, error) {
+	workTypes := make(map[string]domain.WorkType, len(tasks))
+	matched := make(map[string]domain.KeywordRule)
+	prompts := make(map[string]string)
+	var remaining []*domain.Task
+	for _, task := range tasks {
+		assetRules, ok := rules.For(task)
+		if rule, found := assetRules.Match(task); ok && found {
+			workTypes[task.Key] = rule.WorkType
+			matched[task.Key] = rule
+			continue
 		}
+		if assetRules.Prompt != "" {
+			prompts[task.Key] = assetRules.Prompt
+		}
+		remaining = append(remaining, task)
+	}
+	if len(remaining) == 0 {
+		return workTypes, matched, nil
 	}
 
-	return nil
+	var classified map[string]domain.WorkType
+	var err error
+	if prompted, ok := classifier.(ports.PromptedClassifier); ok && len(prompts) > 0 {
+		classified, err = prompted.ClassifyTasksWithPrompts(remaining, prompts)
+	} else {
+		classified, err = classifier.ClassifyTasks(remaining)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	for key, workType := range classified {
+		workTypes[key] = workType
+	}
+	return workTypes, matched, nil
+}
+
+// explainClassification records why a task got its work type: the asset rule that matched it,
+// or the classifier's own explanation when it gives one
+func explainClassification(classifier ports.TaskClassifier, task *domain.Task, workType domain.WorkType, matched map[string]domain.KeywordRule, classifiedAt time.Time) domain.Classification {
+	if rule, ok := matched[task.Key]; ok {
+		return domain.RuleClassification(rule, classifiedAt)
+	}
+	classification := domain.Classification{
+		WorkType:     workType,
+		Source:       domain.ClassificationSourceClassifier,
+		Rationale:    fmt.Sprintf("classified as %s by assetcap", workType),
+		ClassifiedAt: classifiedAt,
+	}
+	if explaining, ok := classifier.(ports.ExplainingClassifier); ok {
+		classification.Rationale, classification.Confidence = explaining.Explain(task, workType)
+	}
+	return classification
+}
+
+// classifyTask classifies a single task like classifyTasks
+func classifyTask(classifier ports.TaskClassifier, task *domain.Task, rules domain.ClassificationRules) (domain.WorkType, error) {
+	assetRules, ok := rules.For(task)
+	if rule, found := assetRules.Match(task); ok && found {
+		return rule.WorkType, nil
+	}
+	if prompted, isPrompted := classifier.(ports.PromptedClassifier); isPrompted && assetRules.Prompt != "" {
+		workTypes, err := prompted.ClassifyTasksWithPrompts([]*domain.Task{task}, map[string]string{task.Key: assetRules.Prompt})
+		if err != nil {
+			return "", err
+		}
+		return workTypes[task.Key], nil
+	}
+	return classifier.ClassifyTask(task)
+}
+
+// applyLabels returns the full label set written to an issue carrying current, merging the
+// classification labels unless all labels are replaced
+func applyLabels(input domain.ClassifyTasksInput, current, labels []string) ([]string, error) {
+	if input.ReplaceAll {
+		return input.LabelPolicy.Replace(current, labels), nil
+	}
+	return input.LabelPolicy.Merge(current, labels)
 }
 
 // GetTasks retrieves tasks for a project and sprint
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
 )
@@ -210,6 +212,65 @@ func TestClassifyTasksUseCase_Execute(t *testing.T) {
 				remoteRepo.On("UpdateLabels", ctx, "TEST-4", []string{"cap-development"}).Return(nil)
 			},
 		},
+		{
+			name: "merge applied labels with existing labels",
+			input: domain.ClassifyTasksInput{
+				Project:     testProject,
+				Sprint:      testSprint,
+				Apply:       true,
+				LabelPolicy: domain.LabelPolicy{Protected: []string{"cap-maintenance"}},
+			},
+			expectedCalls: func(localRepo, remoteRepo *MockTaskRepository, classifier *MockTaskClassifier, _ *MockUserInput) {
+				localRepo.On("FindByProjectAndSprint", ctx, "TEST", "Sprint 1").Return([]*domain.Task{
+					{Key: "TEST-1", Summary: "Task 1", Labels: []string{"team-a", "cap-discovery", "cap-maintenance"}},
+				}, nil)
+				classifier.On("ClassifyTasks", mock.Anything).Return(map[string]domain.WorkType{
+					"TEST-1": domain.WorkTypeDevelopment,
+				}, nil)
+				remoteRepo.On("UpdateLabels", ctx, "TEST-1", []string{"team-a", "cap-maintenance", "cap-development"}).Return(nil)
+				localRepo.On("Save", ctx, mock.MatchedBy(func(task *domain.Task) bool {
+					return len(task.Labels) == 3 && task.Labels[2] == "cap-development"
+				})).Return(nil)
+			},
+		},
+		{
+			name: "replace all labels keeps protected labels",
+			input: domain.ClassifyTasksInput{
+				Project:     testProject,
+				Sprint:      testSprint,
+				Apply:       true,
+				ReplaceAll:  true,
+				LabelPolicy: domain.LabelPolicy{Protected: []string{"security"}},
+			},
+			expectedCalls: func(localRepo, remoteRepo *MockTaskRepository, classifier *MockTaskClassifier, _ *MockUserInput) {
+				localRepo.On("FindByProjectAndSprint", ctx, "TEST", "Sprint 1").Return([]*domain.Task{
+					{Key: "TEST-1", Summary: "Task 1", Labels: []string{"team-a", "security"}},
+				}, nil)
+				classifier.On("ClassifyTasks", mock.Anything).Return(map[string]domain.WorkType{
+					"TEST-1": domain.WorkTypeDevelopment,
+				}, nil)
+				remoteRepo.On("UpdateLabels", ctx, "TEST-1", []string{"security", "cap-development"}).Return(nil)
+				localRepo.On("Save", ctx, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name: "reject labels outside the managed taxonomy",
+			input: domain.ClassifyTasksInput{
+				Project:     testProject,
+				Sprint:      testSprint,
+				Apply:       true,
+				LabelPolicy: domain.LabelPolicy{Managed: []string{"cap-maintenance"}},
+			},
+			expectedError: true,
+			expectedCalls: func(localRepo, _ *MockTaskRepository, classifier *MockTaskClassifier, _ *MockUserInput) {
+				localRepo.On("FindByProjectAndSprint", ctx, "TEST", "Sprint 1").Return([]*domain.Task{
+					{Key: "TEST-1", Summary: "Task 1"},
+				}, nil)
+				classifier.On("ClassifyTasks", mock.Anything).Return(map[string]domain.WorkType{
+					"TEST-1": domain.WorkTypeDevelopment,
+				}, nil)
+			},
+		},
 		{
 			name: "dry run classification",
 			input: domain.ClassifyTasksInput{
@@ -253,7 +314,7 @@ func TestClassifyTasksUseCase_Execute(t *testing.T) {
 			tt.expectedCalls(localRepo, remoteRepo, classifier, userInput)
 
 			// Create use case
-			uc := NewClassifyTasksUseCase(localRepo, remoteRepo, classifier, userInput)
+			uc := NewClassifyTasksUseCase(localRepo, remoteRepo, classifier, userInput, nil)
 
 			// Execute use case
 			err := uc.Execute(ctx, tt.input)
@@ -283,7 +344,7 @@ func TestGetTasks(t *testing.T) {
 		mockUserInput := new(MockUserInput)
 
 		// Create use case
-		uc := NewClassifyTasksUseCase(mockLocalRepo, mockRemoteRepo, mockClassifier, mockUserInput)
+		uc := NewClassifyTasksUseCase(mockLocalRepo, mockRemoteRepo, mockClassifier, mockUserInput, nil)
 
 		// Arrange
 		project := testProject
@@ -318,7 +379,7 @@ func TestGetTasks(t *testing.T) {
 		mockUserInput := new(MockUserInput)
 
 		// Create use case
-		uc := NewClassifyTasksUseCase(mockLocalRepo, mockRemoteRepo, mockClassifier, mockUserInput)
+		uc := NewClassifyTasksUseCase(mockLocalRepo, mockRemoteRepo, mockClassifier, mockUserInput, nil)
 
 		// Arrange
 		project := testProject
@@ -357,7 +418,7 @@ func TestGetTasks(t *testing.T) {
 		mockUserInput := new(MockUserInput)
 
 		// Create use case
-		uc := NewClassifyTasksUseCase(mockLocalRepo, mockRemoteRepo, mockClassifier, mockUserInput)
+		uc := NewClassifyTasksUseCase(mockLocalRepo, mockRemoteRepo, mockClassifier, mockUserInput, nil)
 
 		// Arrange
 		project := testProject
@@ -385,7 +446,7 @@ func TestGetTasks(t *testing.T) {
 		mockUserInput := new(MockUserInput)
 
 		// Create use case
-		uc := NewClassifyTasksUseCase(mockLocalRepo, mockRemoteRepo, mockClassifier, mockUserInput)
+		uc := NewClassifyTasksUseCase(mockLocalRepo, mockRemoteRepo, mockClassifier, mockUserInput, nil)
 
 		// Arrange
 		project := testProject
@@ -415,7 +476,7 @@ func TestGetTasks(t *testing.T) {
 		mockUserInput := new(MockUserInput)
 
 		// Create use case
-		uc := NewClassifyTasksUseCase(mockLocalRepo, mockRemoteRepo, mockClassifier, mockUserInput)
+		uc := NewClassifyTasksUseCase(mockLocalRepo, mockRemoteRepo, mockClassifier, mockUserInput, nil)
 
 		// Arrange
 		project := testProject
@@ -447,3 +508,191 @@ func TestGetTasks(t *testing.T) {
 		mockRemoteRepo.AssertExpectations(t)
 	})
 }
+
+// promptedClassifier classifies every task as workType and records the prompts it was given
+type promptedClassifier struct {
+	fixedClassifier
+	prompts map[string]string
+}
+
+func (c *promptedClassifier) ClassifyTasks(tasks []*domain.Task) (map[string]domain.WorkType, error) {
+	return c.ClassifyTasksWithPrompts(tasks, nil)
+}
+
+func (c *promptedClassifier) ClassifyTasksWithPrompts(tasks []*domain.Task, prompts map[string]string) (map[string]domain.WorkType, error) {
+	c.prompts = prompts
+	workTypes := make(map[string]domain.WorkType, len(tasks))
+	for _, task := range tasks {
+		workTypes[task.Key] = c.workType
+	}
+	return workTypes, nil
+}
+
+func TestClassifyTasks_AssetRules(t *testing.T) {
+	rules := domain.ClassificationRules{
+		"cap-asset-data": {
+			Asset:    "Data Platform",
+			Keywords: []domain.KeywordRule{{Keyword: "pipeline backfill", WorkType: domain.WorkTypeDevelopment}},
+			Prompt:   "Schema migrations are development",
+		},
+	}
+	tasks := []*domain.Task{
+		{Key: "FN-1", Summary: "Run the Pipeline Backfill for March", Labels: []string{"cap-asset-data"}},
+		{Key: "FN-2", Summary: "Fix flaky export", Labels: []string{"cap-asset-data"}},
+		{Key: "FN-3", Summary: "Pipeline backfill elsewhere", Labels: []string{"cap-asset-web"}},
+	}
+	classifier := &promptedClassifier{fixedClassifier: fixedClassifier{workType: domain.WorkTypeMaintenance}}
+
+	workTypes, matched, err := classifyTasks(classifier, tasks, rules)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]domain.WorkType{
+		"FN-1": domain.WorkTypeDevelopment,
+		"FN-2": domain.WorkTypeMaintenance,
+		"FN-3": domain.WorkTypeMaintenance,
+	}, workTypes)
+	assert.Equal(t, map[string]domain.KeywordRule{"FN-1": rules["cap-asset-data"].Keywords[0]}, matched)
+	assert.Equal(t, map[string]string{"FN-2": "Schema migrations are development"}, classifier.prompts)
+
+	workType, err := classifyTask(classifier, tasks[0], rules)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.WorkTypeDevelopment, workType)
+}
+
+// stubEpicMap is an EpicMapRepository holding fixed mappings
+type stubEpicMap []domain.EpicMapping
+
+func (s stubEpicMap) SaveEpicMapping(_ context.Context, _ domain.EpicMapping) error { return nil }
+func (s stubEpicMap) DeleteEpicMapping(_ context.Context, _ string) error           { return nil }
+func (s stubEpicMap) FindEpicMappings(_ context.Context) ([]domain.EpicMapping, error) {
+	return s, nil
+}
+
+func TestClassifyTasksUseCase_EpicMap(t *testing.T) {
+	ctx := context.Background()
+	epicMap := stubEpicMap{{EpicKey: "TEST-100", Asset: "Booking", Label: "cap-asset-booking"}}
+	localRepo := new(MockTaskRepository)
+	remoteRepo := new(MockTaskRepository)
+	classifier := new(MockTaskClassifier)
+	localRepo.On("FindByProjectAndSprint", ctx, "TEST", "Sprint 1").Return([]*domain.Task{
+		{Key: "TEST-1", Summary: "Task 1", Epic: "TEST-100", Labels: []string{"frontend"}},
+		{Key: "TEST-2", Summary: "Task 2", Epic: "TEST-200"},
+	}, nil)
+	classifier.On("ClassifyTasks", mock.Anything).Return(map[string]domain.WorkType{
+		"TEST-1": domain.WorkTypeDevelopment,
+		"TEST-2": domain.WorkTypeMaintenance,
+	}, nil)
+	remoteRepo.On("UpdateLabels", ctx, "TEST-1", []string{"frontend", "cap-asset-booking", "cap-development"}).Return(nil)
+	remoteRepo.On("UpdateLabels", ctx, "TEST-2", []string{"cap-maintenance"}).Return(nil)
+	var saved []*domain.Task
+	localRepo.On("Save", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		saved = append(saved, args.Get(1).(*domain.Task))
+	}).Return(nil)
+
+	err := NewClassifyTasksUseCase(localRepo, remoteRepo, classifier, new(MockUserInput), epicMap).Execute(ctx, domain.ClassifyTasksInput{
+		Project: testProject,
+		Sprint:  testSprint,
+		Apply:   true,
+	})
+
+	assert.NoError(t, err)
+	remoteRepo.AssertExpectations(t)
+	assert.Len(t, saved, 2)
+	assert.Empty(t, saved[0].EpicAsset, "the inherited label is on the issue once written")
+	assert.Equal(t, []string{"cap-asset-booking"}, saved[0].AssetLabels())
+}
+
+// propertyRepository is a remote repository recording the issue properties written
+type propertyRepository struct {
+	*MockTaskRepository
+	properties map[string]any
+}
+
+func (r *propertyRepository) SetIssueProperty(_ context.Context, issueKey, key string, value any) error {
+	r.properties[issueKey+"/"+key] = value
+	return nil
+}
+
+// explainingClassifier is a promptedClassifier that explains its work types
+type explainingClassifier struct {
+	promptedClassifier
+}
+
+func (c *explainingClassifier) Explain(_ *domain.Task, workType domain.WorkType) (string, float64) {
+	return fmt.Sprintf("looks like %s", workType), 0.8
+}
+
+func TestClassifyTasksUseCase_StoresClassificationProperties(t *testing.T) {
+	ctx := context.Background()
+	rule := domain.KeywordRule{Keyword: "backfill", WorkType: domain.WorkTypeDevelopment}
+	localRepo := new(MockTaskRepository)
+	remoteRepo := &propertyRepository{MockTaskRepository: new(MockTaskRepository), properties: map[string]any{}}
+	localRepo.On("FindByProjectAndSprint", ctx, testProject, testSprint).Return([]*domain.Task{
+		{Key: "TEST-1", Summary: "Run the backfill", Labels: []string{"cap-asset-data"}},
+		{Key: "TEST-2", Summary: "Fix flaky export"},
+	}, nil)
+	localRepo.On("Save", ctx, mock.Anything).Return(nil)
+	remoteRepo.On("UpdateLabels", ctx, "TEST-1", []string{"cap-asset-data", "cap-development"}).Return(nil)
+	remoteRepo.On("UpdateLabels", ctx, "TEST-2", []string{"cap-maintenance"}).Return(nil)
+	classifier := &explainingClassifier{promptedClassifier{fixedClassifier: fixedClassifier{workType: domain.WorkTypeMaintenance}}}
+
+	err := NewClassifyTasksUseCase(localRepo, remoteRepo, classifier, new(MockUserInput), nil).Execute(ctx, domain.ClassifyTasksInput{
+		Project: testProject,
+		Sprint:  testSprint,
+		Apply:   true,
+		Rules:   domain.ClassificationRules{"cap-asset-data": {Asset: "Data Platform", Keywords: []domain.KeywordRule{rule}}},
+	})
+
+	assert.NoError(t, err)
+	require.Len(t, remoteRepo.properties, 2)
+	byRule := remoteRepo.properties["TEST-1/assetcap.classification"].(domain.Classification)
+	assert.Equal(t, domain.ClassificationSourceAssetRule, byRule.Source)
+	assert.Equal(t, "backfill", byRule.Rule)
+	assert.Equal(t, 1.0, byRule.Confidence)
+	byClassifier := remoteRepo.properties["TEST-2/assetcap.classification"].(domain.Classification)
+	assert.Equal(t, domain.Classification{
+		WorkType:     domain.WorkTypeMaintenance,
+		Source:       domain.ClassificationSourceClassifier,
+		Rationale:    "looks like cap-maintenance",
+		Confidence:   0.8,
+		ClassifiedAt: byRule.ClassifiedAt,
+	}, byClassifier)
+}
+
+func TestClassifyTasksUseCase_SavesClassificationLocally(t *testing.T) {
+	ctx := context.Background()
+	localRepo := new(MockTaskRepository)
+	remoteRepo := new(MockTaskRepository)
+	localRepo.On("FindByProjectAndSprint", ctx, testProject, testSprint).Return([]*domain.Task{
+		{Key: "TEST-1", Summary: "Fix flaky export"},
+	}, nil)
+	var saved *domain.Task
+	localRepo.On("Save", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(1).(*domain.Task)
+	}).Return(nil)
+	classifier := &explainingClassifier{promptedClassifier{fixedClassifier: fixedClassifier{workType: domain.WorkTypeMaintenance}}}
+
+	err := NewClassifyTasksUseCase(localRepo, remoteRepo, classifier, new(MockUserInput), nil).Execute(ctx, domain.ClassifyTasksInput{
+		Project: testProject,
+		Sprint:  testSprint,
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, saved.Classification, "the classification is kept without --apply")
+	assert.Equal(t, domain.WorkTypeMaintenance, saved.Classification.WorkType)
+	assert.Equal(t, "looks like cap-maintenance", saved.Classification.Rationale)
+	assert.Equal(t, 0.8, saved.Classification.Confidence)
+	remoteRepo.AssertNotCalled(t, "UpdateLabels", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestExplainClassification(t *testing.T) {
+	at := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	classification := explainClassification(&fixedClassifier{}, &domain.Task{Key: "TEST-1"}, domain.WorkTypeDiscovery, nil, at)
+
+	assert.Equal(t, domain.Classification{
+		WorkType:     domain.WorkTypeDiscovery,
+		Source:       domain.ClassificationSourceClassifier,
+		Rationale:    "classified as cap-discovery by assetcap",
+		ClassifiedAt: at,
+	}, classification, "classifiers without explanations give no confidence")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
 	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain/ports"
 )
 
@@ -11,13 +12,15 @@ import (
 type FetchTasksUseCase struct {
 	remoteRepo ports.TaskRepository
 	localRepo  ports.TaskRepository
+	epicRepo   ports.EpicMapRepository
 }
 
-// NewFetchTasksUseCase creates a new fetch tasks use case
-func NewFetchTasksUseCase(remoteRepo, localRepo ports.TaskRepository) *FetchTasksUseCase {
+// NewFetchTasksUseCase creates a new fetch tasks use case. The epic map is optional.
+func NewFetchTasksUseCase(remoteRepo, localRepo ports.TaskRepository, epicRepo ports.EpicMapRepository) *FetchTasksUseCase {
 	return &FetchTasksUseCase{
 		remoteRepo: remoteRepo,
 		localRepo:  localRepo,
+		epicRepo:   epicRepo,
 	}
 }
 
@@ -37,8 +40,73 @@ func (u *FetchTasksUseCase) Execute(ctx context.Context, project, sprint, platfo
 		return fmt.Errorf("failed to fetch tasks: %w", err)
 	}
 
+	return u.save(ctx, tasks)
+}
+
+// ExecuteByFixVersion fetches the tasks of a given project and fix version
+func (u *FetchTasksUseCase) ExecuteByFixVersion(ctx context.Context, project, fixVersion, platform string) error {
+	if project == "" {
+		return fmt.Errorf("project is required")
+	}
+
+	if fixVersion == "" {
+		return fmt.Errorf("fix version is required")
+	}
+
+	if platform == "" {
+		return fmt.Errorf("platform is required")
+	}
+
+	finder, ok := u.remoteRepo.(ports.TaskReleaseFinder)
+	if !ok {
+		return fmt.Errorf("platform %s does not support fetching tasks by fix version", platform)
+	}
+
+	tasks, err := finder.FindByProjectAndFixVersion(ctx, project, fixVersion)
+	if err != nil {
+		return fmt.Errorf("failed to fetch tasks: %w", err)
+	}
+
+	return u.save(ctx, tasks)
+}
+
+// ExecuteImport stores tasks read outside of the platform, such as from a Jira export, keeping
+// those of the project when one is given
+func (u *FetchTasksUseCase) ExecuteImport(ctx context.Context, tasks []*domain.Task, project string) error {
+	if project == "" {
+		return u.save(ctx, tasks)
+	}
+	var projectTasks []*domain.Task
+	for _, task := range tasks {
+		if task.Project == project {
+			projectTasks = append(projectTasks, task)
+		}
+	}
+	return u.save(ctx, projectTasks)
+}
+
+// save stores the fetched tasks locally and lists them. The platform knows nothing of the
+// code references linked to the tasks, so those of the stored tasks are kept, and label
+// changes recorded earlier are kept alongside the ones fetched. Tasks of mapped epics
+// inherit the asset of their epic.
+func (u *FetchTasksUseCase) save(ctx context.Context, tasks []*domain.Task) error {
+	epicMap, err := loadEpicMap(ctx, u.epicRepo)
+	if err != nil {
+		return err
+	}
+
 	// Save tasks to local storage
+	inherited := 0
 	for _, task := range tasks {
+		if epicMap.Apply(task) {
+			inherited++
+		}
+		if existing, err := u.localRepo.FindByKey(ctx, task.Key); err == nil && existing != nil {
+			if len(task.CodeReferences) == 0 {
+				task.CodeReferences = existing.CodeReferences
+			}
+			task.LabelHistory = domain.MergeLabelHistory(existing.LabelHistory, task.LabelHistory)
+		}
 		if err := u.localRepo.Save(ctx, task); err != nil {
 			return fmt.Errorf("failed to save task %s: %w", task.Key, err)
 		}
@@ -46,6 +114,9 @@ func (u *FetchTasksUseCase) Execute(ctx context.Context, project, sprint, platfo
 
 	// Display tasks
 	fmt.Printf("Found and saved %d tasks\n", len(tasks))
+	if inherited > 0 {
+		fmt.Printf("%d tasks inherit the asset of their epic\n", inherited)
+	}
 	for _, task := range tasks {
 		sprintInfo := ""
 		if task.Sprint != "" {
@@ -56,3 +127,15 @@ func (u *FetchTasksUseCase) Execute(ctx context.Context, project, sprint, platfo
 
 	return nil
 }
+
+// loadEpicMap loads the epic mappings, or none when no epic map is configured
+func loadEpicMap(ctx context.Context, epicRepo ports.EpicMapRepository) (domain.EpicMap, error) {
+	if epicRepo == nil {
+		return nil, nil
+	}
+	mappings, err := epicRepo.FindEpicMappings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load epic map: %w", err)
+	}
+	return domain.NewEpicMap(mappings), nil
+}
@@ -17,7 +17,7 @@ func TestFetchTasksUseCase(t *testing.T) {
 	// Create mock repositories
 	remoteRepo := testutil.NewMockTaskRepository()
 	localRepo := testutil.NewMockTaskRepository()
-	useCase := NewFetchTasksUseCase(remoteRepo, localRepo)
+	useCase := NewFetchTasksUseCase(remoteRepo, localRepo, nil)
 
 	// Create test tasks
 	now := time.Now()
@@ -134,3 +134,135 @@ func TestFetchTasksUseCase(t *testing.T) {
 		})
 	}
 }
+
+// releaseTaskRepository is a mock remote repository that can find tasks by fix version
+type releaseTaskRepository struct {
+	*testutil.MockTaskRepository
+	findByFixVersion func(ctx context.Context, project, fixVersion string) ([]*domain.Task, error)
+}
+
+func (r *releaseTaskRepository) FindByProjectAndFixVersion(ctx context.Context, project, fixVersion string) ([]*domain.Task, error) {
+	return r.findByFixVersion(ctx, project, fixVersion)
+}
+
+func TestFetchTasksUseCase_ExecuteByFixVersion(t *testing.T) {
+	task := &domain.Task{Key: "TEST-1", Summary: "Release work", Project: "TEST", Sprint: "2024.5", FixVersions: []string{"2024.5"}}
+	remoteRepo := &releaseTaskRepository{
+		MockTaskRepository: testutil.NewMockTaskRepository(),
+		findByFixVersion: func(_ context.Context, project, fixVersion string) ([]*domain.Task, error) {
+			assert.Equal(t, "TEST", project)
+			assert.Equal(t, "2024.5", fixVersion)
+			return []*domain.Task{task}, nil
+		},
+	}
+	localRepo := testutil.NewMockTaskRepository()
+	var saved []string
+	localRepo.SetSaveFunc(func(_ context.Context, task *domain.Task) error {
+		saved = append(saved, task.Key)
+		return nil
+	})
+
+	err := NewFetchTasksUseCase(remoteRepo, localRepo, nil).ExecuteByFixVersion(context.Background(), "TEST", "2024.5", "jira")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"TEST-1"}, saved)
+
+	err = NewFetchTasksUseCase(remoteRepo, localRepo, nil).ExecuteByFixVersion(context.Background(), "TEST", "", "jira")
+	assert.EqualError(t, err, "fix version is required")
+
+	remoteRepo.findByFixVersion = func(_ context.Context, _, _ string) ([]*domain.Task, error) {
+		return nil, errors.New("not found")
+	}
+	err = NewFetchTasksUseCase(remoteRepo, localRepo, nil).ExecuteByFixVersion(context.Background(), "TEST", "2024.5", "jira")
+	assert.EqualError(t, err, "failed to fetch tasks: not found")
+
+	err = NewFetchTasksUseCase(testutil.NewMockTaskRepository(), localRepo, nil).ExecuteByFixVersion(context.Background(), "TEST", "2024.5", "jira")
+	assert.EqualError(t, err, "platform jira does not support fetching tasks by fix version")
+}
+
+func TestFetchTasksUseCase_KeepsCodeReferences(t *testing.T) {
+	references := []domain.CodeReference{{Kind: domain.CodeReferenceCommit, Repository: "acme/api", ID: "a1b2c3"}}
+	remoteRepo := testutil.NewMockTaskRepository()
+	remoteRepo.SetFindByProjectAndSprintFunc(func(_ context.Context, _, _ string) ([]*domain.Task, error) {
+		return []*domain.Task{{Key: "TEST-1", Summary: "Refetched"}, {Key: "TEST-2", Summary: "New"}}, nil
+	})
+	localRepo := testutil.NewMockTaskRepository()
+	localRepo.SetFindByKeyFunc(func(_ context.Context, key string) (*domain.Task, error) {
+		if key != "TEST-1" {
+			return nil, errors.New("task not found")
+		}
+		return &domain.Task{Key: key, CodeReferences: references}, nil
+	})
+	saved := make(map[string]*domain.Task)
+	localRepo.SetSaveFunc(func(_ context.Context, task *domain.Task) error {
+		saved[task.Key] = task
+		return nil
+	})
+
+	require.NoError(t, NewFetchTasksUseCase(remoteRepo, localRepo, nil).Execute(context.Background(), "TEST", "Sprint 1", "jira"))
+	assert.Equal(t, references, saved["TEST-1"].CodeReferences)
+	assert.Empty(t, saved["TEST-2"].CodeReferences)
+}
+
+func TestFetchTasksUseCase_MergesLabelHistory(t *testing.T) {
+	recorded := domain.LabelChange{Label: "cap-development", Action: domain.LabelAdded, Author: "Jane Doe", At: time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)}
+	fetched := domain.LabelChange{Label: "cap-development", Action: domain.LabelRemoved, Author: "John Roe", At: time.Date(2024, 3, 8, 9, 0, 0, 0, time.UTC)}
+	remoteRepo := testutil.NewMockTaskRepository()
+	remoteRepo.SetFindByProjectAndSprintFunc(func(_ context.Context, _, _ string) ([]*domain.Task, error) {
+		return []*domain.Task{{Key: "TEST-1", Summary: "Refetched", LabelHistory: []domain.LabelChange{fetched}}}, nil
+	})
+	localRepo := testutil.NewMockTaskRepository()
+	localRepo.SetFindByKeyFunc(func(_ context.Context, key string) (*domain.Task, error) {
+		return &domain.Task{Key: key, LabelHistory: []domain.LabelChange{recorded, fetched}}, nil
+	})
+	saved := make(map[string]*domain.Task)
+	localRepo.SetSaveFunc(func(_ context.Context, task *domain.Task) error {
+		saved[task.Key] = task
+		return nil
+	})
+
+	require.NoError(t, NewFetchTasksUseCase(remoteRepo, localRepo, nil).Execute(context.Background(), "TEST", "Sprint 1", "jira"))
+	assert.Equal(t, []domain.LabelChange{recorded, fetched}, saved["TEST-1"].LabelHistory)
+}
+
+func TestFetchTasksUseCase_AppliesEpicMap(t *testing.T) {
+	remoteRepo := testutil.NewMockTaskRepository()
+	remoteRepo.SetFindByProjectAndSprintFunc(func(_ context.Context, _, _ string) ([]*domain.Task, error) {
+		return []*domain.Task{
+			{Key: "TEST-1", Summary: "Child", Epic: "TEST-100"},
+			{Key: "TEST-2", Summary: "Linked child", Epic: "TEST-100", Labels: []string{"cap-asset-search"}},
+		}, nil
+	})
+	localRepo := testutil.NewMockTaskRepository()
+	saved := make(map[string]*domain.Task)
+	localRepo.SetSaveFunc(func(_ context.Context, task *domain.Task) error {
+		saved[task.Key] = task
+		return nil
+	})
+	epicMap := stubEpicMap{{EpicKey: "TEST-100", Asset: "Booking", Label: "cap-asset-booking"}}
+
+	require.NoError(t, NewFetchTasksUseCase(remoteRepo, localRepo, epicMap).Execute(context.Background(), "TEST", "Sprint 1", "jira"))
+	assert.Equal(t, "cap-asset-booking", saved["TEST-1"].EpicAsset)
+	assert.Empty(t, saved["TEST-1"].Labels)
+	assert.Empty(t, saved["TEST-2"].EpicAsset)
+}
+
+func TestFetchTasksUseCase_ExecuteImport(t *testing.T) {
+	localRepo := testutil.NewMockTaskRepository()
+	saved := make(map[string]*domain.Task)
+	localRepo.SetSaveFunc(func(_ context.Context, task *domain.Task) error {
+		saved[task.Key] = task
+		return nil
+	})
+	useCase := NewFetchTasksUseCase(nil, localRepo, nil)
+	tasks := []*domain.Task{
+		{Key: "TEST-1", Summary: "Exported", Project: "TEST"},
+		{Key: "OPS-1", Summary: "Other project", Project: "OPS"},
+	}
+
+	require.NoError(t, useCase.ExecuteImport(context.Background(), tasks, "TEST"))
+	assert.Contains(t, saved, "TEST-1")
+	assert.NotContains(t, saved, "OPS-1")
+
+	require.NoError(t, useCase.ExecuteImport(context.Background(), tasks, ""))
+	assert.Contains(t, saved, "OPS-1", "every task is imported without a project")
+}
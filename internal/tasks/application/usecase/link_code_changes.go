@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain/ports"
+)
+
+// LinkCodeChangesUseCase links the commits and pull requests mentioning the keys of stored
+// tasks to those tasks
+type LinkCodeChangesUseCase struct {
+	localRepo ports.TaskRepository
+	codeHost  ports.CodeHost
+}
+
+// NewLinkCodeChangesUseCase creates a new instance of LinkCodeChangesUseCase. The code host
+// is optional; linking fails without one.
+func NewLinkCodeChangesUseCase(localRepo ports.TaskRepository, codeHost ports.CodeHost) *LinkCodeChangesUseCase {
+	return &LinkCodeChangesUseCase{
+		localRepo: localRepo,
+		codeHost:  codeHost,
+	}
+}
+
+// Execute looks up the code changes made since the first of the sprint's tasks was created,
+// stores the references found on each task and summarizes them
+func (uc *LinkCodeChangesUseCase) Execute(ctx context.Context, input domain.CodeLinkInput) (*domain.CodeLinkResult, error) {
+	if input.Project == "" {
+		return nil, fmt.Errorf("project is required")
+	}
+	if input.Sprint == "" {
+		return nil, fmt.Errorf("sprint is required")
+	}
+	if uc.codeHost == nil {
+		return nil, domain.ErrNoCodeHost
+	}
+
+	tasks, err := uc.localRepo.FindByProjectAndSprint(ctx, input.Project, input.Sprint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find tasks: %w", err)
+	}
+	if len(tasks) == 0 {
+		return nil, fmt.Errorf("no stored tasks for project %s and sprint %s; run tasks fetch first", input.Project, input.Sprint)
+	}
+
+	changes, err := uc.codeHost.ListChanges(ctx, earliestCreation(tasks))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list code changes: %w", err)
+	}
+	references := domain.MatchCodeChanges(changes)
+
+	updated := 0
+	for _, task := range tasks {
+		if !task.SetCodeReferences(references[task.Key]) {
+			continue
+		}
+		if err := uc.localRepo.Save(ctx, task); err != nil {
+			return nil, fmt.Errorf("failed to save task %s: %w", task.Key, err)
+		}
+		updated++
+	}
+
+	result := domain.SummarizeCodeLinks(input.Project, input.Sprint, tasks)
+	result.Updated = updated
+	return &result, nil
+}
+
+// earliestCreation returns when the first of the tasks was created
+func earliestCreation(tasks []*domain.Task) time.Time {
+	earliest := tasks[0].CreatedAt
+	for _, task := range tasks[1:] {
+		if task.CreatedAt.Before(earliest) {
+			earliest = task.CreatedAt
+		}
+	}
+	return earliest
+}
@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/application/usecase/testutil"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+type stubCodeHost struct {
+	changes []domain.CodeChange
+	err     error
+	since   time.Time
+}
+
+func (h *stubCodeHost) ListChanges(_ context.Context, since time.Time) ([]domain.CodeChange, error) {
+	h.since = since
+	return h.changes, h.err
+}
+
+func commitChange(id, message string, date time.Time) domain.CodeChange {
+	return domain.CodeChange{
+		Reference: domain.CodeReference{Kind: domain.CodeReferenceCommit, Repository: "acme/api", ID: id, Title: message, Date: date},
+		Text:      message,
+	}
+}
+
+func TestLinkCodeChanges_Execute(t *testing.T) {
+	created := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	tasks := []*domain.Task{
+		{Key: "FN-1", WorkType: domain.WorkTypeDevelopment, CreatedAt: created.AddDate(0, 0, 2)},
+		{Key: "FN-2", WorkType: domain.WorkTypeDevelopment, CreatedAt: created},
+		{Key: "FN-3", WorkType: domain.WorkTypeMaintenance, CreatedAt: created.AddDate(0, 0, 1)},
+	}
+	repo := testutil.NewMockTaskRepository()
+	repo.SetFindByProjectAndSprintFunc(func(_ context.Context, project, sprint string) ([]*domain.Task, error) {
+		assert.Equal(t, "FN", project)
+		assert.Equal(t, "Penguins", sprint)
+		return tasks, nil
+	})
+	var saved []string
+	repo.SetSaveFunc(func(_ context.Context, task *domain.Task) error {
+		saved = append(saved, task.Key)
+		return nil
+	})
+	host := &stubCodeHost{changes: []domain.CodeChange{
+		commitChange("a1", "FN-1: add ledger export", created.AddDate(0, 0, 3)),
+		{
+			Reference: domain.CodeReference{Kind: domain.CodeReferencePullRequest, Repository: "acme/api", ID: "12", Date: created.AddDate(0, 0, 4)},
+			Text:      "Ledger export\nfeature/FN-1-ledger\n",
+		},
+		commitChange("b2", "Fix flaky test for FN-3", created.AddDate(0, 0, 5)),
+		commitChange("c3", "Bump dependencies", created.AddDate(0, 0, 5)),
+	}}
+	uc := NewLinkCodeChangesUseCase(repo, host)
+
+	result, err := uc.Execute(context.Background(), domain.CodeLinkInput{Project: "FN", Sprint: "Penguins"})
+	require.NoError(t, err)
+	assert.Equal(t, created, host.since, "changes are listed from the first task's creation")
+	assert.Equal(t, []string{"FN-1", "FN-3"}, saved)
+	assert.Equal(t, &domain.CodeLinkResult{
+		Project: "FN", Sprint: "Penguins", Tasks: 3, Linked: 2, Commits: 2, PullRequests: 1,
+		WithoutCode: []string{"FN-2"}, Updated: 2,
+	}, result)
+
+	// Linking again finds nothing new to save
+	saved = nil
+	result, err = uc.Execute(context.Background(), domain.CodeLinkInput{Project: "FN", Sprint: "Penguins"})
+	require.NoError(t, err)
+	assert.Empty(t, saved)
+	assert.Zero(t, result.Updated)
+}
+
+func TestLinkCodeChanges_Errors(t *testing.T) {
+	repo := testutil.NewMockTaskRepository()
+	input := domain.CodeLinkInput{Project: "FN", Sprint: "Penguins"}
+
+	_, err := NewLinkCodeChangesUseCase(repo, &stubCodeHost{}).Execute(context.Background(), domain.CodeLinkInput{Sprint: "Penguins"})
+	assert.EqualError(t, err, "project is required")
+
+	_, err = NewLinkCodeChangesUseCase(repo, &stubCodeHost{}).Execute(context.Background(), domain.CodeLinkInput{Project: "FN"})
+	assert.EqualError(t, err, "sprint is required")
+
+	_, err = NewLinkCodeChangesUseCase(repo, nil).Execute(context.Background(), input)
+	assert.ErrorIs(t, err, domain.ErrNoCodeHost)
+
+	repo.SetFindByProjectAndSprintFunc(func(_ context.Context, _, _ string) ([]*domain.Task, error) {
+		return nil, nil
+	})
+	_, err = NewLinkCodeChangesUseCase(repo, &stubCodeHost{}).Execute(context.Background(), input)
+	assert.EqualError(t, err, "no stored tasks for project FN and sprint Penguins; run tasks fetch first")
+
+	repo.SetFindByProjectAndSprintFunc(func(_ context.Context, _, _ string) ([]*domain.Task, error) {
+		return []*domain.Task{{Key: "FN-1"}}, nil
+	})
+	_, err = NewLinkCodeChangesUseCase(repo, &stubCodeHost{err: errors.New("rate limited")}).Execute(context.Background(), input)
+	assert.EqualError(t, err, "failed to list code changes: rate limited")
+}
@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain/ports"
+)
+
+// OrgReportUseCase rolls the classified tasks of every team up to an organization summary
+type OrgReportUseCase struct {
+	localRepo ports.TaskRepository
+	splitRepo ports.WorkTypeSplitRepository
+	cache     ports.RollupCache
+}
+
+// NewOrgReportUseCase creates a new instance of OrgReportUseCase. The split repository and
+// cache are optional.
+func NewOrgReportUseCase(localRepo ports.TaskRepository, splitRepo ports.WorkTypeSplitRepository, cache ports.RollupCache) *OrgReportUseCase {
+	return &OrgReportUseCase{
+		localRepo: localRepo,
+		splitRepo: splitRepo,
+		cache:     cache,
+	}
+}
+
+// teamResult is the outcome of rolling up one team
+type teamResult struct {
+	rollup domain.TeamRollup
+	cached bool
+	err    error
+}
+
+// Execute rolls up the teams concurrently, reusing the cached rollup of every team whose
+// tasks and splits are unchanged, and reports them in the order of the input projects
+func (uc *OrgReportUseCase) Execute(ctx context.Context, input domain.OrgReportInput) (*domain.OrgReport, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+	quarter, _ := domain.ParseQuarter(input.Quarter)
+
+	splits, err := uc.splits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]teamResult, len(input.Projects))
+	var wg sync.WaitGroup
+	for i, project := range input.Projects {
+		wg.Add(1)
+		go func(i int, project string) {
+			defer wg.Done()
+			results[i].rollup, results[i].cached, results[i].err = uc.teamRollup(ctx, project, quarter, splits)
+		}(i, project)
+	}
+	wg.Wait()
+
+	report := &domain.OrgReport{Quarter: quarter.String()}
+	for i, result := range results {
+		if result.err != nil {
+			return nil, fmt.Errorf("failed to roll up team %s: %w", input.Projects[i], result.err)
+		}
+		if result.cached {
+			report.Cached++
+		}
+		report.Teams = append(report.Teams, result.rollup)
+	}
+	report.Overall = domain.RollupTeams(report.Quarter, report.Teams)
+	return report, nil
+}
+
+// splits returns the stored work type splits by issue key
+func (uc *OrgReportUseCase) splits(ctx context.Context) (map[string]domain.WorkTypeSplit, error) {
+	byKey := make(map[string]domain.WorkTypeSplit)
+	if uc.splitRepo == nil {
+		return byKey, nil
+	}
+	splits, err := uc.splitRepo.FindSplits(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find work type splits: %w", err)
+	}
+	for _, split := range splits {
+		byKey[split.IssueKey] = split
+	}
+	return byKey, nil
+}
+
+// teamRollup summarizes the team's tasks created within the quarter, returning the cached
+// rollup instead when it was computed from the same tasks and splits
+func (uc *OrgReportUseCase) teamRollup(ctx context.Context, project string, quarter domain.Quarter, splits map[string]domain.WorkTypeSplit) (domain.TeamRollup, bool, error) {
+	tasks, err := uc.localRepo.FindByProject(ctx, project)
+	if err != nil {
+		return domain.TeamRollup{}, false, fmt.Errorf("failed to find tasks: %w", err)
+	}
+	var inQuarter []*domain.Task
+	for _, task := range tasks {
+		if quarter.Contains(task.CreatedAt) {
+			inQuarter = append(inQuarter, task)
+		}
+	}
+	sort.Slice(inQuarter, func(i, j int) bool { return inQuarter[i].Key < inQuarter[j].Key })
+	fingerprint := rollupFingerprint(inQuarter, splits)
+
+	if uc.cache != nil {
+		cached, err := uc.cache.FindRollup(ctx, project, quarter.String())
+		if err != nil {
+			return domain.TeamRollup{}, false, fmt.Errorf("failed to read cached rollup: %w", err)
+		}
+		if cached != nil && cached.Fingerprint == fingerprint {
+			return *cached, true, nil
+		}
+	}
+
+	rollup := domain.TeamRollup{
+		Team:        project,
+		Quarter:     quarter.String(),
+		Tasks:       len(inQuarter),
+		WorkTypes:   make(map[domain.WorkType]float64),
+		Fingerprint: fingerprint,
+	}
+	for _, task := range inQuarter {
+		commits, pullRequests := task.CodeCounts()
+		rollup.Commits += commits
+		rollup.PullRequests += pullRequests
+		if task.LacksCodeEvidence() {
+			rollup.DevelopmentWithoutCode++
+		}
+		if split, ok := splits[task.Key]; ok {
+			for workType, share := range split.Shares {
+				rollup.WorkTypes[workType] += share / 100
+			}
+			continue
+		}
+		if task.WorkType == "" {
+			rollup.Unclassified++
+			continue
+		}
+		rollup.WorkTypes[task.WorkType]++
+	}
+
+	if uc.cache != nil {
+		if err := uc.cache.SaveRollup(ctx, rollup); err != nil {
+			return domain.TeamRollup{}, false, fmt.Errorf("failed to cache rollup: %w", err)
+		}
+	}
+	return rollup, false, nil
+}
+
+// rollupFingerprint hashes what a rollup depends on: the key, work type and last update of
+// each task, its number of code references, and the shares of the splits applying to them
+func rollupFingerprint(tasks []*domain.Task, splits map[string]domain.WorkTypeSplit) string {
+	hash := sha256.New()
+	for _, task := range tasks {
+		fmt.Fprintf(hash, "%s|%s|%s|%d|%d\n", task.Key, task.WorkType, task.UpdatedAt.UTC().Format(time.RFC3339Nano), task.Version, len(task.CodeReferences))
+		if split, ok := splits[task.Key]; ok {
+			for _, workType := range domain.ReportWorkTypes {
+				fmt.Fprintf(hash, "split|%s|%g\n", workType, split.Shares[workType])
+			}
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
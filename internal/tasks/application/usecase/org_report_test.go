@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/application/usecase/testutil"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+type memorySplitRepository struct {
+	splits []domain.WorkTypeSplit
+}
+
+func (r *memorySplitRepository) SaveSplit(_ context.Context, split domain.WorkTypeSplit) error {
+	r.splits = append(r.splits, split)
+	return nil
+}
+
+func (r *memorySplitRepository) DeleteSplit(_ context.Context, _ string) error {
+	return nil
+}
+
+func (r *memorySplitRepository) FindSplits(_ context.Context) ([]domain.WorkTypeSplit, error) {
+	return r.splits, nil
+}
+
+type memoryRollupCache struct {
+	mu      sync.Mutex
+	rollups map[string]domain.TeamRollup
+	saves   int
+}
+
+func (c *memoryRollupCache) FindRollup(_ context.Context, team, quarter string) (*domain.TeamRollup, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rollup, ok := c.rollups[team+"/"+quarter]
+	if !ok {
+		return nil, nil
+	}
+	return &rollup, nil
+}
+
+func (c *memoryRollupCache) SaveRollup(_ context.Context, rollup domain.TeamRollup) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rollups[rollup.Team+"/"+rollup.Quarter] = rollup
+	c.saves++
+	return nil
+}
+
+func orgFixture() map[string][]*domain.Task {
+	inQuarter := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	return map[string][]*domain.Task{
+		"FN": {
+			{Key: "FN-1", Project: "FN", WorkType: domain.WorkTypeDevelopment, CreatedAt: inQuarter, CodeReferences: []domain.CodeReference{
+				{Kind: domain.CodeReferenceCommit, Repository: "acme/api", ID: "a1"},
+				{Kind: domain.CodeReferencePullRequest, Repository: "acme/api", ID: "7"},
+			}},
+			{Key: "FN-2", Project: "FN", WorkType: domain.WorkTypeMaintenance, CreatedAt: inQuarter},
+			{Key: "FN-3", Project: "FN", WorkType: domain.WorkTypeDevelopment, CreatedAt: inQuarter},
+			{Key: "FN-4", Project: "FN", CreatedAt: inQuarter},
+			{Key: "FN-5", Project: "FN", WorkType: domain.WorkTypeDevelopment, CreatedAt: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		"OPS": {
+			{Key: "OPS-1", Project: "OPS", WorkType: domain.WorkTypeMaintenance, CreatedAt: inQuarter},
+		},
+	}
+}
+
+func newOrgReportUseCase(tasks map[string][]*domain.Task, splits []domain.WorkTypeSplit, cache *memoryRollupCache) *OrgReportUseCase {
+	repo := testutil.NewMockTaskRepository()
+	repo.SetFindByProjectFunc(func(_ context.Context, project string) ([]*domain.Task, error) {
+		if project == "BROKEN" {
+			return nil, errors.New("storage unavailable")
+		}
+		return tasks[project], nil
+	})
+	if cache == nil {
+		// Pass an untyped nil so the use case sees no cache
+		return NewOrgReportUseCase(repo, &memorySplitRepository{splits: splits}, nil)
+	}
+	return NewOrgReportUseCase(repo, &memorySplitRepository{splits: splits}, cache)
+}
+
+func TestOrgReport_RollsUpTeams(t *testing.T) {
+	splits := []domain.WorkTypeSplit{{IssueKey: "FN-3", Shares: map[domain.WorkType]float64{domain.WorkTypeDevelopment: 60, domain.WorkTypeDiscovery: 40}}}
+	uc := newOrgReportUseCase(orgFixture(), splits, nil)
+
+	report, err := uc.Execute(context.Background(), domain.OrgReportInput{Projects: []string{"OPS", "FN", "NEW"}, Quarter: "2024-Q2"})
+	require.NoError(t, err)
+
+	require.Len(t, report.Teams, 3)
+	assert.Equal(t, []string{"OPS", "FN", "NEW"}, []string{report.Teams[0].Team, report.Teams[1].Team, report.Teams[2].Team})
+
+	fn := report.Teams[1]
+	assert.Equal(t, 4, fn.Tasks)
+	assert.Equal(t, 1, fn.Unclassified)
+	assert.InDelta(t, 1.6, fn.WorkTypes[domain.WorkTypeDevelopment], 0.001)
+	assert.InDelta(t, 1.0, fn.WorkTypes[domain.WorkTypeMaintenance], 0.001)
+	assert.InDelta(t, 0.4, fn.WorkTypes[domain.WorkTypeDiscovery], 0.001)
+	assert.Zero(t, report.Teams[2].Tasks)
+	assert.Equal(t, 1, fn.Commits)
+	assert.Equal(t, 1, fn.PullRequests)
+	assert.Equal(t, 1, fn.DevelopmentWithoutCode, "FN-3 is development without code changes")
+
+	assert.Equal(t, "2024-Q2", report.Overall.Quarter)
+	assert.Equal(t, 5, report.Overall.Tasks)
+	assert.InDelta(t, 2.0, report.Overall.WorkTypes[domain.WorkTypeMaintenance], 0.001)
+	assert.Equal(t, 1, report.Overall.Commits)
+	assert.Zero(t, report.Cached)
+}
+
+func TestOrgReport_ReusesCachedRollups(t *testing.T) {
+	tasks := orgFixture()
+	cache := &memoryRollupCache{rollups: make(map[string]domain.TeamRollup)}
+	uc := newOrgReportUseCase(tasks, nil, cache)
+	input := domain.OrgReportInput{Projects: []string{"FN", "OPS"}, Quarter: "2024-Q2"}
+
+	first, err := uc.Execute(context.Background(), input)
+	require.NoError(t, err)
+	assert.Zero(t, first.Cached)
+	assert.Equal(t, 2, cache.saves)
+
+	second, err := uc.Execute(context.Background(), input)
+	require.NoError(t, err)
+	assert.Equal(t, 2, second.Cached)
+	assert.Equal(t, first.Teams, second.Teams)
+
+	// Reclassifying a task invalidates its team's cached rollup only
+	tasks["OPS"][0].WorkType = domain.WorkTypeDevelopment
+	tasks["OPS"][0].UpdatedAt = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	third, err := uc.Execute(context.Background(), input)
+	require.NoError(t, err)
+	assert.Equal(t, 1, third.Cached)
+	assert.Equal(t, 1.0, third.Teams[1].WorkTypes[domain.WorkTypeDevelopment])
+
+	// Linking code changes invalidates the cached rollup as well
+	tasks["FN"][2].SetCodeReferences([]domain.CodeReference{{Kind: domain.CodeReferenceCommit, Repository: "acme/api", ID: "b2"}})
+	fourth, err := uc.Execute(context.Background(), input)
+	require.NoError(t, err)
+	assert.Equal(t, 1, fourth.Cached)
+	assert.Equal(t, 2, fourth.Teams[0].Commits)
+	assert.Zero(t, fourth.Teams[0].DevelopmentWithoutCode)
+}
+
+func TestOrgReport_Errors(t *testing.T) {
+	uc := newOrgReportUseCase(orgFixture(), nil, nil)
+
+	_, err := uc.Execute(context.Background(), domain.OrgReportInput{Quarter: "2024-Q2"})
+	assert.ErrorIs(t, err, domain.ErrNoProjects)
+
+	_, err = uc.Execute(context.Background(), domain.OrgReportInput{Projects: []string{"FN"}, Quarter: "Q2"})
+	assert.ErrorIs(t, err, domain.ErrInvalidQuarter)
+
+	_, err = uc.Execute(context.Background(), domain.OrgReportInput{Projects: []string{"FN", "BROKEN"}, Quarter: "2024-Q2"})
+	assert.EqualError(t, err, "failed to roll up team BROKEN: failed to find tasks: storage unavailable")
+}
@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain/ports"
+)
+
+// SampleTasksUseCase draws reproducible random samples of classified tasks for audits
+type SampleTasksUseCase struct {
+	localRepo  ports.TaskRepository
+	sampleRepo ports.SampleRepository
+	now        func() time.Time
+}
+
+// NewSampleTasksUseCase creates a new instance of SampleTasksUseCase
+func NewSampleTasksUseCase(localRepo ports.TaskRepository, sampleRepo ports.SampleRepository) *SampleTasksUseCase {
+	return &SampleTasksUseCase{
+		localRepo:  localRepo,
+		sampleRepo: sampleRepo,
+		now:        time.Now,
+	}
+}
+
+// Execute selects the sample and records its definition.
+// A zero seed is replaced by a time-based one, which is recorded so the sample can be reproduced.
+func (uc *SampleTasksUseCase) Execute(ctx context.Context, input domain.SampleTasksInput) (*domain.TaskSample, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+	quarter, _ := domain.ParseQuarter(input.Quarter)
+
+	tasks, err := uc.localRepo.FindByProject(ctx, input.Project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find tasks: %w", err)
+	}
+
+	population := classifiedTasksIn(tasks, quarter)
+	if len(population) == 0 {
+		return nil, fmt.Errorf("no classified tasks found for project %s in %s", input.Project, quarter)
+	}
+
+	seed := input.Seed
+	if seed == 0 {
+		seed = uc.now().UnixNano()
+	}
+
+	var selected []*domain.Task
+	if input.Stratify == domain.StratifyWorkType {
+		selected = stratifiedSample(population, input.Size, seed)
+	} else {
+		selected = randomSample(population, input.Size, rand.New(rand.NewSource(seed)))
+	}
+	sort.Slice(selected, func(i, j int) bool { return selected[i].Key < selected[j].Key })
+
+	keys := make([]string, 0, len(selected))
+	for _, task := range selected {
+		keys = append(keys, task.Key)
+	}
+
+	definition := domain.SampleDefinition{
+		ID:         fmt.Sprintf("%s-%s-%d", input.Project, quarter, seed),
+		Project:    input.Project,
+		Quarter:    quarter.String(),
+		Size:       input.Size,
+		Stratify:   input.Stratify,
+		Seed:       seed,
+		Population: len(population),
+		TaskKeys:   keys,
+		CreatedAt:  uc.now(),
+	}
+	if err := uc.sampleRepo.SaveSample(ctx, definition); err != nil {
+		return nil, fmt.Errorf("failed to record sample definition: %w", err)
+	}
+
+	return &domain.TaskSample{Definition: definition, Tasks: selected}, nil
+}
+
+// classifiedTasksIn returns the classified tasks created within the quarter, sorted by key
+func classifiedTasksIn(tasks []*domain.Task, quarter domain.Quarter) []*domain.Task {
+	var result []*domain.Task
+	for _, task := range tasks {
+		if task.WorkType != "" && quarter.Contains(task.CreatedAt) {
+			result = append(result, task)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Key < result[j].Key })
+	return result
+}
+
+// randomSample picks up to size tasks using rng
+func randomSample(tasks []*domain.Task, size int, rng *rand.Rand) []*domain.Task {
+	if size > len(tasks) {
+		size = len(tasks)
+	}
+	selected := make([]*domain.Task, 0, size)
+	for _, i := range rng.Perm(len(tasks))[:size] {
+		selected = append(selected, tasks[i])
+	}
+	return selected
+}
+
+// stratifiedSample picks tasks from each work type proportionally to its share of the population
+func stratifiedSample(tasks []*domain.Task, size int, seed int64) []*domain.Task {
+	strata := make(map[domain.WorkType][]*domain.Task)
+	for _, task := range tasks {
+		strata[task.WorkType] = append(strata[task.WorkType], task)
+	}
+
+	workTypes := make([]domain.WorkType, 0, len(strata))
+	for workType := range strata {
+		workTypes = append(workTypes, workType)
+	}
+	sort.Slice(workTypes, func(i, j int) bool { return workTypes[i] < workTypes[j] })
+
+	if size > len(tasks) {
+		size = len(tasks)
+	}
+
+	// Largest remainder allocation of the sample size across strata
+	quotas := make(map[domain.WorkType]int, len(workTypes))
+	remainders := make(map[domain.WorkType]float64, len(workTypes))
+	allocated := 0
+	for _, workType := range workTypes {
+		exact := float64(size) * float64(len(strata[workType])) / float64(len(tasks))
+		quotas[workType] = int(exact)
+		remainders[workType] = exact - float64(quotas[workType])
+		allocated += quotas[workType]
+	}
+	byRemainder := append([]domain.WorkType(nil), workTypes...)
+	sort.SliceStable(byRemainder, func(i, j int) bool { return remainders[byRemainder[i]] > remainders[byRemainder[j]] })
+	for i := 0; allocated < size; i = (i + 1) % len(byRemainder) {
+		workType := byRemainder[i]
+		if quotas[workType] < len(strata[workType]) {
+			quotas[workType]++
+			allocated++
+		}
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	var selected []*domain.Task
+	for _, workType := range workTypes {
+		selected = append(selected, randomSample(strata[workType], quotas[workType], rng)...)
+	}
+	return selected
+}
+
+// FormatSampleCSV exports a sample with the classification rationale, evidence link and notes of each task
+func FormatSampleCSV(sample *domain.TaskSample, baseURL string) (string, error) {
+	buffer := &strings.Builder{}
+	writer := csv.NewWriter(buffer)
+
+	headers := []string{"key", "summary", "sprint", "type", "status", "work_type", "rationale", "labels", "epic", "evidence", "notes"}
+	if err := writer.Write(headers); err != nil {
+		return "", err
+	}
+
+	for _, task := range sample.Tasks {
+		evidence := ""
+		if baseURL != "" {
+			evidence = fmt.Sprintf("%s/browse/%s", strings.TrimRight(baseURL, "/"), task.Key)
+		}
+		record := []string{
+			task.Key,
+			task.Summary,
+			task.Sprint,
+			string(task.Type),
+			string(task.Status),
+			string(task.WorkType),
+			task.ClassificationRationale(),
+			strings.Join(task.Labels, " "),
+			task.Epic,
+			evidence,
+			domain.JoinNotes(sample.Notes[task.Key]),
+		}
+		if err := writer.Write(record); err != nil {
+			return "", err
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", fmt.Errorf("failed to write sample CSV: %w", err)
+	}
+	return buffer.String(), nil
+}
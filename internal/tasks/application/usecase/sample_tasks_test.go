@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/application/usecase/testutil"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+type memorySampleRepository struct {
+	samples map[string]domain.SampleDefinition
+}
+
+func (r *memorySampleRepository) SaveSample(_ context.Context, definition domain.SampleDefinition) error {
+	r.samples[definition.ID] = definition
+	return nil
+}
+
+func (r *memorySampleRepository) FindSample(_ context.Context, id string) (*domain.SampleDefinition, error) {
+	definition, ok := r.samples[id]
+	if !ok {
+		return nil, fmt.Errorf("sample %s not found", id)
+	}
+	return &definition, nil
+}
+
+func sampleFixture() []*domain.Task {
+	inQuarter := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	var tasks []*domain.Task
+	for i := 1; i <= 12; i++ {
+		workType := domain.WorkTypeDevelopment
+		if i%4 == 0 {
+			workType = domain.WorkTypeMaintenance
+		}
+		tasks = append(tasks, &domain.Task{
+			Key:       fmt.Sprintf("FN-%02d", i),
+			Summary:   fmt.Sprintf("Task %d", i),
+			Project:   "FN",
+			Platform:  "jira",
+			WorkType:  workType,
+			Labels:    []string{string(workType)},
+			CreatedAt: inQuarter,
+		})
+	}
+	// Unclassified and out of quarter tasks are not part of the population
+	tasks = append(tasks,
+		&domain.Task{Key: "FN-90", Project: "FN", CreatedAt: inQuarter},
+		&domain.Task{Key: "FN-91", Project: "FN", WorkType: domain.WorkTypeDevelopment, CreatedAt: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)},
+	)
+	return tasks
+}
+
+func newSampleUseCase(tasks []*domain.Task) (*SampleTasksUseCase, *memorySampleRepository) {
+	repo := testutil.NewMockTaskRepository()
+	repo.SetFindByProjectFunc(func(_ context.Context, _ string) ([]*domain.Task, error) {
+		return tasks, nil
+	})
+	samples := &memorySampleRepository{samples: make(map[string]domain.SampleDefinition)}
+	return NewSampleTasksUseCase(repo, samples), samples
+}
+
+func TestSampleTasks_ReproducibleWithSeed(t *testing.T) {
+	uc, samples := newSampleUseCase(sampleFixture())
+	input := domain.SampleTasksInput{Project: "FN", Quarter: "2024-Q2", Size: 5, Seed: 7}
+
+	first, err := uc.Execute(context.Background(), input)
+	require.NoError(t, err)
+	second, err := uc.Execute(context.Background(), input)
+	require.NoError(t, err)
+
+	assert.Len(t, first.Tasks, 5)
+	assert.Equal(t, first.Definition.TaskKeys, second.Definition.TaskKeys)
+	assert.Equal(t, 12, first.Definition.Population)
+	assert.NotContains(t, first.Definition.TaskKeys, "FN-90")
+	assert.NotContains(t, first.Definition.TaskKeys, "FN-91")
+
+	recorded, err := samples.FindSample(context.Background(), "FN-2024-Q2-7")
+	require.NoError(t, err)
+	assert.Equal(t, first.Definition.TaskKeys, recorded.TaskKeys)
+}
+
+func TestSampleTasks_StratifiedByWorkType(t *testing.T) {
+	uc, _ := newSampleUseCase(sampleFixture())
+
+	sample, err := uc.Execute(context.Background(), domain.SampleTasksInput{
+		Project: "FN", Quarter: "2024-Q2", Size: 4, Stratify: domain.StratifyWorkType, Seed: 3,
+	})
+	require.NoError(t, err)
+
+	counts := make(map[domain.WorkType]int)
+	for _, task := range sample.Tasks {
+		counts[task.WorkType]++
+	}
+	assert.Equal(t, 3, counts[domain.WorkTypeDevelopment])
+	assert.Equal(t, 1, counts[domain.WorkTypeMaintenance])
+}
+
+func TestSampleTasks_SizeLargerThanPopulation(t *testing.T) {
+	uc, _ := newSampleUseCase(sampleFixture())
+
+	sample, err := uc.Execute(context.Background(), domain.SampleTasksInput{
+		Project: "FN", Quarter: "2024-Q2", Size: 50, Stratify: domain.StratifyWorkType, Seed: 1,
+	})
+	require.NoError(t, err)
+	assert.Len(t, sample.Tasks, 12)
+}
+
+func TestSampleTasks_GeneratesSeedWhenMissing(t *testing.T) {
+	uc, _ := newSampleUseCase(sampleFixture())
+	uc.now = func() time.Time { return time.Unix(0, 99) }
+
+	sample, err := uc.Execute(context.Background(), domain.SampleTasksInput{Project: "FN", Quarter: "2024-Q2", Size: 2})
+	require.NoError(t, err)
+	assert.Equal(t, int64(99), sample.Definition.Seed)
+}
+
+func TestSampleTasks_Errors(t *testing.T) {
+	uc, _ := newSampleUseCase(sampleFixture())
+
+	_, err := uc.Execute(context.Background(), domain.SampleTasksInput{Project: "FN", Quarter: "2024-05", Size: 2})
+	assert.ErrorIs(t, err, domain.ErrInvalidQuarter)
+
+	_, err = uc.Execute(context.Background(), domain.SampleTasksInput{Project: "FN", Quarter: "2023-Q1", Size: 2})
+	assert.EqualError(t, err, "no classified tasks found for project FN in 2023-Q1")
+}
+
+func TestFormatSampleCSV(t *testing.T) {
+	sample := &domain.TaskSample{Tasks: []*domain.Task{{
+		Key:      "FN-1",
+		Summary:  "Build, checkout",
+		Platform: "jira",
+		WorkType: domain.WorkTypeDevelopment,
+		Labels:   []string{"cap-development", "cap-asset-checkout"},
+	}}, Notes: map[string][]domain.TaskNote{"FN-1": {
+		{IssueKey: "FN-1", Text: "confirmed with tech lead", CreatedAt: time.Date(2024, 5, 2, 14, 30, 0, 0, time.UTC)},
+	}}}
+
+	data, err := FormatSampleCSV(sample, "https://example.atlassian.net/")
+	require.NoError(t, err)
+	assert.Equal(t, "key,summary,sprint,type,status,work_type,rationale,labels,epic,evidence,notes\n"+
+		"FN-1,\"Build, checkout\",,,,cap-development,labelled cap-development in jira,cap-development cap-asset-checkout,,https://example.atlassian.net/browse/FN-1,2024-05-02 14:30: confirmed with tech lead\n", data)
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// ClassificationPropertyKey is the Jira issue property holding the classification of an issue
+const ClassificationPropertyKey = "assetcap.classification"
+
+// ClassificationSource tells what decided the work type of a task
+type ClassificationSource string
+
+// Classification sources
+const (
+	// ClassificationSourceAssetRule is a keyword rule of the asset the task is linked to
+	ClassificationSourceAssetRule ClassificationSource = "asset-rule"
+	// ClassificationSourceClassifier is the configured classifier
+	ClassificationSourceClassifier ClassificationSource = "classifier"
+)
+
+// Classification records why a task got its work type. It is stored as a property of the
+// Jira issue, out of sight in the UI but readable by other tools through the API.
+type Classification struct {
+	WorkType WorkType             `json:"workType"`
+	Source   ClassificationSource `json:"source"`
+	// Rule is the keyword of the asset rule that matched, if any
+	Rule      string `json:"rule,omitempty"`
+	Rationale string `json:"rationale"`
+	// Confidence is the probability the work type is right, from 0 to 1; omitted when the
+	// classifier does not tell
+	Confidence   float64   `json:"confidence,omitempty"`
+	ClassifiedAt time.Time `json:"classifiedAt"`
+}
+
+// RuleClassification records a work type decided by a keyword rule of the task's asset
+func RuleClassification(rule KeywordRule, classifiedAt time.Time) Classification {
+	return Classification{
+		WorkType:     rule.WorkType,
+		Source:       ClassificationSourceAssetRule,
+		Rule:         rule.Keyword,
+		Rationale:    fmt.Sprintf("matched keyword %q of the asset's classification rules", rule.Keyword),
+		Confidence:   1,
+		ClassifiedAt: classifiedAt,
+	}
+}
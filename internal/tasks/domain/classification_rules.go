@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"strings"
+)
+
+// assetLabelPrefix is the label prefix linking tasks to an asset
+const assetLabelPrefix = "cap-asset-"
+
+// AssetLabel returns the task label of an asset given its name or full label. Multi-word names
+// are linked by their first word.
+func AssetLabel(asset string) string {
+	if strings.HasPrefix(asset, assetLabelPrefix) {
+		return asset
+	}
+	words := strings.Fields(asset)
+	if len(words) == 0 {
+		return ""
+	}
+	return assetLabelPrefix + strings.ToLower(words[0])
+}
+
+// IsAssetLabel reports whether a task label links the task to an asset
+func IsAssetLabel(label string) bool {
+	return strings.HasPrefix(label, assetLabelPrefix) && len(label) > len(assetLabelPrefix)
+}
+
+// KeywordRule classifies the tasks mentioning Keyword as WorkType
+type KeywordRule struct {
+	Keyword  string
+	WorkType WorkType
+}
+
+// AssetRules are the classification rules an asset owns for its linked tasks
+type AssetRules struct {
+	// Asset is the name of the asset owning the rules
+	Asset string
+	// Keywords are tried in order; the first found in a task's summary or description wins
+	Keywords []KeywordRule
+	// Prompt is added to the instructions of classifiers that accept them
+	Prompt string
+}
+
+// ClassificationRules holds the rules of each asset, keyed by the asset's task label
+type ClassificationRules map[string]AssetRules
+
+// For returns the rules of the first asset the task is linked to that has any
+func (r ClassificationRules) For(task *Task) (AssetRules, bool) {
+	for _, label := range task.AssetLabels() {
+		if rules, ok := r[label]; ok {
+			return rules, true
+		}
+	}
+	return AssetRules{}, false
+}
+
+// Match returns the work type of the first keyword rule found, regardless of case, in the
+// summary or description of the task
+func (r AssetRules) Match(task *Task) (KeywordRule, bool) {
+	text := strings.ToLower(task.Summary + "\n" + task.Description)
+	for _, rule := range r.Keywords {
+		if rule.Keyword != "" && strings.Contains(text, strings.ToLower(rule.Keyword)) {
+			return rule, true
+		}
+	}
+	return KeywordRule{}, false
+}
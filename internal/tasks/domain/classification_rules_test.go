@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssetLabel(t *testing.T) {
+	assert.Equal(t, "cap-asset-data", AssetLabel("cap-asset-data"))
+	assert.Equal(t, "cap-asset-data", AssetLabel("Data Platform"))
+	assert.Equal(t, "", AssetLabel(" "))
+}
+
+func TestIsAssetLabel(t *testing.T) {
+	assert.True(t, IsAssetLabel("cap-asset-data"))
+	assert.False(t, IsAssetLabel("cap-asset-"))
+	assert.False(t, IsAssetLabel("cap-development"))
+}
+
+func TestClassificationRules(t *testing.T) {
+	rules := ClassificationRules{
+		"cap-asset-data": {Keywords: []KeywordRule{
+			{Keyword: "backfill", WorkType: WorkTypeDevelopment},
+			{Keyword: "incident", WorkType: WorkTypeMaintenance},
+		}},
+	}
+	linked := &Task{Key: "FN-1", Summary: "Incident review", Description: "Backfill after the outage", Labels: []string{"other", "cap-asset-data"}}
+
+	assetRules, ok := rules.For(linked)
+	assert.True(t, ok)
+	rule, found := assetRules.Match(linked)
+	assert.True(t, found)
+	assert.Equal(t, WorkTypeDevelopment, rule.WorkType, "rules are tried in order")
+
+	_, found = assetRules.Match(&Task{Summary: "Dashboard polish"})
+	assert.False(t, found)
+
+	_, ok = rules.For(&Task{Labels: []string{"cap-asset-web"}})
+	assert.False(t, ok)
+}
@@ -6,4 +6,10 @@ type ClassifyTasksInput struct {
 	Sprint  string
 	DryRun  bool
 	Apply   bool
+	// LabelPolicy guards the labels applied to the issues
+	LabelPolicy LabelPolicy
+	// ReplaceAll replaces every unprotected label of the issues instead of merging
+	ReplaceAll bool
+	// Rules are the classification rules of the assets the tasks are linked to
+	Rules ClassificationRules
 }
@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// ErrNoCodeHost is returned when code linking is requested without a configured code host
+var ErrNoCodeHost = errors.New("no code host configured: set code.host and code.repositories in the config file")
+
+// CodeReferenceKind tells commits and pull requests apart
+type CodeReferenceKind string
+
+const (
+	CodeReferenceCommit      CodeReferenceKind = "commit"
+	CodeReferencePullRequest CodeReferenceKind = "pull_request"
+)
+
+// CodeReference is a commit or pull request referencing a task by its key
+type CodeReference struct {
+	Kind       CodeReferenceKind `json:"kind"`
+	Repository string            `json:"repository"`
+	// ID is the commit hash or pull request number
+	ID     string    `json:"id"`
+	Title  string    `json:"title"`
+	URL    string    `json:"url"`
+	Author string    `json:"author,omitempty"`
+	Date   time.Time `json:"date"`
+}
+
+// CodeChange is a commit or pull request found on a code host, with the text searched for
+// issue keys: its message, or its title, description and branch
+type CodeChange struct {
+	Reference CodeReference
+	Text      string
+}
+
+// issueKeyPattern matches Jira issue keys such as FN-123
+var issueKeyPattern = regexp.MustCompile(`\b[A-Z][A-Z0-9]+-[0-9]+\b`)
+
+// IssueKeysIn returns the distinct issue keys mentioned in a text, in order of appearance
+func IssueKeysIn(text string) []string {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, key := range issueKeyPattern.FindAllString(text, -1) {
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// MatchCodeChanges returns the references of the changes mentioning each issue key
+func MatchCodeChanges(changes []CodeChange) map[string][]CodeReference {
+	byKey := make(map[string][]CodeReference)
+	for _, change := range changes {
+		for _, key := range IssueKeysIn(change.Text) {
+			byKey[key] = append(byKey[key], change.Reference)
+		}
+	}
+	return byKey
+}
+
+// SetCodeReferences replaces the code references of the task, oldest first, and reports
+// whether they changed
+func (t *Task) SetCodeReferences(references []CodeReference) bool {
+	unique := make([]CodeReference, 0, len(references))
+	seen := make(map[string]bool)
+	for _, reference := range references {
+		id := string(reference.Kind) + "|" + reference.Repository + "|" + reference.ID
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, reference)
+		}
+	}
+	sort.SliceStable(unique, func(i, j int) bool {
+		if !unique[i].Date.Equal(unique[j].Date) {
+			return unique[i].Date.Before(unique[j].Date)
+		}
+		return unique[i].ID < unique[j].ID
+	})
+
+	if sameReferences(t.CodeReferences, unique) {
+		return false
+	}
+	if len(unique) == 0 {
+		unique = nil
+	}
+	t.CodeReferences = unique
+	t.UpdatedAt = time.Now()
+	t.Version++
+	return true
+}
+
+// sameReferences reports whether two lists hold the same references in the same order
+func sameReferences(a, b []CodeReference) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Kind != b[i].Kind || a[i].Repository != b[i].Repository || a[i].ID != b[i].ID ||
+			a[i].Title != b[i].Title || a[i].URL != b[i].URL || !a[i].Date.Equal(b[i].Date) {
+			return false
+		}
+	}
+	return true
+}
+
+// CodeCounts returns the number of commits and pull requests referencing the task
+func (t *Task) CodeCounts() (commits, pullRequests int) {
+	for _, reference := range t.CodeReferences {
+		switch reference.Kind {
+		case CodeReferenceCommit:
+			commits++
+		case CodeReferencePullRequest:
+			pullRequests++
+		}
+	}
+	return commits, pullRequests
+}
+
+// LacksCodeEvidence reports whether the task is classified as development without any
+// commit or pull request referencing it
+func (t *Task) LacksCodeEvidence() bool {
+	return t.WorkType == WorkTypeDevelopment && len(t.CodeReferences) == 0
+}
+
+// CodeLinkInput selects the stored tasks whose code references are looked up
+type CodeLinkInput struct {
+	Project string
+	Sprint  string
+}
+
+// CodeLinkResult summarizes the code references linked to the tasks of a sprint
+type CodeLinkResult struct {
+	Project string
+	Sprint  string
+	Tasks   int
+	// Linked counts the tasks referenced by at least one commit or pull request
+	Linked       int
+	Commits      int
+	PullRequests int
+	// WithoutCode lists the keys of the development tasks no code change references
+	WithoutCode []string
+	// Updated counts the tasks whose stored references changed
+	Updated int
+}
+
+// SummarizeCodeLinks counts the code references of the tasks and flags the development tasks
+// without any
+func SummarizeCodeLinks(project, sprint string, tasks []*Task) CodeLinkResult {
+	result := CodeLinkResult{Project: project, Sprint: sprint, Tasks: len(tasks)}
+	for _, task := range tasks {
+		commits, pullRequests := task.CodeCounts()
+		result.Commits += commits
+		result.PullRequests += pullRequests
+		if len(task.CodeReferences) > 0 {
+			result.Linked++
+		}
+		if task.LacksCodeEvidence() {
+			result.WithoutCode = append(result.WithoutCode, task.Key)
+		}
+	}
+	sort.Strings(result.WithoutCode)
+	return result
+}
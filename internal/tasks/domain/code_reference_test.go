@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssueKeysIn(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"commit message", "FN-12: add ledger export", []string{"FN-12"}},
+		{"branch name", "feature/FN-12-ledger", []string{"FN-12"}},
+		{"several keys once each", "FN-12 and OPS2-7, follow-up of FN-12", []string{"FN-12", "OPS2-7"}},
+		{"lowercase is not a key", "fn-12 fixes", nil},
+		{"no key", "Bump dependencies", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IssueKeysIn(tt.text))
+		})
+	}
+}
+
+func TestMatchCodeChanges(t *testing.T) {
+	commit := CodeReference{Kind: CodeReferenceCommit, Repository: "acme/api", ID: "a1"}
+	pull := CodeReference{Kind: CodeReferencePullRequest, Repository: "acme/api", ID: "7"}
+
+	matched := MatchCodeChanges([]CodeChange{
+		{Reference: commit, Text: "FN-1: export, see FN-2"},
+		{Reference: pull, Text: "Export\nfeature/FN-1\n"},
+		{Reference: CodeReference{ID: "b2"}, Text: "Bump dependencies"},
+	})
+
+	assert.Equal(t, map[string][]CodeReference{
+		"FN-1": {commit, pull},
+		"FN-2": {commit},
+	}, matched)
+}
+
+func TestTask_SetCodeReferences(t *testing.T) {
+	older := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.AddDate(0, 0, 1)
+	commit := CodeReference{Kind: CodeReferenceCommit, Repository: "acme/api", ID: "a1", Date: newer}
+	pull := CodeReference{Kind: CodeReferencePullRequest, Repository: "acme/api", ID: "7", Date: older}
+	task := &Task{Key: "FN-1", Version: 1}
+
+	assert.True(t, task.SetCodeReferences([]CodeReference{commit, pull, commit}))
+	assert.Equal(t, []CodeReference{pull, commit}, task.CodeReferences, "oldest first, without duplicates")
+	assert.Equal(t, 2, task.Version)
+
+	assert.False(t, task.SetCodeReferences([]CodeReference{commit, pull}))
+	assert.Equal(t, 2, task.Version)
+
+	assert.True(t, task.SetCodeReferences(nil))
+	assert.Nil(t, task.CodeReferences)
+	assert.Equal(t, 3, task.Version)
+}
+
+func TestTask_CodeEvidence(t *testing.T) {
+	task := &Task{Key: "FN-1", WorkType: WorkTypeDevelopment}
+	assert.True(t, task.LacksCodeEvidence())
+
+	task.CodeReferences = []CodeReference{
+		{Kind: CodeReferenceCommit, ID: "a1"},
+		{Kind: CodeReferenceCommit, ID: "b2"},
+		{Kind: CodeReferencePullRequest, ID: "7"},
+	}
+	commits, pullRequests := task.CodeCounts()
+	assert.Equal(t, 2, commits)
+	assert.Equal(t, 1, pullRequests)
+	assert.False(t, task.LacksCodeEvidence())
+
+	maintenance := &Task{Key: "FN-2", WorkType: WorkTypeMaintenance}
+	assert.False(t, maintenance.LacksCodeEvidence(), "only development work is expected to change code")
+}
+
+func TestSummarizeCodeLinks(t *testing.T) {
+	tasks := []*Task{
+		{Key: "FN-3", WorkType: WorkTypeDevelopment},
+		{Key: "FN-1", WorkType: WorkTypeDevelopment, CodeReferences: []CodeReference{{Kind: CodeReferencePullRequest, ID: "7"}}},
+		{Key: "FN-2", WorkType: WorkTypeDevelopment},
+		{Key: "FN-4"},
+	}
+
+	assert.Equal(t, CodeLinkResult{
+		Project: "FN", Sprint: "Penguins", Tasks: 4, Linked: 1, PullRequests: 1,
+		WithoutCode: []string{"FN-2", "FN-3"},
+	}, SummarizeCodeLinks("FN", "Penguins", tasks))
+}
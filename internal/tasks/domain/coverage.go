@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// SprintCoverage counts the tasks of a sprint and how many are classified, by work type
+type SprintCoverage struct {
+	Project   string
+	Sprint    string
+	Tasks     int
+	WorkTypes map[WorkType]int
+	// Started is when the first task of the sprint was created, used to order sprints
+	Started time.Time
+}
+
+// Classified returns the number of tasks with a work type
+func (c SprintCoverage) Classified() int {
+	classified := 0
+	for _, count := range c.WorkTypes {
+		classified += count
+	}
+	return classified
+}
+
+// Percent returns the percentage of the tasks that are classified
+func (c SprintCoverage) Percent() float64 {
+	if c.Tasks == 0 {
+		return 0
+	}
+	return float64(c.Classified()) / float64(c.Tasks) * 100
+}
+
+// CoverageBySprint counts the classified tasks of every project and sprint, oldest sprint
+// first. Tasks without a sprint are left out.
+func CoverageBySprint(tasks []*Task) []SprintCoverage {
+	type sprintKey struct{ project, sprint string }
+	bySprint := make(map[sprintKey]*SprintCoverage)
+	for _, task := range tasks {
+		if task.Sprint == "" {
+			continue
+		}
+		key := sprintKey{task.Project, task.Sprint}
+		coverage, ok := bySprint[key]
+		if !ok {
+			coverage = &SprintCoverage{Project: task.Project, Sprint: task.Sprint, WorkTypes: make(map[WorkType]int), Started: task.CreatedAt}
+			bySprint[key] = coverage
+		}
+		coverage.Tasks++
+		if task.WorkType != "" {
+			coverage.WorkTypes[task.WorkType]++
+		}
+		if task.CreatedAt.Before(coverage.Started) {
+			coverage.Started = task.CreatedAt
+		}
+	}
+
+	coverages := make([]SprintCoverage, 0, len(bySprint))
+	for _, coverage := range bySprint {
+		coverages = append(coverages, *coverage)
+	}
+	sort.Slice(coverages, func(i, j int) bool {
+		if !coverages[i].Started.Equal(coverages[j].Started) {
+			return coverages[i].Started.Before(coverages[j].Started)
+		}
+		if coverages[i].Project != coverages[j].Project {
+			return coverages[i].Project < coverages[j].Project
+		}
+		return coverages[i].Sprint < coverages[j].Sprint
+	})
+	return coverages
+}
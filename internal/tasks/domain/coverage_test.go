@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoverageBySprint(t *testing.T) {
+	created := func(day int) time.Time { return time.Date(2024, time.May, day, 0, 0, 0, 0, time.UTC) }
+	tasks := []*Task{
+		{Key: "FN-3", Project: "FN", Sprint: "Sprint 8", WorkType: WorkTypeDevelopment, CreatedAt: created(15)},
+		{Key: "FN-1", Project: "FN", Sprint: "Sprint 7", WorkType: WorkTypeDevelopment, CreatedAt: created(2)},
+		{Key: "FN-2", Project: "FN", Sprint: "Sprint 7", CreatedAt: created(1)},
+		{Key: "FN-4", Project: "FN", Sprint: "Sprint 7", WorkType: WorkTypeMaintenance, CreatedAt: created(3)},
+		{Key: "FN-5", Project: "FN", CreatedAt: created(1)},
+	}
+
+	coverages := CoverageBySprint(tasks)
+
+	require.Len(t, coverages, 2)
+	assert.Equal(t, "Sprint 7", coverages[0].Sprint)
+	assert.Equal(t, created(1), coverages[0].Started)
+	assert.Equal(t, 3, coverages[0].Tasks)
+	assert.Equal(t, 2, coverages[0].Classified())
+	assert.InDelta(t, 66.67, coverages[0].Percent(), 0.01)
+	assert.Equal(t, map[WorkType]int{WorkTypeDevelopment: 1, WorkTypeMaintenance: 1}, coverages[0].WorkTypes)
+	assert.Equal(t, "Sprint 8", coverages[1].Sprint)
+	assert.Equal(t, 100.0, coverages[1].Percent())
+}
+
+func TestSprintCoverage_PercentWithoutTasks(t *testing.T) {
+	assert.Equal(t, 0.0, SprintCoverage{}.Percent())
+}
@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrEmptyEpic       = errors.New("epic key cannot be empty")
+	ErrEmptyEpicAsset  = errors.New("epic asset cannot be empty")
+	ErrInvalidEpicLink = errors.New("epic asset label must start with cap-asset-")
+)
+
+// EpicMapping links an epic to the asset its child tasks belong to
+type EpicMapping struct {
+	EpicKey string `json:"epic_key"`
+	Asset   string `json:"asset"`
+	// Label is the asset label the child tasks inherit
+	Label     string    `json:"label"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewEpicMapping creates the mapping of an epic to an asset and its task label
+func NewEpicMapping(epicKey, asset, label string) (*EpicMapping, error) {
+	if strings.TrimSpace(epicKey) == "" {
+		return nil, ErrEmptyEpic
+	}
+	if strings.TrimSpace(asset) == "" {
+		return nil, ErrEmptyEpicAsset
+	}
+	if !IsAssetLabel(label) {
+		return nil, ErrInvalidEpicLink
+	}
+	return &EpicMapping{
+		EpicKey:   strings.ToUpper(strings.TrimSpace(epicKey)),
+		Asset:     asset,
+		Label:     label,
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+// EpicMap holds the epic mappings by epic key
+type EpicMap map[string]EpicMapping
+
+// NewEpicMap indexes the mappings by epic key
+func NewEpicMap(mappings []EpicMapping) EpicMap {
+	epicMap := make(EpicMap, len(mappings))
+	for _, mapping := range mappings {
+		epicMap[mapping.EpicKey] = mapping
+	}
+	return epicMap
+}
+
+// Apply sets the asset label the task inherits from its epic. Tasks carrying an asset label
+// of their own, or whose epic is not mapped, inherit nothing. It reports whether the task
+// inherits a label.
+func (m EpicMap) Apply(task *Task) bool {
+	task.EpicAsset = ""
+	if task.hasAssetLabel() {
+		return false
+	}
+	mapping, ok := m[strings.ToUpper(task.Epic)]
+	if !ok || task.Epic == "" {
+		return false
+	}
+	task.EpicAsset = mapping.Label
+	return true
+}
+
+// UnmappedEpic is an epic without a mapping whose child tasks are not linked to an asset
+type UnmappedEpic struct {
+	EpicKey string   `json:"epic_key"`
+	Tasks   int      `json:"tasks"`
+	Sprints []string `json:"sprints"`
+}
+
+// UnmappedEpics returns the unmapped epics of the tasks updated since the given time with
+// child tasks linked to no asset, the epics with the most such tasks first
+func UnmappedEpics(tasks []*Task, epicMap EpicMap, since time.Time) []UnmappedEpic {
+	byEpic := make(map[string]*UnmappedEpic)
+	for _, task := range tasks {
+		if task.Epic == "" || task.UpdatedAt.Before(since) || len(task.AssetLabels()) > 0 {
+			continue
+		}
+		key := strings.ToUpper(task.Epic)
+		if _, mapped := epicMap[key]; mapped {
+			continue
+		}
+		epic, ok := byEpic[key]
+		if !ok {
+			epic = &UnmappedEpic{EpicKey: key}
+			byEpic[key] = epic
+		}
+		epic.Tasks++
+		if task.Sprint != "" && !containsString(epic.Sprints, task.Sprint) {
+			epic.Sprints = append(epic.Sprints, task.Sprint)
+		}
+	}
+
+	result := make([]UnmappedEpic, 0, len(byEpic))
+	for _, epic := range byEpic {
+		sort.Strings(epic.Sprints)
+		result = append(result, *epic)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Tasks != result[j].Tasks {
+			return result[i].Tasks > result[j].Tasks
+		}
+		return result[i].EpicKey < result[j].EpicKey
+	})
+	return result
+}
+
+// AssetLabels returns the asset labels linking the task to assets: its own, or else the one
+// inherited from its epic
+func (t *Task) AssetLabels() []string {
+	var labels []string
+	for _, label := range t.Labels {
+		if IsAssetLabel(label) {
+			labels = append(labels, label)
+		}
+	}
+	if len(labels) == 0 && t.EpicAsset != "" {
+		labels = append(labels, t.EpicAsset)
+	}
+	return labels
+}
+
+// containsString reports whether values holds value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEpicMapping(t *testing.T) {
+	mapping, err := NewEpicMapping(" fn-100 ", "Booking", "cap-asset-booking")
+	require.NoError(t, err)
+	assert.Equal(t, "FN-100", mapping.EpicKey)
+	assert.Equal(t, "Booking", mapping.Asset)
+	assert.Equal(t, "cap-asset-booking", mapping.Label)
+
+	_, err = NewEpicMapping("", "Booking", "cap-asset-booking")
+	assert.ErrorIs(t, err, ErrEmptyEpic)
+	_, err = NewEpicMapping("FN-100", " ", "cap-asset-booking")
+	assert.ErrorIs(t, err, ErrEmptyEpicAsset)
+	_, err = NewEpicMapping("FN-100", "Booking", "booking")
+	assert.ErrorIs(t, err, ErrInvalidEpicLink)
+}
+
+func TestEpicMap_Apply(t *testing.T) {
+	epicMap := NewEpicMap([]EpicMapping{{EpicKey: "FN-100", Asset: "Booking", Label: "cap-asset-booking"}})
+
+	child := &Task{Key: "FN-1", Epic: "fn-100", Labels: []string{"cap-development"}}
+	assert.True(t, epicMap.Apply(child))
+	assert.Equal(t, "cap-asset-booking", child.EpicAsset)
+	assert.Equal(t, []string{"cap-asset-booking"}, child.AssetLabels())
+
+	linked := &Task{Key: "FN-2", Epic: "FN-100", Labels: []string{"cap-asset-search"}, EpicAsset: "cap-asset-booking"}
+	assert.False(t, epicMap.Apply(linked))
+	assert.Empty(t, linked.EpicAsset)
+	assert.Equal(t, []string{"cap-asset-search"}, linked.AssetLabels())
+
+	other := &Task{Key: "FN-3", Epic: "FN-300"}
+	assert.False(t, epicMap.Apply(other))
+	assert.Empty(t, other.AssetLabels())
+}
+
+func TestUnmappedEpics(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	epicMap := NewEpicMap([]EpicMapping{{EpicKey: "FN-100", Label: "cap-asset-booking"}})
+	tasks := []*Task{
+		{Key: "FN-1", Epic: "FN-100", Sprint: "Sprint 1", UpdatedAt: now},
+		{Key: "FN-2", Epic: "FN-200", Sprint: "Sprint 2", UpdatedAt: now},
+		{Key: "FN-3", Epic: "FN-200", Sprint: "Sprint 1", UpdatedAt: now},
+		{Key: "FN-4", Epic: "FN-200", Sprint: "Sprint 1", UpdatedAt: now},
+		{Key: "FN-5", Epic: "FN-300", Sprint: "Sprint 2", UpdatedAt: now},
+		{Key: "FN-6", Epic: "FN-400", Labels: []string{"cap-asset-search"}, UpdatedAt: now},
+		{Key: "FN-7", Epic: "FN-500", UpdatedAt: now.AddDate(0, -6, 0)},
+		{Key: "FN-8", UpdatedAt: now},
+	}
+
+	epics := UnmappedEpics(tasks, epicMap, now.AddDate(0, 0, -90))
+
+	require.Len(t, epics, 2)
+	assert.Equal(t, UnmappedEpic{EpicKey: "FN-200", Tasks: 3, Sprints: []string{"Sprint 1", "Sprint 2"}}, epics[0])
+	assert.Equal(t, UnmappedEpic{EpicKey: "FN-300", Tasks: 1, Sprints: []string{"Sprint 2"}}, epics[1])
+}
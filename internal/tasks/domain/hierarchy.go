@@ -0,0 +1,28 @@
+package domain
+
+// HierarchyLink is one ancestor of a task, such as its epic or initiative
+type HierarchyLink struct {
+	Level string `json:"level"`
+	Key   string `json:"key"`
+}
+
+// AncestorAt returns the key of the task's ancestor at the given hierarchy level
+func (t *Task) AncestorAt(level string) string {
+	for _, link := range t.Hierarchy {
+		if link.Level == level {
+			return link.Key
+		}
+	}
+	return ""
+}
+
+// RollupByLevel groups tasks by their ancestor at the given hierarchy level.
+// Tasks without an ancestor at that level are grouped under the empty key.
+func RollupByLevel(tasks []*Task, level string) map[string][]*Task {
+	groups := make(map[string][]*Task)
+	for _, task := range tasks {
+		key := task.AncestorAt(level)
+		groups[key] = append(groups[key], task)
+	}
+	return groups
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTask_AncestorAt(t *testing.T) {
+	task := &Task{Hierarchy: []HierarchyLink{
+		{Level: "epic", Key: "FN-10"},
+		{Level: "initiative", Key: "FN-100"},
+	}}
+
+	assert.Equal(t, "FN-10", task.AncestorAt("epic"))
+	assert.Equal(t, "FN-100", task.AncestorAt("initiative"))
+	assert.Equal(t, "", task.AncestorAt("theme"))
+}
+
+func TestRollupByLevel(t *testing.T) {
+	first := &Task{Key: "FN-1", Hierarchy: []HierarchyLink{{Level: "epic", Key: "FN-10"}, {Level: "initiative", Key: "FN-100"}}}
+	second := &Task{Key: "FN-2", Hierarchy: []HierarchyLink{{Level: "epic", Key: "FN-20"}, {Level: "initiative", Key: "FN-100"}}}
+	orphan := &Task{Key: "FN-3"}
+
+	groups := RollupByLevel([]*Task{first, second, orphan}, "initiative")
+
+	assert.Equal(t, []*Task{first, second}, groups["FN-100"])
+	assert.Equal(t, []*Task{orphan}, groups[""])
+}
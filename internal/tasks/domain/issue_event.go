@@ -0,0 +1,24 @@
+package domain
+
+// IssueEventType represents the kind of change reported by a platform for an issue
+type IssueEventType string
+
+const (
+	IssueEventCreated IssueEventType = "created"
+	IssueEventUpdated IssueEventType = "updated"
+	IssueEventDeleted IssueEventType = "deleted"
+)
+
+// IssueEvent is a change to a single issue pushed by a platform, e.g. through a webhook
+type IssueEvent struct {
+	Type IssueEventType
+	Task *Task
+}
+
+// IssueEventInput represents the input parameters for applying an issue event to the local store
+type IssueEventInput struct {
+	Event      IssueEvent
+	Reclassify bool
+	// Rules are the classification rules of the assets, applied when reclassifying
+	Rules ClassificationRules
+}
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"sort"
+)
+
+// LabelCoverageInput selects the done tasks of a project's quarter and the coverage they must reach
+type LabelCoverageInput struct {
+	Project string
+	Quarter string
+	// WorkTypeThreshold and AssetThreshold are the minimum percentages of done tasks with a
+	// work type label and with an asset link; zero disables a check
+	WorkTypeThreshold float64
+	AssetThreshold    float64
+}
+
+// Validate checks the project and quarter
+func (i LabelCoverageInput) Validate() error {
+	if i.Project == "" {
+		return ErrEmptyProject
+	}
+	_, err := ParseQuarter(i.Quarter)
+	return err
+}
+
+// LabelCoverage reports how many done tasks of a project's quarter carry a work type label
+// and a link to an asset
+type LabelCoverage struct {
+	Project      string `json:"project"`
+	Quarter      string `json:"quarter"`
+	Done         int    `json:"done"`
+	WithWorkType int    `json:"withWorkType"`
+	WithAsset    int    `json:"withAsset"`
+	// WorkTypePercent and AssetPercent are zero when the quarter has no done tasks
+	WorkTypePercent   float64 `json:"workTypePercent"`
+	AssetPercent      float64 `json:"assetPercent"`
+	WorkTypeThreshold float64 `json:"workTypeThreshold"`
+	AssetThreshold    float64 `json:"assetThreshold"`
+	// Passed is set when every enabled threshold is met, or the quarter has no done tasks
+	Passed bool `json:"passed"`
+	// MissingWorkType and MissingAsset list the done tasks lacking the label, by key
+	MissingWorkType []string `json:"missingWorkType"`
+	MissingAsset    []string `json:"missingAsset"`
+}
+
+// WorkTypePassed reports whether the work type coverage meets its threshold
+func (c LabelCoverage) WorkTypePassed() bool {
+	return c.Done == 0 || c.WorkTypePercent >= c.WorkTypeThreshold
+}
+
+// AssetPassed reports whether the asset link coverage meets its threshold
+func (c LabelCoverage) AssetPassed() bool {
+	return c.Done == 0 || c.AssetPercent >= c.AssetThreshold
+}
+
+// CoverLabels measures the label coverage of the done tasks created in the input's quarter.
+// Only labels count: a work type assetcap inferred but never wrote back is not covered.
+func CoverLabels(input LabelCoverageInput, tasks []*Task) (LabelCoverage, error) {
+	if err := input.Validate(); err != nil {
+		return LabelCoverage{}, err
+	}
+	quarter, _ := ParseQuarter(input.Quarter)
+
+	coverage := LabelCoverage{
+		Project:           input.Project,
+		Quarter:           quarter.String(),
+		WorkTypeThreshold: input.WorkTypeThreshold,
+		AssetThreshold:    input.AssetThreshold,
+		MissingWorkType:   []string{},
+		MissingAsset:      []string{},
+	}
+	for _, task := range tasks {
+		if task.Project != input.Project || !task.IsDone() || !quarter.Contains(task.CreatedAt) {
+			continue
+		}
+		coverage.Done++
+		if task.hasWorkTypeLabel() {
+			coverage.WithWorkType++
+		} else {
+			coverage.MissingWorkType = append(coverage.MissingWorkType, task.Key)
+		}
+		if task.hasAssetLabel() {
+			coverage.WithAsset++
+		} else {
+			coverage.MissingAsset = append(coverage.MissingAsset, task.Key)
+		}
+	}
+	sort.Strings(coverage.MissingWorkType)
+	sort.Strings(coverage.MissingAsset)
+
+	if coverage.Done > 0 {
+		coverage.WorkTypePercent = float64(coverage.WithWorkType) / float64(coverage.Done) * 100
+		coverage.AssetPercent = float64(coverage.WithAsset) / float64(coverage.Done) * 100
+	}
+	coverage.Passed = coverage.WorkTypePassed() && coverage.AssetPassed()
+	return coverage, nil
+}
+
+// hasWorkTypeLabel reports whether one of the task's labels is a work type
+func (t *Task) hasWorkTypeLabel() bool {
+	for _, label := range t.Labels {
+		switch WorkType(label) {
+		case WorkTypeMaintenance, WorkTypeDiscovery, WorkTypeDevelopment:
+			return true
+		}
+	}
+	return false
+}
+
+// hasAssetLabel reports whether one of the task's labels links it to an asset
+func (t *Task) hasAssetLabel() bool {
+	for _, label := range t.Labels {
+		if IsAssetLabel(label) {
+			return true
+		}
+	}
+	return false
+}
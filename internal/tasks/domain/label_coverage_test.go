@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoverLabels(t *testing.T) {
+	inQuarter := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	tasks := []*Task{
+		{Key: "FN-1", Project: "FN", Status: TaskStatusDone, CreatedAt: inQuarter, Labels: []string{"cap-development", "cap-asset-booking"}},
+		{Key: "FN-2", Project: "FN", Status: TaskStatusDone, CreatedAt: inQuarter, Labels: []string{"cap-maintenance"}},
+		{Key: "FN-3", Project: "FN", Status: TaskStatusDone, CreatedAt: inQuarter, WorkType: WorkTypeDiscovery, Labels: []string{"cap-asset-search"}},
+		{Key: "FN-4", Project: "FN", Status: TaskStatusDone, CreatedAt: inQuarter, Labels: []string{"cap-discovery", "cap-asset-search"}},
+		{Key: "FN-5", Project: "FN", Status: TaskStatusInProgress, CreatedAt: inQuarter},
+		{Key: "FN-6", Project: "FN", Status: TaskStatusDone, CreatedAt: time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)},
+		{Key: "OPS-1", Project: "OPS", Status: TaskStatusDone, CreatedAt: inQuarter},
+	}
+
+	coverage, err := CoverLabels(LabelCoverageInput{Project: "FN", Quarter: "2024-Q2", WorkTypeThreshold: 75, AssetThreshold: 80}, tasks)
+	require.NoError(t, err)
+	assert.Equal(t, "2024-Q2", coverage.Quarter)
+	assert.Equal(t, 4, coverage.Done)
+	assert.Equal(t, 3, coverage.WithWorkType)
+	assert.Equal(t, 3, coverage.WithAsset)
+	assert.Equal(t, 75.0, coverage.WorkTypePercent)
+	assert.Equal(t, 75.0, coverage.AssetPercent)
+	assert.Equal(t, []string{"FN-3"}, coverage.MissingWorkType, "a work type without its label is not covered")
+	assert.Equal(t, []string{"FN-2"}, coverage.MissingAsset)
+	assert.True(t, coverage.WorkTypePassed())
+	assert.False(t, coverage.AssetPassed())
+	assert.False(t, coverage.Passed)
+}
+
+func TestCoverLabels_NoDoneTasks(t *testing.T) {
+	coverage, err := CoverLabels(LabelCoverageInput{Project: "FN", Quarter: "2024-Q2", WorkTypeThreshold: 90}, nil)
+	require.NoError(t, err)
+	assert.Zero(t, coverage.Done)
+	assert.Zero(t, coverage.WorkTypePercent)
+	assert.True(t, coverage.Passed)
+}
+
+func TestCoverLabels_InvalidInput(t *testing.T) {
+	_, err := CoverLabels(LabelCoverageInput{Quarter: "2024-Q2"}, nil)
+	assert.ErrorIs(t, err, ErrEmptyProject)
+
+	_, err = CoverLabels(LabelCoverageInput{Project: "FN", Quarter: "Q2"}, nil)
+	assert.ErrorIs(t, err, ErrInvalidQuarter)
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// LabelChangeAction tells additions and removals of a label apart
+type LabelChangeAction string
+
+const (
+	LabelAdded   LabelChangeAction = "added"
+	LabelRemoved LabelChangeAction = "removed"
+)
+
+// LabelChange is a cap-* label added to or removed from an issue, as recorded in its changelog
+type LabelChange struct {
+	Label  string            `json:"label"`
+	Action LabelChangeAction `json:"action"`
+	// Author is the display name of the user who changed the labels
+	Author string    `json:"author,omitempty"`
+	At     time.Time `json:"at"`
+}
+
+// LabelChanges returns the cap-* labels added and removed by a change of the labels field
+// from one space-separated list to another, by label
+func LabelChanges(from, to, author string, at time.Time) []LabelChange {
+	before := capLabels(strings.Fields(from))
+	after := capLabels(strings.Fields(to))
+
+	var changes []LabelChange
+	for label := range after {
+		if !before[label] {
+			changes = append(changes, LabelChange{Label: label, Action: LabelAdded, Author: author, At: at})
+		}
+	}
+	for label := range before {
+		if !after[label] {
+			changes = append(changes, LabelChange{Label: label, Action: LabelRemoved, Author: author, At: at})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Label < changes[j].Label })
+	return changes
+}
+
+// MergeLabelHistory combines the label changes already recorded with newly read ones,
+// dropping duplicates, oldest first. Changes Jira no longer returns are kept.
+func MergeLabelHistory(recorded, read []LabelChange) []LabelChange {
+	seen := make(map[LabelChange]bool, len(recorded)+len(read))
+	var merged []LabelChange
+	for _, change := range append(append([]LabelChange{}, recorded...), read...) {
+		key := change
+		key.At = change.At.UTC()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		merged = append(merged, change)
+	}
+	sort.SliceStable(merged, func(i, j int) bool { return merged[i].At.Before(merged[j].At) })
+	return merged
+}
+
+// LabelHistory is the classification label history of an issue
+type LabelHistory struct {
+	IssueKey string `json:"issueKey"`
+	Summary  string `json:"summary"`
+	// Labels are the cap-* labels the issue carries now
+	Labels []string `json:"labels"`
+	// Changes are the recorded cap-* label changes, oldest first
+	Changes []LabelChange `json:"changes"`
+}
+
+// NewLabelHistory returns the classification label history recorded for a task
+func NewLabelHistory(task *Task) *LabelHistory {
+	history := &LabelHistory{
+		IssueKey: task.Key,
+		Summary:  task.Summary,
+		Labels:   []string{},
+		Changes:  MergeLabelHistory(nil, task.LabelHistory),
+	}
+	for _, label := range task.Labels {
+		if strings.HasPrefix(label, "cap-") {
+			history.Labels = append(history.Labels, label)
+		}
+	}
+	if history.Changes == nil {
+		history.Changes = []LabelChange{}
+	}
+	return history
+}
+
+// capLabels returns the set of cap-* labels in a label list
+func capLabels(labels []string) map[string]bool {
+	set := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		if strings.HasPrefix(label, "cap-") {
+			set[label] = true
+		}
+	}
+	return set
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelChanges(t *testing.T) {
+	at := time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC)
+
+	changes := LabelChanges("backend cap-development", "backend cap-maintenance cap-asset-booking", "Jane Doe", at)
+
+	assert.Equal(t, []LabelChange{
+		{Label: "cap-asset-booking", Action: LabelAdded, Author: "Jane Doe", At: at},
+		{Label: "cap-development", Action: LabelRemoved, Author: "Jane Doe", At: at},
+		{Label: "cap-maintenance", Action: LabelAdded, Author: "Jane Doe", At: at},
+	}, changes)
+	assert.Empty(t, LabelChanges("backend", "backend frontend", "Jane Doe", at), "other labels are ignored")
+}
+
+func TestMergeLabelHistory(t *testing.T) {
+	first := LabelChange{Label: "cap-development", Action: LabelAdded, Author: "Jane Doe", At: time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)}
+	second := LabelChange{Label: "cap-development", Action: LabelRemoved, Author: "John Roe", At: time.Date(2024, 3, 8, 9, 0, 0, 0, time.UTC)}
+	sameInstant := second
+	sameInstant.At = second.At.In(time.FixedZone("CET", 3600))
+
+	merged := MergeLabelHistory([]LabelChange{first, second}, []LabelChange{sameInstant})
+	assert.Equal(t, []LabelChange{first, second}, merged, "the same change read again is kept once")
+
+	merged = MergeLabelHistory([]LabelChange{second}, []LabelChange{first})
+	assert.Equal(t, []LabelChange{first, second}, merged, "changes are sorted oldest first")
+
+	assert.Nil(t, MergeLabelHistory(nil, nil))
+}
+
+func TestNewLabelHistory(t *testing.T) {
+	change := LabelChange{Label: "cap-maintenance", Action: LabelAdded, Author: "Jane Doe", At: time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)}
+	task := &Task{Key: "FN-123", Summary: "Fix login", Labels: []string{"backend", "cap-maintenance"}, LabelHistory: []LabelChange{change}}
+
+	history := NewLabelHistory(task)
+
+	assert.Equal(t, &LabelHistory{
+		IssueKey: "FN-123",
+		Summary:  "Fix login",
+		Labels:   []string{"cap-maintenance"},
+		Changes:  []LabelChange{change},
+	}, history)
+
+	empty := NewLabelHistory(&Task{Key: "FN-1"})
+	assert.NotNil(t, empty.Labels)
+	assert.NotNil(t, empty.Changes)
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LabelPolicy guards the labels written back to the issue tracker. Patterns match a label
+// exactly or, when ending in "*", by prefix.
+type LabelPolicy struct {
+	// Managed are the labels classification may add or remove; empty means the work type labels
+	Managed []string
+	// Protected labels are never removed, not even when replacing all labels
+	Protected []string
+}
+
+// Merge returns the labels of an issue carrying current once the classification labels are
+// applied: managed labels are swapped for the new ones and every other label is kept
+func (p LabelPolicy) Merge(current, labels []string) ([]string, error) {
+	for _, label := range labels {
+		if !p.isManaged(label) {
+			return nil, fmt.Errorf("label %s is outside the managed label taxonomy", label)
+		}
+	}
+
+	merged := make([]string, 0, len(current)+len(labels))
+	for _, label := range current {
+		if p.isManaged(label) && !p.isProtected(label) {
+			continue
+		}
+		merged = appendLabel(merged, label)
+	}
+	for _, label := range labels {
+		merged = appendLabel(merged, label)
+	}
+	return merged, nil
+}
+
+// Replace returns the classification labels, keeping the protected labels of current
+func (p LabelPolicy) Replace(current, labels []string) []string {
+	replaced := make([]string, 0, len(current)+len(labels))
+	for _, label := range current {
+		if p.isProtected(label) {
+			replaced = appendLabel(replaced, label)
+		}
+	}
+	for _, label := range labels {
+		replaced = appendLabel(replaced, label)
+	}
+	return replaced
+}
+
+func (p LabelPolicy) isManaged(label string) bool {
+	if len(p.Managed) == 0 {
+		switch WorkType(label) {
+		case WorkTypeMaintenance, WorkTypeDiscovery, WorkTypeDevelopment:
+			return true
+		}
+		return false
+	}
+	return matchesAnyLabel(p.Managed, label)
+}
+
+func (p LabelPolicy) isProtected(label string) bool {
+	return matchesAnyLabel(p.Protected, label)
+}
+
+// matchesAnyLabel reports whether the label matches one of the patterns
+func matchesAnyLabel(patterns []string, label string) bool {
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(label, prefix) {
+				return true
+			}
+			continue
+		}
+		if pattern == label {
+			return true
+		}
+	}
+	return false
+}
+
+// appendLabel appends the label unless it is already present
+func appendLabel(labels []string, label string) []string {
+	for _, existing := range labels {
+		if existing == label {
+			return labels
+		}
+	}
+	return append(labels, label)
+}
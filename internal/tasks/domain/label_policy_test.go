@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelPolicy_Merge(t *testing.T) {
+	t.Run("keeps unrelated labels and swaps the work type", func(t *testing.T) {
+		labels, err := LabelPolicy{}.Merge([]string{"team-a", "cap-maintenance", "cap-asset-booking"}, []string{"cap-development"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"team-a", "cap-asset-booking", "cap-development"}, labels)
+	})
+
+	t.Run("keeps protected managed labels", func(t *testing.T) {
+		policy := LabelPolicy{Managed: []string{"cap-*"}, Protected: []string{"cap-asset-*"}}
+
+		labels, err := policy.Merge([]string{"team-a", "cap-maintenance", "cap-asset-booking"}, []string{"cap-development"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"team-a", "cap-asset-booking", "cap-development"}, labels)
+	})
+
+	t.Run("rejects labels outside the taxonomy", func(t *testing.T) {
+		_, err := LabelPolicy{Managed: []string{"cap-maintenance"}}.Merge(nil, []string{"cap-development"})
+
+		assert.EqualError(t, err, "label cap-development is outside the managed label taxonomy")
+	})
+
+	t.Run("does not duplicate labels", func(t *testing.T) {
+		labels, err := LabelPolicy{}.Merge([]string{"cap-development"}, []string{"cap-development"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"cap-development"}, labels)
+	})
+}
+
+func TestLabelPolicy_Replace(t *testing.T) {
+	policy := LabelPolicy{Protected: []string{"security", "cap-asset-*"}}
+
+	labels := policy.Replace([]string{"team-a", "security", "cap-asset-booking", "cap-maintenance"}, []string{"cap-development"})
+
+	assert.Equal(t, []string{"security", "cap-asset-booking", "cap-development"}, labels)
+}
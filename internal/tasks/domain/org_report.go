@@ -0,0 +1,81 @@
+package domain
+
+import "errors"
+
+var ErrNoProjects = errors.New("at least one project is required")
+
+// OrgReportInput represents the input parameters for an organization-wide rollup
+type OrgReportInput struct {
+	// Projects are the team projects rolled up, in report order
+	Projects []string
+	Quarter  string
+}
+
+// Validate checks the rollup parameters
+func (i OrgReportInput) Validate() error {
+	if len(i.Projects) == 0 {
+		return ErrNoProjects
+	}
+	if _, err := ParseQuarter(i.Quarter); err != nil {
+		return err
+	}
+	return nil
+}
+
+// TeamRollup summarizes the classified tasks of one team created within a quarter. Split
+// tasks count towards each work type by their share.
+type TeamRollup struct {
+	Team         string               `json:"team"`
+	Quarter      string               `json:"quarter"`
+	Tasks        int                  `json:"tasks"`
+	Unclassified int                  `json:"unclassified"`
+	WorkTypes    map[WorkType]float64 `json:"work_types"`
+	// Commits and PullRequests count the code changes referencing the tasks
+	Commits      int `json:"commits"`
+	PullRequests int `json:"pull_requests"`
+	// DevelopmentWithoutCode counts the development tasks no code change references
+	DevelopmentWithoutCode int `json:"development_without_code"`
+	// Fingerprint identifies the stored tasks and splits the rollup was computed from
+	Fingerprint string `json:"fingerprint"`
+}
+
+// Classified returns the number of tasks with a work type
+func (r TeamRollup) Classified() int {
+	return r.Tasks - r.Unclassified
+}
+
+// Share returns the percentage of the classified tasks counted as the work type
+func (r TeamRollup) Share(workType WorkType) float64 {
+	if r.Classified() == 0 {
+		return 0
+	}
+	return r.WorkTypes[workType] / float64(r.Classified()) * 100
+}
+
+// OrgReport rolls the team summaries of a quarter up to the organization
+type OrgReport struct {
+	Quarter string
+	Teams   []TeamRollup
+	Overall TeamRollup
+	// Cached counts the teams whose rollup was reused because their tasks had not changed
+	Cached int
+}
+
+// RollupTeams aggregates the team rollups into the organization total
+func RollupTeams(quarter string, teams []TeamRollup) TeamRollup {
+	overall := TeamRollup{Team: "organization", Quarter: quarter, WorkTypes: make(map[WorkType]float64)}
+	for _, team := range teams {
+		overall.Tasks += team.Tasks
+		overall.Unclassified += team.Unclassified
+		overall.Commits += team.Commits
+		overall.PullRequests += team.PullRequests
+		overall.DevelopmentWithoutCode += team.DevelopmentWithoutCode
+		for workType, count := range team.WorkTypes {
+			overall.WorkTypes[workType] += count
+		}
+	}
+	return overall
+}
+
+// ReportWorkTypes are the capitalization work types in report order
+var ReportWorkTypes = []WorkType{WorkTypeDevelopment, WorkTypeMaintenance, WorkTypeDiscovery}
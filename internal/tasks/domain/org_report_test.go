@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrgReportInput_Validate(t *testing.T) {
+	assert.NoError(t, OrgReportInput{Projects: []string{"FN"}, Quarter: "2024-Q2"}.Validate())
+	assert.ErrorIs(t, OrgReportInput{Quarter: "2024-Q2"}.Validate(), ErrNoProjects)
+	assert.ErrorIs(t, OrgReportInput{Projects: []string{"FN"}, Quarter: "2024"}.Validate(), ErrInvalidQuarter)
+}
+
+func TestTeamRollup_Share(t *testing.T) {
+	rollup := TeamRollup{Tasks: 5, Unclassified: 1, WorkTypes: map[WorkType]float64{WorkTypeDevelopment: 3, WorkTypeMaintenance: 1}}
+
+	assert.Equal(t, 4, rollup.Classified())
+	assert.Equal(t, 75.0, rollup.Share(WorkTypeDevelopment))
+	assert.Equal(t, 0.0, rollup.Share(WorkTypeDiscovery))
+	assert.Equal(t, 0.0, TeamRollup{}.Share(WorkTypeDevelopment))
+}
+
+func TestRollupTeams(t *testing.T) {
+	overall := RollupTeams("2024-Q2", []TeamRollup{
+		{Team: "FN", Tasks: 4, Unclassified: 1, WorkTypes: map[WorkType]float64{WorkTypeDevelopment: 2.5, WorkTypeDiscovery: 0.5}},
+		{Team: "OPS", Tasks: 2, WorkTypes: map[WorkType]float64{WorkTypeDevelopment: 1, WorkTypeMaintenance: 1}},
+	})
+
+	assert.Equal(t, "organization", overall.Team)
+	assert.Equal(t, "2024-Q2", overall.Quarter)
+	assert.Equal(t, 6, overall.Tasks)
+	assert.Equal(t, 1, overall.Unclassified)
+	assert.Equal(t, map[WorkType]float64{WorkTypeDevelopment: 3.5, WorkTypeDiscovery: 0.5, WorkTypeMaintenance: 1}, overall.WorkTypes)
+}
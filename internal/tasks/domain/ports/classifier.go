@@ -10,3 +10,23 @@ type TaskClassifier interface {
 	// ClassifyTasks determines the work type for multiple tasks
 	ClassifyTasks(tasks []*domain.Task) (map[string]domain.WorkType, error)
 }
+
+// PromptedClassifier is a TaskClassifier that takes additional instructions per task, such as
+// the prompt additions of the asset a task is linked to
+type PromptedClassifier interface {
+	TaskClassifier
+
+	// ClassifyTasksWithPrompts determines the work type for multiple tasks, following the
+	// instructions given for each task key
+	ClassifyTasksWithPrompts(tasks []*domain.Task, prompts map[string]string) (map[string]domain.WorkType, error)
+}
+
+// ExplainingClassifier is a TaskClassifier that can tell why it gave a task its work type and
+// how likely the work type is to be right
+type ExplainingClassifier interface {
+	TaskClassifier
+
+	// Explain returns the rationale and the confidence, from 0 to 1, of a work type the
+	// classifier gave to a task
+	Explain(task *domain.Task, workType domain.WorkType) (string, float64)
+}
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// CodeHost defines the interface of code hosting platforms whose commits and pull requests
+// can be linked to tasks
+type CodeHost interface {
+	// ListChanges returns the commits and pull requests of the configured repositories
+	// created or updated since the given time
+	ListChanges(ctx context.Context, since time.Time) ([]domain.CodeChange, error)
+}
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// EpicMapRepository defines the interface for persisting the epic to asset mappings
+type EpicMapRepository interface {
+	// SaveEpicMapping persists a mapping, replacing any previous mapping of the same epic
+	SaveEpicMapping(ctx context.Context, mapping domain.EpicMapping) error
+	// DeleteEpicMapping removes the mapping of an epic
+	DeleteEpicMapping(ctx context.Context, epicKey string) error
+	// FindEpicMappings returns every stored mapping, ordered by epic key
+	FindEpicMappings(ctx context.Context) ([]domain.EpicMapping, error)
+}
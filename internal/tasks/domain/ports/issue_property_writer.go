@@ -0,0 +1,10 @@
+package ports
+
+import "context"
+
+// IssuePropertyWriter defines the interface of remote repositories that can store data on an
+// issue outside of its fields, such as Jira entity properties
+type IssuePropertyWriter interface {
+	// SetIssueProperty stores value, encoded as JSON, under key on the issue
+	SetIssueProperty(ctx context.Context, issueKey, key string, value any) error
+}
@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// RollupCache defines the interface for caching the team rollups of organization reports
+type RollupCache interface {
+	// FindRollup retrieves the cached rollup of a team and quarter, or nil when none is cached
+	FindRollup(ctx context.Context, team, quarter string) (*domain.TeamRollup, error)
+	// SaveRollup caches a rollup, replacing any previous one of the same team and quarter
+	SaveRollup(ctx context.Context, rollup domain.TeamRollup) error
+}
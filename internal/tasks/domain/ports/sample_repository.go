@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// SampleRepository defines the interface for persisting audit sample definitions
+type SampleRepository interface {
+	// SaveSample persists a sample definition
+	SaveSample(ctx context.Context, definition domain.SampleDefinition) error
+	// FindSample retrieves a sample definition by its ID
+	FindSample(ctx context.Context, id string) (*domain.SampleDefinition, error)
+}
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// TaskAssetFinder defines the interface of repositories that look tasks up by their asset
+// without scanning every task
+type TaskAssetFinder interface {
+	// FindByAsset retrieves the tasks linked to an asset, given its name or task label
+	FindByAsset(ctx context.Context, asset string) ([]*domain.Task, error)
+}
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// TaskNoteRepository defines the interface for persisting the notes analysts leave on tasks
+type TaskNoteRepository interface {
+	// SaveNote appends a note to the notes of its issue
+	SaveNote(ctx context.Context, note domain.TaskNote) error
+	// FindNotes returns the notes of the given issues keyed by issue, oldest first;
+	// no issue keys returns every note
+	FindNotes(ctx context.Context, issueKeys ...string) (map[string][]domain.TaskNote, error)
+}
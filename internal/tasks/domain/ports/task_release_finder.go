@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// TaskReleaseFinder defines the interface of repositories that can find tasks by the
+// release they are shipped in rather than the sprint they were worked on
+type TaskReleaseFinder interface {
+	// FindByProjectAndFixVersion finds all tasks for a given project and fix version
+	FindByProjectAndFixVersion(ctx context.Context, project, fixVersion string) ([]*domain.Task, error)
+}
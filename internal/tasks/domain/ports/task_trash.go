@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// TaskTrash defines the interface of repositories that keep deleted tasks for a while
+type TaskTrash interface {
+	// FindDeleted returns the deleted tasks that have not expired yet, most recent first
+	FindDeleted(ctx context.Context) ([]*domain.DeletedTask, error)
+	// Restore moves a deleted task back into the task store
+	Restore(ctx context.Context, key string) (*domain.Task, error)
+	// PurgeByProjectAndSprint permanently removes the tasks of a project and sprint,
+	// from the task store and the trash
+	PurgeByProjectAndSprint(ctx context.Context, project, sprint string) error
+}
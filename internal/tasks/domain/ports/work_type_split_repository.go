@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// WorkTypeSplitRepository defines the interface for persisting per-issue work type splits
+type WorkTypeSplitRepository interface {
+	// SaveSplit persists a split, replacing any previous split of the same issue
+	SaveSplit(ctx context.Context, split domain.WorkTypeSplit) error
+	// DeleteSplit removes the split of an issue
+	DeleteSplit(ctx context.Context, issueKey string) error
+	// FindSplits returns every stored split, ordered by issue key
+	FindSplits(ctx context.Context) ([]domain.WorkTypeSplit, error)
+}
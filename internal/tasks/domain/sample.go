@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrInvalidQuarter     = errors.New("quarter must be in the form YYYY-QN (e.g. 2024-Q2)")
+	ErrInvalidSampleSize  = errors.New("sample size must be greater than zero")
+	ErrInvalidStratifyKey = errors.New("invalid stratify key")
+)
+
+// StratifyWorkType draws the sample proportionally from each work type
+const StratifyWorkType = "worktype"
+
+var quarterPattern = regexp.MustCompile(`^(\d{4})-Q([1-4])$`)
+
+// Quarter represents a calendar quarter such as 2024-Q2
+type Quarter struct {
+	Year   int
+	Number int
+}
+
+// ParseQuarter parses a quarter in the form YYYY-QN
+func ParseQuarter(value string) (Quarter, error) {
+	matches := quarterPattern.FindStringSubmatch(value)
+	if matches == nil {
+		return Quarter{}, ErrInvalidQuarter
+	}
+	year, _ := strconv.Atoi(matches[1])
+	number, _ := strconv.Atoi(matches[2])
+	return Quarter{Year: year, Number: number}, nil
+}
+
+// String returns the quarter in the form YYYY-QN
+func (q Quarter) String() string {
+	return fmt.Sprintf("%d-Q%d", q.Year, q.Number)
+}
+
+// Start returns the first instant of the quarter in UTC
+func (q Quarter) Start() time.Time {
+	return time.Date(q.Year, time.Month((q.Number-1)*3+1), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// End returns the first instant after the quarter in UTC
+func (q Quarter) End() time.Time {
+	return q.Start().AddDate(0, 3, 0)
+}
+
+// Contains reports whether t falls within the quarter
+func (q Quarter) Contains(t time.Time) bool {
+	return !t.Before(q.Start()) && t.Before(q.End())
+}
+
+// SampleTasksInput represents the input parameters for sampling classified tasks
+type SampleTasksInput struct {
+	Project  string
+	Quarter  string
+	Size     int
+	Stratify string
+	Seed     int64
+}
+
+// Validate checks the sampling parameters
+func (i SampleTasksInput) Validate() error {
+	if i.Project == "" {
+		return ErrEmptyProject
+	}
+	if _, err := ParseQuarter(i.Quarter); err != nil {
+		return err
+	}
+	if i.Size <= 0 {
+		return ErrInvalidSampleSize
+	}
+	if i.Stratify != "" && i.Stratify != StratifyWorkType {
+		return fmt.Errorf("%w: %s", ErrInvalidStratifyKey, i.Stratify)
+	}
+	return nil
+}
+
+// SampleDefinition records how a sample was drawn so it can be reproduced and verified
+type SampleDefinition struct {
+	ID         string    `json:"id"`
+	Project    string    `json:"project"`
+	Quarter    string    `json:"quarter"`
+	Size       int       `json:"size"`
+	Stratify   string    `json:"stratify,omitempty"`
+	Seed       int64     `json:"seed"`
+	Population int       `json:"population"`
+	TaskKeys   []string  `json:"task_keys"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TaskSample is a reproducible random sample of classified tasks
+type TaskSample struct {
+	Definition SampleDefinition
+	Tasks      []*Task
+	// Notes holds the analysts' notes on the sampled tasks, keyed by issue
+	Notes map[string][]TaskNote
+}
+
+// ClassificationRationale explains where the work type of a task comes from
+func (t *Task) ClassificationRationale() string {
+	if t.WorkType == "" {
+		return "not classified"
+	}
+	for _, label := range t.Labels {
+		if label == string(t.WorkType) {
+			return fmt.Sprintf("labelled %s in %s", t.WorkType, t.Platform)
+		}
+	}
+	return fmt.Sprintf("classified as %s by assetcap", t.WorkType)
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuarter(t *testing.T) {
+	quarter, err := ParseQuarter("2024-Q2")
+	require.NoError(t, err)
+	assert.Equal(t, "2024-Q2", quarter.String())
+	assert.Equal(t, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), quarter.Start())
+	assert.Equal(t, time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), quarter.End())
+	assert.True(t, quarter.Contains(time.Date(2024, 6, 30, 23, 59, 0, 0, time.UTC)))
+	assert.False(t, quarter.Contains(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)))
+
+	for _, value := range []string{"2024-Q5", "2024Q2", "Q2-2024", ""} {
+		_, err := ParseQuarter(value)
+		assert.ErrorIs(t, err, ErrInvalidQuarter, value)
+	}
+}
+
+func TestSampleTasksInput_Validate(t *testing.T) {
+	valid := SampleTasksInput{Project: "FN", Quarter: "2024-Q2", Size: 25, Stratify: StratifyWorkType}
+	assert.NoError(t, valid.Validate())
+
+	noProject := valid
+	noProject.Project = ""
+	assert.ErrorIs(t, noProject.Validate(), ErrEmptyProject)
+
+	noSize := valid
+	noSize.Size = 0
+	assert.ErrorIs(t, noSize.Validate(), ErrInvalidSampleSize)
+
+	badStratify := valid
+	badStratify.Stratify = "assignee"
+	assert.ErrorIs(t, badStratify.Validate(), ErrInvalidStratifyKey)
+}
+
+func TestTask_ClassificationRationale(t *testing.T) {
+	task := &Task{Platform: "jira"}
+	assert.Equal(t, "not classified", task.ClassificationRationale())
+
+	task.WorkType = WorkTypeMaintenance
+	assert.Equal(t, "classified as cap-maintenance by assetcap", task.ClassificationRationale())
+
+	task.Labels = []string{"cap-maintenance"}
+	assert.Equal(t, "labelled cap-maintenance in jira", task.ClassificationRationale())
+}
@@ -63,18 +63,33 @@ type Task struct {
 	Key         string       `json:"key"`
 	Summary     string       `json:"summary"`
 	Description string       `json:"description"`
+	Comments    []string     `json:"comments,omitempty"`
 	Project     string       `json:"project"`
 	Sprint      string       `json:"sprint"`
+	FixVersions []string     `json:"fix_versions,omitempty"`
 	Platform    string       `json:"platform"`
 	Status      TaskStatus   `json:"status"`
 	Type        TaskType     `json:"type"`
 	Priority    TaskPriority `json:"priority"`
 	WorkType    WorkType     `json:"work_type"`
-	Labels      []string     `json:"labels"`
-	Epic        string       `json:"epic"`
-	CreatedAt   time.Time    `json:"created_at"`
-	UpdatedAt   time.Time    `json:"updated_at"`
-	Version     int          `json:"version"`
+	// Classification tells why the task got its work type and how confident the classifier was
+	Classification *Classification `json:"classification,omitempty"`
+	Labels         []string        `json:"labels"`
+	Epic           string          `json:"epic"`
+	Hierarchy      []HierarchyLink `json:"hierarchy,omitempty"`
+	// EpicAsset is the asset label inherited from the epic map when the task carries none
+	EpicAsset string `json:"epic_asset,omitempty"`
+	// CodeReferences are the commits and pull requests mentioning the task's key
+	CodeReferences []CodeReference `json:"code_references,omitempty"`
+	// OriginalEstimate and RemainingEstimate are the platform's time estimates in hours, nil
+	// when the task is not estimated
+	OriginalEstimate  *float64 `json:"original_estimate,omitempty"`
+	RemainingEstimate *float64 `json:"remaining_estimate,omitempty"`
+	// LabelHistory are the cap-* label changes read from the platform's changelog
+	LabelHistory []LabelChange `json:"label_history,omitempty"`
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+	Version      int           `json:"version"`
 }
 
 // NewTask creates a new task with the given parameters
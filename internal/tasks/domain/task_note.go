@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrEmptyNote is returned when a note has no text
+var ErrEmptyNote = errors.New("task note cannot be empty")
+
+// TaskNote is an analyst's timestamped remark on a task, e.g. who confirmed its work type
+type TaskNote struct {
+	IssueKey  string    `json:"issue_key"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewTaskNote creates a note on an issue written at the given time
+func NewTaskNote(issueKey, text string, at time.Time) (*TaskNote, error) {
+	if issueKey == "" {
+		return nil, ErrEmptyKey
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, ErrEmptyNote
+	}
+	return &TaskNote{
+		IssueKey:  strings.ToUpper(issueKey),
+		Text:      text,
+		CreatedAt: at,
+	}, nil
+}
+
+// String describes the note with its timestamp, e.g. "2024-05-02 14:30: confirmed with tech lead"
+func (n TaskNote) String() string {
+	return fmt.Sprintf("%s: %s", n.CreatedAt.Format("2006-01-02 15:04"), n.Text)
+}
+
+// JoinNotes writes the notes on one line, oldest first, for exports
+func JoinNotes(notes []TaskNote) string {
+	parts := make([]string, 0, len(notes))
+	for _, note := range notes {
+		parts = append(parts, note.String())
+	}
+	return strings.Join(parts, " | ")
+}
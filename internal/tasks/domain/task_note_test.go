@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTaskNote(t *testing.T) {
+	at := time.Date(2024, 5, 2, 14, 30, 0, 0, time.UTC)
+
+	note, err := NewTaskNote("fn-123", "  confirmed with tech lead: maintenance ", at)
+
+	require.NoError(t, err)
+	assert.Equal(t, "FN-123", note.IssueKey)
+	assert.Equal(t, "confirmed with tech lead: maintenance", note.Text)
+	assert.Equal(t, "2024-05-02 14:30: confirmed with tech lead: maintenance", note.String())
+
+	_, err = NewTaskNote("", "text", at)
+	assert.ErrorIs(t, err, ErrEmptyKey)
+	_, err = NewTaskNote("FN-123", " ", at)
+	assert.ErrorIs(t, err, ErrEmptyNote)
+}
+
+func TestJoinNotes(t *testing.T) {
+	notes := []TaskNote{
+		{Text: "first", CreatedAt: time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)},
+		{Text: "second", CreatedAt: time.Date(2024, 5, 3, 10, 15, 0, 0, time.UTC)},
+	}
+
+	assert.Equal(t, "2024-05-02 09:00: first | 2024-05-03 10:15: second", JoinNotes(notes))
+	assert.Empty(t, JoinNotes(nil))
+}
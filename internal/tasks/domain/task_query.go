@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// TaskSort orders listed tasks
+type TaskSort string
+
+const (
+	// TaskSortKey orders tasks by project and issue number
+	TaskSortKey TaskSort = "key"
+	// TaskSortStatus orders tasks through the workflow, from to do to done
+	TaskSortStatus TaskSort = "status"
+	// TaskSortUpdated lists the most recently updated tasks first
+	TaskSortUpdated TaskSort = "updated"
+)
+
+// statusOrder ranks the statuses through the workflow
+var statusOrder = map[TaskStatus]int{
+	TaskStatusTodo:       0,
+	TaskStatusInProgress: 1,
+	TaskStatusBlocked:    2,
+	TaskStatusDone:       3,
+}
+
+// ParseTaskSort parses a sort order; an empty value sorts by key
+func ParseTaskSort(value string) (TaskSort, error) {
+	switch order := TaskSort(strings.ToLower(strings.TrimSpace(value))); order {
+	case "":
+		return TaskSortKey, nil
+	case TaskSortKey, TaskSortStatus, TaskSortUpdated:
+		return order, nil
+	default:
+		return "", fmt.Errorf("invalid sort %q: must be key, status or updated", value)
+	}
+}
+
+// TaskFilter selects tasks by status, type and work type. Empty criteria match every task;
+// Unclassified adds the tasks without a work type to the work types matched.
+type TaskFilter struct {
+	Statuses     []TaskStatus
+	Types        []TaskType
+	WorkTypes    []WorkType
+	Unclassified bool
+}
+
+// NewTaskFilter builds a filter from status, type and work type names. Statuses and types
+// match regardless of case, spaces or dashes ("in progress" is IN_PROGRESS, "sub-task" is
+// SUBTASK) and work types may omit their cap- prefix.
+func NewTaskFilter(statuses, types, workTypes []string, unclassified bool) (TaskFilter, error) {
+	filter := TaskFilter{Unclassified: unclassified}
+	for _, value := range statuses {
+		status := TaskStatus(normalizeEnum(value))
+		if _, ok := statusOrder[status]; !ok {
+			return TaskFilter{}, fmt.Errorf("%w: %q", ErrInvalidStatus, value)
+		}
+		filter.Statuses = append(filter.Statuses, status)
+	}
+	for _, value := range types {
+		taskType := TaskType(strings.ReplaceAll(normalizeEnum(value), "_", ""))
+		switch taskType {
+		case TaskTypeStory, TaskTypeTask, TaskTypeBug, TaskTypeEpic, TaskTypeSubtask:
+		default:
+			return TaskFilter{}, fmt.Errorf("%w: %q", ErrInvalidType, value)
+		}
+		filter.Types = append(filter.Types, taskType)
+	}
+	for _, value := range workTypes {
+		workType := WorkType(strings.ToLower(strings.TrimSpace(value)))
+		if !strings.HasPrefix(string(workType), "cap-") {
+			workType = "cap-" + workType
+		}
+		switch workType {
+		case WorkTypeDevelopment, WorkTypeMaintenance, WorkTypeDiscovery:
+		default:
+			return TaskFilter{}, fmt.Errorf("%w: %q", ErrInvalidWorkType, value)
+		}
+		filter.WorkTypes = append(filter.WorkTypes, workType)
+	}
+	return filter, nil
+}
+
+// normalizeEnum upper-cases a status or type name and joins its words with underscores
+func normalizeEnum(value string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToUpper(value), func(r rune) bool {
+		return r == ' ' || r == '-' || r == '_'
+	}), "_")
+}
+
+// Matches reports whether the task meets every criterion of the filter
+func (f TaskFilter) Matches(task *Task) bool {
+	if len(f.Statuses) > 0 && !slices.Contains(f.Statuses, task.Status) {
+		return false
+	}
+	if len(f.Types) > 0 && !slices.Contains(f.Types, task.Type) {
+		return false
+	}
+	if len(f.WorkTypes) > 0 || f.Unclassified {
+		return slices.Contains(f.WorkTypes, task.WorkType) || (f.Unclassified && task.WorkType == "")
+	}
+	return true
+}
+
+// Apply returns the tasks matching the filter, in their original order
+func (f TaskFilter) Apply(tasks []*Task) []*Task {
+	var matched []*Task
+	for _, task := range tasks {
+		if f.Matches(task) {
+			matched = append(matched, task)
+		}
+	}
+	return matched
+}
+
+// SortTasks orders the tasks in place, breaking ties by key
+func SortTasks(tasks []*Task, by TaskSort) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		a, b := tasks[i], tasks[j]
+		switch by {
+		case TaskSortStatus:
+			if statusOrder[a.Status] != statusOrder[b.Status] {
+				return statusOrder[a.Status] < statusOrder[b.Status]
+			}
+		case TaskSortUpdated:
+			if !a.UpdatedAt.Equal(b.UpdatedAt) {
+				return a.UpdatedAt.After(b.UpdatedAt)
+			}
+		}
+		return keyLess(a.Key, b.Key)
+	})
+}
+
+// keyLess orders issue keys by project, then numerically by issue number, so FN-9 precedes FN-10
+func keyLess(a, b string) bool {
+	projectA, numberA, okA := splitKey(a)
+	projectB, numberB, okB := splitKey(b)
+	if !okA || !okB || projectA != projectB {
+		return a < b
+	}
+	return numberA < numberB
+}
+
+// splitKey splits an issue key into its project and number
+func splitKey(key string) (string, int, bool) {
+	i := strings.LastIndex(key, "-")
+	if i < 0 {
+		return "", 0, false
+	}
+	number, err := strconv.Atoi(key[i+1:])
+	if err != nil {
+		return "", 0, false
+	}
+	return key[:i], number, true
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTaskFilter(t *testing.T) {
+	filter, err := NewTaskFilter([]string{"In Progress", "done"}, []string{"sub-task"}, []string{"development", "cap-discovery"}, true)
+	require.NoError(t, err)
+	assert.Equal(t, TaskFilter{
+		Statuses:     []TaskStatus{TaskStatusInProgress, TaskStatusDone},
+		Types:        []TaskType{TaskTypeSubtask},
+		WorkTypes:    []WorkType{WorkTypeDevelopment, WorkTypeDiscovery},
+		Unclassified: true,
+	}, filter)
+
+	_, err = NewTaskFilter([]string{"archived"}, nil, nil, false)
+	assert.ErrorIs(t, err, ErrInvalidStatus)
+	_, err = NewTaskFilter(nil, []string{"spike"}, nil, false)
+	assert.ErrorIs(t, err, ErrInvalidType)
+	_, err = NewTaskFilter(nil, nil, []string{"research"}, false)
+	assert.ErrorIs(t, err, ErrInvalidWorkType)
+}
+
+func TestTaskFilter_Apply(t *testing.T) {
+	tasks := []*Task{
+		{Key: "FN-1", Status: TaskStatusDone, Type: TaskTypeStory, WorkType: WorkTypeDevelopment},
+		{Key: "FN-2", Status: TaskStatusDone, Type: TaskTypeBug, WorkType: WorkTypeMaintenance},
+		{Key: "FN-3", Status: TaskStatusTodo, Type: TaskTypeStory},
+		{Key: "FN-4", Status: TaskStatusDone, Type: TaskTypeStory},
+	}
+	keys := func(tasks []*Task) []string {
+		var keys []string
+		for _, task := range tasks {
+			keys = append(keys, task.Key)
+		}
+		return keys
+	}
+
+	tests := []struct {
+		name   string
+		filter TaskFilter
+		want   []string
+	}{
+		{"no criteria", TaskFilter{}, []string{"FN-1", "FN-2", "FN-3", "FN-4"}},
+		{"status", TaskFilter{Statuses: []TaskStatus{TaskStatusDone}}, []string{"FN-1", "FN-2", "FN-4"}},
+		{"status and type", TaskFilter{Statuses: []TaskStatus{TaskStatusDone}, Types: []TaskType{TaskTypeStory}}, []string{"FN-1", "FN-4"}},
+		{"work type", TaskFilter{WorkTypes: []WorkType{WorkTypeMaintenance}}, []string{"FN-2"}},
+		{"unclassified", TaskFilter{Unclassified: true}, []string{"FN-3", "FN-4"}},
+		{"work type or unclassified", TaskFilter{WorkTypes: []WorkType{WorkTypeDevelopment}, Unclassified: true}, []string{"FN-1", "FN-3", "FN-4"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, keys(tt.filter.Apply(tasks)))
+		})
+	}
+}
+
+func TestSortTasks(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, time.May, d, 0, 0, 0, 0, time.UTC) }
+	newTasks := func() []*Task {
+		return []*Task{
+			{Key: "FN-10", Status: TaskStatusTodo, UpdatedAt: day(3)},
+			{Key: "FN-9", Status: TaskStatusDone, UpdatedAt: day(1)},
+			{Key: "AB-2", Status: TaskStatusInProgress, UpdatedAt: day(3)},
+		}
+	}
+	keys := func(tasks []*Task) []string {
+		var keys []string
+		for _, task := range tasks {
+			keys = append(keys, task.Key)
+		}
+		return keys
+	}
+
+	for by, want := range map[TaskSort][]string{
+		TaskSortKey:     {"AB-2", "FN-9", "FN-10"},
+		TaskSortStatus:  {"FN-10", "AB-2", "FN-9"},
+		TaskSortUpdated: {"AB-2", "FN-10", "FN-9"},
+	} {
+		tasks := newTasks()
+		SortTasks(tasks, by)
+		assert.Equal(t, want, keys(tasks), by)
+	}
+}
+
+func TestParseTaskSort(t *testing.T) {
+	order, err := ParseTaskSort("")
+	require.NoError(t, err)
+	assert.Equal(t, TaskSortKey, order)
+
+	order, err = ParseTaskSort("Updated")
+	require.NoError(t, err)
+	assert.Equal(t, TaskSortUpdated, order)
+
+	_, err = ParseTaskSort("priority")
+	assert.Error(t, err)
+}
@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// DefaultTrashRetention is how long deleted records are kept before they expire
+const DefaultTrashRetention = 30 * 24 * time.Hour
+
+// DeletedTask is a task kept in the trash after deletion
+type DeletedTask struct {
+	Task      *Task     `json:"task"`
+	DeletedAt time.Time `json:"deletedAt"`
+	// ExpiresAt is when the record is removed from the trash for good
+	ExpiresAt time.Time `json:"-"`
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrEmptySplit      = errors.New("a work type split needs at least one share")
+	ErrInvalidShare    = errors.New("work type shares must be between 0 and 100")
+	ErrSplitNotHundred = errors.New("work type shares must add up to 100")
+)
+
+// splitTolerance absorbs rounding when checking that shares add up to 100
+const splitTolerance = 0.01
+
+// WorkTypeSplit divides the hours of a single issue across work types, as percentages
+type WorkTypeSplit struct {
+	IssueKey  string               `json:"issue_key"`
+	Shares    map[WorkType]float64 `json:"shares"`
+	UpdatedAt time.Time            `json:"updated_at"`
+}
+
+// NewWorkTypeSplit creates a split of an issue, dropping zero shares. The shares must name
+// known work types and add up to 100.
+func NewWorkTypeSplit(issueKey string, shares map[WorkType]float64) (*WorkTypeSplit, error) {
+	if issueKey == "" {
+		return nil, ErrEmptyKey
+	}
+
+	split := &WorkTypeSplit{
+		IssueKey:  strings.ToUpper(issueKey),
+		Shares:    make(map[WorkType]float64),
+		UpdatedAt: time.Now(),
+	}
+	total := 0.0
+	for workType, share := range shares {
+		switch workType {
+		case WorkTypeMaintenance, WorkTypeDiscovery, WorkTypeDevelopment:
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrInvalidWorkType, workType)
+		}
+		if share < 0 || share > 100 {
+			return nil, fmt.Errorf("%w: %s is %g", ErrInvalidShare, workType, share)
+		}
+		if share == 0 {
+			continue
+		}
+		split.Shares[workType] = share
+		total += share
+	}
+
+	if len(split.Shares) == 0 {
+		return nil, ErrEmptySplit
+	}
+	if math.Abs(total-100) > splitTolerance {
+		return nil, fmt.Errorf("%w, got %g", ErrSplitNotHundred, total)
+	}
+	return split, nil
+}
+
+// WorkTypes returns the work types of the split in a stable order
+func (s WorkTypeSplit) WorkTypes() []WorkType {
+	workTypes := make([]WorkType, 0, len(s.Shares))
+	for workType := range s.Shares {
+		workTypes = append(workTypes, workType)
+	}
+	sort.Slice(workTypes, func(i, j int) bool { return workTypes[i] < workTypes[j] })
+	return workTypes
+}
+
+// String describes the split, e.g. "cap-development 70%, cap-maintenance 30%"
+func (s WorkTypeSplit) String() string {
+	parts := make([]string, 0, len(s.Shares))
+	for _, workType := range s.WorkTypes() {
+		parts = append(parts, fmt.Sprintf("%s %g%%", workType, s.Shares[workType]))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWorkTypeSplit(t *testing.T) {
+	split, err := NewWorkTypeSplit("fn-123", map[WorkType]float64{
+		WorkTypeDevelopment: 70,
+		WorkTypeMaintenance: 30,
+		WorkTypeDiscovery:   0,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "FN-123", split.IssueKey)
+	assert.Equal(t, []WorkType{WorkTypeDevelopment, WorkTypeMaintenance}, split.WorkTypes())
+	assert.Equal(t, "cap-development 70%, cap-maintenance 30%", split.String())
+	assert.False(t, split.UpdatedAt.IsZero())
+}
+
+func TestNewWorkTypeSplit_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		shares  map[WorkType]float64
+		wantErr error
+	}{
+		{"empty key", "", map[WorkType]float64{WorkTypeDevelopment: 100}, ErrEmptyKey},
+		{"no shares", "FN-1", map[WorkType]float64{WorkTypeDevelopment: 0}, ErrEmptySplit},
+		{"unknown work type", "FN-1", map[WorkType]float64{"cap-research": 100}, ErrInvalidWorkType},
+		{"negative share", "FN-1", map[WorkType]float64{WorkTypeDevelopment: 110, WorkTypeMaintenance: -10}, ErrInvalidShare},
+		{"not a hundred", "FN-1", map[WorkType]float64{WorkTypeDevelopment: 70, WorkTypeMaintenance: 20}, ErrSplitNotHundred},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewWorkTypeSplit(tt.key, tt.shares)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package classifier
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// maxDescriptionLength keeps long descriptions from filling the model's context
+const maxDescriptionLength = 4000
+
+// ErrUnreadableAnswer is returned when the model's answer holds no known work type
+var ErrUnreadableAnswer = errors.New("unreadable classification from the model")
+
+// Generator sends a prompt to a language model and returns its answer
+type Generator interface {
+	Generate(prompt string) (string, error)
+}
+
+// llamaAnswer is the JSON the model is asked to answer with
+type llamaAnswer struct {
+	WorkType   string  `json:"workType"`
+	Confidence float64 `json:"confidence"`
+	Rationale  string  `json:"rationale"`
+}
+
+// LlamaClassifier implements TaskClassifier by asking a LLaMA model for the work type of each
+// task from its summary and description. It remembers the model's rationale and confidence so
+// the classification of each task can be explained.
+type LlamaClassifier struct {
+	llm Generator
+
+	mu      sync.Mutex
+	answers map[string]llamaAnswer
+}
+
+// NewLlamaClassifier creates a classifier asking the given model
+func NewLlamaClassifier(llm Generator) *LlamaClassifier {
+	return &LlamaClassifier{
+		llm:     llm,
+		answers: make(map[string]llamaAnswer),
+	}
+}
+
+// ClassifyTask asks the model for the work type of a task
+func (c *LlamaClassifier) ClassifyTask(task *domain.Task) (domain.WorkType, error) {
+	return c.classify(task, "")
+}
+
+// ClassifyTasks asks the model for the work type of each task
+func (c *LlamaClassifier) ClassifyTasks(tasks []*domain.Task) (map[string]domain.WorkType, error) {
+	return c.ClassifyTasksWithPrompts(tasks, nil)
+}
+
+// ClassifyTasksWithPrompts asks the model for the work type of each task, adding the
+// instructions given for its key to the prompt
+func (c *LlamaClassifier) ClassifyTasksWithPrompts(tasks []*domain.Task, prompts map[string]string) (map[string]domain.WorkType, error) {
+	result := make(map[string]domain.WorkType, len(tasks))
+	for _, task := range tasks {
+		workType, err := c.classify(task, prompts[task.Key])
+		if err != nil {
+			return nil, err
+		}
+		result[task.Key] = workType
+	}
+	return result, nil
+}
+
+// Explain returns the model's rationale and confidence for the work type of a task
+func (c *LlamaClassifier) Explain(task *domain.Task, workType domain.WorkType) (string, float64) {
+	c.mu.Lock()
+	answer, ok := c.answers[task.Key]
+	c.mu.Unlock()
+	if !ok {
+		return fmt.Sprintf("classified as %s by the LLaMA model", workType), 0
+	}
+	return answer.Rationale, answer.Confidence
+}
+
+// classify asks the model for the work type of a task and remembers its answer
+func (c *LlamaClassifier) classify(task *domain.Task, instructions string) (domain.WorkType, error) {
+	response, err := c.llm.Generate(classificationPrompt(task, instructions))
+	if err != nil {
+		return "", fmt.Errorf("failed to classify task %s: %w", task.Key, err)
+	}
+	answer, workType, err := parseAnswer(response)
+	if err != nil {
+		return "", fmt.Errorf("failed to classify task %s: %w", task.Key, err)
+	}
+
+	c.mu.Lock()
+	c.answers[task.Key] = answer
+	c.mu.Unlock()
+	return workType, nil
+}
+
+// classificationPrompt asks for the work type of a task as JSON
+func classificationPrompt(task *domain.Task, instructions string) string {
+	description := task.Description
+	if len(description) > maxDescriptionLength {
+		description = description[:maxDescriptionLength] + "..."
+	}
+
+	var prompt strings.Builder
+	prompt.WriteString("You classify software tasks for the capitalization of development costs.\n")
+	prompt.WriteString("Pick the one work type describing the task:\n")
+	prompt.WriteString("- development: building new features or capabilities of the asset\n")
+	prompt.WriteString("- maintenance: fixing bugs, upgrades, support and keeping the asset running\n")
+	prompt.WriteString("- discovery: research, spikes, prototypes and evaluating options\n\n")
+	if instructions != "" {
+		fmt.Fprintf(&prompt, "Instructions for this asset: %s\n\n", instructions)
+	}
+	fmt.Fprintf(&prompt, "Task: %s\n", task.Key)
+	fmt.Fprintf(&prompt, "Summary: %s\n", task.Summary)
+	if description != "" {
+		fmt.Fprintf(&prompt, "Description: %s\n", description)
+	}
+	prompt.WriteString("\nAnswer with JSON only, in this form:\n")
+	prompt.WriteString(`{"workType": "development", "confidence": 0.8, "rationale": "one sentence"}`)
+	prompt.WriteString("\nwhere confidence is the probability, from 0 to 1, that the work type is right.\n")
+	return prompt.String()
+}
+
+// parseAnswer reads the JSON object of the model's answer, which may be wrapped in prose or a
+// code block, and maps its work type
+func parseAnswer(response string) (llamaAnswer, domain.WorkType, error) {
+	var answer llamaAnswer
+	start, end := strings.Index(response, "{"), strings.LastIndex(response, "}")
+	if start < 0 || end < start {
+		return answer, "", fmt.Errorf("%w: %q", ErrUnreadableAnswer, response)
+	}
+	if err := json.Unmarshal([]byte(response[start:end+1]), &answer); err != nil {
+		return answer, "", fmt.Errorf("%w: %q", ErrUnreadableAnswer, response)
+	}
+
+	workType, ok := parseWorkType(answer.WorkType)
+	if !ok {
+		return answer, "", fmt.Errorf("%w: unknown work type %q", ErrUnreadableAnswer, answer.WorkType)
+	}
+	if answer.Confidence < 0 {
+		answer.Confidence = 0
+	} else if answer.Confidence > 1 {
+		answer.Confidence = 1
+	}
+	answer.Rationale = strings.TrimSpace(answer.Rationale)
+	if answer.Rationale == "" {
+		answer.Rationale = fmt.Sprintf("classified as %s by the LLaMA model", workType)
+	}
+	return answer, workType, nil
+}
+
+// parseWorkType maps a work type named with or without its cap- prefix
+func parseWorkType(value string) (domain.WorkType, bool) {
+	switch strings.TrimPrefix(strings.ToLower(strings.TrimSpace(value)), "cap-") {
+	case "development":
+		return domain.WorkTypeDevelopment, true
+	case "maintenance":
+		return domain.WorkTypeMaintenance, true
+	case "discovery":
+		return domain.WorkTypeDiscovery, true
+	}
+	return "", false
+}
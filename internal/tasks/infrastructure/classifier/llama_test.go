@@ -0,0 +1,100 @@
+package classifier
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// fakeGenerator answers each prompt with the next response
+type fakeGenerator struct {
+	responses []string
+	prompts   []string
+	err       error
+}
+
+func (g *fakeGenerator) Generate(prompt string) (string, error) {
+	g.prompts = append(g.prompts, prompt)
+	if g.err != nil {
+		return "", g.err
+	}
+	response := g.responses[0]
+	g.responses = g.responses[1:]
+	return response, nil
+}
+
+func newLlamaTestTask(t *testing.T, key string) *domain.Task {
+	task, err := domain.NewTask(key, "Add export of invoices", "TEST", "Sprint 1", "JIRA")
+	require.NoError(t, err)
+	task.Description = "Customers can download their invoices as PDF"
+	return task
+}
+
+func TestLlamaClassifier_ClassifyTask(t *testing.T) {
+	llm := &fakeGenerator{responses: []string{
+		"Here is the classification:\n```json\n{\"workType\": \"development\", \"confidence\": 0.85, \"rationale\": \"Adds a new export feature\"}\n```",
+	}}
+	classifier := NewLlamaClassifier(llm)
+	task := newLlamaTestTask(t, "TEST-1")
+
+	workType, err := classifier.ClassifyTask(task)
+	require.NoError(t, err)
+	assert.Equal(t, domain.WorkTypeDevelopment, workType)
+	assert.Contains(t, llm.prompts[0], "Summary: Add export of invoices")
+	assert.Contains(t, llm.prompts[0], "Description: Customers can download their invoices as PDF")
+
+	rationale, confidence := classifier.Explain(task, workType)
+	assert.Equal(t, "Adds a new export feature", rationale)
+	assert.Equal(t, 0.85, confidence)
+}
+
+func TestLlamaClassifier_ClassifyTasksWithPrompts(t *testing.T) {
+	llm := &fakeGenerator{responses: []string{
+		`{"workType": "cap-maintenance", "confidence": 1.4}`,
+		`{"workType": "Discovery", "confidence": 0.6, "rationale": "A spike"}`,
+	}}
+	classifier := NewLlamaClassifier(llm)
+	first, second := newLlamaTestTask(t, "TEST-1"), newLlamaTestTask(t, "TEST-2")
+
+	workTypes, err := classifier.ClassifyTasksWithPrompts([]*domain.Task{first, second}, map[string]string{"TEST-1": "Bugs are maintenance"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]domain.WorkType{"TEST-1": domain.WorkTypeMaintenance, "TEST-2": domain.WorkTypeDiscovery}, workTypes)
+	assert.Contains(t, llm.prompts[0], "Instructions for this asset: Bugs are maintenance")
+	assert.NotContains(t, llm.prompts[1], "Instructions for this asset")
+
+	rationale, confidence := classifier.Explain(first, domain.WorkTypeMaintenance)
+	assert.Equal(t, "classified as cap-maintenance by the LLaMA model", rationale)
+	assert.Equal(t, 1.0, confidence, "the confidence is capped at 1")
+}
+
+func TestLlamaClassifier_Errors(t *testing.T) {
+	t.Run("model unreachable", func(t *testing.T) {
+		classifier := NewLlamaClassifier(&fakeGenerator{err: errors.New("connection refused")})
+		_, err := classifier.ClassifyTasks([]*domain.Task{newLlamaTestTask(t, "TEST-1")})
+		assert.EqualError(t, err, "failed to classify task TEST-1: connection refused")
+	})
+
+	t.Run("no JSON in the answer", func(t *testing.T) {
+		classifier := NewLlamaClassifier(&fakeGenerator{responses: []string{"It is development work"}})
+		_, err := classifier.ClassifyTask(newLlamaTestTask(t, "TEST-1"))
+		assert.ErrorIs(t, err, ErrUnreadableAnswer)
+	})
+
+	t.Run("unknown work type", func(t *testing.T) {
+		classifier := NewLlamaClassifier(&fakeGenerator{responses: []string{`{"workType": "operations"}`}})
+		_, err := classifier.ClassifyTask(newLlamaTestTask(t, "TEST-1"))
+		assert.ErrorIs(t, err, ErrUnreadableAnswer)
+		assert.ErrorContains(t, err, `unknown work type "operations"`)
+	})
+}
+
+func TestLlamaClassifier_ExplainUnclassifiedTask(t *testing.T) {
+	classifier := NewLlamaClassifier(&fakeGenerator{})
+	rationale, confidence := classifier.Explain(newLlamaTestTask(t, "TEST-9"), domain.WorkTypeDiscovery)
+	assert.Equal(t, "classified as cap-discovery by the LLaMA model", rationale)
+	assert.Zero(t, confidence)
+}
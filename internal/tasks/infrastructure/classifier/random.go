@@ -1,6 +1,7 @@
 package classifier
 
 import (
+	"fmt"
 	"math/rand"
 	"time"
 
@@ -30,6 +31,11 @@ func (c *RandomClassifier) ClassifyTask(_ *domain.Task) (domain.WorkType, error)
 	return workTypes[c.rng.Intn(len(workTypes))], nil
 }
 
+// Explain tells that the work type was picked at random among the work types
+func (c *RandomClassifier) Explain(_ *domain.Task, workType domain.WorkType) (string, float64) {
+	return fmt.Sprintf("%s picked at random", workType), 1.0 / 3
+}
+
 // ClassifyTasks randomly assigns work types to multiple tasks
 func (c *RandomClassifier) ClassifyTasks(tasks []*domain.Task) (map[string]domain.WorkType, error) {
 	result := make(map[string]domain.WorkType)
@@ -0,0 +1,143 @@
+package codehost
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// Bitbucket lists the commits and pull requests of Bitbucket Cloud repositories
+type Bitbucket struct {
+	client *client
+}
+
+type bitbucketLinks struct {
+	HTML struct {
+		Href string `json:"href"`
+	} `json:"html"`
+}
+
+type bitbucketCommit struct {
+	Hash    string    `json:"hash"`
+	Message string    `json:"message"`
+	Date    time.Time `json:"date"`
+	Author  struct {
+		Raw  string `json:"raw"`
+		User struct {
+			DisplayName string `json:"display_name"`
+		} `json:"user"`
+	} `json:"author"`
+	Links bitbucketLinks `json:"links"`
+}
+
+type bitbucketPullRequest struct {
+	ID          int       `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	CreatedOn   time.Time `json:"created_on"`
+	UpdatedOn   time.Time `json:"updated_on"`
+	Author      struct {
+		DisplayName string `json:"display_name"`
+	} `json:"author"`
+	Source struct {
+		Branch struct {
+			Name string `json:"name"`
+		} `json:"branch"`
+	} `json:"source"`
+	Links bitbucketLinks `json:"links"`
+}
+
+// bitbucketPage is a page of a Bitbucket listing, linking to the next one
+type bitbucketPage[T any] struct {
+	Values []T    `json:"values"`
+	Next   string `json:"next"`
+}
+
+// ListChanges returns the commits made and pull requests updated since the given time
+func (b *Bitbucket) ListChanges(ctx context.Context, since time.Time) ([]domain.CodeChange, error) {
+	var changes []domain.CodeChange
+	for _, repository := range b.client.config.Repositories {
+		commits, err := b.commits(ctx, repository, since)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list commits of %s: %w", repository, err)
+		}
+		pulls, err := b.pullRequests(ctx, repository, since)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pull requests of %s: %w", repository, err)
+		}
+		changes = append(changes, commits...)
+		changes = append(changes, pulls...)
+	}
+	return changes, nil
+}
+
+// commits pages through the commits of a repository, newest first, until one was made before
+// the given time
+func (b *Bitbucket) commits(ctx context.Context, repository string, since time.Time) ([]domain.CodeChange, error) {
+	var changes []domain.CodeChange
+	next := "/repositories/" + repository + "/commits?pagelen=100"
+	for next != "" {
+		var page bitbucketPage[bitbucketCommit]
+		if err := b.client.get(ctx, next, &page); err != nil {
+			return nil, err
+		}
+		for _, commit := range page.Values {
+			if commit.Date.Before(since) {
+				return changes, nil
+			}
+			author := commit.Author.User.DisplayName
+			if author == "" {
+				author = commit.Author.Raw
+			}
+			changes = append(changes, domain.CodeChange{
+				Reference: domain.CodeReference{
+					Kind:       domain.CodeReferenceCommit,
+					Repository: repository,
+					ID:         commit.Hash,
+					Title:      firstLine(commit.Message),
+					URL:        commit.Links.HTML.Href,
+					Author:     author,
+					Date:       commit.Date.UTC(),
+				},
+				Text: commit.Message,
+			})
+		}
+		next = page.Next
+	}
+	return changes, nil
+}
+
+// pullRequests pages through the pull requests of a repository in any state, most recently
+// updated first, until one was last updated before the given time
+func (b *Bitbucket) pullRequests(ctx context.Context, repository string, since time.Time) ([]domain.CodeChange, error) {
+	var changes []domain.CodeChange
+	next := "/repositories/" + repository + "/pullrequests?state=OPEN&state=MERGED&state=DECLINED&state=SUPERSEDED&sort=-updated_on&pagelen=50"
+	for next != "" {
+		var page bitbucketPage[bitbucketPullRequest]
+		if err := b.client.get(ctx, next, &page); err != nil {
+			return nil, err
+		}
+		for _, pull := range page.Values {
+			if pull.UpdatedOn.Before(since) {
+				return changes, nil
+			}
+			changes = append(changes, domain.CodeChange{
+				Reference: domain.CodeReference{
+					Kind:       domain.CodeReferencePullRequest,
+					Repository: repository,
+					ID:         strconv.Itoa(pull.ID),
+					Title:      pull.Title,
+					URL:        pull.Links.HTML.Href,
+					Author:     pull.Author.DisplayName,
+					Date:       pull.CreatedOn.UTC(),
+				},
+				Text: pull.Title + "\n" + pull.Source.Branch.Name + "\n" + pull.Description,
+			})
+		}
+		next = page.Next
+	}
+	return changes, nil
+}
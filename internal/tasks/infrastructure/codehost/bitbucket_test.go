@@ -0,0 +1,60 @@
+package codehost
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+func TestBitbucket_ListChanges(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/repositories/acme/api/commits" && r.URL.Query().Get("page") == "":
+			fmt.Fprintf(w, `{"values": [{"hash": "f00d", "message": "FN-2 fix rounding", "date": "2024-05-06T08:00:00+00:00",
+				"author": {"raw": "Carol <carol@example.com>"}, "links": {"html": {"href": "https://bitbucket.org/acme/api/commits/f00d"}}}],
+				"next": "%s/repositories/acme/api/commits?page=2"}`, server.URL)
+		case r.URL.Path == "/repositories/acme/api/commits":
+			w.Write([]byte(`{"values": [
+				{"hash": "beef", "message": "FN-1 ledger", "date": "2024-05-02T08:00:00+00:00", "author": {"user": {"display_name": "Alice"}}},
+				{"hash": "dead", "message": "FN-0 older", "date": "2024-04-02T08:00:00+00:00"}
+			], "next": "unreachable"}`))
+		case r.URL.Path == "/repositories/acme/api/pullrequests":
+			assert.Equal(t, []string{"OPEN", "MERGED", "DECLINED", "SUPERSEDED"}, r.URL.Query()["state"])
+			w.Write([]byte(`{"values": [{"id": 4, "title": "Ledger", "description": "", "created_on": "2024-05-02T09:00:00+00:00",
+				"updated_on": "2024-05-03T09:00:00+00:00", "author": {"display_name": "Alice"}, "source": {"branch": {"name": "feature/FN-1"}}}]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	}))
+	defer server.Close()
+
+	host, err := New(Config{Host: HostBitbucket, BaseURL: server.URL, Repositories: []string{"acme/api"}})
+	require.NoError(t, err)
+
+	changes, err := host.ListChanges(context.Background(), time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, changes, 3, "commits made before since stop the paging")
+
+	assert.Equal(t, domain.CodeReference{
+		Kind:       domain.CodeReferenceCommit,
+		Repository: "acme/api",
+		ID:         "f00d",
+		Title:      "FN-2 fix rounding",
+		URL:        "https://bitbucket.org/acme/api/commits/f00d",
+		Author:     "Carol <carol@example.com>",
+		Date:       time.Date(2024, 5, 6, 8, 0, 0, 0, time.UTC),
+	}, changes[0].Reference)
+	assert.Equal(t, "Alice", changes[1].Reference.Author)
+	assert.Equal(t, domain.CodeReferencePullRequest, changes[2].Reference.Kind)
+	assert.Equal(t, "4", changes[2].Reference.ID)
+	assert.Equal(t, []string{"FN-1"}, domain.IssueKeysIn(changes[2].Text))
+}
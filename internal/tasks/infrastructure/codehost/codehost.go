@@ -0,0 +1,136 @@
+// Package codehost lists the commits and pull requests of GitHub and Bitbucket repositories,
+// so they can be linked to the tasks whose keys they mention.
+package codehost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain/ports"
+)
+
+// Supported code hosts
+const (
+	HostGitHub    = "github"
+	HostBitbucket = "bitbucket"
+)
+
+// Default API locations of the hosted services
+const (
+	DefaultGitHubURL    = "https://api.github.com"
+	DefaultBitbucketURL = "https://api.bitbucket.org/2.0"
+)
+
+const (
+	envGitHubToken          = "GITHUB_TOKEN"
+	envBitbucketUsername    = "BITBUCKET_USERNAME"
+	envBitbucketAppPassword = "BITBUCKET_APP_PASSWORD"
+)
+
+// Config selects the code host and the repositories whose changes are listed
+type Config struct {
+	Host string
+	// BaseURL overrides the API location, e.g. for GitHub Enterprise
+	BaseURL string
+	// Repositories are named owner/name on GitHub and workspace/slug on Bitbucket
+	Repositories []string
+	// Username and Token authenticate the requests: a GitHub token, or a Bitbucket username
+	// and app password. Public repositories can be read without.
+	Username string
+	Token    string
+}
+
+// ConfigFromEnv completes the configuration with the credentials of the host's environment
+// variables: GITHUB_TOKEN, or BITBUCKET_USERNAME and BITBUCKET_APP_PASSWORD
+func ConfigFromEnv(cfg Config) Config {
+	switch cfg.Host {
+	case HostGitHub:
+		cfg.Token = os.Getenv(envGitHubToken)
+	case HostBitbucket:
+		cfg.Username = os.Getenv(envBitbucketUsername)
+		cfg.Token = os.Getenv(envBitbucketAppPassword)
+	}
+	return cfg
+}
+
+// New creates the client of the configured code host
+func New(cfg Config) (ports.CodeHost, error) {
+	if len(cfg.Repositories) == 0 {
+		return nil, fmt.Errorf("no repositories configured for %s", cfg.Host)
+	}
+	for _, repository := range cfg.Repositories {
+		owner, name, ok := strings.Cut(repository, "/")
+		if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+			return nil, fmt.Errorf("invalid repository %q: must be owner/name", repository)
+		}
+	}
+
+	c := &client{httpClient: &http.Client{Timeout: 30 * time.Second}, config: cfg}
+	switch cfg.Host {
+	case HostGitHub:
+		if c.config.BaseURL == "" {
+			c.config.BaseURL = DefaultGitHubURL
+		}
+		return &GitHub{client: c}, nil
+	case HostBitbucket:
+		if c.config.BaseURL == "" {
+			c.config.BaseURL = DefaultBitbucketURL
+		}
+		return &Bitbucket{client: c}, nil
+	default:
+		return nil, fmt.Errorf("unsupported code host: %s", cfg.Host)
+	}
+}
+
+// client performs the authenticated API requests of a code host
+type client struct {
+	httpClient *http.Client
+	config     Config
+}
+
+// get requests an API path, or an absolute URL such as a next page link, and decodes the
+// JSON response into out
+func (c *client) get(ctx context.Context, path string, out interface{}) error {
+	url := path
+	if !strings.HasPrefix(path, "http://") && !strings.HasPrefix(path, "https://") {
+		url = strings.TrimSuffix(c.config.BaseURL, "/") + path
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	switch {
+	case c.config.Username != "" && c.config.Token != "":
+		req.SetBasicAuth(c.config.Username, c.config.Token)
+	case c.config.Token != "":
+		req.Header.Set("Authorization", "Bearer "+c.config.Token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// firstLine returns the first line of a commit message
+func firstLine(message string) string {
+	line, _, _ := strings.Cut(message, "\n")
+	return strings.TrimSpace(line)
+}
@@ -0,0 +1,73 @@
+package codehost
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	github, err := New(Config{Host: HostGitHub, Repositories: []string{"acme/api"}})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultGitHubURL, github.(*GitHub).client.config.BaseURL)
+
+	bitbucket, err := New(Config{Host: HostBitbucket, BaseURL: "https://bitbucket.example.com/2.0", Repositories: []string{"acme/api"}})
+	require.NoError(t, err)
+	assert.Equal(t, "https://bitbucket.example.com/2.0", bitbucket.(*Bitbucket).client.config.BaseURL)
+
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr string
+	}{
+		{"no repositories", Config{Host: HostGitHub}, "no repositories configured for github"},
+		{"repository without owner", Config{Host: HostGitHub, Repositories: []string{"api"}}, `invalid repository "api": must be owner/name`},
+		{"nested repository", Config{Host: HostGitHub, Repositories: []string{"acme/api/web"}}, `invalid repository "acme/api/web": must be owner/name`},
+		{"unknown host", Config{Host: "gitlab", Repositories: []string{"acme/api"}}, "unsupported code host: gitlab"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.config)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv(envGitHubToken, "ghp_token")
+	t.Setenv(envBitbucketUsername, "alice")
+	t.Setenv(envBitbucketAppPassword, "app-password")
+
+	assert.Equal(t, Config{Host: HostGitHub, Token: "ghp_token"}, ConfigFromEnv(Config{Host: HostGitHub}))
+	assert.Equal(t, Config{Host: HostBitbucket, Username: "alice", Token: "app-password"}, ConfigFromEnv(Config{Host: HostBitbucket}))
+}
+
+func TestClient_Get(t *testing.T) {
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		if r.URL.Path == "/missing" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+
+	var out struct{ OK bool }
+	bearer := &client{httpClient: server.Client(), config: Config{BaseURL: server.URL, Token: "ghp_token"}}
+	require.NoError(t, bearer.get(context.Background(), "/ok", &out))
+	assert.True(t, out.OK)
+	assert.Equal(t, "Bearer ghp_token", authorization)
+
+	basic := &client{httpClient: server.Client(), config: Config{BaseURL: "https://unused.example.com", Username: "alice", Token: "secret"}}
+	require.NoError(t, basic.get(context.Background(), server.URL+"/ok", &out), "absolute next page links are followed as is")
+	assert.Equal(t, "Basic YWxpY2U6c2VjcmV0", authorization)
+
+	err := bearer.get(context.Background(), "/missing", &out)
+	assert.ErrorContains(t, err, "unexpected status code: 404")
+}
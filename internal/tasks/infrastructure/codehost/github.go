@@ -0,0 +1,135 @@
+package codehost
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// githubPageSize is the number of commits or pull requests requested per page
+const githubPageSize = 100
+
+// GitHub lists the commits and pull requests of GitHub repositories
+type GitHub struct {
+	client *client
+}
+
+type githubCommit struct {
+	SHA     string `json:"sha"`
+	HTMLURL string `json:"html_url"`
+	Commit  struct {
+		Message string `json:"message"`
+		Author  struct {
+			Name string    `json:"name"`
+			Date time.Time `json:"date"`
+		} `json:"author"`
+	} `json:"commit"`
+}
+
+type githubPullRequest struct {
+	Number    int       `json:"number"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	HTMLURL   string    `json:"html_url"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	User      struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	Head struct {
+		Ref string `json:"ref"`
+	} `json:"head"`
+}
+
+// ListChanges returns the commits made and pull requests updated since the given time
+func (g *GitHub) ListChanges(ctx context.Context, since time.Time) ([]domain.CodeChange, error) {
+	var changes []domain.CodeChange
+	for _, repository := range g.client.config.Repositories {
+		commits, err := g.commits(ctx, repository, since)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list commits of %s: %w", repository, err)
+		}
+		pulls, err := g.pullRequests(ctx, repository, since)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pull requests of %s: %w", repository, err)
+		}
+		changes = append(changes, commits...)
+		changes = append(changes, pulls...)
+	}
+	return changes, nil
+}
+
+// commits pages through the commits of a repository made since the given time
+func (g *GitHub) commits(ctx context.Context, repository string, since time.Time) ([]domain.CodeChange, error) {
+	var changes []domain.CodeChange
+	for page := 1; ; page++ {
+		query := url.Values{"per_page": {strconv.Itoa(githubPageSize)}, "page": {strconv.Itoa(page)}}
+		if !since.IsZero() {
+			query.Set("since", since.UTC().Format(time.RFC3339))
+		}
+		var commits []githubCommit
+		if err := g.client.get(ctx, "/repos/"+repository+"/commits?"+query.Encode(), &commits); err != nil {
+			return nil, err
+		}
+		for _, commit := range commits {
+			changes = append(changes, domain.CodeChange{
+				Reference: domain.CodeReference{
+					Kind:       domain.CodeReferenceCommit,
+					Repository: repository,
+					ID:         commit.SHA,
+					Title:      firstLine(commit.Commit.Message),
+					URL:        commit.HTMLURL,
+					Author:     commit.Commit.Author.Name,
+					Date:       commit.Commit.Author.Date,
+				},
+				Text: commit.Commit.Message,
+			})
+		}
+		if len(commits) < githubPageSize {
+			return changes, nil
+		}
+	}
+}
+
+// pullRequests pages through the pull requests of a repository, most recently updated first,
+// until one was last updated before the given time
+func (g *GitHub) pullRequests(ctx context.Context, repository string, since time.Time) ([]domain.CodeChange, error) {
+	var changes []domain.CodeChange
+	for page := 1; ; page++ {
+		query := url.Values{
+			"state":     {"all"},
+			"sort":      {"updated"},
+			"direction": {"desc"},
+			"per_page":  {strconv.Itoa(githubPageSize)},
+			"page":      {strconv.Itoa(page)},
+		}
+		var pulls []githubPullRequest
+		if err := g.client.get(ctx, "/repos/"+repository+"/pulls?"+query.Encode(), &pulls); err != nil {
+			return nil, err
+		}
+		for _, pull := range pulls {
+			if pull.UpdatedAt.Before(since) {
+				return changes, nil
+			}
+			changes = append(changes, domain.CodeChange{
+				Reference: domain.CodeReference{
+					Kind:       domain.CodeReferencePullRequest,
+					Repository: repository,
+					ID:         strconv.Itoa(pull.Number),
+					Title:      pull.Title,
+					URL:        pull.HTMLURL,
+					Author:     pull.User.Login,
+					Date:       pull.CreatedAt,
+				},
+				Text: pull.Title + "\n" + pull.Head.Ref + "\n" + pull.Body,
+			})
+		}
+		if len(pulls) < githubPageSize {
+			return changes, nil
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package codehost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+func TestGitHub_ListChanges(t *testing.T) {
+	since := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	// A full first page of commits makes the client ask for the second one
+	var firstPage []map[string]interface{}
+	for i := 0; i < githubPageSize; i++ {
+		firstPage = append(firstPage, map[string]interface{}{
+			"sha":    fmt.Sprintf("sha%03d", i),
+			"commit": map[string]interface{}{"message": "Bump dependencies", "author": map[string]interface{}{"name": "Bob", "date": since}},
+		})
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/repos/acme/api/commits" && r.URL.Query().Get("page") == "1":
+			assert.Equal(t, "2024-05-01T00:00:00Z", r.URL.Query().Get("since"))
+			json.NewEncoder(w).Encode(firstPage)
+		case r.URL.Path == "/repos/acme/api/commits":
+			w.Write([]byte(`[{"sha": "a1b2c3d4e5", "html_url": "https://github.com/acme/api/commit/a1b2c3d4e5",
+				"commit": {"message": "FN-1: add ledger export\n\nLong description", "author": {"name": "Alice", "date": "2024-05-03T10:00:00Z"}}}]`))
+		case r.URL.Path == "/repos/acme/api/pulls":
+			assert.Equal(t, "all", r.URL.Query().Get("state"))
+			w.Write([]byte(`[
+				{"number": 12, "title": "Ledger export", "body": "Closes FN-1", "html_url": "https://github.com/acme/api/pull/12",
+				 "created_at": "2024-05-02T09:00:00Z", "updated_at": "2024-05-04T09:00:00Z", "user": {"login": "alice"}, "head": {"ref": "feature/FN-1"}},
+				{"number": 3, "title": "Old work", "created_at": "2024-03-01T09:00:00Z", "updated_at": "2024-03-02T09:00:00Z"}
+			]`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	}))
+	defer server.Close()
+
+	host, err := New(Config{Host: HostGitHub, BaseURL: server.URL, Repositories: []string{"acme/api"}})
+	require.NoError(t, err)
+
+	changes, err := host.ListChanges(context.Background(), since)
+	require.NoError(t, err)
+	require.Len(t, changes, githubPageSize+2, "the pull request updated before since is left out")
+
+	commit := changes[githubPageSize]
+	assert.Equal(t, domain.CodeReference{
+		Kind:       domain.CodeReferenceCommit,
+		Repository: "acme/api",
+		ID:         "a1b2c3d4e5",
+		Title:      "FN-1: add ledger export",
+		URL:        "https://github.com/acme/api/commit/a1b2c3d4e5",
+		Author:     "Alice",
+		Date:       time.Date(2024, 5, 3, 10, 0, 0, 0, time.UTC),
+	}, commit.Reference)
+
+	pull := changes[githubPageSize+1]
+	assert.Equal(t, domain.CodeReferencePullRequest, pull.Reference.Kind)
+	assert.Equal(t, "12", pull.Reference.ID)
+	assert.Equal(t, "alice", pull.Reference.Author)
+	assert.Equal(t, []string{"FN-1"}, domain.IssueKeysIn(pull.Text))
+}
+
+func TestGitHub_ListChanges_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad credentials", http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	host, err := New(Config{Host: HostGitHub, BaseURL: server.URL, Repositories: []string{"acme/api"}})
+	require.NoError(t, err)
+
+	_, err = host.ListChanges(context.Background(), time.Time{})
+	assert.ErrorContains(t, err, "failed to list commits of acme/api: unexpected status code: 401")
+}
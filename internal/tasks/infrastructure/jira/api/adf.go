@@ -0,0 +1,227 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ADFNode is a node of an Atlassian Document Format document, the rich text of Jira
+// descriptions and comments. The document itself is the root node of type doc.
+type ADFNode struct {
+	Type    string                 `json:"type"`
+	Version int                    `json:"version,omitempty"`
+	Text    string                 `json:"text,omitempty"`
+	Attrs   map[string]interface{} `json:"attrs,omitempty"`
+	Marks   []ADFMark              `json:"marks,omitempty"`
+	Content []ADFNode              `json:"content,omitempty"`
+}
+
+// ADFMark formats a text node, e.g. as a link
+type ADFMark struct {
+	Type  string                 `json:"type"`
+	Attrs map[string]interface{} `json:"attrs,omitempty"`
+}
+
+// UnmarshalJSON also accepts the plain string descriptions of older Jira APIs, reading them
+// as a single text node
+func (n *ADFNode) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*n = ADFNode{}
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*n = ADFNode{Type: "text", Text: text}
+		return nil
+	}
+	type plain ADFNode
+	return json.Unmarshal(data, (*plain)(n))
+}
+
+// adfInlineTypes are the node types that flow within a line of text
+var adfInlineTypes = map[string]bool{
+	"text": true, "hardBreak": true, "mention": true, "emoji": true,
+	"inlineCard": true, "date": true, "status": true, "mediaInline": true,
+}
+
+// PlainText converts the document to plain text, one line per paragraph, heading, list
+// item and table row. Lists keep their markers, links their URL and mentions their name.
+func (n ADFNode) PlainText() string {
+	return strings.TrimSpace(strings.Join(adfBlocks([]ADFNode{n}), "\n"))
+}
+
+// adfBlocks renders block nodes as lines, joining runs of inline nodes into one line
+func adfBlocks(nodes []ADFNode) []string {
+	var lines []string
+	var run []ADFNode
+	flush := func() {
+		if len(run) > 0 {
+			lines = append(lines, adfLines(adfInline(run))...)
+			run = nil
+		}
+	}
+	for _, node := range nodes {
+		if adfInlineTypes[node.Type] {
+			run = append(run, node)
+			continue
+		}
+		flush()
+		lines = append(lines, adfBlock(node)...)
+	}
+	flush()
+	return lines
+}
+
+// adfBlock renders a block node as lines
+func adfBlock(node ADFNode) []string {
+	switch node.Type {
+	case "paragraph", "heading", "codeBlock":
+		return adfLines(adfInline(node.Content))
+	case "bulletList", "orderedList", "taskList":
+		return adfList(node)
+	case "blockquote":
+		lines := adfBlocks(node.Content)
+		for i := range lines {
+			lines[i] = "> " + lines[i]
+		}
+		return lines
+	case "rule":
+		return []string{"---"}
+	case "table":
+		var lines []string
+		for _, row := range node.Content {
+			cells := make([]string, 0, len(row.Content))
+			for _, cell := range row.Content {
+				cells = append(cells, strings.Join(adfBlocks(cell.Content), " "))
+			}
+			lines = append(lines, strings.Join(cells, " | "))
+		}
+		return lines
+	case "expand", "nestedExpand":
+		lines := adfBlocks(node.Content)
+		if title := adfAttr(node, "title"); title != "" {
+			lines = append([]string{title}, lines...)
+		}
+		return lines
+	}
+	return adfBlocks(node.Content)
+}
+
+// adfList renders the items of a list, one marker per item and nested lines indented
+func adfList(list ADFNode) []string {
+	order := 1
+	if start, ok := list.Attrs["order"].(float64); ok && start > 0 {
+		order = int(start)
+	}
+
+	var lines []string
+	for _, item := range list.Content {
+		marker := "- "
+		switch list.Type {
+		case "orderedList":
+			marker = strconv.Itoa(order) + ". "
+			order++
+		case "taskList":
+			marker = "[ ] "
+			if adfAttr(item, "state") == "DONE" {
+				marker = "[x] "
+			}
+		}
+		itemLines := adfBlocks(item.Content)
+		if item.Type == "taskList" {
+			// Nested task lists are items of their parent list
+			itemLines = adfList(item)
+			marker = "  "
+		}
+		for i, line := range itemLines {
+			if i == 0 {
+				lines = append(lines, marker+line)
+				continue
+			}
+			lines = append(lines, "  "+line)
+		}
+	}
+	return lines
+}
+
+// adfInline renders inline nodes as text, where hard breaks are newlines
+func adfInline(nodes []ADFNode) string {
+	var b strings.Builder
+	for _, node := range nodes {
+		switch node.Type {
+		case "text":
+			b.WriteString(node.Text)
+			if href := adfLink(node); href != "" && href != node.Text {
+				fmt.Fprintf(&b, " (%s)", href)
+			}
+		case "hardBreak":
+			b.WriteString("\n")
+		case "mention":
+			name := adfAttr(node, "text")
+			if name == "" {
+				name = "@" + adfAttr(node, "id")
+			}
+			b.WriteString(name)
+		case "emoji":
+			emoji := adfAttr(node, "text")
+			if emoji == "" {
+				emoji = adfAttr(node, "shortName")
+			}
+			b.WriteString(emoji)
+		case "inlineCard":
+			b.WriteString(adfAttr(node, "url"))
+		case "status":
+			b.WriteString(adfAttr(node, "text"))
+		case "date":
+			b.WriteString(adfDate(adfAttr(node, "timestamp")))
+		default:
+			b.WriteString(adfInline(node.Content))
+		}
+	}
+	return b.String()
+}
+
+// adfLines splits text into lines, dropping the blank ones
+func adfLines(text string) []string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, strings.TrimRight(line, " \t"))
+		}
+	}
+	return lines
+}
+
+// adfLink returns the URL of a text node's link mark, if any
+func adfLink(node ADFNode) string {
+	for _, mark := range node.Marks {
+		if mark.Type == "link" {
+			href, _ := mark.Attrs["href"].(string)
+			return href
+		}
+	}
+	return ""
+}
+
+// adfAttr returns a string attribute of a node, or an empty string
+func adfAttr(node ADFNode, name string) string {
+	switch value := node.Attrs[name].(type) {
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	return ""
+}
+
+// adfDate formats the millisecond timestamp of a date node as a day
+func adfDate(timestamp string) string {
+	millis, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return timestamp
+	}
+	return time.UnixMilli(millis).UTC().Format("2006-01-02")
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestADFNode_PlainText(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		want     string
+	}{
+		{
+			name:     "paragraphs and headings",
+			document: `{"type": "doc", "version": 1, "content": [{"type": "heading", "attrs": {"level": 1}, "content": [{"type": "text", "text": "Goal"}]}, {"type": "paragraph", "content": [{"type": "text", "text": "Ship the "}, {"type": "text", "text": "booking", "marks": [{"type": "strong"}]}, {"type": "text", "text": " flow"}]}, {"type": "paragraph", "content": []}]}`,
+			want:     "Goal\nShip the booking flow",
+		},
+		{
+			name:     "nested and ordered lists",
+			document: `{"type": "doc", "content": [{"type": "bulletList", "content": [{"type": "listItem", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "API"}]}, {"type": "orderedList", "attrs": {"order": 3}, "content": [{"type": "listItem", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "schema"}]}]}, {"type": "listItem", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "handler"}]}]}]}]}]}]}`,
+			want:     "- API\n  3. schema\n  4. handler",
+		},
+		{
+			name:     "task list",
+			document: `{"type": "doc", "content": [{"type": "taskList", "content": [{"type": "taskItem", "attrs": {"state": "DONE"}, "content": [{"type": "text", "text": "design"}]}, {"type": "taskItem", "attrs": {"state": "TODO"}, "content": [{"type": "text", "text": "build"}]}]}]}`,
+			want:     "[x] design\n[ ] build",
+		},
+		{
+			name:     "links, mentions, cards, emoji, status and dates",
+			document: `{"type": "doc", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "spec", "marks": [{"type": "link", "attrs": {"href": "https://wiki/spec"}}]}, {"type": "text", "text": " by "}, {"type": "mention", "attrs": {"id": "acc-1", "text": "@Jane"}}, {"type": "text", "text": " "}, {"type": "emoji", "attrs": {"shortName": ":tada:", "text": "🎉"}}, {"type": "hardBreak"}, {"type": "inlineCard", "attrs": {"url": "https://jira/FN-1"}}, {"type": "text", "text": " "}, {"type": "status", "attrs": {"text": "BLOCKED"}}, {"type": "text", "text": " until "}, {"type": "date", "attrs": {"timestamp": "1717200000000"}}]}]}`,
+			want:     "spec (https://wiki/spec) by @Jane 🎉\nhttps://jira/FN-1 BLOCKED until 2024-06-01",
+		},
+		{
+			name:     "code block, quote and rule",
+			document: `{"type": "doc", "content": [{"type": "codeBlock", "attrs": {"language": "go"}, "content": [{"type": "text", "text": "func main() {\n}"}]}, {"type": "rule"}, {"type": "blockquote", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "quoted"}]}]}]}`,
+			want:     "func main() {\n}\n---\n> quoted",
+		},
+		{
+			name:     "table",
+			document: `{"type": "doc", "content": [{"type": "table", "content": [{"type": "tableRow", "content": [{"type": "tableHeader", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "Asset"}]}]}, {"type": "tableHeader", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "Hours"}]}]}]}, {"type": "tableRow", "content": [{"type": "tableCell", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "Booking"}]}]}, {"type": "tableCell", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "12"}]}]}]}]}]}`,
+			want:     "Asset | Hours\nBooking | 12",
+		},
+		{
+			name:     "panels and expands",
+			document: `{"type": "doc", "content": [{"type": "panel", "attrs": {"panelType": "info"}, "content": [{"type": "paragraph", "content": [{"type": "text", "text": "note"}]}]}, {"type": "expand", "attrs": {"title": "Details"}, "content": [{"type": "paragraph", "content": [{"type": "text", "text": "hidden"}]}]}]}`,
+			want:     "note\nDetails\nhidden",
+		},
+		{
+			name:     "plain string",
+			document: `"legacy description"`,
+			want:     "legacy description",
+		},
+		{
+			name:     "null",
+			document: `null`,
+			want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var node ADFNode
+			require.NoError(t, json.Unmarshal([]byte(tt.document), &node))
+
+			assert.Equal(t, tt.want, node.PlainText())
+		})
+	}
+}
@@ -16,32 +16,55 @@ type JiraIssue struct {
 
 // SearchResult represents the Jira API search response
 type SearchResult struct {
+	// StartAt is the index of the first issue of the page
+	StartAt int `json:"startAt"`
+	// Total is the number of issues matching the query, nil when Jira does not report it
+	Total  *int    `json:"total,omitempty"`
 	Issues []Issue `json:"issues"`
+	// ChangelogUnavailable marks a search Jira answered without changelogs for lack of permission
+	ChangelogUnavailable bool `json:"-"`
 }
 
 // Issue represents a Jira issue
 type Issue struct {
 	Key    string `json:"key"`
 	Fields Fields `json:"fields"`
+	// Changelog is returned next to the fields when the search expands changelogs
+	Changelog Changelog `json:"changelog"`
+}
+
+// Histories returns the changelog of the issue, wherever the response placed it
+func (i Issue) Histories() []ChangelogHistory {
+	if len(i.Changelog.Histories) > 0 {
+		return i.Changelog.Histories
+	}
+	return i.Fields.Changelog.Histories
 }
 
 // Fields represents the fields of a Jira issue
 type Fields struct {
-	Summary     string                 `json:"summary"`
-	Description Description            `json:"description"`
-	Status      Status                 `json:"status"`
-	Project     Project                `json:"project"`
-	Sprint      []Sprint               `json:"sprint"`
-	Changelog   Changelog              `json:"changelog"`
-	Created     string                 `json:"created"`
-	Updated     string                 `json:"updated"`
-	Assignee    Assignee               `json:"assignee"`
-	IssueType   IssueType              `json:"issuetype"`
-	Parent      *Issue                 `json:"parent"`
-	WorkType    string                 `json:"customfield_10014"`
-	AssetName   string                 `json:"customfield_10015"`
-	Labels      []string               `json:"labels"`
-	RawFields   map[string]interface{} `json:"-"`
+	Summary     string      `json:"summary"`
+	Description Description `json:"description"`
+	Comment     Comments    `json:"comment"`
+	Status      Status      `json:"status"`
+	Project     Project     `json:"project"`
+	Sprint      []Sprint    `json:"sprint"`
+	FixVersions []Version   `json:"fixVersions"`
+	Changelog   Changelog   `json:"changelog"`
+	Created     string      `json:"created"`
+	Updated     string      `json:"updated"`
+	Resolved    string      `json:"resolutiondate"`
+	Assignee    Assignee    `json:"assignee"`
+	IssueType   IssueType   `json:"issuetype"`
+	Parent      *Issue      `json:"parent"`
+	WorkType    string      `json:"customfield_10014"`
+	AssetName   string      `json:"customfield_10015"`
+	Labels      []string    `json:"labels"`
+	// OriginalEstimate and RemainingEstimate are the time tracking estimates in seconds, nil
+	// when the issue is not estimated
+	OriginalEstimate  *int64                 `json:"timeoriginalestimate"`
+	RemainingEstimate *int64                 `json:"timeestimate"`
+	RawFields         map[string]interface{} `json:"-"`
 }
 
 // UnmarshalJSON implements custom JSON unmarshaling for Fields
@@ -52,9 +75,6 @@ func (f *Fields) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	// Store raw fields for later use
-	f.RawFields = rawFields
-
 	// Create a temporary struct for standard fields
 	type tempFields Fields
 	var temp tempFields
@@ -65,6 +85,9 @@ func (f *Fields) UnmarshalJSON(data []byte) error {
 	// Copy standard fields
 	*f = Fields(temp)
 
+	// Store raw fields for later use
+	f.RawFields = rawFields
+
 	// Look for sprint field in all custom fields
 	for key, value := range rawFields {
 		if strings.HasPrefix(key, "customfield_") {
@@ -92,9 +115,35 @@ func (f *Fields) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// UseSprintField reads the sprints from the sprint field detected for the instance only,
+// instead of the first custom field that looks like one
+func (f *Fields) UseSprintField(field string) {
+	f.Sprint = nil
+	value, ok := f.RawFields[field]
+	if !ok {
+		return
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	var sprints []Sprint
+	if err := json.Unmarshal(data, &sprints); err == nil {
+		f.Sprint = sprints
+	}
+}
+
 // Status represents the status of a Jira issue
 type Status struct {
-	Name string `json:"name"`
+	ID             string         `json:"id,omitempty"`
+	Name           string         `json:"name"`
+	StatusCategory StatusCategory `json:"statusCategory"`
+}
+
+// StatusCategory represents the category of a Jira status: new, indeterminate or done. Unlike
+// status names, the categories do not depend on the language of the Jira instance.
+type StatusCategory struct {
+	Key string `json:"key,omitempty"`
 }
 
 // Project represents a Jira project
@@ -114,6 +163,14 @@ type Sprint struct {
 	Goal         string `json:"goal,omitempty"`
 }
 
+// Version represents a Jira fix version
+type Version struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Released    bool   `json:"released"`
+	ReleaseDate string `json:"releaseDate,omitempty"`
+}
+
 // ChangelogItem represents a single change in a Jira issue's history
 type ChangelogItem struct {
 	Field      string `json:"field"`
@@ -123,26 +180,35 @@ type ChangelogItem struct {
 
 // ChangelogHistory represents a historical change in a Jira issue
 type ChangelogHistory struct {
+	Author  User            `json:"author"`
 	Created string          `json:"created"`
 	Items   []ChangelogItem `json:"items"`
 }
 
+// User is the Jira user who made a change
+type User struct {
+	AccountID   string `json:"accountId"`
+	DisplayName string `json:"displayName"`
+}
+
 // Changelog represents the changelog of a Jira issue
 type Changelog struct {
 	Histories []ChangelogHistory `json:"histories"`
 }
 
-// Description represents the description content of a Jira issue
-type Description struct {
-	Type    string `json:"type"`
-	Version int    `json:"version"`
-	Content []struct {
-		Type    string `json:"type"`
-		Content []struct {
-			Type string `json:"type"`
-			Text string `json:"text"`
-		} `json:"content"`
-	} `json:"content"`
+// Description represents the description content of a Jira issue, an ADF document
+type Description = ADFNode
+
+// Comments represents the comments of a Jira issue
+type Comments struct {
+	Comments []Comment `json:"comments"`
+}
+
+// Comment represents a comment on a Jira issue
+type Comment struct {
+	Author  Assignee `json:"author"`
+	Body    ADFNode  `json:"body"`
+	Created string   `json:"created"`
 }
 
 // Assignee represents the assignee of a Jira issue
@@ -150,7 +216,26 @@ type Assignee struct {
 	DisplayName string `json:"displayName"`
 }
 
-// Add IssueType struct
+// IssueType represents the type of a Jira issue
 type IssueType struct {
+	ID   string `json:"id,omitempty"`
 	Name string `json:"name"`
+	// Subtask and HierarchyLevel place the type in the issue type hierarchy: sub-tasks are at
+	// level -1, standard issues at 0 and epics at 1
+	Subtask        bool `json:"subtask,omitempty"`
+	HierarchyLevel int  `json:"hierarchyLevel,omitempty"`
+}
+
+// WebhookEvent represents the body of a Jira issue webhook
+type WebhookEvent struct {
+	WebhookEvent string `json:"webhookEvent"`
+	Issue        Issue  `json:"issue"`
+	// Timestamp is when the event happened, in milliseconds since the epoch
+	Timestamp int64 `json:"timestamp"`
+	// User made the change reported by the event
+	User User `json:"user"`
+	// Changelog lists the fields changed by the event
+	Changelog struct {
+		Items []ChangelogItem `json:"items"`
+	} `json:"changelog"`
 }
@@ -86,3 +86,19 @@ func TestSearchResult_UnmarshalJSON(t *testing.T) {
 	require.NoError(t, err, "Should parse updated timestamp")
 	assert.Equal(t, "2024-03-20T11:00:00Z", updated.Format(time.RFC3339), "Updated timestamp should match")
 }
+
+func TestFields_UseSprintField(t *testing.T) {
+	data := []byte(`{
+		"customfield_10007": [{"name": "Board column", "state": "active"}],
+		"customfield_10100": [{"id": 7, "name": "Sprint 1", "state": "closed"}]
+	}`)
+
+	var fields Fields
+	require.NoError(t, json.Unmarshal(data, &fields))
+	fields.UseSprintField("customfield_10100")
+	require.Len(t, fields.Sprint, 1)
+	assert.Equal(t, "Sprint 1", fields.Sprint[0].Name)
+
+	fields.UseSprintField("customfield_10020")
+	assert.Empty(t, fields.Sprint, "issues without the detected field have no sprints")
+}
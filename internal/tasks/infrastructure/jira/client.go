@@ -4,13 +4,19 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"os"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/helmedeiros/digital-asset-capitalization/internal/jirafield"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/jql"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/redact"
 	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
 	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/infrastructure/jira/api"
 )
@@ -20,8 +26,14 @@ type Client interface {
 	// FetchTasks retrieves tasks from Jira for a given project and sprint
 	FetchTasks(ctx context.Context, project, sprint string) ([]*domain.Task, error)
 
+	// FetchTasksByFixVersion retrieves tasks from Jira for a given project and fix version
+	FetchTasksByFixVersion(ctx context.Context, project, fixVersion string) ([]*domain.Task, error)
+
 	// UpdateLabels updates the labels of a Jira issue
 	UpdateLabels(ctx context.Context, issueKey string, labels []string) error
+
+	// SetIssueProperty stores a JSON value as an entity property of a Jira issue
+	SetIssueProperty(ctx context.Context, issueKey, key string, value any) error
 }
 
 // HTTPClient defines the interface for making HTTP requests
@@ -39,22 +51,45 @@ var NewClient ClientFactory = newClient
 type client struct {
 	httpClient HTTPClient
 	config     *Config
+	// warnings receives warnings about skipped and incomplete results, os.Stderr when nil
+	warnings io.Writer
+
+	// sprintPeriods caches the sprint dates looked up on the Agile board API
+	sprintMu      sync.Mutex
+	sprintPeriods map[string]sprintPeriod
 }
 
+// DefaultTimeout bounds each Jira request when no HTTP client is configured
+const DefaultTimeout = 30 * time.Second
+
 // NewClient creates a new Jira client instance
 func newClient(config *Config) (Client, error) {
 	if err := config.Validate(); err != nil {
 		return nil, err
 	}
 
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: DefaultTimeout,
+		}
+	}
 	return &client{
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		config: config,
+		httpClient: httpClient,
+		config:     config,
+		warnings:   os.Stderr,
 	}, nil
 }
 
+// warnf writes a warning to the warnings writer, keeping stdout for the command output
+func (c *client) warnf(format string, args ...any) {
+	w := c.warnings
+	if w == nil {
+		w = os.Stderr
+	}
+	fmt.Fprintf(w, format, args...)
+}
+
 // mapJiraStatus converts a Jira status to our domain TaskStatus
 func mapJiraStatus(status string) domain.TaskStatus {
 	switch strings.ToUpper(status) {
@@ -71,6 +106,38 @@ func mapJiraStatus(status string) domain.TaskStatus {
 	}
 }
 
+// taskStatus converts a Jira status to our domain TaskStatus by its category, which does not
+// depend on the language of the status names. Blocked statuses have no category of their own,
+// and statuses without a category are mapped by name.
+func taskStatus(status api.Status) domain.TaskStatus {
+	if mapped := mapJiraStatus(status.Name); mapped == domain.TaskStatusBlocked {
+		return mapped
+	}
+	switch status.StatusCategory.Key {
+	case "new":
+		return domain.TaskStatusTodo
+	case "indeterminate":
+		return domain.TaskStatusInProgress
+	case "done":
+		return domain.TaskStatusDone
+	default:
+		return mapJiraStatus(status.Name)
+	}
+}
+
+// taskType converts a Jira issue type to our domain TaskType, recognizing sub-tasks and epics
+// by their level in the issue type hierarchy and the other types by name
+func taskType(issueType api.IssueType) domain.TaskType {
+	switch {
+	case issueType.Subtask || issueType.HierarchyLevel < 0:
+		return domain.TaskTypeSubtask
+	case issueType.HierarchyLevel > 0:
+		return domain.TaskTypeEpic
+	default:
+		return mapJiraType(issueType.Name)
+	}
+}
+
 // mapJiraType converts a Jira issue type to our domain TaskType
 func mapJiraType(issueType string) domain.TaskType {
 	switch strings.ToUpper(issueType) {
@@ -135,140 +202,163 @@ func wasWorkedOnDuringSprint(issue api.Issue, sprintStart, sprintEnd time.Time)
 	return false
 }
 
-// convertToDomainTasks converts Jira issues to domain tasks
-func (c *client) convertToDomainTasks(searchResp api.SearchResult, sprint string) ([]*domain.Task, error) {
+// resolvedDuringSprint stands in for the changelog when Jira withholds it: an issue counts
+// for the sprint it was resolved in, or for its last sprint while unresolved
+func resolvedDuringSprint(issue api.Issue, sprint string, sprintStart, sprintEnd time.Time) bool {
+	if issue.Fields.Resolved == "" {
+		return issue.Fields.Sprint[len(issue.Fields.Sprint)-1].Name == sprint
+	}
+	resolved, err := parseTime(issue.Fields.Resolved)
+	if err != nil {
+		return false
+	}
+	return !resolved.Before(sprintStart) && !resolved.After(sprintEnd)
+}
+
+// convertToDomainTasks converts Jira issues to domain tasks. Issues whose sprint dates are
+// neither in the sprint field nor on the board API are skipped with a warning.
+func (c *client) convertToDomainTasks(ctx context.Context, searchResp api.SearchResult, sprint string) ([]*domain.Task, error) {
 	tasks := make([]*domain.Task, 0, len(searchResp.Issues))
 	for _, issue := range searchResp.Issues {
-		// Get sprint dates if available
-		var sprintStart, sprintEnd time.Time
-		if len(issue.Fields.Sprint) > 0 {
-			for _, s := range issue.Fields.Sprint {
-				if s.Name == sprint {
-					var err error
-					if s.StartDate != "" {
-						sprintStart, err = parseTime(s.StartDate)
-						if err != nil {
-							continue
-						}
-					}
-					if s.EndDate != "" {
-						sprintEnd, err = parseTime(s.EndDate)
-						if err != nil {
-							continue
-						}
-					}
-					break
-				}
-			}
-		}
-
-		// Skip if we couldn't get valid sprint dates
-		if sprintStart.IsZero() || sprintEnd.IsZero() {
+		period, err := c.sprintPeriod(ctx, issue, sprint)
+		if err != nil {
+			c.warnf("Warning: skipping %s: %v\n", issue.Key, err)
 			continue
 		}
+		sprintStart, sprintEnd := period.start, period.end
 
 		// For issues with multiple sprints, check if there was any work done during this sprint
 		if len(issue.Fields.Sprint) > 1 {
-			if !wasWorkedOnDuringSprint(issue, sprintStart, sprintEnd) {
+			if searchResp.ChangelogUnavailable {
+				if !resolvedDuringSprint(issue, sprint, sprintStart, sprintEnd) {
+					continue
+				}
+			} else if !wasWorkedOnDuringSprint(issue, sprintStart, sprintEnd) {
 				continue
 			}
 		}
 
-		// Handle empty timestamps
-		created := time.Now()
-		updated := time.Now()
-
-		if issue.Fields.Created != "" {
-			var err error
-			created, err = parseTime(issue.Fields.Created)
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse created time: %w", err)
-			}
+		task, err := issueToTask(issue)
+		if err != nil {
+			return nil, err
 		}
+		tasks = append(tasks, task)
+	}
 
-		if issue.Fields.Updated != "" {
-			var err error
-			updated, err = parseTime(issue.Fields.Updated)
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse updated time: %w", err)
-			}
+	return tasks, nil
+}
+
+// estimateHours converts a time tracking estimate in seconds to hours
+func estimateHours(seconds *int64) *float64 {
+	if seconds == nil {
+		return nil
+	}
+	hours := float64(*seconds) / 3600
+	return &hours
+}
+
+// issueToTask converts a single Jira issue to a domain task
+func issueToTask(issue api.Issue) (*domain.Task, error) {
+	// Handle empty timestamps
+	created := time.Now()
+	updated := time.Now()
+
+	if issue.Fields.Created != "" {
+		var err error
+		created, err = parseTime(issue.Fields.Created)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse created time: %w", err)
 		}
+	}
 
-		// Handle empty sprint
-		sprintName := ""
-		if len(issue.Fields.Sprint) > 0 {
-			var sprintNames []string
-			for _, s := range issue.Fields.Sprint {
-				if s.Name != "" {
-					sprintNames = append(sprintNames, s.Name)
-				}
-			}
-			if len(sprintNames) > 0 {
-				sprintName = strings.Join(sprintNames, ", ")
-			}
+	if issue.Fields.Updated != "" {
+		var err error
+		updated, err = parseTime(issue.Fields.Updated)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse updated time: %w", err)
 		}
+	}
 
-		// Use the project key from the issue key if not available in fields
-		projectKey := issue.Fields.Project.Key
-		if projectKey == "" {
-			parts := strings.Split(issue.Key, "-")
-			if len(parts) > 0 {
-				projectKey = parts[0]
+	// Handle empty sprint
+	sprintName := ""
+	if len(issue.Fields.Sprint) > 0 {
+		var sprintNames []string
+		for _, s := range issue.Fields.Sprint {
+			if s.Name != "" {
+				sprintNames = append(sprintNames, s.Name)
 			}
 		}
+		if len(sprintNames) > 0 {
+			sprintName = strings.Join(sprintNames, ", ")
+		}
+	}
 
-		// Get the parent issue key for stories
-		epicKey := ""
-		if issue.Fields.Parent != nil {
-			epicKey = issue.Fields.Parent.Key
+	var fixVersions []string
+	for _, version := range issue.Fields.FixVersions {
+		if version.Name != "" {
+			fixVersions = append(fixVersions, version.Name)
 		}
+	}
+	// Issues released outside of a sprint are grouped under their fix version
+	if sprintName == "" && len(fixVersions) > 0 {
+		sprintName = fixVersions[0]
+	}
 
-		task, err := domain.NewTask(issue.Key, issue.Fields.Summary, projectKey, sprintName, "JIRA")
-		if err != nil {
-			return nil, fmt.Errorf("failed to create task: %w", err)
+	// Use the project key from the issue key if not available in fields
+	projectKey := issue.Fields.Project.Key
+	if projectKey == "" {
+		parts := strings.Split(issue.Key, "-")
+		if len(parts) > 0 {
+			projectKey = parts[0]
 		}
+	}
 
-		// Set additional fields
-		var description string
-		if len(issue.Fields.Description.Content) > 0 {
-			for _, content := range issue.Fields.Description.Content {
-				if content.Type == "paragraph" {
-					for _, text := range content.Content {
-						if text.Type == "text" {
-							description += text.Text
-						}
-					}
-				}
-			}
+	// Get the parent issue key for stories
+	epicKey := ""
+	if issue.Fields.Parent != nil {
+		epicKey = issue.Fields.Parent.Key
+	}
+
+	task, err := domain.NewTask(issue.Key, issue.Fields.Summary, projectKey, sprintName, "JIRA")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create task: %w", err)
+	}
+
+	// Set additional fields
+	task.Description = issue.Fields.Description.PlainText()
+	for _, comment := range issue.Fields.Comment.Comments {
+		if body := comment.Body.PlainText(); body != "" {
+			task.Comments = append(task.Comments, body)
 		}
-		task.Description = strings.TrimSpace(description)
-		task.Status = mapJiraStatus(issue.Fields.Status.Name)
-		task.Type = mapJiraType(issue.Fields.IssueType.Name)
-		task.Priority = domain.TaskPriorityMedium // Default priority since it's not available in the API
-		task.Labels = issue.Fields.Labels
-		task.Epic = epicKey
-		task.CreatedAt = created
-		task.UpdatedAt = updated
-
-		// Set work type from labels
-		for _, label := range issue.Fields.Labels {
-			switch label {
-			case "cap-maintenance":
-				task.WorkType = domain.WorkTypeMaintenance
-			case "cap-discovery":
-				task.WorkType = domain.WorkTypeDiscovery
-			case "cap-development":
-				task.WorkType = domain.WorkTypeDevelopment
-			}
-			if task.WorkType != "" {
-				break
-			}
+	}
+	task.Status = taskStatus(issue.Fields.Status)
+	task.Type = taskType(issue.Fields.IssueType)
+	task.Priority = domain.TaskPriorityMedium // Default priority since it's not available in the API
+	task.Labels = issue.Fields.Labels
+	task.FixVersions = fixVersions
+	task.Epic = epicKey
+	task.CreatedAt = created
+	task.UpdatedAt = updated
+	task.LabelHistory = labelHistory(issue.Histories())
+	task.OriginalEstimate = estimateHours(issue.Fields.OriginalEstimate)
+	task.RemainingEstimate = estimateHours(issue.Fields.RemainingEstimate)
+
+	// Set work type from labels
+	for _, label := range issue.Fields.Labels {
+		switch label {
+		case "cap-maintenance":
+			task.WorkType = domain.WorkTypeMaintenance
+		case "cap-discovery":
+			task.WorkType = domain.WorkTypeDiscovery
+		case "cap-development":
+			task.WorkType = domain.WorkTypeDevelopment
+		}
+		if task.WorkType != "" {
+			break
 		}
-
-		tasks = append(tasks, task)
 	}
 
-	return tasks, nil
+	return task, nil
 }
 
 // FetchTasks retrieves tasks from Jira for a given project and sprint
@@ -284,15 +374,122 @@ func (c *client) FetchTasks(ctx context.Context, project, sprint string) ([]*dom
 	}
 	jql += " ORDER BY key ASC"
 
-	// Build request URL with fields and expand parameters
-	url := fmt.Sprintf("%s/rest/api/3/search?jql=%s&fields=*all&expand=changelog",
+	searchResp, err := c.search(ctx, jql)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := c.convertToDomainTasks(ctx, searchResp, sprint)
+	if err != nil {
+		return nil, err
+	}
+
+	c.resolveHierarchy(ctx, searchResp.Issues, tasks)
+	return tasks, nil
+}
+
+// FetchTasksByFixVersion retrieves tasks from Jira for a given project and fix version.
+// Unlike sprints, every issue of the release is kept regardless of when it was worked on.
+func (c *client) FetchTasksByFixVersion(ctx context.Context, project, fixVersion string) ([]*domain.Task, error) {
+	if project == "" {
+		return nil, fmt.Errorf("project is required")
+	}
+	if fixVersion == "" {
+		return nil, fmt.Errorf("fix version is required")
+	}
+
+	query := fmt.Sprintf("project = %s AND fixVersion = %s ORDER BY key ASC", project, jql.Quote(fixVersion))
+	searchResp, err := c.search(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*domain.Task, 0, len(searchResp.Issues))
+	for _, issue := range searchResp.Issues {
+		task, err := issueToTask(issue)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	c.resolveHierarchy(ctx, searchResp.Issues, tasks)
+	return tasks, nil
+}
+
+// search runs a JQL query and returns the matching issues with all fields and their changelog.
+// When Jira denies permission to the changelog, the query is repeated without it and the
+// result marked ChangelogUnavailable.
+func (c *client) search(ctx context.Context, jql string) (api.SearchResult, error) {
+	searchResp, err := c.searchIssues(ctx, jql, true)
+	var denied *changelogDeniedError
+	if !errors.As(err, &denied) {
+		return searchResp, err
+	}
+
+	c.warnf("Warning: changelog unavailable (%s); falling back to resolution date heuristic\n", denied.status)
+	searchResp, err = c.searchIssues(ctx, jql, false)
+	searchResp.ChangelogUnavailable = true
+	return searchResp, err
+}
+
+// changelogDeniedError reports a search refused for lack of permission to expand changelogs
+type changelogDeniedError struct {
+	status string
+	body   string
+}
+
+// Error implements error
+func (e *changelogDeniedError) Error() string {
+	return fmt.Sprintf("unexpected status code: %s, body: %s", e.status, redact.String(e.body))
+}
+
+// searchIssues runs a JQL query for the configured fields, expanding the changelogs when asked
+// to, and gathers the issues of every page of the result
+func (c *client) searchIssues(ctx context.Context, jql string, changelog bool) (api.SearchResult, error) {
+	var result api.SearchResult
+	for {
+		page, err := c.searchPage(ctx, jql, changelog, len(result.Issues))
+		if err != nil {
+			return api.SearchResult{}, err
+		}
+		result.Issues = append(result.Issues, page.Issues...)
+		result.Total = page.Total
+
+		if page.Total == nil || len(result.Issues) >= *page.Total {
+			break
+		}
+		if len(page.Issues) == 0 {
+			c.warnf("Warning: Jira reported %d issues but %d were received\n", *page.Total, len(result.Issues))
+			break
+		}
+	}
+
+	if field := c.config.Fields.SprintField; field != "" {
+		for i := range result.Issues {
+			result.Issues[i].Fields.UseSprintField(field)
+		}
+	}
+	return result, nil
+}
+
+// searchPage runs a JQL query for the configured fields and returns the page of issues
+// starting at the given index
+func (c *client) searchPage(ctx context.Context, jql string, changelog bool, startAt int) (api.SearchResult, error) {
+	// Build request URL with fields, paging and expand parameters
+	url := fmt.Sprintf("%s/rest/api/3/search?jql=%s&fields=%s&startAt=%d&maxResults=%d",
 		c.config.GetBaseURL(),
-		url.QueryEscape(jql))
+		url.QueryEscape(jql),
+		url.QueryEscape(strings.Join(c.config.SearchFields(), ",")),
+		startAt, c.config.SearchPageSize())
+	if changelog {
+		url += "&expand=changelog"
+	}
 
 	// Create request
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return api.SearchResult{}, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Add authentication headers
@@ -302,68 +499,135 @@ func (c *client) FetchTasks(ctx context.Context, project, sprint string) ([]*dom
 	// Execute request
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		return api.SearchResult{}, redact.Error(fmt.Errorf("failed to execute request: %w", err))
 	}
 	defer resp.Body.Close()
 
 	// Check response status and body
 	body, _ := io.ReadAll(resp.Body)
+	if changelog && changelogDenied(resp.StatusCode, body) {
+		return api.SearchResult{}, &changelogDeniedError{status: resp.Status, body: string(body)}
+	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+		return api.SearchResult{}, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, redact.String(string(body)))
 	}
 
 	// Parse response
-	var searchResp api.SearchResult
-	if err := json.NewDecoder(bytes.NewReader(body)).Decode(&searchResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	var page api.SearchResult
+	if err := json.NewDecoder(bytes.NewReader(body)).Decode(&page); err != nil {
+		return api.SearchResult{}, fmt.Errorf("failed to decode response: %w", err)
 	}
+	return page, nil
+}
 
-	return c.convertToDomainTasks(searchResp, sprint)
+// changelogDenied reports whether Jira refused a changelog search for lack of permission
+func changelogDenied(status int, body []byte) bool {
+	if status == http.StatusForbidden {
+		return true
+	}
+	return status == http.StatusBadRequest && strings.Contains(strings.ToLower(string(body)), "permission")
 }
 
-type HTTPClientImpl struct {
-	client  *http.Client
-	baseURL string
-	auth    string
+// resolveHierarchy stores the configured parent chain on each task, fetching
+// ancestors that are not part of the search result
+func (c *client) resolveHierarchy(ctx context.Context, issues []api.Issue, tasks []*domain.Task) {
+	levels := c.config.GetHierarchy()
+	fieldsByKey := make(map[string]map[string]interface{}, len(issues))
+	for _, issue := range issues {
+		fieldsByKey[issue.Key] = issue.Fields.RawFields
+	}
+
+	for _, task := range tasks {
+		fields := fieldsByKey[task.Key]
+		var chain []domain.HierarchyLink
+		for i, level := range levels {
+			parentKey := parentKeyFrom(fields[level.Field])
+			if parentKey == "" {
+				break
+			}
+			chain = append(chain, domain.HierarchyLink{Level: level.Level, Key: parentKey})
+			if i == len(levels)-1 {
+				break
+			}
+
+			parentFields, ok := fieldsByKey[parentKey]
+			if !ok {
+				var err error
+				parentFields, err = c.fetchIssueFields(ctx, parentKey, levels)
+				if err != nil {
+					c.warnf("Warning: failed to resolve hierarchy above %s: %v\n", parentKey, err)
+					break
+				}
+				fieldsByKey[parentKey] = parentFields
+			}
+			fields = parentFields
+		}
+
+		task.Hierarchy = chain
+		if len(chain) > 0 {
+			task.Epic = chain[0].Key
+		}
+	}
 }
 
-type Field struct {
-	ID     string `json:"id"`
-	Name   string `json:"name"`
-	Schema struct {
-		Type   string `json:"type"`
-		Custom string `json:"custom"`
-	} `json:"schema"`
+// parentKeyFrom extracts an issue key from a parent field, which is either
+// a plain key or an issue object
+func parentKeyFrom(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		if key, ok := v["key"].(string); ok {
+			return key
+		}
+	}
+	return ""
 }
 
-func (c *HTTPClientImpl) getSprintFieldID() (string, error) {
-	url := fmt.Sprintf("%s/rest/api/2/field", c.baseURL)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return "", fmt.Errorf("error creating request: %v", err)
+// fetchIssueFields retrieves the hierarchy fields of a single issue
+func (c *client) fetchIssueFields(ctx context.Context, issueKey string, levels []HierarchyLevel) (map[string]interface{}, error) {
+	fieldIDs := make([]string, 0, len(levels))
+	for _, level := range levels {
+		fieldIDs = append(fieldIDs, level.Field)
 	}
 
-	req.Header.Set("Authorization", c.auth)
-	req.Header.Set("Content-Type", "application/json")
+	url := fmt.Sprintf("%s/rest/api/3/issue/%s?fields=%s", c.config.GetBaseURL(), issueKey, strings.Join(fieldIDs, ","))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", c.config.GetAuthHeader())
+	req.Header.Set("Accept", "application/json")
 
-	resp, err := c.client.Do(req)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("error making request: %v", err)
+		return nil, redact.Error(fmt.Errorf("failed to execute request: %w", err))
 	}
 	defer resp.Body.Close()
 
-	var fields []Field
-	if err := json.NewDecoder(resp.Body).Decode(&fields); err != nil {
-		return "", fmt.Errorf("error decoding response: %v", err)
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, redact.String(string(body)))
 	}
 
-	for _, field := range fields {
-		if field.Schema.Custom == "com.pyxis.greenhopper.jira:gh-sprint" {
-			return field.ID, nil
-		}
+	var issue struct {
+		Fields map[string]interface{} `json:"fields"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&issue); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
+	return issue.Fields, nil
+}
+
+type HTTPClientImpl struct {
+	client  *http.Client
+	baseURL string
+	auth    string
+}
 
-	return "", fmt.Errorf("sprint field not found")
+// getSprintFieldID detects the custom field holding the sprints of the instance's issues
+func (c *HTTPClientImpl) getSprintFieldID() (string, error) {
+	return jirafield.DetectSprintField(c.client, c.baseURL, c.auth)
 }
 
 func (c *HTTPClientImpl) GetTasks(project string, sprint string) ([]api.JiraIssue, error) {
@@ -383,13 +647,13 @@ func (c *HTTPClientImpl) GetTasks(project string, sprint string) ([]api.JiraIssu
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("error making request: %v", err)
+		return nil, redact.Error(fmt.Errorf("error making request: %v", err))
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("error response from Jira: %s - %s", resp.Status, string(body))
+		return nil, fmt.Errorf("error response from Jira: %s - %s", resp.Status, redact.String(string(body)))
 	}
 
 	var result api.SearchResult
@@ -449,15 +713,45 @@ func (c *client) UpdateLabels(ctx context.Context, issueKey string, labels []str
 	// Send request
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
+		return redact.Error(fmt.Errorf("failed to send request: %w", err))
 	}
 	defer resp.Body.Close()
 
 	// Check response status
 	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to update labels: status %d, body: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("failed to update labels: status %d, body: %s", resp.StatusCode, redact.String(string(body)))
 	}
 
 	return nil
 }
+
+// SetIssueProperty stores a JSON value as an entity property of a Jira issue. Properties are
+// not shown in the Jira UI but are returned by the REST API.
+func (c *client) SetIssueProperty(ctx context.Context, issueKey, key string, value any) error {
+	jsonBody, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal issue property: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/rest/api/3/issue/%s/properties/%s", c.config.GetBaseURL(), issueKey, key)
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", c.config.GetAuthHeader())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return redact.Error(fmt.Errorf("failed to send request: %w", err))
+	}
+	defer resp.Body.Close()
+
+	// Jira answers 201 when the property is created and 200 when it is updated
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to set issue property %s: status %d, body: %s", key, resp.StatusCode, redact.String(string(body)))
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package jira
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -70,7 +71,7 @@ func TestClient_FetchTasks(t *testing.T) {
 			assert.Equal(t, http.MethodGet, r.Method, "Method should be GET")
 			assert.Equal(t, "/rest/api/3/search", r.URL.Path, "Path should match")
 			assert.Equal(t, "project = TEST AND sprint in (\"Sprint 1\") ORDER BY key ASC", r.URL.Query().Get("jql"), "JQL should match")
-			assert.Equal(t, "*all", r.URL.Query().Get("fields"), "Fields should match")
+			assert.Equal(t, strings.Join(append(append([]string{}, DefaultFields...), DefaultSprintField), ","), r.URL.Query().Get("fields"), "Fields should match")
 			assert.Equal(t, "changelog", r.URL.Query().Get("expand"), "Expand should match")
 
 			// Verify auth header
@@ -634,7 +635,7 @@ func TestFetchTasksWithMultipleSprints(t *testing.T) {
 			}
 
 			// Convert to domain tasks
-			tasks, err := mockClient.convertToDomainTasks(searchResp, tt.sprint)
+			tasks, err := mockClient.convertToDomainTasks(context.Background(), searchResp, tt.sprint)
 			require.NoError(t, err)
 
 			// Check if the issue was included in the results
@@ -701,6 +702,22 @@ func Test_mapJiraType(t *testing.T) {
 	}
 }
 
+func Test_taskStatus(t *testing.T) {
+	assert.Equal(t, domain.TaskStatusDone, taskStatus(api.Status{Name: "Fertig", StatusCategory: api.StatusCategory{Key: "done"}}))
+	assert.Equal(t, domain.TaskStatusInProgress, taskStatus(api.Status{Name: "Em andamento", StatusCategory: api.StatusCategory{Key: "indeterminate"}}))
+	assert.Equal(t, domain.TaskStatusTodo, taskStatus(api.Status{Name: "À faire", StatusCategory: api.StatusCategory{Key: "new"}}))
+	assert.Equal(t, domain.TaskStatusBlocked, taskStatus(api.Status{Name: "Blocked", StatusCategory: api.StatusCategory{Key: "indeterminate"}}))
+	assert.Equal(t, domain.TaskStatusDone, taskStatus(api.Status{Name: "Resolved"}), "statuses without a category are mapped by name")
+}
+
+func Test_taskType(t *testing.T) {
+	assert.Equal(t, domain.TaskTypeSubtask, taskType(api.IssueType{Name: "Unteraufgabe", Subtask: true}))
+	assert.Equal(t, domain.TaskTypeSubtask, taskType(api.IssueType{Name: "Subtarefa", HierarchyLevel: -1}))
+	assert.Equal(t, domain.TaskTypeEpic, taskType(api.IssueType{Name: "Épico", HierarchyLevel: 1}))
+	assert.Equal(t, domain.TaskTypeStory, taskType(api.IssueType{Name: "Story"}))
+	assert.Equal(t, domain.TaskTypeTask, taskType(api.IssueType{Name: "Aufgabe"}))
+}
+
 type mockTransport struct {
 	responses map[string]*http.Response
 	errors    map[string]error
@@ -885,10 +902,326 @@ func TestConvertToDomainTasks_WorkType(t *testing.T) {
 		Issues: []api.Issue{issue},
 	}
 
-	tasks, err := client.convertToDomainTasks(searchResp, "Sprint 1")
+	tasks, err := client.convertToDomainTasks(context.Background(), searchResp, "Sprint 1")
 	assert.NoError(t, err)
 	assert.Len(t, tasks, 1)
 
 	task := tasks[0]
 	assert.Equal(t, domain.WorkTypeDevelopment, task.WorkType)
 }
+
+func TestConvertToDomainTasks_DescriptionAndComments(t *testing.T) {
+	client := &client{}
+	var issue api.Issue
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"key": "TEST-1",
+		"fields": {
+			"summary": "Test task",
+			"project": {"key": "TEST"},
+			"customfield_10020": [{"name": "Sprint 1", "startDate": "2025-01-01T00:00:00.000Z", "endDate": "2025-01-14T00:00:00.000Z"}],
+			"created": "2025-01-01T00:00:00.000Z",
+			"updated": "2025-01-01T00:00:00.000Z",
+			"description": {"type": "doc", "version": 1, "content": [
+				{"type": "heading", "attrs": {"level": 2}, "content": [{"type": "text", "text": "Scope"}]},
+				{"type": "bulletList", "content": [
+					{"type": "listItem", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "New booking API"}]}]}
+				]}
+			]},
+			"comment": {"comments": [
+				{"body": {"type": "doc", "version": 1, "content": [{"type": "paragraph", "content": [
+					{"type": "mention", "attrs": {"id": "acc-1", "text": "@Jane Doe"}},
+					{"type": "text", "text": " fixed in prod"}
+				]}]}},
+				{"body": {"type": "doc", "version": 1, "content": []}}
+			]}
+		}
+	}`), &issue))
+
+	tasks, err := client.convertToDomainTasks(context.Background(), api.SearchResult{Issues: []api.Issue{issue}}, "Sprint 1")
+
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "Scope\n- New booking API", tasks[0].Description)
+	assert.Equal(t, []string{"@Jane Doe fixed in prod"}, tasks[0].Comments)
+}
+
+func TestConvertToDomainTasks_Estimates(t *testing.T) {
+	client := &client{}
+	var estimated, unestimated api.Issue
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"key": "TEST-1",
+		"fields": {
+			"summary": "Test task",
+			"project": {"key": "TEST"},
+			"customfield_10020": [{"name": "Sprint 1", "startDate": "2025-01-01T00:00:00.000Z", "endDate": "2025-01-14T00:00:00.000Z"}],
+			"created": "2025-01-01T00:00:00.000Z", "updated": "2025-01-01T00:00:00.000Z",
+			"timeoriginalestimate": 28800,
+			"timeestimate": 5400
+		}
+	}`), &estimated))
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"key": "TEST-2",
+		"fields": {"summary": "Test task", "project": {"key": "TEST"}, "customfield_10020": [{"name": "Sprint 1", "startDate": "2025-01-01T00:00:00.000Z", "endDate": "2025-01-14T00:00:00.000Z"}], "created": "2025-01-01T00:00:00.000Z", "updated": "2025-01-01T00:00:00.000Z", "timeoriginalestimate": null}
+	}`), &unestimated))
+
+	tasks, err := client.convertToDomainTasks(context.Background(), api.SearchResult{Issues: []api.Issue{estimated, unestimated}}, "Sprint 1")
+
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+	require.NotNil(t, tasks[0].OriginalEstimate)
+	assert.Equal(t, 8.0, *tasks[0].OriginalEstimate)
+	require.NotNil(t, tasks[0].RemainingEstimate)
+	assert.Equal(t, 1.5, *tasks[0].RemainingEstimate)
+	assert.Nil(t, tasks[1].OriginalEstimate)
+	assert.Nil(t, tasks[1].RemainingEstimate)
+}
+
+func TestClient_FetchTasksResolvesHierarchy(t *testing.T) {
+	now := time.Now().Format(time.RFC3339)
+	var parentRequests int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/search":
+			fmt.Fprintf(w, `{"issues": [
+				{"key": "FN-1", "fields": {
+					"summary": "Story one", "created": %[1]q, "updated": %[1]q,
+					"customfield_10100": [{"name": "Sprint 1", "startDate": %[1]q, "endDate": %[1]q}],
+					"parent": {"key": "FN-10"}
+				}},
+				{"key": "FN-2", "fields": {
+					"summary": "Story two", "created": %[1]q, "updated": %[1]q,
+					"customfield_10100": [{"name": "Sprint 1", "startDate": %[1]q, "endDate": %[1]q}],
+					"parent": {"key": "FN-10"}
+				}},
+				{"key": "FN-3", "fields": {
+					"summary": "No epic", "created": %[1]q, "updated": %[1]q,
+					"customfield_10100": [{"name": "Sprint 1", "startDate": %[1]q, "endDate": %[1]q}]
+				}}
+			]}`, now)
+		case "/rest/api/3/issue/FN-10":
+			parentRequests++
+			assert.Equal(t, "parent,customfield_10200", r.URL.Query().Get("fields"))
+			w.Write([]byte(`{"key": "FN-10", "fields": {"customfield_10200": "FN-100"}}`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{
+		BaseURL: server.URL,
+		Email:   "test@example.com",
+		Token:   "test-token",
+		Hierarchy: []HierarchyLevel{
+			{Level: "epic", Field: "parent"},
+			{Level: "initiative", Field: "customfield_10200"},
+		},
+	})
+	require.NoError(t, err)
+
+	tasks, err := client.FetchTasks(context.Background(), "FN", "Sprint 1")
+	require.NoError(t, err)
+	require.Len(t, tasks, 3)
+
+	expected := []domain.HierarchyLink{{Level: "epic", Key: "FN-10"}, {Level: "initiative", Key: "FN-100"}}
+	assert.Equal(t, expected, tasks[0].Hierarchy)
+	assert.Equal(t, expected, tasks[1].Hierarchy)
+	assert.Equal(t, "FN-10", tasks[0].Epic)
+	assert.Empty(t, tasks[2].Hierarchy)
+	assert.Equal(t, 1, parentRequests, "parent issues should be fetched once")
+}
+
+func TestParentKeyFrom(t *testing.T) {
+	assert.Equal(t, "FN-1", parentKeyFrom("FN-1"))
+	assert.Equal(t, "FN-2", parentKeyFrom(map[string]interface{}{"key": "FN-2"}))
+	assert.Equal(t, "", parentKeyFrom(nil))
+	assert.Equal(t, "", parentKeyFrom(42.0))
+}
+
+func TestClient_FetchTasksByFixVersion(t *testing.T) {
+	now := time.Now().Format(time.RFC3339)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/3/search", r.URL.Path)
+		assert.Equal(t, `project = FN AND fixVersion = "2024.5" ORDER BY key ASC`, r.URL.Query().Get("jql"))
+		fmt.Fprintf(w, `{"issues": [
+			{"key": "FN-1", "fields": {
+				"summary": "Sprint work", "created": %[1]q, "updated": %[1]q,
+				"customfield_10100": [{"name": "Sprint 1", "startDate": %[1]q, "endDate": %[1]q}],
+				"fixVersions": [{"id": "10001", "name": "2024.5", "released": true}]
+			}},
+			{"key": "FN-2", "fields": {
+				"summary": "Kanban work", "created": %[1]q, "updated": %[1]q,
+				"fixVersions": [{"id": "10001", "name": "2024.5", "released": true}]
+			}}
+		]}`, now)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{BaseURL: server.URL, Email: "test@example.com", Token: "test-token"})
+	require.NoError(t, err)
+
+	tasks, err := client.FetchTasksByFixVersion(context.Background(), "FN", "2024.5")
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+	assert.Equal(t, "Sprint 1", tasks[0].Sprint)
+	assert.Equal(t, []string{"2024.5"}, tasks[0].FixVersions)
+	assert.Equal(t, "2024.5", tasks[1].Sprint, "issues outside a sprint are grouped under the fix version")
+
+	_, err = client.FetchTasksByFixVersion(context.Background(), "FN", "")
+	assert.EqualError(t, err, "fix version is required")
+}
+
+func TestClient_FetchTasksByQuotedFixVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, `project = FN AND fixVersion = "2024.5 \"Phoenix\" \\ hotfix" ORDER BY key ASC`, r.URL.Query().Get("jql"))
+		fmt.Fprint(w, `{"issues": []}`)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{BaseURL: server.URL, Email: "test@example.com", Token: "test-token"})
+	require.NoError(t, err)
+
+	_, err = client.FetchTasksByFixVersion(context.Background(), "FN", `2024.5 "Phoenix" \ hotfix`)
+	require.NoError(t, err)
+}
+
+func TestClient_FetchTasksWithoutChangelogPermission(t *testing.T) {
+	sprints := `"customfield_10100": [
+		{"id": 1, "name": "Sprint 1", "startDate": "2024-01-01T00:00:00Z", "endDate": "2024-01-14T23:59:59Z"},
+		{"id": 2, "name": "Sprint 2", "startDate": "2024-01-15T00:00:00Z", "endDate": "2024-01-28T23:59:59Z"}
+	]`
+	var expands []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expands = append(expands, r.URL.Query().Get("expand"))
+		if r.URL.Query().Get("expand") == "changelog" {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"errorMessages": ["You do not have permission to view the issue history."]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"issues": [
+			{"key": "TEST-1", "fields": {"summary": "Resolved in sprint 1", "resolutiondate": "2024-01-10T12:00:00Z", %[1]s}},
+			{"key": "TEST-2", "fields": {"summary": "Resolved in sprint 2", "resolutiondate": "2024-01-20T12:00:00Z", %[1]s}},
+			{"key": "TEST-3", "fields": {"summary": "Unresolved", %[1]s}}
+		]}`, sprints)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{BaseURL: server.URL, Email: "test@example.com", Token: "test-token"})
+	require.NoError(t, err)
+
+	tasks, err := client.FetchTasks(context.Background(), "TEST", "Sprint 1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"changelog", ""}, expands)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "TEST-1", tasks[0].Key)
+
+	tasks, err = client.FetchTasks(context.Background(), "TEST", "Sprint 2")
+	require.NoError(t, err)
+	keys := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		keys = append(keys, task.Key)
+	}
+	assert.Equal(t, []string{"TEST-2", "TEST-3"}, keys)
+}
+
+func TestClient_FetchTasksReadsEveryPage(t *testing.T) {
+	sprint := `"customfield_10100": [{"name": "Sprint 1", "startDate": "2024-01-01T00:00:00Z", "endDate": "2024-01-14T23:59:59Z"}]`
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		pages = append(pages, query.Get("startAt")+"/"+query.Get("maxResults"))
+		// Jira caps the page below the requested size
+		switch query.Get("startAt") {
+		case "0":
+			fmt.Fprintf(w, `{"startAt": 0, "total": 3, "issues": [
+				{"key": "FN-1", "fields": {"summary": "One", "resolutiondate": "2024-01-05T12:00:00Z", %[1]s}},
+				{"key": "FN-2", "fields": {"summary": "Two", "resolutiondate": "2024-01-06T12:00:00Z", %[1]s}}
+			]}`, sprint)
+		case "2":
+			fmt.Fprintf(w, `{"startAt": 2, "total": 3, "issues": [
+				{"key": "FN-3", "fields": {"summary": "Three", "resolutiondate": "2024-01-07T12:00:00Z", %[1]s}}
+			]}`, sprint)
+		default:
+			t.Errorf("unexpected page starting at %s", query.Get("startAt"))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{BaseURL: server.URL, Email: "test@example.com", Token: "test-token", PageSize: 5})
+	require.NoError(t, err)
+
+	tasks, err := client.FetchTasks(context.Background(), "FN", "Sprint 1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0/5", "2/5"}, pages)
+	keys := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		keys = append(keys, task.Key)
+	}
+	assert.Equal(t, []string{"FN-1", "FN-2", "FN-3"}, keys)
+}
+
+func TestClient_FetchTasksStopsOnEmptyPage(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("startAt") != "0" {
+			fmt.Fprint(w, `{"total": 5, "issues": []}`)
+			return
+		}
+		fmt.Fprint(w, `{"total": 5, "issues": [{"key": "FN-1", "fields": {"summary": "One", "fixVersions": [{"name": "2024.5"}]}}]}`)
+	}))
+	defer server.Close()
+
+	jiraClient, err := NewClient(&Config{BaseURL: server.URL, Email: "test@example.com", Token: "test-token"})
+	require.NoError(t, err)
+	var warnings bytes.Buffer
+	jiraClient.(*client).warnings = &warnings
+
+	_, err = jiraClient.FetchTasksByFixVersion(context.Background(), "FN", "2024.5")
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests, "an empty page ends the search even when Jira reported more issues")
+	assert.Equal(t, "Warning: Jira reported 5 issues but 1 were received\n", warnings.String(), "warnings go to the warnings writer, not stdout")
+}
+
+func TestClient_FetchTasksReportsOtherSearchErrors(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{BaseURL: server.URL, Email: "test@example.com", Token: "test-token"})
+	require.NoError(t, err)
+
+	_, err = client.FetchTasks(context.Background(), "TEST", "Sprint 1")
+	assert.ErrorContains(t, err, "unexpected status code: 401")
+	assert.Equal(t, 1, requests)
+}
+
+func TestClient_SetIssueProperty(t *testing.T) {
+	var method, path, body string
+	status := http.StatusCreated
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{BaseURL: server.URL, Email: "test@example.com", Token: "test-token"})
+	require.NoError(t, err)
+
+	value := domain.Classification{WorkType: domain.WorkTypeDevelopment, Source: domain.ClassificationSourceClassifier, Rationale: "picked at random"}
+	require.NoError(t, client.SetIssueProperty(context.Background(), "TEST-1", domain.ClassificationPropertyKey, value))
+	assert.Equal(t, "PUT", method)
+	assert.Equal(t, "/rest/api/3/issue/TEST-1/properties/assetcap.classification", path)
+	assert.JSONEq(t, `{"workType": "cap-development", "source": "classifier", "rationale": "picked at random", "classifiedAt": "0001-01-01T00:00:00Z"}`, body)
+
+	status = http.StatusForbidden
+	err = client.SetIssueProperty(context.Background(), "TEST-1", domain.ClassificationPropertyKey, value)
+	assert.ErrorContains(t, err, "failed to set issue property assetcap.classification: status 403")
+}
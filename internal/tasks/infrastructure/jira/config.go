@@ -6,6 +6,8 @@ import (
 	"net/url"
 	"os"
 	"strings"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/jirafield"
 )
 
 const (
@@ -14,11 +16,54 @@ const (
 	envJiraToken   = "JIRA_TOKEN"
 )
 
+// HierarchyLevel maps a hierarchy level to the field that holds the parent key
+// on the issues one level below it
+type HierarchyLevel struct {
+	Level string
+	Field string
+}
+
+// DefaultHierarchy resolves only the epic through the standard parent field
+var DefaultHierarchy = []HierarchyLevel{{Level: "epic", Field: "parent"}}
+
+// DefaultSprintField is the custom field Jira Cloud stores the sprints of an issue in
+const DefaultSprintField = jirafield.DefaultSprintField
+
+// DefaultFields are the issue fields the task model consumes: the standard fields, the
+// work type and asset name custom fields, the story points and the time tracking estimates
+var DefaultFields = []string{
+	"summary", "description", "comment", "status", "project", "issuetype", "labels",
+	"assignee", "parent", "fixVersions", "created", "updated", "resolutiondate",
+	"customfield_10014", "customfield_10015", "customfield_13192",
+	"timeoriginalestimate", "timeestimate",
+}
+
+// DefaultPageSize is the number of issues requested per page of a search
+const DefaultPageSize = 100
+
+// FieldSelection controls which issue fields searches request
+type FieldSelection struct {
+	// SprintField holds the sprints of an issue, as detected for the instance; empty requests
+	// DefaultSprintField and finds the sprints in whichever custom field holds them
+	SprintField string
+	// Extra lists fields requested on top of DefaultFields, e.g. for extensions
+	Extra []string
+	// All requests every field, as Jira does by default
+	All bool
+}
+
 // Config holds the configuration for the JIRA client
 type Config struct {
-	BaseURL string
-	Email   string
-	Token   string
+	BaseURL   string
+	Email     string
+	Token     string
+	Hierarchy []HierarchyLevel
+	Fields    FieldSelection
+	// PageSize is the number of issues requested per page of a search; zero uses
+	// DefaultPageSize. Jira may return fewer, so pages advance by the issues received.
+	PageSize int
+	// HTTPClient sends the requests; nil uses a client with DefaultTimeout
+	HTTPClient HTTPClient
 }
 
 // ConfigFactory is a function type for creating new Jira configurations
@@ -97,6 +142,54 @@ func (c *Config) GetToken() string {
 	return c.Token
 }
 
+// GetHierarchy returns the configured hierarchy levels, from the closest parent upwards
+func (c *Config) GetHierarchy() []HierarchyLevel {
+	if len(c.Hierarchy) == 0 {
+		return DefaultHierarchy
+	}
+	return c.Hierarchy
+}
+
+// SearchPageSize returns the number of issues requested per page of a search
+func (c *Config) SearchPageSize() int {
+	if c.PageSize <= 0 {
+		return DefaultPageSize
+	}
+	return c.PageSize
+}
+
+// SearchFields returns the fields searches request: the default fields plus the sprint,
+// hierarchy and extra fields, or every field when the selection asks for all of them
+func (c *Config) SearchFields() []string {
+	if c.Fields.All {
+		return []string{"*all"}
+	}
+	sprintField := c.Fields.SprintField
+	if sprintField == "" {
+		sprintField = DefaultSprintField
+	}
+
+	fields := make([]string, 0, len(DefaultFields)+1+len(c.Hierarchy)+len(c.Fields.Extra))
+	seen := make(map[string]bool)
+	add := func(field string) {
+		if field != "" && !seen[field] {
+			seen[field] = true
+			fields = append(fields, field)
+		}
+	}
+	for _, field := range DefaultFields {
+		add(field)
+	}
+	add(sprintField)
+	for _, level := range c.GetHierarchy() {
+		add(level.Field)
+	}
+	for _, field := range c.Fields.Extra {
+		add(strings.TrimSpace(field))
+	}
+	return fields
+}
+
 // GetAuthHeader returns the base64 encoded authentication header for Jira API
 func (c *Config) GetAuthHeader() string {
 	authString := fmt.Sprintf("%s:%s", c.Email, c.Token)
@@ -233,3 +233,39 @@ func TestConfig_ValidateCredentials(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_SearchFields(t *testing.T) {
+	t.Run("requests the default, sprint and hierarchy fields", func(t *testing.T) {
+		config := &Config{}
+
+		fields := config.SearchFields()
+
+		assert.Equal(t, append(append([]string{}, DefaultFields...), DefaultSprintField), fields)
+	})
+
+	t.Run("adds the configured sprint, hierarchy and extra fields once", func(t *testing.T) {
+		config := &Config{
+			Hierarchy: []HierarchyLevel{{Level: "epic", Field: "parent"}, {Level: "initiative", Field: "customfield_10200"}},
+			Fields: FieldSelection{
+				SprintField: "customfield_10007",
+				Extra:       []string{"customfield_10300", " summary ", "customfield_10200"},
+			},
+		}
+
+		fields := config.SearchFields()
+
+		assert.Equal(t, DefaultFields, fields[:len(DefaultFields)])
+		assert.Equal(t, []string{"customfield_10007", "customfield_10200", "customfield_10300"}, fields[len(DefaultFields):])
+	})
+
+	t.Run("requests every field when asked to", func(t *testing.T) {
+		config := &Config{Fields: FieldSelection{All: true, Extra: []string{"customfield_10300"}}}
+
+		assert.Equal(t, []string{"*all"}, config.SearchFields())
+	})
+}
+
+func TestConfig_SearchPageSize(t *testing.T) {
+	assert.Equal(t, DefaultPageSize, (&Config{}).SearchPageSize())
+	assert.Equal(t, 25, (&Config{PageSize: 25}).SearchPageSize())
+}
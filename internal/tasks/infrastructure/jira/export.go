@@ -0,0 +1,221 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/jiraexport"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain/ports"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/infrastructure/jira/api"
+)
+
+// ErrOffline is returned by the operations an export cannot stand in for, such as writing
+// labels back to Jira
+var ErrOffline = fmt.Errorf("not available offline: Jira is read from an export")
+
+// ExportRepository is a read-only task repository over a Jira issue export, standing in for
+// the Jira API where the tool cannot reach it
+type ExportRepository struct {
+	tasks []*domain.Task
+}
+
+// NewExportRepository creates a task repository reading the issues of a JSON or CSV export
+func NewExportRepository(path string) (*ExportRepository, error) {
+	format, data, err := jiraexport.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var issues []api.Issue
+	if format == jiraexport.FormatCSV {
+		issues, err = csvExportIssues(data)
+	} else {
+		issues, err = jsonExportIssues(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read export %s: %w", path, err)
+	}
+
+	tasks := make([]*domain.Task, 0, len(issues))
+	for _, issue := range issues {
+		task, err := issueToTask(issue)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read export %s: issue %s: %w", path, issue.Key, err)
+		}
+		tasks = append(tasks, task)
+	}
+	return &ExportRepository{tasks: tasks}, nil
+}
+
+// jsonExportIssues decodes the issues of a JSON export
+func jsonExportIssues(data []byte) ([]api.Issue, error) {
+	raw, err := jiraexport.Issues(data)
+	if err != nil {
+		return nil, err
+	}
+	issues := make([]api.Issue, 0, len(raw))
+	for _, element := range raw {
+		var issue api.Issue
+		if err := json.Unmarshal(element, &issue); err != nil {
+			return nil, fmt.Errorf("invalid issue in export: %w", err)
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// csvExportIssues converts the records of a CSV export to issues
+func csvExportIssues(data []byte) ([]api.Issue, error) {
+	records, err := jiraexport.ReadCSV(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	issues := make([]api.Issue, 0, len(records))
+	for _, record := range records {
+		issue := api.Issue{
+			Key: record.Key,
+			Fields: api.Fields{
+				Summary:           record.Summary,
+				Status:            api.Status{Name: record.Status, StatusCategory: api.StatusCategory{Key: record.StatusCategoryKey()}},
+				Project:           api.Project{Key: record.Project},
+				Created:           record.Created,
+				Updated:           record.Updated,
+				Resolved:          record.Resolved,
+				Assignee:          api.Assignee{DisplayName: record.Assignee},
+				IssueType:         api.IssueType{Name: record.IssueType},
+				Labels:            record.Labels,
+				OriginalEstimate:  record.OriginalEstimate,
+				RemainingEstimate: record.RemainingEstimate,
+			},
+		}
+		if record.Description != "" {
+			issue.Fields.Description = api.Description{Type: "text", Text: record.Description}
+		}
+		if record.Parent != "" {
+			issue.Fields.Parent = &api.Issue{Key: record.Parent}
+		}
+		for _, sprint := range record.Sprints {
+			issue.Fields.Sprint = append(issue.Fields.Sprint, api.Sprint{Name: sprint})
+		}
+		for _, version := range record.FixVersions {
+			issue.Fields.FixVersions = append(issue.Fields.FixVersions, api.Version{Name: version})
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// inSprint reports whether a task's sprints, joined by commas, include the sprint
+func inSprint(task *domain.Task, sprint string) bool {
+	for _, name := range strings.Split(task.Sprint, ",") {
+		if strings.TrimSpace(name) == sprint {
+			return true
+		}
+	}
+	return false
+}
+
+// Save is not available on an export
+func (r *ExportRepository) Save(_ context.Context, _ *domain.Task) error {
+	return ErrOffline
+}
+
+// FindByKey finds an exported task by its key
+func (r *ExportRepository) FindByKey(_ context.Context, key string) (*domain.Task, error) {
+	for _, task := range r.tasks {
+		if task.Key == key {
+			return task, nil
+		}
+	}
+	return nil, fmt.Errorf("task %s not found in the export", key)
+}
+
+// FindByProjectAndSprint finds the exported tasks of a project in a sprint
+func (r *ExportRepository) FindByProjectAndSprint(_ context.Context, project, sprint string) ([]*domain.Task, error) {
+	var tasks []*domain.Task
+	for _, task := range r.tasks {
+		if task.Project == project && inSprint(task, sprint) {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+// FindByProjectAndFixVersion finds the exported tasks of a project released in a fix version
+func (r *ExportRepository) FindByProjectAndFixVersion(_ context.Context, project, fixVersion string) ([]*domain.Task, error) {
+	var tasks []*domain.Task
+	for _, task := range r.tasks {
+		if task.Project != project {
+			continue
+		}
+		for _, version := range task.FixVersions {
+			if version == fixVersion {
+				tasks = append(tasks, task)
+				break
+			}
+		}
+	}
+	return tasks, nil
+}
+
+// FindByProject finds the exported tasks of a project
+func (r *ExportRepository) FindByProject(_ context.Context, project string) ([]*domain.Task, error) {
+	var tasks []*domain.Task
+	for _, task := range r.tasks {
+		if task.Project == project {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+// FindBySprint finds the exported tasks of a sprint
+func (r *ExportRepository) FindBySprint(_ context.Context, sprint string) ([]*domain.Task, error) {
+	var tasks []*domain.Task
+	for _, task := range r.tasks {
+		if inSprint(task, sprint) {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+// FindByPlatform returns the exported tasks, which all come from Jira
+func (r *ExportRepository) FindByPlatform(_ context.Context, platform string) ([]*domain.Task, error) {
+	var tasks []*domain.Task
+	for _, task := range r.tasks {
+		if task.Platform == platform {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+// FindAll returns every exported task
+func (r *ExportRepository) FindAll(_ context.Context) ([]*domain.Task, error) {
+	return r.tasks, nil
+}
+
+// Delete is not available on an export
+func (r *ExportRepository) Delete(_ context.Context, _ string) error {
+	return ErrOffline
+}
+
+// DeleteByProjectAndSprint is not available on an export
+func (r *ExportRepository) DeleteByProjectAndSprint(_ context.Context, _, _ string) error {
+	return ErrOffline
+}
+
+// UpdateLabels cannot write labels back to Jira from an export
+func (r *ExportRepository) UpdateLabels(_ context.Context, _ string, _ []string) error {
+	return ErrOffline
+}
+
+// Ensure ExportRepository implements ports.TaskRepository
+var _ ports.TaskRepository = (*ExportRepository)(nil)
+
+// Ensure ExportRepository implements ports.TaskReleaseFinder
+var _ ports.TaskReleaseFinder = (*ExportRepository)(nil)
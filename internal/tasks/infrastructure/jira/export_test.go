@@ -0,0 +1,99 @@
+package jira
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+func writeExport(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestExportRepository_JSON(t *testing.T) {
+	repo, err := NewExportRepository(writeExport(t, "FN.json", `{
+		"issues": [
+			{
+				"key": "FN-1",
+				"fields": {
+					"summary": "Build checkout",
+					"project": {"key": "FN"},
+					"status": {"name": "Done", "statusCategory": {"key": "done"}},
+					"issuetype": {"name": "Story"},
+					"labels": ["cap-development"],
+					"parent": {"key": "FN-100"},
+					"customfield_10020": [{"id": 7, "name": "Penguins", "state": "closed"}],
+					"created": "2024-03-18T09:00:00.000+0000",
+					"updated": "2024-03-19T17:30:00.000+0000"
+				}
+			},
+			{
+				"key": "FN-2",
+				"fields": {"summary": "Release notes", "project": {"key": "FN"}, "fixVersions": [{"name": "1.0"}]}
+			}
+		]
+	}`))
+	require.NoError(t, err)
+
+	tasks, err := repo.FindByProjectAndSprint(context.Background(), "FN", "Penguins")
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "FN-1", tasks[0].Key)
+	assert.Equal(t, domain.TaskStatusDone, tasks[0].Status)
+	assert.Equal(t, domain.WorkTypeDevelopment, tasks[0].WorkType)
+	assert.Equal(t, "FN-100", tasks[0].Epic)
+
+	tasks, err = repo.FindByProjectAndFixVersion(context.Background(), "FN", "1.0")
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "FN-2", tasks[0].Key)
+
+	task, err := repo.FindByKey(context.Background(), "FN-2")
+	require.NoError(t, err)
+	assert.Equal(t, "Release notes", task.Summary)
+	_, err = repo.FindByKey(context.Background(), "FN-3")
+	assert.ErrorContains(t, err, "not found in the export")
+}
+
+func TestExportRepository_CSV(t *testing.T) {
+	repo, err := NewExportRepository(writeExport(t, "FN.csv", "Summary,Issue key,Issue Type,Status,Status Category,Project key,Description,Created,Sprint,Sprint,Labels\n"+
+		"Build checkout,FN-1,Story,Done,Done,FN,Card payments,18/Mar/24 9:00 AM,Owls,Penguins,cap-maintenance\n"+
+		"Fix login,OPS-2,Bug,To Do,To Do,OPS,,20/Mar/24 9:00 AM,Penguins,,\n"))
+	require.NoError(t, err)
+
+	tasks, err := repo.FindByProjectAndSprint(context.Background(), "FN", "Penguins")
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "Owls, Penguins", tasks[0].Sprint)
+	assert.Equal(t, "Card payments", tasks[0].Description)
+	assert.Equal(t, domain.WorkTypeMaintenance, tasks[0].WorkType)
+	assert.Equal(t, domain.TaskStatusDone, tasks[0].Status)
+
+	tasks, err = repo.FindBySprint(context.Background(), "Penguins")
+	require.NoError(t, err)
+	assert.Len(t, tasks, 2)
+
+	tasks, err = repo.FindAll(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, tasks, 2)
+}
+
+func TestExportRepository_ReadOnly(t *testing.T) {
+	repo, err := NewExportRepository(writeExport(t, "FN.json", `[]`))
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, repo.UpdateLabels(context.Background(), "FN-1", []string{"cap-development"}), ErrOffline)
+	assert.ErrorIs(t, repo.Delete(context.Background(), "FN-1"), ErrOffline)
+
+	_, err = NewExportRepository(writeExport(t, "FN.csv", "Summary\nBuild\n"))
+	assert.ErrorContains(t, err, "no Issue key column")
+}
@@ -15,10 +15,33 @@ type TaskRepository struct {
 
 // NewRepository creates a new Jira repository instance
 func NewRepository() (*TaskRepository, error) {
+	return NewRepositoryWithHierarchy(nil)
+}
+
+// NewRepositoryWithHierarchy creates a new Jira repository instance that resolves
+// the given parent hierarchy for each task. An empty hierarchy resolves the epic only.
+func NewRepositoryWithHierarchy(hierarchy []HierarchyLevel) (*TaskRepository, error) {
+	return NewRepositoryWithClient(hierarchy, nil)
+}
+
+// NewRepositoryWithClient creates a new Jira repository instance that resolves the given
+// parent hierarchy and sends its requests with httpClient, or a default client when nil
+func NewRepositoryWithClient(hierarchy []HierarchyLevel, httpClient HTTPClient) (*TaskRepository, error) {
+	return NewRepositoryWithFields(hierarchy, httpClient, FieldSelection{}, 0)
+}
+
+// NewRepositoryWithFields creates a new Jira repository instance like NewRepositoryWithClient
+// whose searches request the selected fields, pageSize issues at a time; zero uses
+// DefaultPageSize
+func NewRepositoryWithFields(hierarchy []HierarchyLevel, httpClient HTTPClient, fields FieldSelection, pageSize int) (*TaskRepository, error) {
 	config, err := NewConfig()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Jira configuration: %w", err)
 	}
+	config.Hierarchy = hierarchy
+	config.HTTPClient = httpClient
+	config.Fields = fields
+	config.PageSize = pageSize
 
 	client, err := NewClient(config)
 	if err != nil {
@@ -47,6 +70,11 @@ func (r *TaskRepository) FindByProjectAndSprint(ctx context.Context, project, sp
 	return r.client.FetchTasks(ctx, project, sprint)
 }
 
+// FindByProjectAndFixVersion finds all tasks for a given project and fix version
+func (r *TaskRepository) FindByProjectAndFixVersion(ctx context.Context, project, fixVersion string) ([]*domain.Task, error) {
+	return r.client.FetchTasksByFixVersion(ctx, project, fixVersion)
+}
+
 // FindByProject finds all tasks for a given project
 func (r *TaskRepository) FindByProject(_ context.Context, _ string) ([]*domain.Task, error) {
 	// TODO: Implement task retrieval by project in Jira
@@ -88,5 +116,16 @@ func (r *TaskRepository) UpdateLabels(ctx context.Context, taskKey string, label
 	return r.client.UpdateLabels(ctx, taskKey, labels)
 }
 
+// SetIssueProperty stores a JSON value as an entity property of a Jira issue
+func (r *TaskRepository) SetIssueProperty(ctx context.Context, issueKey, key string, value any) error {
+	return r.client.SetIssueProperty(ctx, issueKey, key, value)
+}
+
 // Ensure Repository implements ports.Repository
 var _ ports.TaskRepository = (*TaskRepository)(nil)
+
+// Ensure Repository implements ports.TaskReleaseFinder
+var _ ports.TaskReleaseFinder = (*TaskRepository)(nil)
+
+// Ensure Repository implements ports.IssuePropertyWriter
+var _ ports.IssuePropertyWriter = (*TaskRepository)(nil)
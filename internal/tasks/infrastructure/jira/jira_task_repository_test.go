@@ -28,8 +28,10 @@ func (m *MockHTTPClient) Do(req *http.Request) (*http.Response, error) {
 
 // MockClient is a mock implementation of Client
 type MockClient struct {
-	FetchTasksFunc   func(ctx context.Context, project, sprint string) ([]*domain.Task, error)
-	UpdateLabelsFunc func(ctx context.Context, issueKey string, labels []string) error
+	FetchTasksFunc             func(ctx context.Context, project, sprint string) ([]*domain.Task, error)
+	FetchTasksByFixVersionFunc func(ctx context.Context, project, fixVersion string) ([]*domain.Task, error)
+	UpdateLabelsFunc           func(ctx context.Context, issueKey string, labels []string) error
+	SetIssuePropertyFunc       func(ctx context.Context, issueKey, key string, value any) error
 }
 
 func (m *MockClient) FetchTasks(ctx context.Context, project, sprint string) ([]*domain.Task, error) {
@@ -39,6 +41,13 @@ func (m *MockClient) FetchTasks(ctx context.Context, project, sprint string) ([]
 	return nil, nil
 }
 
+func (m *MockClient) FetchTasksByFixVersion(ctx context.Context, project, fixVersion string) ([]*domain.Task, error) {
+	if m.FetchTasksByFixVersionFunc != nil {
+		return m.FetchTasksByFixVersionFunc(ctx, project, fixVersion)
+	}
+	return nil, nil
+}
+
 func (m *MockClient) UpdateLabels(ctx context.Context, issueKey string, labels []string) error {
 	if m.UpdateLabelsFunc != nil {
 		return m.UpdateLabelsFunc(ctx, issueKey, labels)
@@ -46,6 +55,13 @@ func (m *MockClient) UpdateLabels(ctx context.Context, issueKey string, labels [
 	return nil
 }
 
+func (m *MockClient) SetIssueProperty(ctx context.Context, issueKey, key string, value any) error {
+	if m.SetIssuePropertyFunc != nil {
+		return m.SetIssuePropertyFunc(ctx, issueKey, key, value)
+	}
+	return nil
+}
+
 type mockClient struct {
 	fetchTasksFunc   func(ctx context.Context, project, sprint string) ([]*domain.Task, error)
 	updateLabelsFunc func(ctx context.Context, issueKey string, labels []string) error
@@ -58,6 +74,10 @@ func (m *mockClient) FetchTasks(ctx context.Context, project, sprint string) ([]
 	return nil, nil
 }
 
+func (m *mockClient) FetchTasksByFixVersion(_ context.Context, _, _ string) ([]*domain.Task, error) {
+	return nil, nil
+}
+
 func (m *mockClient) UpdateLabels(ctx context.Context, issueKey string, labels []string) error {
 	if m.updateLabelsFunc != nil {
 		return m.updateLabelsFunc(ctx, issueKey, labels)
@@ -65,6 +85,10 @@ func (m *mockClient) UpdateLabels(ctx context.Context, issueKey string, labels [
 	return nil
 }
 
+func (m *mockClient) SetIssueProperty(_ context.Context, _, _ string, _ any) error {
+	return nil
+}
+
 func TestNewRepository(t *testing.T) {
 	// Save the original functions and restore them after the test
 	originalNewClient := NewClient
@@ -130,6 +154,32 @@ func TestNewRepository(t *testing.T) {
 	}
 }
 
+func TestNewRepositoryWithClient(t *testing.T) {
+	originalNewClient := NewClient
+	originalNewConfig := NewConfig
+	defer func() {
+		NewClient = originalNewClient
+		NewConfig = originalNewConfig
+	}()
+
+	NewConfig = func() (*Config, error) {
+		return &Config{BaseURL: "https://test.atlassian.net", Email: "test@example.com", Token: "test-token"}, nil
+	}
+	var got *Config
+	NewClient = func(config *Config) (Client, error) {
+		got = config
+		return originalNewClient(config)
+	}
+	httpClient := &http.Client{}
+
+	repo, err := NewRepositoryWithClient([]HierarchyLevel{{Level: "epic", Field: "parent"}}, httpClient)
+	require.NoError(t, err)
+	require.NotNil(t, repo)
+	assert.Same(t, httpClient, got.HTTPClient)
+	assert.Same(t, httpClient, repo.client.(*client).httpClient)
+	assert.Equal(t, []HierarchyLevel{{Level: "epic", Field: "parent"}}, got.Hierarchy)
+}
+
 func TestRepository_FindByProjectAndSprint(t *testing.T) {
 	ctx := context.Background()
 
@@ -0,0 +1,35 @@
+package jira
+
+import (
+	"strings"
+	"time"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/infrastructure/jira/api"
+)
+
+// labelHistory returns the cap-* label changes recorded in an issue's changelog, oldest
+// first. Histories with an unreadable date are skipped.
+func labelHistory(histories []api.ChangelogHistory) []domain.LabelChange {
+	var changes []domain.LabelChange
+	for _, history := range histories {
+		at, err := parseTime(history.Created)
+		if err != nil {
+			continue
+		}
+		changes = append(changes, labelItemChanges(history.Items, history.Author, at)...)
+	}
+	return domain.MergeLabelHistory(nil, changes)
+}
+
+// labelItemChanges returns the cap-* label changes among the items of a single change
+func labelItemChanges(items []api.ChangelogItem, author api.User, at time.Time) []domain.LabelChange {
+	var changes []domain.LabelChange
+	for _, item := range items {
+		if !strings.EqualFold(item.Field, "labels") {
+			continue
+		}
+		changes = append(changes, domain.LabelChanges(item.FromString, item.ToString, author.DisplayName, at)...)
+	}
+	return changes
+}
@@ -0,0 +1,86 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/infrastructure/jira/api"
+)
+
+func TestIssueToTask_LabelHistory(t *testing.T) {
+	var issue api.Issue
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"key": "FN-123",
+		"fields": {
+			"summary": "Fix login",
+			"project": {"key": "FN"},
+			"labels": ["cap-maintenance"],
+			"customfield_10100": [{"name": "Sprint 1"}]
+		},
+		"changelog": {"histories": [
+			{"author": {"displayName": "John Roe"}, "created": "2024-03-08T09:00:00.000+0000", "items": [
+				{"field": "labels", "fromString": "cap-development", "toString": "cap-maintenance"}
+			]},
+			{"author": {"displayName": "Jane Doe"}, "created": "2024-03-01T09:00:00.000+0000", "items": [
+				{"field": "status", "fromString": "To Do", "toString": "In Progress"},
+				{"field": "labels", "fromString": "backend", "toString": "backend cap-development"}
+			]},
+			{"author": {"displayName": "Jane Doe"}, "created": "not a date", "items": [
+				{"field": "labels", "fromString": "", "toString": "cap-discovery"}
+			]}
+		]}
+	}`), &issue))
+
+	task, err := issueToTask(issue)
+	require.NoError(t, err)
+
+	first := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	second := time.Date(2024, 3, 8, 9, 0, 0, 0, time.UTC)
+	require.Len(t, task.LabelHistory, 3)
+	assert.Equal(t, "cap-development", task.LabelHistory[0].Label)
+	assert.Equal(t, domain.LabelAdded, task.LabelHistory[0].Action)
+	assert.Equal(t, "Jane Doe", task.LabelHistory[0].Author)
+	assert.True(t, first.Equal(task.LabelHistory[0].At))
+	assert.Equal(t, "cap-development", task.LabelHistory[1].Label)
+	assert.Equal(t, domain.LabelRemoved, task.LabelHistory[1].Action)
+	assert.Equal(t, "cap-maintenance", task.LabelHistory[2].Label)
+	assert.Equal(t, domain.LabelAdded, task.LabelHistory[2].Action)
+	assert.Equal(t, "John Roe", task.LabelHistory[2].Author)
+	assert.True(t, second.Equal(task.LabelHistory[2].At))
+}
+
+func TestWebhookHandler_RecordsLabelChanges(t *testing.T) {
+	var received []domain.IssueEvent
+	handler := NewWebhookHandler(nil, "", func(_ context.Context, event domain.IssueEvent) error {
+		received = append(received, event)
+		return nil
+	})
+
+	rec := postWebhook(handler, `{
+		"webhookEvent": "jira:issue_updated",
+		"timestamp": 1709888400000,
+		"user": {"displayName": "John Roe"},
+		"changelog": {"items": [{"field": "labels", "fromString": "cap-development", "toString": "cap-maintenance"}]},
+		"issue": {"key": "FN-1", "fields": {
+			"summary": "Build checkout",
+			"project": {"key": "FN"},
+			"labels": ["cap-maintenance"],
+			"customfield_10100": [{"name": "Sprint 1"}]
+		}}
+	}`, nil)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	require.Len(t, received, 1)
+	at := time.UnixMilli(1709888400000)
+	assert.Equal(t, []domain.LabelChange{
+		{Label: "cap-development", Action: domain.LabelRemoved, Author: "John Roe", At: at},
+		{Label: "cap-maintenance", Action: domain.LabelAdded, Author: "John Roe", At: at},
+	}, received[0].Task.LabelHistory)
+}
@@ -0,0 +1,141 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/redact"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/infrastructure/jira/api"
+)
+
+// sprintPeriod is the start and end of a sprint
+type sprintPeriod struct {
+	start time.Time
+	end   time.Time
+}
+
+// sprintPeriod returns the dates of the named sprint of an issue, taken from the issue's sprint
+// field or, when the field lacks them, from the Agile board API
+func (c *client) sprintPeriod(ctx context.Context, issue api.Issue, sprint string) (sprintPeriod, error) {
+	for _, s := range issue.Fields.Sprint {
+		if s.Name != sprint {
+			continue
+		}
+		if period, err := parseSprintPeriod(s); err == nil {
+			return period, nil
+		}
+		return c.boardSprintPeriod(ctx, s)
+	}
+	return sprintPeriod{}, fmt.Errorf("issue is not in sprint %s", sprint)
+}
+
+// parseSprintPeriod parses the dates of a sprint, failing when either is missing
+func parseSprintPeriod(s api.Sprint) (sprintPeriod, error) {
+	if s.StartDate == "" || s.EndDate == "" {
+		return sprintPeriod{}, fmt.Errorf("sprint %s has no start or end date", s.Name)
+	}
+	start, err := parseTime(s.StartDate)
+	if err != nil {
+		return sprintPeriod{}, err
+	}
+	end, err := parseTime(s.EndDate)
+	if err != nil {
+		return sprintPeriod{}, err
+	}
+	return sprintPeriod{start: start, end: end}, nil
+}
+
+// boardSprintPeriod looks the sprint up on the Agile board API, by ID when known and otherwise
+// by name among the sprints of its board. Lookups are cached for the life of the client.
+func (c *client) boardSprintPeriod(ctx context.Context, s api.Sprint) (sprintPeriod, error) {
+	if c.config == nil || c.httpClient == nil {
+		return sprintPeriod{}, fmt.Errorf("sprint %s has no start or end date", s.Name)
+	}
+	key := fmt.Sprintf("board %d sprint %s", s.BoardID, s.Name)
+	if s.ID > 0 {
+		key = fmt.Sprintf("sprint %d", s.ID)
+	}
+
+	c.sprintMu.Lock()
+	defer c.sprintMu.Unlock()
+	if period, ok := c.sprintPeriods[key]; ok {
+		return period, nil
+	}
+
+	var (
+		found api.Sprint
+		err   error
+	)
+	switch {
+	case s.ID > 0:
+		err = c.getAgile(ctx, fmt.Sprintf("/rest/agile/1.0/sprint/%d", s.ID), &found)
+	case s.BoardID > 0:
+		found, err = c.findBoardSprint(ctx, s.BoardID, s.Name)
+	default:
+		err = fmt.Errorf("sprint %s has no start or end date and no ID or board to look it up", s.Name)
+	}
+	if err != nil {
+		return sprintPeriod{}, fmt.Errorf("failed to get dates of sprint %s: %w", s.Name, err)
+	}
+	period, err := parseSprintPeriod(found)
+	if err != nil {
+		return sprintPeriod{}, err
+	}
+
+	if c.sprintPeriods == nil {
+		c.sprintPeriods = make(map[string]sprintPeriod)
+	}
+	c.sprintPeriods[key] = period
+	return period, nil
+}
+
+// findBoardSprint pages through the sprints of a board for the one with the given name
+func (c *client) findBoardSprint(ctx context.Context, boardID int, name string) (api.Sprint, error) {
+	for startAt := 0; ; {
+		var page struct {
+			IsLast bool         `json:"isLast"`
+			Values []api.Sprint `json:"values"`
+		}
+		if err := c.getAgile(ctx, fmt.Sprintf("/rest/agile/1.0/board/%d/sprint?startAt=%d", boardID, startAt), &page); err != nil {
+			return api.Sprint{}, err
+		}
+		for _, s := range page.Values {
+			if s.Name == name {
+				return s, nil
+			}
+		}
+		if page.IsLast || len(page.Values) == 0 {
+			return api.Sprint{}, fmt.Errorf("sprint %s not found on board %d", name, boardID)
+		}
+		startAt += len(page.Values)
+	}
+}
+
+// getAgile decodes the response of a GET on the Agile API into out
+func (c *client) getAgile(ctx context.Context, path string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.config.GetBaseURL()+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", c.config.GetAuthHeader())
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return redact.Error(fmt.Errorf("failed to execute request: %w", err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, redact.String(string(body)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/infrastructure/jira/api"
+)
+
+// newBoardServer serves the Agile sprint endpoints and counts the requests made
+func newBoardServer(t *testing.T, requests *int) *client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		switch r.URL.Path + "?" + r.URL.RawQuery {
+		case "/rest/agile/1.0/sprint/7?":
+			fmt.Fprint(w, `{"id": 7, "name": "Sprint 7", "startDate": "2025-01-01T09:00:00.000+01:00", "endDate": "2025-01-14T18:00:00.000+01:00"}`)
+		case "/rest/agile/1.0/board/3/sprint?startAt=0":
+			fmt.Fprint(w, `{"isLast": false, "values": [{"id": 1, "name": "Sprint 1", "startDate": "2024-12-01T09:00:00.000Z", "endDate": "2024-12-14T18:00:00.000Z"}]}`)
+		case "/rest/agile/1.0/board/3/sprint?startAt=1":
+			fmt.Fprint(w, `{"isLast": true, "values": [{"id": 8, "name": "Sprint 8", "startDate": "2025-01-15T09:00:00.000Z", "endDate": "2025-01-28T18:00:00.000Z"}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return &client{
+		httpClient: server.Client(),
+		config:     &Config{BaseURL: server.URL, Email: "test@example.com", Token: "test-token"},
+	}
+}
+
+// issueInSprint returns an issue whose sprint field holds only the given sprint
+func issueInSprint(key string, sprint api.Sprint) api.Issue {
+	return api.Issue{Key: key, Fields: api.Fields{
+		Summary: "Task " + key,
+		Project: api.Project{Key: "FN"},
+		Sprint:  []api.Sprint{sprint},
+		Created: "2025-01-02T00:00:00.000Z",
+		Updated: "2025-01-02T00:00:00.000Z",
+	}}
+}
+
+func TestConvertToDomainTasks_SprintDatesFromBoard(t *testing.T) {
+	var requests int
+	c := newBoardServer(t, &requests)
+
+	issues := []api.Issue{
+		issueInSprint("FN-1", api.Sprint{ID: 7, Name: "Sprint 7", BoardID: 3}),
+		issueInSprint("FN-2", api.Sprint{ID: 7, Name: "Sprint 7", BoardID: 3}),
+	}
+	tasks, err := c.convertToDomainTasks(context.Background(), api.SearchResult{Issues: issues}, "Sprint 7")
+
+	require.NoError(t, err)
+	assert.Len(t, tasks, 2, "issues without inline dates are no longer skipped")
+	assert.Equal(t, 1, requests, "the sprint dates are looked up once")
+}
+
+func TestSprintPeriod(t *testing.T) {
+	t.Run("inline dates need no lookup", func(t *testing.T) {
+		var requests int
+		c := newBoardServer(t, &requests)
+		issue := issueInSprint("FN-1", api.Sprint{ID: 7, Name: "Sprint 7", StartDate: "2025-02-01T00:00:00Z", EndDate: "2025-02-14T00:00:00Z"})
+
+		period, err := c.sprintPeriod(context.Background(), issue, "Sprint 7")
+		require.NoError(t, err)
+		assert.Equal(t, 1, period.start.Day())
+		assert.Equal(t, 0, requests)
+	})
+
+	t.Run("by name across the pages of the board", func(t *testing.T) {
+		var requests int
+		c := newBoardServer(t, &requests)
+		issue := issueInSprint("FN-1", api.Sprint{Name: "Sprint 8", BoardID: 3})
+
+		period, err := c.sprintPeriod(context.Background(), issue, "Sprint 8")
+		require.NoError(t, err)
+		assert.Equal(t, 15, period.start.Day())
+		assert.Equal(t, 28, period.end.Day())
+		assert.Equal(t, 2, requests)
+	})
+
+	t.Run("unknown sprint", func(t *testing.T) {
+		var requests int
+		c := newBoardServer(t, &requests)
+		issue := issueInSprint("FN-1", api.Sprint{Name: "Sprint 9", BoardID: 3})
+
+		_, err := c.sprintPeriod(context.Background(), issue, "Sprint 9")
+		assert.ErrorContains(t, err, "sprint Sprint 9 not found on board 3")
+	})
+
+	t.Run("nothing to look the sprint up by", func(t *testing.T) {
+		var requests int
+		c := newBoardServer(t, &requests)
+		issue := issueInSprint("FN-1", api.Sprint{Name: "Sprint 9"})
+
+		_, err := c.sprintPeriod(context.Background(), issue, "Sprint 9")
+		assert.ErrorContains(t, err, "no ID or board")
+		assert.Equal(t, 0, requests)
+	})
+
+	t.Run("not in the sprint", func(t *testing.T) {
+		var requests int
+		c := newBoardServer(t, &requests)
+		_, err := c.sprintPeriod(context.Background(), issueInSprint("FN-1", api.Sprint{ID: 7, Name: "Sprint 7"}), "Sprint 1")
+		assert.ErrorContains(t, err, "issue is not in sprint Sprint 1")
+	})
+}
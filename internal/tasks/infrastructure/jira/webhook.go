@@ -0,0 +1,133 @@
+package jira
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/infrastructure/jira/api"
+)
+
+const (
+	// webhookSignatureHeader carries the HMAC signature of webhooks registered with a secret
+	webhookSignatureHeader = "X-Hub-Signature"
+	maxWebhookBodySize     = 5 << 20
+)
+
+var webhookEventTypes = map[string]domain.IssueEventType{
+	"jira:issue_created": domain.IssueEventCreated,
+	"jira:issue_updated": domain.IssueEventUpdated,
+	"jira:issue_deleted": domain.IssueEventDeleted,
+}
+
+// IssueEventHandler processes an issue event received from Jira
+type IssueEventHandler func(ctx context.Context, event domain.IssueEvent) error
+
+// WebhookHandler receives Jira issue webhooks for the tracked projects
+type WebhookHandler struct {
+	projects map[string]bool
+	secret   string
+	handle   IssueEventHandler
+}
+
+// NewWebhookHandler creates a new webhook handler. An empty project list tracks
+// every project, and an empty secret disables signature verification.
+func NewWebhookHandler(projects []string, secret string, handle IssueEventHandler) *WebhookHandler {
+	tracked := make(map[string]bool, len(projects))
+	for _, project := range projects {
+		tracked[project] = true
+	}
+	return &WebhookHandler{
+		projects: tracked,
+		secret:   secret,
+		handle:   handle,
+	}
+}
+
+// ServeHTTP implements http.Handler
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBodySize))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	if h.secret != "" && !h.validSignature(body, r.Header.Get(webhookSignatureHeader)) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	var payload api.WebhookEvent
+	if err := json.Unmarshal(body, &payload); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	eventType, ok := webhookEventTypes[payload.WebhookEvent]
+	if !ok || payload.Issue.Key == "" {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	project := issueProject(payload.Issue)
+	if len(h.projects) > 0 && !h.projects[project] {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	task := &domain.Task{Key: payload.Issue.Key, Project: project}
+	if eventType != domain.IssueEventDeleted {
+		task, err = issueToTask(payload.Issue)
+		if err != nil {
+			log.Printf("Ignoring %s event for %s: %v", payload.WebhookEvent, payload.Issue.Key, err)
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		if payload.Timestamp > 0 {
+			changes := labelItemChanges(payload.Changelog.Items, payload.User, time.UnixMilli(payload.Timestamp))
+			task.LabelHistory = domain.MergeLabelHistory(task.LabelHistory, changes)
+		}
+	}
+
+	if err := h.handle(r.Context(), domain.IssueEvent{Type: eventType, Task: task}); err != nil {
+		log.Printf("Failed to apply %s event for %s: %v", payload.WebhookEvent, payload.Issue.Key, err)
+		http.Error(w, "failed to apply event", http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("Applied %s event for %s", payload.WebhookEvent, payload.Issue.Key)
+	w.WriteHeader(http.StatusOK)
+}
+
+// validSignature checks the sha256 HMAC signature Jira sends for webhooks with a secret
+func (h *WebhookHandler) validSignature(body []byte, signature string) bool {
+	expected, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(h.secret))
+	mac.Write(body)
+	return hmac.Equal([]byte(expected), []byte(hex.EncodeToString(mac.Sum(nil))))
+}
+
+// issueProject returns the project key of an issue, falling back to the key prefix
+func issueProject(issue api.Issue) string {
+	if issue.Fields.Project.Key != "" {
+		return issue.Fields.Project.Key
+	}
+	project, _, _ := strings.Cut(issue.Key, "-")
+	return project
+}
@@ -0,0 +1,141 @@
+package jira
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+const updatedPayload = `{
+	"webhookEvent": "jira:issue_updated",
+	"issue": {
+		"key": "FN-1",
+		"fields": {
+			"summary": "Build checkout",
+			"project": {"key": "FN"},
+			"status": {"name": "In Progress"},
+			"issuetype": {"name": "Story"},
+			"labels": ["cap-development"],
+			"customfield_10100": [{"name": "Sprint 1"}]
+		}
+	}
+}`
+
+func postWebhook(handler http.Handler, body string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/jira", strings.NewReader(body))
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestWebhookHandler_AppliesIssueUpdate(t *testing.T) {
+	var received []domain.IssueEvent
+	handler := NewWebhookHandler([]string{"FN"}, "", func(_ context.Context, event domain.IssueEvent) error {
+		received = append(received, event)
+		return nil
+	})
+
+	rec := postWebhook(handler, updatedPayload, nil)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	require.Len(t, received, 1)
+	assert.Equal(t, domain.IssueEventUpdated, received[0].Type)
+	assert.Equal(t, "FN-1", received[0].Task.Key)
+	assert.Equal(t, "Sprint 1", received[0].Task.Sprint)
+	assert.Equal(t, domain.TaskStatusInProgress, received[0].Task.Status)
+	assert.Equal(t, domain.WorkTypeDevelopment, received[0].Task.WorkType)
+}
+
+func TestWebhookHandler_IgnoredEvents(t *testing.T) {
+	tests := []struct {
+		name     string
+		projects []string
+		body     string
+	}{
+		{"untracked project", []string{"XY"}, updatedPayload},
+		{"unsupported event", nil, `{"webhookEvent": "comment_created", "issue": {"key": "FN-1"}}`},
+		{"issue without sprint", nil, `{"webhookEvent": "jira:issue_updated", "issue": {"key": "FN-1", "fields": {"summary": "No sprint"}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewWebhookHandler(tt.projects, "", func(_ context.Context, _ domain.IssueEvent) error {
+				t.Fatal("handler should not be called")
+				return nil
+			})
+
+			rec := postWebhook(handler, tt.body, nil)
+			assert.Equal(t, http.StatusAccepted, rec.Code)
+		})
+	}
+}
+
+func TestWebhookHandler_DeletedIssue(t *testing.T) {
+	var received domain.IssueEvent
+	handler := NewWebhookHandler(nil, "", func(_ context.Context, event domain.IssueEvent) error {
+		received = event
+		return nil
+	})
+
+	rec := postWebhook(handler, `{"webhookEvent": "jira:issue_deleted", "issue": {"key": "FN-7", "fields": {}}}`, nil)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, domain.IssueEventDeleted, received.Type)
+	assert.Equal(t, "FN-7", received.Task.Key)
+	assert.Equal(t, "FN", received.Task.Project)
+}
+
+func TestWebhookHandler_Signature(t *testing.T) {
+	calls := 0
+	handler := NewWebhookHandler(nil, "s3cret", func(_ context.Context, _ domain.IssueEvent) error {
+		calls++
+		return nil
+	})
+
+	rec := postWebhook(handler, updatedPayload, map[string]string{"X-Hub-Signature": sign("wrong", updatedPayload)})
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = postWebhook(handler, updatedPayload, nil)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = postWebhook(handler, updatedPayload, map[string]string{"X-Hub-Signature": sign("s3cret", updatedPayload)})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 1, calls)
+}
+
+func TestWebhookHandler_Errors(t *testing.T) {
+	handler := NewWebhookHandler(nil, "", func(_ context.Context, _ domain.IssueEvent) error {
+		return errors.New("disk full")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/webhooks/jira", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+	rec = postWebhook(handler, "not json", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = postWebhook(handler, updatedPayload, nil)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
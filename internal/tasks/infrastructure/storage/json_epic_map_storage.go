@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain/ports"
+)
+
+// JSONEpicMapStorage implements EpicMapRepository using a JSON file
+type JSONEpicMapStorage struct {
+	dir  string
+	file string
+}
+
+// NewJSONEpicMapStorage creates a new JSON epic map storage instance
+func NewJSONEpicMapStorage(dir, file string) *JSONEpicMapStorage {
+	return &JSONEpicMapStorage{
+		dir:  dir,
+		file: file,
+	}
+}
+
+// SaveEpicMapping persists a mapping, replacing any previous mapping of the same epic
+func (s *JSONEpicMapStorage) SaveEpicMapping(_ context.Context, mapping domain.EpicMapping) error {
+	mappings, err := s.loadMappings()
+	if err != nil {
+		return fmt.Errorf("failed to load epic mappings: %w", err)
+	}
+
+	mappings[mapping.EpicKey] = mapping
+	return s.saveMappings(mappings)
+}
+
+// DeleteEpicMapping removes the mapping of an epic
+func (s *JSONEpicMapStorage) DeleteEpicMapping(_ context.Context, epicKey string) error {
+	mappings, err := s.loadMappings()
+	if err != nil {
+		return fmt.Errorf("failed to load epic mappings: %w", err)
+	}
+
+	key := strings.ToUpper(epicKey)
+	if _, exists := mappings[key]; !exists {
+		return fmt.Errorf("epic %s is not mapped to an asset", key)
+	}
+	delete(mappings, key)
+	return s.saveMappings(mappings)
+}
+
+// FindEpicMappings returns every stored mapping, ordered by epic key
+func (s *JSONEpicMapStorage) FindEpicMappings(_ context.Context) ([]domain.EpicMapping, error) {
+	mappings, err := s.loadMappings()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load epic mappings: %w", err)
+	}
+
+	result := make([]domain.EpicMapping, 0, len(mappings))
+	for _, mapping := range mappings {
+		result = append(result, mapping)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].EpicKey < result[j].EpicKey })
+	return result, nil
+}
+
+// loadMappings loads all mappings from the JSON file, keyed by epic
+func (s *JSONEpicMapStorage) loadMappings() (map[string]domain.EpicMapping, error) {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(s.dir, s.file))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return make(map[string]domain.EpicMapping), nil
+		}
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var mappings map[string]domain.EpicMapping
+	if err := json.Unmarshal(data, &mappings); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal epic mappings: %w", err)
+	}
+	if mappings == nil {
+		mappings = make(map[string]domain.EpicMapping)
+	}
+	return mappings, nil
+}
+
+// saveMappings writes all mappings to the JSON file
+func (s *JSONEpicMapStorage) saveMappings(mappings map[string]domain.EpicMapping) error {
+	data, err := json.MarshalIndent(mappings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal epic mappings: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(s.dir, s.file), data, 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// Ensure JSONEpicMapStorage implements EpicMapRepository
+var _ ports.EpicMapRepository = (*JSONEpicMapStorage)(nil)
@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+func TestJSONEpicMapStorage_SaveFindAndDelete(t *testing.T) {
+	storage := NewJSONEpicMapStorage(t.TempDir(), "epics.json")
+	ctx := context.Background()
+
+	mappings, err := storage.FindEpicMappings(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, mappings)
+
+	require.NoError(t, storage.SaveEpicMapping(ctx, domain.EpicMapping{EpicKey: "FN-200", Asset: "Search", Label: "cap-asset-search"}))
+	require.NoError(t, storage.SaveEpicMapping(ctx, domain.EpicMapping{EpicKey: "FN-100", Asset: "Search", Label: "cap-asset-search"}))
+	require.NoError(t, storage.SaveEpicMapping(ctx, domain.EpicMapping{EpicKey: "FN-100", Asset: "Booking", Label: "cap-asset-booking"}))
+
+	mappings, err = storage.FindEpicMappings(ctx)
+	require.NoError(t, err)
+	require.Len(t, mappings, 2)
+	assert.Equal(t, "FN-100", mappings[0].EpicKey)
+	assert.Equal(t, "cap-asset-booking", mappings[0].Label)
+	assert.Equal(t, "FN-200", mappings[1].EpicKey)
+
+	require.NoError(t, storage.DeleteEpicMapping(ctx, "fn-100"))
+	assert.EqualError(t, storage.DeleteEpicMapping(ctx, "FN-100"), "epic FN-100 is not mapped to an asset")
+	mappings, err = storage.FindEpicMappings(ctx)
+	require.NoError(t, err)
+	require.Len(t, mappings, 1)
+	assert.Equal(t, "FN-200", mappings[0].EpicKey)
+}
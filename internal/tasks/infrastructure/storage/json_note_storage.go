@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain/ports"
+)
+
+// JSONNoteStorage implements TaskNoteRepository using a JSON file
+type JSONNoteStorage struct {
+	dir  string
+	file string
+}
+
+// NewJSONNoteStorage creates a new JSON task note storage instance
+func NewJSONNoteStorage(dir, file string) *JSONNoteStorage {
+	return &JSONNoteStorage{
+		dir:  dir,
+		file: file,
+	}
+}
+
+// SaveNote appends a note to the notes of its issue
+func (s *JSONNoteStorage) SaveNote(_ context.Context, note domain.TaskNote) error {
+	notes, err := s.loadNotes()
+	if err != nil {
+		return fmt.Errorf("failed to load notes: %w", err)
+	}
+
+	key := strings.ToUpper(note.IssueKey)
+	notes[key] = append(notes[key], note)
+	sort.SliceStable(notes[key], func(i, j int) bool { return notes[key][i].CreatedAt.Before(notes[key][j].CreatedAt) })
+	return s.saveNotes(notes)
+}
+
+// FindNotes returns the notes of the given issues keyed by issue, oldest first;
+// no issue keys returns every note
+func (s *JSONNoteStorage) FindNotes(_ context.Context, issueKeys ...string) (map[string][]domain.TaskNote, error) {
+	notes, err := s.loadNotes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load notes: %w", err)
+	}
+	if len(issueKeys) == 0 {
+		return notes, nil
+	}
+
+	result := make(map[string][]domain.TaskNote)
+	for _, issueKey := range issueKeys {
+		key := strings.ToUpper(issueKey)
+		if found := notes[key]; len(found) > 0 {
+			result[key] = found
+		}
+	}
+	return result, nil
+}
+
+// loadNotes loads all notes from the JSON file, keyed by issue
+func (s *JSONNoteStorage) loadNotes() (map[string][]domain.TaskNote, error) {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(s.dir, s.file))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return make(map[string][]domain.TaskNote), nil
+		}
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var notes map[string][]domain.TaskNote
+	if err := json.Unmarshal(data, &notes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal notes: %w", err)
+	}
+	if notes == nil {
+		notes = make(map[string][]domain.TaskNote)
+	}
+	return notes, nil
+}
+
+// saveNotes writes all notes to the JSON file
+func (s *JSONNoteStorage) saveNotes(notes map[string][]domain.TaskNote) error {
+	data, err := json.MarshalIndent(notes, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal notes: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(s.dir, s.file), data, 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// Ensure JSONNoteStorage implements TaskNoteRepository
+var _ ports.TaskNoteRepository = (*JSONNoteStorage)(nil)
@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+func TestJSONNoteStorage_SaveAndFind(t *testing.T) {
+	storage := NewJSONNoteStorage(t.TempDir(), "notes.json")
+	ctx := context.Background()
+	day := time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)
+
+	notes, err := storage.FindNotes(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, notes)
+
+	require.NoError(t, storage.SaveNote(ctx, domain.TaskNote{IssueKey: "FN-1", Text: "later", CreatedAt: day.Add(time.Hour)}))
+	require.NoError(t, storage.SaveNote(ctx, domain.TaskNote{IssueKey: "FN-1", Text: "earlier", CreatedAt: day}))
+	require.NoError(t, storage.SaveNote(ctx, domain.TaskNote{IssueKey: "FN-2", Text: "other", CreatedAt: day}))
+
+	notes, err = storage.FindNotes(ctx, "fn-1", "FN-3")
+	require.NoError(t, err)
+	require.Len(t, notes, 1)
+	require.Len(t, notes["FN-1"], 2)
+	assert.Equal(t, "earlier", notes["FN-1"][0].Text)
+	assert.Equal(t, "later", notes["FN-1"][1].Text)
+
+	notes, err = storage.FindNotes(ctx)
+	require.NoError(t, err)
+	assert.Len(t, notes, 2)
+}
+
+func TestJSONNoteStorage_InvalidFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.json"), []byte("{"), 0644))
+	storage := NewJSONNoteStorage(dir, "notes.json")
+
+	_, err := storage.FindNotes(context.Background())
+
+	assert.ErrorContains(t, err, "failed to unmarshal notes")
+}
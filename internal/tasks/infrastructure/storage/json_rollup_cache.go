@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain/ports"
+)
+
+// JSONRollupCache implements RollupCache using a JSON file. It is safe for the concurrent
+// use of organization reports, which roll up teams in parallel.
+type JSONRollupCache struct {
+	dir  string
+	file string
+	mu   sync.Mutex
+}
+
+// NewJSONRollupCache creates a new JSON rollup cache instance
+func NewJSONRollupCache(dir, file string) *JSONRollupCache {
+	return &JSONRollupCache{
+		dir:  dir,
+		file: file,
+	}
+}
+
+// FindRollup retrieves the cached rollup of a team and quarter, or nil when none is cached
+func (c *JSONRollupCache) FindRollup(_ context.Context, team, quarter string) (*domain.TeamRollup, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	rollups, err := c.loadRollups()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rollups: %w", err)
+	}
+	rollup, exists := rollups[rollupKey(team, quarter)]
+	if !exists {
+		return nil, nil
+	}
+	return &rollup, nil
+}
+
+// SaveRollup caches a rollup, replacing any previous one of the same team and quarter
+func (c *JSONRollupCache) SaveRollup(_ context.Context, rollup domain.TeamRollup) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	rollups, err := c.loadRollups()
+	if err != nil {
+		return fmt.Errorf("failed to load rollups: %w", err)
+	}
+
+	rollups[rollupKey(rollup.Team, rollup.Quarter)] = rollup
+
+	data, err := json.MarshalIndent(rollups, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal rollups: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(c.dir, c.file), data, 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// loadRollups loads all cached rollups from the JSON file
+func (c *JSONRollupCache) loadRollups() (map[string]domain.TeamRollup, error) {
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(c.dir, c.file))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return make(map[string]domain.TeamRollup), nil
+		}
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var rollups map[string]domain.TeamRollup
+	if err := json.Unmarshal(data, &rollups); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal rollups: %w", err)
+	}
+	return rollups, nil
+}
+
+// rollupKey identifies the rollup of a team in a quarter
+func rollupKey(team, quarter string) string {
+	return team + "/" + quarter
+}
+
+// Ensure JSONRollupCache implements RollupCache
+var _ ports.RollupCache = (*JSONRollupCache)(nil)
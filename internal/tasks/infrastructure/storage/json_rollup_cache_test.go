@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+func TestJSONRollupCache_SaveAndFind(t *testing.T) {
+	cache := NewJSONRollupCache(t.TempDir(), "rollups.json")
+	ctx := context.Background()
+
+	missing, err := cache.FindRollup(ctx, "FN", "2024-Q2")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	rollup := domain.TeamRollup{
+		Team:        "FN",
+		Quarter:     "2024-Q2",
+		Tasks:       3,
+		WorkTypes:   map[domain.WorkType]float64{domain.WorkTypeDevelopment: 2.5, domain.WorkTypeDiscovery: 0.5},
+		Fingerprint: "abc",
+	}
+	require.NoError(t, cache.SaveRollup(ctx, rollup))
+	require.NoError(t, cache.SaveRollup(ctx, domain.TeamRollup{Team: "FN", Quarter: "2024-Q3", Fingerprint: "def"}))
+
+	found, err := cache.FindRollup(ctx, "FN", "2024-Q2")
+	require.NoError(t, err)
+	assert.Equal(t, rollup, *found)
+
+	other, err := cache.FindRollup(ctx, "FN", "2024-Q3")
+	require.NoError(t, err)
+	assert.Equal(t, "def", other.Fingerprint)
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain/ports"
+)
+
+// JSONSampleStorage implements SampleRepository using a JSON file
+type JSONSampleStorage struct {
+	dir  string
+	file string
+}
+
+// NewJSONSampleStorage creates a new JSON sample storage instance
+func NewJSONSampleStorage(dir, file string) *JSONSampleStorage {
+	return &JSONSampleStorage{
+		dir:  dir,
+		file: file,
+	}
+}
+
+// SaveSample persists a sample definition, replacing any previous one with the same ID
+func (s *JSONSampleStorage) SaveSample(_ context.Context, definition domain.SampleDefinition) error {
+	samples, err := s.loadSamples()
+	if err != nil {
+		return fmt.Errorf("failed to load samples: %w", err)
+	}
+
+	samples[definition.ID] = definition
+
+	data, err := json.MarshalIndent(samples, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal samples: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(s.dir, s.file), data, 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// FindSample retrieves a sample definition by its ID
+func (s *JSONSampleStorage) FindSample(_ context.Context, id string) (*domain.SampleDefinition, error) {
+	samples, err := s.loadSamples()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load samples: %w", err)
+	}
+
+	definition, exists := samples[id]
+	if !exists {
+		return nil, fmt.Errorf("sample %s not found", id)
+	}
+	return &definition, nil
+}
+
+// loadSamples loads all sample definitions from the JSON file
+func (s *JSONSampleStorage) loadSamples() (map[string]domain.SampleDefinition, error) {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(s.dir, s.file))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return make(map[string]domain.SampleDefinition), nil
+		}
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var samples map[string]domain.SampleDefinition
+	if err := json.Unmarshal(data, &samples); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal samples: %w", err)
+	}
+	return samples, nil
+}
+
+// Ensure JSONSampleStorage implements SampleRepository
+var _ ports.SampleRepository = (*JSONSampleStorage)(nil)
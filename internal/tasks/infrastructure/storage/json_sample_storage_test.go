@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+func TestJSONSampleStorage_SaveAndFind(t *testing.T) {
+	storage := NewJSONSampleStorage(t.TempDir(), "samples.json")
+	ctx := context.Background()
+
+	definition := domain.SampleDefinition{
+		ID:       "FN-2024-Q2-42",
+		Project:  "FN",
+		Quarter:  "2024-Q2",
+		Size:     2,
+		Seed:     42,
+		TaskKeys: []string{"FN-1", "FN-2"},
+	}
+	require.NoError(t, storage.SaveSample(ctx, definition))
+
+	found, err := storage.FindSample(ctx, "FN-2024-Q2-42")
+	require.NoError(t, err)
+	assert.Equal(t, definition.TaskKeys, found.TaskKeys)
+	assert.Equal(t, int64(42), found.Seed)
+
+	_, err = storage.FindSample(ctx, "missing")
+	assert.EqualError(t, err, "sample missing not found")
+}
@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain/ports"
+)
+
+// JSONSplitStorage implements WorkTypeSplitRepository using a JSON file
+type JSONSplitStorage struct {
+	dir  string
+	file string
+}
+
+// NewJSONSplitStorage creates a new JSON work type split storage instance
+func NewJSONSplitStorage(dir, file string) *JSONSplitStorage {
+	return &JSONSplitStorage{
+		dir:  dir,
+		file: file,
+	}
+}
+
+// SaveSplit persists a split, replacing any previous split of the same issue
+func (s *JSONSplitStorage) SaveSplit(_ context.Context, split domain.WorkTypeSplit) error {
+	splits, err := s.loadSplits()
+	if err != nil {
+		return fmt.Errorf("failed to load splits: %w", err)
+	}
+
+	splits[split.IssueKey] = split
+	return s.saveSplits(splits)
+}
+
+// DeleteSplit removes the split of an issue
+func (s *JSONSplitStorage) DeleteSplit(_ context.Context, issueKey string) error {
+	splits, err := s.loadSplits()
+	if err != nil {
+		return fmt.Errorf("failed to load splits: %w", err)
+	}
+
+	key := strings.ToUpper(issueKey)
+	if _, exists := splits[key]; !exists {
+		return fmt.Errorf("issue %s has no work type split", key)
+	}
+	delete(splits, key)
+	return s.saveSplits(splits)
+}
+
+// FindSplits returns every stored split, ordered by issue key
+func (s *JSONSplitStorage) FindSplits(_ context.Context) ([]domain.WorkTypeSplit, error) {
+	splits, err := s.loadSplits()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load splits: %w", err)
+	}
+
+	result := make([]domain.WorkTypeSplit, 0, len(splits))
+	for _, split := range splits {
+		result = append(result, split)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].IssueKey < result[j].IssueKey })
+	return result, nil
+}
+
+// loadSplits loads all splits from the JSON file, keyed by issue
+func (s *JSONSplitStorage) loadSplits() (map[string]domain.WorkTypeSplit, error) {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(s.dir, s.file))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return make(map[string]domain.WorkTypeSplit), nil
+		}
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var splits map[string]domain.WorkTypeSplit
+	if err := json.Unmarshal(data, &splits); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal splits: %w", err)
+	}
+	return splits, nil
+}
+
+// saveSplits writes all splits to the JSON file
+func (s *JSONSplitStorage) saveSplits(splits map[string]domain.WorkTypeSplit) error {
+	data, err := json.MarshalIndent(splits, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal splits: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(s.dir, s.file), data, 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// Ensure JSONSplitStorage implements WorkTypeSplitRepository
+var _ ports.WorkTypeSplitRepository = (*JSONSplitStorage)(nil)
@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+func TestJSONSplitStorage_SaveFindAndDelete(t *testing.T) {
+	storage := NewJSONSplitStorage(t.TempDir(), "splits.json")
+	ctx := context.Background()
+
+	splits, err := storage.FindSplits(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, splits)
+
+	require.NoError(t, storage.SaveSplit(ctx, domain.WorkTypeSplit{
+		IssueKey: "FN-2",
+		Shares:   map[domain.WorkType]float64{domain.WorkTypeDevelopment: 100},
+	}))
+	require.NoError(t, storage.SaveSplit(ctx, domain.WorkTypeSplit{
+		IssueKey: "FN-1",
+		Shares:   map[domain.WorkType]float64{domain.WorkTypeDevelopment: 50, domain.WorkTypeMaintenance: 50},
+	}))
+	require.NoError(t, storage.SaveSplit(ctx, domain.WorkTypeSplit{
+		IssueKey: "FN-2",
+		Shares:   map[domain.WorkType]float64{domain.WorkTypeDevelopment: 60, domain.WorkTypeDiscovery: 40},
+	}))
+
+	splits, err = storage.FindSplits(ctx)
+	require.NoError(t, err)
+	require.Len(t, splits, 2)
+	assert.Equal(t, "FN-1", splits[0].IssueKey)
+	assert.Equal(t, "FN-2", splits[1].IssueKey)
+	assert.Equal(t, 40.0, splits[1].Shares[domain.WorkTypeDiscovery])
+
+	require.NoError(t, storage.DeleteSplit(ctx, "fn-1"))
+	assert.EqualError(t, storage.DeleteSplit(ctx, "FN-1"), "issue FN-1 has no work type split")
+	splits, err = storage.FindSplits(ctx)
+	require.NoError(t, err)
+	require.Len(t, splits, 1)
+	assert.Equal(t, "FN-2", splits[0].IssueKey)
+}
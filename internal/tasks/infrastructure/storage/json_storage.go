@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
+	"github.com/helmedeiros/digital-asset-capitalization/internal/schema"
 	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
 	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain/ports"
 )
@@ -15,13 +17,28 @@ import (
 type JSONStorage struct {
 	dir  string
 	file string
+	// now returns the current time, used to date deletions
+	now func() time.Time
+	// retention is how long deleted tasks are kept in the trash
+	retention time.Duration
 }
 
 // NewJSONStorage creates a new JSON storage instance
 func NewJSONStorage(dir, file string) *JSONStorage {
+	return NewJSONStorageWithTrashRetention(dir, file, domain.DefaultTrashRetention)
+}
+
+// NewJSONStorageWithTrashRetention creates a JSON storage instance that keeps deleted
+// tasks for the given duration; zero uses the default
+func NewJSONStorageWithTrashRetention(dir, file string, retention time.Duration) *JSONStorage {
+	if retention <= 0 {
+		retention = domain.DefaultTrashRetention
+	}
 	return &JSONStorage{
-		dir:  dir,
-		file: file,
+		dir:       dir,
+		file:      file,
+		now:       time.Now,
+		retention: retention,
 	}
 }
 
@@ -65,69 +82,61 @@ func (s *JSONStorage) FindByKey(_ context.Context, key string) (*domain.Task, er
 
 // FindByProjectAndSprint retrieves tasks for a specific project and sprint
 func (s *JSONStorage) FindByProjectAndSprint(_ context.Context, project, sprint string) ([]*domain.Task, error) {
-	tasks, err := s.loadTasks()
+	result, err := s.findIndexed(func(index *taskIndex) []string {
+		return index.ProjectSprint[projectSprintKey(project, sprint)]
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to load tasks: %w", err)
 	}
-
-	var result []*domain.Task
-	for _, task := range tasks {
-		if task.Project == project && task.Sprint == sprint {
-			result = append(result, task)
-		}
-	}
-
 	return result, nil
 }
 
 // FindByProject retrieves all tasks for a specific project
 func (s *JSONStorage) FindByProject(_ context.Context, project string) ([]*domain.Task, error) {
-	tasks, err := s.loadTasks()
+	result, err := s.findIndexed(func(index *taskIndex) []string {
+		return index.Project[project]
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to load tasks: %w", err)
 	}
-
-	var result []*domain.Task
-	for _, task := range tasks {
-		if task.Project == project {
-			result = append(result, task)
-		}
-	}
-
 	return result, nil
 }
 
 // FindBySprint retrieves all tasks for a specific sprint
 func (s *JSONStorage) FindBySprint(_ context.Context, sprint string) ([]*domain.Task, error) {
-	tasks, err := s.loadTasks()
+	result, err := s.findIndexed(func(index *taskIndex) []string {
+		return index.Sprint[sprint]
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to load tasks: %w", err)
 	}
-
-	var result []*domain.Task
-	for _, task := range tasks {
-		if task.Sprint == sprint {
-			result = append(result, task)
-		}
-	}
-
 	return result, nil
 }
 
 // FindByPlatform retrieves all tasks for a specific platform
 func (s *JSONStorage) FindByPlatform(_ context.Context, platform string) ([]*domain.Task, error) {
-	tasks, err := s.loadTasks()
+	result, err := s.findIndexed(func(index *taskIndex) []string {
+		return index.Platform[platform]
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to load tasks: %w", err)
 	}
+	return result, nil
+}
 
-	var result []*domain.Task
-	for _, task := range tasks {
-		if task.Platform == platform {
-			result = append(result, task)
-		}
+// FindByAsset retrieves the tasks linked to an asset, given its name or task label
+func (s *JSONStorage) FindByAsset(_ context.Context, asset string) ([]*domain.Task, error) {
+	label := domain.AssetLabel(asset)
+	if label == "" {
+		return nil, fmt.Errorf("asset cannot be empty")
 	}
 
+	result, err := s.findIndexed(func(index *taskIndex) []string {
+		return index.Asset[label]
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tasks: %w", err)
+	}
 	return result, nil
 }
 
@@ -146,7 +155,7 @@ func (s *JSONStorage) FindAll(_ context.Context) ([]*domain.Task, error) {
 	return result, nil
 }
 
-// Delete removes a task
+// Delete removes a task, keeping it in the trash until it expires
 func (s *JSONStorage) Delete(_ context.Context, key string) error {
 	if key == "" {
 		return fmt.Errorf("task key cannot be empty")
@@ -157,15 +166,20 @@ func (s *JSONStorage) Delete(_ context.Context, key string) error {
 		return fmt.Errorf("failed to load tasks: %w", err)
 	}
 
-	if _, exists := tasks[key]; !exists {
+	task, exists := tasks[key]
+	if !exists {
 		return fmt.Errorf("task %s not found", key)
 	}
 
+	if err := s.moveToTrash(task); err != nil {
+		return err
+	}
 	delete(tasks, key)
 	return s.saveTasks(tasks)
 }
 
-// DeleteByProjectAndSprint removes all tasks for a specific project and sprint
+// DeleteByProjectAndSprint removes all tasks for a specific project and sprint, keeping
+// them in the trash until they expire
 func (s *JSONStorage) DeleteByProjectAndSprint(_ context.Context, project, sprint string) error {
 	tasks, err := s.loadTasks()
 	if err != nil {
@@ -174,12 +188,18 @@ func (s *JSONStorage) DeleteByProjectAndSprint(_ context.Context, project, sprin
 
 	// Create a new map with tasks to keep
 	newTasks := make(map[string]*domain.Task)
+	var deleted []*domain.Task
 	for key, task := range tasks {
 		if task.Project != project || task.Sprint != sprint {
 			newTasks[key] = task
+		} else {
+			deleted = append(deleted, task)
 		}
 	}
 
+	if err := s.moveToTrash(deleted...); err != nil {
+		return err
+	}
 	return s.saveTasks(newTasks)
 }
 
@@ -203,27 +223,140 @@ func (s *JSONStorage) UpdateLabels(_ context.Context, taskKey string, labels []s
 	return s.saveTasks(tasks)
 }
 
+// findIndexed retrieves the tasks whose keys lookup selects from the index. With an index
+// matching the tasks file only the selected tasks are read from the file and decoded;
+// otherwise every task is loaded and the index rebuilt.
+func (s *JSONStorage) findIndexed(lookup func(*taskIndex) []string) ([]*domain.Task, error) {
+	info, err := s.statTasksFile()
+	if err != nil || info == nil {
+		return nil, err
+	}
+	if index := s.loadIndex(); index.matches(info) {
+		if result, ok := s.readIndexed(index, lookup(index)); ok {
+			return result, nil
+		}
+	}
+
+	data, info, err := s.readTasksFile()
+	if err != nil || data == nil {
+		return nil, err
+	}
+	tasks, err := s.decodeTasks(data, info)
+	if err != nil {
+		return nil, err
+	}
+	var result []*domain.Task
+	for _, key := range lookup(newTaskIndex(tasks, data, nil)) {
+		result = append(result, tasks[key])
+	}
+	return result, nil
+}
+
+// readIndexed reads the tasks of the keys from their offsets in the tasks file. It reports
+// false when one cannot be read there, so the caller falls back to loading the whole file.
+func (s *JSONStorage) readIndexed(index *taskIndex, keys []string) ([]*domain.Task, bool) {
+	if len(keys) == 0 {
+		return nil, true
+	}
+	file, err := os.Open(s.tasksPath())
+	if err != nil {
+		return nil, false
+	}
+	defer file.Close()
+
+	result := make([]*domain.Task, 0, len(keys))
+	for _, key := range keys {
+		offsets, ok := index.Offsets[key]
+		if !ok || offsets[1] <= offsets[0] {
+			return nil, false
+		}
+		data := make([]byte, offsets[1]-offsets[0])
+		if _, err := file.ReadAt(data, offsets[0]); err != nil {
+			return nil, false
+		}
+		var task domain.Task
+		if err := json.Unmarshal(data, &task); err != nil || task.Key != key {
+			return nil, false
+		}
+		result = append(result, &task)
+	}
+	return result, true
+}
+
 // loadTasks loads all tasks from the JSON file
 func (s *JSONStorage) loadTasks() (map[string]*domain.Task, error) {
+	data, info, err := s.readTasksFile()
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return make(map[string]*domain.Task), nil
+	}
+	return s.decodeTasks(data, info)
+}
+
+// tasksPath is the path of the JSON file
+func (s *JSONStorage) tasksPath() string {
+	return filepath.Join(s.dir, s.file)
+}
+
+// statTasksFile describes the JSON file, returning nil when it does not exist yet
+func (s *JSONStorage) statTasksFile() (os.FileInfo, error) {
 	// Create directory if it doesn't exist
 	if err := os.MkdirAll(s.dir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create directory: %w", err)
 	}
 
-	filePath := filepath.Join(s.dir, s.file)
-	data, err := os.ReadFile(filePath)
+	info, err := os.Stat(s.tasksPath())
 	if err != nil {
 		if os.IsNotExist(err) {
-			return make(map[string]*domain.Task), nil
+			return nil, nil
 		}
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
+	return info, nil
+}
+
+// readTasksFile reads the JSON file, returning nil when it does not exist yet. The file is
+// described as it was read, or not at all when it was written meanwhile.
+func (s *JSONStorage) readTasksFile() ([]byte, os.FileInfo, error) {
+	info, err := s.statTasksFile()
+	if err != nil || info == nil {
+		return nil, nil, err
+	}
+
+	data, err := os.ReadFile(s.tasksPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	if after, err := os.Stat(s.tasksPath()); err != nil || !sameFile(info, after) {
+		info = nil
+	}
+	return data, info, nil
+}
+
+// decodeTasks decodes the tasks of the JSON file described by info. A file matching the index
+// was validated when the index was built, so only other files are checked against the schema
+// and then indexed.
+func (s *JSONStorage) decodeTasks(data []byte, info os.FileInfo) (map[string]*domain.Task, error) {
+	fresh := s.loadIndex().matches(info)
+	if !fresh {
+		if err := schema.Validate(schema.Tasks, data); err != nil {
+			return nil, fmt.Errorf("invalid tasks file %s: %w", s.tasksPath(), err)
+		}
+	}
 
 	var tasks map[string]*domain.Task
 	if err := json.Unmarshal(data, &tasks); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal tasks: %w", err)
 	}
 
+	if !fresh && info != nil {
+		s.writeIndex(newTaskIndex(tasks, data, info))
+	}
 	return tasks, nil
 }
 
@@ -234,13 +367,18 @@ func (s *JSONStorage) saveTasks(tasks map[string]*domain.Task) error {
 		return fmt.Errorf("failed to marshal tasks: %w", err)
 	}
 
-	filePath := filepath.Join(s.dir, s.file)
-	if err := os.WriteFile(filePath, data, 0644); err != nil {
+	if err := os.WriteFile(s.tasksPath(), data, 0644); err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
 	}
 
+	if info, err := os.Stat(s.tasksPath()); err == nil {
+		s.writeIndex(newTaskIndex(tasks, data, info))
+	}
 	return nil
 }
 
-// Ensure JSONStorage implements TaskRepository
-var _ ports.TaskRepository = (*JSONStorage)(nil)
+// Ensure JSONStorage implements TaskRepository and TaskAssetFinder
+var (
+	_ ports.TaskRepository  = (*JSONStorage)(nil)
+	_ ports.TaskAssetFinder = (*JSONStorage)(nil)
+)
@@ -417,3 +417,12 @@ func TestJSONStorage_EdgeCases(t *testing.T) {
 		assert.Error(t, err, "Expected error loading invalid JSON")
 	})
 }
+
+func TestJSONStorage_InvalidFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tasks.json"), []byte(`{"FN-1": {"key": "FN-1", "version": "2"}}`), 0644))
+	storage := NewJSONStorage(dir, "tasks.json")
+
+	_, err := storage.FindAll(context.Background())
+	assert.ErrorContains(t, err, "invalid tasks file "+filepath.Join(dir, "tasks.json")+": line 1: FN-1.version: expected integer, got string; remove the quotes around the number")
+}
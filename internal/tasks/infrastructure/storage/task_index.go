@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// taskIndexVersion is bumped when the index layout changes, so older indexes are rebuilt
+const taskIndexVersion = 3
+
+// taskIndex holds the keys of the stored tasks by the fields they are looked up by, and where
+// each task sits in the tasks file. It is derived from the tasks file and kept next to it, so
+// lookups on large workspaces read and decode only the tasks they return.
+type taskIndex struct {
+	Version int `json:"version"`
+	// ModTime and Size identify the tasks file the index was built from. An index not
+	// matching the file, after the file was edited by hand or imported, is stale and rebuilt.
+	ModTime int64 `json:"mod_time"`
+	Size    int64 `json:"size"`
+	// Offsets holds the byte range of each task's JSON object in the tasks file, by key
+	Offsets       map[string][2]int64 `json:"offsets"`
+	ProjectSprint map[string][]string `json:"project_sprint"`
+	Project       map[string][]string `json:"project"`
+	Sprint        map[string][]string `json:"sprint"`
+	Platform      map[string][]string `json:"platform"`
+	// Asset holds the tasks linked to each asset, by asset label
+	Asset map[string][]string `json:"asset"`
+}
+
+// newTaskIndex indexes the tasks read from or written to the tasks file data, described by
+// info. Without info the index only serves the lookup at hand and is not stored.
+func newTaskIndex(tasks map[string]*domain.Task, data []byte, info os.FileInfo) *taskIndex {
+	index := &taskIndex{
+		Version:       taskIndexVersion,
+		ProjectSprint: make(map[string][]string),
+		Project:       make(map[string][]string),
+		Sprint:        make(map[string][]string),
+		Platform:      make(map[string][]string),
+		Asset:         make(map[string][]string),
+	}
+
+	keys := make([]string, 0, len(tasks))
+	for key := range tasks {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		task := tasks[key]
+		projectSprint := projectSprintKey(task.Project, task.Sprint)
+		index.ProjectSprint[projectSprint] = append(index.ProjectSprint[projectSprint], key)
+		index.Project[task.Project] = append(index.Project[task.Project], key)
+		index.Sprint[task.Sprint] = append(index.Sprint[task.Sprint], key)
+		index.Platform[task.Platform] = append(index.Platform[task.Platform], key)
+		seen := make(map[string]bool)
+		for _, label := range task.AssetLabels() {
+			if !seen[label] {
+				seen[label] = true
+				index.Asset[label] = append(index.Asset[label], key)
+			}
+		}
+	}
+	if info != nil {
+		index.ModTime = info.ModTime().UnixNano()
+		index.Size = info.Size()
+		index.Offsets = taskOffsets(data)
+	}
+	return index
+}
+
+// matches reports whether the index was built from the tasks file described by info
+func (i *taskIndex) matches(info os.FileInfo) bool {
+	return i != nil && info != nil && i.Version == taskIndexVersion &&
+		i.ModTime == info.ModTime().UnixNano() && i.Size == info.Size() && i.Offsets != nil
+}
+
+// taskOffsets returns the byte range of each task's JSON object in the tasks file data, or
+// nil when the data is not a JSON object
+func taskOffsets(data []byte) map[string][2]int64 {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil
+	}
+	offsets := make(map[string][2]int64)
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil
+		}
+		key, _ := token.(string)
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil
+		}
+		end := decoder.InputOffset()
+		offsets[key] = [2]int64{end - int64(len(value)), end}
+	}
+	return offsets
+}
+
+// sameFile reports whether two descriptions of the tasks file match, so the file was not
+// written between them
+func sameFile(a, b os.FileInfo) bool {
+	return a != nil && b != nil && a.ModTime().Equal(b.ModTime()) && a.Size() == b.Size()
+}
+
+// projectSprintKey identifies the tasks of a project in a sprint
+func projectSprintKey(project, sprint string) string {
+	return project + "/" + sprint
+}
+
+// indexPath is the index file of the tasks file, such as tasks.index.json for tasks.json
+func (s *JSONStorage) indexPath() string {
+	return filepath.Join(s.dir, strings.TrimSuffix(s.file, filepath.Ext(s.file))+".index.json")
+}
+
+// loadIndex reads the index of the tasks file, or nil when there is none or it cannot be read
+func (s *JSONStorage) loadIndex() *taskIndex {
+	data, err := os.ReadFile(s.indexPath())
+	if err != nil {
+		return nil
+	}
+	var index taskIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil
+	}
+	return &index
+}
+
+// writeIndex stores the index of the tasks file. Failing to write it is not an error: the
+// tasks file stays the source of truth and a missing or stale index is rebuilt on next read.
+func (s *JSONStorage) writeIndex(index *taskIndex) {
+	data, err := json.Marshal(index)
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(s.indexPath(), data, 0644)
+}
@@ -0,0 +1,179 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+func TestJSONStorage_IndexMaintainedOnSaveAndDelete(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	storage := NewJSONStorage(dir, "tasks.json")
+
+	require.NoError(t, storage.Save(ctx, &domain.Task{Key: "FN-1", Project: "FN", Sprint: "Sprint 1", Platform: "jira", Labels: []string{"cap-asset-booking", "cap-development"}}))
+	require.NoError(t, storage.Save(ctx, &domain.Task{Key: "FN-2", Project: "FN", Sprint: "Sprint 2", Platform: "jira", Labels: []string{"cap-asset-booking", "cap-asset-search"}}))
+	require.FileExists(t, filepath.Join(dir, "tasks.index.json"))
+
+	index := storage.loadIndex()
+	require.NotNil(t, index)
+	assert.Equal(t, []string{"FN-1"}, index.ProjectSprint["FN/Sprint 1"])
+	assert.Equal(t, []string{"FN-1", "FN-2"}, index.Project["FN"])
+	assert.Equal(t, []string{"FN-1", "FN-2"}, index.Platform["jira"])
+	assert.Equal(t, []string{"FN-1", "FN-2"}, index.Asset["cap-asset-booking"])
+	assert.Equal(t, []string{"FN-2"}, index.Asset["cap-asset-search"])
+	assert.NotContains(t, index.Asset, "cap-development", "only asset labels are indexed")
+
+	require.NoError(t, storage.Delete(ctx, "FN-1"))
+	tasks, err := storage.FindByAsset(ctx, "booking")
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "FN-2", tasks[0].Key)
+
+	tasks, err = storage.FindByProjectAndSprint(ctx, "FN", "Sprint 1")
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+}
+
+func TestJSONStorage_StaleIndexIsRebuilt(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	storage := NewJSONStorage(dir, "tasks.json")
+	require.NoError(t, storage.Save(ctx, &domain.Task{Key: "FN-1", Project: "FN", Sprint: "Sprint 1", Platform: "jira"}))
+
+	// The tasks file is replaced behind the storage's back, as by a hand edit or an import
+	edited := `{"FN-7": {"key": "FN-7", "summary": "Imported", "description": "", "project": "FN", "sprint": "Sprint 1", "platform": "jira", "status": "", "type": "", "priority": "", "work_type": "", "labels": ["cap-asset-booking"], "epic": "", "created_at": "0001-01-01T00:00:00Z", "updated_at": "0001-01-01T00:00:00Z", "version": 0}}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tasks.json"), []byte(edited), 0644))
+
+	tasks, err := storage.FindByProjectAndSprint(ctx, "FN", "Sprint 1")
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "FN-7", tasks[0].Key)
+	info, err := os.Stat(filepath.Join(dir, "tasks.json"))
+	require.NoError(t, err)
+	assert.True(t, storage.loadIndex().matches(info), "the index is rebuilt from the edited file")
+
+	tasks, err = storage.FindByAsset(ctx, "cap-asset-booking")
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "Imported", tasks[0].Summary)
+}
+
+func TestJSONStorage_MissingIndex(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	storage := NewJSONStorage(dir, "tasks.json")
+	require.NoError(t, storage.Save(ctx, &domain.Task{Key: "FN-1", Project: "FN", Sprint: "Sprint 1", Platform: "jira"}))
+	require.NoError(t, os.Remove(filepath.Join(dir, "tasks.index.json")))
+
+	tasks, err := storage.FindByPlatform(ctx, "jira")
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.FileExists(t, filepath.Join(dir, "tasks.index.json"))
+
+	_, err = storage.FindByAsset(ctx, " ")
+	assert.EqualError(t, err, "asset cannot be empty")
+}
+
+func TestJSONStorage_FindWithoutTasksFile(t *testing.T) {
+	tasks, err := NewJSONStorage(t.TempDir(), "tasks.json").FindBySprint(context.Background(), "Sprint 1")
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+}
+
+func TestJSONStorage_IndexesInheritedEpicAsset(t *testing.T) {
+	ctx := context.Background()
+	storage := NewJSONStorage(t.TempDir(), "tasks.json")
+	require.NoError(t, storage.Save(ctx, &domain.Task{Key: "FN-1", Project: "FN", Sprint: "Sprint 1", Epic: "FN-100", EpicAsset: "cap-asset-booking"}))
+
+	tasks, err := storage.FindByAsset(ctx, "booking")
+
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "FN-1", tasks[0].Key)
+}
+
+func TestJSONStorage_IndexOffsets(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	storage := NewJSONStorage(dir, "tasks.json")
+	require.NoError(t, storage.Save(ctx, &domain.Task{Key: "FN-1", Summary: "Book a room", Project: "FN", Sprint: "Sprint 1"}))
+	require.NoError(t, storage.Save(ctx, &domain.Task{Key: "FN-2", Summary: "Search rooms", Project: "FN", Sprint: "Sprint 2"}))
+
+	data, err := os.ReadFile(filepath.Join(dir, "tasks.json"))
+	require.NoError(t, err)
+	index := storage.loadIndex()
+	require.Len(t, index.Offsets, 2)
+	offsets := index.Offsets["FN-2"]
+	assert.Contains(t, string(data[offsets[0]:offsets[1]]), `"summary": "Search rooms"`)
+	assert.Equal(t, byte('{'), data[offsets[0]])
+	assert.Equal(t, byte('}'), data[offsets[1]-1])
+
+	// An index whose offsets no longer hold the task is not trusted
+	index.Offsets["FN-2"] = index.Offsets["FN-1"]
+	storage.writeIndex(index)
+	tasks, err := storage.FindByProjectAndSprint(ctx, "FN", "Sprint 2")
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "Search rooms", tasks[0].Summary)
+
+	assert.Nil(t, taskOffsets([]byte(`[1, 2]`)))
+}
+
+// BenchmarkJSONStorage_FindByProjectAndSprint compares a sprint lookup through the index with
+// scanning every task of a workspace of 5000 tasks over 100 sprints
+func BenchmarkJSONStorage_FindByProjectAndSprint(b *testing.B) {
+	ctx := context.Background()
+	storage := NewJSONStorage(b.TempDir(), "tasks.json")
+	tasks := make(map[string]*domain.Task)
+	for i := 0; i < 5000; i++ {
+		key := fmt.Sprintf("FN-%d", i)
+		tasks[key] = &domain.Task{
+			Key:         key,
+			Summary:     "Benchmark task",
+			Description: "A task description long enough to weigh on decoding the whole file",
+			Project:     "FN",
+			Sprint:      fmt.Sprintf("Sprint %d", i%100),
+			Platform:    "jira",
+			Labels:      []string{"cap-asset-booking", "cap-development"},
+		}
+	}
+	if err := storage.saveTasks(tasks); err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("indexed", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			found, err := storage.FindByProjectAndSprint(ctx, "FN", "Sprint 42")
+			if err != nil || len(found) != 50 {
+				b.Fatalf("found %d tasks: %v", len(found), err)
+			}
+		}
+	})
+	b.Run("scan", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			all, err := storage.loadTasks()
+			if err != nil {
+				b.Fatal(err)
+			}
+			var found []*domain.Task
+			for _, task := range all {
+				if task.Project == "FN" && task.Sprint == "Sprint 42" {
+					found = append(found, task)
+				}
+			}
+			if len(found) != 50 {
+				b.Fatalf("found %d tasks", len(found))
+			}
+		}
+	})
+}
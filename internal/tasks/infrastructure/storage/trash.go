@@ -0,0 +1,167 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain/ports"
+)
+
+// trashDir is the directory, next to the tasks file, holding the deleted tasks
+const trashDir = "trash"
+
+// Ensure JSONStorage keeps deleted tasks
+var _ ports.TaskTrash = (*JSONStorage)(nil)
+
+// FindDeleted returns the deleted tasks that have not expired yet, most recent first
+func (s *JSONStorage) FindDeleted(_ context.Context) ([]*domain.DeletedTask, error) {
+	trash, err := s.loadTrash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load trash: %w", err)
+	}
+
+	result := make([]*domain.DeletedTask, 0, len(trash))
+	for _, deleted := range trash {
+		result = append(result, deleted)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].DeletedAt.Equal(result[j].DeletedAt) {
+			return result[i].DeletedAt.After(result[j].DeletedAt)
+		}
+		return result[i].Task.Key < result[j].Task.Key
+	})
+	return result, nil
+}
+
+// Restore moves a deleted task back into the task store
+func (s *JSONStorage) Restore(_ context.Context, key string) (*domain.Task, error) {
+	if key == "" {
+		return nil, fmt.Errorf("task key cannot be empty")
+	}
+
+	trash, err := s.loadTrash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load trash: %w", err)
+	}
+	deleted, exists := trash[key]
+	if !exists {
+		return nil, fmt.Errorf("deleted task %s not found", key)
+	}
+
+	tasks, err := s.loadTasks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tasks: %w", err)
+	}
+	if _, exists := tasks[key]; exists {
+		return nil, fmt.Errorf("task %s already exists", key)
+	}
+
+	tasks[key] = deleted.Task
+	if err := s.saveTasks(tasks); err != nil {
+		return nil, err
+	}
+	delete(trash, key)
+	if err := s.saveTrash(trash); err != nil {
+		return nil, err
+	}
+	return deleted.Task, nil
+}
+
+// PurgeByProjectAndSprint permanently removes the tasks of a project and sprint, from
+// the task store and the trash
+func (s *JSONStorage) PurgeByProjectAndSprint(_ context.Context, project, sprint string) error {
+	tasks, err := s.loadTasks()
+	if err != nil {
+		return fmt.Errorf("failed to load tasks: %w", err)
+	}
+	trash, err := s.loadTrash()
+	if err != nil {
+		return fmt.Errorf("failed to load trash: %w", err)
+	}
+
+	for key, task := range tasks {
+		if task.Project == project && task.Sprint == sprint {
+			delete(tasks, key)
+		}
+	}
+	for key, deleted := range trash {
+		if deleted.Task.Project == project && deleted.Task.Sprint == sprint {
+			delete(trash, key)
+		}
+	}
+
+	if err := s.saveTasks(tasks); err != nil {
+		return err
+	}
+	return s.saveTrash(trash)
+}
+
+// moveToTrash keeps copies of deleted tasks in the trash
+func (s *JSONStorage) moveToTrash(tasks ...*domain.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	trash, err := s.loadTrash()
+	if err != nil {
+		return fmt.Errorf("failed to load trash: %w", err)
+	}
+	deletedAt := s.now().UTC()
+	for _, task := range tasks {
+		trash[task.Key] = &domain.DeletedTask{Task: task, DeletedAt: deletedAt}
+	}
+	return s.saveTrash(trash)
+}
+
+// loadTrash loads the deleted tasks, leaving out the expired ones
+func (s *JSONStorage) loadTrash() (map[string]*domain.DeletedTask, error) {
+	trash := make(map[string]*domain.DeletedTask)
+	data, err := os.ReadFile(s.trashPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return trash, nil
+		}
+		return nil, fmt.Errorf("failed to read trash file: %w", err)
+	}
+	if err := json.Unmarshal(data, &trash); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal trash: %w", err)
+	}
+
+	now := s.now()
+	for key, deleted := range trash {
+		if deleted.Task == nil {
+			delete(trash, key)
+			continue
+		}
+		deleted.ExpiresAt = deleted.DeletedAt.Add(s.retention)
+		if !now.Before(deleted.ExpiresAt) {
+			delete(trash, key)
+		}
+	}
+	return trash, nil
+}
+
+// saveTrash writes the deleted tasks to the trash file
+func (s *JSONStorage) saveTrash(trash map[string]*domain.DeletedTask) error {
+	data, err := json.MarshalIndent(trash, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal trash: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.trashPath()), 0755); err != nil {
+		return fmt.Errorf("failed to create trash directory: %w", err)
+	}
+	if err := os.WriteFile(s.trashPath(), data, 0644); err != nil {
+		return fmt.Errorf("failed to write trash file: %w", err)
+	}
+	return nil
+}
+
+// trashPath returns the path of the file holding the deleted tasks
+func (s *JSONStorage) trashPath() string {
+	return filepath.Join(s.dir, trashDir, s.file)
+}
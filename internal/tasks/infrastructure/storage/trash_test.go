@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+func newTrashStorage(t *testing.T, retention time.Duration) (*JSONStorage, *time.Time) {
+	t.Helper()
+	storage := NewJSONStorageWithTrashRetention(t.TempDir(), "tasks.json", retention)
+	now := time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)
+	storage.now = func() time.Time { return now }
+	return storage, &now
+}
+
+func TestJSONStorage_Trash(t *testing.T) {
+	ctx := context.Background()
+	storage, now := newTrashStorage(t, 0)
+	require.NoError(t, storage.Save(ctx, &domain.Task{Key: "FN-1", Project: "FN", Sprint: "Sprint 1"}))
+	require.NoError(t, storage.Save(ctx, &domain.Task{Key: "FN-2", Project: "FN", Sprint: "Sprint 1"}))
+	require.NoError(t, storage.Save(ctx, &domain.Task{Key: "FN-3", Project: "FN", Sprint: "Sprint 2"}))
+
+	require.NoError(t, storage.DeleteByProjectAndSprint(ctx, "FN", "Sprint 1"))
+	*now = now.Add(time.Hour)
+	require.NoError(t, storage.Delete(ctx, "FN-3"))
+
+	t.Run("keeps deleted tasks out of the store", func(t *testing.T) {
+		tasks, err := storage.FindAll(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, tasks)
+	})
+
+	t.Run("lists deleted tasks most recent first", func(t *testing.T) {
+		deleted, err := storage.FindDeleted(ctx)
+		require.NoError(t, err)
+		require.Len(t, deleted, 3)
+		assert.Equal(t, "FN-3", deleted[0].Task.Key)
+		assert.Equal(t, "FN-1", deleted[1].Task.Key)
+		assert.Equal(t, "FN-2", deleted[2].Task.Key)
+		assert.Equal(t, time.Date(2024, 2, 9, 9, 0, 0, 0, time.UTC), deleted[1].ExpiresAt)
+	})
+
+	t.Run("restores a deleted task", func(t *testing.T) {
+		task, err := storage.Restore(ctx, "FN-1")
+		require.NoError(t, err)
+		assert.Equal(t, "Sprint 1", task.Sprint)
+
+		_, err = storage.FindByKey(ctx, "FN-1")
+		require.NoError(t, err)
+
+		_, err = storage.Restore(ctx, "FN-1")
+		assert.ErrorContains(t, err, "deleted task FN-1 not found")
+	})
+
+	t.Run("purges live and deleted tasks of a sprint", func(t *testing.T) {
+		require.NoError(t, storage.PurgeByProjectAndSprint(ctx, "FN", "Sprint 1"))
+
+		tasks, err := storage.FindAll(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, tasks)
+		deleted, err := storage.FindDeleted(ctx)
+		require.NoError(t, err)
+		require.Len(t, deleted, 1)
+		assert.Equal(t, "FN-3", deleted[0].Task.Key)
+	})
+}
+
+func TestJSONStorage_TrashRetention(t *testing.T) {
+	ctx := context.Background()
+	storage, now := newTrashStorage(t, 24*time.Hour)
+	require.NoError(t, storage.Save(ctx, &domain.Task{Key: "FN-1"}))
+	require.NoError(t, storage.Delete(ctx, "FN-1"))
+
+	*now = now.Add(24 * time.Hour)
+	deleted, err := storage.FindDeleted(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, deleted)
+}
@@ -0,0 +1,317 @@
+// Package telemetry records opt-in, anonymous usage statistics of assetcap commands: the command
+// name, how long it ran, whether it succeeded and the size of the datasets it handled, never
+// their content. It stays disabled until enabled with `assetcap telemetry enable`. Events are
+// buffered locally and, when an endpoint is configured, posted to it after each command.
+package telemetry
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	stateFile  = "telemetry.json"
+	bufferFile = "telemetry-buffer.jsonl"
+	// MaxBuffered is how many events the local buffer keeps; the oldest are dropped first
+	MaxBuffered = 1000
+	// sendTimeout bounds the post of the buffer so telemetry never holds up a command
+	sendTimeout = 2 * time.Second
+)
+
+// Status of a recorded command
+const (
+	StatusOK    = "ok"
+	StatusError = "error"
+)
+
+// Event is the record of one command run
+type Event struct {
+	// InstallID is a random identifier of the installation, generated when telemetry is enabled
+	InstallID string `json:"installId"`
+	Command   string `json:"command"`
+	// Hour is the hour the command started, in UTC
+	Hour       time.Time `json:"hour"`
+	DurationMS int64     `json:"durationMs"`
+	Status     string    `json:"status"`
+	// Sizes counts the records of each dataset the command handled, e.g. tasks or assets
+	Sizes map[string]int `json:"sizes,omitempty"`
+}
+
+// State is whether telemetry is enabled and the identifier its events are sent under
+type State struct {
+	Enabled   bool   `json:"enabled"`
+	InstallID string `json:"installId,omitempty"`
+}
+
+// Status describes the telemetry of this installation
+type Status struct {
+	State
+	Endpoint string
+	Buffered int
+	// OptedOut is set when the DO_NOT_TRACK environment variable disables recording
+	OptedOut bool
+}
+
+// Recorder records command events into a buffer in dir and sends them to the endpoint. A nil
+// Recorder records nothing.
+type Recorder struct {
+	dir      string
+	endpoint string
+	client   *http.Client
+	now      func() time.Time
+
+	mu    sync.Mutex
+	sizes map[string]int
+}
+
+// NewRecorder creates a recorder keeping its state and buffer in dir and posting events to
+// endpoint, or only buffering them when endpoint is empty
+func NewRecorder(dir, endpoint string) *Recorder {
+	return &Recorder{
+		dir:      dir,
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: sendTimeout},
+		now:      time.Now,
+	}
+}
+
+// Enable turns telemetry on, generating the installation identifier the first time
+func (r *Recorder) Enable() (State, error) {
+	state, err := r.state()
+	if err != nil {
+		return State{}, err
+	}
+	if state.InstallID == "" {
+		id := make([]byte, 16)
+		if _, err := rand.Read(id); err != nil {
+			return State{}, fmt.Errorf("failed to generate install id: %w", err)
+		}
+		state.InstallID = hex.EncodeToString(id)
+	}
+	state.Enabled = true
+	return state, r.saveState(state)
+}
+
+// Disable turns telemetry off and discards the events not sent yet
+func (r *Recorder) Disable() error {
+	state, err := r.state()
+	if err != nil {
+		return err
+	}
+	state.Enabled = false
+	if err := r.saveState(state); err != nil {
+		return err
+	}
+	if err := os.Remove(r.path(bufferFile)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove telemetry buffer: %w", err)
+	}
+	return nil
+}
+
+// Status returns whether telemetry is enabled, where it is sent and how many events are buffered
+func (r *Recorder) Status() (Status, error) {
+	state, err := r.state()
+	if err != nil {
+		return Status{}, err
+	}
+	events, err := r.buffered()
+	if err != nil {
+		return Status{}, err
+	}
+	return Status{State: state, Endpoint: r.endpoint, Buffered: len(events), OptedOut: optedOut()}, nil
+}
+
+// Count records the size of a dataset handled by the running command
+func (r *Recorder) Count(dataset string, size int) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.sizes == nil {
+		r.sizes = make(map[string]int)
+	}
+	r.sizes[dataset] = size
+}
+
+// Record buffers the event of a command that started at started and ended with err, then sends
+// the buffer when an endpoint is configured. It does nothing while telemetry is disabled, and
+// failures are returned for logging only: telemetry never fails a command.
+func (r *Recorder) Record(ctx context.Context, command string, started time.Time, err error) error {
+	if r == nil || optedOut() {
+		return nil
+	}
+	r.mu.Lock()
+	sizes := r.sizes
+	r.sizes = nil
+	r.mu.Unlock()
+
+	state, stateErr := r.state()
+	if stateErr != nil || !state.Enabled {
+		return stateErr
+	}
+
+	event := Event{
+		InstallID:  state.InstallID,
+		Command:    command,
+		Hour:       started.UTC().Truncate(time.Hour),
+		DurationMS: r.now().Sub(started).Milliseconds(),
+		Status:     StatusOK,
+		Sizes:      sizes,
+	}
+	if err != nil {
+		event.Status = StatusError
+	}
+	if err := r.append(event); err != nil {
+		return err
+	}
+	if r.endpoint == "" {
+		return nil
+	}
+	_, sendErr := r.Flush(ctx)
+	return sendErr
+}
+
+// Flush posts the buffered events to the endpoint as a JSON array and empties the buffer once
+// accepted, returning how many events were sent
+func (r *Recorder) Flush(ctx context.Context) (int, error) {
+	if r.endpoint == "" {
+		return 0, nil
+	}
+	events, err := r.buffered()
+	if err != nil || len(events) == 0 {
+		return 0, err
+	}
+
+	body, err := json.Marshal(events)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal telemetry events: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create telemetry request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send telemetry: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return 0, fmt.Errorf("telemetry endpoint returned %s", resp.Status)
+	}
+
+	if err := os.Remove(r.path(bufferFile)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return 0, fmt.Errorf("failed to clear telemetry buffer: %w", err)
+	}
+	return len(events), nil
+}
+
+// optedOut reports whether the DO_NOT_TRACK convention disables recording
+func optedOut() bool {
+	value := os.Getenv("DO_NOT_TRACK")
+	return value != "" && value != "0"
+}
+
+// state reads the telemetry state; telemetry is disabled when it was never enabled
+func (r *Recorder) state() (State, error) {
+	var state State
+	data, err := os.ReadFile(r.path(stateFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, fmt.Errorf("failed to read telemetry state: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("failed to unmarshal telemetry state: %w", err)
+	}
+	return state, nil
+}
+
+// saveState writes the telemetry state
+func (r *Recorder) saveState(state State) error {
+	if err := os.MkdirAll(r.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal telemetry state: %w", err)
+	}
+	if err := os.WriteFile(r.path(stateFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write telemetry state: %w", err)
+	}
+	return nil
+}
+
+// buffered reads the events not sent yet, oldest first
+func (r *Recorder) buffered() ([]Event, error) {
+	file, err := os.Open(r.path(bufferFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read telemetry buffer: %w", err)
+	}
+	defer file.Close()
+
+	var events []Event
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			// A line cut short by an interrupted write is skipped
+			continue
+		}
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read telemetry buffer: %w", err)
+	}
+	return events, nil
+}
+
+// append adds the event to the buffer, dropping the oldest events beyond MaxBuffered
+func (r *Recorder) append(event Event) error {
+	events, err := r.buffered()
+	if err != nil {
+		return err
+	}
+	events = append(events, event)
+	if len(events) > MaxBuffered {
+		events = events[len(events)-MaxBuffered:]
+	}
+
+	var buf bytes.Buffer
+	for _, e := range events {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("failed to marshal telemetry event: %w", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	if err := os.MkdirAll(r.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(r.path(bufferFile), buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write telemetry buffer: %w", err)
+	}
+	return nil
+}
+
+// path returns the path of a telemetry file
+func (r *Recorder) path(name string) string {
+	return filepath.Join(r.dir, name)
+}
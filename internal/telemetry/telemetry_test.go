@@ -0,0 +1,145 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRecorder(t *testing.T, endpoint string) *Recorder {
+	t.Helper()
+	t.Setenv("DO_NOT_TRACK", "")
+	recorder := NewRecorder(t.TempDir(), endpoint)
+	recorder.now = func() time.Time { return time.Date(2024, time.May, 2, 10, 30, 1, 500e6, time.UTC) }
+	return recorder
+}
+
+var started = time.Date(2024, time.May, 2, 10, 30, 0, 0, time.UTC)
+
+func TestRecorder_DisabledByDefault(t *testing.T) {
+	recorder := newTestRecorder(t, "")
+
+	require.NoError(t, recorder.Record(context.Background(), "tasks show", started, nil))
+
+	status, err := recorder.Status()
+	require.NoError(t, err)
+	assert.False(t, status.Enabled)
+	assert.Zero(t, status.Buffered)
+	_, err = os.Stat(filepath.Join(recorder.dir, bufferFile))
+	assert.True(t, os.IsNotExist(err), "nothing is written while disabled")
+}
+
+func TestRecorder_RecordBuffersEvents(t *testing.T) {
+	recorder := newTestRecorder(t, "")
+	state, err := recorder.Enable()
+	require.NoError(t, err)
+	assert.Len(t, state.InstallID, 32)
+
+	recorder.Count("tasks", 120)
+	require.NoError(t, recorder.Record(context.Background(), "tasks show", started, nil))
+	require.NoError(t, recorder.Record(context.Background(), "sprint allocate", started, errors.New("boom")))
+
+	events, err := recorder.buffered()
+	require.NoError(t, err)
+	assert.Equal(t, []Event{
+		{InstallID: state.InstallID, Command: "tasks show", Hour: started.Truncate(time.Hour), DurationMS: 1500, Status: StatusOK, Sizes: map[string]int{"tasks": 120}},
+		{InstallID: state.InstallID, Command: "sprint allocate", Hour: started.Truncate(time.Hour), DurationMS: 1500, Status: StatusError},
+	}, events)
+
+	again, err := recorder.Enable()
+	require.NoError(t, err)
+	assert.Equal(t, state.InstallID, again.InstallID, "re-enabling keeps the install id")
+}
+
+func TestRecorder_BufferIsCapped(t *testing.T) {
+	recorder := newTestRecorder(t, "")
+	_, err := recorder.Enable()
+	require.NoError(t, err)
+
+	for i := 0; i < MaxBuffered+5; i++ {
+		require.NoError(t, recorder.Record(context.Background(), "assets list", started, nil))
+	}
+
+	status, err := recorder.Status()
+	require.NoError(t, err)
+	assert.Equal(t, MaxBuffered, status.Buffered)
+}
+
+func TestRecorder_DoNotTrack(t *testing.T) {
+	recorder := newTestRecorder(t, "")
+	_, err := recorder.Enable()
+	require.NoError(t, err)
+	t.Setenv("DO_NOT_TRACK", "1")
+
+	require.NoError(t, recorder.Record(context.Background(), "tasks show", started, nil))
+
+	status, err := recorder.Status()
+	require.NoError(t, err)
+	assert.True(t, status.OptedOut)
+	assert.Zero(t, status.Buffered)
+}
+
+func TestRecorder_SendsToEndpoint(t *testing.T) {
+	var received []Event
+	fail := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	recorder := newTestRecorder(t, server.URL)
+	_, err := recorder.Enable()
+	require.NoError(t, err)
+
+	err = recorder.Record(context.Background(), "tasks show", started, nil)
+	assert.EqualError(t, err, "telemetry endpoint returned 503 Service Unavailable")
+	status, err := recorder.Status()
+	require.NoError(t, err)
+	assert.Equal(t, 1, status.Buffered, "unsent events stay buffered")
+
+	fail = false
+	require.NoError(t, recorder.Record(context.Background(), "assets list", started, nil))
+	require.Len(t, received, 2)
+	assert.Equal(t, "tasks show", received[0].Command)
+	assert.Equal(t, "assets list", received[1].Command)
+	status, err = recorder.Status()
+	require.NoError(t, err)
+	assert.Zero(t, status.Buffered)
+}
+
+func TestRecorder_DisableDiscardsBuffer(t *testing.T) {
+	recorder := newTestRecorder(t, "")
+	_, err := recorder.Enable()
+	require.NoError(t, err)
+	require.NoError(t, recorder.Record(context.Background(), "tasks show", started, nil))
+
+	require.NoError(t, recorder.Disable())
+
+	status, err := recorder.Status()
+	require.NoError(t, err)
+	assert.False(t, status.Enabled)
+	assert.Zero(t, status.Buffered)
+	assert.NotEmpty(t, status.InstallID)
+}
+
+func TestRecorder_NilRecordsNothing(t *testing.T) {
+	var recorder *Recorder
+
+	recorder.Count("tasks", 1)
+	assert.NoError(t, recorder.Record(context.Background(), "tasks show", started, nil))
+}
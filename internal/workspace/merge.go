@@ -0,0 +1,211 @@
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	tasksdomain "github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+// mergers combine a local data file with the archived one, by file name; the other files
+// are kept as they are locally
+var mergers = map[string]func(local, imported []byte) ([]byte, error){
+	"assets.json":   mergeAssets,
+	"tasks.json":    mergeTasks,
+	"teams.json":    mergeTeams,
+	"absences.json": mergeAbsences,
+	"notes.json":    mergeNotes,
+	"epics.json":    mergeEpicMappings,
+}
+
+// newer reports whether the imported record replaces the local one: the later update wins,
+// then the higher version
+func newer(local, imported time.Time, localVersion, importedVersion int) bool {
+	if !imported.Equal(local) {
+		return imported.After(local)
+	}
+	return importedVersion > localVersion
+}
+
+// mergeAssets combines the asset catalogues by asset name, keeping the newer of each asset
+func mergeAssets(local, imported []byte) ([]byte, error) {
+	var assets, incoming map[string]*assetsdomain.Asset
+	if err := unmarshalBoth(local, imported, &assets, &incoming); err != nil {
+		return nil, err
+	}
+	if assets == nil {
+		assets = make(map[string]*assetsdomain.Asset)
+	}
+	for name, asset := range incoming {
+		current, ok := assets[name]
+		if !ok || newer(current.UpdatedAt, asset.UpdatedAt, current.Version, asset.Version) {
+			assets[name] = asset
+		}
+	}
+	return marshal(assets)
+}
+
+// mergeTasks combines the tasks by key, keeping the newer of each task
+func mergeTasks(local, imported []byte) ([]byte, error) {
+	var tasks, incoming map[string]*tasksdomain.Task
+	if err := unmarshalBoth(local, imported, &tasks, &incoming); err != nil {
+		return nil, err
+	}
+	if tasks == nil {
+		tasks = make(map[string]*tasksdomain.Task)
+	}
+	for key, task := range incoming {
+		current, ok := tasks[key]
+		if !ok || newer(current.UpdatedAt, task.UpdatedAt, current.Version, task.Version) {
+			tasks[key] = task
+		}
+	}
+	return marshal(tasks)
+}
+
+// mergeTeams combines the teams by project, adding the members, aliases and holidays missing locally;
+// the local account IDs and capacities win over the archived ones
+func mergeTeams(local, imported []byte) ([]byte, error) {
+	var teams, incoming sprintdomain.TeamMap
+	if err := unmarshalBoth(local, imported, &teams, &incoming); err != nil {
+		return nil, err
+	}
+	if teams == nil {
+		teams = make(sprintdomain.TeamMap)
+	}
+	for project, team := range incoming {
+		current, ok := teams[project]
+		if !ok {
+			teams[project] = team
+			continue
+		}
+		current.Team = appendMissing(current.Team, team.Team...)
+		for member, aliases := range team.Aliases {
+			if current.Aliases == nil {
+				current.Aliases = make(map[string][]string)
+			}
+			current.Aliases[member] = appendMissing(current.Aliases[member], aliases...)
+		}
+		for member, id := range team.AccountIDs {
+			if _, ok := current.AccountIDs[member]; !ok {
+				if current.AccountIDs == nil {
+					current.AccountIDs = make(map[string]string)
+				}
+				current.AccountIDs[member] = id
+			}
+		}
+		for member, capacity := range team.Capacity {
+			if _, ok := current.Capacity[member]; !ok {
+				if current.Capacity == nil {
+					current.Capacity = make(map[string]float64)
+				}
+				current.Capacity[member] = capacity
+			}
+		}
+		current.Holidays = appendMissing(current.Holidays, team.Holidays...)
+		teams[project] = current
+	}
+	return marshal(teams)
+}
+
+// mergeAbsences adds the archived absences not recorded locally
+func mergeAbsences(local, imported []byte) ([]byte, error) {
+	var calendar, incoming sprintdomain.AbsenceCalendar
+	if err := unmarshalBoth(local, imported, &calendar, &incoming); err != nil {
+		return nil, err
+	}
+	if calendar == nil {
+		calendar = make(sprintdomain.AbsenceCalendar)
+	}
+	for member, absences := range incoming {
+		calendar.Add(member, absences...)
+	}
+	return marshal(calendar)
+}
+
+// mergeEpicMappings combines the epic mappings by epic, keeping the most recently updated
+func mergeEpicMappings(local, imported []byte) ([]byte, error) {
+	var mappings, incoming map[string]tasksdomain.EpicMapping
+	if err := unmarshalBoth(local, imported, &mappings, &incoming); err != nil {
+		return nil, err
+	}
+	if mappings == nil {
+		mappings = make(map[string]tasksdomain.EpicMapping)
+	}
+	for key, mapping := range incoming {
+		current, ok := mappings[key]
+		if !ok || mapping.UpdatedAt.After(current.UpdatedAt) {
+			mappings[key] = mapping
+		}
+	}
+	return marshal(mappings)
+}
+
+// mergeNotes combines the task notes by issue, adding the notes missing locally in the
+// order they were written
+func mergeNotes(local, imported []byte) ([]byte, error) {
+	var notes, incoming map[string][]tasksdomain.TaskNote
+	if err := unmarshalBoth(local, imported, &notes, &incoming); err != nil {
+		return nil, err
+	}
+	if notes == nil {
+		notes = make(map[string][]tasksdomain.TaskNote)
+	}
+	for key, more := range incoming {
+		for _, note := range more {
+			known := false
+			for _, current := range notes[key] {
+				if current.Text == note.Text && current.CreatedAt.Equal(note.CreatedAt) {
+					known = true
+					break
+				}
+			}
+			if !known {
+				notes[key] = append(notes[key], note)
+			}
+		}
+		sort.SliceStable(notes[key], func(i, j int) bool { return notes[key][i].CreatedAt.Before(notes[key][j].CreatedAt) })
+	}
+	return marshal(notes)
+}
+
+// appendMissing appends the values not already in values
+func appendMissing(values []string, more ...string) []string {
+	for _, value := range more {
+		known := false
+		for _, v := range values {
+			if v == value {
+				known = true
+				break
+			}
+		}
+		if !known {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// unmarshalBoth decodes the local and the archived version of a data file
+func unmarshalBoth(local, imported []byte, localValue, importedValue interface{}) error {
+	if err := json.Unmarshal(local, localValue); err != nil {
+		return fmt.Errorf("failed to unmarshal local file: %w", err)
+	}
+	if err := json.Unmarshal(imported, importedValue); err != nil {
+		return fmt.Errorf("failed to unmarshal archived file: %w", err)
+	}
+	return nil
+}
+
+// marshal encodes a merged data file as the stores write it
+func marshal(v interface{}) ([]byte, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal merged file: %w", err)
+	}
+	return data, nil
+}
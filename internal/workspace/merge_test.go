@@ -0,0 +1,155 @@
+package workspace
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	assetsdomain "github.com/helmedeiros/digital-asset-capitalization/internal/assets/domain"
+	sprintdomain "github.com/helmedeiros/digital-asset-capitalization/internal/sprint/domain"
+	tasksdomain "github.com/helmedeiros/digital-asset-capitalization/internal/tasks/domain"
+)
+
+func TestNewer(t *testing.T) {
+	earlier := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Hour)
+
+	assert.True(t, newer(earlier, later, 5, 1))
+	assert.False(t, newer(later, earlier, 1, 5))
+	assert.True(t, newer(earlier, earlier, 1, 2))
+	assert.False(t, newer(earlier, earlier, 2, 2))
+}
+
+func TestMergeAssets(t *testing.T) {
+	earlier := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Hour)
+	local, err := json.Marshal(map[string]*assetsdomain.Asset{
+		"api": {Name: "api", Description: "local", UpdatedAt: later},
+		"web": {Name: "web", Description: "local", UpdatedAt: earlier},
+	})
+	require.NoError(t, err)
+	imported, err := json.Marshal(map[string]*assetsdomain.Asset{
+		"api":    {Name: "api", Description: "imported", UpdatedAt: earlier},
+		"web":    {Name: "web", Description: "imported", UpdatedAt: later},
+		"mobile": {Name: "mobile", Description: "imported", UpdatedAt: earlier},
+	})
+	require.NoError(t, err)
+
+	data, err := mergeAssets(local, imported)
+	require.NoError(t, err)
+	var merged map[string]*assetsdomain.Asset
+	require.NoError(t, json.Unmarshal(data, &merged))
+	assert.Len(t, merged, 3)
+	assert.Equal(t, "local", merged["api"].Description)
+	assert.Equal(t, "imported", merged["web"].Description)
+	assert.Equal(t, "imported", merged["mobile"].Description)
+
+	_, err = mergeAssets([]byte("not json"), imported)
+	assert.ErrorContains(t, err, "failed to unmarshal local file")
+}
+
+func TestMergeTasks(t *testing.T) {
+	updated := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	local, err := json.Marshal(map[string]*tasksdomain.Task{
+		"PROJ-1": {Key: "PROJ-1", Summary: "local", UpdatedAt: updated, Version: 3},
+	})
+	require.NoError(t, err)
+	imported, err := json.Marshal(map[string]*tasksdomain.Task{
+		"PROJ-1": {Key: "PROJ-1", Summary: "imported", UpdatedAt: updated, Version: 2},
+		"PROJ-2": {Key: "PROJ-2", Summary: "imported", UpdatedAt: updated, Version: 1},
+	})
+	require.NoError(t, err)
+
+	data, err := mergeTasks(local, imported)
+	require.NoError(t, err)
+	var merged map[string]*tasksdomain.Task
+	require.NoError(t, json.Unmarshal(data, &merged))
+	assert.Equal(t, "local", merged["PROJ-1"].Summary)
+	assert.Equal(t, "imported", merged["PROJ-2"].Summary)
+}
+
+func TestMergeTeams(t *testing.T) {
+	local := []byte(`{
+		"PROJ": {"team": ["Ana"], "aliases": {"Ana": ["ana.s"]}, "account_ids": {"Ana": "local-id"}}
+	}`)
+	imported := []byte(`{
+		"PROJ": {
+			"team": ["Ana", "Bruno"],
+			"aliases": {"Ana": ["ana.s", "asilva"], "Bruno": ["bruno"]},
+			"account_ids": {"Ana": "imported-id", "Bruno": "bruno-id"},
+			"capacity": {"Bruno": 0.5}
+		},
+		"WEB": {"team": ["Carla"]}
+	}`)
+
+	data, err := mergeTeams(local, imported)
+	require.NoError(t, err)
+	var merged sprintdomain.TeamMap
+	require.NoError(t, json.Unmarshal(data, &merged))
+	assert.Equal(t, sprintdomain.Team{
+		Team:       []string{"Ana", "Bruno"},
+		Aliases:    map[string][]string{"Ana": {"ana.s", "asilva"}, "Bruno": {"bruno"}},
+		AccountIDs: map[string]string{"Ana": "local-id", "Bruno": "bruno-id"},
+		Capacity:   map[string]float64{"Bruno": 0.5},
+	}, merged["PROJ"])
+	assert.Equal(t, sprintdomain.Team{Team: []string{"Carla"}}, merged["WEB"])
+}
+
+func TestMergeAbsences(t *testing.T) {
+	local := []byte(`{"Ana": [{"from": "2024-05-06", "to": "2024-05-07"}]}`)
+	imported := []byte(`{
+		"Ana": [{"from": "2024-05-06", "to": "2024-05-07"}, {"from": "2024-05-20", "to": "2024-05-20"}],
+		"Bruno": [{"from": "2024-05-08", "to": "2024-05-08", "reason": "holiday"}]
+	}`)
+
+	data, err := mergeAbsences(local, imported)
+	require.NoError(t, err)
+	var merged sprintdomain.AbsenceCalendar
+	require.NoError(t, json.Unmarshal(data, &merged))
+	assert.Len(t, merged["Ana"], 2)
+	assert.Len(t, merged["Bruno"], 1)
+	assert.Equal(t, "holiday", merged["Bruno"][0].Reason)
+}
+
+func TestMergeNotes(t *testing.T) {
+	local := []byte(`{"FN-1": [{"issue_key": "FN-1", "text": "checked", "created_at": "2024-05-03T10:00:00Z"}]}`)
+	imported := []byte(`{
+		"FN-1": [
+			{"issue_key": "FN-1", "text": "confirmed with tech lead", "created_at": "2024-05-02T09:00:00Z"},
+			{"issue_key": "FN-1", "text": "checked", "created_at": "2024-05-03T10:00:00Z"}
+		],
+		"FN-2": [{"issue_key": "FN-2", "text": "split agreed", "created_at": "2024-05-04T09:00:00Z"}]
+	}`)
+
+	data, err := mergeNotes(local, imported)
+	require.NoError(t, err)
+	var merged map[string][]tasksdomain.TaskNote
+	require.NoError(t, json.Unmarshal(data, &merged))
+	require.Len(t, merged["FN-1"], 2)
+	assert.Equal(t, "confirmed with tech lead", merged["FN-1"][0].Text)
+	assert.Equal(t, "checked", merged["FN-1"][1].Text)
+	assert.Len(t, merged["FN-2"], 1)
+}
+
+func TestMergeEpicMappings(t *testing.T) {
+	local := []byte(`{
+		"FN-100": {"epic_key": "FN-100", "asset": "Booking", "label": "cap-asset-booking", "updated_at": "2024-05-03T10:00:00Z"},
+		"FN-200": {"epic_key": "FN-200", "asset": "Search", "label": "cap-asset-search", "updated_at": "2024-05-03T10:00:00Z"}
+	}`)
+	imported := []byte(`{
+		"FN-100": {"epic_key": "FN-100", "asset": "Checkout", "label": "cap-asset-checkout", "updated_at": "2024-05-01T10:00:00Z"},
+		"FN-200": {"epic_key": "FN-200", "asset": "Catalog", "label": "cap-asset-catalog", "updated_at": "2024-05-04T10:00:00Z"},
+		"FN-300": {"epic_key": "FN-300", "asset": "Payments", "label": "cap-asset-payments", "updated_at": "2024-05-04T10:00:00Z"}
+	}`)
+
+	data, err := mergeEpicMappings(local, imported)
+	require.NoError(t, err)
+	var merged map[string]tasksdomain.EpicMapping
+	require.NoError(t, json.Unmarshal(data, &merged))
+	assert.Equal(t, "Booking", merged["FN-100"].Asset)
+	assert.Equal(t, "Catalog", merged["FN-200"].Asset)
+	assert.Equal(t, "Payments", merged["FN-300"].Asset)
+}
@@ -0,0 +1,400 @@
+// Package workspace exports the assetcap workspace, the storage directory with its assets,
+// tasks, teams, allocations and configuration, to a gzipped tar archive, and imports such an
+// archive back, either replacing the local files or merging both workspaces.
+package workspace
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/helmedeiros/digital-asset-capitalization/internal/config"
+	"github.com/helmedeiros/digital-asset-capitalization/internal/schema"
+)
+
+// FormatVersion is the version of the archive layout; archives of a newer one are rejected
+const FormatVersion = 1
+
+const (
+	manifestName = "manifest.json"
+	configName   = "config.json"
+)
+
+// excluded are the files never exported: the credentials and the telemetry of the installation,
+// and the task index, which is rebuilt from the tasks
+var excluded = map[string]bool{
+	filepath.Base(config.DefaultCredentialsPath): true,
+	"telemetry.json":         true,
+	"telemetry-buffer.jsonl": true,
+	"tasks.index.json":       true,
+}
+
+// documents are the data files validated against their schema before an import writes anything
+var documents = map[string]schema.Document{
+	"assets.json": schema.Assets,
+	"tasks.json":  schema.Tasks,
+	"teams.json":  schema.Teams,
+}
+
+var (
+	// ErrNewerFormat is returned for archives written by a newer assetcap
+	ErrNewerFormat = errors.New("workspace archive format is newer than supported")
+	// ErrFilesExist is returned when an import would replace local files
+	ErrFilesExist = errors.New("workspace files already exist")
+)
+
+// Manifest describes an exported workspace
+type Manifest struct {
+	FormatVersion int       `json:"formatVersion"`
+	ExportedAt    time.Time `json:"exportedAt"`
+	// Files are the archived files, relative to the storage directory
+	Files []string `json:"files"`
+}
+
+// Workspace is the storage directory and the configuration of an assetcap installation
+type Workspace struct {
+	Dir string
+	// ConfigPath is the configuration file, archived as config.json without its secrets
+	ConfigPath string
+}
+
+// New creates a workspace of the storage directory and the configuration file
+func New(dir, configPath string) *Workspace {
+	return &Workspace{Dir: dir, ConfigPath: configPath}
+}
+
+// Export writes the workspace to w as a gzipped tar archive, its manifest first
+func (ws *Workspace) Export(w io.Writer, now time.Time) (Manifest, error) {
+	files, err := ws.collect()
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	manifest := Manifest{FormatVersion: FormatVersion, ExportedAt: now.UTC(), Files: make([]string, 0, len(files))}
+	for name := range files {
+		manifest.Files = append(manifest.Files, name)
+	}
+	sort.Strings(manifest.Files)
+	if len(manifest.Files) == 0 {
+		return Manifest{}, fmt.Errorf("workspace %s has no files to export", ws.Dir)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return Manifest{}, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	if err := writeEntry(tw, manifestName, data, manifest.ExportedAt); err != nil {
+		return Manifest{}, err
+	}
+	for _, name := range manifest.Files {
+		if err := writeEntry(tw, name, files[name], manifest.ExportedAt); err != nil {
+			return Manifest{}, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return Manifest{}, fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return Manifest{}, fmt.Errorf("failed to write archive: %w", err)
+	}
+	return manifest, nil
+}
+
+// collect reads the files to export, keyed by their slash separated path in the archive
+func (ws *Workspace) collect() (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	err := filepath.WalkDir(ws.Dir, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && p == ws.Dir {
+				return fs.SkipDir
+			}
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(ws.Dir, p)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if excluded[name] || name == configName {
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		files[name] = data
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workspace %s: %w", ws.Dir, err)
+	}
+
+	if _, err := os.Stat(ws.ConfigPath); err == nil {
+		cfg, err := config.Load(ws.ConfigPath)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.MarshalIndent(cfg.WithoutSecrets(), "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal config: %w", err)
+		}
+		files[configName] = append(data, '\n')
+	}
+	return files, nil
+}
+
+// writeEntry adds a file to the archive
+func writeEntry(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	header := &tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), ModTime: modTime, Typeflag: tar.TypeReg}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write %s to archive: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s to archive: %w", name, err)
+	}
+	return nil
+}
+
+// ImportOptions selects how an archive is combined with the local workspace
+type ImportOptions struct {
+	// Merge combines the archive with the local files instead of replacing them
+	Merge bool
+	// Force replaces the local files with the archived ones
+	Force bool
+	// Hooks installs the hooks of the archived configuration; without it the local hooks are
+	// kept, so an import never runs commands from another machine
+	Hooks bool
+}
+
+// ImportResult lists what an import did with each archived file
+type ImportResult struct {
+	Manifest Manifest
+	// Written are the files created or replaced
+	Written []string
+	// Merged are the local files combined with the archived ones
+	Merged []string
+	// Kept are the local files left as they were when merging
+	Kept []string
+	// Hooks are the hooks of the archived configuration installed with ImportOptions.Hooks
+	Hooks []config.HookConfig
+	// SkippedHooks are the hooks of the archived configuration left out without it
+	SkippedHooks []config.HookConfig
+}
+
+// Import reads an archive written by Export into the workspace. Every file is checked, the
+// archive format against FormatVersion and the data files against their schemas, before
+// anything is written. Without Merge or Force, an import never replaces local files. A
+// replaced configuration keeps the local hooks unless opts.Hooks is set.
+func (ws *Workspace) Import(r io.Reader, opts ImportOptions) (ImportResult, error) {
+	manifest, files, err := readArchive(r)
+	if err != nil {
+		return ImportResult{}, err
+	}
+
+	result := ImportResult{Manifest: manifest}
+	local := make(map[string][]byte)
+	var existing []string
+	for _, name := range manifest.Files {
+		data, err := os.ReadFile(ws.target(name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return ImportResult{}, fmt.Errorf("failed to read %s: %w", ws.target(name), err)
+		}
+		local[name] = data
+		existing = append(existing, name)
+	}
+	if len(existing) > 0 && !opts.Merge && !opts.Force {
+		return ImportResult{}, fmt.Errorf("%w: %s", ErrFilesExist, strings.Join(existing, ", "))
+	}
+
+	for _, name := range manifest.Files {
+		data := files[name]
+		current, exists := local[name]
+		switch {
+		case !exists || !opts.Merge:
+			result.Written = append(result.Written, name)
+		case mergers[name] != nil:
+			merged, err := mergers[name](current, data)
+			if err != nil {
+				return ImportResult{}, fmt.Errorf("failed to merge %s: %w", name, err)
+			}
+			data = merged
+			result.Merged = append(result.Merged, name)
+		default:
+			result.Kept = append(result.Kept, name)
+			continue
+		}
+		if name == configName {
+			hooks, err := archivedHooks(data)
+			if err != nil {
+				return ImportResult{}, err
+			}
+			if opts.Hooks {
+				result.Hooks = hooks
+			} else {
+				if data, err = withLocalHooks(data, current); err != nil {
+					return ImportResult{}, err
+				}
+				result.SkippedHooks = hooks
+			}
+		}
+		if err := writeFile(ws.target(name), data); err != nil {
+			return ImportResult{}, err
+		}
+	}
+	return result, nil
+}
+
+// archivedHooks returns the hooks of an archived configuration
+func archivedHooks(data []byte) ([]config.HookConfig, error) {
+	var cfg struct {
+		Hooks []config.HookConfig `json:"hooks"`
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to read %s from archive: %w", configName, err)
+	}
+	return cfg.Hooks, nil
+}
+
+// withLocalHooks returns the archived configuration with the hooks of the local one, none
+// when there is no local configuration
+func withLocalHooks(archived, local []byte) ([]byte, error) {
+	var fields, localFields map[string]json.RawMessage
+	if err := json.Unmarshal(archived, &fields); err != nil {
+		return nil, fmt.Errorf("failed to read %s from archive: %w", configName, err)
+	}
+	if local != nil {
+		if err := json.Unmarshal(local, &localFields); err != nil {
+			return nil, fmt.Errorf("failed to read the local %s: %w", configName, err)
+		}
+	}
+	if fields == nil {
+		fields = make(map[string]json.RawMessage)
+	}
+	delete(fields, "hooks")
+	if hooks, ok := localFields["hooks"]; ok {
+		fields["hooks"] = hooks
+	}
+	data, err := json.MarshalIndent(fields, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// target returns where an archived file is written
+func (ws *Workspace) target(name string) string {
+	if name == configName {
+		return ws.ConfigPath
+	}
+	return filepath.Join(ws.Dir, filepath.FromSlash(name))
+}
+
+// readArchive reads and checks the manifest and files of an archive
+func readArchive(r io.Reader) (Manifest, map[string][]byte, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return Manifest{}, nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+	defer gz.Close()
+
+	files := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Manifest{}, nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if header.Typeflag == tar.TypeDir {
+			continue
+		}
+		name := path.Clean(header.Name)
+		if header.Typeflag != tar.TypeReg || path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return Manifest{}, nil, fmt.Errorf("archive entry %s is not a workspace file", header.Name)
+		}
+		var buf bytes.Buffer
+		if _, err := io.Copy(&buf, tr); err != nil {
+			return Manifest{}, nil, fmt.Errorf("failed to read %s from archive: %w", name, err)
+		}
+		files[name] = buf.Bytes()
+	}
+
+	data, ok := files[manifestName]
+	if !ok {
+		return Manifest{}, nil, fmt.Errorf("archive has no %s, it is not an assetcap workspace export", manifestName)
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return Manifest{}, nil, fmt.Errorf("failed to unmarshal manifest: %w", err)
+	}
+	if manifest.FormatVersion > FormatVersion {
+		return Manifest{}, nil, fmt.Errorf("%w: archive format %d, supported up to %d", ErrNewerFormat, manifest.FormatVersion, FormatVersion)
+	}
+	if manifest.FormatVersion < 1 {
+		return Manifest{}, nil, fmt.Errorf("invalid archive format version %d", manifest.FormatVersion)
+	}
+
+	for _, name := range manifest.Files {
+		data, ok := files[name]
+		if !ok {
+			return Manifest{}, nil, fmt.Errorf("archive is missing %s listed in its manifest", name)
+		}
+		if err := validateFile(name, data); err != nil {
+			return Manifest{}, nil, err
+		}
+	}
+	return manifest, files, nil
+}
+
+// validateFile checks an archived data file against its schema and the configuration
+// against the supported settings
+func validateFile(name string, data []byte) error {
+	if doc, ok := documents[name]; ok {
+		if err := schema.Validate(doc, data); err != nil {
+			return fmt.Errorf("invalid %s in archive: %w", name, err)
+		}
+	}
+	if name == configName {
+		cfg := config.Default()
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return fmt.Errorf("failed to unmarshal %s in archive: %w", name, err)
+		}
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid %s in archive: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// writeFile writes an imported file, creating its directory
+func writeFile(p string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return fmt.Errorf("failed to create directory of %s: %w", p, err)
+	}
+	if err := os.WriteFile(p, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", p, err)
+	}
+	return nil
+}
